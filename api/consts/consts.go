@@ -2,4 +2,9 @@ package consts
 
 const (
 	WebsocketProtocolTcpProxyV1 = "boundary-tcp-proxy-v1"
+
+	// WebsocketStatusSessionCanceled is the websocket close status workers
+	// use when closing a connection because its session was canceled. The
+	// close reason is the reason given when the session was canceled.
+	WebsocketStatusSessionCanceled = 3001
 )
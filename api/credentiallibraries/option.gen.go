@@ -114,6 +114,30 @@ func DefaultVaultSSHCertificateCredentialLibraryAdditionalValidPrincipals() Opti
 	}
 }
 
+func WithVaultPkiCredentialLibraryAltNames(inAltNames string) Option {
+	return func(o *options) {
+		raw, ok := o.postMap["attributes"]
+		if !ok {
+			raw = interface{}(map[string]interface{}{})
+		}
+		val := raw.(map[string]interface{})
+		val["alt_names"] = inAltNames
+		o.postMap["attributes"] = val
+	}
+}
+
+func DefaultVaultPkiCredentialLibraryAltNames() Option {
+	return func(o *options) {
+		raw, ok := o.postMap["attributes"]
+		if !ok {
+			raw = interface{}(map[string]interface{}{})
+		}
+		val := raw.(map[string]interface{})
+		val["alt_names"] = nil
+		o.postMap["attributes"] = val
+	}
+}
+
 func WithAttributes(inAttributes map[string]interface{}) Option {
 	return func(o *options) {
 		o.postMap["attributes"] = inAttributes
@@ -126,6 +150,18 @@ func DefaultAttributes() Option {
 	}
 }
 
+func WithVaultPkiCredentialLibraryCommonName(inCommonName string) Option {
+	return func(o *options) {
+		raw, ok := o.postMap["attributes"]
+		if !ok {
+			raw = interface{}(map[string]interface{}{})
+		}
+		val := raw.(map[string]interface{})
+		val["common_name"] = inCommonName
+		o.postMap["attributes"] = val
+	}
+}
+
 func WithCredentialMappingOverrides(inCredentialMappingOverrides map[string]interface{}) Option {
 	return func(o *options) {
 		o.postMap["credential_mapping_overrides"] = inCredentialMappingOverrides
@@ -330,6 +366,30 @@ func DefaultVaultSSHCertificateCredentialLibraryKeyType() Option {
 	}
 }
 
+func WithVaultDatabaseCredentialLibraryMountPath(inMountPath string) Option {
+	return func(o *options) {
+		raw, ok := o.postMap["attributes"]
+		if !ok {
+			raw = interface{}(map[string]interface{}{})
+		}
+		val := raw.(map[string]interface{})
+		val["mount_path"] = inMountPath
+		o.postMap["attributes"] = val
+	}
+}
+
+func WithVaultPkiCredentialLibraryMountPath(inMountPath string) Option {
+	return func(o *options) {
+		raw, ok := o.postMap["attributes"]
+		if !ok {
+			raw = interface{}(map[string]interface{}{})
+		}
+		val := raw.(map[string]interface{})
+		val["mount_path"] = inMountPath
+		o.postMap["attributes"] = val
+	}
+}
+
 func WithName(inName string) Option {
 	return func(o *options) {
 		o.postMap["name"] = inName
@@ -366,6 +426,30 @@ func WithVaultSSHCertificateCredentialLibraryPath(inPath string) Option {
 	}
 }
 
+func WithVaultDatabaseCredentialLibraryRole(inRole string) Option {
+	return func(o *options) {
+		raw, ok := o.postMap["attributes"]
+		if !ok {
+			raw = interface{}(map[string]interface{}{})
+		}
+		val := raw.(map[string]interface{})
+		val["role"] = inRole
+		o.postMap["attributes"] = val
+	}
+}
+
+func WithVaultPkiCredentialLibraryRole(inRole string) Option {
+	return func(o *options) {
+		raw, ok := o.postMap["attributes"]
+		if !ok {
+			raw = interface{}(map[string]interface{}{})
+		}
+		val := raw.(map[string]interface{})
+		val["role"] = inRole
+		o.postMap["attributes"] = val
+	}
+}
+
 func WithVaultSSHCertificateCredentialLibraryTtl(inTtl string) Option {
 	return func(o *options) {
 		raw, ok := o.postMap["attributes"]
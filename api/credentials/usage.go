@@ -0,0 +1,81 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package credentials
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+
+	"github.com/hashicorp/boundary/api"
+)
+
+type CredentialUsageReadResult struct {
+	Items        []*CredentialUsage `json:"items,omitempty"`
+	ListToken    string             `json:"list_token,omitempty"`
+	ResponseType string             `json:"response_type,omitempty"`
+	Response     *api.Response
+}
+
+func (n CredentialUsageReadResult) GetItems() []*CredentialUsage {
+	return n.Items
+}
+
+func (n CredentialUsageReadResult) GetResponse() *api.Response {
+	return n.Response
+}
+
+// ReadUsage builds and sends a request to the API for the sessions the
+// credential with credentialId has been issued to, most recent first. If the
+// result is split into pages, the remaining pages are fetched and returned
+// together with the first one.
+func (c *Client) ReadUsage(ctx context.Context, credentialId string, opt ...Option) (*CredentialUsageReadResult, error) {
+	if credentialId == "" {
+		return nil, fmt.Errorf("empty credentialId value passed into ReadUsage request")
+	}
+	if c.client == nil {
+		return nil, fmt.Errorf("nil client")
+	}
+
+	opts, apiOpts := getOpts(opt...)
+	target := new(CredentialUsageReadResult)
+	for {
+		req, err := c.client.NewRequest(ctx, "GET", fmt.Sprintf("credentials/%s:read-usage", url.PathEscape(credentialId)), nil, apiOpts...)
+		if err != nil {
+			return nil, fmt.Errorf("error creating ReadUsage request: %w", err)
+		}
+
+		if target.ListToken != "" {
+			opts.queryMap["list_token"] = target.ListToken
+		}
+		if len(opts.queryMap) > 0 {
+			q := url.Values{}
+			for k, v := range opts.queryMap {
+				q.Add(k, v)
+			}
+			req.URL.RawQuery = q.Encode()
+		}
+
+		resp, err := c.client.Do(req)
+		if err != nil {
+			return nil, fmt.Errorf("error performing client request during ReadUsage call: %w", err)
+		}
+
+		page := new(CredentialUsageReadResult)
+		apiErr, err := resp.Decode(page)
+		if err != nil {
+			return nil, fmt.Errorf("error decoding ReadUsage response: %w", err)
+		}
+		if apiErr != nil {
+			return nil, apiErr
+		}
+		target.Items = append(target.Items, page.Items...)
+		target.ListToken = page.ListToken
+		target.ResponseType = page.ResponseType
+		target.Response = resp
+		if target.ResponseType == "complete" || target.ResponseType == "" || target.ListToken == "" {
+			return target, nil
+		}
+	}
+}
@@ -11,8 +11,10 @@ import (
 )
 
 const (
-	usernamePasswordCredentialType = "username_password"
-	sshPrivateKeyCredentialType    = "ssh_private_key"
+	usernamePasswordCredentialType     = "username_password"
+	sshPrivateKeyCredentialType        = "ssh_private_key"
+	cloudAccessCredentialType          = "cloud_access"
+	tlsClientCertificateCredentialType = "tls_client_certificate"
 )
 
 // UsernamePassword contains username and password credentials
@@ -39,15 +41,124 @@ type SshPrivateKey struct {
 	Consumed bool
 }
 
+// The cloud providers a CloudAccess credential can be issued for
+const (
+	CloudProviderAws   = "aws"
+	CloudProviderGcp   = "gcp"
+	CloudProviderAzure = "azure"
+)
+
+// CloudAccess contains the values used to access the API of a cloud
+// provider. Which values are set depends on the provider: an access key id
+// and secret access key with an optional session token for AWS, an OAuth2
+// access token and/or a service account key for GCP, or a client id and
+// secret with optional tenant and subscription ids for Azure.
+type CloudAccess struct {
+	// Provider is the cloud provider the credential is for. If empty the
+	// credential is treated as an AWS credential.
+	Provider string `mapstructure:"provider"`
+
+	AccessKeyId     string `mapstructure:"access_key_id"`
+	SecretAccessKey string `mapstructure:"secret_access_key"`
+	SessionToken    string `mapstructure:"session_token"`
+
+	AccessToken string `mapstructure:"access_token"`
+	// ServiceAccountKey is the JSON encoded GCP service account key file
+	ServiceAccountKey string `mapstructure:"service_account_key"`
+
+	ClientId       string `mapstructure:"client_id"`
+	ClientSecret   string `mapstructure:"client_secret"`
+	TenantId       string `mapstructure:"tenant_id"`
+	SubscriptionId string `mapstructure:"subscription_id"`
+
+	// Expiration is the RFC 3339 formatted time the credential expires at, if
+	// any
+	Expiration string `mapstructure:"expiration"`
+
+	// ServiceAccountKeyFile can be set by the caller to the path of a file
+	// containing ServiceAccountKey. GCP client libraries only read service
+	// account keys from a file.
+	ServiceAccountKeyFile string `mapstructure:"-"`
+
+	Raw *targets.SessionCredential
+	// Consumed can be set by the caller to indicate that the credential has
+	// been used, e.g. exported to the environment of a command
+	Consumed bool
+}
+
+func (c CloudAccess) provider() string {
+	if c.Provider == "" {
+		return CloudProviderAws
+	}
+	return c.Provider
+}
+
+func (c CloudAccess) valid() bool {
+	switch c.provider() {
+	case CloudProviderAws:
+		return c.AccessKeyId != "" && c.SecretAccessKey != ""
+	case CloudProviderGcp:
+		return c.AccessToken != "" || c.ServiceAccountKey != ""
+	case CloudProviderAzure:
+		return c.ClientId != "" && c.ClientSecret != ""
+	}
+	return false
+}
+
+// Env returns the credential as environment variables in the form used by
+// the CLIs and SDKs of its provider. For GCP, GOOGLE_APPLICATION_CREDENTIALS
+// is only set if ServiceAccountKeyFile is set.
+func (c CloudAccess) Env() []string {
+	var env []string
+	add := func(k, v string) {
+		if v != "" {
+			env = append(env, k+"="+v)
+		}
+	}
+	switch c.provider() {
+	case CloudProviderAws:
+		add("AWS_ACCESS_KEY_ID", c.AccessKeyId)
+		add("AWS_SECRET_ACCESS_KEY", c.SecretAccessKey)
+		add("AWS_SESSION_TOKEN", c.SessionToken)
+		add("AWS_CREDENTIAL_EXPIRATION", c.Expiration)
+	case CloudProviderGcp:
+		add("GOOGLE_APPLICATION_CREDENTIALS", c.ServiceAccountKeyFile)
+		add("CLOUDSDK_AUTH_ACCESS_TOKEN", c.AccessToken)
+		add("GOOGLE_OAUTH_ACCESS_TOKEN", c.AccessToken)
+	case CloudProviderAzure:
+		add("AZURE_CLIENT_ID", c.ClientId)
+		add("AZURE_CLIENT_SECRET", c.ClientSecret)
+		add("AZURE_TENANT_ID", c.TenantId)
+		add("AZURE_SUBSCRIPTION_ID", c.SubscriptionId)
+	}
+	return env
+}
+
+// TlsClientCertificate contains a PEM encoded client certificate chain and
+// private key with an optional CA certificate bundle used to verify the
+// server
+type TlsClientCertificate struct {
+	Certificate   string `mapstructure:"certificate"`
+	PrivateKey    string `mapstructure:"private_key"`
+	CaCertificate string `mapstructure:"ca_certificate"`
+
+	Raw *targets.SessionCredential
+	// Consumed can be set by the caller to indicate that the credential has
+	// been used, e.g. passed to a client
+	Consumed bool
+}
+
 type Credentials struct {
 	UsernamePassword []UsernamePassword
 	SshPrivateKey    []SshPrivateKey
+	CloudAccess      []CloudAccess
+	TlsClientCert    []TlsClientCertificate
 	// Unspecified are credentials that do not match one of the types above
 	Unspecified []*targets.SessionCredential
 }
 
 func (c Credentials) UnconsumedSessionCredentials() []*targets.SessionCredential {
-	out := make([]*targets.SessionCredential, 0, len(c.SshPrivateKey)+len(c.UsernamePassword)+len(c.Unspecified))
+	out := make([]*targets.SessionCredential, 0, len(c.SshPrivateKey)+len(c.UsernamePassword)+len(c.CloudAccess)+len(c.TlsClientCert)+len(c.Unspecified))
 
 	// Unspecified credentials cannot be consumed
 	out = append(out, c.Unspecified...)
@@ -62,6 +173,16 @@ func (c Credentials) UnconsumedSessionCredentials() []*targets.SessionCredential
 			out = append(out, c.Raw)
 		}
 	}
+	for _, c := range c.CloudAccess {
+		if !c.Consumed {
+			out = append(out, c.Raw)
+		}
+	}
+	for _, c := range c.TlsClientCert {
+		if !c.Consumed {
+			out = append(out, c.Raw)
+		}
+	}
 	return out
 }
 
@@ -77,6 +198,8 @@ func ParseCredentials(creds []*targets.SessionCredential) (Credentials, error) {
 
 		var upCred UsernamePassword
 		var spkCred SshPrivateKey
+		var caCred CloudAccess
+		var tlsCred TlsClientCertificate
 		switch cred.CredentialSource.CredentialType {
 		case usernamePasswordCredentialType:
 			// Decode attributes from credential struct
@@ -101,6 +224,30 @@ func ParseCredentials(creds []*targets.SessionCredential) (Credentials, error) {
 				out.SshPrivateKey = append(out.SshPrivateKey, spkCred)
 				continue
 			}
+
+		case cloudAccessCredentialType:
+			// Decode attributes from credential struct
+			if err := mapstructure.Decode(cred.Credential, &caCred); err != nil {
+				return Credentials{}, err
+			}
+
+			if caCred.valid() {
+				caCred.Raw = cred
+				out.CloudAccess = append(out.CloudAccess, caCred)
+				continue
+			}
+
+		case tlsClientCertificateCredentialType:
+			// Decode attributes from credential struct
+			if err := mapstructure.Decode(cred.Credential, &tlsCred); err != nil {
+				return Credentials{}, err
+			}
+
+			if tlsCred.Certificate != "" && tlsCred.PrivateKey != "" {
+				tlsCred.Raw = cred
+				out.TlsClientCert = append(out.TlsClientCert, tlsCred)
+				continue
+			}
 		}
 
 		// Credential type is unspecified, make a best effort attempt to parse
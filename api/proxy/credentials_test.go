@@ -39,6 +39,51 @@ var (
 		},
 	}
 
+	typedCloudAccess = &targets.SessionCredential{
+		CredentialSource: &targets.CredentialSource{
+			CredentialType: cloudAccessCredentialType,
+		},
+		Credential: map[string]any{
+			"access_key_id":     "ASIA",
+			"secret_access_key": "secret",
+			"session_token":     "token",
+			"expiration":        "2024-06-01T12:00:00Z",
+		},
+	}
+
+	typedGcpCloudAccess = &targets.SessionCredential{
+		CredentialSource: &targets.CredentialSource{
+			CredentialType: cloudAccessCredentialType,
+		},
+		Credential: map[string]any{
+			"provider":     "gcp",
+			"access_token": "ya29.token",
+		},
+	}
+
+	typedAzureCloudAccess = &targets.SessionCredential{
+		CredentialSource: &targets.CredentialSource{
+			CredentialType: cloudAccessCredentialType,
+		},
+		Credential: map[string]any{
+			"provider":      "azure",
+			"client_id":     "client",
+			"client_secret": "secret",
+			"tenant_id":     "tenant",
+		},
+	}
+
+	typedTlsClientCertificate = &targets.SessionCredential{
+		CredentialSource: &targets.CredentialSource{
+			CredentialType: tlsClientCertificateCredentialType,
+		},
+		Credential: map[string]any{
+			"certificate":    "my-cert",
+			"private_key":    "my-key",
+			"ca_certificate": "my-ca",
+		},
+	}
+
 	vaultUsernamePasswordDeprecatedSubtype = &targets.SessionCredential{
 		CredentialSource: &targets.CredentialSource{
 			Type: vaultSubtype,
@@ -215,6 +260,75 @@ func Test_parseCredentials(t *testing.T) {
 			},
 			wantErr: false,
 		},
+		{
+			name: "cloud-access-typed",
+			creds: []*targets.SessionCredential{
+				typedCloudAccess,
+			},
+			wantCreds: Credentials{
+				CloudAccess: []CloudAccess{
+					{
+						AccessKeyId:     "ASIA",
+						SecretAccessKey: "secret",
+						SessionToken:    "token",
+						Expiration:      "2024-06-01T12:00:00Z",
+						Raw:             typedCloudAccess,
+					},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "cloud-access-gcp-typed",
+			creds: []*targets.SessionCredential{
+				typedGcpCloudAccess,
+			},
+			wantCreds: Credentials{
+				CloudAccess: []CloudAccess{
+					{
+						Provider:    CloudProviderGcp,
+						AccessToken: "ya29.token",
+						Raw:         typedGcpCloudAccess,
+					},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "cloud-access-azure-typed",
+			creds: []*targets.SessionCredential{
+				typedAzureCloudAccess,
+			},
+			wantCreds: Credentials{
+				CloudAccess: []CloudAccess{
+					{
+						Provider:     CloudProviderAzure,
+						ClientId:     "client",
+						ClientSecret: "secret",
+						TenantId:     "tenant",
+						Raw:          typedAzureCloudAccess,
+					},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "tls-client-certificate-typed",
+			creds: []*targets.SessionCredential{
+				typedTlsClientCertificate,
+			},
+			wantCreds: Credentials{
+				TlsClientCert: []TlsClientCertificate{
+					{
+						Certificate:   "my-cert",
+						PrivateKey:    "my-key",
+						CaCertificate: "my-ca",
+						Raw:           typedTlsClientCertificate,
+					},
+				},
+			},
+			wantErr: false,
+		},
 		{
 			name: "ssh-private-key-typed",
 			creds: []*targets.SessionCredential{
@@ -446,6 +560,8 @@ func Test_parseCredentials(t *testing.T) {
 
 			assert.ElementsMatch(tt.wantCreds.UsernamePassword, creds.UsernamePassword)
 			assert.ElementsMatch(tt.wantCreds.SshPrivateKey, creds.SshPrivateKey)
+			assert.ElementsMatch(tt.wantCreds.CloudAccess, creds.CloudAccess)
+			assert.ElementsMatch(tt.wantCreds.TlsClientCert, creds.TlsClientCert)
 			assert.ElementsMatch(tt.wantCreds.Unspecified, creds.Unspecified)
 		})
 	}
@@ -507,6 +623,52 @@ func Test_unconsumedSessionCredentials(t *testing.T) {
 			},
 			wantCreds: nil,
 		},
+		{
+			name: "cloud-access",
+			creds: Credentials{
+				CloudAccess: []CloudAccess{
+					{
+						Raw: typedCloudAccess,
+					},
+				},
+			},
+			wantCreds: []*targets.SessionCredential{typedCloudAccess},
+		},
+		{
+			name: "tls-client-certificate",
+			creds: Credentials{
+				TlsClientCert: []TlsClientCertificate{
+					{
+						Raw: typedTlsClientCertificate,
+					},
+				},
+			},
+			wantCreds: []*targets.SessionCredential{typedTlsClientCertificate},
+		},
+		{
+			name: "tls-client-certificate-consumed",
+			creds: Credentials{
+				TlsClientCert: []TlsClientCertificate{
+					{
+						Raw:      typedTlsClientCertificate,
+						Consumed: true,
+					},
+				},
+			},
+			wantCreds: nil,
+		},
+		{
+			name: "cloud-access-consumed",
+			creds: Credentials{
+				CloudAccess: []CloudAccess{
+					{
+						Raw:      typedCloudAccess,
+						Consumed: true,
+					},
+				},
+			},
+			wantCreds: nil,
+		},
 		{
 			name: "Unspecified",
 			creds: Credentials{
@@ -627,3 +789,81 @@ func Test_unconsumedSessionCredentials(t *testing.T) {
 		})
 	}
 }
+
+func TestCloudAccess_Env(t *testing.T) {
+	tests := []struct {
+		name string
+		cred CloudAccess
+		want []string
+	}{
+		{
+			name: "keys-only",
+			cred: CloudAccess{
+				AccessKeyId:     "AKIA",
+				SecretAccessKey: "secret",
+			},
+			want: []string{
+				"AWS_ACCESS_KEY_ID=AKIA",
+				"AWS_SECRET_ACCESS_KEY=secret",
+			},
+		},
+		{
+			name: "all",
+			cred: CloudAccess{
+				AccessKeyId:     "ASIA",
+				SecretAccessKey: "secret",
+				SessionToken:    "token",
+				Expiration:      "2024-06-01T12:00:00Z",
+			},
+			want: []string{
+				"AWS_ACCESS_KEY_ID=ASIA",
+				"AWS_SECRET_ACCESS_KEY=secret",
+				"AWS_SESSION_TOKEN=token",
+				"AWS_CREDENTIAL_EXPIRATION=2024-06-01T12:00:00Z",
+			},
+		},
+		{
+			name: "gcp-access-token",
+			cred: CloudAccess{
+				Provider:    CloudProviderGcp,
+				AccessToken: "ya29.token",
+			},
+			want: []string{
+				"CLOUDSDK_AUTH_ACCESS_TOKEN=ya29.token",
+				"GOOGLE_OAUTH_ACCESS_TOKEN=ya29.token",
+			},
+		},
+		{
+			name: "gcp-service-account-key",
+			cred: CloudAccess{
+				Provider:              CloudProviderGcp,
+				ServiceAccountKey:     `{"type":"service_account"}`,
+				ServiceAccountKeyFile: "/tmp/key.json",
+			},
+			want: []string{
+				"GOOGLE_APPLICATION_CREDENTIALS=/tmp/key.json",
+			},
+		},
+		{
+			name: "azure",
+			cred: CloudAccess{
+				Provider:       CloudProviderAzure,
+				ClientId:       "client",
+				ClientSecret:   "secret",
+				TenantId:       "tenant",
+				SubscriptionId: "subscription",
+			},
+			want: []string{
+				"AZURE_CLIENT_ID=client",
+				"AZURE_CLIENT_SECRET=secret",
+				"AZURE_TENANT_ID=tenant",
+				"AZURE_SUBSCRIPTION_ID=subscription",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.cred.Env())
+		})
+	}
+}
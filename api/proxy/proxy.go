@@ -49,11 +49,14 @@ type ClientProxy struct {
 	workerAddr              string
 	listenAddrPort          netip.AddrPort
 	listener                *atomic.Value
+	packetConn              *atomic.Pointer[net.UDPConn]
 	listenerCloseOnce       *sync.Once
 	clientTlsConf           *tls.Config
 	connWg                  *sync.WaitGroup
 	started                 *atomic.Bool
 	skipSessionTeardown     bool
+	sessionCanceled         *atomic.Bool
+	cancelReason            *ua.String
 }
 
 // New creates a new client proxy. The given context should be cancelable; once
@@ -63,10 +66,12 @@ type ClientProxy struct {
 //
 // Supported options:
 //
-// * WithListenAddrPort - Specify a TCP address and port on which to listen
+// * WithListenAddrPort - Specify a TCP address and port on which to listen, or
+// a UDP address and port for sessions to udp targets
 //
 // * WithListener - Specify a custom listener on which to accept connections;
-// overrides WithListenAddrPort if both are set
+// overrides WithListenAddrPort if both are set. Not supported for sessions to
+// udp targets.
 //
 // * WithSessionAuthorizationData - Specify an already-unmarshaled session
 // authorization object. If set, authzToken can be empty.
@@ -94,6 +99,7 @@ func New(ctx context.Context, authzToken string, opt ...Option) (*ClientProxy, e
 		connsLeftCh:             make(chan int32),
 		connectionsLeft:         new(atomic.Int32),
 		listener:                new(atomic.Value),
+		packetConn:              new(atomic.Pointer[net.UDPConn]),
 		listenerCloseOnce:       new(sync.Once),
 		connWg:                  new(sync.WaitGroup),
 		listenAddrPort:          opts.WithListenAddrPort,
@@ -101,6 +107,8 @@ func New(ctx context.Context, authzToken string, opt ...Option) (*ClientProxy, e
 		started:                 new(atomic.Bool),
 		skipSessionTeardown:     opts.WithSkipSessionTeardown,
 		apiClient:               opts.withApiClient,
+		sessionCanceled:         new(atomic.Bool),
+		cancelReason:            ua.NewString(""),
 	}
 
 	if opts.WithListener != nil {
@@ -123,6 +131,9 @@ func New(ctx context.Context, authzToken string, opt ...Option) (*ClientProxy, e
 	if len(p.sessionAuthzData.WorkerInfo) == 0 {
 		return nil, errors.New("no workers found in authorization data")
 	}
+	if opts.WithListener != nil && p.isUdp() {
+		return nil, errors.New("custom listeners are not supported for udp sessions")
+	}
 
 	if opts.WithListener == nil {
 		if p.listenAddrPort.Port() == 0 {
@@ -187,7 +198,16 @@ func (p *ClientProxy) Start(opt ...Option) (retErr error) {
 		opts.withSessionTeardownTimeout = sessionCancelTimeout
 	}
 
-	if p.listener.Load() == nil {
+	if p.isUdp() {
+		pc, err := net.ListenUDP("udp", &net.UDPAddr{
+			IP:   p.listenAddrPort.Addr().AsSlice(),
+			Port: int(p.listenAddrPort.Port()),
+		})
+		if err != nil {
+			return fmt.Errorf("unable to start listening: %w", err)
+		}
+		p.packetConn.Store(pc)
+	} else if p.listener.Load() == nil {
 		var err error
 		ln, err := net.ListenTCP("tcp", &net.TCPAddr{
 			IP:   p.listenAddrPort.Addr().AsSlice(),
@@ -203,7 +223,13 @@ func (p *ClientProxy) Start(opt ...Option) (retErr error) {
 		p.listenerCloseOnce.Do(func() {
 			// Forces the for loop to exit instead of spinning on errors
 			p.connectionsLeft.Store(0)
-			if err := p.listener.Load().(net.Listener).Close(); err != nil && err != net.ErrClosed {
+			var err error
+			if pc := p.packetConn.Load(); pc != nil {
+				err = pc.Close()
+			} else {
+				err = p.listener.Load().(net.Listener).Close()
+			}
+			if err != nil && err != net.ErrClosed {
 				retErr = errors.Join(retErr, fmt.Errorf("error closing proxy listener: %w", err))
 			}
 		})
@@ -216,6 +242,10 @@ func (p *ClientProxy) Start(opt ...Option) (retErr error) {
 	p.connWg.Add(1)
 	go func() {
 		defer p.connWg.Done()
+		if p.isUdp() {
+			p.serveUdp(fin, listenerCloseFunc)
+			return
+		}
 		for {
 			listeningConn, err := p.listener.Load().(net.Listener).Accept()
 			if err != nil {
@@ -315,7 +345,9 @@ func (p *ClientProxy) Start(opt ...Option) (retErr error) {
 				if p.callerConnectionsLeftCh != nil {
 					p.callerConnectionsLeftCh <- connsLeft
 				}
-				if connsLeft == 0 {
+				// UDP flows share the listener, so serveUdp closes it once
+				// the last flow ends instead.
+				if connsLeft == 0 && !p.isUdp() {
 					// Close the listener as we can't authorize any more
 					// connections
 					return
@@ -375,8 +407,8 @@ func (p *ClientProxy) ListenerAddress(ctx context.Context) string {
 	switch {
 	case p.cachedListenerAddress.Load() != "":
 		return p.cachedListenerAddress.Load()
-	case p.listener.Load() != nil:
-		addr := p.listener.Load().(net.Listener).Addr().String()
+	case p.listenerAddress() != "":
+		addr := p.listenerAddress()
 		p.cachedListenerAddress.Store(addr)
 		return addr
 	case ctx == nil:
@@ -389,9 +421,8 @@ func (p *ClientProxy) ListenerAddress(ctx context.Context) string {
 			timer.Stop()
 			return ""
 		case <-timer.C:
-			if p.listener.Load() != nil {
+			if addr := p.listenerAddress(); addr != "" {
 				timer.Stop()
-				addr := p.listener.Load().(net.Listener).Addr().String()
 				p.cachedListenerAddress.Store(addr)
 				return addr
 			}
@@ -400,6 +431,18 @@ func (p *ClientProxy) ListenerAddress(ctx context.Context) string {
 	}
 }
 
+// listenerAddress returns the address of the listener or UDP socket, or an
+// empty string if neither has been started.
+func (p *ClientProxy) listenerAddress() string {
+	if pc := p.packetConn.Load(); pc != nil {
+		return pc.LocalAddr().String()
+	}
+	if ln := p.listener.Load(); ln != nil {
+		return ln.(net.Listener).Addr().String()
+	}
+	return ""
+}
+
 // SessionCreatedTime returns the creation time of the session
 //
 // EXPERIMENTAL: While this API is not expected to change, it is new and
@@ -424,3 +467,21 @@ func (p *ClientProxy) SessionExpiration() time.Time {
 func (p *ClientProxy) ConnectionsLeft() int32 {
 	return p.connectionsLeft.Load()
 }
+
+// SessionCanceled returns whether the proxy stopped because the session was
+// canceled.
+//
+// EXPERIMENTAL: While this API is not expected to change, it is new and
+// feedback from users may necessitate changes.
+func (p *ClientProxy) SessionCanceled() bool {
+	return p.sessionCanceled.Load()
+}
+
+// CancelReason returns the reason given when the session was canceled, if
+// any. It is only set if SessionCanceled returns true.
+//
+// EXPERIMENTAL: While this API is not expected to change, it is new and
+// feedback from users may necessitate changes.
+func (p *ClientProxy) CancelReason() string {
+	return p.cancelReason.Load()
+}
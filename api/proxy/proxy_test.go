@@ -67,6 +67,15 @@ func TestNew(t *testing.T) {
 			name: "with listener",
 			opts: []Option{WithSessionAuthorizationData(sessionAuth), WithListener(l)},
 		},
+		{
+			name: "udp with listener",
+			transformFn: func(in *targets.SessionAuthorizationData) *targets.SessionAuthorizationData {
+				in.Type = "udp"
+				return in
+			},
+			opts:        []Option{WithSessionAuthorizationData(sessionAuth), WithListener(l)},
+			errContains: "custom listeners are not supported for udp sessions",
+		},
 	}
 	for _, tt := range tc {
 		t.Run(tt.name, func(t *testing.T) {
@@ -0,0 +1,186 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package proxy
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"sync"
+
+	"nhooyr.io/websocket"
+)
+
+const (
+	// maxDatagramSize is the largest datagram that can be relayed, which is
+	// the largest length that fits in a frame header. It is larger than the
+	// payload of any UDP datagram, which is at most 65507 bytes over IPv4 and
+	// 65527 bytes over IPv6.
+	maxDatagramSize = 1<<16 - 1
+
+	// udpFlowQueueSize is the number of datagrams from a local client that are
+	// buffered while its flow is being established with the worker. Any
+	// further datagrams are dropped.
+	udpFlowQueueSize = 64
+)
+
+// isUdp returns whether the session is to a udp target, in which case the
+// proxy listens on a UDP socket.
+func (p *ClientProxy) isUdp() bool {
+	return p.sessionAuthzData.Type == "udp"
+}
+
+// serveUdp reads datagrams from the proxy's UDP socket and relays them to the
+// worker. Each local address sending datagrams is a flow that uses its own
+// session connection, and datagrams the worker relays back are sent to that
+// address. The worker ends a flow once it has been idle for a while. serveUdp
+// returns once the socket is closed, which happens when the last flow ends and
+// no more connections can be authorized.
+func (p *ClientProxy) serveUdp(fin chan<- error, listenerCloseFunc func()) {
+	pc := p.packetConn.Load()
+	var flowsMu sync.Mutex
+	flows := make(map[string]chan []byte)
+	buf := make([]byte, maxDatagramSize)
+	for {
+		n, addr, err := pc.ReadFromUDP(buf)
+		if err != nil {
+			select {
+			case <-p.ctx.Done():
+				return
+			default:
+				if errors.Is(err, net.ErrClosed) {
+					return
+				}
+				// No reason to think we can successfully read the next
+				// datagram, so cancel the proxy
+				fin <- fmt.Errorf("error reading from udp listener: %w", err)
+				listenerCloseFunc()
+				p.cancel()
+				return
+			}
+		}
+		datagram := make([]byte, n)
+		copy(datagram, buf[:n])
+
+		flowsMu.Lock()
+		queue, ok := flows[addr.String()]
+		if !ok {
+			if p.connectionsLeft.Load() == 0 {
+				flowsMu.Unlock()
+				continue
+			}
+			queue = make(chan []byte, udpFlowQueueSize)
+			flows[addr.String()] = queue
+			p.connWg.Add(1)
+			go func() {
+				defer p.connWg.Done()
+				err := p.runUdpFlow(pc, addr, queue)
+
+				flowsMu.Lock()
+				delete(flows, addr.String())
+				last := len(flows) == 0
+				flowsMu.Unlock()
+
+				switch {
+				case err != nil:
+					fin <- err
+					// No reason to think we can successfully handle the next
+					// flow that comes our way, so cancel the proxy
+					listenerCloseFunc()
+					p.cancel()
+				case last && p.connectionsLeft.Load() == 0:
+					// We can't authorize any more flows
+					listenerCloseFunc()
+					p.cancel()
+				}
+			}()
+		}
+		select {
+		case queue <- datagram:
+		default:
+		}
+		flowsMu.Unlock()
+	}
+}
+
+// runUdpFlow establishes a session connection for the flow from addr and
+// relays datagrams between it and the worker until the worker closes the
+// connection.
+func (p *ClientProxy) runUdpFlow(pc *net.UDPConn, addr *net.UDPAddr, queue <-chan []byte) error {
+	wsConn, err := p.getWsConn(p.ctx)
+	if err != nil {
+		return fmt.Errorf("error from getWsConn: %w", err)
+	}
+	if err := p.handshake(wsConn); err != nil {
+		return fmt.Errorf("error from handshake: %w", err)
+	}
+
+	netConn := websocket.NetConn(p.ctx, wsConn, websocket.MessageBinary)
+	defer netConn.Close()
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		buf := make([]byte, maxDatagramSize)
+		for {
+			n, err := readDatagram(netConn, buf)
+			if err != nil {
+				p.checkSessionCanceled(err)
+				return
+			}
+			if _, err := pc.WriteToUDP(buf[:n], addr); err != nil {
+				return
+			}
+		}
+	}()
+
+	for {
+		select {
+		case <-done:
+			return nil
+		case datagram := <-queue:
+			if err := writeDatagram(netConn, datagram); err != nil {
+				netConn.Close()
+				<-done
+				return nil
+			}
+		}
+	}
+}
+
+// writeDatagram writes p to w in a single write as a frame holding the length
+// of p as a big-endian uint16 followed by p. This is the framing the worker
+// expects for udp sessions. An error is returned if p is longer than
+// maxDatagramSize.
+//
+// The framing is needed because the worker hands the connection to its udp
+// handler as a byte stream, which loses the boundaries of websocket messages,
+// so each datagram can't simply be sent as a message with sdk/wspb.
+func writeDatagram(w io.Writer, p []byte) error {
+	if len(p) > maxDatagramSize {
+		return fmt.Errorf("datagram of %d bytes is larger than the maximum of %d bytes", len(p), maxDatagramSize)
+	}
+	frame := make([]byte, 2+len(p))
+	binary.BigEndian.PutUint16(frame, uint16(len(p)))
+	copy(frame[2:], p)
+	_, err := w.Write(frame)
+	return err
+}
+
+// readDatagram reads a frame written by writeDatagram from r into buf, which
+// must be at least maxDatagramSize long, and returns the length of the
+// datagram.
+func readDatagram(r io.Reader, buf []byte) (int, error) {
+	var header [2]byte
+	if _, err := io.ReadFull(r, header[:]); err != nil {
+		return 0, err
+	}
+	n := int(binary.BigEndian.Uint16(header[:]))
+	if _, err := io.ReadFull(r, buf[:n]); err != nil {
+		return 0, err
+	}
+	return n, nil
+}
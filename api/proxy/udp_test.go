@@ -0,0 +1,106 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package proxy
+
+import (
+	"bytes"
+	"context"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"nhooyr.io/websocket"
+)
+
+func TestUdpListenerAddr(t *testing.T) {
+	t.Parallel()
+	require, assert := require.New(t), assert.New(t)
+
+	sessionAuth := testSessionAuth(t)
+	sessionAuth.Type = "udp"
+
+	p, err := New(context.Background(), "", WithSessionAuthorizationData(sessionAuth))
+	require.NoError(err)
+	assert.True(p.isUdp())
+
+	pc, err := net.ListenUDP("udp", &net.UDPAddr{IP: net.IPv4(127, 0, 0, 1)})
+	require.NoError(err)
+	defer pc.Close()
+	p.packetConn.Store(pc)
+	assert.Equal(pc.LocalAddr().String(), p.ListenerAddress(context.Background()))
+}
+
+func TestDatagramFraming(t *testing.T) {
+	t.Parallel()
+	require, assert := require.New(t), assert.New(t)
+
+	var stream bytes.Buffer
+	datagrams := [][]byte{
+		[]byte("hello"),
+		{},
+		bytes.Repeat([]byte("x"), maxDatagramSize),
+	}
+	for _, d := range datagrams {
+		require.NoError(writeDatagram(&stream, d))
+	}
+	buf := make([]byte, maxDatagramSize)
+	for _, d := range datagrams {
+		n, err := readDatagram(&stream, buf)
+		require.NoError(err)
+		assert.Equal(d, buf[:n])
+	}
+	_, err := readDatagram(&stream, buf)
+	assert.Error(err)
+	assert.Error(writeDatagram(&stream, make([]byte, maxDatagramSize+1)))
+	assert.Zero(stream.Len())
+}
+
+func TestDatagramFraming_Websocket(t *testing.T) {
+	t.Parallel()
+	require, assert := require.New(t), assert.New(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	// Echo each frame back over a websocket, the way the worker relays
+	// datagrams of a udp session.
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		wsConn, err := websocket.Accept(w, r, nil)
+		if err != nil {
+			return
+		}
+		netConn := websocket.NetConn(ctx, wsConn, websocket.MessageBinary)
+		defer netConn.Close()
+		buf := make([]byte, maxDatagramSize)
+		for {
+			n, err := readDatagram(netConn, buf)
+			if err != nil {
+				return
+			}
+			if err := writeDatagram(netConn, buf[:n]); err != nil {
+				return
+			}
+		}
+	}))
+	defer srv.Close()
+
+	wsConn, _, err := websocket.Dial(ctx, "ws://"+srv.Listener.Addr().String(), nil)
+	require.NoError(err)
+	netConn := websocket.NetConn(ctx, wsConn, websocket.MessageBinary)
+	defer netConn.Close()
+
+	// The largest UDP payloads are larger than the default read limit of a
+	// websocket message, and the framing must still relay them whole.
+	buf := make([]byte, maxDatagramSize)
+	for _, size := range []int{maxDatagramSize, 65527, 65507, 1 << 15, 1<<15 + 1} {
+		d := bytes.Repeat([]byte{byte(size)}, size)
+		require.NoError(writeDatagram(netConn, d))
+		n, err := readDatagram(netConn, buf)
+		require.NoError(err)
+		assert.Equal(size, n)
+		assert.Equal(d, buf[:n])
+	}
+}
@@ -72,6 +72,36 @@ func (p *ClientProxy) sendSessionTeardown(ctx context.Context) error {
 }
 
 func (p *ClientProxy) runTcpProxyV1(wsConn *websocket.Conn, listeningConn net.Conn) error {
+	if err := p.handshake(wsConn); err != nil {
+		return err
+	}
+
+	// Get a wrapped net.Conn so we can use io.Copy
+	netConn := websocket.NetConn(p.ctx, wsConn, websocket.MessageBinary)
+
+	localWg := new(sync.WaitGroup)
+	localWg.Add(2)
+	go func() {
+		defer localWg.Done()
+		io.Copy(netConn, listeningConn)
+		netConn.Close()
+		listeningConn.Close()
+	}()
+	go func() {
+		defer localWg.Done()
+		_, err := io.Copy(listeningConn, netConn)
+		p.checkSessionCanceled(err)
+		listeningConn.Close()
+		netConn.Close()
+	}()
+	localWg.Wait()
+
+	return nil
+}
+
+// handshake sends the client handshake over wsConn and reads the worker's
+// result, which authorizes a new connection for the session.
+func (p *ClientProxy) handshake(wsConn *websocket.Conn) error {
 	handshake := pb.ClientHandshake{TofuToken: p.tofuToken}
 	if err := wspb.Write(p.ctx, wsConn, &handshake); err != nil {
 		return fmt.Errorf("error sending handshake to worker: %w", err)
@@ -102,25 +132,17 @@ func (p *ClientProxy) runTcpProxyV1(wsConn *websocket.Conn, listeningConn net.Co
 	if handshakeResult.GetConnectionsLeft() != -1 {
 		p.connsLeftCh <- handshakeResult.GetConnectionsLeft()
 	}
-
-	// Get a wrapped net.Conn so we can use io.Copy
-	netConn := websocket.NetConn(p.ctx, wsConn, websocket.MessageBinary)
-
-	localWg := new(sync.WaitGroup)
-	localWg.Add(2)
-	go func() {
-		defer localWg.Done()
-		io.Copy(netConn, listeningConn)
-		netConn.Close()
-		listeningConn.Close()
-	}()
-	go func() {
-		defer localWg.Done()
-		io.Copy(listeningConn, netConn)
-		listeningConn.Close()
-		netConn.Close()
-	}()
-	localWg.Wait()
-
 	return nil
 }
+
+// checkSessionCanceled records whether the worker closed the connection
+// because the session was canceled and, if so, stops the proxy.
+func (p *ClientProxy) checkSessionCanceled(err error) {
+	var closeErr websocket.CloseError
+	if !errors.As(err, &closeErr) || closeErr.Code != consts.WebsocketStatusSessionCanceled {
+		return
+	}
+	p.cancelReason.Store(closeErr.Reason)
+	p.sessionCanceled.Store(true)
+	p.cancel()
+}
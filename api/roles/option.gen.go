@@ -115,6 +115,30 @@ func DefaultDescription() Option {
 	}
 }
 
+func WithMaxActiveSessionsPerUser(inMaxActiveSessionsPerUser uint32) Option {
+	return func(o *options) {
+		o.postMap["max_active_sessions_per_user"] = inMaxActiveSessionsPerUser
+	}
+}
+
+func DefaultMaxActiveSessionsPerUser() Option {
+	return func(o *options) {
+		o.postMap["max_active_sessions_per_user"] = nil
+	}
+}
+
+func WithMaxDailySessionsPerUser(inMaxDailySessionsPerUser uint32) Option {
+	return func(o *options) {
+		o.postMap["max_daily_sessions_per_user"] = inMaxDailySessionsPerUser
+	}
+}
+
+func DefaultMaxDailySessionsPerUser() Option {
+	return func(o *options) {
+		o.postMap["max_daily_sessions_per_user"] = nil
+	}
+}
+
 func WithName(inName string) Option {
 	return func(o *options) {
 		o.postMap["name"] = inName
@@ -8,6 +8,7 @@ import (
 	"errors"
 	"fmt"
 	"net/url"
+	"strconv"
 
 	"github.com/hashicorp/boundary/api"
 )
@@ -217,6 +218,72 @@ func (c *Client) DestroyKeyVersion(ctx context.Context, scopeId string, keyVersi
 	return target, nil
 }
 
+type OplogEntryListResult struct {
+	Items    []*OplogEntry
+	Cursor   string
+	response *api.Response
+}
+
+func (n OplogEntryListResult) GetItems() []*OplogEntry {
+	return n.Items
+}
+
+func (n OplogEntryListResult) GetResponse() *api.Response {
+	return n.response
+}
+
+// ListOplogEntries lists the entries of the operation log of the scope written
+// after the entries returned by the request which returned the cursor, oldest
+// first. An empty cursor lists the entries starting with the oldest one, and a
+// pageSize of 0 uses the controller's default page size. Pass the Cursor of
+// the result in the next call to resume listing. WithRecursive also lists the
+// entries of the scopes within the scope.
+func (c *Client) ListOplogEntries(ctx context.Context, scopeId string, cursor string, pageSize uint32, opt ...Option) (*OplogEntryListResult, error) {
+	if scopeId == "" {
+		return nil, fmt.Errorf("empty scopeId value passed into ListOplogEntries request")
+	}
+	if c.client == nil {
+		return nil, fmt.Errorf("nil client")
+	}
+
+	opts, apiOpts := getOpts(opt...)
+
+	req, err := c.client.NewRequest(ctx, "GET", "scopes/"+url.PathEscape(scopeId)+":list-oplog-entries", nil, apiOpts...)
+	if err != nil {
+		return nil, fmt.Errorf("error creating ListOplogEntries request: %w", err)
+	}
+
+	if cursor != "" {
+		opts.queryMap["cursor"] = cursor
+	}
+	if pageSize != 0 {
+		opts.queryMap["page_size"] = strconv.FormatUint(uint64(pageSize), 10)
+	}
+	if len(opts.queryMap) > 0 {
+		q := url.Values{}
+		for k, v := range opts.queryMap {
+			q.Add(k, v)
+		}
+		req.URL.RawQuery = q.Encode()
+	}
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("error performing client request during ListOplogEntries call: %w", err)
+	}
+
+	target := new(OplogEntryListResult)
+	apiErr, err := resp.Decode(target)
+	if err != nil {
+		return nil, fmt.Errorf("error decoding ListOplogEntries response: %w", err)
+	}
+	if apiErr != nil {
+		return nil, apiErr
+	}
+	target.response = resp
+	return target, nil
+}
+
 type (
 	AttachStoragePolicyResult = ScopeReadResult
 	DetachStoragePolicyResult = ScopeReadResult
@@ -116,6 +116,66 @@ func DefaultDescription() Option {
 	}
 }
 
+func WithKeyRotationDestroyOldVersions(inKeyRotationDestroyOldVersions bool) Option {
+	return func(o *options) {
+		o.postMap["key_rotation_destroy_old_versions"] = inKeyRotationDestroyOldVersions
+	}
+}
+
+func DefaultKeyRotationDestroyOldVersions() Option {
+	return func(o *options) {
+		o.postMap["key_rotation_destroy_old_versions"] = nil
+	}
+}
+
+func WithKeyRotationIntervalSeconds(inKeyRotationIntervalSeconds uint32) Option {
+	return func(o *options) {
+		o.postMap["key_rotation_interval_seconds"] = inKeyRotationIntervalSeconds
+	}
+}
+
+func DefaultKeyRotationIntervalSeconds() Option {
+	return func(o *options) {
+		o.postMap["key_rotation_interval_seconds"] = nil
+	}
+}
+
+func WithKeyRotationRewrap(inKeyRotationRewrap bool) Option {
+	return func(o *options) {
+		o.postMap["key_rotation_rewrap"] = inKeyRotationRewrap
+	}
+}
+
+func DefaultKeyRotationRewrap() Option {
+	return func(o *options) {
+		o.postMap["key_rotation_rewrap"] = nil
+	}
+}
+
+func WithMaxActiveSessionsPerUser(inMaxActiveSessionsPerUser uint32) Option {
+	return func(o *options) {
+		o.postMap["max_active_sessions_per_user"] = inMaxActiveSessionsPerUser
+	}
+}
+
+func DefaultMaxActiveSessionsPerUser() Option {
+	return func(o *options) {
+		o.postMap["max_active_sessions_per_user"] = nil
+	}
+}
+
+func WithMaxDailySessionsPerUser(inMaxDailySessionsPerUser uint32) Option {
+	return func(o *options) {
+		o.postMap["max_daily_sessions_per_user"] = inMaxDailySessionsPerUser
+	}
+}
+
+func DefaultMaxDailySessionsPerUser() Option {
+	return func(o *options) {
+		o.postMap["max_daily_sessions_per_user"] = nil
+	}
+}
+
 func WithName(inName string) Option {
 	return func(o *options) {
 		o.postMap["name"] = inName
@@ -17,19 +17,24 @@ import (
 )
 
 type Scope struct {
-	Id                          string              `json:"id,omitempty"`
-	ScopeId                     string              `json:"scope_id,omitempty"`
-	Scope                       *ScopeInfo          `json:"scope,omitempty"`
-	Name                        string              `json:"name,omitempty"`
-	Description                 string              `json:"description,omitempty"`
-	CreatedTime                 time.Time           `json:"created_time,omitempty"`
-	UpdatedTime                 time.Time           `json:"updated_time,omitempty"`
-	Version                     uint32              `json:"version,omitempty"`
-	Type                        string              `json:"type,omitempty"`
-	PrimaryAuthMethodId         string              `json:"primary_auth_method_id,omitempty"`
-	AuthorizedActions           []string            `json:"authorized_actions,omitempty"`
-	AuthorizedCollectionActions map[string][]string `json:"authorized_collection_actions,omitempty"`
-	StoragePolicyId             string              `json:"storage_policy_id,omitempty"`
+	Id                            string              `json:"id,omitempty"`
+	ScopeId                       string              `json:"scope_id,omitempty"`
+	Scope                         *ScopeInfo          `json:"scope,omitempty"`
+	Name                          string              `json:"name,omitempty"`
+	Description                   string              `json:"description,omitempty"`
+	CreatedTime                   time.Time           `json:"created_time,omitempty"`
+	UpdatedTime                   time.Time           `json:"updated_time,omitempty"`
+	Version                       uint32              `json:"version,omitempty"`
+	Type                          string              `json:"type,omitempty"`
+	PrimaryAuthMethodId           string              `json:"primary_auth_method_id,omitempty"`
+	MaxActiveSessionsPerUser      uint32              `json:"max_active_sessions_per_user,omitempty"`
+	MaxDailySessionsPerUser       uint32              `json:"max_daily_sessions_per_user,omitempty"`
+	KeyRotationIntervalSeconds    uint32              `json:"key_rotation_interval_seconds,omitempty"`
+	KeyRotationRewrap             bool                `json:"key_rotation_rewrap,omitempty"`
+	KeyRotationDestroyOldVersions bool                `json:"key_rotation_destroy_old_versions,omitempty"`
+	AuthorizedActions             []string            `json:"authorized_actions,omitempty"`
+	AuthorizedCollectionActions   map[string][]string `json:"authorized_collection_actions,omitempty"`
+	StoragePolicyId               string              `json:"storage_policy_id,omitempty"`
 }
 
 type ScopeReadResult struct {
@@ -74,3 +74,58 @@ func (c *Client) Cancel(ctx context.Context, sessionId string, version uint32, o
 	target.Response = resp
 	return target, nil
 }
+
+type SessionCancelResult struct {
+	Items    []*Session `json:"items,omitempty"`
+	Response *api.Response
+}
+
+func (n SessionCancelResult) GetItems() []*Session {
+	return n.Items
+}
+
+func (n SessionCancelResult) GetResponse() *api.Response {
+	return n.Response
+}
+
+// CancelSessions builds and sends a request to the API to cancel the sessions
+// in the scope with scopeId that match the given user, target and worker. At
+// least one of WithUserId, WithTargetId or WithWorkerId must be provided.
+// Sessions the caller isn't allowed to cancel are skipped; the canceled
+// sessions are returned.
+func (c *Client) CancelSessions(ctx context.Context, scopeId string, opt ...Option) (*SessionCancelResult, error) {
+	if scopeId == "" {
+		return nil, fmt.Errorf("empty scopeId value passed into CancelSessions request")
+	}
+	if c.client == nil {
+		return nil, errors.New("nil client")
+	}
+
+	opts, apiOpts := getOpts(opt...)
+
+	opts.postMap["scope_id"] = scopeId
+	if opts.withRecursive {
+		opts.postMap["recursive"] = true
+	}
+
+	req, err := c.client.NewRequest(ctx, "POST", "sessions:cancel", opts.postMap, apiOpts...)
+	if err != nil {
+		return nil, fmt.Errorf("error creating CancelSessions request: %w", err)
+	}
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("error performing client request during CancelSessions call: %w", err)
+	}
+
+	target := new(SessionCancelResult)
+	apiErr, err := resp.Decode(target)
+	if err != nil {
+		return nil, fmt.Errorf("error decoding CancelSessions response: %w", err)
+	}
+	if apiErr != nil {
+		return nil, apiErr
+	}
+	target.Response = resp
+	return target, nil
+}
@@ -115,3 +115,27 @@ func DefaultIncludeTerminated() Option {
 		o.postMap["include_terminated"] = nil
 	}
 }
+
+func WithReason(inReason string) Option {
+	return func(o *options) {
+		o.postMap["reason"] = inReason
+	}
+}
+
+func WithTargetId(inTargetId string) Option {
+	return func(o *options) {
+		o.postMap["target_id"] = inTargetId
+	}
+}
+
+func WithUserId(inUserId string) Option {
+	return func(o *options) {
+		o.postMap["user_id"] = inUserId
+	}
+}
+
+func WithWorkerId(inWorkerId string) Option {
+	return func(o *options) {
+		o.postMap["worker_id"] = inWorkerId
+	}
+}
@@ -35,6 +35,9 @@ type Session struct {
 	Status            string            `json:"status,omitempty"`
 	Certificate       []byte            `json:"certificate,omitempty"`
 	TerminationReason string            `json:"termination_reason,omitempty"`
+	Reason            string            `json:"reason,omitempty"`
+	TicketId          string            `json:"ticket_id,omitempty"`
+	CancelReason      string            `json:"cancel_reason,omitempty"`
 	AuthorizedActions []string          `json:"authorized_actions,omitempty"`
 	Connections       []*Connection     `json:"connections,omitempty"`
 }
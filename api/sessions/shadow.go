@@ -0,0 +1,74 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package sessions
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+
+	"github.com/hashicorp/boundary/api"
+)
+
+type ShadowAuthorizationResult struct {
+	Item     *ShadowAuthorization
+	Response *api.Response
+}
+
+func (n ShadowAuthorizationResult) GetItem() *ShadowAuthorization {
+	return n.Item
+}
+
+func (n ShadowAuthorizationResult) GetResponse() *api.Response {
+	return n.Response
+}
+
+// Shadow builds and sends a request to the API to observe the terminal of the
+// active session with sessionId. If withInput is true, the returned
+// authorization also allows sending input to the terminal.
+func (c *Client) Shadow(ctx context.Context, sessionId string, withInput bool, opt ...Option) (*ShadowAuthorizationResult, error) {
+	if sessionId == "" {
+		return nil, fmt.Errorf("empty sessionId value passed into Shadow request")
+	}
+	if c.client == nil {
+		return nil, errors.New("nil client")
+	}
+
+	opts, apiOpts := getOpts(opt...)
+
+	if withInput {
+		opts.postMap["with_input"] = withInput
+	}
+
+	req, err := c.client.NewRequest(ctx, "POST", fmt.Sprintf("sessions/%s:shadow", url.PathEscape(sessionId)), opts.postMap, apiOpts...)
+	if err != nil {
+		return nil, fmt.Errorf("error creating Shadow request: %w", err)
+	}
+
+	if len(opts.queryMap) > 0 {
+		q := url.Values{}
+		for k, v := range opts.queryMap {
+			q.Add(k, v)
+		}
+		req.URL.RawQuery = q.Encode()
+	}
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("error performing client request during Shadow call: %w", err)
+	}
+
+	target := new(ShadowAuthorizationResult)
+	target.Item = new(ShadowAuthorization)
+	apiErr, err := resp.Decode(target.Item)
+	if err != nil {
+		return nil, fmt.Errorf("error decoding Shadow response: %w", err)
+	}
+	if apiErr != nil {
+		return nil, apiErr
+	}
+	target.Response = resp
+	return target, nil
+}
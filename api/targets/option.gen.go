@@ -103,6 +103,18 @@ func WithRecursive(recurse bool) Option {
 	}
 }
 
+func WithAccessWindow(inAccessWindow *AccessWindow) Option {
+	return func(o *options) {
+		o.postMap["access_window"] = inAccessWindow
+	}
+}
+
+func DefaultAccessWindow() Option {
+	return func(o *options) {
+		o.postMap["access_window"] = nil
+	}
+}
+
 func WithAddress(inAddress string) Option {
 	return func(o *options) {
 		o.postMap["address"] = inAddress
@@ -193,6 +205,30 @@ func DefaultTcpTargetDefaultClientPort() Option {
 	}
 }
 
+func WithUdpTargetDefaultClientPort(inDefaultClientPort uint32) Option {
+	return func(o *options) {
+		raw, ok := o.postMap["attributes"]
+		if !ok {
+			raw = interface{}(map[string]interface{}{})
+		}
+		val := raw.(map[string]interface{})
+		val["default_client_port"] = inDefaultClientPort
+		o.postMap["attributes"] = val
+	}
+}
+
+func DefaultUdpTargetDefaultClientPort() Option {
+	return func(o *options) {
+		raw, ok := o.postMap["attributes"]
+		if !ok {
+			raw = interface{}(map[string]interface{}{})
+		}
+		val := raw.(map[string]interface{})
+		val["default_client_port"] = nil
+		o.postMap["attributes"] = val
+	}
+}
+
 func WithSshTargetDefaultPort(inDefaultPort uint32) Option {
 	return func(o *options) {
 		raw, ok := o.postMap["attributes"]
@@ -241,6 +277,30 @@ func DefaultTcpTargetDefaultPort() Option {
 	}
 }
 
+func WithUdpTargetDefaultPort(inDefaultPort uint32) Option {
+	return func(o *options) {
+		raw, ok := o.postMap["attributes"]
+		if !ok {
+			raw = interface{}(map[string]interface{}{})
+		}
+		val := raw.(map[string]interface{})
+		val["default_port"] = inDefaultPort
+		o.postMap["attributes"] = val
+	}
+}
+
+func DefaultUdpTargetDefaultPort() Option {
+	return func(o *options) {
+		raw, ok := o.postMap["attributes"]
+		if !ok {
+			raw = interface{}(map[string]interface{}{})
+		}
+		val := raw.(map[string]interface{})
+		val["default_port"] = nil
+		o.postMap["attributes"] = val
+	}
+}
+
 func WithDescription(inDescription string) Option {
 	return func(o *options) {
 		o.postMap["description"] = inDescription
@@ -277,6 +337,18 @@ func WithSshTargetEnableSessionRecording(inEnableSessionRecording bool) Option {
 	}
 }
 
+func WithHostHealthCheck(inHostHealthCheck string) Option {
+	return func(o *options) {
+		o.postMap["host_health_check"] = inHostHealthCheck
+	}
+}
+
+func DefaultHostHealthCheck() Option {
+	return func(o *options) {
+		o.postMap["host_health_check"] = nil
+	}
+}
+
 func WithHostId(inHostId string) Option {
 	return func(o *options) {
 		o.postMap["host_id"] = inHostId
@@ -319,6 +391,12 @@ func DefaultName() Option {
 	}
 }
 
+func WithReason(inReason string) Option {
+	return func(o *options) {
+		o.postMap["reason"] = inReason
+	}
+}
+
 func WithScopeId(inScopeId string) Option {
 	return func(o *options) {
 		o.postMap["scope_id"] = inScopeId
@@ -343,6 +421,18 @@ func DefaultSessionConnectionLimit() Option {
 	}
 }
 
+func WithSessionDownloadRateLimit(inSessionDownloadRateLimit uint32) Option {
+	return func(o *options) {
+		o.postMap["session_download_rate_limit"] = inSessionDownloadRateLimit
+	}
+}
+
+func DefaultSessionDownloadRateLimit() Option {
+	return func(o *options) {
+		o.postMap["session_download_rate_limit"] = nil
+	}
+}
+
 func WithSessionMaxSeconds(inSessionMaxSeconds uint32) Option {
 	return func(o *options) {
 		o.postMap["session_max_seconds"] = inSessionMaxSeconds
@@ -355,6 +445,42 @@ func DefaultSessionMaxSeconds() Option {
 	}
 }
 
+func WithSessionReasonRequired(inSessionReasonRequired bool) Option {
+	return func(o *options) {
+		o.postMap["session_reason_required"] = inSessionReasonRequired
+	}
+}
+
+func DefaultSessionReasonRequired() Option {
+	return func(o *options) {
+		o.postMap["session_reason_required"] = nil
+	}
+}
+
+func WithSessionTicketPattern(inSessionTicketPattern string) Option {
+	return func(o *options) {
+		o.postMap["session_ticket_pattern"] = inSessionTicketPattern
+	}
+}
+
+func DefaultSessionTicketPattern() Option {
+	return func(o *options) {
+		o.postMap["session_ticket_pattern"] = nil
+	}
+}
+
+func WithSessionUploadRateLimit(inSessionUploadRateLimit uint32) Option {
+	return func(o *options) {
+		o.postMap["session_upload_rate_limit"] = inSessionUploadRateLimit
+	}
+}
+
+func DefaultSessionUploadRateLimit() Option {
+	return func(o *options) {
+		o.postMap["session_upload_rate_limit"] = nil
+	}
+}
+
 func WithSshTargetStorageBucketId(inStorageBucketId string) Option {
 	return func(o *options) {
 		raw, ok := o.postMap["attributes"]
@@ -379,6 +505,36 @@ func DefaultSshTargetStorageBucketId() Option {
 	}
 }
 
+func WithTemplateId(inTemplateId string) Option {
+	return func(o *options) {
+		o.postMap["template_id"] = inTemplateId
+	}
+}
+
+func DefaultTemplateId() Option {
+	return func(o *options) {
+		o.postMap["template_id"] = nil
+	}
+}
+
+func WithTemplateOverrides(inTemplateOverrides []string) Option {
+	return func(o *options) {
+		o.postMap["template_overrides"] = inTemplateOverrides
+	}
+}
+
+func DefaultTemplateOverrides() Option {
+	return func(o *options) {
+		o.postMap["template_overrides"] = nil
+	}
+}
+
+func WithTicketId(inTicketId string) Option {
+	return func(o *options) {
+		o.postMap["ticket_id"] = inTicketId
+	}
+}
+
 func WithWorkerFilter(inWorkerFilter string) Option {
 	return func(o *options) {
 		o.postMap["worker_filter"] = inWorkerFilter
@@ -390,3 +546,15 @@ func DefaultWorkerFilter() Option {
 		o.postMap["worker_filter"] = nil
 	}
 }
+
+func WithWorkerSelectionStrategy(inWorkerSelectionStrategy string) Option {
+	return func(o *options) {
+		o.postMap["worker_selection_strategy"] = inWorkerSelectionStrategy
+	}
+}
+
+func DefaultWorkerSelectionStrategy() Option {
+	return func(o *options) {
+		o.postMap["worker_selection_strategy"] = nil
+	}
+}
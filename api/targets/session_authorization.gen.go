@@ -26,4 +26,5 @@ type SessionAuthorization struct {
 	Expiration         time.Time            `json:"expiration,omitempty"`
 	Credentials        []*SessionCredential `json:"credentials,omitempty"`
 	SessionRecordingId string               `json:"session_recording_id,omitempty"`
+	Route              []string             `json:"route,omitempty"`
 }
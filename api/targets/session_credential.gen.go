@@ -8,4 +8,5 @@ type SessionCredential struct {
 	CredentialSource *CredentialSource      `json:"credential_source,omitempty"`
 	Secret           *SessionSecret         `json:"secret,omitempty"`
 	Credential       map[string]interface{} `json:"credential,omitempty"`
+	Lease            *CredentialLease       `json:"lease,omitempty"`
 }
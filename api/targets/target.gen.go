@@ -34,6 +34,13 @@ type Target struct {
 	WorkerFilter                           string                 `json:"worker_filter,omitempty"`
 	EgressWorkerFilter                     string                 `json:"egress_worker_filter,omitempty"`
 	IngressWorkerFilter                    string                 `json:"ingress_worker_filter,omitempty"`
+	WorkerSelectionStrategy                string                 `json:"worker_selection_strategy,omitempty"`
+	HostHealthCheck                        string                 `json:"host_health_check,omitempty"`
+	AccessWindow                           *AccessWindow          `json:"access_window,omitempty"`
+	SessionReasonRequired                  bool                   `json:"session_reason_required,omitempty"`
+	SessionTicketPattern                   string                 `json:"session_ticket_pattern,omitempty"`
+	SessionUploadRateLimit                 uint32                 `json:"session_upload_rate_limit,omitempty"`
+	SessionDownloadRateLimit               uint32                 `json:"session_download_rate_limit,omitempty"`
 	BrokeredCredentialSourceIds            []string               `json:"brokered_credential_source_ids,omitempty"`
 	BrokeredCredentialSources              []*CredentialSource    `json:"brokered_credential_sources,omitempty"`
 	InjectedApplicationCredentialSourceIds []string               `json:"injected_application_credential_source_ids,omitempty"`
@@ -43,6 +50,8 @@ type Target struct {
 	Address                                string                 `json:"address,omitempty"`
 	Aliases                                []*Alias               `json:"aliases,omitempty"`
 	WithAliases                            []*Alias               `json:"with_aliases,omitempty"`
+	TemplateId                             string                 `json:"template_id,omitempty"`
+	TemplateOverrides                      []string               `json:"template_overrides,omitempty"`
 }
 
 type TargetReadResult struct {
@@ -0,0 +1,67 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package targettemplates
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+
+	"github.com/hashicorp/boundary/api"
+)
+
+type OutOfSyncTargetListResult struct {
+	Items    []*OutOfSyncTarget
+	response *api.Response
+}
+
+func (n OutOfSyncTargetListResult) GetItems() []*OutOfSyncTarget {
+	return n.Items
+}
+
+func (n OutOfSyncTargetListResult) GetResponse() *api.Response {
+	return n.response
+}
+
+// ListOutOfSyncTargets returns the targets attached to the template whose
+// settings no longer match the template's managed settings.
+func (c *Client) ListOutOfSyncTargets(ctx context.Context, templateId string, opt ...Option) (*OutOfSyncTargetListResult, error) {
+	if templateId == "" {
+		return nil, fmt.Errorf("empty templateId value passed into ListOutOfSyncTargets request")
+	}
+	if c.client == nil {
+		return nil, fmt.Errorf("nil client")
+	}
+
+	opts, apiOpts := getOpts(opt...)
+
+	req, err := c.client.NewRequest(ctx, "GET", "target-templates/"+url.PathEscape(templateId)+":list-out-of-sync-targets", nil, apiOpts...)
+	if err != nil {
+		return nil, fmt.Errorf("error creating ListOutOfSyncTargets request: %w", err)
+	}
+
+	if len(opts.queryMap) > 0 {
+		q := url.Values{}
+		for k, v := range opts.queryMap {
+			q.Add(k, v)
+		}
+		req.URL.RawQuery = q.Encode()
+	}
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("error performing client request during ListOutOfSyncTargets call: %w", err)
+	}
+
+	target := new(OutOfSyncTargetListResult)
+	apiErr, err := resp.Decode(target)
+	if err != nil {
+		return nil, fmt.Errorf("error decoding ListOutOfSyncTargets response: %w", err)
+	}
+	if apiErr != nil {
+		return nil, apiErr
+	}
+	target.response = resp
+	return target, nil
+}
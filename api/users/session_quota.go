@@ -0,0 +1,70 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package users
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+
+	"github.com/hashicorp/boundary/api"
+)
+
+type SessionQuotaReadResult struct {
+	Item     *SessionQuota
+	Response *api.Response
+}
+
+func (n SessionQuotaReadResult) GetItem() *SessionQuota {
+	return n.Item
+}
+
+func (n SessionQuotaReadResult) GetResponse() *api.Response {
+	return n.Response
+}
+
+// ReadSessionQuota builds and sends a request to the API for the session quota
+// of the user with userId in the project with scopeId, along with the number of
+// sessions counted against it.
+func (c *Client) ReadSessionQuota(ctx context.Context, userId, scopeId string, opt ...Option) (*SessionQuotaReadResult, error) {
+	if userId == "" {
+		return nil, fmt.Errorf("empty userId value passed into ReadSessionQuota request")
+	}
+	if scopeId == "" {
+		return nil, fmt.Errorf("empty scopeId value passed into ReadSessionQuota request")
+	}
+	if c.client == nil {
+		return nil, fmt.Errorf("nil client")
+	}
+
+	opts, apiOpts := getOpts(opt...)
+	req, err := c.client.NewRequest(ctx, "GET", fmt.Sprintf("users/%s:read-session-quota", url.PathEscape(userId)), nil, apiOpts...)
+	if err != nil {
+		return nil, fmt.Errorf("error creating ReadSessionQuota request: %w", err)
+	}
+
+	opts.queryMap["scope_id"] = scopeId
+	q := url.Values{}
+	for k, v := range opts.queryMap {
+		q.Add(k, v)
+	}
+	req.URL.RawQuery = q.Encode()
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("error performing client request during ReadSessionQuota call: %w", err)
+	}
+
+	target := new(SessionQuotaReadResult)
+	target.Item = new(SessionQuota)
+	apiErr, err := resp.Decode(target.Item)
+	if err != nil {
+		return nil, fmt.Errorf("error decoding ReadSessionQuota response: %w", err)
+	}
+	if apiErr != nil {
+		return nil, apiErr
+	}
+	target.Response = resp
+	return target, nil
+}
@@ -0,0 +1,68 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package workers
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/boundary/api"
+)
+
+type AttestResult struct {
+	WorkerId string `json:"worker_id,omitempty"`
+
+	Response *api.Response
+}
+
+func (n AttestResult) GetResponse() *api.Response {
+	return n.Response
+}
+
+// Attest registers a worker with the controller by presenting an identity
+// document trusted by one of the controller's worker attestation policies.
+// The request does not need to be authenticated.
+func (c *Client) Attest(ctx context.Context, workerGeneratedAuthToken, attestationType, attestationDocument string, opt ...Option) (*AttestResult, error) {
+	switch {
+	case workerGeneratedAuthToken == "":
+		return nil, fmt.Errorf("empty workerGeneratedAuthToken value passed into Attest request")
+	case attestationType == "":
+		return nil, fmt.Errorf("empty attestationType value passed into Attest request")
+	case attestationDocument == "":
+		return nil, fmt.Errorf("empty attestationDocument value passed into Attest request")
+	}
+
+	_, apiOpts := getOpts(opt...)
+
+	if c.client == nil {
+		return nil, fmt.Errorf("nil client")
+	}
+
+	body := map[string]any{
+		"worker_generated_auth_token": workerGeneratedAuthToken,
+		"attestation_type":            attestationType,
+		"attestation_document":        attestationDocument,
+	}
+
+	req, err := c.client.NewRequest(ctx, "POST", "workers:attest", body, apiOpts...)
+	if err != nil {
+		return nil, fmt.Errorf("error creating Attest request: %w", err)
+	}
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("error performing client request during Attest call: %w", err)
+	}
+
+	target := new(AttestResult)
+	apiErr, err := resp.Decode(target)
+	if err != nil {
+		return nil, fmt.Errorf("error decoding Attest response: %w", err)
+	}
+	if apiErr != nil {
+		return nil, apiErr
+	}
+	target.Response = resp
+	return target, nil
+}
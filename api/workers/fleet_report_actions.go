@@ -0,0 +1,56 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package workers
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+)
+
+// ReadFleetReport returns a summary of the workers by version and
+// configuration tag, their status and the workers too old for the features
+// targets may use.
+func (c *Client) ReadFleetReport(ctx context.Context, scopeId string, opt ...Option) (*FleetReportReadResult, error) {
+	if scopeId == "" {
+		return nil, fmt.Errorf("empty scopeId value passed into ReadFleetReport request")
+	}
+
+	opts, apiOpts := getOpts(opt...)
+	opts.queryMap["scope_id"] = scopeId
+
+	if c.client == nil {
+		return nil, fmt.Errorf("nil client")
+	}
+
+	req, err := c.client.NewRequest(ctx, "GET", "workers:read-fleet-report", nil, apiOpts...)
+	if err != nil {
+		return nil, fmt.Errorf("error creating ReadFleetReport request: %w", err)
+	}
+
+	if len(opts.queryMap) > 0 {
+		q := url.Values{}
+		for k, v := range opts.queryMap {
+			q.Add(k, v)
+		}
+		req.URL.RawQuery = q.Encode()
+	}
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("error performing client request during ReadFleetReport call: %w", err)
+	}
+
+	target := new(FleetReportReadResult)
+	target.Item = new(FleetReport)
+	apiErr, err := resp.Decode(target.Item)
+	if err != nil {
+		return nil, fmt.Errorf("error decoding ReadFleetReport response: %w", err)
+	}
+	if apiErr != nil {
+		return nil, apiErr
+	}
+	target.Response = resp
+	return target, nil
+}
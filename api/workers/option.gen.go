@@ -115,6 +115,30 @@ func DefaultDescription() Option {
 	}
 }
 
+func WithDrain(inDrain bool) Option {
+	return func(o *options) {
+		o.postMap["drain"] = inDrain
+	}
+}
+
+func DefaultDrain() Option {
+	return func(o *options) {
+		o.postMap["drain"] = nil
+	}
+}
+
+func WithDrainTimeoutSeconds(inDrainTimeoutSeconds uint32) Option {
+	return func(o *options) {
+		o.postMap["drain_timeout_seconds"] = inDrainTimeoutSeconds
+	}
+}
+
+func DefaultDrainTimeoutSeconds() Option {
+	return func(o *options) {
+		o.postMap["drain_timeout_seconds"] = nil
+	}
+}
+
 func WithName(inName string) Option {
 	return func(o *options) {
 		o.postMap["name"] = inName
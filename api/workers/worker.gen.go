@@ -39,6 +39,10 @@ type Worker struct {
 	DirectlyConnectedDownstreamWorkers []string            `json:"directly_connected_downstream_workers,omitempty"`
 	AuthorizedActions                  []string            `json:"authorized_actions,omitempty"`
 	LocalStorageState                  string              `json:"local_storage_state,omitempty"`
+	Drain                              bool                `json:"drain,omitempty"`
+	DrainTimeoutSeconds                uint32              `json:"drain_timeout_seconds,omitempty"`
+	DrainDeadline                      time.Time           `json:"drain_deadline,omitempty"`
+	OperationalState                   string              `json:"operational_state,omitempty"`
 }
 
 type WorkerReadResult struct {
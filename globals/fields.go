@@ -35,6 +35,11 @@ const (
 	GrantsField                                 = "grants"
 	GrantStringsField                           = "grant_strings"
 	PrimaryAuthMethodIdField                    = "primary_auth_method_id"
+	MaxActiveSessionsPerUserField               = "max_active_sessions_per_user"
+	MaxDailySessionsPerUserField                = "max_daily_sessions_per_user"
+	KeyRotationIntervalSecondsField             = "key_rotation_interval_seconds"
+	KeyRotationRewrapField                      = "key_rotation_rewrap"
+	KeyRotationDestroyOldVersionsField          = "key_rotation_destroy_old_versions"
 	TargetIdField                               = "target_id"
 	HostIdField                                 = "host_id"
 	HostSetIdField                              = "host_set_id"
@@ -51,6 +56,18 @@ const (
 	WorkerFilterField                           = "worker_filter"
 	EgressWorkerFilterField                     = "egress_worker_filter"
 	IngressWorkerFilterField                    = "ingress_worker_filter"
+	WorkerSelectionStrategyField                = "worker_selection_strategy"
+	HostHealthCheckField                        = "host_health_check"
+	AccessWindowField                           = "access_window"
+	SessionReasonRequiredField                  = "session_reason_required"
+	SessionTicketPatternField                   = "session_ticket_pattern"
+	SessionUploadRateLimitField                 = "session_upload_rate_limit"
+	SessionDownloadRateLimitField               = "session_download_rate_limit"
+	ReasonField                                 = "reason"
+	TicketIdField                               = "ticket_id"
+	CancelReasonField                           = "cancel_reason"
+	TemplateIdField                             = "template_id"
+	TemplateOverridesField                      = "template_overrides"
 	AccountIdsField                             = "account_ids"
 	AccountsField                               = "accounts"
 	LoginNameField                              = "login_name"
@@ -91,6 +108,8 @@ const (
 	ConfigurationField                          = "configuration"
 	WorkerGeneratedAuthTokenField               = "worker_generated_auth_token"
 	WorkerProvidedConfigurationField            = "worker_provided_configuration"
+	AttestationTypeField                        = "attestation_type"
+	AttestationDocumentField                    = "attestation_document"
 	ActiveConnectionCountField                  = "active_connection_count"
 	ControllerGeneratedActivationToken          = "controller_generated_activation_token"
 	ReleaseVersionField                         = "release_version"
@@ -123,4 +142,8 @@ const (
 	ValueField                                  = "value"
 	WithAliasesField                            = "with_aliases"
 	LocalStorageStateField                      = "local_storage_state"
+	DrainField                                  = "drain"
+	DrainTimeoutSecondsField                    = "drain_timeout_seconds"
+	DrainDeadlineField                          = "drain_deadline"
+	OperationalStateField                       = "operational_state"
 )
@@ -64,4 +64,10 @@ var (
 	// WorkerAuthNonceValidityPeriod is exported so we can modify it in tests if
 	// we want
 	WorkerAuthNonceValidityPeriod = 2 * time.Minute
+
+	// WorkerAttestationNonceValidityPeriod is how long the ids of the identity
+	// documents workers registered themselves with are kept to refuse their
+	// reuse. Documents expiring further in the future are not accepted. It is
+	// exported so we can modify it in tests if we want
+	WorkerAttestationNonceValidityPeriod = 24 * time.Hour
 )
@@ -69,6 +69,12 @@ const (
 	// VaultSshCertificateCredentialLibraryPrefix is the prefix for Vault SSH
 	// certificate credential libraries
 	VaultSshCertificateCredentialLibraryPrefix = "clvsclt"
+	// VaultDatabaseCredentialLibraryPrefix is the prefix for Vault database
+	// credential libraries
+	VaultDatabaseCredentialLibraryPrefix = "clvdb"
+	// VaultPkiCredentialLibraryPrefix is the prefix for Vault PKI credential
+	// libraries
+	VaultPkiCredentialLibraryPrefix = "clvpki"
 	// DynamicCredentialPrefix is the prefix for Vault dynamic credentials
 	VaultDynamicCredentialPrefix = "cdvlt"
 
@@ -82,6 +88,9 @@ const (
 	SshPrivateKeyCredentialPrefix = "credspk"
 	// JsonCredentialPrefix is the prefix for generic JSON creds
 	JsonCredentialPrefix = "credjson"
+	// TlsClientCertificateCredentialPrefix is the prefix for TLS client
+	// certificate creds
+	TlsClientCertificateCredentialPrefix = "credtls"
 
 	// StaticHostCatalogPrefix is the prefix for static host catalogs
 	StaticHostCatalogPrefix = "hcst"
@@ -111,6 +120,10 @@ const (
 	TcpTargetPrefix = "ttcp"
 	// SshTargetPrefix is the prefix for TCP targets
 	SshTargetPrefix = "tssh"
+	// UdpTargetPrefix is the prefix for UDP targets
+	UdpTargetPrefix = "tudp"
+	// TargetTemplatePrefix is the prefix for target templates
+	TargetTemplatePrefix = "ttpl"
 
 	// WorkerPrefix is the prefix for workers
 	WorkerPrefix = "w"
@@ -235,6 +248,14 @@ var prefixToResourceType = map[string]ResourceInfo{
 		Type:    resource.CredentialLibrary,
 		Subtype: UnknownSubtype,
 	},
+	VaultDatabaseCredentialLibraryPrefix: {
+		Type:    resource.CredentialLibrary,
+		Subtype: UnknownSubtype,
+	},
+	VaultPkiCredentialLibraryPrefix: {
+		Type:    resource.CredentialLibrary,
+		Subtype: UnknownSubtype,
+	},
 	VaultDynamicCredentialPrefix: {
 		Type:    resource.Credential,
 		Subtype: UnknownSubtype,
@@ -256,6 +277,10 @@ var prefixToResourceType = map[string]ResourceInfo{
 		Type:    resource.Credential,
 		Subtype: UnknownSubtype,
 	},
+	TlsClientCertificateCredentialPrefix: {
+		Type:    resource.Credential,
+		Subtype: UnknownSubtype,
+	},
 
 	StaticHostCatalogPrefix: {
 		Type:    resource.HostCatalog,
@@ -308,6 +333,14 @@ var prefixToResourceType = map[string]ResourceInfo{
 		Type:    resource.Target,
 		Subtype: UnknownSubtype,
 	},
+	UdpTargetPrefix: {
+		Type:    resource.Target,
+		Subtype: UnknownSubtype,
+	},
+	TargetTemplatePrefix: {
+		Type:    resource.TargetTemplate,
+		Subtype: UnknownSubtype,
+	},
 
 	WorkerPrefix: {
 		Type:    resource.Worker,
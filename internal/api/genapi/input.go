@@ -28,6 +28,7 @@ import (
 	"github.com/hashicorp/boundary/sdk/pbs/controller/api/resources/sessions"
 	"github.com/hashicorp/boundary/sdk/pbs/controller/api/resources/storagebuckets"
 	"github.com/hashicorp/boundary/sdk/pbs/controller/api/resources/targets"
+	"github.com/hashicorp/boundary/sdk/pbs/controller/api/resources/targettemplates"
 	"github.com/hashicorp/boundary/sdk/pbs/controller/api/resources/users"
 	"github.com/hashicorp/boundary/sdk/pbs/controller/api/resources/workers"
 	"google.golang.org/protobuf/proto"
@@ -199,6 +200,16 @@ var inputStructs = []*structInfo{
 			{Name: "TotalCount", JsonTags: []string{"string"}},
 		},
 	},
+	{
+		inProto:     &scopes.OplogEntry{},
+		outFile:     "scopes/oplog_entry.gen.go",
+		skipOptions: true,
+	},
+	{
+		inProto:     &scopes.OplogOperation{},
+		outFile:     "scopes/oplog_operation.gen.go",
+		skipOptions: true,
+	},
 	{
 		inProto: &scopes.Scope{},
 		outFile: "scopes/scope.gen.go",
@@ -268,6 +279,11 @@ var inputStructs = []*structInfo{
 		outFile:     "users/account.gen.go",
 		skipOptions: true,
 	},
+	{
+		inProto:     &users.SessionQuota{},
+		outFile:     "users/session_quota.gen.go",
+		skipOptions: true,
+	},
 	{
 		inProto: &users.User{},
 		outFile: "users/user.gen.go",
@@ -637,6 +653,50 @@ var inputStructs = []*structInfo{
 			mapstructureConversionTemplate,
 		},
 	},
+	{
+		inProto:     &credentiallibraries.VaultDatabaseCredentialLibraryAttributes{},
+		outFile:     "credentiallibraries/vault_database_credential_library_attributes.gen.go",
+		subtypeName: "VaultDatabaseCredentialLibrary",
+		subtype:     "vault-database",
+		fieldOverrides: []fieldInfo{
+			{
+				Name:        "MountPath",
+				SkipDefault: true,
+			},
+			{
+				Name:        "Role",
+				SkipDefault: true,
+			},
+		},
+		parentTypeName: "CredentialLibrary",
+		templates: []*template.Template{
+			mapstructureConversionTemplate,
+		},
+	},
+	{
+		inProto:     &credentiallibraries.VaultPkiCredentialLibraryAttributes{},
+		outFile:     "credentiallibraries/vault_pki_credential_library_attributes.gen.go",
+		subtypeName: "VaultPkiCredentialLibrary",
+		subtype:     "vault-pki",
+		fieldOverrides: []fieldInfo{
+			{
+				Name:        "MountPath",
+				SkipDefault: true,
+			},
+			{
+				Name:        "Role",
+				SkipDefault: true,
+			},
+			{
+				Name:        "CommonName",
+				SkipDefault: true,
+			},
+		},
+		parentTypeName: "CredentialLibrary",
+		templates: []*template.Template{
+			mapstructureConversionTemplate,
+		},
+	},
 	{
 		inProto: &credentiallibraries.CredentialLibrary{},
 		outFile: "credentiallibraries/credential_library.gen.go",
@@ -727,6 +787,30 @@ var inputStructs = []*structInfo{
 			mapstructureConversionTemplate,
 		},
 	},
+	{
+		inProto:     &credentials.TlsClientCertificateAttributes{},
+		outFile:     "credentials/tls_client_certificate_attributes.gen.go",
+		subtypeName: "TlsClientCertificateCredential",
+		subtype:     "tls_client_certificate",
+		fieldOverrides: []fieldInfo{
+			{
+				Name:        "Certificate",
+				SkipDefault: true,
+			},
+			{
+				Name:        "PrivateKey",
+				SkipDefault: true,
+			},
+		},
+		parentTypeName: "Credential",
+		templates: []*template.Template{
+			mapstructureConversionTemplate,
+		},
+	},
+	{
+		inProto: &credentials.CredentialUsage{},
+		outFile: "credentials/credential_usage.gen.go",
+	},
 	{
 		inProto: &credentials.Credential{},
 		outFile: "credentials/credential.gen.go",
@@ -1006,6 +1090,10 @@ var inputStructs = []*structInfo{
 		inProto: &targets.CredentialSource{},
 		outFile: "targets/credential_source.gen.go",
 	},
+	{
+		inProto: &targets.AccessWindow{},
+		outFile: "targets/access_window.gen.go",
+	},
 	{
 		inProto: &targets.Alias{},
 		outFile: "targets/alias.gen.go",
@@ -1028,6 +1116,10 @@ var inputStructs = []*structInfo{
 			},
 		},
 	},
+	{
+		inProto: &targets.CredentialLease{},
+		outFile: "targets/credential_lease.gen.go",
+	},
 	{
 		inProto: &targets.SessionCredential{},
 		outFile: "targets/session_credential.gen.go",
@@ -1056,6 +1148,15 @@ var inputStructs = []*structInfo{
 			mapstructureConversionTemplate,
 		},
 	},
+	{
+		inProto:        &targets.UdpTargetAttributes{},
+		outFile:        "targets/udp_target_attributes.gen.go",
+		subtypeName:    "UdpTarget",
+		parentTypeName: "Target",
+		templates: []*template.Template{
+			mapstructureConversionTemplate,
+		},
+	},
 	{
 		inProto:        &targets.SshTargetAttributes{},
 		outFile:        "targets/ssh_target_attributes.gen.go",
@@ -1128,6 +1229,18 @@ var inputStructs = []*structInfo{
 				FieldType:   "string",
 				SkipDefault: true,
 			},
+			{
+				Name:        "Reason",
+				ProtoName:   "reason",
+				FieldType:   "string",
+				SkipDefault: true,
+			},
+			{
+				Name:        "TicketId",
+				ProtoName:   "ticket_id",
+				FieldType:   "string",
+				SkipDefault: true,
+			},
 			{
 				Name:      "BrokeredCredentialSourceIds",
 				ProtoName: "brokered_credential_source_ids",
@@ -1150,6 +1263,31 @@ var inputStructs = []*structInfo{
 		createResponseTypes: []string{CreateResponseType, ReadResponseType, UpdateResponseType, DeleteResponseType, ListResponseType},
 		recursiveListing:    true,
 	},
+	{
+		inProto: &targettemplates.OutOfSyncTarget{},
+		outFile: "targettemplates/out_of_sync_target.gen.go",
+	},
+	{
+		inProto: &targettemplates.TargetTemplate{},
+		outFile: "targettemplates/target_template.gen.go",
+		templates: []*template.Template{
+			clientTemplate,
+			commonCreateTemplate,
+			readTemplate,
+			updateTemplate,
+			deleteTemplate,
+			listTemplate,
+		},
+		pluralResourceName:  "target-templates",
+		versionEnabled:      true,
+		createResponseTypes: []string{CreateResponseType, ReadResponseType, UpdateResponseType, DeleteResponseType, ListResponseType},
+		recursiveListing:    true,
+	},
+	{
+		inProto:     &sessions.ShadowAuthorization{},
+		outFile:     "sessions/shadow_authorization.gen.go",
+		skipOptions: true,
+	},
 	{
 		inProto: &sessions.SessionState{},
 		outFile: "sessions/state.gen.go",
@@ -1180,6 +1318,30 @@ var inputStructs = []*structInfo{
 				FieldType: "bool",
 				Query:     true,
 			},
+			{
+				Name:        "Reason",
+				ProtoName:   "reason",
+				FieldType:   "string",
+				SkipDefault: true,
+			},
+			{
+				Name:        "UserId",
+				ProtoName:   "user_id",
+				FieldType:   "string",
+				SkipDefault: true,
+			},
+			{
+				Name:        "TargetId",
+				ProtoName:   "target_id",
+				FieldType:   "string",
+				SkipDefault: true,
+			},
+			{
+				Name:        "WorkerId",
+				ProtoName:   "worker_id",
+				FieldType:   "string",
+				SkipDefault: true,
+			},
 		},
 		pluralResourceName:  "sessions",
 		createResponseTypes: []string{CreateResponseType, ReadResponseType, UpdateResponseType, DeleteResponseType, ListResponseType},
@@ -1357,6 +1519,40 @@ var inputStructs = []*structInfo{
 		outFile:             "workers/certificate_authority.gen.go",
 		createResponseTypes: []string{ReadResponseType},
 	},
+	{
+		inProto: &workers.FleetVersion{},
+		outFile: "workers/fleet_version.gen.go",
+	},
+	{
+		inProto: &workers.FleetTag{},
+		outFile: "workers/fleet_tag.gen.go",
+	},
+	{
+		inProto: &workers.FleetWorker{},
+		outFile: "workers/fleet_worker.gen.go",
+	},
+	{
+		inProto: &workers.FleetFeature{},
+		outFile: "workers/fleet_feature.gen.go",
+	},
+	{
+		inProto:             &workers.FleetReport{},
+		outFile:             "workers/fleet_report.gen.go",
+		createResponseTypes: []string{ReadResponseType},
+	},
+	{
+		inProto: &workers.TopologyNode{},
+		outFile: "workers/topology_node.gen.go",
+	},
+	{
+		inProto: &workers.TopologyEdge{},
+		outFile: "workers/topology_edge.gen.go",
+	},
+	{
+		inProto:             &workers.Topology{},
+		outFile:             "workers/topology.gen.go",
+		createResponseTypes: []string{ReadResponseType},
+	},
 	{
 		inProto: &workers.Worker{},
 		outFile: "workers/worker.gen.go",
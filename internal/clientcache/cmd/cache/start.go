@@ -8,6 +8,7 @@ import (
 	stderrors "errors"
 	"fmt"
 	"io"
+	"net/url"
 	"os"
 	"os/exec"
 	"path/filepath"
@@ -15,6 +16,7 @@ import (
 	"sync"
 	"time"
 
+	"github.com/hashicorp/boundary/internal/clientcache/internal/agent"
 	"github.com/hashicorp/boundary/internal/clientcache/internal/daemon"
 	"github.com/hashicorp/boundary/internal/cmd/base"
 	"github.com/hashicorp/boundary/internal/errors"
@@ -51,6 +53,8 @@ type StartCommand struct {
 	flagLogFormat               string
 	flagStoreDebug              bool
 	flagBackground              bool
+	flagClientAgent             bool
+	flagClientAgentDnsAddr      string
 }
 
 func (c *StartCommand) Synopsis() string {
@@ -134,6 +138,18 @@ func (c *StartCommand) Flags() *base.FlagSets {
 		Default: false,
 		Usage:   `Run the cache daemon in the background`,
 	})
+	f.BoolVar(&base.BoolVar{
+		Name:    "client-agent",
+		Target:  &c.flagClientAgent,
+		Default: false,
+		Usage:   `Run the client agent alongside the cache. The client agent answers DNS queries for resolvable aliases and transparently authorizes and proxies sessions for connections made to the addresses it answers with. Only supported on Linux.`,
+	})
+	f.StringVar(&base.StringVar{
+		Name:    "client-agent-dns-address",
+		Target:  &c.flagClientAgentDnsAddr,
+		Default: agent.DefaultDnsListenAddr,
+		Usage:   `The address the client agent answers DNS queries on. The system resolver needs to be configured to send queries for alias values to this address.`,
+	})
 
 	return set
 }
@@ -196,18 +212,20 @@ func (c *StartCommand) Run(args []string) int {
 	}
 
 	cfg := &daemon.Config{
-		ContextCancel:           cancel,
-		RefreshInterval:         c.flagRefreshInterval,
-		RecheckSupportInterval:  c.flagRecheckSupportInterval,
-		MaxSearchStaleness:      c.flagMaxSearchStaleness,
-		MaxSearchRefreshTimeout: c.flagMaxSearchRefreshTimeout,
-		DatabaseUrl:             c.flagDatabaseUrl,
-		LogLevel:                c.flagLogLevel,
-		LogFormat:               c.flagLogFormat,
-		LogWriter:               io.MultiWriter(writers...),
-		LogFileName:             logFileName,
-		DotDirectory:            dotDir,
-		RunningInBackground:     os.Getenv(backgroundEnvName) == backgroundEnvVal,
+		ContextCancel:            cancel,
+		RefreshInterval:          c.flagRefreshInterval,
+		RecheckSupportInterval:   c.flagRecheckSupportInterval,
+		MaxSearchStaleness:       c.flagMaxSearchStaleness,
+		MaxSearchRefreshTimeout:  c.flagMaxSearchRefreshTimeout,
+		DatabaseUrl:              c.flagDatabaseUrl,
+		LogLevel:                 c.flagLogLevel,
+		LogFormat:                c.flagLogFormat,
+		LogWriter:                io.MultiWriter(writers...),
+		LogFileName:              logFileName,
+		DotDirectory:             dotDir,
+		RunningInBackground:      os.Getenv(backgroundEnvName) == backgroundEnvVal,
+		EnableClientAgent:        c.flagClientAgent,
+		ClientAgentDnsListenAddr: c.flagClientAgentDnsAddr,
 	}
 
 	srv, err := daemon.New(ctx, cfg)
@@ -250,6 +268,13 @@ func DefaultDotDirectory(ctx context.Context) (string, error) {
 	return filepath.Join(homeDir, dotDirname), nil
 }
 
+// ClientAgentSocketAddress returns the address of the unix socket the client
+// agent run alongside the cache serves its API on, given the boundary dot
+// directory.
+func ClientAgentSocketAddress(dotPath string) *url.URL {
+	return agent.SocketAddress(dotPath)
+}
+
 // logFile returns a log file which is rotated after it reaches the provided
 // maximum size in mb before being rotated out.  The rotated out log file gets
 // a suffix that matches the time that the rotation happened. Up to 3 log files
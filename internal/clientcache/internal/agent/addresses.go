@@ -0,0 +1,57 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package agent
+
+import (
+	"context"
+	"net/netip"
+	"sync"
+
+	"github.com/hashicorp/boundary/internal/errors"
+)
+
+// addressPool allocates a loopback address from a prefix for each alias value
+// the agent resolves. A value keeps its address for as long as the agent runs
+// so clients caching the DNS answer keep reaching the same alias.
+type addressPool struct {
+	mu      sync.Mutex
+	prefix  netip.Prefix
+	next    netip.Addr
+	byValue map[string]netip.Addr
+}
+
+func newAddressPool(ctx context.Context, prefix netip.Prefix) (*addressPool, error) {
+	const op = "agent.newAddressPool"
+	switch {
+	case !prefix.IsValid():
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "address prefix is invalid")
+	case !prefix.Addr().Is4() || !prefix.Addr().IsLoopback():
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "address prefix must be an ipv4 loopback prefix")
+	}
+	prefix = prefix.Masked()
+	return &addressPool{
+		prefix: prefix,
+		// Skip the network address of the prefix
+		next:    prefix.Addr().Next(),
+		byValue: make(map[string]netip.Addr),
+	}, nil
+}
+
+// addressFor returns the address allocated to value, allocating one if value
+// does not have one yet.
+func (p *addressPool) addressFor(ctx context.Context, value string) (netip.Addr, error) {
+	const op = "agent.(addressPool).addressFor"
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if addr, ok := p.byValue[value]; ok {
+		return addr, nil
+	}
+	if !p.next.IsValid() || !p.prefix.Contains(p.next) {
+		return netip.Addr{}, errors.New(ctx, errors.Conflict, op, "no loopback addresses left to allocate")
+	}
+	addr := p.next
+	p.next = p.next.Next()
+	p.byValue[value] = addr
+	return addr, nil
+}
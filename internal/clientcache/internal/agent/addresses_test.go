@@ -0,0 +1,78 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package agent
+
+import (
+	"context"
+	"net/netip"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewAddressPool(t *testing.T) {
+	ctx := context.Background()
+	cases := []struct {
+		name          string
+		prefix        netip.Prefix
+		errorContains string
+	}{
+		{
+			name:   "valid",
+			prefix: netip.MustParsePrefix("127.100.0.0/16"),
+		},
+		{
+			name:          "invalid",
+			errorContains: "address prefix is invalid",
+		},
+		{
+			name:          "not loopback",
+			prefix:        netip.MustParsePrefix("10.0.0.0/8"),
+			errorContains: "must be an ipv4 loopback prefix",
+		},
+		{
+			name:          "ipv6",
+			prefix:        netip.MustParsePrefix("::1/128"),
+			errorContains: "must be an ipv4 loopback prefix",
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			p, err := newAddressPool(ctx, tc.prefix)
+			if tc.errorContains != "" {
+				assert.ErrorContains(t, err, tc.errorContains)
+				assert.Nil(t, p)
+				return
+			}
+			assert.NoError(t, err)
+			assert.NotNil(t, p)
+		})
+	}
+}
+
+func TestAddressPool_AddressFor(t *testing.T) {
+	ctx := context.Background()
+	p, err := newAddressPool(ctx, netip.MustParsePrefix("127.100.0.7/30"))
+	require.NoError(t, err)
+
+	first, err := p.addressFor(ctx, "first")
+	require.NoError(t, err)
+	assert.Equal(t, netip.MustParseAddr("127.100.0.5"), first)
+
+	second, err := p.addressFor(ctx, "second")
+	require.NoError(t, err)
+	assert.Equal(t, netip.MustParseAddr("127.100.0.6"), second)
+
+	again, err := p.addressFor(ctx, "first")
+	require.NoError(t, err)
+	assert.Equal(t, first, again)
+
+	third, err := p.addressFor(ctx, "third")
+	require.NoError(t, err)
+	assert.Equal(t, netip.MustParseAddr("127.100.0.7"), third)
+
+	_, err = p.addressFor(ctx, "fourth")
+	assert.ErrorContains(t, err, "no loopback addresses left")
+}
@@ -0,0 +1,340 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package agent
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"net/netip"
+	"sort"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/hashicorp/boundary/api"
+	"github.com/hashicorp/boundary/api/targets"
+	"github.com/hashicorp/boundary/internal/clientcache/internal/cache"
+	"github.com/hashicorp/boundary/internal/cmd/base"
+	"github.com/hashicorp/boundary/internal/errors"
+	"github.com/hashicorp/boundary/internal/event"
+	"github.com/hashicorp/boundary/internal/util"
+	"github.com/miekg/dns"
+)
+
+const (
+	// DefaultDnsListenAddr is the default address the agent answers DNS
+	// queries on. The system resolver needs to be configured to send queries
+	// for alias values to it. Port 5353 is avoided since it is taken by mDNS
+	// responders.
+	DefaultDnsListenAddr = "127.0.0.1:8053"
+
+	// maxRecentErrors is the number of errors reported in the agent status.
+	maxRecentErrors = 10
+)
+
+// DefaultAddressPrefix is the default prefix loopback addresses are
+// allocated to aliases from.
+var DefaultAddressPrefix = netip.MustParsePrefix("127.100.0.0/16")
+
+// Repository is the part of the cache repository used by the agent.
+type Repository interface {
+	ResolveAlias(ctx context.Context, value string) (*cache.ResolvedAlias, error)
+	AddRawToken(ctx context.Context, bAddr string, rawToken string) error
+	LookupToken(ctx context.Context, authTokenId string, opt ...cache.Option) (*cache.AuthToken, error)
+}
+
+// ClientProvider is an interface that provides an api.Client
+type ClientProvider interface {
+	Client(opt ...base.Option) (*api.Client, error)
+}
+
+// Config is the configuration of the client agent.
+type Config struct {
+	Repository     Repository
+	ClientProvider ClientProvider
+	// Refresh is called when a new auth token is added through the agent
+	// so the cache picks up the aliases of its user.
+	Refresh func()
+	// DotDirectory is the directory the unix socket the agent serves its API
+	// on is created in.
+	DotDirectory string
+	// DnsListenAddr is the address the agent answers DNS queries on.
+	// Defaults to DefaultDnsListenAddr.
+	DnsListenAddr string
+	// AddressPrefix is the loopback prefix addresses are allocated to
+	// aliases from. Defaults to DefaultAddressPrefix.
+	AddressPrefix netip.Prefix
+}
+
+func (c *Config) validate(ctx context.Context) error {
+	const op = "agent.(Config).validate"
+	switch {
+	case util.IsNil(c.Repository):
+		return errors.New(ctx, errors.InvalidParameter, op, "missing repository")
+	case util.IsNil(c.ClientProvider):
+		return errors.New(ctx, errors.InvalidParameter, op, "missing client provider")
+	case c.Refresh == nil:
+		return errors.New(ctx, errors.InvalidParameter, op, "missing refresh function")
+	case c.DotDirectory == "":
+		return errors.New(ctx, errors.InvalidParameter, op, "missing dot directory")
+	}
+	return nil
+}
+
+// Session is a transparent session the agent has authorized for an alias.
+type Session struct {
+	Alias                string                        `json:"alias"`
+	SessionAuthorization *targets.SessionAuthorization `json:"session_authorization"`
+}
+
+// authTokenInfo is the auth token most recently added to the agent.
+type authTokenInfo struct {
+	boundaryAddr string
+	id           string
+	expiry       time.Time
+}
+
+// Agent is the client agent. It answers DNS queries for resolvable aliases
+// and transparently authorizes and proxies sessions for connections made to
+// the addresses it answers with.
+type Agent struct {
+	conf  *Config
+	addrs *addressPool
+
+	ctx     context.Context
+	paused  atomic.Bool
+	lastTok atomic.Pointer[authTokenInfo]
+
+	mu           sync.Mutex
+	listeners    map[netip.AddrPort]*transparentListener
+	sessions     map[string]*Session
+	recentErrors []string
+}
+
+// New returns a client agent using the provided configuration.
+func New(ctx context.Context, conf *Config) (*Agent, error) {
+	const op = "agent.New"
+	if conf == nil {
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "missing config")
+	}
+	if err := conf.validate(ctx); err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	c := *conf
+	if c.DnsListenAddr == "" {
+		c.DnsListenAddr = DefaultDnsListenAddr
+	}
+	if !c.AddressPrefix.IsValid() {
+		c.AddressPrefix = DefaultAddressPrefix
+	}
+	addrs, err := newAddressPool(ctx, c.AddressPrefix)
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	return &Agent{
+		conf:      &c,
+		addrs:     addrs,
+		ctx:       ctx,
+		listeners: make(map[netip.AddrPort]*transparentListener),
+		sessions:  make(map[string]*Session),
+	}, nil
+}
+
+// Serve answers DNS queries and serves the agent API until the provided
+// context is done. Sessions that are running when it returns are left to end
+// on their own.
+func (a *Agent) Serve(ctx context.Context) error {
+	const op = "agent.(Agent).Serve"
+	a.ctx = ctx
+
+	pc, err := net.ListenPacket("udp", a.conf.DnsListenAddr)
+	if err != nil {
+		return errors.Wrap(ctx, err, op, errors.WithMsg("unable to listen for dns queries"))
+	}
+	dnsSrv := &dns.Server{
+		PacketConn: pc,
+		Handler:    dns.HandlerFunc(a.serveDns),
+	}
+	l, err := listener(ctx, a.conf.DotDirectory)
+	if err != nil {
+		_ = pc.Close()
+		return errors.Wrap(ctx, err, op, errors.WithMsg("unable to listen for agent api requests"))
+	}
+	mux := http.NewServeMux()
+	mux.HandleFunc("/v1/status", a.statusHandler)
+	mux.HandleFunc("/v1/pause", a.pauseHandler)
+	mux.HandleFunc("/v1/resume", a.resumeHandler)
+	mux.HandleFunc("/v1/sessions", a.sessionsHandler)
+	mux.HandleFunc("/v1/tokens", a.tokenHandler)
+	httpSrv := &http.Server{
+		Handler: mux,
+		BaseContext: func(net.Listener) context.Context {
+			return ctx
+		},
+	}
+	event.WriteSysEvent(ctx, op, "client agent started", "socket_address", SocketAddress(a.conf.DotDirectory).String(), "dns_listening_address", pc.LocalAddr().String())
+
+	var wg sync.WaitGroup
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		if err := dnsSrv.ActivateAndServe(); err != nil {
+			event.WriteError(ctx, op, err, event.WithInfoMsg("error serving dns"))
+		}
+	}()
+	go func() {
+		defer wg.Done()
+		if err := httpSrv.Serve(l); err != nil && err != http.ErrServerClosed && !errors.Is(err, net.ErrClosed) {
+			event.WriteError(ctx, op, err, event.WithInfoMsg("error serving agent api"))
+		}
+	}()
+
+	<-ctx.Done()
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if err := dnsSrv.ShutdownContext(shutdownCtx); err != nil {
+		event.WriteError(shutdownCtx, op, err, event.WithInfoMsg("error shutting down dns server"))
+	}
+	if err := httpSrv.Shutdown(shutdownCtx); err != nil {
+		event.WriteError(shutdownCtx, op, err, event.WithInfoMsg("error shutting down agent api server"))
+	}
+	a.mu.Lock()
+	for addr, t := range a.listeners {
+		_ = t.close()
+		delete(a.listeners, addr)
+	}
+	a.mu.Unlock()
+	wg.Wait()
+	return nil
+}
+
+// Paused reports whether the agent is paused. A paused agent refuses DNS
+// queries and closes new connections to alias addresses.
+func (a *Agent) Paused() bool {
+	return a.paused.Load()
+}
+
+// Pause pauses the agent.
+func (a *Agent) Pause() {
+	a.paused.Store(true)
+}
+
+// Resume resumes a paused agent.
+func (a *Agent) Resume() {
+	a.paused.Store(false)
+}
+
+// resolve returns the loopback address for the alias with the provided value,
+// making sure connections to it are being accepted. An invalid address is
+// returned if there is no alias with the value.
+func (a *Agent) resolve(ctx context.Context, value string) (netip.Addr, error) {
+	const op = "agent.(Agent).resolve"
+	ra, err := a.conf.Repository.ResolveAlias(ctx, value)
+	if err != nil {
+		return netip.Addr{}, errors.Wrap(ctx, err, op)
+	}
+	if ra == nil {
+		return netip.Addr{}, nil
+	}
+	port, err := clientPort(ra)
+	if err != nil {
+		return netip.Addr{}, err
+	}
+	addr, err := a.addrs.addressFor(ctx, value)
+	if err != nil {
+		return netip.Addr{}, errors.Wrap(ctx, err, op)
+	}
+	if err := a.ensureListener(ctx, value, netip.AddrPortFrom(addr, port)); err != nil {
+		return netip.Addr{}, errors.Wrap(ctx, err, op)
+	}
+	return addr, nil
+}
+
+// ensureListener starts accepting connections for the alias on addrPort if
+// it isn't already.
+func (a *Agent) ensureListener(ctx context.Context, alias string, addrPort netip.AddrPort) error {
+	const op = "agent.(Agent).ensureListener"
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if _, ok := a.listeners[addrPort]; ok {
+		return nil
+	}
+	ln, err := net.Listen("tcp", addrPort.String())
+	if err != nil {
+		return errors.Wrap(ctx, err, op, errors.WithMsg("unable to listen for connections to alias %q", alias))
+	}
+	t := &transparentListener{
+		agent: a,
+		alias: alias,
+		ln:    ln,
+	}
+	a.listeners[addrPort] = t
+	go t.serve(a.ctx)
+	return nil
+}
+
+// clientPort returns the port connections to the alias are expected on,
+// which is the default client port of the aliased target or, if that isn't
+// set, its default port.
+func clientPort(ra *cache.ResolvedAlias) (uint16, error) {
+	tar := ra.Target
+	switch {
+	case tar == nil:
+		return 0, fmt.Errorf("target %q of alias %q is not cached", ra.Alias.DestinationId, ra.Alias.Value)
+	case tar.Type == "udp":
+		return 0, fmt.Errorf("target %q of alias %q is a udp target, which is not supported for transparent sessions", tar.Id, ra.Alias.Value)
+	}
+	for _, attr := range []string{"default_client_port", "default_port"} {
+		if port, ok := tar.Attributes[attr].(float64); ok && port > 0 && port <= 65535 {
+			return uint16(port), nil
+		}
+	}
+	return 0, fmt.Errorf("target %q of alias %q has no default port", tar.Id, ra.Alias.Value)
+}
+
+func (a *Agent) addSession(s *Session) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.sessions[s.SessionAuthorization.SessionId] = s
+}
+
+func (a *Agent) removeSession(sessionId string) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	delete(a.sessions, sessionId)
+}
+
+// listSessions returns the running transparent sessions, oldest first.
+func (a *Agent) listSessions() []*Session {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	ret := make([]*Session, 0, len(a.sessions))
+	for _, s := range a.sessions {
+		ret = append(ret, s)
+	}
+	sort.Slice(ret, func(i, j int) bool {
+		return ret[i].SessionAuthorization.CreatedTime.Before(ret[j].SessionAuthorization.CreatedTime)
+	})
+	return ret
+}
+
+// recordError writes err as an error event and keeps it to be reported in
+// the agent status.
+func (a *Agent) recordError(ctx context.Context, op event.Op, err error) {
+	event.WriteError(ctx, op, err)
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.recentErrors = append(a.recentErrors, err.Error())
+	if len(a.recentErrors) > maxRecentErrors {
+		a.recentErrors = a.recentErrors[len(a.recentErrors)-maxRecentErrors:]
+	}
+}
+
+func (a *Agent) listRecentErrors() []string {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return append([]string{}, a.recentErrors...)
+}
@@ -0,0 +1,11 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+/*
+Package agent contains the client agent run by the client cache daemon. The
+agent answers DNS queries for the resolvable aliases held in the cache with a
+loopback address allocated to each alias. The first connection made to that
+address transparently authorizes a session to the aliased target and proxies
+the connection through it.
+*/
+package agent
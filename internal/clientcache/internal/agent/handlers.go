@@ -0,0 +1,192 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package agent
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/boundary/api"
+	"github.com/hashicorp/boundary/internal/errors"
+	"github.com/hashicorp/boundary/internal/event"
+	"github.com/hashicorp/boundary/version"
+)
+
+const (
+	statusRunning = "running"
+	statusPaused  = "paused"
+)
+
+// GetStatusResponse is the response body of the status endpoint.
+type GetStatusResponse struct {
+	BoundaryAddr    string     `json:"boundary_addr"`
+	AuthTokenId     string     `json:"auth_token_id"`
+	AuthTokenExpiry *time.Time `json:"auth_token_expiry"`
+	Version         string     `json:"version"`
+	Status          string     `json:"status"`
+	Errors          []string   `json:"errors"`
+	Warnings        []string   `json:"warnings"`
+}
+
+// ListSessionsResponse is the response body of the sessions endpoint.
+type ListSessionsResponse struct {
+	Items []*Session `json:"items"`
+}
+
+// UpsertTokenRequest is the request body of the tokens endpoint.
+type UpsertTokenRequest struct {
+	// BoundaryAddr is a required field for all requests
+	BoundaryAddr string `json:"boundary_addr,omitempty"`
+	// The raw auth token for this user.
+	Token string `json:"token,omitempty"`
+}
+
+func (a *Agent) statusHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		writeError(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	res := &GetStatusResponse{
+		Version: version.Get().FullVersionNumber(false),
+		Status:  statusRunning,
+		Errors:  a.listRecentErrors(),
+	}
+	if a.Paused() {
+		res.Status = statusPaused
+	}
+	if tok := a.lastTok.Load(); tok != nil {
+		res.BoundaryAddr = tok.boundaryAddr
+		res.AuthTokenId = tok.id
+		if !tok.expiry.IsZero() {
+			expiry := tok.expiry
+			res.AuthTokenExpiry = &expiry
+			if time.Now().After(expiry) {
+				res.Warnings = append(res.Warnings, fmt.Sprintf("Auth token %q has expired", tok.id))
+			}
+		}
+	}
+	writeJson(w, res)
+}
+
+func (a *Agent) pauseHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		writeError(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	a.Pause()
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (a *Agent) resumeHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		writeError(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	a.Resume()
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (a *Agent) sessionsHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		writeError(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	writeJson(w, &ListSessionsResponse{Items: a.listSessions()})
+}
+
+func (a *Agent) tokenHandler(w http.ResponseWriter, r *http.Request) {
+	const op = "agent.(Agent).tokenHandler"
+	reqCtx := r.Context()
+	if r.Method != http.MethodPost {
+		writeError(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	data, err := io.ReadAll(r.Body)
+	if err != nil {
+		writeError(w, "unable to read request body", http.StatusBadRequest)
+		return
+	}
+	var req UpsertTokenRequest
+	if err := json.Unmarshal(data, &req); err != nil {
+		writeError(w, "unable to parse request body", http.StatusBadRequest)
+		return
+	}
+	parts := strings.Split(req.Token, "_")
+	switch {
+	case req.BoundaryAddr == "":
+		writeError(w, "boundary_addr is a required field but was empty", http.StatusBadRequest)
+		return
+	case req.Token == "":
+		writeError(w, "token is a required field but was empty", http.StatusBadRequest)
+		return
+	case len(parts) != 3:
+		writeError(w, "token is not in the proper format", http.StatusBadRequest)
+		return
+	}
+	atId := strings.Join(parts[:2], "_")
+
+	oldTok, err := a.conf.Repository.LookupToken(reqCtx, atId)
+	if err != nil {
+		event.WriteError(reqCtx, op, err, event.WithInfoMsg("error when trying to look up existing cached auth token", "auth_token_id", atId))
+		writeError(w, "error performing auth token lookup", http.StatusInternalServerError)
+		return
+	}
+	if err := a.conf.Repository.AddRawToken(reqCtx, req.BoundaryAddr, req.Token); err != nil {
+		errCode := http.StatusInternalServerError
+		if errors.Match(errors.T(errors.Forbidden), err) {
+			errCode = http.StatusForbidden
+		}
+		err := fmt.Errorf("Failed to add a raw token with id %q: %w", atId, err)
+		event.WriteError(reqCtx, op, err)
+		writeError(w, err.Error(), errCode)
+		return
+	}
+	newTok, err := a.conf.Repository.LookupToken(reqCtx, atId)
+	if err != nil {
+		event.WriteError(reqCtx, op, err, event.WithInfoMsg("error when trying to look up newly added cached auth token", "auth_token_id", atId))
+		writeError(w, "error performing follow up auth token lookup", http.StatusInternalServerError)
+		return
+	}
+	info := &authTokenInfo{
+		boundaryAddr: req.BoundaryAddr,
+		id:           atId,
+	}
+	if newTok != nil {
+		info.expiry = newTok.ExpirationTime
+	}
+	a.lastTok.Store(info)
+
+	w.WriteHeader(http.StatusNoContent)
+
+	if oldTok == nil && newTok != nil {
+		a.conf.Refresh()
+	}
+}
+
+func writeJson(w http.ResponseWriter, v any) {
+	j, err := json.Marshal(v)
+	if err != nil {
+		writeError(w, "error marshaling response", http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	_, _ = w.Write(j)
+}
+
+func writeError(w http.ResponseWriter, msg string, s int) {
+	status := http.StatusText(s)
+	b, err := json.Marshal(&api.Error{
+		Kind:    status,
+		Message: msg,
+	})
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Unable to marshal error {Kind: %s, Message: %q} into api error format: %s", status, msg, err.Error()), http.StatusInternalServerError)
+		return
+	}
+	http.Error(w, string(b), s)
+}
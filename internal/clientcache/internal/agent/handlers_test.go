@@ -0,0 +1,133 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package agent
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/boundary/api/targets"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAgent_PauseResume(t *testing.T) {
+	a, _ := testAgent(t, nil, nil)
+
+	status := func() *GetStatusResponse {
+		rec := httptest.NewRecorder()
+		a.statusHandler(rec, httptest.NewRequest(http.MethodGet, "/v1/status", nil))
+		require.Equal(t, http.StatusOK, rec.Code)
+		var res GetStatusResponse
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &res))
+		return &res
+	}
+	assert.Equal(t, statusRunning, status().Status)
+
+	rec := httptest.NewRecorder()
+	a.pauseHandler(rec, httptest.NewRequest(http.MethodPost, "/v1/pause", nil))
+	assert.Equal(t, http.StatusNoContent, rec.Code)
+	assert.True(t, a.Paused())
+	assert.Equal(t, statusPaused, status().Status)
+
+	rec = httptest.NewRecorder()
+	a.resumeHandler(rec, httptest.NewRequest(http.MethodPost, "/v1/resume", nil))
+	assert.Equal(t, http.StatusNoContent, rec.Code)
+	assert.False(t, a.Paused())
+	assert.Equal(t, statusRunning, status().Status)
+
+	rec = httptest.NewRecorder()
+	a.pauseHandler(rec, httptest.NewRequest(http.MethodGet, "/v1/pause", nil))
+	assert.Equal(t, http.StatusMethodNotAllowed, rec.Code)
+}
+
+func TestAgent_SessionsHandler(t *testing.T) {
+	a, _ := testAgent(t, nil, nil)
+	now := time.Now().Truncate(time.Second)
+	a.addSession(&Session{Alias: "second", SessionAuthorization: &targets.SessionAuthorization{SessionId: "s_2", CreatedTime: now}})
+	a.addSession(&Session{Alias: "first", SessionAuthorization: &targets.SessionAuthorization{SessionId: "s_1", CreatedTime: now.Add(-time.Minute)}})
+
+	rec := httptest.NewRecorder()
+	a.sessionsHandler(rec, httptest.NewRequest(http.MethodGet, "/v1/sessions", nil))
+	require.Equal(t, http.StatusOK, rec.Code)
+	var res ListSessionsResponse
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &res))
+	require.Len(t, res.Items, 2)
+	assert.Equal(t, "first", res.Items[0].Alias)
+	assert.Equal(t, "s_1", res.Items[0].SessionAuthorization.SessionId)
+	assert.Equal(t, "second", res.Items[1].Alias)
+
+	a.removeSession("s_1")
+	rec = httptest.NewRecorder()
+	a.sessionsHandler(rec, httptest.NewRequest(http.MethodGet, "/v1/sessions", nil))
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &res))
+	require.Len(t, res.Items, 1)
+	assert.Equal(t, "second", res.Items[0].Alias)
+}
+
+func TestAgent_TokenHandler(t *testing.T) {
+	var refreshed int
+	a, repo := testAgent(t, nil, func() { refreshed++ })
+
+	post := func(body string) *httptest.ResponseRecorder {
+		rec := httptest.NewRecorder()
+		a.tokenHandler(rec, httptest.NewRequest(http.MethodPost, "/v1/tokens", strings.NewReader(body)))
+		return rec
+	}
+
+	errorCases := []struct {
+		name          string
+		body          string
+		errorContains string
+	}{
+		{
+			name:          "unparsable",
+			body:          "{",
+			errorContains: "unable to parse request body",
+		},
+		{
+			name:          "missing address",
+			body:          `{"token":"at_1_token"}`,
+			errorContains: "boundary_addr is a required field",
+		},
+		{
+			name:          "missing token",
+			body:          `{"boundary_addr":"http://127.0.0.1:9200"}`,
+			errorContains: "token is a required field",
+		},
+		{
+			name:          "malformed token",
+			body:          `{"boundary_addr":"http://127.0.0.1:9200","token":"at_1"}`,
+			errorContains: "token is not in the proper format",
+		},
+	}
+	for _, tc := range errorCases {
+		t.Run(tc.name, func(t *testing.T) {
+			rec := post(tc.body)
+			assert.Equal(t, http.StatusBadRequest, rec.Code)
+			assert.Contains(t, rec.Body.String(), tc.errorContains)
+		})
+	}
+
+	rec := post(`{"boundary_addr":"http://127.0.0.1:9200","token":"at_1_token"}`)
+	require.Equal(t, http.StatusNoContent, rec.Code)
+	assert.Contains(t, repo.tokens, "at_1")
+	assert.Equal(t, 1, refreshed)
+
+	// Adding a token already in the cache doesn't refresh it again
+	rec = post(`{"boundary_addr":"http://127.0.0.1:9200","token":"at_1_token"}`)
+	require.Equal(t, http.StatusNoContent, rec.Code)
+	assert.Equal(t, 1, refreshed)
+
+	rec = httptest.NewRecorder()
+	a.statusHandler(rec, httptest.NewRequest(http.MethodGet, "/v1/status", nil))
+	var res GetStatusResponse
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &res))
+	assert.Equal(t, "http://127.0.0.1:9200", res.BoundaryAddr)
+	assert.Equal(t, "at_1", res.AuthTokenId)
+}
@@ -0,0 +1,62 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package agent
+
+import (
+	"context"
+	"net"
+	"net/url"
+	"os"
+	"path/filepath"
+
+	"github.com/hashicorp/boundary/internal/errors"
+)
+
+const (
+	sockAddr = "socket/agent.sock"
+
+	// The agent api hands out brokered credentials and accepts auth tokens,
+	// so like the cache daemon's socket only the user that started the agent
+	// is allowed to connect to it.
+	socketDirPerms = 0o700
+	socketPerms    = 0o600
+)
+
+// listener provides a Listener on the agent api unix socket in the provided
+// directory.
+func listener(ctx context.Context, path string) (net.Listener, error) {
+	const op = "agent.listener"
+	socketName := filepath.Join(path, sockAddr)
+	if err := os.Remove(socketName); err != nil {
+		// If the socket existed before and wasn't cleaned up delete it now.
+		if !os.IsNotExist(err) {
+			return nil, errors.Wrap(ctx, err, op)
+		}
+	}
+
+	socketPath := filepath.Dir(socketName)
+	if err := os.MkdirAll(socketPath, socketDirPerms); err != nil {
+		return nil, errors.Wrap(ctx, err, op, errors.WithMsg("failed to create boundary directory"))
+	}
+
+	l, err := net.Listen("unix", socketName)
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op, errors.WithMsg("failed listening"))
+	}
+	if err := os.Chmod(socketName, socketPerms); err != nil {
+		_ = l.Close()
+		return nil, errors.Wrap(ctx, err, op, errors.WithMsg("changing socket permissions"))
+	}
+	return l, nil
+}
+
+// SocketAddress returns the unix socket *url.URL the agent serves its api on
+// when started with the provided directory. Verifying the path is valid is the
+// responsibility of the caller.
+func SocketAddress(path string) *url.URL {
+	return &url.URL{
+		Scheme: "unix",
+		Path:   filepath.Join(path, sockAddr),
+	}
+}
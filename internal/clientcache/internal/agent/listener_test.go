@@ -0,0 +1,41 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+//go:build !windows
+// +build !windows
+
+package agent
+
+import (
+	"context"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestListenerSocketPermissions(t *testing.T) {
+	ctx := context.Background()
+
+	// Not t.TempDir since unix socket paths are limited to 108 characters.
+	path, err := os.MkdirTemp("", "*")
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = os.RemoveAll(path) })
+	l, err := listener(ctx, path)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = l.Close() })
+	socketFile := l.Addr().String()
+	assert.Equal(t, SocketAddress(path).Path, socketFile)
+	fi, err := os.Stat(socketFile)
+	require.NoError(t, err)
+	assert.Equal(t, os.ModeSocket, fi.Mode().Type())
+	assert.Equal(t, fs.FileMode(0o600), fi.Mode().Perm(), "permissions were ", fi.Mode().Perm().String())
+
+	di, err := os.Stat(filepath.Dir(socketFile))
+	require.NoError(t, err)
+	assert.Equal(t, os.ModeDir, di.Mode().Type())
+	assert.Equal(t, fs.FileMode(0o700), di.Mode().Perm(), "permissions were ", di.Mode().Perm().String())
+}
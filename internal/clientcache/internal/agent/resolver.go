@@ -0,0 +1,67 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package agent
+
+import (
+	"strings"
+
+	"github.com/miekg/dns"
+)
+
+// dnsTtl is the ttl of answers for aliases. It is kept short so that
+// clients notice aliases being removed.
+const dnsTtl = 5
+
+// serveDns answers A queries for alias values with the loopback address
+// allocated to the alias. Queries for other types of records for an alias
+// get an empty answer, queries for names which are not alias values are
+// answered with NXDOMAIN, and all queries are refused while the agent is
+// paused so that the system resolver falls back to other servers.
+func (a *Agent) serveDns(w dns.ResponseWriter, req *dns.Msg) {
+	const op = "agent.(Agent).serveDns"
+	m := new(dns.Msg)
+	m.SetReply(req)
+	defer func() {
+		_ = w.WriteMsg(m)
+	}()
+
+	switch {
+	case a.Paused():
+		m.Rcode = dns.RcodeRefused
+		return
+	case len(req.Question) != 1:
+		m.Rcode = dns.RcodeFormatError
+		return
+	}
+	q := req.Question[0]
+	if q.Qclass != dns.ClassINET {
+		m.Rcode = dns.RcodeNotImplemented
+		return
+	}
+
+	value := strings.ToLower(strings.TrimSuffix(q.Name, "."))
+	addr, err := a.resolve(a.ctx, value)
+	switch {
+	case err != nil:
+		a.recordError(a.ctx, op, err)
+		m.Rcode = dns.RcodeServerFailure
+		return
+	case !addr.IsValid():
+		m.Rcode = dns.RcodeNameError
+		return
+	}
+
+	m.Authoritative = true
+	if q.Qtype == dns.TypeA || q.Qtype == dns.TypeANY {
+		m.Answer = append(m.Answer, &dns.A{
+			Hdr: dns.RR_Header{
+				Name:   q.Name,
+				Rrtype: dns.TypeA,
+				Class:  dns.ClassINET,
+				Ttl:    dnsTtl,
+			},
+			A: addr.AsSlice(),
+		})
+	}
+}
@@ -0,0 +1,136 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package agent
+
+import (
+	"net"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/boundary/api/aliases"
+	"github.com/hashicorp/boundary/api/authtokens"
+	"github.com/hashicorp/boundary/api/targets"
+	"github.com/hashicorp/boundary/internal/clientcache/internal/cache"
+	"github.com/miekg/dns"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// testDnsWriter is a dns.ResponseWriter which keeps the written message.
+type testDnsWriter struct {
+	msg *dns.Msg
+}
+
+var _ dns.ResponseWriter = (*testDnsWriter)(nil)
+
+func (w *testDnsWriter) LocalAddr() net.Addr         { return &net.UDPAddr{} }
+func (w *testDnsWriter) RemoteAddr() net.Addr        { return &net.UDPAddr{} }
+func (w *testDnsWriter) WriteMsg(m *dns.Msg) error   { w.msg = m; return nil }
+func (w *testDnsWriter) Write(b []byte) (int, error) { return len(b), nil }
+func (w *testDnsWriter) Close() error                { return nil }
+func (w *testDnsWriter) TsigStatus() error           { return nil }
+func (w *testDnsWriter) TsigTimersOnly(bool)         {}
+func (w *testDnsWriter) Hijack()                     {}
+
+// freePort returns a tcp port that nothing is listening on.
+func freePort(t *testing.T) int {
+	t.Helper()
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer l.Close()
+	return l.Addr().(*net.TCPAddr).Port
+}
+
+// assertRecentError asserts one of the agent's recent errors contains substr.
+func assertRecentError(t *testing.T, a *Agent, substr string) {
+	t.Helper()
+	for _, e := range a.listRecentErrors() {
+		if strings.Contains(e, substr) {
+			return
+		}
+	}
+	t.Errorf("no recent error contains %q: %v", substr, a.listRecentErrors())
+}
+
+func TestAgent_ServeDns(t *testing.T) {
+	port := freePort(t)
+	at := &authtokens.AuthToken{Id: "at_1", Token: "at_1_token"}
+	resolved := map[string]*cache.ResolvedAlias{
+		"prod-db.boundary": {
+			Alias: &aliases.Alias{Id: "alt_1", Value: "prod-db.boundary", DestinationId: "ttcp_1"},
+			Target: &targets.Target{
+				Id:         "ttcp_1",
+				Type:       "tcp",
+				Attributes: map[string]any{"default_port": float64(22), "default_client_port": float64(port)},
+			},
+			AuthToken: at,
+		},
+		"uncached.boundary": {
+			Alias:     &aliases.Alias{Id: "alt_2", Value: "uncached.boundary", DestinationId: "ttcp_2"},
+			AuthToken: at,
+		},
+		"dns.boundary": {
+			Alias:     &aliases.Alias{Id: "alt_3", Value: "dns.boundary", DestinationId: "tudp_1"},
+			Target:    &targets.Target{Id: "tudp_1", Type: "udp", Attributes: map[string]any{"default_port": float64(53)}},
+			AuthToken: at,
+		},
+	}
+	a, _ := testAgent(t, resolved, nil)
+
+	query := func(name string, qtype uint16) *dns.Msg {
+		req := new(dns.Msg)
+		req.SetQuestion(dns.Fqdn(name), qtype)
+		w := &testDnsWriter{}
+		a.serveDns(w, req)
+		require.NotNil(t, w.msg)
+		return w.msg
+	}
+
+	t.Run("unknown name", func(t *testing.T) {
+		m := query("unknown.boundary", dns.TypeA)
+		assert.Equal(t, dns.RcodeNameError, m.Rcode)
+		assert.Empty(t, m.Answer)
+	})
+	t.Run("alias", func(t *testing.T) {
+		m := query("Prod-DB.boundary", dns.TypeA)
+		require.Equal(t, dns.RcodeSuccess, m.Rcode)
+		require.Len(t, m.Answer, 1)
+		ans := m.Answer[0].(*dns.A)
+		assert.Equal(t, "127.211.0.1", ans.A.String())
+		assert.Equal(t, uint32(dnsTtl), ans.Hdr.Ttl)
+
+		// Connections to the answered address are accepted by the agent
+		conn, err := net.Dial("tcp", net.JoinHostPort(ans.A.String(), strconv.Itoa(port)))
+		require.NoError(t, err)
+		conn.Close()
+
+		// Resolving again returns the same address
+		m = query("prod-db.boundary", dns.TypeA)
+		require.Len(t, m.Answer, 1)
+		assert.Equal(t, ans.A.String(), m.Answer[0].(*dns.A).A.String())
+	})
+	t.Run("alias with no A record asked for", func(t *testing.T) {
+		m := query("prod-db.boundary", dns.TypeAAAA)
+		assert.Equal(t, dns.RcodeSuccess, m.Rcode)
+		assert.Empty(t, m.Answer)
+	})
+	t.Run("uncached target", func(t *testing.T) {
+		m := query("uncached.boundary", dns.TypeA)
+		assert.Equal(t, dns.RcodeServerFailure, m.Rcode)
+		assertRecentError(t, a, "is not cached")
+	})
+	t.Run("udp target", func(t *testing.T) {
+		m := query("dns.boundary", dns.TypeA)
+		assert.Equal(t, dns.RcodeServerFailure, m.Rcode)
+		assertRecentError(t, a, "not supported for transparent sessions")
+	})
+	t.Run("paused", func(t *testing.T) {
+		a.Pause()
+		defer a.Resume()
+		m := query("prod-db.boundary", dns.TypeA)
+		assert.Equal(t, dns.RcodeRefused, m.Rcode)
+		assert.Empty(t, m.Answer)
+	})
+}
@@ -0,0 +1,95 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package agent
+
+import (
+	"context"
+	"net"
+	"net/netip"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/hashicorp/boundary/api"
+	"github.com/hashicorp/boundary/internal/clientcache/internal/cache"
+	"github.com/hashicorp/boundary/internal/cmd/base"
+	"github.com/stretchr/testify/require"
+)
+
+// testRepository is an in memory Repository.
+type testRepository struct {
+	mu       sync.Mutex
+	resolved map[string]*cache.ResolvedAlias
+	tokens   map[string]*cache.AuthToken
+}
+
+func (r *testRepository) ResolveAlias(_ context.Context, value string) (*cache.ResolvedAlias, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.resolved[value], nil
+}
+
+func (r *testRepository) AddRawToken(_ context.Context, _ string, rawToken string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	id := strings.Join(strings.Split(rawToken, "_")[:2], "_")
+	r.tokens[id] = &cache.AuthToken{Id: id}
+	return nil
+}
+
+func (r *testRepository) LookupToken(_ context.Context, authTokenId string, _ ...cache.Option) (*cache.AuthToken, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.tokens[authTokenId], nil
+}
+
+type testClientProvider struct{}
+
+func (testClientProvider) Client(...base.Option) (*api.Client, error) {
+	return api.NewClient(nil)
+}
+
+// testAgent returns an agent using an in memory repository holding the
+// provided resolved aliases, keyed by value. The agent allocates addresses
+// from a prefix that is unlikely to be in use.
+func testAgent(t *testing.T, resolved map[string]*cache.ResolvedAlias, refresh func()) (*Agent, *testRepository) {
+	t.Helper()
+	repo := &testRepository{
+		resolved: resolved,
+		tokens:   make(map[string]*cache.AuthToken),
+	}
+	if refresh == nil {
+		refresh = func() {}
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	a, err := New(ctx, &Config{
+		Repository:     repo,
+		ClientProvider: testClientProvider{},
+		Refresh:        refresh,
+		DotDirectory:   t.TempDir(),
+		AddressPrefix:  netip.MustParsePrefix("127.211.0.0/16"),
+	})
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		cancel()
+		a.mu.Lock()
+		defer a.mu.Unlock()
+		for _, l := range a.listeners {
+			_ = l.close()
+		}
+	})
+	return a, repo
+}
+
+// testConn returns one end of a connected pair of connections, closing both
+// ends when the test is done.
+func testConn(t *testing.T) net.Conn {
+	t.Helper()
+	c1, c2 := net.Pipe()
+	t.Cleanup(func() {
+		c1.Close()
+		c2.Close()
+	})
+	return c1
+}
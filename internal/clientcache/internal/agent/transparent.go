@@ -0,0 +1,187 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package agent
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"sync"
+
+	apiproxy "github.com/hashicorp/boundary/api/proxy"
+	"github.com/hashicorp/boundary/api/targets"
+	"github.com/hashicorp/boundary/internal/cmd/base"
+	"github.com/hashicorp/boundary/internal/errors"
+	"github.com/hashicorp/boundary/internal/event"
+)
+
+// transparentListener listens on the address allocated to an alias. The first
+// connection made to it authorizes a session to the aliased target, and that
+// connection and any later ones are proxied through the session until it
+// ends. The next connection after that authorizes a new session.
+type transparentListener struct {
+	agent *Agent
+	alias string
+	ln    net.Listener
+
+	mu      sync.Mutex
+	current *sessionListener
+}
+
+// serve accepts connections until the listener is closed.
+func (t *transparentListener) serve(ctx context.Context) {
+	const op = "agent.(transparentListener).serve"
+	for {
+		conn, err := t.ln.Accept()
+		if err != nil {
+			if !errors.Is(err, net.ErrClosed) {
+				event.WriteError(ctx, op, err, event.WithInfoMsg("error accepting connection", "alias", t.alias))
+			}
+			return
+		}
+		if err := t.handle(ctx, conn); err != nil {
+			_ = conn.Close()
+			t.agent.recordError(ctx, op, fmt.Errorf("connection to alias %q: %w", t.alias, err))
+		}
+	}
+}
+
+// handle hands conn to the proxy of the alias's current session, authorizing
+// a new session first if there is none.
+func (t *transparentListener) handle(ctx context.Context, conn net.Conn) error {
+	if t.agent.Paused() {
+		return fmt.Errorf("the client agent is paused")
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.current != nil && t.current.deliver(conn) {
+		return nil
+	}
+	sl, err := t.startSession(ctx)
+	if err != nil {
+		return err
+	}
+	t.current = sl
+	if !sl.deliver(conn) {
+		return fmt.Errorf("session ended before the connection could be proxied")
+	}
+	return nil
+}
+
+// startSession authorizes a session to the aliased target and starts proxying
+// connections handed to the returned sessionListener through it.
+func (t *transparentListener) startSession(ctx context.Context) (*sessionListener, error) {
+	const op = "agent.(transparentListener).startSession"
+	ra, err := t.agent.conf.Repository.ResolveAlias(ctx, t.alias)
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	if ra == nil {
+		return nil, fmt.Errorf("alias is no longer resolvable")
+	}
+	client, err := t.agent.conf.ClientProvider.Client(base.WithNoTokenValue())
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	if err := client.SetAddr(ra.BoundaryAddr); err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	client.SetToken(ra.AuthToken.Token)
+
+	var opts []targets.Option
+	if args, ok := ra.Alias.Attributes["authorize_session_arguments"].(map[string]any); ok {
+		if hostId, ok := args["host_id"].(string); ok && hostId != "" {
+			opts = append(opts, targets.WithHostId(hostId))
+		}
+	}
+	sar, err := targets.NewClient(client).AuthorizeSession(ctx, ra.Alias.DestinationId, opts...)
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	sa := sar.Item
+
+	sl := newSessionListener(t.ln.Addr())
+	p, err := apiproxy.New(ctx, sa.AuthorizationToken, apiproxy.WithListener(sl), apiproxy.WithApiClient(client))
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	t.agent.addSession(&Session{Alias: t.alias, SessionAuthorization: sa})
+	event.WriteSysEvent(ctx, op, "authorized transparent session", "alias", t.alias, "session_id", sa.SessionId)
+	go func() {
+		defer t.agent.removeSession(sa.SessionId)
+		defer sl.Close()
+		if err := p.Start(); err != nil {
+			event.WriteSysEvent(ctx, op, "transparent session ended", "alias", t.alias, "session_id", sa.SessionId, "reason", err.Error())
+			return
+		}
+		event.WriteSysEvent(ctx, op, "transparent session ended", "alias", t.alias, "session_id", sa.SessionId)
+	}()
+	return sl, nil
+}
+
+// close stops accepting connections for the alias. Sessions already running
+// are left to end on their own.
+func (t *transparentListener) close() error {
+	return t.ln.Close()
+}
+
+// sessionListener is the net.Listener a session's proxy accepts connections
+// from. Connections are handed to it by the transparentListener and closing
+// it does not close the underlying listener, so the alias's address stays
+// bound between sessions.
+type sessionListener struct {
+	addr      net.Addr
+	conns     chan net.Conn
+	done      chan struct{}
+	closeOnce sync.Once
+}
+
+var _ net.Listener = (*sessionListener)(nil)
+
+func newSessionListener(addr net.Addr) *sessionListener {
+	return &sessionListener{
+		addr:  addr,
+		conns: make(chan net.Conn),
+		done:  make(chan struct{}),
+	}
+}
+
+// Accept implements net.Listener.
+func (l *sessionListener) Accept() (net.Conn, error) {
+	select {
+	case conn := <-l.conns:
+		return conn, nil
+	case <-l.done:
+		return nil, net.ErrClosed
+	}
+}
+
+// Close implements net.Listener.
+func (l *sessionListener) Close() error {
+	l.closeOnce.Do(func() {
+		close(l.done)
+	})
+	return nil
+}
+
+// Addr implements net.Listener.
+func (l *sessionListener) Addr() net.Addr {
+	return l.addr
+}
+
+// deliver hands conn to the proxy accepting from l. It reports false if l was
+// closed before the proxy accepted conn.
+func (l *sessionListener) deliver(conn net.Conn) bool {
+	select {
+	case <-l.done:
+		return false
+	default:
+	}
+	select {
+	case l.conns <- conn:
+		return true
+	case <-l.done:
+		return false
+	}
+}
@@ -0,0 +1,46 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package agent
+
+import (
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSessionListener(t *testing.T) {
+	addr := &net.TCPAddr{IP: net.IPv4(127, 0, 0, 1), Port: 22}
+	l := newSessionListener(addr)
+	assert.Equal(t, addr, l.Addr())
+
+	conn := testConn(t)
+	accepted := make(chan net.Conn)
+	go func() {
+		c, err := l.Accept()
+		assert.NoError(t, err)
+		accepted <- c
+	}()
+	require.True(t, l.deliver(conn))
+	assert.Equal(t, conn, <-accepted)
+
+	require.NoError(t, l.Close())
+	require.NoError(t, l.Close())
+	_, err := l.Accept()
+	assert.ErrorIs(t, err, net.ErrClosed)
+	assert.False(t, l.deliver(testConn(t)))
+}
+
+func TestTransparentListener_Paused(t *testing.T) {
+	a, _ := testAgent(t, nil, nil)
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	tl := &transparentListener{agent: a, alias: "prod-db.boundary", ln: ln}
+	t.Cleanup(func() { _ = tl.close() })
+
+	a.Pause()
+	err = tl.handle(a.ctx, testConn(t))
+	assert.ErrorContains(t, err, "the client agent is paused")
+}
@@ -12,6 +12,8 @@ import (
 
 	"github.com/hashicorp/boundary/api"
 	"github.com/hashicorp/boundary/api/aliases"
+	"github.com/hashicorp/boundary/api/authtokens"
+	"github.com/hashicorp/boundary/api/targets"
 	"github.com/hashicorp/boundary/api/users"
 	"github.com/hashicorp/boundary/internal/db"
 	"github.com/hashicorp/boundary/internal/errors"
@@ -321,6 +323,96 @@ func (r *Repository) QueryResolvableAliases(ctx context.Context, authTokenId, qu
 	return ret, nil
 }
 
+// ResolvedAlias is a resolvable alias along with what is needed to connect
+// through it: the address of the Boundary instance it was cached from, the
+// target it resolves to if that target is cached, and an auth token of the user
+// the alias is resolvable for.
+type ResolvedAlias struct {
+	Alias        *aliases.Alias
+	Target       *targets.Target
+	BoundaryAddr string
+	AuthToken    *authtokens.AuthToken
+}
+
+// ResolveAlias looks up a cached resolvable alias with the provided value for
+// any user that has an auth token available to the cache. If no such alias
+// exists, nil is returned with no error.
+func (r *Repository) ResolveAlias(ctx context.Context, value string) (*ResolvedAlias, error) {
+	const op = "cache.(Repository).ResolveAlias"
+	switch {
+	case value == "":
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "value is missing")
+	}
+
+	var cachedResolvableAliases []*ResolvableAlias
+	if err := r.rw.SearchWhere(ctx, &cachedResolvableAliases, "value = ?", []any{value}, db.WithLimit(-1)); err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	for _, cachedA := range cachedResolvableAliases {
+		u, err := r.lookupUser(ctx, cachedA.FkUserId)
+		if err != nil {
+			return nil, errors.Wrap(ctx, err, op)
+		}
+		if u == nil {
+			continue
+		}
+		at, err := r.lookupAuthTokenForUser(ctx, u)
+		if err != nil {
+			return nil, errors.Wrap(ctx, err, op)
+		}
+		if at == nil {
+			continue
+		}
+		var a aliases.Alias
+		if err := json.Unmarshal([]byte(cachedA.Item), &a); err != nil {
+			return nil, errors.Wrap(ctx, err, op)
+		}
+		ret := &ResolvedAlias{
+			Alias:        &a,
+			BoundaryAddr: u.Address,
+			AuthToken:    at,
+		}
+		tars, err := r.searchTargets(ctx, "id = ?", []any{cachedA.DestinationId}, withUserId(u.Id))
+		if err != nil {
+			return nil, errors.Wrap(ctx, err, op)
+		}
+		if len(tars) > 0 {
+			ret.Target = tars[0]
+		}
+		return ret, nil
+	}
+	return nil, nil
+}
+
+// lookupAuthTokenForUser returns an auth token for the provided user from
+// either the keyring or the in memory keyringless tokens. nil is returned if
+// the cache has no token available for the user.
+func (r *Repository) lookupAuthTokenForUser(ctx context.Context, u *user) (*authtokens.AuthToken, error) {
+	const op = "cache.(Repository).lookupAuthTokenForUser"
+	tokens, err := r.listTokens(ctx, u)
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	for _, t := range tokens {
+		if atv, ok := r.idToKeyringlessAuthToken.Load(t.Id); ok {
+			if at, ok := atv.(*authtokens.AuthToken); ok {
+				return at, nil
+			}
+		}
+		keyringTokens, err := r.listKeyringTokens(ctx, t)
+		if err != nil {
+			return nil, errors.Wrap(ctx, err, op)
+		}
+		for _, kt := range keyringTokens {
+			at := r.tokenKeyringFn(kt.KeyringType, kt.TokenName)
+			if at != nil && at.Id == kt.AuthTokenId {
+				return at, nil
+			}
+		}
+	}
+	return nil, nil
+}
+
 func (r *Repository) searchResolvableAliases(ctx context.Context, condition string, searchArgs []any, opt ...Option) ([]*aliases.Alias, error) {
 	const op = "cache.(Repository).searchResolvableAliases"
 	switch {
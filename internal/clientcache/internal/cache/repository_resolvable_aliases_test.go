@@ -452,6 +452,100 @@ func TestRepository_QueryAliases(t *testing.T) {
 	})
 }
 
+func TestRepository_ResolveAlias(t *testing.T) {
+	ctx := context.Background()
+	s, err := cachedb.Open(ctx)
+	require.NoError(t, err)
+
+	addr := "address"
+	u1 := &user{
+		Id:      "u1",
+		Address: addr,
+	}
+	at1 := &authtokens.AuthToken{
+		Id:     "at_1",
+		Token:  "at_1_token",
+		UserId: u1.Id,
+	}
+	kt1 := KeyringToken{
+		KeyringType: "k1",
+		TokenName:   "t1",
+		AuthTokenId: at1.Id,
+	}
+	atMap := map[ringToken]*authtokens.AuthToken{
+		{"k1", "t1"}: at1,
+	}
+	r, err := NewRepository(ctx, s, &sync.Map{}, mapBasedAuthTokenKeyringLookup(atMap), sliceBasedAuthTokenBoundaryReader(maps.Values(atMap)))
+	require.NoError(t, err)
+	require.NoError(t, r.AddKeyringToken(ctx, addr, kt1))
+
+	t.Run("value is missing", func(t *testing.T) {
+		got, err := r.ResolveAlias(ctx, "")
+		assert.Nil(t, got)
+		assert.ErrorContains(t, err, "value is missing")
+	})
+
+	tar := &targets.Target{
+		Id:      "ttcp_123",
+		Name:    "target",
+		Type:    "tcp",
+		ScopeId: "p_123",
+		Attributes: map[string]any{
+			"default_port": float64(22),
+		},
+	}
+	require.NoError(t, r.refreshTargets(ctx, u1, map[AuthToken]string{{Id: "id"}: "something"},
+		WithTargetRetrievalFunc(testStaticResourceRetrievalFunc(t, [][]*targets.Target{{tar}}, [][]string{nil}))))
+
+	als := []*aliases.Alias{
+		{
+			Id:            "alt_1",
+			ScopeId:       "global",
+			DestinationId: tar.Id,
+			Value:         "prod-db.boundary",
+			Type:          "target",
+		},
+		{
+			Id:            "alt_2",
+			ScopeId:       "global",
+			DestinationId: "ttcp_uncached",
+			Value:         "uncached.boundary",
+			Type:          "target",
+		},
+	}
+	require.NoError(t, r.refreshResolvableAliases(ctx, u1, map[AuthToken]string{{Id: "id"}: "something"},
+		WithAliasRetrievalFunc(testStaticResourceRetrievalFuncForId(t, [][]*aliases.Alias{als}, [][]string{nil}))))
+
+	t.Run("unknown value", func(t *testing.T) {
+		got, err := r.ResolveAlias(ctx, "unknown.boundary")
+		assert.NoError(t, err)
+		assert.Nil(t, got)
+	})
+	t.Run("alias with cached target", func(t *testing.T) {
+		got, err := r.ResolveAlias(ctx, "prod-db.boundary")
+		require.NoError(t, err)
+		require.NotNil(t, got)
+		assert.Equal(t, als[0], got.Alias)
+		assert.Equal(t, tar, got.Target)
+		assert.Equal(t, addr, got.BoundaryAddr)
+		assert.Equal(t, at1, got.AuthToken)
+	})
+	t.Run("alias without cached target", func(t *testing.T) {
+		got, err := r.ResolveAlias(ctx, "uncached.boundary")
+		require.NoError(t, err)
+		require.NotNil(t, got)
+		assert.Equal(t, als[1], got.Alias)
+		assert.Nil(t, got.Target)
+		assert.Equal(t, at1, got.AuthToken)
+	})
+	t.Run("no token available", func(t *testing.T) {
+		delete(atMap, ringToken{"k1", "t1"})
+		got, err := r.ResolveAlias(ctx, "prod-db.boundary")
+		assert.NoError(t, err)
+		assert.Nil(t, got)
+	})
+}
+
 func TestDefaultAliasRetrievalFunc(t *testing.T) {
 	oldDur := globals.RefreshReadLookbackDuration
 	globals.RefreshReadLookbackDuration = 0
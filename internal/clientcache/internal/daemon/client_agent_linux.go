@@ -0,0 +1,42 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+//go:build linux
+// +build linux
+
+package daemon
+
+import (
+	"context"
+	"sync"
+
+	"github.com/hashicorp/boundary/internal/clientcache/internal/agent"
+	"github.com/hashicorp/boundary/internal/clientcache/internal/cache"
+	"github.com/hashicorp/boundary/internal/errors"
+	"github.com/hashicorp/boundary/internal/event"
+)
+
+// startClientAgent starts the client agent, which runs until the provided
+// context is done. The returned WaitGroup is done once the agent has stopped.
+func (s *CacheServer) startClientAgent(ctx context.Context, cp ClientProvider, repo *cache.Repository, refresher refresher) (*sync.WaitGroup, error) {
+	const op = "daemon.(CacheServer).startClientAgent"
+	a, err := agent.New(ctx, &agent.Config{
+		Repository:     repo,
+		ClientProvider: cp,
+		Refresh:        refresher.refresh,
+		DotDirectory:   s.conf.DotDirectory,
+		DnsListenAddr:  s.conf.ClientAgentDnsListenAddr,
+	})
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		if err := a.Serve(ctx); err != nil {
+			event.WriteError(ctx, op, err, event.WithInfoMsg("client agent stopped"))
+		}
+	}()
+	return &wg, nil
+}
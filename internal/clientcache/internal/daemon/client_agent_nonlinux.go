@@ -0,0 +1,22 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+//go:build !linux
+// +build !linux
+
+package daemon
+
+import (
+	"context"
+	"sync"
+
+	"github.com/hashicorp/boundary/internal/clientcache/internal/cache"
+	"github.com/hashicorp/boundary/internal/errors"
+)
+
+// startClientAgent returns an error since the client agent is only run by
+// the cache on linux.
+func (s *CacheServer) startClientAgent(ctx context.Context, _ ClientProvider, _ *cache.Repository, _ refresher) (*sync.WaitGroup, error) {
+	const op = "daemon.(CacheServer).startClientAgent"
+	return nil, errors.New(ctx, errors.InvalidParameter, op, "the client agent is only supported on linux")
+}
@@ -18,6 +18,7 @@ import (
 
 	"github.com/hashicorp/boundary/api"
 	"github.com/hashicorp/boundary/api/authtokens"
+	"github.com/hashicorp/boundary/internal/clientcache/internal/agent"
 	"github.com/hashicorp/boundary/internal/clientcache/internal/cache"
 	cachedb "github.com/hashicorp/boundary/internal/clientcache/internal/db"
 	"github.com/hashicorp/boundary/internal/cmd/base"
@@ -89,6 +90,11 @@ type Config struct {
 	// The maximum amount of time a refresh should block a search request from
 	// completing before it times out.
 	MaxSearchRefreshTimeout time.Duration
+	// If set, the client agent is run alongside the cache. The client agent
+	// is only supported on linux.
+	EnableClientAgent bool
+	// The address the client agent answers DNS queries on.
+	ClientAgentDnsListenAddr string
 }
 
 func (sc *Config) validate(ctx context.Context) error {
@@ -264,6 +270,13 @@ func (s *CacheServer) Serve(ctx context.Context, cmd Commander, opt ...Option) e
 		ticOptions = append(ticOptions, withRecheckSupportInterval(ctx, s.conf.RecheckSupportInterval))
 	}
 
+	if s.conf.EnableClientAgent {
+		s.info["Client Agent Domain Socket"] = agent.SocketAddress(s.conf.DotDirectory).String()
+		s.infoKeys = append(s.infoKeys, "Client Agent Domain Socket")
+		s.info["Client Agent Dns Address"] = s.conf.ClientAgentDnsListenAddr
+		s.infoKeys = append(s.infoKeys, "Client Agent Dns Address")
+	}
+
 	s.printInfo(ctx)
 
 	repo, err := cache.NewRepository(ctx, s.store.Load(), &sync.Map{}, cmd.ReadTokenFromKeyring, opts.withBoundaryTokenReaderFunc)
@@ -293,6 +306,17 @@ func (s *CacheServer) Serve(ctx context.Context, cmd Commander, opt ...Option) e
 		tic.startRecheckCachingSupport(tickingCtx)
 	}()
 
+	if s.conf.EnableClientAgent {
+		agentWg, err := s.startClientAgent(tickingCtx, cmd, repo, tic)
+		if err != nil {
+			return errors.Wrap(ctx, err, op)
+		}
+		defer func() {
+			cancel()
+			agentWg.Wait()
+		}()
+	}
+
 	mux := http.NewServeMux()
 	searchFn, err := newSearchHandlerFunc(ctx, repo, refreshService, s.logger)
 	if err != nil {
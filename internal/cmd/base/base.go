@@ -18,9 +18,7 @@ import (
 	"syscall"
 
 	"github.com/hashicorp/boundary/api"
-	"github.com/hashicorp/boundary/globals"
 	kms_plugin_assets "github.com/hashicorp/boundary/plugins/kms"
-	"github.com/hashicorp/boundary/sdk/wrapper"
 	"github.com/hashicorp/go-hclog"
 	wrapping "github.com/hashicorp/go-kms-wrapping/v2"
 	configutil "github.com/hashicorp/go-secure-stdlib/configutil/v2"
@@ -38,6 +36,7 @@ const (
 	EnabledPluginAws
 	EnabledPluginHostAzure
 	EnabledPluginMinio
+	EnabledPluginHostDns
 )
 
 // MinioEnabled controls if the Minio storage plugin should be initiated or not
@@ -53,6 +52,8 @@ func (e EnabledPlugin) String() string {
 		return "Azure"
 	case EnabledPluginMinio:
 		return "MinIO"
+	case EnabledPluginHostDns:
+		return "DNS"
 	default:
 		return ""
 	}
@@ -280,10 +281,9 @@ func (c *Command) Client(opt ...Option) (*api.Client, error) {
 		c.client.SetToken("")
 
 	case c.FlagRecoveryConfig != "":
-		wrapper, cleanupFunc, err := wrapper.GetWrapperFromPath(
+		wrapper, cleanupFunc, err := recoveryWrapperFromPath(
 			c.Context,
 			c.FlagRecoveryConfig,
-			globals.KmsPurposeRecovery,
 			configutil.WithPluginOptions(
 				pluginutil.WithPluginsMap(kms_plugin_assets.BuiltinKmsPlugins()),
 				pluginutil.WithPluginsFilesystem(kms_plugin_assets.KmsPluginPrefix, kms_plugin_assets.FileSystem()),
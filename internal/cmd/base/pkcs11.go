@@ -0,0 +1,86 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package base
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/hashicorp/boundary/globals"
+	"github.com/hashicorp/boundary/internal/kms/pkcs11"
+	"github.com/hashicorp/boundary/sdk/wrapper"
+	wrapping "github.com/hashicorp/go-kms-wrapping/v2"
+	"github.com/hashicorp/go-secure-stdlib/configutil/v2"
+)
+
+// pkcs11KmsPurposes are the purposes a "pkcs11" kms block can be used for.
+var pkcs11KmsPurposes = []string{
+	globals.KmsPurposeRoot,
+	globals.KmsPurposePreviousRoot,
+	globals.KmsPurposeWorkerAuth,
+	globals.KmsPurposeRecovery,
+}
+
+// isPkcs11Kms reports whether the kms block configures a PKCS#11 wrapper.
+func isPkcs11Kms(kms *configutil.KMS) bool {
+	return strings.EqualFold(kms.Type, wrapping.WrapperTypePkcs11.String())
+}
+
+// configureWrapper returns the wrapper for the kms block. PKCS#11 wrappers
+// are built into Boundary rather than run as plugins, so they are configured
+// here; all other types are configured through configutil.
+func configureWrapper(ctx context.Context, kms *configutil.KMS, infoKeys *[]string, info *map[string]string, opt ...configutil.Option) (wrapping.Wrapper, func() error, error) {
+	if !isPkcs11Kms(kms) {
+		return configutil.ConfigureWrapper(ctx, kms, infoKeys, info, opt...)
+	}
+	for _, purpose := range kms.Purpose {
+		if !slices.Contains(pkcs11KmsPurposes, strings.ToLower(purpose)) {
+			return nil, nil, fmt.Errorf("KMS type %q cannot be used for purpose %q, it is only supported for purposes %s", kms.Type, purpose, strings.Join(pkcs11KmsPurposes, ", "))
+		}
+	}
+
+	w := pkcs11.NewWrapper()
+	wrapperConfig, err := w.SetConfig(ctx, wrapping.WithConfigMap(kms.Config))
+	if err != nil {
+		return nil, nil, err
+	}
+	if infoKeys != nil && info != nil && *info != nil {
+		md := wrapperConfig.GetMetadata()
+		for _, v := range []struct{ key, name string }{
+			{pkcs11.ConfigLib, "PKCS#11 Library"},
+			{pkcs11.ConfigSlot, "PKCS#11 Slot"},
+			{pkcs11.ConfigTokenLabel, "PKCS#11 Token Label"},
+			{pkcs11.ConfigKeyLabel, "PKCS#11 Key Label"},
+		} {
+			if md[v.key] == "" {
+				continue
+			}
+			k := fmt.Sprintf("%v %s", kms.Purpose, v.name)
+			*infoKeys = append(*infoKeys, k)
+			(*info)[k] = md[v.key]
+		}
+	}
+	return w, nil, nil
+}
+
+// recoveryWrapperFromPath returns the wrapper of the kms block with the
+// recovery purpose in the config file at path, or nil if there is none.
+func recoveryWrapperFromPath(ctx context.Context, path string, opt ...configutil.Option) (wrapping.Wrapper, func() error, error) {
+	kmses, err := configutil.LoadConfigKMSes(path)
+	if err != nil {
+		return nil, nil, fmt.Errorf("Error parsing config file: %w", err)
+	}
+	for _, kms := range kmses {
+		if isPkcs11Kms(kms) && slices.Contains(kms.Purpose, globals.KmsPurposeRecovery) {
+			w, cleanup, err := configureWrapper(ctx, kms, nil, nil, opt...)
+			if err != nil {
+				return nil, nil, fmt.Errorf("Error configuring kms: %w", err)
+			}
+			return w, cleanup, nil
+		}
+	}
+	return wrapper.GetWrapperFromPath(ctx, path, globals.KmsPurposeRecovery, opt...)
+}
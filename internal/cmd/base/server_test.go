@@ -780,3 +780,45 @@ func TestSetupWorkerPublicAddress(t *testing.T) {
 		})
 	}
 }
+
+func TestServer_SetupKMSes_Pkcs11(t *testing.T) {
+	tests := []struct {
+		name            string
+		purposes        []string
+		config          map[string]string
+		wantErrContains string
+	}{
+		{
+			name:            "unsupported purpose",
+			purposes:        []string{globals.KmsPurposeBsr},
+			wantErrContains: fmt.Sprintf("cannot be used for purpose %q", globals.KmsPurposeBsr),
+		},
+		{
+			name:            "missing lib",
+			purposes:        []string{globals.KmsPurposeRoot},
+			config:          map[string]string{"key_label": "boundary-root", "slot": "0", "pin": "1234"},
+			wantErrContains: `missing "lib"`,
+		},
+	}
+	logger := hclog.Default()
+	serLock := new(sync.Mutex)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert, require := assert.New(t), require.New(t)
+			conf := &configutil.SharedConfig{
+				Seals: []*configutil.KMS{
+					{
+						Type:    "pkcs11",
+						Purpose: tt.purposes,
+						Config:  tt.config,
+					},
+				},
+			}
+			s := NewServer(&Command{Context: context.Background()})
+			require.NoError(s.SetupEventing(s.Context, logger, serLock, "setup-kms-testing"))
+			err := s.SetupKMSes(s.Context, cli.NewMockUi(), &config.Config{SharedConfig: conf})
+			require.Error(err)
+			assert.Contains(err.Error(), tt.wantErrContains)
+		})
+	}
+}
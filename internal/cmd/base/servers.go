@@ -596,7 +596,7 @@ func (b *Server) SetupKMSes(ctx context.Context, ui cli.Ui, config *config.Confi
 			origPurpose := kms.Purpose
 			kms.Purpose = []string{purpose}
 
-			wrapper, cleanupFunc, wrapperConfigError := configutil.ConfigureWrapper(
+			wrapper, cleanupFunc, wrapperConfigError := configureWrapper(
 				ctx,
 				kms,
 				&b.InfoKeys,
@@ -7,6 +7,7 @@ import (
 	"github.com/hashicorp/boundary/internal/cmd/base"
 	"github.com/hashicorp/boundary/internal/cmd/commands/accountscmd"
 	"github.com/hashicorp/boundary/internal/cmd/commands/aliasescmd"
+	"github.com/hashicorp/boundary/internal/cmd/commands/applycmd"
 	"github.com/hashicorp/boundary/internal/cmd/commands/authenticate"
 	"github.com/hashicorp/boundary/internal/cmd/commands/authmethodscmd"
 	"github.com/hashicorp/boundary/internal/cmd/commands/authtokenscmd"
@@ -25,6 +26,7 @@ import (
 	"github.com/hashicorp/boundary/internal/cmd/commands/hostsetscmd"
 	"github.com/hashicorp/boundary/internal/cmd/commands/logout"
 	"github.com/hashicorp/boundary/internal/cmd/commands/managedgroupscmd"
+	"github.com/hashicorp/boundary/internal/cmd/commands/oplogcmd"
 	"github.com/hashicorp/boundary/internal/cmd/commands/policiescmd"
 	"github.com/hashicorp/boundary/internal/cmd/commands/rolescmd"
 	"github.com/hashicorp/boundary/internal/cmd/commands/scopescmd"
@@ -33,6 +35,7 @@ import (
 	"github.com/hashicorp/boundary/internal/cmd/commands/sessionscmd"
 	"github.com/hashicorp/boundary/internal/cmd/commands/storagebucketscmd"
 	"github.com/hashicorp/boundary/internal/cmd/commands/targetscmd"
+	"github.com/hashicorp/boundary/internal/cmd/commands/targettemplatescmd"
 	"github.com/hashicorp/boundary/internal/cmd/commands/unsupported"
 	"github.com/hashicorp/boundary/internal/cmd/commands/userscmd"
 	"github.com/hashicorp/boundary/internal/cmd/commands/version"
@@ -55,6 +58,7 @@ func initCommands(ui, serverCmdUi cli.Ui, runOpts *RunOptions) {
 			return &server.Command{
 				Server:    base.NewServer(base.NewServerCommand(serverCmdUi)),
 				SighupCh:  base.MakeSighupCh(),
+				SigUSR1Ch: MakeSigUSR1Ch(),
 				SigUSR2Ch: MakeSigUSR2Ch(),
 			}, nil
 		},
@@ -224,6 +228,12 @@ func initCommands(ui, serverCmdUi cli.Ui, runOpts *RunOptions) {
 			}
 		}),
 
+		"apply": wrapper.Wrap(func() wrapper.WrappableCommand {
+			return &applycmd.ApplyCommand{
+				Command: base.NewCommand(ui, opts...),
+			}
+		}),
+
 		"auth-methods": func() (cli.Command, error) {
 			return &authmethodscmd.Command{
 				Command: base.NewCommand(ui, opts...),
@@ -567,6 +577,12 @@ func initCommands(ui, serverCmdUi cli.Ui, runOpts *RunOptions) {
 				Func:    "read",
 			}
 		}),
+		"credentials read-usage": wrapper.Wrap(func() wrapper.WrappableCommand {
+			return &credentialscmd.Command{
+				Command: base.NewCommand(ui, opts...),
+				Func:    "read-usage",
+			}
+		}),
 		"credentials delete": wrapper.Wrap(func() wrapper.WrappableCommand {
 			return &credentialscmd.Command{
 				Command: base.NewCommand(ui, opts...),
@@ -603,6 +619,12 @@ func initCommands(ui, serverCmdUi cli.Ui, runOpts *RunOptions) {
 				Func:    "create",
 			}
 		}),
+		"credentials create tls-client-certificate": wrapper.Wrap(func() wrapper.WrappableCommand {
+			return &credentialscmd.TlsClientCertificateCommand{
+				Command: base.NewCommand(ui, opts...),
+				Func:    "create",
+			}
+		}),
 		"credentials update": wrapper.Wrap(func() wrapper.WrappableCommand {
 			return &credentialscmd.Command{
 				Command: base.NewCommand(ui, opts...),
@@ -627,6 +649,12 @@ func initCommands(ui, serverCmdUi cli.Ui, runOpts *RunOptions) {
 				Func:    "update",
 			}
 		}),
+		"credentials update tls-client-certificate": wrapper.Wrap(func() wrapper.WrappableCommand {
+			return &credentialscmd.TlsClientCertificateCommand{
+				Command: base.NewCommand(ui, opts...),
+				Func:    "update",
+			}
+		}),
 
 		"daemon": func() (cli.Command, error) {
 			return &unsupported.UnsupportedCommand{
@@ -658,6 +686,12 @@ func initCommands(ui, serverCmdUi cli.Ui, runOpts *RunOptions) {
 			}, nil
 		},
 
+		"export": wrapper.Wrap(func() wrapper.WrappableCommand {
+			return &applycmd.ExportCommand{
+				Command: base.NewCommand(ui, opts...),
+			}
+		}),
+
 		"groups": func() (cli.Command, error) {
 			return &groupscmd.Command{
 				Command: base.NewCommand(ui, opts...),
@@ -964,6 +998,17 @@ func initCommands(ui, serverCmdUi cli.Ui, runOpts *RunOptions) {
 			}
 		}),
 
+		"oplog": func() (cli.Command, error) {
+			return &oplogcmd.Command{
+				Command: base.NewCommand(ui, opts...),
+			}, nil
+		},
+		"oplog tail": wrapper.Wrap(func() wrapper.WrappableCommand {
+			return &oplogcmd.TailCommand{
+				Command: base.NewCommand(ui, opts...),
+			}
+		}),
+
 		"policies": func() (cli.Command, error) {
 			return &policiescmd.Command{
 				Command: base.NewCommand(ui, opts...),
@@ -1207,6 +1252,17 @@ func initCommands(ui, serverCmdUi cli.Ui, runOpts *RunOptions) {
 				Func:    "cancel",
 			}
 		}),
+		"sessions bulk-cancel": wrapper.Wrap(func() wrapper.WrappableCommand {
+			return &sessionscmd.Command{
+				Command: base.NewCommand(ui, opts...),
+				Func:    "bulk-cancel",
+			}
+		}),
+		"sessions shadow": wrapper.Wrap(func() wrapper.WrappableCommand {
+			return &sessionscmd.ShadowCommand{
+				Command: base.NewCommand(ui, opts...),
+			}
+		}),
 
 		"session-recordings": func() (cli.Command, error) {
 			return &sessionrecordingscmd.Command{
@@ -1278,6 +1334,48 @@ func initCommands(ui, serverCmdUi cli.Ui, runOpts *RunOptions) {
 			}
 		}),
 
+		"target-templates": func() (cli.Command, error) {
+			return &targettemplatescmd.Command{
+				Command: base.NewCommand(ui, opts...),
+			}, nil
+		},
+		"target-templates create": wrapper.Wrap(func() wrapper.WrappableCommand {
+			return &targettemplatescmd.Command{
+				Command: base.NewCommand(ui, opts...),
+				Func:    "create",
+			}
+		}),
+		"target-templates read": wrapper.Wrap(func() wrapper.WrappableCommand {
+			return &targettemplatescmd.Command{
+				Command: base.NewCommand(ui, opts...),
+				Func:    "read",
+			}
+		}),
+		"target-templates update": wrapper.Wrap(func() wrapper.WrappableCommand {
+			return &targettemplatescmd.Command{
+				Command: base.NewCommand(ui, opts...),
+				Func:    "update",
+			}
+		}),
+		"target-templates delete": wrapper.Wrap(func() wrapper.WrappableCommand {
+			return &targettemplatescmd.Command{
+				Command: base.NewCommand(ui, opts...),
+				Func:    "delete",
+			}
+		}),
+		"target-templates list": wrapper.Wrap(func() wrapper.WrappableCommand {
+			return &targettemplatescmd.Command{
+				Command: base.NewCommand(ui, opts...),
+				Func:    "list",
+			}
+		}),
+		"target-templates list-out-of-sync-targets": wrapper.Wrap(func() wrapper.WrappableCommand {
+			return &targettemplatescmd.Command{
+				Command: base.NewCommand(ui, opts...),
+				Func:    "list-out-of-sync-targets",
+			}
+		}),
+
 		"targets": func() (cli.Command, error) {
 			return &targetscmd.Command{
 				Command: base.NewCommand(ui, opts...),
@@ -1325,6 +1423,12 @@ func initCommands(ui, serverCmdUi cli.Ui, runOpts *RunOptions) {
 				Func:    "create",
 			}
 		}),
+		"targets create udp": wrapper.Wrap(func() wrapper.WrappableCommand {
+			return &targetscmd.UdpCommand{
+				Command: base.NewCommand(ui, opts...),
+				Func:    "create",
+			}
+		}),
 		"targets update": wrapper.Wrap(func() wrapper.WrappableCommand {
 			return &targetscmd.Command{
 				Command: base.NewCommand(ui, opts...),
@@ -1343,6 +1447,12 @@ func initCommands(ui, serverCmdUi cli.Ui, runOpts *RunOptions) {
 				Func:    "update",
 			}
 		}),
+		"targets update udp": wrapper.Wrap(func() wrapper.WrappableCommand {
+			return &targetscmd.UdpCommand{
+				Command: base.NewCommand(ui, opts...),
+				Func:    "update",
+			}
+		}),
 		"targets add-host-sources": wrapper.Wrap(func() wrapper.WrappableCommand {
 			return &targetscmd.Command{
 				Command: base.NewCommand(ui, opts...),
@@ -1440,6 +1550,12 @@ func initCommands(ui, serverCmdUi cli.Ui, runOpts *RunOptions) {
 				Func:    "remove-accounts",
 			}
 		}),
+		"users read-session-quota": wrapper.Wrap(func() wrapper.WrappableCommand {
+			return &userscmd.Command{
+				Command: base.NewCommand(ui, opts...),
+				Func:    "read-session-quota",
+			}
+		}),
 
 		"workers": func() (cli.Command, error) {
 			return &workerscmd.Command{
@@ -1506,6 +1622,16 @@ func initCommands(ui, serverCmdUi cli.Ui, runOpts *RunOptions) {
 				Func:    "remove-worker-tags",
 			}
 		}),
+		"workers fleet-report": wrapper.Wrap(func() wrapper.WrappableCommand {
+			return &workerscmd.WorkerFleetReportCommand{
+				Command: base.NewCommand(ui, opts...),
+			}
+		}),
+		"workers topology": wrapper.Wrap(func() wrapper.WrappableCommand {
+			return &workerscmd.WorkerTopologyCommand{
+				Command: base.NewCommand(ui, opts...),
+			}
+		}),
 		"workers certificate-authority": wrapper.Wrap(func() wrapper.WrappableCommand {
 			return &workerscmd.WorkerCACommand{
 				Command: base.NewCommand(ui, opts...),
@@ -0,0 +1,222 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package applycmd
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/hashicorp/boundary/api"
+	"github.com/hashicorp/boundary/internal/cmd/base"
+	"github.com/mitchellh/cli"
+	"github.com/mitchellh/go-wordwrap"
+	"github.com/posener/complete"
+)
+
+var (
+	_ cli.Command             = (*ApplyCommand)(nil)
+	_ cli.CommandAutocomplete = (*ApplyCommand)(nil)
+)
+
+type ApplyCommand struct {
+	*base.Command
+
+	flagFile             string
+	flagDryRun           bool
+	flagAllowScopeDelete bool
+}
+
+func (c *ApplyCommand) Synopsis() string {
+	return wordwrap.WrapString("Bring the resources within a scope to the state in a configuration file", base.TermWidth)
+}
+
+func (c *ApplyCommand) Help() string {
+	return base.WrapForHelpText([]string{
+		"Usage: boundary apply -f <file> [options]",
+		"",
+		"  Create, update and delete the auth methods, roles, host catalogs, credential stores and targets within a scope and its child scopes so that they match a configuration file, as written by \"boundary export\". Resources are matched with the configuration by name, and resources without a name are left alone. A resource is not updated or deleted if it changed after it was read. Scopes which are not in the configuration are only deleted, along with everything within them, if -allow-scope-delete is set. Example:",
+		"",
+		`    $ boundary apply -scope-id global -f boundary.hcl`,
+		"",
+		"  Show the changes that would be made, without making them:",
+		"",
+		`    $ boundary apply -scope-id global -f boundary.hcl -dry-run`,
+		"",
+		"",
+	}) + c.Flags().Help()
+}
+
+func (c *ApplyCommand) Flags() *base.FlagSets {
+	set := c.FlagSet(base.FlagSetHTTP | base.FlagSetClient | base.FlagSetOutputFormat)
+	f := set.NewFlagSet("Command Options")
+
+	f.StringVar(&base.StringVar{
+		Name:    "scope-id",
+		Target:  &c.FlagScopeId,
+		EnvVar:  "BOUNDARY_SCOPE_ID",
+		Default: "global",
+		Usage:   "The id of the scope to apply the configuration to",
+	})
+	f.StringVar(&base.StringVar{
+		Name:       "file",
+		Aliases:    []string{"f"},
+		Target:     &c.flagFile,
+		Completion: complete.PredictFiles("*"),
+		Usage:      `The HCL or JSON configuration file to apply, or "-" to read it from standard input.`,
+	})
+	f.BoolVar(&base.BoolVar{
+		Name:   "dry-run",
+		Target: &c.flagDryRun,
+		Usage:  "If set, the changes are shown but not made.",
+	})
+	f.BoolVar(&base.BoolVar{
+		Name:   "allow-scope-delete",
+		Target: &c.flagAllowScopeDelete,
+		Usage:  "If set, scopes which are not in the configuration are deleted, along with every resource within them.",
+	})
+
+	return set
+}
+
+func (c *ApplyCommand) AutocompleteArgs() complete.Predictor {
+	return complete.PredictNothing
+}
+
+func (c *ApplyCommand) AutocompleteFlags() complete.Flags {
+	return c.Flags().Completions()
+}
+
+func (c *ApplyCommand) Run(args []string) int {
+	f := c.Flags()
+
+	if err := f.Parse(args); err != nil {
+		c.PrintCliError(err)
+		return base.CommandUserError
+	}
+	if c.FlagScopeId == "" {
+		c.PrintCliError(errors.New("Scope ID must be passed in via -scope-id or BOUNDARY_SCOPE_ID"))
+		return base.CommandUserError
+	}
+	if c.flagFile == "" {
+		c.PrintCliError(errors.New("A configuration file must be passed in via -file"))
+		return base.CommandUserError
+	}
+
+	var b []byte
+	var err error
+	if c.flagFile == "-" {
+		b, err = io.ReadAll(os.Stdin)
+	} else {
+		b, err = os.ReadFile(c.flagFile)
+	}
+	if err != nil {
+		c.PrintCliError(fmt.Errorf("Error reading the configuration file: %w", err))
+		return base.CommandUserError
+	}
+	desired, err := parseConfig(b, "")
+	if err != nil {
+		c.PrintCliError(fmt.Errorf("Error in the configuration file: %w", err))
+		return base.CommandUserError
+	}
+
+	client, err := c.Client()
+	if c.WrapperCleanupFunc != nil {
+		defer func() {
+			if err := c.WrapperCleanupFunc(); err != nil {
+				c.PrintCliError(fmt.Errorf("Error cleaning kms wrapper: %w", err))
+			}
+		}()
+	}
+	if err != nil {
+		c.PrintCliError(fmt.Errorf("Error creating API client: %w", err))
+		return base.CommandCliError
+	}
+
+	r := &stateReader{client: client}
+	current, err := r.read(c.Context, c.FlagScopeId)
+	if err != nil {
+		if apiErr := api.AsServerError(err); apiErr != nil {
+			c.PrintApiError(apiErr, "Error from controller when reading the current configuration")
+			return base.CommandApiError
+		}
+		c.PrintCliError(fmt.Errorf("Error trying to read the current configuration: %w", err))
+		return base.CommandCliError
+	}
+	changes, err := plan(client, desired, current)
+	if err != nil {
+		c.PrintCliError(fmt.Errorf("Error in the configuration file: %w", err))
+		return base.CommandUserError
+	}
+
+	deletedScopes := scopeDeletes(changes)
+	if len(deletedScopes) > 0 && !c.flagAllowScopeDelete && !c.flagDryRun {
+		c.PrintCliError(fmt.Errorf("The configuration would delete the scopes %q and every resource within them. Use -dry-run to show the resources which would be deleted, and -allow-scope-delete to delete them", deletedScopes))
+		return base.CommandUserError
+	}
+
+	if !c.flagDryRun {
+		for i, ch := range changes {
+			if err := ch.apply(c.Context); err != nil {
+				if i > 0 && base.Format(c.UI) == "table" {
+					c.printResult(changes[:i], false)
+				}
+				if apiErr := api.AsServerError(err); apiErr != nil {
+					c.PrintApiError(apiErr, fmt.Sprintf("Error from controller when trying to %s", ch.summary()))
+					return base.CommandApiError
+				}
+				c.PrintCliError(fmt.Errorf("Error trying to %s: %w", ch.summary(), err))
+				return base.CommandCliError
+			}
+		}
+	}
+	if !c.printResult(changes, c.flagDryRun) {
+		return base.CommandCliError
+	}
+	if len(deletedScopes) > 0 && !c.flagAllowScopeDelete && base.Format(c.UI) == "table" {
+		c.UI.Warn("\nDeleting scopes requires -allow-scope-delete.")
+	}
+	return base.CommandSuccess
+}
+
+// printResult prints the changes which were made, or which would be made if
+// dryRun is set.
+func (c *ApplyCommand) printResult(changes []*change, dryRun bool) bool {
+	switch base.Format(c.UI) {
+	case "json":
+		if changes == nil {
+			changes = []*change{}
+		}
+		b, err := json.Marshal(struct {
+			DryRun  bool      `json:"dry_run"`
+			Changes []*change `json:"changes"`
+		}{
+			DryRun:  dryRun,
+			Changes: changes,
+		})
+		if err != nil {
+			c.PrintCliError(fmt.Errorf("Error formatting as JSON: %w", err))
+			return false
+		}
+		return c.PrintJson(b)
+
+	default:
+		counts := make(map[string]int)
+		for _, ch := range changes {
+			c.UI.Output(ch.String())
+			counts[ch.Action]++
+		}
+		switch {
+		case len(changes) == 0:
+			c.UI.Output("No changes; the resources match the configuration.")
+		case dryRun:
+			c.UI.Output(fmt.Sprintf("\nDry run: %d to create, %d to update, %d to delete.", counts[actionCreate], counts[actionUpdate], counts[actionDelete]))
+		default:
+			c.UI.Output(fmt.Sprintf("\n%d created, %d updated, %d deleted.", counts[actionCreate], counts[actionUpdate], counts[actionDelete]))
+		}
+		return true
+	}
+}
@@ -0,0 +1,484 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package applycmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/hcl"
+	"github.com/hashicorp/hcl/hcl/printer"
+)
+
+const (
+	formatHCL  = "hcl"
+	formatJSON = "json"
+)
+
+// Config is the configuration of the resources within a scope. The top level
+// of a configuration file describes the contents of the scope it is exported
+// from or applied to, and each nested scope describes the contents of a child
+// scope. Resources are identified by their name, which must be unique among
+// the resources of the same kind within their parent.
+type Config struct {
+	AuthMethods      []*AuthMethod      `hcl:"auth_method" json:"auth_methods,omitempty"`
+	Roles            []*Role            `hcl:"role" json:"roles,omitempty"`
+	HostCatalogs     []*HostCatalog     `hcl:"host_catalog" json:"host_catalogs,omitempty"`
+	CredentialStores []*CredentialStore `hcl:"credential_store" json:"credential_stores,omitempty"`
+	Targets          []*Target          `hcl:"target" json:"targets,omitempty"`
+	Scopes           []*Scope           `hcl:"scope" json:"scopes,omitempty"`
+}
+
+// Scope is the configuration of a child scope and the resources within it.
+type Scope struct {
+	Name        string `hcl:",key" json:"name"`
+	Description string `hcl:"description" json:"description,omitempty"`
+	Config      `hcl:",squash"`
+
+	id      string
+	version uint32
+	// unnamed is the number of resources directly within the scope which
+	// were left out of its configuration because they have no name.
+	unnamed int
+}
+
+// AuthMethod is the configuration of an auth method.
+type AuthMethod struct {
+	Name        string         `hcl:",key" json:"name"`
+	Type        string         `hcl:"type" json:"type"`
+	Description string         `hcl:"description" json:"description,omitempty"`
+	Attributes  map[string]any `hcl:"attributes" json:"attributes,omitempty"`
+
+	id      string
+	version uint32
+}
+
+// Role is the configuration of a role. The principals of a role are not part
+// of its configuration, since the users and groups they refer to are not
+// either.
+type Role struct {
+	Name          string   `hcl:",key" json:"name"`
+	Description   string   `hcl:"description" json:"description,omitempty"`
+	GrantScopeIds []string `hcl:"grant_scope_ids" json:"grant_scope_ids,omitempty"`
+	GrantStrings  []string `hcl:"grant_strings" json:"grant_strings,omitempty"`
+
+	id      string
+	version uint32
+}
+
+// HostCatalog is the configuration of a host catalog and the hosts and host
+// sets within it. Hosts can only be configured in static host catalogs.
+type HostCatalog struct {
+	Name        string         `hcl:",key" json:"name"`
+	Type        string         `hcl:"type" json:"type"`
+	PluginName  string         `hcl:"plugin_name" json:"plugin_name,omitempty"`
+	Description string         `hcl:"description" json:"description,omitempty"`
+	Attributes  map[string]any `hcl:"attributes" json:"attributes,omitempty"`
+	Secrets     map[string]any `hcl:"secrets" json:"secrets,omitempty"`
+	Hosts       []*Host        `hcl:"host" json:"hosts,omitempty"`
+	HostSets    []*HostSet     `hcl:"host_set" json:"host_sets,omitempty"`
+
+	id      string
+	version uint32
+}
+
+// Host is the configuration of a host in a static host catalog.
+type Host struct {
+	Name        string         `hcl:",key" json:"name"`
+	Description string         `hcl:"description" json:"description,omitempty"`
+	Attributes  map[string]any `hcl:"attributes" json:"attributes,omitempty"`
+
+	id      string
+	version uint32
+}
+
+// HostSet is the configuration of a host set. Hosts holds the names of the
+// hosts in a static host set.
+type HostSet struct {
+	Name        string         `hcl:",key" json:"name"`
+	Description string         `hcl:"description" json:"description,omitempty"`
+	Attributes  map[string]any `hcl:"attributes" json:"attributes,omitempty"`
+	Hosts       []string       `hcl:"hosts" json:"hosts,omitempty"`
+
+	id      string
+	version uint32
+}
+
+// CredentialStore is the configuration of a credential store and the
+// credential libraries within it. Credential libraries can only be configured
+// in Vault credential stores.
+type CredentialStore struct {
+	Name                string               `hcl:",key" json:"name"`
+	Type                string               `hcl:"type" json:"type"`
+	Description         string               `hcl:"description" json:"description,omitempty"`
+	Attributes          map[string]any       `hcl:"attributes" json:"attributes,omitempty"`
+	CredentialLibraries []*CredentialLibrary `hcl:"credential_library" json:"credential_libraries,omitempty"`
+
+	id      string
+	version uint32
+}
+
+// CredentialLibrary is the configuration of a credential library.
+type CredentialLibrary struct {
+	Name           string         `hcl:",key" json:"name"`
+	Type           string         `hcl:"type" json:"type"`
+	Description    string         `hcl:"description" json:"description,omitempty"`
+	CredentialType string         `hcl:"credential_type" json:"credential_type,omitempty"`
+	Attributes     map[string]any `hcl:"attributes" json:"attributes,omitempty"`
+
+	id      string
+	version uint32
+}
+
+// Target is the configuration of a target. Host sources refer to host sets as
+// "<host catalog name>/<host set name>" and credential sources refer to
+// credential libraries as "<credential store name>/<credential library name>",
+// both within the target's scope. Sources which are not part of the
+// configuration are referred to by their ID.
+type Target struct {
+	Name                                 string         `hcl:",key" json:"name"`
+	Type                                 string         `hcl:"type" json:"type"`
+	Description                          string         `hcl:"description" json:"description,omitempty"`
+	Address                              string         `hcl:"address" json:"address,omitempty"`
+	SessionMaxSeconds                    int            `hcl:"session_max_seconds" json:"session_max_seconds,omitempty"`
+	SessionConnectionLimit               int            `hcl:"session_connection_limit" json:"session_connection_limit,omitempty"`
+	EgressWorkerFilter                   string         `hcl:"egress_worker_filter" json:"egress_worker_filter,omitempty"`
+	IngressWorkerFilter                  string         `hcl:"ingress_worker_filter" json:"ingress_worker_filter,omitempty"`
+	Attributes                           map[string]any `hcl:"attributes" json:"attributes,omitempty"`
+	HostSources                          []string       `hcl:"host_sources" json:"host_sources,omitempty"`
+	BrokeredCredentialSources            []string       `hcl:"brokered_credential_sources" json:"brokered_credential_sources,omitempty"`
+	InjectedApplicationCredentialSources []string       `hcl:"injected_application_credential_sources" json:"injected_application_credential_sources,omitempty"`
+
+	id      string
+	version uint32
+}
+
+// parseConfig parses a configuration in the given format. If the format is
+// empty, a configuration starting with "{" is parsed as JSON and any other
+// configuration as HCL.
+func parseConfig(b []byte, format string) (*Config, error) {
+	if format == "" {
+		format = formatHCL
+		if bytes.HasPrefix(bytes.TrimSpace(b), []byte("{")) {
+			format = formatJSON
+		}
+	}
+
+	cfg := new(Config)
+	switch format {
+	case formatJSON:
+		if err := json.Unmarshal(b, cfg); err != nil {
+			return nil, fmt.Errorf("error parsing JSON configuration: %w", err)
+		}
+	case formatHCL:
+		if err := hcl.Decode(cfg, string(b)); err != nil {
+			return nil, fmt.Errorf("error parsing HCL configuration: %w", err)
+		}
+		// HCL decodes nested objects as lists of objects and integers as
+		// ints. Round trip through JSON so that attribute values have the
+		// same types as the ones read from the API.
+		collapseObjects(reflect.ValueOf(cfg))
+		js, err := json.Marshal(cfg)
+		if err != nil {
+			return nil, fmt.Errorf("error normalizing HCL configuration: %w", err)
+		}
+		cfg = new(Config)
+		if err := json.Unmarshal(js, cfg); err != nil {
+			return nil, fmt.Errorf("error normalizing HCL configuration: %w", err)
+		}
+	default:
+		return nil, fmt.Errorf("unknown configuration format %q", format)
+	}
+
+	if err := cfg.validate(); err != nil {
+		return nil, err
+	}
+	return cfg, nil
+}
+
+// collapseObjects replaces the single element lists of objects HCL decodes
+// nested objects into with the object, in every map within v.
+func collapseObjects(v reflect.Value) {
+	switch v.Kind() {
+	case reflect.Pointer:
+		if !v.IsNil() {
+			collapseObjects(v.Elem())
+		}
+	case reflect.Struct:
+		for i := 0; i < v.NumField(); i++ {
+			if v.Type().Field(i).IsExported() {
+				collapseObjects(v.Field(i))
+			}
+		}
+	case reflect.Slice:
+		for i := 0; i < v.Len(); i++ {
+			collapseObjects(v.Index(i))
+		}
+	case reflect.Map:
+		if m, ok := v.Interface().(map[string]any); ok {
+			for k, val := range m {
+				m[k] = collapseValue(val)
+			}
+		}
+	}
+}
+
+func collapseValue(v any) any {
+	switch v := v.(type) {
+	case []map[string]any:
+		if len(v) == 1 {
+			return collapseValue(v[0])
+		}
+		l := make([]any, 0, len(v))
+		for _, e := range v {
+			l = append(l, collapseValue(e))
+		}
+		return l
+	case []any:
+		for i, e := range v {
+			v[i] = collapseValue(e)
+		}
+		return v
+	case map[string]any:
+		for k, e := range v {
+			v[k] = collapseValue(e)
+		}
+		return v
+	default:
+		return v
+	}
+}
+
+// validate checks that every resource in the configuration has a name which is
+// unique among its siblings, and a type where one is required.
+func (c *Config) validate() error {
+	return c.validateIn("")
+}
+
+func (c *Config) validateIn(path string) error {
+	kinds := []struct {
+		kind  string
+		names []string
+		types []string
+	}{
+		{kind: "auth method", names: keys(c.AuthMethods), types: types(c.AuthMethods)},
+		{kind: "role", names: keys(c.Roles)},
+		{kind: "host catalog", names: keys(c.HostCatalogs), types: types(c.HostCatalogs)},
+		{kind: "credential store", names: keys(c.CredentialStores), types: types(c.CredentialStores)},
+		{kind: "target", names: keys(c.Targets), types: types(c.Targets)},
+		{kind: "scope", names: keys(c.Scopes)},
+	}
+	for _, k := range kinds {
+		if err := validateNames(k.kind, path, k.names, k.types); err != nil {
+			return err
+		}
+	}
+	for _, hc := range c.HostCatalogs {
+		p := joinPath(path, hc.Name)
+		if err := validateNames("host", p, keys(hc.Hosts), nil); err != nil {
+			return err
+		}
+		if err := validateNames("host set", p, keys(hc.HostSets), nil); err != nil {
+			return err
+		}
+	}
+	for _, cs := range c.CredentialStores {
+		if err := validateNames("credential library", joinPath(path, cs.Name), keys(cs.CredentialLibraries), types(cs.CredentialLibraries)); err != nil {
+			return err
+		}
+	}
+	for _, s := range c.Scopes {
+		if err := s.validateIn(joinPath(path, s.Name)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func validateNames(kind, path string, names, types []string) error {
+	seen := make(map[string]bool, len(names))
+	for i, n := range names {
+		if n == "" {
+			return fmt.Errorf("%s without a name in %q", kind, path)
+		}
+		if seen[n] {
+			return fmt.Errorf("duplicate %s %q", kind, joinPath(path, n))
+		}
+		seen[n] = true
+		if types != nil && types[i] == "" {
+			return fmt.Errorf("%s %q has no type", kind, joinPath(path, n))
+		}
+	}
+	return nil
+}
+
+// joinPath returns the path of the resource with the given name within the
+// resource or scope at path.
+func joinPath(path, name string) string {
+	if path == "" {
+		return name
+	}
+	return path + "/" + name
+}
+
+// encodeConfig encodes a configuration in the given format.
+func encodeConfig(cfg *Config, format string) ([]byte, error) {
+	switch format {
+	case formatJSON:
+		b, err := json.MarshalIndent(cfg, "", "  ")
+		if err != nil {
+			return nil, err
+		}
+		return append(b, '\n'), nil
+	case formatHCL:
+		var buf bytes.Buffer
+		writeHCLBody(&buf, reflect.ValueOf(cfg).Elem())
+		return printer.Format(buf.Bytes())
+	default:
+		return nil, fmt.Errorf("unknown configuration format %q", format)
+	}
+}
+
+// writeHCLBody writes the fields of the struct v as HCL attributes and blocks,
+// using their hcl tags. Zero values are omitted.
+func writeHCLBody(buf *bytes.Buffer, v reflect.Value) {
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if !f.IsExported() {
+			continue
+		}
+		name, opt, _ := strings.Cut(f.Tag.Get("hcl"), ",")
+		fv := v.Field(i)
+		switch {
+		case opt == "key":
+		case opt == "squash":
+			writeHCLBody(buf, fv)
+		case fv.Kind() == reflect.Slice && fv.Type().Elem().Kind() == reflect.Pointer:
+			for j := 0; j < fv.Len(); j++ {
+				block := fv.Index(j).Elem()
+				fmt.Fprintf(buf, "\n%s %s {\n", name, strconv.Quote(blockKey(block)))
+				writeHCLBody(buf, block)
+				buf.WriteString("}\n")
+			}
+		case fv.IsZero() || (fv.Kind() == reflect.Slice || fv.Kind() == reflect.Map) && fv.Len() == 0:
+		default:
+			fmt.Fprintf(buf, "%s = ", name)
+			writeHCLValue(buf, fv.Interface())
+			buf.WriteString("\n")
+		}
+	}
+}
+
+// blockKey returns the value of the field of the struct v tagged as the HCL
+// block key.
+func blockKey(v reflect.Value) string {
+	for i := 0; i < v.NumField(); i++ {
+		if _, opt, _ := strings.Cut(v.Type().Field(i).Tag.Get("hcl"), ","); opt == "key" {
+			return v.Field(i).String()
+		}
+	}
+	return ""
+}
+
+var hclIdentifier = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_-]*$`)
+
+func writeHCLValue(buf *bytes.Buffer, v any) {
+	switch v := v.(type) {
+	case string:
+		buf.WriteString(strconv.Quote(v))
+	case bool:
+		buf.WriteString(strconv.FormatBool(v))
+	case int:
+		buf.WriteString(strconv.Itoa(v))
+	case float64:
+		buf.WriteString(strconv.FormatFloat(v, 'f', -1, 64))
+	case []string:
+		l := make([]any, 0, len(v))
+		for _, e := range v {
+			l = append(l, e)
+		}
+		writeHCLValue(buf, l)
+	case []any:
+		buf.WriteString("[")
+		for i, e := range v {
+			if i > 0 {
+				buf.WriteString(", ")
+			}
+			writeHCLValue(buf, e)
+		}
+		buf.WriteString("]")
+	case map[string]any:
+		ks := make([]string, 0, len(v))
+		for k, e := range v {
+			// HCL has no null value, so attributes without a value are
+			// left out.
+			if e != nil {
+				ks = append(ks, k)
+			}
+		}
+		sort.Strings(ks)
+		buf.WriteString("{\n")
+		for _, k := range ks {
+			name := k
+			if !hclIdentifier.MatchString(name) {
+				name = strconv.Quote(name)
+			}
+			fmt.Fprintf(buf, "%s = ", name)
+			writeHCLValue(buf, v[k])
+			buf.WriteString("\n")
+		}
+		buf.WriteString("}")
+	default:
+		// Values decoded from JSON are always one of the types above.
+		b, _ := json.Marshal(v)
+		buf.Write(b)
+	}
+}
+
+type resource interface {
+	key() string
+	typ() string
+}
+
+func keys[T resource](rs []T) []string {
+	ks := make([]string, 0, len(rs))
+	for _, r := range rs {
+		ks = append(ks, r.key())
+	}
+	return ks
+}
+
+func types[T resource](rs []T) []string {
+	ts := make([]string, 0, len(rs))
+	for _, r := range rs {
+		ts = append(ts, r.typ())
+	}
+	return ts
+}
+
+func (s *Scope) key() string             { return s.Name }
+func (s *Scope) typ() string             { return "" }
+func (a *AuthMethod) key() string        { return a.Name }
+func (a *AuthMethod) typ() string        { return a.Type }
+func (r *Role) key() string              { return r.Name }
+func (r *Role) typ() string              { return "" }
+func (h *HostCatalog) key() string       { return h.Name }
+func (h *HostCatalog) typ() string       { return h.Type }
+func (h *Host) key() string              { return h.Name }
+func (h *Host) typ() string              { return "" }
+func (h *HostSet) key() string           { return h.Name }
+func (h *HostSet) typ() string           { return "" }
+func (c *CredentialStore) key() string   { return c.Name }
+func (c *CredentialStore) typ() string   { return c.Type }
+func (c *CredentialLibrary) key() string { return c.Name }
+func (c *CredentialLibrary) typ() string { return c.Type }
+func (t *Target) key() string            { return t.Name }
+func (t *Target) typ() string            { return t.Type }
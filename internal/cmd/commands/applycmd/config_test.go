@@ -0,0 +1,180 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package applycmd
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testConfigHCL = `
+auth_method "password" {
+  type = "password"
+
+  attributes = {
+    min_password_length = 12
+  }
+}
+
+role "admins" {
+  grant_scope_ids = ["this", "descendants"]
+  grant_strings   = ["ids=*;type=*;actions=*"]
+}
+
+scope "org" {
+  description = "An \"org\"\nscope"
+
+  scope "project" {
+    host_catalog "static" {
+      type = "static"
+
+      host "db" {
+        attributes = {
+          address = "10.0.0.1"
+        }
+      }
+
+      host_set "dbs" {
+        hosts = ["db"]
+      }
+    }
+
+    target "postgres" {
+      type                     = "tcp"
+      session_connection_limit = -1
+
+      attributes = {
+        default_port = 5432
+
+        nested = {
+          "key with spaces" = [1, "two"]
+        }
+      }
+
+      host_sources = ["static/dbs"]
+    }
+  }
+}
+`
+
+func testConfig() *Config {
+	return &Config{
+		AuthMethods: []*AuthMethod{{
+			Name:       "password",
+			Type:       "password",
+			Attributes: map[string]any{"min_password_length": float64(12)},
+		}},
+		Roles: []*Role{{
+			Name:          "admins",
+			GrantScopeIds: []string{"this", "descendants"},
+			GrantStrings:  []string{"ids=*;type=*;actions=*"},
+		}},
+		Scopes: []*Scope{{
+			Name:        "org",
+			Description: "An \"org\"\nscope",
+			Config: Config{
+				Scopes: []*Scope{{
+					Name: "project",
+					Config: Config{
+						HostCatalogs: []*HostCatalog{{
+							Name: "static",
+							Type: "static",
+							Hosts: []*Host{{
+								Name:       "db",
+								Attributes: map[string]any{"address": "10.0.0.1"},
+							}},
+							HostSets: []*HostSet{{
+								Name:  "dbs",
+								Hosts: []string{"db"},
+							}},
+						}},
+						Targets: []*Target{{
+							Name:                   "postgres",
+							Type:                   "tcp",
+							SessionConnectionLimit: -1,
+							Attributes: map[string]any{
+								"default_port": float64(5432),
+								"nested":       map[string]any{"key with spaces": []any{float64(1), "two"}},
+							},
+							HostSources: []string{"static/dbs"},
+						}},
+					},
+				}},
+			},
+		}},
+	}
+}
+
+func TestParseConfig(t *testing.T) {
+	cfg, err := parseConfig([]byte(testConfigHCL), "")
+	require.NoError(t, err)
+	assert.Equal(t, testConfig(), cfg)
+
+	js, err := encodeConfig(cfg, formatJSON)
+	require.NoError(t, err)
+	cfg, err = parseConfig(js, "")
+	require.NoError(t, err)
+	assert.Equal(t, testConfig(), cfg)
+}
+
+func TestEncodeConfig(t *testing.T) {
+	b, err := encodeConfig(testConfig(), formatHCL)
+	require.NoError(t, err)
+	assert.Equal(t, testConfigHCL[1:], string(b))
+
+	cfg, err := parseConfig(b, formatHCL)
+	require.NoError(t, err)
+	assert.Equal(t, testConfig(), cfg)
+}
+
+func TestParseConfig_Invalid(t *testing.T) {
+	tests := []struct {
+		name    string
+		config  string
+		wantErr string
+	}{
+		{
+			name:    "syntax",
+			config:  `role "a" {`,
+			wantErr: "error parsing HCL configuration",
+		},
+		{
+			name:    "json-syntax",
+			config:  `{"roles": [`,
+			wantErr: "error parsing JSON configuration",
+		},
+		{
+			name:    "duplicate",
+			config:  `scope "org" { role "a" {} role "a" {} }`,
+			wantErr: `duplicate role "org/a"`,
+		},
+		{
+			name:    "no-name",
+			config:  `{"scopes": [{"name": "org", "targets": [{"type": "tcp"}]}]}`,
+			wantErr: `target without a name in "org"`,
+		},
+		{
+			name: "no-type",
+			config: `
+scope "org" {
+  scope "p" {
+    credential_store "vault" {
+      type = "vault"
+      credential_library "kv" {}
+    }
+  }
+}`,
+			wantErr: `credential library "org/p/vault/kv" has no type`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := parseConfig([]byte(tt.config), "")
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.wantErr)
+		})
+	}
+}
@@ -0,0 +1,198 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package applycmd
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/hashicorp/boundary/api"
+	"github.com/hashicorp/boundary/internal/cmd/base"
+	"github.com/mitchellh/cli"
+	"github.com/mitchellh/go-wordwrap"
+	"github.com/posener/complete"
+)
+
+var (
+	_ cli.Command             = (*ExportCommand)(nil)
+	_ cli.CommandAutocomplete = (*ExportCommand)(nil)
+)
+
+type ExportCommand struct {
+	*base.Command
+
+	flagFormat string
+	flagOutput string
+}
+
+func (c *ExportCommand) Synopsis() string {
+	return wordwrap.WrapString("Export the configuration of the resources within a scope", base.TermWidth)
+}
+
+func (c *ExportCommand) Help() string {
+	return base.WrapForHelpText([]string{
+		"Usage: boundary export [options]",
+		"",
+		"  Export the configuration of the auth methods, roles, host catalogs, credential stores and targets within a scope and its child scopes, in a form that can be applied with \"boundary apply\". Resources without a name are left out. Example:",
+		"",
+		`    $ boundary export -scope-id global -output boundary.hcl`,
+		"",
+		"",
+	}) + c.Flags().Help()
+}
+
+func (c *ExportCommand) Flags() *base.FlagSets {
+	set := c.FlagSet(base.FlagSetHTTP | base.FlagSetClient)
+	f := set.NewFlagSet("Command Options")
+
+	f.StringVar(&base.StringVar{
+		Name:    "scope-id",
+		Target:  &c.FlagScopeId,
+		EnvVar:  "BOUNDARY_SCOPE_ID",
+		Default: "global",
+		Usage:   "The id of the scope to export",
+	})
+	f.StringVar(&base.StringVar{
+		Name:       "format",
+		Target:     &c.flagFormat,
+		Default:    formatHCL,
+		Completion: complete.PredictSet(formatHCL, formatJSON),
+		Usage:      `The format of the exported configuration. Valid values are "hcl" or "json".`,
+	})
+	f.StringVar(&base.StringVar{
+		Name:       "output",
+		Target:     &c.flagOutput,
+		Completion: complete.PredictFiles("*"),
+		Usage:      "The file to write the configuration to. If not set, the configuration is written to standard output.",
+	})
+
+	return set
+}
+
+func (c *ExportCommand) AutocompleteArgs() complete.Predictor {
+	return complete.PredictNothing
+}
+
+func (c *ExportCommand) AutocompleteFlags() complete.Flags {
+	return c.Flags().Completions()
+}
+
+func (c *ExportCommand) Run(args []string) int {
+	f := c.Flags()
+
+	if err := f.Parse(args); err != nil {
+		c.PrintCliError(err)
+		return base.CommandUserError
+	}
+	if c.FlagScopeId == "" {
+		c.PrintCliError(errors.New("Scope ID must be passed in via -scope-id or BOUNDARY_SCOPE_ID"))
+		return base.CommandUserError
+	}
+	switch c.flagFormat {
+	case formatHCL, formatJSON:
+	default:
+		c.PrintCliError(fmt.Errorf("Unknown configuration format %q, must be %q or %q", c.flagFormat, formatHCL, formatJSON))
+		return base.CommandUserError
+	}
+
+	client, err := c.Client()
+	if c.WrapperCleanupFunc != nil {
+		defer func() {
+			if err := c.WrapperCleanupFunc(); err != nil {
+				c.PrintCliError(fmt.Errorf("Error cleaning kms wrapper: %w", err))
+			}
+		}()
+	}
+	if err != nil {
+		c.PrintCliError(fmt.Errorf("Error creating API client: %w", err))
+		return base.CommandCliError
+	}
+
+	r := &stateReader{client: client}
+	current, err := r.read(c.Context, c.FlagScopeId)
+	if err != nil {
+		if apiErr := api.AsServerError(err); apiErr != nil {
+			c.PrintApiError(apiErr, "Error from controller when reading the configuration")
+			return base.CommandApiError
+		}
+		c.PrintCliError(fmt.Errorf("Error trying to read the configuration: %w", err))
+		return base.CommandCliError
+	}
+	for _, s := range r.skipped {
+		c.UI.Warn(fmt.Sprintf("Skipping %s", s))
+	}
+
+	b, err := encodeConfig(exportable(&current.Config), c.flagFormat)
+	if err != nil {
+		c.PrintCliError(fmt.Errorf("Error encoding the configuration: %w", err))
+		return base.CommandCliError
+	}
+	if c.flagOutput == "" {
+		c.UI.Output(strings.TrimSuffix(string(b), "\n"))
+		return base.CommandSuccess
+	}
+	if err := os.WriteFile(c.flagOutput, b, 0o600); err != nil {
+		c.PrintCliError(fmt.Errorf("Error writing the configuration: %w", err))
+		return base.CommandCliError
+	}
+	return base.CommandSuccess
+}
+
+// readOnlyAttributes holds the attributes of each type of resource which are
+// read from the controller but cannot be set.
+var readOnlyAttributes = map[string][]string{
+	"oidc":  {"callback_url", "state"},
+	"vault": {"token_status"},
+}
+
+// exportable removes the attributes which cannot be set from the current
+// configuration cfg, so that it can be applied.
+func exportable(cfg *Config) *Config {
+	for _, am := range cfg.AuthMethods {
+		am.Attributes = settableAttributes(am.Attributes, am.Type)
+	}
+	for _, hc := range cfg.HostCatalogs {
+		hc.Attributes = settableAttributes(hc.Attributes, hc.Type)
+		for _, h := range hc.Hosts {
+			h.Attributes = settableAttributes(h.Attributes, "")
+		}
+		for _, hs := range hc.HostSets {
+			hs.Attributes = settableAttributes(hs.Attributes, "")
+		}
+	}
+	for _, cs := range cfg.CredentialStores {
+		cs.Attributes = settableAttributes(cs.Attributes, cs.Type)
+		for _, cl := range cs.CredentialLibraries {
+			cl.Attributes = settableAttributes(cl.Attributes, "")
+		}
+	}
+	for _, t := range cfg.Targets {
+		t.Attributes = settableAttributes(t.Attributes, "")
+	}
+	for _, s := range cfg.Scopes {
+		exportable(&s.Config)
+	}
+	return cfg
+}
+
+// settableAttributes returns the attributes of a resource of the given type
+// without the ones which cannot be set, including the HMACs of the attributes
+// which cannot be read.
+func settableAttributes(attrs map[string]any, typ string) map[string]any {
+	out := make(map[string]any, len(attrs))
+	for k, v := range attrs {
+		if !strings.HasSuffix(k, "_hmac") {
+			out[k] = v
+		}
+	}
+	for _, k := range readOnlyAttributes[typ] {
+		delete(out, k)
+	}
+	if len(out) == 0 {
+		return nil
+	}
+	return out
+}
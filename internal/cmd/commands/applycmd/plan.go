@@ -0,0 +1,1126 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package applycmd
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/boundary/api"
+	"github.com/hashicorp/boundary/api/authmethods"
+	"github.com/hashicorp/boundary/api/credentiallibraries"
+	"github.com/hashicorp/boundary/api/credentialstores"
+	"github.com/hashicorp/boundary/api/hostcatalogs"
+	"github.com/hashicorp/boundary/api/hosts"
+	"github.com/hashicorp/boundary/api/hostsets"
+	"github.com/hashicorp/boundary/api/roles"
+	"github.com/hashicorp/boundary/api/scopes"
+	"github.com/hashicorp/boundary/api/targets"
+)
+
+const (
+	actionCreate = "create"
+	actionUpdate = "update"
+	actionDelete = "delete"
+)
+
+// change is a change to a single resource, which is part of bringing the
+// resources within a scope to their configured state.
+type change struct {
+	Action   string   `json:"action"`
+	Resource string   `json:"resource"`
+	Path     string   `json:"path"`
+	Fields   []string `json:"fields,omitempty"`
+	// Cascades describes the resources which are deleted along with a
+	// deleted scope.
+	Cascades []string `json:"cascades,omitempty"`
+
+	apply func(context.Context) error
+}
+
+func (c *change) String() string {
+	var sym string
+	switch c.Action {
+	case actionCreate:
+		sym = "+"
+	case actionUpdate:
+		sym = "~"
+	case actionDelete:
+		sym = "-"
+	}
+	s := sym + " " + c.summary()
+	if len(c.Fields) > 0 {
+		s += " (" + strings.Join(c.Fields, ", ") + ")"
+	}
+	for _, r := range c.Cascades {
+		s += "\n    deletes " + r
+	}
+	return s
+}
+
+// summary describes the change without the fields it changes.
+func (c *change) summary() string {
+	return fmt.Sprintf("%s %s %q", c.Action, c.Resource, c.Path)
+}
+
+// planner computes the changes between the configured and the current state
+// of the resources within a scope. The changes refer to the configured
+// resources, which are given the ID and version of the resource they are
+// matched with or created as, so that later changes can refer to them.
+type planner struct {
+	client *api.Client
+
+	changes []*change
+	deletes []*change
+}
+
+// plan returns the changes which bring the resources within the scope current
+// was read from to the configuration in desired. Resources are created and
+// updated before the resources within them and the resources referring to
+// them. Resources are deleted last, so that the resources referring to them
+// have been updated first. Deleting a scope or a resource that contains other
+// resources deletes those resources too, so they have no changes of their
+// own; the deletion of a scope describes them instead. Use scopeDeletes to
+// find the scopes which would be deleted before applying the changes.
+func plan(client *api.Client, desired *Config, current *Scope) ([]*change, error) {
+	p := &planner{client: client}
+	root := &Scope{Config: *desired, id: current.id, version: current.version}
+	if err := p.planContents("", root, &current.Config); err != nil {
+		return nil, err
+	}
+	return append(p.changes, p.deletes...), nil
+}
+
+func (p *planner) add(action, resource, path string, fields []string, apply func(context.Context) error) *change {
+	c := &change{
+		Action:   action,
+		Resource: resource,
+		Path:     path,
+		Fields:   fields,
+		apply:    apply,
+	}
+	if action == actionDelete {
+		p.deletes = append(p.deletes, c)
+		return c
+	}
+	p.changes = append(p.changes, c)
+	return c
+}
+
+// remove adds a change which deletes a resource, unless its version is no
+// longer the one it was read with. The check is best effort: the resource can
+// still be changed by someone else between reading its version and deleting
+// it, since the delete requests of the API do not take a version.
+func (p *planner) remove(resource, path string, version uint32, read func(context.Context) (uint32, error), del func(context.Context) error) *change {
+	return p.add(actionDelete, resource, path, nil, func(ctx context.Context) error {
+		v, err := read(ctx)
+		if err != nil {
+			return err
+		}
+		if v != version {
+			return fmt.Errorf("%s %q was changed after it was read", resource, path)
+		}
+		return del(ctx)
+	})
+}
+
+func (p *planner) planContents(path string, ds *Scope, cur *Config) error {
+	if err := p.planAuthMethods(path, ds, cur.AuthMethods); err != nil {
+		return err
+	}
+	p.planRoles(path, ds, cur.Roles)
+	if err := p.planHostCatalogs(path, ds, cur.HostCatalogs); err != nil {
+		return err
+	}
+	if err := p.planCredentialStores(path, ds, cur.CredentialStores); err != nil {
+		return err
+	}
+	if err := p.planTargets(path, ds, cur); err != nil {
+		return err
+	}
+	return p.planScopes(path, ds, cur.Scopes)
+}
+
+func (p *planner) planScopes(path string, parent *Scope, current []*Scope) error {
+	sClient := scopes.NewClient(p.client)
+	existing, removed := match(parent.Scopes, current)
+	for _, d := range parent.Scopes {
+		sp := joinPath(path, d.Name)
+		cur := new(Config)
+		c, ok := existing[d.Name]
+		switch {
+		case !ok:
+			p.add(actionCreate, "scope", sp, nil, func(ctx context.Context) error {
+				// The roles of a scope are part of its configuration, so the
+				// roles normally created with a scope are not.
+				opts := []scopes.Option{
+					scopes.WithName(d.Name),
+					scopes.WithSkipAdminRoleCreation(true),
+					scopes.WithSkipDefaultRoleCreation(true),
+				}
+				if d.Description != "" {
+					opts = append(opts, scopes.WithDescription(d.Description))
+				}
+				res, err := sClient.Create(ctx, parent.id, opts...)
+				if err != nil {
+					return err
+				}
+				d.id, d.version = res.Item.Id, res.Item.Version
+				return nil
+			})
+		default:
+			d.id, d.version = c.id, c.version
+			cur = &c.Config
+			if d.Description != c.Description {
+				p.add(actionUpdate, "scope", sp, []string{"description"}, func(ctx context.Context) error {
+					opt := scopes.WithDescription(d.Description)
+					if d.Description == "" {
+						opt = scopes.DefaultDescription()
+					}
+					res, err := sClient.Update(ctx, d.id, d.version, opt)
+					if err != nil {
+						return err
+					}
+					d.version = res.Item.Version
+					return nil
+				})
+			}
+		}
+		if err := p.planContents(sp, d, cur); err != nil {
+			return err
+		}
+	}
+	for _, c := range removed {
+		sp := joinPath(path, c.Name)
+		ch := p.remove("scope", sp, c.version,
+			func(ctx context.Context) (uint32, error) {
+				res, err := sClient.Read(ctx, c.id)
+				if err != nil {
+					return 0, err
+				}
+				return res.Item.Version, nil
+			},
+			func(ctx context.Context) error {
+				_, err := sClient.Delete(ctx, c.id)
+				return err
+			})
+		ch.Cascades = c.contents(sp)
+	}
+	return nil
+}
+
+// contents describes the resources within the scope at path, including those
+// within its child scopes.
+func (s *Scope) contents(path string) []string {
+	var out []string
+	add := func(resource, path string) {
+		out = append(out, fmt.Sprintf("%s %q", resource, path))
+	}
+	for _, am := range s.AuthMethods {
+		add("auth method", joinPath(path, am.Name))
+	}
+	for _, r := range s.Roles {
+		add("role", joinPath(path, r.Name))
+	}
+	for _, hc := range s.HostCatalogs {
+		hp := joinPath(path, hc.Name)
+		add("host catalog", hp)
+		for _, h := range hc.Hosts {
+			add("host", joinPath(hp, h.Name))
+		}
+		for _, hs := range hc.HostSets {
+			add("host set", joinPath(hp, hs.Name))
+		}
+	}
+	for _, cs := range s.CredentialStores {
+		cp := joinPath(path, cs.Name)
+		add("credential store", cp)
+		for _, cl := range cs.CredentialLibraries {
+			add("credential library", joinPath(cp, cl.Name))
+		}
+	}
+	for _, t := range s.Targets {
+		add("target", joinPath(path, t.Name))
+	}
+	for _, c := range s.Scopes {
+		cp := joinPath(path, c.Name)
+		add("scope", cp)
+		out = append(out, c.contents(cp)...)
+	}
+	if s.unnamed > 0 {
+		out = append(out, fmt.Sprintf("%d resource(s) without a name in scope %q, and anything within them", s.unnamed, path))
+	}
+	return out
+}
+
+// scopeDeletes returns the paths of the scopes which the changes delete.
+func scopeDeletes(changes []*change) []string {
+	var paths []string
+	for _, c := range changes {
+		if c.Action == actionDelete && c.Resource == "scope" {
+			paths = append(paths, c.Path)
+		}
+	}
+	return paths
+}
+
+func (p *planner) planAuthMethods(path string, s *Scope, current []*AuthMethod) error {
+	amClient := authmethods.NewClient(p.client)
+	existing, removed := match(s.AuthMethods, current)
+	for _, d := range s.AuthMethods {
+		rp := joinPath(path, d.Name)
+		c, ok := existing[d.Name]
+		if !ok {
+			p.add(actionCreate, "auth method", rp, nil, func(ctx context.Context) error {
+				opts := []authmethods.Option{authmethods.WithName(d.Name)}
+				if d.Description != "" {
+					opts = append(opts, authmethods.WithDescription(d.Description))
+				}
+				if len(d.Attributes) > 0 {
+					opts = append(opts, authmethods.WithAttributes(d.Attributes))
+				}
+				res, err := amClient.Create(ctx, d.Type, s.id, opts...)
+				if err != nil {
+					return err
+				}
+				d.id, d.version = res.Item.Id, res.Item.Version
+				return nil
+			})
+			continue
+		}
+		if d.Type != c.Type {
+			return typeChangeError("auth method", rp, c.Type, d.Type)
+		}
+		d.id, d.version = c.id, c.version
+
+		var fields []string
+		var opts []authmethods.Option
+		if d.Description != c.Description {
+			fields = append(fields, "description")
+			opts = append(opts, authmethods.WithDescription(d.Description))
+			if d.Description == "" {
+				opts[len(opts)-1] = authmethods.DefaultDescription()
+			}
+		}
+		if attrs := changedAttributes(d.Attributes, c.Attributes); len(attrs) > 0 {
+			fields = append(fields, attributeFields(attrs)...)
+			opts = append(opts, authmethods.WithAttributes(attrs))
+		}
+		if len(fields) > 0 {
+			p.add(actionUpdate, "auth method", rp, fields, func(ctx context.Context) error {
+				res, err := amClient.Update(ctx, d.id, d.version, opts...)
+				if err != nil {
+					return err
+				}
+				d.version = res.Item.Version
+				return nil
+			})
+		}
+	}
+	for _, c := range removed {
+		p.remove("auth method", joinPath(path, c.Name), c.version,
+			func(ctx context.Context) (uint32, error) {
+				res, err := amClient.Read(ctx, c.id)
+				if err != nil {
+					return 0, err
+				}
+				return res.Item.Version, nil
+			},
+			func(ctx context.Context) error {
+				_, err := amClient.Delete(ctx, c.id)
+				return err
+			})
+	}
+	return nil
+}
+
+func (p *planner) planRoles(path string, s *Scope, current []*Role) {
+	rClient := roles.NewClient(p.client)
+	existing, removed := match(s.Roles, current)
+	for _, d := range s.Roles {
+		rp := joinPath(path, d.Name)
+		// Roles are created with a default grant scope, which is left alone
+		// unless grant scopes are configured.
+		setGrantScopes := len(d.GrantScopeIds) > 0
+		setGrants := len(d.GrantStrings) > 0
+		c, ok := existing[d.Name]
+		if ok {
+			d.id, d.version = c.id, c.version
+			setGrantScopes = setGrantScopes && !sameSet(d.GrantScopeIds, c.GrantScopeIds)
+			setGrants = !sameSet(d.GrantStrings, c.GrantStrings)
+		}
+		updateDescription := ok && d.Description != c.Description
+
+		var fields []string
+		if updateDescription {
+			fields = append(fields, "description")
+		}
+		if setGrantScopes {
+			fields = append(fields, "grant_scope_ids")
+		}
+		if setGrants {
+			fields = append(fields, "grant_strings")
+		}
+		apply := func(ctx context.Context) error {
+			if !ok {
+				opts := []roles.Option{roles.WithName(d.Name)}
+				if d.Description != "" {
+					opts = append(opts, roles.WithDescription(d.Description))
+				}
+				res, err := rClient.Create(ctx, s.id, opts...)
+				if err != nil {
+					return err
+				}
+				d.id, d.version = res.Item.Id, res.Item.Version
+			}
+			if updateDescription {
+				opt := roles.WithDescription(d.Description)
+				if d.Description == "" {
+					opt = roles.DefaultDescription()
+				}
+				res, err := rClient.Update(ctx, d.id, d.version, opt)
+				if err != nil {
+					return err
+				}
+				d.version = res.Item.Version
+			}
+			if setGrantScopes {
+				res, err := rClient.SetGrantScopes(ctx, d.id, d.version, d.GrantScopeIds)
+				if err != nil {
+					return err
+				}
+				d.version = res.Item.Version
+			}
+			if setGrants {
+				res, err := rClient.SetGrants(ctx, d.id, d.version, d.GrantStrings)
+				if err != nil {
+					return err
+				}
+				d.version = res.Item.Version
+			}
+			return nil
+		}
+		switch {
+		case !ok:
+			p.add(actionCreate, "role", rp, nil, apply)
+		case len(fields) > 0:
+			p.add(actionUpdate, "role", rp, fields, apply)
+		}
+	}
+	for _, c := range removed {
+		p.remove("role", joinPath(path, c.Name), c.version,
+			func(ctx context.Context) (uint32, error) {
+				res, err := rClient.Read(ctx, c.id)
+				if err != nil {
+					return 0, err
+				}
+				return res.Item.Version, nil
+			},
+			func(ctx context.Context) error {
+				_, err := rClient.Delete(ctx, c.id)
+				return err
+			})
+	}
+}
+
+func (p *planner) planHostCatalogs(path string, s *Scope, current []*HostCatalog) error {
+	hcClient := hostcatalogs.NewClient(p.client)
+	existing, removed := match(s.HostCatalogs, current)
+	for _, d := range s.HostCatalogs {
+		rp := joinPath(path, d.Name)
+		if d.Type != staticType && len(d.Hosts) > 0 {
+			return fmt.Errorf("host catalog %q: hosts can only be configured in static host catalogs", rp)
+		}
+		c, ok := existing[d.Name]
+		if !ok {
+			c = new(HostCatalog)
+			p.add(actionCreate, "host catalog", rp, nil, func(ctx context.Context) error {
+				opts := []hostcatalogs.Option{hostcatalogs.WithName(d.Name)}
+				if d.Description != "" {
+					opts = append(opts, hostcatalogs.WithDescription(d.Description))
+				}
+				if d.PluginName != "" {
+					opts = append(opts, hostcatalogs.WithPluginName(d.PluginName))
+				}
+				if len(d.Attributes) > 0 {
+					opts = append(opts, hostcatalogs.WithAttributes(d.Attributes))
+				}
+				if len(d.Secrets) > 0 {
+					opts = append(opts, hostcatalogs.WithSecrets(d.Secrets))
+				}
+				res, err := hcClient.Create(ctx, d.Type, s.id, opts...)
+				if err != nil {
+					return err
+				}
+				d.id, d.version = res.Item.Id, res.Item.Version
+				return nil
+			})
+		} else {
+			if d.Type != c.Type {
+				return typeChangeError("host catalog", rp, c.Type, d.Type)
+			}
+			d.id, d.version = c.id, c.version
+
+			// Secrets cannot be read back, so they are only set when the
+			// host catalog is created.
+			var fields []string
+			var opts []hostcatalogs.Option
+			if d.Description != c.Description {
+				fields = append(fields, "description")
+				opts = append(opts, hostcatalogs.WithDescription(d.Description))
+				if d.Description == "" {
+					opts[len(opts)-1] = hostcatalogs.DefaultDescription()
+				}
+			}
+			if attrs := changedAttributes(d.Attributes, c.Attributes); len(attrs) > 0 {
+				fields = append(fields, attributeFields(attrs)...)
+				opts = append(opts, hostcatalogs.WithAttributes(attrs))
+			}
+			if len(fields) > 0 {
+				p.add(actionUpdate, "host catalog", rp, fields, func(ctx context.Context) error {
+					res, err := hcClient.Update(ctx, d.id, d.version, opts...)
+					if err != nil {
+						return err
+					}
+					d.version = res.Item.Version
+					return nil
+				})
+			}
+		}
+		p.planHosts(rp, d, c.Hosts)
+		p.planHostSets(rp, d, c.HostSets)
+	}
+	for _, c := range removed {
+		p.remove("host catalog", joinPath(path, c.Name), c.version,
+			func(ctx context.Context) (uint32, error) {
+				res, err := hcClient.Read(ctx, c.id)
+				if err != nil {
+					return 0, err
+				}
+				return res.Item.Version, nil
+			},
+			func(ctx context.Context) error {
+				_, err := hcClient.Delete(ctx, c.id)
+				return err
+			})
+	}
+	return nil
+}
+
+func (p *planner) planHosts(path string, hc *HostCatalog, current []*Host) {
+	hClient := hosts.NewClient(p.client)
+	existing, removed := match(hc.Hosts, current)
+	for _, d := range hc.Hosts {
+		rp := joinPath(path, d.Name)
+		c, ok := existing[d.Name]
+		if !ok {
+			p.add(actionCreate, "host", rp, nil, func(ctx context.Context) error {
+				opts := []hosts.Option{hosts.WithName(d.Name)}
+				if d.Description != "" {
+					opts = append(opts, hosts.WithDescription(d.Description))
+				}
+				if len(d.Attributes) > 0 {
+					opts = append(opts, hosts.WithAttributes(d.Attributes))
+				}
+				res, err := hClient.Create(ctx, hc.id, opts...)
+				if err != nil {
+					return err
+				}
+				d.id, d.version = res.Item.Id, res.Item.Version
+				return nil
+			})
+			continue
+		}
+		d.id, d.version = c.id, c.version
+
+		var fields []string
+		var opts []hosts.Option
+		if d.Description != c.Description {
+			fields = append(fields, "description")
+			opts = append(opts, hosts.WithDescription(d.Description))
+			if d.Description == "" {
+				opts[len(opts)-1] = hosts.DefaultDescription()
+			}
+		}
+		if attrs := changedAttributes(d.Attributes, c.Attributes); len(attrs) > 0 {
+			fields = append(fields, attributeFields(attrs)...)
+			opts = append(opts, hosts.WithAttributes(attrs))
+		}
+		if len(fields) > 0 {
+			p.add(actionUpdate, "host", rp, fields, func(ctx context.Context) error {
+				res, err := hClient.Update(ctx, d.id, d.version, opts...)
+				if err != nil {
+					return err
+				}
+				d.version = res.Item.Version
+				return nil
+			})
+		}
+	}
+	for _, c := range removed {
+		p.remove("host", joinPath(path, c.Name), c.version,
+			func(ctx context.Context) (uint32, error) {
+				res, err := hClient.Read(ctx, c.id)
+				if err != nil {
+					return 0, err
+				}
+				return res.Item.Version, nil
+			},
+			func(ctx context.Context) error {
+				_, err := hClient.Delete(ctx, c.id)
+				return err
+			})
+	}
+}
+
+func (p *planner) planHostSets(path string, hc *HostCatalog, current []*HostSet) {
+	hsClient := hostsets.NewClient(p.client)
+	existing, removed := match(hc.HostSets, current)
+	for _, d := range hc.HostSets {
+		rp := joinPath(path, d.Name)
+		setHosts := len(d.Hosts) > 0
+		c, ok := existing[d.Name]
+		var fields []string
+		var opts []hostsets.Option
+		if ok {
+			d.id, d.version = c.id, c.version
+			setHosts = !sameSet(d.Hosts, c.Hosts)
+			if d.Description != c.Description {
+				fields = append(fields, "description")
+				opts = append(opts, hostsets.WithDescription(d.Description))
+				if d.Description == "" {
+					opts[len(opts)-1] = hostsets.DefaultDescription()
+				}
+			}
+			if attrs := changedAttributes(d.Attributes, c.Attributes); len(attrs) > 0 {
+				fields = append(fields, attributeFields(attrs)...)
+				opts = append(opts, hostsets.WithAttributes(attrs))
+			}
+		}
+		if setHosts {
+			fields = append(fields, "hosts")
+		}
+		apply := func(ctx context.Context) error {
+			if !ok {
+				opts := []hostsets.Option{hostsets.WithName(d.Name)}
+				if d.Description != "" {
+					opts = append(opts, hostsets.WithDescription(d.Description))
+				}
+				if len(d.Attributes) > 0 {
+					opts = append(opts, hostsets.WithAttributes(d.Attributes))
+				}
+				res, err := hsClient.Create(ctx, hc.id, opts...)
+				if err != nil {
+					return err
+				}
+				d.id, d.version = res.Item.Id, res.Item.Version
+			}
+			if len(opts) > 0 {
+				res, err := hsClient.Update(ctx, d.id, d.version, opts...)
+				if err != nil {
+					return err
+				}
+				d.version = res.Item.Version
+			}
+			if setHosts {
+				// Hosts are referred to by name, or by ID if they are not
+				// part of the configuration.
+				ids := make([]string, 0, len(d.Hosts))
+				for _, n := range d.Hosts {
+					id := n
+					if i := slices.IndexFunc(hc.Hosts, func(h *Host) bool { return h.Name == n }); i >= 0 {
+						id = hc.Hosts[i].id
+					}
+					ids = append(ids, id)
+				}
+				res, err := hsClient.SetHosts(ctx, d.id, d.version, ids)
+				if err != nil {
+					return err
+				}
+				d.version = res.Item.Version
+			}
+			return nil
+		}
+		switch {
+		case !ok:
+			p.add(actionCreate, "host set", rp, nil, apply)
+		case len(fields) > 0:
+			p.add(actionUpdate, "host set", rp, fields, apply)
+		}
+	}
+	for _, c := range removed {
+		p.remove("host set", joinPath(path, c.Name), c.version,
+			func(ctx context.Context) (uint32, error) {
+				res, err := hsClient.Read(ctx, c.id)
+				if err != nil {
+					return 0, err
+				}
+				return res.Item.Version, nil
+			},
+			func(ctx context.Context) error {
+				_, err := hsClient.Delete(ctx, c.id)
+				return err
+			})
+	}
+}
+
+func (p *planner) planCredentialStores(path string, s *Scope, current []*CredentialStore) error {
+	csClient := credentialstores.NewClient(p.client)
+	existing, removed := match(s.CredentialStores, current)
+	for _, d := range s.CredentialStores {
+		rp := joinPath(path, d.Name)
+		if d.Type != vaultType && len(d.CredentialLibraries) > 0 {
+			return fmt.Errorf("credential store %q: credential libraries can only be configured in vault credential stores", rp)
+		}
+		c, ok := existing[d.Name]
+		if !ok {
+			c = new(CredentialStore)
+			p.add(actionCreate, "credential store", rp, nil, func(ctx context.Context) error {
+				opts := []credentialstores.Option{credentialstores.WithName(d.Name)}
+				if d.Description != "" {
+					opts = append(opts, credentialstores.WithDescription(d.Description))
+				}
+				if len(d.Attributes) > 0 {
+					opts = append(opts, credentialstores.WithAttributes(d.Attributes))
+				}
+				res, err := csClient.Create(ctx, d.Type, s.id, opts...)
+				if err != nil {
+					return err
+				}
+				d.id, d.version = res.Item.Id, res.Item.Version
+				return nil
+			})
+		} else {
+			if d.Type != c.Type {
+				return typeChangeError("credential store", rp, c.Type, d.Type)
+			}
+			d.id, d.version = c.id, c.version
+
+			var fields []string
+			var opts []credentialstores.Option
+			if d.Description != c.Description {
+				fields = append(fields, "description")
+				opts = append(opts, credentialstores.WithDescription(d.Description))
+				if d.Description == "" {
+					opts[len(opts)-1] = credentialstores.DefaultDescription()
+				}
+			}
+			if attrs := changedAttributes(d.Attributes, c.Attributes); len(attrs) > 0 {
+				fields = append(fields, attributeFields(attrs)...)
+				opts = append(opts, credentialstores.WithAttributes(attrs))
+			}
+			if len(fields) > 0 {
+				p.add(actionUpdate, "credential store", rp, fields, func(ctx context.Context) error {
+					res, err := csClient.Update(ctx, d.id, d.version, opts...)
+					if err != nil {
+						return err
+					}
+					d.version = res.Item.Version
+					return nil
+				})
+			}
+		}
+		if err := p.planCredentialLibraries(rp, d, c.CredentialLibraries); err != nil {
+			return err
+		}
+	}
+	for _, c := range removed {
+		p.remove("credential store", joinPath(path, c.Name), c.version,
+			func(ctx context.Context) (uint32, error) {
+				res, err := csClient.Read(ctx, c.id)
+				if err != nil {
+					return 0, err
+				}
+				return res.Item.Version, nil
+			},
+			func(ctx context.Context) error {
+				_, err := csClient.Delete(ctx, c.id)
+				return err
+			})
+	}
+	return nil
+}
+
+func (p *planner) planCredentialLibraries(path string, cs *CredentialStore, current []*CredentialLibrary) error {
+	clClient := credentiallibraries.NewClient(p.client)
+	existing, removed := match(cs.CredentialLibraries, current)
+	for _, d := range cs.CredentialLibraries {
+		rp := joinPath(path, d.Name)
+		c, ok := existing[d.Name]
+		if !ok {
+			p.add(actionCreate, "credential library", rp, nil, func(ctx context.Context) error {
+				opts := []credentiallibraries.Option{credentiallibraries.WithName(d.Name)}
+				if d.Description != "" {
+					opts = append(opts, credentiallibraries.WithDescription(d.Description))
+				}
+				if d.CredentialType != "" {
+					opts = append(opts, credentiallibraries.WithCredentialType(d.CredentialType))
+				}
+				if len(d.Attributes) > 0 {
+					opts = append(opts, credentiallibraries.WithAttributes(d.Attributes))
+				}
+				res, err := clClient.Create(ctx, d.Type, cs.id, opts...)
+				if err != nil {
+					return err
+				}
+				d.id, d.version = res.Item.Id, res.Item.Version
+				return nil
+			})
+			continue
+		}
+		if d.Type != c.Type {
+			return typeChangeError("credential library", rp, c.Type, d.Type)
+		}
+		if d.CredentialType != "" && d.CredentialType != c.CredentialType {
+			return fmt.Errorf("credential library %q: credential type cannot be changed from %q to %q", rp, c.CredentialType, d.CredentialType)
+		}
+		d.id, d.version = c.id, c.version
+
+		var fields []string
+		var opts []credentiallibraries.Option
+		if d.Description != c.Description {
+			fields = append(fields, "description")
+			opts = append(opts, credentiallibraries.WithDescription(d.Description))
+			if d.Description == "" {
+				opts[len(opts)-1] = credentiallibraries.DefaultDescription()
+			}
+		}
+		if attrs := changedAttributes(d.Attributes, c.Attributes); len(attrs) > 0 {
+			fields = append(fields, attributeFields(attrs)...)
+			opts = append(opts, credentiallibraries.WithAttributes(attrs))
+		}
+		if len(fields) > 0 {
+			p.add(actionUpdate, "credential library", rp, fields, func(ctx context.Context) error {
+				res, err := clClient.Update(ctx, d.id, d.version, opts...)
+				if err != nil {
+					return err
+				}
+				d.version = res.Item.Version
+				return nil
+			})
+		}
+	}
+	for _, c := range removed {
+		p.remove("credential library", joinPath(path, c.Name), c.version,
+			func(ctx context.Context) (uint32, error) {
+				res, err := clClient.Read(ctx, c.id)
+				if err != nil {
+					return 0, err
+				}
+				return res.Item.Version, nil
+			},
+			func(ctx context.Context) error {
+				_, err := clClient.Delete(ctx, c.id)
+				return err
+			})
+	}
+	return nil
+}
+
+func (p *planner) planTargets(path string, s *Scope, cur *Config) error {
+	tClient := targets.NewClient(p.client)
+	existing, removed := match(s.Targets, cur.Targets)
+	currentRefs := sourceRefs(cur)
+	for _, d := range s.Targets {
+		rp := joinPath(path, d.Name)
+		hostSources, err := resolveSources(rp, "host source", d.HostSources, s.hostSet)
+		if err != nil {
+			return err
+		}
+		brokered, err := resolveSources(rp, "brokered credential source", d.BrokeredCredentialSources, s.credentialLibrary)
+		if err != nil {
+			return err
+		}
+		injected, err := resolveSources(rp, "injected application credential source", d.InjectedApplicationCredentialSources, s.credentialLibrary)
+		if err != nil {
+			return err
+		}
+
+		setHostSources := len(d.HostSources) > 0
+		setCredentialSources := len(d.BrokeredCredentialSources) > 0 || len(d.InjectedApplicationCredentialSources) > 0
+		c, ok := existing[d.Name]
+		var fields []string
+		var opts []targets.Option
+		if ok {
+			if d.Type != c.Type {
+				return typeChangeError("target", rp, c.Type, d.Type)
+			}
+			d.id, d.version = c.id, c.version
+
+			strs := []struct {
+				field            string
+				desired, current string
+				with             func(string) targets.Option
+				clear            func() targets.Option
+			}{
+				{"description", d.Description, c.Description, targets.WithDescription, targets.DefaultDescription},
+				{"address", d.Address, c.Address, targets.WithAddress, targets.DefaultAddress},
+				{"egress_worker_filter", d.EgressWorkerFilter, c.EgressWorkerFilter, targets.WithEgressWorkerFilter, targets.DefaultEgressWorkerFilter},
+				{"ingress_worker_filter", d.IngressWorkerFilter, c.IngressWorkerFilter, targets.WithIngressWorkerFilter, targets.DefaultIngressWorkerFilter},
+			}
+			for _, f := range strs {
+				if f.desired == f.current {
+					continue
+				}
+				fields = append(fields, f.field)
+				if f.desired == "" {
+					opts = append(opts, f.clear())
+				} else {
+					opts = append(opts, f.with(f.desired))
+				}
+			}
+			// Session limits which are not configured keep their current
+			// value.
+			if d.SessionMaxSeconds != 0 && d.SessionMaxSeconds != c.SessionMaxSeconds {
+				fields = append(fields, "session_max_seconds")
+				opts = append(opts, targets.WithSessionMaxSeconds(uint32(d.SessionMaxSeconds)))
+			}
+			if d.SessionConnectionLimit != 0 && d.SessionConnectionLimit != c.SessionConnectionLimit {
+				fields = append(fields, "session_connection_limit")
+				opts = append(opts, targets.WithSessionConnectionLimit(int32(d.SessionConnectionLimit)))
+			}
+			if attrs := changedAttributes(d.Attributes, c.Attributes); len(attrs) > 0 {
+				fields = append(fields, attributeFields(attrs)...)
+				opts = append(opts, targets.WithAttributes(attrs))
+			}
+
+			currentHostSources := canonicalRefs(c.HostSources, currentRefs)
+			currentBrokered := canonicalRefs(c.BrokeredCredentialSources, currentRefs)
+			currentInjected := canonicalRefs(c.InjectedApplicationCredentialSources, currentRefs)
+			setHostSources = !sameSet(canonicalRefs(d.HostSources, currentRefs), currentHostSources)
+			setCredentialSources = !sameSet(canonicalRefs(d.BrokeredCredentialSources, currentRefs), currentBrokered) ||
+				!sameSet(canonicalRefs(d.InjectedApplicationCredentialSources, currentRefs), currentInjected)
+		}
+		if setHostSources {
+			fields = append(fields, "host_sources")
+		}
+		if setCredentialSources {
+			fields = append(fields, "credential_sources")
+		}
+
+		apply := func(ctx context.Context) error {
+			if !ok {
+				opts := []targets.Option{targets.WithName(d.Name)}
+				if d.Description != "" {
+					opts = append(opts, targets.WithDescription(d.Description))
+				}
+				if d.Address != "" {
+					opts = append(opts, targets.WithAddress(d.Address))
+				}
+				if d.EgressWorkerFilter != "" {
+					opts = append(opts, targets.WithEgressWorkerFilter(d.EgressWorkerFilter))
+				}
+				if d.IngressWorkerFilter != "" {
+					opts = append(opts, targets.WithIngressWorkerFilter(d.IngressWorkerFilter))
+				}
+				if d.SessionMaxSeconds != 0 {
+					opts = append(opts, targets.WithSessionMaxSeconds(uint32(d.SessionMaxSeconds)))
+				}
+				if d.SessionConnectionLimit != 0 {
+					opts = append(opts, targets.WithSessionConnectionLimit(int32(d.SessionConnectionLimit)))
+				}
+				if len(d.Attributes) > 0 {
+					opts = append(opts, targets.WithAttributes(d.Attributes))
+				}
+				res, err := tClient.Create(ctx, d.Type, s.id, opts...)
+				if err != nil {
+					return err
+				}
+				d.id, d.version = res.Item.Id, res.Item.Version
+			}
+			if len(opts) > 0 {
+				res, err := tClient.Update(ctx, d.id, d.version, opts...)
+				if err != nil {
+					return err
+				}
+				d.version = res.Item.Version
+			}
+			if setHostSources {
+				res, err := tClient.SetHostSources(ctx, d.id, d.version, sourceIds(hostSources))
+				if err != nil {
+					return err
+				}
+				d.version = res.Item.Version
+			}
+			if setCredentialSources {
+				res, err := tClient.SetCredentialSources(ctx, d.id, d.version,
+					targets.WithBrokeredCredentialSourceIds(sourceIds(brokered)),
+					targets.WithInjectedApplicationCredentialSourceIds(sourceIds(injected)))
+				if err != nil {
+					return err
+				}
+				d.version = res.Item.Version
+			}
+			return nil
+		}
+		switch {
+		case !ok:
+			p.add(actionCreate, "target", rp, nil, apply)
+		case len(fields) > 0:
+			p.add(actionUpdate, "target", rp, fields, apply)
+		}
+	}
+	for _, c := range removed {
+		p.remove("target", joinPath(path, c.Name), c.version,
+			func(ctx context.Context) (uint32, error) {
+				res, err := tClient.Read(ctx, c.id)
+				if err != nil {
+					return 0, err
+				}
+				return res.Item.Version, nil
+			},
+			func(ctx context.Context) error {
+				_, err := tClient.Delete(ctx, c.id)
+				return err
+			})
+	}
+	return nil
+}
+
+// hostSet returns a pointer to the ID of the host set within the scope which
+// ref refers to as "<host catalog name>/<host set name>".
+func (s *Scope) hostSet(ref string) *string {
+	for _, hc := range s.HostCatalogs {
+		if name, ok := strings.CutPrefix(ref, hc.Name+"/"); ok {
+			if i := slices.IndexFunc(hc.HostSets, func(hs *HostSet) bool { return hs.Name == name }); i >= 0 {
+				return &hc.HostSets[i].id
+			}
+		}
+	}
+	return nil
+}
+
+// credentialLibrary returns a pointer to the ID of the credential library
+// within the scope which ref refers to as "<credential store name>/<credential
+// library name>".
+func (s *Scope) credentialLibrary(ref string) *string {
+	for _, cs := range s.CredentialStores {
+		if name, ok := strings.CutPrefix(ref, cs.Name+"/"); ok {
+			if i := slices.IndexFunc(cs.CredentialLibraries, func(cl *CredentialLibrary) bool { return cl.Name == name }); i >= 0 {
+				return &cs.CredentialLibraries[i].id
+			}
+		}
+	}
+	return nil
+}
+
+// resolveSources returns pointers to the IDs of the sources refs refer to. The
+// IDs of sources created by the plan are only known once they are created. A
+// reference which does not contain a "/" is an ID, and is used as is.
+func resolveSources(path, kind string, refs []string, find func(string) *string) ([]*string, error) {
+	ids := make([]*string, 0, len(refs))
+	for _, ref := range refs {
+		if id := find(ref); id != nil {
+			ids = append(ids, id)
+			continue
+		}
+		if strings.Contains(ref, "/") {
+			return nil, fmt.Errorf("target %q: %s %q not found", path, kind, ref)
+		}
+		ids = append(ids, &ref)
+	}
+	return ids, nil
+}
+
+func sourceIds(ids []*string) []string {
+	out := make([]string, 0, len(ids))
+	for _, id := range ids {
+		out = append(out, *id)
+	}
+	return out
+}
+
+// sourceRefs returns the references to the host sets and credential libraries
+// in the current configuration of a scope, by their ID.
+func sourceRefs(cur *Config) map[string]string {
+	refs := make(map[string]string)
+	for _, hc := range cur.HostCatalogs {
+		for _, hs := range hc.HostSets {
+			refs[hs.id] = joinPath(hc.Name, hs.Name)
+		}
+	}
+	for _, cs := range cur.CredentialStores {
+		for _, cl := range cs.CredentialLibraries {
+			refs[cl.id] = joinPath(cs.Name, cl.Name)
+		}
+	}
+	return refs
+}
+
+// canonicalRefs replaces the references by ID in refs to resources which can
+// be referred to by name.
+func canonicalRefs(refs []string, byId map[string]string) []string {
+	out := make([]string, 0, len(refs))
+	for _, r := range refs {
+		if n, ok := byId[r]; ok {
+			r = n
+		}
+		out = append(out, r)
+	}
+	return out
+}
+
+// match returns the current resources by the name of the desired resource
+// they match, and the current resources which match no desired resource.
+func match[T resource](desired, current []T) (map[string]T, []T) {
+	names := make(map[string]bool, len(desired))
+	for _, d := range desired {
+		names[d.key()] = true
+	}
+	existing := make(map[string]T, len(current))
+	var removed []T
+	for _, c := range current {
+		if names[c.key()] {
+			existing[c.key()] = c
+			continue
+		}
+		removed = append(removed, c)
+	}
+	return existing, removed
+}
+
+// changedAttributes returns the desired attributes which differ from the
+// current ones. Attributes which are not configured keep their current value,
+// and attributes whose value is only ever returned as an HMAC, such as
+// passwords and tokens, cannot be compared and are only set on creation.
+func changedAttributes(desired, current map[string]any) map[string]any {
+	changed := make(map[string]any)
+	for k, v := range desired {
+		if _, ok := current[k+"_hmac"]; ok {
+			continue
+		}
+		if !reflect.DeepEqual(v, current[k]) {
+			changed[k] = v
+		}
+	}
+	return changed
+}
+
+func attributeFields(attrs map[string]any) []string {
+	fields := make([]string, 0, len(attrs))
+	for k := range attrs {
+		fields = append(fields, "attributes."+k)
+	}
+	sort.Strings(fields)
+	return fields
+}
+
+// sameSet reports whether a and b hold the same strings, in any order.
+func sameSet(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	a, b = slices.Clone(a), slices.Clone(b)
+	slices.Sort(a)
+	slices.Sort(b)
+	return slices.Equal(a, b)
+}
+
+func typeChangeError(resource, path, from, to string) error {
+	return fmt.Errorf("%s %q: type cannot be changed from %q to %q", resource, path, from, to)
+}
@@ -0,0 +1,341 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package applycmd
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"path"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/boundary/api"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testCurrentState() *Scope {
+	return &Scope{
+		id:      "global",
+		version: 1,
+		Config: Config{
+			AuthMethods: []*AuthMethod{
+				{Name: "password", Type: "password", Attributes: map[string]any{"min_password_length": float64(8)}, id: "ampw_1", version: 1},
+				{Name: "ldap", Type: "ldap", Attributes: map[string]any{"bind_dn": "cn=boundary", "bind_password_hmac": "hmac"}, id: "amldap_1", version: 1},
+				{Name: "old", Type: "oidc", id: "amoidc_1", version: 1},
+			},
+			Roles: []*Role{
+				{Name: "admins", GrantScopeIds: []string{"this"}, GrantStrings: []string{"ids=*;type=*;actions=read"}, id: "r_1", version: 1},
+			},
+			Scopes: []*Scope{
+				{
+					Name: "org", id: "o_1", version: 1,
+					Config: Config{
+						Scopes: []*Scope{{
+							Name: "project", id: "p_1", version: 1,
+							Config: Config{
+								HostCatalogs: []*HostCatalog{{
+									Name: "static", Type: "static", id: "hcst_1", version: 1,
+									Hosts: []*Host{
+										{Name: "db", Attributes: map[string]any{"address": "10.0.0.1"}, id: "hst_1", version: 1},
+									},
+									HostSets: []*HostSet{
+										{Name: "dbs", Hosts: []string{"db"}, id: "hsst_1", version: 1},
+									},
+								}},
+								Targets: []*Target{
+									{Name: "postgres", Type: "tcp", SessionMaxSeconds: 28800, Attributes: map[string]any{"default_port": float64(5432)}, HostSources: []string{"static/dbs"}, id: "ttcp_1", version: 1},
+									{Name: "old", Type: "tcp", id: "ttcp_2", version: 1},
+								},
+							},
+						}},
+					},
+				},
+				{
+					Name: "gone", id: "o_2", version: 1, unnamed: 1,
+					Config: Config{
+						AuthMethods: []*AuthMethod{
+							{Name: "password", Type: "password", id: "ampw_2", version: 1},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func testClient(t *testing.T) *api.Client {
+	t.Helper()
+	client, err := api.NewClient(nil)
+	require.NoError(t, err)
+	return client
+}
+
+func TestPlan(t *testing.T) {
+	desired, err := parseConfig([]byte(`
+auth_method "password" {
+  type = "password"
+  attributes = {
+    min_password_length = 12
+  }
+}
+
+auth_method "ldap" {
+  type = "ldap"
+  attributes = {
+    bind_dn       = "cn=boundary"
+    bind_password = "secret"
+  }
+}
+
+role "admins" {
+  grant_scope_ids = ["this", "descendants"]
+  grant_strings   = ["ids=*;type=*;actions=*"]
+}
+
+scope "org" {
+  scope "project" {
+    host_catalog "static" {
+      type = "static"
+      host "db" {
+        attributes = {
+          address = "10.0.0.1"
+        }
+      }
+      host_set "dbs" {
+        hosts = ["db"]
+      }
+      host_set "all" {
+        hosts = ["db"]
+      }
+    }
+
+    target "postgres" {
+      type        = "tcp"
+      description = "Postgres"
+      attributes = {
+        default_port = 5432
+      }
+      host_sources = ["hsst_1"]
+    }
+  }
+
+  scope "new-project" {
+    target "web" {
+      type    = "tcp"
+      address = "web.example.com"
+    }
+  }
+}
+`), "")
+	require.NoError(t, err)
+
+	changes, err := plan(testClient(t), desired, testCurrentState())
+	require.NoError(t, err)
+	var got []string
+	for _, c := range changes {
+		got = append(got, c.String())
+	}
+	assert.Equal(t, []string{
+		`~ update auth method "password" (attributes.min_password_length)`,
+		`~ update role "admins" (grant_scope_ids, grant_strings)`,
+		`+ create host set "org/project/static/all"`,
+		`~ update target "org/project/postgres" (description)`,
+		`+ create scope "org/new-project"`,
+		`+ create target "org/new-project/web"`,
+		`- delete auth method "old"`,
+		`- delete target "org/project/old"`,
+		`- delete scope "gone"` +
+			"\n    deletes auth method \"gone/password\"" +
+			"\n    deletes 1 resource(s) without a name in scope \"gone\", and anything within them",
+	}, got)
+	assert.Equal(t, []string{"gone"}, scopeDeletes(changes))
+
+	// The configured resources matched with the current ones are given their
+	// IDs, so that the changes can refer to them.
+	assert.Equal(t, "ttcp_1", desired.Scopes[0].Scopes[0].Targets[0].id)
+	assert.Equal(t, "hcst_1", desired.Scopes[0].Scopes[0].HostCatalogs[0].id)
+}
+
+func TestPlan_NoChanges(t *testing.T) {
+	current := testCurrentState()
+	desired := exportable(&testCurrentState().Config)
+	changes, err := plan(testClient(t), desired, current)
+	require.NoError(t, err)
+	assert.Empty(t, changes)
+}
+
+func TestPlan_Errors(t *testing.T) {
+	tests := []struct {
+		name    string
+		config  string
+		wantErr string
+	}{
+		{
+			name:    "type-change",
+			config:  `auth_method "password" { type = "oidc" }`,
+			wantErr: `auth method "password": type cannot be changed from "password" to "oidc"`,
+		},
+		{
+			name: "unknown-host-source",
+			config: `
+scope "org" {
+  scope "project" {
+    target "postgres" {
+      type         = "tcp"
+      host_sources = ["static/missing"]
+    }
+  }
+}`,
+			wantErr: `target "org/project/postgres": host source "static/missing" not found`,
+		},
+		{
+			name: "plugin-hosts",
+			config: `
+scope "org" {
+  scope "project" {
+    host_catalog "aws" {
+      type = "plugin"
+      host "h" {}
+    }
+  }
+}`,
+			wantErr: `host catalog "org/project/aws": hosts can only be configured in static host catalogs`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			desired, err := parseConfig([]byte(tt.config), "")
+			require.NoError(t, err)
+			_, err = plan(testClient(t), desired, testCurrentState())
+			require.Error(t, err)
+			assert.Equal(t, tt.wantErr, err.Error())
+		})
+	}
+}
+
+func TestPlan_ApplyOrder(t *testing.T) {
+	current := &Scope{
+		id:      "global",
+		version: 1,
+		Config: Config{
+			Scopes: []*Scope{
+				{
+					Name: "org", id: "o_1", version: 1,
+					Config: Config{
+						Scopes: []*Scope{{
+							Name: "project", id: "p_1", version: 1,
+							Config: Config{
+								HostCatalogs: []*HostCatalog{{
+									Name: "static", Type: "static", id: "hcst_1", version: 1,
+									HostSets: []*HostSet{
+										{Name: "old", id: "hsst_1", version: 1},
+									},
+								}},
+								Targets: []*Target{
+									{Name: "postgres", Type: "tcp", HostSources: []string{"static/old"}, id: "ttcp_1", version: 1},
+									{Name: "old", Type: "tcp", id: "ttcp_2", version: 1},
+								},
+							},
+						}},
+					},
+				},
+				{Name: "gone", id: "o_2", version: 1},
+			},
+		},
+	}
+	// The target is moved from the old host set to the new one, which needs
+	// to be created first, before the old one is deleted.
+	config := []byte(`
+scope "org" {
+  scope "project" {
+    host_catalog "static" {
+      type = "static"
+      host_set "new" {}
+    }
+
+    target "postgres" {
+      type         = "tcp"
+      host_sources = ["static/new"]
+    }
+  }
+}
+`)
+
+	tests := []struct {
+		name string
+		// readVersion is the version the mock controller returns when a
+		// resource is read before it is deleted.
+		readVersion uint32
+		want        []string
+		wantErr     string
+	}{
+		{
+			name:        "deletes-after-updates",
+			readVersion: 1,
+			want: []string{
+				"POST /v1/host-sets",
+				"POST /v1/targets/ttcp_1:set-host-sources",
+				"GET /v1/host-sets/hsst_1",
+				"DELETE /v1/host-sets/hsst_1",
+				"GET /v1/targets/ttcp_2",
+				"DELETE /v1/targets/ttcp_2",
+				"GET /v1/scopes/o_2",
+				"DELETE /v1/scopes/o_2",
+			},
+		},
+		{
+			name:        "changed-after-read",
+			readVersion: 2,
+			want: []string{
+				"POST /v1/host-sets",
+				"POST /v1/targets/ttcp_1:set-host-sources",
+				"GET /v1/host-sets/hsst_1",
+			},
+			wantErr: `host set "org/project/static/old" was changed after it was read`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []string
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				got = append(got, r.Method+" "+r.URL.Path)
+				w.Header().Set("Content-Type", "application/json")
+				switch {
+				case r.Method == http.MethodDelete:
+					w.WriteHeader(http.StatusNoContent)
+				case r.Method == http.MethodGet:
+					fmt.Fprintf(w, `{"id":%q,"version":%d}`, path.Base(r.URL.Path), tt.readVersion)
+				case r.URL.Path == "/v1/host-sets":
+					fmt.Fprint(w, `{"id":"hsst_2","version":1}`)
+				default:
+					id, _, _ := strings.Cut(path.Base(r.URL.Path), ":")
+					fmt.Fprintf(w, `{"id":%q,"version":2}`, id)
+				}
+			}))
+			defer srv.Close()
+			client := testClient(t)
+			require.NoError(t, client.SetAddr(srv.URL))
+
+			desired, err := parseConfig(config, "")
+			require.NoError(t, err)
+			changes, err := plan(client, desired, current)
+			require.NoError(t, err)
+			for _, ch := range changes {
+				if err = ch.apply(context.Background()); err != nil {
+					break
+				}
+			}
+			if tt.wantErr != "" {
+				require.Error(t, err)
+				assert.Equal(t, tt.wantErr, err.Error())
+			} else {
+				require.NoError(t, err)
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -0,0 +1,330 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package applycmd
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/boundary/api"
+	"github.com/hashicorp/boundary/api/authmethods"
+	"github.com/hashicorp/boundary/api/credentiallibraries"
+	"github.com/hashicorp/boundary/api/credentialstores"
+	"github.com/hashicorp/boundary/api/hostcatalogs"
+	"github.com/hashicorp/boundary/api/hosts"
+	"github.com/hashicorp/boundary/api/hostsets"
+	"github.com/hashicorp/boundary/api/roles"
+	"github.com/hashicorp/boundary/api/scopes"
+	"github.com/hashicorp/boundary/api/targets"
+	"github.com/hashicorp/boundary/internal/types/scope"
+)
+
+const (
+	staticType = "static"
+	vaultType  = "vault"
+)
+
+// stateReader reads the current configuration of the resources within a scope
+// from the controller. The resources read keep their ID and version, so that
+// they can be updated.
+type stateReader struct {
+	client *api.Client
+
+	// skipped describes the resources which were left out of the
+	// configuration because they have no name to identify them by.
+	skipped []string
+}
+
+// read returns the current configuration of the resources within the scope
+// and its child scopes, as the contents of the returned scope.
+func (r *stateReader) read(ctx context.Context, scopeId string) (*Scope, error) {
+	res, err := scopes.NewClient(r.client).Read(ctx, scopeId)
+	if err != nil {
+		return nil, fmt.Errorf("error reading scope %s: %w", scopeId, err)
+	}
+	s := &Scope{
+		Name:        res.Item.Name,
+		Description: res.Item.Description,
+		id:          res.Item.Id,
+		version:     res.Item.Version,
+	}
+	if err := r.readContents(ctx, s, res.Item.Type); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+func (r *stateReader) skip(s *Scope, kind, id string) {
+	s.unnamed++
+	r.skipped = append(r.skipped, fmt.Sprintf("%s %s in scope %s has no name", kind, id, s.id))
+}
+
+func (r *stateReader) readContents(ctx context.Context, s *Scope, scopeType string) error {
+	if scopeType != scope.Project.String() {
+		ams, err := authmethods.NewClient(r.client).List(ctx, s.id)
+		if err != nil {
+			return fmt.Errorf("error listing auth methods in scope %s: %w", s.id, err)
+		}
+		for _, am := range ams.Items {
+			if am.Name == "" {
+				r.skip(s, "auth method", am.Id)
+				continue
+			}
+			s.AuthMethods = append(s.AuthMethods, &AuthMethod{
+				Name:        am.Name,
+				Type:        am.Type,
+				Description: am.Description,
+				Attributes:  am.Attributes,
+				id:          am.Id,
+				version:     am.Version,
+			})
+		}
+	}
+
+	if err := r.readRoles(ctx, s); err != nil {
+		return err
+	}
+
+	if scopeType == scope.Project.String() {
+		// Targets refer to host sets and credential libraries by ID, which
+		// are translated into references by name.
+		refs := make(map[string]string)
+		if err := r.readHostCatalogs(ctx, s, refs); err != nil {
+			return err
+		}
+		if err := r.readCredentialStores(ctx, s, refs); err != nil {
+			return err
+		}
+		return r.readTargets(ctx, s, refs)
+	}
+
+	children, err := scopes.NewClient(r.client).List(ctx, s.id)
+	if err != nil {
+		return fmt.Errorf("error listing scopes in scope %s: %w", s.id, err)
+	}
+	for _, child := range children.Items {
+		if child.Name == "" {
+			r.skip(s, "scope", child.Id)
+			continue
+		}
+		cs := &Scope{
+			Name:        child.Name,
+			Description: child.Description,
+			id:          child.Id,
+			version:     child.Version,
+		}
+		if err := r.readContents(ctx, cs, child.Type); err != nil {
+			return err
+		}
+		s.Scopes = append(s.Scopes, cs)
+	}
+	return nil
+}
+
+func (r *stateReader) readRoles(ctx context.Context, s *Scope) error {
+	rClient := roles.NewClient(r.client)
+	rls, err := rClient.List(ctx, s.id)
+	if err != nil {
+		return fmt.Errorf("error listing roles in scope %s: %w", s.id, err)
+	}
+	for _, rl := range rls.Items {
+		if rl.Name == "" {
+			r.skip(s, "role", rl.Id)
+			continue
+		}
+		// Listed roles do not include their grants.
+		res, err := rClient.Read(ctx, rl.Id)
+		if err != nil {
+			return fmt.Errorf("error reading role %s: %w", rl.Id, err)
+		}
+		s.Roles = append(s.Roles, &Role{
+			Name:          res.Item.Name,
+			Description:   res.Item.Description,
+			GrantScopeIds: res.Item.GrantScopeIds,
+			GrantStrings:  res.Item.GrantStrings,
+			id:            res.Item.Id,
+			version:       res.Item.Version,
+		})
+	}
+	return nil
+}
+
+func (r *stateReader) readHostCatalogs(ctx context.Context, s *Scope, refs map[string]string) error {
+	hcs, err := hostcatalogs.NewClient(r.client).List(ctx, s.id)
+	if err != nil {
+		return fmt.Errorf("error listing host catalogs in scope %s: %w", s.id, err)
+	}
+	hClient := hosts.NewClient(r.client)
+	hsClient := hostsets.NewClient(r.client)
+	for _, item := range hcs.Items {
+		if item.Name == "" {
+			r.skip(s, "host catalog", item.Id)
+			continue
+		}
+		hc := &HostCatalog{
+			Name:        item.Name,
+			Type:        item.Type,
+			Description: item.Description,
+			Attributes:  item.Attributes,
+			id:          item.Id,
+			version:     item.Version,
+		}
+		if item.Plugin != nil {
+			hc.PluginName = item.Plugin.Name
+		}
+
+		// Host sets refer to hosts by ID, which are translated into their
+		// names.
+		hostNames := make(map[string]string)
+		if hc.Type == staticType {
+			hs, err := hClient.List(ctx, hc.id)
+			if err != nil {
+				return fmt.Errorf("error listing hosts in host catalog %s: %w", hc.id, err)
+			}
+			for _, h := range hs.Items {
+				if h.Name == "" {
+					r.skip(s, "host", h.Id)
+					continue
+				}
+				hostNames[h.Id] = h.Name
+				hc.Hosts = append(hc.Hosts, &Host{
+					Name:        h.Name,
+					Description: h.Description,
+					Attributes:  h.Attributes,
+					id:          h.Id,
+					version:     h.Version,
+				})
+			}
+		}
+
+		sets, err := hsClient.List(ctx, hc.id)
+		if err != nil {
+			return fmt.Errorf("error listing host sets in host catalog %s: %w", hc.id, err)
+		}
+		for _, set := range sets.Items {
+			if set.Name == "" {
+				r.skip(s, "host set", set.Id)
+				continue
+			}
+			// Listed host sets do not include their hosts.
+			res, err := hsClient.Read(ctx, set.Id)
+			if err != nil {
+				return fmt.Errorf("error reading host set %s: %w", set.Id, err)
+			}
+			hs := &HostSet{
+				Name:        res.Item.Name,
+				Description: res.Item.Description,
+				Attributes:  res.Item.Attributes,
+				id:          res.Item.Id,
+				version:     res.Item.Version,
+			}
+			if hc.Type == staticType {
+				hs.Hosts = refsFor(res.Item.HostIds, hostNames)
+			}
+			refs[hs.id] = joinPath(hc.Name, hs.Name)
+			hc.HostSets = append(hc.HostSets, hs)
+		}
+		s.HostCatalogs = append(s.HostCatalogs, hc)
+	}
+	return nil
+}
+
+func (r *stateReader) readCredentialStores(ctx context.Context, s *Scope, refs map[string]string) error {
+	css, err := credentialstores.NewClient(r.client).List(ctx, s.id)
+	if err != nil {
+		return fmt.Errorf("error listing credential stores in scope %s: %w", s.id, err)
+	}
+	clClient := credentiallibraries.NewClient(r.client)
+	for _, item := range css.Items {
+		if item.Name == "" {
+			r.skip(s, "credential store", item.Id)
+			continue
+		}
+		cs := &CredentialStore{
+			Name:        item.Name,
+			Type:        item.Type,
+			Description: item.Description,
+			Attributes:  item.Attributes,
+			id:          item.Id,
+			version:     item.Version,
+		}
+		if cs.Type == vaultType {
+			cls, err := clClient.List(ctx, cs.id)
+			if err != nil {
+				return fmt.Errorf("error listing credential libraries in credential store %s: %w", cs.id, err)
+			}
+			for _, cl := range cls.Items {
+				if cl.Name == "" {
+					r.skip(s, "credential library", cl.Id)
+					continue
+				}
+				refs[cl.Id] = joinPath(cs.Name, cl.Name)
+				cs.CredentialLibraries = append(cs.CredentialLibraries, &CredentialLibrary{
+					Name:           cl.Name,
+					Type:           cl.Type,
+					Description:    cl.Description,
+					CredentialType: cl.CredentialType,
+					Attributes:     cl.Attributes,
+					id:             cl.Id,
+					version:        cl.Version,
+				})
+			}
+		}
+		s.CredentialStores = append(s.CredentialStores, cs)
+	}
+	return nil
+}
+
+func (r *stateReader) readTargets(ctx context.Context, s *Scope, refs map[string]string) error {
+	tClient := targets.NewClient(r.client)
+	ts, err := tClient.List(ctx, s.id)
+	if err != nil {
+		return fmt.Errorf("error listing targets in scope %s: %w", s.id, err)
+	}
+	for _, t := range ts.Items {
+		if t.Name == "" {
+			r.skip(s, "target", t.Id)
+			continue
+		}
+		// Listed targets do not include their host and credential sources.
+		res, err := tClient.Read(ctx, t.Id)
+		if err != nil {
+			return fmt.Errorf("error reading target %s: %w", t.Id, err)
+		}
+		item := res.Item
+		s.Targets = append(s.Targets, &Target{
+			Name:                                 item.Name,
+			Type:                                 item.Type,
+			Description:                          item.Description,
+			Address:                              item.Address,
+			SessionMaxSeconds:                    int(item.SessionMaxSeconds),
+			SessionConnectionLimit:               int(item.SessionConnectionLimit),
+			EgressWorkerFilter:                   item.EgressWorkerFilter,
+			IngressWorkerFilter:                  item.IngressWorkerFilter,
+			Attributes:                           item.Attributes,
+			HostSources:                          refsFor(item.HostSourceIds, refs),
+			BrokeredCredentialSources:            refsFor(item.BrokeredCredentialSourceIds, refs),
+			InjectedApplicationCredentialSources: refsFor(item.InjectedApplicationCredentialSourceIds, refs),
+			id:                                   item.Id,
+			version:                              item.Version,
+		})
+	}
+	return nil
+}
+
+// refsFor returns the references to the resources with the given IDs, which is
+// their name in names, or their ID if they have none.
+func refsFor(ids []string, names map[string]string) []string {
+	if len(ids) == 0 {
+		return nil
+	}
+	refs := make([]string, 0, len(ids))
+	for _, id := range ids {
+		if n, ok := names[id]; ok {
+			id = n
+		}
+		refs = append(refs, id)
+	}
+	return refs
+}
@@ -53,7 +53,7 @@ func addToken(ctx context.Context, apiClient *api.Client, port uint) (*api.Respo
 	// the command.
 	client.RetryMax = 0
 
-	req, err := retryablehttp.NewRequestWithContext(ctx, "POST", clientAgentUrl(port, "v1/tokens"),
+	req, err := retryablehttp.NewRequestWithContext(ctx, "POST", clientAgentUrl(ctx, client, port, "v1/tokens"),
 		retryablehttp.ReaderFunc(func() (io.Reader, error) {
 			b, err := json.Marshal(&pa)
 			if err != nil {
@@ -4,10 +4,16 @@
 package clientagentcmd
 
 import (
+	"context"
 	"fmt"
+	"net"
+	"net/http"
+	"os"
 	"strings"
 
+	cachecmd "github.com/hashicorp/boundary/internal/clientcache/cmd/cache"
 	"github.com/hashicorp/boundary/internal/cmd/base"
+	"github.com/hashicorp/go-retryablehttp"
 	"github.com/mitchellh/cli"
 	"github.com/posener/complete"
 )
@@ -67,6 +73,21 @@ func (c *ClientAgentCommand) Run(args []string) int {
 }
 
 // clientAgentUrl constructs the full URL for a client agent request given a port and path.
-func clientAgentUrl(port uint, path string) string {
+// The client agent run alongside the cache serves its API on a unix socket in
+// the boundary dot directory which only its user can connect to. If that socket
+// exists the provided client is set to dial it and the port is not used.
+func clientAgentUrl(ctx context.Context, client *retryablehttp.Client, port uint, path string) string {
+	if dotPath, err := cachecmd.DefaultDotDirectory(ctx); err == nil {
+		addr := cachecmd.ClientAgentSocketAddress(dotPath)
+		if _, err := os.Stat(addr.Path); err == nil {
+			if transport, ok := client.HTTPClient.Transport.(*http.Transport); ok {
+				transport.DialContext = func(ctx context.Context, _, _ string) (net.Conn, error) {
+					var dialer net.Dialer
+					return dialer.DialContext(ctx, "unix", addr.Path)
+				}
+				return fmt.Sprintf("http://localhost/%s", path)
+			}
+		}
+	}
 	return fmt.Sprintf("http://localhost:%d/%s", port, path)
 }
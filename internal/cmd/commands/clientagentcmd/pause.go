@@ -108,7 +108,7 @@ func (c *PauseCommand) Pause(ctx context.Context) (*api.Response, *api.Error, er
 	client.RetryWaitMin = 100 * time.Millisecond
 	client.RetryWaitMax = 1000 * time.Millisecond
 
-	req, err := retryablehttp.NewRequestWithContext(ctx, "POST", clientAgentUrl(c.FlagClientAgentPort, "v1/pause"), nil)
+	req, err := retryablehttp.NewRequestWithContext(ctx, "POST", clientAgentUrl(ctx, client, c.FlagClientAgentPort, "v1/pause"), nil)
 	if err != nil {
 		return nil, nil, err
 	}
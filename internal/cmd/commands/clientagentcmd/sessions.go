@@ -126,7 +126,7 @@ func (c *SessionsCommand) Sessions(ctx context.Context) (*api.Response, *ListSes
 	client.RetryWaitMin = 100 * time.Millisecond
 	client.RetryWaitMax = 1500 * time.Millisecond
 
-	req, err := retryablehttp.NewRequestWithContext(ctx, "GET", clientAgentUrl(c.FlagClientAgentPort, "v1/sessions"), nil)
+	req, err := retryablehttp.NewRequestWithContext(ctx, "GET", clientAgentUrl(ctx, client, c.FlagClientAgentPort, "v1/sessions"), nil)
 	if err != nil {
 		return nil, nil, nil, err
 	}
@@ -119,7 +119,7 @@ func (c *StatusCommand) Status(ctx context.Context) (*api.Response, *GetStatusRe
 	client.RetryWaitMin = 100 * time.Millisecond
 	client.RetryWaitMax = 1500 * time.Millisecond
 
-	req, err := retryablehttp.NewRequestWithContext(ctx, "GET", clientAgentUrl(c.FlagClientAgentPort, "v1/status"), nil)
+	req, err := retryablehttp.NewRequestWithContext(ctx, "GET", clientAgentUrl(ctx, client, c.FlagClientAgentPort, "v1/status"), nil)
 	if err != nil {
 		return nil, nil, nil, err
 	}
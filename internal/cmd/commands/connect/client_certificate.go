@@ -0,0 +1,59 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package connect
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/hashicorp/boundary/api/proxy"
+)
+
+// clientCertificateFiles holds the paths of the temporary files a brokered
+// TLS client certificate credential was written to
+type clientCertificateFiles struct {
+	certificate   string
+	privateKey    string
+	caCertificate string
+}
+
+// writeClientCertificateFiles writes the certificate, private key and, if
+// set, CA certificate of cred to temporary files so they can be passed to a
+// client by path. The files are removed by the command's cleanup funcs.
+func writeClientCertificateFiles(c *Command, cred proxy.TlsClientCertificate) (*clientCertificateFiles, error) {
+	var files clientCertificateFiles
+	var err error
+	if files.certificate, err = writeTempFile(c, "client certificate", cred.Certificate); err != nil {
+		return nil, err
+	}
+	if files.privateKey, err = writeTempFile(c, "client private key", cred.PrivateKey); err != nil {
+		return nil, err
+	}
+	if cred.CaCertificate != "" {
+		if files.caCertificate, err = writeTempFile(c, "CA certificate", cred.CaCertificate); err != nil {
+			return nil, err
+		}
+	}
+	return &files, nil
+}
+
+func writeTempFile(c *Command, desc, contents string) (string, error) {
+	f, err := os.CreateTemp("", "*")
+	if err != nil {
+		return "", fmt.Errorf("Error saving %s to tmp file: %w", desc, err)
+	}
+	c.cleanupFuncs = append(c.cleanupFuncs, func() error {
+		if err := os.Remove(f.Name()); err != nil {
+			return fmt.Errorf("Error removing temporary %s file; consider removing %s manually: %w", desc, f.Name(), err)
+		}
+		return nil
+	})
+	if _, err := f.WriteString(contents); err != nil {
+		return "", fmt.Errorf("Error writing %s file to %s: %w", desc, f.Name(), err)
+	}
+	if err := f.Close(); err != nil {
+		return "", fmt.Errorf("Error closing %s file after writing to %s: %w", desc, f.Name(), err)
+	}
+	return f.Name(), nil
+}
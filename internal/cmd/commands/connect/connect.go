@@ -66,6 +66,8 @@ type Command struct {
 	flagTargetId   string
 	flagTargetName string
 	flagHostId     string
+	flagReason     string
+	flagTicketId   string
 	flagExec       string
 	flagUsername   string
 	flagDbname     string
@@ -170,12 +172,24 @@ func (c *Command) Flags() *base.FlagSets {
 		Usage:  "The ID of a specific host to connect to out of the hosts from the target's host sets. If not specified, one is chosen at random.",
 	})
 
+	f.StringVar(&base.StringVar{
+		Name:   "reason",
+		Target: &c.flagReason,
+		Usage:  "A justification for the session. Required if the target requires a session reason.",
+	})
+
+	f.StringVar(&base.StringVar{
+		Name:   "ticket-id",
+		Target: &c.flagTicketId,
+		Usage:  "The ID of the ticket justifying the session. Required if the target requires a session ticket.",
+	})
+
 	f.StringVar(&base.StringVar{
 		Name:       "exec",
 		Target:     &c.flagExec,
 		EnvVar:     "BOUNDARY_CONNECT_EXEC",
 		Completion: complete.PredictAnything,
-		Usage:      `If set, after connecting to the worker, the given binary will be executed. This should be a binary on your path, or an absolute path. If all command flags are followed by " -- " (space, two hyphens, space), then any arguments after that will be sent directly to the binary.`,
+		Usage:      `If set, after connecting to the worker, the given binary will be executed. This should be a binary on your path, or an absolute path. If all command flags are followed by " -- " (space, two hyphens, space), then any arguments after that will be sent directly to the binary. If the session brokers a cloud access credential, it is exported to the binary as environment variables: AWS_ACCESS_KEY_ID, AWS_SECRET_ACCESS_KEY and AWS_SESSION_TOKEN for AWS; GOOGLE_APPLICATION_CREDENTIALS (pointing to a temporary service account key file), CLOUDSDK_AUTH_ACCESS_TOKEN and GOOGLE_OAUTH_ACCESS_TOKEN for GCP; and AZURE_CLIENT_ID, AZURE_CLIENT_SECRET, AZURE_TENANT_ID and AZURE_SUBSCRIPTION_ID for Azure.`,
 	})
 
 	f.StringVar(&base.StringVar{
@@ -382,6 +396,12 @@ func (c *Command) Run(args []string) (retCode int) {
 		if len(c.flagHostId) != 0 {
 			opts = append(opts, targets.WithHostId(c.flagHostId))
 		}
+		if len(c.flagReason) != 0 {
+			opts = append(opts, targets.WithReason(c.flagReason))
+		}
+		if len(c.flagTicketId) != 0 {
+			opts = append(opts, targets.WithTicketId(c.flagTicketId))
+		}
 		if len(c.flagTargetName) > 0 {
 			opts = append(opts, targets.WithName(c.flagTargetName))
 		}
@@ -524,7 +544,14 @@ func (c *Command) Run(args []string) (retCode int) {
 	case <-c.Context.Done():
 		termInfo.Reason = "Received shutdown signal"
 	default:
-		if c.execCmdReturnValue != nil {
+		if clientProxy.SessionCanceled() {
+			// Print this even when running a command so the user knows why
+			// their connection went away
+			termInfo.Reason = "Session was canceled"
+			if reason := clientProxy.CancelReason(); reason != "" {
+				termInfo.Reason += ": " + reason
+			}
+		} else if c.execCmdReturnValue != nil {
 			// Don't print out in this case, so ensure we clear it
 			termInfo.Reason = ""
 		} else if time.Now().After(clientProxy.SessionExpiration()) {
@@ -665,13 +692,25 @@ func (c *Command) handleExec(clientProxy *apiproxy.ClientProxy, passthroughArgs
 		creds = sshCreds
 
 	case "kube":
-		kubeArgs, err := c.kubeFlags.buildArgs(c, port, host, addr)
+		kubeArgs, kubeCreds, err := c.kubeFlags.buildArgs(c, port, host, addr, creds)
 		if err != nil {
 			c.PrintCliError(fmt.Errorf("Error parsing session args: %w", err))
 			c.execCmdReturnValue.Store(int32(3))
 			return
 		}
 		args = append(args, kubeArgs...)
+		creds = kubeCreds
+	}
+
+	// Cloud access keys are exported to the environment of the wrapped
+	// command so cloud provider CLIs and SDKs pick them up automatically
+	if len(creds.CloudAccess) > 0 {
+		ca := &creds.CloudAccess[0]
+		if ca.ServiceAccountKey != "" && argsErr == nil {
+			ca.ServiceAccountKeyFile, argsErr = writeTempFile(c, "service account key", ca.ServiceAccountKey)
+		}
+		envs = append(envs, ca.Env()...)
+		ca.Consumed = true
 	}
 
 	if argsErr != nil {
@@ -8,6 +8,7 @@ import (
 	"net/url"
 	"strings"
 
+	"github.com/hashicorp/boundary/api/proxy"
 	"github.com/hashicorp/boundary/internal/cmd/base"
 	"github.com/posener/complete"
 )
@@ -56,14 +57,15 @@ func (f *kubeFlags) defaultExec() string {
 	return strings.ToLower(f.flagKubeStyle)
 }
 
-func (f *kubeFlags) buildArgs(c *Command, port, ip, addr string) ([]string, error) {
+func (f *kubeFlags) buildArgs(c *Command, port, ip, addr string, creds proxy.Credentials) ([]string, proxy.Credentials, error) {
 	var args []string
+	retCreds := creds
 	host := f.flagKubeHost
 	if host == "" && c.sessInfo.Endpoint != "" {
 		hostUrl := c.sessInfo.Endpoint
 		u, err := url.Parse(hostUrl)
 		if err != nil {
-			return nil, fmt.Errorf("error parsing endpoint URL: %w", err)
+			return nil, proxy.Credentials{}, fmt.Errorf("error parsing endpoint URL: %w", err)
 		}
 		host = u.Hostname()
 	}
@@ -74,6 +76,22 @@ func (f *kubeFlags) buildArgs(c *Command, port, ip, addr string) ([]string, erro
 			args = append(args, "--tls-server-name", host)
 		}
 		args = append(args, "--server", fmt.Sprintf("%s://%s", f.flagKubeScheme, addr))
+
+		if len(retCreds.TlsClientCert) > 0 {
+			// For now just grab the first tls client certificate credential brokered
+			files, err := writeClientCertificateFiles(c, retCreds.TlsClientCert[0])
+			if err != nil {
+				return nil, proxy.Credentials{}, err
+			}
+			retCreds.TlsClientCert[0].Consumed = true
+			args = append(args,
+				"--client-certificate", files.certificate,
+				"--client-key", files.privateKey,
+			)
+			if files.caCertificate != "" {
+				args = append(args, "--certificate-authority", files.caCertificate)
+			}
+		}
 	}
-	return args, nil
+	return args, retCreds, nil
 }
@@ -109,6 +109,25 @@ func (p *postgresFlags) buildArgs(c *Command, port, ip, _ string, creds proxy.Cr
 				c.UI.Warn("Credentials are being brokered but no -dbname parameter provided. psql may misinterpret another parameter as the database name.")
 			}
 		}
+
+		if len(retCreds.TlsClientCert) > 0 {
+			// For now just grab the first tls client certificate credential brokered
+			files, err := writeClientCertificateFiles(c, retCreds.TlsClientCert[0])
+			if err != nil {
+				return nil, nil, proxy.Credentials{}, err
+			}
+			retCreds.TlsClientCert[0].Consumed = true
+			envs = append(envs,
+				fmt.Sprintf("PGSSLCERT=%s", files.certificate),
+				fmt.Sprintf("PGSSLKEY=%s", files.privateKey),
+			)
+			if files.caCertificate != "" {
+				envs = append(envs,
+					fmt.Sprintf("PGSSLROOTCERT=%s", files.caCertificate),
+					"PGSSLMODE=verify-ca",
+				)
+			}
+		}
 	}
 	return
 }
@@ -18,8 +18,67 @@ const (
 	privateKeyFlagName           = "private-key"
 	privateKeyPassphraseFlagName = "private-key-passphrase"
 	secretFlagName               = "secret"
+	certificateFlagName          = "certificate"
+	caCertificateFlagName        = "ca-certificate"
 )
 
+func init() {
+	extraActionsFlagsMapFunc = extraActionsFlagsMapFuncImpl
+	extraSynopsisFunc = extraSynopsisFuncImpl
+	executeExtraActions = executeExtraActionsImpl
+	printCustomActionOutput = printCustomActionOutputImpl
+}
+
+type extraCmdVars struct {
+	usageResult *credentials.CredentialUsageReadResult
+}
+
+func extraActionsFlagsMapFuncImpl() map[string][]string {
+	return map[string][]string{
+		"read-usage": {"id"},
+	}
+}
+
+func extraSynopsisFuncImpl(c *Command) string {
+	switch c.Func {
+	case "read-usage":
+		return "Read the sessions a credential has been issued to"
+	default:
+		return ""
+	}
+}
+
+func executeExtraActionsImpl(c *Command, origResp *api.Response, origItem *credentials.Credential, origItems []*credentials.Credential, origError error, credClient *credentials.Client, _ uint32, opts []credentials.Option) (*api.Response, *credentials.Credential, []*credentials.Credential, error) {
+	switch c.Func {
+	case "read-usage":
+		var err error
+		c.usageResult, err = credClient.ReadUsage(c.Context, c.FlagId, opts...)
+		if err != nil {
+			return nil, nil, nil, err
+		}
+		return c.usageResult.GetResponse(), nil, nil, nil
+	}
+	return origResp, origItem, origItems, origError
+}
+
+func printCustomActionOutputImpl(c *Command) (bool, error) {
+	switch c.Func {
+	case "read-usage":
+		switch base.Format(c.UI) {
+		case "table":
+			c.UI.Output(printUsageTable(c.usageResult.GetItems()))
+			return true, nil
+
+		case "json":
+			if ok := c.PrintJsonItems(c.usageResult.GetResponse()); !ok {
+				return false, fmt.Errorf("error formatting as JSON")
+			}
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
 func (c *Command) extraHelpFunc(helpMap map[string]func() string) string {
 	var helpStr string
 	switch c.Func {
@@ -59,12 +118,48 @@ func (c *Command) extraHelpFunc(helpMap map[string]func() string) string {
 			"",
 			"  Please see the typed subcommand help for detailed usage information.",
 		})
+	case "read-usage":
+		helpStr = base.WrapForHelpText([]string{
+			"Usage: boundary credentials read-usage [options] [args]",
+			"",
+			"  List the sessions a credential has been issued to, most recent first. The secret itself is never included. Example:",
+			"",
+			`      $ boundary credentials read-usage -id credup_1234567890`,
+			"",
+			"",
+		})
 	default:
 		helpStr = helpMap["base"]()
 	}
 	return helpStr + c.Flags().Help()
 }
 
+func printUsageTable(items []*credentials.CredentialUsage) string {
+	if len(items) == 0 {
+		return "No credential usage found"
+	}
+
+	output := []string{
+		"",
+		"Credential usage information:",
+	}
+	for i, u := range items {
+		if i > 0 {
+			output = append(output, "")
+		}
+		output = append(output,
+			fmt.Sprintf("  Session ID:            %s", u.SessionId),
+			fmt.Sprintf("    Issued Time:         %s", u.CreatedTime.Local().Format(time.RFC1123)),
+			fmt.Sprintf("    User ID:             %s", u.UserId),
+			fmt.Sprintf("    Target ID:           %s", u.TargetId),
+			fmt.Sprintf("    Scope ID:            %s", u.ScopeId),
+			fmt.Sprintf("    Purpose:             %s", u.Purpose),
+		)
+	}
+
+	return base.WrapForHelpText(output)
+}
+
 func (c *Command) printListTable(items []*credentials.Credential) string {
 	if len(items) == 0 {
 		return "No credentials found"
@@ -186,4 +281,6 @@ var keySubstMap = map[string]string{
 	"password_hmac":               "Password HMAC",
 	"private_key_hmac":            "Private Key HMAC",
 	"private_key_passphrase_hmac": "Private Key Passphrase HMAC",
+	"certificate":                 "Certificate",
+	"ca_certificate":              "CA Certificate",
 }
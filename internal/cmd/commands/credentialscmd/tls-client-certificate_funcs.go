@@ -0,0 +1,135 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package credentialscmd
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/hashicorp/boundary/api/credentials"
+	"github.com/hashicorp/boundary/internal/cmd/base"
+	"github.com/hashicorp/go-secure-stdlib/parseutil"
+)
+
+func init() {
+	extraTlsClientCertificateFlagsFunc = extraTlsClientCertificateFlagsFuncImpl
+	extraTlsClientCertificateActionsFlagsMapFunc = extraTlsClientCertificateActionsFlagsMapFuncImpl
+	extraTlsClientCertificateFlagsHandlingFunc = extraTlsClientCertificateFlagHandlingFuncImpl
+}
+
+type extraTlsClientCertificateCmdVars struct {
+	flagCertificate   string
+	flagPrivateKey    string
+	flagCaCertificate string
+}
+
+func extraTlsClientCertificateActionsFlagsMapFuncImpl() map[string][]string {
+	flags := map[string][]string{
+		"create": {
+			certificateFlagName,
+			privateKeyFlagName,
+			caCertificateFlagName,
+		},
+	}
+	flags["update"] = flags["create"]
+	return flags
+}
+
+func extraTlsClientCertificateFlagsFuncImpl(c *TlsClientCertificateCommand, set *base.FlagSets, _ *base.FlagSet) {
+	f := set.NewFlagSet("TLS Client Certificate Credential Options")
+
+	for _, name := range flagsTlsClientCertificateMap[c.Func] {
+		switch name {
+		case certificateFlagName:
+			f.StringVar(&base.StringVar{
+				Name:   certificateFlagName,
+				Target: &c.flagCertificate,
+				Usage:  "The PEM encoded client certificate chain associated with the credential. This can refer to a file on disk (file://) from which the value will be read or an env var (env://) from which the value will be read.",
+			})
+		case privateKeyFlagName:
+			f.StringVar(&base.StringVar{
+				Name:   privateKeyFlagName,
+				Target: &c.flagPrivateKey,
+				Usage:  "The PEM encoded private key for the client certificate. This can refer to a file on disk (file://) from which the value will be read or an env var (env://) from which the value will be read.",
+			})
+		case caCertificateFlagName:
+			f.StringVar(&base.StringVar{
+				Name:   caCertificateFlagName,
+				Target: &c.flagCaCertificate,
+				Usage:  "The PEM encoded CA certificate bundle used to verify the server the client certificate is presented to. This can refer to a file on disk (file://) from which the value will be read or an env var (env://) from which the value will be read.",
+			})
+		}
+	}
+}
+
+func extraTlsClientCertificateFlagHandlingFuncImpl(c *TlsClientCertificateCommand, _ *base.FlagSets, opts *[]credentials.Option) bool {
+	parse := func(name, value string) (string, bool) {
+		v, err := parseutil.MustParsePath(value)
+		switch {
+		case err == nil:
+			return v, true
+		case errors.Is(err, parseutil.ErrNotAUrl), errors.Is(err, parseutil.ErrNotParsed):
+			c.UI.Error(fmt.Sprintf("%s flag must be used with env:// or file:// syntax", name))
+		default:
+			c.UI.Error(fmt.Sprintf("Error parsing %s flag: %v", name, err))
+		}
+		return "", false
+	}
+
+	if c.flagCertificate != "" {
+		cert, ok := parse("Certificate", c.flagCertificate)
+		if !ok {
+			return false
+		}
+		*opts = append(*opts, credentials.WithTlsClientCertificateCredentialCertificate(cert))
+	}
+	if c.flagPrivateKey != "" {
+		key, ok := parse("Private key", c.flagPrivateKey)
+		if !ok {
+			return false
+		}
+		*opts = append(*opts, credentials.WithTlsClientCertificateCredentialPrivateKey(key))
+	}
+	switch c.flagCaCertificate {
+	case "":
+	case "null":
+		*opts = append(*opts, credentials.DefaultTlsClientCertificateCredentialCaCertificate())
+	default:
+		ca, ok := parse("CA certificate", c.flagCaCertificate)
+		if !ok {
+			return false
+		}
+		*opts = append(*opts, credentials.WithTlsClientCertificateCredentialCaCertificate(ca))
+	}
+
+	return true
+}
+
+func (c *TlsClientCertificateCommand) extraTlsClientCertificateHelpFunc(_ map[string]func() string) string {
+	var helpStr string
+	switch c.Func {
+	case "create":
+		helpStr = base.WrapForHelpText([]string{
+			"Usage: boundary credentials create tls-client-certificate -credential-store-id [options] [args]",
+			"",
+			"  Create a TLS client certificate credential. Example:",
+			"",
+			`    $ boundary credentials create tls-client-certificate -credential-store-id csst_1234567890 -certificate file:///home/user/client.crt -private-key file:///home/user/client.key -ca-certificate file:///home/user/ca.crt`,
+			"",
+			"",
+		})
+
+	case "update":
+		helpStr = base.WrapForHelpText([]string{
+			"Usage: boundary credentials update tls-client-certificate [options] [args]",
+			"",
+			"  Update a TLS client certificate credential given its ID. Example:",
+			"",
+			`    $ boundary credentials update tls-client-certificate -id credtls_1234567890 -certificate file:///home/user/client.crt -private-key file:///home/user/client.key`,
+			"",
+			"",
+		})
+	}
+	return helpStr + c.Flags().Help()
+}
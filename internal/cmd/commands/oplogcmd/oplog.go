@@ -0,0 +1,37 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package oplogcmd
+
+import (
+	"github.com/hashicorp/boundary/internal/cmd/base"
+	"github.com/mitchellh/cli"
+)
+
+var _ cli.Command = (*Command)(nil)
+
+type Command struct {
+	*base.Command
+}
+
+func (c *Command) Synopsis() string {
+	return "Read the changes recorded in Boundary's operation log"
+}
+
+func (c *Command) Help() string {
+	return base.WrapForHelpText([]string{
+		"Usage: boundary oplog <subcommand> [options] [args]",
+		"",
+		"  This command groups subcommands for reading the operation log (oplog), which records every change made to Boundary's configuration. Example:",
+		"",
+		"    Print the changes made in the global scope and its child scopes, and wait for new ones:",
+		"",
+		"      $ boundary oplog tail -recursive -follow",
+		"",
+		"  Please see the individual subcommand help for detailed usage information.",
+	})
+}
+
+func (c *Command) Run(args []string) int {
+	return cli.RunResultHelp
+}
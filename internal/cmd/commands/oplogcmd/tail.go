@@ -0,0 +1,197 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package oplogcmd
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/boundary/api"
+	"github.com/hashicorp/boundary/api/scopes"
+	"github.com/hashicorp/boundary/internal/cmd/base"
+	"github.com/mitchellh/cli"
+	"github.com/mitchellh/go-wordwrap"
+	"github.com/posener/complete"
+)
+
+var (
+	_ cli.Command             = (*TailCommand)(nil)
+	_ cli.CommandAutocomplete = (*TailCommand)(nil)
+)
+
+const defaultPollInterval = 5 * time.Second
+
+type TailCommand struct {
+	*base.Command
+
+	flagRecursive    bool
+	flagCursor       string
+	flagCursorFile   string
+	flagFollow       bool
+	flagPollInterval time.Duration
+}
+
+func (c *TailCommand) Synopsis() string {
+	return wordwrap.WrapString("Print the changes recorded in the operation log of a scope as JSON", base.TermWidth)
+}
+
+func (c *TailCommand) Help() string {
+	return base.WrapForHelpText([]string{
+		"Usage: boundary oplog tail [options]",
+		"",
+		"  Print the changes recorded in the operation log of a scope, oldest first, as one JSON object per line. Example:",
+		"",
+		`    $ boundary oplog tail -scope-id global`,
+		"",
+		"  Follow the changes made in the global scope and its child scopes, saving the cursor so a later run resumes where this one stopped:",
+		"",
+		`    $ boundary oplog tail -scope-id global -recursive -follow -cursor-file oplog.cursor`,
+		"",
+		"",
+	}) + c.Flags().Help()
+}
+
+func (c *TailCommand) Flags() *base.FlagSets {
+	set := c.FlagSet(base.FlagSetHTTP | base.FlagSetClient)
+	f := set.NewFlagSet("Command Options")
+
+	f.StringVar(&base.StringVar{
+		Name:    "scope-id",
+		Target:  &c.FlagScopeId,
+		EnvVar:  "BOUNDARY_SCOPE_ID",
+		Default: "global",
+		Usage:   "The id of the scope whose changes to print",
+	})
+	f.BoolVar(&base.BoolVar{
+		Name:   "recursive",
+		Target: &c.flagRecursive,
+		Usage:  "If set, the changes of the scopes within the scope are also printed.",
+	})
+	f.StringVar(&base.StringVar{
+		Name:   "cursor",
+		Target: &c.flagCursor,
+		Usage:  "Print only the changes made after the position of this cursor, as written to -cursor-file. Takes precedence over the cursor read from -cursor-file.",
+	})
+	f.StringVar(&base.StringVar{
+		Name:       "cursor-file",
+		Target:     &c.flagCursorFile,
+		Completion: complete.PredictFiles("*"),
+		Usage:      "A file the cursor is read from when the command starts, if it exists, and written to after each batch of changes is printed.",
+	})
+	f.BoolVar(&base.BoolVar{
+		Name:    "follow",
+		Aliases: []string{"f"},
+		Target:  &c.flagFollow,
+		Usage:   "If set, the command waits for new changes and prints them until it is interrupted.",
+	})
+	f.DurationVar(&base.DurationVar{
+		Name:    "poll-interval",
+		Target:  &c.flagPollInterval,
+		Default: defaultPollInterval,
+		Usage:   "How often to check for new changes when -follow is set.",
+	})
+
+	return set
+}
+
+func (c *TailCommand) AutocompleteArgs() complete.Predictor {
+	return complete.PredictNothing
+}
+
+func (c *TailCommand) AutocompleteFlags() complete.Flags {
+	return c.Flags().Completions()
+}
+
+func (c *TailCommand) Run(args []string) int {
+	f := c.Flags()
+
+	if err := f.Parse(args); err != nil {
+		c.PrintCliError(err)
+		return base.CommandUserError
+	}
+	if c.FlagScopeId == "" {
+		c.PrintCliError(errors.New("Scope ID must be passed in via -scope-id or BOUNDARY_SCOPE_ID"))
+		return base.CommandUserError
+	}
+	if c.flagPollInterval <= 0 {
+		c.PrintCliError(errors.New("Poll interval must be greater than zero"))
+		return base.CommandUserError
+	}
+
+	cursor := c.flagCursor
+	if cursor == "" && c.flagCursorFile != "" {
+		b, err := os.ReadFile(c.flagCursorFile)
+		switch {
+		case err == nil:
+			cursor = strings.TrimSpace(string(b))
+		case !errors.Is(err, os.ErrNotExist):
+			c.PrintCliError(fmt.Errorf("Error reading cursor file: %w", err))
+			return base.CommandUserError
+		}
+	}
+
+	client, err := c.Client()
+	if c.WrapperCleanupFunc != nil {
+		defer func() {
+			if err := c.WrapperCleanupFunc(); err != nil {
+				c.PrintCliError(fmt.Errorf("Error cleaning kms wrapper: %w", err))
+			}
+		}()
+	}
+	if err != nil {
+		c.PrintCliError(fmt.Errorf("Error creating API client: %w", err))
+		return base.CommandCliError
+	}
+
+	sClient := scopes.NewClient(client)
+	for {
+		result, err := sClient.ListOplogEntries(c.Context, c.FlagScopeId, cursor, 0, scopes.WithRecursive(c.flagRecursive))
+		if err != nil {
+			if c.Context.Err() != nil {
+				return base.CommandSuccess
+			}
+			if apiErr := api.AsServerError(err); apiErr != nil {
+				c.PrintApiError(apiErr, "Error from controller when listing oplog entries")
+				return base.CommandApiError
+			}
+			c.PrintCliError(fmt.Errorf("Error trying to list oplog entries: %w", err))
+			return base.CommandCliError
+		}
+
+		for _, item := range result.GetItems() {
+			b, err := json.Marshal(item)
+			if err != nil {
+				c.PrintCliError(fmt.Errorf("Error formatting oplog entry %d as JSON: %w", item.Id, err))
+				return base.CommandCliError
+			}
+			c.UI.Output(string(b))
+		}
+		if result.Cursor != "" && result.Cursor != cursor {
+			cursor = result.Cursor
+			if c.flagCursorFile != "" {
+				if err := os.WriteFile(c.flagCursorFile, []byte(cursor+"\n"), 0o600); err != nil {
+					c.PrintCliError(fmt.Errorf("Error writing cursor file: %w", err))
+					return base.CommandCliError
+				}
+			}
+		}
+
+		if len(result.GetItems()) > 0 {
+			// There may be more entries than fit in a page.
+			continue
+		}
+		if !c.flagFollow {
+			return base.CommandSuccess
+		}
+		select {
+		case <-c.Context.Done():
+			return base.CommandSuccess
+		case <-time.After(c.flagPollInterval):
+		}
+	}
+}
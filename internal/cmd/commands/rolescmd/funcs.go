@@ -5,6 +5,7 @@ package rolescmd
 
 import (
 	"fmt"
+	"strconv"
 	"strings"
 	"time"
 
@@ -24,14 +25,23 @@ func init() {
 	executeExtraActions = executeExtraActionsImpl
 }
 
+const (
+	flagMaxActiveSessionsPerUserName = "max-active-sessions-per-user"
+	flagMaxDailySessionsPerUserName  = "max-daily-sessions-per-user"
+)
+
 type extraCmdVars struct {
-	flagGrantScopeIds []string
-	flagPrincipals    []string
-	flagGrants        []string
+	flagGrantScopeIds            []string
+	flagPrincipals               []string
+	flagGrants                   []string
+	flagMaxActiveSessionsPerUser string
+	flagMaxDailySessionsPerUser  string
 }
 
 func extraActionsFlagsMapFuncImpl() map[string][]string {
 	return map[string][]string{
+		"create":              {flagMaxActiveSessionsPerUserName, flagMaxDailySessionsPerUserName},
+		"update":              {flagMaxActiveSessionsPerUserName, flagMaxDailySessionsPerUserName},
 		"add-principals":      {"id", "principal", "version"},
 		"set-principals":      {"id", "principal", "version"},
 		"remove-principals":   {"id", "principal", "version"},
@@ -199,6 +209,18 @@ func extraFlagsFuncImpl(c *Command, _ *base.FlagSets, f *base.FlagSet) {
 				Target: &c.flagGrants,
 				Usage:  "The grants to add, remove, or set. May be specified multiple times. Can be in compact string format or JSON (be sure to escape JSON properly).",
 			})
+		case flagMaxActiveSessionsPerUserName:
+			f.StringVar(&base.StringVar{
+				Name:   flagMaxActiveSessionsPerUserName,
+				Target: &c.flagMaxActiveSessionsPerUser,
+				Usage:  "The maximum number of pending or active sessions a user can hold when authorizing sessions in the scopes the role grants permissions in. Set to \"null\" to remove the limit.",
+			})
+		case flagMaxDailySessionsPerUserName:
+			f.StringVar(&base.StringVar{
+				Name:   flagMaxDailySessionsPerUserName,
+				Target: &c.flagMaxDailySessionsPerUser,
+				Usage:  "The maximum number of sessions a user can authorize in any 24 hour period in the scopes the role grants permissions in. Set to \"null\" to remove the limit.",
+			})
 		}
 	}
 }
@@ -257,6 +279,32 @@ func extraFlagsHandlingFuncImpl(c *Command, _ *base.FlagSets, opts *[]roles.Opti
 		}
 	}
 
+	switch c.flagMaxActiveSessionsPerUser {
+	case "":
+	case "null":
+		*opts = append(*opts, roles.DefaultMaxActiveSessionsPerUser())
+	default:
+		limit, err := strconv.ParseUint(c.flagMaxActiveSessionsPerUser, 10, 32)
+		if err != nil {
+			c.UI.Error(fmt.Sprintf("Error parsing %q: %s", c.flagMaxActiveSessionsPerUser, err))
+			return false
+		}
+		*opts = append(*opts, roles.WithMaxActiveSessionsPerUser(uint32(limit)))
+	}
+
+	switch c.flagMaxDailySessionsPerUser {
+	case "":
+	case "null":
+		*opts = append(*opts, roles.DefaultMaxDailySessionsPerUser())
+	default:
+		limit, err := strconv.ParseUint(c.flagMaxDailySessionsPerUser, 10, 32)
+		if err != nil {
+			c.UI.Error(fmt.Sprintf("Error parsing %q: %s", c.flagMaxDailySessionsPerUser, err))
+			return false
+		}
+		*opts = append(*opts, roles.WithMaxDailySessionsPerUser(uint32(limit)))
+	}
+
 	if len(c.flagGrants) > 0 {
 		for _, grant := range c.flagGrants {
 			parsed, err := perms.Parse(c.Context, scope.Global.String(), grant)
@@ -411,6 +459,12 @@ func printItemTable(item *roles.Role, resp *api.Response) string {
 	if item.Description != "" {
 		nonAttributeMap["Description"] = item.Description
 	}
+	if item.MaxActiveSessionsPerUser > 0 {
+		nonAttributeMap["Max Active Sessions Per User"] = item.MaxActiveSessionsPerUser
+	}
+	if item.MaxDailySessionsPerUser > 0 {
+		nonAttributeMap["Max Daily Sessions Per User"] = item.MaxDailySessionsPerUser
+	}
 
 	maxLength := base.MaxAttributesLength(nonAttributeMap, nil, nil)
 
@@ -6,6 +6,7 @@ package scopescmd
 import (
 	"fmt"
 	"sort"
+	"strconv"
 	"time"
 
 	"github.com/hashicorp/boundary/api"
@@ -14,10 +15,15 @@ import (
 )
 
 const (
-	flagPrimaryAuthMethodIdName     = "primary-auth-method-id"
-	flagSkipAdminRoleCreationName   = "skip-admin-role-creation"
-	flagSkipDefaultRoleCreationName = "skip-default-role-creation"
-	flagStoragePolicyIdName         = "storage-policy-id"
+	flagKeyRotationDestroyOldVersionsName = "key-rotation-destroy-old-versions"
+	flagKeyRotationIntervalSecondsName    = "key-rotation-interval-seconds"
+	flagKeyRotationRewrapName             = "key-rotation-rewrap"
+	flagMaxActiveSessionsPerUserName      = "max-active-sessions-per-user"
+	flagMaxDailySessionsPerUserName       = "max-daily-sessions-per-user"
+	flagPrimaryAuthMethodIdName           = "primary-auth-method-id"
+	flagSkipAdminRoleCreationName         = "skip-admin-role-creation"
+	flagSkipDefaultRoleCreationName       = "skip-default-role-creation"
+	flagStoragePolicyIdName               = "storage-policy-id"
 )
 
 func init() {
@@ -29,18 +35,23 @@ func init() {
 
 func extraActionsFlagsMapFuncImpl() map[string][]string {
 	return map[string][]string{
-		"create":                {flagSkipAdminRoleCreationName, flagSkipDefaultRoleCreationName},
-		"update":                {flagPrimaryAuthMethodIdName},
+		"create":                {flagSkipAdminRoleCreationName, flagSkipDefaultRoleCreationName, flagMaxActiveSessionsPerUserName, flagMaxDailySessionsPerUserName, flagKeyRotationIntervalSecondsName, flagKeyRotationRewrapName, flagKeyRotationDestroyOldVersionsName},
+		"update":                {flagPrimaryAuthMethodIdName, flagMaxActiveSessionsPerUserName, flagMaxDailySessionsPerUserName, flagKeyRotationIntervalSecondsName, flagKeyRotationRewrapName, flagKeyRotationDestroyOldVersionsName},
 		"attach-storage-policy": {"id", "version", flagStoragePolicyIdName},
 		"detach-storage-policy": {"id", "version"},
 	}
 }
 
 type extraCmdVars struct {
-	flagSkipAdminRoleCreation   bool
-	flagSkipDefaultRoleCreation bool
-	flagPrimaryAuthMethodId     string
-	flagStoragePolicyId         string
+	flagSkipAdminRoleCreation    bool
+	flagSkipDefaultRoleCreation  bool
+	flagPrimaryAuthMethodId      string
+	flagStoragePolicyId          string
+	flagMaxActiveSessionsPerUser string
+	flagMaxDailySessionsPerUser  string
+	flagKeyRotationInterval      string
+	flagKeyRotationRewrap        string
+	flagKeyRotationDestroy       string
 }
 
 func extraFlagsFuncImpl(c *Command, set *base.FlagSets, f *base.FlagSet) {
@@ -64,6 +75,36 @@ func extraFlagsFuncImpl(c *Command, set *base.FlagSets, f *base.FlagSet) {
 				Target: &c.flagPrimaryAuthMethodId,
 				Usage:  "If set, the primary auth method id for the scope.  A primary auth method is allowed to create users on first login and is also used as a source for account full name and email for a scope's users",
 			})
+		case flagMaxActiveSessionsPerUserName:
+			f.StringVar(&base.StringVar{
+				Name:   flagMaxActiveSessionsPerUserName,
+				Target: &c.flagMaxActiveSessionsPerUser,
+				Usage:  "The maximum number of pending or active sessions a user can hold when authorizing sessions in the scope and its descendants. Set to \"null\" to remove the limit.",
+			})
+		case flagMaxDailySessionsPerUserName:
+			f.StringVar(&base.StringVar{
+				Name:   flagMaxDailySessionsPerUserName,
+				Target: &c.flagMaxDailySessionsPerUser,
+				Usage:  "The maximum number of sessions a user can authorize in any 24 hour period in the scope and its descendants. Set to \"null\" to remove the limit.",
+			})
+		case flagKeyRotationIntervalSecondsName:
+			f.StringVar(&base.StringVar{
+				Name:   flagKeyRotationIntervalSecondsName,
+				Target: &c.flagKeyRotationInterval,
+				Usage:  "The number of seconds after which the keys of the scope are automatically rotated. Set to \"null\" to only rotate the keys manually.",
+			})
+		case flagKeyRotationRewrapName:
+			f.StringVar(&base.StringVar{
+				Name:   flagKeyRotationRewrapName,
+				Target: &c.flagKeyRotationRewrap,
+				Usage:  "A boolean indicating if existing data keys are rewrapped with the new root key version when the keys of the scope are automatically rotated.",
+			})
+		case flagKeyRotationDestroyOldVersionsName:
+			f.StringVar(&base.StringVar{
+				Name:   flagKeyRotationDestroyOldVersionsName,
+				Target: &c.flagKeyRotationDestroy,
+				Usage:  "A boolean indicating if the destruction of previous key versions is scheduled when the keys of the scope are automatically rotated.",
+			})
 		case flagStoragePolicyIdName:
 			f.StringVar(&base.StringVar{
 				Name:   flagStoragePolicyIdName,
@@ -94,6 +135,71 @@ func extraFlagsHandlingFuncImpl(c *Command, _ *base.FlagSets, opts *[]scopes.Opt
 		*opts = append(*opts, scopes.WithPrimaryAuthMethodId(c.flagPrimaryAuthMethodId))
 	}
 
+	switch c.flagMaxActiveSessionsPerUser {
+	case "":
+	case "null":
+		*opts = append(*opts, scopes.DefaultMaxActiveSessionsPerUser())
+	default:
+		limit, err := strconv.ParseUint(c.flagMaxActiveSessionsPerUser, 10, 32)
+		if err != nil {
+			c.UI.Error(fmt.Sprintf("Error parsing %q: %s", c.flagMaxActiveSessionsPerUser, err))
+			return false
+		}
+		*opts = append(*opts, scopes.WithMaxActiveSessionsPerUser(uint32(limit)))
+	}
+
+	switch c.flagMaxDailySessionsPerUser {
+	case "":
+	case "null":
+		*opts = append(*opts, scopes.DefaultMaxDailySessionsPerUser())
+	default:
+		limit, err := strconv.ParseUint(c.flagMaxDailySessionsPerUser, 10, 32)
+		if err != nil {
+			c.UI.Error(fmt.Sprintf("Error parsing %q: %s", c.flagMaxDailySessionsPerUser, err))
+			return false
+		}
+		*opts = append(*opts, scopes.WithMaxDailySessionsPerUser(uint32(limit)))
+	}
+
+	switch c.flagKeyRotationInterval {
+	case "":
+	case "null":
+		*opts = append(*opts, scopes.DefaultKeyRotationIntervalSeconds())
+	default:
+		interval, err := strconv.ParseUint(c.flagKeyRotationInterval, 10, 32)
+		if err != nil {
+			c.UI.Error(fmt.Sprintf("Error parsing %q: %s", c.flagKeyRotationInterval, err))
+			return false
+		}
+		*opts = append(*opts, scopes.WithKeyRotationIntervalSeconds(uint32(interval)))
+	}
+
+	switch c.flagKeyRotationRewrap {
+	case "":
+	case "null":
+		*opts = append(*opts, scopes.DefaultKeyRotationRewrap())
+	case "false":
+		*opts = append(*opts, scopes.WithKeyRotationRewrap(false))
+	case "true":
+		*opts = append(*opts, scopes.WithKeyRotationRewrap(true))
+	default:
+		c.UI.Error(fmt.Sprintf("Invalid bool value for %s %v", flagKeyRotationRewrapName, c.flagKeyRotationRewrap))
+		return false
+	}
+
+	switch c.flagKeyRotationDestroy {
+	case "":
+	case "null":
+		*opts = append(*opts, scopes.DefaultKeyRotationDestroyOldVersions())
+	case "false":
+		*opts = append(*opts, scopes.WithKeyRotationDestroyOldVersions(false))
+	case "true":
+		*opts = append(*opts, scopes.WithKeyRotationDestroyOldVersions(true))
+	default:
+		c.UI.Error(fmt.Sprintf("Invalid bool value for %s %v", flagKeyRotationDestroyOldVersionsName, c.flagKeyRotationDestroy))
+		return false
+	}
+
 	return true
 }
 
@@ -200,6 +306,17 @@ func printItemTable(item *scopes.Scope, resp *api.Response) string {
 	if item.StoragePolicyId != "" {
 		nonAttributeMap["Storage Policy ID"] = item.StoragePolicyId
 	}
+	if item.MaxActiveSessionsPerUser > 0 {
+		nonAttributeMap["Max Active Sessions Per User"] = item.MaxActiveSessionsPerUser
+	}
+	if item.MaxDailySessionsPerUser > 0 {
+		nonAttributeMap["Max Daily Sessions Per User"] = item.MaxDailySessionsPerUser
+	}
+	if item.KeyRotationIntervalSeconds > 0 {
+		nonAttributeMap["Key Rotation Interval"] = (time.Duration(item.KeyRotationIntervalSeconds) * time.Second).String()
+		nonAttributeMap["Key Rotation Rewrap"] = item.KeyRotationRewrap
+		nonAttributeMap["Key Rotation Destroys Old Versions"] = item.KeyRotationDestroyOldVersions
+	}
 
 	maxLength := base.MaxAttributesLength(nonAttributeMap, nil, nil)
 
@@ -26,7 +26,9 @@ import (
 	"github.com/hashicorp/boundary/internal/errors"
 	"github.com/hashicorp/boundary/internal/event"
 	"github.com/hashicorp/boundary/internal/kms"
+	"github.com/hashicorp/boundary/internal/util"
 	"github.com/hashicorp/go-hclog"
+	wrapping "github.com/hashicorp/go-kms-wrapping/v2"
 	"github.com/hashicorp/go-secure-stdlib/mlock"
 	"github.com/hashicorp/go-secure-stdlib/parseutil"
 	"github.com/hashicorp/go-uuid"
@@ -47,6 +49,7 @@ type Command struct {
 	opsServer *ops.Server
 
 	SighupCh  chan struct{}
+	SigUSR1Ch chan struct{}
 	SigUSR2Ch chan struct{}
 
 	Config *config.Config
@@ -488,7 +491,7 @@ func (c *Command) Run(args []string) int {
 			}
 		}()
 
-		err = verifyDatabaseState(c.Context, c.Server.Database, c.schemaManager)
+		err = verifyDatabaseState(c.Context, c.Server.Database, c.schemaManager, c.RootKms)
 		if err != nil {
 			c.UI.Error(err.Error())
 			return base.CommandCliError
@@ -509,7 +512,7 @@ func (c *Command) Run(args []string) int {
 	}
 	if c.Config.Controller != nil {
 		// append host-only plugins
-		c.EnabledPlugins = append(c.EnabledPlugins, base.EnabledPluginHostAzure)
+		c.EnabledPlugins = append(c.EnabledPlugins, base.EnabledPluginHostAzure, base.EnabledPluginHostDns)
 		if err := c.StartController(c.Context); err != nil {
 			c.UI.Error(err.Error())
 			return base.CommandCliError
@@ -824,6 +827,17 @@ func (c *Command) WaitForInterrupt() int {
 				c.UI.Error(fmt.Errorf("Error(s) were encountered during reload: %w", err).Error())
 			}
 
+		case <-c.SigUSR1Ch:
+			if c.worker == nil {
+				event.WriteSysEvent(context.TODO(), op, "ignoring drain signal, no worker is running")
+				break
+			}
+			if c.worker.Draining() {
+				c.worker.Undrain()
+			} else {
+				c.worker.Drain(c.Config.Worker.DrainTimeoutDuration)
+			}
+
 		case <-c.SigUSR2Ch:
 			buf := make([]byte, 32*1024*1024)
 			n := runtime.Stack(buf[:], true)
@@ -890,7 +904,7 @@ func (c *Command) Reload(newConf *config.Config) error {
 	return reloadErrors
 }
 
-func verifyKmsSetup(dbase *db.DB) error {
+func verifyKmsSetup(dbase *db.DB, rootWrapper wrapping.Wrapper) error {
 	const op = "server.(Command).verifyKmsExists"
 	rw := db.New(dbase)
 
@@ -899,6 +913,11 @@ func verifyKmsSetup(dbase *db.DB) error {
 	if err != nil {
 		return fmt.Errorf("%s: error creating kms: %w", op, err)
 	}
+	if !util.IsNil(rootWrapper) {
+		if err := kmsCache.AddExternalWrappers(ctx, kms.WithRootWrapper(rootWrapper)); err != nil {
+			return fmt.Errorf("%s: error adding root wrapper to kms: %w", op, err)
+		}
+	}
 	if err := kmsCache.VerifyGlobalRoot(ctx); err != nil {
 		return err
 	}
@@ -937,7 +956,7 @@ func (c *Command) reloadControllerDatabase(newConfig *config.Config) error {
 		return fmt.Errorf("failed to acquire shared lock on new database: %w", err)
 	}
 
-	err = verifyDatabaseState(c.Context, newDb, newDbSchemaManager)
+	err = verifyDatabaseState(c.Context, newDb, newDbSchemaManager, c.RootKms)
 	if err != nil {
 		_ = newDbSchemaManager.Close(c.Context)
 		_ = newDb.Close(c.Context)
@@ -1007,7 +1026,8 @@ func acquireSchemaManager(ctx context.Context, db *db.DB, skipSharedLock bool) (
 }
 
 // verifyDatabaseState checks that the migrations and kms setup for the given database are correctly setup.
-func verifyDatabaseState(ctx context.Context, db *db.DB, schemaManager *schema.Manager) error {
+// When set, the root wrapper must be able to decrypt the global root key.
+func verifyDatabaseState(ctx context.Context, db *db.DB, schemaManager *schema.Manager, rootWrapper wrapping.Wrapper) error {
 	if db == nil {
 		return fmt.Errorf("nil database")
 	}
@@ -1038,7 +1058,7 @@ func verifyDatabaseState(ctx context.Context, db *db.DB, schemaManager *schema.M
 			"Ensure all controllers are shut down before running the migration command.")
 	}
 
-	err = verifyKmsSetup(db)
+	err = verifyKmsSetup(db, rootWrapper)
 	if err != nil {
 		return fmt.Errorf("Database is in a bad state. Please revert the database "+
 			"into the last known good state. (Failed to verify kms setup: %w)", err)
@@ -4,6 +4,7 @@
 package sessionscmd
 
 import (
+	"errors"
 	"fmt"
 	"net"
 	"strconv"
@@ -13,28 +14,50 @@ import (
 	"github.com/hashicorp/boundary/api"
 	"github.com/hashicorp/boundary/api/sessions"
 	"github.com/hashicorp/boundary/internal/cmd/base"
+	"github.com/mitchellh/go-wordwrap"
 )
 
 const (
 	flagIncludeTerminated = "include-terminated"
+	flagReason            = "reason"
+	flagUserId            = "user-id"
+	flagTargetId          = "target-id"
+	flagWorkerId          = "worker-id"
 )
 
 func init() {
 	extraActionsFlagsMapFunc = extraActionsFlagsMapFuncImpl
+	extraSynopsisFunc = extraSynopsisFuncImpl
 	extraFlagsFunc = extraFlagsFuncImpl
 	extraFlagsHandlingFunc = extraFlagsHandlingFuncImpl
 	executeExtraActions = executeExtraActionsImpl
+	printCustomActionOutput = printCustomActionOutputImpl
 }
 
 func extraActionsFlagsMapFuncImpl() map[string][]string {
 	return map[string][]string{
-		"cancel": {"id"},
-		"list":   {flagIncludeTerminated},
+		"cancel":      {"id", flagReason},
+		"list":        {flagIncludeTerminated},
+		"bulk-cancel": {"scope-id", "recursive", flagUserId, flagTargetId, flagWorkerId, flagReason},
 	}
 }
 
 type extraCmdVars struct {
 	flagIncludeTerminated bool
+	flagReason            string
+	flagUserId            string
+	flagTargetId          string
+	flagWorkerId          string
+
+	bulkCancelResult *sessions.SessionCancelResult
+}
+
+func extraSynopsisFuncImpl(c *Command) string {
+	switch c.Func {
+	case "bulk-cancel":
+		return wordwrap.WrapString("Cancel the sessions of a user, target or worker", base.TermWidth)
+	}
+	return ""
 }
 
 func extraFlagsFuncImpl(c *Command, set *base.FlagSets, f *base.FlagSet) {
@@ -46,6 +69,30 @@ func extraFlagsFuncImpl(c *Command, set *base.FlagSets, f *base.FlagSet) {
 				Target: &c.flagIncludeTerminated,
 				Usage:  "If set, terminated sessions will be included in the results.",
 			})
+		case flagReason:
+			f.StringVar(&base.StringVar{
+				Name:   flagReason,
+				Target: &c.flagReason,
+				Usage:  "The reason for canceling, which is shown to the session's user when their connections are closed.",
+			})
+		case flagUserId:
+			f.StringVar(&base.StringVar{
+				Name:   flagUserId,
+				Target: &c.flagUserId,
+				Usage:  "If set, only sessions of this user are canceled.",
+			})
+		case flagTargetId:
+			f.StringVar(&base.StringVar{
+				Name:   flagTargetId,
+				Target: &c.flagTargetId,
+				Usage:  "If set, only sessions to this target are canceled.",
+			})
+		case flagWorkerId:
+			f.StringVar(&base.StringVar{
+				Name:   flagWorkerId,
+				Target: &c.flagWorkerId,
+				Usage:  "If set, only sessions with open connections proxied by this worker are canceled.",
+			})
 		}
 	}
 }
@@ -54,6 +101,28 @@ func extraFlagsHandlingFuncImpl(c *Command, _ *base.FlagSets, opts *[]sessions.O
 	if c.flagIncludeTerminated {
 		*opts = append(*opts, sessions.WithIncludeTerminated(c.flagIncludeTerminated))
 	}
+	if c.Func == "bulk-cancel" {
+		if c.FlagScopeId == "" {
+			c.PrintCliError(errors.New("Scope ID must be passed in via -scope-id or BOUNDARY_SCOPE_ID"))
+			return false
+		}
+		if c.flagUserId == "" && c.flagTargetId == "" && c.flagWorkerId == "" {
+			c.PrintCliError(errors.New("At least one of -user-id, -target-id or -worker-id must be provided"))
+			return false
+		}
+	}
+	if c.flagReason != "" {
+		*opts = append(*opts, sessions.WithReason(c.flagReason))
+	}
+	if c.flagUserId != "" {
+		*opts = append(*opts, sessions.WithUserId(c.flagUserId))
+	}
+	if c.flagTargetId != "" {
+		*opts = append(*opts, sessions.WithTargetId(c.flagTargetId))
+	}
+	if c.flagWorkerId != "" {
+		*opts = append(*opts, sessions.WithWorkerId(c.flagWorkerId))
+	}
 	return true
 }
 
@@ -79,7 +148,20 @@ func (c *Command) extraHelpFunc(helpMap map[string]func() string) string {
 			"",
 			"  Cancel the session specified by ID. If the session is already canceled, this command succeeds with no effect. Example:",
 			"",
-			`    $ boundary sessions cancel -id s_1234567890`,
+			`    $ boundary sessions cancel -id s_1234567890 -reason "Scheduled maintenance"`,
+			"",
+			"  If -reason is given, it is shown to the session's user when their connections are closed.",
+			"",
+			"",
+		})
+
+	case "bulk-cancel":
+		helpStr = base.WrapForHelpText([]string{
+			"Usage: boundary sessions bulk-cancel [options] [args]",
+			"",
+			"  Cancel the pending and active sessions in a project that match the given user, target or worker. At least one of -user-id, -target-id or -worker-id must be provided. Sessions the caller isn't allowed to cancel are skipped. Example:",
+			"",
+			`    $ boundary sessions bulk-cancel -scope-id global -recursive -user-id u_1234567890 -reason "Account disabled"`,
 			"",
 			"",
 		})
@@ -99,10 +181,40 @@ func executeExtraActionsImpl(c *Command, origResp *api.Response, origItem *sessi
 			return nil, nil, nil, err
 		}
 		return result.GetResponse(), result.GetItem(), nil, err
+
+	case "bulk-cancel":
+		result, err := sessionClient.CancelSessions(c.Context, c.FlagScopeId, opts...)
+		if err != nil {
+			return nil, nil, nil, err
+		}
+		c.bulkCancelResult = result
+		return result.GetResponse(), nil, result.GetItems(), nil
 	}
 	return origResp, origItem, origItems, origError
 }
 
+func printCustomActionOutputImpl(c *Command) (bool, error) {
+	switch c.Func {
+	case "bulk-cancel":
+		switch base.Format(c.UI) {
+		case "table":
+			if len(c.bulkCancelResult.GetItems()) == 0 {
+				c.UI.Output("No sessions canceled")
+				return true, nil
+			}
+			c.UI.Output(c.printListTable(c.bulkCancelResult.GetItems()))
+			return true, nil
+
+		case "json":
+			if ok := c.PrintJsonItems(c.bulkCancelResult.GetResponse()); !ok {
+				return false, fmt.Errorf("error formatting as JSON")
+			}
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
 func (c *Command) printListTable(items []*sessions.Session) string {
 	if len(items) == 0 {
 		return "No sessions found"
@@ -215,6 +327,15 @@ func printItemTable(item *sessions.Session, resp *api.Response) string {
 	if len(strings.TrimSpace(item.TerminationReason)) > 0 {
 		nonAttributeMap["Termination Reason"] = item.TerminationReason
 	}
+	if item.Reason != "" {
+		nonAttributeMap["Reason"] = item.Reason
+	}
+	if item.CancelReason != "" {
+		nonAttributeMap["Cancel Reason"] = item.CancelReason
+	}
+	if item.TicketId != "" {
+		nonAttributeMap["Ticket ID"] = item.TicketId
+	}
 
 	maxLength := base.MaxAttributesLength(nonAttributeMap, nil, nil)
 
@@ -0,0 +1,291 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package sessionscmd
+
+import (
+	"bytes"
+	"context"
+	"crypto/ed25519"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"os"
+	"strings"
+
+	"github.com/hashicorp/boundary/api"
+	"github.com/hashicorp/boundary/api/consts"
+	"github.com/hashicorp/boundary/api/sessions"
+	"github.com/hashicorp/boundary/api/targets"
+	"github.com/hashicorp/boundary/internal/bsr"
+	"github.com/hashicorp/boundary/internal/bsr/ssh"
+	"github.com/hashicorp/boundary/internal/cmd/base"
+	pb "github.com/hashicorp/boundary/sdk/pbs/proxy"
+	"github.com/hashicorp/boundary/sdk/wspb"
+	"github.com/hashicorp/go-cleanhttp"
+	"github.com/mitchellh/cli"
+	"github.com/mitchellh/go-wordwrap"
+	"github.com/posener/complete"
+	"golang.org/x/term"
+	"nhooyr.io/websocket"
+)
+
+var (
+	_ cli.Command             = (*ShadowCommand)(nil)
+	_ cli.CommandAutocomplete = (*ShadowCommand)(nil)
+)
+
+type ShadowCommand struct {
+	*base.Command
+
+	flagWithInput bool
+}
+
+func (c *ShadowCommand) Synopsis() string {
+	return wordwrap.WrapString("Observe the terminal of an active SSH session", base.TermWidth)
+}
+
+func (c *ShadowCommand) Help() string {
+	return base.WrapForHelpText([]string{
+		"Usage: boundary sessions shadow [args]",
+		"",
+		"  Observe the terminal of an active SSH session. The terminal is written to stdout as it is sent to the session's user. Only sessions whose SSH connection is terminated by the worker can be shadowed. Example:",
+		"",
+		`    $ boundary sessions shadow -id s_1234567890`,
+		"",
+		"  If -with-input is set, what is typed is also sent to the session. Press Ctrl-] to stop shadowing.",
+		"",
+		"",
+	}) + c.Flags().Help()
+}
+
+func (c *ShadowCommand) Flags() *base.FlagSets {
+	set := c.FlagSet(base.FlagSetHTTP | base.FlagSetClient)
+	f := set.NewFlagSet("Command Options")
+
+	f.StringVar(&base.StringVar{
+		Name:   "id",
+		Target: &c.FlagId,
+		Usage:  "The id of the session to shadow.",
+	})
+	f.BoolVar(&base.BoolVar{
+		Name:   "with-input",
+		Target: &c.flagWithInput,
+		Usage:  "If set, input typed while shadowing is sent to the session. Requires the shadow grant to allow input.",
+	})
+	return set
+}
+
+func (c *ShadowCommand) AutocompleteArgs() complete.Predictor {
+	return complete.PredictAnything
+}
+
+func (c *ShadowCommand) AutocompleteFlags() complete.Flags {
+	return c.Flags().Completions()
+}
+
+// shadowEscapeByte is Ctrl-], which ends the shadow when sending input.
+const shadowEscapeByte = 0x1d
+
+func (c *ShadowCommand) Run(args []string) int {
+	f := c.Flags()
+
+	if err := f.Parse(args); err != nil {
+		c.PrintCliError(err)
+		return base.CommandUserError
+	}
+
+	switch {
+	case c.FlagId == "":
+		c.PrintCliError(errors.New("ID must be provided via -id"))
+		return base.CommandUserError
+	}
+
+	client, err := c.Client()
+	if c.WrapperCleanupFunc != nil {
+		defer func() {
+			if err := c.WrapperCleanupFunc(); err != nil {
+				c.PrintCliError(fmt.Errorf("Error cleaning kms wrapper: %w", err))
+			}
+		}()
+	}
+	if err != nil {
+		c.PrintCliError(fmt.Errorf("Error creating API client: %w", err))
+		return base.CommandCliError
+	}
+
+	result, err := sessions.NewClient(client).Shadow(c.Context, c.FlagId, c.flagWithInput)
+	if err != nil {
+		if apiErr := api.AsServerError(err); apiErr != nil {
+			c.PrintApiError(apiErr, "Error from controller when requesting to shadow session")
+			return base.CommandApiError
+		}
+		c.PrintCliError(fmt.Errorf("Error requesting to shadow session: %w", err))
+		return base.CommandCliError
+	}
+	item := result.GetItem()
+	authzData, err := targets.SessionAuthorization{AuthorizationToken: item.AuthorizationToken}.GetSessionAuthorizationData()
+	if err != nil {
+		c.PrintCliError(fmt.Errorf("Error decoding shadow authorization: %w", err))
+		return base.CommandCliError
+	}
+
+	ctx, cancel := context.WithDeadline(c.Context, item.ExpirationTime)
+	defer cancel()
+
+	wsConn, err := dialShadow(ctx, authzData)
+	if err != nil {
+		c.PrintCliError(err)
+		return base.CommandCliError
+	}
+	defer wsConn.Close(websocket.StatusNormalClosure, "shadow finished")
+
+	handshake := &pb.ClientHandshake{
+		Command:     pb.HANDSHAKECOMMAND_HANDSHAKECOMMAND_SHADOW,
+		ShadowToken: item.ShadowToken,
+	}
+	if err := wspb.Write(ctx, wsConn, handshake); err != nil {
+		c.PrintCliError(fmt.Errorf("Error sending handshake to worker: %w", err))
+		return base.CommandCliError
+	}
+	var handshakeResult pb.HandshakeResult
+	if err := wspb.Read(ctx, wsConn, &handshakeResult); err != nil {
+		c.PrintCliError(fmt.Errorf("Error reading handshake result: %w", err))
+		return base.CommandCliError
+	}
+
+	netConn := websocket.NetConn(ctx, wsConn, websocket.MessageBinary)
+	defer netConn.Close()
+
+	if c.flagWithInput {
+		if term.IsTerminal(int(os.Stdin.Fd())) {
+			oldState, err := term.MakeRaw(int(os.Stdin.Fd()))
+			if err != nil {
+				c.PrintCliError(fmt.Errorf("Error setting terminal to raw mode: %w", err))
+				return base.CommandCliError
+			}
+			defer term.Restore(int(os.Stdin.Fd()), oldState)
+		}
+		go func() {
+			defer cancel()
+			buf := make([]byte, 1024)
+			for {
+				n, err := os.Stdin.Read(buf)
+				if n > 0 {
+					if i := bytes.IndexByte(buf[:n], shadowEscapeByte); i >= 0 {
+						netConn.Write(buf[:i])
+						return
+					}
+					if _, err := netConn.Write(buf[:n]); err != nil {
+						return
+					}
+				}
+				if err != nil {
+					return
+				}
+			}
+		}()
+	}
+
+	dec, err := bsr.NewChunkDecoder(ctx, netConn)
+	if err != nil {
+		c.PrintCliError(fmt.Errorf("Error creating chunk decoder: %w", err))
+		return base.CommandCliError
+	}
+	for {
+		chunk, err := dec.Decode(ctx)
+		switch {
+		case err == io.EOF, ctx.Err() != nil:
+			return base.CommandSuccess
+		case err != nil:
+			var closeErr websocket.CloseError
+			if errors.As(err, &closeErr) && closeErr.Code == websocket.StatusNormalClosure {
+				return base.CommandSuccess
+			}
+			c.PrintCliError(fmt.Errorf("Error reading session terminal: %w", err))
+			return base.CommandCliError
+		}
+		if dc, ok := chunk.(*ssh.DataChunk); ok && dc.GetDirection() == bsr.Outbound {
+			os.Stdout.Write(dc.Data)
+		}
+	}
+}
+
+// dialShadow opens a websocket connection to the first worker in the
+// authorization data, authenticating with the observer's certificate. The
+// worker must present the session's certificate, which issued the observer's.
+func dialShadow(ctx context.Context, authzData *targets.SessionAuthorizationData) (*websocket.Conn, error) {
+	if len(authzData.WorkerInfo) == 0 {
+		return nil, errors.New("no workers found in shadow authorization")
+	}
+	workerAddr := authzData.WorkerInfo[0].Address
+
+	parsedCert, err := x509.ParseCertificate(authzData.Certificate)
+	if err != nil {
+		return nil, fmt.Errorf("unable to decode mTLS certificate: %w", err)
+	}
+	workerHost, _, err := net.SplitHostPort(workerAddr)
+	if err != nil {
+		if !strings.Contains(err.Error(), "missing port") {
+			return nil, fmt.Errorf("error splitting worker host/port: %w", err)
+		}
+		workerHost = workerAddr
+	}
+	tlsConf := &tls.Config{
+		Certificates: []tls.Certificate{
+			{
+				Certificate: [][]byte{authzData.Certificate},
+				PrivateKey:  ed25519.PrivateKey(authzData.PrivateKey),
+				Leaf:        parsedCert,
+			},
+		},
+		ServerName: workerHost,
+		MinVersion: tls.VersionTLS13,
+		NextProtos: []string{"http/1.1", authzData.SessionId},
+
+		// This is set this way so we can make use of VerifyConnection. We are
+		// not skipping verification!
+		InsecureSkipVerify: true,
+		VerifyConnection: func(cs tls.ConnectionState) error {
+			if len(cs.PeerCertificates) == 0 {
+				return errors.New("no peer certificates provided")
+			}
+			sessionCert := cs.PeerCertificates[0]
+			if err := parsedCert.CheckSignatureFrom(sessionCert); err != nil {
+				return fmt.Errorf("worker certificate did not issue the shadow certificate: %w", err)
+			}
+			certPool := x509.NewCertPool()
+			certPool.AddCert(sessionCert)
+			_, err := sessionCert.Verify(x509.VerifyOptions{
+				DNSName: authzData.SessionId,
+				Roots:   certPool,
+				KeyUsages: []x509.ExtKeyUsage{
+					x509.ExtKeyUsageServerAuth,
+				},
+			})
+			return err
+		},
+	}
+
+	transport := cleanhttp.DefaultTransport()
+	transport.DialContext = func(ctx context.Context, network, addr string) (net.Conn, error) {
+		dialer := &tls.Dialer{Config: tlsConf}
+		return dialer.DialContext(ctx, network, addr)
+	}
+	conn, _, err := websocket.Dial(
+		ctx,
+		fmt.Sprintf("ws://%s/v1/proxy", workerAddr),
+		&websocket.DialOptions{
+			HTTPClient:   &http.Client{Transport: transport},
+			Subprotocols: []string{consts.WebsocketProtocolTcpProxyV1},
+		},
+	)
+	if err != nil {
+		return nil, fmt.Errorf("error dialing the worker: %w", err)
+	}
+	return conn, nil
+}
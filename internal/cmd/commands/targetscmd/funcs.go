@@ -7,7 +7,9 @@ import (
 	"bytes"
 	"encoding/base64"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"math"
 	"strings"
 	"time"
 
@@ -20,6 +22,7 @@ import (
 	"github.com/hashicorp/boundary/internal/credential/static"
 	"github.com/hashicorp/boundary/internal/credential/vault"
 	"github.com/hashicorp/boundary/internal/types/scope"
+	"github.com/hashicorp/go-secure-stdlib/parseutil"
 	"github.com/hashicorp/go-secure-stdlib/strutil"
 	"github.com/mitchellh/go-wordwrap"
 	"github.com/posener/complete"
@@ -40,12 +43,14 @@ type extraCmdVars struct {
 	flagBrokeredCredentialSources            []string
 	flagInjectedApplicationCredentialSources []string
 	flagHostId                               string
+	flagReason                               string
+	flagTicketId                             string
 	sar                                      *targets.SessionAuthorizationResult
 }
 
 func extraActionsFlagsMapFuncImpl() map[string][]string {
 	return map[string][]string{
-		"authorize-session":         {"id", "host-id"},
+		"authorize-session":         {"id", "host-id", "reason", "ticket-id"},
 		"add-host-sources":          {"id", "host-source", "version"},
 		"remove-host-sources":       {"id", "host-source", "version"},
 		"set-host-sources":          {"id", "host-source", "version"},
@@ -241,6 +246,18 @@ func extraFlagsFuncImpl(c *Command, _ *base.FlagSets, f *base.FlagSet) {
 				Target: &c.flagHostId,
 				Usage:  "The ID of a specific host to connect to out of the hosts from the target's host sets. If not specified, one is chosen at random.",
 			})
+		case "reason":
+			f.StringVar(&base.StringVar{
+				Name:   "reason",
+				Target: &c.flagReason,
+				Usage:  "A justification for the session. Required if the target requires a session reason.",
+			})
+		case "ticket-id":
+			f.StringVar(&base.StringVar{
+				Name:   "ticket-id",
+				Target: &c.flagTicketId,
+				Usage:  "The ID of the ticket justifying the session. Required if the target requires a session ticket.",
+			})
 		case "brokered-credential-source":
 			f.StringSliceVar(&base.StringSliceVar{
 				Name:   "brokered-credential-source",
@@ -382,6 +399,12 @@ func extraFlagsHandlingFuncImpl(c *Command, _ *base.FlagSets, opts *[]targets.Op
 		if len(c.flagHostId) != 0 {
 			*opts = append(*opts, targets.WithHostId(c.flagHostId))
 		}
+		if len(c.flagReason) != 0 {
+			*opts = append(*opts, targets.WithReason(c.flagReason))
+		}
+		if len(c.flagTicketId) != 0 {
+			*opts = append(*opts, targets.WithTicketId(c.flagTicketId))
+		}
 	}
 
 	return true
@@ -497,6 +520,35 @@ func (c *Command) printListTable(items []*targets.Target) string {
 	return base.WrapForHelpText(output)
 }
 
+// parseAccessWindowFlag parses the value of the access-window flag, a json
+// object which can also be provided as a file:// or env:// path.
+func parseAccessWindowFlag(val string) (*targets.AccessWindow, error) {
+	parsed, err := parseutil.ParsePath(val)
+	if err != nil && !errors.Is(err, parseutil.ErrNotAUrl) {
+		return nil, fmt.Errorf("error parsing access-window flag as a URL: %w", err)
+	}
+	dec := json.NewDecoder(strings.NewReader(parsed))
+	dec.DisallowUnknownFields()
+	var aw targets.AccessWindow
+	if err := dec.Decode(&aw); err != nil {
+		return nil, fmt.Errorf("error parsing access-window flag as JSON: %w", err)
+	}
+	return &aw, nil
+}
+
+// parseRateLimitFlag parses the value of a rate limit flag, a number of bytes
+// per second which can be given as a capacity string such as "10mb".
+func parseRateLimitFlag(val string) (uint32, error) {
+	limit, err := parseutil.ParseCapacityString(val)
+	if err != nil {
+		return 0, err
+	}
+	if limit == 0 || limit > math.MaxUint32 {
+		return 0, fmt.Errorf("rate limit must be between 1 and %d bytes per second", uint32(math.MaxUint32))
+	}
+	return uint32(limit), nil
+}
+
 func printItemTable(item *targets.Target, resp *api.Response) string {
 	nonAttributeMap := map[string]any{}
 	if item.Id != "" {
@@ -532,6 +584,24 @@ func printItemTable(item *targets.Target, resp *api.Response) string {
 	if item.IngressWorkerFilter != "" {
 		nonAttributeMap["Ingress Worker Filter"] = item.IngressWorkerFilter
 	}
+	if item.WorkerSelectionStrategy != "" {
+		nonAttributeMap["Worker Selection Strategy"] = item.WorkerSelectionStrategy
+	}
+	if item.HostHealthCheck != "" {
+		nonAttributeMap["Host Health Check"] = item.HostHealthCheck
+	}
+	if item.SessionReasonRequired {
+		nonAttributeMap["Session Reason Required"] = item.SessionReasonRequired
+	}
+	if item.SessionTicketPattern != "" {
+		nonAttributeMap["Session Ticket Pattern"] = item.SessionTicketPattern
+	}
+	if item.SessionUploadRateLimit != 0 {
+		nonAttributeMap["Session Upload Rate Limit"] = item.SessionUploadRateLimit
+	}
+	if item.SessionDownloadRateLimit != 0 {
+		nonAttributeMap["Session Download Rate Limit"] = item.SessionDownloadRateLimit
+	}
 	if resp != nil && resp.Map != nil {
 		if resp.Map[globals.SessionConnectionLimitField] != nil {
 			nonAttributeMap["Session Connection Limit"] = item.SessionConnectionLimit
@@ -593,6 +663,26 @@ func printItemTable(item *targets.Target, resp *api.Response) string {
 		}
 	}
 
+	var accessWindowMap map[string]any
+	if item.AccessWindow != nil {
+		accessWindowMap = map[string]any{
+			"Time Zone":          "UTC",
+			"Terminate Sessions": item.AccessWindow.TerminateSessions,
+		}
+		if item.AccessWindow.TimeZone != "" {
+			accessWindowMap["Time Zone"] = item.AccessWindow.TimeZone
+		}
+		if len(item.AccessWindow.Schedules) > 0 {
+			accessWindowMap["Schedules"] = strings.Join(item.AccessWindow.Schedules, ", ")
+		}
+		if len(item.AccessWindow.BlackoutDates) > 0 {
+			accessWindowMap["Blackout Dates"] = strings.Join(item.AccessWindow.BlackoutDates, ", ")
+		}
+		if l := len("Terminate Sessions"); l > maxLength {
+			maxLength = l
+		}
+	}
+
 	var aliasMaps []map[string]any
 	if len(item.Aliases) > 0 {
 		for _, al := range item.Aliases {
@@ -633,6 +723,14 @@ func printItemTable(item *targets.Target, resp *api.Response) string {
 		"",
 	)
 
+	if accessWindowMap != nil {
+		ret = append(ret,
+			"  Access Window:",
+			base.WrapMap(4, maxLength, accessWindowMap),
+			"",
+		)
+	}
+
 	if len(aliasMaps) > 0 {
 		ret = append(ret,
 			"  Aliases:",
@@ -706,6 +804,9 @@ func printCustomActionOutputImpl(c *Command) (bool, error) {
 				"Type":                item.Type,
 				"Authorization Token": item.AuthorizationToken,
 			}
+			if len(item.Route) > 0 {
+				nonAttributeMap["Route"] = strings.Join(item.Route, " -> ")
+			}
 
 			maxLength := 0
 			for k := range nonAttributeMap {
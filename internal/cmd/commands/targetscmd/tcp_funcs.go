@@ -11,6 +11,7 @@ import (
 	"github.com/hashicorp/boundary/api/targets"
 	"github.com/hashicorp/boundary/internal/cmd/base"
 	"github.com/hashicorp/go-bexpr"
+	"github.com/posener/complete"
 )
 
 func init() {
@@ -24,28 +25,42 @@ func extraTcpActionsFlagsMapFuncImpl() map[string][]string {
 		"create": {
 			"address", "default-port", "default-client-port", "session-max-seconds",
 			"session-connection-limit", "egress-worker-filter", "ingress-worker-filter",
+			"worker-selection-strategy", "host-health-check", "access-window", "session-reason-required",
+			"session-ticket-pattern", "session-upload-rate-limit", "session-download-rate-limit",
 			"with-alias-value", "with-alias-scope-id", "with-alias-authorize-session-host-id",
+			"template-id", "template-override",
 		},
 		"update": {
 			"address", "default-port", "default-client-port", "session-max-seconds",
 			"session-connection-limit", "worker-filter", "egress-worker-filter",
-			"ingress-worker-filter",
+			"ingress-worker-filter", "worker-selection-strategy", "host-health-check", "access-window",
+			"session-reason-required", "session-ticket-pattern", "session-upload-rate-limit",
+			"session-download-rate-limit", "template-id", "template-override",
 		},
 	}
 }
 
 type extraTcpCmdVars struct {
-	flagDefaultPort            string
-	flagDefaultClientPort      string
-	flagSessionMaxSeconds      string
-	flagSessionConnectionLimit string
-	flagWorkerFilter           string
-	flagEgressWorkerFilter     string
-	flagIngressWorkerFilter    string
-	flagAddress                string
-	flagWithAliasValue         string
-	flagWithAliasScopeId       string
-	flagWithAliasHostId        string
+	flagDefaultPort              string
+	flagDefaultClientPort        string
+	flagSessionMaxSeconds        string
+	flagSessionConnectionLimit   string
+	flagWorkerFilter             string
+	flagEgressWorkerFilter       string
+	flagIngressWorkerFilter      string
+	flagWorkerSelectionStrategy  string
+	flagHostHealthCheck          string
+	flagAccessWindow             string
+	flagSessionReasonRequired    string
+	flagSessionTicketPattern     string
+	flagSessionUploadRateLimit   string
+	flagSessionDownloadRateLimit string
+	flagTemplateId               string
+	flagTemplateOverrides        []string
+	flagAddress                  string
+	flagWithAliasValue           string
+	flagWithAliasScopeId         string
+	flagWithAliasHostId          string
 }
 
 func (c *TcpCommand) extraTcpHelpFunc(helpMap map[string]func() string) string {
@@ -129,6 +144,63 @@ func extraTcpFlagsFuncImpl(c *TcpCommand, set *base.FlagSets, f *base.FlagSet) {
 				Target: &c.flagIngressWorkerFilter,
 				Usage:  "A boolean expression to filter which ingress workers can handle sessions for this target.",
 			})
+		case "worker-selection-strategy":
+			fs.StringVar(&base.StringVar{
+				Name:       "worker-selection-strategy",
+				Target:     &c.flagWorkerSelectionStrategy,
+				Completion: complete.PredictSet("random", "least_connections", "weighted"),
+				Usage:      `The strategy used to order the workers that can handle sessions for this target. One of "random", "least_connections" or "weighted". Defaults to "random".`,
+			})
+		case "host-health-check":
+			fs.StringVar(&base.StringVar{
+				Name:       "host-health-check",
+				Target:     &c.flagHostHealthCheck,
+				Completion: complete.PredictSet("tcp", "tls"),
+				Usage:      `The probe workers use to check the health of this target's hosts, which lets sessions skip unhealthy hosts and fail over to healthy ones. One of "tcp" or "tls". Health checking is disabled if unset.`,
+			})
+		case "access-window":
+			fs.StringVar(&base.StringVar{
+				Name:   "access-window",
+				Target: &c.flagAccessWindow,
+				Usage:  `A JSON object restricting when sessions to this target can be authorized and connected to, with the keys "schedules", "time_zone", "blackout_dates" and "terminate_sessions". Can be a file:// or env:// path. Use "null" to remove the access window.`,
+			})
+		case "session-reason-required":
+			fs.StringVar(&base.StringVar{
+				Name:       "session-reason-required",
+				Target:     &c.flagSessionReasonRequired,
+				Completion: complete.PredictSet("true", "false"),
+				Usage:      "A boolean indicating if users must provide a reason when authorizing a session to this target.",
+			})
+		case "session-ticket-pattern":
+			fs.StringVar(&base.StringVar{
+				Name:   "session-ticket-pattern",
+				Target: &c.flagSessionTicketPattern,
+				Usage:  `A regular expression that ticket IDs provided when authorizing a session to this target must match. If set, a ticket ID is required. Use "null" to remove the requirement.`,
+			})
+		case "session-upload-rate-limit":
+			fs.StringVar(&base.StringVar{
+				Name:   "session-upload-rate-limit",
+				Target: &c.flagSessionUploadRateLimit,
+				Usage:  `The maximum number of bytes per second the clients of a session to this target can send, e.g. "10mb". Overrides the session upload limit configured on workers. Use "null" to remove the limit.`,
+			})
+		case "session-download-rate-limit":
+			fs.StringVar(&base.StringVar{
+				Name:   "session-download-rate-limit",
+				Target: &c.flagSessionDownloadRateLimit,
+				Usage:  `The maximum number of bytes per second sent to the clients of a session to this target, e.g. "10mb". Overrides the session download limit configured on workers. Use "null" to remove the limit.`,
+			})
+		case "template-id":
+			fs.StringVar(&base.StringVar{
+				Name:   "template-id",
+				Target: &c.flagTemplateId,
+				Usage:  `The ID of a target template whose settings are applied to this target. Use "null" to detach the target from its template.`,
+			})
+		case "template-override":
+			fs.StringSliceVar(&base.StringSliceVar{
+				Name:   "template-override",
+				Target: &c.flagTemplateOverrides,
+				Usage:  `A setting of this target that is not managed by its template, such as "session_max_seconds". May be specified multiple times. Use "null" to clear the overrides.`,
+			})
 		case "with-alias-value":
 			fs.StringVar(&base.StringVar{
 				Name:   "with-alias-value",
@@ -246,6 +318,89 @@ func extraTcpFlagsHandlingFuncImpl(c *TcpCommand, _ *base.FlagSets, opts *[]targ
 		}
 		*opts = append(*opts, targets.WithIngressWorkerFilter(c.flagIngressWorkerFilter))
 	}
+	switch c.flagWorkerSelectionStrategy {
+	case "":
+	case "null":
+		*opts = append(*opts, targets.DefaultWorkerSelectionStrategy())
+	default:
+		*opts = append(*opts, targets.WithWorkerSelectionStrategy(c.flagWorkerSelectionStrategy))
+	}
+	switch c.flagHostHealthCheck {
+	case "":
+	case "null":
+		*opts = append(*opts, targets.DefaultHostHealthCheck())
+	default:
+		*opts = append(*opts, targets.WithHostHealthCheck(c.flagHostHealthCheck))
+	}
+	switch c.flagAccessWindow {
+	case "":
+	case "null":
+		*opts = append(*opts, targets.DefaultAccessWindow())
+	default:
+		aw, err := parseAccessWindowFlag(c.flagAccessWindow)
+		if err != nil {
+			c.UI.Error(err.Error())
+			return false
+		}
+		*opts = append(*opts, targets.WithAccessWindow(aw))
+	}
+	switch c.flagSessionReasonRequired {
+	case "":
+	case "null":
+		*opts = append(*opts, targets.DefaultSessionReasonRequired())
+	case "false":
+		*opts = append(*opts, targets.WithSessionReasonRequired(false))
+	case "true":
+		*opts = append(*opts, targets.WithSessionReasonRequired(true))
+	default:
+		c.UI.Error(fmt.Sprintf("Invalid bool value for session-reason-required %v", c.flagSessionReasonRequired))
+		return false
+	}
+	switch c.flagSessionTicketPattern {
+	case "":
+	case "null":
+		*opts = append(*opts, targets.DefaultSessionTicketPattern())
+	default:
+		*opts = append(*opts, targets.WithSessionTicketPattern(c.flagSessionTicketPattern))
+	}
+	switch c.flagSessionUploadRateLimit {
+	case "":
+	case "null":
+		*opts = append(*opts, targets.DefaultSessionUploadRateLimit())
+	default:
+		limit, err := parseRateLimitFlag(c.flagSessionUploadRateLimit)
+		if err != nil {
+			c.UI.Error(fmt.Sprintf("Error parsing %q: %s", c.flagSessionUploadRateLimit, err))
+			return false
+		}
+		*opts = append(*opts, targets.WithSessionUploadRateLimit(limit))
+	}
+	switch c.flagSessionDownloadRateLimit {
+	case "":
+	case "null":
+		*opts = append(*opts, targets.DefaultSessionDownloadRateLimit())
+	default:
+		limit, err := parseRateLimitFlag(c.flagSessionDownloadRateLimit)
+		if err != nil {
+			c.UI.Error(fmt.Sprintf("Error parsing %q: %s", c.flagSessionDownloadRateLimit, err))
+			return false
+		}
+		*opts = append(*opts, targets.WithSessionDownloadRateLimit(limit))
+	}
+	switch c.flagTemplateId {
+	case "":
+	case "null":
+		*opts = append(*opts, targets.DefaultTemplateId())
+	default:
+		*opts = append(*opts, targets.WithTemplateId(c.flagTemplateId))
+	}
+	switch {
+	case len(c.flagTemplateOverrides) == 0:
+	case len(c.flagTemplateOverrides) == 1 && c.flagTemplateOverrides[0] == "null":
+		*opts = append(*opts, targets.DefaultTemplateOverrides())
+	default:
+		*opts = append(*opts, targets.WithTemplateOverrides(c.flagTemplateOverrides))
+	}
 
 	switch c.flagAddress {
 	case "":
@@ -0,0 +1,463 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package targetscmd
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/hashicorp/boundary/api/targets"
+	"github.com/hashicorp/boundary/internal/cmd/base"
+	"github.com/hashicorp/go-bexpr"
+	"github.com/posener/complete"
+)
+
+func init() {
+	extraUdpActionsFlagsMapFunc = extraUdpActionsFlagsMapFuncImpl
+	extraUdpFlagsFunc = extraUdpFlagsFuncImpl
+	extraUdpFlagsHandlingFunc = extraUdpFlagsHandlingFuncImpl
+}
+
+func extraUdpActionsFlagsMapFuncImpl() map[string][]string {
+	return map[string][]string{
+		"create": {
+			"address", "default-port", "default-client-port", "session-max-seconds",
+			"session-connection-limit", "egress-worker-filter", "ingress-worker-filter",
+			"worker-selection-strategy", "host-health-check", "access-window", "session-reason-required",
+			"session-ticket-pattern", "session-upload-rate-limit", "session-download-rate-limit",
+			"with-alias-value", "with-alias-scope-id", "with-alias-authorize-session-host-id",
+			"template-id", "template-override",
+		},
+		"update": {
+			"address", "default-port", "default-client-port", "session-max-seconds",
+			"session-connection-limit", "worker-filter", "egress-worker-filter",
+			"ingress-worker-filter", "worker-selection-strategy", "host-health-check", "access-window",
+			"session-reason-required", "session-ticket-pattern", "session-upload-rate-limit",
+			"session-download-rate-limit", "template-id", "template-override",
+		},
+	}
+}
+
+type extraUdpCmdVars struct {
+	flagDefaultPort              string
+	flagDefaultClientPort        string
+	flagSessionMaxSeconds        string
+	flagSessionConnectionLimit   string
+	flagWorkerFilter             string
+	flagEgressWorkerFilter       string
+	flagIngressWorkerFilter      string
+	flagWorkerSelectionStrategy  string
+	flagHostHealthCheck          string
+	flagAccessWindow             string
+	flagSessionReasonRequired    string
+	flagSessionTicketPattern     string
+	flagSessionUploadRateLimit   string
+	flagSessionDownloadRateLimit string
+	flagTemplateId               string
+	flagTemplateOverrides        []string
+	flagAddress                  string
+	flagWithAliasValue           string
+	flagWithAliasScopeId         string
+	flagWithAliasHostId          string
+}
+
+func (c *UdpCommand) extraUdpHelpFunc(helpMap map[string]func() string) string {
+	var helpStr string
+	switch c.Func {
+	case "create":
+		helpStr = base.WrapForHelpText([]string{
+			"Usage: boundary targets create udp [options] [args]",
+			"",
+			"  Create a udp-type target. Example:",
+			"",
+			`    $ boundary targets create udp -name prodops -description "Udp target for ProdOps"`,
+			"",
+			"",
+		})
+
+	case "update":
+		helpStr = base.WrapForHelpText([]string{
+			"Usage: boundary targets update udp [options] [args]",
+			"",
+			"  Update a udp-type target given its ID. Example:",
+			"",
+			`    $ boundary targets update udp -id tudp_1234567890 -name "devops" -description "Udp target for DevOps"`,
+			"",
+			"",
+		})
+	}
+	return helpStr + c.Flags().Help()
+}
+
+func extraUdpFlagsFuncImpl(c *UdpCommand, set *base.FlagSets, f *base.FlagSet) {
+	fs := set.NewFlagSet("UDP Target Options")
+
+	for _, name := range flagsUdpMap[c.Func] {
+		switch name {
+		case "address":
+			fs.StringVar(&base.StringVar{
+				Name:   "address",
+				Target: &c.flagAddress,
+				Usage:  "Optionally, a valid network address to connect to for this target. Can not be used alongside host sources.",
+			})
+		case "default-port":
+			fs.StringVar(&base.StringVar{
+				Name:   "default-port",
+				Target: &c.flagDefaultPort,
+				Usage:  "The default port to set on the target.",
+			})
+		case "default-client-port":
+			fs.StringVar(&base.StringVar{
+				Name:   "default-client-port",
+				Target: &c.flagDefaultClientPort,
+				Usage:  "The default client port to set on the target.",
+			})
+		case "session-max-seconds":
+			fs.StringVar(&base.StringVar{
+				Name:   "session-max-seconds",
+				Target: &c.flagSessionMaxSeconds,
+				Usage:  `The maximum lifetime of the session, including all connections. Can be specified as an integer number of seconds or a duration string.`,
+			})
+		case "session-connection-limit":
+			fs.StringVar(&base.StringVar{
+				Name:   "session-connection-limit",
+				Target: &c.flagSessionConnectionLimit,
+				Usage:  "The maximum number of connections allowed for a session. -1 means unlimited.",
+			})
+		case "worker-filter":
+			fs.StringVar(&base.StringVar{
+				Name:   "worker-filter",
+				Target: &c.flagWorkerFilter,
+				Usage:  "Deprecated: use egress or ingress filters instead.",
+			})
+		case "egress-worker-filter":
+			fs.StringVar(&base.StringVar{
+				Name:   "egress-worker-filter",
+				Target: &c.flagEgressWorkerFilter,
+				Usage:  "A boolean expression to filter which egress workers can handle sessions for this target.",
+			})
+		case "ingress-worker-filter":
+			fs.StringVar(&base.StringVar{
+				Name:   "ingress-worker-filter",
+				Target: &c.flagIngressWorkerFilter,
+				Usage:  "A boolean expression to filter which ingress workers can handle sessions for this target.",
+			})
+		case "worker-selection-strategy":
+			fs.StringVar(&base.StringVar{
+				Name:       "worker-selection-strategy",
+				Target:     &c.flagWorkerSelectionStrategy,
+				Completion: complete.PredictSet("random", "least_connections", "weighted"),
+				Usage:      `The strategy used to order the workers that can handle sessions for this target. One of "random", "least_connections" or "weighted". Defaults to "random".`,
+			})
+		case "host-health-check":
+			fs.StringVar(&base.StringVar{
+				Name:       "host-health-check",
+				Target:     &c.flagHostHealthCheck,
+				Completion: complete.PredictSet("tcp", "tls"),
+				Usage:      `The probe workers use to check the health of this target's hosts, which lets sessions skip unhealthy hosts and fail over to healthy ones. One of "tcp" or "tls". Health checking is disabled if unset.`,
+			})
+		case "access-window":
+			fs.StringVar(&base.StringVar{
+				Name:   "access-window",
+				Target: &c.flagAccessWindow,
+				Usage:  `A JSON object restricting when sessions to this target can be authorized and connected to, with the keys "schedules", "time_zone", "blackout_dates" and "terminate_sessions". Can be a file:// or env:// path. Use "null" to remove the access window.`,
+			})
+		case "session-reason-required":
+			fs.StringVar(&base.StringVar{
+				Name:       "session-reason-required",
+				Target:     &c.flagSessionReasonRequired,
+				Completion: complete.PredictSet("true", "false"),
+				Usage:      "A boolean indicating if users must provide a reason when authorizing a session to this target.",
+			})
+		case "session-ticket-pattern":
+			fs.StringVar(&base.StringVar{
+				Name:   "session-ticket-pattern",
+				Target: &c.flagSessionTicketPattern,
+				Usage:  `A regular expression that ticket IDs provided when authorizing a session to this target must match. If set, a ticket ID is required. Use "null" to remove the requirement.`,
+			})
+		case "session-upload-rate-limit":
+			fs.StringVar(&base.StringVar{
+				Name:   "session-upload-rate-limit",
+				Target: &c.flagSessionUploadRateLimit,
+				Usage:  `The maximum number of bytes per second the clients of a session to this target can send, e.g. "10mb". Overrides the session upload limit configured on workers. Use "null" to remove the limit.`,
+			})
+		case "session-download-rate-limit":
+			fs.StringVar(&base.StringVar{
+				Name:   "session-download-rate-limit",
+				Target: &c.flagSessionDownloadRateLimit,
+				Usage:  `The maximum number of bytes per second sent to the clients of a session to this target, e.g. "10mb". Overrides the session download limit configured on workers. Use "null" to remove the limit.`,
+			})
+		case "template-id":
+			fs.StringVar(&base.StringVar{
+				Name:   "template-id",
+				Target: &c.flagTemplateId,
+				Usage:  `The ID of a target template whose settings are applied to this target. Use "null" to detach the target from its template.`,
+			})
+		case "template-override":
+			fs.StringSliceVar(&base.StringSliceVar{
+				Name:   "template-override",
+				Target: &c.flagTemplateOverrides,
+				Usage:  `A setting of this target that is not managed by its template, such as "session_max_seconds". May be specified multiple times. Use "null" to clear the overrides.`,
+			})
+		case "with-alias-value":
+			fs.StringVar(&base.StringVar{
+				Name:   "with-alias-value",
+				Target: &c.flagWithAliasValue,
+				Usage:  "The value for an alias to be created for and at the same time as this target.",
+			})
+		case "with-alias-scope-id":
+			fs.StringVar(&base.StringVar{
+				Name:    "with-alias-scope-id",
+				Target:  &c.flagWithAliasScopeId,
+				Default: "global",
+				Usage:   "The scope id for an alias to be created for and at the same time as this target.",
+			})
+		case "with-alias-authorize-session-host-id":
+			fs.StringVar(&base.StringVar{
+				Name:   "with-alias-authorize-session-host-id",
+				Target: &c.flagWithAliasHostId,
+				Usage:  "The authorize session host id flag used by an alias to be created for and at the same time as this target.",
+			})
+		}
+	}
+}
+
+func extraUdpFlagsHandlingFuncImpl(c *UdpCommand, _ *base.FlagSets, opts *[]targets.Option) bool {
+	switch c.flagDefaultPort {
+	case "":
+	case "null":
+		*opts = append(*opts, targets.DefaultUdpTargetDefaultPort())
+	default:
+		port, err := strconv.ParseUint(c.flagDefaultPort, 10, 32)
+		if err != nil {
+			c.UI.Error(fmt.Sprintf("Error parsing %q: %s", c.flagDefaultPort, err))
+			return false
+		}
+		*opts = append(*opts, targets.WithUdpTargetDefaultPort(uint32(port)))
+	}
+
+	switch c.flagDefaultClientPort {
+	case "":
+	case "null":
+		*opts = append(*opts, targets.DefaultUdpTargetDefaultClientPort())
+	default:
+		port, err := strconv.ParseUint(c.flagDefaultClientPort, 10, 32)
+		if err != nil {
+			c.UI.Error(fmt.Sprintf("Error parsing %q: %s", c.flagDefaultClientPort, err))
+			return false
+		}
+		*opts = append(*opts, targets.WithUdpTargetDefaultClientPort(uint32(port)))
+	}
+
+	switch c.flagSessionMaxSeconds {
+	case "":
+	case "null":
+		*opts = append(*opts, targets.DefaultSessionMaxSeconds())
+	default:
+		var final uint32
+		dur, err := strconv.ParseUint(c.flagSessionMaxSeconds, 10, 32)
+		if err == nil {
+			final = uint32(dur)
+		} else {
+			dur, err := time.ParseDuration(c.flagSessionMaxSeconds)
+			if err != nil {
+				c.UI.Error(fmt.Sprintf("Error parsing %q: %s", c.flagSessionMaxSeconds, err))
+				return false
+			}
+			final = uint32(dur.Seconds())
+		}
+		*opts = append(*opts, targets.WithSessionMaxSeconds(final))
+	}
+
+	switch c.flagSessionConnectionLimit {
+	case "":
+	case "null":
+		*opts = append(*opts, targets.DefaultSessionConnectionLimit())
+	default:
+		limit, err := strconv.ParseInt(c.flagSessionConnectionLimit, 10, 32)
+		if err != nil {
+			c.UI.Error(fmt.Sprintf("Error parsing %q: %s", c.flagSessionConnectionLimit, err))
+			return false
+		}
+		*opts = append(*opts, targets.WithSessionConnectionLimit(int32(limit)))
+	}
+
+	switch c.flagWorkerFilter {
+	case "":
+	case "null":
+		*opts = append(*opts, targets.DefaultWorkerFilter())
+	default:
+		if _, err := bexpr.CreateEvaluator(c.flagWorkerFilter); err != nil {
+			c.UI.Error(fmt.Sprintf("Unable to successfully parse worker filter expression: %s", err))
+			return false
+		}
+		*opts = append(*opts, targets.WithWorkerFilter(c.flagWorkerFilter))
+	}
+
+	switch c.flagEgressWorkerFilter {
+	case "":
+	case "null":
+		*opts = append(*opts, targets.DefaultEgressWorkerFilter())
+	default:
+		if _, err := bexpr.CreateEvaluator(c.flagEgressWorkerFilter); err != nil {
+			c.UI.Error(fmt.Sprintf("Unable to successfully parse egress filter expression: %s", err))
+			return false
+		}
+		*opts = append(*opts, targets.WithEgressWorkerFilter(c.flagEgressWorkerFilter))
+	}
+	switch c.flagIngressWorkerFilter {
+	case "":
+	case "null":
+		*opts = append(*opts, targets.DefaultIngressWorkerFilter())
+	default:
+		if _, err := bexpr.CreateEvaluator(c.flagIngressWorkerFilter); err != nil {
+			c.UI.Error(fmt.Sprintf("Unable to successfully parse ingress filter expression: %s", err))
+			return false
+		}
+		*opts = append(*opts, targets.WithIngressWorkerFilter(c.flagIngressWorkerFilter))
+	}
+	switch c.flagWorkerSelectionStrategy {
+	case "":
+	case "null":
+		*opts = append(*opts, targets.DefaultWorkerSelectionStrategy())
+	default:
+		*opts = append(*opts, targets.WithWorkerSelectionStrategy(c.flagWorkerSelectionStrategy))
+	}
+	switch c.flagHostHealthCheck {
+	case "":
+	case "null":
+		*opts = append(*opts, targets.DefaultHostHealthCheck())
+	default:
+		*opts = append(*opts, targets.WithHostHealthCheck(c.flagHostHealthCheck))
+	}
+	switch c.flagAccessWindow {
+	case "":
+	case "null":
+		*opts = append(*opts, targets.DefaultAccessWindow())
+	default:
+		aw, err := parseAccessWindowFlag(c.flagAccessWindow)
+		if err != nil {
+			c.UI.Error(err.Error())
+			return false
+		}
+		*opts = append(*opts, targets.WithAccessWindow(aw))
+	}
+	switch c.flagSessionReasonRequired {
+	case "":
+	case "null":
+		*opts = append(*opts, targets.DefaultSessionReasonRequired())
+	case "false":
+		*opts = append(*opts, targets.WithSessionReasonRequired(false))
+	case "true":
+		*opts = append(*opts, targets.WithSessionReasonRequired(true))
+	default:
+		c.UI.Error(fmt.Sprintf("Invalid bool value for session-reason-required %v", c.flagSessionReasonRequired))
+		return false
+	}
+	switch c.flagSessionTicketPattern {
+	case "":
+	case "null":
+		*opts = append(*opts, targets.DefaultSessionTicketPattern())
+	default:
+		*opts = append(*opts, targets.WithSessionTicketPattern(c.flagSessionTicketPattern))
+	}
+	switch c.flagSessionUploadRateLimit {
+	case "":
+	case "null":
+		*opts = append(*opts, targets.DefaultSessionUploadRateLimit())
+	default:
+		limit, err := parseRateLimitFlag(c.flagSessionUploadRateLimit)
+		if err != nil {
+			c.UI.Error(fmt.Sprintf("Error parsing %q: %s", c.flagSessionUploadRateLimit, err))
+			return false
+		}
+		*opts = append(*opts, targets.WithSessionUploadRateLimit(limit))
+	}
+	switch c.flagSessionDownloadRateLimit {
+	case "":
+	case "null":
+		*opts = append(*opts, targets.DefaultSessionDownloadRateLimit())
+	default:
+		limit, err := parseRateLimitFlag(c.flagSessionDownloadRateLimit)
+		if err != nil {
+			c.UI.Error(fmt.Sprintf("Error parsing %q: %s", c.flagSessionDownloadRateLimit, err))
+			return false
+		}
+		*opts = append(*opts, targets.WithSessionDownloadRateLimit(limit))
+	}
+	switch c.flagTemplateId {
+	case "":
+	case "null":
+		*opts = append(*opts, targets.DefaultTemplateId())
+	default:
+		*opts = append(*opts, targets.WithTemplateId(c.flagTemplateId))
+	}
+	switch {
+	case len(c.flagTemplateOverrides) == 0:
+	case len(c.flagTemplateOverrides) == 1 && c.flagTemplateOverrides[0] == "null":
+		*opts = append(*opts, targets.DefaultTemplateOverrides())
+	default:
+		*opts = append(*opts, targets.WithTemplateOverrides(c.flagTemplateOverrides))
+	}
+
+	switch c.flagAddress {
+	case "":
+	case "null":
+		*opts = append(*opts, targets.DefaultAddress())
+	default:
+		*opts = append(*opts, targets.WithAddress(c.flagAddress))
+	}
+
+	var aliasValue string
+	switch c.flagWithAliasValue {
+	case "":
+	case "null":
+		c.UI.Error("The with-alias-value flag cannot be set to null")
+		return false
+	default:
+		aliasValue = c.flagWithAliasValue
+	}
+
+	var aliasScopeId string
+	switch c.flagWithAliasScopeId {
+	case "":
+	case "null":
+		c.UI.Error("The with-alias-scope-id flag cannot be set to null")
+		return false
+	default:
+		aliasScopeId = c.flagWithAliasScopeId
+	}
+
+	var aliasHostId string
+	switch c.flagWithAliasHostId {
+	case "":
+	case "null":
+		c.UI.Error("The with-alias-authorize-session-host-id flag cannot be set to null")
+		return false
+	default:
+		aliasHostId = c.flagWithAliasHostId
+	}
+
+	switch {
+	case aliasValue != "" && aliasScopeId == "":
+		c.UI.Error("The with-alias-value flag must be used with the with-alias-scope-id flag")
+		return false
+	case aliasValue != "" && aliasScopeId != "":
+		a := targets.Alias{
+			Value:   aliasValue,
+			ScopeId: aliasScopeId,
+		}
+		if aliasHostId != "" {
+			a.Attributes = &targets.TargetAliasAttributes{
+				AuthorizeSessionArguments: &targets.AuthorizeSessionArguments{
+					HostId: aliasHostId,
+				},
+			}
+		}
+		*opts = append(*opts, targets.WithAliases([]targets.Alias{a}))
+	}
+
+	return true
+}
@@ -0,0 +1,407 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package targettemplatescmd
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/boundary/api"
+	"github.com/hashicorp/boundary/api/targettemplates"
+	"github.com/hashicorp/boundary/internal/cmd/base"
+	"github.com/hashicorp/go-bexpr"
+)
+
+func init() {
+	extraActionsFlagsMapFunc = extraActionsFlagsMapFuncImpl
+	extraSynopsisFunc = extraSynopsisFuncImpl
+	extraFlagsFunc = extraFlagsFuncImpl
+	extraFlagsHandlingFunc = extraFlagsHandlingFuncImpl
+	executeExtraActions = executeExtraActionsImpl
+	printCustomActionOutput = printCustomActionOutputImpl
+}
+
+type extraCmdVars struct {
+	flagSessionMaxSeconds                    string
+	flagSessionConnectionLimit               string
+	flagEgressWorkerFilter                   string
+	flagIngressWorkerFilter                  string
+	flagHostSources                          []string
+	flagBrokeredCredentialSources            []string
+	flagInjectedApplicationCredentialSources []string
+	outOfSyncResult                          *targettemplates.OutOfSyncTargetListResult
+}
+
+func extraActionsFlagsMapFuncImpl() map[string][]string {
+	settings := []string{
+		"session-max-seconds", "session-connection-limit", "egress-worker-filter", "ingress-worker-filter",
+		"host-source", "brokered-credential-source", "injected-application-credential-source",
+	}
+	return map[string][]string{
+		"create":                   settings,
+		"update":                   settings,
+		"list-out-of-sync-targets": {"id"},
+	}
+}
+
+func extraSynopsisFuncImpl(c *Command) string {
+	switch c.Func {
+	case "list-out-of-sync-targets":
+		return "List the targets whose settings have drifted from a target template"
+	}
+	return ""
+}
+
+func (c *Command) extraHelpFunc(helpMap map[string]func() string) string {
+	var helpStr string
+	switch c.Func {
+	case "list-out-of-sync-targets":
+		helpStr = base.WrapForHelpText([]string{
+			"Usage: boundary target-templates list-out-of-sync-targets [options] [args]",
+			"",
+			"  List the targets attached to a target template whose managed settings no longer match the template, along with the fields that differ. Example:",
+			"",
+			`    $ boundary target-templates list-out-of-sync-targets -id ttpl_1234567890`,
+			"",
+			"",
+		})
+
+	default:
+		helpStr = helpMap["base"]()
+	}
+	return helpStr + c.Flags().Help()
+}
+
+func extraFlagsFuncImpl(c *Command, set *base.FlagSets, _ *base.FlagSet) {
+	if c.Func != "create" && c.Func != "update" {
+		return
+	}
+	fs := set.NewFlagSet("Target Template Options")
+
+	for _, name := range flagsMap[c.Func] {
+		switch name {
+		case "session-max-seconds":
+			fs.StringVar(&base.StringVar{
+				Name:   "session-max-seconds",
+				Target: &c.flagSessionMaxSeconds,
+				Usage:  `The maximum lifetime of sessions to targets using this template. Can be specified as an integer number of seconds or a duration string.`,
+			})
+		case "session-connection-limit":
+			fs.StringVar(&base.StringVar{
+				Name:   "session-connection-limit",
+				Target: &c.flagSessionConnectionLimit,
+				Usage:  "The maximum number of connections allowed for sessions to targets using this template. -1 means unlimited.",
+			})
+		case "egress-worker-filter":
+			fs.StringVar(&base.StringVar{
+				Name:   "egress-worker-filter",
+				Target: &c.flagEgressWorkerFilter,
+				Usage:  "A boolean expression to filter which egress workers can handle sessions for targets using this template.",
+			})
+		case "ingress-worker-filter":
+			fs.StringVar(&base.StringVar{
+				Name:   "ingress-worker-filter",
+				Target: &c.flagIngressWorkerFilter,
+				Usage:  "A boolean expression to filter which ingress workers can handle sessions for targets using this template.",
+			})
+		case "host-source":
+			fs.StringSliceVar(&base.StringSliceVar{
+				Name:   "host-source",
+				Target: &c.flagHostSources,
+				Usage:  `The host sources to set on targets using this template. May be specified multiple times. Use "null" to clear them.`,
+			})
+		case "brokered-credential-source":
+			fs.StringSliceVar(&base.StringSliceVar{
+				Name:   "brokered-credential-source",
+				Target: &c.flagBrokeredCredentialSources,
+				Usage:  `The brokered credential sources to set on targets using this template. May be specified multiple times. Use "null" to clear them.`,
+			})
+		case "injected-application-credential-source":
+			fs.StringSliceVar(&base.StringSliceVar{
+				Name:   "injected-application-credential-source",
+				Target: &c.flagInjectedApplicationCredentialSources,
+				Usage:  `The injected application credential sources to set on targets using this template. May be specified multiple times. Use "null" to clear them.`,
+			})
+		}
+	}
+}
+
+func extraFlagsHandlingFuncImpl(c *Command, _ *base.FlagSets, opts *[]targettemplates.Option) bool {
+	switch c.flagSessionMaxSeconds {
+	case "":
+	case "null":
+		*opts = append(*opts, targettemplates.DefaultSessionMaxSeconds())
+	default:
+		var final uint32
+		dur, err := strconv.ParseUint(c.flagSessionMaxSeconds, 10, 32)
+		if err == nil {
+			final = uint32(dur)
+		} else {
+			dur, err := time.ParseDuration(c.flagSessionMaxSeconds)
+			if err != nil {
+				c.UI.Error(fmt.Sprintf("Error parsing %q: %s", c.flagSessionMaxSeconds, err))
+				return false
+			}
+			final = uint32(dur.Seconds())
+		}
+		*opts = append(*opts, targettemplates.WithSessionMaxSeconds(final))
+	}
+
+	switch c.flagSessionConnectionLimit {
+	case "":
+	case "null":
+		*opts = append(*opts, targettemplates.DefaultSessionConnectionLimit())
+	default:
+		limit, err := strconv.ParseInt(c.flagSessionConnectionLimit, 10, 32)
+		if err != nil {
+			c.UI.Error(fmt.Sprintf("Error parsing %q: %s", c.flagSessionConnectionLimit, err))
+			return false
+		}
+		*opts = append(*opts, targettemplates.WithSessionConnectionLimit(int32(limit)))
+	}
+
+	switch c.flagEgressWorkerFilter {
+	case "":
+	case "null":
+		*opts = append(*opts, targettemplates.DefaultEgressWorkerFilter())
+	default:
+		if _, err := bexpr.CreateEvaluator(c.flagEgressWorkerFilter); err != nil {
+			c.UI.Error(fmt.Sprintf("Unable to successfully parse egress filter expression: %s", err))
+			return false
+		}
+		*opts = append(*opts, targettemplates.WithEgressWorkerFilter(c.flagEgressWorkerFilter))
+	}
+
+	switch c.flagIngressWorkerFilter {
+	case "":
+	case "null":
+		*opts = append(*opts, targettemplates.DefaultIngressWorkerFilter())
+	default:
+		if _, err := bexpr.CreateEvaluator(c.flagIngressWorkerFilter); err != nil {
+			c.UI.Error(fmt.Sprintf("Unable to successfully parse ingress filter expression: %s", err))
+			return false
+		}
+		*opts = append(*opts, targettemplates.WithIngressWorkerFilter(c.flagIngressWorkerFilter))
+	}
+
+	switch {
+	case len(c.flagHostSources) == 0:
+	case len(c.flagHostSources) == 1 && c.flagHostSources[0] == "null":
+		*opts = append(*opts, targettemplates.DefaultHostSourceIds())
+	default:
+		*opts = append(*opts, targettemplates.WithHostSourceIds(c.flagHostSources))
+	}
+
+	switch {
+	case len(c.flagBrokeredCredentialSources) == 0:
+	case len(c.flagBrokeredCredentialSources) == 1 && c.flagBrokeredCredentialSources[0] == "null":
+		*opts = append(*opts, targettemplates.DefaultBrokeredCredentialSourceIds())
+	default:
+		*opts = append(*opts, targettemplates.WithBrokeredCredentialSourceIds(c.flagBrokeredCredentialSources))
+	}
+
+	switch {
+	case len(c.flagInjectedApplicationCredentialSources) == 0:
+	case len(c.flagInjectedApplicationCredentialSources) == 1 && c.flagInjectedApplicationCredentialSources[0] == "null":
+		*opts = append(*opts, targettemplates.DefaultInjectedApplicationCredentialSourceIds())
+	default:
+		*opts = append(*opts, targettemplates.WithInjectedApplicationCredentialSourceIds(c.flagInjectedApplicationCredentialSources))
+	}
+
+	return true
+}
+
+func executeExtraActionsImpl(c *Command, origResp *api.Response, origItem *targettemplates.TargetTemplate, origItems []*targettemplates.TargetTemplate, origError error, templateClient *targettemplates.Client, _ uint32, opts []targettemplates.Option) (*api.Response, *targettemplates.TargetTemplate, []*targettemplates.TargetTemplate, error) {
+	switch c.Func {
+	case "list-out-of-sync-targets":
+		var err error
+		c.outOfSyncResult, err = templateClient.ListOutOfSyncTargets(c.Context, c.FlagId, opts...)
+		if err != nil {
+			return nil, nil, nil, err
+		}
+		return c.outOfSyncResult.GetResponse(), nil, nil, nil
+	}
+	return origResp, origItem, origItems, origError
+}
+
+func (c *Command) printListTable(items []*targettemplates.TargetTemplate) string {
+	if len(items) == 0 {
+		return "No target templates found"
+	}
+
+	var output []string
+	output = []string{
+		"",
+		"Target Template information:",
+	}
+
+	for i, item := range items {
+		if i > 0 {
+			output = append(output, "")
+		}
+		if item.Id != "" {
+			output = append(output,
+				fmt.Sprintf("  ID:                    %s", item.Id),
+			)
+		} else {
+			output = append(output,
+				fmt.Sprintf("  ID:                    %s", "(not available)"),
+			)
+		}
+		if c.FlagRecursive && item.ScopeId != "" {
+			output = append(output,
+				fmt.Sprintf("    Scope ID:            %s", item.ScopeId),
+			)
+		}
+		if item.Version > 0 {
+			output = append(output,
+				fmt.Sprintf("    Version:             %d", item.Version),
+			)
+		}
+		if item.Name != "" {
+			output = append(output,
+				fmt.Sprintf("    Name:                %s", item.Name),
+			)
+		}
+		if item.Description != "" {
+			output = append(output,
+				fmt.Sprintf("    Description:         %s", item.Description),
+			)
+		}
+		if len(item.AuthorizedActions) > 0 {
+			output = append(output,
+				"    Authorized Actions:",
+				base.WrapSlice(6, item.AuthorizedActions),
+			)
+		}
+	}
+
+	return base.WrapForHelpText(output)
+}
+
+func printItemTable(item *targettemplates.TargetTemplate, resp *api.Response) string {
+	nonAttributeMap := map[string]any{}
+	if item.Id != "" {
+		nonAttributeMap["ID"] = item.Id
+	}
+	if item.Version != 0 {
+		nonAttributeMap["Version"] = item.Version
+	}
+	if !item.CreatedTime.IsZero() {
+		nonAttributeMap["Created Time"] = item.CreatedTime.Local().Format(time.RFC1123)
+	}
+	if !item.UpdatedTime.IsZero() {
+		nonAttributeMap["Updated Time"] = item.UpdatedTime.Local().Format(time.RFC1123)
+	}
+	if item.Name != "" {
+		nonAttributeMap["Name"] = item.Name
+	}
+	if item.Description != "" {
+		nonAttributeMap["Description"] = item.Description
+	}
+	if item.SessionMaxSeconds != 0 {
+		nonAttributeMap["Session Max Seconds"] = item.SessionMaxSeconds
+	}
+	if item.SessionConnectionLimit != 0 {
+		nonAttributeMap["Session Connection Limit"] = item.SessionConnectionLimit
+	}
+	if item.EgressWorkerFilter != "" {
+		nonAttributeMap["Egress Worker Filter"] = item.EgressWorkerFilter
+	}
+	if item.IngressWorkerFilter != "" {
+		nonAttributeMap["Ingress Worker Filter"] = item.IngressWorkerFilter
+	}
+
+	maxLength := base.MaxAttributesLength(nonAttributeMap, nil, nil)
+
+	ret := []string{
+		"",
+		"Target Template information:",
+		base.WrapMap(2, maxLength+2, nonAttributeMap),
+	}
+
+	if item.Scope != nil {
+		ret = append(ret,
+			"",
+			"  Scope:",
+			base.ScopeInfoForOutput(item.Scope, maxLength),
+		)
+	}
+
+	if len(item.AuthorizedActions) > 0 {
+		ret = append(ret,
+			"",
+			"  Authorized Actions:",
+			base.WrapSlice(4, item.AuthorizedActions),
+		)
+	}
+
+	if len(item.HostSourceIds) > 0 {
+		ret = append(ret,
+			"",
+			"  Host Source IDs:",
+			base.WrapSlice(4, item.HostSourceIds),
+		)
+	}
+
+	if len(item.BrokeredCredentialSourceIds) > 0 {
+		ret = append(ret,
+			"",
+			"  Brokered Credential Source IDs:",
+			base.WrapSlice(4, item.BrokeredCredentialSourceIds),
+		)
+	}
+
+	if len(item.InjectedApplicationCredentialSourceIds) > 0 {
+		ret = append(ret,
+			"",
+			"  Injected Application Credential Source IDs:",
+			base.WrapSlice(4, item.InjectedApplicationCredentialSourceIds),
+		)
+	}
+
+	return base.WrapForHelpText(ret)
+}
+
+func printCustomActionOutputImpl(c *Command) (bool, error) {
+	switch c.Func {
+	case "list-out-of-sync-targets":
+		switch base.Format(c.UI) {
+		case "table":
+			c.UI.Output(printOutOfSyncTable(c.outOfSyncResult.GetItems()))
+			return true, nil
+
+		case "json":
+			if ok := c.PrintJsonItems(c.outOfSyncResult.GetResponse()); !ok {
+				return false, fmt.Errorf("error formatting as JSON")
+			}
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func printOutOfSyncTable(items []*targettemplates.OutOfSyncTarget) string {
+	if len(items) == 0 {
+		return "No out of sync targets found"
+	}
+
+	output := []string{
+		"",
+		"Out of sync target information:",
+	}
+	for i, item := range items {
+		if i > 0 {
+			output = append(output, "")
+		}
+		output = append(output,
+			fmt.Sprintf("  Target ID:             %s", item.TargetId),
+			fmt.Sprintf("    Fields:              %s", strings.Join(item.Fields, ", ")),
+		)
+	}
+
+	return base.WrapForHelpText(output)
+}
@@ -20,17 +20,20 @@ func init() {
 	extraFlagsFunc = extraFlagsFuncImpl
 	extraFlagsHandlingFunc = extraFlagsHandlingFuncImpl
 	executeExtraActions = executeExtraActionsImpl
+	printCustomActionOutput = printCustomActionOutputImpl
 }
 
 type extraCmdVars struct {
-	flagAccounts []string
+	flagAccounts       []string
+	sessionQuotaResult *users.SessionQuotaReadResult
 }
 
 func extraActionsFlagsMapFuncImpl() map[string][]string {
 	return map[string][]string{
-		"add-accounts":    {"id", "account", "version"},
-		"set-accounts":    {"id", "account", "version"},
-		"remove-accounts": {"id", "account", "version"},
+		"add-accounts":       {"id", "account", "version"},
+		"set-accounts":       {"id", "account", "version"},
+		"remove-accounts":    {"id", "account", "version"},
+		"read-session-quota": {"id", "scope-id"},
 	}
 }
 
@@ -47,6 +50,8 @@ func extraSynopsisFuncImpl(c *Command) string {
 			in = "Remove accounts from"
 		}
 		return wordwrap.WrapString(fmt.Sprintf("%s a user within Boundary", in), base.TermWidth)
+	case "read-session-quota":
+		return "Read the session quota of a user in a project"
 	}
 
 	return ""
@@ -88,6 +93,17 @@ func (c *Command) extraHelpFunc(helpMap map[string]func() string) string {
 			"",
 		})
 
+	case "read-session-quota":
+		helpStr = base.WrapForHelpText([]string{
+			"Usage: boundary users read-session-quota [options] [args]",
+			"",
+			"  Read the session quota of a user in a project, along with the number of sessions counted against it. Example:",
+			"",
+			`    $ boundary users read-session-quota -id u_1234567890 -scope-id p_1234567890`,
+			"",
+			"",
+		})
+
 	default:
 		helpStr = helpMap["base"]()
 	}
@@ -125,6 +141,12 @@ func extraFlagsHandlingFuncImpl(c *Command, _ *base.FlagSets, opts *[]users.Opti
 				c.flagAccounts = nil
 			}
 		}
+
+	case "read-session-quota":
+		if c.FlagScopeId == "" {
+			c.UI.Error("Scope ID must be passed in via -scope-id or BOUNDARY_SCOPE_ID")
+			return false
+		}
 	}
 
 	return true
@@ -150,6 +172,13 @@ func executeExtraActionsImpl(c *Command, origResp *api.Response, origItem *users
 			return nil, nil, nil, err
 		}
 		return result.GetResponse(), result.GetItem(), nil, err
+	case "read-session-quota":
+		var err error
+		c.sessionQuotaResult, err = userClient.ReadSessionQuota(c.Context, c.FlagId, c.FlagScopeId, opts...)
+		if err != nil {
+			return nil, nil, nil, err
+		}
+		return c.sessionQuotaResult.GetResponse(), nil, nil, nil
 	}
 	return origResp, origItem, origItems, origError
 }
@@ -316,3 +345,46 @@ func printItemTable(item *users.User, resp *api.Response) string {
 
 	return base.WrapForHelpText(ret)
 }
+
+func printCustomActionOutputImpl(c *Command) (bool, error) {
+	switch c.Func {
+	case "read-session-quota":
+		switch base.Format(c.UI) {
+		case "table":
+			c.UI.Output(printSessionQuotaTable(c.sessionQuotaResult.GetItem()))
+			return true, nil
+
+		case "json":
+			if ok := c.PrintJsonItem(c.sessionQuotaResult.GetResponse()); !ok {
+				return false, fmt.Errorf("error formatting as JSON")
+			}
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func printSessionQuotaTable(item *users.SessionQuota) string {
+	unlimited := func(n uint32) any {
+		if n == 0 {
+			return "unlimited"
+		}
+		return n
+	}
+	nonAttributeMap := map[string]any{
+		"User ID":             item.UserId,
+		"Scope ID":            item.ScopeId,
+		"Max Active Sessions": unlimited(item.MaxActiveSessions),
+		"Active Sessions":     item.ActiveSessions,
+		"Max Daily Sessions":  unlimited(item.MaxDailySessions),
+		"Daily Sessions":      item.DailySessions,
+	}
+
+	maxLength := base.MaxAttributesLength(nonAttributeMap, nil, nil)
+
+	return base.WrapForHelpText([]string{
+		"",
+		"Session quota information:",
+		base.WrapMap(2, maxLength+2, nonAttributeMap),
+	})
+}
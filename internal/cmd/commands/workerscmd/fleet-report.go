@@ -0,0 +1,237 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package workerscmd
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/boundary/api"
+	"github.com/hashicorp/boundary/api/workers"
+	"github.com/hashicorp/boundary/internal/cmd/base"
+	"github.com/hashicorp/boundary/internal/cmd/common"
+	"github.com/mitchellh/cli"
+	"github.com/mitchellh/go-wordwrap"
+	"github.com/posener/complete"
+)
+
+var (
+	_ cli.Command             = (*WorkerFleetReportCommand)(nil)
+	_ cli.CommandAutocomplete = (*WorkerFleetReportCommand)(nil)
+)
+
+type WorkerFleetReportCommand struct {
+	*base.Command
+}
+
+func (c *WorkerFleetReportCommand) Synopsis() string {
+	return wordwrap.WrapString("Report the versions, status and compatibility of Boundary workers", base.TermWidth)
+}
+
+var flagsFleetReport = map[string][]string{
+	"": {"scope-id"},
+}
+
+func (c *WorkerFleetReportCommand) Help() string {
+	return base.WrapForHelpText([]string{
+		"Usage: boundary workers fleet-report [options]",
+		"",
+		"  Report the workers by version and configuration tag, along with their last status, active sessions and downstream workers. Workers whose version is too old for features targets may use are listed per feature; sessions for targets using those features are not proxied through them. Example:",
+		"",
+		`    $ boundary workers fleet-report`,
+		"",
+		"",
+	}) + c.Flags().Help()
+}
+
+func (c *WorkerFleetReportCommand) Flags() *base.FlagSets {
+	set := c.FlagSet(base.FlagSetHTTP | base.FlagSetClient | base.FlagSetOutputFormat)
+	f := set.NewFlagSet("Command Options")
+	common.PopulateCommonFlags(c.Command, f, "fleet report", flagsFleetReport, "")
+
+	return set
+}
+
+func (c *WorkerFleetReportCommand) AutocompleteArgs() complete.Predictor {
+	return complete.PredictNothing
+}
+
+func (c *WorkerFleetReportCommand) AutocompleteFlags() complete.Flags {
+	return c.Flags().Completions()
+}
+
+func (c *WorkerFleetReportCommand) Run(args []string) int {
+	f := c.Flags()
+
+	if err := f.Parse(args); err != nil {
+		c.PrintCliError(err)
+		return base.CommandUserError
+	}
+	if c.FlagScopeId == "" {
+		c.PrintCliError(errors.New("Scope ID must be passed in via -scope-id or BOUNDARY_SCOPE_ID"))
+		return base.CommandUserError
+	}
+
+	client, err := c.Client()
+	if c.WrapperCleanupFunc != nil {
+		defer func() {
+			if err := c.WrapperCleanupFunc(); err != nil {
+				c.PrintCliError(fmt.Errorf("Error cleaning kms wrapper: %w", err))
+			}
+		}()
+	}
+	if err != nil {
+		c.PrintCliError(fmt.Errorf("Error creating API client: %w", err))
+		return base.CommandCliError
+	}
+
+	result, err := workers.NewClient(client).ReadFleetReport(c.Context, c.FlagScopeId)
+	if err != nil {
+		if apiErr := api.AsServerError(err); apiErr != nil {
+			c.PrintApiError(apiErr, "Error from controller when reading the fleet report")
+			return base.CommandApiError
+		}
+		c.PrintCliError(fmt.Errorf("Error trying to read the fleet report: %w", err))
+		return base.CommandCliError
+	}
+
+	switch base.Format(c.UI) {
+	case "table":
+		c.UI.Output(printFleetReportTable(result.GetItem()))
+
+	case "json":
+		if ok := c.PrintJsonItem(result.GetResponse()); !ok {
+			return base.CommandCliError
+		}
+	}
+
+	return base.CommandSuccess
+}
+
+func printFleetReportTable(item *workers.FleetReport) string {
+	if item == nil {
+		return "No fleet report found"
+	}
+
+	output := []string{
+		"",
+		"Worker fleet report:",
+		fmt.Sprintf("  Controller Version:        %s", item.ControllerVersion),
+		fmt.Sprintf("  Worker Count:              %d", item.WorkerCount),
+	}
+
+	if len(item.Versions) > 0 {
+		output = append(output,
+			"",
+			"  Versions:",
+		)
+		for _, v := range item.Versions {
+			ver := v.ReleaseVersion
+			if ver == "" {
+				ver = "(unknown)"
+			}
+			output = append(output,
+				fmt.Sprintf("    %s: %d", ver, v.WorkerCount),
+			)
+		}
+	}
+
+	if len(item.ConfigTags) > 0 {
+		output = append(output,
+			"",
+			"  Configuration Tags:",
+		)
+		for _, t := range item.ConfigTags {
+			output = append(output,
+				fmt.Sprintf("    %s=%s: %d", t.Key, t.Value, t.WorkerCount),
+			)
+		}
+	}
+
+	if len(item.Features) > 0 {
+		output = append(output,
+			"",
+			"  Target Features:",
+		)
+		for _, f := range item.Features {
+			output = append(output,
+				fmt.Sprintf("    %s:", f.Name),
+				fmt.Sprintf("      Version Constraint:    %s", f.VersionConstraint),
+			)
+			if len(f.UnsupportedWorkerIds) > 0 {
+				output = append(output,
+					fmt.Sprintf("      Unsupported Workers:   %s", strings.Join(f.UnsupportedWorkerIds, ", ")),
+				)
+			}
+		}
+	}
+
+	if len(item.Workers) > 0 {
+		output = append(output,
+			"",
+			"  Workers:",
+		)
+	}
+	for i, w := range item.Workers {
+		if i > 0 {
+			output = append(output, "")
+		}
+		output = append(output,
+			fmt.Sprintf("    ID:                      %s", w.Id),
+		)
+		if w.Name != "" {
+			output = append(output,
+				fmt.Sprintf("      Name:                  %s", w.Name),
+			)
+		}
+		if w.ReleaseVersion != "" {
+			output = append(output,
+				fmt.Sprintf("      Release Version:       %s", w.ReleaseVersion),
+			)
+		}
+		if w.OperationalState != "" {
+			output = append(output,
+				fmt.Sprintf("      Operational State:     %s", w.OperationalState),
+			)
+		}
+		if !w.LastStatusTime.IsZero() {
+			output = append(output,
+				fmt.Sprintf("      Last Status Age:       %s", time.Duration(w.StatusAgeSeconds)*time.Second),
+			)
+		}
+		output = append(output,
+			fmt.Sprintf("      Active Connections:    %d", w.ActiveConnectionCount),
+			fmt.Sprintf("      Active Sessions:       %d", w.ActiveSessionCount),
+		)
+		if len(w.ConfigTags) > 0 {
+			keys := make([]string, 0, len(w.ConfigTags))
+			for k := range w.ConfigTags {
+				keys = append(keys, k)
+			}
+			sort.Strings(keys)
+			var tags []string
+			for _, k := range keys {
+				tags = append(tags, fmt.Sprintf("%s=%s", k, strings.Join(w.ConfigTags[k], ",")))
+			}
+			output = append(output,
+				fmt.Sprintf("      Configuration Tags:    %s", strings.Join(tags, " ")),
+			)
+		}
+		if len(w.DownstreamWorkerIds) > 0 {
+			output = append(output,
+				fmt.Sprintf("      Downstream Workers:    %s", strings.Join(w.DownstreamWorkerIds, ", ")),
+			)
+		}
+		if len(w.UnsupportedFeatures) > 0 {
+			output = append(output,
+				fmt.Sprintf("      Unsupported Features:  %s", strings.Join(w.UnsupportedFeatures, ", ")),
+			)
+		}
+	}
+
+	return base.WrapForHelpText(output)
+}
@@ -4,7 +4,9 @@
 package workerscmd
 
 import (
+	"flag"
 	"fmt"
+	"strconv"
 	"strings"
 	"time"
 
@@ -27,9 +29,15 @@ func extraActionsFlagsMapFuncImpl() map[string][]string {
 		"add-worker-tags":    {"id", "tag", "version"},
 		"set-worker-tags":    {"id", "tag", "version"},
 		"remove-worker-tags": {"id", "tag", "version"},
+		"update":             {"drain", "drain-timeout"},
 	}
 }
 
+type extraCmdVars struct {
+	flagDrain        bool
+	flagDrainTimeout string
+}
+
 func extraSynopsisFuncImpl(c *Command) string {
 	switch c.Func {
 	case "add-worker-tags":
@@ -58,6 +66,17 @@ func (c *Command) extraHelpFunc(helpMap map[string]func() string) string {
 			"",
 			"  Please see the workers subcommand help for detailed usage information.",
 		})
+	case "update":
+		helpStr = helpMap[c.Func]() + base.WrapForHelpText([]string{
+			"",
+			"  To stop a worker from being given new sessions while letting its existing sessions finish:",
+			"",
+			`    $ boundary workers update -id w_1234567890 -drain -drain-timeout 1h`,
+			"",
+			"  Sessions still proxied through the worker after the drain timeout are canceled. Use -drain=false to return the worker to service.",
+			"",
+			"",
+		})
 	case "add-worker-tags":
 		helpStr = base.WrapForHelpText([]string{
 			"Usage: boundary workers add-worker-tags [options] [args]",
@@ -116,12 +135,57 @@ func extraFlagsFuncImpl(c *Command, _ *base.FlagSets, f *base.FlagSet) {
 				NullCheck: nullCheckFn,
 				Usage:     "The api tag resources to add, remove, or set.",
 			})
+		case "drain":
+			f.BoolVar(&base.BoolVar{
+				Name:   "drain",
+				Target: &c.flagDrain,
+				Usage:  "If set, the worker is not given new sessions but continues to proxy its existing ones. Use -drain=false to stop draining.",
+			})
+		case "drain-timeout":
+			f.StringVar(&base.StringVar{
+				Name:   "drain-timeout",
+				Target: &c.flagDrainTimeout,
+				Usage:  `The time after which sessions still proxied through the draining worker are canceled. Can be specified as an integer number of seconds or a duration string. Use "null" to let sessions finish on their own.`,
+			})
 		}
 	}
 }
 
-func extraFlagsHandlingFuncImpl(c *Command, _ *base.FlagSets, opts *[]workers.Option) bool {
+func extraFlagsHandlingFuncImpl(c *Command, f *base.FlagSets, opts *[]workers.Option) bool {
 	switch c.Func {
+	case "update":
+		var drainSet bool
+		f.Visit(func(fl *flag.Flag) {
+			if fl.Name == "drain" {
+				drainSet = true
+			}
+		})
+		if drainSet {
+			*opts = append(*opts, workers.WithDrain(c.flagDrain))
+		}
+		switch c.flagDrainTimeout {
+		case "":
+		case "null":
+			*opts = append(*opts, workers.DefaultDrainTimeoutSeconds())
+		default:
+			if drainSet && !c.flagDrain {
+				c.UI.Error("-drain-timeout cannot be used with -drain=false")
+				return false
+			}
+			var final uint32
+			dur, err := strconv.ParseUint(c.flagDrainTimeout, 10, 32)
+			if err == nil {
+				final = uint32(dur)
+			} else {
+				dur, err := time.ParseDuration(c.flagDrainTimeout)
+				if err != nil {
+					c.UI.Error(fmt.Sprintf("Error parsing %q: %s", c.flagDrainTimeout, err))
+					return false
+				}
+				final = uint32(dur.Seconds())
+			}
+			*opts = append(*opts, workers.WithDrainTimeoutSeconds(final))
+		}
 	case "add-worker-tags", "remove-worker-tags":
 		if len(c.FlagTags) == 0 {
 			c.UI.Error("No tags supplied via -tag")
@@ -223,6 +287,11 @@ func (c *Command) printListTable(items []*workers.Worker) string {
 				fmt.Sprintf("    Last Status Time:        %s", item.LastStatusTime.Format(time.RFC1123)),
 			)
 		}
+		if item.OperationalState != "" {
+			output = append(output,
+				fmt.Sprintf("    Operational State:       %s", item.OperationalState),
+			)
+		}
 		if len(item.DirectlyConnectedDownstreamWorkers) > 0 {
 			output = append(output,
 				"    Directly Connected Downstream Workers:",
@@ -278,6 +347,15 @@ func printItemTable(item *workers.Worker, resp *api.Response) string {
 	if item.LocalStorageState != "" {
 		nonAttributeMap["Local Storage State"] = item.LocalStorageState
 	}
+	if item.OperationalState != "" {
+		nonAttributeMap["Operational State"] = item.OperationalState
+	}
+	if item.Drain {
+		nonAttributeMap["Drain"] = item.Drain
+	}
+	if !item.DrainDeadline.IsZero() {
+		nonAttributeMap["Drain Deadline"] = item.DrainDeadline.Local().Format(time.RFC1123)
+	}
 
 	resultMap := resp.Map
 	if count, ok := resultMap[globals.ActiveConnectionCountField]; ok {
@@ -0,0 +1,282 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package workerscmd
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/boundary/api"
+	"github.com/hashicorp/boundary/api/workers"
+	"github.com/hashicorp/boundary/internal/cmd/base"
+	"github.com/hashicorp/boundary/internal/cmd/common"
+	"github.com/mitchellh/cli"
+	"github.com/mitchellh/go-wordwrap"
+	"github.com/posener/complete"
+)
+
+var (
+	_ cli.Command             = (*WorkerTopologyCommand)(nil)
+	_ cli.CommandAutocomplete = (*WorkerTopologyCommand)(nil)
+)
+
+const (
+	topologyGraphTree = "tree"
+	topologyGraphDot  = "dot"
+)
+
+type WorkerTopologyCommand struct {
+	*base.Command
+
+	flagGraph string
+}
+
+func (c *WorkerTopologyCommand) Synopsis() string {
+	return wordwrap.WrapString("Show the graph of connections between Boundary workers", base.TermWidth)
+}
+
+var flagsTopology = map[string][]string{
+	"": {"scope-id"},
+}
+
+func (c *WorkerTopologyCommand) Help() string {
+	return base.WrapForHelpText([]string{
+		"Usage: boundary workers topology [options]",
+		"",
+		"  Show the workers which have recently reported their status as a tree rooted at the workers connected directly to a controller, with the downstream workers connected to each worker below it. Example:",
+		"",
+		`    $ boundary workers topology`,
+		"",
+		"  Render the topology in the DOT language, for use with Graphviz:",
+		"",
+		`    $ boundary workers topology -graph dot | dot -Tsvg > topology.svg`,
+		"",
+		"",
+	}) + c.Flags().Help()
+}
+
+func (c *WorkerTopologyCommand) Flags() *base.FlagSets {
+	set := c.FlagSet(base.FlagSetHTTP | base.FlagSetClient | base.FlagSetOutputFormat)
+	f := set.NewFlagSet("Command Options")
+	common.PopulateCommonFlags(c.Command, f, "topology", flagsTopology, "")
+
+	f.StringVar(&base.StringVar{
+		Name:       "graph",
+		Target:     &c.flagGraph,
+		Default:    topologyGraphTree,
+		Completion: complete.PredictSet(topologyGraphTree, topologyGraphDot),
+		Usage:      `How to render the topology when the output format is "table". Valid values are "tree" or "dot".`,
+	})
+
+	return set
+}
+
+func (c *WorkerTopologyCommand) AutocompleteArgs() complete.Predictor {
+	return complete.PredictNothing
+}
+
+func (c *WorkerTopologyCommand) AutocompleteFlags() complete.Flags {
+	return c.Flags().Completions()
+}
+
+func (c *WorkerTopologyCommand) Run(args []string) int {
+	f := c.Flags()
+
+	if err := f.Parse(args); err != nil {
+		c.PrintCliError(err)
+		return base.CommandUserError
+	}
+	if c.FlagScopeId == "" {
+		c.PrintCliError(errors.New("Scope ID must be passed in via -scope-id or BOUNDARY_SCOPE_ID"))
+		return base.CommandUserError
+	}
+	switch c.flagGraph {
+	case topologyGraphTree, topologyGraphDot:
+	default:
+		c.PrintCliError(fmt.Errorf("Unknown graph rendering %q, must be %q or %q", c.flagGraph, topologyGraphTree, topologyGraphDot))
+		return base.CommandUserError
+	}
+
+	client, err := c.Client()
+	if c.WrapperCleanupFunc != nil {
+		defer func() {
+			if err := c.WrapperCleanupFunc(); err != nil {
+				c.PrintCliError(fmt.Errorf("Error cleaning kms wrapper: %w", err))
+			}
+		}()
+	}
+	if err != nil {
+		c.PrintCliError(fmt.Errorf("Error creating API client: %w", err))
+		return base.CommandCliError
+	}
+
+	result, err := workers.NewClient(client).ReadTopology(c.Context, c.FlagScopeId)
+	if err != nil {
+		if apiErr := api.AsServerError(err); apiErr != nil {
+			c.PrintApiError(apiErr, "Error from controller when reading the worker topology")
+			return base.CommandApiError
+		}
+		c.PrintCliError(fmt.Errorf("Error trying to read the worker topology: %w", err))
+		return base.CommandCliError
+	}
+
+	switch base.Format(c.UI) {
+	case "table":
+		switch c.flagGraph {
+		case topologyGraphDot:
+			c.UI.Output(renderTopologyDot(result.GetItem()))
+		default:
+			c.UI.Output(renderTopologyTree(result.GetItem(), time.Now()))
+		}
+
+	case "json":
+		if ok := c.PrintJsonItem(result.GetResponse()); !ok {
+			return base.CommandCliError
+		}
+	}
+
+	return base.CommandSuccess
+}
+
+// renderTopologyTree renders the topology as a tree rooted at the controllers.
+// Workers whose upstream has not been seen recently are listed separately, and
+// a worker reachable through several upstreams is listed under each of them.
+func renderTopologyTree(item *workers.Topology, now time.Time) string {
+	if item == nil || len(item.Nodes) == 0 {
+		return "No workers found"
+	}
+
+	nodes := make(map[string]*workers.TopologyNode, len(item.Nodes))
+	for _, n := range item.Nodes {
+		nodes[n.Id] = n
+	}
+	children := make(map[string][]*workers.TopologyEdge)
+	for _, e := range item.Edges {
+		children[e.UpstreamWorkerId] = append(children[e.UpstreamWorkerId], e)
+	}
+
+	var b strings.Builder
+	reached := make(map[string]bool, len(item.Nodes))
+	var walk func(id string, edge *workers.TopologyEdge, prefix string, last bool, path map[string]bool)
+	walk = func(id string, edge *workers.TopologyEdge, prefix string, last bool, path map[string]bool) {
+		branch, next := "├── ", "│   "
+		if last {
+			branch, next = "└── ", "    "
+		}
+		b.WriteString(prefix + branch + describeTopologyNode(id, nodes[id], edge, now))
+		if path[id] {
+			b.WriteString(" (cycle)\n")
+			return
+		}
+		b.WriteString("\n")
+		reached[id] = true
+		path[id] = true
+		defer delete(path, id)
+		for i, e := range children[id] {
+			walk(e.DownstreamWorkerId, e, prefix+next, i == len(children[id])-1, path)
+		}
+	}
+
+	var roots []string
+	for _, n := range item.Nodes {
+		if n.ControllerConnected {
+			roots = append(roots, n.Id)
+		}
+	}
+	b.WriteString("Controllers\n")
+	for i, id := range roots {
+		walk(id, nil, "", i == len(roots)-1, map[string]bool{})
+	}
+
+	// Workers not reached from a controller either have an upstream which
+	// has not been seen recently or are part of a cycle of workers.
+	knownUpstream := make(map[string]bool)
+	for _, e := range item.Edges {
+		if nodes[e.UpstreamWorkerId] != nil {
+			knownUpstream[e.DownstreamWorkerId] = true
+		}
+	}
+	var orphans []string
+	for _, n := range item.Nodes {
+		if !reached[n.Id] && !knownUpstream[n.Id] {
+			orphans = append(orphans, n.Id)
+		}
+	}
+	if len(orphans) > 0 {
+		b.WriteString("\nWorkers without a recently seen upstream\n")
+		for i, id := range orphans {
+			walk(id, nil, "", i == len(orphans)-1, map[string]bool{})
+		}
+	}
+	for _, n := range item.Nodes {
+		if !reached[n.Id] {
+			b.WriteString("\nWorkers connected in a cycle\n")
+			walk(n.Id, nil, "", true, map[string]bool{})
+		}
+	}
+	return strings.TrimSuffix(b.String(), "\n")
+}
+
+func describeTopologyNode(id string, n *workers.TopologyNode, edge *workers.TopologyEdge, now time.Time) string {
+	parts := []string{id}
+	if n == nil {
+		return id + " [not recently seen]"
+	}
+	if n.Name != "" {
+		parts = append(parts, fmt.Sprintf("(%s)", n.Name))
+	}
+	var details []string
+	if n.ReleaseVersion != "" {
+		details = append(details, n.ReleaseVersion)
+	}
+	if n.OperationalState != "" && n.OperationalState != "active" {
+		details = append(details, n.OperationalState)
+	}
+	if n.StatusRttMs > 0 {
+		details = append(details, fmt.Sprintf("rtt %dms", n.StatusRttMs))
+	}
+	if !n.LastStatusTime.IsZero() {
+		details = append(details, fmt.Sprintf("status %s ago", now.Sub(n.LastStatusTime).Round(time.Second)))
+	}
+	if edge != nil && !edge.LastSeenTime.IsZero() {
+		details = append(details, fmt.Sprintf("link %s ago", now.Sub(edge.LastSeenTime).Round(time.Second)))
+	}
+	if len(details) > 0 {
+		parts = append(parts, "["+strings.Join(details, ", ")+"]")
+	}
+	return strings.Join(parts, " ")
+}
+
+// renderTopologyDot renders the topology as a directed graph in the DOT
+// language, with edges pointing from upstream to downstream workers.
+func renderTopologyDot(item *workers.Topology) string {
+	var b strings.Builder
+	b.WriteString("digraph topology {\n")
+	b.WriteString("  controllers [shape=box];\n")
+	if item != nil {
+		for _, n := range item.Nodes {
+			label := n.Id
+			if n.Name != "" {
+				label += "\n" + n.Name
+			}
+			if n.ReleaseVersion != "" {
+				label += "\n" + n.ReleaseVersion
+			}
+			if n.StatusRttMs > 0 {
+				label += fmt.Sprintf("\nrtt %dms", n.StatusRttMs)
+			}
+			fmt.Fprintf(&b, "  %q [label=%q];\n", n.Id, label)
+			if n.ControllerConnected {
+				fmt.Fprintf(&b, "  controllers -> %q;\n", n.Id)
+			}
+		}
+		for _, e := range item.Edges {
+			fmt.Fprintf(&b, "  %q -> %q;\n", e.UpstreamWorkerId, e.DownstreamWorkerId)
+		}
+	}
+	b.WriteString("}")
+	return b.String()
+}
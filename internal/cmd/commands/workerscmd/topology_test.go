@@ -0,0 +1,60 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package workerscmd
+
+import (
+	"testing"
+	"time"
+
+	"github.com/hashicorp/boundary/api/workers"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRenderTopology(t *testing.T) {
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	topology := &workers.Topology{
+		Nodes: []*workers.TopologyNode{
+			{Id: "w_egress", Name: "egress", ReleaseVersion: "Boundary v0.16.0", LastStatusTime: now.Add(-2 * time.Second), StatusRttMs: 40},
+			{Id: "w_ingress", Name: "ingress", ReleaseVersion: "Boundary v0.16.0", LastStatusTime: now.Add(-time.Second), StatusRttMs: 5, ControllerConnected: true},
+			{Id: "w_orphan", OperationalState: "draining"},
+		},
+		Edges: []*workers.TopologyEdge{
+			{UpstreamWorkerId: "w_ingress", DownstreamWorkerId: "w_egress", LastSeenTime: now.Add(-time.Second)},
+			{UpstreamWorkerId: "w_gone", DownstreamWorkerId: "w_orphan"},
+		},
+	}
+
+	assert.Equal(t, `Controllers
+└── w_ingress (ingress) [Boundary v0.16.0, rtt 5ms, status 1s ago]
+    └── w_egress (egress) [Boundary v0.16.0, rtt 40ms, status 2s ago, link 1s ago]
+
+Workers without a recently seen upstream
+└── w_orphan [draining]`, renderTopologyTree(topology, now))
+
+	assert.Equal(t, `digraph topology {
+  controllers [shape=box];
+  "w_egress" [label="w_egress\negress\nBoundary v0.16.0\nrtt 40ms"];
+  "w_ingress" [label="w_ingress\ningress\nBoundary v0.16.0\nrtt 5ms"];
+  controllers -> "w_ingress";
+  "w_orphan" [label="w_orphan"];
+  "w_ingress" -> "w_egress";
+  "w_gone" -> "w_orphan";
+}`, renderTopologyDot(topology))
+
+	assert.Equal(t, "No workers found", renderTopologyTree(&workers.Topology{}, now))
+
+	cycle := &workers.Topology{
+		Nodes: []*workers.TopologyNode{{Id: "w_a"}, {Id: "w_b"}},
+		Edges: []*workers.TopologyEdge{
+			{UpstreamWorkerId: "w_a", DownstreamWorkerId: "w_b"},
+			{UpstreamWorkerId: "w_b", DownstreamWorkerId: "w_a"},
+		},
+	}
+	assert.Equal(t, `Controllers
+
+Workers connected in a cycle
+└── w_a
+    └── w_b
+        └── w_a (cycle)`, renderTopologyTree(cycle, now))
+}
@@ -23,6 +23,7 @@ import (
 	"github.com/hashicorp/boundary/internal/db"
 	"github.com/hashicorp/boundary/internal/event"
 	"github.com/hashicorp/boundary/internal/ratelimit"
+	"github.com/hashicorp/boundary/internal/server/attestation"
 	"github.com/hashicorp/boundary/internal/storage"
 	"github.com/hashicorp/boundary/internal/util"
 	kms_plugin_assets "github.com/hashicorp/boundary/plugins/kms"
@@ -220,6 +221,10 @@ type Controller struct {
 	ApiRateLimiterMaxQuotas int               `hcl:"api_rate_limit_max_quotas"`
 	ApiRateLimitDisable     bool              `hcl:"api_rate_limit_disable"`
 
+	// WorkerAttestations are the trust policies used to validate the identity
+	// documents presented by workers registering themselves.
+	WorkerAttestations []*attestation.Config `hcl:"-"`
+
 	// License is the license used by HCP builds
 	License string `hcl:"license"`
 
@@ -313,6 +318,77 @@ type Worker struct {
 	// pre-0.13 method of using KMSes to authenticate. This is currently only
 	// supported to throw an error if used telling people they need to upgrade.
 	UseDeprecatedKmsAuthMethod bool `hcl:"use_deprecated_kms_auth_method"`
+
+	// DrainTimeout is the time (as a duration) after a worker is drained with
+	// the SIGUSR1 signal after which the sessions it is still proxying are
+	// canceled. If not set, the sessions are left to finish on their own.
+	DrainTimeout         any           `hcl:"drain_timeout"`
+	DrainTimeoutDuration time.Duration `hcl:"-"`
+
+	// MaxConnections is the maximum number of connections the worker proxies
+	// concurrently. Connections beyond the maximum are rejected. Zero means
+	// there is no limit.
+	MaxConnections int `hcl:"max_connections"`
+
+	// DataPlaneIdleConnections is the number of idle data plane connections
+	// the worker keeps open to each upstream worker, over which the upstream
+	// routes multi-hop sessions through it. Zero means the default number is
+	// used.
+	DataPlaneIdleConnections int `hcl:"data_plane_idle_connections"`
+
+	// BandwidthLimit holds the throughput caps the worker enforces on the
+	// connections it proxies.
+	BandwidthLimit *BandwidthLimit `hcl:"bandwidth_limit"`
+
+	// Attestation configures the worker to register itself with the
+	// controller by presenting a signed identity document, instead of waiting
+	// for an operator to authorize it.
+	Attestation *WorkerAttestation `hcl:"attestation"`
+}
+
+// WorkerAttestation configures how a worker registers itself using an
+// identity document trusted by the controller.
+type WorkerAttestation struct {
+	// Type is the type of the identity document, e.g. "jwt". It must match
+	// a worker_attestation block of the controller.
+	Type string `hcl:"type"`
+
+	// Address is the address of the controller's API the document is sent
+	// to, e.g. "https://boundary.example.com:9200".
+	Address string `hcl:"address"`
+
+	// Document is the identity document. It can be a path, env var, or
+	// direct value. It is read again on every attempt so that documents
+	// refreshed on disk are picked up.
+	Document string `hcl:"document"`
+}
+
+// BandwidthLimit is the configuration block that specifies the throughput
+// caps, in bytes per second, a worker enforces on proxied traffic. Upload is
+// traffic sent by the client to the target and download is traffic sent by the
+// target to the client. The expected input value for each field is a "capacity
+// string", e.g. "10mb", which is converted into a uint64 value measured in
+// bytes per second. Caps that are not set are not enforced.
+type BandwidthLimit struct {
+	// ConnectionUpload and ConnectionDownload cap each proxied connection.
+	ConnectionUploadRaw   any    `hcl:"connection_upload"`
+	ConnectionUpload      uint64 `hcl:"-"`
+	ConnectionDownloadRaw any    `hcl:"connection_download"`
+	ConnectionDownload    uint64 `hcl:"-"`
+
+	// SessionUpload and SessionDownload cap all connections of a session
+	// combined. They can be overridden by the session's target.
+	SessionUploadRaw   any    `hcl:"session_upload"`
+	SessionUpload      uint64 `hcl:"-"`
+	SessionDownloadRaw any    `hcl:"session_download"`
+	SessionDownload    uint64 `hcl:"-"`
+
+	// WorkerUpload and WorkerDownload cap all connections proxied by the
+	// worker combined.
+	WorkerUploadRaw   any    `hcl:"worker_upload"`
+	WorkerUpload      uint64 `hcl:"-"`
+	WorkerDownloadRaw any    `hcl:"worker_download"`
+	WorkerDownload    uint64 `hcl:"-"`
 }
 
 type Database struct {
@@ -738,6 +814,11 @@ func Parse(d string) (*Config, error) {
 		if result.Controller.ApiRateLimiterMaxQuotas <= 0 {
 			result.Controller.ApiRateLimiterMaxQuotas = ratelimit.DefaultLimiterMaxQuotas()
 		}
+
+		result.Controller.WorkerAttestations, err = parseWorkerAttestations(obj.Node)
+		if err != nil {
+			return nil, err
+		}
 	}
 
 	// Parse worker tags
@@ -810,6 +891,38 @@ func Parse(d string) (*Config, error) {
 			}
 			result.Worker.RecordingStorageMinimumAvailableDiskSpace = recordingStorageMinimumAvailableDiskSpace
 		}
+		if !util.IsNil(result.Worker.DrainTimeout) {
+			t, err := parseutil.ParseDurationSecond(result.Worker.DrainTimeout)
+			if err != nil {
+				return result, err
+			}
+			result.Worker.DrainTimeoutDuration = t
+		}
+		if result.Worker.DrainTimeoutDuration < 0 {
+			return nil, errors.New("Worker drain timeout value is negative")
+		}
+		if result.Worker.MaxConnections < 0 {
+			return nil, errors.New("Worker max connections value is negative")
+		}
+		if result.Worker.DataPlaneIdleConnections < 0 {
+			return nil, errors.New("Worker data plane idle connections value is negative")
+		}
+		if result.Worker.BandwidthLimit != nil {
+			if err := parseBandwidthLimit(result.Worker.BandwidthLimit); err != nil {
+				return nil, err
+			}
+		}
+		if a := result.Worker.Attestation; a != nil {
+			switch {
+			case a.Type == "":
+				return nil, errors.New("Worker attestation type is empty")
+			case a.Address == "":
+				return nil, errors.New("Worker attestation address is empty")
+			case a.Document == "":
+				return nil, errors.New("Worker attestation document is empty")
+			}
+		}
+
 		// RecordingStorageMinimumAvailableDiskSpace defaults to 500MiB when not set by the user
 		if result.Worker.RecordingStoragePath != "" && result.Worker.RecordingStorageMinimumAvailableDiskSpace == 0 {
 			result.Worker.RecordingStorageMinimumAvailableDiskSpace = storage.DefaultMinimumAvailableDiskSpace
@@ -1092,6 +1205,84 @@ func parseWorkerUpstreams(c *Config) ([]string, error) {
 	}
 }
 
+// parseBandwidthLimit parses the capacity strings of the worker bandwidth_limit
+// block into their byte per second values.
+func parseWorkerAttestations(node ast.Node) ([]*attestation.Config, error) {
+	list, ok := node.(*ast.ObjectList)
+	if !ok {
+		return nil, fmt.Errorf("error parsing: file doesn't contain a root object")
+	}
+	controllerList := list.Filter("controller")
+
+	var configs []*attestation.Config
+	for _, item := range controllerList.Items {
+		controller, ok := item.Val.(*ast.ObjectType)
+		if !ok {
+			return nil, fmt.Errorf("error parsing: file doesn't contain controller object")
+		}
+		attestationList := controller.List.Filter("worker_attestation")
+
+		for i, item := range attestationList.Items {
+			var a attestation.Config
+			if err := hcl.DecodeObject(&a, item.Val); err != nil {
+				return nil, fmt.Errorf("error decoding controller worker_attestation entry %d: %w", i, err)
+			}
+			if a.Type == "" {
+				return nil, fmt.Errorf("controller worker_attestation entry %d is missing a type", i)
+			}
+			for j, key := range a.PublicKeys {
+				var err error
+				a.PublicKeys[j], err = parseutil.ParsePath(key)
+				if err != nil && !errors.Is(err, parseutil.ErrNotAUrl) {
+					return nil, fmt.Errorf("error parsing controller worker_attestation public key for entry %d: %w", i, err)
+				}
+			}
+			if a.JwksCaCert != "" {
+				var err error
+				a.JwksCaCert, err = parseutil.ParsePath(a.JwksCaCert)
+				if err != nil && !errors.Is(err, parseutil.ErrNotAUrl) {
+					return nil, fmt.Errorf("error parsing controller worker_attestation jwks ca cert for entry %d: %w", i, err)
+				}
+			}
+			if a.MaxAgeHCL != "" {
+				var err error
+				a.MaxAge, err = parseutil.ParseDurationSecond(a.MaxAgeHCL)
+				if err != nil {
+					return nil, fmt.Errorf("error decoding controller worker_attestation max age for entry %d: %w", i, err)
+				}
+			}
+			configs = append(configs, &a)
+		}
+	}
+
+	return configs, nil
+}
+
+func parseBandwidthLimit(l *BandwidthLimit) error {
+	for _, v := range []struct {
+		name string
+		raw  any
+		to   *uint64
+	}{
+		{"connection_upload", l.ConnectionUploadRaw, &l.ConnectionUpload},
+		{"connection_download", l.ConnectionDownloadRaw, &l.ConnectionDownload},
+		{"session_upload", l.SessionUploadRaw, &l.SessionUpload},
+		{"session_download", l.SessionDownloadRaw, &l.SessionDownload},
+		{"worker_upload", l.WorkerUploadRaw, &l.WorkerUpload},
+		{"worker_download", l.WorkerDownloadRaw, &l.WorkerDownload},
+	} {
+		if util.IsNil(v.raw) {
+			continue
+		}
+		val, err := parseutil.ParseCapacityString(v.raw)
+		if err != nil {
+			return fmt.Errorf("Error parsing worker bandwidth limit %s: %w", v.name, err)
+		}
+		*v.to = val
+	}
+	return nil
+}
+
 func parseEventing(eventObj *ast.ObjectItem) (*event.EventerConfig, error) {
 	// Decode the outside struct
 	var result event.EventerConfig
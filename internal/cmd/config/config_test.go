@@ -13,6 +13,7 @@ import (
 
 	"github.com/hashicorp/boundary/internal/event"
 	"github.com/hashicorp/boundary/internal/ratelimit"
+	"github.com/hashicorp/boundary/internal/server/attestation"
 	configutil "github.com/hashicorp/go-secure-stdlib/configutil/v2"
 	"github.com/hashicorp/go-secure-stdlib/listenerutil"
 	"github.com/hashicorp/go-secure-stdlib/parseutil"
@@ -723,6 +724,145 @@ func TestDevWorkerRecordingStorageMinimumAvailableCapacity(t *testing.T) {
 	}
 }
 
+func TestDevWorkerBandwidthLimit(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name                           string
+		devWorkerProvidedConfiguration string
+		expectedMaxConnections         int
+		expectedLimit                  *BandwidthLimit
+		expectedErrMsg                 string
+	}{
+		{
+			name: "no limits",
+			devWorkerProvidedConfiguration: `
+			worker {
+				name = "w_1234567890"
+				initial_upstreams = ["127.0.0.1"]
+			}
+			`,
+		},
+		{
+			name: "limits",
+			devWorkerProvidedConfiguration: `
+			worker {
+				name = "w_1234567890"
+				initial_upstreams = ["127.0.0.1"]
+				max_connections = 500
+				bandwidth_limit {
+					connection_download = "1mib"
+					session_upload = "2mb"
+					session_download = "4096"
+					worker_download = "1gb"
+				}
+			}
+			`,
+			expectedMaxConnections: 500,
+			expectedLimit: &BandwidthLimit{
+				ConnectionDownloadRaw: "1mib",
+				ConnectionDownload:    1024 * 1024,
+				SessionUploadRaw:      "2mb",
+				SessionUpload:         2 * 1000 * 1000,
+				SessionDownloadRaw:    "4096",
+				SessionDownload:       4096,
+				WorkerDownloadRaw:     "1gb",
+				WorkerDownload:        1000 * 1000 * 1000,
+			},
+		},
+		{
+			name: "invalid limit",
+			devWorkerProvidedConfiguration: `
+			worker {
+				name = "w_1234567890"
+				initial_upstreams = ["127.0.0.1"]
+				bandwidth_limit {
+					worker_upload = "fast"
+				}
+			}
+			`,
+			expectedErrMsg: "Error parsing worker bandwidth limit worker_upload",
+		},
+		{
+			name: "negative max connections",
+			devWorkerProvidedConfiguration: `
+			worker {
+				name = "w_1234567890"
+				initial_upstreams = ["127.0.0.1"]
+				max_connections = -1
+			}
+			`,
+			expectedErrMsg: "Worker max connections value is negative",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			parsed, err := Parse(devConfig + tt.devWorkerProvidedConfiguration)
+			if tt.expectedErrMsg != "" {
+				require.Error(t, err)
+				assert.ErrorContains(t, err, tt.expectedErrMsg)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expectedMaxConnections, parsed.Worker.MaxConnections)
+			assert.Equal(t, tt.expectedLimit, parsed.Worker.BandwidthLimit)
+		})
+	}
+}
+
+func TestDevWorkerDataPlaneIdleConnections(t *testing.T) {
+	t.Parallel()
+	parsed, err := Parse(devConfig + `
+	worker {
+		name = "w_1234567890"
+		initial_upstreams = ["127.0.0.1"]
+		data_plane_idle_connections = 10
+	}
+	`)
+	require.NoError(t, err)
+	assert.Equal(t, 10, parsed.Worker.DataPlaneIdleConnections)
+
+	_, err = Parse(devConfig + `
+	worker {
+		name = "w_1234567890"
+		initial_upstreams = ["127.0.0.1"]
+		data_plane_idle_connections = -1
+	}
+	`)
+	assert.ErrorContains(t, err, "Worker data plane idle connections value is negative")
+}
+
+func TestDevWorkerDrainTimeout(t *testing.T) {
+	t.Parallel()
+	parsed, err := Parse(devConfig + `
+	worker {
+		name = "w_1234567890"
+		initial_upstreams = ["127.0.0.1"]
+		drain_timeout = "1h"
+	}
+	`)
+	require.NoError(t, err)
+	assert.Equal(t, time.Hour, parsed.Worker.DrainTimeoutDuration)
+
+	parsed, err = Parse(devConfig + `
+	worker {
+		name = "w_1234567890"
+		initial_upstreams = ["127.0.0.1"]
+		drain_timeout = 90
+	}
+	`)
+	require.NoError(t, err)
+	assert.Equal(t, 90*time.Second, parsed.Worker.DrainTimeoutDuration)
+
+	_, err = Parse(devConfig + `
+	worker {
+		name = "w_1234567890"
+		initial_upstreams = ["127.0.0.1"]
+		drain_timeout = "-1s"
+	}
+	`)
+	assert.ErrorContains(t, err, "Worker drain timeout value is negative")
+}
+
 func TestDevWorkerRecordingStoragePath(t *testing.T) {
 	t.Parallel()
 	td := t.TempDir()
@@ -1904,6 +2044,146 @@ func TestControllerApiRateLimits(t *testing.T) {
 	}
 }
 
+func TestControllerWorkerAttestations(t *testing.T) {
+	tests := []struct {
+		name      string
+		in        string
+		exp       []*attestation.Config
+		expErrStr string
+	}{
+		{
+			name: "none",
+			in: `
+			controller {
+				name = "c"
+			}`,
+		},
+		{
+			name: "jwt",
+			in: `
+			controller {
+				worker_attestation {
+					type               = "jwt"
+					issuer             = "https://metadata.example.com"
+					audiences          = ["boundary"]
+					jwks_url           = "https://metadata.example.com/jwks"
+					signing_algorithms = ["RS256"]
+					bound_claims = {
+						project = "prod"
+					}
+					name_claim = "instance_name"
+					claim_tags = {
+						region = "zone"
+					}
+					max_age = "10m"
+				}
+			}`,
+			exp: []*attestation.Config{
+				{
+					Type:              "jwt",
+					Issuer:            "https://metadata.example.com",
+					Audiences:         []string{"boundary"},
+					JwksUrl:           "https://metadata.example.com/jwks",
+					SigningAlgorithms: []string{"RS256"},
+					BoundClaims:       map[string]string{"project": "prod"},
+					NameClaim:         "instance_name",
+					ClaimTags:         map[string]string{"region": "zone"},
+					MaxAgeHCL:         "10m",
+					MaxAge:            10 * time.Minute,
+				},
+			},
+		},
+		{
+			name: "missing type",
+			in: `
+			controller {
+				worker_attestation {
+					jwks_url = "https://metadata.example.com/jwks"
+				}
+			}`,
+			expErrStr: "controller worker_attestation entry 0 is missing a type",
+		},
+		{
+			name: "bad max age",
+			in: `
+			controller {
+				worker_attestation {
+					type    = "jwt"
+					max_age = "soon"
+				}
+			}`,
+			expErrStr: "error decoding controller worker_attestation max age for entry 0",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, err := Parse(tt.in)
+			if tt.expErrStr != "" {
+				require.ErrorContains(t, err, tt.expErrStr)
+				return
+			}
+
+			require.NoError(t, err)
+			require.NotNil(t, c.Controller)
+			require.Equal(t, tt.exp, c.Controller.WorkerAttestations)
+		})
+	}
+}
+
+func TestWorkerAttestation(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name           string
+		in             string
+		expected       *WorkerAttestation
+		expectedErrMsg string
+	}{
+		{
+			name: "valid",
+			in: `
+			worker {
+				name = "w_1234567890"
+				attestation {
+					type     = "jwt"
+					address  = "https://boundary.example.com:9200"
+					document = "file:///var/run/identity/token"
+				}
+			}
+			`,
+			expected: &WorkerAttestation{
+				Type:     "jwt",
+				Address:  "https://boundary.example.com:9200",
+				Document: "file:///var/run/identity/token",
+			},
+		},
+		{
+			name: "missing address",
+			in: `
+			worker {
+				name = "w_1234567890"
+				attestation {
+					type     = "jwt"
+					document = "file:///var/run/identity/token"
+				}
+			}
+			`,
+			expectedErrMsg: "Worker attestation address is empty",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			parsed, err := Parse(devConfig + tt.in)
+			if tt.expectedErrMsg != "" {
+				require.Error(t, err)
+				assert.ErrorContains(t, err, tt.expectedErrMsg)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, parsed.Worker.Attestation)
+		})
+	}
+}
+
 func TestWorkerDescription(t *testing.T) {
 	tests := []struct {
 		name           string
@@ -360,12 +360,13 @@ var inputStructs = map[string][]*cmdInfo{
 	},
 	"credentials": {
 		{
-			ResourceType:     resource.Credential.String(),
-			Pkg:              "credentials",
-			StdActions:       []string{"read", "delete", "list"},
-			HasExtraHelpFunc: true,
-			Container:        "CredentialStore",
-			HasId:            true,
+			ResourceType:        resource.Credential.String(),
+			Pkg:                 "credentials",
+			StdActions:          []string{"read", "delete", "list"},
+			HasExtraCommandVars: true,
+			HasExtraHelpFunc:    true,
+			Container:           "CredentialStore",
+			HasId:               true,
 		},
 		{
 			ResourceType:         resource.Credential.String(),
@@ -415,6 +416,22 @@ var inputStructs = map[string][]*cmdInfo{
 			PrefixAttributeFieldErrorsWithSubactionPrefix: true,
 			HasJsonObject: true,
 		},
+		{
+			ResourceType:         resource.Credential.String(),
+			Pkg:                  "credentials",
+			StdActions:           []string{"create", "update"},
+			SubActionPrefix:      "tls_client_certificate",
+			HasExtraCommandVars:  true,
+			SkipNormalHelp:       true,
+			HasExtraHelpFunc:     true,
+			HasId:                true,
+			HasName:              true,
+			HasDescription:       true,
+			Container:            "CredentialStore",
+			VersionedActions:     []string{"update"},
+			NeedsSubtypeInCreate: true,
+			PrefixAttributeFieldErrorsWithSubactionPrefix: true,
+		},
 	},
 	"groups": {
 		{
@@ -725,6 +742,38 @@ var inputStructs = map[string][]*cmdInfo{
 			AliasFieldFlag:             "FlagId",
 			FlagNameOverwrittenByAlias: "id",
 		},
+		{
+			ResourceType:               resource.Target.String(),
+			Pkg:                        "targets",
+			StdActions:                 []string{"create", "update"},
+			SubActionPrefix:            "udp",
+			HasExtraCommandVars:        true,
+			SkipNormalHelp:             true,
+			HasExtraHelpFunc:           true,
+			HasId:                      true,
+			HasName:                    true,
+			Container:                  "Scope",
+			HasDescription:             true,
+			VersionedActions:           []string{"update"},
+			NeedsSubtypeInCreate:       true,
+			UsesAlias:                  true,
+			AliasFieldFlag:             "FlagId",
+			FlagNameOverwrittenByAlias: "id",
+		},
+	},
+	"targettemplates": {
+		{
+			ResourceType:        resource.TargetTemplate.String(),
+			Pkg:                 "targettemplates",
+			StdActions:          []string{"create", "read", "update", "delete", "list"},
+			HasExtraCommandVars: true,
+			HasExtraHelpFunc:    true,
+			HasId:               true,
+			Container:           "Scope",
+			HasName:             true,
+			HasDescription:      true,
+			VersionedActions:    []string{"update"},
+		},
 	},
 	"users": {
 		{
@@ -742,15 +791,16 @@ var inputStructs = map[string][]*cmdInfo{
 	},
 	"workers": {
 		{
-			ResourceType:     resource.Worker.String(),
-			Pkg:              "workers",
-			StdActions:       []string{"read", "update", "delete", "list"},
-			HasExtraHelpFunc: true,
-			HasId:            true,
-			Container:        "Scope",
-			HasName:          true,
-			HasDescription:   true,
-			VersionedActions: []string{"update", "add-worker-tags", "set-worker-tags", "remove-worker-tags"},
+			ResourceType:        resource.Worker.String(),
+			Pkg:                 "workers",
+			StdActions:          []string{"read", "update", "delete", "list"},
+			HasExtraCommandVars: true,
+			HasExtraHelpFunc:    true,
+			HasId:               true,
+			Container:           "Scope",
+			HasName:             true,
+			HasDescription:      true,
+			VersionedActions:    []string{"update", "add-worker-tags", "set-worker-tags", "remove-worker-tags"},
 		},
 		{
 			ResourceType:          resource.Worker.String(),
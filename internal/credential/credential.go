@@ -7,6 +7,7 @@ package credential
 
 import (
 	"context"
+	"time"
 
 	"github.com/hashicorp/boundary/globals"
 	"github.com/hashicorp/boundary/internal/boundary"
@@ -76,6 +77,25 @@ type Dynamic interface {
 	Purpose() Purpose
 }
 
+// Lease contains the details of the lease an external system attached to a
+// dynamic credential. The lease is renewed while the session is active and
+// revoked when the session ends.
+type Lease struct {
+	// Id is the identifier of the lease in the external system.
+	Id string
+	// Duration is the time to live of the lease at the time it was issued.
+	Duration time.Duration
+	// Renewable is true if the lease can be renewed.
+	Renewable bool
+}
+
+// Leased is a dynamic credential that is bound to a lease in the system
+// that issued it.
+type Leased interface {
+	Dynamic
+	Lease() Lease
+}
+
 // Static is a static credential that is stored directly in a credential store.
 type Static interface {
 	boundary.Resource
@@ -143,3 +163,36 @@ type SshPrivateKey interface {
 	PrivateKey() PrivateKey
 	PrivateKeyPassphrase() []byte
 }
+
+// CloudAccess is a credential containing the values used to access the API
+// of a cloud provider. Provider returns "aws", "gcp" or "azure" and
+// determines which of the other values are set: an access key id, a secret
+// access key and an optional session token for AWS, an OAuth2 access token
+// and/or a service account key for GCP, and a client id, client secret and
+// optional tenant and subscription ids for Azure. The returned expiration
+// time is the zero time if the credential does not expire.
+type CloudAccess interface {
+	Credential
+	Provider() string
+	AccessKeyId() string
+	SecretAccessKey() Password
+	SessionToken() string
+	AccessToken() Password
+	ServiceAccountKey() Password
+	ClientId() string
+	ClientSecret() Password
+	TenantId() string
+	SubscriptionId() string
+	Expiration() time.Time
+}
+
+// TlsClientCertificate is a credential containing a PEM encoded X.509
+// client certificate chain, the PEM encoded private key for the leaf
+// certificate and an optional PEM encoded CA certificate bundle used to
+// verify the server the certificate is presented to.
+type TlsClientCertificate interface {
+	Credential
+	Certificate() []byte
+	PrivateKey() PrivateKey
+	CaCertificate() []byte
+}
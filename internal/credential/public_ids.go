@@ -17,6 +17,7 @@ func init() {
 	globals.RegisterPrefixToResourceInfo(globals.UsernamePasswordCredentialPreviousPrefix, resource.Credential, Domain, UsernamePasswordSubtype)
 	globals.RegisterPrefixToResourceInfo(globals.SshPrivateKeyCredentialPrefix, resource.Credential, Domain, SshPrivateKeySubtype)
 	globals.RegisterPrefixToResourceInfo(globals.JsonCredentialPrefix, resource.Credential, Domain, JsonSubtype)
+	globals.RegisterPrefixToResourceInfo(globals.TlsClientCertificateCredentialPrefix, resource.Credential, Domain, TlsClientCertificateSubtype)
 }
 
 const (
@@ -25,6 +26,8 @@ const (
 	SshPrivateKeySubtype = globals.Subtype("ssh_private_key")
 
 	JsonSubtype = globals.Subtype("json")
+
+	TlsClientCertificateSubtype = globals.Subtype("tls_client_certificate")
 )
 
 func NewUsernamePasswordCredentialId(ctx context.Context) (string, error) {
@@ -50,3 +53,11 @@ func NewJsonCredentialId(ctx context.Context) (string, error) {
 	}
 	return id, nil
 }
+
+func NewTlsClientCertificateCredentialId(ctx context.Context) (string, error) {
+	id, err := db.NewPublicId(ctx, globals.TlsClientCertificateCredentialPrefix)
+	if err != nil {
+		return "", errors.Wrap(ctx, err, "credential.NewTlsClientCertificateCredentialId")
+	}
+	return id, nil
+}
@@ -93,6 +93,24 @@ func (c *listCredentialResult) toCredential(ctx context.Context) (credential.Sta
 			cred.PrivateKeyPassphraseHmac = []byte(c.Hmac2)
 		}
 		return cred, nil
+	case "tls":
+		cred := &TlsClientCertificateCredential{
+			TlsClientCertificateCredential: &store.TlsClientCertificateCredential{
+				PublicId:    c.PublicId,
+				StoreId:     c.StoreId,
+				Name:        c.Name,
+				Description: c.Description,
+				CreateTime:  c.CreateTime,
+				UpdateTime:  c.UpdateTime,
+				Version:     uint32(c.Version),
+				KeyId:       c.KeyId,
+			},
+		}
+		// Assign byte slices only if the string isn't empty
+		if c.Hmac1 != "" {
+			cred.PrivateKeyHmac = []byte(c.Hmac1)
+		}
+		return cred, nil
 	default:
 		return nil, errors.New(ctx, errors.Internal, op, fmt.Sprintf("unexpected static credential type %s returned", c.Type))
 	}
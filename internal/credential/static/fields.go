@@ -12,4 +12,6 @@ const (
 	privateKeyField           = "PrivateKey"
 	PrivateKeyPassphraseField = "PrivateKeyPassphrase"
 	objectField               = "Object"
+	certificateField          = "Certificate"
+	caCertificateField        = "CaCertificate"
 )
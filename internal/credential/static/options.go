@@ -3,6 +3,8 @@
 
 package static
 
+import "github.com/hashicorp/boundary/internal/credential"
+
 // getOpts - iterate the inbound Options and return a struct
 func getOpts(opt ...Option) options {
 	opts := getDefaultOptions()
@@ -22,6 +24,8 @@ type options struct {
 	withLimit                int
 	withPublicId             string
 	withPrivateKeyPassphrase []byte
+	withCaCertificate        []byte
+	withStartPageAfterUsage  *credential.Usage
 }
 
 func getDefaultOptions() options {
@@ -64,3 +68,19 @@ func WithPrivateKeyPassphrase(with []byte) Option {
 		o.withPrivateKeyPassphrase = with
 	}
 }
+
+// WithCaCertificate provides an optional PEM encoded CA certificate bundle
+// to use.
+func WithCaCertificate(with []byte) Option {
+	return func(o *options) {
+		o.withCaCertificate = with
+	}
+}
+
+// WithStartPageAfterUsage is used to list the usage records of a credential
+// which come after usage in the listing order.
+func WithStartPageAfterUsage(usage *credential.Usage) Option {
+	return func(o *options) {
+		o.withStartPageAfterUsage = usage
+	}
+}
@@ -36,4 +36,11 @@ func Test_GetOpts(t *testing.T) {
 		testOpts.withPrivateKeyPassphrase = []byte("my-pass")
 		assert.Equal(t, opts, testOpts)
 	})
+	t.Run("WithCaCertificate", func(t *testing.T) {
+		opts := getOpts(WithCaCertificate([]byte("ca")))
+		testOpts := getDefaultOptions()
+		assert.NotEqual(t, opts, testOpts)
+		testOpts.withCaCertificate = []byte("ca")
+		assert.Equal(t, opts, testOpts)
+	})
 }
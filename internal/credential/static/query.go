@@ -41,13 +41,26 @@ where store.project_id = ?
   and json.key_id = ?;
 `
 
+	credStaticTlsClientCertificateRewrapQuery = `
+select distinct
+  tls.public_id,
+  tls.private_key_encrypted,
+  tls.key_id
+from credential_static_tls_client_certificate_credential tls
+  inner join credential_static_store store
+    on store.public_id = tls.store_id
+where store.project_id = ?
+  and tls.key_id = ?;
+`
+
 	estimateCountCredentials = `
 select sum(reltuples::bigint) as estimate
   from pg_class
  where oid in (
   'credential_static_json_credential'::regclass,
   'credential_static_username_password_credential'::regclass,
-  'credential_static_ssh_private_key_credential'::regclass
+  'credential_static_ssh_private_key_credential'::regclass,
+  'credential_static_tls_client_certificate_credential'::regclass
  )
 `
 
@@ -74,6 +87,11 @@ ssh_creds as (
     from credential_static_ssh_private_key_credential
    where public_id in (select public_id from credentials)
 ),
+tls_creds as (
+  select *
+    from credential_static_tls_client_certificate_credential
+   where public_id in (select public_id from credentials)
+),
 final as (
   select public_id,
          store_id,
@@ -119,6 +137,21 @@ final as (
          private_key_passphrase_hmac as hmac2,
          'ssh' as type
     from ssh_creds
+   union
+  select public_id,
+         store_id,
+         project_id,
+         name,
+         description,
+         create_time,
+         update_time,
+         version,
+         null as username,     -- Add this to make the union uniform
+         key_id,
+         private_key_hmac as hmac1,
+         null::bytea as hmac2, -- Add this to make the union uniform
+         'tls' as type
+    from tls_creds
 )
   select *
     from final
@@ -149,6 +182,11 @@ ssh_creds as (
     from credential_static_ssh_private_key_credential
    where public_id in (select public_id from credentials)
 ),
+tls_creds as (
+  select *
+    from credential_static_tls_client_certificate_credential
+   where public_id in (select public_id from credentials)
+),
 final as (
   select public_id,
          store_id,
@@ -194,6 +232,21 @@ final as (
          private_key_passphrase_hmac as hmac2,
          'ssh' as type
     from ssh_creds
+   union
+  select public_id,
+         store_id,
+         project_id,
+         name,
+         description,
+         create_time,
+         update_time,
+         version,
+         null as username,     -- Add this to make the union uniform
+         key_id,
+         private_key_hmac as hmac1,
+         null::bytea as hmac2, -- Add this to make the union uniform
+         'tls' as type
+    from tls_creds
 )
   select *
     from final
@@ -224,6 +277,11 @@ ssh_creds as (
     from credential_static_ssh_private_key_credential
    where public_id in (select public_id from credentials)
 ),
+tls_creds as (
+  select *
+    from credential_static_tls_client_certificate_credential
+   where public_id in (select public_id from credentials)
+),
 final as (
   select public_id,
          store_id,
@@ -269,6 +327,21 @@ final as (
          private_key_passphrase_hmac as hmac2,
          'ssh' as type
     from ssh_creds
+   union
+  select public_id,
+         store_id,
+         project_id,
+         name,
+         description,
+         create_time,
+         update_time,
+         version,
+         null as username,     -- Add this to make the union uniform
+         key_id,
+         private_key_hmac as hmac1,
+         null::bytea as hmac2, -- Add this to make the union uniform
+         'tls' as type
+    from tls_creds
 )
   select *
     from final
@@ -300,6 +373,11 @@ ssh_creds as (
     from credential_static_ssh_private_key_credential
    where public_id in (select public_id from credentials)
 ),
+tls_creds as (
+  select *
+    from credential_static_tls_client_certificate_credential
+   where public_id in (select public_id from credentials)
+),
 final as (
   select public_id,
          store_id,
@@ -345,6 +423,21 @@ final as (
          private_key_passphrase_hmac as hmac2,
          'ssh' as type
     from ssh_creds
+   union
+  select public_id,
+         store_id,
+         project_id,
+         name,
+         description,
+         create_time,
+         update_time,
+         version,
+         null as username,     -- Add this to make the union uniform
+         key_id,
+         private_key_hmac as hmac1,
+         null::bytea as hmac2, -- Add this to make the union uniform
+         'tls' as type
+    from tls_creds
 )
   select *
     from final
@@ -5,6 +5,7 @@ package static
 
 import (
 	"context"
+	"crypto/tls"
 	"database/sql"
 	"fmt"
 	"slices"
@@ -282,6 +283,92 @@ func (r *Repository) CreateJsonCredential(
 	return newCred, nil
 }
 
+// CreateTlsClientCertificateCredential inserts c into the repository and
+// returns a new TlsClientCertificateCredential containing the credential's
+// PublicId. c is not changed. c must not contain a PublicId. The PublicId is
+// generated and assigned by this method. c must contain a valid StoreId.
+//
+// The private key is encrypted and a HmacSha256 of the private key is
+// calculated. Only the PrivateKeyHmac is returned, the plain-text and
+// encrypted private key are not returned.
+//
+// Both c.Name and c.Description are optional. If c.Name is set, it must be
+// unique within c.ProjectId. Both c.CreateTime and c.UpdateTime are ignored.
+func (r *Repository) CreateTlsClientCertificateCredential(
+	ctx context.Context,
+	projectId string,
+	c *TlsClientCertificateCredential,
+	_ ...Option,
+) (*TlsClientCertificateCredential, error) {
+	const op = "static.(Repository).CreateTlsClientCertificateCredential"
+	if c == nil {
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "missing credential")
+	}
+	if c.TlsClientCertificateCredential == nil {
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "missing embedded credential")
+	}
+	if projectId == "" {
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "missing project id")
+	}
+	if c.Certificate == nil {
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "missing certificate")
+	}
+	if c.PrivateKey == nil {
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "missing private key")
+	}
+	if c.StoreId == "" {
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "missing store id")
+	}
+	if c.PublicId != "" {
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "public id not empty")
+	}
+
+	c = c.clone()
+	id, err := credential.NewTlsClientCertificateCredentialId(ctx)
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	c.PublicId = id
+	oplogWrapper, err := r.kms.GetWrapper(ctx, projectId, kms.KeyPurposeOplog)
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op, errors.WithMsg("unable to get oplog wrapper"))
+	}
+
+	// encrypt
+	databaseWrapper, err := r.kms.GetWrapper(ctx, projectId, kms.KeyPurposeDatabase)
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op, errors.WithMsg("unable to get database wrapper"))
+	}
+	if err := c.encrypt(ctx, databaseWrapper); err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+
+	var newCred *TlsClientCertificateCredential
+	_, err = r.writer.DoTx(ctx, db.StdRetryCnt, db.ExpBackoff{},
+		func(_ db.Reader, w db.Writer) error {
+			newCred = c.clone()
+			if err := w.Create(ctx, newCred,
+				db.WithOplog(oplogWrapper, newCred.oplog(oplog.OpType_OP_TYPE_CREATE))); err != nil {
+				return errors.Wrap(ctx, err, op)
+			}
+
+			return nil
+		},
+	)
+	if err != nil {
+		if errors.IsUniqueError(err) {
+			return nil, errors.Wrap(ctx, err, op, errors.WithMsg(fmt.Sprintf("in store: %s: name %s already exists", c.StoreId, c.Name)))
+		}
+		return nil, errors.Wrap(ctx, err, op, errors.WithMsg(fmt.Sprintf("in store: %s", c.StoreId)))
+	}
+
+	// Clear private key fields, only PrivateKeyHmac should be returned
+	newCred.PrivateKeyEncrypted = nil
+	newCred.PrivateKey = nil
+
+	return newCred, nil
+}
+
 // LookupCredential returns the Credential for the publicId. Returns
 // nil, nil if no Credential is found for the publicId.
 // TODO: This should hit a view and return the interface type...
@@ -338,6 +425,20 @@ func (r *Repository) LookupCredential(ctx context.Context, publicId string, _ ..
 		jsonCred.ObjectEncrypted = nil
 		jsonCred.Object = nil
 		cred = jsonCred
+
+	case credential.TlsClientCertificateSubtype:
+		tlsCred := allocTlsClientCertificateCredential()
+		tlsCred.PublicId = publicId
+		if err := r.reader.LookupByPublicId(ctx, tlsCred); err != nil {
+			if errors.IsNotFoundError(err) {
+				return nil, nil
+			}
+			return nil, errors.Wrap(ctx, err, op, errors.WithMsg(fmt.Sprintf("failed for: %s", publicId)))
+		}
+		// Clear private key fields, only PrivateKeyHmac should be returned
+		tlsCred.PrivateKeyEncrypted = nil
+		tlsCred.PrivateKey = nil
+		cred = tlsCred
 	}
 
 	return cred, nil
@@ -719,6 +820,171 @@ func (r *Repository) UpdateJsonCredential(ctx context.Context,
 	return returnedCredential, rowsUpdated, nil
 }
 
+// UpdateTlsClientCertificateCredential updates the repository entry for
+// c.PublicId with the values in c for the fields listed in fieldMaskPaths. It
+// returns a new TlsClientCertificateCredential containing the updated values
+// and a count of the number of records updated. c is not changed.
+//
+// c must contain a valid PublicId. Only Name, Description, Certificate,
+// PrivateKey and CaCertificate can be changed. If c.Name is set to a
+// non-empty string, it must be unique within c.ProjectId.
+//
+// An attribute of c will be set to NULL in the database if the attribute in c
+// is the zero value and it is included in fieldMaskPaths.
+func (r *Repository) UpdateTlsClientCertificateCredential(ctx context.Context,
+	projectId string,
+	c *TlsClientCertificateCredential,
+	version uint32,
+	fieldMaskPaths []string,
+	_ ...Option,
+) (*TlsClientCertificateCredential, int, error) {
+	const op = "static.(Repository).UpdateTlsClientCertificateCredential"
+	if c == nil {
+		return nil, db.NoRowsAffected, errors.New(ctx, errors.InvalidParameter, op, "missing credential")
+	}
+	if c.TlsClientCertificateCredential == nil {
+		return nil, db.NoRowsAffected, errors.New(ctx, errors.InvalidParameter, op, "missing embedded credential")
+	}
+	if c.PublicId == "" {
+		return nil, db.NoRowsAffected, errors.New(ctx, errors.InvalidPublicId, op, "missing public id")
+	}
+	if version == 0 {
+		return nil, db.NoRowsAffected, errors.New(ctx, errors.InvalidParameter, op, "missing version")
+	}
+	if projectId == "" {
+		return nil, db.NoRowsAffected, errors.New(ctx, errors.InvalidParameter, op, "missing project id")
+	}
+	if c.StoreId == "" {
+		return nil, db.NoRowsAffected, errors.New(ctx, errors.InvalidParameter, op, "missing store id")
+	}
+	c = c.clone()
+
+	var updateCertificate, updatePrivateKey bool
+	for _, f := range fieldMaskPaths {
+		switch {
+		case strings.EqualFold(nameField, f):
+		case strings.EqualFold(descriptionField, f):
+		case strings.EqualFold(certificateField, f):
+			updateCertificate = true
+		case strings.EqualFold(caCertificateField, f):
+		case strings.EqualFold(privateKeyField, f):
+			updatePrivateKey = true
+		default:
+			return nil, db.NoRowsAffected, errors.New(ctx, errors.InvalidFieldMask, op, f)
+		}
+	}
+	dbMask, nullFields := dbw.BuildUpdatePaths(
+		map[string]any{
+			nameField:          c.Name,
+			descriptionField:   c.Description,
+			certificateField:   c.Certificate,
+			caCertificateField: c.CaCertificate,
+			privateKeyField:    c.PrivateKey,
+		},
+		fieldMaskPaths,
+		nil,
+	)
+	if len(dbMask) == 0 && len(nullFields) == 0 {
+		return nil, db.NoRowsAffected, errors.New(ctx, errors.EmptyFieldMask, op, "missing field mask")
+	}
+
+	if updatePrivateKey {
+		// Private key has been updated, re-encrypt and recalculate hmac
+		databaseWrapper, err := r.kms.GetWrapper(ctx, projectId, kms.KeyPurposeDatabase)
+		if err != nil {
+			return nil, db.NoRowsAffected, errors.Wrap(ctx, err, op, errors.WithMsg("unable to get database wrapper"))
+		}
+		if err := c.encrypt(ctx, databaseWrapper); err != nil {
+			return nil, db.NoRowsAffected, errors.Wrap(ctx, err, op)
+		}
+
+		// Set PrivateKeyHmac and PrivateKeyEncrypted masks for update.
+		dbMask = append(dbMask, "PrivateKeyHmac", "PrivateKeyEncrypted", "KeyId")
+	}
+
+	oplogWrapper, err := r.kms.GetWrapper(ctx, projectId, kms.KeyPurposeOplog)
+	if err != nil {
+		return nil, db.NoRowsAffected,
+			errors.Wrap(ctx, err, op, errors.WithMsg("unable to get oplog wrapper"))
+	}
+
+	var rowsUpdated int
+	var returnedCredential *TlsClientCertificateCredential
+	_, err = r.writer.DoTx(ctx, db.StdRetryCnt, db.ExpBackoff{},
+		func(reader db.Reader, w db.Writer) error {
+			if updateCertificate != updatePrivateKey {
+				// Only one half of the key pair is being replaced, it must
+				// match the stored other half.
+				if err := r.checkStoredKeyPair(ctx, reader, projectId, c, updateCertificate); err != nil {
+					return errors.Wrap(ctx, err, op)
+				}
+			}
+			returnedCredential = c.clone()
+			var err error
+			rowsUpdated, err = w.Update(ctx, returnedCredential,
+				dbMask, nullFields,
+				db.WithOplog(oplogWrapper, returnedCredential.oplog(oplog.OpType_OP_TYPE_UPDATE)),
+				db.WithVersion(&version))
+			if err != nil {
+				return errors.Wrap(ctx, err, op)
+			}
+			if rowsUpdated > 1 {
+				return errors.New(ctx, errors.MultipleRecords, op, "more than 1 resource would have been updated")
+			}
+			return nil
+		},
+	)
+	if err != nil {
+		return nil, db.NoRowsAffected, err
+	}
+
+	// Clear private key fields, only PrivateKeyHmac should be returned
+	returnedCredential.PrivateKeyEncrypted = nil
+	returnedCredential.PrivateKey = nil
+
+	return returnedCredential, rowsUpdated, nil
+}
+
+// checkStoredKeyPair verifies that the certificate or private key being
+// updated in c forms a valid key pair with the counterpart stored for c in
+// the repository. If updateCertificate is true the certificate of c is
+// checked against the stored private key, otherwise the private key of c is
+// checked against the stored certificate.
+func (r *Repository) checkStoredKeyPair(ctx context.Context, reader db.Reader, projectId string, c *TlsClientCertificateCredential, updateCertificate bool) error {
+	const op = "static.(Repository).checkStoredKeyPair"
+	if (updateCertificate && len(c.Certificate) == 0) || (!updateCertificate && len(c.PrivateKey) == 0) {
+		// clearing a required field, the database rejects the update
+		return nil
+	}
+	stored := allocTlsClientCertificateCredential()
+	stored.PublicId = c.PublicId
+	if err := reader.LookupById(ctx, stored); err != nil {
+		if errors.IsNotFoundError(err) {
+			// the update will not affect any rows
+			return nil
+		}
+		return errors.Wrap(ctx, err, op)
+	}
+
+	cert, key := c.Certificate, []byte(c.PrivateKey)
+	if updateCertificate {
+		databaseWrapper, err := r.kms.GetWrapper(ctx, projectId, kms.KeyPurposeDatabase, kms.WithKeyId(stored.KeyId))
+		if err != nil {
+			return errors.Wrap(ctx, err, op, errors.WithMsg("unable to get database wrapper"))
+		}
+		if err := stored.decrypt(ctx, databaseWrapper); err != nil {
+			return errors.Wrap(ctx, err, op)
+		}
+		key = stored.PrivateKey
+	} else {
+		cert = stored.Certificate
+	}
+	if _, err := tls.X509KeyPair(cert, key); err != nil {
+		return errors.New(ctx, errors.InvalidParameter, op, fmt.Sprintf("certificate and private key do not match: %v", err))
+	}
+	return nil
+}
+
 // ListCredentials returns a slice of static credentials
 // for the storeId. Supports the following options:
 //   - credential.WithLimit
@@ -875,6 +1141,11 @@ func (r *Repository) DeleteCredential(ctx context.Context, projectId, id string,
 		c.PublicId = id
 		input = c
 		md = c.oplog(oplog.OpType_OP_TYPE_DELETE)
+	case credential.TlsClientCertificateSubtype:
+		c := allocTlsClientCertificateCredential()
+		c.PublicId = id
+		input = c
+		md = c.oplog(oplog.OpType_OP_TYPE_DELETE)
 	default:
 		return db.NoRowsAffected, errors.New(ctx, errors.InvalidParameter, op, "unknown type")
 	}
@@ -953,6 +1224,13 @@ func (r *Repository) ListDeletedCredentialIds(ctx context.Context, since time.Ti
 		for _, cl := range deletedSSHPrivateKeyCredentials {
 			credentialStoreIds = append(credentialStoreIds, cl.PublicId)
 		}
+		var deletedTlsClientCertificateCredentials []*deletedTlsClientCertificateCredential
+		if err := r.SearchWhere(ctx, &deletedTlsClientCertificateCredentials, "delete_time >= ?", []any{since}); err != nil {
+			return errors.Wrap(ctx, err, op, errors.WithMsg("failed to query deleted tls client certificate credentials"))
+		}
+		for _, cl := range deletedTlsClientCertificateCredentials {
+			credentialStoreIds = append(credentialStoreIds, cl.PublicId)
+		}
 		var err error
 		transactionTimestamp, err = r.Now(ctx)
 		if err != nil {
@@ -565,6 +565,176 @@ func TestRepository_CreateJsonCredential(t *testing.T) {
 	})
 }
 
+func TestRepository_CreateTlsClientCertificateCredential(t *testing.T) {
+	t.Parallel()
+	conn, _ := db.TestSetup(t, "postgres")
+	rw := db.New(conn)
+	wrapper := db.TestWrapper(t)
+	_, prj := iam.TestScopes(t, iam.TestRepo(t, conn, wrapper))
+
+	cs := TestCredentialStore(t, conn, wrapper, prj.PublicId)
+	cert, key, ca := TestTlsClientCertificate(t)
+
+	tests := []struct {
+		name        string
+		projectId   string
+		cred        *TlsClientCertificateCredential
+		wantErrCode errors.Code
+	}{
+		{
+			name:        "missing-cred",
+			projectId:   prj.PublicId,
+			wantErrCode: errors.InvalidParameter,
+		},
+		{
+			name:        "missing-embedded-cred",
+			projectId:   prj.PublicId,
+			cred:        &TlsClientCertificateCredential{},
+			wantErrCode: errors.InvalidParameter,
+		},
+		{
+			name: "missing-project-id",
+			cred: &TlsClientCertificateCredential{
+				TlsClientCertificateCredential: &store.TlsClientCertificateCredential{
+					Certificate: cert,
+					PrivateKey:  key,
+					StoreId:     cs.PublicId,
+				},
+			},
+			wantErrCode: errors.InvalidParameter,
+		},
+		{
+			name:      "missing-certificate",
+			projectId: prj.PublicId,
+			cred: &TlsClientCertificateCredential{
+				TlsClientCertificateCredential: &store.TlsClientCertificateCredential{
+					PrivateKey: key,
+					StoreId:    cs.PublicId,
+				},
+			},
+			wantErrCode: errors.InvalidParameter,
+		},
+		{
+			name:      "missing-private-key",
+			projectId: prj.PublicId,
+			cred: &TlsClientCertificateCredential{
+				TlsClientCertificateCredential: &store.TlsClientCertificateCredential{
+					Certificate: cert,
+					StoreId:     cs.PublicId,
+				},
+			},
+			wantErrCode: errors.InvalidParameter,
+		},
+		{
+			name:      "missing-store-id",
+			projectId: prj.PublicId,
+			cred: &TlsClientCertificateCredential{
+				TlsClientCertificateCredential: &store.TlsClientCertificateCredential{
+					Certificate: cert,
+					PrivateKey:  key,
+				},
+			},
+			wantErrCode: errors.InvalidParameter,
+		},
+		{
+			name:      "public-id-set",
+			projectId: prj.PublicId,
+			cred: &TlsClientCertificateCredential{
+				TlsClientCertificateCredential: &store.TlsClientCertificateCredential{
+					PublicId:    "ctls_1234567890",
+					Certificate: cert,
+					PrivateKey:  key,
+					StoreId:     cs.PublicId,
+				},
+			},
+			wantErrCode: errors.InvalidParameter,
+		},
+		{
+			name:      "valid",
+			projectId: prj.PublicId,
+			cred: &TlsClientCertificateCredential{
+				TlsClientCertificateCredential: &store.TlsClientCertificateCredential{
+					Certificate: cert,
+					PrivateKey:  key,
+					StoreId:     cs.PublicId,
+				},
+			},
+		},
+		{
+			name:      "valid-with-ca-certificate",
+			projectId: prj.PublicId,
+			cred: &TlsClientCertificateCredential{
+				TlsClientCertificateCredential: &store.TlsClientCertificateCredential{
+					Certificate:   cert,
+					PrivateKey:    key,
+					CaCertificate: ca,
+					StoreId:       cs.PublicId,
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			assert, require := assert.New(t), require.New(t)
+			ctx := context.Background()
+			kkms := kms.TestKms(t, conn, wrapper)
+			repo, err := NewRepository(ctx, rw, rw, kkms)
+			require.NoError(err)
+			require.NotNil(repo)
+
+			got, err := repo.CreateTlsClientCertificateCredential(ctx, tt.projectId, tt.cred)
+			if tt.wantErrCode != 0 {
+				assert.Truef(errors.Match(errors.T(tt.wantErrCode), err), "want err: %q got: %q", tt.wantErrCode, err)
+				assert.Nil(got)
+				return
+			}
+			require.NoError(err)
+			assertPublicId(t, globals.TlsClientCertificateCredentialPrefix, got.PublicId)
+			assert.Equal(tt.cred.Certificate, got.Certificate)
+			assert.Equal(tt.cred.CaCertificate, got.CaCertificate)
+			assert.Nil(got.PrivateKey)
+			assert.Nil(got.PrivateKeyEncrypted)
+
+			// Validate private key
+			lookupCred := allocTlsClientCertificateCredential()
+			lookupCred.PublicId = got.PublicId
+			require.NoError(rw.LookupById(ctx, lookupCred))
+
+			databaseWrapper, err := kkms.GetWrapper(context.Background(), tt.projectId, kms.KeyPurposeDatabase)
+			require.NoError(err)
+			require.NoError(lookupCred.decrypt(ctx, databaseWrapper))
+			assert.Equal(tt.cred.PrivateKey, lookupCred.PrivateKey)
+
+			// Validate hmac
+			hm, err := crypto.HmacSha256(ctx, tt.cred.PrivateKey, databaseWrapper, []byte(tt.cred.StoreId), nil)
+			require.NoError(err)
+			assert.Equal([]byte(hm), got.PrivateKeyHmac)
+
+			// Validate oplog
+			assert.NoError(db.TestVerifyOplog(t, rw, got.PublicId, db.WithOperation(oplog.OpType_OP_TYPE_CREATE), db.WithCreateNotBefore(10*time.Second)))
+		})
+	}
+
+	t.Run("duplicate-names", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		ctx := context.Background()
+		kms := kms.TestKms(t, conn, wrapper)
+		repo, err := NewRepository(ctx, rw, rw, kms)
+		require.NoError(err)
+
+		in, err := NewTlsClientCertificateCredential(ctx, cs.GetPublicId(), cert, credential.PrivateKey(key), WithName("tls-name"))
+		require.NoError(err)
+		got, err := repo.CreateTlsClientCertificateCredential(ctx, prj.PublicId, in)
+		require.NoError(err)
+		assert.Equal(in.Name, got.Name)
+
+		got2, err := repo.CreateTlsClientCertificateCredential(ctx, prj.PublicId, in)
+		assert.Truef(errors.Match(errors.T(errors.NotUnique), err), "want err code: %v got err: %v", errors.NotUnique, err)
+		assert.Nil(got2)
+	})
+}
+
 func TestRepository_LookupCredential(t *testing.T) {
 	t.Parallel()
 	conn, _ := db.TestSetup(t, "postgres")
@@ -2489,6 +2659,167 @@ func TestRepository_UpdateJsonCredential(t *testing.T) {
 	}
 }
 
+func TestRepository_UpdateTlsClientCertificateCredential(t *testing.T) {
+	t.Parallel()
+	conn, _ := db.TestSetup(t, "postgres")
+	rw := db.New(conn)
+	wrapper := db.TestWrapper(t)
+	_, prj := iam.TestScopes(t, iam.TestRepo(t, conn, wrapper))
+	cs := TestCredentialStore(t, conn, wrapper, prj.PublicId)
+
+	cert, key, ca := TestTlsClientCertificate(t)
+	otherCert, otherKey, _ := TestTlsClientCertificate(t)
+
+	tests := []struct {
+		name        string
+		update      *store.TlsClientCertificateCredential
+		masks       []string
+		want        *store.TlsClientCertificateCredential
+		wantErrCode errors.Code
+	}{
+		{
+			name:   "change-name-and-description",
+			update: &store.TlsClientCertificateCredential{Name: "new-name", Description: "new-description"},
+			masks:  []string{nameField, descriptionField},
+			want:   &store.TlsClientCertificateCredential{Name: "new-name", Description: "new-description", Certificate: cert, PrivateKey: key},
+		},
+		{
+			name:   "change-ca-certificate",
+			update: &store.TlsClientCertificateCredential{CaCertificate: ca},
+			masks:  []string{caCertificateField},
+			want:   &store.TlsClientCertificateCredential{Certificate: cert, PrivateKey: key, CaCertificate: ca},
+		},
+		{
+			name:   "change-certificate-and-private-key",
+			update: &store.TlsClientCertificateCredential{Certificate: otherCert, PrivateKey: otherKey},
+			masks:  []string{certificateField, privateKeyField},
+			want:   &store.TlsClientCertificateCredential{Certificate: otherCert, PrivateKey: otherKey},
+		},
+		{
+			name:   "change-private-key-matching-stored-certificate",
+			update: &store.TlsClientCertificateCredential{PrivateKey: key},
+			masks:  []string{privateKeyField},
+			want:   &store.TlsClientCertificateCredential{Certificate: cert, PrivateKey: key},
+		},
+		{
+			name:        "change-private-key-not-matching-stored-certificate",
+			update:      &store.TlsClientCertificateCredential{PrivateKey: otherKey},
+			masks:       []string{privateKeyField},
+			wantErrCode: errors.InvalidParameter,
+		},
+		{
+			name:        "change-certificate-not-matching-stored-private-key",
+			update:      &store.TlsClientCertificateCredential{Certificate: otherCert},
+			masks:       []string{certificateField},
+			wantErrCode: errors.InvalidParameter,
+		},
+		{
+			name:        "invalid-field-mask",
+			update:      &store.TlsClientCertificateCredential{Name: "new-name"},
+			masks:       []string{"Username"},
+			wantErrCode: errors.InvalidFieldMask,
+		},
+		{
+			name:        "empty-field-mask",
+			update:      &store.TlsClientCertificateCredential{Name: "new-name"},
+			wantErrCode: errors.EmptyFieldMask,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			assert, require := assert.New(t), require.New(t)
+			ctx := context.Background()
+			kkms := kms.TestKms(t, conn, wrapper)
+			repo, err := NewRepository(ctx, rw, rw, kkms)
+			require.NoError(err)
+
+			orig := TestTlsClientCertificateCredential(t, conn, wrapper, cert, key, cs.PublicId, prj.PublicId)
+			in := &TlsClientCertificateCredential{TlsClientCertificateCredential: tt.update}
+			in.PublicId = orig.PublicId
+			in.StoreId = orig.StoreId
+
+			got, gotCount, err := repo.UpdateTlsClientCertificateCredential(ctx, prj.PublicId, in, orig.Version, tt.masks)
+			if tt.wantErrCode != 0 {
+				assert.Truef(errors.Match(errors.T(tt.wantErrCode), err), "want err: %q got: %q", tt.wantErrCode, err)
+				assert.Equal(db.NoRowsAffected, gotCount)
+				assert.Nil(got)
+
+				// the stored credential is unchanged
+				lookupCred := allocTlsClientCertificateCredential()
+				lookupCred.PublicId = orig.PublicId
+				require.NoError(rw.LookupById(ctx, lookupCred))
+				assert.Equal(orig.Version, lookupCred.Version)
+				return
+			}
+			require.NoError(err)
+			assert.Equal(1, gotCount)
+			assert.Equal(orig.Version+1, got.Version)
+			assert.Equal(tt.want.Name, got.Name)
+			assert.Equal(tt.want.Description, got.Description)
+			assert.Equal(tt.want.Certificate, got.Certificate)
+			assert.Equal(tt.want.CaCertificate, got.CaCertificate)
+			assert.Nil(got.PrivateKey)
+			assert.Nil(got.PrivateKeyEncrypted)
+
+			lookupCred := allocTlsClientCertificateCredential()
+			lookupCred.PublicId = got.PublicId
+			require.NoError(rw.LookupById(ctx, lookupCred))
+			databaseWrapper, err := kkms.GetWrapper(ctx, prj.PublicId, kms.KeyPurposeDatabase, kms.WithKeyId(lookupCred.KeyId))
+			require.NoError(err)
+			require.NoError(lookupCred.decrypt(ctx, databaseWrapper))
+			assert.Equal(tt.want.PrivateKey, lookupCred.PrivateKey)
+
+			assert.NoError(db.TestVerifyOplog(t, rw, got.PublicId, db.WithOperation(oplog.OpType_OP_TYPE_UPDATE), db.WithCreateNotBefore(10*time.Second)))
+		})
+	}
+}
+
+func TestRepository_UpdateTlsClientCertificateCredentialKeyUpdate(t *testing.T) {
+	t.Parallel()
+	conn, _ := db.TestSetup(t, "postgres")
+	rw := db.New(conn)
+	wrapper := db.TestWrapper(t)
+	assert, require := assert.New(t), require.New(t)
+	ctx := context.Background()
+	kkms := kms.TestKms(t, conn, wrapper)
+	repo, err := NewRepository(ctx, rw, rw, kkms)
+	require.NoError(err)
+
+	_, prj := iam.TestScopes(t, iam.TestRepo(t, conn, wrapper))
+	credStore := TestCredentialStore(t, conn, wrapper, prj.GetPublicId())
+	cert, key, _ := TestTlsClientCertificate(t)
+	orig, err := repo.CreateTlsClientCertificateCredential(ctx, prj.GetPublicId(), &TlsClientCertificateCredential{
+		TlsClientCertificateCredential: &store.TlsClientCertificateCredential{
+			Certificate: cert,
+			PrivateKey:  key,
+			StoreId:     credStore.PublicId,
+		},
+	})
+	require.NoError(err)
+
+	require.NoError(kkms.RotateKeys(ctx, prj.GetPublicId()))
+
+	// Updating only the certificate must decrypt the stored private key with
+	// the key version it was encrypted with.
+	orig.Certificate = cert
+	got, _, err := repo.UpdateTlsClientCertificateCredential(ctx, prj.GetPublicId(), orig, orig.GetVersion(), []string{certificateField})
+	require.NoError(err)
+	assert.Equal(orig.KeyId, got.KeyId)
+
+	// Updating the private key re-encrypts it with the new key version.
+	got.PrivateKey = key
+	got2, _, err := repo.UpdateTlsClientCertificateCredential(ctx, prj.GetPublicId(), got, got.GetVersion(), []string{privateKeyField})
+	require.NoError(err)
+	assert.NotEqual(orig.KeyId, got2.KeyId)
+
+	databaseWrapper, err := kkms.GetWrapper(ctx, prj.GetPublicId(), kms.KeyPurposeDatabase, kms.WithKeyId(got2.KeyId))
+	require.NoError(err)
+	hm, err := crypto.HmacSha256(ctx, key, databaseWrapper, []byte(credStore.GetPublicId()), nil)
+	require.NoError(err)
+	assert.Equal([]byte(hm), got2.PrivateKeyHmac)
+}
+
 func TestRepository_ListDeletedCredentialIds(t *testing.T) {
 	t.Parallel()
 	assert, require := assert.New(t), require.New(t)
@@ -0,0 +1,42 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package static
+
+import (
+	"context"
+
+	"github.com/hashicorp/boundary/internal/credential"
+	"github.com/hashicorp/boundary/internal/db"
+	"github.com/hashicorp/boundary/internal/errors"
+)
+
+// ListCredentialUsage returns the usage records for the credential with
+// credentialId, most recent first. WithLimit and WithStartPageAfterUsage are
+// the only valid options.
+func (r *Repository) ListCredentialUsage(ctx context.Context, credentialId string, opt ...Option) ([]*credential.Usage, error) {
+	const op = "static.(Repository).ListCredentialUsage"
+	if credentialId == "" {
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "missing credential id")
+	}
+
+	opts := getOpts(opt...)
+	limit := r.defaultLimit
+	if opts.withLimit != 0 {
+		// non-zero signals an override of the default limit for the repo.
+		limit = opts.withLimit
+	}
+
+	where, args := "credential_id = ?", []any{credentialId}
+	if after := opts.withStartPageAfterUsage; after != nil {
+		where += " and (create_time, session_id, credential_purpose) < (?, ?, ?)"
+		args = append(args, after.CreateTime.AsTime(), after.SessionId, after.CredentialPurpose)
+	}
+
+	var usage []*credential.Usage
+	if err := r.reader.SearchWhere(ctx, &usage, where, args,
+		db.WithLimit(limit), db.WithOrder("create_time desc, session_id desc, credential_purpose desc")); err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	return usage, nil
+}
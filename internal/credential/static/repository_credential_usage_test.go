@@ -0,0 +1,101 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package static
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/boundary/internal/credential"
+	"github.com/hashicorp/boundary/internal/db"
+	"github.com/hashicorp/boundary/internal/errors"
+	"github.com/hashicorp/boundary/internal/iam"
+	"github.com/hashicorp/boundary/internal/kms"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRepository_ListCredentialUsage(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	conn, _ := db.TestSetup(t, "postgres")
+	rw := db.New(conn)
+	wrapper := db.TestWrapper(t)
+	kms := kms.TestKms(t, conn, wrapper)
+
+	repo, err := NewRepository(ctx, rw, rw, kms)
+	require.NoError(t, err)
+
+	_, prj := iam.TestScopes(t, iam.TestRepo(t, conn, wrapper))
+	cs := TestCredentialStore(t, conn, wrapper, prj.GetPublicId())
+	cred := TestUsernamePasswordCredential(t, conn, wrapper, "user", "pass", cs.GetPublicId(), prj.GetPublicId())
+	other := TestUsernamePasswordCredential(t, conn, wrapper, "other", "pass", cs.GetPublicId(), prj.GetPublicId())
+
+	// Usage records are normally written by the database when a credential
+	// is bound to a session. They have no foreign keys so they can be
+	// written directly here.
+	usage := func(credId, sessionId string) *credential.Usage {
+		u := &credential.Usage{
+			SessionId:         sessionId,
+			CredentialId:      credId,
+			CredentialPurpose: credential.BrokeredPurpose,
+			UserId:            "u_1234567890",
+			TargetId:          "ttcp_1234567890",
+			ProjectId:         prj.GetPublicId(),
+		}
+		require.NoError(t, rw.Create(ctx, u))
+		return u
+	}
+	first := usage(cred.GetPublicId(), "s_1111111111")
+	second := usage(cred.GetPublicId(), "s_2222222222")
+	usage(other.GetPublicId(), "s_2222222222")
+
+	t.Run("missing-credential-id", func(t *testing.T) {
+		got, err := repo.ListCredentialUsage(ctx, "")
+		assert.Truef(t, errors.Match(errors.T(errors.InvalidParameter), err), "unexpected error: %v", err)
+		assert.Nil(t, got)
+	})
+	t.Run("most-recent-first", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		got, err := repo.ListCredentialUsage(ctx, cred.GetPublicId())
+		require.NoError(err)
+		require.Len(got, 2)
+		assert.Equal(second.SessionId, got[0].SessionId)
+		assert.Equal(first.SessionId, got[1].SessionId)
+		for _, u := range got {
+			assert.Equal(cred.GetPublicId(), u.CredentialId)
+			assert.Equal(credential.BrokeredPurpose, u.CredentialPurpose)
+			assert.Equal("u_1234567890", u.UserId)
+			assert.Equal("ttcp_1234567890", u.TargetId)
+			assert.Equal(prj.GetPublicId(), u.ProjectId)
+			assert.Empty(u.CredentialLibraryId)
+			assert.NotNil(u.CreateTime)
+		}
+	})
+	t.Run("with-limit", func(t *testing.T) {
+		got, err := repo.ListCredentialUsage(ctx, cred.GetPublicId(), WithLimit(1))
+		require.NoError(t, err)
+		require.Len(t, got, 1)
+		assert.Equal(t, second.SessionId, got[0].SessionId)
+	})
+	t.Run("start-page-after-usage", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		page, err := repo.ListCredentialUsage(ctx, cred.GetPublicId(), WithLimit(1))
+		require.NoError(err)
+		require.Len(page, 1)
+		page, err = repo.ListCredentialUsage(ctx, cred.GetPublicId(), WithLimit(1), WithStartPageAfterUsage(page[0]))
+		require.NoError(err)
+		require.Len(page, 1)
+		assert.Equal(first.SessionId, page[0].SessionId)
+		page, err = repo.ListCredentialUsage(ctx, cred.GetPublicId(), WithLimit(1), WithStartPageAfterUsage(page[0]))
+		require.NoError(err)
+		assert.Empty(page)
+	})
+	t.Run("unused", func(t *testing.T) {
+		unused := TestUsernamePasswordCredential(t, conn, wrapper, "unused", "pass", cs.GetPublicId(), prj.GetPublicId())
+		got, err := repo.ListCredentialUsage(ctx, unused.GetPublicId())
+		require.NoError(t, err)
+		assert.Empty(t, got)
+	})
+}
@@ -58,6 +58,11 @@ func TestRepository_Retrieve(t *testing.T) {
 	jsonCred1 := TestJsonCredential(t, conn, wrapper, staticStore.GetPublicId(), prj.GetPublicId(), obj)
 	jsonCred2 := TestJsonCredential(t, conn, wrapper, staticStore.GetPublicId(), prj.GetPublicId(), secondObj)
 
+	tlsCert, tlsKey, _ := TestTlsClientCertificate(t)
+	tlsCred1 := TestTlsClientCertificateCredential(t, conn, wrapper, tlsCert, tlsKey, staticStore.GetPublicId(), prj.GetPublicId())
+	tlsCert2, tlsKey2, tlsCa2 := TestTlsClientCertificate(t)
+	tlsCred2 := TestTlsClientCertificateCredential(t, conn, wrapper, tlsCert2, tlsKey2, staticStore.GetPublicId(), prj.GetPublicId(), WithCaCertificate(tlsCa2))
+
 	type args struct {
 		credIds   []string
 		projectId string
@@ -143,14 +148,24 @@ func TestRepository_Retrieve(t *testing.T) {
 				jsonCred1, jsonCred2,
 			},
 		},
+		{
+			name: "valid-tls-client-certificate-creds",
+			args: args{
+				projectId: prj.GetPublicId(),
+				credIds:   []string{tlsCred1.GetPublicId(), tlsCred2.GetPublicId()},
+			},
+			wantCreds: []credential.Static{
+				tlsCred1, tlsCred2,
+			},
+		},
 		{
 			name: "valid-mixed-creds",
 			args: args{
 				projectId: prj.GetPublicId(),
-				credIds:   []string{upCred1.GetPublicId(), spkCred1.GetPublicId(), spkCredWithPass.GetPublicId(), spkCred2.GetPublicId(), upCred2.GetPublicId(), jsonCred1.GetPublicId(), jsonCred2.GetPublicId()},
+				credIds:   []string{upCred1.GetPublicId(), spkCred1.GetPublicId(), spkCredWithPass.GetPublicId(), spkCred2.GetPublicId(), upCred2.GetPublicId(), jsonCred1.GetPublicId(), jsonCred2.GetPublicId(), tlsCred1.GetPublicId()},
 			},
 			wantCreds: []credential.Static{
-				upCred1, spkCred1, spkCredWithPass, spkCred2, upCred2, jsonCred1, jsonCred2,
+				upCred1, spkCred1, spkCredWithPass, spkCred2, upCred2, jsonCred1, jsonCred2, tlsCred1,
 			},
 		},
 	}
@@ -171,7 +186,8 @@ func TestRepository_Retrieve(t *testing.T) {
 					cmpopts.IgnoreUnexported(
 						UsernamePasswordCredential{}, store.UsernamePasswordCredential{},
 						SshPrivateKeyCredential{}, store.SshPrivateKeyCredential{},
-						JsonCredential{}, store.JsonCredential{}),
+						JsonCredential{}, store.JsonCredential{},
+						TlsClientCertificateCredential{}, store.TlsClientCertificateCredential{}),
 					cmpopts.IgnoreTypes(&timestamp.Timestamp{}),
 					cmpopts.IgnoreFields(SshPrivateKeyCredential{}, "PassphraseUnneeded"),
 					cmpopts.SortSlices(func(x, y credential.Static) bool {
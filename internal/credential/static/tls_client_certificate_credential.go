@@ -0,0 +1,165 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package static
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+
+	"github.com/hashicorp/boundary/internal/credential"
+	"github.com/hashicorp/boundary/internal/credential/static/store"
+	"github.com/hashicorp/boundary/internal/db/timestamp"
+	"github.com/hashicorp/boundary/internal/errors"
+	"github.com/hashicorp/boundary/internal/libs/crypto"
+	"github.com/hashicorp/boundary/internal/oplog"
+	"github.com/hashicorp/boundary/internal/types/resource"
+	wrapping "github.com/hashicorp/go-kms-wrapping/v2"
+	"github.com/hashicorp/go-kms-wrapping/v2/extras/structwrapping"
+	"google.golang.org/protobuf/proto"
+)
+
+var _ credential.Static = (*TlsClientCertificateCredential)(nil)
+
+// A TlsClientCertificateCredential contains the credential with a PEM
+// encoded X.509 client certificate chain, the private key for the
+// certificate and an optional CA certificate bundle. It is owned by a
+// credential store.
+type TlsClientCertificateCredential struct {
+	*store.TlsClientCertificateCredential
+	tableName string `gorm:"-"`
+}
+
+// NewTlsClientCertificateCredential creates a new in memory static
+// Credential containing a PEM encoded client certificate chain and private
+// key that is assigned to storeId. The certificate and private key must
+// form a valid key pair. Name, description and CA certificate are the only
+// valid options. All other options are ignored.
+func NewTlsClientCertificateCredential(
+	ctx context.Context,
+	storeId string,
+	certificate []byte,
+	privateKey credential.PrivateKey,
+	opt ...Option,
+) (*TlsClientCertificateCredential, error) {
+	const op = "static.NewTlsClientCertificateCredential"
+
+	if len(certificate) != 0 && len(privateKey) != 0 {
+		if _, err := tls.X509KeyPair(certificate, privateKey); err != nil {
+			return nil, errors.Wrap(ctx, err, op, errors.WithCode(errors.InvalidParameter))
+		}
+	}
+
+	opts := getOpts(opt...)
+	if len(opts.withCaCertificate) != 0 {
+		if !x509.NewCertPool().AppendCertsFromPEM(opts.withCaCertificate) {
+			return nil, errors.New(ctx, errors.InvalidParameter, op, "invalid ca certificate")
+		}
+	}
+
+	l := &TlsClientCertificateCredential{
+		TlsClientCertificateCredential: &store.TlsClientCertificateCredential{
+			StoreId:       storeId,
+			Name:          opts.withName,
+			Description:   opts.withDescription,
+			Certificate:   certificate,
+			PrivateKey:    privateKey,
+			CaCertificate: opts.withCaCertificate,
+		},
+	}
+	return l, nil
+}
+
+func allocTlsClientCertificateCredential() *TlsClientCertificateCredential {
+	return &TlsClientCertificateCredential{
+		TlsClientCertificateCredential: &store.TlsClientCertificateCredential{},
+	}
+}
+
+func (c *TlsClientCertificateCredential) clone() *TlsClientCertificateCredential {
+	cp := proto.Clone(c.TlsClientCertificateCredential)
+	return &TlsClientCertificateCredential{
+		TlsClientCertificateCredential: cp.(*store.TlsClientCertificateCredential),
+	}
+}
+
+// TableName returns the table name.
+func (c *TlsClientCertificateCredential) TableName() string {
+	if c.tableName != "" {
+		return c.tableName
+	}
+	return "credential_static_tls_client_certificate_credential"
+}
+
+// SetTableName sets the table name.
+func (c *TlsClientCertificateCredential) SetTableName(n string) {
+	c.tableName = n
+}
+
+// GetResourceType returns the resource type of the Credential
+func (c *TlsClientCertificateCredential) GetResourceType() resource.Type {
+	return resource.Credential
+}
+
+func (c *TlsClientCertificateCredential) oplog(op oplog.OpType) oplog.Metadata {
+	metadata := oplog.Metadata{
+		"resource-public-id": []string{c.PublicId},
+		"resource-type":      []string{"credential-static-tls-client-certificate"},
+		"op-type":            []string{op.String()},
+	}
+	if c.StoreId != "" {
+		metadata["store-id"] = []string{c.StoreId}
+	}
+	return metadata
+}
+
+func (c *TlsClientCertificateCredential) encrypt(ctx context.Context, cipher wrapping.Wrapper) error {
+	const op = "static.(TlsClientCertificateCredential).encrypt"
+	if len(c.PrivateKey) == 0 {
+		return errors.New(ctx, errors.InvalidParameter, op, "no private key defined")
+	}
+	if err := structwrapping.WrapStruct(ctx, cipher, c.TlsClientCertificateCredential, nil); err != nil {
+		return errors.Wrap(ctx, err, op, errors.WithCode(errors.Encrypt))
+	}
+	keyId, err := cipher.KeyId(ctx)
+	if err != nil {
+		return errors.Wrap(ctx, err, op, errors.WithCode(errors.Encrypt), errors.WithMsg("error reading cipher key id"))
+	}
+	c.KeyId = keyId
+	if err := c.hmacPrivateKey(ctx, cipher); err != nil {
+		return errors.Wrap(ctx, err, op)
+	}
+	return nil
+}
+
+func (c *TlsClientCertificateCredential) decrypt(ctx context.Context, cipher wrapping.Wrapper) error {
+	const op = "static.(TlsClientCertificateCredential).decrypt"
+	if err := structwrapping.UnwrapStruct(ctx, cipher, c.TlsClientCertificateCredential, nil); err != nil {
+		return errors.Wrap(ctx, err, op, errors.WithCode(errors.Decrypt))
+	}
+	return nil
+}
+
+func (c *TlsClientCertificateCredential) hmacPrivateKey(ctx context.Context, cipher wrapping.Wrapper) error {
+	const op = "static.(TlsClientCertificateCredential).hmacPrivateKey"
+	if cipher == nil {
+		return errors.New(ctx, errors.InvalidParameter, op, "missing cipher")
+	}
+	hm, err := crypto.HmacSha256(ctx, c.PrivateKey, cipher, []byte(c.StoreId), nil)
+	if err != nil {
+		return errors.Wrap(ctx, err, op)
+	}
+	c.PrivateKeyHmac = []byte(hm)
+	return nil
+}
+
+type deletedTlsClientCertificateCredential struct {
+	PublicId   string `gorm:"primary_key"`
+	DeleteTime *timestamp.Timestamp
+}
+
+// TableName returns the tablename to override the default gorm table name
+func (s *deletedTlsClientCertificateCredential) TableName() string {
+	return "credential_static_tls_client_certificate_credential_deleted"
+}
@@ -0,0 +1,210 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package static
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/hashicorp/boundary/internal/credential"
+	"github.com/hashicorp/boundary/internal/credential/static/store"
+	"github.com/hashicorp/boundary/internal/db"
+	"github.com/hashicorp/boundary/internal/errors"
+	"github.com/hashicorp/boundary/internal/iam"
+	"github.com/hashicorp/boundary/internal/kms"
+	"github.com/hashicorp/boundary/internal/libs/crypto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/testing/protocmp"
+)
+
+func TestTlsClientCertificateCredential_New(t *testing.T) {
+	t.Parallel()
+	conn, _ := db.TestSetup(t, "postgres")
+	wrapper := db.TestWrapper(t)
+	kkms := kms.TestKms(t, conn, wrapper)
+	rw := db.New(conn)
+
+	cert, key, ca := TestTlsClientCertificate(t)
+
+	_, prj := iam.TestScopes(t, iam.TestRepo(t, conn, wrapper))
+	cs := TestCredentialStore(t, conn, wrapper, prj.PublicId)
+
+	type args struct {
+		certificate []byte
+		privateKey  credential.PrivateKey
+		storeId     string
+		options     []Option
+	}
+
+	tests := []struct {
+		name           string
+		args           args
+		want           *TlsClientCertificateCredential
+		wantCreateErr  bool
+		wantEncryptErr bool
+	}{
+		{
+			name: "missing-private-key",
+			args: args{
+				certificate: cert,
+				storeId:     cs.PublicId,
+			},
+			want:           allocTlsClientCertificateCredential(),
+			wantEncryptErr: true,
+		},
+		{
+			name: "missing-certificate",
+			args: args{
+				privateKey: key,
+				storeId:    cs.PublicId,
+			},
+			want:          allocTlsClientCertificateCredential(),
+			wantCreateErr: true,
+		},
+		{
+			name: "missing-store-id",
+			args: args{
+				certificate: cert,
+				privateKey:  key,
+			},
+			want:          allocTlsClientCertificateCredential(),
+			wantCreateErr: true,
+		},
+		{
+			name: "valid-no-options",
+			args: args{
+				certificate: cert,
+				privateKey:  key,
+				storeId:     cs.PublicId,
+			},
+			want: &TlsClientCertificateCredential{
+				TlsClientCertificateCredential: &store.TlsClientCertificateCredential{
+					Certificate: cert,
+					PrivateKey:  key,
+					StoreId:     cs.PublicId,
+				},
+			},
+		},
+		{
+			name: "valid-with-options",
+			args: args{
+				certificate: cert,
+				privateKey:  key,
+				storeId:     cs.PublicId,
+				options: []Option{
+					WithName("my-credential"),
+					WithDescription("my-credential-description"),
+					WithCaCertificate(ca),
+				},
+			},
+			want: &TlsClientCertificateCredential{
+				TlsClientCertificateCredential: &store.TlsClientCertificateCredential{
+					Certificate:   cert,
+					PrivateKey:    key,
+					CaCertificate: ca,
+					StoreId:       cs.PublicId,
+					Name:          "my-credential",
+					Description:   "my-credential-description",
+				},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			assert, require := assert.New(t), require.New(t)
+			ctx := context.Background()
+			got, err := NewTlsClientCertificateCredential(ctx, tt.args.storeId, tt.args.certificate, tt.args.privateKey, tt.args.options...)
+			require.NoError(err)
+			require.NotNil(got)
+			assert.Emptyf(got.PublicId, "PublicId set")
+
+			id, err := credential.NewTlsClientCertificateCredentialId(ctx)
+			require.NoError(err)
+
+			tt.want.PublicId = id
+			got.PublicId = id
+
+			databaseWrapper, err := kkms.GetWrapper(context.Background(), prj.PublicId, kms.KeyPurposeDatabase)
+			require.NoError(err)
+
+			err = got.encrypt(ctx, databaseWrapper)
+			if tt.wantEncryptErr {
+				require.Error(err)
+				return
+			}
+			assert.NoError(err)
+
+			err = rw.Create(context.Background(), got)
+			if tt.wantCreateErr {
+				require.Error(err)
+				return
+			}
+			assert.NoError(err)
+
+			got2 := allocTlsClientCertificateCredential()
+			got2.PublicId = id
+			require.NoError(rw.LookupById(ctx, got2))
+
+			err = got2.decrypt(ctx, databaseWrapper)
+			require.NoError(err)
+
+			// Timestamps and version are automatically set
+			tt.want.CreateTime = got2.CreateTime
+			tt.want.UpdateTime = got2.UpdateTime
+			tt.want.Version = got2.Version
+
+			// KeyId is allocated via kms no need to validate in this test
+			tt.want.KeyId = got2.KeyId
+			got2.PrivateKeyEncrypted = nil
+
+			// encrypt also calculates the hmac, validate it is correct
+			hm, err := crypto.HmacSha256(ctx, got.PrivateKey, databaseWrapper, []byte(got.StoreId), nil)
+			require.NoError(err)
+			tt.want.PrivateKeyHmac = []byte(hm)
+
+			assert.Empty(cmp.Diff(tt.want, got2.clone(), protocmp.Transform()))
+		})
+	}
+}
+
+func TestNewTlsClientCertificateCredential_Validation(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	cert, key, ca := TestTlsClientCertificate(t)
+	_, otherKey, _ := TestTlsClientCertificate(t)
+
+	tests := []struct {
+		name        string
+		certificate []byte
+		privateKey  credential.PrivateKey
+		options     []Option
+		wantErr     bool
+	}{
+		{name: "valid", certificate: cert, privateKey: key, options: []Option{WithCaCertificate(ca)}},
+		{name: "bad-certificate", certificate: []byte("foobar"), privateKey: key, wantErr: true},
+		{name: "bad-private-key", certificate: cert, privateKey: []byte("foobar"), wantErr: true},
+		{name: "mismatched-key-pair", certificate: cert, privateKey: otherKey, wantErr: true},
+		{name: "bad-ca-certificate", certificate: cert, privateKey: key, options: []Option{WithCaCertificate([]byte("foobar"))}, wantErr: true},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			assert, require := assert.New(t), require.New(t)
+			got, err := NewTlsClientCertificateCredential(ctx, "csst_1234567890", tt.certificate, tt.privateKey, tt.options...)
+			if tt.wantErr {
+				assert.Truef(errors.Match(errors.T(errors.InvalidParameter), err), "want err code: %q got: %q", errors.InvalidParameter, err)
+				assert.Nil(got)
+				return
+			}
+			require.NoError(err)
+			assert.Equal(tt.certificate, got.GetCertificate())
+			assert.Equal([]byte(tt.privateKey), got.GetPrivateKey())
+			assert.Equal(ca, got.GetCaCertificate())
+		})
+	}
+}
@@ -0,0 +1,28 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package credential
+
+import (
+	"github.com/hashicorp/boundary/internal/db/timestamp"
+)
+
+// Usage records that a credential was issued to a session. Usage records
+// are written by the database when a credential is bound to a session and
+// are retained after the session, credential, user and target have been
+// deleted. A Usage never contains the secret itself.
+type Usage struct {
+	SessionId           string  `gorm:"primary_key"`
+	CredentialId        string  `gorm:"primary_key"`
+	CredentialPurpose   Purpose `gorm:"primary_key"`
+	CredentialLibraryId string
+	UserId              string
+	TargetId            string
+	ProjectId           string
+	CreateTime          *timestamp.Timestamp
+}
+
+// TableName returns the table name for gorm.
+func (*Usage) TableName() string {
+	return "credential_usage"
+}
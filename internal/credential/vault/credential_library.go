@@ -154,6 +154,10 @@ type listCredentialLibraryResult struct {
 	CriticalOptions           string
 	Extensions                string
 	AdditionalValidPrincipals string
+	MountPath                 string
+	Role                      string
+	CommonName                string
+	AltNames                  string
 	CreateTime                *timestamp.Timestamp
 	UpdateTime                *timestamp.Timestamp
 	Version                   int
@@ -206,6 +210,38 @@ func (l *listCredentialLibraryResult) toLibrary(ctx context.Context) (credential
 				AdditionalValidPrincipals: l.AdditionalValidPrincipals,
 			},
 		}, nil
+	case "database":
+		return &DatabaseCredentialLibrary{
+			DatabaseCredentialLibrary: &store.DatabaseCredentialLibrary{
+				PublicId:       l.PublicId,
+				StoreId:        l.StoreId,
+				Name:           l.Name,
+				Description:    l.Description,
+				CreateTime:     l.CreateTime,
+				UpdateTime:     l.UpdateTime,
+				Version:        uint32(l.Version),
+				MountPath:      l.MountPath,
+				Role:           l.Role,
+				CredentialType: l.CredentialType,
+			},
+		}, nil
+	case "pki":
+		return &PkiCredentialLibrary{
+			PkiCredentialLibrary: &store.PkiCredentialLibrary{
+				PublicId:       l.PublicId,
+				StoreId:        l.StoreId,
+				Name:           l.Name,
+				Description:    l.Description,
+				CreateTime:     l.CreateTime,
+				UpdateTime:     l.UpdateTime,
+				Version:        uint32(l.Version),
+				MountPath:      l.MountPath,
+				Role:           l.Role,
+				CommonName:     l.CommonName,
+				AltNames:       l.AltNames,
+				CredentialType: l.CredentialType,
+			},
+		}, nil
 	default:
 		return nil, errors.New(ctx, errors.Internal, op, fmt.Sprintf("unexpected vault credential library type %s returned", l.Type))
 	}
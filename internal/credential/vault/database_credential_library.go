@@ -0,0 +1,131 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package vault
+
+import (
+	"path"
+	"strings"
+
+	"github.com/hashicorp/boundary/globals"
+	"github.com/hashicorp/boundary/internal/credential"
+	"github.com/hashicorp/boundary/internal/credential/vault/store"
+	"github.com/hashicorp/boundary/internal/db/timestamp"
+	"github.com/hashicorp/boundary/internal/oplog"
+	"github.com/hashicorp/boundary/internal/types/resource"
+	"google.golang.org/protobuf/proto"
+)
+
+// databaseCredsSegment is the path segment the Vault database secrets
+// engine uses for generating dynamic credentials for a role.
+// See: https://developer.hashicorp.com/vault/api-docs/secret/databases#generate-credentials
+const databaseCredsSegment = "creds"
+
+// DatabaseCredentialsPath returns the Vault path used to generate
+// credentials for role from the database secrets engine mounted at
+// mountPath.
+func DatabaseCredentialsPath(mountPath, role string) string {
+	return path.Join(strings.Trim(mountPath, "/"), databaseCredsSegment, role)
+}
+
+// DatabaseCredentialLibrary is a credential library that issues
+// username_password credentials for a role using the vault database
+// secrets engine. Credentials issued by the library are bound to a Vault
+// lease which is renewed while the session is active and revoked when the
+// session ends.
+// See: https://developer.hashicorp.com/vault/api-docs/secret/databases#generate-credentials
+type DatabaseCredentialLibrary struct {
+	*store.DatabaseCredentialLibrary
+	tableName string `gorm:"-"`
+}
+
+// NewDatabaseCredentialLibrary creates a new in memory
+// DatabaseCredentialLibrary assigned to storeId for the database secrets
+// engine mounted at mountPath in Vault. Name and description are the only
+// valid options. All other options are ignored.
+func NewDatabaseCredentialLibrary(storeId string, mountPath string, role string, opt ...Option) (*DatabaseCredentialLibrary, error) {
+	opts := getOpts(opt...)
+
+	l := &DatabaseCredentialLibrary{
+		DatabaseCredentialLibrary: &store.DatabaseCredentialLibrary{
+			StoreId:        storeId,
+			Name:           opts.withName,
+			Description:    opts.withDescription,
+			MountPath:      mountPath,
+			Role:           role,
+			CredentialType: string(globals.UsernamePasswordCredentialType),
+		},
+	}
+
+	return l, nil
+}
+
+func allocDatabaseCredentialLibrary() *DatabaseCredentialLibrary {
+	return &DatabaseCredentialLibrary{
+		DatabaseCredentialLibrary: &store.DatabaseCredentialLibrary{},
+	}
+}
+
+func (l *DatabaseCredentialLibrary) clone() *DatabaseCredentialLibrary {
+	cp := proto.Clone(l.DatabaseCredentialLibrary)
+	return &DatabaseCredentialLibrary{
+		DatabaseCredentialLibrary: cp.(*store.DatabaseCredentialLibrary),
+	}
+}
+
+func (l *DatabaseCredentialLibrary) setId(i string) {
+	l.PublicId = i
+}
+
+// TableName returns the table name.
+func (l *DatabaseCredentialLibrary) TableName() string {
+	if l.tableName != "" {
+		return l.tableName
+	}
+	return "credential_vault_database_library"
+}
+
+// SetTableName sets the table name.
+func (l *DatabaseCredentialLibrary) SetTableName(n string) {
+	l.tableName = n
+}
+
+// GetResourceType returns the resource type of the CredentialLibrary
+func (l *DatabaseCredentialLibrary) GetResourceType() resource.Type {
+	return resource.CredentialLibrary
+}
+
+func (l *DatabaseCredentialLibrary) oplog(op oplog.OpType) oplog.Metadata {
+	metadata := oplog.Metadata{
+		"resource-public-id": []string{l.PublicId},
+		"resource-type":      []string{"credential-vault-database-library"},
+		"op-type":            []string{op.String()},
+	}
+	if l.StoreId != "" {
+		metadata["store-id"] = []string{l.StoreId}
+	}
+	return metadata
+}
+
+// CredentialType returns the type of credential the library retrieves.
+func (l *DatabaseCredentialLibrary) CredentialType() globals.CredentialType {
+	return globals.CredentialType(l.DatabaseCredentialLibrary.CredentialType)
+}
+
+// CredentialsPath returns the Vault path the library requests
+// credentials from.
+func (l *DatabaseCredentialLibrary) CredentialsPath() string {
+	return DatabaseCredentialsPath(l.GetMountPath(), l.GetRole())
+}
+
+var _ credential.Library = (*DatabaseCredentialLibrary)(nil)
+
+type deletedDatabaseCredentialLibrary struct {
+	PublicId   string `gorm:"primary_key"`
+	DeleteTime *timestamp.Timestamp
+}
+
+// TableName returns the tablename to override the default gorm table name
+func (s *deletedDatabaseCredentialLibrary) TableName() string {
+	return "credential_vault_database_library_deleted"
+}
@@ -0,0 +1,50 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package vault
+
+import (
+	"testing"
+
+	"github.com/hashicorp/boundary/globals"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDatabaseCredentialsPath(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		mountPath string
+		role      string
+		want      string
+	}{
+		{mountPath: "database", role: "readonly", want: "database/creds/readonly"},
+		{mountPath: "/database/", role: "readonly", want: "database/creds/readonly"},
+		{mountPath: "team/postgres", role: "dba", want: "team/postgres/creds/dba"},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.want, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, tt.want, DatabaseCredentialsPath(tt.mountPath, tt.role))
+		})
+	}
+}
+
+func TestNewDatabaseCredentialLibrary(t *testing.T) {
+	t.Parallel()
+	assert, require := assert.New(t), require.New(t)
+
+	got, err := NewDatabaseCredentialLibrary("csvlt_1234567890", "team/postgres", "dba",
+		WithName("name"), WithDescription("desc"), WithMethod(MethodPost))
+	require.NoError(err)
+	require.NotNil(got)
+	assert.Equal("csvlt_1234567890", got.GetStoreId())
+	assert.Equal("name", got.GetName())
+	assert.Equal("desc", got.GetDescription())
+	assert.Equal("team/postgres", got.GetMountPath())
+	assert.Equal("dba", got.GetRole())
+	assert.Equal(globals.UsernamePasswordCredentialType, got.CredentialType())
+	assert.Equal("team/postgres/creds/dba", got.CredentialsPath())
+	assert.Empty(got.GetPublicId())
+}
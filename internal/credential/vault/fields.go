@@ -27,6 +27,11 @@ const (
 	// principal update has been requested.
 	AdditionalValidPrincipalsField = "AdditionalValidPrincipals"
 
+	mountPathField  = "MountPath"
+	roleField       = "Role"
+	commonNameField = "CommonName"
+	altNamesField   = "AltNames"
+
 	certificateField    = "Certificate"
 	certificateKeyField = "CertificateKey"
 	vaultAddressField   = "VaultAddress"
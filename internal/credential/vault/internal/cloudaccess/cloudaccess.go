@@ -0,0 +1,268 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package cloudaccess
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"time"
+)
+
+// The cloud providers a cloud access credential can be issued for.
+const (
+	ProviderAws   = "aws"
+	ProviderGcp   = "gcp"
+	ProviderAzure = "azure"
+)
+
+type (
+	data map[string]any
+
+	// extractFunc attempts to extract the cloud access values from sd
+	// using a known Vault data response format.
+	extractFunc func(sd data) Values
+)
+
+// Values contains the cloud access values extracted from a Vault secret.
+// Which values are set depends on Provider.
+type Values struct {
+	Provider string
+
+	// AWS
+	AccessKeyId     string
+	SecretAccessKey string
+	SessionToken    string
+
+	// GCP
+	AccessToken       string
+	ServiceAccountKey string
+
+	// Azure
+	ClientId       string
+	ClientSecret   string
+	TenantId       string
+	SubscriptionId string
+
+	Expiration time.Time
+}
+
+// valid reports whether v contains the values required to access the API
+// of its provider.
+func (v Values) valid() bool {
+	switch v.Provider {
+	case ProviderAws:
+		return v.AccessKeyId != "" && v.SecretAccessKey != ""
+	case ProviderGcp:
+		return v.AccessToken != "" || v.ServiceAccountKey != ""
+	case ProviderAzure:
+		return v.ClientId != "" && v.ClientSecret != ""
+	}
+	return false
+}
+
+// attributeNames are the names of the attributes that may hold the AWS
+// cloud access values, in the order they are checked. The first set matches
+// the response of the Vault AWS secrets engine, the second matches the names
+// of the environment variables used by the AWS CLI and SDKs in lower case.
+var attributeNames = []struct {
+	accessKeyId, secretAccessKey, sessionToken string
+}{
+	{accessKeyId: "access_key", secretAccessKey: "secret_key", sessionToken: "security_token"},
+	{accessKeyId: "access_key_id", secretAccessKey: "secret_access_key", sessionToken: "session_token"},
+}
+
+const (
+	// expirationAttribute is the name of the optional attribute holding the
+	// RFC 3339 formatted expiration time of the cloud access keys.
+	expirationAttribute = "expiration"
+
+	// The attributes returned by the Vault GCP secrets engine for an OAuth2
+	// access token and a service account key. The service account key is
+	// the base64 encoded JSON key file.
+	// See: https://developer.hashicorp.com/vault/api-docs/secret/gcp#generate-secret-iam-service-account-creds-oauth2-access-token
+	gcpTokenAttribute          = "token"
+	gcpExpiresAtAttribute      = "expires_at_seconds"
+	gcpPrivateKeyDataAttribute = "private_key_data"
+
+	// The attributes used to store GCP credentials in a KV secret.
+	gcpAccessTokenAttribute       = "access_token"
+	gcpServiceAccountKeyAttribute = "service_account_key"
+
+	// The attributes returned by the Vault Azure secrets engine. The tenant
+	// and subscription ids are not returned by the secrets engine but may be
+	// stored alongside the client id and secret in a KV secret.
+	// See: https://developer.hashicorp.com/vault/api-docs/secret/azure#generate-credentials
+	azureClientIdAttribute       = "client_id"
+	azureClientSecretAttribute   = "client_secret"
+	azureTenantIdAttribute       = "tenant_id"
+	azureSubscriptionIdAttribute = "subscription_id"
+)
+
+// Extract attempts to extract the cloud access values stored within the
+// provided data. The data is matched against the AWS, GCP and Azure formats
+// in that order.
+//
+// Extract does not return partial results, i.e. if the access key id was
+// extracted but not the secret access key an empty Values is returned.
+func Extract(d data) Values {
+	for _, f := range []extractFunc{
+		defaultExtract,
+		kv2Extract,
+	} {
+		if v := f(d); v.valid() {
+			return v
+		}
+	}
+
+	return Values{}
+}
+
+// defaultExtract looks for the known attribute names in the data map.
+func defaultExtract(sd data) Values {
+	if sd == nil {
+		// nothing to do return early
+		return Values{}
+	}
+
+	for _, f := range []extractFunc{
+		awsExtract,
+		gcpExtract,
+		azureExtract,
+	} {
+		if v := f(sd); v.valid() {
+			return v
+		}
+	}
+	return Values{}
+}
+
+// awsExtract looks for AWS access keys in the data map.
+func awsExtract(sd data) Values {
+	for _, n := range attributeNames {
+		id, _ := sd[n.accessKeyId].(string)
+		secret, _ := sd[n.secretAccessKey].(string)
+		if id == "" || secret == "" {
+			continue
+		}
+		v := Values{
+			Provider:        ProviderAws,
+			AccessKeyId:     id,
+			SecretAccessKey: secret,
+		}
+		v.SessionToken, _ = sd[n.sessionToken].(string)
+		if e, ok := sd[expirationAttribute].(string); ok {
+			if t, err := time.Parse(time.RFC3339, e); err == nil {
+				v.Expiration = t
+			}
+		}
+		return v
+	}
+	return Values{}
+}
+
+// gcpExtract looks for a GCP OAuth2 access token or service account key in
+// the data map.
+func gcpExtract(sd data) Values {
+	v := Values{Provider: ProviderGcp}
+	if v.AccessToken, _ = sd[gcpTokenAttribute].(string); v.AccessToken == "" {
+		v.AccessToken, _ = sd[gcpAccessTokenAttribute].(string)
+	}
+	if v.AccessToken != "" {
+		if secs, ok := toInt64(sd[gcpExpiresAtAttribute]); ok && secs > 0 {
+			v.Expiration = time.Unix(secs, 0).UTC()
+		}
+	}
+
+	if k, _ := sd[gcpPrivateKeyDataAttribute].(string); k != "" {
+		if dk, err := base64.StdEncoding.DecodeString(k); err == nil && json.Valid(dk) {
+			v.ServiceAccountKey = string(dk)
+		}
+	}
+	if v.ServiceAccountKey == "" {
+		switch k := sd[gcpServiceAccountKeyAttribute].(type) {
+		case string:
+			if json.Valid([]byte(k)) {
+				v.ServiceAccountKey = k
+			}
+		case map[string]any:
+			if b, err := json.Marshal(k); err == nil {
+				v.ServiceAccountKey = string(b)
+			}
+		}
+	}
+	return v
+}
+
+// azureExtract looks for an Azure service principal in the data map.
+func azureExtract(sd data) Values {
+	v := Values{Provider: ProviderAzure}
+	v.ClientId, _ = sd[azureClientIdAttribute].(string)
+	v.ClientSecret, _ = sd[azureClientSecretAttribute].(string)
+	v.TenantId, _ = sd[azureTenantIdAttribute].(string)
+	v.SubscriptionId, _ = sd[azureSubscriptionIdAttribute].(string)
+	return v
+}
+
+// toInt64 converts a numeric value decoded from a Vault response to an
+// int64.
+func toInt64(n any) (int64, bool) {
+	switch n := n.(type) {
+	case json.Number:
+		i, err := n.Int64()
+		return i, err == nil
+	case float64:
+		return int64(n), true
+	case int64:
+		return n, true
+	case int:
+		return int64(n), true
+	}
+	return 0, false
+}
+
+// kv2Extract looks for the known attribute names in the embedded 'data'
+// field within the data map.
+//
+// Additionally it validates the data is in the expected KV-v2 format:
+//
+//	{
+//		"data": {},
+//		"metadata: {}
+//	}
+//
+// If the format does not match, it returns an empty Values. See:
+// https://www.vaultproject.io/api/secret/kv/kv-v2#sample-response-1
+func kv2Extract(sd data) Values {
+	if sd == nil {
+		// nothing to do return early
+		return Values{}
+	}
+
+	var data, metadata map[string]any
+	for k, v := range sd {
+		switch k {
+		case "data":
+			var ok bool
+			if data, ok = v.(map[string]any); !ok {
+				// data field should be of type map[string]any in KV-v2
+				return Values{}
+			}
+		case "metadata":
+			var ok bool
+			if metadata, ok = v.(map[string]any); !ok {
+				// metadata field should be of type map[string]any in KV-v2
+				return Values{}
+			}
+		default:
+			// secretData contains a non valid KV-v2 top level field
+			return Values{}
+		}
+	}
+	if data == nil || metadata == nil {
+		// missing required KV-v2 field
+		return Values{}
+	}
+
+	return defaultExtract(data)
+}
@@ -0,0 +1,224 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package cloudaccess
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExtract(t *testing.T) {
+	t.Parallel()
+
+	exp := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name  string
+		given data
+		want  Values
+	}{
+		{
+			name: "nil-input",
+			want: Values{},
+		},
+		{
+			name:  "no-secret",
+			given: data{},
+			want:  Values{},
+		},
+		{
+			name: "missing-secret-key",
+			given: data{
+				"access_key": "AKIA",
+			},
+			want: Values{},
+		},
+		{
+			name: "aws-secrets-engine-iam-user",
+			given: data{
+				"access_key":     "AKIA",
+				"secret_key":     "secret",
+				"security_token": nil,
+			},
+			want: Values{
+				Provider:        ProviderAws,
+				AccessKeyId:     "AKIA",
+				SecretAccessKey: "secret",
+			},
+		},
+		{
+			name: "aws-secrets-engine-sts",
+			given: data{
+				"access_key":     "ASIA",
+				"secret_key":     "secret",
+				"security_token": "token",
+			},
+			want: Values{
+				Provider:        ProviderAws,
+				AccessKeyId:     "ASIA",
+				SecretAccessKey: "secret",
+				SessionToken:    "token",
+			},
+		},
+		{
+			name: "environment-style-names",
+			given: data{
+				"access_key_id":     "ASIA",
+				"secret_access_key": "secret",
+				"session_token":     "token",
+				"expiration":        exp.Format(time.RFC3339),
+			},
+			want: Values{
+				Provider:        ProviderAws,
+				AccessKeyId:     "ASIA",
+				SecretAccessKey: "secret",
+				SessionToken:    "token",
+				Expiration:      exp,
+			},
+		},
+		{
+			name: "invalid-expiration-ignored",
+			given: data{
+				"access_key_id":     "ASIA",
+				"secret_access_key": "secret",
+				"expiration":        "tomorrow",
+			},
+			want: Values{
+				Provider:        ProviderAws,
+				AccessKeyId:     "ASIA",
+				SecretAccessKey: "secret",
+			},
+		},
+		{
+			name: "kv2",
+			given: data{
+				"metadata": map[string]any{},
+				"data": map[string]any{
+					"access_key_id":     "AKIA",
+					"secret_access_key": "secret",
+				},
+			},
+			want: Values{
+				Provider:        ProviderAws,
+				AccessKeyId:     "AKIA",
+				SecretAccessKey: "secret",
+			},
+		},
+		{
+			name: "kv2-missing-metadata",
+			given: data{
+				"data": map[string]any{
+					"access_key_id":     "AKIA",
+					"secret_access_key": "secret",
+				},
+			},
+			want: Values{},
+		},
+		{
+			name: "kv2-invalid-top-level-field",
+			given: data{
+				"metadata": map[string]any{},
+				"extra":    "field",
+				"data": map[string]any{
+					"access_key_id":     "AKIA",
+					"secret_access_key": "secret",
+				},
+			},
+			want: Values{},
+		},
+		{
+			name: "gcp-secrets-engine-access-token",
+			given: data{
+				"token":              "ya29.token",
+				"token_ttl":          json.Number("3599"),
+				"expires_at_seconds": json.Number(strconv.FormatInt(exp.Unix(), 10)),
+			},
+			want: Values{
+				Provider:    ProviderGcp,
+				AccessToken: "ya29.token",
+				Expiration:  exp,
+			},
+		},
+		{
+			name: "gcp-secrets-engine-service-account-key",
+			given: data{
+				"private_key_data": base64.StdEncoding.EncodeToString([]byte(`{"type":"service_account"}`)),
+				"key_algorithm":    "KEY_ALG_RSA_2048",
+				"key_type":         "TYPE_GOOGLE_CREDENTIALS_FILE",
+			},
+			want: Values{
+				Provider:          ProviderGcp,
+				ServiceAccountKey: `{"type":"service_account"}`,
+			},
+		},
+		{
+			name: "gcp-invalid-private-key-data",
+			given: data{
+				"private_key_data": "not base64 json",
+			},
+			want: Values{},
+		},
+		{
+			name: "gcp-kv-service-account-key-object",
+			given: data{
+				"metadata": map[string]any{},
+				"data": map[string]any{
+					"service_account_key": map[string]any{"type": "service_account"},
+				},
+			},
+			want: Values{
+				Provider:          ProviderGcp,
+				ServiceAccountKey: `{"type":"service_account"}`,
+			},
+		},
+		{
+			name: "azure-secrets-engine",
+			given: data{
+				"client_id":     "client",
+				"client_secret": "secret",
+			},
+			want: Values{
+				Provider:     ProviderAzure,
+				ClientId:     "client",
+				ClientSecret: "secret",
+			},
+		},
+		{
+			name: "azure-kv-with-tenant-and-subscription",
+			given: data{
+				"metadata": map[string]any{},
+				"data": map[string]any{
+					"client_id":       "client",
+					"client_secret":   "secret",
+					"tenant_id":       "tenant",
+					"subscription_id": "subscription",
+				},
+			},
+			want: Values{
+				Provider:       ProviderAzure,
+				ClientId:       "client",
+				ClientSecret:   "secret",
+				TenantId:       "tenant",
+				SubscriptionId: "subscription",
+			},
+		},
+		{
+			name: "azure-missing-client-secret",
+			given: data{
+				"client_id": "client",
+				"tenant_id": "tenant",
+			},
+			want: Values{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, Extract(tt.given))
+		})
+	}
+}
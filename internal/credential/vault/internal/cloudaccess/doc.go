@@ -0,0 +1,6 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+// Package cloudaccess provides access to the cloud access keys stored in a
+// Vault secret.
+package cloudaccess
@@ -0,0 +1,6 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+// Package tlsclientcert provides access to the X.509 client certificate,
+// private key and CA certificates stored in a Vault secret.
+package tlsclientcert
@@ -0,0 +1,149 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package tlsclientcert
+
+import (
+	"strings"
+)
+
+type (
+	data map[string]any
+
+	// extractFunc attempts to extract the client certificate values from sd
+	// using a known Vault data response format.
+	extractFunc func(sd data) Values
+)
+
+// Values contains the PEM encoded client certificate values extracted from
+// a Vault secret.
+type Values struct {
+	Certificate   []byte
+	PrivateKey    []byte
+	CaCertificate []byte
+}
+
+const (
+	certificateAttribute   = "certificate"
+	privateKeyAttribute    = "private_key"
+	caCertificateAttribute = "ca_certificate"
+
+	// caChainAttribute and issuingCaAttribute are the names of the CA
+	// attributes in the response of the Vault PKI secrets engine issue
+	// endpoint. See:
+	// https://developer.hashicorp.com/vault/api-docs/secret/pki#generate-certificate-and-key
+	caChainAttribute   = "ca_chain"
+	issuingCaAttribute = "issuing_ca"
+)
+
+// Extract attempts to extract the client certificate values stored within
+// the provided data.
+//
+// Extract does not return partial results, i.e. if the certificate was
+// extracted but not the private key an empty Values is returned. The CA
+// certificate is optional.
+func Extract(d data) Values {
+	for _, f := range []extractFunc{
+		defaultExtract,
+		kv2Extract,
+	} {
+		v := f(d)
+		if len(v.Certificate) > 0 && len(v.PrivateKey) > 0 {
+			// got valid certificate and private key from secret
+			return v
+		}
+	}
+
+	return Values{}
+}
+
+// defaultExtract looks for the known attribute names in the data map. The
+// CA certificate is taken from the ca_certificate attribute if present,
+// otherwise from the ca_chain or issuing_ca attributes returned by the
+// Vault PKI secrets engine.
+func defaultExtract(sd data) Values {
+	if sd == nil {
+		// nothing to do return early
+		return Values{}
+	}
+
+	cert, _ := sd[certificateAttribute].(string)
+	key, _ := sd[privateKeyAttribute].(string)
+	if cert == "" || key == "" {
+		return Values{}
+	}
+
+	v := Values{
+		Certificate: []byte(cert),
+		PrivateKey:  []byte(key),
+	}
+	if ca := caCertificate(sd); ca != "" {
+		v.CaCertificate = []byte(ca)
+	}
+	return v
+}
+
+func caCertificate(sd data) string {
+	if ca, ok := sd[caCertificateAttribute].(string); ok && ca != "" {
+		return ca
+	}
+	if chain, ok := sd[caChainAttribute].([]any); ok {
+		var certs []string
+		for _, c := range chain {
+			if s, ok := c.(string); ok && s != "" {
+				certs = append(certs, strings.TrimSpace(s))
+			}
+		}
+		if len(certs) > 0 {
+			return strings.Join(certs, "\n") + "\n"
+		}
+	}
+	ca, _ := sd[issuingCaAttribute].(string)
+	return ca
+}
+
+// kv2Extract looks for the known attribute names in the embedded 'data'
+// field within the data map.
+//
+// Additionally it validates the data is in the expected KV-v2 format:
+//
+//	{
+//		"data": {},
+//		"metadata: {}
+//	}
+//
+// If the format does not match, it returns an empty Values. See:
+// https://www.vaultproject.io/api/secret/kv/kv-v2#sample-response-1
+func kv2Extract(sd data) Values {
+	if sd == nil {
+		// nothing to do return early
+		return Values{}
+	}
+
+	var data, metadata map[string]any
+	for k, v := range sd {
+		switch k {
+		case "data":
+			var ok bool
+			if data, ok = v.(map[string]any); !ok {
+				// data field should be of type map[string]any in KV-v2
+				return Values{}
+			}
+		case "metadata":
+			var ok bool
+			if metadata, ok = v.(map[string]any); !ok {
+				// metadata field should be of type map[string]any in KV-v2
+				return Values{}
+			}
+		default:
+			// secretData contains a non valid KV-v2 top level field
+			return Values{}
+		}
+	}
+	if data == nil || metadata == nil {
+		// missing required KV-v2 field
+		return Values{}
+	}
+
+	return defaultExtract(data)
+}
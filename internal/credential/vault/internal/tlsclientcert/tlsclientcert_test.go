@@ -0,0 +1,130 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package tlsclientcert
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExtract(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name  string
+		given data
+		want  Values
+	}{
+		{
+			name: "nil-input",
+			want: Values{},
+		},
+		{
+			name:  "no-secret",
+			given: data{},
+			want:  Values{},
+		},
+		{
+			name: "missing-private-key",
+			given: data{
+				"certificate": "cert",
+			},
+			want: Values{},
+		},
+		{
+			name: "invalid-certificate-type",
+			given: data{
+				"certificate": 1,
+				"private_key": "key",
+			},
+			want: Values{},
+		},
+		{
+			name: "pki-issue-response",
+			given: data{
+				"certificate":      "cert",
+				"private_key":      "key",
+				"private_key_type": "ec",
+				"issuing_ca":       "intermediate",
+				"ca_chain":         []any{"intermediate\n", "root"},
+				"serial_number":    "39:dd",
+				"expiration":       1654105687,
+			},
+			want: Values{
+				Certificate:   []byte("cert"),
+				PrivateKey:    []byte("key"),
+				CaCertificate: []byte("intermediate\nroot\n"),
+			},
+		},
+		{
+			name: "pki-issue-response-no-chain",
+			given: data{
+				"certificate": "cert",
+				"private_key": "key",
+				"issuing_ca":  "root",
+			},
+			want: Values{
+				Certificate:   []byte("cert"),
+				PrivateKey:    []byte("key"),
+				CaCertificate: []byte("root"),
+			},
+		},
+		{
+			name: "kv-ca-certificate",
+			given: data{
+				"certificate":    "cert",
+				"private_key":    "key",
+				"ca_certificate": "ca",
+				"issuing_ca":     "ignored",
+			},
+			want: Values{
+				Certificate:   []byte("cert"),
+				PrivateKey:    []byte("key"),
+				CaCertificate: []byte("ca"),
+			},
+		},
+		{
+			name: "kv2",
+			given: data{
+				"metadata": map[string]any{},
+				"data": map[string]any{
+					"certificate": "cert",
+					"private_key": "key",
+				},
+			},
+			want: Values{
+				Certificate: []byte("cert"),
+				PrivateKey:  []byte("key"),
+			},
+		},
+		{
+			name: "kv2-invalid-data",
+			given: data{
+				"metadata": map[string]any{},
+				"data":     "invalid",
+			},
+			want: Values{},
+		},
+		{
+			name: "kv2-extra-field",
+			given: data{
+				"metadata": map[string]any{},
+				"data": map[string]any{
+					"certificate": "cert",
+					"private_key": "key",
+				},
+				"extra": "field",
+			},
+			want: Values{},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, tt.want, Extract(tt.given))
+		})
+	}
+}
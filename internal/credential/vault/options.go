@@ -45,6 +45,8 @@ type options struct {
 	withCriticalOptions           string
 	withExtensions                string
 	withAdditionalValidPrincipals []string
+
+	withAltNames []string
 }
 
 func getDefaultOptions() options {
@@ -238,3 +240,11 @@ func WithAdditionalValidPrincipals(p []string) Option {
 		o.withAdditionalValidPrincipals = p
 	}
 }
+
+// WithAltNames provides an optional list of subject alternative names to
+// request for certificates issued by a pki credential library.
+func WithAltNames(n []string) Option {
+	return func(o *options) {
+		o.withAltNames = n
+	}
+}
@@ -131,4 +131,10 @@ func Test_GetOpts(t *testing.T) {
 		testOpts.withMappingOverride = unknownMapper(1)
 		assert.Equal(t, opts, testOpts)
 	})
+	t.Run("WithAltNames", func(t *testing.T) {
+		opts := getOpts(WithAltNames([]string{"a.example.com", "b.example.com"}))
+		testOpts := getDefaultOptions()
+		testOpts.withAltNames = []string{"a.example.com", "b.example.com"}
+		assert.Equal(t, opts, testOpts)
+	})
 }
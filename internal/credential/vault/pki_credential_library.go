@@ -0,0 +1,133 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package vault
+
+import (
+	"path"
+	"strings"
+
+	"github.com/hashicorp/boundary/globals"
+	"github.com/hashicorp/boundary/internal/credential"
+	"github.com/hashicorp/boundary/internal/credential/vault/store"
+	"github.com/hashicorp/boundary/internal/db/timestamp"
+	"github.com/hashicorp/boundary/internal/oplog"
+	"github.com/hashicorp/boundary/internal/types/resource"
+	"google.golang.org/protobuf/proto"
+)
+
+// pkiIssueSegment is the path segment the Vault pki secrets engine uses
+// for issuing a new certificate and private key for a role.
+// See: https://developer.hashicorp.com/vault/api-docs/secret/pki#generate-certificate-and-key
+const pkiIssueSegment = "issue"
+
+// PkiIssuePath returns the Vault path used to issue certificates for role
+// from the pki secrets engine mounted at mountPath.
+func PkiIssuePath(mountPath, role string) string {
+	return path.Join(strings.Trim(mountPath, "/"), pkiIssueSegment, role)
+}
+
+// PkiCredentialLibrary is a credential library that issues
+// tls_client_certificate credentials for a role using the vault pki secrets
+// engine. Every request issues a new certificate and private key for
+// CommonName and, if set, AltNames. CommonName and AltNames may contain
+// templates which are rendered for each session. If the role generates
+// leases, the certificate is revoked when the session ends.
+// See: https://developer.hashicorp.com/vault/api-docs/secret/pki#generate-certificate-and-key
+type PkiCredentialLibrary struct {
+	*store.PkiCredentialLibrary
+	tableName string `gorm:"-"`
+}
+
+// NewPkiCredentialLibrary creates a new in memory PkiCredentialLibrary
+// assigned to storeId for the pki secrets engine mounted at mountPath in
+// Vault. Certificates are requested for commonName. Name, description and
+// alt names are the only valid options. All other options are ignored.
+func NewPkiCredentialLibrary(storeId string, mountPath string, role string, commonName string, opt ...Option) (*PkiCredentialLibrary, error) {
+	opts := getOpts(opt...)
+
+	l := &PkiCredentialLibrary{
+		PkiCredentialLibrary: &store.PkiCredentialLibrary{
+			StoreId:        storeId,
+			Name:           opts.withName,
+			Description:    opts.withDescription,
+			MountPath:      mountPath,
+			Role:           role,
+			CommonName:     commonName,
+			AltNames:       strings.Join(opts.withAltNames, ","),
+			CredentialType: string(globals.TlsClientCertificateCredentialType),
+		},
+	}
+
+	return l, nil
+}
+
+func allocPkiCredentialLibrary() *PkiCredentialLibrary {
+	return &PkiCredentialLibrary{
+		PkiCredentialLibrary: &store.PkiCredentialLibrary{},
+	}
+}
+
+func (l *PkiCredentialLibrary) clone() *PkiCredentialLibrary {
+	cp := proto.Clone(l.PkiCredentialLibrary)
+	return &PkiCredentialLibrary{
+		PkiCredentialLibrary: cp.(*store.PkiCredentialLibrary),
+	}
+}
+
+func (l *PkiCredentialLibrary) setId(i string) {
+	l.PublicId = i
+}
+
+// TableName returns the table name.
+func (l *PkiCredentialLibrary) TableName() string {
+	if l.tableName != "" {
+		return l.tableName
+	}
+	return "credential_vault_pki_library"
+}
+
+// SetTableName sets the table name.
+func (l *PkiCredentialLibrary) SetTableName(n string) {
+	l.tableName = n
+}
+
+// GetResourceType returns the resource type of the CredentialLibrary
+func (l *PkiCredentialLibrary) GetResourceType() resource.Type {
+	return resource.CredentialLibrary
+}
+
+func (l *PkiCredentialLibrary) oplog(op oplog.OpType) oplog.Metadata {
+	metadata := oplog.Metadata{
+		"resource-public-id": []string{l.PublicId},
+		"resource-type":      []string{"credential-vault-pki-library"},
+		"op-type":            []string{op.String()},
+	}
+	if l.StoreId != "" {
+		metadata["store-id"] = []string{l.StoreId}
+	}
+	return metadata
+}
+
+// CredentialType returns the type of credential the library retrieves.
+func (l *PkiCredentialLibrary) CredentialType() globals.CredentialType {
+	return globals.CredentialType(l.PkiCredentialLibrary.CredentialType)
+}
+
+// IssuePath returns the Vault path the library requests certificates
+// from.
+func (l *PkiCredentialLibrary) IssuePath() string {
+	return PkiIssuePath(l.GetMountPath(), l.GetRole())
+}
+
+var _ credential.Library = (*PkiCredentialLibrary)(nil)
+
+type deletedPkiCredentialLibrary struct {
+	PublicId   string `gorm:"primary_key"`
+	DeleteTime *timestamp.Timestamp
+}
+
+// TableName returns the tablename to override the default gorm table name
+func (s *deletedPkiCredentialLibrary) TableName() string {
+	return "credential_vault_pki_library_deleted"
+}
@@ -0,0 +1,52 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package vault
+
+import (
+	"testing"
+
+	"github.com/hashicorp/boundary/globals"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPkiIssuePath(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		mountPath string
+		role      string
+		want      string
+	}{
+		{mountPath: "pki", role: "client", want: "pki/issue/client"},
+		{mountPath: "/pki/", role: "client", want: "pki/issue/client"},
+		{mountPath: "team/pki_int", role: "dba", want: "team/pki_int/issue/dba"},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.want, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, tt.want, PkiIssuePath(tt.mountPath, tt.role))
+		})
+	}
+}
+
+func TestNewPkiCredentialLibrary(t *testing.T) {
+	t.Parallel()
+	assert, require := assert.New(t), require.New(t)
+
+	got, err := NewPkiCredentialLibrary("csvlt_1234567890", "team/pki_int", "client", "client.example.com",
+		WithName("name"), WithDescription("desc"), WithAltNames([]string{"a.example.com", "b.example.com"}), WithMethod(MethodGet))
+	require.NoError(err)
+	require.NotNil(got)
+	assert.Equal("csvlt_1234567890", got.GetStoreId())
+	assert.Equal("name", got.GetName())
+	assert.Equal("desc", got.GetDescription())
+	assert.Equal("team/pki_int", got.GetMountPath())
+	assert.Equal("client", got.GetRole())
+	assert.Equal("client.example.com", got.GetCommonName())
+	assert.Equal("a.example.com,b.example.com", got.GetAltNames())
+	assert.Equal(globals.TlsClientCertificateCredentialType, got.CredentialType())
+	assert.Equal("team/pki_int/issue/client", got.IssuePath())
+	assert.Empty(got.GetPublicId())
+}
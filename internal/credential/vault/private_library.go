@@ -22,7 +22,9 @@ import (
 
 	"github.com/hashicorp/boundary/globals"
 	"github.com/hashicorp/boundary/internal/credential"
+	"github.com/hashicorp/boundary/internal/credential/vault/internal/cloudaccess"
 	"github.com/hashicorp/boundary/internal/credential/vault/internal/sshprivatekey"
+	"github.com/hashicorp/boundary/internal/credential/vault/internal/tlsclientcert"
 	"github.com/hashicorp/boundary/internal/credential/vault/internal/usernamepassword"
 	"github.com/hashicorp/boundary/internal/db/sentinel"
 	"github.com/hashicorp/boundary/internal/db/timestamp"
@@ -38,7 +40,10 @@ import (
 	"google.golang.org/protobuf/proto"
 )
 
-var _ credential.Dynamic = (*baseCred)(nil)
+var (
+	_ credential.Dynamic = (*baseCred)(nil)
+	_ credential.Leased  = (*baseCred)(nil)
+)
 
 type baseCred struct {
 	*Credential
@@ -54,6 +59,19 @@ func (bc *baseCred) getExpiration() time.Duration  { return bc.expiration }
 func (bc *baseCred) getCredential() *Credential    { return bc.Credential }
 func (bc *baseCred) isRevokable() bool             { return bc.ExternalId != sentinel.ExternalIdNone }
 
+// Lease returns the Vault lease attached to the credential. The returned
+// Lease has an empty Id if Vault did not return a lease id.
+func (bc *baseCred) Lease() credential.Lease {
+	l := credential.Lease{
+		Duration:  bc.expiration,
+		Renewable: bc.IsRenewable,
+	}
+	if bc.isRevokable() {
+		l.Id = bc.ExternalId
+	}
+	return l
+}
+
 // convert converts bc to a specific credential type if bc is not
 // UnspecifiedType.
 func convert(ctx context.Context, bc *baseCred) (dynamicCred, error) {
@@ -62,6 +80,10 @@ func convert(ctx context.Context, bc *baseCred) (dynamicCred, error) {
 		return baseToUsrPass(ctx, bc)
 	case globals.SshPrivateKeyCredentialType:
 		return baseToSshPriKey(ctx, bc)
+	case globals.CloudAccessCredentialType:
+		return baseToCloudAccess(ctx, bc)
+	case globals.TlsClientCertificateCredentialType:
+		return baseToTlsClientCert(ctx, bc)
 	}
 	return bc, nil
 }
@@ -162,6 +184,109 @@ func baseToSshPriKey(ctx context.Context, bc *baseCred) (*sshPrivateKeyCred, err
 	}, nil
 }
 
+var _ credential.CloudAccess = (*cloudAccessCred)(nil)
+
+type cloudAccessCred struct {
+	*baseCred
+	provider          string
+	accessKeyId       string
+	secretAccessKey   credential.Password
+	sessionToken      string
+	accessToken       credential.Password
+	serviceAccountKey credential.Password
+	clientId          string
+	clientSecret      credential.Password
+	tenantId          string
+	subscriptionId    string
+	expiration        time.Time
+}
+
+func (c *cloudAccessCred) Provider() string                       { return c.provider }
+func (c *cloudAccessCred) AccessKeyId() string                    { return c.accessKeyId }
+func (c *cloudAccessCred) SecretAccessKey() credential.Password   { return c.secretAccessKey }
+func (c *cloudAccessCred) SessionToken() string                   { return c.sessionToken }
+func (c *cloudAccessCred) AccessToken() credential.Password       { return c.accessToken }
+func (c *cloudAccessCred) ServiceAccountKey() credential.Password { return c.serviceAccountKey }
+func (c *cloudAccessCred) ClientId() string                       { return c.clientId }
+func (c *cloudAccessCred) ClientSecret() credential.Password      { return c.clientSecret }
+func (c *cloudAccessCred) TenantId() string                       { return c.tenantId }
+func (c *cloudAccessCred) SubscriptionId() string                 { return c.subscriptionId }
+func (c *cloudAccessCred) Expiration() time.Time                  { return c.expiration }
+
+func baseToCloudAccess(ctx context.Context, bc *baseCred) (*cloudAccessCred, error) {
+	switch {
+	case bc == nil:
+		return nil, errors.E(ctx, errors.WithCode(errors.InvalidParameter), errors.WithMsg("nil baseCred"))
+	case bc.lib == nil:
+		return nil, errors.E(ctx, errors.WithCode(errors.InvalidParameter), errors.WithMsg("nil baseCred.lib"))
+	case bc.Library().CredentialType() != globals.CloudAccessCredentialType:
+		return nil, errors.E(ctx, errors.WithCode(errors.InvalidParameter), errors.WithMsg("invalid credential type"))
+	}
+
+	v := cloudaccess.Extract(bc.secretData)
+	if v.Provider == "" {
+		return nil, errors.E(ctx, errors.WithCode(errors.VaultInvalidCredentialMapping))
+	}
+
+	// Credentials issued by a Vault secrets engine expire with their lease
+	// unless the secret contains an explicit expiration time.
+	expiration := v.Expiration
+	if expiration.IsZero() && bc.isRevokable() && bc.expiration > 0 {
+		expiration = time.Now().Add(bc.expiration).Truncate(time.Second)
+	}
+
+	return &cloudAccessCred{
+		baseCred:          bc,
+		provider:          v.Provider,
+		accessKeyId:       v.AccessKeyId,
+		secretAccessKey:   credential.Password(v.SecretAccessKey),
+		sessionToken:      v.SessionToken,
+		accessToken:       credential.Password(v.AccessToken),
+		serviceAccountKey: credential.Password(v.ServiceAccountKey),
+		clientId:          v.ClientId,
+		clientSecret:      credential.Password(v.ClientSecret),
+		tenantId:          v.TenantId,
+		subscriptionId:    v.SubscriptionId,
+		expiration:        expiration,
+	}, nil
+}
+
+var _ credential.TlsClientCertificate = (*tlsClientCertCred)(nil)
+
+type tlsClientCertCred struct {
+	*baseCred
+	certificate   []byte
+	privateKey    credential.PrivateKey
+	caCertificate []byte
+}
+
+func (c *tlsClientCertCred) Certificate() []byte               { return c.certificate }
+func (c *tlsClientCertCred) PrivateKey() credential.PrivateKey { return c.privateKey }
+func (c *tlsClientCertCred) CaCertificate() []byte             { return c.caCertificate }
+
+func baseToTlsClientCert(ctx context.Context, bc *baseCred) (*tlsClientCertCred, error) {
+	switch {
+	case bc == nil:
+		return nil, errors.E(ctx, errors.WithCode(errors.InvalidParameter), errors.WithMsg("nil baseCred"))
+	case bc.lib == nil:
+		return nil, errors.E(ctx, errors.WithCode(errors.InvalidParameter), errors.WithMsg("nil baseCred.lib"))
+	case bc.Library().CredentialType() != globals.TlsClientCertificateCredentialType:
+		return nil, errors.E(ctx, errors.WithCode(errors.InvalidParameter), errors.WithMsg("invalid credential type"))
+	}
+
+	v := tlsclientcert.Extract(bc.secretData)
+	if len(v.Certificate) == 0 || len(v.PrivateKey) == 0 {
+		return nil, errors.E(ctx, errors.WithCode(errors.VaultInvalidCredentialMapping))
+	}
+
+	return &tlsClientCertCred{
+		baseCred:      bc,
+		certificate:   v.Certificate,
+		privateKey:    v.PrivateKey,
+		caCertificate: v.CaCertificate,
+	}, nil
+}
+
 type sshCertCred struct {
 	*sshPrivateKeyCred
 	certificate []byte
@@ -5,13 +5,18 @@ package vault
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"strconv"
 	"testing"
 	"time"
 
 	"github.com/hashicorp/boundary/globals"
 	"github.com/hashicorp/boundary/internal/credential"
+	"github.com/hashicorp/boundary/internal/credential/vault/internal/cloudaccess"
+	"github.com/hashicorp/boundary/internal/credential/vault/store"
 	"github.com/hashicorp/boundary/internal/db"
+	"github.com/hashicorp/boundary/internal/db/sentinel"
 	"github.com/hashicorp/boundary/internal/errors"
 	"github.com/hashicorp/boundary/internal/iam"
 	"github.com/hashicorp/boundary/internal/kms"
@@ -1227,6 +1232,246 @@ func TestBaseToSshPriKey(t *testing.T) {
 	}
 }
 
+func TestBaseToCloudAccess(t *testing.T) {
+	t.Parallel()
+
+	exp := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name    string
+		given   *baseCred
+		want    *cloudAccessCred
+		wantErr errors.Code
+	}{
+		{
+			name:    "nil-input",
+			wantErr: errors.InvalidParameter,
+		},
+		{
+			name:    "nil-library",
+			given:   &baseCred{},
+			wantErr: errors.InvalidParameter,
+		},
+		{
+			name: "library-not-cloud-access-type",
+			given: &baseCred{
+				lib: &genericIssuingCredentialLibrary{
+					CredType: string(globals.UsernamePasswordCredentialType),
+				},
+			},
+			wantErr: errors.InvalidParameter,
+		},
+		{
+			name: "invalid-no-secret-key",
+			given: &baseCred{
+				Credential: &Credential{Credential: &store.Credential{ExternalId: sentinel.ExternalIdNone}},
+				lib: &genericIssuingCredentialLibrary{
+					CredType: string(globals.CloudAccessCredentialType),
+				},
+				secretData: map[string]any{
+					"access_key": "AKIA",
+				},
+			},
+			wantErr: errors.VaultInvalidCredentialMapping,
+		},
+		{
+			name: "valid-static-keys",
+			given: &baseCred{
+				Credential: &Credential{Credential: &store.Credential{ExternalId: sentinel.ExternalIdNone}},
+				lib: &genericIssuingCredentialLibrary{
+					CredType: string(globals.CloudAccessCredentialType),
+				},
+				secretData: map[string]any{
+					"access_key_id":     "AKIA",
+					"secret_access_key": "secret",
+				},
+			},
+			want: &cloudAccessCred{
+				provider:        cloudaccess.ProviderAws,
+				accessKeyId:     "AKIA",
+				secretAccessKey: credential.Password("secret"),
+			},
+		},
+		{
+			name: "valid-explicit-expiration",
+			given: &baseCred{
+				Credential: &Credential{
+					Credential: &store.Credential{ExternalId: "aws/creds/role/123"},
+					expiration: time.Hour,
+				},
+				lib: &genericIssuingCredentialLibrary{
+					CredType: string(globals.CloudAccessCredentialType),
+				},
+				secretData: map[string]any{
+					"access_key":     "ASIA",
+					"secret_key":     "secret",
+					"security_token": "token",
+					"expiration":     exp.Format(time.RFC3339),
+				},
+			},
+			want: &cloudAccessCred{
+				provider:        cloudaccess.ProviderAws,
+				accessKeyId:     "ASIA",
+				secretAccessKey: credential.Password("secret"),
+				sessionToken:    "token",
+				expiration:      exp,
+			},
+		},
+		{
+			name: "valid-gcp-access-token",
+			given: &baseCred{
+				Credential: &Credential{Credential: &store.Credential{ExternalId: sentinel.ExternalIdNone}},
+				lib: &genericIssuingCredentialLibrary{
+					CredType: string(globals.CloudAccessCredentialType),
+				},
+				secretData: map[string]any{
+					"token":              "ya29.token",
+					"expires_at_seconds": json.Number(strconv.FormatInt(exp.Unix(), 10)),
+				},
+			},
+			want: &cloudAccessCred{
+				provider:    cloudaccess.ProviderGcp,
+				accessToken: credential.Password("ya29.token"),
+				expiration:  exp,
+			},
+		},
+		{
+			name: "valid-azure-service-principal",
+			given: &baseCred{
+				Credential: &Credential{Credential: &store.Credential{ExternalId: sentinel.ExternalIdNone}},
+				lib: &genericIssuingCredentialLibrary{
+					CredType: string(globals.CloudAccessCredentialType),
+				},
+				secretData: map[string]any{
+					"client_id":       "client",
+					"client_secret":   "secret",
+					"tenant_id":       "tenant",
+					"subscription_id": "subscription",
+				},
+			},
+			want: &cloudAccessCred{
+				provider:       cloudaccess.ProviderAzure,
+				clientId:       "client",
+				clientSecret:   credential.Password("secret"),
+				tenantId:       "tenant",
+				subscriptionId: "subscription",
+			},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			assert, require := assert.New(t), require.New(t)
+			got, err := baseToCloudAccess(context.Background(), tt.given)
+			if tt.wantErr != 0 {
+				assert.Truef(errors.Match(errors.T(tt.wantErr), err), "want err: %q got: %q", tt.wantErr, err)
+				assert.Nil(got)
+				return
+			}
+			require.NoError(err)
+			want := tt.want
+			want.baseCred = tt.given
+			assert.Equal(want, got)
+		})
+	}
+
+	t.Run("lease-expiration", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		bc := &baseCred{
+			Credential: &Credential{
+				Credential: &store.Credential{ExternalId: "aws/creds/role/123"},
+				expiration: time.Hour,
+			},
+			lib: &genericIssuingCredentialLibrary{
+				CredType: string(globals.CloudAccessCredentialType),
+			},
+			secretData: map[string]any{
+				"access_key": "ASIA",
+				"secret_key": "secret",
+			},
+		}
+		before := time.Now().Truncate(time.Second)
+		got, err := baseToCloudAccess(context.Background(), bc)
+		require.NoError(err)
+		assert.WithinRange(got.Expiration(), before.Add(time.Hour), time.Now().Add(time.Hour))
+	})
+}
+
+func TestBaseToTlsClientCert(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		given   *baseCred
+		want    *tlsClientCertCred
+		wantErr errors.Code
+	}{
+		{
+			name:    "nil-input",
+			wantErr: errors.InvalidParameter,
+		},
+		{
+			name:    "nil-library",
+			given:   &baseCred{},
+			wantErr: errors.InvalidParameter,
+		},
+		{
+			name: "library-not-tls-client-certificate-type",
+			given: &baseCred{
+				lib: &genericIssuingCredentialLibrary{
+					CredType: string(globals.UsernamePasswordCredentialType),
+				},
+			},
+			wantErr: errors.InvalidParameter,
+		},
+		{
+			name: "invalid-no-private-key",
+			given: &baseCred{
+				lib: &genericIssuingCredentialLibrary{
+					CredType: string(globals.TlsClientCertificateCredentialType),
+				},
+				secretData: map[string]any{
+					"certificate": "cert",
+				},
+			},
+			wantErr: errors.VaultInvalidCredentialMapping,
+		},
+		{
+			name: "valid-pki-response",
+			given: &baseCred{
+				lib: &genericIssuingCredentialLibrary{
+					CredType: string(globals.TlsClientCertificateCredentialType),
+				},
+				secretData: map[string]any{
+					"certificate": "cert",
+					"private_key": "key",
+					"issuing_ca":  "ca",
+				},
+			},
+			want: &tlsClientCertCred{
+				certificate:   []byte("cert"),
+				privateKey:    credential.PrivateKey("key"),
+				caCertificate: []byte("ca"),
+			},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			assert, require := assert.New(t), require.New(t)
+			got, err := baseToTlsClientCert(context.Background(), tt.given)
+			if tt.wantErr != 0 {
+				assert.Truef(errors.Match(errors.T(tt.wantErr), err), "want err: %q got: %q", tt.wantErr, err)
+				assert.Nil(got)
+				return
+			}
+			require.NoError(err)
+			want := tt.want
+			want.baseCred = tt.given
+			assert.Equal(want, got)
+		})
+	}
+}
+
 func TestRepository_sshCertIssuingCredentialLibrary_retrieveCredential(t *testing.T) {
 	t.Parallel()
 
@@ -18,6 +18,8 @@ func init() {
 	globals.RegisterPrefixToResourceInfo(globals.VaultDynamicCredentialPrefix, resource.Credential, credential.Domain, Subtype)
 	globals.RegisterPrefixToResourceInfo(globals.VaultCredentialLibraryPrefix, resource.CredentialLibrary, credential.Domain, GenericLibrarySubtype)
 	globals.RegisterPrefixToResourceInfo(globals.VaultSshCertificateCredentialLibraryPrefix, resource.CredentialLibrary, credential.Domain, SSHCertificateLibrarySubtype)
+	globals.RegisterPrefixToResourceInfo(globals.VaultDatabaseCredentialLibraryPrefix, resource.CredentialLibrary, credential.Domain, DatabaseLibrarySubtype)
+	globals.RegisterPrefixToResourceInfo(globals.VaultPkiCredentialLibraryPrefix, resource.CredentialLibrary, credential.Domain, PkiLibrarySubtype)
 }
 
 // PublicId prefixes for the resources in the vault package.
@@ -25,6 +27,8 @@ const (
 	Subtype                      = globals.Subtype("vault")
 	GenericLibrarySubtype        = globals.Subtype("vault-generic")
 	SSHCertificateLibrarySubtype = globals.Subtype("vault-ssh-certificate")
+	DatabaseLibrarySubtype       = globals.Subtype("vault-database")
+	PkiLibrarySubtype            = globals.Subtype("vault-pki")
 )
 
 func newCredentialStoreId(ctx context.Context) (string, error) {
@@ -58,3 +62,19 @@ func newSSHCertificateCredentialLibraryId(ctx context.Context) (string, error) {
 	}
 	return id, nil
 }
+
+func newDatabaseCredentialLibraryId(ctx context.Context) (string, error) {
+	id, err := db.NewPublicId(ctx, globals.VaultDatabaseCredentialLibraryPrefix)
+	if err != nil {
+		return "", errors.Wrap(ctx, err, "vault.newDatabaseCredentialLibraryId")
+	}
+	return id, nil
+}
+
+func newPkiCredentialLibraryId(ctx context.Context) (string, error) {
+	id, err := db.NewPublicId(ctx, globals.VaultPkiCredentialLibraryPrefix)
+	if err != nil {
+		return "", errors.Wrap(ctx, err, "vault.newPkiCredentialLibraryId")
+	}
+	return id, nil
+}
@@ -195,7 +195,9 @@ select sum(reltuples::bigint) as estimate
   from pg_class
  where oid in (
   'credential_vault_library'::regclass,
-  'credential_vault_ssh_cert_library'::regclass
+  'credential_vault_ssh_cert_library'::regclass,
+  'credential_vault_database_library'::regclass,
+  'credential_vault_pki_library'::regclass
 )
 `
 
@@ -217,6 +219,16 @@ ssh_cert_libs as (
     from credential_vault_ssh_cert_library
    where public_id in (select public_id from libraries)
 ),
+database_libs as (
+  select *
+    from credential_vault_database_library
+   where public_id in (select public_id from libraries)
+),
+pki_libs as (
+  select *
+    from credential_vault_pki_library
+   where public_id in (select public_id from libraries)
+),
 final as (
   select public_id,
          store_id,
@@ -238,6 +250,10 @@ final as (
          null as critical_options,             -- Add to make union uniform
          null as extensions,                   -- Add to make union uniform
          null as additional_valid_principals,  -- Add to make union uniform
+         null as mount_path,                   -- Add to make union uniform
+         null as role,                         -- Add to make union uniform
+         null as common_name,                  -- Add to make union uniform
+         null as alt_names,                    -- Add to make union uniform
          'generic' as type
     from generic_libs
    union
@@ -261,8 +277,66 @@ final as (
          critical_options,
          extensions,
          additional_valid_principals,
+         null as mount_path,        -- Add to make union uniform
+         null as role,              -- Add to make union uniform
+         null as common_name,       -- Add to make union uniform
+         null as alt_names,         -- Add to make union uniform
          'ssh' as type
     from ssh_cert_libs
+   union
+  select public_id,
+         store_id,
+         project_id,
+         name,
+         description,
+         create_time,
+         update_time,
+         version,
+         null as vault_path,                   -- Add to make union uniform
+         credential_type,
+         null as http_method,                  -- Add to make union uniform
+         null as http_request_body,            -- Add to make union uniform
+         null as username,                     -- Add to make union uniform
+         null as key_type,                     -- Add to make union uniform
+         null as key_bits,                     -- Add to make union uniform
+         null as ttl,                          -- Add to make union uniform
+         null as key_id,                       -- Add to make union uniform
+         null as critical_options,             -- Add to make union uniform
+         null as extensions,                   -- Add to make union uniform
+         null as additional_valid_principals,  -- Add to make union uniform
+         mount_path,
+         role,
+         null as common_name,                  -- Add to make union uniform
+         null as alt_names,                    -- Add to make union uniform
+         'database' as type
+    from database_libs
+   union
+  select public_id,
+         store_id,
+         project_id,
+         name,
+         description,
+         create_time,
+         update_time,
+         version,
+         null as vault_path,                   -- Add to make union uniform
+         credential_type,
+         null as http_method,                  -- Add to make union uniform
+         null as http_request_body,            -- Add to make union uniform
+         null as username,                     -- Add to make union uniform
+         null as key_type,                     -- Add to make union uniform
+         null as key_bits,                     -- Add to make union uniform
+         null as ttl,                          -- Add to make union uniform
+         null as key_id,                       -- Add to make union uniform
+         null as critical_options,             -- Add to make union uniform
+         null as extensions,                   -- Add to make union uniform
+         null as additional_valid_principals,  -- Add to make union uniform
+         mount_path,
+         role,
+         common_name,
+         alt_names,
+         'pki' as type
+    from pki_libs
 )
   select *
     from final
@@ -288,6 +362,16 @@ ssh_cert_libs as (
     from credential_vault_ssh_cert_library
    where public_id in (select public_id from libraries)
 ),
+database_libs as (
+  select *
+    from credential_vault_database_library
+   where public_id in (select public_id from libraries)
+),
+pki_libs as (
+  select *
+    from credential_vault_pki_library
+   where public_id in (select public_id from libraries)
+),
 final as (
   select public_id,
          store_id,
@@ -309,6 +393,10 @@ final as (
          null as critical_options,             -- Add to make union uniform
          null as extensions,                   -- Add to make union uniform
          null as additional_valid_principals,  -- Add to make union uniform
+         null as mount_path,                   -- Add to make union uniform
+         null as role,                         -- Add to make union uniform
+         null as common_name,                  -- Add to make union uniform
+         null as alt_names,                    -- Add to make union uniform
          'generic' as type
     from generic_libs
    union
@@ -332,8 +420,66 @@ final as (
          critical_options,
          extensions,
          additional_valid_principals,
+         null as mount_path,        -- Add to make union uniform
+         null as role,              -- Add to make union uniform
+         null as common_name,       -- Add to make union uniform
+         null as alt_names,         -- Add to make union uniform
          'ssh' as type
     from ssh_cert_libs
+   union
+  select public_id,
+         store_id,
+         project_id,
+         name,
+         description,
+         create_time,
+         update_time,
+         version,
+         null as vault_path,                   -- Add to make union uniform
+         credential_type,
+         null as http_method,                  -- Add to make union uniform
+         null as http_request_body,            -- Add to make union uniform
+         null as username,                     -- Add to make union uniform
+         null as key_type,                     -- Add to make union uniform
+         null as key_bits,                     -- Add to make union uniform
+         null as ttl,                          -- Add to make union uniform
+         null as key_id,                       -- Add to make union uniform
+         null as critical_options,             -- Add to make union uniform
+         null as extensions,                   -- Add to make union uniform
+         null as additional_valid_principals,  -- Add to make union uniform
+         mount_path,
+         role,
+         null as common_name,                  -- Add to make union uniform
+         null as alt_names,                    -- Add to make union uniform
+         'database' as type
+    from database_libs
+   union
+  select public_id,
+         store_id,
+         project_id,
+         name,
+         description,
+         create_time,
+         update_time,
+         version,
+         null as vault_path,                   -- Add to make union uniform
+         credential_type,
+         null as http_method,                  -- Add to make union uniform
+         null as http_request_body,            -- Add to make union uniform
+         null as username,                     -- Add to make union uniform
+         null as key_type,                     -- Add to make union uniform
+         null as key_bits,                     -- Add to make union uniform
+         null as ttl,                          -- Add to make union uniform
+         null as key_id,                       -- Add to make union uniform
+         null as critical_options,             -- Add to make union uniform
+         null as extensions,                   -- Add to make union uniform
+         null as additional_valid_principals,  -- Add to make union uniform
+         mount_path,
+         role,
+         common_name,
+         alt_names,
+         'pki' as type
+    from pki_libs
 )
   select *
     from final
@@ -359,6 +505,16 @@ ssh_cert_libs as (
     from credential_vault_ssh_cert_library
    where public_id in (select public_id from libraries)
 ),
+database_libs as (
+  select *
+    from credential_vault_database_library
+   where public_id in (select public_id from libraries)
+),
+pki_libs as (
+  select *
+    from credential_vault_pki_library
+   where public_id in (select public_id from libraries)
+),
 final as (
   select public_id,
          store_id,
@@ -380,6 +536,10 @@ final as (
          null as critical_options,             -- Add to make union uniform
          null as extensions,                   -- Add to make union uniform
          null as additional_valid_principals,  -- Add to make union uniform
+         null as mount_path,                   -- Add to make union uniform
+         null as role,                         -- Add to make union uniform
+         null as common_name,                  -- Add to make union uniform
+         null as alt_names,                    -- Add to make union uniform
          'generic' as type
     from generic_libs
    union
@@ -403,8 +563,66 @@ final as (
          critical_options,
          extensions,
          additional_valid_principals,
+         null as mount_path,        -- Add to make union uniform
+         null as role,              -- Add to make union uniform
+         null as common_name,       -- Add to make union uniform
+         null as alt_names,         -- Add to make union uniform
          'ssh' as type
     from ssh_cert_libs
+   union
+  select public_id,
+         store_id,
+         project_id,
+         name,
+         description,
+         create_time,
+         update_time,
+         version,
+         null as vault_path,                   -- Add to make union uniform
+         credential_type,
+         null as http_method,                  -- Add to make union uniform
+         null as http_request_body,            -- Add to make union uniform
+         null as username,                     -- Add to make union uniform
+         null as key_type,                     -- Add to make union uniform
+         null as key_bits,                     -- Add to make union uniform
+         null as ttl,                          -- Add to make union uniform
+         null as key_id,                       -- Add to make union uniform
+         null as critical_options,             -- Add to make union uniform
+         null as extensions,                   -- Add to make union uniform
+         null as additional_valid_principals,  -- Add to make union uniform
+         mount_path,
+         role,
+         null as common_name,                  -- Add to make union uniform
+         null as alt_names,                    -- Add to make union uniform
+         'database' as type
+    from database_libs
+   union
+  select public_id,
+         store_id,
+         project_id,
+         name,
+         description,
+         create_time,
+         update_time,
+         version,
+         null as vault_path,                   -- Add to make union uniform
+         credential_type,
+         null as http_method,                  -- Add to make union uniform
+         null as http_request_body,            -- Add to make union uniform
+         null as username,                     -- Add to make union uniform
+         null as key_type,                     -- Add to make union uniform
+         null as key_bits,                     -- Add to make union uniform
+         null as ttl,                          -- Add to make union uniform
+         null as key_id,                       -- Add to make union uniform
+         null as critical_options,             -- Add to make union uniform
+         null as extensions,                   -- Add to make union uniform
+         null as additional_valid_principals,  -- Add to make union uniform
+         mount_path,
+         role,
+         common_name,
+         alt_names,
+         'pki' as type
+    from pki_libs
 )
   select *
     from final
@@ -431,6 +649,16 @@ ssh_cert_libs as (
     from credential_vault_ssh_cert_library
    where public_id in (select public_id from libraries)
 ),
+database_libs as (
+  select *
+    from credential_vault_database_library
+   where public_id in (select public_id from libraries)
+),
+pki_libs as (
+  select *
+    from credential_vault_pki_library
+   where public_id in (select public_id from libraries)
+),
 final as (
   select public_id,
          store_id,
@@ -452,6 +680,10 @@ final as (
          null as critical_options,             -- Add to make union uniform
          null as extensions,                   -- Add to make union uniform
          null as additional_valid_principals,  -- Add to make union uniform
+         null as mount_path,                   -- Add to make union uniform
+         null as role,                         -- Add to make union uniform
+         null as common_name,                  -- Add to make union uniform
+         null as alt_names,                    -- Add to make union uniform
          'generic' as type
     from generic_libs
    union
@@ -475,8 +707,66 @@ final as (
          critical_options,
          extensions,
          additional_valid_principals,
+         null as mount_path,        -- Add to make union uniform
+         null as role,              -- Add to make union uniform
+         null as common_name,       -- Add to make union uniform
+         null as alt_names,         -- Add to make union uniform
          'ssh' as type
     from ssh_cert_libs
+   union
+  select public_id,
+         store_id,
+         project_id,
+         name,
+         description,
+         create_time,
+         update_time,
+         version,
+         null as vault_path,                   -- Add to make union uniform
+         credential_type,
+         null as http_method,                  -- Add to make union uniform
+         null as http_request_body,            -- Add to make union uniform
+         null as username,                     -- Add to make union uniform
+         null as key_type,                     -- Add to make union uniform
+         null as key_bits,                     -- Add to make union uniform
+         null as ttl,                          -- Add to make union uniform
+         null as key_id,                       -- Add to make union uniform
+         null as critical_options,             -- Add to make union uniform
+         null as extensions,                   -- Add to make union uniform
+         null as additional_valid_principals,  -- Add to make union uniform
+         mount_path,
+         role,
+         null as common_name,                  -- Add to make union uniform
+         null as alt_names,                    -- Add to make union uniform
+         'database' as type
+    from database_libs
+   union
+  select public_id,
+         store_id,
+         project_id,
+         name,
+         description,
+         create_time,
+         update_time,
+         version,
+         null as vault_path,                   -- Add to make union uniform
+         credential_type,
+         null as http_method,                  -- Add to make union uniform
+         null as http_request_body,            -- Add to make union uniform
+         null as username,                     -- Add to make union uniform
+         null as key_type,                     -- Add to make union uniform
+         null as key_bits,                     -- Add to make union uniform
+         null as ttl,                          -- Add to make union uniform
+         null as key_id,                       -- Add to make union uniform
+         null as critical_options,             -- Add to make union uniform
+         null as extensions,                   -- Add to make union uniform
+         null as additional_valid_principals,  -- Add to make union uniform
+         mount_path,
+         role,
+         common_name,
+         alt_names,
+         'pki' as type
+    from pki_libs
 )
   select *
     from final
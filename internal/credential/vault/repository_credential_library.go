@@ -621,6 +621,20 @@ func (r *Repository) ListDeletedLibraryIds(ctx context.Context, since time.Time)
 		for _, cl := range deletedSshCredentialLibraries {
 			credentialLibraryIds = append(credentialLibraryIds, cl.PublicId)
 		}
+		var deletedDatabaseCredentialLibraries []*deletedDatabaseCredentialLibrary
+		if err := r.SearchWhere(ctx, &deletedDatabaseCredentialLibraries, "delete_time >= ?", []any{since}); err != nil {
+			return errors.Wrap(ctx, err, op, errors.WithMsg("failed to query deleted database credential libraries"))
+		}
+		for _, cl := range deletedDatabaseCredentialLibraries {
+			credentialLibraryIds = append(credentialLibraryIds, cl.PublicId)
+		}
+		var deletedPkiCredentialLibraries []*deletedPkiCredentialLibrary
+		if err := r.SearchWhere(ctx, &deletedPkiCredentialLibraries, "delete_time >= ?", []any{since}); err != nil {
+			return errors.Wrap(ctx, err, op, errors.WithMsg("failed to query deleted pki credential libraries"))
+		}
+		for _, cl := range deletedPkiCredentialLibraries {
+			credentialLibraryIds = append(credentialLibraryIds, cl.PublicId)
+		}
 		var err error
 		transactionTimestamp, err = r.Now(ctx)
 		if err != nil {
@@ -0,0 +1,304 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package vault
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/boundary/globals"
+	"github.com/hashicorp/boundary/internal/db"
+	"github.com/hashicorp/boundary/internal/errors"
+	"github.com/hashicorp/boundary/internal/kms"
+	"github.com/hashicorp/boundary/internal/oplog"
+	"github.com/hashicorp/go-dbw"
+)
+
+// CreatePkiCredentialLibrary inserts l into the repository and returns
+// a new PkiCredentialLibrary containing the credential library's
+// PublicId. l is not changed. l must contain a valid StoreId, MountPath,
+// Role and CommonName. l must not contain a PublicId. The PublicId is
+// generated and assigned by this method.
+//
+// l.Name, l.Description and l.AltNames are optional. If l.Name is set, it
+// must be unique within l.StoreId.
+//
+// Both l.CreateTime and l.UpdateTime are ignored.
+func (r *Repository) CreatePkiCredentialLibrary(ctx context.Context, projectId string, l *PkiCredentialLibrary, _ ...Option) (*PkiCredentialLibrary, error) {
+	const op = "vault.(Repository).CreatePkiCredentialLibrary"
+	if l == nil {
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "nil PkiCredentialLibrary")
+	}
+	if l.PkiCredentialLibrary == nil {
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "nil embedded l")
+	}
+	if l.StoreId == "" {
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "no store id")
+	}
+	if strings.Trim(l.MountPath, "/") == "" {
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "no mount path")
+	}
+	if l.Role == "" {
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "no role")
+	}
+	if strings.Contains(l.Role, "/") {
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "role contains a path separator")
+	}
+	if l.CommonName == "" {
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "no common name")
+	}
+	if l.PublicId != "" {
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "public id not empty")
+	}
+	if projectId == "" {
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "no project id")
+	}
+
+	l = l.clone()
+	l.MountPath = strings.Trim(l.MountPath, "/")
+
+	if l.GetCredentialType() == "" {
+		l.PkiCredentialLibrary.CredentialType = string(globals.TlsClientCertificateCredentialType)
+	}
+	if l.GetCredentialType() != string(globals.TlsClientCertificateCredentialType) {
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "invalid credential type")
+	}
+
+	id, err := newPkiCredentialLibraryId(ctx)
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	l.setId(id)
+
+	oplogWrapper, err := r.kms.GetWrapper(ctx, projectId, kms.KeyPurposeOplog)
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op, errors.WithMsg("unable to get oplog wrapper"))
+	}
+
+	var newPkiCredentialLibrary *PkiCredentialLibrary
+	_, err = r.writer.DoTx(ctx, db.StdRetryCnt, db.ExpBackoff{},
+		func(_ db.Reader, w db.Writer) error {
+			var msgs []*oplog.Message
+			ticket, err := w.GetTicket(ctx, l)
+			if err != nil {
+				return errors.Wrap(ctx, err, op, errors.WithMsg("unable to get ticket"))
+			}
+
+			// insert credential library
+			newPkiCredentialLibrary = l.clone()
+			var lOplogMsg oplog.Message
+			if err := w.Create(ctx, newPkiCredentialLibrary, db.NewOplogMsg(&lOplogMsg)); err != nil {
+				return errors.Wrap(ctx, err, op)
+			}
+			msgs = append(msgs, &lOplogMsg)
+
+			metadata := l.oplog(oplog.OpType_OP_TYPE_CREATE)
+			if err := w.WriteOplogEntryWith(ctx, oplogWrapper, ticket, metadata, msgs); err != nil {
+				return errors.Wrap(ctx, err, op, errors.WithMsg("unable to write oplog"))
+			}
+			return nil
+		},
+	)
+
+	if err != nil {
+		if errors.IsUniqueError(err) {
+			return nil, errors.Wrap(ctx, err, op, errors.WithMsg(fmt.Sprintf("in credential store: %s: name %s already exists", l.StoreId, l.Name)))
+		}
+		return nil, errors.Wrap(ctx, err, op, errors.WithMsg(fmt.Sprintf("in credential store: %s", l.StoreId)))
+	}
+	return newPkiCredentialLibrary, nil
+}
+
+// UpdatePkiCredentialLibrary updates the repository entry for
+// l.PublicId with the values in l for the fields listed in fieldMaskPaths.
+// It returns a new PkiCredentialLibrary containing the updated values
+// and a count of the number of records updated. l is not changed.
+//
+// l must contain a valid PublicId. Name, Description, MountPath, Role,
+// CommonName and AltNames can be updated. If l.Name is set to a non-empty
+// string, it must be unique within l.StoreId. MountPath, Role and
+// CommonName cannot be set to NULL.
+//
+// An attribute of l will be set to NULL in the database if the attribute
+// in l is the zero value and it is included in fieldMaskPaths.
+func (r *Repository) UpdatePkiCredentialLibrary(ctx context.Context, projectId string, l *PkiCredentialLibrary, version uint32, fieldMaskPaths []string, _ ...Option) (*PkiCredentialLibrary, int, error) {
+	const op = "vault.(Repository).UpdatePkiCredentialLibrary"
+	if l == nil {
+		return nil, db.NoRowsAffected, errors.New(ctx, errors.InvalidParameter, op, "missing PkiCredentialLibrary")
+	}
+	if l.PkiCredentialLibrary == nil {
+		return nil, db.NoRowsAffected, errors.New(ctx, errors.InvalidParameter, op, "missing embedded PkiCredentialLibrary")
+	}
+	if l.PublicId == "" {
+		return nil, db.NoRowsAffected, errors.New(ctx, errors.InvalidPublicId, op, "missing public id")
+	}
+	if version == 0 {
+		return nil, db.NoRowsAffected, errors.New(ctx, errors.InvalidParameter, op, "missing version")
+	}
+	if projectId == "" {
+		return nil, db.NoRowsAffected, errors.New(ctx, errors.InvalidParameter, op, "missing project id")
+	}
+	l = l.clone()
+	l.MountPath = strings.Trim(l.MountPath, "/")
+
+	for _, f := range fieldMaskPaths {
+		switch {
+		case strings.EqualFold(nameField, f):
+		case strings.EqualFold(descriptionField, f):
+		case strings.EqualFold(mountPathField, f):
+			if l.MountPath == "" {
+				return nil, db.NoRowsAffected, errors.New(ctx, errors.InvalidParameter, op, "no mount path")
+			}
+		case strings.EqualFold(roleField, f):
+			if l.Role == "" {
+				return nil, db.NoRowsAffected, errors.New(ctx, errors.InvalidParameter, op, "no role")
+			}
+			if strings.Contains(l.Role, "/") {
+				return nil, db.NoRowsAffected, errors.New(ctx, errors.InvalidParameter, op, "role contains a path separator")
+			}
+		case strings.EqualFold(commonNameField, f):
+			if l.CommonName == "" {
+				return nil, db.NoRowsAffected, errors.New(ctx, errors.InvalidParameter, op, "no common name")
+			}
+		case strings.EqualFold(altNamesField, f):
+		default:
+			return nil, db.NoRowsAffected, errors.New(ctx, errors.InvalidFieldMask, op, f)
+		}
+	}
+
+	dbMask, nullFields := dbw.BuildUpdatePaths(
+		map[string]any{
+			nameField:        l.Name,
+			descriptionField: l.Description,
+			mountPathField:   l.MountPath,
+			roleField:        l.Role,
+			commonNameField:  l.CommonName,
+			altNamesField:    l.AltNames,
+		},
+		fieldMaskPaths,
+		nil,
+	)
+	if len(dbMask) == 0 && len(nullFields) == 0 {
+		return nil, db.NoRowsAffected, errors.New(ctx, errors.EmptyFieldMask, op, "missing field mask")
+	}
+
+	oplogWrapper, err := r.kms.GetWrapper(ctx, projectId, kms.KeyPurposeOplog)
+	if err != nil {
+		return nil, db.NoRowsAffected, errors.Wrap(ctx, err, op, errors.WithCode(errors.Encrypt),
+			errors.WithMsg("unable to get oplog wrapper"))
+	}
+
+	var rowsUpdated int
+	var returnedCredentialLibrary *PkiCredentialLibrary
+	_, err = r.writer.DoTx(ctx, db.StdRetryCnt, db.ExpBackoff{},
+		func(rr db.Reader, w db.Writer) error {
+			var msgs []*oplog.Message
+			ticket, err := w.GetTicket(ctx, l)
+			if err != nil {
+				return errors.Wrap(ctx, err, op, errors.WithMsg("unable to get ticket"))
+			}
+
+			l := l.clone()
+			var lOplogMsg oplog.Message
+			rowsUpdated, err = w.Update(ctx, l, dbMask, nullFields, db.NewOplogMsg(&lOplogMsg), db.WithVersion(&version))
+			if err != nil {
+				if errors.IsUniqueError(err) {
+					return errors.New(ctx, errors.NotUnique, op,
+						fmt.Sprintf("name %s already exists: %s", l.Name, l.PublicId))
+				}
+				return errors.Wrap(ctx, err, op, errors.WithMsg("unable to update credential library"))
+			}
+			switch rowsUpdated {
+			case 1:
+			case 0:
+				return nil
+			default:
+				return errors.New(ctx, errors.MultipleRecords, op, fmt.Sprintf("updated credential library and %d rows updated", rowsUpdated))
+			}
+			msgs = append(msgs, &lOplogMsg)
+
+			metadata := l.oplog(oplog.OpType_OP_TYPE_UPDATE)
+			if err := w.WriteOplogEntryWith(ctx, oplogWrapper, ticket, metadata, msgs); err != nil {
+				return errors.Wrap(ctx, err, op, errors.WithMsg("unable to write oplog"))
+			}
+
+			dl := allocPkiCredentialLibrary()
+			dl.PublicId = l.PublicId
+			if err = rr.LookupById(ctx, dl); err != nil {
+				return errors.Wrap(ctx, err, op, errors.WithMsg("unable to retrieve updated credential library"))
+			}
+			returnedCredentialLibrary = dl
+			return nil
+		},
+	)
+
+	if err != nil {
+		if errors.IsUniqueError(err) {
+			return nil, db.NoRowsAffected, errors.New(ctx, errors.NotUnique, op,
+				fmt.Sprintf("name %s already exists: %s", l.Name, l.PublicId))
+		}
+		return nil, db.NoRowsAffected, errors.Wrap(ctx, err, op, errors.WithMsg(l.PublicId))
+	}
+
+	return returnedCredentialLibrary, rowsUpdated, nil
+}
+
+// LookupPkiCredentialLibrary returns the PkiCredentialLibrary for
+// publicId. Returns nil, nil if no PkiCredentialLibrary is found for
+// publicId.
+func (r *Repository) LookupPkiCredentialLibrary(ctx context.Context, publicId string, _ ...Option) (*PkiCredentialLibrary, error) {
+	const op = "vault.(Repository).LookupPkiCredentialLibrary"
+	if publicId == "" {
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "no public id")
+	}
+	l := allocPkiCredentialLibrary()
+	l.PublicId = publicId
+	if err := r.reader.LookupByPublicId(ctx, l); err != nil {
+		if errors.IsNotFoundError(err) {
+			return nil, nil
+		}
+		return nil, errors.Wrap(ctx, err, op, errors.WithMsg(fmt.Sprintf("failed for: %s", publicId)))
+	}
+	return l, nil
+}
+
+// DeletePkiCredentialLibrary deletes publicId from the repository and
+// returns the number of records deleted.
+func (r *Repository) DeletePkiCredentialLibrary(ctx context.Context, projectId string, publicId string, _ ...Option) (int, error) {
+	const op = "vault.(Repository).DeletePkiCredentialLibrary"
+	if publicId == "" {
+		return db.NoRowsAffected, errors.New(ctx, errors.InvalidParameter, op, "no public id")
+	}
+	if projectId == "" {
+		return db.NoRowsAffected, errors.New(ctx, errors.InvalidParameter, op, "no project id")
+	}
+
+	l := allocPkiCredentialLibrary()
+	l.PublicId = publicId
+
+	oplogWrapper, err := r.kms.GetWrapper(ctx, projectId, kms.KeyPurposeOplog)
+	if err != nil {
+		return db.NoRowsAffected, errors.Wrap(ctx, err, op, errors.WithMsg("unable to get oplog wrapper"))
+	}
+
+	var rowsDeleted int
+	_, err = r.writer.DoTx(
+		ctx, db.StdRetryCnt, db.ExpBackoff{},
+		func(_ db.Reader, w db.Writer) (err error) {
+			dl := l.clone()
+			rowsDeleted, err = w.Delete(ctx, dl, db.WithOplog(oplogWrapper, l.oplog(oplog.OpType_OP_TYPE_DELETE)))
+			if err == nil && rowsDeleted > 1 {
+				return errors.New(ctx, errors.MultipleRecords, op, "more than 1 CredentialLibrary would have been deleted")
+			}
+			return err
+		},
+	)
+
+	if err != nil {
+		return db.NoRowsAffected, errors.Wrap(ctx, err, op, errors.WithMsg(fmt.Sprintf("delete failed for %s", l.PublicId)))
+	}
+
+	return rowsDeleted, nil
+}
@@ -0,0 +1,401 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package vault
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/boundary/globals"
+	"github.com/hashicorp/boundary/internal/credential/vault/store"
+	"github.com/hashicorp/boundary/internal/db"
+	"github.com/hashicorp/boundary/internal/errors"
+	"github.com/hashicorp/boundary/internal/iam"
+	"github.com/hashicorp/boundary/internal/kms"
+	"github.com/hashicorp/boundary/internal/oplog"
+	"github.com/hashicorp/boundary/internal/scheduler"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRepository_CreatePkiCredentialLibrary(t *testing.T) {
+	t.Parallel()
+	conn, _ := db.TestSetup(t, "postgres")
+	rw := db.New(conn)
+	wrapper := db.TestWrapper(t)
+
+	_, prj := iam.TestScopes(t, iam.TestRepo(t, conn, wrapper))
+	cs := TestCredentialStores(t, conn, wrapper, prj.GetPublicId(), 1)[0]
+
+	newLib := func(storeId, mountPath, role, commonName string, opt ...Option) *PkiCredentialLibrary {
+		l, _ := NewPkiCredentialLibrary(storeId, mountPath, role, commonName, opt...)
+		return l
+	}
+
+	tests := []struct {
+		name    string
+		in      *PkiCredentialLibrary
+		want    *PkiCredentialLibrary
+		wantErr errors.Code
+	}{
+		{
+			name:    "nil-PkiCredentialLibrary",
+			wantErr: errors.InvalidParameter,
+		},
+		{
+			name:    "nil-embedded-PkiCredentialLibrary",
+			in:      &PkiCredentialLibrary{},
+			wantErr: errors.InvalidParameter,
+		},
+		{
+			name:    "invalid-no-store-id",
+			in:      newLib("", "pki", "client", "client.example.com"),
+			wantErr: errors.InvalidParameter,
+		},
+		{
+			name: "invalid-public-id-set",
+			in: func() *PkiCredentialLibrary {
+				l := newLib(cs.GetPublicId(), "pki", "client", "client.example.com")
+				l.PublicId = "abcd_OOOOOOOOOO"
+				return l
+			}(),
+			wantErr: errors.InvalidParameter,
+		},
+		{
+			name:    "invalid-no-mount-path",
+			in:      newLib(cs.GetPublicId(), "/", "client", "client.example.com"),
+			wantErr: errors.InvalidParameter,
+		},
+		{
+			name:    "invalid-no-role",
+			in:      newLib(cs.GetPublicId(), "pki", "", "client.example.com"),
+			wantErr: errors.InvalidParameter,
+		},
+		{
+			name:    "invalid-role-with-path-separator",
+			in:      newLib(cs.GetPublicId(), "pki", "a/b", "client.example.com"),
+			wantErr: errors.InvalidParameter,
+		},
+		{
+			name:    "invalid-no-common-name",
+			in:      newLib(cs.GetPublicId(), "pki", "client", ""),
+			wantErr: errors.InvalidParameter,
+		},
+		{
+			name: "invalid-credential-type",
+			in: func() *PkiCredentialLibrary {
+				l := newLib(cs.GetPublicId(), "pki", "client", "client.example.com")
+				l.PkiCredentialLibrary.CredentialType = string(globals.SshPrivateKeyCredentialType)
+				return l
+			}(),
+			wantErr: errors.InvalidParameter,
+		},
+		{
+			name: "valid-no-options",
+			in:   newLib(cs.GetPublicId(), "pki", "client", "client.example.com"),
+			want: &PkiCredentialLibrary{
+				PkiCredentialLibrary: &store.PkiCredentialLibrary{
+					StoreId:        cs.GetPublicId(),
+					MountPath:      "pki",
+					Role:           "client",
+					CommonName:     "client.example.com",
+					CredentialType: string(globals.TlsClientCertificateCredentialType),
+				},
+			},
+		},
+		{
+			name: "valid-mount-path-trimmed",
+			in:   newLib(cs.GetPublicId(), "/team/pki/", "client", "{{.User.Name}}.example.com", WithName("test-name"), WithDescription("test-description"), WithAltNames([]string{"a.example.com", "b.example.com"})),
+			want: &PkiCredentialLibrary{
+				PkiCredentialLibrary: &store.PkiCredentialLibrary{
+					StoreId:        cs.GetPublicId(),
+					Name:           "test-name",
+					Description:    "test-description",
+					MountPath:      "team/pki",
+					Role:           "client",
+					CommonName:     "{{.User.Name}}.example.com",
+					AltNames:       "a.example.com,b.example.com",
+					CredentialType: string(globals.TlsClientCertificateCredentialType),
+				},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			assert, require := assert.New(t), require.New(t)
+			ctx := context.Background()
+			kms := kms.TestKms(t, conn, wrapper)
+			sche := scheduler.TestScheduler(t, conn, wrapper)
+			repo, err := NewRepository(ctx, rw, rw, kms, sche)
+			require.NoError(err)
+			require.NotNil(repo)
+
+			got, err := repo.CreatePkiCredentialLibrary(ctx, prj.GetPublicId(), tt.in)
+			if tt.wantErr != 0 {
+				assert.Truef(errors.Match(errors.T(tt.wantErr), err), "want err: %q got: %q", tt.wantErr, err)
+				assert.Nil(got)
+				return
+			}
+			require.NoError(err)
+			assert.Empty(tt.in.PublicId)
+			require.NotNil(got)
+			assertPublicId(t, globals.VaultPkiCredentialLibraryPrefix, got.GetPublicId())
+			assert.NotSame(tt.in, got)
+			assert.Equal(tt.want.Name, got.Name)
+			assert.Equal(tt.want.Description, got.Description)
+			assert.Equal(tt.want.MountPath, got.MountPath)
+			assert.Equal(tt.want.Role, got.Role)
+			assert.Equal(tt.want.CommonName, got.CommonName)
+			assert.Equal(tt.want.AltNames, got.AltNames)
+			assert.Equal(tt.want.CredentialType, got.GetCredentialType())
+			assert.Equal(got.CreateTime, got.UpdateTime)
+			assert.NoError(db.TestVerifyOplog(t, rw, got.GetPublicId(), db.WithOperation(oplog.OpType_OP_TYPE_CREATE), db.WithCreateNotBefore(10*time.Second)))
+		})
+	}
+
+	t.Run("invalid-duplicate-names", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		ctx := context.Background()
+		kms := kms.TestKms(t, conn, wrapper)
+		sche := scheduler.TestScheduler(t, conn, wrapper)
+		repo, err := NewRepository(ctx, rw, rw, kms, sche)
+		require.NoError(err)
+
+		in := newLib(cs.GetPublicId(), "pki", "client", "client.example.com", WithName("duplicate"))
+		got, err := repo.CreatePkiCredentialLibrary(ctx, prj.GetPublicId(), in)
+		require.NoError(err)
+		require.NotNil(got)
+
+		got2, err := repo.CreatePkiCredentialLibrary(ctx, prj.GetPublicId(), in)
+		assert.Truef(errors.Match(errors.T(errors.NotUnique), err), "want err code: %v got err: %v", errors.NotUnique, err)
+		assert.Nil(got2)
+	})
+}
+
+func TestRepository_LookupPkiCredentialLibrary(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	conn, _ := db.TestSetup(t, "postgres")
+	rw := db.New(conn)
+	wrapper := db.TestWrapper(t)
+	kms := kms.TestKms(t, conn, wrapper)
+	sche := scheduler.TestScheduler(t, conn, wrapper)
+
+	_, prj := iam.TestScopes(t, iam.TestRepo(t, conn, wrapper))
+	cs := TestCredentialStores(t, conn, wrapper, prj.GetPublicId(), 1)[0]
+	l := TestPkiCredentialLibraries(t, conn, wrapper, cs.GetPublicId(), 1)[0]
+
+	repo, err := NewRepository(ctx, rw, rw, kms, sche)
+	require.NoError(t, err)
+
+	t.Run("found", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		got, err := repo.LookupPkiCredentialLibrary(ctx, l.GetPublicId())
+		require.NoError(err)
+		require.NotNil(got)
+		assert.Equal(l.GetMountPath(), got.GetMountPath())
+		assert.Equal(l.GetRole(), got.GetRole())
+		assert.Equal(l.GetCommonName(), got.GetCommonName())
+		assert.Equal(globals.TlsClientCertificateCredentialType, got.CredentialType())
+	})
+	t.Run("not-found", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		badId, err := newPkiCredentialLibraryId(ctx)
+		require.NoError(err)
+		got, err := repo.LookupPkiCredentialLibrary(ctx, badId)
+		assert.NoError(err)
+		assert.Nil(got)
+	})
+	t.Run("empty-public-id", func(t *testing.T) {
+		got, err := repo.LookupPkiCredentialLibrary(ctx, "")
+		assert.Truef(t, errors.Match(errors.T(errors.InvalidParameter), err), "want err code: %v got err: %v", errors.InvalidParameter, err)
+		assert.Nil(t, got)
+	})
+}
+
+func TestRepository_UpdatePkiCredentialLibrary(t *testing.T) {
+	t.Parallel()
+	conn, _ := db.TestSetup(t, "postgres")
+	rw := db.New(conn)
+	wrapper := db.TestWrapper(t)
+
+	_, prj := iam.TestScopes(t, iam.TestRepo(t, conn, wrapper))
+	cs := TestCredentialStores(t, conn, wrapper, prj.GetPublicId(), 1)[0]
+
+	tests := []struct {
+		name      string
+		update    *store.PkiCredentialLibrary
+		masks     []string
+		want      *store.PkiCredentialLibrary
+		wantCount int
+		wantErr   errors.Code
+	}{
+		{
+			name:      "change-name-and-description",
+			update:    &store.PkiCredentialLibrary{Name: "new-name", Description: "new-description"},
+			masks:     []string{nameField, descriptionField},
+			want:      &store.PkiCredentialLibrary{Name: "new-name", Description: "new-description", MountPath: "pki", Role: "role-0", CommonName: "host-0.example.com"},
+			wantCount: 1,
+		},
+		{
+			name:      "change-mount-path-and-role",
+			update:    &store.PkiCredentialLibrary{MountPath: "team/pki", Role: "client"},
+			masks:     []string{mountPathField, roleField},
+			want:      &store.PkiCredentialLibrary{MountPath: "team/pki", Role: "client", CommonName: "host-0.example.com"},
+			wantCount: 1,
+		},
+		{
+			name:      "change-common-name-and-alt-names",
+			update:    &store.PkiCredentialLibrary{CommonName: "client.example.com", AltNames: "a.example.com"},
+			masks:     []string{commonNameField, altNamesField},
+			want:      &store.PkiCredentialLibrary{MountPath: "pki", Role: "role-0", CommonName: "client.example.com", AltNames: "a.example.com"},
+			wantCount: 1,
+		},
+		{
+			name:      "delete-alt-names",
+			update:    &store.PkiCredentialLibrary{},
+			masks:     []string{altNamesField},
+			want:      &store.PkiCredentialLibrary{MountPath: "pki", Role: "role-0", CommonName: "host-0.example.com"},
+			wantCount: 1,
+		},
+		{
+			name:    "delete-mount-path",
+			update:  &store.PkiCredentialLibrary{},
+			masks:   []string{mountPathField},
+			wantErr: errors.InvalidParameter,
+		},
+		{
+			name:    "delete-role",
+			update:  &store.PkiCredentialLibrary{},
+			masks:   []string{roleField},
+			wantErr: errors.InvalidParameter,
+		},
+		{
+			name:    "delete-common-name",
+			update:  &store.PkiCredentialLibrary{},
+			masks:   []string{commonNameField},
+			wantErr: errors.InvalidParameter,
+		},
+		{
+			name:    "role-with-path-separator",
+			update:  &store.PkiCredentialLibrary{Role: "a/b"},
+			masks:   []string{roleField},
+			wantErr: errors.InvalidParameter,
+		},
+		{
+			name:    "invalid-field-mask",
+			update:  &store.PkiCredentialLibrary{CredentialType: string(globals.SshPrivateKeyCredentialType)},
+			masks:   []string{"CredentialType"},
+			wantErr: errors.InvalidFieldMask,
+		},
+		{
+			name:    "empty-field-mask",
+			update:  &store.PkiCredentialLibrary{Name: "new-name"},
+			wantErr: errors.EmptyFieldMask,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			assert, require := assert.New(t), require.New(t)
+			ctx := context.Background()
+			kms := kms.TestKms(t, conn, wrapper)
+			sche := scheduler.TestScheduler(t, conn, wrapper)
+			repo, err := NewRepository(ctx, rw, rw, kms, sche)
+			require.NoError(err)
+
+			orig := TestPkiCredentialLibraries(t, conn, wrapper, cs.GetPublicId(), 1)[0]
+			in := &PkiCredentialLibrary{PkiCredentialLibrary: tt.update}
+			in.PublicId = orig.GetPublicId()
+
+			got, gotCount, err := repo.UpdatePkiCredentialLibrary(ctx, prj.GetPublicId(), in, 1, tt.masks)
+			if tt.wantErr != 0 {
+				assert.Truef(errors.Match(errors.T(tt.wantErr), err), "want err: %q got: %q", tt.wantErr, err)
+				assert.Equal(db.NoRowsAffected, gotCount)
+				assert.Nil(got)
+				return
+			}
+			require.NoError(err)
+			require.NotNil(got)
+			assert.Equal(tt.wantCount, gotCount)
+			assert.Equal(tt.want.Name, got.GetName())
+			assert.Equal(tt.want.Description, got.GetDescription())
+			assert.Equal(tt.want.MountPath, got.GetMountPath())
+			assert.Equal(tt.want.Role, got.GetRole())
+			assert.Equal(tt.want.CommonName, got.GetCommonName())
+			assert.Equal(tt.want.AltNames, got.GetAltNames())
+			assert.Equal(uint32(2), got.GetVersion())
+			assert.Equal(globals.TlsClientCertificateCredentialType, got.CredentialType())
+			assert.NoError(db.TestVerifyOplog(t, rw, got.GetPublicId(), db.WithOperation(oplog.OpType_OP_TYPE_UPDATE), db.WithCreateNotBefore(10*time.Second)))
+		})
+	}
+
+	t.Run("wrong-version", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		ctx := context.Background()
+		kms := kms.TestKms(t, conn, wrapper)
+		sche := scheduler.TestScheduler(t, conn, wrapper)
+		repo, err := NewRepository(ctx, rw, rw, kms, sche)
+		require.NoError(err)
+
+		orig := TestPkiCredentialLibraries(t, conn, wrapper, cs.GetPublicId(), 1)[0]
+		in := orig.clone()
+		in.Role = "changed"
+		got, gotCount, err := repo.UpdatePkiCredentialLibrary(ctx, prj.GetPublicId(), in, 2, []string{roleField})
+		assert.Error(err)
+		assert.Equal(db.NoRowsAffected, gotCount)
+		assert.Nil(got)
+	})
+}
+
+func TestRepository_DeletePkiCredentialLibrary(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	conn, _ := db.TestSetup(t, "postgres")
+	rw := db.New(conn)
+	wrapper := db.TestWrapper(t)
+	kms := kms.TestKms(t, conn, wrapper)
+	sche := scheduler.TestScheduler(t, conn, wrapper)
+
+	_, prj := iam.TestScopes(t, iam.TestRepo(t, conn, wrapper))
+	cs := TestCredentialStores(t, conn, wrapper, prj.GetPublicId(), 1)[0]
+	l := TestPkiCredentialLibraries(t, conn, wrapper, cs.GetPublicId(), 1)[0]
+
+	repo, err := NewRepository(ctx, rw, rw, kms, sche)
+	require.NoError(t, err)
+
+	badId, err := newPkiCredentialLibraryId(ctx)
+	require.NoError(t, err)
+
+	t.Run("empty-public-id", func(t *testing.T) {
+		_, err := repo.DeletePkiCredentialLibrary(ctx, prj.GetPublicId(), "")
+		assert.Truef(t, errors.Match(errors.T(errors.InvalidParameter), err), "want err code: %v got err: %v", errors.InvalidParameter, err)
+	})
+	t.Run("not-found", func(t *testing.T) {
+		got, err := repo.DeletePkiCredentialLibrary(ctx, prj.GetPublicId(), badId)
+		assert.NoError(t, err)
+		assert.Equal(t, 0, got)
+	})
+	t.Run("found", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		since := time.Now().AddDate(-1, 0, 0)
+		got, err := repo.DeletePkiCredentialLibrary(ctx, prj.GetPublicId(), l.GetPublicId())
+		require.NoError(err)
+		assert.Equal(1, got)
+
+		cl, err := repo.LookupPkiCredentialLibrary(ctx, l.GetPublicId())
+		assert.NoError(err)
+		assert.Nil(cl)
+
+		deletedIds, _, err := repo.ListDeletedLibraryIds(ctx, since)
+		require.NoError(err)
+		assert.Contains(deletedIds, l.GetPublicId())
+	})
+}
@@ -175,6 +175,70 @@ func TestSSHCertificateCredentialLibraries(t testing.TB, conn *db.DB, _ wrapping
 	return libs
 }
 
+// TestDatabaseCredentialLibraries creates count number of vault database
+// credential libraries in the provided DB with the provided store id. If any
+// errors are encountered during the creation of the credential libraries,
+// the test will fail.
+func TestDatabaseCredentialLibraries(t testing.TB, conn *db.DB, _ wrapping.Wrapper, storeId string, count int) []*DatabaseCredentialLibrary {
+	t.Helper()
+	ctx := context.Background()
+	assert, require := assert.New(t), require.New(t)
+	w := db.New(conn)
+	var libs []*DatabaseCredentialLibrary
+
+	for i := 0; i < count; i++ {
+		lib, err := NewDatabaseCredentialLibrary(storeId, "database", fmt.Sprintf("role-%d", i))
+		assert.NoError(err)
+		require.NotNil(lib)
+		id, err := newDatabaseCredentialLibraryId(ctx)
+		assert.NoError(err)
+		require.NotEmpty(id)
+		lib.PublicId = id
+
+		_, err2 := w.DoTx(ctx, db.StdRetryCnt, db.ExpBackoff{},
+			func(_ db.Reader, iw db.Writer) error {
+				return iw.Create(ctx, lib)
+			},
+		)
+
+		require.NoError(err2)
+		libs = append(libs, lib)
+	}
+	return libs
+}
+
+// TestPkiCredentialLibraries creates count number of vault pki credential
+// libraries in the provided DB with the provided store id. If any errors
+// are encountered during the creation of the credential libraries, the
+// test will fail.
+func TestPkiCredentialLibraries(t testing.TB, conn *db.DB, _ wrapping.Wrapper, storeId string, count int) []*PkiCredentialLibrary {
+	t.Helper()
+	ctx := context.Background()
+	assert, require := assert.New(t), require.New(t)
+	w := db.New(conn)
+	var libs []*PkiCredentialLibrary
+
+	for i := 0; i < count; i++ {
+		lib, err := NewPkiCredentialLibrary(storeId, "pki", fmt.Sprintf("role-%d", i), fmt.Sprintf("host-%d.example.com", i))
+		assert.NoError(err)
+		require.NotNil(lib)
+		id, err := newPkiCredentialLibraryId(ctx)
+		assert.NoError(err)
+		require.NotEmpty(id)
+		lib.PublicId = id
+
+		_, err2 := w.DoTx(ctx, db.StdRetryCnt, db.ExpBackoff{},
+			func(_ db.Reader, iw db.Writer) error {
+				return iw.Create(ctx, lib)
+			},
+		)
+
+		require.NoError(err2)
+		libs = append(libs, lib)
+	}
+	return libs
+}
+
 // TestCredentials creates count number of vault credentials in the provided DB with
 // the provided library id and session id. If any errors are encountered
 // during the creation of the credentials, the test will fail.
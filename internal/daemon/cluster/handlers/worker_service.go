@@ -5,7 +5,10 @@ package handlers
 
 import (
 	"context"
+	"crypto/subtle"
 	"fmt"
+	"slices"
+	"strings"
 	"sync"
 	"sync/atomic"
 	"time"
@@ -13,6 +16,7 @@ import (
 	dcommon "github.com/hashicorp/boundary/internal/daemon/common"
 	"github.com/hashicorp/boundary/internal/daemon/controller/common"
 	"github.com/hashicorp/boundary/internal/daemon/controller/handlers"
+	"github.com/hashicorp/boundary/internal/errors"
 	"github.com/hashicorp/boundary/internal/event"
 	pbs "github.com/hashicorp/boundary/internal/gen/controller/servers/services"
 	intglobals "github.com/hashicorp/boundary/internal/globals"
@@ -41,17 +45,32 @@ type workerServiceServer struct {
 	kms                 *kms.Kms
 	livenessTimeToStale *atomic.Int64
 	controllerExt       intglobals.ControllerExtension
+
+	// recordedDownstreams holds the downstream workers last recorded for each
+	// worker by this controller, keyed by worker id.
+	recordedDownstreams *sync.Map
+}
+
+// recordedDownstreams are the downstream workers recorded for a worker.
+type recordedDownstreams struct {
+	ids  string
+	time time.Time
 }
 
+// downstreamsRecordInterval is how often the downstream workers reported in
+// a worker's status are recorded when they did not change. Another controller
+// may have recorded different ones in the meantime.
+const downstreamsRecordInterval = time.Minute
+
 var (
 	_ pbs.SessionServiceServer            = &workerServiceServer{}
 	_ pbs.ServerCoordinationServiceServer = &workerServiceServer{}
 
-	workerFilterSelectionFn = egressFilterSelector
+	workerFilterSelectionFn = ingressFilterSelector
 	// connectionRouteFn returns a route to the egress worker.  If the requester
 	// is the egress worker a route of length 1 is returned. A route of
 	// length 0 is never returned unless there is an error.
-	connectionRouteFn = singleHopConnectionRoute
+	connectionRouteFn = multiHopConnectionRoute
 
 	// getProtocolContext populates the protocol specific context fields
 	// depending on the protocol used to for the boundary connection. Defaults
@@ -62,10 +81,62 @@ var (
 )
 
 // singleHopConnectionRoute returns a route consisting of the singlehop worker (the root worker id)
-func singleHopConnectionRoute(_ context.Context, w *server.Worker, _ *session.Session, _ *session.AuthzSummary, _ *server.Repository, _ common.Downstreamers) ([]string, error) {
+func singleHopConnectionRoute(_ context.Context, w *server.Worker, _ *session.Session, _ *session.AuthzSummary, _ *server.Repository, _ common.Downstreamers, _ time.Duration) ([]string, error) {
 	return []string{w.GetPublicId()}, nil
 }
 
+// multiHopConnectionRoute returns a route from the requesting worker through
+// its downstream workers to a worker matching the session's egress filter. If
+// the session has no ingress worker filter, or the requesting worker matches
+// the egress filter, a single hop route is returned. Only workers that have
+// reported their status within livenessTimeToStale are used for egress.
+func multiHopConnectionRoute(ctx context.Context, w *server.Worker, sess *session.Session, authzSummary *session.AuthzSummary, repo *server.Repository, downstreams common.Downstreamers, livenessTimeToStale time.Duration) ([]string, error) {
+	const op = "handlers.multiHopConnectionRoute"
+	egressFilter := egressFilterSelector(sess)
+	if sess.IngressWorkerFilter == "" || egressFilter == "" {
+		return singleHopConnectionRoute(ctx, w, sess, authzSummary, repo, downstreams, livenessTimeToStale)
+	}
+	eval, err := bexpr.CreateEvaluator(egressFilter)
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	ok, err := eval.Evaluate(map[string]any{
+		"name": w.GetName(),
+		"tags": w.CanonicalTags(),
+	})
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	if ok {
+		return singleHopConnectionRoute(ctx, w, sess, authzSummary, repo, downstreams, livenessTimeToStale)
+	}
+
+	liveWorkers, err := repo.ListWorkers(ctx, []string{scope.Global.String()}, server.WithLiveness(livenessTimeToStale))
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	egressWorkers, err := dcommon.WorkerList(liveWorkers).Filtered(eval)
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	egressIds := make(map[string]struct{}, len(egressWorkers))
+	for _, ew := range egressWorkers {
+		egressIds[ew.GetPublicId()] = struct{}{}
+	}
+	workerDownstreams, err := repo.ListWorkerDownstreams(ctx)
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	route := common.ShortestWorkerRoute(w.GetPublicId(), workerDownstreams, func(id string) bool {
+		_, ok := egressIds[id]
+		return ok
+	})
+	if len(route) == 0 {
+		return nil, errors.New(ctx, errors.NotFound, op, fmt.Sprintf("no route from worker %q to an egress worker", w.GetPublicId()))
+	}
+	return route, nil
+}
+
 func NewWorkerServiceServer(
 	serversRepoFn common.ServersRepoFactory,
 	workerAuthRepoFn common.WorkerAuthRepoStorageFactory,
@@ -87,9 +158,31 @@ func NewWorkerServiceServer(
 		kms:                 kms,
 		livenessTimeToStale: livenessTimeToStale,
 		controllerExt:       controllerExt,
+		recordedDownstreams: new(sync.Map),
 	}
 }
 
+// shouldRecordDownstreams reports whether the downstream workers reported by
+// the worker differ from the ones this controller last recorded for it, or
+// were recorded more than downstreamsRecordInterval ago. If so, they are
+// remembered as recorded.
+func (ws *workerServiceServer) shouldRecordDownstreams(workerId string, downstreamIds []string) bool {
+	ids := slices.Clone(downstreamIds)
+	slices.Sort(ids)
+	cur := recordedDownstreams{ids: strings.Join(ids, ","), time: time.Now()}
+	prev, loaded := ws.recordedDownstreams.Swap(workerId, cur)
+	if !loaded {
+		return true
+	}
+	p := prev.(recordedDownstreams)
+	if p.ids == cur.ids && cur.time.Sub(p.time) < downstreamsRecordInterval {
+		// Keep the time of the last write.
+		ws.recordedDownstreams.Store(workerId, p)
+		return false
+	}
+	return true
+}
+
 func (ws *workerServiceServer) Status(ctx context.Context, req *pbs.StatusRequest) (*pbs.StatusResponse, error) {
 	const op = "workers.(workerServiceServer).Status"
 	// TODO: on the worker, if we get errors back from this repeatedly, do we
@@ -147,7 +240,8 @@ func (ws *workerServiceServer) Status(ctx context.Context, req *pbs.StatusReques
 		server.WithWorkerTags(workerTags...),
 		server.WithReleaseVersion(wStat.ReleaseVersion),
 		server.WithOperationalState(wStat.OperationalState),
-		server.WithLocalStorageState(wStat.LocalStorageState))
+		server.WithLocalStorageState(wStat.LocalStorageState),
+		server.WithStatusRttMs(wStat.GetStatusRttMs()))
 	opts := []server.Option{server.WithUpdateTags(req.GetUpdateTags())}
 	if wStat.GetPublicId() != "" {
 		opts = append(opts, server.WithPublicId(wStat.GetPublicId()))
@@ -160,6 +254,20 @@ func (ws *workerServiceServer) Status(ctx context.Context, req *pbs.StatusReques
 		event.WriteError(ctx, op, err, event.WithInfoMsg("error storing worker status"))
 		return &pbs.StatusResponse{}, status.Errorf(codes.Internal, "Error storing worker status: %v", err)
 	}
+	if len(req.GetHostEndpointHealth()) > 0 {
+		health := make([]*server.HostEndpointHealth, 0, len(req.GetHostEndpointHealth()))
+		for _, h := range req.GetHostEndpointHealth() {
+			health = append(health, &server.HostEndpointHealth{
+				Endpoint: h.GetEndpoint(),
+				Healthy:  h.GetHealthy(),
+			})
+		}
+		// Failing to record endpoint health shouldn't fail the status update,
+		// the endpoints are treated as healthy until a later report succeeds.
+		if err := serverRepo.UpsertHostEndpointHealth(ctx, wrk.GetPublicId(), health); err != nil {
+			event.WriteError(ctx, op, err, event.WithInfoMsg("error storing host endpoint health"))
+		}
+	}
 	controllers, err := serverRepo.ListControllers(ctx, server.WithLiveness(time.Duration(ws.livenessTimeToStale.Load())))
 	if err != nil {
 		event.WriteError(ctx, op, err, event.WithInfoMsg("error getting current controllers"))
@@ -190,6 +298,16 @@ func (ws *workerServiceServer) Status(ctx context.Context, req *pbs.StatusReques
 		}
 		authorizedDownstreams.WorkerPublicIds = dcommon.WorkerList(knownConnectedWorkers).PublicIds()
 	}
+	// Record which downstream workers are connected to this worker so that
+	// multi-hop routes can be computed when authorizing sessions. They are
+	// only written when they changed, and failing to write them doesn't fail
+	// the status since they are written again with the next one.
+	if ws.shouldRecordDownstreams(wrk.GetPublicId(), authorizedDownstreams.GetWorkerPublicIds()) {
+		if err := serverRepo.UpsertWorkerDownstreams(ctx, wrk.GetPublicId(), authorizedDownstreams.GetWorkerPublicIds()); err != nil {
+			event.WriteError(ctx, op, err, event.WithInfoMsg("error storing worker downstreams", "worker_id", wrk.GetPublicId()))
+			ws.recordedDownstreams.Delete(wrk.GetPublicId())
+		}
+	}
 
 	if len(req.GetConnectedUnmappedWorkerKeyIdentifiers()) > 0 {
 		authorizedKeyIds, err := workerAuthRepo.FilterToAuthorizedWorkerKeyIds(ctx, req.GetConnectedUnmappedWorkerKeyIdentifiers())
@@ -215,6 +333,7 @@ func (ws *workerServiceServer) Status(ctx context.Context, req *pbs.StatusReques
 		WorkerId:                    wrk.GetPublicId(),
 		AuthorizedWorkers:           authorizedWorkerList,
 		AuthorizedDownstreamWorkers: authorizedDownstreams,
+		Drain:                       wrk.GetDrain(),
 	}
 
 	stateReport := make([]*session.StateReport, 0, len(req.GetJobs()))
@@ -305,6 +424,7 @@ func (ws *workerServiceServer) Status(ctx context.Context, req *pbs.StatusReques
 						Status:          na.Status.ProtoVal(),
 						Connections:     connChanges,
 						ProcessingError: processErr,
+						CancelReason:    na.CancelReason,
 					},
 				},
 			},
@@ -388,6 +508,16 @@ func egressFilterSelector(sessionInfo *session.Session) string {
 	return ""
 }
 
+// ingressFilterSelector returns the ingress worker filter if the session has
+// one, since the worker serving the session to a client is then the ingress
+// worker. Otherwise it falls back to egressFilterSelector.
+func ingressFilterSelector(sessionInfo *session.Session) string {
+	if sessionInfo.IngressWorkerFilter != "" {
+		return sessionInfo.IngressWorkerFilter
+	}
+	return egressFilterSelector(sessionInfo)
+}
+
 // noProtocolContext doesn't provide any protocol context since tcp doesn't need any
 func noProtocolContext(
 	context.Context,
@@ -429,7 +559,7 @@ func lookupSessionWorkerFilter(ctx context.Context, sessionInfo *session.Session
 		// we can select a worker for egress that wouldn't potentially grant access
 		// to a private ip address in the network of the boundary deployment in the
 		// case of hcp.
-		if _, err := connectionRouteFn(ctx, w, sessionInfo, authzSummary, serversRepo, ws.downstreams); err != nil {
+		if _, err := connectionRouteFn(ctx, w, sessionInfo, authzSummary, serversRepo, ws.downstreams, time.Duration(ws.livenessTimeToStale.Load())); err != nil {
 			return status.Errorf(codes.Internal, "error calculating route to endpoint: %v", err)
 		}
 		return nil
@@ -461,13 +591,80 @@ func lookupSessionWorkerFilter(ctx context.Context, sessionInfo *session.Session
 	// we can select a worker for egress that wouldn't potentially grant access
 	// to a private ip address in the network of the boundary deployment in the
 	// case of hcp.
-	if _, err = connectionRouteFn(ctx, w, sessionInfo, authzSummary, serversRepo, ws.downstreams); err != nil {
+	if _, err = connectionRouteFn(ctx, w, sessionInfo, authzSummary, serversRepo, ws.downstreams, time.Duration(ws.livenessTimeToStale.Load())); err != nil {
 		return status.Errorf(codes.Internal, "error calculating route to endpoint: %v", err)
 	}
 
 	return nil
 }
 
+// routedConnectionSessionId returns the id of the session the connection in
+// the request belongs to. It fails if the connection is unknown, belongs to
+// another session than the one requested, or is no longer authorized or
+// connected.
+func routedConnectionSessionId(ctx context.Context, ws *workerServiceServer, req *pbs.LookupSessionRequest) (string, error) {
+	connRepo, err := ws.connectionRepoFn()
+	if err != nil {
+		return "", status.Errorf(codes.Internal, "Error getting connection repo: %v", err)
+	}
+	conn, states, err := connRepo.LookupConnection(ctx, req.GetConnectionId())
+	if err != nil {
+		return "", status.Errorf(codes.Internal, "Error looking up connection: %v", err)
+	}
+	if conn == nil || (req.GetSessionId() != "" && conn.SessionId != req.GetSessionId()) {
+		return "", status.Error(codes.PermissionDenied, "Unknown connection ID.")
+	}
+	if len(states) == 0 {
+		return "", status.Error(codes.Internal, "Empty connection states during lookup.")
+	}
+	switch states[0].Status {
+	case session.StatusAuthorized, session.StatusConnected:
+	default:
+		return "", status.Error(codes.PermissionDenied, "Connection is no longer active.")
+	}
+	return conn.SessionId, nil
+}
+
+// routedSessionWorkerFilter verifies that the worker in the request can be
+// the egress worker of a multi-hop route for the session. Multi-hop routes
+// are only built for sessions with an egress worker filter.
+func routedSessionWorkerFilter(ctx context.Context, sessionInfo *session.Session, ws *workerServiceServer, req *pbs.LookupSessionRequest) error {
+	const op = "workers.routedSessionWorkerFilter"
+	filter := egressFilterSelector(sessionInfo)
+	if filter == "" {
+		return handlers.ApiErrorWithCodeAndMessage(codes.FailedPrecondition, "Session has no egress worker filter")
+	}
+	serversRepo, err := ws.serversRepoFn()
+	if err != nil {
+		event.WriteError(ctx, op, err, event.WithInfoMsg("error getting server repo"))
+		return status.Errorf(codes.Internal, "Error acquiring server repo when looking up session: %v", err)
+	}
+	w, err := serversRepo.LookupWorker(ctx, req.GetWorkerId())
+	if err != nil {
+		event.WriteError(ctx, op, err, event.WithInfoMsg("error looking up worker", "worker_id", req.WorkerId))
+		return status.Errorf(codes.Internal, "Error looking up worker: %v", err)
+	}
+	if w == nil {
+		return status.Errorf(codes.Internal, "Worker not found")
+	}
+	eval, err := bexpr.CreateEvaluator(filter)
+	if err != nil {
+		event.WriteError(ctx, op, err, event.WithInfoMsg("error creating worker filter evaluator", "worker_id", req.WorkerId))
+		return status.Errorf(codes.Internal, "Error creating worker filter evaluator: %v", err)
+	}
+	ok, err := eval.Evaluate(map[string]any{
+		"name": w.GetName(),
+		"tags": w.CanonicalTags(),
+	})
+	if err != nil {
+		return status.Errorf(codes.Internal, fmt.Sprintf("Worker filter expression evaluation resulted in error: %s", err))
+	}
+	if !ok {
+		return handlers.ApiErrorWithCodeAndMessage(codes.FailedPrecondition, "Egress worker filter expression precludes this worker from serving this session")
+	}
+	return nil
+}
+
 func (ws *workerServiceServer) LookupSession(ctx context.Context, req *pbs.LookupSessionRequest) (*pbs.LookupSessionResponse, error) {
 	const op = "workers.(workerServiceServer).LookupSession"
 
@@ -475,12 +672,20 @@ func (ws *workerServiceServer) LookupSession(ctx context.Context, req *pbs.Looku
 		return nil, status.Errorf(codes.InvalidArgument, "Did not receive worker id when looking up session")
 	}
 
+	sessionId := req.GetSessionId()
+	if req.GetConnectionId() != "" {
+		var err error
+		if sessionId, err = routedConnectionSessionId(ctx, ws, req); err != nil {
+			return nil, err
+		}
+	}
+
 	sessRepo, err := ws.sessionRepoFn()
 	if err != nil {
 		return nil, status.Errorf(codes.Internal, "Error getting session repo: %v", err)
 	}
 
-	sessionInfo, authzSummary, err := sessRepo.LookupSession(ctx, req.GetSessionId())
+	sessionInfo, authzSummary, err := sessRepo.LookupSession(ctx, sessionId)
 	if err != nil {
 		return nil, status.Errorf(codes.Internal, "Error looking up session: %v", err)
 	}
@@ -491,7 +696,11 @@ func (ws *workerServiceServer) LookupSession(ctx context.Context, req *pbs.Looku
 		return nil, status.Error(codes.Internal, "Empty session states during lookup.")
 	}
 
-	err = lookupSessionWorkerFilter(ctx, sessionInfo, authzSummary, ws, req)
+	if req.GetConnectionId() != "" {
+		err = routedSessionWorkerFilter(ctx, sessionInfo, ws, req)
+	} else {
+		err = lookupSessionWorkerFilter(ctx, sessionInfo, authzSummary, ws, req)
+	}
 	if err != nil {
 		return nil, err
 	}
@@ -518,18 +727,24 @@ func (ws *workerServiceServer) LookupSession(ctx context.Context, req *pbs.Looku
 			Certificate: sessionInfo.Certificate,
 			PrivateKey:  sessionInfo.CertificatePrivateKey,
 		},
-		Status:          sessionInfo.States[0].Status.ProtoVal(),
-		Version:         sessionInfo.Version,
-		TofuToken:       string(sessionInfo.TofuToken),
-		Endpoint:        sessionInfo.Endpoint,
-		Expiration:      sessionInfo.ExpirationTime.Timestamp,
-		ConnectionLimit: sessionInfo.ConnectionLimit,
-		ConnectionsLeft: authzSummary.ConnectionLimit,
-		HostId:          sessionInfo.HostId,
-		HostSetId:       sessionInfo.HostSetId,
-		TargetId:        sessionInfo.TargetId,
-		UserId:          sessionInfo.UserId,
-		Credentials:     workerCreds,
+		Status:            sessionInfo.States[0].Status.ProtoVal(),
+		Version:           sessionInfo.Version,
+		TofuToken:         string(sessionInfo.TofuToken),
+		Endpoint:          sessionInfo.Endpoint,
+		Expiration:        sessionInfo.ExpirationTime.Timestamp,
+		ConnectionLimit:   sessionInfo.ConnectionLimit,
+		ConnectionsLeft:   authzSummary.ConnectionLimit,
+		HostId:            sessionInfo.HostId,
+		HostSetId:         sessionInfo.HostSetId,
+		TargetId:          sessionInfo.TargetId,
+		UserId:            sessionInfo.UserId,
+		Credentials:       workerCreds,
+		HostHealthCheck:   sessionInfo.HostHealthCheck,
+		FailoverEndpoints: sessionInfo.FailoverEndpoints,
+		ProbedEndpoints:   sessionInfo.ProbedEndpoints,
+		AccessWindow:      sessionInfo.AccessWindow,
+		UploadRateLimit:   sessionInfo.UploadRateLimit,
+		DownloadRateLimit: sessionInfo.DownloadRateLimit,
 	}
 	if resp.ConnectionsLeft != -1 {
 		resp.ConnectionsLeft -= int32(authzSummary.CurrentConnectionCount)
@@ -631,7 +846,7 @@ func (ws *workerServiceServer) AuthorizeConnection(ctx context.Context, req *pbs
 		return nil, status.Errorf(codes.Internal, "Invalid session info in lookup session response")
 	}
 
-	route, err := connectionRouteFn(ctx, w, sessInfo, authzSummary, serversRepo, ws.downstreams)
+	route, err := connectionRouteFn(ctx, w, sessInfo, authzSummary, serversRepo, ws.downstreams, time.Duration(ws.livenessTimeToStale.Load()))
 	if err != nil {
 		return nil, status.Errorf(codes.Internal, "error getting route to egress worker: %v", err)
 	}
@@ -747,3 +962,58 @@ func (ws *workerServiceServer) CloseConnection(ctx context.Context, req *pbs.Clo
 
 	return ret, nil
 }
+
+func (ws *workerServiceServer) AuthorizeShadow(ctx context.Context, req *pbs.AuthorizeShadowRequest) (*pbs.AuthorizeShadowResponse, error) {
+	const op = "workers.(workerServiceServer).AuthorizeShadow"
+	if req.GetSessionId() == "" || req.GetShadowToken() == "" || len(req.GetCertificate()) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "Missing session id, shadow token or certificate.")
+	}
+
+	sessRepo, err := ws.sessionRepoFn()
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "error getting session repo: %v", err)
+	}
+
+	shadow, err := sessRepo.LookupShadow(ctx, req.GetSessionId(), req.GetShadowToken())
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "error looking up shadow: %v", err)
+	}
+	if shadow == nil {
+		return nil, status.Error(codes.PermissionDenied, "Unknown or expired shadow token.")
+	}
+	// The token is only good together with the certificate issued for it.
+	if subtle.ConstantTimeCompare(shadow.Certificate, req.GetCertificate()) != 1 {
+		return nil, status.Error(codes.PermissionDenied, "Certificate was not issued for the shadow token.")
+	}
+
+	sessionInfo, _, err := sessRepo.LookupSession(ctx, req.GetSessionId())
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "error looking up session: %v", err)
+	}
+	if sessionInfo == nil || len(sessionInfo.States) == 0 {
+		return nil, status.Error(codes.PermissionDenied, "Unknown session ID.")
+	}
+	if sessionInfo.States[0].Status != session.StatusActive {
+		return nil, status.Error(codes.FailedPrecondition, "Session is not active.")
+	}
+
+	// Only the workers proxying a connection of the session have a terminal
+	// to stream to the observer.
+	workerIds, err := sessRepo.ListConnectedWorkerIds(ctx, req.GetSessionId())
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "error listing session workers: %v", err)
+	}
+	if !slices.Contains(workerIds, req.GetWorkerId()) {
+		return nil, status.Error(codes.FailedPrecondition, "Worker is not proxying a connection of the session.")
+	}
+
+	expiration := shadow.ExpirationTime.GetTimestamp()
+	if sessionInfo.ExpirationTime.GetTimestamp().AsTime().Before(expiration.AsTime()) {
+		expiration = sessionInfo.ExpirationTime.GetTimestamp()
+	}
+	return &pbs.AuthorizeShadowResponse{
+		UserId:     shadow.UserId,
+		WithInput:  shadow.WithInput,
+		Expiration: expiration,
+	}, nil
+}
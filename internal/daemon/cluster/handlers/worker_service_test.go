@@ -158,6 +158,19 @@ func TestLookupSession(t *testing.T) {
 	err = repo.AddSessionCredentials(ctx, sessWithCreds.ProjectId, sessWithCreds.GetPublicId(), workerCreds)
 	require.NoError(t, err)
 
+	// Connections proxied by the egress worker of a multi-hop route.
+	routedConn := session.TestConnection(t, conn, sessWithWorkerFilter.PublicId, "127.0.0.1", 22, "127.0.0.1", 22, "127.0.0.1")
+	sessNoEgressFilter := session.TestSession(t, conn, wrapper, session.ComposedOf{
+		UserId:      uId,
+		HostId:      h.GetPublicId(),
+		TargetId:    tar.GetPublicId(),
+		HostSetId:   hs.GetPublicId(),
+		AuthTokenId: at.GetPublicId(),
+		ProjectId:   prj.GetPublicId(),
+		Endpoint:    "tcp://127.0.0.1:22",
+	})
+	unroutableConn := session.TestConnection(t, conn, sessNoEgressFilter.PublicId, "127.0.0.1", 22, "127.0.0.1", 22, "127.0.0.1")
+
 	s := NewWorkerServiceServer(serversRepoFn, workerAuthRepoFn, sessionRepoFn, connectionRepoFn, nil, new(sync.Map), kms, new(atomic.Int64), fce)
 	require.NotNil(t, s)
 
@@ -200,6 +213,57 @@ func TestLookupSession(t *testing.T) {
 			wantErr:    true,
 			wantErrMsg: "rpc error: code = Internal desc = Worker not found",
 		},
+		{
+			name: "unknown connection id",
+			req: &pbs.LookupSessionRequest{
+				ConnectionId: "sc_fakeconnection",
+				WorkerId:     worker1.GetPublicId(),
+			},
+			wantErr:    true,
+			wantErrMsg: "rpc error: code = PermissionDenied desc = Unknown connection ID.",
+		},
+		{
+			name: "connection of another session",
+			req: &pbs.LookupSessionRequest{
+				SessionId:    sess.PublicId,
+				ConnectionId: routedConn.PublicId,
+				WorkerId:     worker1.GetPublicId(),
+			},
+			wantErr:    true,
+			wantErrMsg: "rpc error: code = PermissionDenied desc = Unknown connection ID.",
+		},
+		{
+			name: "connection of a session without an egress filter",
+			req: &pbs.LookupSessionRequest{
+				ConnectionId: unroutableConn.PublicId,
+				WorkerId:     worker1.GetPublicId(),
+			},
+			wantErr:    true,
+			wantErrMsg: "rpc error: code = FailedPrecondition desc = Session has no egress worker filter",
+		},
+		{
+			name: "Valid routed connection",
+			req: &pbs.LookupSessionRequest{
+				ConnectionId: routedConn.PublicId,
+				WorkerId:     worker1.GetPublicId(),
+			},
+			want: &pbs.LookupSessionResponse{
+				Authorization: &targets.SessionAuthorizationData{
+					SessionId:   sessWithWorkerFilter.PublicId,
+					Certificate: sessWithWorkerFilter.Certificate,
+					PrivateKey:  sessWithWorkerFilter.CertificatePrivateKey,
+				},
+				ConnectionLimit: 1,
+				ConnectionsLeft: 0,
+				Version:         1,
+				Endpoint:        sessWithWorkerFilter.Endpoint,
+				HostId:          sessWithWorkerFilter.HostId,
+				HostSetId:       sessWithWorkerFilter.HostSetId,
+				TargetId:        sessWithWorkerFilter.TargetId,
+				UserId:          sessWithWorkerFilter.UserId,
+				Status:          pbs.SESSIONSTATUS_SESSIONSTATUS_PENDING,
+			},
+		},
 		{
 			name: "Valid",
 			req: &pbs.LookupSessionRequest{
@@ -630,3 +694,13 @@ func TestHcpbWorkers(t *testing.T) {
 	}
 	assert.ElementsMatch(expValues, gotValues)
 }
+
+func TestIngressFilterSelector(t *testing.T) {
+	assert.Equal(t, "", ingressFilterSelector(&session.Session{}))
+	assert.Equal(t, `"/name" == "w"`, ingressFilterSelector(&session.Session{WorkerFilter: `"/name" == "w"`}))
+	assert.Equal(t, `"e" in "/tags/type"`, ingressFilterSelector(&session.Session{EgressWorkerFilter: `"e" in "/tags/type"`}))
+	assert.Equal(t, `"i" in "/tags/type"`, ingressFilterSelector(&session.Session{
+		EgressWorkerFilter:  `"e" in "/tags/type"`,
+		IngressWorkerFilter: `"i" in "/tags/type"`,
+	}))
+}
@@ -16,6 +16,17 @@ import (
 	"google.golang.org/grpc/codes"
 )
 
+// TargetFeatures are the worker features which targets may use, depending on
+// their type and configuration. Sessions for a target are only proxied
+// through workers supporting the features it uses.
+var TargetFeatures = []version.Feature{
+	version.UdpTarget,
+	version.TargetBandwidthLimit,
+	version.TargetAccessWindow,
+	version.HostHealthFailover,
+	version.SessionShadow,
+}
+
 // WorkerList is a helper type to make the selection of workers clearer and more declarative.
 type WorkerList []*server.Worker
 
@@ -60,6 +71,31 @@ func (w WorkerList) SupportsFeature(f version.Feature) WorkerList {
 	return ret
 }
 
+// SupportsFeatures returns a new WorkerList composed of all workers in this
+// WorkerList which support every one of the provided features.
+func (w WorkerList) SupportsFeatures(f ...version.Feature) WorkerList {
+	var ret []*server.Worker
+	for _, worker := range w {
+		if len(UnsupportedFeatures(worker, f...)) == 0 {
+			ret = append(ret, worker)
+		}
+	}
+	return ret
+}
+
+// UnsupportedFeatures returns the features from the provided list which the
+// release version of the worker does not support.
+func UnsupportedFeatures(worker *server.Worker, f ...version.Feature) []version.Feature {
+	var ret []version.Feature
+	sv := version.FromVersionString(worker.GetReleaseVersion()).Semver()
+	for _, feature := range f {
+		if !version.SupportsFeature(sv, feature) {
+			ret = append(ret, feature)
+		}
+	}
+	return ret
+}
+
 // filtered returns a new workerList where all elements contained in it are the
 // ones which from the original workerList that pass the evaluator's evaluation.
 func (w WorkerList) Filtered(eval *bexpr.Evaluator) (WorkerList, error) {
@@ -0,0 +1,37 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package scopeids
+
+import "github.com/hashicorp/boundary/internal/types/action"
+
+// getOpts - iterate the inbound Options and return a struct
+func getOpts(opt ...Option) options {
+	opts := getDefaultOptions()
+	for _, o := range opt {
+		o(&opts)
+	}
+	return opts
+}
+
+// Option - how Options are passed as arguments
+type Option func(*options)
+
+// options = how options are represented
+type options struct {
+	withListAction action.Type
+}
+
+func getDefaultOptions() options {
+	return options{
+		withListAction: action.List,
+	}
+}
+
+// WithListAction provides the action which must be granted in a scope for
+// it to be listed in. Defaults to action.List.
+func WithListAction(a action.Type) Option {
+	return func(o *options) {
+		o.withListAction = a
+	}
+}
@@ -0,0 +1,23 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package scopeids
+
+import (
+	"testing"
+
+	"github.com/hashicorp/boundary/internal/types/action"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_GetOpts(t *testing.T) {
+	t.Parallel()
+	t.Run("default", func(t *testing.T) {
+		opts := getOpts()
+		assert.Equal(t, action.List, opts.withListAction)
+	})
+	t.Run("WithListAction", func(t *testing.T) {
+		opts := getOpts(WithListAction(action.ListScopeOplogEntries))
+		assert.Equal(t, action.ListScopeOplogEntries, opts.withListAction)
+	})
+}
@@ -54,6 +54,10 @@ type GetListingResourceInformationInput struct {
 	// The type of resource being listed
 	Type resource.Type
 
+	// The action which must be granted in a scope for it to be listed in.
+	// Defaults to action.List.
+	ListAction action.Type
+
 	// Whether the search is recursive
 	Recursive bool
 
@@ -139,6 +143,10 @@ func GetListingResourceInformation(
 		return nil, err
 	}
 
+	listAction := input.ListAction
+	if listAction == action.Unknown {
+		listAction = action.List
+	}
 	res := perms.Resource{
 		Type: input.Type,
 	}
@@ -153,7 +161,7 @@ func GetListingResourceInformation(
 		aSet := input.AuthResults.FetchActionSetForType(ctx,
 			// This is overridden by WithResource
 			resource.Unknown,
-			action.NewActionSet(action.List),
+			action.NewActionSet(listAction),
 			auth.WithResource(&res),
 		)
 		switch len(aSet) {
@@ -163,7 +171,7 @@ func GetListingResourceInformation(
 			// lookup might fail.
 			deferredScopes = append(deferredScopes, scp)
 		case 1:
-			if !aSet.HasAction(action.List) {
+			if !aSet.HasAction(listAction) {
 				return nil, errors.New(ctx, errors.Internal, op, "unexpected action in set")
 			}
 			if output.ScopeResourceMap[scpId] == nil {
@@ -329,14 +337,18 @@ func GetListingScopeIds(
 	typ resource.Type,
 	// Whether or not the search should be recursive
 	recursive bool,
+	// Supported options: WithListAction
+	opt ...Option,
 ) ([]string, map[string]*scopes.ScopeInfo, error) {
 	const op = "scopeids.GetListingScopeIds"
+	opts := getOpts(opt...)
 	scopeResourceInfo, err := GetListingResourceInformation(ctx,
 		GetListingResourceInformationInput{
 			IamRepoFn:   repoFn,
 			AuthResults: authResults,
 			RootScopeId: rootScopeId,
 			Type:        typ,
+			ListAction:  opts.withListAction,
 			Recursive:   recursive,
 		},
 	)
@@ -0,0 +1,42 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package common
+
+import "sort"
+
+// ShortestWorkerRoute returns the shortest route of worker ids starting at
+// from and ending at the first worker for which isEgress returns true,
+// following only edges from a worker to the downstream workers connected to
+// it. downstreams maps a worker id to the ids of its connected downstream
+// workers. If from is itself an egress worker the route contains only from.
+// Ties are broken by worker id so the result is deterministic. Nil is returned
+// if no egress worker is reachable.
+func ShortestWorkerRoute(from string, downstreams map[string][]string, isEgress func(string) bool) []string {
+	if from == "" || isEgress == nil {
+		return nil
+	}
+	previous := map[string]string{from: ""}
+	queue := []string{from}
+	for len(queue) > 0 {
+		current := queue[0]
+		queue = queue[1:]
+		if isEgress(current) {
+			var route []string
+			for id := current; id != ""; id = previous[id] {
+				route = append([]string{id}, route...)
+			}
+			return route
+		}
+		next := append([]string(nil), downstreams[current]...)
+		sort.Strings(next)
+		for _, id := range next {
+			if _, seen := previous[id]; seen {
+				continue
+			}
+			previous[id] = current
+			queue = append(queue, id)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,55 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package common
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestShortestWorkerRoute(t *testing.T) {
+	// dmz1 and dmz2 are ingress workers, priv workers are downstream of the
+	// dmz tier and egress1 is only reachable through priv1.
+	downstreams := map[string][]string{
+		"dmz1":  {"priv2", "priv1"},
+		"dmz2":  {"priv2"},
+		"priv1": {"egress1", "dmz1"},
+		"priv2": {"priv3"},
+		"priv3": {"priv2"},
+	}
+	is := func(ids ...string) func(string) bool {
+		return func(id string) bool {
+			for _, v := range ids {
+				if v == id {
+					return true
+				}
+			}
+			return false
+		}
+	}
+
+	tests := []struct {
+		name     string
+		from     string
+		isEgress func(string) bool
+		want     []string
+	}{
+		{name: "from-is-egress", from: "dmz1", isEgress: is("dmz1"), want: []string{"dmz1"}},
+		{name: "one-hop", from: "dmz1", isEgress: is("priv1"), want: []string{"dmz1", "priv1"}},
+		{name: "two-hops", from: "dmz1", isEgress: is("egress1"), want: []string{"dmz1", "priv1", "egress1"}},
+		{name: "shortest-wins", from: "priv1", isEgress: is("priv2", "egress1"), want: []string{"priv1", "egress1"}},
+		{name: "same-length-broken-by-id", from: "dmz1", isEgress: is("priv3", "egress1"), want: []string{"dmz1", "priv1", "egress1"}},
+		{name: "tie-broken-by-id", from: "dmz1", isEgress: is("priv1", "priv2"), want: []string{"dmz1", "priv1"}},
+		{name: "cycle-unreachable", from: "dmz2", isEgress: is("egress1")},
+		{name: "unknown-worker", from: "other", isEgress: is("egress1")},
+		{name: "empty-from", isEgress: is("egress1")},
+		{name: "nil-egress", from: "dmz1"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, ShortestWorkerRoute(tt.from, downstreams, tt.isEgress))
+		})
+	}
+}
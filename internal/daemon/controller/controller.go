@@ -41,6 +41,7 @@ import (
 	kmsjob "github.com/hashicorp/boundary/internal/kms/job"
 	"github.com/hashicorp/boundary/internal/pagination/purge"
 	"github.com/hashicorp/boundary/internal/plugin"
+	dnsplugin "github.com/hashicorp/boundary/internal/plugin/dns"
 	"github.com/hashicorp/boundary/internal/plugin/loopback"
 	"github.com/hashicorp/boundary/internal/ratelimit"
 	"github.com/hashicorp/boundary/internal/recording"
@@ -48,6 +49,7 @@ import (
 	"github.com/hashicorp/boundary/internal/scheduler/cleaner"
 	"github.com/hashicorp/boundary/internal/scheduler/job"
 	"github.com/hashicorp/boundary/internal/server"
+	"github.com/hashicorp/boundary/internal/server/attestation"
 	serversjob "github.com/hashicorp/boundary/internal/server/job"
 	"github.com/hashicorp/boundary/internal/session"
 	"github.com/hashicorp/boundary/internal/snapshot"
@@ -131,6 +133,10 @@ type Controller struct {
 	rateLimiter   ratelimit.Limiter
 	rateLimiterMu sync.RWMutex
 
+	// workerAttestors validate the identity documents of workers registering
+	// themselves
+	workerAttestors []attestation.Attestor
+
 	// Repo factory methods
 	AuthTokenRepoFn           common.AuthTokenRepoFactory
 	VaultCredentialRepoFn     common.VaultCredentialRepoFactory
@@ -268,6 +274,14 @@ func New(ctx context.Context, conf *Config) (*Controller, error) {
 		return nil, fmt.Errorf("error initializing rate limiter: %w", err)
 	}
 
+	for i, ac := range conf.RawConfig.Controller.WorkerAttestations {
+		a, err := attestation.New(ctx, ac)
+		if err != nil {
+			return nil, fmt.Errorf("error creating worker attestor %d: %w", i, err)
+		}
+		c.workerAttestors = append(c.workerAttestors, a)
+	}
+
 	var pluginLogger hclog.Logger
 	for _, enabledPlugin := range c.enabledPlugins {
 		if pluginLogger == nil {
@@ -290,6 +304,13 @@ func New(ctx context.Context, conf *Config) (*Controller, error) {
 			if _, err = conf.RegisterPlugin(ctx, "loopback", plg, []plugin.PluginType{plugin.PluginTypeHost, plugin.PluginTypeStorage}, opts...); err != nil {
 				return nil, err
 			}
+		case enabledPlugin == base.EnabledPluginHostDns:
+			// The DNS plugin runs in-process so it is available even when
+			// external plugins are skipped.
+			plg := loopback.NewWrappingPluginHostClient(dnsplugin.NewDnsPlugin())
+			if _, err := conf.RegisterPlugin(ctx, dnsplugin.PluginName, plg, []plugin.PluginType{plugin.PluginTypeHost}, plugin.WithDescription(fmt.Sprintf("Built-in %s host plugin", enabledPlugin.String()))); err != nil {
+				return nil, fmt.Errorf("error registering %s host plugin: %w", dnsplugin.PluginName, err)
+			}
 		case enabledPlugin == base.EnabledPluginHostAzure && !c.conf.SkipPlugins:
 			pluginType := strings.ToLower(enabledPlugin.String())
 			client, cleanup, err := external_plugins.CreateHostPlugin(
@@ -42,6 +42,7 @@ import (
 	"github.com/hashicorp/boundary/internal/daemon/controller/handlers/sessions"
 	"github.com/hashicorp/boundary/internal/daemon/controller/handlers/storage_buckets"
 	"github.com/hashicorp/boundary/internal/daemon/controller/handlers/targets"
+	"github.com/hashicorp/boundary/internal/daemon/controller/handlers/targettemplates"
 	"github.com/hashicorp/boundary/internal/daemon/controller/handlers/users"
 	"github.com/hashicorp/boundary/internal/daemon/controller/handlers/workers"
 	"github.com/hashicorp/boundary/internal/daemon/controller/internal/metric"
@@ -206,7 +207,7 @@ func (c *Controller) registerGrpcServices(s *grpc.Server) error {
 		services.RegisterScopeServiceServer(s, os)
 	}
 	if _, ok := currentServices[services.UserService_ServiceDesc.ServiceName]; !ok {
-		us, err := users.NewService(c.baseContext, c.IamRepoFn, c.TargetAliasRepoFn, c.conf.RawConfig.Controller.MaxPageSize)
+		us, err := users.NewService(c.baseContext, c.IamRepoFn, c.TargetAliasRepoFn, c.SessionRepoFn, c.conf.RawConfig.Controller.MaxPageSize)
 		if err != nil {
 			return fmt.Errorf("failed to create user handler service: %w", err)
 		}
@@ -288,7 +289,7 @@ func (c *Controller) registerGrpcServices(s *grpc.Server) error {
 		services.RegisterRoleServiceServer(s, rs)
 	}
 	if _, ok := currentServices[services.SessionService_ServiceDesc.ServiceName]; !ok {
-		ss, err := sessions.NewService(c.baseContext, c.SessionRepoFn, c.IamRepoFn, c.conf.RawConfig.Controller.MaxPageSize)
+		ss, err := sessions.NewService(c.baseContext, c.SessionRepoFn, c.IamRepoFn, c.ServersRepoFn, c.conf.RawConfig.Controller.MaxPageSize)
 		if err != nil {
 			return fmt.Errorf("failed to create session handler service: %w", err)
 		}
@@ -329,7 +330,7 @@ func (c *Controller) registerGrpcServices(s *grpc.Server) error {
 	}
 	if _, ok := currentServices[services.WorkerService_ServiceDesc.ServiceName]; !ok {
 		ws, err := workers.NewService(c.baseContext, c.ServersRepoFn, c.IamRepoFn, c.WorkerAuthRepoStorageFn,
-			c.downstreamWorkers)
+			c.downstreamWorkers, c.workerAttestors)
 		if err != nil {
 			return fmt.Errorf("failed to create worker handler service: %w", err)
 		}
@@ -347,6 +348,13 @@ func (c *Controller) registerGrpcServices(s *grpc.Server) error {
 		}
 		services.RegisterAliasServiceServer(s, as)
 	}
+	if _, ok := currentServices[services.TargetTemplateService_ServiceDesc.ServiceName]; !ok {
+		tts, err := targettemplates.NewService(c.baseContext, c.TargetRepoFn, c.IamRepoFn)
+		if err != nil {
+			return fmt.Errorf("failed to create target template handler service: %w", err)
+		}
+		services.RegisterTargetTemplateServiceServer(s, tts)
+	}
 	if _, ok := currentServices[services.CredentialService_ServiceDesc.ServiceName]; !ok {
 		c, err := credentials.NewService(
 			c.baseContext,
@@ -438,6 +446,9 @@ func registerGrpcGatewayEndpoints(ctx context.Context, gwMux *runtime.ServeMux,
 	if err := services.RegisterAliasServiceHandlerFromEndpoint(ctx, gwMux, gatewayTarget, dialOptions); err != nil {
 		return fmt.Errorf("failed to register alias service handler: %w", err)
 	}
+	if err := services.RegisterTargetTemplateServiceHandlerFromEndpoint(ctx, gwMux, gatewayTarget, dialOptions); err != nil {
+		return fmt.Errorf("failed to register target template service handler: %w", err)
+	}
 	if err := services.RegisterPolicyServiceHandlerFromEndpoint(ctx, gwMux, gatewayTarget, dialOptions); err != nil {
 		return fmt.Errorf("failed to register policy handler: %w", err)
 	}
@@ -45,6 +45,9 @@ const (
 	keyBitsField               = "attributes.key_bits"
 	criticalOptionsField       = "attributes.critical_options"
 	extensionsField            = "attributes.extensions"
+	mountPathField             = "attributes.mount_path"
+	roleField                  = "attributes.role"
+	commonNameField            = "attributes.common_name"
 	domain                     = "credential"
 )
 
@@ -57,8 +60,10 @@ const (
 )
 
 var (
-	maskManager        handlers.MaskManager
-	sshCertMaskManager handlers.MaskManager
+	maskManager         handlers.MaskManager
+	sshCertMaskManager  handlers.MaskManager
+	databaseMaskManager handlers.MaskManager
+	pkiMaskManager      handlers.MaskManager
 
 	// IdActions contains the set of actions that can be performed on
 	// individual resources
@@ -79,6 +84,8 @@ var (
 	validCredentialTypesVaultGeneric = []globals.CredentialType{
 		globals.UsernamePasswordCredentialType,
 		globals.SshPrivateKeyCredentialType,
+		globals.CloudAccessCredentialType,
+		globals.TlsClientCertificateCredentialType,
 		globals.UnspecifiedCredentialType,
 	}
 
@@ -105,6 +112,20 @@ func init() {
 	); err != nil {
 		panic(err)
 	}
+	if databaseMaskManager, err = handlers.NewMaskManager(
+		context.Background(),
+		handlers.MaskDestination{&store.DatabaseCredentialLibrary{}},
+		handlers.MaskSource{&pb.CredentialLibrary{}, &pb.VaultDatabaseCredentialLibraryAttributes{}},
+	); err != nil {
+		panic(err)
+	}
+	if pkiMaskManager, err = handlers.NewMaskManager(
+		context.Background(),
+		handlers.MaskDestination{&store.PkiCredentialLibrary{}},
+		handlers.MaskSource{&pb.CredentialLibrary{}, &pb.VaultPkiCredentialLibraryAttributes{}},
+	); err != nil {
+		panic(err)
+	}
 
 	// TODO: refactor to remove IdActionsMap and CollectionActions package variables
 	action.RegisterResource(resource.CredentialLibrary, IdActions, CollectionActions)
@@ -370,6 +391,24 @@ func (s Service) UpdateCredentialLibrary(ctx context.Context, req *pbs.UpdateCre
 			return nil, err
 		}
 		currentCredentialType = globals.CredentialType(cur.GetCredentialType())
+	case vault.DatabaseLibrarySubtype:
+		cur, err := repo.LookupDatabaseCredentialLibrary(ctx, req.Id)
+		if err != nil {
+			return nil, err
+		}
+		if cur == nil {
+			return nil, handlers.NotFoundErrorf("Credential Library %q doesn't exist.", req.GetId())
+		}
+		currentCredentialType = globals.CredentialType(cur.GetCredentialType())
+	case vault.PkiLibrarySubtype:
+		cur, err := repo.LookupPkiCredentialLibrary(ctx, req.Id)
+		if err != nil {
+			return nil, err
+		}
+		if cur == nil {
+			return nil, handlers.NotFoundErrorf("Credential Library %q doesn't exist.", req.GetId())
+		}
+		currentCredentialType = globals.CredentialType(cur.GetCredentialType())
 	default:
 		cur, err := repo.LookupCredentialLibrary(ctx, req.Id)
 		if err != nil {
@@ -454,6 +493,24 @@ func (s Service) getFromRepo(ctx context.Context, id string) (credential.Library
 			return nil, errors.New(ctx, errors.InvalidParameter, op, fmt.Sprintf("ssh certificate credential library %q not found", id))
 		}
 		return cs, err
+	case vault.DatabaseLibrarySubtype:
+		cs, err := repo.LookupDatabaseCredentialLibrary(ctx, id)
+		if err != nil && !errors.IsNotFoundError(err) {
+			return nil, errors.Wrap(ctx, err, op)
+		}
+		if cs == nil {
+			return nil, errors.New(ctx, errors.InvalidParameter, op, fmt.Sprintf("database credential library %q not found", id))
+		}
+		return cs, err
+	case vault.PkiLibrarySubtype:
+		cs, err := repo.LookupPkiCredentialLibrary(ctx, id)
+		if err != nil && !errors.IsNotFoundError(err) {
+			return nil, errors.Wrap(ctx, err, op)
+		}
+		if cs == nil {
+			return nil, errors.New(ctx, errors.InvalidParameter, op, fmt.Sprintf("pki credential library %q not found", id))
+		}
+		return cs, err
 	}
 	return nil, errors.New(ctx, errors.InvalidParameter, op, "unrecognized credential library subtype")
 }
@@ -479,6 +536,40 @@ func (s Service) createInRepo(ctx context.Context, scopeId string, item *pb.Cred
 			return nil, handlers.ApiErrorWithCodeAndMessage(codes.Internal, "Unable to create ssh certificate credential library but no error returned from repository.")
 		}
 		out = rl
+	case vault.DatabaseLibrarySubtype.String():
+		cl, err := toStorageVaultDatabaseLibrary(item.GetCredentialStoreId(), item)
+		if err != nil {
+			return nil, errors.Wrap(ctx, err, op)
+		}
+		repo, err := s.repoFn()
+		if err != nil {
+			return nil, errors.Wrap(ctx, err, op)
+		}
+		rl, err := repo.CreateDatabaseCredentialLibrary(ctx, scopeId, cl)
+		if err != nil {
+			return nil, errors.Wrap(ctx, err, op, errors.WithMsg("unable to create database credential library"))
+		}
+		if rl == nil {
+			return nil, handlers.ApiErrorWithCodeAndMessage(codes.Internal, "Unable to create database credential library but no error returned from repository.")
+		}
+		out = rl
+	case vault.PkiLibrarySubtype.String():
+		cl, err := toStorageVaultPkiLibrary(item.GetCredentialStoreId(), item)
+		if err != nil {
+			return nil, errors.Wrap(ctx, err, op)
+		}
+		repo, err := s.repoFn()
+		if err != nil {
+			return nil, errors.Wrap(ctx, err, op)
+		}
+		rl, err := repo.CreatePkiCredentialLibrary(ctx, scopeId, cl)
+		if err != nil {
+			return nil, errors.Wrap(ctx, err, op, errors.WithMsg("unable to create pki credential library"))
+		}
+		if rl == nil {
+			return nil, handlers.ApiErrorWithCodeAndMessage(codes.Internal, "Unable to create pki credential library but no error returned from repository.")
+		}
+		out = rl
 	default:
 		cl, err := toStorageVaultLibrary(ctx, item.GetCredentialStoreId(), item)
 		if err != nil {
@@ -555,6 +646,40 @@ func (s Service) updateInRepo(
 		if rowsUpdated == 0 {
 			return nil, handlers.NotFoundErrorf("Credential Library %q doesn't exist or incorrect version provided.", id)
 		}
+	case vault.DatabaseLibrarySubtype:
+		dbMasks = append(dbMasks, databaseMaskManager.Translate(masks)...)
+		if len(dbMasks) == 0 {
+			return nil, handlers.InvalidArgumentErrorf("No valid fields included in the update mask.", map[string]string{"update_mask": "No valid fields provided in the update mask."})
+		}
+		cl, err := toStorageVaultDatabaseLibrary(item.GetCredentialStoreId(), item)
+		if err != nil {
+			return nil, errors.Wrap(ctx, err, op)
+		}
+		cl.PublicId = id
+		out, rowsUpdated, err = repo.UpdateDatabaseCredentialLibrary(ctx, projId, cl, item.GetVersion(), dbMasks)
+		if err != nil {
+			return nil, errors.Wrap(ctx, err, op, errors.WithMsg("unable to update credential library"))
+		}
+		if rowsUpdated == 0 {
+			return nil, handlers.NotFoundErrorf("Credential Library %q doesn't exist or incorrect version provided.", id)
+		}
+	case vault.PkiLibrarySubtype:
+		dbMasks = append(dbMasks, pkiMaskManager.Translate(masks)...)
+		if len(dbMasks) == 0 {
+			return nil, handlers.InvalidArgumentErrorf("No valid fields included in the update mask.", map[string]string{"update_mask": "No valid fields provided in the update mask."})
+		}
+		cl, err := toStorageVaultPkiLibrary(item.GetCredentialStoreId(), item)
+		if err != nil {
+			return nil, errors.Wrap(ctx, err, op)
+		}
+		cl.PublicId = id
+		out, rowsUpdated, err = repo.UpdatePkiCredentialLibrary(ctx, projId, cl, item.GetVersion(), dbMasks)
+		if err != nil {
+			return nil, errors.Wrap(ctx, err, op, errors.WithMsg("unable to update credential library"))
+		}
+		if rowsUpdated == 0 {
+			return nil, handlers.NotFoundErrorf("Credential Library %q doesn't exist or incorrect version provided.", id)
+		}
 	default:
 		dbMasks = append(dbMasks, maskManager.Translate(masks)...)
 		if len(dbMasks) == 0 {
@@ -586,6 +711,10 @@ func (s Service) deleteFromRepo(ctx context.Context, scopeId, id string) (bool,
 	switch globals.ResourceInfoFromPrefix(id).Subtype {
 	case vault.SSHCertificateLibrarySubtype:
 		rows, err = repo.DeleteSSHCertificateCredentialLibrary(ctx, scopeId, id)
+	case vault.DatabaseLibrarySubtype:
+		rows, err = repo.DeleteDatabaseCredentialLibrary(ctx, scopeId, id)
+	case vault.PkiLibrarySubtype:
+		rows, err = repo.DeletePkiCredentialLibrary(ctx, scopeId, id)
 	default:
 		rows, err = repo.DeleteCredentialLibrary(ctx, scopeId, id)
 	}
@@ -638,6 +767,28 @@ func (s Service) authResult(ctx context.Context, id string, a action.Type) auth.
 				return res
 			}
 			parentId = cl.GetStoreId()
+		case vault.DatabaseLibrarySubtype:
+			cl, err := repo.LookupDatabaseCredentialLibrary(ctx, id)
+			if err != nil {
+				res.Error = err
+				return res
+			}
+			if cl == nil {
+				res.Error = handlers.NotFoundError()
+				return res
+			}
+			parentId = cl.GetStoreId()
+		case vault.PkiLibrarySubtype:
+			cl, err := repo.LookupPkiCredentialLibrary(ctx, id)
+			if err != nil {
+				res.Error = err
+				return res
+			}
+			if cl == nil {
+				res.Error = handlers.NotFoundError()
+				return res
+			}
+			parentId = cl.GetStoreId()
 		default:
 			res.Error = errors.New(ctx, errors.InvalidParameter, op, "unrecognized credential library subtype from id")
 			return res
@@ -841,7 +992,46 @@ func toProto(ctx context.Context, in credential.Library, opt ...handlers.Option)
 				VaultSshCertificateCredentialLibraryAttributes: attrs,
 			}
 		}
-
+	case vault.DatabaseLibrarySubtype:
+		vaultIn, ok := in.(*vault.DatabaseCredentialLibrary)
+		if !ok {
+			return nil, errors.New(ctx, errors.Internal, op, "unable to cast to vault database credential library")
+		}
+		// Database libraries always issue username_password credentials
+		// using the attribute names of the database secrets engine.
+		if outputFields.Has(globals.CredentialTypeField) {
+			out.CredentialType = vaultIn.GetCredentialType()
+		}
+		if outputFields.Has(globals.AttributesField) {
+			out.Attrs = &pb.CredentialLibrary_VaultDatabaseCredentialLibraryAttributes{
+				VaultDatabaseCredentialLibraryAttributes: &pb.VaultDatabaseCredentialLibraryAttributes{
+					MountPath: wrapperspb.String(vaultIn.GetMountPath()),
+					Role:      wrapperspb.String(vaultIn.GetRole()),
+				},
+			}
+		}
+	case vault.PkiLibrarySubtype:
+		vaultIn, ok := in.(*vault.PkiCredentialLibrary)
+		if !ok {
+			return nil, errors.New(ctx, errors.Internal, op, "unable to cast to vault pki credential library")
+		}
+		// Pki libraries always issue tls_client_certificate credentials.
+		if outputFields.Has(globals.CredentialTypeField) {
+			out.CredentialType = vaultIn.GetCredentialType()
+		}
+		if outputFields.Has(globals.AttributesField) {
+			attrs := &pb.VaultPkiCredentialLibraryAttributes{
+				MountPath:  wrapperspb.String(vaultIn.GetMountPath()),
+				Role:       wrapperspb.String(vaultIn.GetRole()),
+				CommonName: wrapperspb.String(vaultIn.GetCommonName()),
+			}
+			if vaultIn.GetAltNames() != "" {
+				attrs.AltNames = wrapperspb.String(vaultIn.GetAltNames())
+			}
+			out.Attrs = &pb.CredentialLibrary_VaultPkiCredentialLibraryAttributes{
+				VaultPkiCredentialLibraryAttributes: attrs,
+			}
+		}
 	}
 	return &out, nil
 }
@@ -900,6 +1090,9 @@ func toStorageVaultLibrary(ctx context.Context, storeId string, in *pb.Credentia
 		if len(mapOpts) > 0 {
 			opts = append(opts, vault.WithMappingOverride(vault.NewSshPrivateKeyOverride(mapOpts...)))
 		}
+
+	case globals.CloudAccessCredentialType, globals.TlsClientCertificateCredentialType:
+		opts = append(opts, vault.WithCredentialType(credentialType))
 	}
 
 	cs, err := vault.NewCredentialLibrary(storeId, attrs.GetPath().GetValue(), opts...)
@@ -962,6 +1155,35 @@ func toStorageVaultSSHCertificateLibrary(ctx context.Context, storeId string, in
 	return cs, err
 }
 
+func toStorageVaultDatabaseLibrary(storeId string, in *pb.CredentialLibrary) (out *vault.DatabaseCredentialLibrary, err error) {
+	var opts []vault.Option
+	if in.GetName() != nil {
+		opts = append(opts, vault.WithName(in.GetName().GetValue()))
+	}
+	if in.GetDescription() != nil {
+		opts = append(opts, vault.WithDescription(in.GetDescription().GetValue()))
+	}
+
+	attrs := in.GetVaultDatabaseCredentialLibraryAttributes()
+	return vault.NewDatabaseCredentialLibrary(storeId, attrs.GetMountPath().GetValue(), attrs.GetRole().GetValue(), opts...)
+}
+
+func toStorageVaultPkiLibrary(storeId string, in *pb.CredentialLibrary) (out *vault.PkiCredentialLibrary, err error) {
+	var opts []vault.Option
+	if in.GetName() != nil {
+		opts = append(opts, vault.WithName(in.GetName().GetValue()))
+	}
+	if in.GetDescription() != nil {
+		opts = append(opts, vault.WithDescription(in.GetDescription().GetValue()))
+	}
+
+	attrs := in.GetVaultPkiCredentialLibraryAttributes()
+	if attrs.GetAltNames().GetValue() != "" {
+		opts = append(opts, vault.WithAltNames(strings.Split(attrs.GetAltNames().GetValue(), ",")))
+	}
+	return vault.NewPkiCredentialLibrary(storeId, attrs.GetMountPath().GetValue(), attrs.GetRole().GetValue(), attrs.GetCommonName().GetValue(), opts...)
+}
+
 // A validateX method should exist for each method above.  These methods do not make calls to any backing service but enforce
 // requirements on the structure of the request.  They verify that:
 //   - The path passed in is correctly formatted
@@ -972,6 +1194,10 @@ func validateGetRequest(req *pbs.GetCredentialLibraryRequest) error {
 	switch globals.ResourceInfoFromPrefix(req.GetId()).Subtype {
 	case vault.SSHCertificateLibrarySubtype:
 		prefix = globals.VaultSshCertificateCredentialLibraryPrefix
+	case vault.DatabaseLibrarySubtype:
+		prefix = globals.VaultDatabaseCredentialLibraryPrefix
+	case vault.PkiLibrarySubtype:
+		prefix = globals.VaultPkiCredentialLibraryPrefix
 	default:
 		prefix = globals.VaultCredentialLibraryPrefix
 	}
@@ -1018,8 +1244,10 @@ func validateCreateRequest(req *pbs.CreateCredentialLibraryRequest) error {
 			}
 
 			if t != vault.GenericLibrarySubtype.String() &&
-				t != vault.SSHCertificateLibrarySubtype.String() {
-				badFields[globals.CredentialStoreIdField] = fmt.Sprintf("Type must be a vault subtype %q or %q", vault.GenericLibrarySubtype.String(), vault.SSHCertificateLibrarySubtype.String())
+				t != vault.SSHCertificateLibrarySubtype.String() &&
+				t != vault.DatabaseLibrarySubtype.String() &&
+				t != vault.PkiLibrarySubtype.String() {
+				badFields[globals.CredentialStoreIdField] = fmt.Sprintf("Type must be a vault subtype %q, %q, %q or %q", vault.GenericLibrarySubtype.String(), vault.SSHCertificateLibrarySubtype.String(), vault.DatabaseLibrarySubtype.String(), vault.PkiLibrarySubtype.String())
 			}
 
 			switch req.GetItem().GetType() {
@@ -1081,6 +1309,30 @@ func validateCreateRequest(req *pbs.CreateCredentialLibraryRequest) error {
 					badFields[keyTypeField] = "If set, value must be 'ed25519', 'ecdsa', or 'rsa'."
 				}
 				validateKeyBits(badFields, attrs.GetKeyBits().GetValue(), attrs.GetKeyType().GetValue())
+			case vault.DatabaseLibrarySubtype.String():
+				if req.GetItem().GetCredentialType() != "" && req.GetItem().GetCredentialType() != string(globals.UsernamePasswordCredentialType) {
+					badFields[globals.CredentialTypeField] = fmt.Sprintf("If set, value must be %q.", globals.UsernamePasswordCredentialType)
+				}
+				if req.GetItem().GetCredentialMappingOverrides() != nil {
+					badFields[globals.CredentialMappingOverridesField] = "This field is not supported for this credential library type."
+				}
+				attrs := req.GetItem().GetVaultDatabaseCredentialLibraryAttributes()
+				if attrs == nil {
+					badFields[attributesPathField] = "This is a required field."
+				}
+				validateDatabaseAttributes(badFields, attrs, nil)
+			case vault.PkiLibrarySubtype.String():
+				if req.GetItem().GetCredentialType() != "" && req.GetItem().GetCredentialType() != string(globals.TlsClientCertificateCredentialType) {
+					badFields[globals.CredentialTypeField] = fmt.Sprintf("If set, value must be %q.", globals.TlsClientCertificateCredentialType)
+				}
+				if req.GetItem().GetCredentialMappingOverrides() != nil {
+					badFields[globals.CredentialMappingOverridesField] = "This field is not supported for this credential library type."
+				}
+				attrs := req.GetItem().GetVaultPkiCredentialLibraryAttributes()
+				if attrs == nil {
+					badFields[attributesPathField] = "This is a required field."
+				}
+				validatePkiAttributes(badFields, attrs, nil)
 			}
 		default:
 			badFields[globals.CredentialStoreIdField] = "This field must be a valid credential store id."
@@ -1097,6 +1349,10 @@ func validateUpdateRequest(req *pbs.UpdateCredentialLibraryRequest, currentCrede
 		prefix = globals.VaultCredentialLibraryPrefix
 	case vault.SSHCertificateLibrarySubtype:
 		prefix = globals.VaultSshCertificateCredentialLibraryPrefix
+	case vault.DatabaseLibrarySubtype:
+		prefix = globals.VaultDatabaseCredentialLibraryPrefix
+	case vault.PkiLibrarySubtype:
+		prefix = globals.VaultPkiCredentialLibraryPrefix
 	}
 	return handlers.ValidateUpdateRequest(req, req.GetItem(), func() map[string]string {
 		badFields := map[string]string{}
@@ -1145,13 +1401,39 @@ func validateUpdateRequest(req *pbs.UpdateCredentialLibraryRequest, currentCrede
 				}
 				validateKeyBits(badFields, attrs.GetKeyBits().GetValue(), attrs.GetKeyType().GetValue())
 			}
+		case vault.DatabaseLibrarySubtype:
+			if req.GetItem().GetType() != "" && req.GetItem().GetType() != vault.DatabaseLibrarySubtype.String() {
+				badFields[globals.TypeField] = "Cannot modify resource type."
+			}
+			if req.GetItem().GetCredentialType() != "" && req.GetItem().GetCredentialType() != string(currentCredentialType) {
+				badFields[globals.CredentialTypeField] = "Cannot modify credential type."
+			}
+			if handlers.MaskContains(req.GetUpdateMask().GetPaths(), credentialMappingPathField) || getMapUpdate(credentialMappingPathField, req.GetUpdateMask().GetPaths()) {
+				badFields[globals.CredentialMappingOverridesField] = "This field is not supported for this credential library type."
+			}
+			if attrs := req.GetItem().GetVaultDatabaseCredentialLibraryAttributes(); attrs != nil {
+				validateDatabaseAttributes(badFields, attrs, req.GetUpdateMask().GetPaths())
+			}
+		case vault.PkiLibrarySubtype:
+			if req.GetItem().GetType() != "" && req.GetItem().GetType() != vault.PkiLibrarySubtype.String() {
+				badFields[globals.TypeField] = "Cannot modify resource type."
+			}
+			if req.GetItem().GetCredentialType() != "" && req.GetItem().GetCredentialType() != string(currentCredentialType) {
+				badFields[globals.CredentialTypeField] = "Cannot modify credential type."
+			}
+			if handlers.MaskContains(req.GetUpdateMask().GetPaths(), credentialMappingPathField) || getMapUpdate(credentialMappingPathField, req.GetUpdateMask().GetPaths()) {
+				badFields[globals.CredentialMappingOverridesField] = "This field is not supported for this credential library type."
+			}
+			if attrs := req.GetItem().GetVaultPkiCredentialLibraryAttributes(); attrs != nil {
+				validatePkiAttributes(badFields, attrs, req.GetUpdateMask().GetPaths())
+			}
 		}
 		return badFields
 	}, prefix)
 }
 
 func validateDeleteRequest(req *pbs.DeleteCredentialLibraryRequest) error {
-	return handlers.ValidateDeleteRequest(handlers.NoopValidatorFn, req, globals.VaultCredentialLibraryPrefix, globals.VaultSshCertificateCredentialLibraryPrefix)
+	return handlers.ValidateDeleteRequest(handlers.NoopValidatorFn, req, globals.VaultCredentialLibraryPrefix, globals.VaultSshCertificateCredentialLibraryPrefix, globals.VaultDatabaseCredentialLibraryPrefix, globals.VaultPkiCredentialLibraryPrefix)
 }
 
 func validateListRequest(ctx context.Context, req *pbs.ListCredentialLibrariesRequest) error {
@@ -1183,6 +1465,10 @@ func validateMapping(badFields map[string]string, credentialType globals.Credent
 		validFields[usernameAttribute] = true
 		validFields[privateKeyAttribute] = true
 		validFields[pkPassphraseAttribute] = true
+	case globals.CloudAccessCredentialType:
+		// cloud access credentials do not support mapping overrides
+	case globals.TlsClientCertificateCredentialType:
+		// tls client certificate credentials do not support mapping overrides
 	default:
 		badFields[globals.CredentialTypeField] = fmt.Sprintf("Unknown credential type %q", credentialType)
 		return
@@ -1199,6 +1485,43 @@ func validateMapping(badFields map[string]string, credentialType globals.Credent
 	}
 }
 
+// validateDatabaseAttributes appends to badFields if the mount path or role
+// of a database credential library are missing or malformed. If masks is
+// not nil, only the fields included in masks are validated.
+func validateDatabaseAttributes(badFields map[string]string, attrs *pb.VaultDatabaseCredentialLibraryAttributes, masks []string) {
+	validateMountPathAndRole(badFields, attrs.GetMountPath().GetValue(), attrs.GetRole().GetValue(), masks)
+}
+
+// validatePkiAttributes appends to badFields if the mount path, role or
+// common name of a pki credential library are missing or malformed. If
+// masks is not nil, only the fields included in masks are validated.
+func validatePkiAttributes(badFields map[string]string, attrs *pb.VaultPkiCredentialLibraryAttributes, masks []string) {
+	validateMountPathAndRole(badFields, attrs.GetMountPath().GetValue(), attrs.GetRole().GetValue(), masks)
+	if masks == nil || handlers.MaskContains(masks, commonNameField) {
+		if attrs.GetCommonName().GetValue() == "" {
+			badFields[commonNameField] = "This is a required field and cannot be set to empty."
+		}
+	}
+}
+
+// validateMountPathAndRole appends to badFields if the mount path or role
+// of a secrets engine backed credential library are missing or malformed.
+func validateMountPathAndRole(badFields map[string]string, mountPath, role string, masks []string) {
+	if masks == nil || handlers.MaskContains(masks, mountPathField) {
+		if strings.Trim(mountPath, "/") == "" {
+			badFields[mountPathField] = "This is a required field and cannot be set to empty."
+		}
+	}
+	if masks == nil || handlers.MaskContains(masks, roleField) {
+		switch {
+		case role == "":
+			badFields[roleField] = "This is a required field and cannot be set to empty."
+		case strings.Contains(role, "/"):
+			badFields[roleField] = "Must not contain a path separator."
+		}
+	}
+}
+
 // validateKeyBits appends to badFields if keyBits and keyType aren't accepted combinations for an SSHCertificateCredentialLibrary.
 // If keyType is an empty string, validateKeyBits only validates keyBits.
 func validateKeyBits(badFields map[string]string, keyBits uint32, keyType string) {
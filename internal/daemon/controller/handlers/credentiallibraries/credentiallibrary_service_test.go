@@ -2711,6 +2711,667 @@ func TestUpdate_SSHCertificateCredentialLibrary(t *testing.T) {
 	}
 }
 
+func TestCreate_DatabaseCredentialLibrary(t *testing.T) {
+	ctx := context.Background()
+	conn, _ := db.TestSetup(t, "postgres")
+	wrapper := db.TestWrapper(t)
+	kms := kms.TestKms(t, conn, wrapper)
+	sche := scheduler.TestScheduler(t, conn, wrapper)
+	rw := db.New(conn)
+
+	iamRepo := iam.TestRepo(t, conn, wrapper)
+	iamRepoFn := func() (*iam.Repository, error) {
+		return iamRepo, nil
+	}
+	repoFn := func() (*vault.Repository, error) {
+		return vault.NewRepository(ctx, rw, rw, kms, sche)
+	}
+
+	_, prj := iam.TestScopes(t, iamRepo)
+	store := vault.TestCredentialStores(t, conn, wrapper, prj.GetPublicId(), 1)[0]
+
+	dbAttrs := func(mountPath, role string) *pb.CredentialLibrary_VaultDatabaseCredentialLibraryAttributes {
+		attrs := &pb.VaultDatabaseCredentialLibraryAttributes{}
+		if mountPath != "" {
+			attrs.MountPath = wrapperspb.String(mountPath)
+		}
+		if role != "" {
+			attrs.Role = wrapperspb.String(role)
+		}
+		return &pb.CredentialLibrary_VaultDatabaseCredentialLibraryAttributes{
+			VaultDatabaseCredentialLibraryAttributes: attrs,
+		}
+	}
+
+	cases := []struct {
+		name        string
+		req         *pbs.CreateCredentialLibraryRequest
+		res         *pbs.CreateCredentialLibraryResponse
+		errContains string
+	}{
+		{
+			name: "missing mount path",
+			req: &pbs.CreateCredentialLibraryRequest{Item: &pb.CredentialLibrary{
+				CredentialStoreId: store.GetPublicId(),
+				Type:              vault.DatabaseLibrarySubtype.String(),
+				Attrs:             dbAttrs("", "readonly"),
+			}},
+			errContains: mountPathField,
+		},
+		{
+			name: "missing role",
+			req: &pbs.CreateCredentialLibraryRequest{Item: &pb.CredentialLibrary{
+				CredentialStoreId: store.GetPublicId(),
+				Type:              vault.DatabaseLibrarySubtype.String(),
+				Attrs:             dbAttrs("database", ""),
+			}},
+			errContains: roleField,
+		},
+		{
+			name: "role with path separator",
+			req: &pbs.CreateCredentialLibraryRequest{Item: &pb.CredentialLibrary{
+				CredentialStoreId: store.GetPublicId(),
+				Type:              vault.DatabaseLibrarySubtype.String(),
+				Attrs:             dbAttrs("database", "a/b"),
+			}},
+			errContains: "Must not contain a path separator.",
+		},
+		{
+			name: "invalid credential type",
+			req: &pbs.CreateCredentialLibraryRequest{Item: &pb.CredentialLibrary{
+				CredentialStoreId: store.GetPublicId(),
+				Type:              vault.DatabaseLibrarySubtype.String(),
+				CredentialType:    string(globals.SshPrivateKeyCredentialType),
+				Attrs:             dbAttrs("database", "readonly"),
+			}},
+			errContains: globals.CredentialTypeField,
+		},
+		{
+			name: "mapping overrides not supported",
+			req: &pbs.CreateCredentialLibraryRequest{Item: &pb.CredentialLibrary{
+				CredentialStoreId: store.GetPublicId(),
+				Type:              vault.DatabaseLibrarySubtype.String(),
+				CredentialMappingOverrides: func() *structpb.Struct {
+					v := map[string]any{usernameAttribute: "user"}
+					ret, err := structpb.NewStruct(v)
+					require.NoError(t, err)
+					return ret
+				}(),
+				Attrs: dbAttrs("database", "readonly"),
+			}},
+			errContains: globals.CredentialMappingOverridesField,
+		},
+		{
+			name: "valid",
+			req: &pbs.CreateCredentialLibraryRequest{Item: &pb.CredentialLibrary{
+				CredentialStoreId: store.GetPublicId(),
+				Type:              vault.DatabaseLibrarySubtype.String(),
+				Name:              wrapperspb.String("name"),
+				Description:       wrapperspb.String("desc"),
+				Attrs:             dbAttrs("/team/postgres/", "readonly"),
+			}},
+			res: &pbs.CreateCredentialLibraryResponse{
+				Uri: fmt.Sprintf("credential-libraries/%s_", globals.VaultDatabaseCredentialLibraryPrefix),
+				Item: &pb.CredentialLibrary{
+					CredentialStoreId: store.GetPublicId(),
+					Name:              wrapperspb.String("name"),
+					Description:       wrapperspb.String("desc"),
+					Scope:             &scopepb.ScopeInfo{Id: prj.GetPublicId(), Type: prj.GetType(), ParentScopeId: prj.GetParentId()},
+					Version:           1,
+					Type:              vault.DatabaseLibrarySubtype.String(),
+					Attrs:             dbAttrs("team/postgres", "readonly"),
+					AuthorizedActions: testAuthorizedActions,
+					CredentialType:    string(globals.UsernamePasswordCredentialType),
+				},
+			},
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert, require := assert.New(t), require.New(t)
+
+			s, err := NewService(ctx, iamRepoFn, repoFn, 1000)
+			require.NoError(err)
+			got, gErr := s.CreateCredentialLibrary(auth.DisabledAuthTestContext(iamRepoFn, prj.GetPublicId()), tc.req)
+			if tc.errContains != "" {
+				require.Error(gErr)
+				assert.True(errors.Is(gErr, handlers.ApiErrorWithCode(codes.InvalidArgument)), "CreateCredentialLibrary(...) got error %v, wanted invalid argument", gErr)
+				assert.Contains(gErr.Error(), tc.errContains)
+				return
+			}
+			require.NoError(gErr)
+			require.NotNil(got)
+			assert.Contains(got.GetUri(), tc.res.Uri)
+			assert.True(strings.HasPrefix(got.GetItem().GetId(), globals.VaultDatabaseCredentialLibraryPrefix+"_"))
+
+			// Clear all values which are hard to compare against.
+			got.Uri, tc.res.Uri = "", ""
+			got.Item.Id = ""
+			got.Item.CreatedTime, got.Item.UpdatedTime = nil, nil
+			assert.Empty(cmp.Diff(got, tc.res, protocmp.Transform()))
+		})
+	}
+}
+
+func TestUpdate_DatabaseCredentialLibrary(t *testing.T) {
+	testCtx := context.Background()
+	conn, _ := db.TestSetup(t, "postgres")
+	wrapper := db.TestWrapper(t)
+	kms := kms.TestKms(t, conn, wrapper)
+	sche := scheduler.TestScheduler(t, conn, wrapper)
+	rw := db.New(conn)
+
+	iamRepo := iam.TestRepo(t, conn, wrapper)
+	iamRepoFn := func() (*iam.Repository, error) {
+		return iamRepo, nil
+	}
+	repoFn := func() (*vault.Repository, error) {
+		return vault.NewRepository(testCtx, rw, rw, kms, sche)
+	}
+
+	_, prj := iam.TestScopes(t, iamRepo)
+	ctx := auth.DisabledAuthTestContext(iamRepoFn, prj.GetPublicId())
+
+	s, err := NewService(testCtx, iamRepoFn, repoFn, 1000)
+	require.NoError(t, err)
+	store := vault.TestCredentialStores(t, conn, wrapper, prj.GetPublicId(), 1)[0]
+
+	freshLibrary := func() (*vault.DatabaseCredentialLibrary, func()) {
+		repo, err := repoFn()
+		require.NoError(t, err)
+		lib, err := vault.NewDatabaseCredentialLibrary(store.GetPublicId(), "database", "readonly")
+		require.NoError(t, err)
+
+		vl, err := repo.CreateDatabaseCredentialLibrary(ctx, prj.GetPublicId(), lib)
+		require.NoError(t, err)
+		clean := func() {
+			_, err := s.DeleteCredentialLibrary(ctx, &pbs.DeleteCredentialLibraryRequest{Id: vl.GetPublicId()})
+			require.NoError(t, err)
+		}
+		return vl, clean
+	}
+
+	fieldmask := func(paths ...string) *fieldmaskpb.FieldMask {
+		return &fieldmaskpb.FieldMask{Paths: paths}
+	}
+
+	successCases := []struct {
+		name string
+		req  *pbs.UpdateCredentialLibraryRequest
+		res  func(*pb.CredentialLibrary) *pb.CredentialLibrary
+	}{
+		{
+			name: "name and description",
+			req: &pbs.UpdateCredentialLibraryRequest{
+				UpdateMask: fieldmask("name", "description"),
+				Item: &pb.CredentialLibrary{
+					Name:        wrapperspb.String("basic"),
+					Description: wrapperspb.String("basic"),
+				},
+			},
+			res: func(in *pb.CredentialLibrary) *pb.CredentialLibrary {
+				out := proto.Clone(in).(*pb.CredentialLibrary)
+				out.Name = wrapperspb.String("basic")
+				out.Description = wrapperspb.String("basic")
+				return out
+			},
+		},
+		{
+			name: "mount path and role",
+			req: &pbs.UpdateCredentialLibraryRequest{
+				UpdateMask: fieldmask(mountPathField, roleField),
+				Item: &pb.CredentialLibrary{
+					Attrs: &pb.CredentialLibrary_VaultDatabaseCredentialLibraryAttributes{
+						VaultDatabaseCredentialLibraryAttributes: &pb.VaultDatabaseCredentialLibraryAttributes{
+							MountPath: wrapperspb.String("team/postgres"),
+							Role:      wrapperspb.String("dba"),
+						},
+					},
+				},
+			},
+			res: func(in *pb.CredentialLibrary) *pb.CredentialLibrary {
+				out := proto.Clone(in).(*pb.CredentialLibrary)
+				out.GetVaultDatabaseCredentialLibraryAttributes().MountPath = wrapperspb.String("team/postgres")
+				out.GetVaultDatabaseCredentialLibraryAttributes().Role = wrapperspb.String("dba")
+				return out
+			},
+		},
+	}
+	for _, tc := range successCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert, require := assert.New(t), require.New(t)
+			l, cleanup := freshLibrary()
+			defer cleanup()
+
+			tc.req.Id = l.GetPublicId()
+			tc.req.Item.Version = 1
+			resToChange, err := s.GetCredentialLibrary(ctx, &pbs.GetCredentialLibraryRequest{Id: l.GetPublicId()})
+			require.NoError(err)
+			want := &pbs.UpdateCredentialLibraryResponse{Item: tc.res(resToChange.GetItem())}
+
+			got, gErr := s.UpdateCredentialLibrary(ctx, tc.req)
+			require.NoError(gErr)
+			require.NotNil(got)
+
+			want.Item.UpdatedTime = got.Item.UpdatedTime
+			assert.EqualValues(2, got.Item.Version)
+			want.Item.Version = 2
+			assert.Empty(cmp.Diff(got, want, protocmp.Transform()))
+		})
+	}
+
+	errCases := []struct {
+		name        string
+		req         *pbs.UpdateCredentialLibraryRequest
+		errContains string
+	}{
+		{
+			name: "clear mount path",
+			req: &pbs.UpdateCredentialLibraryRequest{
+				UpdateMask: fieldmask(mountPathField),
+				Item:       &pb.CredentialLibrary{},
+			},
+			errContains: mountPathField,
+		},
+		{
+			name: "role with path separator",
+			req: &pbs.UpdateCredentialLibraryRequest{
+				UpdateMask: fieldmask(roleField),
+				Item: &pb.CredentialLibrary{
+					Attrs: &pb.CredentialLibrary_VaultDatabaseCredentialLibraryAttributes{
+						VaultDatabaseCredentialLibraryAttributes: &pb.VaultDatabaseCredentialLibraryAttributes{
+							Role: wrapperspb.String("a/b"),
+						},
+					},
+				},
+			},
+			errContains: "Must not contain a path separator.",
+		},
+		{
+			name: "change credential type",
+			req: &pbs.UpdateCredentialLibraryRequest{
+				UpdateMask: fieldmask("credential_type"),
+				Item:       &pb.CredentialLibrary{CredentialType: string(globals.SshPrivateKeyCredentialType)},
+			},
+			errContains: "Cannot modify credential type.",
+		},
+		{
+			name: "mapping overrides",
+			req: &pbs.UpdateCredentialLibraryRequest{
+				UpdateMask: fieldmask(credentialMappingPathField + "." + usernameAttribute),
+				Item:       &pb.CredentialLibrary{},
+			},
+			errContains: globals.CredentialMappingOverridesField,
+		},
+	}
+	for _, tc := range errCases {
+		t.Run(tc.name, func(t *testing.T) {
+			l, cleanup := freshLibrary()
+			defer cleanup()
+
+			tc.req.Id = l.GetPublicId()
+			tc.req.Item.Version = 1
+			got, gErr := s.UpdateCredentialLibrary(ctx, tc.req)
+			require.Error(t, gErr)
+			assert.Truef(t, errors.Is(gErr, handlers.ApiErrorWithCode(codes.InvalidArgument)), "got error %v, wanted invalid argument", gErr)
+			assert.Contains(t, gErr.Error(), tc.errContains)
+			assert.Nil(t, got)
+		})
+	}
+}
+
+func TestCreate_PkiCredentialLibrary(t *testing.T) {
+	ctx := context.Background()
+	conn, _ := db.TestSetup(t, "postgres")
+	wrapper := db.TestWrapper(t)
+	kms := kms.TestKms(t, conn, wrapper)
+	sche := scheduler.TestScheduler(t, conn, wrapper)
+	rw := db.New(conn)
+
+	iamRepo := iam.TestRepo(t, conn, wrapper)
+	iamRepoFn := func() (*iam.Repository, error) {
+		return iamRepo, nil
+	}
+	repoFn := func() (*vault.Repository, error) {
+		return vault.NewRepository(ctx, rw, rw, kms, sche)
+	}
+
+	_, prj := iam.TestScopes(t, iamRepo)
+	store := vault.TestCredentialStores(t, conn, wrapper, prj.GetPublicId(), 1)[0]
+
+	pkiAttrs := func(mountPath, role, commonName, altNames string) *pb.CredentialLibrary_VaultPkiCredentialLibraryAttributes {
+		attrs := &pb.VaultPkiCredentialLibraryAttributes{}
+		if mountPath != "" {
+			attrs.MountPath = wrapperspb.String(mountPath)
+		}
+		if role != "" {
+			attrs.Role = wrapperspb.String(role)
+		}
+		if commonName != "" {
+			attrs.CommonName = wrapperspb.String(commonName)
+		}
+		if altNames != "" {
+			attrs.AltNames = wrapperspb.String(altNames)
+		}
+		return &pb.CredentialLibrary_VaultPkiCredentialLibraryAttributes{
+			VaultPkiCredentialLibraryAttributes: attrs,
+		}
+	}
+
+	cases := []struct {
+		name        string
+		req         *pbs.CreateCredentialLibraryRequest
+		res         *pbs.CreateCredentialLibraryResponse
+		errContains string
+	}{
+		{
+			name: "missing mount path",
+			req: &pbs.CreateCredentialLibraryRequest{Item: &pb.CredentialLibrary{
+				CredentialStoreId: store.GetPublicId(),
+				Type:              vault.PkiLibrarySubtype.String(),
+				Attrs:             pkiAttrs("", "client", "client.example.com", ""),
+			}},
+			errContains: mountPathField,
+		},
+		{
+			name: "missing role",
+			req: &pbs.CreateCredentialLibraryRequest{Item: &pb.CredentialLibrary{
+				CredentialStoreId: store.GetPublicId(),
+				Type:              vault.PkiLibrarySubtype.String(),
+				Attrs:             pkiAttrs("pki", "", "client.example.com", ""),
+			}},
+			errContains: roleField,
+		},
+		{
+			name: "role with path separator",
+			req: &pbs.CreateCredentialLibraryRequest{Item: &pb.CredentialLibrary{
+				CredentialStoreId: store.GetPublicId(),
+				Type:              vault.PkiLibrarySubtype.String(),
+				Attrs:             pkiAttrs("pki", "a/b", "client.example.com", ""),
+			}},
+			errContains: "Must not contain a path separator.",
+		},
+		{
+			name: "missing common name",
+			req: &pbs.CreateCredentialLibraryRequest{Item: &pb.CredentialLibrary{
+				CredentialStoreId: store.GetPublicId(),
+				Type:              vault.PkiLibrarySubtype.String(),
+				Attrs:             pkiAttrs("pki", "client", "", ""),
+			}},
+			errContains: commonNameField,
+		},
+		{
+			name: "invalid credential type",
+			req: &pbs.CreateCredentialLibraryRequest{Item: &pb.CredentialLibrary{
+				CredentialStoreId: store.GetPublicId(),
+				Type:              vault.PkiLibrarySubtype.String(),
+				CredentialType:    string(globals.UsernamePasswordCredentialType),
+				Attrs:             pkiAttrs("pki", "client", "client.example.com", ""),
+			}},
+			errContains: globals.CredentialTypeField,
+		},
+		{
+			name: "mapping overrides not supported",
+			req: &pbs.CreateCredentialLibraryRequest{Item: &pb.CredentialLibrary{
+				CredentialStoreId: store.GetPublicId(),
+				Type:              vault.PkiLibrarySubtype.String(),
+				CredentialMappingOverrides: func() *structpb.Struct {
+					v := map[string]any{usernameAttribute: "user"}
+					ret, err := structpb.NewStruct(v)
+					require.NoError(t, err)
+					return ret
+				}(),
+				Attrs: pkiAttrs("pki", "client", "client.example.com", ""),
+			}},
+			errContains: globals.CredentialMappingOverridesField,
+		},
+		{
+			name: "valid",
+			req: &pbs.CreateCredentialLibraryRequest{Item: &pb.CredentialLibrary{
+				CredentialStoreId: store.GetPublicId(),
+				Type:              vault.PkiLibrarySubtype.String(),
+				Name:              wrapperspb.String("name"),
+				Description:       wrapperspb.String("desc"),
+				Attrs:             pkiAttrs("/team/pki_int/", "client", "{{.User.Name}}.example.com", "a.example.com,b.example.com"),
+			}},
+			res: &pbs.CreateCredentialLibraryResponse{
+				Uri: fmt.Sprintf("credential-libraries/%s_", globals.VaultPkiCredentialLibraryPrefix),
+				Item: &pb.CredentialLibrary{
+					CredentialStoreId: store.GetPublicId(),
+					Name:              wrapperspb.String("name"),
+					Description:       wrapperspb.String("desc"),
+					Scope:             &scopepb.ScopeInfo{Id: prj.GetPublicId(), Type: prj.GetType(), ParentScopeId: prj.GetParentId()},
+					Version:           1,
+					Type:              vault.PkiLibrarySubtype.String(),
+					Attrs:             pkiAttrs("team/pki_int", "client", "{{.User.Name}}.example.com", "a.example.com,b.example.com"),
+					AuthorizedActions: testAuthorizedActions,
+					CredentialType:    string(globals.TlsClientCertificateCredentialType),
+				},
+			},
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert, require := assert.New(t), require.New(t)
+
+			s, err := NewService(ctx, iamRepoFn, repoFn, 1000)
+			require.NoError(err)
+			got, gErr := s.CreateCredentialLibrary(auth.DisabledAuthTestContext(iamRepoFn, prj.GetPublicId()), tc.req)
+			if tc.errContains != "" {
+				require.Error(gErr)
+				assert.True(errors.Is(gErr, handlers.ApiErrorWithCode(codes.InvalidArgument)), "CreateCredentialLibrary(...) got error %v, wanted invalid argument", gErr)
+				assert.Contains(gErr.Error(), tc.errContains)
+				return
+			}
+			require.NoError(gErr)
+			require.NotNil(got)
+			assert.Contains(got.GetUri(), tc.res.Uri)
+			assert.True(strings.HasPrefix(got.GetItem().GetId(), globals.VaultPkiCredentialLibraryPrefix+"_"))
+
+			// Clear all values which are hard to compare against.
+			got.Uri, tc.res.Uri = "", ""
+			got.Item.Id = ""
+			got.Item.CreatedTime, got.Item.UpdatedTime = nil, nil
+			assert.Empty(cmp.Diff(got, tc.res, protocmp.Transform()))
+		})
+	}
+}
+
+func TestUpdate_PkiCredentialLibrary(t *testing.T) {
+	testCtx := context.Background()
+	conn, _ := db.TestSetup(t, "postgres")
+	wrapper := db.TestWrapper(t)
+	kms := kms.TestKms(t, conn, wrapper)
+	sche := scheduler.TestScheduler(t, conn, wrapper)
+	rw := db.New(conn)
+
+	iamRepo := iam.TestRepo(t, conn, wrapper)
+	iamRepoFn := func() (*iam.Repository, error) {
+		return iamRepo, nil
+	}
+	repoFn := func() (*vault.Repository, error) {
+		return vault.NewRepository(testCtx, rw, rw, kms, sche)
+	}
+
+	_, prj := iam.TestScopes(t, iamRepo)
+	ctx := auth.DisabledAuthTestContext(iamRepoFn, prj.GetPublicId())
+
+	s, err := NewService(testCtx, iamRepoFn, repoFn, 1000)
+	require.NoError(t, err)
+	store := vault.TestCredentialStores(t, conn, wrapper, prj.GetPublicId(), 1)[0]
+
+	freshLibrary := func() (*vault.PkiCredentialLibrary, func()) {
+		repo, err := repoFn()
+		require.NoError(t, err)
+		lib, err := vault.NewPkiCredentialLibrary(store.GetPublicId(), "pki", "client", "client.example.com")
+		require.NoError(t, err)
+
+		vl, err := repo.CreatePkiCredentialLibrary(ctx, prj.GetPublicId(), lib)
+		require.NoError(t, err)
+		clean := func() {
+			_, err := s.DeleteCredentialLibrary(ctx, &pbs.DeleteCredentialLibraryRequest{Id: vl.GetPublicId()})
+			require.NoError(t, err)
+		}
+		return vl, clean
+	}
+
+	fieldmask := func(paths ...string) *fieldmaskpb.FieldMask {
+		return &fieldmaskpb.FieldMask{Paths: paths}
+	}
+
+	successCases := []struct {
+		name string
+		req  *pbs.UpdateCredentialLibraryRequest
+		res  func(*pb.CredentialLibrary) *pb.CredentialLibrary
+	}{
+		{
+			name: "name and description",
+			req: &pbs.UpdateCredentialLibraryRequest{
+				UpdateMask: fieldmask("name", "description"),
+				Item: &pb.CredentialLibrary{
+					Name:        wrapperspb.String("basic"),
+					Description: wrapperspb.String("basic"),
+				},
+			},
+			res: func(in *pb.CredentialLibrary) *pb.CredentialLibrary {
+				out := proto.Clone(in).(*pb.CredentialLibrary)
+				out.Name = wrapperspb.String("basic")
+				out.Description = wrapperspb.String("basic")
+				return out
+			},
+		},
+		{
+			name: "mount path and role",
+			req: &pbs.UpdateCredentialLibraryRequest{
+				UpdateMask: fieldmask(mountPathField, roleField),
+				Item: &pb.CredentialLibrary{
+					Attrs: &pb.CredentialLibrary_VaultPkiCredentialLibraryAttributes{
+						VaultPkiCredentialLibraryAttributes: &pb.VaultPkiCredentialLibraryAttributes{
+							MountPath: wrapperspb.String("team/pki_int"),
+							Role:      wrapperspb.String("dba"),
+						},
+					},
+				},
+			},
+			res: func(in *pb.CredentialLibrary) *pb.CredentialLibrary {
+				out := proto.Clone(in).(*pb.CredentialLibrary)
+				out.GetVaultPkiCredentialLibraryAttributes().MountPath = wrapperspb.String("team/pki_int")
+				out.GetVaultPkiCredentialLibraryAttributes().Role = wrapperspb.String("dba")
+				return out
+			},
+		},
+		{
+			name: "common name and alt names",
+			req: &pbs.UpdateCredentialLibraryRequest{
+				UpdateMask: fieldmask(commonNameField, "attributes.alt_names"),
+				Item: &pb.CredentialLibrary{
+					Attrs: &pb.CredentialLibrary_VaultPkiCredentialLibraryAttributes{
+						VaultPkiCredentialLibraryAttributes: &pb.VaultPkiCredentialLibraryAttributes{
+							CommonName: wrapperspb.String("dba.example.com"),
+							AltNames:   wrapperspb.String("a.example.com"),
+						},
+					},
+				},
+			},
+			res: func(in *pb.CredentialLibrary) *pb.CredentialLibrary {
+				out := proto.Clone(in).(*pb.CredentialLibrary)
+				out.GetVaultPkiCredentialLibraryAttributes().CommonName = wrapperspb.String("dba.example.com")
+				out.GetVaultPkiCredentialLibraryAttributes().AltNames = wrapperspb.String("a.example.com")
+				return out
+			},
+		},
+	}
+	for _, tc := range successCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert, require := assert.New(t), require.New(t)
+			l, cleanup := freshLibrary()
+			defer cleanup()
+
+			tc.req.Id = l.GetPublicId()
+			tc.req.Item.Version = 1
+			resToChange, err := s.GetCredentialLibrary(ctx, &pbs.GetCredentialLibraryRequest{Id: l.GetPublicId()})
+			require.NoError(err)
+			want := &pbs.UpdateCredentialLibraryResponse{Item: tc.res(resToChange.GetItem())}
+
+			got, gErr := s.UpdateCredentialLibrary(ctx, tc.req)
+			require.NoError(gErr)
+			require.NotNil(got)
+
+			want.Item.UpdatedTime = got.Item.UpdatedTime
+			assert.EqualValues(2, got.Item.Version)
+			want.Item.Version = 2
+			assert.Empty(cmp.Diff(got, want, protocmp.Transform()))
+		})
+	}
+
+	errCases := []struct {
+		name        string
+		req         *pbs.UpdateCredentialLibraryRequest
+		errContains string
+	}{
+		{
+			name: "clear mount path",
+			req: &pbs.UpdateCredentialLibraryRequest{
+				UpdateMask: fieldmask(mountPathField),
+				Item:       &pb.CredentialLibrary{},
+			},
+			errContains: mountPathField,
+		},
+		{
+			name: "role with path separator",
+			req: &pbs.UpdateCredentialLibraryRequest{
+				UpdateMask: fieldmask(roleField),
+				Item: &pb.CredentialLibrary{
+					Attrs: &pb.CredentialLibrary_VaultPkiCredentialLibraryAttributes{
+						VaultPkiCredentialLibraryAttributes: &pb.VaultPkiCredentialLibraryAttributes{
+							Role: wrapperspb.String("a/b"),
+						},
+					},
+				},
+			},
+			errContains: "Must not contain a path separator.",
+		},
+		{
+			name: "clear common name",
+			req: &pbs.UpdateCredentialLibraryRequest{
+				UpdateMask: fieldmask(commonNameField),
+				Item:       &pb.CredentialLibrary{},
+			},
+			errContains: commonNameField,
+		},
+		{
+			name: "change credential type",
+			req: &pbs.UpdateCredentialLibraryRequest{
+				UpdateMask: fieldmask("credential_type"),
+				Item:       &pb.CredentialLibrary{CredentialType: string(globals.SshPrivateKeyCredentialType)},
+			},
+			errContains: "Cannot modify credential type.",
+		},
+		{
+			name: "mapping overrides",
+			req: &pbs.UpdateCredentialLibraryRequest{
+				UpdateMask: fieldmask(credentialMappingPathField + "." + usernameAttribute),
+				Item:       &pb.CredentialLibrary{},
+			},
+			errContains: globals.CredentialMappingOverridesField,
+		},
+	}
+	for _, tc := range errCases {
+		t.Run(tc.name, func(t *testing.T) {
+			l, cleanup := freshLibrary()
+			defer cleanup()
+
+			tc.req.Id = l.GetPublicId()
+			tc.req.Item.Version = 1
+			got, gErr := s.UpdateCredentialLibrary(ctx, tc.req)
+			require.Error(t, gErr)
+			assert.Truef(t, errors.Is(gErr, handlers.ApiErrorWithCode(codes.InvalidArgument)), "got error %v, wanted invalid argument", gErr)
+			assert.Contains(t, gErr.Error(), tc.errContains)
+			assert.Nil(t, got)
+		})
+	}
+}
+
 func TestListPagination(t *testing.T) {
 	// Set database read timeout to avoid duplicates in response
 	oldReadTimeout := globals.RefreshReadLookbackDuration
@@ -5,10 +5,13 @@ package credentials
 
 import (
 	"context"
+	"crypto/tls"
 	"crypto/x509"
 	"encoding/base64"
 	"encoding/json"
 	"fmt"
+	"strings"
+	"time"
 
 	"github.com/hashicorp/boundary/globals"
 	"github.com/hashicorp/boundary/internal/credential"
@@ -17,6 +20,7 @@ import (
 	"github.com/hashicorp/boundary/internal/daemon/controller/auth"
 	"github.com/hashicorp/boundary/internal/daemon/controller/common"
 	"github.com/hashicorp/boundary/internal/daemon/controller/handlers"
+	"github.com/hashicorp/boundary/internal/db/timestamp"
 	"github.com/hashicorp/boundary/internal/errors"
 	pbs "github.com/hashicorp/boundary/internal/gen/controller/api/services"
 	"github.com/hashicorp/boundary/internal/listtoken"
@@ -40,6 +44,8 @@ const (
 	privateKeyField           = "attributes.private_key"
 	privateKeyPassphraseField = "attributes.private_key_passphrase"
 	objectField               = "attributes.object"
+	certificateField          = "attributes.certificate"
+	caCertificateField        = "attributes.ca_certificate"
 	domain                    = "credential"
 )
 
@@ -47,6 +53,7 @@ var (
 	upMaskManager   handlers.MaskManager
 	spkMaskManager  handlers.MaskManager
 	jsonMaskManager handlers.MaskManager
+	tlsMaskManager  handlers.MaskManager
 
 	// IdActions contains the set of actions that can be performed on
 	// individual resources
@@ -55,6 +62,7 @@ var (
 		action.Read,
 		action.Update,
 		action.Delete,
+		action.ReadUsage,
 	)
 
 	// CollectionActions contains the set of actions that can be performed on
@@ -88,6 +96,13 @@ func init() {
 	); err != nil {
 		panic(err)
 	}
+	if tlsMaskManager, err = handlers.NewMaskManager(
+		context.Background(),
+		handlers.MaskDestination{&store.TlsClientCertificateCredential{}},
+		handlers.MaskSource{&pb.Credential{}, &pb.TlsClientCertificateAttributes{}},
+	); err != nil {
+		panic(err)
+	}
 
 	// TODO: refactor to remove IdActionsMap and CollectionActions package variables
 	action.RegisterResource(resource.Credential, IdActions, CollectionActions)
@@ -392,6 +407,109 @@ func (s Service) DeleteCredential(ctx context.Context, req *pbs.DeleteCredential
 	return nil, nil
 }
 
+// ReadCredentialUsage implements the interface pbs.CredentialServiceServer.
+func (s Service) ReadCredentialUsage(ctx context.Context, req *pbs.ReadCredentialUsageRequest) (*pbs.ReadCredentialUsageResponse, error) {
+	const op = "credentials.(Service).ReadCredentialUsage"
+	if err := validateReadUsageRequest(req); err != nil {
+		return nil, err
+	}
+	authResults := s.authResult(ctx, req.GetId(), action.ReadUsage)
+	if authResults.Error != nil {
+		return nil, authResults.Error
+	}
+	repo, err := s.repoFn()
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	grantsHash, err := authResults.GrantsHash(ctx)
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	pageSize := int(s.maxPageSize)
+	// Use the requested page size only if it is smaller than
+	// the configured max.
+	if req.GetPageSize() != 0 && uint(req.GetPageSize()) < s.maxPageSize {
+		pageSize = int(req.GetPageSize())
+	}
+
+	// One more item than the page size is requested to find out whether
+	// this is the last page.
+	opts := []static.Option{static.WithLimit(pageSize + 1)}
+	tokenCreateTime := time.Now()
+	if req.GetListToken() != "" {
+		listToken, err := handlers.ParseListToken(ctx, req.GetListToken(), resource.Credential, grantsHash)
+		if err != nil {
+			return nil, err
+		}
+		st, ok := listToken.Subtype.(*listtoken.PaginationToken)
+		if !ok {
+			return nil, handlers.ApiErrorWithCodeAndMessage(codes.InvalidArgument, "unexpected list token subtype: %T", listToken.Subtype)
+		}
+		after, err := usageFromListToken(st, req.GetId())
+		if err != nil {
+			return nil, err
+		}
+		opts = append(opts, static.WithStartPageAfterUsage(after))
+		tokenCreateTime = listToken.CreateTime
+	}
+	usage, err := repo.ListCredentialUsage(ctx, req.GetId(), opts...)
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+
+	resp := &pbs.ReadCredentialUsageResponse{
+		ResponseType: "complete",
+		SortBy:       "created_time",
+		SortDir:      "desc",
+	}
+	if len(usage) > pageSize {
+		usage = usage[:pageSize]
+		last := usage[len(usage)-1]
+		listToken, err := listtoken.NewPagination(ctx, tokenCreateTime, resource.Credential, grantsHash,
+			usageListTokenId(last), last.CreateTime.AsTime())
+		if err != nil {
+			return nil, errors.Wrap(ctx, err, op)
+		}
+		resp.ListToken, err = handlers.MarshalListToken(ctx, listToken, pbs.ResourceType_RESOURCE_TYPE_CREDENTIAL)
+		if err != nil {
+			return nil, errors.Wrap(ctx, err, op)
+		}
+		resp.ResponseType = "delta"
+	}
+	resp.Items = make([]*pb.CredentialUsage, 0, len(usage))
+	for _, u := range usage {
+		resp.Items = append(resp.Items, toUsageProto(u))
+	}
+	return resp, nil
+}
+
+// usageListTokenId returns the item id stored in a list token to continue
+// listing the usage records of a credential after u. Usage records have no
+// id of their own and are identified by their credential, session and
+// purpose. The credential id binds the token to the credential it was
+// created for.
+func usageListTokenId(u *credential.Usage) string {
+	return strings.Join([]string{u.CredentialId, u.SessionId, string(u.CredentialPurpose)}, ":")
+}
+
+// usageFromListToken returns the usage record a list token created by
+// ReadCredentialUsage for credentialId refers to.
+func usageFromListToken(st *listtoken.PaginationToken, credentialId string) (*credential.Usage, error) {
+	parts := strings.Split(st.LastItemId, ":")
+	if len(parts) != 3 || parts[1] == "" || parts[2] == "" {
+		return nil, handlers.InvalidArgumentErrorf("Invalid list token.", map[string]string{"list_token": "The list token was not created for credential usage."})
+	}
+	if parts[0] != credentialId {
+		return nil, handlers.InvalidArgumentErrorf("Invalid list token.", map[string]string{"list_token": "The list token was not created for this credential."})
+	}
+	return &credential.Usage{
+		CredentialId:      parts[0],
+		SessionId:         parts[1],
+		CredentialPurpose: credential.Purpose(parts[2]),
+		CreateTime:        timestamp.New(st.LastItemCreateTime),
+	}, nil
+}
+
 func (s Service) getFromRepo(ctx context.Context, id string) (credential.Static, error) {
 	const op = "credentials.(Service).getFromRepo"
 	repo, err := s.repoFn()
@@ -462,6 +580,23 @@ func (s Service) createInRepo(ctx context.Context, scopeId string, item *pb.Cred
 			return nil, handlers.ApiErrorWithCodeAndMessage(codes.Internal, "Unable to create credential but no error returned from repository.")
 		}
 		return out, nil
+	case credential.TlsClientCertificateSubtype.String():
+		cred, err := toTlsClientCertificateStorageCredential(ctx, item.GetCredentialStoreId(), item)
+		if err != nil {
+			return nil, errors.Wrap(ctx, err, op)
+		}
+		repo, err := s.repoFn()
+		if err != nil {
+			return nil, errors.Wrap(ctx, err, op)
+		}
+		out, err := repo.CreateTlsClientCertificateCredential(ctx, scopeId, cred)
+		if err != nil {
+			return nil, errors.Wrap(ctx, err, op, errors.WithMsg("unable to create credential"))
+		}
+		if out == nil {
+			return nil, handlers.ApiErrorWithCodeAndMessage(codes.Internal, "Unable to create credential but no error returned from repository.")
+		}
+		return out, nil
 	default:
 		return nil, handlers.ApiErrorWithCodeAndMessage(codes.Internal, fmt.Sprintf("Unsupported credential type %q", item.GetType()))
 	}
@@ -561,6 +696,40 @@ func (s Service) updateInRepo(
 		}
 		return out, nil
 
+	case credential.TlsClientCertificateSubtype:
+		dbMasks = append(dbMasks, tlsMaskManager.Translate(masks)...)
+		if len(dbMasks) == 0 {
+			return nil, handlers.InvalidArgumentErrorf("No valid fields included in the update mask.", map[string]string{"update_mask": "No valid fields provided in the update mask."})
+		}
+
+		cred, err := toTlsClientCertificateStorageCredential(ctx, storeId, in)
+		if err != nil {
+			return nil, errors.Wrap(ctx, err, op, errors.WithMsg("unable to convert to tls client certificate storage credential"))
+		}
+		cred.PublicId = id
+		repo, err := s.repoFn()
+		if err != nil {
+			return nil, errors.Wrap(ctx, err, op)
+		}
+		out, rowsUpdated, err := repo.UpdateTlsClientCertificateCredential(ctx, scopeId, cred, item.GetVersion(), dbMasks)
+		if err != nil {
+			if errors.Match(errors.T(errors.InvalidParameter), err) {
+				// the updated certificate or private key does not match the
+				// stored counterpart
+				field := privateKeyField
+				if handlers.MaskContains(masks, certificateField) {
+					field = certificateField
+				}
+				return nil, handlers.InvalidArgumentErrorf("Error in provided request.",
+					map[string]string{field: "Certificate and private key do not match."})
+			}
+			return nil, errors.Wrap(ctx, err, op, errors.WithMsg("unable to update credential"))
+		}
+		if rowsUpdated == 0 {
+			return nil, handlers.NotFoundErrorf("Credential %q doesn't exist or incorrect version provided.", id)
+		}
+		return out, nil
+
 	default:
 		return nil, handlers.ApiErrorWithCodeAndMessage(codes.Internal, fmt.Sprintf("Unsupported credential type %q", item.GetType()))
 
@@ -662,6 +831,18 @@ func newOutputOpts(
 	return outputOpts, true
 }
 
+func toUsageProto(in *credential.Usage) *pb.CredentialUsage {
+	return &pb.CredentialUsage{
+		CredentialId: in.CredentialId,
+		SessionId:    in.SessionId,
+		UserId:       in.UserId,
+		TargetId:     in.TargetId,
+		ScopeId:      in.ProjectId,
+		Purpose:      string(in.CredentialPurpose),
+		CreatedTime:  in.CreateTime.GetTimestamp(),
+	}
+}
+
 func toProto(in credential.Static, opt ...handlers.Option) (*pb.Credential, error) {
 	opts := handlers.GetOpts(opt...)
 	if opts.WithOutputFields == nil {
@@ -684,6 +865,8 @@ func toProto(in credential.Static, opt ...handlers.Option) (*pb.Credential, erro
 			out.Type = credential.SshPrivateKeySubtype.String()
 		case *static.JsonCredential:
 			out.Type = credential.JsonSubtype.String()
+		case *static.TlsClientCertificateCredential:
+			out.Type = credential.TlsClientCertificateSubtype.String()
 		}
 	}
 	if outputFields.Has(globals.DescriptionField) && in.GetDescription() != "" {
@@ -736,6 +919,21 @@ func toProto(in credential.Static, opt ...handlers.Option) (*pb.Credential, erro
 				},
 			}
 		}
+	case *static.TlsClientCertificateCredential:
+		if outputFields.Has(globals.AttributesField) {
+			attrs := &pb.TlsClientCertificateAttributes{
+				PrivateKeyHmac: base64.RawURLEncoding.EncodeToString(cred.GetPrivateKeyHmac()),
+			}
+			if len(cred.GetCertificate()) > 0 {
+				attrs.Certificate = wrapperspb.String(string(cred.GetCertificate()))
+			}
+			if len(cred.GetCaCertificate()) > 0 {
+				attrs.CaCertificate = wrapperspb.String(string(cred.GetCaCertificate()))
+			}
+			out.Attrs = &pb.Credential_TlsClientCertificateAttributes{
+				TlsClientCertificateAttributes: attrs,
+			}
+		}
 	}
 	return &out, nil
 }
@@ -822,6 +1020,65 @@ func toJsonStorageCredential(ctx context.Context, storeId string, in *pb.Credent
 	return cs, err
 }
 
+func toTlsClientCertificateStorageCredential(ctx context.Context, storeId string, in *pb.Credential) (out *static.TlsClientCertificateCredential, err error) {
+	const op = "credentials.toTlsClientCertificateStorageCredential"
+	var opts []static.Option
+	if in.GetName() != nil {
+		opts = append(opts, static.WithName(in.GetName().GetValue()))
+	}
+	if in.GetDescription() != nil {
+		opts = append(opts, static.WithDescription(in.GetDescription().GetValue()))
+	}
+
+	attrs := in.GetTlsClientCertificateAttributes()
+	if attrs.GetCaCertificate() != nil {
+		opts = append(opts, static.WithCaCertificate([]byte(attrs.GetCaCertificate().GetValue())))
+	}
+	cs, err := static.NewTlsClientCertificateCredential(
+		ctx,
+		storeId,
+		[]byte(attrs.GetCertificate().GetValue()),
+		credential.PrivateKey(attrs.GetPrivateKey().GetValue()),
+		opts...)
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op, errors.WithMsg("unable to build credential"))
+	}
+
+	return cs, err
+}
+
+// validateTlsClientCertificateAttributes validates the certificate, private
+// key and CA certificate values of attrs. Only the fields for which check
+// returns true are validated.
+func validateTlsClientCertificateAttributes(attrs *pb.TlsClientCertificateAttributes, badFields map[string]string, check func(string) bool) {
+	cert := attrs.GetCertificate().GetValue()
+	key := attrs.GetPrivateKey().GetValue()
+	var certOk bool
+	if check(certificateField) {
+		switch {
+		case cert == "":
+			badFields[certificateField] = "This is a required field and cannot be set to empty."
+		case !x509.NewCertPool().AppendCertsFromPEM([]byte(cert)):
+			badFields[certificateField] = "Unable to parse given certificate value."
+		default:
+			certOk = true
+		}
+	}
+	if check(privateKeyField) && key == "" {
+		badFields[privateKeyField] = "This is a required field and cannot be set to empty."
+	}
+	if certOk && check(privateKeyField) && key != "" {
+		if _, err := tls.X509KeyPair([]byte(cert), []byte(key)); err != nil {
+			badFields[privateKeyField] = fmt.Sprintf("Private key does not match the given certificate: %v.", err)
+		}
+	}
+	if ca := attrs.GetCaCertificate().GetValue(); check(caCertificateField) && ca != "" {
+		if !x509.NewCertPool().AppendCertsFromPEM([]byte(ca)) {
+			badFields[caCertificateField] = "Unable to parse given CA certificate value."
+		}
+	}
+}
+
 // A validateX method should exist for each method above.  These methods do not make calls to any backing service but enforce
 // requirements on the structure of the request.  They verify that:
 //   - The path passed in is correctly formatted
@@ -835,6 +1092,19 @@ func validateGetRequest(req *pbs.GetCredentialRequest) error {
 		globals.UsernamePasswordCredentialPreviousPrefix,
 		globals.SshPrivateKeyCredentialPrefix,
 		globals.JsonCredentialPrefix,
+		globals.TlsClientCertificateCredentialPrefix,
+	)
+}
+
+func validateReadUsageRequest(req *pbs.ReadCredentialUsageRequest) error {
+	return handlers.ValidateGetRequest(
+		handlers.NoopValidatorFn,
+		req,
+		globals.UsernamePasswordCredentialPrefix,
+		globals.UsernamePasswordCredentialPreviousPrefix,
+		globals.SshPrivateKeyCredentialPrefix,
+		globals.JsonCredentialPrefix,
+		globals.TlsClientCertificateCredentialPrefix,
 	)
 }
 
@@ -891,6 +1161,9 @@ func validateCreateRequest(req *pbs.CreateCredentialRequest) error {
 				badFields[objectField] = "Unable to parse given json value"
 			}
 
+		case credential.TlsClientCertificateSubtype.String():
+			validateTlsClientCertificateAttributes(req.GetItem().GetTlsClientCertificateAttributes(), badFields, func(string) bool { return true })
+
 		default:
 			badFields[globals.TypeField] = fmt.Sprintf("Unsupported credential type %q", req.Item.GetType())
 		}
@@ -954,6 +1227,11 @@ func validateUpdateRequest(req *pbs.UpdateCredentialRequest) error {
 				}
 			}
 
+		case credential.TlsClientCertificateSubtype:
+			validateTlsClientCertificateAttributes(req.GetItem().GetTlsClientCertificateAttributes(), badFields, func(f string) bool {
+				return handlers.MaskContains(req.GetUpdateMask().GetPaths(), f)
+			})
+
 		default:
 			badFields[globals.IdField] = "Unknown credential type."
 		}
@@ -964,6 +1242,7 @@ func validateUpdateRequest(req *pbs.UpdateCredentialRequest) error {
 		globals.UsernamePasswordCredentialPreviousPrefix,
 		globals.SshPrivateKeyCredentialPrefix,
 		globals.JsonCredentialPrefix,
+		globals.TlsClientCertificateCredentialPrefix,
 	)
 }
 
@@ -975,6 +1254,7 @@ func validateDeleteRequest(req *pbs.DeleteCredentialRequest) error {
 		globals.UsernamePasswordCredentialPreviousPrefix,
 		globals.SshPrivateKeyCredentialPrefix,
 		globals.JsonCredentialPrefix,
+		globals.TlsClientCertificateCredentialPrefix,
 	)
 }
 
@@ -48,7 +48,7 @@ import (
 	"google.golang.org/protobuf/types/known/wrapperspb"
 )
 
-var testAuthorizedActions = []string{"no-op", "read", "update", "delete"}
+var testAuthorizedActions = []string{"no-op", "read", "update", "delete", "read-usage"}
 
 func staticJsonCredentialToProto(cred *static.JsonCredential, prj *iam.Scope, hmac string) *pb.Credential {
 	return &pb.Credential{
@@ -605,6 +605,8 @@ func TestCreate(t *testing.T) {
 	store := static.TestCredentialStore(t, conn, wrapper, prj.GetPublicId())
 
 	obj, objBytes := static.TestJsonObject(t)
+	tlsCert, tlsKey, _ := static.TestTlsClientCertificate(t)
+	_, otherTlsKey, _ := static.TestTlsClientCertificate(t)
 
 	cases := []struct {
 		name     string
@@ -744,6 +746,35 @@ func TestCreate(t *testing.T) {
 			res: nil,
 			err: handlers.ApiErrorWithCode(codes.InvalidArgument),
 		},
+		{
+			name: "Must provide certificate",
+			req: &pbs.CreateCredentialRequest{Item: &pb.Credential{
+				CredentialStoreId: store.GetPublicId(),
+				Type:              credential.TlsClientCertificateSubtype.String(),
+				Attrs: &pb.Credential_TlsClientCertificateAttributes{
+					TlsClientCertificateAttributes: &pb.TlsClientCertificateAttributes{
+						PrivateKey: wrapperspb.String(string(tlsKey)),
+					},
+				},
+			}},
+			res: nil,
+			err: handlers.ApiErrorWithCode(codes.InvalidArgument),
+		},
+		{
+			name: "Private key must match certificate",
+			req: &pbs.CreateCredentialRequest{Item: &pb.Credential{
+				CredentialStoreId: store.GetPublicId(),
+				Type:              credential.TlsClientCertificateSubtype.String(),
+				Attrs: &pb.Credential_TlsClientCertificateAttributes{
+					TlsClientCertificateAttributes: &pb.TlsClientCertificateAttributes{
+						Certificate: wrapperspb.String(string(tlsCert)),
+						PrivateKey:  wrapperspb.String(string(otherTlsKey)),
+					},
+				},
+			}},
+			res: nil,
+			err: handlers.ApiErrorWithCode(codes.InvalidArgument),
+		},
 		{
 			name: "valid-up",
 			req: &pbs.CreateCredentialRequest{Item: &pb.Credential{
@@ -1852,3 +1883,208 @@ func TestListPagination(t *testing.T) {
 	require.Error(err)
 	assert.Equal(handlers.ForbiddenError(), err)
 }
+
+func TestUpdate_TlsClientCertificateKeyPair(t *testing.T) {
+	conn, _ := db.TestSetup(t, "postgres")
+	wrapper := db.TestWrapper(t)
+	kkms := kms.TestKms(t, conn, wrapper)
+	rw := db.New(conn)
+
+	iamRepo := iam.TestRepo(t, conn, wrapper)
+	iamRepoFn := func() (*iam.Repository, error) {
+		return iamRepo, nil
+	}
+	staticRepoFn := func() (*static.Repository, error) {
+		return static.NewRepository(context.Background(), rw, rw, kkms)
+	}
+
+	_, prj := iam.TestScopes(t, iamRepo)
+	ctx := auth.DisabledAuthTestContext(iamRepoFn, prj.GetPublicId())
+	s, err := NewService(ctx, iamRepoFn, staticRepoFn, 1000)
+	require.NoError(t, err)
+
+	store := static.TestCredentialStore(t, conn, wrapper, prj.GetPublicId())
+	cert, key, _ := static.TestTlsClientCertificate(t)
+	otherCert, otherKey, _ := static.TestTlsClientCertificate(t)
+
+	cases := []struct {
+		name        string
+		mask        string
+		attrs       *pb.TlsClientCertificateAttributes
+		errContains string
+	}{
+		{
+			name:        "mismatched-private-key",
+			mask:        privateKeyField,
+			attrs:       &pb.TlsClientCertificateAttributes{PrivateKey: wrapperspb.String(string(otherKey))},
+			errContains: privateKeyField,
+		},
+		{
+			name:        "mismatched-certificate",
+			mask:        certificateField,
+			attrs:       &pb.TlsClientCertificateAttributes{Certificate: wrapperspb.String(string(otherCert))},
+			errContains: certificateField,
+		},
+		{
+			name:  "matching-private-key",
+			mask:  privateKeyField,
+			attrs: &pb.TlsClientCertificateAttributes{PrivateKey: wrapperspb.String(string(key))},
+		},
+		{
+			name:  "matching-certificate",
+			mask:  certificateField,
+			attrs: &pb.TlsClientCertificateAttributes{Certificate: wrapperspb.String(string(cert))},
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert, require := assert.New(t), require.New(t)
+			cred := static.TestTlsClientCertificateCredential(t, conn, wrapper, cert, key, store.GetPublicId(), prj.GetPublicId())
+			defer func() {
+				_, err := s.DeleteCredential(ctx, &pbs.DeleteCredentialRequest{Id: cred.GetPublicId()})
+				require.NoError(err)
+			}()
+
+			got, err := s.UpdateCredential(ctx, &pbs.UpdateCredentialRequest{
+				Id:         cred.GetPublicId(),
+				UpdateMask: &fieldmaskpb.FieldMask{Paths: []string{tc.mask}},
+				Item: &pb.Credential{
+					Version: cred.GetVersion(),
+					Attrs: &pb.Credential_TlsClientCertificateAttributes{
+						TlsClientCertificateAttributes: tc.attrs,
+					},
+				},
+			})
+			if tc.errContains != "" {
+				require.Error(err)
+				assert.Truef(errors.Is(err, handlers.ApiErrorWithCode(codes.InvalidArgument)), "got error %v, wanted invalid argument", err)
+				assert.Contains(err.Error(), tc.errContains)
+				assert.Nil(got)
+				return
+			}
+			require.NoError(err)
+			assert.Equal(cred.GetVersion()+1, got.GetItem().GetVersion())
+		})
+	}
+}
+
+func TestReadCredentialUsage(t *testing.T) {
+	ctx := context.Background()
+	conn, _ := db.TestSetup(t, "postgres")
+	wrapper := db.TestWrapper(t)
+	kkms := kms.TestKms(t, conn, wrapper)
+	rw := db.New(conn)
+
+	iamRepo := iam.TestRepo(t, conn, wrapper)
+	iamRepoFn := func() (*iam.Repository, error) {
+		return iamRepo, nil
+	}
+	staticRepoFn := func() (*static.Repository, error) {
+		return static.NewRepository(ctx, rw, rw, kkms)
+	}
+
+	_, prj := iam.TestScopes(t, iamRepo)
+	s, err := NewService(ctx, iamRepoFn, staticRepoFn, 1000)
+	require.NoError(t, err)
+
+	store := static.TestCredentialStore(t, conn, wrapper, prj.GetPublicId())
+	cred := static.TestUsernamePasswordCredential(t, conn, wrapper, "user", "pass", store.GetPublicId(), prj.GetPublicId())
+	other := static.TestUsernamePasswordCredential(t, conn, wrapper, "other", "pass", store.GetPublicId(), prj.GetPublicId())
+	unused := static.TestUsernamePasswordCredential(t, conn, wrapper, "unused", "pass", store.GetPublicId(), prj.GetPublicId())
+
+	// Usage records are normally written by the database when a credential
+	// is bound to a session. They have no foreign keys so they can be
+	// written directly here.
+	usage := func(credId, sessionId string) {
+		require.NoError(t, rw.Create(ctx, &credential.Usage{
+			SessionId:         sessionId,
+			CredentialId:      credId,
+			CredentialPurpose: credential.BrokeredPurpose,
+			UserId:            "u_1234567890",
+			TargetId:          "ttcp_1234567890",
+			ProjectId:         prj.GetPublicId(),
+		}))
+	}
+	usage(cred.GetPublicId(), "s_1111111111")
+	usage(cred.GetPublicId(), "s_2222222222")
+	usage(cred.GetPublicId(), "s_3333333333")
+	usage(other.GetPublicId(), "s_1111111111")
+
+	sessionIds := func(items []*pb.CredentialUsage) []string {
+		var ids []string
+		for _, u := range items {
+			assert.Equal(t, cred.GetPublicId(), u.GetCredentialId())
+			assert.Equal(t, string(credential.BrokeredPurpose), u.GetPurpose())
+			assert.Equal(t, "u_1234567890", u.GetUserId())
+			assert.Equal(t, "ttcp_1234567890", u.GetTargetId())
+			assert.Equal(t, prj.GetPublicId(), u.GetScopeId())
+			assert.NotNil(t, u.GetCreatedTime())
+			ids = append(ids, u.GetSessionId())
+		}
+		return ids
+	}
+
+	t.Run("invalid-id", func(t *testing.T) {
+		got, err := s.ReadCredentialUsage(auth.DisabledAuthTestContext(iamRepoFn, prj.GetPublicId()), &pbs.ReadCredentialUsageRequest{Id: "j_1234567890"})
+		require.Error(t, err)
+		assert.Truef(t, errors.Is(err, handlers.ApiErrorWithCode(codes.InvalidArgument)), "got error %v, wanted invalid argument", err)
+		assert.Nil(t, got)
+	})
+	t.Run("unused", func(t *testing.T) {
+		got, err := s.ReadCredentialUsage(auth.DisabledAuthTestContext(iamRepoFn, prj.GetPublicId()), &pbs.ReadCredentialUsageRequest{Id: unused.GetPublicId()})
+		require.NoError(t, err)
+		assert.Empty(t, got.GetItems())
+		assert.Equal(t, "complete", got.GetResponseType())
+		assert.Empty(t, got.GetListToken())
+	})
+	t.Run("all", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		got, err := s.ReadCredentialUsage(auth.DisabledAuthTestContext(iamRepoFn, prj.GetPublicId()), &pbs.ReadCredentialUsageRequest{Id: cred.GetPublicId()})
+		require.NoError(err)
+		assert.Equal([]string{"s_3333333333", "s_2222222222", "s_1111111111"}, sessionIds(got.GetItems()))
+		assert.Equal("complete", got.GetResponseType())
+		assert.Equal("created_time", got.GetSortBy())
+		assert.Equal("desc", got.GetSortDir())
+		assert.Empty(got.GetListToken())
+	})
+	t.Run("paginated", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		got, err := s.ReadCredentialUsage(auth.DisabledAuthTestContext(iamRepoFn, prj.GetPublicId()), &pbs.ReadCredentialUsageRequest{
+			Id:       cred.GetPublicId(),
+			PageSize: 2,
+		})
+		require.NoError(err)
+		assert.Equal([]string{"s_3333333333", "s_2222222222"}, sessionIds(got.GetItems()))
+		assert.Equal("delta", got.GetResponseType())
+		require.NotEmpty(got.GetListToken())
+
+		got, err = s.ReadCredentialUsage(auth.DisabledAuthTestContext(iamRepoFn, prj.GetPublicId()), &pbs.ReadCredentialUsageRequest{
+			Id:        cred.GetPublicId(),
+			PageSize:  2,
+			ListToken: got.GetListToken(),
+		})
+		require.NoError(err)
+		assert.Equal([]string{"s_1111111111"}, sessionIds(got.GetItems()))
+		assert.Equal("complete", got.GetResponseType())
+		assert.Empty(got.GetListToken())
+	})
+	t.Run("list-token-for-other-credential", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		got, err := s.ReadCredentialUsage(auth.DisabledAuthTestContext(iamRepoFn, prj.GetPublicId()), &pbs.ReadCredentialUsageRequest{
+			Id:       cred.GetPublicId(),
+			PageSize: 1,
+		})
+		require.NoError(err)
+		require.NotEmpty(got.GetListToken())
+
+		got, err = s.ReadCredentialUsage(auth.DisabledAuthTestContext(iamRepoFn, prj.GetPublicId()), &pbs.ReadCredentialUsageRequest{
+			Id:        other.GetPublicId(),
+			PageSize:  1,
+			ListToken: got.GetListToken(),
+		})
+		require.Error(err)
+		assert.Truef(errors.Is(err, handlers.ApiErrorWithCode(codes.InvalidArgument)), "got error %v, wanted invalid argument", err)
+		assert.Contains(err.Error(), "list_token")
+		assert.Nil(got)
+	})
+}
@@ -739,6 +739,12 @@ func (s Service) createInRepo(ctx context.Context, scopeId string, item *pb.Role
 	if item.GetDescription() != nil {
 		opts = append(opts, iam.WithDescription(item.GetDescription().GetValue()))
 	}
+	if item.GetMaxActiveSessionsPerUser() != nil {
+		opts = append(opts, iam.WithMaxActiveSessionsPerUser(item.GetMaxActiveSessionsPerUser().GetValue()))
+	}
+	if item.GetMaxDailySessionsPerUser() != nil {
+		opts = append(opts, iam.WithMaxDailySessionsPerUser(item.GetMaxDailySessionsPerUser().GetValue()))
+	}
 	u, err := iam.NewRole(ctx, scopeId, opts...)
 	if err != nil {
 		return nil, nil, nil, nil, handlers.ApiErrorWithCodeAndMessage(codes.Internal, "Unable to build role for creation: %v.", err)
@@ -766,6 +772,12 @@ func (s Service) updateInRepo(ctx context.Context, scopeId, id string, mask []st
 	if name := item.GetName(); name != nil {
 		opts = append(opts, iam.WithName(name.GetValue()))
 	}
+	if maxActive := item.GetMaxActiveSessionsPerUser(); maxActive != nil {
+		opts = append(opts, iam.WithMaxActiveSessionsPerUser(maxActive.GetValue()))
+	}
+	if maxDaily := item.GetMaxDailySessionsPerUser(); maxDaily != nil {
+		opts = append(opts, iam.WithMaxDailySessionsPerUser(maxDaily.GetValue()))
+	}
 	version := item.GetVersion()
 
 	u, err := iam.NewRole(ctx, scopeId, opts...)
@@ -1095,6 +1107,12 @@ func toProto(ctx context.Context, in *iam.Role, principals []*iam.PrincipalRole,
 	if outputFields.Has(globals.AuthorizedActionsField) {
 		out.AuthorizedActions = opts.WithAuthorizedActions
 	}
+	if outputFields.Has(globals.MaxActiveSessionsPerUserField) && in.GetMaxActiveSessionsPerUser() > 0 {
+		out.MaxActiveSessionsPerUser = wrapperspb.UInt32(in.GetMaxActiveSessionsPerUser())
+	}
+	if outputFields.Has(globals.MaxDailySessionsPerUserField) && in.GetMaxDailySessionsPerUser() > 0 {
+		out.MaxDailySessionsPerUser = wrapperspb.UInt32(in.GetMaxDailySessionsPerUser())
+	}
 	if outputFields.Has(globals.PrincipalIdsField) {
 		for _, p := range principals {
 			out.PrincipalIds = append(out.PrincipalIds, p.GetPrincipalId())
@@ -1175,6 +1193,12 @@ func validateCreateRequest(req *pbs.CreateRoleRequest) error {
 		if item.GetGrants() != nil {
 			badFields["grant_strings"] = "This is a read only field."
 		}
+		if item.GetMaxActiveSessionsPerUser() != nil && item.GetMaxActiveSessionsPerUser().GetValue() == 0 {
+			badFields[globals.MaxActiveSessionsPerUserField] = "This must be greater than zero."
+		}
+		if item.GetMaxDailySessionsPerUser() != nil && item.GetMaxDailySessionsPerUser().GetValue() == 0 {
+			badFields[globals.MaxDailySessionsPerUserField] = "This must be greater than zero."
+		}
 		return badFields
 	})
 }
@@ -1194,6 +1218,12 @@ func validateUpdateRequest(req *pbs.UpdateRoleRequest) error {
 		if req.GetItem().GetGrantStrings() != nil {
 			badFields["grant_strings"] = "This is a read only field and cannot be specified in an update request."
 		}
+		if req.GetItem().GetMaxActiveSessionsPerUser() != nil && req.GetItem().GetMaxActiveSessionsPerUser().GetValue() == 0 {
+			badFields[globals.MaxActiveSessionsPerUserField] = "This must be greater than zero."
+		}
+		if req.GetItem().GetMaxDailySessionsPerUser() != nil && req.GetItem().GetMaxDailySessionsPerUser().GetValue() == 0 {
+			badFields[globals.MaxDailySessionsPerUserField] = "This must be greater than zero."
+		}
 		return badFields
 	}, globals.RolePrefix)
 }
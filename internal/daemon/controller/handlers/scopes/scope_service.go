@@ -26,6 +26,7 @@ import (
 	"github.com/hashicorp/boundary/internal/daemon/controller/handlers/sessions"
 	"github.com/hashicorp/boundary/internal/daemon/controller/handlers/storage_buckets"
 	"github.com/hashicorp/boundary/internal/daemon/controller/handlers/targets"
+	"github.com/hashicorp/boundary/internal/daemon/controller/handlers/targettemplates"
 	"github.com/hashicorp/boundary/internal/daemon/controller/handlers/users"
 	"github.com/hashicorp/boundary/internal/daemon/controller/handlers/workers"
 	"github.com/hashicorp/boundary/internal/errors"
@@ -34,6 +35,7 @@ import (
 	"github.com/hashicorp/boundary/internal/iam/store"
 	"github.com/hashicorp/boundary/internal/kms"
 	"github.com/hashicorp/boundary/internal/listtoken"
+	"github.com/hashicorp/boundary/internal/oplog"
 	"github.com/hashicorp/boundary/internal/pagination"
 	"github.com/hashicorp/boundary/internal/perms"
 	"github.com/hashicorp/boundary/internal/requests"
@@ -45,6 +47,7 @@ import (
 	wrappingKms "github.com/hashicorp/go-kms-wrapping/extras/kms/v2"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/structpb"
 	"google.golang.org/protobuf/types/known/timestamppb"
 	"google.golang.org/protobuf/types/known/wrapperspb"
 )
@@ -72,6 +75,7 @@ var (
 		action.RotateScopeKeys,
 		action.ListScopeKeyVersionDestructionJobs,
 		action.DestroyScopeKeyVersion,
+		action.ListScopeOplogEntries,
 	)
 
 	// TODO: get this from action registry
@@ -112,9 +116,11 @@ var (
 				action.RotateScopeKeys,
 				action.ListScopeKeyVersionDestructionJobs,
 				action.DestroyScopeKeyVersion,
-			), // Only Scope key actions are allowed on the project level
-			resource.Session: sessions.CollectionActions,
-			resource.Target:  targets.CollectionActions,
+				action.ListScopeOplogEntries,
+			), // Only Scope key and oplog actions are allowed on the project level
+			resource.Session:        sessions.CollectionActions,
+			resource.Target:         targets.CollectionActions,
+			resource.TargetTemplate: targettemplates.CollectionActions,
 		},
 	}
 )
@@ -594,6 +600,67 @@ func (s *Service) DestroyKeyVersion(ctx context.Context, req *pbs.DestroyKeyVers
 	}, nil
 }
 
+// ListOplogEntries implements the interface pbs.ScopeServiceServer.
+func (s *Service) ListOplogEntries(ctx context.Context, req *pbs.ListOplogEntriesRequest) (*pbs.ListOplogEntriesResponse, error) {
+	if req.GetScopeId() == "" {
+		req.ScopeId = scope.Global.String()
+	}
+	if err := validateListOplogEntriesRequest(ctx, req); err != nil {
+		return nil, err
+	}
+	authResults := s.authResult(ctx, req.GetScopeId(), action.ListScopeOplogEntries)
+	if authResults.Error != nil {
+		// As when listing scopes, a recursive request can continue since the
+		// caller may be authorized in descendant scopes.
+		if (authResults.Error == handlers.ForbiddenError() || authResults.Error == handlers.UnauthenticatedError()) &&
+			req.GetRecursive() &&
+			authResults.AuthenticationFinished {
+		} else {
+			return nil, authResults.Error
+		}
+	}
+
+	// Only the entries of the scopes the caller is granted the action in are
+	// listed.
+	scopeIds, _, err := scopeids.GetListingScopeIds(
+		ctx, s.repoFn, authResults, req.GetScopeId(), resource.Scope, req.GetRecursive(),
+		scopeids.WithListAction(action.ListScopeOplogEntries))
+	if err != nil {
+		return nil, err
+	}
+
+	var after kms.OplogCursor
+	if req.GetCursor() != "" {
+		// The cursor was validated by validateListOplogEntriesRequest
+		after, _ = kms.ParseOplogCursor(ctx, req.GetCursor())
+	}
+	pageSize := int(s.maxPageSize)
+	if req.GetPageSize() != 0 && uint(req.GetPageSize()) < s.maxPageSize {
+		pageSize = int(req.GetPageSize())
+	}
+
+	entries, next, err := s.kmsRepo.ListOplogEntries(ctx, scopeIds, after, pageSize)
+	if err != nil {
+		return nil, err
+	}
+	items := make([]*pb.OplogEntry, 0, len(entries))
+	for _, entry := range entries {
+		item, err := oplogEntryToProto(ctx, entry)
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, item)
+	}
+	resp := &pbs.ListOplogEntriesResponse{
+		Items:  items,
+		Cursor: req.GetCursor(),
+	}
+	if len(items) > 0 {
+		resp.Cursor = next.String()
+	}
+	return resp, nil
+}
+
 // AttachStoragePolicy implements the interface pbs.ScopeServiceServer.
 func (s *Service) AttachStoragePolicy(ctx context.Context, req *pbs.AttachStoragePolicyRequest) (*pbs.AttachStoragePolicyResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "Policies are an Enterprise-only feature")
@@ -629,6 +696,21 @@ func (s *Service) createInRepo(ctx context.Context, authResults auth.VerifyResul
 	if item.GetDescription() != nil {
 		opts = append(opts, iam.WithDescription(item.GetDescription().GetValue()))
 	}
+	if item.GetMaxActiveSessionsPerUser() != nil {
+		opts = append(opts, iam.WithMaxActiveSessionsPerUser(item.GetMaxActiveSessionsPerUser().GetValue()))
+	}
+	if item.GetMaxDailySessionsPerUser() != nil {
+		opts = append(opts, iam.WithMaxDailySessionsPerUser(item.GetMaxDailySessionsPerUser().GetValue()))
+	}
+	if item.GetKeyRotationIntervalSeconds() != nil {
+		opts = append(opts, iam.WithKeyRotationIntervalSeconds(item.GetKeyRotationIntervalSeconds().GetValue()))
+	}
+	if item.GetKeyRotationRewrap() != nil {
+		opts = append(opts, iam.WithKeyRotationRewrap(item.GetKeyRotationRewrap().GetValue()))
+	}
+	if item.GetKeyRotationDestroyOldVersions() != nil {
+		opts = append(opts, iam.WithKeyRotationDestroyOldVersions(item.GetKeyRotationDestroyOldVersions().GetValue()))
+	}
 	opts = append(opts, iam.WithSkipAdminRoleCreation(req.GetSkipAdminRoleCreation()))
 	opts = append(opts, iam.WithSkipDefaultRoleCreation(req.GetSkipDefaultRoleCreation()))
 
@@ -677,6 +759,21 @@ func (s *Service) updateInRepo(ctx context.Context, parentScope *pb.ScopeInfo, s
 		scopePrimaryAuthMethodId = primaryAuthMethodId.GetValue()
 		opts = append(opts, iam.WithPrimaryAuthMethodId(scopePrimaryAuthMethodId))
 	}
+	if maxActive := item.GetMaxActiveSessionsPerUser(); maxActive != nil {
+		opts = append(opts, iam.WithMaxActiveSessionsPerUser(maxActive.GetValue()))
+	}
+	if maxDaily := item.GetMaxDailySessionsPerUser(); maxDaily != nil {
+		opts = append(opts, iam.WithMaxDailySessionsPerUser(maxDaily.GetValue()))
+	}
+	if interval := item.GetKeyRotationIntervalSeconds(); interval != nil {
+		opts = append(opts, iam.WithKeyRotationIntervalSeconds(interval.GetValue()))
+	}
+	if rewrap := item.GetKeyRotationRewrap(); rewrap != nil {
+		opts = append(opts, iam.WithKeyRotationRewrap(rewrap.GetValue()))
+	}
+	if destroy := item.GetKeyRotationDestroyOldVersions(); destroy != nil {
+		opts = append(opts, iam.WithKeyRotationDestroyOldVersions(destroy.GetValue()))
+	}
 	version := item.GetVersion()
 
 	var iamScope *iam.Scope
@@ -691,6 +788,11 @@ func (s *Service) updateInRepo(ctx context.Context, parentScope *pb.ScopeInfo, s
 		iamScope.Description = scopeDesc
 		iamScope.Name = scopeName
 		iamScope.PrimaryAuthMethodId = scopePrimaryAuthMethodId
+		iamScope.MaxActiveSessionsPerUser = item.GetMaxActiveSessionsPerUser().GetValue()
+		iamScope.MaxDailySessionsPerUser = item.GetMaxDailySessionsPerUser().GetValue()
+		iamScope.KeyRotationIntervalSeconds = item.GetKeyRotationIntervalSeconds().GetValue()
+		iamScope.KeyRotationRewrap = item.GetKeyRotationRewrap().GetValue()
+		iamScope.KeyRotationDestroyOldVersions = item.GetKeyRotationDestroyOldVersions().GetValue()
 	case parentScope.GetType() == scope.Global.String():
 		iamScope, err = iam.NewOrg(ctx, opts...)
 	case parentScope.GetType() == scope.Org.String():
@@ -757,7 +859,7 @@ func (s Service) authResult(ctx context.Context, id string, a action.Type) auth.
 	var parentId string
 	opts := []auth.Option{auth.WithType(resource.Scope), auth.WithAction(a)}
 	switch a {
-	case action.List, action.Create, action.ListScopeKeys, action.ListScopeKeyVersionDestructionJobs, action.DestroyScopeKeyVersion:
+	case action.List, action.Create, action.ListScopeKeys, action.ListScopeKeyVersionDestructionJobs, action.DestroyScopeKeyVersion, action.ListScopeOplogEntries:
 		parentId = id
 		s, err := repo.LookupScope(ctx, parentId)
 		if err != nil {
@@ -832,6 +934,21 @@ func ToProto(ctx context.Context, in *iam.Scope, opt ...handlers.Option) (*pb.Sc
 	if outputFields.Has(globals.StoragePolicyIdField) {
 		out.StoragePolicyId = in.GetStoragePolicyId()
 	}
+	if outputFields.Has(globals.MaxActiveSessionsPerUserField) && in.GetMaxActiveSessionsPerUser() > 0 {
+		out.MaxActiveSessionsPerUser = wrapperspb.UInt32(in.GetMaxActiveSessionsPerUser())
+	}
+	if outputFields.Has(globals.MaxDailySessionsPerUserField) && in.GetMaxDailySessionsPerUser() > 0 {
+		out.MaxDailySessionsPerUser = wrapperspb.UInt32(in.GetMaxDailySessionsPerUser())
+	}
+	if outputFields.Has(globals.KeyRotationIntervalSecondsField) && in.GetKeyRotationIntervalSeconds() > 0 {
+		out.KeyRotationIntervalSeconds = wrapperspb.UInt32(in.GetKeyRotationIntervalSeconds())
+	}
+	if outputFields.Has(globals.KeyRotationRewrapField) && in.GetKeyRotationRewrap() {
+		out.KeyRotationRewrap = wrapperspb.Bool(in.GetKeyRotationRewrap())
+	}
+	if outputFields.Has(globals.KeyRotationDestroyOldVersionsField) && in.GetKeyRotationDestroyOldVersions() {
+		out.KeyRotationDestroyOldVersions = wrapperspb.Bool(in.GetKeyRotationDestroyOldVersions())
+	}
 
 	return &out, nil
 }
@@ -901,6 +1018,50 @@ func destructionJobToProto(ctx context.Context, in *kms.DataKeyVersionDestructio
 	return &out, nil
 }
 
+func oplogEntryToProto(ctx context.Context, in *oplog.Entry) (*pb.OplogEntry, error) {
+	out := pb.OplogEntry{
+		Id:            in.GetId(),
+		ScopeId:       in.GetScopeId(),
+		CreatedTime:   in.GetCreateTime().GetTimestamp(),
+		AggregateName: in.GetAggregateName(),
+	}
+	md := make(map[string]any, len(in.GetMetadata()))
+	for _, m := range in.GetMetadata() {
+		values, _ := md[m.GetKey()].([]any)
+		md[m.GetKey()] = append(values, m.GetValue())
+		switch m.GetKey() {
+		case "resource-public-id":
+			out.ResourceId = m.GetValue()
+		case "resource-type":
+			out.ResourceType = m.GetValue()
+		case oplog.ActorMetadataKey:
+			out.ActorId = m.GetValue()
+		}
+	}
+	if len(md) > 0 {
+		var err error
+		if out.Metadata, err = structpb.NewStruct(md); err != nil {
+			return nil, handlers.ApiErrorWithCodeAndMessage(codes.Internal, "unable to convert metadata of oplog entry %d: %s", in.GetId(), err)
+		}
+	}
+	// The data is empty when the entry could not be decrypted.
+	if len(in.GetData()) > 0 {
+		ops, err := in.Operations(ctx)
+		if err != nil {
+			return nil, err
+		}
+		for _, op := range ops {
+			out.Operations = append(out.Operations, &pb.OplogOperation{
+				TypeName:       op.GetTypeName(),
+				OpType:         strings.ToLower(strings.TrimPrefix(op.GetOperationType().String(), "OP_TYPE_")),
+				FieldMaskPaths: op.GetFieldMask().GetPaths(),
+				SetToNullPaths: op.GetNullMask().GetPaths(),
+			})
+		}
+	}
+	return &out, nil
+}
+
 // A validateX method should exist for each method above.  These methods do not make calls to any backing service but enforce
 // requirements on the structure of the request.  They verify that:
 //   - The path passed in is correctly formatted
@@ -947,6 +1108,15 @@ func validateCreateRequest(req *pbs.CreateScopeRequest) error {
 				badFields["type"] = "Project scopes can only be created under an org scope."
 			}
 		}
+		if item.GetMaxActiveSessionsPerUser() != nil && item.GetMaxActiveSessionsPerUser().GetValue() == 0 {
+			badFields[globals.MaxActiveSessionsPerUserField] = "This must be greater than zero."
+		}
+		if item.GetMaxDailySessionsPerUser() != nil && item.GetMaxDailySessionsPerUser().GetValue() == 0 {
+			badFields[globals.MaxDailySessionsPerUserField] = "This must be greater than zero."
+		}
+		if item.GetKeyRotationIntervalSeconds() != nil && item.GetKeyRotationIntervalSeconds().GetValue() == 0 {
+			badFields[globals.KeyRotationIntervalSecondsField] = "This must be greater than zero."
+		}
 		return badFields
 	})
 }
@@ -1001,6 +1171,15 @@ func validateUpdateRequest(req *pbs.UpdateScopeRequest) error {
 	if item.GetPrimaryAuthMethodId().GetValue() != "" && !handlers.ValidId(handlers.Id(item.GetPrimaryAuthMethodId().GetValue()), globals.PasswordAuthMethodPrefix, globals.OidcAuthMethodPrefix, globals.LdapAuthMethodPrefix) {
 		badFields["primary_auth_method_id"] = "Improperly formatted identifier."
 	}
+	if item.GetMaxActiveSessionsPerUser() != nil && item.GetMaxActiveSessionsPerUser().GetValue() == 0 {
+		badFields[globals.MaxActiveSessionsPerUserField] = "This must be greater than zero."
+	}
+	if item.GetMaxDailySessionsPerUser() != nil && item.GetMaxDailySessionsPerUser().GetValue() == 0 {
+		badFields[globals.MaxDailySessionsPerUserField] = "This must be greater than zero."
+	}
+	if item.GetKeyRotationIntervalSeconds() != nil && item.GetKeyRotationIntervalSeconds().GetValue() == 0 {
+		badFields[globals.KeyRotationIntervalSecondsField] = "This must be greater than zero."
+	}
 	if item.GetName() != nil {
 		trimmed := strings.TrimSpace(item.GetName().GetValue())
 		switch {
@@ -1101,6 +1280,22 @@ func validateListKeyVersionDestructionJobsRequest(req *pbs.ListKeyVersionDestruc
 	return nil
 }
 
+func validateListOplogEntriesRequest(ctx context.Context, req *pbs.ListOplogEntriesRequest) error {
+	badFields := map[string]string{}
+	if req.GetScopeId() != scope.Global.String() && !handlers.ValidId(handlers.Id(req.GetScopeId()), scope.Org.Prefix()) && !handlers.ValidId(handlers.Id(req.GetScopeId()), scope.Project.Prefix()) {
+		badFields["scope_id"] = "Must be 'global', a valid org scope id or a valid project scope id when listing oplog entries."
+	}
+	if req.GetCursor() != "" {
+		if _, err := kms.ParseOplogCursor(ctx, req.GetCursor()); err != nil {
+			badFields["cursor"] = "Must be a cursor returned by a previous request."
+		}
+	}
+	if len(badFields) > 0 {
+		return handlers.InvalidArgumentErrorf("Error in provided request.", badFields)
+	}
+	return nil
+}
+
 func validateDestroyKeyVersionRequest(req *pbs.DestroyKeyVersionRequest) error {
 	badFields := map[string]string{}
 	if req.GetScopeId() != scope.Global.String() && !handlers.ValidId(handlers.Id(req.GetScopeId()), scope.Org.Prefix()) && !handlers.ValidId(handlers.Id(req.GetScopeId()), scope.Project.Prefix()) {
@@ -123,6 +123,7 @@ var globalAuthorizedCollectionActions = map[string]*structpb.ListValue{
 			structpb.NewStringValue("list"),
 			structpb.NewStringValue("list-key-version-destruction-jobs"),
 			structpb.NewStringValue("list-keys"),
+			structpb.NewStringValue("list-oplog-entries"),
 			structpb.NewStringValue("rotate-keys"),
 		},
 	},
@@ -145,10 +146,13 @@ var globalAuthorizedCollectionActions = map[string]*structpb.ListValue{
 	},
 	"workers": {
 		Values: []*structpb.Value{
+			structpb.NewStringValue("attest"),
 			structpb.NewStringValue("create:controller-led"),
 			structpb.NewStringValue("create:worker-led"),
 			structpb.NewStringValue("list"),
 			structpb.NewStringValue("read-certificate-authority"),
+			structpb.NewStringValue("read-fleet-report"),
+			structpb.NewStringValue("read-topology"),
 			structpb.NewStringValue("reinitialize-certificate-authority"),
 		},
 	},
@@ -191,6 +195,7 @@ var orgAuthorizedCollectionActions = map[string]*structpb.ListValue{
 			structpb.NewStringValue("list"),
 			structpb.NewStringValue("list-key-version-destruction-jobs"),
 			structpb.NewStringValue("list-keys"),
+			structpb.NewStringValue("list-oplog-entries"),
 			structpb.NewStringValue("rotate-keys"),
 		},
 	},
@@ -248,6 +253,7 @@ var projectAuthorizedCollectionActions = map[string]*structpb.ListValue{
 			structpb.NewStringValue("destroy-key-version"),
 			structpb.NewStringValue("list-key-version-destruction-jobs"),
 			structpb.NewStringValue("list-keys"),
+			structpb.NewStringValue("list-oplog-entries"),
 			structpb.NewStringValue("rotate-keys"),
 		},
 	},
@@ -257,6 +263,12 @@ var projectAuthorizedCollectionActions = map[string]*structpb.ListValue{
 			structpb.NewStringValue("list"),
 		},
 	},
+	"target-templates": {
+		Values: []*structpb.Value{
+			structpb.NewStringValue("create"),
+			structpb.NewStringValue("list"),
+		},
+	},
 }
 
 func TestGet(t *testing.T) {
@@ -3165,6 +3177,160 @@ func TestDestroyKeyVersion(t *testing.T) {
 	}
 }
 
+func TestListOplogEntries(t *testing.T) {
+	tc := controller.NewTestController(t, nil)
+
+	aToken := tc.Token()
+	uToken := tc.UnprivilegedToken()
+
+	iamRepoFn := func() (*iam.Repository, error) {
+		return tc.IamRepo(), nil
+	}
+	serversRepoFn := func() (*server.Repository, error) {
+		return tc.ServersRepo(), nil
+	}
+	authTokenRepoFn := func() (*authtoken.Repository, error) {
+		return tc.AuthTokenRepo(), nil
+	}
+
+	privCtx := auth.NewVerifierContext(
+		tc.Context(),
+		iamRepoFn,
+		authTokenRepoFn,
+		serversRepoFn,
+		tc.Kms(),
+		&authpb.RequestInfo{
+			PublicId:       aToken.Id,
+			EncryptedToken: strings.Split(aToken.Token, "_")[2],
+			TokenFormat:    uint32(auth.AuthTokenTypeBearer),
+		},
+	)
+
+	unprivCtx := auth.NewVerifierContext(
+		tc.Context(),
+		iamRepoFn,
+		authTokenRepoFn,
+		serversRepoFn,
+		tc.Kms(),
+		&authpb.RequestInfo{
+			PublicId:       uToken.Id,
+			EncryptedToken: strings.Split(uToken.Token, "_")[2],
+			TokenFormat:    uint32(auth.AuthTokenTypeBearer),
+		},
+	)
+
+	org, _ := iam.TestScopes(t, tc.IamRepo())
+	listRole := iam.TestRole(t, tc.DbConn(), org.PublicId)
+	_ = iam.TestRoleGrant(t, tc.DbConn(), listRole.PublicId, "ids=*;type=*;actions=list-oplog-entries")
+	_ = iam.TestUserRole(t, tc.DbConn(), listRole.PublicId, aToken.UserId)
+
+	// Update the org on behalf of the admin user, so the oplog entry records
+	// them as the actor.
+	org.Name = "oplogOrg"
+	userCtx := requests.NewRequestContext(tc.Context(), requests.WithUserId(aToken.UserId))
+	org, _, err := tc.IamRepo().UpdateScope(userCtx, org, org.Version, []string{"Name"})
+	require.NoError(t, err)
+
+	wantUpdate := &pb.OplogOperation{
+		TypeName:       "iam_scope",
+		OpType:         "update",
+		FieldMaskPaths: []string{"Name"},
+	}
+	findUpdate := func(t *testing.T, items []*pb.OplogEntry) *pb.OplogEntry {
+		t.Helper()
+		for _, item := range items {
+			if item.GetResourceId() == org.GetPublicId() && item.GetActorId() == aToken.UserId {
+				return item
+			}
+		}
+		return nil
+	}
+
+	s, err := scopes.NewServiceFn(context.Background(), iamRepoFn, tc.Kms(), 1000)
+	require.NoError(t, err, "Couldn't create new scope service.")
+
+	t.Run("list in the org", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		got, err := s.ListOplogEntries(privCtx, &pbs.ListOplogEntriesRequest{ScopeId: org.GetPublicId()})
+		require.NoError(err)
+		item := findUpdate(t, got.GetItems())
+		require.NotNil(item)
+		assert.Equal(org.GetPublicId(), item.GetScopeId())
+		assert.Equal("scope", item.GetResourceType())
+		assert.NotNil(item.GetCreatedTime())
+		require.Len(item.GetOperations(), 1)
+		assert.Empty(cmp.Diff(wantUpdate, item.GetOperations()[0], protocmp.Transform()))
+		assert.True(strings.HasSuffix(got.GetCursor(), fmt.Sprintf(".%d", got.GetItems()[len(got.GetItems())-1].GetId())), "cursor %q", got.GetCursor())
+	})
+	t.Run("resume after the cursor", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		first, err := s.ListOplogEntries(privCtx, &pbs.ListOplogEntriesRequest{ScopeId: org.GetPublicId(), PageSize: 1})
+		require.NoError(err)
+		require.Len(first.GetItems(), 1)
+		next, err := s.ListOplogEntries(privCtx, &pbs.ListOplogEntriesRequest{ScopeId: org.GetPublicId(), Cursor: first.GetCursor()})
+		require.NoError(err)
+		for _, item := range next.GetItems() {
+			assert.NotEqual(first.GetItems()[0].GetId(), item.GetId())
+		}
+		last, err := s.ListOplogEntries(privCtx, &pbs.ListOplogEntriesRequest{ScopeId: org.GetPublicId(), Cursor: next.GetCursor()})
+		require.NoError(err)
+		assert.Empty(last.GetItems())
+		assert.Equal(next.GetCursor(), last.GetCursor())
+	})
+	t.Run("list recursively from global", func(t *testing.T) {
+		require := require.New(t)
+		got, err := s.ListOplogEntries(privCtx, &pbs.ListOplogEntriesRequest{ScopeId: "global", Recursive: true})
+		require.NoError(err)
+		require.NotNil(findUpdate(t, got.GetItems()))
+	})
+	t.Run("not recursive from global", func(t *testing.T) {
+		require := require.New(t)
+		got, err := s.ListOplogEntries(privCtx, &pbs.ListOplogEntriesRequest{ScopeId: "global"})
+		require.NoError(err)
+		require.Nil(findUpdate(t, got.GetItems()))
+	})
+
+	errCases := []struct {
+		name    string
+		req     *pbs.ListOplogEntriesRequest
+		authCtx context.Context
+		err     error
+	}{
+		{
+			name:    "non existing org",
+			req:     &pbs.ListOplogEntriesRequest{ScopeId: "o_DoesntExis"},
+			authCtx: privCtx,
+			err:     handlers.ApiErrorWithCode(codes.NotFound),
+		},
+		{
+			name:    "wrong id prefix",
+			req:     &pbs.ListOplogEntriesRequest{ScopeId: "j_1234567890"},
+			authCtx: privCtx,
+			err:     handlers.ApiErrorWithCode(codes.InvalidArgument),
+		},
+		{
+			name:    "invalid cursor",
+			req:     &pbs.ListOplogEntriesRequest{ScopeId: "global", Cursor: "not-a-cursor"},
+			authCtx: privCtx,
+			err:     handlers.ApiErrorWithCode(codes.InvalidArgument),
+		},
+		{
+			name:    "unauthorized",
+			req:     &pbs.ListOplogEntriesRequest{ScopeId: "global"},
+			authCtx: unprivCtx,
+			err:     handlers.ApiErrorWithCode(codes.PermissionDenied),
+		},
+	}
+	for _, tt := range errCases {
+		t.Run(tt.name, func(t *testing.T) {
+			assert, require := assert.New(t), require.New(t)
+			_, gErr := s.ListOplogEntries(tt.authCtx, tt.req)
+			require.Error(gErr)
+			assert.True(errors.Is(gErr, tt.err), "ListOplogEntries(%+v) got error\n%v, wanted\n%v", tt.req, gErr, tt.err)
+		})
+	}
+}
+
 func TestAttachStoragePolicy(t *testing.T) {
 	t.Run("unimplemented", func(t *testing.T) {
 		service := &scopes.Service{}
@@ -7,6 +7,8 @@ import (
 	"context"
 	stderrors "errors"
 	"fmt"
+	"net/url"
+	"strings"
 
 	"github.com/hashicorp/boundary/globals"
 	"github.com/hashicorp/boundary/internal/daemon/controller/auth"
@@ -25,7 +27,10 @@ import (
 	"github.com/hashicorp/boundary/internal/types/scope"
 	"github.com/hashicorp/boundary/sdk/pbs/controller/api/resources/scopes"
 	pb "github.com/hashicorp/boundary/sdk/pbs/controller/api/resources/sessions"
+	"github.com/hashicorp/boundary/sdk/pbs/controller/api/resources/targets"
+	"github.com/mr-tron/base58"
 	"google.golang.org/grpc/codes"
+	"google.golang.org/protobuf/proto"
 )
 
 var (
@@ -37,6 +42,8 @@ var (
 		action.ReadSelf,
 		action.Cancel,
 		action.CancelSelf,
+		action.Shadow,
+		action.ShadowInput,
 	)
 
 	// CollectionActions contains the set of actions that can be performed on
@@ -46,6 +53,15 @@ var (
 	)
 )
 
+const (
+	// shadowableSessionType is the type of the sessions whose terminal can be
+	// shadowed.
+	shadowableSessionType = "ssh"
+
+	// maxCancelReasonLength bounds the reason given when canceling sessions.
+	maxCancelReasonLength = 1024
+)
+
 func init() {
 	// TODO: refactor to remove IdActions and CollectionActions package variables
 	action.RegisterResource(resource.Session, IdActions, CollectionActions)
@@ -55,15 +71,16 @@ func init() {
 type Service struct {
 	pbs.UnsafeSessionServiceServer
 
-	repoFn      session.RepositoryFactory
-	iamRepoFn   common.IamRepoFactory
-	maxPageSize uint
+	repoFn        session.RepositoryFactory
+	iamRepoFn     common.IamRepoFactory
+	serversRepoFn common.ServersRepoFactory
+	maxPageSize   uint
 }
 
 var _ pbs.SessionServiceServer = (*Service)(nil)
 
 // NewService returns a session service which handles session related requests to boundary.
-func NewService(ctx context.Context, repoFn session.RepositoryFactory, iamRepoFn common.IamRepoFactory, maxPageSize uint) (Service, error) {
+func NewService(ctx context.Context, repoFn session.RepositoryFactory, iamRepoFn common.IamRepoFactory, serversRepoFn common.ServersRepoFactory, maxPageSize uint) (Service, error) {
 	const op = "sessions.NewService"
 	if repoFn == nil {
 		return Service{}, errors.New(ctx, errors.InvalidParameter, op, "missing session repository")
@@ -71,10 +88,13 @@ func NewService(ctx context.Context, repoFn session.RepositoryFactory, iamRepoFn
 	if iamRepoFn == nil {
 		return Service{}, errors.New(ctx, errors.InvalidParameter, op, "missing iam repository")
 	}
+	if serversRepoFn == nil {
+		return Service{}, errors.New(ctx, errors.InvalidParameter, op, "missing servers repository")
+	}
 	if maxPageSize == 0 {
 		maxPageSize = uint(globals.DefaultMaxPageSize)
 	}
-	return Service{repoFn: repoFn, iamRepoFn: iamRepoFn, maxPageSize: maxPageSize}, nil
+	return Service{repoFn: repoFn, iamRepoFn: iamRepoFn, serversRepoFn: serversRepoFn, maxPageSize: maxPageSize}, nil
 }
 
 // GetSessions implements the interface pbs.SessionServiceServer.
@@ -344,7 +364,7 @@ func (s Service) CancelSession(ctx context.Context, req *pbs.CancelSessionReques
 
 	if !skipCancel {
 		// Ignore decryption failures to ensure the user can always cancel a session.
-		ses, err = repo.CancelSession(ctx, req.GetId(), req.GetVersion(), session.WithIgnoreDecryptionFailures(true))
+		ses, err = repo.CancelSession(ctx, req.GetId(), req.GetVersion(), session.WithIgnoreDecryptionFailures(true), session.WithCancelReason(req.GetReason()))
 		if err != nil {
 			return nil, errors.Wrap(ctx, err, op, errors.WithMsg("unable to update session"))
 		}
@@ -366,6 +386,204 @@ func (s Service) CancelSession(ctx context.Context, req *pbs.CancelSessionReques
 	return &pbs.CancelSessionResponse{Item: item}, nil
 }
 
+// CancelSessions implements the interface pbs.SessionServiceServer.
+func (s Service) CancelSessions(ctx context.Context, req *pbs.CancelSessionsRequest) (*pbs.CancelSessionsResponse, error) {
+	const op = "sessions.(Service).CancelSessions"
+
+	if err := validateCancelSessionsRequest(req); err != nil {
+		return nil, err
+	}
+
+	// The caller must be able to list the sessions in the scope; each matching
+	// session is then only canceled if the caller is allowed to cancel it.
+	authResults := s.authResult(ctx, req.GetScopeId(), action.List, false)
+	if authResults.Error != nil {
+		// As for listing, keep going for recursive requests as we may have
+		// authorization on downstream scopes.
+		if (authResults.Error == handlers.ForbiddenError() || authResults.Error == handlers.UnauthenticatedError()) &&
+			req.GetRecursive() &&
+			authResults.AuthenticationFinished {
+		} else {
+			return nil, errors.Wrap(ctx, authResults.Error, op)
+		}
+	}
+
+	var scopeIds map[string]*scopes.ScopeInfo
+	var err error
+	if !req.GetRecursive() {
+		scopeIds = map[string]*scopes.ScopeInfo{authResults.Scope.Id: authResults.Scope}
+	} else {
+		scopeIds, err = authResults.ScopesAuthorizedForList(ctx, req.GetScopeId(), resource.Session)
+		if err != nil {
+			return nil, err
+		}
+	}
+	resp := &pbs.CancelSessionsResponse{}
+	projectIds := make([]string, 0, len(scopeIds))
+	for id := range scopeIds {
+		if strings.HasPrefix(id, scope.Project.Prefix()) {
+			projectIds = append(projectIds, id)
+		}
+	}
+	if len(projectIds) == 0 {
+		return resp, nil
+	}
+
+	repo, err := s.repoFn()
+	if err != nil {
+		return nil, err
+	}
+	candidates, err := repo.ListCancelableSessions(ctx, projectIds,
+		session.WithUserId(req.GetUserId()),
+		session.WithTargetId(req.GetTargetId()),
+		session.WithWorkerId(req.GetWorkerId()))
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+
+	for _, c := range candidates {
+		res := perms.Resource{
+			Type:    resource.Session,
+			Id:      c.GetPublicId(),
+			ScopeId: c.GetProjectId(),
+		}
+		authorizedActions := authResults.FetchActionSetForId(ctx, c.GetPublicId(), IdActions, auth.WithResource(&res))
+		cancelAction := action.Cancel
+		if !authorizedActions.HasAction(action.Cancel) {
+			if c.UserId != authResults.UserId || !authorizedActions.HasAction(action.CancelSelf) {
+				continue
+			}
+			cancelAction = action.CancelSelf
+		}
+		// Ignore decryption failures to ensure the user can always cancel a session.
+		ses, err := repo.CancelSession(ctx, c.GetPublicId(), c.Version, session.WithIgnoreDecryptionFailures(true), session.WithCancelReason(req.GetReason()))
+		if err != nil {
+			// The session may have changed since it was listed; it is left
+			// to the next cancellation.
+			if errors.Match(errors.T(errors.MultipleRecords), err) || errors.IsNotFoundError(err) {
+				continue
+			}
+			return nil, errors.Wrap(ctx, err, op, errors.WithMsg("unable to update session"))
+		}
+
+		outputFields := authResults.FetchOutputFields(res, cancelAction).SelfOrDefaults(authResults.UserId)
+		outputOpts := make([]handlers.Option, 0, 3)
+		outputOpts = append(outputOpts, handlers.WithOutputFields(outputFields))
+		if outputFields.Has(globals.ScopeField) {
+			outputOpts = append(outputOpts, handlers.WithScope(scopeIds[ses.ProjectId]))
+		}
+		if outputFields.Has(globals.AuthorizedActionsField) {
+			outputOpts = append(outputOpts, handlers.WithAuthorizedActions(authorizedActions.Strings()))
+		}
+		item, err := toProto(ctx, ses, outputOpts...)
+		if err != nil {
+			return nil, errors.Wrap(ctx, err, op)
+		}
+		resp.Items = append(resp.Items, item)
+	}
+	return resp, nil
+}
+
+// ShadowSession implements the interface pbs.SessionServiceServer.
+func (s Service) ShadowSession(ctx context.Context, req *pbs.ShadowSessionRequest) (*pbs.ShadowSessionResponse, error) {
+	const op = "sessions.(Service).ShadowSession"
+
+	if err := validateShadowRequest(req); err != nil {
+		return nil, err
+	}
+	// Sending input to the terminal is granted separately from observing it.
+	a := action.Shadow
+	if req.GetWithInput() {
+		a = action.ShadowInput
+	}
+	authResults := s.authResult(ctx, req.GetId(), a, false)
+	if authResults.Error != nil {
+		return nil, authResults.Error
+	}
+
+	repo, err := s.repoFn()
+	if err != nil {
+		return nil, err
+	}
+	ses, _, err := repo.LookupSession(ctx, req.GetId())
+	if err != nil {
+		if errors.IsNotFoundError(err) {
+			return nil, handlers.NotFoundErrorf("Session %q doesn't exist.", req.GetId())
+		}
+		return nil, err
+	}
+	if ses == nil {
+		return nil, handlers.NotFoundErrorf("Session %q doesn't exist.", req.GetId())
+	}
+	if len(ses.States) == 0 || ses.States[0].Status != session.StatusActive {
+		return nil, handlers.ApiErrorWithCodeAndMessage(codes.FailedPrecondition, "Only active sessions can be shadowed.")
+	}
+	endpointUrl, err := url.Parse(ses.Endpoint)
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op, errors.WithMsg("unable to parse session endpoint"))
+	}
+	if endpointUrl.Scheme != shadowableSessionType {
+		return nil, handlers.ApiErrorWithCodeAndMessage(codes.FailedPrecondition, "Only SSH sessions can be shadowed.")
+	}
+
+	// The terminal is streamed by the workers proxying the connections of the
+	// session, so those are the workers the observer connects to.
+	workerIds, err := repo.ListConnectedWorkerIds(ctx, ses.PublicId)
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	serversRepo, err := s.serversRepoFn()
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	var workerInfos []*targets.WorkerInfo
+	for _, id := range workerIds {
+		w, err := serversRepo.LookupWorker(ctx, id)
+		if err != nil {
+			return nil, errors.Wrap(ctx, err, op)
+		}
+		if w == nil || w.GetAddress() == "" {
+			continue
+		}
+		workerInfos = append(workerInfos, &targets.WorkerInfo{Address: w.GetAddress()})
+	}
+	if len(workerInfos) == 0 {
+		return nil, handlers.ApiErrorWithCodeAndMessage(codes.FailedPrecondition, "The session has no connections to shadow.")
+	}
+
+	shadow, shadowToken, err := repo.CreateShadow(ctx, ses, authResults.UserId, req.GetWithInput(), ses.ExpirationTime.GetTimestamp().AsTime())
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+
+	sad := &targets.SessionAuthorizationData{
+		SessionId:   ses.PublicId,
+		TargetId:    ses.TargetId,
+		CreatedTime: ses.CreateTime.GetTimestamp(),
+		Expiration:  ses.ExpirationTime.GetTimestamp(),
+		Type:        endpointUrl.Scheme,
+		// The observer connects with its own short-lived certificate, which
+		// workers only accept for shadowing the session.
+		Certificate: shadow.Certificate,
+		PrivateKey:  shadow.PrivateKey,
+		HostId:      ses.HostId,
+		Endpoint:    ses.Endpoint,
+		WorkerInfo:  workerInfos,
+	}
+	marshaledSad, err := proto.Marshal(sad)
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+
+	return &pbs.ShadowSessionResponse{Item: &pb.ShadowAuthorization{
+		SessionId:          ses.PublicId,
+		WithInput:          shadow.WithInput,
+		ExpirationTime:     shadow.ExpirationTime.GetTimestamp(),
+		AuthorizationToken: base58.FastBase58Encoding(marshaledSad),
+		ShadowToken:        shadowToken,
+	}}, nil
+}
+
 func (s Service) getFromRepo(ctx context.Context, id string) (*session.Session, error) {
 	repo, err := s.repoFn()
 	if err != nil {
@@ -406,7 +624,7 @@ func (s Service) authResult(ctx context.Context, id string, a action.Type, ignor
 			res.Error = handlers.NotFoundError()
 			return res
 		}
-	case action.Read, action.ReadSelf, action.Cancel, action.CancelSelf:
+	case action.Read, action.ReadSelf, action.Cancel, action.CancelSelf, action.Shadow, action.ShadowInput:
 		repo, err := s.repoFn()
 		if err != nil {
 			res.Error = err
@@ -487,6 +705,15 @@ func toProto(ctx context.Context, in *session.Session, opt ...handlers.Option) (
 	if outputFields.Has(globals.TerminationReasonField) {
 		out.TerminationReason = in.TerminationReason
 	}
+	if outputFields.Has(globals.ReasonField) {
+		out.Reason = in.Reason
+	}
+	if outputFields.Has(globals.TicketIdField) {
+		out.TicketId = in.TicketId
+	}
+	if outputFields.Has(globals.CancelReasonField) {
+		out.CancelReason = in.CancelReason
+	}
 	if outputFields.Has(globals.ScopeField) {
 		out.Scope = opts.WithScope
 	}
@@ -567,6 +794,47 @@ func validateCancelRequest(req *pbs.CancelSessionRequest) error {
 	if req.GetVersion() == 0 {
 		badFields["version"] = "Required field."
 	}
+	if len(req.GetReason()) > maxCancelReasonLength {
+		badFields[globals.ReasonField] = fmt.Sprintf("Must be at most %d characters.", maxCancelReasonLength)
+	}
+	if len(badFields) > 0 {
+		return handlers.InvalidArgumentErrorf("Improperly formatted identifier.", badFields)
+	}
+	return nil
+}
+
+func validateCancelSessionsRequest(req *pbs.CancelSessionsRequest) error {
+	badFields := map[string]string{}
+	if !handlers.ValidId(handlers.Id(req.GetScopeId()), scope.Project.Prefix()) &&
+		!req.GetRecursive() {
+		badFields[globals.ScopeIdField] = "This field must be a valid project scope ID or the cancel operation must be recursive."
+	}
+	if req.GetUserId() == "" && req.GetTargetId() == "" && req.GetWorkerId() == "" {
+		badFields[globals.UserIdField] = "At least one of user ID, target ID or worker ID must be provided."
+	}
+	if req.GetUserId() != "" && !handlers.ValidId(handlers.Id(req.GetUserId()), globals.UserPrefix) {
+		badFields[globals.UserIdField] = "Improperly formatted identifier."
+	}
+	if req.GetTargetId() != "" && globals.ResourceInfoFromPrefix(req.GetTargetId()).Type != resource.Target {
+		badFields[globals.TargetIdField] = "Improperly formatted identifier."
+	}
+	if req.GetWorkerId() != "" && !handlers.ValidId(handlers.Id(req.GetWorkerId()), globals.WorkerPrefix) {
+		badFields["worker_id"] = "Improperly formatted identifier."
+	}
+	if len(req.GetReason()) > maxCancelReasonLength {
+		badFields[globals.ReasonField] = fmt.Sprintf("Must be at most %d characters.", maxCancelReasonLength)
+	}
+	if len(badFields) > 0 {
+		return handlers.InvalidArgumentErrorf("Errors in provided fields.", badFields)
+	}
+	return nil
+}
+
+func validateShadowRequest(req *pbs.ShadowSessionRequest) error {
+	badFields := map[string]string{}
+	if !handlers.ValidId(handlers.Id(req.GetId()), globals.SessionPrefix) {
+		badFields["id"] = "Improperly formatted identifier."
+	}
 	if len(badFields) > 0 {
 		return handlers.InvalidArgumentErrorf("Improperly formatted identifier.", badFields)
 	}
@@ -7,6 +7,7 @@ import (
 	"context"
 	"fmt"
 	"net/http/httptest"
+	"strings"
 	"testing"
 	"time"
 
@@ -140,7 +141,7 @@ func TestGetSession(t *testing.T) {
 		t.Run(tc.name, func(t *testing.T) {
 			assert, require := assert.New(t), require.New(t)
 
-			s, err := sessions.NewService(ctx, sessRepoFn, iamRepoFn, 1000)
+			s, err := sessions.NewService(ctx, sessRepoFn, iamRepoFn, serversRepoFn, 1000)
 			require.NoError(err, "Couldn't create new session service.")
 
 			requestInfo := authpb.RequestInfo{
@@ -234,7 +235,7 @@ func TestList_Self(t *testing.T) {
 		Endpoint:    "tcp://127.0.0.1:22",
 	})
 
-	s, err := sessions.NewService(ctx, sessRepoFn, iamRepoFn, 1000)
+	s, err := sessions.NewService(ctx, sessRepoFn, iamRepoFn, serversRepoFn, 1000)
 	require.NoError(t, err, "Couldn't create new session service.")
 
 	cases := []struct {
@@ -632,7 +633,7 @@ func TestList(t *testing.T) {
 	for _, tc := range cases {
 		t.Run(tc.name, func(t *testing.T) {
 			require, assert := require.New(t), assert.New(t)
-			s, err := sessions.NewService(ctx, sessRepoFn, iamRepoFn, 1000)
+			s, err := sessions.NewService(ctx, sessRepoFn, iamRepoFn, serversRepoFn, 1000)
 			require.NoError(err, "Couldn't create new session service.")
 
 			// Test without anon user
@@ -814,7 +815,7 @@ func TestListPagination(t *testing.T) {
 	requestContext := context.WithValue(context.Background(), requests.ContextRequestInformationKey, &requests.RequestContext{})
 	ctx = auth.NewVerifierContext(requestContext, iamRepoFn, tokenRepoFn, serversRepoFn, kms, &requestInfo)
 
-	s, err := sessions.NewService(ctx, sessRepoFn, iamRepoFn, 1000)
+	s, err := sessions.NewService(ctx, sessRepoFn, iamRepoFn, serversRepoFn, 1000)
 	require.NoError(t, err, "Couldn't create new session service.")
 
 	// Start paginating, recursively
@@ -1207,12 +1208,18 @@ func TestCancel(t *testing.T) {
 			res:  nil,
 			err:  handlers.ApiErrorWithCode(codes.InvalidArgument),
 		},
+		{
+			name: "Reason too long",
+			req:  &pbs.CancelSessionRequest{Id: sess.GetPublicId(), Reason: strings.Repeat("a", 1025)},
+			res:  nil,
+			err:  handlers.ApiErrorWithCode(codes.InvalidArgument),
+		},
 	}
 	for _, tc := range cases {
 		t.Run(tc.name, func(t *testing.T) {
 			assert, require := assert.New(t), require.New(t)
 
-			s, err := sessions.NewService(ctx, sessRepoFn, iamRepoFn, 1000)
+			s, err := sessions.NewService(ctx, sessRepoFn, iamRepoFn, serversRepoFn, 1000)
 			require.NoError(err, "Couldn't create new session service.")
 
 			tc.req.Version = version
@@ -1294,3 +1301,121 @@ func TestCancel(t *testing.T) {
 		})
 	}
 }
+
+func TestCancelSessions(t *testing.T) {
+	conn, _ := db.TestSetup(t, "postgres")
+	wrap := db.TestWrapper(t)
+	kms := kms.TestKms(t, conn, wrap)
+
+	iamRepo := iam.TestRepo(t, conn, wrap)
+
+	rw := db.New(conn)
+
+	ctx := context.Background()
+	iamRepoFn := func() (*iam.Repository, error) {
+		return iamRepo, nil
+	}
+	sessRepoFn := func(opt ...session.Option) (*session.Repository, error) {
+		return session.NewRepository(ctx, rw, rw, kms, opt...)
+	}
+	tokenRepoFn := func() (*authtoken.Repository, error) {
+		return authtoken.NewRepository(ctx, rw, rw, kms)
+	}
+	serversRepoFn := func() (*server.Repository, error) {
+		return server.NewRepository(ctx, rw, rw, kms)
+	}
+
+	o, p := iam.TestScopes(t, iamRepo)
+	at := authtoken.TestAuthToken(t, conn, kms, o.GetPublicId())
+	otherAt := authtoken.TestAuthToken(t, conn, kms, o.GetPublicId())
+	hc := static.TestCatalogs(t, conn, p.GetPublicId(), 1)[0]
+	hs := static.TestSets(t, conn, hc.GetPublicId(), 1)[0]
+	h := static.TestHosts(t, conn, hc.GetPublicId(), 1)[0]
+	static.TestSetMembers(t, conn, hs.GetPublicId(), []*static.Host{h})
+	tar := tcp.TestTarget(context.Background(), t, conn, p.GetPublicId(), "test", target.WithHostSources([]string{hs.GetPublicId()}))
+
+	newSession := func(at *authtoken.AuthToken) *session.Session {
+		return session.TestSession(t, conn, wrap, session.ComposedOf{
+			UserId:      at.GetIamUserId(),
+			HostId:      h.GetPublicId(),
+			TargetId:    tar.GetPublicId(),
+			HostSetId:   hs.GetPublicId(),
+			AuthTokenId: at.GetPublicId(),
+			ProjectId:   p.GetPublicId(),
+			Endpoint:    "tcp://127.0.0.1:22",
+		})
+	}
+	sess := newSession(at)
+	otherSess := newSession(otherAt)
+
+	s, err := sessions.NewService(ctx, sessRepoFn, iamRepoFn, serversRepoFn, 1000)
+	require.NoError(t, err, "Couldn't create new session service.")
+
+	requestInfo := authpb.RequestInfo{
+		TokenFormat: uint32(auth.AuthTokenTypeBearer),
+		PublicId:    at.GetPublicId(),
+		Token:       at.GetToken(),
+	}
+	requestContext := context.WithValue(context.Background(), requests.ContextRequestInformationKey, &requests.RequestContext{})
+	authCtx := auth.NewVerifierContext(requestContext, iamRepoFn, tokenRepoFn, serversRepoFn, kms, &requestInfo)
+
+	invalid := []struct {
+		name string
+		req  *pbs.CancelSessionsRequest
+	}{
+		{
+			name: "missing filter",
+			req:  &pbs.CancelSessionsRequest{ScopeId: p.GetPublicId()},
+		},
+		{
+			name: "org scope without recursive",
+			req:  &pbs.CancelSessionsRequest{ScopeId: o.GetPublicId(), UserId: at.GetIamUserId()},
+		},
+		{
+			name: "bad user id",
+			req:  &pbs.CancelSessionsRequest{ScopeId: p.GetPublicId(), UserId: "j_1234567890"},
+		},
+		{
+			name: "bad target id",
+			req:  &pbs.CancelSessionsRequest{ScopeId: p.GetPublicId(), TargetId: "j_1234567890"},
+		},
+		{
+			name: "bad worker id",
+			req:  &pbs.CancelSessionsRequest{ScopeId: p.GetPublicId(), WorkerId: "j_1234567890"},
+		},
+		{
+			name: "reason too long",
+			req:  &pbs.CancelSessionsRequest{ScopeId: p.GetPublicId(), UserId: at.GetIamUserId(), Reason: strings.Repeat("a", 1025)},
+		},
+	}
+	for _, tc := range invalid {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := s.CancelSessions(authCtx, tc.req)
+			require.Error(t, err)
+			assert.True(t, errors.Is(err, handlers.ApiErrorWithCode(codes.InvalidArgument)), "got error %v", err)
+		})
+	}
+
+	t.Run("cancel by target", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		got, err := s.CancelSessions(authCtx, &pbs.CancelSessionsRequest{
+			ScopeId:  p.GetPublicId(),
+			TargetId: tar.GetPublicId(),
+			Reason:   "maintenance",
+		})
+		require.NoError(err)
+		// The caller may only cancel their own session.
+		require.Len(got.GetItems(), 1)
+		item := got.GetItems()[0]
+		assert.Equal(sess.GetPublicId(), item.GetId())
+		assert.Equal(session.StatusCanceling.String(), item.GetStatus())
+		assert.Equal("maintenance", item.GetCancelReason())
+
+		repo, err := sessRepoFn()
+		require.NoError(err)
+		other, _, err := repo.LookupSession(ctx, otherSess.GetPublicId())
+		require.NoError(err)
+		assert.Equal(session.StatusPending, other.States[0].Status)
+		assert.Empty(other.CancelReason)
+	})
+}
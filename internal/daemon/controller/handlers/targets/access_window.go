@@ -0,0 +1,30 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package targets
+
+import (
+	"github.com/hashicorp/boundary/internal/target/accesswindow"
+	pb "github.com/hashicorp/boundary/sdk/pbs/controller/api/resources/targets"
+)
+
+// accessWindowFromProto returns the validated access window described by the
+// proto.
+func accessWindowFromProto(in *pb.AccessWindow) (*accesswindow.Window, error) {
+	return accesswindow.New(in.GetSchedules(), in.GetTimeZone(), in.GetBlackoutDates(), in.GetTerminateSessions())
+}
+
+// accessWindowToProto returns the proto of the json encoded access window
+// stored on a target.
+func accessWindowToProto(in string) (*pb.AccessWindow, error) {
+	w, err := accesswindow.Parse(in)
+	if err != nil {
+		return nil, err
+	}
+	return &pb.AccessWindow{
+		Schedules:         w.Schedules,
+		TimeZone:          w.TimeZone,
+		BlackoutDates:     w.BlackoutDates,
+		TerminateSessions: w.TerminateSessions,
+	}, nil
+}
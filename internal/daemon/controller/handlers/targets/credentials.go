@@ -8,6 +8,7 @@ import (
 	"encoding/base64"
 	"encoding/json"
 	"fmt"
+	"time"
 
 	"github.com/hashicorp/boundary/globals"
 	"github.com/hashicorp/boundary/internal/credential"
@@ -21,6 +22,14 @@ import (
 	"google.golang.org/protobuf/types/known/structpb"
 )
 
+// issuedCredential identifies a credential issued to a session. It never
+// contains the secret itself.
+type issuedCredential struct {
+	id        string
+	libraryId string
+	purpose   credential.Purpose
+}
+
 // dynamicToWorkerCredential converts the strongly typed credential.Dynamic into
 // a session.Credential suitable for passing to a Boundary worker.
 func dynamicToWorkerCredential(ctx context.Context, cred credential.Dynamic) (session.Credential, error) {
@@ -127,10 +136,26 @@ func dynamicToSessionCredential(ctx context.Context, cred credential.Dynamic) (*
 				return nil, errors.Wrap(ctx, err, op, errors.WithMsg("creating proto struct for credential"))
 			}
 
+		case credential.CloudAccess:
+			credData, err = cloudAccessToStruct(c)
+			if err != nil {
+				return nil, errors.Wrap(ctx, err, op, errors.WithMsg("creating proto struct for credential"))
+			}
+
+		case credential.TlsClientCertificate:
+			credData, err = structpb.NewStruct(tlsClientCertificateToMap(c.Certificate(), c.PrivateKey(), c.CaCertificate()))
+			if err != nil {
+				return nil, errors.Wrap(ctx, err, op, errors.WithMsg("creating proto struct for credential"))
+			}
+
 		default:
 			return nil, errors.New(ctx, errors.InvalidParameter, op, fmt.Sprintf("unsupported credential %T", c))
 		}
 	}
+	var lease *pb.CredentialLease
+	if lc, ok := cred.(credential.Leased); ok {
+		lease = leaseToProto(lc.Lease())
+	}
 
 	return &pb.SessionCredential{
 		CredentialSource: &pb.CredentialSource{
@@ -146,9 +171,64 @@ func dynamicToSessionCredential(ctx context.Context, cred credential.Dynamic) (*
 			Decoded: sSecret,
 		},
 		Credential: credData,
+		Lease:      lease,
 	}, nil
 }
 
+// cloudAccessToStruct converts a cloud access credential into the struct
+// returned to the client. Only the values of the credential's provider are
+// included and empty optional values are omitted.
+func cloudAccessToStruct(c credential.CloudAccess) (*structpb.Struct, error) {
+	fields := map[string]any{
+		"provider": c.Provider(),
+	}
+	set := func(k, v string) {
+		if v != "" {
+			fields[k] = v
+		}
+	}
+	set("access_key_id", c.AccessKeyId())
+	set("secret_access_key", string(c.SecretAccessKey()))
+	set("session_token", c.SessionToken())
+	set("access_token", string(c.AccessToken()))
+	set("service_account_key", string(c.ServiceAccountKey()))
+	set("client_id", c.ClientId())
+	set("client_secret", string(c.ClientSecret()))
+	set("tenant_id", c.TenantId())
+	set("subscription_id", c.SubscriptionId())
+	if e := c.Expiration(); !e.IsZero() {
+		fields["expiration"] = e.UTC().Format(time.RFC3339)
+	}
+	return structpb.NewStruct(fields)
+}
+
+// tlsClientCertificateToMap converts the values of a tls client certificate
+// credential into the fields returned to the client. An empty CA certificate
+// is omitted.
+func tlsClientCertificateToMap(cert []byte, key credential.PrivateKey, ca []byte) map[string]any {
+	fields := map[string]any{
+		"certificate": string(cert),
+		"private_key": string(key),
+	}
+	if len(ca) > 0 {
+		fields["ca_certificate"] = string(ca)
+	}
+	return fields
+}
+
+// leaseToProto converts the lease of a dynamic credential into the lease
+// returned to the client. It returns nil if the lease has no id.
+func leaseToProto(lease credential.Lease) *pb.CredentialLease {
+	if lease.Id == "" {
+		return nil
+	}
+	return &pb.CredentialLease{
+		Id:              lease.Id,
+		DurationSeconds: uint32(lease.Duration.Round(time.Second).Seconds()),
+		Renewable:       lease.Renewable,
+	}
+}
+
 // staticToWorkerCredential converts the credential.Static into
 // a session.Credential suitable for passing to a Boundary worker.
 func staticToWorkerCredential(ctx context.Context, cred credential.Static) (session.Credential, error) {
@@ -249,6 +329,15 @@ func staticToSessionCredential(ctx context.Context, cred credential.Static) (*pb
 		}
 		secret = object
 
+	case *credstatic.TlsClientCertificateCredential:
+		var err error
+		credType = string(globals.TlsClientCertificateCredentialType)
+		secret = tlsClientCertificateToMap(c.GetCertificate(), c.GetPrivateKey(), c.GetCaCertificate())
+		credData, err = structpb.NewStruct(secret)
+		if err != nil {
+			return nil, errors.Wrap(ctx, err, op, errors.WithMsg("creating proto struct for tls client certificate credential"))
+		}
+
 	default:
 		return nil, errors.New(ctx, errors.InvalidParameter, op, fmt.Sprintf("unsupported credential %T", c))
 	}
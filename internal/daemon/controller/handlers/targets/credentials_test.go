@@ -0,0 +1,110 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package targets
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/hashicorp/boundary/internal/credential"
+	pb "github.com/hashicorp/boundary/sdk/pbs/controller/api/resources/targets"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/testing/protocmp"
+)
+
+func TestLeaseToProto(t *testing.T) {
+	cases := []struct {
+		name  string
+		lease credential.Lease
+		want  *pb.CredentialLease
+	}{
+		{
+			name:  "no-lease-id",
+			lease: credential.Lease{Duration: time.Hour, Renewable: true},
+		},
+		{
+			name:  "renewable",
+			lease: credential.Lease{Id: "database/creds/role/abc", Duration: time.Hour + 400*time.Millisecond, Renewable: true},
+			want:  &pb.CredentialLease{Id: "database/creds/role/abc", DurationSeconds: 3600, Renewable: true},
+		},
+		{
+			name:  "not-renewable",
+			lease: credential.Lease{Id: "aws/sts/role/abc", Duration: 15 * time.Minute},
+			want:  &pb.CredentialLease{Id: "aws/sts/role/abc", DurationSeconds: 900},
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Empty(t, cmp.Diff(tc.want, leaseToProto(tc.lease), protocmp.Transform()))
+		})
+	}
+}
+
+type testCloudAccess struct {
+	credential.Credential
+	provider, accessKeyId, sessionToken, clientId, tenantId, subscriptionId string
+	secretAccessKey, accessToken, serviceAccountKey, clientSecret           credential.Password
+	expiration                                                              time.Time
+}
+
+func (c testCloudAccess) Provider() string                       { return c.provider }
+func (c testCloudAccess) AccessKeyId() string                    { return c.accessKeyId }
+func (c testCloudAccess) SecretAccessKey() credential.Password   { return c.secretAccessKey }
+func (c testCloudAccess) SessionToken() string                   { return c.sessionToken }
+func (c testCloudAccess) AccessToken() credential.Password       { return c.accessToken }
+func (c testCloudAccess) ServiceAccountKey() credential.Password { return c.serviceAccountKey }
+func (c testCloudAccess) ClientId() string                       { return c.clientId }
+func (c testCloudAccess) ClientSecret() credential.Password      { return c.clientSecret }
+func (c testCloudAccess) TenantId() string                       { return c.tenantId }
+func (c testCloudAccess) SubscriptionId() string                 { return c.subscriptionId }
+func (c testCloudAccess) Expiration() time.Time                  { return c.expiration }
+
+func TestCloudAccessToStruct(t *testing.T) {
+	exp := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+	cases := []struct {
+		name string
+		cred testCloudAccess
+		want map[string]any
+	}{
+		{
+			name: "aws",
+			cred: testCloudAccess{provider: "aws", accessKeyId: "ASIA", secretAccessKey: "secret", sessionToken: "token", expiration: exp},
+			want: map[string]any{
+				"provider":          "aws",
+				"access_key_id":     "ASIA",
+				"secret_access_key": "secret",
+				"session_token":     "token",
+				"expiration":        "2024-06-01T12:00:00Z",
+			},
+		},
+		{
+			name: "gcp",
+			cred: testCloudAccess{provider: "gcp", accessToken: "ya29.token", serviceAccountKey: `{"type":"service_account"}`},
+			want: map[string]any{
+				"provider":            "gcp",
+				"access_token":        "ya29.token",
+				"service_account_key": `{"type":"service_account"}`,
+			},
+		},
+		{
+			name: "azure",
+			cred: testCloudAccess{provider: "azure", clientId: "client", clientSecret: "secret", tenantId: "tenant"},
+			want: map[string]any{
+				"provider":      "azure",
+				"client_id":     "client",
+				"client_secret": "secret",
+				"tenant_id":     "tenant",
+			},
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := cloudAccessToStruct(tc.cred)
+			require.NoError(t, err)
+			assert.Equal(t, tc.want, got.AsMap())
+		})
+	}
+}
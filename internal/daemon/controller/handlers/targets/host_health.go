@@ -0,0 +1,149 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package targets
+
+import (
+	"net"
+	"net/url"
+
+	wl "github.com/hashicorp/boundary/internal/daemon/common"
+	"github.com/hashicorp/boundary/internal/host"
+)
+
+const (
+	// maxFailoverEndpoints is the maximum number of alternate endpoints a
+	// worker is given to fail over to when it cannot connect to the endpoint
+	// of a session.
+	maxFailoverEndpoints = 3
+	// maxProbedEndpoints is the maximum number of endpoints of other hosts a
+	// worker is asked to probe the health of for a session.
+	maxProbedEndpoints = 16
+)
+
+// hostEndpoints returns the host:port form of each endpoint's address, in the
+// form workers report endpoint health in.
+func hostEndpoints(endpoints []*host.Endpoint, port string) []string {
+	ret := make([]string, 0, len(endpoints))
+	for _, ep := range endpoints {
+		ret = append(ret, net.JoinHostPort(ep.Address, port))
+	}
+	return ret
+}
+
+// healthyEndpoints returns the endpoints whose host:port form is not in
+// unhealthy. The order of the endpoints is preserved.
+func healthyEndpoints(endpoints []*host.Endpoint, port string, unhealthy []string) []*host.Endpoint {
+	if len(unhealthy) == 0 {
+		return endpoints
+	}
+	skip := make(map[string]struct{}, len(unhealthy))
+	for _, u := range unhealthy {
+		skip[u] = struct{}{}
+	}
+	ret := make([]*host.Endpoint, 0, len(endpoints))
+	for _, ep := range endpoints {
+		if _, ok := skip[net.JoinHostPort(ep.Address, port)]; ok {
+			continue
+		}
+		ret = append(ret, ep)
+	}
+	return ret
+}
+
+// failoverEndpoints returns, in the order they should be tried, up to
+// maxFailoverEndpoints endpoint urls of other hosts in the same host set as the
+// chosen endpoint. Alternate hosts are ordered randomly using r so that
+// sessions failing over don't all land on the same host.
+func failoverEndpoints(scheme string, chosen *host.Endpoint, endpoints []*host.Endpoint, port string, r workerRand) []string {
+	seen := map[string]struct{}{chosen.Address: {}}
+	var ret []string
+	for _, ep := range endpoints {
+		if ep.SetId != chosen.SetId {
+			continue
+		}
+		if _, ok := seen[ep.Address]; ok {
+			continue
+		}
+		// Addresses with a port are rejected for the chosen endpoint, so don't
+		// hand them to the worker either.
+		if _, _, err := net.SplitHostPort(ep.Address); err == nil {
+			continue
+		}
+		seen[ep.Address] = struct{}{}
+		ret = append(ret, (&url.URL{
+			Scheme: scheme,
+			Host:   net.JoinHostPort(ep.Address, port),
+		}).String())
+	}
+	r.Shuffle(len(ret), func(i, j int) {
+		ret[i], ret[j] = ret[j], ret[i]
+	})
+	if len(ret) > maxFailoverEndpoints {
+		ret = ret[:maxFailoverEndpoints]
+	}
+	return ret
+}
+
+// probedEndpoints returns, in host:port form, up to maxProbedEndpoints
+// endpoints of other hosts in the same host set as the chosen endpoint for the
+// worker to probe. The endpoints in unhealthy come first, so that a worker
+// notices when they recover, followed by the others in random order.
+func probedEndpoints(chosen *host.Endpoint, endpoints []*host.Endpoint, port string, unhealthy []string, r workerRand) []string {
+	isUnhealthy := make(map[string]struct{}, len(unhealthy))
+	for _, u := range unhealthy {
+		isUnhealthy[u] = struct{}{}
+	}
+	seen := map[string]struct{}{chosen.Address: {}}
+	var first, rest []string
+	for _, ep := range endpoints {
+		if ep.SetId != chosen.SetId {
+			continue
+		}
+		if _, ok := seen[ep.Address]; ok {
+			continue
+		}
+		if _, _, err := net.SplitHostPort(ep.Address); err == nil {
+			continue
+		}
+		seen[ep.Address] = struct{}{}
+		hp := net.JoinHostPort(ep.Address, port)
+		if _, ok := isUnhealthy[hp]; ok {
+			first = append(first, hp)
+			continue
+		}
+		rest = append(rest, hp)
+	}
+	r.Shuffle(len(rest), func(i, j int) {
+		rest[i], rest[j] = rest[j], rest[i]
+	})
+	ret := append(first, rest...)
+	if len(ret) > maxProbedEndpoints {
+		ret = ret[:maxProbedEndpoints]
+	}
+	return ret
+}
+
+// deprioritizeWorkers moves the workers with the given ids, which reported the
+// endpoint of the session as unhealthy, after the other workers. They are kept
+// as a last resort since the host may only be unreachable from some workers.
+// The order of the workers is otherwise preserved.
+func deprioritizeWorkers(workers wl.WorkerList, ids []string) wl.WorkerList {
+	if len(ids) == 0 {
+		return workers
+	}
+	last := make(map[string]struct{}, len(ids))
+	for _, id := range ids {
+		last[id] = struct{}{}
+	}
+	ret := make(wl.WorkerList, 0, len(workers))
+	var deprioritized wl.WorkerList
+	for _, w := range workers {
+		if _, ok := last[w.GetPublicId()]; ok {
+			deprioritized = append(deprioritized, w)
+			continue
+		}
+		ret = append(ret, w)
+	}
+	return append(ret, deprioritized...)
+}
@@ -0,0 +1,128 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package targets
+
+import (
+	"fmt"
+	"math/rand"
+	"testing"
+
+	"github.com/hashicorp/boundary/internal/daemon/common"
+	"github.com/hashicorp/boundary/internal/host"
+	"github.com/hashicorp/boundary/internal/server"
+	"github.com/hashicorp/boundary/internal/types/scope"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHealthyEndpoints(t *testing.T) {
+	endpoints := []*host.Endpoint{
+		{HostId: "h_1", SetId: "s_1", Address: "10.0.0.1"},
+		{HostId: "h_2", SetId: "s_1", Address: "10.0.0.2"},
+		{HostId: "h_3", SetId: "s_1", Address: "::1"},
+	}
+	assert.Equal(t, []string{"10.0.0.1:22", "10.0.0.2:22", "[::1]:22"}, hostEndpoints(endpoints, "22"))
+
+	assert.Equal(t, endpoints, healthyEndpoints(endpoints, "22", nil))
+	assert.Equal(t, endpoints[1:], healthyEndpoints(endpoints, "22", []string{"10.0.0.1:22"}))
+	assert.Equal(t, endpoints[:2], healthyEndpoints(endpoints, "22", []string{"[::1]:22"}))
+	// Health is per port.
+	assert.Equal(t, endpoints, healthyEndpoints(endpoints, "22", []string{"10.0.0.1:443"}))
+	assert.Empty(t, healthyEndpoints(endpoints, "22", hostEndpoints(endpoints, "22")))
+}
+
+func TestFailoverEndpoints(t *testing.T) {
+	chosen := &host.Endpoint{HostId: "h_1", SetId: "s_1", Address: "10.0.0.1"}
+	endpoints := []*host.Endpoint{
+		chosen,
+		{HostId: "h_2", SetId: "s_1", Address: "10.0.0.2"},
+		{HostId: "h_3", SetId: "s_2", Address: "10.0.0.3"},
+		{HostId: "h_4", SetId: "s_1", Address: "10.0.0.1"},
+		{HostId: "h_5", SetId: "s_1", Address: "10.0.0.5:8080"},
+		{HostId: "h_6", SetId: "s_1", Address: "10.0.0.6"},
+	}
+
+	t.Run("same-set", func(t *testing.T) {
+		r := rand.New(rand.NewSource(1))
+		got := failoverEndpoints("tcp", chosen, endpoints, "22", r)
+		assert.ElementsMatch(t, []string{"tcp://10.0.0.2:22", "tcp://10.0.0.6:22"}, got)
+	})
+
+	t.Run("capped", func(t *testing.T) {
+		r := rand.New(rand.NewSource(1))
+		var many []*host.Endpoint
+		for _, a := range []string{"10.0.1.1", "10.0.1.2", "10.0.1.3", "10.0.1.4", "10.0.1.5"} {
+			many = append(many, &host.Endpoint{SetId: "s_1", Address: a})
+		}
+		seen := map[string]struct{}{}
+		for i := 0; i < 50; i++ {
+			got := failoverEndpoints("ssh", chosen, many, "22", r)
+			assert.Len(t, got, maxFailoverEndpoints)
+			for _, g := range got {
+				seen[g] = struct{}{}
+			}
+		}
+		// The alternates are picked randomly so all of them get used.
+		assert.Len(t, seen, len(many))
+	})
+
+	t.Run("none", func(t *testing.T) {
+		r := rand.New(rand.NewSource(1))
+		assert.Empty(t, failoverEndpoints("tcp", chosen, []*host.Endpoint{chosen}, "22", r))
+	})
+}
+
+func TestProbedEndpoints(t *testing.T) {
+	chosen := &host.Endpoint{HostId: "h_1", SetId: "s_1", Address: "10.0.0.1"}
+	endpoints := []*host.Endpoint{
+		chosen,
+		{HostId: "h_2", SetId: "s_1", Address: "10.0.0.2"},
+		{HostId: "h_3", SetId: "s_2", Address: "10.0.0.3"},
+		{HostId: "h_4", SetId: "s_1", Address: "10.0.0.4"},
+		{HostId: "h_5", SetId: "s_1", Address: "10.0.0.5:8080"},
+		{HostId: "h_6", SetId: "s_1", Address: "10.0.0.6"},
+	}
+
+	t.Run("same-set", func(t *testing.T) {
+		r := rand.New(rand.NewSource(1))
+		got := probedEndpoints(chosen, endpoints, "22", nil, r)
+		assert.ElementsMatch(t, []string{"10.0.0.2:22", "10.0.0.4:22", "10.0.0.6:22"}, got)
+	})
+
+	t.Run("unhealthy-first", func(t *testing.T) {
+		r := rand.New(rand.NewSource(1))
+		got := probedEndpoints(chosen, endpoints, "22", []string{"10.0.0.6:22", "10.0.0.3:22"}, r)
+		assert.Equal(t, "10.0.0.6:22", got[0])
+		assert.ElementsMatch(t, []string{"10.0.0.2:22", "10.0.0.4:22"}, got[1:])
+	})
+
+	t.Run("capped", func(t *testing.T) {
+		r := rand.New(rand.NewSource(1))
+		var many []*host.Endpoint
+		for i := 0; i < 2*maxProbedEndpoints; i++ {
+			many = append(many, &host.Endpoint{SetId: "s_1", Address: fmt.Sprintf("10.0.1.%d", i)})
+		}
+		got := probedEndpoints(chosen, many, "22", []string{"10.0.1.30:22"}, r)
+		assert.Len(t, got, maxProbedEndpoints)
+		assert.Equal(t, "10.0.1.30:22", got[0])
+	})
+}
+
+func TestDeprioritizeWorkers(t *testing.T) {
+	newWorker := func(id string) *server.Worker {
+		w := server.NewWorker(scope.Global.String())
+		w.PublicId = id
+		return w
+	}
+	workers := common.WorkerList{newWorker("w_1"), newWorker("w_2"), newWorker("w_3"), newWorker("w_4")}
+	ids := func(ws common.WorkerList) []string {
+		var ret []string
+		for _, w := range ws {
+			ret = append(ret, w.GetPublicId())
+		}
+		return ret
+	}
+
+	assert.Equal(t, []string{"w_1", "w_2", "w_3", "w_4"}, ids(deprioritizeWorkers(workers, nil)))
+	assert.Equal(t, []string{"w_2", "w_4", "w_1", "w_3"}, ids(deprioritizeWorkers(workers, []string{"w_3", "w_1", "w_other"})))
+}
@@ -0,0 +1,38 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package targets
+
+import (
+	"fmt"
+	"regexp"
+
+	"github.com/hashicorp/boundary/globals"
+	"github.com/hashicorp/boundary/internal/daemon/controller/handlers"
+	"github.com/hashicorp/boundary/internal/target"
+	"google.golang.org/grpc/codes"
+)
+
+// checkSessionJustification returns an error if the reason or ticket id don't
+// satisfy the requirements of the target.
+func checkSessionJustification(t target.Target, reason, ticketId string) error {
+	badFields := map[string]string{}
+	if t.GetSessionReasonRequired() && reason == "" {
+		badFields[globals.ReasonField] = "This target requires a reason to authorize a session."
+	}
+	if pattern := t.GetSessionTicketPattern(); pattern != "" {
+		re, err := regexp.Compile(pattern)
+		switch {
+		case err != nil:
+			return handlers.ApiErrorWithCodeAndMessage(codes.Internal, "Unable to parse the session ticket pattern of the target: %v.", err)
+		case ticketId == "":
+			badFields[globals.TicketIdField] = "This target requires a ticket ID to authorize a session."
+		case !re.MatchString(ticketId):
+			badFields[globals.TicketIdField] = fmt.Sprintf("Ticket ID doesn't match the pattern %q required by this target.", pattern)
+		}
+	}
+	if len(badFields) > 0 {
+		return handlers.InvalidArgumentErrorf("Session justification doesn't satisfy the requirements of the target.", badFields)
+	}
+	return nil
+}
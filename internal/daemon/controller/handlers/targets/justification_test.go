@@ -0,0 +1,106 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package targets
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/hashicorp/boundary/globals"
+	"github.com/hashicorp/boundary/internal/daemon/controller/handlers"
+	"github.com/hashicorp/boundary/internal/target"
+	"github.com/hashicorp/boundary/internal/target/targettest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCheckSessionJustification(t *testing.T) {
+	ctx := context.Background()
+	newTarget := func(t *testing.T, opt ...target.Option) target.Target {
+		tar, err := targettest.New(ctx, "p_1234567890", opt...)
+		require.NoError(t, err)
+		return tar
+	}
+
+	cases := []struct {
+		name        string
+		target      target.Target
+		reason      string
+		ticketId    string
+		wantFields  []string
+		wantStatus  int32
+		wantNoError bool
+	}{
+		{
+			name:        "no-requirements",
+			target:      newTarget(t),
+			wantNoError: true,
+		},
+		{
+			name:        "reason-provided",
+			target:      newTarget(t, target.WithSessionReasonRequired(true)),
+			reason:      "investigating an outage",
+			wantNoError: true,
+		},
+		{
+			name:       "reason-missing",
+			target:     newTarget(t, target.WithSessionReasonRequired(true)),
+			ticketId:   "INC-1",
+			wantStatus: http.StatusBadRequest,
+			wantFields: []string{globals.ReasonField},
+		},
+		{
+			name:        "ticket-matches",
+			target:      newTarget(t, target.WithSessionTicketPattern(`^INC-[0-9]+$`)),
+			ticketId:    "INC-1234",
+			wantNoError: true,
+		},
+		{
+			name:       "ticket-missing",
+			target:     newTarget(t, target.WithSessionTicketPattern(`^INC-[0-9]+$`)),
+			wantStatus: http.StatusBadRequest,
+			wantFields: []string{globals.TicketIdField},
+		},
+		{
+			name:       "ticket-mismatch",
+			target:     newTarget(t, target.WithSessionTicketPattern(`^INC-[0-9]+$`)),
+			ticketId:   "CHG-1234",
+			wantStatus: http.StatusBadRequest,
+			wantFields: []string{globals.TicketIdField},
+		},
+		{
+			name:       "both-missing",
+			target:     newTarget(t, target.WithSessionReasonRequired(true), target.WithSessionTicketPattern(`^INC-[0-9]+$`)),
+			wantStatus: http.StatusBadRequest,
+			wantFields: []string{globals.ReasonField, globals.TicketIdField},
+		},
+		{
+			name:       "invalid-pattern",
+			target:     newTarget(t, target.WithSessionTicketPattern(`[`)),
+			ticketId:   "INC-1234",
+			wantStatus: http.StatusInternalServerError,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert, require := assert.New(t), require.New(t)
+			err := checkSessionJustification(tc.target, tc.reason, tc.ticketId)
+			if tc.wantNoError {
+				require.NoError(err)
+				return
+			}
+			require.Error(err)
+			var apiErr *handlers.ApiError
+			require.True(errors.As(err, &apiErr))
+			assert.Equal(tc.wantStatus, apiErr.Status)
+			var gotFields []string
+			for _, f := range apiErr.Inner.GetDetails().GetRequestFields() {
+				gotFields = append(gotFields, f.GetName())
+			}
+			assert.ElementsMatch(tc.wantFields, gotFields)
+		})
+	}
+}
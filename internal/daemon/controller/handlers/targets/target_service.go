@@ -10,6 +10,8 @@ import (
 	"math/rand"
 	"net"
 	"net/url"
+	"regexp"
+	"slices"
 	"strconv"
 	"strings"
 	"sync/atomic"
@@ -31,6 +33,7 @@ import (
 	"github.com/hashicorp/boundary/internal/host"
 	"github.com/hashicorp/boundary/internal/host/plugin"
 	"github.com/hashicorp/boundary/internal/host/static"
+	"github.com/hashicorp/boundary/internal/iam"
 	"github.com/hashicorp/boundary/internal/kms"
 	"github.com/hashicorp/boundary/internal/listtoken"
 	"github.com/hashicorp/boundary/internal/pagination"
@@ -39,6 +42,7 @@ import (
 	"github.com/hashicorp/boundary/internal/server"
 	"github.com/hashicorp/boundary/internal/session"
 	"github.com/hashicorp/boundary/internal/target"
+	"github.com/hashicorp/boundary/internal/target/accesswindow"
 	"github.com/hashicorp/boundary/internal/types/action"
 	"github.com/hashicorp/boundary/internal/types/resource"
 	"github.com/hashicorp/boundary/internal/types/scope"
@@ -58,6 +62,11 @@ import (
 const (
 	credentialDomain = "credential"
 	hostDomain       = "host"
+
+	// maxSessionReasonLength and maxSessionTicketIdLength bound the
+	// justification given when authorizing a session.
+	maxSessionReasonLength   = 1024
+	maxSessionTicketIdLength = 256
 )
 
 // extraWorkerFilterFunc takes in a set of workers and returns another set,
@@ -101,7 +110,7 @@ var (
 	)
 
 	validateCredentialSourcesFn    = func(context.Context, globals.Subtype, []target.CredentialSource) error { return nil }
-	ValidateIngressWorkerFilterFn  = IngressWorkerFilterUnsupported
+	ValidateIngressWorkerFilterFn  = ValidateIngressWorkerFilter
 	AuthorizeSessionWorkerFilterFn = AuthorizeSessionWithWorkerFilter
 	SessionRecordingFn             = NoSessionRecording
 	WorkerFilterDeprecationMessage = fmt.Sprintf("This field is deprecated. Use %s instead.", globals.EgressWorkerFilterField)
@@ -116,6 +125,15 @@ func IngressWorkerFilterUnsupported(string) error {
 	return fmt.Errorf("Ingress Worker Filter field is not supported in OSS")
 }
 
+// ValidateIngressWorkerFilter ensures the ingress worker filter is a valid
+// boolean expression.
+func ValidateIngressWorkerFilter(filter string) error {
+	if _, err := bexpr.CreateEvaluator(filter); err != nil {
+		return fmt.Errorf("Unable to successfully parse ingress filter expression.")
+	}
+	return nil
+}
+
 // Service handles request as described by the pbs.TargetServiceServer interface.
 type Service struct {
 	pbs.UnsafeTargetServiceServer
@@ -785,6 +803,78 @@ func AuthorizeSessionWithWorkerFilter(
 	return selectedWorkers, nil, nil
 }
 
+// ingressWorkerRoutes returns the workers matching the ingress filter which
+// have a route to one of the egress workers, along with the route from each of
+// them keyed by the ingress worker id. downstreams maps worker ids to the ids
+// of the downstream workers connected to them.
+func ingressWorkerRoutes(
+	ingressFilter string,
+	liveWorkers, egressWorkers wl.WorkerList,
+	downstreams map[string][]string,
+) (wl.WorkerList, map[string][]string, error) {
+	eval, err := bexpr.CreateEvaluator(ingressFilter)
+	if err != nil {
+		return nil, nil, err
+	}
+	ingressWorkers, err := liveWorkers.Filtered(eval)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	egressIds := make(map[string]struct{}, len(egressWorkers))
+	for _, w := range egressWorkers {
+		egressIds[w.GetPublicId()] = struct{}{}
+	}
+	isEgress := func(id string) bool {
+		_, ok := egressIds[id]
+		return ok
+	}
+
+	var routable wl.WorkerList
+	routes := make(map[string][]string, len(ingressWorkers))
+	for _, w := range ingressWorkers {
+		route := common.ShortestWorkerRoute(w.GetPublicId(), downstreams, isEgress)
+		if len(route) == 0 {
+			continue
+		}
+		routable = append(routable, w)
+		routes[w.GetPublicId()] = route
+	}
+	if len(routable) == 0 {
+		return nil, nil, handlers.ApiErrorWithCodeAndMessage(
+			codes.FailedPrecondition,
+			"No ingress workers are available with a route to an egress worker for this session, or all have been filtered.")
+	}
+	return routable, routes, nil
+}
+
+// lookupSessionQuota returns the session quota of the user in the project.
+// The quota is enforced by the session repository when the session is created.
+func lookupSessionQuota(ctx context.Context, iamRepoFn common.IamRepoFactory, userId, projectId string) (*iam.SessionQuota, error) {
+	const op = "targets.lookupSessionQuota"
+	iamRepo, err := iamRepoFn()
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	quota, err := iamRepo.LookupSessionQuota(ctx, userId, projectId)
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	return quota, nil
+}
+
+// sessionQuotaError returns the API error for a session which could not be
+// created because it would exceed the session quota of the user, or nil if err
+// is not caused by the quota.
+func sessionQuotaError(err error) error {
+	for e := err; e != nil; e = stderrors.Unwrap(e) {
+		if domainErr, ok := e.(*errors.Err); ok && domainErr.Code == errors.SessionQuotaExceeded {
+			return handlers.ApiErrorWithCodeAndMessage(codes.ResourceExhausted, "%s", domainErr.Msg)
+		}
+	}
+	return nil
+}
+
 func NoSessionRecording(context.Context, intglobals.ControllerExtension, *kms.Kms, target.Target, *session.Session, *server.Worker) (string, error) {
 	return "", nil
 }
@@ -871,6 +961,22 @@ func (s Service) AuthorizeSession(ctx context.Context, req *pbs.AuthorizeSession
 	if t == nil {
 		return nil, handlers.NotFoundErrorf("Target %q not found.", t.GetPublicId())
 	}
+	var window *accesswindow.Window
+	if t.GetAccessWindow() != "" {
+		window, err = accesswindow.Parse(t.GetAccessWindow())
+		if err != nil {
+			return nil, errors.Wrap(ctx, err, op, errors.WithMsg("unable to parse target access window"))
+		}
+		if !window.Allows(time.Now()) {
+			return nil, handlers.ApiErrorWithCodeAndMessage(
+				codes.FailedPrecondition,
+				"Sessions to this target can only be authorized inside of its access window.")
+		}
+	}
+	reason, ticketId := strings.TrimSpace(req.GetReason()), strings.TrimSpace(req.GetTicketId())
+	if err := checkSessionJustification(t, reason, ticketId); err != nil {
+		return nil, err
+	}
 	hostSources := t.GetHostSources()
 	credSources := t.GetCredentialSources()
 	if len(credSources) > 0 {
@@ -888,9 +994,14 @@ func (s Service) AuthorizeSession(ctx context.Context, req *pbs.AuthorizeSession
 	if err != nil {
 		return nil, err
 	}
+	quota, err := lookupSessionQuota(ctx, s.iamRepoFn, authResults.UserId, t.GetProjectId())
+	if err != nil {
+		return nil, err
+	}
 
 	p := strconv.FormatUint(uint64(t.GetDefaultPort()), 10)
 	var h, hostId, hostSetId string
+	var failovers, probed []string
 
 	switch {
 	case t.GetAddress() != "":
@@ -953,6 +1064,27 @@ func (s Service) AuthorizeSession(ctx context.Context, req *pbs.AuthorizeSession
 			}
 		}
 
+		if chosenEndpoint == nil && t.GetHostHealthCheck() != "" {
+			// Skip endpoints which workers have recently reported as
+			// unhealthy and hand the worker the remaining ones in the same
+			// host set to fail over to.
+			unhealthy, err := serversRepo.ListUnhealthyHostEndpoints(ctx, hostEndpoints(endpoints, p), server.WithLiveness(time.Duration(s.workerStatusGracePeriod.Load())))
+			if err != nil {
+				return nil, err
+			}
+			healthy := healthyEndpoints(endpoints, p, unhealthy)
+			if len(healthy) == 0 {
+				return nil, handlers.ApiErrorWithCodeAndMessage(
+					codes.FailedPrecondition,
+					"No healthy hosts are available to handle this session.")
+			}
+			chosenEndpoint = healthy[rand.Intn(len(healthy))]
+			failovers = failoverEndpoints(t.GetType().String(), chosenEndpoint, healthy, p, globalRand{})
+			// The worker also probes the other hosts in the host set,
+			// including the unhealthy ones so their recovery is noticed.
+			probed = probedEndpoints(chosenEndpoint, endpoints, p, unhealthy, globalRand{})
+		}
+
 		if chosenEndpoint == nil {
 			chosenEndpoint = endpoints[rand.Intn(len(endpoints))]
 		}
@@ -987,8 +1119,11 @@ func (s Service) AuthorizeSession(ctx context.Context, req *pbs.AuthorizeSession
 		Host:   net.JoinHostPort(h, p),
 	}
 
-	// Get workers and filter down to ones that can service this request
-	selectedWorkers, err := serversRepo.ListWorkers(ctx, []string{scope.Global.String()}, server.WithLiveness(time.Duration(s.workerStatusGracePeriod.Load())))
+	// Get workers and filter down to ones that can service this request.
+	// Workers that are draining or shutting down are not given new sessions.
+	selectedWorkers, err := serversRepo.ListWorkers(ctx, []string{scope.Global.String()},
+		server.WithLiveness(time.Duration(s.workerStatusGracePeriod.Load())),
+		server.WithActiveWorkers(true))
 	if err != nil {
 		return nil, err
 	}
@@ -999,15 +1134,51 @@ func (s Service) AuthorizeSession(ctx context.Context, req *pbs.AuthorizeSession
 			"No workers are available to handle this session.")
 	}
 
+	// Workers too old to support the features used by the target are
+	// refused; they are reported so operators know to upgrade them.
+	selectedWorkers, incompatible := compatibleWorkers(selectedWorkers, requiredWorkerFeatures(t))
+	if len(incompatible) > 0 {
+		event.WriteSysEvent(ctx, op, "workers excluded from session for lacking features used by the target",
+			"target_id", t.GetPublicId(), "workers", incompatible)
+	}
+	if len(selectedWorkers) == 0 {
+		return nil, handlers.ApiErrorWithCodeAndMessage(
+			codes.FailedPrecondition,
+			"No workers are available to handle this session, the available workers are too old for the features used by this target.")
+	}
+
+	liveWorkers := selectedWorkers
 	selectedWorkers, protoWorker, err := AuthorizeSessionWorkerFilterFn(ctx, t, selectedWorkers, h, s.controllerExt, s.downstreams)
 	if err != nil {
 		return nil, err
 	}
 
-	// Randomize the workers
-	rand.Shuffle(len(selectedWorkers), func(i, j int) {
-		selectedWorkers[i], selectedWorkers[j] = selectedWorkers[j], selectedWorkers[i]
-	})
+	// With an ingress worker filter the client connects to an ingress worker
+	// which routes the connection through downstream workers to one of the
+	// egress workers selected above.
+	var routes map[string][]string
+	if t.GetIngressWorkerFilter() != "" {
+		downstreams, err := serversRepo.ListWorkerDownstreams(ctx, server.WithLiveness(time.Duration(s.workerStatusGracePeriod.Load())))
+		if err != nil {
+			return nil, err
+		}
+		selectedWorkers, routes, err = ingressWorkerRoutes(t.GetIngressWorkerFilter(), liveWorkers, selectedWorkers, downstreams)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// Order the workers using the target's selection strategy
+	selectedWorkers = orderWorkers(t.GetWorkerSelectionStrategy(), selectedWorkers, globalRand{})
+	if t.GetHostHealthCheck() != "" {
+		// Health is reported per worker, so prefer the workers which haven't
+		// found the endpoint unhealthy.
+		unhealthyFor, err := serversRepo.ListHostEndpointUnhealthyWorkers(ctx, endpointUrl.Host, server.WithLiveness(time.Duration(s.workerStatusGracePeriod.Load())))
+		if err != nil {
+			return nil, err
+		}
+		selectedWorkers = deprioritizeWorkers(selectedWorkers, unhealthyFor)
+	}
 
 	var vaultReqs []credential.Request
 	var staticIds []string
@@ -1029,6 +1200,13 @@ func (s Service) AuthorizeSession(ctx context.Context, req *pbs.AuthorizeSession
 
 	expTime := timestamppb.Now()
 	expTime.Seconds += int64(t.GetSessionMaxSeconds())
+	if window != nil && window.TerminateSessions {
+		// End the session when the access window closes, the session is then
+		// terminated the same way as when it reaches its maximum duration.
+		if closes, ok := window.NextClose(time.Now(), expTime.AsTime()); ok {
+			expTime = timestamppb.New(closes)
+		}
+	}
 	sessionComposition := session.ComposedOf{
 		UserId:              authResults.UserId,
 		HostId:              hostId,
@@ -1045,6 +1223,14 @@ func (s Service) AuthorizeSession(ctx context.Context, req *pbs.AuthorizeSession
 		DynamicCredentials:  dynCreds,
 		StaticCredentials:   staticCreds,
 		CorrelationId:       correlationId,
+		HostHealthCheck:     t.GetHostHealthCheck(),
+		FailoverEndpoints:   failovers,
+		ProbedEndpoints:     probed,
+		AccessWindow:        t.GetAccessWindow(),
+		UploadRateLimit:     t.GetSessionUploadRateLimit(),
+		DownloadRateLimit:   t.GetSessionDownloadRateLimit(),
+		Reason:              reason,
+		TicketId:            ticketId,
 	}
 	if protoWorker != nil {
 		sessionComposition.ProtocolWorkerId = protoWorker.GetPublicId()
@@ -1057,8 +1243,11 @@ func (s Service) AuthorizeSession(ctx context.Context, req *pbs.AuthorizeSession
 	if err != nil {
 		return nil, err
 	}
-	sess, err = sessionRepo.CreateSession(ctx, wrapper, sess, wl.WorkerList(selectedWorkers).Addresses())
+	sess, err = sessionRepo.CreateSession(ctx, wrapper, sess, wl.WorkerList(selectedWorkers).Addresses(), session.WithSessionQuota(quota))
 	if err != nil {
+		if quotaErr := sessionQuotaError(err); quotaErr != nil {
+			return nil, quotaErr
+		}
 		return nil, err
 	}
 	defer func() {
@@ -1127,7 +1316,13 @@ func (s Service) AuthorizeSession(ctx context.Context, req *pbs.AuthorizeSession
 
 	var creds []*pb.SessionCredential
 	var workerCreds []session.Credential
+	var issued []issuedCredential
 	for _, cred := range dynamic {
+		issued = append(issued, issuedCredential{
+			id:        cred.GetPublicId(),
+			libraryId: cred.Library().GetPublicId(),
+			purpose:   cred.Purpose(),
+		})
 		switch cred.Purpose() {
 		case credential.InjectedApplicationPurpose:
 			c, err := dynamicToWorkerCredential(ctx, cred)
@@ -1149,6 +1344,10 @@ func (s Service) AuthorizeSession(ctx context.Context, req *pbs.AuthorizeSession
 	}
 
 	for _, sc := range staticCreds {
+		issued = append(issued, issuedCredential{
+			id:      sc.CredentialStaticId,
+			purpose: credential.Purpose(sc.CredentialPurpose),
+		})
 		switch sc.CredentialPurpose {
 		case string(credential.InjectedApplicationPurpose):
 			c, err := staticToWorkerCredential(ctx, staticCredsById[sc.CredentialStaticId])
@@ -1222,6 +1421,9 @@ func (s Service) AuthorizeSession(ctx context.Context, req *pbs.AuthorizeSession
 		Endpoint:           endpointUrl.String(),
 		Credentials:        creds,
 	}
+	if routes != nil {
+		ret.Route = routes[selectedWorkers[0].GetPublicId()]
+	}
 
 	ret.SessionRecordingId, err = SessionRecordingFn(
 		ctx,
@@ -1237,6 +1439,19 @@ func (s Service) AuthorizeSession(ctx context.Context, req *pbs.AuthorizeSession
 		return nil, errors.Wrap(ctx, err, op)
 	}
 
+	// Record a system event per issued credential so credential usage can be
+	// followed in the event stream. The secret itself is never included.
+	for _, ic := range issued {
+		event.WriteSysEvent(ctx, op, "credential issued for session",
+			"credential_id", ic.id,
+			"credential_library_id", ic.libraryId,
+			"credential_purpose", string(ic.purpose),
+			"session_id", sess.PublicId,
+			"user_id", authResults.UserId,
+			"target_id", t.GetPublicId(),
+		)
+	}
+
 	return &pbs.AuthorizeSessionResponse{Item: ret}, nil
 }
 
@@ -1300,6 +1515,31 @@ func (s Service) createInRepo(ctx context.Context, item *pb.Target) (target.Targ
 	if item.GetIngressWorkerFilter() != nil {
 		opts = append(opts, target.WithIngressWorkerFilter(item.GetIngressWorkerFilter().GetValue()))
 	}
+	if item.GetWorkerSelectionStrategy() != nil {
+		opts = append(opts, target.WithWorkerSelectionStrategy(item.GetWorkerSelectionStrategy().GetValue()))
+	}
+	if item.GetHostHealthCheck() != nil {
+		opts = append(opts, target.WithHostHealthCheck(item.GetHostHealthCheck().GetValue()))
+	}
+	if item.GetAccessWindow() != nil {
+		window, err := accessWindowFromProto(item.GetAccessWindow())
+		if err != nil {
+			return nil, nil, nil, handlers.ApiErrorWithCodeAndMessage(codes.InvalidArgument, err.Error())
+		}
+		opts = append(opts, target.WithAccessWindow(window.String()))
+	}
+	if required := item.GetSessionReasonRequired(); required != nil {
+		opts = append(opts, target.WithSessionReasonRequired(required.GetValue()))
+	}
+	if pattern := item.GetSessionTicketPattern(); pattern != nil {
+		opts = append(opts, target.WithSessionTicketPattern(strings.TrimSpace(pattern.GetValue())))
+	}
+	if limit := item.GetSessionUploadRateLimit(); limit != nil {
+		opts = append(opts, target.WithSessionUploadRateLimit(limit.GetValue()))
+	}
+	if limit := item.GetSessionDownloadRateLimit(); limit != nil {
+		opts = append(opts, target.WithSessionDownloadRateLimit(limit.GetValue()))
+	}
 	if item.GetAddress() != nil {
 		opts = append(opts, target.WithAddress(strings.TrimSpace(item.GetAddress().GetValue())))
 	}
@@ -1331,6 +1571,9 @@ func (s Service) createInRepo(ctx context.Context, item *pb.Target) (target.Targ
 		}
 		createOptions = append(createOptions, target.WithAliases(writeAliases))
 	}
+	if templateId := item.GetTemplateId().GetValue(); templateId != "" {
+		createOptions = append(createOptions, target.WithTemplateId(templateId), target.WithTemplateOverrides(item.GetTemplateOverrides()))
+	}
 
 	out, err := repo.CreateTarget(ctx, u, createOptions...)
 	if err != nil {
@@ -1371,6 +1614,31 @@ func (s Service) updateInRepo(ctx context.Context, scopeId, id string, mask []st
 	if ingressFilter := item.GetIngressWorkerFilter(); ingressFilter != nil {
 		opts = append(opts, target.WithIngressWorkerFilter(item.GetIngressWorkerFilter().GetValue()))
 	}
+	if strategy := item.GetWorkerSelectionStrategy(); strategy != nil {
+		opts = append(opts, target.WithWorkerSelectionStrategy(strategy.GetValue()))
+	}
+	if check := item.GetHostHealthCheck(); check != nil {
+		opts = append(opts, target.WithHostHealthCheck(check.GetValue()))
+	}
+	if aw := item.GetAccessWindow(); aw != nil {
+		window, err := accessWindowFromProto(aw)
+		if err != nil {
+			return nil, nil, nil, handlers.ApiErrorWithCodeAndMessage(codes.InvalidArgument, err.Error())
+		}
+		opts = append(opts, target.WithAccessWindow(window.String()))
+	}
+	if required := item.GetSessionReasonRequired(); required != nil {
+		opts = append(opts, target.WithSessionReasonRequired(required.GetValue()))
+	}
+	if pattern := item.GetSessionTicketPattern(); pattern != nil {
+		opts = append(opts, target.WithSessionTicketPattern(strings.TrimSpace(pattern.GetValue())))
+	}
+	if limit := item.GetSessionUploadRateLimit(); limit != nil {
+		opts = append(opts, target.WithSessionUploadRateLimit(limit.GetValue()))
+	}
+	if limit := item.GetSessionDownloadRateLimit(); limit != nil {
+		opts = append(opts, target.WithSessionDownloadRateLimit(limit.GetValue()))
+	}
 	if item.GetAddress() != nil {
 		dbMask = append(dbMask, "Address")
 		opts = append(opts, target.WithAddress(strings.TrimSpace(item.GetAddress().GetValue())))
@@ -1398,8 +1666,22 @@ func (s Service) updateInRepo(ctx context.Context, scopeId, id string, mask []st
 	if err != nil {
 		return nil, nil, nil, errors.Wrap(ctx, err, op, errors.WithMsg("unable to update target"))
 	}
-	dbMask = append(dbMask, maskManager.Translate(mask)...)
-	if len(dbMask) == 0 {
+	// The template of a target is not part of the target's storage message,
+	// so it is set through options of the update.
+	var setTemplate bool
+	for _, f := range maskManager.Translate(mask) {
+		switch f {
+		case "TemplateId":
+			setTemplate = true
+			opts = append(opts, target.WithTemplateId(item.GetTemplateId().GetValue()))
+		case "TemplateOverrides":
+			setTemplate = true
+			opts = append(opts, target.WithTemplateOverrides(item.GetTemplateOverrides()))
+		default:
+			dbMask = append(dbMask, f)
+		}
+	}
+	if len(dbMask) == 0 && !setTemplate {
 		return nil, nil, nil, handlers.InvalidArgumentErrorf("No valid fields included in the update mask.", map[string]string{"update_mask": "No valid paths provided in the update mask."})
 	}
 	repo, err := s.repoFn()
@@ -1410,13 +1692,10 @@ func (s Service) updateInRepo(ctx context.Context, scopeId, id string, mask []st
 	if err != nil {
 		return nil, nil, nil, errors.Wrap(ctx, err, op, errors.WithMsg("unable to update target"))
 	}
-	hs := out.GetHostSources()
-	cl := out.GetCredentialSources()
-
 	if rowsUpdated == 0 {
 		return nil, nil, nil, handlers.NotFoundErrorf("Target %q not found or incorrect version provided.", id)
 	}
-	return out, hs, cl, nil
+	return out, out.GetHostSources(), out.GetCredentialSources(), nil
 }
 
 func (s Service) deleteFromRepo(ctx context.Context, id string) (bool, error) {
@@ -1734,6 +2013,31 @@ func toProto(ctx context.Context, in target.Target, opt ...handlers.Option) (*pb
 	if outputFields.Has(globals.IngressWorkerFilterField) && in.GetIngressWorkerFilter() != "" {
 		out.IngressWorkerFilter = wrapperspb.String(in.GetIngressWorkerFilter())
 	}
+	if outputFields.Has(globals.WorkerSelectionStrategyField) && in.GetWorkerSelectionStrategy() != "" {
+		out.WorkerSelectionStrategy = wrapperspb.String(in.GetWorkerSelectionStrategy())
+	}
+	if outputFields.Has(globals.HostHealthCheckField) && in.GetHostHealthCheck() != "" {
+		out.HostHealthCheck = wrapperspb.String(in.GetHostHealthCheck())
+	}
+	if outputFields.Has(globals.AccessWindowField) && in.GetAccessWindow() != "" {
+		aw, err := accessWindowToProto(in.GetAccessWindow())
+		if err != nil {
+			return nil, errors.Wrap(ctx, err, op, errors.WithMsg("unable to convert access window"))
+		}
+		out.AccessWindow = aw
+	}
+	if outputFields.Has(globals.SessionReasonRequiredField) && in.GetSessionReasonRequired() {
+		out.SessionReasonRequired = wrapperspb.Bool(true)
+	}
+	if outputFields.Has(globals.SessionTicketPatternField) && in.GetSessionTicketPattern() != "" {
+		out.SessionTicketPattern = wrapperspb.String(in.GetSessionTicketPattern())
+	}
+	if outputFields.Has(globals.SessionUploadRateLimitField) && in.GetSessionUploadRateLimit() != 0 {
+		out.SessionUploadRateLimit = wrapperspb.UInt32(in.GetSessionUploadRateLimit())
+	}
+	if outputFields.Has(globals.SessionDownloadRateLimitField) && in.GetSessionDownloadRateLimit() != 0 {
+		out.SessionDownloadRateLimit = wrapperspb.UInt32(in.GetSessionDownloadRateLimit())
+	}
 	if outputFields.Has(globals.ScopeField) {
 		out.Scope = opts.WithScope
 	}
@@ -1767,6 +2071,12 @@ func toProto(ctx context.Context, in target.Target, opt ...handlers.Option) (*pb
 	if outputFields.Has(globals.AddressField) {
 		out.Address = wrapperspb.String(in.GetAddress())
 	}
+	if outputFields.Has(globals.TemplateIdField) && in.GetTemplateId() != "" {
+		out.TemplateId = wrapperspb.String(in.GetTemplateId())
+	}
+	if outputFields.Has(globals.TemplateOverridesField) {
+		out.TemplateOverrides = in.GetTemplateOverrides()
+	}
 
 	var brokeredSources, injectedAppSources []*pb.CredentialSource
 	var brokeredSourceIds, injectedAppSourceIds []string
@@ -1871,6 +2181,34 @@ func validateCreateRequest(req *pbs.CreateTargetRequest) error {
 				badFields[globals.IngressWorkerFilterField] = err.Error()
 			}
 		}
+		if strategy := item.GetWorkerSelectionStrategy(); strategy != nil {
+			if err := ValidateWorkerSelectionStrategy(strategy.GetValue()); err != nil {
+				badFields[globals.WorkerSelectionStrategyField] = err.Error()
+			}
+		}
+		if check := item.GetHostHealthCheck(); check != nil && !host.ValidHealthCheck(check.GetValue()) {
+			badFields[globals.HostHealthCheckField] = fmt.Sprintf("Unknown host health check, must be one of: %s, %s.", host.TcpHealthCheck, host.TlsHealthCheck)
+		}
+		if aw := item.GetAccessWindow(); aw != nil {
+			if _, err := accessWindowFromProto(aw); err != nil {
+				badFields[globals.AccessWindowField] = err.Error()
+			}
+		}
+		if pattern := item.GetSessionTicketPattern(); pattern != nil && pattern.GetValue() != "" {
+			if _, err := regexp.Compile(pattern.GetValue()); err != nil {
+				badFields[globals.SessionTicketPatternField] = fmt.Sprintf("Invalid regular expression: %v.", err)
+			}
+		}
+		if limit := item.GetSessionUploadRateLimit(); limit != nil && limit.GetValue() == 0 {
+			badFields[globals.SessionUploadRateLimitField] = "This must be greater than zero."
+		}
+		if limit := item.GetSessionDownloadRateLimit(); limit != nil && limit.GetValue() == 0 {
+			badFields[globals.SessionDownloadRateLimitField] = "This must be greater than zero."
+		}
+		validateTemplate(item, badFields)
+		if len(item.GetTemplateOverrides()) > 0 && item.GetTemplateId().GetValue() == "" {
+			badFields[globals.TemplateOverridesField] = "This field can only be set along with a template."
+		}
 		if address := item.GetAddress(); address != nil {
 			if len(address.GetValue()) < static.MinHostAddressLength ||
 				len(address.GetValue()) > static.MaxHostAddressLength {
@@ -1903,6 +2241,20 @@ func validateCreateRequest(req *pbs.CreateTargetRequest) error {
 	})
 }
 
+// validateTemplate validates the template of the target and the settings of
+// the target not managed by the template.
+func validateTemplate(item *pb.Target, badFields map[string]string) {
+	if templateId := item.GetTemplateId().GetValue(); templateId != "" && !handlers.ValidId(handlers.Id(templateId), globals.TargetTemplatePrefix) {
+		badFields[globals.TemplateIdField] = "Incorrectly formatted identifier."
+	}
+	for _, f := range item.GetTemplateOverrides() {
+		if !slices.Contains(target.TemplateFields(), f) {
+			badFields[globals.TemplateOverridesField] = fmt.Sprintf("Unknown setting %q, must be one of: %s.", f, strings.Join(target.TemplateFields(), ", "))
+			break
+		}
+	}
+}
+
 func validateUpdateRequest(req *pbs.UpdateTargetRequest) error {
 	item := req.GetItem()
 	return handlers.ValidateUpdateRequest(req, req.GetItem(), func() map[string]string {
@@ -1951,6 +2303,31 @@ func validateUpdateRequest(req *pbs.UpdateTargetRequest) error {
 				badFields[globals.IngressWorkerFilterField] = err.Error()
 			}
 		}
+		if strategy := item.GetWorkerSelectionStrategy(); strategy != nil && strategy.GetValue() != "" {
+			if err := ValidateWorkerSelectionStrategy(strategy.GetValue()); err != nil {
+				badFields[globals.WorkerSelectionStrategyField] = err.Error()
+			}
+		}
+		if check := item.GetHostHealthCheck(); check != nil && check.GetValue() != "" && !host.ValidHealthCheck(check.GetValue()) {
+			badFields[globals.HostHealthCheckField] = fmt.Sprintf("Unknown host health check, must be one of: %s, %s.", host.TcpHealthCheck, host.TlsHealthCheck)
+		}
+		if aw := item.GetAccessWindow(); aw != nil {
+			if _, err := accessWindowFromProto(aw); err != nil {
+				badFields[globals.AccessWindowField] = err.Error()
+			}
+		}
+		if pattern := item.GetSessionTicketPattern(); pattern != nil && pattern.GetValue() != "" {
+			if _, err := regexp.Compile(pattern.GetValue()); err != nil {
+				badFields[globals.SessionTicketPatternField] = fmt.Sprintf("Invalid regular expression: %v.", err)
+			}
+		}
+		if limit := item.GetSessionUploadRateLimit(); limit != nil && limit.GetValue() == 0 {
+			badFields[globals.SessionUploadRateLimitField] = "This must be greater than zero."
+		}
+		if limit := item.GetSessionDownloadRateLimit(); limit != nil && limit.GetValue() == 0 {
+			badFields[globals.SessionDownloadRateLimitField] = "This must be greater than zero."
+		}
+		validateTemplate(item, badFields)
 		if address := item.GetAddress(); address != nil {
 			if len(address.GetValue()) < static.MinHostAddressLength ||
 				len(address.GetValue()) > static.MaxHostAddressLength {
@@ -2104,10 +2481,13 @@ func validateAddCredentialSourcesRequest(req *pbs.AddTargetCredentialSourcesRequ
 	for _, cl := range req.GetBrokeredCredentialSourceIds() {
 		if !handlers.ValidId(handlers.Id(cl),
 			globals.VaultCredentialLibraryPrefix,
+			globals.VaultDatabaseCredentialLibraryPrefix,
+			globals.VaultPkiCredentialLibraryPrefix,
 			globals.UsernamePasswordCredentialPrefix,
 			globals.UsernamePasswordCredentialPreviousPrefix,
 			globals.SshPrivateKeyCredentialPrefix,
-			globals.JsonCredentialPrefix) {
+			globals.JsonCredentialPrefix,
+			globals.TlsClientCertificateCredentialPrefix) {
 			badFields[globals.BrokeredCredentialSourceIdsField] = fmt.Sprintf("Incorrectly formatted credential source identifier %q.", cl)
 			break
 		}
@@ -2115,6 +2495,8 @@ func validateAddCredentialSourcesRequest(req *pbs.AddTargetCredentialSourcesRequ
 	for _, cl := range req.GetInjectedApplicationCredentialSourceIds() {
 		if !handlers.ValidId(handlers.Id(cl),
 			globals.VaultCredentialLibraryPrefix,
+			globals.VaultDatabaseCredentialLibraryPrefix,
+			globals.VaultPkiCredentialLibraryPrefix,
 			globals.VaultSshCertificateCredentialLibraryPrefix,
 			globals.UsernamePasswordCredentialPrefix,
 			globals.UsernamePasswordCredentialPreviousPrefix,
@@ -2140,10 +2522,13 @@ func validateSetCredentialSourcesRequest(req *pbs.SetTargetCredentialSourcesRequ
 	for _, cl := range req.GetBrokeredCredentialSourceIds() {
 		if !handlers.ValidId(handlers.Id(cl),
 			globals.VaultCredentialLibraryPrefix,
+			globals.VaultDatabaseCredentialLibraryPrefix,
+			globals.VaultPkiCredentialLibraryPrefix,
 			globals.UsernamePasswordCredentialPrefix,
 			globals.UsernamePasswordCredentialPreviousPrefix,
 			globals.SshPrivateKeyCredentialPrefix,
-			globals.JsonCredentialPrefix) {
+			globals.JsonCredentialPrefix,
+			globals.TlsClientCertificateCredentialPrefix) {
 			badFields[globals.BrokeredCredentialSourceIdsField] = fmt.Sprintf("Incorrectly formatted credential source identifier %q.", cl)
 			break
 		}
@@ -2151,6 +2536,8 @@ func validateSetCredentialSourcesRequest(req *pbs.SetTargetCredentialSourcesRequ
 	for _, cl := range req.GetInjectedApplicationCredentialSourceIds() {
 		if !handlers.ValidId(handlers.Id(cl),
 			globals.VaultCredentialLibraryPrefix,
+			globals.VaultDatabaseCredentialLibraryPrefix,
+			globals.VaultPkiCredentialLibraryPrefix,
 			globals.VaultSshCertificateCredentialLibraryPrefix,
 			globals.UsernamePasswordCredentialPrefix,
 			globals.UsernamePasswordCredentialPreviousPrefix,
@@ -2180,10 +2567,13 @@ func validateRemoveCredentialSourcesRequest(req *pbs.RemoveTargetCredentialSourc
 	for _, cl := range req.GetBrokeredCredentialSourceIds() {
 		if !handlers.ValidId(handlers.Id(cl),
 			globals.VaultCredentialLibraryPrefix,
+			globals.VaultDatabaseCredentialLibraryPrefix,
+			globals.VaultPkiCredentialLibraryPrefix,
 			globals.UsernamePasswordCredentialPrefix,
 			globals.UsernamePasswordCredentialPreviousPrefix,
 			globals.SshPrivateKeyCredentialPrefix,
-			globals.JsonCredentialPrefix) {
+			globals.JsonCredentialPrefix,
+			globals.TlsClientCertificateCredentialPrefix) {
 			badFields[globals.BrokeredCredentialSourceIdsField] = fmt.Sprintf("Incorrectly formatted credential source identifier %q.", cl)
 			break
 		}
@@ -2191,6 +2581,8 @@ func validateRemoveCredentialSourcesRequest(req *pbs.RemoveTargetCredentialSourc
 	for _, cl := range req.GetInjectedApplicationCredentialSourceIds() {
 		if !handlers.ValidId(handlers.Id(cl),
 			globals.VaultCredentialLibraryPrefix,
+			globals.VaultDatabaseCredentialLibraryPrefix,
+			globals.VaultPkiCredentialLibraryPrefix,
 			globals.VaultSshCertificateCredentialLibraryPrefix,
 			globals.UsernamePasswordCredentialPrefix,
 			globals.UsernamePasswordCredentialPreviousPrefix,
@@ -2241,6 +2633,12 @@ func validateAuthorizeSessionRequest(req *pbs.AuthorizeSessionRequest) error {
 			badFields[globals.HostIdField] = "Incorrectly formatted identifier."
 		}
 	}
+	if len(req.GetReason()) > maxSessionReasonLength {
+		badFields[globals.ReasonField] = fmt.Sprintf("Must be at most %d characters.", maxSessionReasonLength)
+	}
+	if len(req.GetTicketId()) > maxSessionTicketIdLength {
+		badFields[globals.TicketIdField] = fmt.Sprintf("Must be at most %d characters.", maxSessionTicketIdLength)
+	}
 	if len(badFields) > 0 {
 		return handlers.InvalidArgumentErrorf("Errors in provided fields.", badFields)
 	}
@@ -133,3 +133,81 @@ func TestWorkerList_EgressFilter(t *testing.T) {
 		})
 	}
 }
+
+func TestIngressWorkerRoutes(t *testing.T) {
+	newWorker := func(id, tier string) *server.Worker {
+		w := server.NewWorker(scope.Global.String(),
+			server.WithName(id),
+			server.WithAddress(id),
+			server.WithWorkerTags(&server.Tag{Key: "tier", Value: tier}),
+			server.WithTestUseInputTagsAsApiTags(true))
+		w.PublicId = id
+		return w
+	}
+	dmz1, dmz2 := newWorker("w_dmz1", "dmz"), newWorker("w_dmz2", "dmz")
+	priv1, priv2 := newWorker("w_priv1", "private"), newWorker("w_priv2", "private")
+	live := common.WorkerList{dmz1, dmz2, priv1, priv2}
+	downstreams := map[string][]string{
+		dmz1.GetPublicId():  {priv1.GetPublicId()},
+		priv1.GetPublicId(): {priv2.GetPublicId()},
+	}
+
+	tests := []struct {
+		name        string
+		filter      string
+		egress      common.WorkerList
+		wantIngress []string
+		wantRoutes  map[string][]string
+		wantErr     bool
+	}{
+		{
+			name:        "multi-hop",
+			filter:      `"dmz" in "/tags/tier"`,
+			egress:      common.WorkerList{priv2},
+			wantIngress: []string{dmz1.GetPublicId()},
+			wantRoutes: map[string][]string{
+				dmz1.GetPublicId(): {dmz1.GetPublicId(), priv1.GetPublicId(), priv2.GetPublicId()},
+			},
+		},
+		{
+			name:        "ingress-is-egress",
+			filter:      `"dmz" in "/tags/tier"`,
+			egress:      live,
+			wantIngress: []string{dmz1.GetPublicId(), dmz2.GetPublicId()},
+			wantRoutes: map[string][]string{
+				dmz1.GetPublicId(): {dmz1.GetPublicId()},
+				dmz2.GetPublicId(): {dmz2.GetPublicId()},
+			},
+		},
+		{
+			name:    "no-route",
+			filter:  `"/name" == "w_dmz2"`,
+			egress:  common.WorkerList{priv2},
+			wantErr: true,
+		},
+		{
+			name:    "bad-filter",
+			filter:  `tier ==`,
+			egress:  live,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert, require := assert.New(t), require.New(t)
+			got, routes, err := ingressWorkerRoutes(tt.filter, live, tt.egress, downstreams)
+			if tt.wantErr {
+				require.Error(err)
+				return
+			}
+			require.NoError(err)
+			assert.Equal(tt.wantIngress, got.PublicIds())
+			assert.Equal(tt.wantRoutes, routes)
+		})
+	}
+}
+
+func TestValidateIngressWorkerFilter(t *testing.T) {
+	assert.NoError(t, ValidateIngressWorkerFilter(`"dmz" in "/tags/tier"`))
+	assert.Error(t, ValidateIngressWorkerFilter(`tier ==`))
+}
@@ -123,7 +123,7 @@ func init() {
 
 	if maskManager, err = handlers.NewMaskManager(
 		context.Background(),
-		handlers.MaskDestination{&tcpStore.Target{}, &store.TargetAddress{}},
+		handlers.MaskDestination{&tcpStore.Target{}, &store.TargetAddress{}, &store.TemplateTarget{}, &store.TemplateOverride{}},
 		handlers.MaskSource{&pb.Target{}, &pb.TcpTargetAttributes{}},
 	); err != nil {
 		panic(err)
@@ -0,0 +1,174 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package targets
+
+import (
+	"fmt"
+	"math/rand"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/boundary/globals"
+	wl "github.com/hashicorp/boundary/internal/daemon/common"
+	"github.com/hashicorp/boundary/internal/target"
+	"github.com/hashicorp/boundary/internal/target/udp"
+	"github.com/hashicorp/boundary/version"
+)
+
+// The strategies a target can use to order the workers that are able to
+// handle a session. The first worker in the resulting order is the one the
+// client connects to first. An empty strategy is treated as random.
+const (
+	RandomWorkerSelection           = "random"
+	LeastConnectionsWorkerSelection = "least_connections"
+	WeightedWorkerSelection         = "weighted"
+)
+
+// workerRand is the source of randomness used when ordering workers; it is
+// satisfied by *rand.Rand.
+type workerRand interface {
+	Shuffle(n int, swap func(i, j int))
+	Float64() float64
+}
+
+// globalRand is a workerRand using the package level functions of math/rand,
+// which are safe for concurrent use.
+type globalRand struct{}
+
+func (globalRand) Shuffle(n int, swap func(i, j int)) { rand.Shuffle(n, swap) }
+func (globalRand) Float64() float64                   { return rand.Float64() }
+
+var workerSelectionStrategies = []string{
+	RandomWorkerSelection,
+	LeastConnectionsWorkerSelection,
+	WeightedWorkerSelection,
+}
+
+// ValidateWorkerSelectionStrategy returns an error if the strategy is not one
+// of the supported worker selection strategies.
+func ValidateWorkerSelectionStrategy(strategy string) error {
+	for _, s := range workerSelectionStrategies {
+		if strategy == s {
+			return nil
+		}
+	}
+	return fmt.Errorf("unknown worker selection strategy %q, must be one of: %s", strategy, strings.Join(workerSelectionStrategies, ", "))
+}
+
+// orderWorkers orders the workers in place according to the strategy using r
+// as the source of randomness and returns them.
+//
+// The random strategy shuffles the workers. The least connections strategy
+// orders workers by their active connection count, breaking ties randomly. The
+// weighted strategy picks workers at random without replacement, with each
+// worker weighted by the inverse of its active connection count and the round
+// trip time of its last status request, so less loaded and closer workers are
+// more likely to be picked first without all sessions piling onto one of them.
+func orderWorkers(strategy string, workers wl.WorkerList, r workerRand) wl.WorkerList {
+	r.Shuffle(len(workers), func(i, j int) {
+		workers[i], workers[j] = workers[j], workers[i]
+	})
+	switch strategy {
+	case LeastConnectionsWorkerSelection:
+		sort.SliceStable(workers, func(i, j int) bool {
+			return workers[i].ActiveConnectionCount() < workers[j].ActiveConnectionCount()
+		})
+	case WeightedWorkerSelection:
+		weightedShuffle(workers, r)
+	}
+	return workers
+}
+
+// weightedShuffle orders the workers by repeatedly picking one of the
+// remaining workers with a probability proportional to its weight.
+func weightedShuffle(workers wl.WorkerList, r workerRand) {
+	// Workers which have not reported a round trip time yet are treated as
+	// having the average round trip time of the others.
+	var rttSum, rttCount uint64
+	for _, w := range workers {
+		if rtt := w.GetStatusRttMs(); rtt > 0 {
+			rttSum += uint64(rtt)
+			rttCount++
+		}
+	}
+	defaultRtt := float64(1)
+	if rttCount > 0 {
+		defaultRtt = float64(rttSum) / float64(rttCount)
+	}
+
+	weights := make([]float64, len(workers))
+	var total float64
+	for i, w := range workers {
+		rtt := float64(w.GetStatusRttMs())
+		if rtt == 0 {
+			rtt = defaultRtt
+		}
+		weights[i] = 1 / (float64(w.ActiveConnectionCount()+1) * max(rtt, 1))
+		total += weights[i]
+	}
+
+	for i := range workers {
+		pick := r.Float64() * total
+		j := i
+		for ; j < len(workers)-1; j++ {
+			pick -= weights[j]
+			if pick < 0 {
+				break
+			}
+		}
+		total -= weights[j]
+		workers[i], workers[j] = workers[j], workers[i]
+		weights[i], weights[j] = weights[j], weights[i]
+	}
+}
+
+// shadowableTargetType is the type of the targets whose sessions can be
+// shadowed, as the endpoint scheme of a session is the type of its target.
+const shadowableTargetType = globals.Subtype("ssh")
+
+// requiredWorkerFeatures returns the worker features used by the target,
+// which every worker proxying its sessions must support.
+func requiredWorkerFeatures(t target.Target) []version.Feature {
+	var ret []version.Feature
+	if t.GetType() == udp.Subtype {
+		ret = append(ret, version.UdpTarget)
+	}
+	if t.GetSessionUploadRateLimit() != 0 || t.GetSessionDownloadRateLimit() != 0 {
+		ret = append(ret, version.TargetBandwidthLimit)
+	}
+	if t.GetAccessWindow() != "" {
+		ret = append(ret, version.TargetAccessWindow)
+	}
+	if t.GetHostHealthCheck() != "" {
+		ret = append(ret, version.HostHealthFailover)
+	}
+	if t.GetType() == shadowableTargetType {
+		ret = append(ret, version.SessionShadow)
+	}
+	return ret
+}
+
+// compatibleWorkers splits the workers into the ones whose version supports
+// all of the features and the ones which do not, keyed by worker id and
+// listing the features they are missing.
+func compatibleWorkers(workers wl.WorkerList, features []version.Feature) (wl.WorkerList, map[string][]string) {
+	if len(features) == 0 {
+		return workers, nil
+	}
+	var ret wl.WorkerList
+	incompatible := make(map[string][]string)
+	for _, w := range workers {
+		missing := wl.UnsupportedFeatures(w, features...)
+		if len(missing) == 0 {
+			ret = append(ret, w)
+			continue
+		}
+		names := make([]string, 0, len(missing))
+		for _, f := range missing {
+			names = append(names, f.String())
+		}
+		incompatible[w.GetPublicId()] = names
+	}
+	return ret, incompatible
+}
@@ -0,0 +1,162 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package targets
+
+import (
+	"context"
+	"math/rand"
+	"testing"
+
+	"github.com/hashicorp/boundary/globals"
+	"github.com/hashicorp/boundary/internal/daemon/common"
+	"github.com/hashicorp/boundary/internal/server"
+	"github.com/hashicorp/boundary/internal/target"
+	"github.com/hashicorp/boundary/internal/target/tcp"
+	"github.com/hashicorp/boundary/internal/target/udp"
+	"github.com/hashicorp/boundary/internal/types/scope"
+	"github.com/hashicorp/boundary/version"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateWorkerSelectionStrategy(t *testing.T) {
+	for _, s := range []string{RandomWorkerSelection, LeastConnectionsWorkerSelection, WeightedWorkerSelection} {
+		assert.NoError(t, ValidateWorkerSelectionStrategy(s))
+	}
+	assert.Error(t, ValidateWorkerSelectionStrategy(""))
+	assert.Error(t, ValidateWorkerSelectionStrategy("round_robin"))
+}
+
+func TestOrderWorkers(t *testing.T) {
+	newWorker := func(id string, conns, rtt uint32) *server.Worker {
+		w := server.NewWorker(scope.Global.String(),
+			server.WithTestActiveConnectionCount(conns),
+			server.WithStatusRttMs(rtt))
+		w.PublicId = id
+		return w
+	}
+	workers := func() common.WorkerList {
+		return common.WorkerList{
+			newWorker("w_busy", 20, 10),
+			newWorker("w_idle", 0, 10),
+			newWorker("w_far", 0, 400),
+			newWorker("w_new", 0, 0),
+			newWorker("w_some", 2, 10),
+		}
+	}
+	ids := func(ws common.WorkerList) []string {
+		var ret []string
+		for _, w := range ws {
+			ret = append(ret, w.GetPublicId())
+		}
+		return ret
+	}
+	allIds := ids(workers())
+
+	t.Run("random", func(t *testing.T) {
+		r := rand.New(rand.NewSource(1))
+		for _, strategy := range []string{"", RandomWorkerSelection} {
+			firsts := map[string]int{}
+			for i := 0; i < 500; i++ {
+				got := orderWorkers(strategy, workers(), r)
+				require.ElementsMatch(t, allIds, ids(got))
+				firsts[got[0].GetPublicId()]++
+			}
+			assert.Len(t, firsts, len(allIds))
+		}
+	})
+
+	t.Run("least-connections", func(t *testing.T) {
+		r := rand.New(rand.NewSource(1))
+		firsts := map[string]int{}
+		for i := 0; i < 100; i++ {
+			got := orderWorkers(LeastConnectionsWorkerSelection, workers(), r)
+			require.ElementsMatch(t, allIds, ids(got))
+			assert.ElementsMatch(t, []string{"w_idle", "w_far", "w_new"}, ids(got[:3]))
+			assert.Equal(t, "w_some", got[3].GetPublicId())
+			assert.Equal(t, "w_busy", got[4].GetPublicId())
+			firsts[got[0].GetPublicId()]++
+		}
+		// Ties are broken randomly.
+		assert.Len(t, firsts, 3)
+	})
+
+	t.Run("weighted", func(t *testing.T) {
+		r := rand.New(rand.NewSource(1))
+		firsts := map[string]int{}
+		for i := 0; i < 2000; i++ {
+			got := orderWorkers(WeightedWorkerSelection, workers(), r)
+			require.ElementsMatch(t, allIds, ids(got))
+			firsts[got[0].GetPublicId()]++
+		}
+		// Every worker is picked first some of the time, the idle and close
+		// worker most often and the busy worker and far worker least often.
+		assert.Len(t, firsts, len(allIds))
+		assert.Greater(t, firsts["w_idle"], firsts["w_some"])
+		assert.Greater(t, firsts["w_some"], firsts["w_far"])
+		assert.Greater(t, firsts["w_some"], firsts["w_busy"])
+		// A worker without a round trip time is treated as having the average
+		// of the others, which is further than w_some.
+		assert.Greater(t, firsts["w_some"], firsts["w_new"])
+		assert.Greater(t, firsts["w_new"], firsts["w_far"])
+	})
+
+	t.Run("empty", func(t *testing.T) {
+		r := rand.New(rand.NewSource(1))
+		for _, strategy := range workerSelectionStrategies {
+			assert.Empty(t, orderWorkers(strategy, nil, r))
+		}
+	})
+}
+
+// sshTarget is a target of the type whose sessions can be shadowed.
+type sshTarget struct {
+	target.Target
+}
+
+func (sshTarget) GetType() globals.Subtype { return shadowableTargetType }
+
+func TestCompatibleWorkers(t *testing.T) {
+	ctx := context.Background()
+	newWorker := func(id, ver string) *server.Worker {
+		w := server.NewWorker(scope.Global.String(), server.WithReleaseVersion(ver))
+		w.PublicId = id
+		return w
+	}
+	workers := common.WorkerList{
+		newWorker("w_current", "Boundary v0.17.0"),
+		newWorker("w_old", "Boundary v0.16.0"),
+		newWorker("w_unknown", ""),
+	}
+
+	udpTarget, err := target.New(ctx, udp.Subtype, "p_1234567890")
+	require.NoError(t, err)
+	limitedTarget, err := target.New(ctx, tcp.Subtype, "p_1234567890", target.WithSessionDownloadRateLimit(1024))
+	require.NoError(t, err)
+	windowTarget, err := target.New(ctx, tcp.Subtype, "p_1234567890", target.WithAccessWindow(`{"schedules":["* 9-16 * * mon-fri"]}`))
+	require.NoError(t, err)
+	healthTarget, err := target.New(ctx, tcp.Subtype, "p_1234567890", target.WithHostHealthCheck("tls"))
+	require.NoError(t, err)
+	plainTarget, err := target.New(ctx, tcp.Subtype, "p_1234567890")
+	require.NoError(t, err)
+
+	assert.Equal(t, []version.Feature{version.UdpTarget}, requiredWorkerFeatures(udpTarget))
+	assert.Equal(t, []version.Feature{version.TargetBandwidthLimit}, requiredWorkerFeatures(limitedTarget))
+	assert.Equal(t, []version.Feature{version.TargetAccessWindow}, requiredWorkerFeatures(windowTarget))
+	assert.Equal(t, []version.Feature{version.HostHealthFailover}, requiredWorkerFeatures(healthTarget))
+	assert.Equal(t, []version.Feature{version.SessionShadow}, requiredWorkerFeatures(sshTarget{plainTarget}))
+	assert.Empty(t, requiredWorkerFeatures(plainTarget))
+
+	got, incompatible := compatibleWorkers(workers, requiredWorkerFeatures(plainTarget))
+	assert.Len(t, got, 3)
+	assert.Empty(t, incompatible)
+
+	got, incompatible = compatibleWorkers(workers, requiredWorkerFeatures(udpTarget))
+	require.Len(t, got, 1)
+	assert.Equal(t, "w_current", got[0].GetPublicId())
+	assert.Equal(t, map[string][]string{
+		"w_old":     {"udp-target"},
+		"w_unknown": {"udp-target"},
+	}, incompatible)
+}
@@ -0,0 +1,643 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package targettemplates
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/boundary/globals"
+	"github.com/hashicorp/boundary/internal/daemon/controller/auth"
+	"github.com/hashicorp/boundary/internal/daemon/controller/common"
+	"github.com/hashicorp/boundary/internal/daemon/controller/common/scopeids"
+	"github.com/hashicorp/boundary/internal/daemon/controller/handlers"
+	"github.com/hashicorp/boundary/internal/errors"
+	pbs "github.com/hashicorp/boundary/internal/gen/controller/api/services"
+	"github.com/hashicorp/boundary/internal/perms"
+	"github.com/hashicorp/boundary/internal/requests"
+	"github.com/hashicorp/boundary/internal/target"
+	"github.com/hashicorp/boundary/internal/target/store"
+	"github.com/hashicorp/boundary/internal/types/action"
+	"github.com/hashicorp/boundary/internal/types/resource"
+	"github.com/hashicorp/boundary/internal/types/scope"
+	pb "github.com/hashicorp/boundary/sdk/pbs/controller/api/resources/targettemplates"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+var (
+	maskManager handlers.MaskManager
+
+	// IdActions contains the set of actions that can be performed on
+	// individual resources
+	IdActions = action.NewActionSet(
+		action.NoOp,
+		action.Read,
+		action.Update,
+		action.Delete,
+		action.ListOutOfSyncTargets,
+	)
+
+	// CollectionActions contains the set of actions that can be performed on
+	// this collection
+	CollectionActions = action.NewActionSet(
+		action.Create,
+		action.List,
+	)
+
+	hostSourcePrefixes = []string{
+		globals.StaticHostSetPrefix,
+		globals.PluginHostSetPrefix,
+		globals.PluginHostSetPreviousPrefix,
+	}
+
+	credentialSourcePrefixes = []string{
+		globals.VaultCredentialLibraryPrefix,
+		globals.VaultSshCertificateCredentialLibraryPrefix,
+		globals.VaultDatabaseCredentialLibraryPrefix,
+		globals.VaultPkiCredentialLibraryPrefix,
+		globals.UsernamePasswordCredentialPrefix,
+		globals.UsernamePasswordCredentialPreviousPrefix,
+		globals.SshPrivateKeyCredentialPrefix,
+		globals.JsonCredentialPrefix,
+		globals.TlsClientCertificateCredentialPrefix,
+	}
+)
+
+func init() {
+	var err error
+	if maskManager, err = handlers.NewMaskManager(
+		context.Background(),
+		handlers.MaskDestination{&store.Template{}},
+		handlers.MaskSource{&pb.TargetTemplate{}},
+	); err != nil {
+		panic(err)
+	}
+
+	// TODO: refactor to remove IdActionsMap and CollectionActions package variables
+	action.RegisterResource(resource.TargetTemplate, IdActions, CollectionActions)
+}
+
+// Service handles requests as described by the pbs.TargetTemplateServiceServer interface.
+type Service struct {
+	pbs.UnsafeTargetTemplateServiceServer
+
+	repoFn    target.RepositoryFactory
+	iamRepoFn common.IamRepoFactory
+}
+
+var _ pbs.TargetTemplateServiceServer = (*Service)(nil)
+
+// NewService returns a target template service which handles target template
+// related requests to boundary.
+func NewService(ctx context.Context, repoFn target.RepositoryFactory, iamRepoFn common.IamRepoFactory) (Service, error) {
+	const op = "targettemplates.NewService"
+	if repoFn == nil {
+		return Service{}, errors.New(ctx, errors.InvalidParameter, op, "missing target repository")
+	}
+	if iamRepoFn == nil {
+		return Service{}, errors.New(ctx, errors.InvalidParameter, op, "missing iam repository")
+	}
+	return Service{repoFn: repoFn, iamRepoFn: iamRepoFn}, nil
+}
+
+// ListTargetTemplates implements the interface pbs.TargetTemplateServiceServer.
+func (s Service) ListTargetTemplates(ctx context.Context, req *pbs.ListTargetTemplatesRequest) (*pbs.ListTargetTemplatesResponse, error) {
+	if err := validateListRequest(ctx, req); err != nil {
+		return nil, err
+	}
+	authResults := s.authResult(ctx, req.GetScopeId(), action.List)
+	if authResults.Error != nil {
+		// If it's forbidden, and it's a recursive request, and they're
+		// successfully authenticated but just not authorized, keep going as we
+		// may have authorization on downstream scopes. Or, if they've not
+		// authenticated, still process in case u_anon has permissions.
+		if (authResults.Error == handlers.ForbiddenError() || authResults.Error == handlers.UnauthenticatedError()) &&
+			req.GetRecursive() &&
+			authResults.AuthenticationFinished {
+		} else {
+			return nil, authResults.Error
+		}
+	}
+
+	scopeIds, scopeInfoMap, err := scopeids.GetListingScopeIds(
+		ctx, s.iamRepoFn, authResults, req.GetScopeId(), resource.TargetTemplate, req.GetRecursive())
+	if err != nil {
+		return nil, err
+	}
+	// If no scopes match, return an empty response
+	if len(scopeIds) == 0 {
+		return &pbs.ListTargetTemplatesResponse{}, nil
+	}
+
+	tl, err := s.listFromRepo(ctx, scopeIds)
+	if err != nil {
+		return nil, err
+	}
+	if len(tl) == 0 {
+		return &pbs.ListTargetTemplatesResponse{}, nil
+	}
+
+	filter, err := handlers.NewFilter(ctx, req.GetFilter())
+	if err != nil {
+		return nil, err
+	}
+	finalItems := make([]*pb.TargetTemplate, 0, len(tl))
+	res := perms.Resource{
+		Type: resource.TargetTemplate,
+	}
+	for _, item := range tl {
+		res.Id = item.GetPublicId()
+		res.ScopeId = item.GetProjectId()
+		authorizedActions := authResults.FetchActionSetForId(ctx, item.GetPublicId(), IdActions, auth.WithResource(&res)).Strings()
+		if len(authorizedActions) == 0 {
+			continue
+		}
+
+		outputFields := authResults.FetchOutputFields(res, action.List).SelfOrDefaults(authResults.UserId)
+		outputOpts := make([]handlers.Option, 0, 3)
+		outputOpts = append(outputOpts, handlers.WithOutputFields(outputFields))
+		if outputFields.Has(globals.ScopeField) {
+			outputOpts = append(outputOpts, handlers.WithScope(scopeInfoMap[item.GetProjectId()]))
+		}
+		if outputFields.Has(globals.AuthorizedActionsField) {
+			outputOpts = append(outputOpts, handlers.WithAuthorizedActions(authorizedActions))
+		}
+
+		item, err := toProto(ctx, item, outputOpts...)
+		if err != nil {
+			return nil, err
+		}
+
+		if filter.Match(item) {
+			finalItems = append(finalItems, item)
+		}
+	}
+	return &pbs.ListTargetTemplatesResponse{Items: finalItems}, nil
+}
+
+// GetTargetTemplate implements the interface pbs.TargetTemplateServiceServer.
+func (s Service) GetTargetTemplate(ctx context.Context, req *pbs.GetTargetTemplateRequest) (*pbs.GetTargetTemplateResponse, error) {
+	const op = "targettemplates.(Service).GetTargetTemplate"
+
+	if err := validateGetRequest(req); err != nil {
+		return nil, err
+	}
+	authResults := s.authResult(ctx, req.GetId(), action.Read)
+	if authResults.Error != nil {
+		return nil, authResults.Error
+	}
+	tpl, err := s.getFromRepo(ctx, req.GetId())
+	if err != nil {
+		return nil, err
+	}
+
+	outputOpts, err := itemOutputOpts(ctx, authResults, tpl.GetPublicId())
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	item, err := toProto(ctx, tpl, outputOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return &pbs.GetTargetTemplateResponse{Item: item}, nil
+}
+
+// CreateTargetTemplate implements the interface pbs.TargetTemplateServiceServer.
+func (s Service) CreateTargetTemplate(ctx context.Context, req *pbs.CreateTargetTemplateRequest) (*pbs.CreateTargetTemplateResponse, error) {
+	const op = "targettemplates.(Service).CreateTargetTemplate"
+
+	if err := validateCreateRequest(req); err != nil {
+		return nil, err
+	}
+	authResults := s.authResult(ctx, req.GetItem().GetScopeId(), action.Create)
+	if authResults.Error != nil {
+		return nil, authResults.Error
+	}
+	tpl, err := s.createInRepo(ctx, authResults.Scope.GetId(), req.GetItem())
+	if err != nil {
+		return nil, err
+	}
+
+	outputOpts, err := itemOutputOpts(ctx, authResults, tpl.GetPublicId())
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	item, err := toProto(ctx, tpl, outputOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return &pbs.CreateTargetTemplateResponse{Item: item, Uri: fmt.Sprintf("target-templates/%s", item.GetId())}, nil
+}
+
+// UpdateTargetTemplate implements the interface pbs.TargetTemplateServiceServer.
+func (s Service) UpdateTargetTemplate(ctx context.Context, req *pbs.UpdateTargetTemplateRequest) (*pbs.UpdateTargetTemplateResponse, error) {
+	const op = "targettemplates.(Service).UpdateTargetTemplate"
+
+	if err := validateUpdateRequest(req); err != nil {
+		return nil, err
+	}
+	authResults := s.authResult(ctx, req.GetId(), action.Update)
+	if authResults.Error != nil {
+		return nil, authResults.Error
+	}
+	tpl, err := s.updateInRepo(ctx, authResults.Scope.GetId(), req.GetId(), req.GetUpdateMask().GetPaths(), req.GetItem())
+	if err != nil {
+		return nil, err
+	}
+
+	outputOpts, err := itemOutputOpts(ctx, authResults, tpl.GetPublicId())
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	item, err := toProto(ctx, tpl, outputOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return &pbs.UpdateTargetTemplateResponse{Item: item}, nil
+}
+
+// DeleteTargetTemplate implements the interface pbs.TargetTemplateServiceServer.
+func (s Service) DeleteTargetTemplate(ctx context.Context, req *pbs.DeleteTargetTemplateRequest) (*pbs.DeleteTargetTemplateResponse, error) {
+	if err := validateDeleteRequest(req); err != nil {
+		return nil, err
+	}
+	authResults := s.authResult(ctx, req.GetId(), action.Delete)
+	if authResults.Error != nil {
+		return nil, authResults.Error
+	}
+	if _, err := s.deleteFromRepo(ctx, req.GetId()); err != nil {
+		return nil, err
+	}
+	return nil, nil
+}
+
+// ListOutOfSyncTargets implements the interface pbs.TargetTemplateServiceServer.
+func (s Service) ListOutOfSyncTargets(ctx context.Context, req *pbs.ListOutOfSyncTargetsRequest) (*pbs.ListOutOfSyncTargetsResponse, error) {
+	const op = "targettemplates.(Service).ListOutOfSyncTargets"
+
+	if err := validateListOutOfSyncTargetsRequest(req); err != nil {
+		return nil, err
+	}
+	authResults := s.authResult(ctx, req.GetId(), action.ListOutOfSyncTargets)
+	if authResults.Error != nil {
+		return nil, authResults.Error
+	}
+	repo, err := s.repoFn()
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	outOfSync, err := repo.ListOutOfSyncTargets(ctx, req.GetId())
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	items := make([]*pb.OutOfSyncTarget, 0, len(outOfSync))
+	for _, t := range outOfSync {
+		items = append(items, &pb.OutOfSyncTarget{
+			TargetId: t.TargetId,
+			Fields:   t.Fields,
+		})
+	}
+	return &pbs.ListOutOfSyncTargetsResponse{Items: items}, nil
+}
+
+func (s Service) getFromRepo(ctx context.Context, id string) (*target.Template, error) {
+	const op = "targettemplates.(Service).getFromRepo"
+	repo, err := s.repoFn()
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	tpl, err := repo.LookupTemplate(ctx, id)
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	if tpl == nil {
+		return nil, handlers.NotFoundErrorf("Target template %q doesn't exist.", id)
+	}
+	return tpl, nil
+}
+
+func (s Service) listFromRepo(ctx context.Context, scopeIds []string) ([]*target.Template, error) {
+	const op = "targettemplates.(Service).listFromRepo"
+	repo, err := s.repoFn()
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	tl, err := repo.ListTemplates(ctx, scopeIds, target.WithLimit(-1))
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	return tl, nil
+}
+
+func (s Service) createInRepo(ctx context.Context, projectId string, item *pb.TargetTemplate) (*target.Template, error) {
+	const op = "targettemplates.(Service).createInRepo"
+	tpl, err := toStorage(ctx, projectId, item)
+	if err != nil {
+		return nil, handlers.ApiErrorWithCodeAndMessage(codes.Internal, "Unable to build target template for creation: %v.", err)
+	}
+	repo, err := s.repoFn()
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	out, err := repo.CreateTemplate(ctx, tpl)
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	if out == nil {
+		return nil, handlers.ApiErrorWithCodeAndMessage(codes.Internal, "Unable to create target template but no error returned from repository.")
+	}
+	return out, nil
+}
+
+func (s Service) updateInRepo(ctx context.Context, projectId, id string, mask []string, item *pb.TargetTemplate) (*target.Template, error) {
+	const op = "targettemplates.(Service).updateInRepo"
+	tpl, err := toStorage(ctx, projectId, item)
+	if err != nil {
+		return nil, handlers.ApiErrorWithCodeAndMessage(codes.Internal, "Unable to build target template for update: %v.", err)
+	}
+	tpl.PublicId = id
+	dbMask := maskManager.Translate(mask)
+	// The sources of a template are not part of the template's storage
+	// message, so they are translated here.
+	for _, f := range mask {
+		switch f {
+		case globals.HostSourceIdsField:
+			dbMask = append(dbMask, "HostSourceIds")
+		case globals.BrokeredCredentialSourceIdsField:
+			dbMask = append(dbMask, "BrokeredCredentialSourceIds")
+		case globals.InjectedApplicationCredentialSourceIdsField:
+			dbMask = append(dbMask, "InjectedApplicationCredentialSourceIds")
+		}
+	}
+	if len(dbMask) == 0 {
+		return nil, handlers.InvalidArgumentErrorf("No valid fields included in the update mask.", map[string]string{"update_mask": "No valid fields provided in the update mask."})
+	}
+	repo, err := s.repoFn()
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	out, rowsUpdated, err := repo.UpdateTemplate(ctx, tpl, item.GetVersion(), dbMask)
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	if rowsUpdated == 0 {
+		return nil, handlers.NotFoundErrorf("Target template %q doesn't exist or incorrect version provided.", id)
+	}
+	return out, nil
+}
+
+func (s Service) deleteFromRepo(ctx context.Context, id string) (bool, error) {
+	const op = "targettemplates.(Service).deleteFromRepo"
+	repo, err := s.repoFn()
+	if err != nil {
+		return false, err
+	}
+	rows, err := repo.DeleteTemplate(ctx, id)
+	if err != nil {
+		if errors.IsNotFoundError(err) {
+			return false, nil
+		}
+		return false, errors.Wrap(ctx, err, op, errors.WithMsg("unable to delete target template"))
+	}
+	return rows > 0, nil
+}
+
+func (s Service) authResult(ctx context.Context, id string, a action.Type) auth.VerifyResults {
+	res := auth.VerifyResults{}
+	iamRepo, err := s.iamRepoFn()
+	if err != nil {
+		res.Error = err
+		return res
+	}
+
+	var parentId string
+	opts := []auth.Option{auth.WithType(resource.TargetTemplate), auth.WithAction(a)}
+	switch a {
+	case action.List, action.Create:
+		parentId = id
+		scp, err := iamRepo.LookupScope(ctx, parentId)
+		if err != nil {
+			res.Error = err
+			return res
+		}
+		if scp == nil {
+			res.Error = handlers.NotFoundError()
+			return res
+		}
+	default:
+		repo, err := s.repoFn()
+		if err != nil {
+			res.Error = err
+			return res
+		}
+		tpl, err := repo.LookupTemplate(ctx, id)
+		if err != nil {
+			res.Error = err
+			return res
+		}
+		if tpl == nil {
+			res.Error = handlers.NotFoundError()
+			return res
+		}
+		parentId = tpl.GetProjectId()
+		opts = append(opts, auth.WithId(id))
+	}
+	opts = append(opts, auth.WithScopeId(parentId))
+	return auth.Verify(ctx, opts...)
+}
+
+func itemOutputOpts(ctx context.Context, authResults auth.VerifyResults, id string) ([]handlers.Option, error) {
+	const op = "targettemplates.itemOutputOpts"
+	outputFields, ok := requests.OutputFields(ctx)
+	if !ok {
+		return nil, errors.New(ctx, errors.Internal, op, "no request context found")
+	}
+	outputOpts := make([]handlers.Option, 0, 3)
+	outputOpts = append(outputOpts, handlers.WithOutputFields(outputFields))
+	if outputFields.Has(globals.ScopeField) {
+		outputOpts = append(outputOpts, handlers.WithScope(authResults.Scope))
+	}
+	if outputFields.Has(globals.AuthorizedActionsField) {
+		outputOpts = append(outputOpts, handlers.WithAuthorizedActions(authResults.FetchActionSetForId(ctx, id, IdActions).Strings()))
+	}
+	return outputOpts, nil
+}
+
+func toStorage(ctx context.Context, projectId string, item *pb.TargetTemplate) (*target.Template, error) {
+	var opts []target.Option
+	if item.GetName() != nil {
+		opts = append(opts, target.WithName(item.GetName().GetValue()))
+	}
+	if item.GetDescription() != nil {
+		opts = append(opts, target.WithDescription(item.GetDescription().GetValue()))
+	}
+	tpl, err := target.NewTemplate(ctx, projectId, opts...)
+	if err != nil {
+		return nil, err
+	}
+	tpl.SessionMaxSeconds = item.GetSessionMaxSeconds().GetValue()
+	tpl.SessionConnectionLimit = item.GetSessionConnectionLimit().GetValue()
+	tpl.EgressWorkerFilter = item.GetEgressWorkerFilter().GetValue()
+	tpl.IngressWorkerFilter = item.GetIngressWorkerFilter().GetValue()
+	tpl.HostSourceIds = item.GetHostSourceIds()
+	tpl.CredentialSources = target.CredentialSources{
+		BrokeredCredentialIds:            item.GetBrokeredCredentialSourceIds(),
+		InjectedApplicationCredentialIds: item.GetInjectedApplicationCredentialSourceIds(),
+	}
+	return tpl, nil
+}
+
+func toProto(ctx context.Context, in *target.Template, opt ...handlers.Option) (*pb.TargetTemplate, error) {
+	opts := handlers.GetOpts(opt...)
+	if opts.WithOutputFields == nil {
+		return nil, handlers.ApiErrorWithCodeAndMessage(codes.Internal, "output fields not found when building target template proto")
+	}
+	outputFields := *opts.WithOutputFields
+
+	out := pb.TargetTemplate{}
+	if outputFields.Has(globals.IdField) {
+		out.Id = in.GetPublicId()
+	}
+	if outputFields.Has(globals.ScopeIdField) {
+		out.ScopeId = in.GetProjectId()
+	}
+	if outputFields.Has(globals.ScopeField) {
+		out.Scope = opts.WithScope
+	}
+	if outputFields.Has(globals.NameField) && in.GetName() != "" {
+		out.Name = wrapperspb.String(in.GetName())
+	}
+	if outputFields.Has(globals.DescriptionField) && in.GetDescription() != "" {
+		out.Description = wrapperspb.String(in.GetDescription())
+	}
+	if outputFields.Has(globals.CreatedTimeField) {
+		out.CreatedTime = in.GetCreateTime().GetTimestamp()
+	}
+	if outputFields.Has(globals.UpdatedTimeField) {
+		out.UpdatedTime = in.GetUpdateTime().GetTimestamp()
+	}
+	if outputFields.Has(globals.VersionField) {
+		out.Version = in.GetVersion()
+	}
+	if outputFields.Has(globals.SessionMaxSecondsField) && in.GetSessionMaxSeconds() != 0 {
+		out.SessionMaxSeconds = wrapperspb.UInt32(in.GetSessionMaxSeconds())
+	}
+	if outputFields.Has(globals.SessionConnectionLimitField) && in.GetSessionConnectionLimit() != 0 {
+		out.SessionConnectionLimit = wrapperspb.Int32(in.GetSessionConnectionLimit())
+	}
+	if outputFields.Has(globals.EgressWorkerFilterField) && in.GetEgressWorkerFilter() != "" {
+		out.EgressWorkerFilter = wrapperspb.String(in.GetEgressWorkerFilter())
+	}
+	if outputFields.Has(globals.IngressWorkerFilterField) && in.GetIngressWorkerFilter() != "" {
+		out.IngressWorkerFilter = wrapperspb.String(in.GetIngressWorkerFilter())
+	}
+	if outputFields.Has(globals.HostSourceIdsField) {
+		out.HostSourceIds = in.HostSourceIds
+	}
+	if outputFields.Has(globals.BrokeredCredentialSourceIdsField) {
+		out.BrokeredCredentialSourceIds = in.CredentialSources.BrokeredCredentialIds
+	}
+	if outputFields.Has(globals.InjectedApplicationCredentialSourceIdsField) {
+		out.InjectedApplicationCredentialSourceIds = in.CredentialSources.InjectedApplicationCredentialIds
+	}
+	if outputFields.Has(globals.AuthorizedActionsField) {
+		out.AuthorizedActions = opts.WithAuthorizedActions
+	}
+	return &out, nil
+}
+
+// A validateX method should exist for each method above.  These methods do not make calls to any backing service but enforce
+// requirements on the structure of the request.  They verify that:
+//   - The path passed in is correctly formatted
+//   - All required parameters are set
+//   - There are no conflicting parameters provided
+func validateGetRequest(req *pbs.GetTargetTemplateRequest) error {
+	return handlers.ValidateGetRequest(handlers.NoopValidatorFn, req, globals.TargetTemplatePrefix)
+}
+
+func validateCreateRequest(req *pbs.CreateTargetTemplateRequest) error {
+	return handlers.ValidateCreateRequest(req.GetItem(), func() map[string]string {
+		badFields := map[string]string{}
+		if !handlers.ValidId(handlers.Id(req.GetItem().GetScopeId()), scope.Project.Prefix()) {
+			badFields[globals.ScopeIdField] = "This field is missing or improperly formatted."
+		}
+		validateItem(req.GetItem(), badFields)
+		return badFields
+	})
+}
+
+func validateUpdateRequest(req *pbs.UpdateTargetTemplateRequest) error {
+	return handlers.ValidateUpdateRequest(req, req.GetItem(), func() map[string]string {
+		badFields := map[string]string{}
+		validateItem(req.GetItem(), badFields)
+		return badFields
+	}, globals.TargetTemplatePrefix)
+}
+
+func validateItem(item *pb.TargetTemplate, badFields map[string]string) {
+	if item.GetSessionMaxSeconds() != nil && item.GetSessionMaxSeconds().GetValue() == 0 {
+		badFields[globals.SessionMaxSecondsField] = "This must be greater than zero."
+	}
+	if item.GetSessionConnectionLimit() != nil {
+		if limit := item.GetSessionConnectionLimit().GetValue(); limit == 0 || limit < -1 {
+			badFields[globals.SessionConnectionLimitField] = "This must be -1 (unlimited) or greater than zero."
+		}
+	}
+	for field, filter := range map[string]string{
+		globals.EgressWorkerFilterField:  item.GetEgressWorkerFilter().GetValue(),
+		globals.IngressWorkerFilterField: item.GetIngressWorkerFilter().GetValue(),
+	} {
+		if filter == "" {
+			continue
+		}
+		if _, err := handlers.NewFilter(context.Background(), filter); err != nil {
+			badFields[field] = fmt.Sprintf("Unable to successfully parse filter expression: %s.", err)
+		}
+	}
+	for _, id := range item.GetHostSourceIds() {
+		if !handlers.ValidId(handlers.Id(id), hostSourcePrefixes...) {
+			badFields[globals.HostSourceIdsField] = fmt.Sprintf("Incorrectly formatted host source identifier %q.", id)
+			break
+		}
+	}
+	for field, ids := range map[string][]string{
+		globals.BrokeredCredentialSourceIdsField:            item.GetBrokeredCredentialSourceIds(),
+		globals.InjectedApplicationCredentialSourceIdsField: item.GetInjectedApplicationCredentialSourceIds(),
+	} {
+		for _, id := range ids {
+			if !handlers.ValidId(handlers.Id(id), credentialSourcePrefixes...) {
+				badFields[field] = fmt.Sprintf("Incorrectly formatted credential source identifier %q.", id)
+				break
+			}
+		}
+	}
+}
+
+func validateDeleteRequest(req *pbs.DeleteTargetTemplateRequest) error {
+	return handlers.ValidateDeleteRequest(handlers.NoopValidatorFn, req, globals.TargetTemplatePrefix)
+}
+
+func validateListOutOfSyncTargetsRequest(req *pbs.ListOutOfSyncTargetsRequest) error {
+	if !handlers.ValidId(handlers.Id(req.GetId()), globals.TargetTemplatePrefix) {
+		return handlers.InvalidArgumentErrorf("Improperly formatted identifier.", map[string]string{globals.IdField: "Incorrectly formatted identifier."})
+	}
+	return nil
+}
+
+func validateListRequest(ctx context.Context, req *pbs.ListTargetTemplatesRequest) error {
+	badFields := map[string]string{}
+	if !handlers.ValidId(handlers.Id(req.GetScopeId()), scope.Org.Prefix()) &&
+		!handlers.ValidId(handlers.Id(req.GetScopeId()), scope.Project.Prefix()) &&
+		req.GetScopeId() != scope.Global.String() {
+		badFields[globals.ScopeIdField] = "Incorrectly formatted identifier."
+	}
+	if _, err := handlers.NewFilter(ctx, req.GetFilter()); err != nil {
+		badFields[globals.FilterField] = fmt.Sprintf("This field could not be parsed. %v", err)
+	}
+	if len(badFields) > 0 {
+		return handlers.InvalidArgumentErrorf("Improperly formatted identifier.", badFields)
+	}
+	return nil
+}
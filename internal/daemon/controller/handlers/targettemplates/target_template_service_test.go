@@ -0,0 +1,246 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package targettemplates_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/hashicorp/boundary/internal/daemon/controller/auth"
+	"github.com/hashicorp/boundary/internal/daemon/controller/handlers"
+	"github.com/hashicorp/boundary/internal/daemon/controller/handlers/targettemplates"
+	"github.com/hashicorp/boundary/internal/db"
+	pbs "github.com/hashicorp/boundary/internal/gen/controller/api/services"
+	"github.com/hashicorp/boundary/internal/host/static"
+	"github.com/hashicorp/boundary/internal/iam"
+	"github.com/hashicorp/boundary/internal/kms"
+	"github.com/hashicorp/boundary/internal/target"
+	"github.com/hashicorp/boundary/internal/target/tcp"
+	pb "github.com/hashicorp/boundary/sdk/pbs/controller/api/resources/targettemplates"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/genproto/protobuf/field_mask"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+type testEnv struct {
+	conn      *db.DB
+	repoFn    target.RepositoryFactory
+	iamRepoFn func() (*iam.Repository, error)
+	proj      *iam.Scope
+	service   targettemplates.Service
+}
+
+func newTestEnv(t *testing.T) *testEnv {
+	t.Helper()
+	ctx := context.Background()
+	conn, _ := db.TestSetup(t, "postgres")
+	rw := db.New(conn)
+	wrap := db.TestWrapper(t)
+	kmsCache := kms.TestKms(t, conn, wrap)
+	iamRepo := iam.TestRepo(t, conn, wrap)
+	env := &testEnv{
+		conn: conn,
+		repoFn: func(o ...target.Option) (*target.Repository, error) {
+			return target.NewRepository(ctx, rw, rw, kmsCache, o...)
+		},
+		iamRepoFn: func() (*iam.Repository, error) {
+			return iamRepo, nil
+		},
+	}
+	_, env.proj = iam.TestScopes(t, iamRepo)
+	var err error
+	env.service, err = targettemplates.NewService(ctx, env.repoFn, env.iamRepoFn)
+	require.NoError(t, err)
+	return env
+}
+
+func (e *testEnv) ctx() context.Context {
+	return auth.DisabledAuthTestContext(e.iamRepoFn, e.proj.GetPublicId())
+}
+
+func (e *testEnv) lookupTarget(t *testing.T, id string) target.Target {
+	t.Helper()
+	repo, err := e.repoFn()
+	require.NoError(t, err)
+	tgt, err := repo.LookupTarget(context.Background(), id)
+	require.NoError(t, err)
+	require.NotNil(t, tgt)
+	return tgt
+}
+
+func TestCreate(t *testing.T) {
+	env := newTestEnv(t)
+	cats := static.TestCatalogs(t, env.conn, env.proj.GetPublicId(), 1)
+	hsets := static.TestSets(t, env.conn, cats[0].GetPublicId(), 1)
+
+	cases := []struct {
+		name string
+		item *pb.TargetTemplate
+		err  error
+	}{
+		{
+			name: "valid",
+			item: &pb.TargetTemplate{
+				ScopeId:           env.proj.GetPublicId(),
+				Name:              wrapperspb.String("valid"),
+				SessionMaxSeconds: wrapperspb.UInt32(3600),
+				HostSourceIds:     []string{hsets[0].GetPublicId()},
+			},
+		},
+		{
+			name: "not-a-project",
+			item: &pb.TargetTemplate{
+				ScopeId: env.proj.GetParentId(),
+				Name:    wrapperspb.String("not-a-project"),
+			},
+			err: handlers.ApiErrorWithCode(codes.InvalidArgument),
+		},
+		{
+			name: "zero-session-max-seconds",
+			item: &pb.TargetTemplate{
+				ScopeId:           env.proj.GetPublicId(),
+				SessionMaxSeconds: wrapperspb.UInt32(0),
+			},
+			err: handlers.ApiErrorWithCode(codes.InvalidArgument),
+		},
+		{
+			name: "invalid-connection-limit",
+			item: &pb.TargetTemplate{
+				ScopeId:                env.proj.GetPublicId(),
+				SessionConnectionLimit: wrapperspb.Int32(-2),
+			},
+			err: handlers.ApiErrorWithCode(codes.InvalidArgument),
+		},
+		{
+			name: "invalid-host-source",
+			item: &pb.TargetTemplate{
+				ScopeId:       env.proj.GetPublicId(),
+				HostSourceIds: []string{"ttcp_1234567890"},
+			},
+			err: handlers.ApiErrorWithCode(codes.InvalidArgument),
+		},
+		{
+			name: "invalid-filter",
+			item: &pb.TargetTemplate{
+				ScopeId:            env.proj.GetPublicId(),
+				EgressWorkerFilter: wrapperspb.String(`"prod" in`),
+			},
+			err: handlers.ApiErrorWithCode(codes.InvalidArgument),
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert, require := assert.New(t), require.New(t)
+			got, err := env.service.CreateTargetTemplate(env.ctx(), &pbs.CreateTargetTemplateRequest{Item: tc.item})
+			if tc.err != nil {
+				require.Error(err)
+				assert.True(errors.Is(err, tc.err), "CreateTargetTemplate got error %v, wanted %v", err, tc.err)
+				return
+			}
+			require.NoError(err)
+			assert.Equal("target-templates/"+got.GetItem().GetId(), got.GetUri())
+			assert.Equal(tc.item.GetScopeId(), got.GetItem().GetScopeId())
+			assert.Equal(tc.item.GetName(), got.GetItem().GetName())
+			assert.Equal(tc.item.GetSessionMaxSeconds(), got.GetItem().GetSessionMaxSeconds())
+			assert.Equal(tc.item.GetHostSourceIds(), got.GetItem().GetHostSourceIds())
+			assert.Equal(uint32(1), got.GetItem().GetVersion())
+		})
+	}
+}
+
+func TestUpdate(t *testing.T) {
+	env := newTestEnv(t)
+	ctx := context.Background()
+	repo, err := env.repoFn()
+	require.NoError(t, err)
+
+	created, err := env.service.CreateTargetTemplate(env.ctx(), &pbs.CreateTargetTemplateRequest{Item: &pb.TargetTemplate{
+		ScopeId:           env.proj.GetPublicId(),
+		SessionMaxSeconds: wrapperspb.UInt32(3600),
+	}})
+	require.NoError(t, err)
+	tplId := created.GetItem().GetId()
+	tgt := tcp.TestTarget(ctx, t, env.conn, env.proj.GetPublicId(), "using")
+	require.NoError(t, repo.SetTargetTemplate(ctx, tgt.GetPublicId(), tplId, nil))
+
+	t.Run("propagates", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		got, err := env.service.UpdateTargetTemplate(env.ctx(), &pbs.UpdateTargetTemplateRequest{
+			Id:         tplId,
+			UpdateMask: &field_mask.FieldMask{Paths: []string{"session_max_seconds"}},
+			Item: &pb.TargetTemplate{
+				Version:           created.GetItem().GetVersion(),
+				SessionMaxSeconds: wrapperspb.UInt32(60),
+			},
+		})
+		require.NoError(err)
+		assert.Equal(uint32(60), got.GetItem().GetSessionMaxSeconds().GetValue())
+		assert.Equal(created.GetItem().GetVersion()+1, got.GetItem().GetVersion())
+		assert.Equal(uint32(60), env.lookupTarget(t, tgt.GetPublicId()).GetSessionMaxSeconds())
+	})
+
+	t.Run("wrong-version", func(t *testing.T) {
+		_, err := env.service.UpdateTargetTemplate(env.ctx(), &pbs.UpdateTargetTemplateRequest{
+			Id:         tplId,
+			UpdateMask: &field_mask.FieldMask{Paths: []string{"session_max_seconds"}},
+			Item: &pb.TargetTemplate{
+				Version:           created.GetItem().GetVersion() + 10,
+				SessionMaxSeconds: wrapperspb.UInt32(120),
+			},
+		})
+		require.Error(t, err)
+		assert.True(t, errors.Is(err, handlers.ApiErrorWithCode(codes.NotFound)), "got error %v", err)
+		assert.Equal(t, uint32(60), env.lookupTarget(t, tgt.GetPublicId()).GetSessionMaxSeconds())
+	})
+
+	t.Run("no-mask", func(t *testing.T) {
+		_, err := env.service.UpdateTargetTemplate(env.ctx(), &pbs.UpdateTargetTemplateRequest{
+			Id:   tplId,
+			Item: &pb.TargetTemplate{Version: created.GetItem().GetVersion()},
+		})
+		require.Error(t, err)
+		assert.True(t, errors.Is(err, handlers.ApiErrorWithCode(codes.InvalidArgument)), "got error %v", err)
+	})
+}
+
+func TestListOutOfSyncTargets(t *testing.T) {
+	env := newTestEnv(t)
+	ctx := context.Background()
+	repo, err := env.repoFn()
+	require.NoError(t, err)
+
+	created, err := env.service.CreateTargetTemplate(env.ctx(), &pbs.CreateTargetTemplateRequest{Item: &pb.TargetTemplate{
+		ScopeId:           env.proj.GetPublicId(),
+		SessionMaxSeconds: wrapperspb.UInt32(3600),
+	}})
+	require.NoError(t, err)
+	tplId := created.GetItem().GetId()
+	drifting := tcp.TestTarget(ctx, t, env.conn, env.proj.GetPublicId(), "drifting")
+	inSync := tcp.TestTarget(ctx, t, env.conn, env.proj.GetPublicId(), "in-sync")
+	for _, tgt := range []target.Target{drifting, inSync} {
+		require.NoError(t, repo.SetTargetTemplate(ctx, tgt.GetPublicId(), tplId, nil))
+	}
+
+	got, err := env.service.ListOutOfSyncTargets(env.ctx(), &pbs.ListOutOfSyncTargetsRequest{Id: tplId})
+	require.NoError(t, err)
+	assert.Empty(t, got.GetItems())
+
+	drifted := env.lookupTarget(t, drifting.GetPublicId())
+	drifted.SetSessionMaxSeconds(60)
+	_, _, err = repo.UpdateTarget(ctx, drifted, drifted.GetVersion(), []string{"SessionMaxSeconds"})
+	require.NoError(t, err)
+
+	got, err = env.service.ListOutOfSyncTargets(env.ctx(), &pbs.ListOutOfSyncTargetsRequest{Id: tplId})
+	require.NoError(t, err)
+	require.Len(t, got.GetItems(), 1)
+	assert.Equal(t, drifting.GetPublicId(), got.GetItems()[0].GetTargetId())
+	assert.Equal(t, []string{target.TemplateSessionMaxSecondsField}, got.GetItems()[0].GetFields())
+
+	_, err = env.service.ListOutOfSyncTargets(env.ctx(), &pbs.ListOutOfSyncTargetsRequest{Id: "ttpl_1234567890"})
+	require.Error(t, err)
+	assert.True(t, errors.Is(err, handlers.ApiErrorWithCode(codes.NotFound)), "got error %v", err)
+}
@@ -6,6 +6,7 @@ package users
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/hashicorp/boundary/globals"
 	talias "github.com/hashicorp/boundary/internal/alias/target"
@@ -22,6 +23,7 @@ import (
 	"github.com/hashicorp/boundary/internal/pagination"
 	"github.com/hashicorp/boundary/internal/perms"
 	"github.com/hashicorp/boundary/internal/requests"
+	"github.com/hashicorp/boundary/internal/session"
 	"github.com/hashicorp/boundary/internal/types/action"
 	"github.com/hashicorp/boundary/internal/types/resource"
 	"github.com/hashicorp/boundary/internal/types/scope"
@@ -47,6 +49,7 @@ var (
 		action.SetAccounts,
 		action.RemoveAccounts,
 		action.ListResolvableAliases,
+		action.ReadSessionQuota,
 	)
 
 	// CollectionActions contains the set of actions that can be performed on
@@ -74,26 +77,29 @@ func init() {
 type Service struct {
 	pbs.UnsafeUserServiceServer
 
-	repoFn      common.IamRepoFactory
-	aliasRepoFn common.TargetAliasRepoFactory
-	maxPageSize uint
+	repoFn        common.IamRepoFactory
+	aliasRepoFn   common.TargetAliasRepoFactory
+	sessionRepoFn session.RepositoryFactory
+	maxPageSize   uint
 }
 
 var _ pbs.UserServiceServer = (*Service)(nil)
 
 // NewService returns a user service which handles user related requests to boundary.
-func NewService(ctx context.Context, repo common.IamRepoFactory, aliasRepoFn common.TargetAliasRepoFactory, maxPageSize uint) (Service, error) {
+func NewService(ctx context.Context, repo common.IamRepoFactory, aliasRepoFn common.TargetAliasRepoFactory, sessionRepoFn session.RepositoryFactory, maxPageSize uint) (Service, error) {
 	const op = "users.NewService"
 	switch {
 	case repo == nil:
 		return Service{}, errors.New(ctx, errors.InvalidParameter, op, "missing iam repository")
 	case aliasRepoFn == nil:
 		return Service{}, errors.New(ctx, errors.InvalidParameter, op, "missing alias repository")
+	case sessionRepoFn == nil:
+		return Service{}, errors.New(ctx, errors.InvalidParameter, op, "missing session repository")
 	}
 	if maxPageSize == 0 {
 		maxPageSize = uint(globals.DefaultMaxPageSize)
 	}
-	return Service{repoFn: repo, aliasRepoFn: aliasRepoFn, maxPageSize: maxPageSize}, nil
+	return Service{repoFn: repo, aliasRepoFn: aliasRepoFn, sessionRepoFn: sessionRepoFn, maxPageSize: maxPageSize}, nil
 }
 
 // ListUsers implements the interface pbs.UserServiceServer.
@@ -595,6 +601,60 @@ func (s Service) ListResolvableAliases(ctx context.Context, req *pbs.ListResolva
 	return resp, nil
 }
 
+// ReadSessionQuota implements the interface pbs.UserServiceServer.
+func (s Service) ReadSessionQuota(ctx context.Context, req *pbs.ReadSessionQuotaRequest) (*pbs.ReadSessionQuotaResponse, error) {
+	const op = "users.(Service).ReadSessionQuota"
+	if err := validateReadSessionQuotaRequest(req); err != nil {
+		return nil, err
+	}
+	authResults := s.authResult(ctx, req.GetId(), action.ReadSessionQuota)
+	if authResults.Error != nil {
+		return nil, authResults.Error
+	}
+	iamRepo, err := s.repoFn()
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	quota, err := iamRepo.LookupSessionQuota(ctx, req.GetId(), req.GetScopeId())
+	if err != nil {
+		if errors.IsNotFoundError(err) {
+			return nil, handlers.NotFoundErrorf("Scope %q doesn't exist.", req.GetScopeId())
+		}
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	sessionRepo, err := s.sessionRepoFn()
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	since := time.Now().Add(-iam.DailySessionsPeriod)
+	active, daily, err := sessionRepo.CountUserSessions(ctx, req.GetId(), req.GetScopeId(), since)
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	item := &pb.SessionQuota{
+		UserId:         req.GetId(),
+		ScopeId:        req.GetScopeId(),
+		ActiveSessions: uint32(active),
+		DailySessions:  uint32(daily),
+	}
+	// Each limit counts the sessions in its scope's subtree. The one closest
+	// to being exceeded is reported, since it refuses the next session first.
+	var activeLeft, dailyLeft int64
+	for _, l := range quota.Limits {
+		active, daily, err := sessionRepo.CountUserSessions(ctx, req.GetId(), l.ScopeId, since)
+		if err != nil {
+			return nil, errors.Wrap(ctx, err, op)
+		}
+		if left := int64(l.MaxActiveSessions) - int64(active); l.MaxActiveSessions > 0 && (item.MaxActiveSessions == 0 || left < activeLeft) {
+			item.MaxActiveSessions, item.ActiveSessions, activeLeft = l.MaxActiveSessions, uint32(active), left
+		}
+		if left := int64(l.MaxDailySessions) - int64(daily); l.MaxDailySessions > 0 && (item.MaxDailySessions == 0 || left < dailyLeft) {
+			item.MaxDailySessions, item.DailySessions, dailyLeft = l.MaxDailySessions, uint32(daily), left
+		}
+	}
+	return &pbs.ReadSessionQuotaResponse{Item: item}, nil
+}
+
 // aclAndGrantHashForUser returns an ACL from the grants provided to the user and
 // the hash of those grants.
 func (s Service) aclAndGrantHashForUser(ctx context.Context, userId string) (perms.ACL, []byte, error) {
@@ -1048,6 +1108,20 @@ func validateRemoveUserAccountsRequest(req *pbs.RemoveUserAccountsRequest) error
 	return nil
 }
 
+func validateReadSessionQuotaRequest(req *pbs.ReadSessionQuotaRequest) error {
+	badFields := map[string]string{}
+	if !handlers.ValidId(handlers.Id(req.GetId()), globals.UserPrefix) {
+		badFields[globals.IdField] = "Incorrectly formatted identifier."
+	}
+	if !handlers.ValidId(handlers.Id(req.GetScopeId()), scope.Project.Prefix()) {
+		badFields[globals.ScopeIdField] = "Must be a valid project scope id."
+	}
+	if len(badFields) > 0 {
+		return handlers.InvalidArgumentErrorf("Errors in provided fields.", badFields)
+	}
+	return nil
+}
+
 func validateListResolvableAliasesRequest(req *pbs.ListResolvableAliasesRequest) error {
 	badFields := map[string]string{}
 	if !handlers.ValidId(handlers.Id(req.GetId()), globals.UserPrefix) {
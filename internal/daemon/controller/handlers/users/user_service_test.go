@@ -30,6 +30,7 @@ import (
 	"github.com/hashicorp/boundary/internal/kms"
 	"github.com/hashicorp/boundary/internal/requests"
 	"github.com/hashicorp/boundary/internal/server"
+	"github.com/hashicorp/boundary/internal/session"
 	"github.com/hashicorp/boundary/internal/target/tcp"
 	"github.com/hashicorp/boundary/internal/types/scope"
 	pbalias "github.com/hashicorp/boundary/sdk/pbs/controller/api/resources/aliases"
@@ -46,9 +47,9 @@ import (
 	"github.com/stretchr/testify/require"
 )
 
-var testAuthorizedActions = []string{"no-op", "read", "update", "delete", "add-accounts", "set-accounts", "remove-accounts", "list-resolvable-aliases"}
+var testAuthorizedActions = []string{"no-op", "read", "update", "delete", "add-accounts", "set-accounts", "remove-accounts", "list-resolvable-aliases", "read-session-quota"}
 
-func createDefaultUserAndRepos(t *testing.T, withAccts bool) (*iam.User, []string, common.IamRepoFactory, common.TargetAliasRepoFactory) {
+func createDefaultUserAndRepos(t *testing.T, withAccts bool) (*iam.User, []string, common.IamRepoFactory, common.TargetAliasRepoFactory, session.RepositoryFactory) {
 	t.Helper()
 	ctx := context.Background()
 	conn, _ := db.TestSetup(t, "postgres")
@@ -62,12 +63,15 @@ func createDefaultUserAndRepos(t *testing.T, withAccts bool) (*iam.User, []strin
 	aliasRepoFn := func() (*talias.Repository, error) {
 		return talias.NewRepository(context.Background(), rw, rw, kmsCache)
 	}
+	sessionRepoFn := func(opt ...session.Option) (*session.Repository, error) {
+		return session.NewRepository(context.Background(), rw, rw, kmsCache, opt...)
+	}
 	o, _ := iam.TestScopes(t, repo)
 	u := iam.TestUser(t, repo, o.GetPublicId(), iam.WithDescription("default"), iam.WithName("default"))
 
 	switch withAccts {
 	case false:
-		return u, nil, repoFn, aliasRepoFn
+		return u, nil, repoFn, aliasRepoFn, sessionRepoFn
 	default:
 		require := require.New(t)
 		databaseWrap, err := kmsCache.GetWrapper(ctx, o.PublicId, kms.KeyPurposeDatabase)
@@ -92,12 +96,12 @@ func createDefaultUserAndRepos(t *testing.T, withAccts bool) (*iam.User, []strin
 		// reload the user with their accounts
 		u, accts, err := repo.LookupUser(ctx, u.PublicId)
 		require.NoError(err)
-		return u, accts, repoFn, aliasRepoFn
+		return u, accts, repoFn, aliasRepoFn, sessionRepoFn
 	}
 }
 
 func TestGet(t *testing.T) {
-	u, uAccts, repoFn, aliasRepo := createDefaultUserAndRepos(t, true)
+	u, uAccts, repoFn, aliasRepo, sessionRepoFn := createDefaultUserAndRepos(t, true)
 
 	toMerge := &pbs.GetUserRequest{
 		Id: u.GetPublicId(),
@@ -157,7 +161,7 @@ func TestGet(t *testing.T) {
 			req := proto.Clone(toMerge).(*pbs.GetUserRequest)
 			proto.Merge(req, tc.req)
 
-			s, err := users.NewService(context.Background(), repoFn, aliasRepo, 1000)
+			s, err := users.NewService(context.Background(), repoFn, aliasRepo, sessionRepoFn, 1000)
 			require.NoError(err, "Couldn't create new user service.")
 
 			got, gErr := s.GetUser(auth.DisabledAuthTestContext(repoFn, u.GetScopeId()), req)
@@ -190,6 +194,9 @@ func TestList(t *testing.T) {
 	aliasRepoFn := func() (*talias.Repository, error) {
 		return talias.NewRepository(context.Background(), rw, rw, kmsCache)
 	}
+	sessionRepoFn := func(opt ...session.Option) (*session.Repository, error) {
+		return session.NewRepository(context.Background(), rw, rw, kmsCache)
+	}
 
 	oNoUsers, _ := iam.TestScopes(t, iamRepo)
 	oWithUsers, _ := iam.TestScopes(t, iamRepo)
@@ -206,7 +213,7 @@ func TestList(t *testing.T) {
 	secondaryAm := password.TestAuthMethods(t, conn, oWithUsers.PublicId, 1)
 	require.Len(t, secondaryAm, 1)
 
-	s, err := users.NewService(context.Background(), repoFn, aliasRepoFn, 1000)
+	s, err := users.NewService(context.Background(), repoFn, aliasRepoFn, sessionRepoFn, 1000)
 	require.NoError(t, err)
 
 	var wantUsers []*pb.User
@@ -418,6 +425,9 @@ func TestListPagination(t *testing.T) {
 	aliasRepoFn := func() (*talias.Repository, error) {
 		return talias.NewRepository(ctx, rw, rw, kms)
 	}
+	sessionRepoFn := func(opt ...session.Option) (*session.Repository, error) {
+		return session.NewRepository(ctx, rw, rw, kms, opt...)
+	}
 	tokenRepoFn := func() (*authtoken.Repository, error) {
 		return authtoken.NewRepository(ctx, rw, rw, kms)
 	}
@@ -491,7 +501,7 @@ func TestListPagination(t *testing.T) {
 	}
 	slices.Reverse(allUsers)
 
-	a, err := users.NewService(ctx, iamRepoFn, aliasRepoFn, 1000)
+	a, err := users.NewService(ctx, iamRepoFn, aliasRepoFn, sessionRepoFn, 1000)
 	require.NoError(t, err, "Couldn't create new user service.")
 
 	// Run analyze to update postgres estimates
@@ -786,6 +796,9 @@ func TestListResolvableAliasesPagination(t *testing.T) {
 	aliasRepoFn := func() (*talias.Repository, error) {
 		return talias.NewRepository(ctx, rw, rw, kms)
 	}
+	sessionRepoFn := func(opt ...session.Option) (*session.Repository, error) {
+		return session.NewRepository(ctx, rw, rw, kms, opt...)
+	}
 	tokenRepoFn := func() (*authtoken.Repository, error) {
 		return authtoken.NewRepository(ctx, rw, rw, kms)
 	}
@@ -847,7 +860,7 @@ func TestListResolvableAliasesPagination(t *testing.T) {
 	slices.Reverse(allAliases)
 	slices.Reverse(allAliasPbs)
 
-	a, err := users.NewService(ctx, iamRepoFn, aliasRepoFn, 1000)
+	a, err := users.NewService(ctx, iamRepoFn, aliasRepoFn, sessionRepoFn, 1000)
 	require.NoError(t, err, "Couldn't create new user service.")
 
 	// Run analyze to update postgres estimates
@@ -1110,9 +1123,9 @@ func TestListResolvableAliasesPagination(t *testing.T) {
 }
 
 func TestDelete(t *testing.T) {
-	u, _, repoFn, aliasRepoFn := createDefaultUserAndRepos(t, false)
+	u, _, repoFn, aliasRepoFn, sessionRepoFn := createDefaultUserAndRepos(t, false)
 
-	s, err := users.NewService(context.Background(), repoFn, aliasRepoFn, 1000)
+	s, err := users.NewService(context.Background(), repoFn, aliasRepoFn, sessionRepoFn, 1000)
 	require.NoError(t, err, "Error when getting new user service.")
 
 	cases := []struct {
@@ -1157,9 +1170,9 @@ func TestDelete(t *testing.T) {
 
 func TestDelete_twice(t *testing.T) {
 	assert, require := assert.New(t), require.New(t)
-	u, _, repoFn, aliasRepoFn := createDefaultUserAndRepos(t, false)
+	u, _, repoFn, aliasRepoFn, sessionRepoFn := createDefaultUserAndRepos(t, false)
 
-	s, err := users.NewService(context.Background(), repoFn, aliasRepoFn, 1000)
+	s, err := users.NewService(context.Background(), repoFn, aliasRepoFn, sessionRepoFn, 1000)
 	require.NoError(err, "Error when getting new user service")
 	req := &pbs.DeleteUserRequest{
 		Id: u.GetPublicId(),
@@ -1173,7 +1186,7 @@ func TestDelete_twice(t *testing.T) {
 }
 
 func TestCreate(t *testing.T) {
-	defaultUser, _, repoFn, aliasRepoFn := createDefaultUserAndRepos(t, false)
+	defaultUser, _, repoFn, aliasRepoFn, sessionRepoFn := createDefaultUserAndRepos(t, false)
 	defaultCreated := defaultUser.GetCreateTime().GetTimestamp().AsTime()
 
 	cases := []struct {
@@ -1251,7 +1264,7 @@ func TestCreate(t *testing.T) {
 	for _, tc := range cases {
 		t.Run(tc.name, func(t *testing.T) {
 			assert, require := assert.New(t), require.New(t)
-			s, err := users.NewService(context.Background(), repoFn, aliasRepoFn, 1000)
+			s, err := users.NewService(context.Background(), repoFn, aliasRepoFn, sessionRepoFn, 1000)
 			require.NoError(err, "Error when getting new user service.")
 
 			got, gErr := s.CreateUser(auth.DisabledAuthTestContext(repoFn, tc.req.GetItem().GetScopeId()), tc.req)
@@ -1286,8 +1299,8 @@ func TestCreate(t *testing.T) {
 }
 
 func TestUpdate(t *testing.T) {
-	u, _, repoFn, aliasRepoFn := createDefaultUserAndRepos(t, false)
-	tested, err := users.NewService(context.Background(), repoFn, aliasRepoFn, 1000)
+	u, _, repoFn, aliasRepoFn, sessionRepoFn := createDefaultUserAndRepos(t, false)
+	tested, err := users.NewService(context.Background(), repoFn, aliasRepoFn, sessionRepoFn, 1000)
 	require.NoError(t, err, "Error when getting new user service.")
 
 	created := u.GetCreateTime().GetTimestamp().AsTime()
@@ -1575,7 +1588,10 @@ func TestAddAccount(t *testing.T) {
 	aliasRepoFn := func() (*talias.Repository, error) {
 		return talias.NewRepository(ctx, rw, rw, kmsCache)
 	}
-	s, err := users.NewService(ctx, repoFn, aliasRepoFn, 1000)
+	sessionRepoFn := func(opt ...session.Option) (*session.Repository, error) {
+		return session.NewRepository(ctx, rw, rw, kmsCache, opt...)
+	}
+	s, err := users.NewService(ctx, repoFn, aliasRepoFn, sessionRepoFn, 1000)
 	require.NoError(t, err, "Error when getting new user service.")
 
 	o, _ := iam.TestScopes(t, iamRepo)
@@ -1739,7 +1755,10 @@ func TestSetAccount(t *testing.T) {
 	aliasRepoFn := func() (*talias.Repository, error) {
 		return talias.NewRepository(ctx, rw, rw, kmsCache)
 	}
-	s, err := users.NewService(ctx, repoFn, aliasRepoFn, 1000)
+	sessionRepoFn := func(opt ...session.Option) (*session.Repository, error) {
+		return session.NewRepository(ctx, rw, rw, kmsCache, opt...)
+	}
+	s, err := users.NewService(ctx, repoFn, aliasRepoFn, sessionRepoFn, 1000)
 	require.NoError(t, err, "Error when getting new user service.")
 
 	o, _ := iam.TestScopes(t, iamRepo)
@@ -1905,7 +1924,10 @@ func TestRemoveAccount(t *testing.T) {
 	aliasRepoFn := func() (*talias.Repository, error) {
 		return talias.NewRepository(ctx, rw, rw, kmsCache)
 	}
-	s, err := users.NewService(ctx, repoFn, aliasRepoFn, 1000)
+	sessionRepoFn := func(opt ...session.Option) (*session.Repository, error) {
+		return session.NewRepository(ctx, rw, rw, kmsCache, opt...)
+	}
+	s, err := users.NewService(ctx, repoFn, aliasRepoFn, sessionRepoFn, 1000)
 	require.NoError(t, err, "Error when getting new user service.")
 
 	o, _ := iam.TestScopes(t, iamRepo)
@@ -2077,3 +2099,67 @@ func TestRemoveAccount(t *testing.T) {
 		})
 	}
 }
+
+func TestReadSessionQuota(t *testing.T) {
+	u, _, repoFn, aliasRepoFn, sessionRepoFn := createDefaultUserAndRepos(t, false)
+	s, err := users.NewService(context.Background(), repoFn, aliasRepoFn, sessionRepoFn, 1000)
+	require.NoError(t, err, "Couldn't create new user service.")
+
+	repo, err := repoFn()
+	require.NoError(t, err)
+	org, proj := iam.TestScopes(t, repo)
+	proj.MaxActiveSessionsPerUser = 5
+	_, _, err = repo.UpdateScope(context.Background(), proj, proj.GetVersion(), []string{"MaxActiveSessionsPerUser"})
+	require.NoError(t, err)
+	// The org's limit is reported for the daily sessions since the project
+	// doesn't set one.
+	org.MaxDailySessionsPerUser = 20
+	_, _, err = repo.UpdateScope(context.Background(), org, org.GetVersion(), []string{"MaxDailySessionsPerUser"})
+	require.NoError(t, err)
+
+	cases := []struct {
+		name string
+		req  *pbs.ReadSessionQuotaRequest
+		res  *pbs.ReadSessionQuotaResponse
+		err  error
+	}{
+		{
+			name: "Read quota",
+			req:  &pbs.ReadSessionQuotaRequest{Id: u.GetPublicId(), ScopeId: proj.GetPublicId()},
+			res: &pbs.ReadSessionQuotaResponse{Item: &pb.SessionQuota{
+				UserId:            u.GetPublicId(),
+				ScopeId:           proj.GetPublicId(),
+				MaxActiveSessions: 5,
+				MaxDailySessions:  20,
+			}},
+		},
+		{
+			name: "Bad user id",
+			req:  &pbs.ReadSessionQuotaRequest{Id: "bad id", ScopeId: proj.GetPublicId()},
+			err:  handlers.ApiErrorWithCode(codes.InvalidArgument),
+		},
+		{
+			name: "Org scope id",
+			req:  &pbs.ReadSessionQuotaRequest{Id: u.GetPublicId(), ScopeId: org.GetPublicId()},
+			err:  handlers.ApiErrorWithCode(codes.InvalidArgument),
+		},
+		{
+			name: "Missing project",
+			req:  &pbs.ReadSessionQuotaRequest{Id: u.GetPublicId(), ScopeId: "p_doesntexist"},
+			err:  handlers.ApiErrorWithCode(codes.NotFound),
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert, require := assert.New(t), require.New(t)
+			got, gErr := s.ReadSessionQuota(auth.DisabledAuthTestContext(repoFn, u.GetScopeId()), tc.req)
+			if tc.err != nil {
+				require.Error(gErr)
+				assert.True(errors.Is(gErr, tc.err), "ReadSessionQuota(%+v) got error %v, wanted %v", tc.req, gErr, tc.err)
+				return
+			}
+			require.NoError(gErr)
+			assert.Empty(cmp.Diff(tc.res, got, protocmp.Transform()))
+		})
+	}
+}
@@ -9,7 +9,9 @@ import (
 	"encoding/hex"
 	stderrors "errors"
 	"fmt"
+	"sort"
 	"strings"
+	"time"
 
 	"github.com/hashicorp/boundary/globals"
 	wl "github.com/hashicorp/boundary/internal/daemon/common"
@@ -17,29 +19,38 @@ import (
 	"github.com/hashicorp/boundary/internal/daemon/controller/common"
 	"github.com/hashicorp/boundary/internal/daemon/controller/common/scopeids"
 	"github.com/hashicorp/boundary/internal/daemon/controller/handlers"
+	"github.com/hashicorp/boundary/internal/db/timestamp"
 	"github.com/hashicorp/boundary/internal/errors"
+	"github.com/hashicorp/boundary/internal/event"
 	pbs "github.com/hashicorp/boundary/internal/gen/controller/api/services"
 	"github.com/hashicorp/boundary/internal/perms"
 	"github.com/hashicorp/boundary/internal/requests"
 	"github.com/hashicorp/boundary/internal/server"
+	"github.com/hashicorp/boundary/internal/server/attestation"
 	"github.com/hashicorp/boundary/internal/server/store"
 	"github.com/hashicorp/boundary/internal/types/action"
 	"github.com/hashicorp/boundary/internal/types/resource"
 	"github.com/hashicorp/boundary/internal/types/scope"
 	"github.com/hashicorp/boundary/internal/util"
 	pb "github.com/hashicorp/boundary/sdk/pbs/controller/api/resources/workers"
+	"github.com/hashicorp/boundary/version"
 	"github.com/hashicorp/go-secure-stdlib/strutil"
 	"github.com/hashicorp/nodeenrollment/types"
 	"github.com/mr-tron/base58"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/protobuf/proto"
 	"google.golang.org/protobuf/types/known/structpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
 	"google.golang.org/protobuf/types/known/wrapperspb"
 )
 
 const (
 	PkiWorkerType = "pki"
 	KmsWorkerType = "kms"
+
+	// The storage fields the drain related api fields are translated to.
+	drainDbField         = "drain"
+	drainDeadlineDbField = "DrainDeadline"
 )
 
 var (
@@ -65,6 +76,9 @@ var (
 		action.List,
 		action.ReadCertificateAuthority,
 		action.ReinitializeCertificateAuthority,
+		action.AttestWorker,
+		action.ReadFleetReport,
+		action.ReadTopology,
 	)
 	// downstreamWorkers returns a list of worker ids which are directly
 	// connected downstream of the provided worker.
@@ -97,13 +111,16 @@ type Service struct {
 	workerAuthFn common.WorkerAuthRepoStorageFactory
 	iamRepoFn    common.IamRepoFactory
 	downstreams  common.Downstreamers
+	attestors    []attestation.Attestor
 }
 
 var _ pbs.WorkerServiceServer = (*Service)(nil)
 
-// NewService returns a worker service which handles worker related requests to boundary.
+// NewService returns a worker service which handles worker related requests to
+// boundary. The attestors validate the identity documents of workers
+// registering themselves; attestation is refused if there are none.
 func NewService(ctx context.Context, repo common.ServersRepoFactory, iamRepoFn common.IamRepoFactory,
-	workerAuthFn common.WorkerAuthRepoStorageFactory, ds common.Downstreamers,
+	workerAuthFn common.WorkerAuthRepoStorageFactory, ds common.Downstreamers, attestors []attestation.Attestor,
 ) (Service, error) {
 	const op = "workers.NewService"
 	if repo == nil {
@@ -115,7 +132,7 @@ func NewService(ctx context.Context, repo common.ServersRepoFactory, iamRepoFn c
 	if workerAuthFn == nil {
 		return Service{}, errors.New(ctx, errors.InvalidParameter, op, "missing worker auth repository")
 	}
-	return Service{repoFn: repo, iamRepoFn: iamRepoFn, workerAuthFn: workerAuthFn, downstreams: ds}, nil
+	return Service{repoFn: repo, iamRepoFn: iamRepoFn, workerAuthFn: workerAuthFn, downstreams: ds, attestors: attestors}, nil
 }
 
 // ListWorkers implements the interface pbs.WorkerServiceServer.
@@ -367,13 +384,15 @@ func (s Service) UpdateWorker(ctx context.Context, req *pbs.UpdateWorkerRequest)
 	// NOTE on the second case: because KMS-authed workers have predictable IDs
 	// generated from the scope and name, it's functionally equivalent to
 	// checking the type, but works for both KMS-PKI and old-style KMS workers.
+	// Any worker can be drained though, since draining is not part of the
+	// worker's configuration.
 	switch {
 	case wl.IsManagedWorker(w):
 		return nil, handlers.InvalidArgumentErrorf(
 			"Error in provided request.",
 			map[string]string{"id": "Managed workers cannot be updated."},
 		)
-	case possibleKmsWorkerId == w.GetPublicId():
+	case possibleKmsWorkerId == w.GetPublicId() && !onlyDrainFields(req.GetUpdateMask().GetPaths()):
 		return nil, handlers.InvalidArgumentErrorf(
 			"Error in provided request.",
 			map[string]string{"id": "KMS workers cannot be updated through the API and must be updated via their configuration file."},
@@ -575,6 +594,278 @@ func (s Service) ReinitializeCertificateAuthority(ctx context.Context, req *pbs.
 	return &pbs.ReinitializeCertificateAuthorityResponse{Item: ca}, nil
 }
 
+// AttestWorker implements the interface pbs.WorkerServiceServer and handles a
+// request to create a new worker and consume a worker-generated authorization
+// request, once the identity document of the worker has been validated by one
+// of the configured attestors.
+func (s Service) AttestWorker(ctx context.Context, req *pbs.AttestWorkerRequest) (*pbs.AttestWorkerResponse, error) {
+	const op = "workers.(Service).AttestWorker"
+	if err := validateAttestRequest(req); err != nil {
+		return nil, err
+	}
+
+	// The caller is not authenticated and auth.Verify is not called: the
+	// identity document is the credential, and the attestation trust policies
+	// of the controller take the place of the grants which are normally
+	// required to create a worker.
+	if len(s.attestors) == 0 {
+		return nil, handlers.ForbiddenError()
+	}
+	id, err := attestation.Attest(ctx, s.attestors, attestation.Type(req.GetAttestationType()), req.GetAttestationDocument())
+	if err != nil {
+		event.WriteError(ctx, op, err, event.WithInfoMsg("worker attestation failed"))
+		return nil, handlers.ForbiddenError()
+	}
+
+	reqBytes, err := base58.FastBase58Decoding(req.GetWorkerGeneratedAuthToken())
+	if err != nil {
+		return nil, handlers.InvalidArgumentErrorf("Errors in provided fields.", map[string]string{globals.WorkerGeneratedAuthTokenField: "Unable to decode the token."})
+	}
+	creds := new(types.FetchNodeCredentialsRequest)
+	if err := proto.Unmarshal(reqBytes, creds); err != nil {
+		return nil, handlers.InvalidArgumentErrorf("Errors in provided fields.", map[string]string{globals.WorkerGeneratedAuthTokenField: "Unable to unmarshal the token."})
+	}
+
+	item := &pb.Worker{ScopeId: scope.Global.String()}
+	if id.Name != "" {
+		item.Name = wrapperspb.String(strings.ToLower(id.Name))
+	}
+	apiTags, err := tagsToMapProto(id.Tags)
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	if err := validateAttestedIdentity(item, apiTags); err != nil {
+		return nil, err
+	}
+
+	// The id of the document is stored with the worker and is kept until the
+	// document expires, so a replayed document is refused.
+	if id.Expiration.After(time.Now().Add(globals.WorkerAttestationNonceValidityPeriod)) {
+		event.WriteError(ctx, op, stderrors.New("identity document expires too far in the future to guard against its reuse"), event.WithInfoMsg("worker attestation failed"))
+		return nil, handlers.ForbiddenError()
+	}
+	tags := make([]*server.Tag, 0, len(apiTags))
+	for k, lv := range apiTags {
+		for _, v := range lv.GetValues() {
+			tags = append(tags, &server.Tag{Key: k, Value: v.GetStringValue()})
+		}
+	}
+
+	// The worker, its tags and the nonce of the document are created in a
+	// single transaction.
+	created, err := s.createInRepo(ctx, item,
+		server.WithFetchNodeCredentialsRequest(creds),
+		server.WithApiTags(tags...),
+		server.WithAttestationNonce(id.DocumentId))
+	if err != nil {
+		if errors.IsUniqueError(err) {
+			event.WriteError(ctx, op, err, event.WithInfoMsg("worker attestation failed: identity document was already used or worker name is taken"))
+			return nil, handlers.ForbiddenError()
+		}
+		return nil, fmt.Errorf("%s: error creating worker: %w", op, err)
+	}
+
+	return &pbs.AttestWorkerResponse{WorkerId: created.GetPublicId()}, nil
+}
+
+// ReadFleetReport returns a summary of all workers by version and
+// configuration tag along with their status, load, downstream workers and the
+// target features their version does not support.
+func (s Service) ReadFleetReport(ctx context.Context, req *pbs.ReadFleetReportRequest) (*pbs.ReadFleetReportResponse, error) {
+	const op = "workers.(Service).ReadFleetReport"
+	if err := validateReadFleetReportRequest(req); err != nil {
+		return nil, err
+	}
+
+	authResults := s.authResult(ctx, req.GetScopeId(), action.ReadFleetReport)
+	if authResults.Error != nil {
+		return nil, authResults.Error
+	}
+
+	repo, err := s.repoFn()
+	if err != nil {
+		return nil, err
+	}
+	workers, err := repo.ListWorkers(ctx, []string{scope.Global.String()}, server.WithLiveness(-1), server.WithLimit(-1))
+	if err != nil {
+		return nil, err
+	}
+	downstreams, err := repo.ListWorkerDownstreams(ctx)
+	if err != nil {
+		return nil, err
+	}
+	sessionCounts, err := repo.ListWorkerActiveSessionCounts(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	report, err := fleetReportToProto(workers, downstreams, sessionCounts, time.Now())
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	return &pbs.ReadFleetReportResponse{Item: report}, nil
+}
+
+// fleetReportToProto builds the fleet report of the workers, computing the
+// age of their last status relative to now.
+func fleetReportToProto(workers []*server.Worker, downstreams map[string][]string, sessionCounts map[string]uint32, now time.Time) (*pb.FleetReport, error) {
+	report := &pb.FleetReport{
+		ControllerVersion: version.Get().FullVersionNumber(false),
+		WorkerCount:       uint32(len(workers)),
+	}
+
+	versions := make(map[string]*pb.FleetVersion)
+	type tagKey struct{ key, value string }
+	tags := make(map[tagKey]*pb.FleetTag)
+	features := make([]*pb.FleetFeature, 0, len(wl.TargetFeatures))
+	for _, f := range wl.TargetFeatures {
+		features = append(features, &pb.FleetFeature{
+			Name:              f.String(),
+			VersionConstraint: version.FeatureConstraint(f),
+		})
+	}
+
+	for _, w := range workers {
+		fw := &pb.FleetWorker{
+			Id:                    w.GetPublicId(),
+			Name:                  w.GetName(),
+			ReleaseVersion:        w.GetReleaseVersion(),
+			ActiveConnectionCount: w.ActiveConnectionCount(),
+			ActiveSessionCount:    sessionCounts[w.GetPublicId()],
+			OperationalState:      w.GetOperationalState(),
+			DownstreamWorkerIds:   downstreams[w.GetPublicId()],
+		}
+		if lst := w.GetLastStatusTime(); lst != nil {
+			fw.LastStatusTime = lst.GetTimestamp()
+			if age := now.Sub(lst.AsTime()); age > 0 {
+				fw.StatusAgeSeconds = uint32(age.Seconds())
+			}
+		}
+		if configTags := w.GetConfigTags(); len(configTags) > 0 {
+			var err error
+			fw.ConfigTags, err = tagsToMapProto(configTags)
+			if err != nil {
+				return nil, err
+			}
+			for k, vs := range configTags {
+				for _, v := range vs {
+					t, ok := tags[tagKey{k, v}]
+					if !ok {
+						t = &pb.FleetTag{Key: k, Value: v}
+						tags[tagKey{k, v}] = t
+					}
+					t.WorkerCount++
+				}
+			}
+		}
+		for i, f := range wl.TargetFeatures {
+			if len(wl.UnsupportedFeatures(w, f)) > 0 {
+				fw.UnsupportedFeatures = append(fw.UnsupportedFeatures, f.String())
+				features[i].UnsupportedWorkerIds = append(features[i].UnsupportedWorkerIds, w.GetPublicId())
+			}
+		}
+
+		v, ok := versions[w.GetReleaseVersion()]
+		if !ok {
+			v = &pb.FleetVersion{ReleaseVersion: w.GetReleaseVersion()}
+			versions[w.GetReleaseVersion()] = v
+		}
+		v.WorkerCount++
+		v.WorkerIds = append(v.WorkerIds, w.GetPublicId())
+
+		report.Workers = append(report.Workers, fw)
+	}
+
+	for _, v := range versions {
+		report.Versions = append(report.Versions, v)
+	}
+	sort.Slice(report.Versions, func(i, j int) bool {
+		return report.Versions[i].GetReleaseVersion() < report.Versions[j].GetReleaseVersion()
+	})
+	for _, t := range tags {
+		report.ConfigTags = append(report.ConfigTags, t)
+	}
+	sort.Slice(report.ConfigTags, func(i, j int) bool {
+		if report.ConfigTags[i].GetKey() != report.ConfigTags[j].GetKey() {
+			return report.ConfigTags[i].GetKey() < report.ConfigTags[j].GetKey()
+		}
+		return report.ConfigTags[i].GetValue() < report.ConfigTags[j].GetValue()
+	})
+	sort.Slice(report.Workers, func(i, j int) bool {
+		return report.Workers[i].GetId() < report.Workers[j].GetId()
+	})
+	report.Features = features
+	return report, nil
+}
+
+// ReadTopology returns the graph of the workers which have recently reported
+// their status and the connections between upstream and downstream workers.
+func (s Service) ReadTopology(ctx context.Context, req *pbs.ReadTopologyRequest) (*pbs.ReadTopologyResponse, error) {
+	const op = "workers.(Service).ReadTopology"
+	if err := validateReadTopologyRequest(req); err != nil {
+		return nil, err
+	}
+
+	authResults := s.authResult(ctx, req.GetScopeId(), action.ReadTopology)
+	if authResults.Error != nil {
+		return nil, authResults.Error
+	}
+
+	repo, err := s.repoFn()
+	if err != nil {
+		return nil, err
+	}
+	workers, err := repo.ListWorkers(ctx, []string{scope.Global.String()}, server.WithLimit(-1))
+	if err != nil {
+		return nil, err
+	}
+	edges, err := repo.ListWorkerDownstreamEdges(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return &pbs.ReadTopologyResponse{Item: topologyToProto(workers, edges)}, nil
+}
+
+// topologyToProto builds the topology graph from the workers and the edges
+// between them. Workers which are not the downstream of any other worker are
+// connected directly to a controller.
+func topologyToProto(workers []*server.Worker, edges []*server.WorkerDownstreamEdge) *pb.Topology {
+	hasUpstream := make(map[string]bool, len(edges))
+	ret := &pb.Topology{}
+	for _, e := range edges {
+		hasUpstream[e.DownstreamWorkerId] = true
+		ret.Edges = append(ret.Edges, &pb.TopologyEdge{
+			UpstreamWorkerId:   e.WorkerId,
+			DownstreamWorkerId: e.DownstreamWorkerId,
+			LastSeenTime:       timestamppb.New(e.LastSeen),
+		})
+	}
+	sort.Slice(ret.Edges, func(i, j int) bool {
+		if ret.Edges[i].GetUpstreamWorkerId() != ret.Edges[j].GetUpstreamWorkerId() {
+			return ret.Edges[i].GetUpstreamWorkerId() < ret.Edges[j].GetUpstreamWorkerId()
+		}
+		return ret.Edges[i].GetDownstreamWorkerId() < ret.Edges[j].GetDownstreamWorkerId()
+	})
+
+	for _, w := range workers {
+		ret.Nodes = append(ret.Nodes, &pb.TopologyNode{
+			Id:                  w.GetPublicId(),
+			Name:                w.GetName(),
+			Address:             w.GetAddress(),
+			ReleaseVersion:      w.GetReleaseVersion(),
+			OperationalState:    w.GetOperationalState(),
+			LastStatusTime:      w.GetLastStatusTime().GetTimestamp(),
+			StatusRttMs:         w.GetStatusRttMs(),
+			ControllerConnected: !hasUpstream[w.GetPublicId()],
+		})
+	}
+	sort.Slice(ret.Nodes, func(i, j int) bool {
+		return ret.Nodes[i].GetId() < ret.Nodes[j].GetId()
+	})
+	return ret
+}
+
 func (s Service) listFromRepo(ctx context.Context, scopeIds []string) ([]*server.Worker, error) {
 	repo, err := s.repoFn()
 	if err != nil {
@@ -650,6 +941,10 @@ func (s Service) updateInRepo(ctx context.Context, scopeId, id string, mask []st
 	}
 	w := server.NewWorker(scopeId, opts...)
 	w.PublicId = id
+	w.Drain = item.GetDrain().GetValue()
+	if timeout := item.GetDrainTimeoutSeconds(); timeout != nil {
+		w.DrainDeadline = timestamp.New(time.Now().Add(time.Duration(timeout.GetValue()) * time.Second))
+	}
 	dbMask := maskManager.Translate(mask)
 	if len(dbMask) == 0 {
 		return nil, handlers.InvalidArgumentErrorf("No valid fields included in the update mask.", map[string]string{"update_mask": "No valid fields provided in the update mask."})
@@ -760,7 +1055,7 @@ func (s Service) authResult(ctx context.Context, id string, a action.Type) auth.
 	var parentId string
 	opts := []auth.Option{auth.WithType(resource.Worker), auth.WithAction(a)}
 	switch a {
-	case action.List, action.CreateWorkerLed, action.CreateControllerLed, action.ReadCertificateAuthority, action.ReinitializeCertificateAuthority:
+	case action.List, action.CreateWorkerLed, action.CreateControllerLed, action.ReadCertificateAuthority, action.ReinitializeCertificateAuthority, action.ReadFleetReport, action.ReadTopology:
 		parentId = id
 	default:
 		w, err := repo.LookupWorker(ctx, id)
@@ -862,6 +1157,15 @@ func (s Service) toProto(ctx context.Context, in *server.Worker, opt ...handlers
 	if outputFields.Has(globals.LocalStorageStateField) {
 		out.LocalStorageState = in.GetLocalStorageState()
 	}
+	if outputFields.Has(globals.OperationalStateField) && in.GetOperationalState() != "" {
+		out.OperationalState = in.GetOperationalState()
+	}
+	if outputFields.Has(globals.DrainField) && in.GetDrain() {
+		out.Drain = wrapperspb.Bool(true)
+	}
+	if outputFields.Has(globals.DrainDeadlineField) && in.GetDrainDeadline() != nil {
+		out.DrainDeadline = in.GetDrainDeadline().GetTimestamp()
+	}
 	if outputFields.Has(globals.AuthorizedActionsField) && opts.WithAuthorizedActions != nil {
 		out.AuthorizedActions = opts.WithAuthorizedActions
 		possibleKmsWorkerId, err := server.NewWorkerIdFromScopeAndName(ctx, in.GetScopeId(), in.GetName())
@@ -999,10 +1303,38 @@ func validateUpdateRequest(req *pbs.UpdateWorkerRequest) error {
 		if !strutil.Printable(descriptionString) {
 			badFields[globals.DescriptionField] = "Contains non-printable characters."
 		}
+		if req.GetItem().GetDrainDeadline() != nil {
+			badFields[globals.DrainDeadlineField] = "This is a read only field. Use drain_timeout_seconds instead."
+		}
+		if req.GetItem().GetOperationalState() != "" {
+			badFields[globals.OperationalStateField] = "This is a read only field."
+		}
+		paths := maskManager.Translate(req.GetUpdateMask().GetPaths())
+		if handlers.MaskContains(paths, drainDeadlineDbField) {
+			switch {
+			case !handlers.MaskContains(paths, drainDbField) || !req.GetItem().GetDrain().GetValue():
+				badFields[globals.DrainTimeoutSecondsField] = "Can only be set when setting drain to true."
+			case req.GetItem().GetDrainTimeoutSeconds() != nil && req.GetItem().GetDrainTimeoutSeconds().GetValue() == 0:
+				badFields[globals.DrainTimeoutSecondsField] = "Must be greater than zero."
+			}
+		}
 		return badFields
 	}, globals.WorkerPrefix)
 }
 
+// onlyDrainFields reports whether the update mask only touches the fields used
+// to drain a worker.
+func onlyDrainFields(paths []string) bool {
+	for _, p := range maskManager.Translate(paths) {
+		switch p {
+		case drainDbField, drainDeadlineDbField:
+		default:
+			return false
+		}
+	}
+	return true
+}
+
 func validateCreateRequest(item *pb.Worker, act action.Type) error {
 	if util.IsNil(item) {
 		return handlers.InvalidArgumentErrorf("Request item is nil", nil)
@@ -1076,6 +1408,48 @@ func validateStringForDb(str string) string {
 	}
 }
 
+func validateAttestRequest(req *pbs.AttestWorkerRequest) error {
+	badFields := map[string]string{}
+	if req.GetWorkerGeneratedAuthToken() == "" {
+		badFields[globals.WorkerGeneratedAuthTokenField] = "This field is required."
+	}
+	if req.GetAttestationType() == "" {
+		badFields[globals.AttestationTypeField] = "This field is required."
+	}
+	if req.GetAttestationDocument() == "" {
+		badFields[globals.AttestationDocumentField] = "This field is required."
+	}
+	if len(badFields) > 0 {
+		return handlers.InvalidArgumentErrorf("Errors in provided fields.", badFields)
+	}
+	return nil
+}
+
+// validateAttestedIdentity validates the name and tags derived from an
+// identity document, since they are not under the control of an operator.
+func validateAttestedIdentity(item *pb.Worker, apiTags map[string]*structpb.ListValue) error {
+	badFields := map[string]string{}
+	if !strutil.Printable(item.GetName().GetValue()) {
+		badFields[globals.NameField] = "Name derived from the attestation document contains non-printable characters."
+	}
+	for k, lv := range apiTags {
+		if err := validateStringForDb(k); err != "" {
+			badFields[globals.ApiTagsField] = "Tag keys derived from the attestation document " + err
+			break
+		}
+		for _, v := range lv.GetValues() {
+			if err := validateStringForDb(v.GetStringValue()); err != "" {
+				badFields[globals.ApiTagsField] = "Tag values derived from the attestation document " + err
+				break
+			}
+		}
+	}
+	if len(badFields) > 0 {
+		return handlers.InvalidArgumentErrorf("Errors in provided fields.", badFields)
+	}
+	return nil
+}
+
 func validateAddTagsRequest(req *pbs.AddWorkerTagsRequest) error {
 	badFields := map[string]string{}
 	if !handlers.ValidId(handlers.Id(req.GetId()), globals.WorkerPrefix) {
@@ -1195,6 +1569,28 @@ func validateReadCaRequest(req *pbs.ReadCertificateAuthorityRequest) error {
 	return nil
 }
 
+func validateReadFleetReportRequest(req *pbs.ReadFleetReportRequest) error {
+	badFields := map[string]string{}
+	if req.GetScopeId() != scope.Global.String() {
+		badFields["scope_id"] = "Must be 'global' when reading the fleet report."
+	}
+	if len(badFields) > 0 {
+		return handlers.InvalidArgumentErrorf("Error in provided request.", badFields)
+	}
+	return nil
+}
+
+func validateReadTopologyRequest(req *pbs.ReadTopologyRequest) error {
+	badFields := map[string]string{}
+	if req.GetScopeId() != scope.Global.String() {
+		badFields["scope_id"] = "Must be 'global' when reading the topology."
+	}
+	if len(badFields) > 0 {
+		return handlers.InvalidArgumentErrorf("Error in provided request.", badFields)
+	}
+	return nil
+}
+
 func validateReinitCaRequest(req *pbs.ReinitializeCertificateAuthorityRequest) error {
 	badFields := map[string]string{}
 	if req.GetScopeId() != scope.Global.String() {
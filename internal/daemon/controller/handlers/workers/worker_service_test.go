@@ -5,10 +5,13 @@ package workers
 
 import (
 	"context"
+	"crypto/x509"
+	"encoding/pem"
 	"fmt"
 	"sort"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/google/go-cmp/cmp/cmpopts"
@@ -23,10 +26,12 @@ import (
 	"github.com/hashicorp/boundary/internal/iam"
 	"github.com/hashicorp/boundary/internal/kms"
 	"github.com/hashicorp/boundary/internal/server"
+	"github.com/hashicorp/boundary/internal/server/attestation"
 	"github.com/hashicorp/boundary/internal/types/action"
 	"github.com/hashicorp/boundary/internal/types/scope"
 	"github.com/hashicorp/boundary/sdk/pbs/controller/api/resources/scopes"
 	pb "github.com/hashicorp/boundary/sdk/pbs/controller/api/resources/workers"
+	"github.com/hashicorp/cap/oidc"
 	"github.com/hashicorp/go-secure-stdlib/strutil"
 	"github.com/hashicorp/nodeenrollment"
 	"github.com/hashicorp/nodeenrollment/rotation"
@@ -139,6 +144,7 @@ func TestGet(t *testing.T) {
 		Type:                               KmsWorkerType,
 		DirectlyConnectedDownstreamWorkers: connectedDownstreams,
 		LocalStorageState:                  server.UnknownLocalStorageState.String(),
+		OperationalState:                   deprecatedKmsWorker.GetOperationalState(),
 	}
 
 	var pkiWorkerKeyId string
@@ -183,6 +189,7 @@ func TestGet(t *testing.T) {
 		Type:                               PkiWorkerType,
 		DirectlyConnectedDownstreamWorkers: connectedDownstreams,
 		LocalStorageState:                  server.AvailableLocalStorageState.String(),
+		OperationalState:                   pkiWorker.GetOperationalState(),
 	}
 
 	var managedPkiWorkerKeyId string
@@ -241,6 +248,7 @@ func TestGet(t *testing.T) {
 		Type:                               PkiWorkerType,
 		DirectlyConnectedDownstreamWorkers: connectedDownstreams,
 		LocalStorageState:                  server.AvailableLocalStorageState.String(),
+		OperationalState:                   managedPkiWorker.GetOperationalState(),
 	}
 
 	cases := []struct {
@@ -289,7 +297,7 @@ func TestGet(t *testing.T) {
 	}
 	for _, tc := range cases {
 		t.Run(tc.name, func(t *testing.T) {
-			s, err := NewService(context.Background(), repoFn, iamRepoFn, workerAuthRepoFn, nil)
+			s, err := NewService(context.Background(), repoFn, iamRepoFn, workerAuthRepoFn, nil, nil)
 			require.NoError(t, err, "Couldn't create new worker service.")
 
 			got, err := s.GetWorker(auth.DisabledAuthTestContext(iamRepoFn, tc.scopeId), tc.req)
@@ -360,6 +368,7 @@ func TestList(t *testing.T) {
 			ReleaseVersion:                     w.ReleaseVersion,
 			DirectlyConnectedDownstreamWorkers: connectedDownstreams,
 			LocalStorageState:                  server.UnknownLocalStorageState.String(),
+			OperationalState:                   w.GetOperationalState(),
 		})
 	}
 
@@ -382,6 +391,7 @@ func TestList(t *testing.T) {
 			ReleaseVersion:                     w.ReleaseVersion,
 			DirectlyConnectedDownstreamWorkers: connectedDownstreams,
 			LocalStorageState:                  server.UnknownLocalStorageState.String(),
+			OperationalState:                   w.GetOperationalState(),
 		})
 	}
 
@@ -431,7 +441,7 @@ func TestList(t *testing.T) {
 	for _, tc := range cases {
 		t.Run(tc.name, func(t *testing.T) {
 			assert, require := assert.New(t), require.New(t)
-			s, err := NewService(context.Background(), repoFn, iamRepoFn, workerAuthRepoFn, nil)
+			s, err := NewService(context.Background(), repoFn, iamRepoFn, workerAuthRepoFn, nil, nil)
 			require.NoError(err, "Couldn't create new worker service.")
 
 			// Test with a non-anon user
@@ -491,7 +501,7 @@ func TestDelete(t *testing.T) {
 		return workerAuthRepo, nil
 	}
 
-	s, err := NewService(ctx, repoFn, iamRepoFn, workerAuthRepoFn, nil)
+	s, err := NewService(ctx, repoFn, iamRepoFn, workerAuthRepoFn, nil, nil)
 	require.NoError(t, err, "Error when getting new worker service.")
 
 	wUnmanaged := server.TestKmsWorker(t, conn, wrap, server.WithWorkerTags(&server.Tag{
@@ -621,7 +631,7 @@ func TestUpdate(t *testing.T) {
 			Id: wkr.GetPublicId(),
 		}
 	}
-	workerService, err := NewService(ctx, repoFn, iamRepoFn, workerAuthRepoFn, nil)
+	workerService, err := NewService(ctx, repoFn, iamRepoFn, workerAuthRepoFn, nil, nil)
 	require.NoError(t, err)
 	expectedScope := &scopes.ScopeInfo{Id: scope.Global.String(), Type: scope.Global.String(), Name: scope.Global.String(), Description: "Global Scope"}
 
@@ -658,6 +668,7 @@ func TestUpdate(t *testing.T) {
 						Type:                               PkiWorkerType,
 						DirectlyConnectedDownstreamWorkers: connectedDownstreams,
 						LocalStorageState:                  server.UnknownLocalStorageState.String(),
+						OperationalState:                   wkr.GetOperationalState(),
 					},
 				}
 			},
@@ -688,6 +699,7 @@ func TestUpdate(t *testing.T) {
 						Type:                               PkiWorkerType,
 						DirectlyConnectedDownstreamWorkers: connectedDownstreams,
 						LocalStorageState:                  server.UnknownLocalStorageState.String(),
+						OperationalState:                   wkr.GetOperationalState(),
 					},
 				}
 			},
@@ -760,6 +772,7 @@ func TestUpdate(t *testing.T) {
 						Type:                               PkiWorkerType,
 						DirectlyConnectedDownstreamWorkers: connectedDownstreams,
 						LocalStorageState:                  server.UnknownLocalStorageState.String(),
+						OperationalState:                   wkr.GetOperationalState(),
 					},
 				}
 			},
@@ -787,6 +800,7 @@ func TestUpdate(t *testing.T) {
 						Type:                               PkiWorkerType,
 						DirectlyConnectedDownstreamWorkers: connectedDownstreams,
 						LocalStorageState:                  server.UnknownLocalStorageState.String(),
+						OperationalState:                   wkr.GetOperationalState(),
 					},
 				}
 				// In the previous test, the name will now be blank if it's the
@@ -822,6 +836,7 @@ func TestUpdate(t *testing.T) {
 						Type:                               PkiWorkerType,
 						DirectlyConnectedDownstreamWorkers: connectedDownstreams,
 						LocalStorageState:                  server.UnknownLocalStorageState.String(),
+						OperationalState:                   wkr.GetOperationalState(),
 					},
 				}
 				// The name will not be updated if it's the pki-kms worker
@@ -857,6 +872,7 @@ func TestUpdate(t *testing.T) {
 						Type:                               PkiWorkerType,
 						DirectlyConnectedDownstreamWorkers: connectedDownstreams,
 						LocalStorageState:                  server.UnknownLocalStorageState.String(),
+						OperationalState:                   wkr.GetOperationalState(),
 					},
 				}
 				// The name will not have been updated previously if it's the pki-kms worker
@@ -1116,7 +1132,7 @@ func TestUpdate_DeprecatedKMS(t *testing.T) {
 	toMerge := &pbs.UpdateWorkerRequest{
 		Id: wkr.GetPublicId(),
 	}
-	workerService, err := NewService(ctx, repoFn, iamRepoFn, workerAuthRepoFn, nil)
+	workerService, err := NewService(ctx, repoFn, iamRepoFn, workerAuthRepoFn, nil, nil)
 	require.NoError(t, err)
 
 	cases := []struct {
@@ -1161,6 +1177,31 @@ func TestUpdate_DeprecatedKMS(t *testing.T) {
 			},
 			errContains: "This is a read only field.",
 		},
+		{
+			name: "Cant set drain timeout without drain",
+			req: &pbs.UpdateWorkerRequest{
+				UpdateMask: &field_mask.FieldMask{
+					Paths: []string{"drain_timeout_seconds"},
+				},
+				Item: &pb.Worker{
+					DrainTimeoutSeconds: wrapperspb.UInt32(60),
+				},
+			},
+			errContains: "Can only be set when setting drain to true.",
+		},
+		{
+			name: "Cant set drain along with name",
+			req: &pbs.UpdateWorkerRequest{
+				UpdateMask: &field_mask.FieldMask{
+					Paths: []string{"drain", "name"},
+				},
+				Item: &pb.Worker{
+					Drain: wrapperspb.Bool(true),
+					Name:  wrapperspb.String("name"),
+				},
+			},
+			errContains: "KMS workers cannot be updated through the API",
+		},
 	}
 	for _, tc := range cases {
 		t.Run(tc.name, func(t *testing.T) {
@@ -1176,6 +1217,72 @@ func TestUpdate_DeprecatedKMS(t *testing.T) {
 	}
 }
 
+func TestUpdate_Drain(t *testing.T) {
+	conn, _ := db.TestSetup(t, "postgres")
+	wrapper := db.TestWrapper(t)
+	kms := kms.TestKms(t, conn, wrapper)
+	ctx := context.Background()
+	rw := db.New(conn)
+
+	iamRepo := iam.TestRepo(t, conn, wrapper)
+	iamRepoFn := func() (*iam.Repository, error) {
+		return iamRepo, nil
+	}
+	repo, err := server.NewRepository(ctx, rw, rw, kms)
+	require.NoError(t, err)
+	repoFn := func() (*server.Repository, error) {
+		return repo, nil
+	}
+
+	workerAuthRepo, err := server.NewRepositoryStorage(ctx, rw, rw, kms)
+	require.NoError(t, err)
+	workerAuthRepoFn := func() (*server.WorkerAuthRepositoryStorage, error) {
+		return workerAuthRepo, nil
+	}
+
+	// KMS workers can't have their name or description updated through the
+	// API but can still be drained.
+	wkr := server.TestKmsWorker(t, conn, wrapper,
+		server.WithName("default"),
+		server.WithDescription("default"))
+
+	workerService, err := NewService(ctx, repoFn, iamRepoFn, workerAuthRepoFn, nil, nil)
+	require.NoError(t, err)
+	requestCtx := auth.DisabledAuthTestContext(iamRepoFn, scope.Global.String())
+
+	before := time.Now()
+	got, err := workerService.UpdateWorker(requestCtx, &pbs.UpdateWorkerRequest{
+		Id: wkr.GetPublicId(),
+		UpdateMask: &field_mask.FieldMask{
+			Paths: []string{"drain", "drain_timeout_seconds"},
+		},
+		Item: &pb.Worker{
+			Version:             wkr.GetVersion(),
+			Drain:               wrapperspb.Bool(true),
+			DrainTimeoutSeconds: wrapperspb.UInt32(3600),
+		},
+	})
+	require.NoError(t, err)
+	assert.True(t, got.GetItem().GetDrain().GetValue())
+	require.NotNil(t, got.GetItem().GetDrainDeadline())
+	assert.WithinDuration(t, before.Add(time.Hour), got.GetItem().GetDrainDeadline().AsTime(), time.Minute)
+	assert.Nil(t, got.GetItem().GetDrainTimeoutSeconds())
+
+	got, err = workerService.UpdateWorker(requestCtx, &pbs.UpdateWorkerRequest{
+		Id: wkr.GetPublicId(),
+		UpdateMask: &field_mask.FieldMask{
+			Paths: []string{"drain"},
+		},
+		Item: &pb.Worker{
+			Version: got.GetItem().GetVersion(),
+			Drain:   wrapperspb.Bool(false),
+		},
+	})
+	require.NoError(t, err)
+	assert.Nil(t, got.GetItem().GetDrain())
+	assert.Nil(t, got.GetItem().GetDrainDeadline())
+}
+
 func TestUpdate_BadVersion(t *testing.T) {
 	ctx := context.Background()
 	conn, _ := db.TestSetup(t, "postgres")
@@ -1201,7 +1308,7 @@ func TestUpdate_BadVersion(t *testing.T) {
 		return repo, nil
 	}
 
-	workerService, err := NewService(ctx, repoFn, iamRepoFn, workerAuthRepoFn, nil)
+	workerService, err := NewService(ctx, repoFn, iamRepoFn, workerAuthRepoFn, nil, nil)
 	require.NoError(t, err, "Failed to create a new host set service.")
 
 	wkr := server.TestPkiWorker(t, conn, wrapper)
@@ -1239,7 +1346,7 @@ func TestCreateWorkerLed(t *testing.T) {
 		return workerAuthRepo, nil
 	}
 
-	testSrv, err := NewService(testCtx, repoFn, iamRepoFn, workerAuthRepoFn, nil)
+	testSrv, err := NewService(testCtx, repoFn, iamRepoFn, workerAuthRepoFn, nil, nil)
 	require.NoError(t, err, "Error when getting new worker service.")
 
 	// Get an initial set of authorized node credentials
@@ -1491,7 +1598,7 @@ func TestCreateWorkerLed(t *testing.T) {
 				repoFn := func() (*server.Repository, error) {
 					return server.NewRepository(testCtx, rw, &db.Db{}, testKms)
 				}
-				testSrv, err := NewService(testCtx, repoFn, iamRepoFn, workerAuthRepoFn, nil)
+				testSrv, err := NewService(testCtx, repoFn, iamRepoFn, workerAuthRepoFn, nil, nil)
 				require.NoError(t, err, "Error when getting new worker service.")
 				return testSrv
 			}(),
@@ -1524,7 +1631,7 @@ func TestCreateWorkerLed(t *testing.T) {
 						return server.NewRepository(testCtx, rw, rw, testKms)
 					}
 				}
-				testSrv, err := NewService(testCtx, repoFn, iamRepoFn, workerAuthRepoFn, nil)
+				testSrv, err := NewService(testCtx, repoFn, iamRepoFn, workerAuthRepoFn, nil, nil)
 				require.NoError(t, err, "Error when getting new worker service.")
 				return testSrv
 			}(),
@@ -1605,6 +1712,187 @@ func TestCreateWorkerLed(t *testing.T) {
 	}
 }
 
+func TestAttestWorker(t *testing.T) {
+	testCtx := context.Background()
+	conn, _ := db.TestSetup(t, "postgres")
+	testRootWrapper := db.TestWrapper(t)
+	iamRepo := iam.TestRepo(t, conn, testRootWrapper)
+	iamRepoFn := func() (*iam.Repository, error) {
+		return iamRepo, nil
+	}
+	rw := db.New(conn)
+	testKms := kms.TestKms(t, conn, testRootWrapper)
+	repoFn := func() (*server.Repository, error) {
+		return server.NewRepository(testCtx, rw, rw, testKms)
+	}
+	workerAuthRepo, err := server.NewRepositoryStorage(testCtx, rw, rw, testKms)
+	require.NoError(t, err)
+	workerAuthRepoFn := func() (*server.WorkerAuthRepositoryStorage, error) {
+		return workerAuthRepo, nil
+	}
+	_, err = rotation.RotateRootCertificates(testCtx, workerAuthRepo)
+	require.NoError(t, err)
+
+	// The local signer stands in for the issuer of the identity documents,
+	// e.g. the instance metadata service of a cloud provider.
+	pub, priv := oidc.TestGenerateKeys(t)
+	der, err := x509.MarshalPKIXPublicKey(pub)
+	require.NoError(t, err)
+	attestor, err := attestation.New(testCtx, &attestation.Config{
+		Type:              string(attestation.JwtType),
+		Issuer:            "https://metadata.example.com",
+		PublicKeys:        []string{string(pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der}))},
+		SigningAlgorithms: []string{string(oidc.ES256)},
+		BoundClaims:       map[string]string{"project": "prod"},
+		NameClaim:         "instance_name",
+		ClaimTags:         map[string]string{"region": "region"},
+	})
+	require.NoError(t, err)
+
+	testSrv, err := NewService(testCtx, repoFn, iamRepoFn, workerAuthRepoFn, nil, []attestation.Attestor{attestor})
+	require.NoError(t, err)
+	noAttestorsSrv, err := NewService(testCtx, repoFn, iamRepoFn, workerAuthRepoFn, nil, nil)
+	require.NoError(t, err)
+
+	fetchReqFn := func() string {
+		fileStorage, err := file.New(testCtx)
+		require.NoError(t, err)
+		defer func() { fileStorage.Cleanup(testCtx) }()
+
+		nodeCreds, err := types.NewNodeCredentials(testCtx, fileStorage)
+		require.NoError(t, err)
+		fetchReq, err := nodeCreds.CreateFetchNodeCredentialsRequest(testCtx)
+		require.NoError(t, err)
+		fetchEncoded, err := proto.Marshal(fetchReq)
+		require.NoError(t, err)
+		return base58.Encode(fetchEncoded)
+	}
+	documentFn := func(name, project string, lifetime time.Duration) string {
+		return oidc.TestSignJWT(t, priv, string(oidc.ES256), map[string]any{
+			"iss":           "https://metadata.example.com",
+			"iat":           time.Now().Unix(),
+			"exp":           time.Now().Add(lifetime).Unix(),
+			"jti":           name,
+			"project":       project,
+			"instance_name": name,
+			"region":        "us-east-1",
+		}, nil)
+	}
+	reusedDocument := documentFn("worker-2", "prod", time.Minute)
+
+	tests := []struct {
+		name      string
+		service   Service
+		req       *pbs.AttestWorkerRequest
+		wantName  string
+		wantTags  map[string][]string
+		wantErrIs error
+	}{
+		{
+			name:    "missing-document",
+			service: testSrv,
+			req: &pbs.AttestWorkerRequest{
+				WorkerGeneratedAuthToken: fetchReqFn(),
+				AttestationType:          "jwt",
+			},
+			wantErrIs: handlers.ApiErrorWithCode(codes.InvalidArgument),
+		},
+		{
+			name:    "no-attestors",
+			service: noAttestorsSrv,
+			req: &pbs.AttestWorkerRequest{
+				WorkerGeneratedAuthToken: fetchReqFn(),
+				AttestationType:          "jwt",
+				AttestationDocument:      documentFn("no-attestors", "prod", time.Minute),
+			},
+			wantErrIs: handlers.ForbiddenError(),
+		},
+		{
+			name:    "untrusted-document",
+			service: testSrv,
+			req: &pbs.AttestWorkerRequest{
+				WorkerGeneratedAuthToken: fetchReqFn(),
+				AttestationType:          "jwt",
+				AttestationDocument:      documentFn("untrusted", "dev", time.Minute),
+			},
+			wantErrIs: handlers.ForbiddenError(),
+		},
+		{
+			name:    "bad-token",
+			service: testSrv,
+			req: &pbs.AttestWorkerRequest{
+				WorkerGeneratedAuthToken: "not-a-token!",
+				AttestationType:          "jwt",
+				AttestationDocument:      documentFn("bad-token", "prod", time.Minute),
+			},
+			wantErrIs: handlers.ApiErrorWithCode(codes.InvalidArgument),
+		},
+		{
+			name:    "success",
+			service: testSrv,
+			req: &pbs.AttestWorkerRequest{
+				WorkerGeneratedAuthToken: fetchReqFn(),
+				AttestationType:          "jwt",
+				AttestationDocument:      documentFn("Worker-1", "prod", time.Minute),
+			},
+			wantName: "worker-1",
+			wantTags: map[string][]string{"region": {"us-east-1"}},
+		},
+		{
+			name:    "first-use",
+			service: testSrv,
+			req: &pbs.AttestWorkerRequest{
+				WorkerGeneratedAuthToken: fetchReqFn(),
+				AttestationType:          "jwt",
+				AttestationDocument:      reusedDocument,
+			},
+			wantName: "worker-2",
+			wantTags: map[string][]string{"region": {"us-east-1"}},
+		},
+		{
+			name:    "reused-document",
+			service: testSrv,
+			req: &pbs.AttestWorkerRequest{
+				WorkerGeneratedAuthToken: fetchReqFn(),
+				AttestationType:          "jwt",
+				AttestationDocument:      reusedDocument,
+			},
+			wantErrIs: handlers.ForbiddenError(),
+		},
+		{
+			name:    "long-lived-document",
+			service: testSrv,
+			req: &pbs.AttestWorkerRequest{
+				WorkerGeneratedAuthToken: fetchReqFn(),
+				AttestationType:          "jwt",
+				AttestationDocument:      documentFn("long-lived", "prod", 2*globals.WorkerAttestationNonceValidityPeriod),
+			},
+			wantErrIs: handlers.ForbiddenError(),
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			assert, require := assert.New(t), require.New(t)
+			got, err := tc.service.AttestWorker(testCtx, tc.req)
+			if tc.wantErrIs != nil {
+				require.Error(err)
+				assert.Nil(got)
+				assert.ErrorIs(err, tc.wantErrIs)
+				return
+			}
+			require.NoError(err)
+			require.NotEmpty(got.GetWorkerId())
+
+			repo, err := repoFn()
+			require.NoError(err)
+			w, err := repo.LookupWorker(testCtx, got.GetWorkerId())
+			require.NoError(err)
+			assert.Equal(tc.wantName, w.GetName())
+			assert.Equal(tc.wantTags, w.GetApiTags())
+		})
+	}
+}
+
 func TestCreateControllerLed(t *testing.T) {
 	testCtx := context.Background()
 	conn, _ := db.TestSetup(t, "postgres")
@@ -1625,7 +1913,7 @@ func TestCreateControllerLed(t *testing.T) {
 		return rootStorage, nil
 	}
 
-	testSrv, err := NewService(testCtx, repoFn, iamRepoFn, authRepoFn, nil)
+	testSrv, err := NewService(testCtx, repoFn, iamRepoFn, authRepoFn, nil, nil)
 	require.NoError(t, err, "Error when getting new worker service.")
 
 	// Get an initial set of authorized node credentials
@@ -1839,7 +2127,7 @@ func TestCreateControllerLed(t *testing.T) {
 				repoFn := func() (*server.Repository, error) {
 					return server.NewRepository(testCtx, rw, &db.Db{}, testKms)
 				}
-				testSrv, err := NewService(testCtx, repoFn, iamRepoFn, authRepoFn, nil)
+				testSrv, err := NewService(testCtx, repoFn, iamRepoFn, authRepoFn, nil, nil)
 				require.NoError(t, err, "Error when getting new worker service.")
 				return testSrv
 			}(),
@@ -1871,7 +2159,7 @@ func TestCreateControllerLed(t *testing.T) {
 						return server.NewRepository(testCtx, rw, rw, testKms)
 					}
 				}
-				testSrv, err := NewService(testCtx, repoFn, iamRepoFn, authRepoFn, nil)
+				testSrv, err := NewService(testCtx, repoFn, iamRepoFn, authRepoFn, nil, nil)
 				require.NoError(t, err, "Error when getting new worker service.")
 				return testSrv
 			}(),
@@ -1974,7 +2262,7 @@ func TestService_AddWorkerTags(t *testing.T) {
 	workerAuthRepoFn := func() (*server.WorkerAuthRepositoryStorage, error) {
 		return workerAuthRepo, nil
 	}
-	s, err := NewService(context.Background(), repoFn, iamRepoFn, workerAuthRepoFn, nil)
+	s, err := NewService(context.Background(), repoFn, iamRepoFn, workerAuthRepoFn, nil, nil)
 	require.NoError(err)
 	worker := server.TestKmsWorker(t, conn, wrapper)
 
@@ -2134,7 +2422,7 @@ func TestService_SetWorkerTags(t *testing.T) {
 	workerAuthRepoFn := func() (*server.WorkerAuthRepositoryStorage, error) {
 		return workerAuthRepo, nil
 	}
-	s, err := NewService(context.Background(), repoFn, iamRepoFn, workerAuthRepoFn, nil)
+	s, err := NewService(context.Background(), repoFn, iamRepoFn, workerAuthRepoFn, nil, nil)
 	require.NoError(err)
 	worker := server.TestKmsWorker(t, conn, wrapper)
 
@@ -2297,7 +2585,7 @@ func TestService_RemoveWorkerTags(t *testing.T) {
 	workerAuthRepoFn := func() (*server.WorkerAuthRepositoryStorage, error) {
 		return workerAuthRepo, nil
 	}
-	s, err := NewService(context.Background(), repoFn, iamRepoFn, workerAuthRepoFn, nil)
+	s, err := NewService(context.Background(), repoFn, iamRepoFn, workerAuthRepoFn, nil, nil)
 	require.NoError(err)
 	worker := server.TestKmsWorker(t, conn, wrapper)
 
@@ -2496,7 +2784,7 @@ func TestReadCertificateAuthority(t *testing.T) {
 	_, err = rotation.RotateRootCertificates(ctx, workerAuthRepo)
 	require.NoError(err)
 
-	testSrv, err := NewService(ctx, repoFn, iamRepoFn, workerAuthRepoFn, nil)
+	testSrv, err := NewService(ctx, repoFn, iamRepoFn, workerAuthRepoFn, nil, nil)
 	require.NoError(err, "Error when getting new worker service.")
 
 	tests := []struct {
@@ -2545,6 +2833,118 @@ func TestReadCertificateAuthority(t *testing.T) {
 	}
 }
 
+func TestReadFleetReport(t *testing.T) {
+	require, assert := require.New(t), assert.New(t)
+	ctx := context.Background()
+	wrapper := db.TestWrapper(t)
+	conn, _ := db.TestSetup(t, "postgres")
+	rw := db.New(conn)
+	kmsCache := kms.TestKms(t, conn, wrapper)
+
+	iamRepo := iam.TestRepo(t, conn, wrapper)
+	iamRepoFn := func() (*iam.Repository, error) {
+		return iamRepo, nil
+	}
+	repo, err := server.NewRepository(ctx, rw, rw, kmsCache)
+	require.NoError(err)
+	repoFn := func() (*server.Repository, error) {
+		return repo, nil
+	}
+	workerAuthRepoFn := func() (*server.WorkerAuthRepositoryStorage, error) {
+		return server.NewRepositoryStorage(ctx, rw, rw, kmsCache)
+	}
+
+	current := server.TestKmsWorker(t, conn, wrapper,
+		server.WithReleaseVersion("Boundary v0.17.0"),
+		server.WithWorkerTags(&server.Tag{Key: "region", Value: "east"}))
+	old := server.TestKmsWorker(t, conn, wrapper,
+		server.WithReleaseVersion("Boundary v0.16.0"),
+		server.WithWorkerTags(&server.Tag{Key: "region", Value: "east"}, &server.Tag{Key: "region", Value: "west"}))
+	require.NoError(repo.UpsertWorkerDownstreams(ctx, current.GetPublicId(), []string{old.GetPublicId()}))
+
+	testSrv, err := NewService(ctx, repoFn, iamRepoFn, workerAuthRepoFn, nil, nil)
+	require.NoError(err, "Error when getting new worker service.")
+
+	_, err = testSrv.ReadFleetReport(auth.DisabledAuthTestContext(iamRepoFn, scope.Global.String()), &pbs.ReadFleetReportRequest{ScopeId: "invalid-scope"})
+	require.Error(err)
+	assert.ErrorIs(err, handlers.ApiErrorWithCode(codes.InvalidArgument))
+
+	got, err := testSrv.ReadFleetReport(auth.DisabledAuthTestContext(iamRepoFn, scope.Global.String()), &pbs.ReadFleetReportRequest{ScopeId: scope.Global.String()})
+	require.NoError(err)
+	report := got.GetItem()
+	assert.Equal(uint32(2), report.GetWorkerCount())
+	require.Len(report.GetVersions(), 2)
+	assert.Equal("Boundary v0.16.0", report.GetVersions()[0].GetReleaseVersion())
+	assert.Equal([]string{old.GetPublicId()}, report.GetVersions()[0].GetWorkerIds())
+	assert.Empty(cmp.Diff([]*pb.FleetTag{
+		{Key: "region", Value: "east", WorkerCount: 2},
+		{Key: "region", Value: "west", WorkerCount: 1},
+	}, report.GetConfigTags(), protocmp.Transform()))
+	for _, f := range report.GetFeatures() {
+		assert.NotEmpty(f.GetVersionConstraint())
+		assert.Equal([]string{old.GetPublicId()}, f.GetUnsupportedWorkerIds())
+	}
+	require.Len(report.GetWorkers(), 2)
+	for _, w := range report.GetWorkers() {
+		switch w.GetId() {
+		case current.GetPublicId():
+			assert.Empty(w.GetUnsupportedFeatures())
+			assert.Equal([]string{old.GetPublicId()}, w.GetDownstreamWorkerIds())
+		case old.GetPublicId():
+			assert.Len(w.GetUnsupportedFeatures(), len(wl.TargetFeatures))
+			assert.Empty(w.GetDownstreamWorkerIds())
+		}
+		assert.NotNil(w.GetLastStatusTime())
+		assert.Zero(w.GetActiveSessionCount())
+	}
+}
+
+func TestReadTopology(t *testing.T) {
+	require, assert := require.New(t), assert.New(t)
+	ctx := context.Background()
+	wrapper := db.TestWrapper(t)
+	conn, _ := db.TestSetup(t, "postgres")
+	rw := db.New(conn)
+	kmsCache := kms.TestKms(t, conn, wrapper)
+
+	iamRepo := iam.TestRepo(t, conn, wrapper)
+	iamRepoFn := func() (*iam.Repository, error) {
+		return iamRepo, nil
+	}
+	repo, err := server.NewRepository(ctx, rw, rw, kmsCache)
+	require.NoError(err)
+	repoFn := func() (*server.Repository, error) {
+		return repo, nil
+	}
+	workerAuthRepoFn := func() (*server.WorkerAuthRepositoryStorage, error) {
+		return server.NewRepositoryStorage(ctx, rw, rw, kmsCache)
+	}
+
+	ingress := server.TestKmsWorker(t, conn, wrapper)
+	egress := server.TestKmsWorker(t, conn, wrapper)
+	require.NoError(repo.UpsertWorkerDownstreams(ctx, ingress.GetPublicId(), []string{egress.GetPublicId()}))
+
+	testSrv, err := NewService(ctx, repoFn, iamRepoFn, workerAuthRepoFn, nil, nil)
+	require.NoError(err, "Error when getting new worker service.")
+
+	_, err = testSrv.ReadTopology(auth.DisabledAuthTestContext(iamRepoFn, scope.Global.String()), &pbs.ReadTopologyRequest{ScopeId: "invalid-scope"})
+	require.Error(err)
+	assert.ErrorIs(err, handlers.ApiErrorWithCode(codes.InvalidArgument))
+
+	got, err := testSrv.ReadTopology(auth.DisabledAuthTestContext(iamRepoFn, scope.Global.String()), &pbs.ReadTopologyRequest{ScopeId: scope.Global.String()})
+	require.NoError(err)
+	topology := got.GetItem()
+	require.Len(topology.GetEdges(), 1)
+	assert.Equal(ingress.GetPublicId(), topology.GetEdges()[0].GetUpstreamWorkerId())
+	assert.Equal(egress.GetPublicId(), topology.GetEdges()[0].GetDownstreamWorkerId())
+	assert.NotNil(topology.GetEdges()[0].GetLastSeenTime())
+	require.Len(topology.GetNodes(), 2)
+	for _, n := range topology.GetNodes() {
+		assert.Equal(n.GetId() == ingress.GetPublicId(), n.GetControllerConnected())
+		assert.NotNil(n.GetLastStatusTime())
+	}
+}
+
 func TestReinitializeCertificateAuthority(t *testing.T) {
 	require, assert := require.New(t), assert.New(t)
 	ctx := context.Background()
@@ -2572,7 +2972,7 @@ func TestReinitializeCertificateAuthority(t *testing.T) {
 	_, err = rotation.RotateRootCertificates(ctx, workerAuthRepo)
 	require.NoError(err)
 
-	testSrv, err := NewService(ctx, repoFn, iamRepoFn, workerAuthRepoFn, nil)
+	testSrv, err := NewService(ctx, repoFn, iamRepoFn, workerAuthRepoFn, nil, nil)
 	require.NoError(err, "Error when getting new worker service.")
 
 	tests := []struct {
@@ -0,0 +1,80 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package worker
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/boundary/api"
+	"github.com/hashicorp/boundary/api/workers"
+	"github.com/hashicorp/boundary/internal/cmd/config"
+	"github.com/hashicorp/boundary/internal/errors"
+	"github.com/hashicorp/boundary/internal/event"
+	"github.com/hashicorp/go-secure-stdlib/parseutil"
+)
+
+// attestationRetryInterval is the time waited between two attempts to
+// register the worker through attestation.
+const attestationRetryInterval = 10 * time.Second
+
+// startAttestation registers the worker with the controller by presenting its
+// identity document along with its worker-generated auth token, retrying
+// until the controller accepts it, the worker authenticates by other means or
+// the context is canceled.
+func (w *Worker) startAttestation(cancelCtx context.Context, conf *config.WorkerAttestation, workerGeneratedAuthToken string) {
+	const op = "worker.(Worker).startAttestation"
+	timer := time.NewTimer(0)
+	defer timer.Stop()
+	for {
+		select {
+		case <-cancelCtx.Done():
+			return
+		case <-timer.C:
+		}
+		if w.everAuthenticated.Load() != authenticationStatusNeverAuthenticated {
+			return
+		}
+		workerId, err := attest(cancelCtx, conf, workerGeneratedAuthToken)
+		if err == nil {
+			event.WriteSysEvent(cancelCtx, op, "worker registered through attestation", "worker_id", workerId)
+			return
+		}
+		event.WriteError(cancelCtx, op, err, event.WithInfoMsg("worker attestation failed, retrying", "retry_in", attestationRetryInterval.String()))
+		timer.Reset(attestationRetryInterval)
+	}
+}
+
+// attest sends the identity document to the controller and returns the id
+// of the worker created for it. The document is read on every call so that
+// documents refreshed on disk are picked up.
+func attest(ctx context.Context, conf *config.WorkerAttestation, workerGeneratedAuthToken string) (string, error) {
+	const op = "worker.attest"
+	document, err := parseutil.ParsePath(conf.Document)
+	if err != nil && !errors.Is(err, parseutil.ErrNotAUrl) {
+		return "", fmt.Errorf("%s: error reading attestation document: %w", op, err)
+	}
+	document = strings.TrimSpace(document)
+	if document == "" {
+		return "", fmt.Errorf("%s: attestation document is empty", op)
+	}
+
+	client, err := api.NewClient(nil)
+	if err != nil {
+		return "", fmt.Errorf("%s: error creating api client: %w", op, err)
+	}
+	if err := client.SetAddr(conf.Address); err != nil {
+		return "", fmt.Errorf("%s: error setting attestation address: %w", op, err)
+	}
+	// The identity document is the credential, make sure no token picked up
+	// from the environment is sent along with it.
+	client.SetToken("")
+	res, err := workers.NewClient(client).Attest(ctx, workerGeneratedAuthToken, conf.Type, document)
+	if err != nil {
+		return "", fmt.Errorf("%s: %w", op, err)
+	}
+	return res.WorkerId, nil
+}
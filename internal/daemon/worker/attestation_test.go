@@ -0,0 +1,86 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package worker
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/boundary/internal/cmd/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAttest(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	var got map[string]string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/v1/workers:attest" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		if strings.TrimSpace(strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer")) != "" {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		got = nil
+		if err := json.NewDecoder(r.Body).Decode(&got); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		if got["attestation_document"] != "signed-document" {
+			w.WriteHeader(http.StatusForbidden)
+			_, _ = w.Write([]byte(`{"kind":"PermissionDenied","message":"Forbidden."}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"worker_id":"w_1234567890"}`))
+	}))
+	defer srv.Close()
+
+	docPath := filepath.Join(t.TempDir(), "token")
+	require.NoError(t, os.WriteFile(docPath, []byte("signed-document\n"), 0o600))
+
+	t.Run("success", func(t *testing.T) {
+		workerId, err := attest(ctx, &config.WorkerAttestation{
+			Type:     "jwt",
+			Address:  srv.URL,
+			Document: "file://" + docPath,
+		}, "fetch-request")
+		require.NoError(t, err)
+		assert.Equal(t, "w_1234567890", workerId)
+		assert.Equal(t, map[string]string{
+			"worker_generated_auth_token": "fetch-request",
+			"attestation_type":            "jwt",
+			"attestation_document":        "signed-document",
+		}, got)
+	})
+
+	t.Run("untrusted", func(t *testing.T) {
+		_, err := attest(ctx, &config.WorkerAttestation{
+			Type:     "jwt",
+			Address:  srv.URL,
+			Document: "other-document",
+		}, "fetch-request")
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "Forbidden")
+	})
+
+	t.Run("missing-document", func(t *testing.T) {
+		_, err := attest(ctx, &config.WorkerAttestation{
+			Type:     "jwt",
+			Address:  srv.URL,
+			Document: "file://" + filepath.Join(t.TempDir(), "missing"),
+		}, "fetch-request")
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "error reading attestation document")
+	})
+}
@@ -0,0 +1,205 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package worker
+
+import (
+	"context"
+	"math"
+	"net"
+	"sync"
+
+	"github.com/hashicorp/boundary/internal/cmd/config"
+	"golang.org/x/time/rate"
+)
+
+// minBandwidthBurst is the smallest burst allowed by a bandwidth limiter. It
+// keeps very low limits from splitting traffic into tiny reads and writes.
+const minBandwidthBurst = 4 * 1024
+
+// newBandwidthLimiter returns a limiter allowing bytesPerSecond bytes per
+// second, or nil if bytesPerSecond is zero, meaning there is no limit.
+func newBandwidthLimiter(bytesPerSecond uint64) *rate.Limiter {
+	if bytesPerSecond == 0 {
+		return nil
+	}
+	burst := bytesPerSecond
+	switch {
+	case burst < minBandwidthBurst:
+		burst = minBandwidthBurst
+	case burst > math.MaxInt32:
+		burst = math.MaxInt32
+	}
+	return rate.NewLimiter(rate.Limit(bytesPerSecond), int(burst))
+}
+
+// sessionBandwidth holds the limiters shared by all connections of a session.
+type sessionBandwidth struct {
+	upload   *rate.Limiter
+	download *rate.Limiter
+	conns    int
+}
+
+// bandwidthManager enforces the bandwidth limits of the worker's
+// configuration on proxied connections. Upload limits apply to the bytes read
+// from the client and download limits to the bytes written to the client.
+type bandwidthManager struct {
+	limits config.BandwidthLimit
+
+	workerUpload   *rate.Limiter
+	workerDownload *rate.Limiter
+
+	mu       sync.Mutex
+	sessions map[string]*sessionBandwidth
+}
+
+// newBandwidthManager returns a bandwidthManager enforcing the given limits. A
+// nil limits means no limits are configured for the worker, limits of the
+// session's target are still enforced.
+func newBandwidthManager(limits *config.BandwidthLimit) *bandwidthManager {
+	m := &bandwidthManager{
+		sessions: make(map[string]*sessionBandwidth),
+	}
+	if limits != nil {
+		m.limits = *limits
+	}
+	m.workerUpload = newBandwidthLimiter(m.limits.WorkerUpload)
+	m.workerDownload = newBandwidthLimiter(m.limits.WorkerDownload)
+	return m
+}
+
+// limitConn wraps the connection so that reads and writes are limited by the
+// connection, session and worker limits. The session limits of the worker
+// configuration are overridden by uploadLimit and downloadLimit if they are
+// not zero. The returned function must be called once the connection is
+// closed to release the limiters of the session.
+func (m *bandwidthManager) limitConn(ctx context.Context, conn net.Conn, sessionId string, uploadLimit, downloadLimit uint64) (net.Conn, func()) {
+	if uploadLimit == 0 {
+		uploadLimit = m.limits.SessionUpload
+	}
+	if downloadLimit == 0 {
+		downloadLimit = m.limits.SessionDownload
+	}
+
+	m.mu.Lock()
+	sb, ok := m.sessions[sessionId]
+	if !ok {
+		sb = &sessionBandwidth{
+			upload:   newBandwidthLimiter(uploadLimit),
+			download: newBandwidthLimiter(downloadLimit),
+		}
+		m.sessions[sessionId] = sb
+	}
+	sb.conns++
+	m.mu.Unlock()
+
+	release := func() {
+		m.mu.Lock()
+		defer m.mu.Unlock()
+		sb.conns--
+		if sb.conns <= 0 {
+			delete(m.sessions, sessionId)
+		}
+	}
+
+	lc := &limitedConn{
+		Conn: conn,
+		ctx:  ctx,
+		readLimiters: nonNilLimiters(
+			newBandwidthLimiter(m.limits.ConnectionUpload), sb.upload, m.workerUpload),
+		writeLimiters: nonNilLimiters(
+			newBandwidthLimiter(m.limits.ConnectionDownload), sb.download, m.workerDownload),
+	}
+	if len(lc.readLimiters) == 0 && len(lc.writeLimiters) == 0 {
+		return conn, release
+	}
+	return lc, release
+}
+
+func nonNilLimiters(limiters ...*rate.Limiter) []*rate.Limiter {
+	var ret []*rate.Limiter
+	for _, l := range limiters {
+		if l != nil {
+			ret = append(ret, l)
+		}
+	}
+	return ret
+}
+
+// limitedConn is a `net.Conn` implementation that waits on its limiters before
+// passing on the bytes read from or written to the underlying `net.Conn`. All
+// other `net.Conn` function calls are a pass-through to the underlying
+// `net.Conn`.
+type limitedConn struct {
+	net.Conn
+
+	ctx           context.Context
+	readLimiters  []*rate.Limiter
+	writeLimiters []*rate.Limiter
+}
+
+// Read wraps the embedded conn's Read() and waits until the read bytes are
+// allowed by the upload limiters.
+func (c *limitedConn) Read(in []byte) (int, error) {
+	if len(c.readLimiters) == 0 {
+		return c.Conn.Read(in)
+	}
+	if max := maxBurst(c.readLimiters); len(in) > max {
+		in = in[:max]
+	}
+	n, err := c.Conn.Read(in)
+	if n > 0 {
+		if werr := waitN(c.ctx, c.readLimiters, n); werr != nil {
+			return n, werr
+		}
+	}
+	return n, err
+}
+
+// Write waits until the bytes are allowed by the download limiters before
+// writing them to the embedded conn. Writes larger than the burst of the
+// limiters are split up.
+func (c *limitedConn) Write(in []byte) (int, error) {
+	if len(c.writeLimiters) == 0 {
+		return c.Conn.Write(in)
+	}
+	max := maxBurst(c.writeLimiters)
+	var written int
+	for len(in) > 0 {
+		chunk := in
+		if len(chunk) > max {
+			chunk = chunk[:max]
+		}
+		if err := waitN(c.ctx, c.writeLimiters, len(chunk)); err != nil {
+			return written, err
+		}
+		n, err := c.Conn.Write(chunk)
+		written += n
+		if err != nil {
+			return written, err
+		}
+		in = in[n:]
+	}
+	return written, nil
+}
+
+// maxBurst returns the largest number of bytes all of the limiters allow at
+// once.
+func maxBurst(limiters []*rate.Limiter) int {
+	max := math.MaxInt32
+	for _, l := range limiters {
+		if b := l.Burst(); b < max {
+			max = b
+		}
+	}
+	return max
+}
+
+func waitN(ctx context.Context, limiters []*rate.Limiter, n int) error {
+	for _, l := range limiters {
+		if err := l.WaitN(ctx, n); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,84 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package worker
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/boundary/internal/cmd/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBandwidthManager_LimitConn(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	t.Run("no limits", func(t *testing.T) {
+		m := newBandwidthManager(nil)
+		underlying := newTestNetConn(10, false, false, false)
+		conn, release := m.limitConn(ctx, underlying, "s_1234567890", 0, 0)
+		defer release()
+		assert.Equal(t, underlying, conn)
+	})
+
+	t.Run("download limited", func(t *testing.T) {
+		const limit = 16 * 1024
+		m := newBandwidthManager(&config.BandwidthLimit{ConnectionDownload: limit})
+		conn, release := m.limitConn(ctx, newTestNetConn(10, false, false, false), "s_1234567890", 0, 0)
+		defer release()
+		require.IsType(t, &limitedConn{}, conn)
+
+		// The first burst is written right away, the second one has to wait
+		// for a second.
+		start := time.Now()
+		written, err := conn.Write(make([]byte, 2*limit))
+		require.NoError(t, err)
+		assert.Equal(t, 2*limit, written)
+		assert.GreaterOrEqual(t, time.Since(start), 900*time.Millisecond)
+
+		read, err := conn.Read(make([]byte, 10))
+		require.NoError(t, err)
+		assert.Equal(t, 10, read)
+	})
+
+	t.Run("target overrides session limits", func(t *testing.T) {
+		m := newBandwidthManager(&config.BandwidthLimit{SessionUpload: 1024, SessionDownload: 1024})
+		_, release := m.limitConn(ctx, newTestNetConn(10, false, false, false), "s_1234567890", 8192, 0)
+		defer release()
+		sb := m.sessions["s_1234567890"]
+		require.NotNil(t, sb)
+		assert.EqualValues(t, 8192, sb.upload.Limit())
+		assert.EqualValues(t, 1024, sb.download.Limit())
+	})
+
+	t.Run("session limiters shared", func(t *testing.T) {
+		m := newBandwidthManager(&config.BandwidthLimit{SessionUpload: 1024})
+		first, releaseFirst := m.limitConn(ctx, newTestNetConn(10, false, false, false), "s_1234567890", 0, 0)
+		second, releaseSecond := m.limitConn(ctx, newTestNetConn(10, false, false, false), "s_1234567890", 0, 0)
+		other, releaseOther := m.limitConn(ctx, newTestNetConn(10, false, false, false), "s_0987654321", 0, 0)
+		defer releaseOther()
+
+		assert.Same(t, first.(*limitedConn).readLimiters[0], second.(*limitedConn).readLimiters[0])
+		assert.NotSame(t, first.(*limitedConn).readLimiters[0], other.(*limitedConn).readLimiters[0])
+
+		releaseFirst()
+		assert.Contains(t, m.sessions, "s_1234567890")
+		releaseSecond()
+		assert.NotContains(t, m.sessions, "s_1234567890")
+		assert.Contains(t, m.sessions, "s_0987654321")
+	})
+
+	t.Run("worker limiters shared", func(t *testing.T) {
+		m := newBandwidthManager(&config.BandwidthLimit{WorkerUpload: 1024})
+		first, releaseFirst := m.limitConn(ctx, newTestNetConn(10, false, false, false), "s_1234567890", 0, 0)
+		defer releaseFirst()
+		second, releaseSecond := m.limitConn(ctx, newTestNetConn(10, false, false, false), "s_0987654321", 0, 0)
+		defer releaseSecond()
+		assert.Same(t, m.workerUpload, first.(*limitedConn).readLimiters[0])
+		assert.Same(t, m.workerUpload, second.(*limitedConn).readLimiters[0])
+	})
+}
@@ -14,6 +14,7 @@ import (
 	"net/url"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/hashicorp/boundary/globals"
 	"github.com/hashicorp/boundary/internal/daemon/common"
@@ -23,6 +24,8 @@ import (
 	"github.com/hashicorp/boundary/internal/errors"
 	"github.com/hashicorp/boundary/internal/event"
 	pbs "github.com/hashicorp/boundary/internal/gen/controller/servers/services"
+	"github.com/hashicorp/boundary/internal/host"
+	"github.com/hashicorp/boundary/internal/target/accesswindow"
 	"github.com/hashicorp/boundary/internal/util"
 	"github.com/hashicorp/boundary/sdk/pbs/proxy"
 	"github.com/hashicorp/boundary/sdk/wspb"
@@ -147,6 +150,21 @@ func (w *Worker) handleProxy(listenerCfg *listenerutil.ListenerConfig, sessionMa
 			}
 			return
 		}
+		// A peer not presenting the session's certificate is an observer,
+		// which can only shadow the session.
+		peerCert := r.TLS.PeerCertificates[0].Raw
+		isObserver := subtle.ConstantTimeCompare(peerCert, sess.GetCertificate().Raw) != 1
+		if handshake.Command == proxy.HANDSHAKECOMMAND_HANDSHAKECOMMAND_SHADOW {
+			w.handleShadow(ctx, connCtx, conn, sess, &handshake, peerCert)
+			return
+		}
+		if isObserver {
+			event.WriteError(ctx, op, stderrors.New("shadow certificate used for a connection"), event.WithInfo("session_id", sessionId))
+			if err = conn.Close(websocket.StatusPolicyViolation, "certificate can only be used to shadow the session"); err != nil {
+				event.WriteError(ctx, op, err, event.WithInfoMsg("error closing client connection"))
+			}
+			return
+		}
 		if len(handshake.GetTofuToken()) != 20 {
 			event.WriteError(ctx, op, stderrors.New("invalid tofu token"))
 			if err = conn.Close(websocket.StatusUnsupportedData, "invalid tofu token"); err != nil {
@@ -198,6 +216,26 @@ func (w *Worker) handleProxy(listenerCfg *listenerutil.ListenerConfig, sessionMa
 			}
 		}
 
+		// Connections are only authorized inside the access window of the
+		// session's target.
+		if aw := sess.GetAccessWindow(); aw != "" {
+			window, err := accesswindow.Parse(aw)
+			if err != nil {
+				event.WriteError(ctx, op, err, event.WithInfoMsg("unable to parse access window", "session_id", sessionId))
+				if err = conn.Close(websocket.StatusInternalError, "unable to parse access window"); err != nil {
+					event.WriteError(ctx, op, err, event.WithInfoMsg("error closing client connection"))
+				}
+				return
+			}
+			if !window.Allows(time.Now()) {
+				event.WriteError(ctx, op, stderrors.New("connection attempted outside of access window"), event.WithInfo("session_id", sessionId))
+				if err = conn.Close(websocket.StatusPolicyViolation, "outside of target access window"); err != nil {
+					event.WriteError(ctx, op, err, event.WithInfoMsg("error closing client connection"))
+				}
+				return
+			}
+		}
+
 		if w.LastStatusSuccess() == nil || w.LastStatusSuccess().WorkerId == "" {
 			event.WriteError(ctx, op, stderrors.New("worker id is empty"))
 			if err = conn.Close(websocket.StatusInternalError, "worker id is empty"); err != nil {
@@ -207,9 +245,18 @@ func (w *Worker) handleProxy(listenerCfg *listenerutil.ListenerConfig, sessionMa
 		}
 		workerId := w.LastStatusSuccess().WorkerId
 
+		// The count of proxied connections includes this connection.
+		if max := w.conf.RawConfig.Worker.MaxConnections; max > 0 && proxyHandlers.ProxyState.CurrentProxiedConnections() > int64(max) {
+			event.WriteError(ctx, op, stderrors.New("worker max connections reached"), event.WithInfo("session_id", sessionId, "max_connections", max))
+			if err = conn.Close(websocket.StatusTryAgainLater, "worker max connections reached"); err != nil {
+				event.WriteError(ctx, op, err, event.WithInfoMsg("error closing client connection"))
+			}
+			return
+		}
+
 		var acResp *pbs.AuthorizeConnectionResponse
 		var connsLeft int32
-		acResp, connsLeft, err = sess.RequestAuthorizeConnection(ctx, workerId, connCancel)
+		acResp, connsLeft, err = sess.RequestAuthorizeConnection(ctx, workerId, sessionCanceledCloser(conn, sess, connCancel))
 		if err != nil {
 			event.WriteError(ctx, op, err, event.WithInfoMsg("unable to authorize connection"))
 			if err = conn.Close(websocket.StatusInternalError, "unable to authorize connection"); err != nil {
@@ -221,7 +268,11 @@ func (w *Worker) handleProxy(listenerCfg *listenerutil.ListenerConfig, sessionMa
 
 		// Wrapping the client websocket with a `net.Conn` implementation that
 		// records the bytes that go across Read() and Write().
+		// The counted bytes are then limited by the bandwidth limits of the
+		// worker and the session's target.
 		cc := &countingConn{Conn: websocket.NetConn(connCtx, conn, websocket.MessageBinary)}
+		lc, releaseBandwidth := w.bandwidth.limitConn(connCtx, cc, sessionId, uint64(sess.GetUploadRateLimit()), uint64(sess.GetDownloadRateLimit()))
+		defer releaseBandwidth()
 		err = sess.ApplyConnectionCounterCallbacks(acResp.GetConnectionId(), cc.BytesRead, cc.BytesWritten)
 		if err != nil {
 			event.WriteError(ctx, op, err, event.WithInfoMsg("unable to set counter callbacks for session connection"))
@@ -276,7 +327,32 @@ func (w *Worker) handleProxy(listenerCfg *listenerutil.ListenerConfig, sessionMa
 			}
 		}
 
-		pDialer, err := proxyHandlers.GetEndpointDialer(ctx, endpointUrl.Host, workerId, acResp, w.downstreamReceiver, proxyHandlers.WithDnsServerAddress(w.conf.WorkerDnsServer))
+		var failoverHosts []string
+		for _, fe := range sess.GetFailoverEndpoints() {
+			feUrl, err := url.Parse(fe)
+			if err != nil {
+				event.WriteError(ctx, op, err, event.WithInfoMsg("worker failed to parse failover endpoint", "endpoint", fe))
+				continue
+			}
+			failoverHosts = append(failoverHosts, feUrl.Host)
+		}
+		dialerOpts := []proxyHandlers.Option{
+			proxyHandlers.WithDnsServerAddress(w.conf.WorkerDnsServer),
+			proxyHandlers.WithFailoverEndpoints(failoverHosts),
+		}
+		if hc := sess.GetHostHealthCheck(); hc != "" {
+			probed := append([]string{endpointUrl.Host}, failoverHosts...)
+			w.hostHealth.register(host.HealthCheck(hc), sess.GetExpiration(), append(probed, sess.GetProbedEndpoints()...)...)
+			// Report the endpoints found unreachable and the one actually
+			// reached when failing over along with the next status.
+			dialerOpts = append(dialerOpts, proxyHandlers.WithEndpointDialHook(func(endpoint string, err error) {
+				w.hostHealth.observe(endpoint, err == nil)
+			}))
+		}
+		if endpointUrl.Scheme == proxyHandlers.UdpHandlerName {
+			dialerOpts = append(dialerOpts, proxyHandlers.WithNetwork("udp"))
+		}
+		pDialer, err := proxyHandlers.GetEndpointDialer(ctx, endpointUrl.Host, workerId, acResp, w.downstreamReceiver, dialerOpts...)
 		if err != nil {
 			conn.Close(proxyHandlers.WebsocketStatusProtocolSetupError, "unable to get endpoint dialer")
 			event.WriteError(ctx, op, err)
@@ -284,7 +360,7 @@ func (w *Worker) handleProxy(listenerCfg *listenerutil.ListenerConfig, sessionMa
 		}
 
 		// Verify the protocol has a supported proxy before calling RequestAuthorizeConnection
-		handleProxyFn, err := proxyHandlers.GetHandler(workerId, acResp.GetProtocolContext())
+		handleProxyFn, err := proxyHandlers.GetHandler(workerId, endpointUrl.Scheme, acResp.GetProtocolContext())
 		if err != nil {
 			conn.Close(proxyHandlers.WebsocketStatusProtocolSetupError, "unable to get proxy handler")
 			event.WriteError(ctx, op, err)
@@ -295,7 +371,7 @@ func (w *Worker) handleProxy(listenerCfg *listenerutil.ListenerConfig, sessionMa
 			conn.Close(proxyHandlers.WebsocketStatusProtocolSetupError, "error getting decryption function")
 			event.WriteError(ctx, op, err)
 		}
-		runProxy, err := handleProxyFn(ctx, ctx, decryptFn, cc, pDialer, acResp.GetConnectionId(), protocolCtx, w.recorderManager)
+		runProxy, err := handleProxyFn(ctx, ctx, decryptFn, lc, pDialer, acResp.GetConnectionId(), protocolCtx, w.recorderManager)
 		if err != nil {
 			conn.Close(proxyHandlers.WebsocketStatusProtocolSetupError, "unable to setup proxying")
 			event.WriteError(ctx, op, err)
@@ -0,0 +1,184 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package worker
+
+import (
+	"context"
+	"crypto/tls"
+	"net"
+	"sort"
+	"sync"
+	"time"
+
+	pbs "github.com/hashicorp/boundary/internal/gen/controller/servers/services"
+	"github.com/hashicorp/boundary/internal/host"
+)
+
+const (
+	// hostHealthProbeInterval is how often the endpoints of sessions handled
+	// by this worker are probed.
+	hostHealthProbeInterval = 10 * time.Second
+	// hostHealthProbeTimeout bounds the time spent probing a single endpoint.
+	hostHealthProbeTimeout = 5 * time.Second
+)
+
+// probedEndpoint is an endpoint being probed and the result of its most recent
+// probe.
+type probedEndpoint struct {
+	check      host.HealthCheck
+	expiration time.Time
+	probed     bool
+	healthy    bool
+}
+
+// hostHealthProber periodically probes the endpoints of the sessions this
+// worker handles which have a host health check. The results are reported to
+// the controller in the status request, which uses them to avoid sending new
+// sessions to unhealthy hosts.
+type hostHealthProber struct {
+	mu        sync.Mutex
+	endpoints map[string]*probedEndpoint
+	timeout   time.Duration
+	// probeFn is called to probe an endpoint, it is replaced in tests.
+	probeFn func(ctx context.Context, check host.HealthCheck, endpoint string, timeout time.Duration) bool
+}
+
+func newHostHealthProber() *hostHealthProber {
+	return &hostHealthProber{
+		endpoints: make(map[string]*probedEndpoint),
+		timeout:   hostHealthProbeTimeout,
+		probeFn:   probeEndpoint,
+	}
+}
+
+// register starts probing the endpoints, in host:port form, with the check
+// until the expiration. Registering an endpoint which is already probed
+// extends its expiration.
+func (p *hostHealthProber) register(check host.HealthCheck, expiration time.Time, endpoints ...string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	for _, ep := range endpoints {
+		if ep == "" {
+			continue
+		}
+		pe, ok := p.endpoints[ep]
+		if !ok {
+			p.endpoints[ep] = &probedEndpoint{check: check, expiration: expiration}
+			continue
+		}
+		pe.check = check
+		if expiration.After(pe.expiration) {
+			pe.expiration = expiration
+		}
+	}
+}
+
+// observe records the result of connecting to a registered endpoint for a
+// session, so that an endpoint found unreachable, or reached after failing
+// over, is reported without waiting for the next probe. Endpoints which are
+// not registered are ignored.
+func (p *hostHealthProber) observe(endpoint string, healthy bool) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if pe, ok := p.endpoints[endpoint]; ok {
+		pe.probed = true
+		pe.healthy = healthy
+	}
+}
+
+// probe removes the expired endpoints and probes the remaining ones
+// concurrently, returning once all probes have completed.
+func (p *hostHealthProber) probe(ctx context.Context) {
+	now := time.Now()
+	toProbe := make(map[string]host.HealthCheck)
+	p.mu.Lock()
+	for ep, pe := range p.endpoints {
+		if now.After(pe.expiration) {
+			delete(p.endpoints, ep)
+			continue
+		}
+		toProbe[ep] = pe.check
+	}
+	p.mu.Unlock()
+
+	var wg sync.WaitGroup
+	for ep, check := range toProbe {
+		wg.Add(1)
+		go func(ep string, check host.HealthCheck) {
+			defer wg.Done()
+			healthy := p.probeFn(ctx, check, ep, p.timeout)
+			if ctx.Err() != nil {
+				// A probe interrupted by shutdown says nothing about the host.
+				return
+			}
+			p.mu.Lock()
+			defer p.mu.Unlock()
+			if pe, ok := p.endpoints[ep]; ok {
+				pe.probed = true
+				pe.healthy = healthy
+			}
+		}(ep, check)
+	}
+	wg.Wait()
+}
+
+// report returns the result of the most recent probe of each endpoint which
+// has been probed, ordered by endpoint.
+func (p *hostHealthProber) report() []*pbs.HostEndpointHealth {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	var ret []*pbs.HostEndpointHealth
+	for ep, pe := range p.endpoints {
+		if !pe.probed {
+			continue
+		}
+		ret = append(ret, &pbs.HostEndpointHealth{
+			Endpoint: ep,
+			Healthy:  pe.healthy,
+		})
+	}
+	sort.Slice(ret, func(i, j int) bool {
+		return ret[i].GetEndpoint() < ret[j].GetEndpoint()
+	})
+	return ret
+}
+
+// start probes the registered endpoints every interval until the context is
+// done.
+func (p *hostHealthProber) start(ctx context.Context, interval time.Duration) {
+	timer := time.NewTimer(interval)
+	defer timer.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-timer.C:
+			p.probe(ctx)
+			timer.Reset(interval)
+		}
+	}
+}
+
+// probeEndpoint returns whether the endpoint passes the health check. The tls
+// health check does not verify the certificate presented by the endpoint, only
+// that a handshake can be completed.
+func probeEndpoint(ctx context.Context, check host.HealthCheck, endpoint string, timeout time.Duration) bool {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+	var d net.Dialer
+	conn, err := d.DialContext(ctx, "tcp", endpoint)
+	if err != nil {
+		return false
+	}
+	defer conn.Close()
+	if check != host.TlsHealthCheck {
+		return true
+	}
+	serverName, _, _ := net.SplitHostPort(endpoint)
+	tlsConn := tls.Client(conn, &tls.Config{
+		ServerName:         serverName,
+		InsecureSkipVerify: true,
+	})
+	return tlsConn.HandshakeContext(ctx) == nil
+}
@@ -0,0 +1,103 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package worker
+
+import (
+	"context"
+	"crypto/tls"
+	"net"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/boundary/internal/host"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHostHealthProber(t *testing.T) {
+	ctx := context.Background()
+	p := newHostHealthProber()
+	healthy := map[string]bool{"10.0.0.1:22": true, "10.0.0.2:22": false}
+	var mu sync.Mutex
+	var probed []string
+	p.probeFn = func(_ context.Context, check host.HealthCheck, endpoint string, _ time.Duration) bool {
+		mu.Lock()
+		defer mu.Unlock()
+		assert.Equal(t, host.TcpHealthCheck, check)
+		probed = append(probed, endpoint)
+		return healthy[endpoint]
+	}
+	report := func() map[string]bool {
+		ret := map[string]bool{}
+		for _, h := range p.report() {
+			ret[h.GetEndpoint()] = h.GetHealthy()
+		}
+		return ret
+	}
+
+	assert.Empty(t, report())
+
+	p.register(host.TcpHealthCheck, time.Now().Add(time.Hour), "10.0.0.1:22", "10.0.0.2:22")
+	p.register(host.TcpHealthCheck, time.Now().Add(-time.Hour), "10.0.0.3:22")
+	// Endpoints are only reported once they have been probed.
+	assert.Empty(t, report())
+
+	p.probe(ctx)
+	assert.ElementsMatch(t, []string{"10.0.0.1:22", "10.0.0.2:22"}, probed)
+	assert.Equal(t, map[string]bool{"10.0.0.1:22": true, "10.0.0.2:22": false}, report())
+
+	// Re-registering an endpoint never shortens its expiration, expired
+	// endpoints are no longer probed or reported.
+	p.register(host.TcpHealthCheck, time.Now().Add(-time.Hour), "10.0.0.1:22")
+	p.register(host.TcpHealthCheck, time.Now().Add(time.Millisecond), "10.0.0.4:22")
+	time.Sleep(10 * time.Millisecond)
+	probed = nil
+	p.probe(ctx)
+	assert.ElementsMatch(t, []string{"10.0.0.1:22", "10.0.0.2:22"}, probed)
+	assert.Equal(t, map[string]bool{"10.0.0.1:22": true, "10.0.0.2:22": false}, report())
+
+	// Connecting to an endpoint records its health right away, unregistered
+	// endpoints are ignored.
+	p.observe("10.0.0.1:22", false)
+	p.observe("10.0.0.5:22", false)
+	assert.Equal(t, map[string]bool{"10.0.0.1:22": false, "10.0.0.2:22": false}, report())
+}
+
+func TestProbeEndpoint(t *testing.T) {
+	ctx := context.Background()
+	timeout := time.Second
+
+	tcpL, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer tcpL.Close()
+	go func() {
+		for {
+			c, err := tcpL.Accept()
+			if err != nil {
+				return
+			}
+			_ = c.Close()
+		}
+	}()
+
+	tlsSrv := httptest.NewUnstartedServer(nil)
+	tlsSrv.TLS = &tls.Config{}
+	tlsSrv.StartTLS()
+	defer tlsSrv.Close()
+
+	closed, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	closedAddr := closed.Addr().String()
+	require.NoError(t, closed.Close())
+
+	assert.True(t, probeEndpoint(ctx, host.TcpHealthCheck, tcpL.Addr().String(), timeout))
+	assert.False(t, probeEndpoint(ctx, host.TcpHealthCheck, closedAddr, timeout))
+
+	assert.True(t, probeEndpoint(ctx, host.TlsHealthCheck, tlsSrv.Listener.Addr().String(), timeout))
+	// A listener which doesn't speak tls fails the tls check.
+	assert.False(t, probeEndpoint(ctx, host.TlsHealthCheck, tcpL.Addr().String(), timeout))
+	assert.False(t, probeEndpoint(ctx, host.TlsHealthCheck, closedAddr, timeout))
+}
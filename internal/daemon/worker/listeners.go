@@ -44,7 +44,7 @@ const (
 )
 
 // the function that handles a secondary connection over a provided listener
-var handleSecondaryConnection = closeListeners
+var handleSecondaryConnection = handleDataPlaneConnections
 
 // closeListeners handles the secondary connection listeners by closing them.
 // l is the grpc listener and l2 is the data plane listener.
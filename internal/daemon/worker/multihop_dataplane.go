@@ -0,0 +1,648 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package worker
+
+import (
+	"context"
+	"encoding/binary"
+	"encoding/json"
+	stderrors "errors"
+	"fmt"
+	"io"
+	"net"
+	"net/url"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/boundary/internal/daemon/cluster"
+	"github.com/hashicorp/boundary/internal/daemon/common"
+	proxyHandlers "github.com/hashicorp/boundary/internal/daemon/worker/proxy"
+	"github.com/hashicorp/boundary/internal/errors"
+	"github.com/hashicorp/boundary/internal/event"
+	pbs "github.com/hashicorp/boundary/internal/gen/controller/servers/services"
+)
+
+// Multi-hop sessions are proxied from an upstream worker to a downstream
+// worker over data plane connections. Since downstream workers may not be
+// reachable from their upstream, the downstream worker dials its upstream
+// using the DataPlaneProxyAlpnValue and keeps a few of these connections idle.
+// When the upstream needs to reach a downstream worker it takes one of the idle
+// connections, writes a dataPlaneRequest and reads a dataPlaneResponse, after
+// which the connection carries the proxied session traffic.
+
+const (
+	// dataPlaneIdleConnsPerUpstream is the default number of idle data plane
+	// connections a downstream worker keeps open to each upstream.
+	dataPlaneIdleConnsPerUpstream = 3
+	// dataPlaneMinRetryInterval and dataPlaneMaxRetryInterval bound the time
+	// waited before dialing an upstream again after dialing it failed. The
+	// time doubles with each consecutive failure.
+	dataPlaneMinRetryInterval = time.Second
+	dataPlaneMaxRetryInterval = 2 * time.Minute
+	// dataPlaneMaxIdleTime is how long an upstream worker keeps an idle data
+	// plane connection before closing it. The downstream worker replaces
+	// closed connections.
+	dataPlaneMaxIdleTime = 5 * time.Minute
+	// dataPlaneHandshakeTimeout bounds the time spent exchanging the request
+	// and response on a data plane connection.
+	dataPlaneHandshakeTimeout = 15 * time.Second
+	// dataPlaneMaxFrameSize bounds the size of a request or response.
+	dataPlaneMaxFrameSize = 64 * 1024
+)
+
+// dataPlaneRequest asks a downstream worker to reach the endpoint, routing
+// through Route if it is not empty. The egress worker fails over to
+// FailoverEndpoints in order if the endpoint cannot be reached. It only reaches
+// endpoints of the session ConnectionId belongs to.
+type dataPlaneRequest struct {
+	ConnectionId      string   `json:"connection_id"`
+	Endpoint          string   `json:"endpoint"`
+	FailoverEndpoints []string `json:"failover_endpoints,omitempty"`
+	Route             []string `json:"route,omitempty"`
+}
+
+// dataPlaneResponse is sent by the downstream worker once the endpoint has been
+// reached or reaching it failed.
+type dataPlaneResponse struct {
+	EndpointIp   string `json:"endpoint_ip,omitempty"`
+	EndpointPort uint32 `json:"endpoint_port,omitempty"`
+	Error        string `json:"error,omitempty"`
+}
+
+// writeDataPlaneFrame writes v as length prefixed json.
+func writeDataPlaneFrame(w io.Writer, v any) error {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	if len(b) > dataPlaneMaxFrameSize {
+		return fmt.Errorf("data plane frame of %d bytes exceeds maximum size", len(b))
+	}
+	buf := make([]byte, 4+len(b))
+	binary.BigEndian.PutUint32(buf, uint32(len(b)))
+	copy(buf[4:], b)
+	_, err = w.Write(buf)
+	return err
+}
+
+// readDataPlaneFrame reads length prefixed json into v.
+func readDataPlaneFrame(r io.Reader, v any) error {
+	var size [4]byte
+	if _, err := io.ReadFull(r, size[:]); err != nil {
+		return err
+	}
+	n := binary.BigEndian.Uint32(size[:])
+	if n > dataPlaneMaxFrameSize {
+		return fmt.Errorf("data plane frame of %d bytes exceeds maximum size", n)
+	}
+	b := make([]byte, n)
+	if _, err := io.ReadFull(r, b); err != nil {
+		return err
+	}
+	return json.Unmarshal(b, v)
+}
+
+// routedConn is a connection to an endpoint reached through downstream
+// workers. It reports the endpoint address the egress worker connected to.
+type routedConn struct {
+	net.Conn
+	ip   string
+	port uint32
+}
+
+// GetIp returns the ip address of the endpoint.
+func (c *routedConn) GetIp() string { return c.ip }
+
+// GetPort returns the port of the endpoint.
+func (c *routedConn) GetPort() uint32 { return c.port }
+
+type idleDataPlaneConn struct {
+	net.Conn
+	added time.Time
+}
+
+// dataPlanePool holds the idle data plane connections downstream workers have
+// opened to this worker, keyed by the downstream worker id. It is the
+// Worker.downstreamReceiver and satisfies proxy.RouteDialer.
+type dataPlanePool struct {
+	mu      sync.Mutex
+	conns   map[string][]*idleDataPlaneConn
+	maxIdle time.Duration
+}
+
+var (
+	_ reverseConnReceiver       = (*dataPlanePool)(nil)
+	_ proxyHandlers.RouteDialer = (*dataPlanePool)(nil)
+)
+
+func newDataPlanePool() reverseConnReceiver {
+	return &dataPlanePool{
+		conns:   make(map[string][]*idleDataPlaneConn),
+		maxIdle: dataPlaneMaxIdleTime,
+	}
+}
+
+func (p *dataPlanePool) add(workerId string, c net.Conn) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.conns[workerId] = append(p.conns[workerId], &idleDataPlaneConn{Conn: c, added: time.Now()})
+}
+
+// take removes and returns the most recently added idle connection to the
+// worker, or nil if there is none.
+func (p *dataPlanePool) take(workerId string) net.Conn {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	conns := p.conns[workerId]
+	if len(conns) == 0 {
+		return nil
+	}
+	c := conns[len(conns)-1]
+	if len(conns) == 1 {
+		delete(p.conns, workerId)
+	} else {
+		p.conns[workerId] = conns[:len(conns)-1]
+	}
+	return c.Conn
+}
+
+// evict closes the idle connections which were added before the cutoff.
+func (p *dataPlanePool) evict(cutoff time.Time) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	for id, conns := range p.conns {
+		kept := conns[:0]
+		for _, c := range conns {
+			if c.added.Before(cutoff) {
+				_ = c.Close()
+				continue
+			}
+			kept = append(kept, c)
+		}
+		if len(kept) == 0 {
+			delete(p.conns, id)
+			continue
+		}
+		p.conns[id] = kept
+	}
+}
+
+// acceptConnections adds the connections accepted on l to the pool until the
+// listener is closed.
+func (p *dataPlanePool) acceptConnections(ctx context.Context, l net.Listener) {
+	const op = "worker.(dataPlanePool).acceptConnections"
+	for {
+		c, err := l.Accept()
+		if err != nil {
+			if !stderrors.Is(err, net.ErrClosed) && ctx.Err() == nil {
+				event.WriteError(ctx, op, err, event.WithInfoMsg("error accepting data plane connection"))
+			}
+			return
+		}
+		wi, err := cluster.GetWorkerInfoFromStateMap(ctx, c)
+		if err != nil || wi.WorkerId == "" {
+			// The tracking listener already closes connections it cannot
+			// identify, but connections without a worker id can't be routed to.
+			_ = c.Close()
+			continue
+		}
+		p.add(wi.WorkerId, c)
+	}
+}
+
+// DialRoute reaches the endpoint, or one of the failover endpoints, through the
+// downstream workers in route and returns a connection carrying the endpoint's
+// traffic.
+func (p *dataPlanePool) DialRoute(ctx context.Context, route []string, endpoint string, failoverEndpoints []string, connectionId string) (net.Conn, error) {
+	const op = "worker.(dataPlanePool).DialRoute"
+	if len(route) == 0 {
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "empty route")
+	}
+	req := &dataPlaneRequest{
+		ConnectionId:      connectionId,
+		Endpoint:          endpoint,
+		FailoverEndpoints: failoverEndpoints,
+		Route:             route[1:],
+	}
+	// Idle connections may have been closed by the downstream worker, so try
+	// the next one if the exchange fails before a response is received.
+	for {
+		c := p.take(route[0])
+		if c == nil {
+			return nil, errors.New(ctx, errors.Internal, op, fmt.Sprintf("no data plane connection available to downstream worker %q", route[0]))
+		}
+		resp, err := exchangeDataPlaneRequest(c, req)
+		if err != nil {
+			_ = c.Close()
+			continue
+		}
+		if resp.Error != "" {
+			_ = c.Close()
+			return nil, errors.New(ctx, errors.Internal, op, fmt.Sprintf("downstream worker %q: %s", route[0], resp.Error))
+		}
+		return &routedConn{Conn: c, ip: resp.EndpointIp, port: resp.EndpointPort}, nil
+	}
+}
+
+func exchangeDataPlaneRequest(c net.Conn, req *dataPlaneRequest) (*dataPlaneResponse, error) {
+	if err := c.SetDeadline(time.Now().Add(dataPlaneHandshakeTimeout)); err != nil {
+		return nil, err
+	}
+	if err := writeDataPlaneFrame(c, req); err != nil {
+		return nil, err
+	}
+	resp := new(dataPlaneResponse)
+	if err := readDataPlaneFrame(c, resp); err != nil {
+		return nil, err
+	}
+	if resp.Error != "" {
+		// The downstream worker closes the connection after an error.
+		return resp, nil
+	}
+	if err := c.SetDeadline(time.Time{}); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// StartProcessingPendingConnections waits until the context is done and
+// closes the idle connections. Connections are added to the pool as they are
+// accepted by handleDataPlaneConnections.
+func (p *dataPlanePool) StartProcessingPendingConnections(ctx context.Context, _ func() string) error {
+	<-ctx.Done()
+	p.evict(time.Now().Add(time.Hour))
+	return nil
+}
+
+// StartConnectionMgmtTicking periodically closes idle connections which have
+// not been used within the pool's max idle time. A negative ticks value runs
+// until the context is done.
+func (p *dataPlanePool) StartConnectionMgmtTicking(ctx context.Context, _ func() string, ticks int) error {
+	const op = "worker.(dataPlanePool).StartConnectionMgmtTicking"
+	if ticks == 0 {
+		return errors.New(ctx, errors.InvalidParameter, op, "ticks must not be zero")
+	}
+	timer := time.NewTicker(p.maxIdle / 5)
+	defer timer.Stop()
+	for i := 0; ticks < 0 || i < ticks; i++ {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-timer.C:
+			p.evict(time.Now().Add(-p.maxIdle))
+		}
+	}
+	return nil
+}
+
+// handleDataPlaneConnections handles the secondary connection listeners. The
+// reverse grpc listener is not supported and is closed. Connections accepted
+// on the data plane listener are added to the receiver if it is a
+// dataPlanePool, otherwise that listener is closed as well.
+func handleDataPlaneConnections(ctx context.Context, l, l2 net.Listener, receiver any) error {
+	pool, ok := receiver.(*dataPlanePool)
+	if !ok || pool == nil {
+		return closeListeners(ctx, l, l2, receiver)
+	}
+	if err := closeListeners(ctx, l, nil, receiver); err != nil {
+		return err
+	}
+	if l2 != nil {
+		go pool.acceptConnections(ctx, l2)
+	}
+	return nil
+}
+
+// dataPlaneUpstreams is an addressReceiver which keeps idle data plane
+// connections open to each upstream worker so the upstream can route
+// multi-hop sessions through this worker.
+type dataPlaneUpstreams struct {
+	mu    sync.Mutex
+	addrs []string
+	conns map[string]map[net.Conn]struct{}
+	// retries holds the upstream addresses which could not be dialed.
+	retries map[string]*dataPlaneRetry
+
+	// idleConns is the number of idle connections kept open to each upstream.
+	// If zero, dataPlaneIdleConnsPerUpstream are kept open.
+	idleConns int
+
+	// dialFn dials a data plane connection to the upstream address.
+	dialFn func(context.Context, string) (net.Conn, error)
+	// enabledFn reports whether data plane connections should be opened. They
+	// are only useful once this worker has an id and its upstream is a worker.
+	enabledFn func() bool
+	// handleFn serves a request read from a data plane connection. It owns
+	// the connection.
+	handleFn func(context.Context, net.Conn, *dataPlaneRequest)
+}
+
+var _ addressReceiver = (*dataPlaneUpstreams)(nil)
+
+// Type returns secondaryConnectionReceiverType.
+func (*dataPlaneUpstreams) Type() receiverType {
+	return secondaryConnectionReceiverType
+}
+
+// InitialAddresses sets the upstream addresses.
+func (u *dataPlaneUpstreams) InitialAddresses(addrs []string) {
+	u.SetAddresses(addrs)
+}
+
+// SetAddresses sets the upstream addresses and closes idle connections to
+// upstreams which are no longer present.
+func (u *dataPlaneUpstreams) SetAddresses(addrs []string) {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+	u.addrs = append(u.addrs[:0:0], addrs...)
+	current := make(map[string]struct{}, len(addrs))
+	for _, a := range addrs {
+		current[a] = struct{}{}
+	}
+	for a, conns := range u.conns {
+		if _, ok := current[a]; ok {
+			continue
+		}
+		for c := range conns {
+			_ = c.Close()
+		}
+		delete(u.conns, a)
+	}
+	for a := range u.retries {
+		if _, ok := current[a]; !ok {
+			delete(u.retries, a)
+		}
+	}
+}
+
+// dataPlaneRetry records the consecutive failures to dial an upstream.
+type dataPlaneRetry struct {
+	failures int
+	next     time.Time
+}
+
+// dialFailed records a failure to dial the upstream address and reports
+// whether it is the first consecutive one, along with the time waited before
+// dialing it again.
+func (u *dataPlaneUpstreams) dialFailed(addr string, now time.Time) (bool, time.Duration) {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+	if u.retries == nil {
+		u.retries = make(map[string]*dataPlaneRetry)
+	}
+	r := u.retries[addr]
+	if r == nil {
+		r = new(dataPlaneRetry)
+		u.retries[addr] = r
+	}
+	wait := dataPlaneMaxRetryInterval
+	if r.failures < 8 {
+		wait = min(dataPlaneMinRetryInterval<<r.failures, dataPlaneMaxRetryInterval)
+	}
+	r.failures++
+	r.next = now.Add(wait)
+	return r.failures == 1, wait
+}
+
+// fill opens idle connections until each upstream address has idleConns of
+// them. Upstreams which could not be dialed are skipped until their retry time
+// has passed. Only the first of consecutive failures is logged as an error so
+// an unreachable upstream doesn't flood the logs.
+func (u *dataPlaneUpstreams) fill(ctx context.Context) {
+	const op = "worker.(dataPlaneUpstreams).fill"
+	if !u.enabledFn() {
+		return
+	}
+	idleConns := u.idleConns
+	if idleConns <= 0 {
+		idleConns = dataPlaneIdleConnsPerUpstream
+	}
+	now := time.Now()
+	u.mu.Lock()
+	needed := make(map[string]int, len(u.addrs))
+	for _, a := range u.addrs {
+		if r := u.retries[a]; r != nil && now.Before(r.next) {
+			continue
+		}
+		needed[a] = idleConns - len(u.conns[a])
+	}
+	u.mu.Unlock()
+
+	for addr, n := range needed {
+		for i := 0; i < n; i++ {
+			c, err := u.dialFn(ctx, addr)
+			if err != nil {
+				first, wait := u.dialFailed(addr, now)
+				if first {
+					event.WriteError(ctx, op, err, event.WithInfoMsg("error dialing data plane connection", "upstream_address", addr))
+				} else {
+					event.WriteSysEvent(ctx, op, "still unable to dial data plane connection", "upstream_address", addr, "error", err.Error(), "retry_in", wait.String())
+				}
+				break
+			}
+			u.mu.Lock()
+			delete(u.retries, addr)
+			if u.conns == nil {
+				u.conns = make(map[string]map[net.Conn]struct{})
+			}
+			if u.conns[addr] == nil {
+				u.conns[addr] = make(map[net.Conn]struct{})
+			}
+			u.conns[addr][c] = struct{}{}
+			u.mu.Unlock()
+			go u.serve(ctx, addr, c)
+		}
+	}
+}
+
+// serve waits for a request on the idle connection and hands it to handleFn.
+func (u *dataPlaneUpstreams) serve(ctx context.Context, addr string, c net.Conn) {
+	req := new(dataPlaneRequest)
+	err := readDataPlaneFrame(c, req)
+	u.mu.Lock()
+	delete(u.conns[addr], c)
+	u.mu.Unlock()
+	if err != nil {
+		_ = c.Close()
+		return
+	}
+	u.handleFn(ctx, c, req)
+}
+
+// start keeps the idle connections topped up until the context is done, at
+// which point all idle connections are closed.
+func (u *dataPlaneUpstreams) start(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		u.fill(ctx)
+		select {
+		case <-ctx.Done():
+			u.SetAddresses(nil)
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// dataPlaneUpstreamsEnabled reports whether this worker should open data plane
+// connections to its upstreams. Controllers don't accept them, so they are
+// only opened when the last status was proxied by an upstream worker, which
+// doesn't pass on the controller addresses.
+func (w *Worker) dataPlaneUpstreamsEnabled() bool {
+	s := w.LastStatusSuccess()
+	return s != nil && s.GetWorkerId() != "" && len(s.GetCalculatedUpstreams()) == 0
+}
+
+func (w *Worker) newDataPlaneUpstreams() *dataPlaneUpstreams {
+	u := &dataPlaneUpstreams{
+		dialFn:    w.upstreamDialerFunc(common.DataPlaneProxyAlpnValue),
+		enabledFn: w.dataPlaneUpstreamsEnabled,
+		handleFn:  w.handleDataPlaneRequest,
+	}
+	if w.conf != nil && w.conf.RawConfig != nil && w.conf.RawConfig.Worker != nil {
+		u.idleConns = w.conf.RawConfig.Worker.DataPlaneIdleConnections
+	}
+	return u
+}
+
+// handleDataPlaneRequest reaches the requested endpoint, either directly or
+// through further downstream workers, and proxies the traffic between it and
+// the upstream worker.
+func (w *Worker) handleDataPlaneRequest(ctx context.Context, c net.Conn, req *dataPlaneRequest) {
+	const op = "worker.(Worker).handleDataPlaneRequest"
+	defer c.Close()
+
+	reply := func(resp *dataPlaneResponse) bool {
+		_ = c.SetWriteDeadline(time.Now().Add(dataPlaneHandshakeTimeout))
+		if err := writeDataPlaneFrame(c, resp); err != nil {
+			event.WriteError(ctx, op, err, event.WithInfoMsg("error writing data plane response", "connection_id", req.ConnectionId))
+			return false
+		}
+		_ = c.SetWriteDeadline(time.Time{})
+		return true
+	}
+
+	var workerId string
+	if s := w.LastStatusSuccess(); s != nil {
+		workerId = s.GetWorkerId()
+	}
+
+	endpoint := req.Endpoint
+	dialerOpts := []proxyHandlers.Option{proxyHandlers.WithDnsServerAddress(w.conf.WorkerDnsServer)}
+	if len(req.Route) > 0 {
+		// The failover endpoints are passed on and checked by the egress
+		// worker.
+		dialerOpts = append(dialerOpts, proxyHandlers.WithFailoverEndpoints(req.FailoverEndpoints))
+	} else {
+		// This is the egress worker. The endpoint sent by the upstream worker
+		// is only used if it is one of the endpoints of the session the
+		// connection belongs to.
+		if w.sessionManager == nil {
+			reply(&dataPlaneResponse{Error: "worker is not ready to proxy connections"})
+			return
+		}
+		sess, err := w.sessionManager.LookupRoutedSession(ctx, req.ConnectionId, workerId)
+		if err != nil {
+			event.WriteError(ctx, op, err, event.WithInfoMsg("error looking up session of routed connection", "connection_id", req.ConnectionId))
+			reply(&dataPlaneResponse{Error: "unknown connection"})
+			return
+		}
+		var failover []string
+		endpoint, failover, err = routedEndpoint(sess.GetEndpoint(), sess.GetFailoverEndpoints(), req.Endpoint, req.FailoverEndpoints)
+		if err != nil {
+			event.WriteError(ctx, op, err, event.WithInfoMsg("rejecting routed connection", "connection_id", req.ConnectionId, "session_id", sess.GetId()))
+			reply(&dataPlaneResponse{Error: "endpoint is not an endpoint of the session"})
+			return
+		}
+		dialerOpts = append(dialerOpts, proxyHandlers.WithFailoverEndpoints(failover))
+	}
+
+	acResp := &pbs.AuthorizeConnectionResponse{
+		ConnectionId: req.ConnectionId,
+		Route:        append([]string{workerId}, req.Route...),
+	}
+	pDialer, err := proxyHandlers.GetEndpointDialer(ctx, endpoint, workerId, acResp, w.downstreamReceiver, dialerOpts...)
+	if err != nil {
+		event.WriteError(ctx, op, err, event.WithInfoMsg("error getting endpoint dialer", "connection_id", req.ConnectionId))
+		reply(&dataPlaneResponse{Error: "unable to get endpoint dialer"})
+		return
+	}
+	remote, err := pDialer.Dial(ctx)
+	if err != nil {
+		event.WriteError(ctx, op, err, event.WithInfoMsg("error dialing endpoint", "connection_id", req.ConnectionId))
+		reply(&dataPlaneResponse{Error: "unable to reach endpoint"})
+		return
+	}
+	defer remote.Close()
+
+	addr := pDialer.LastConnectionAddr()
+	if !reply(&dataPlaneResponse{EndpointIp: addr.Ip(), EndpointPort: addr.Port()}) {
+		return
+	}
+	pipeConns(c, remote)
+}
+
+// routedEndpoint returns the address the egress worker of a multi-hop route
+// dials for a session with the given endpoint and failover endpoints, along
+// with the addresses to fail over to. The upstream worker may have picked a
+// failover endpoint as requested, and the order to fail over in as
+// requestedFailover, but any address which is not one of the session's
+// endpoints is rejected. Without requestedFailover the remaining session
+// endpoints are failed over to.
+func routedEndpoint(sessionEndpoint string, failoverEndpoints []string, requested string, requestedFailover []string) (string, []string, error) {
+	var hosts []string
+	for _, e := range append([]string{sessionEndpoint}, failoverEndpoints...) {
+		u, err := url.Parse(e)
+		if err != nil {
+			return "", nil, fmt.Errorf("parsing session endpoint %q: %w", e, err)
+		}
+		hosts = append(hosts, u.Host)
+	}
+	isSessionHost := func(e string) bool {
+		for _, h := range hosts {
+			if h == e {
+				return true
+			}
+		}
+		return false
+	}
+	for _, e := range requestedFailover {
+		if !isSessionHost(e) {
+			return "", nil, fmt.Errorf("failover endpoint %q is not an endpoint of the session", e)
+		}
+	}
+	if requested == "" {
+		if len(requestedFailover) > 0 {
+			return hosts[0], requestedFailover, nil
+		}
+		return hosts[0], hosts[1:], nil
+	}
+	for i, h := range hosts {
+		if h == requested {
+			if len(requestedFailover) > 0 {
+				return h, requestedFailover, nil
+			}
+			failover := append(append([]string{}, hosts[:i]...), hosts[i+1:]...)
+			return h, failover, nil
+		}
+	}
+	return "", nil, fmt.Errorf("endpoint %q is not an endpoint of the session", requested)
+}
+
+// pipeConns copies data between a and b until either side is done, then
+// closes both.
+func pipeConns(a, b net.Conn) {
+	done := make(chan struct{}, 2)
+	cp := func(dst, src net.Conn) {
+		_, _ = io.Copy(dst, src)
+		done <- struct{}{}
+	}
+	go cp(a, b)
+	go cp(b, a)
+	<-done
+	_ = a.Close()
+	_ = b.Close()
+	<-done
+}
@@ -0,0 +1,369 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package worker
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"net"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/boundary/internal/cmd/base"
+	"github.com/hashicorp/boundary/internal/daemon/worker/session"
+	pbs "github.com/hashicorp/boundary/internal/gen/controller/servers/services"
+	"github.com/hashicorp/boundary/sdk/pbs/controller/api/resources/targets"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func TestDataPlaneFrame(t *testing.T) {
+	var buf bytes.Buffer
+	in := &dataPlaneRequest{ConnectionId: "sc_1", Endpoint: "10.0.0.1:22", Route: []string{"w_2"}}
+	require.NoError(t, writeDataPlaneFrame(&buf, in))
+	out := new(dataPlaneRequest)
+	require.NoError(t, readDataPlaneFrame(&buf, out))
+	assert.Equal(t, in, out)
+
+	assert.Error(t, writeDataPlaneFrame(&buf, &dataPlaneRequest{Endpoint: strings.Repeat("a", dataPlaneMaxFrameSize)}))
+	assert.Error(t, readDataPlaneFrame(bytes.NewReader([]byte{0xff, 0xff, 0xff, 0xff}), out))
+	assert.ErrorIs(t, readDataPlaneFrame(bytes.NewReader([]byte{0, 0}), out), io.ErrUnexpectedEOF)
+}
+
+func TestDataPlanePool_DialRoute(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	pool := newDataPlanePool().(*dataPlanePool)
+	var mu sync.Mutex
+	var gotReqs []*dataPlaneRequest
+	upstreams := &dataPlaneUpstreams{
+		// Each dial hands the other end of the pipe to the upstream's pool.
+		dialFn: func(context.Context, string) (net.Conn, error) {
+			down, up := net.Pipe()
+			pool.add("w_down", up)
+			return down, nil
+		},
+		enabledFn: func() bool { return true },
+		handleFn: func(_ context.Context, c net.Conn, req *dataPlaneRequest) {
+			defer c.Close()
+			mu.Lock()
+			gotReqs = append(gotReqs, req)
+			mu.Unlock()
+			if req.Endpoint == "unreachable:22" {
+				_ = writeDataPlaneFrame(c, &dataPlaneResponse{Error: "unable to reach endpoint"})
+				return
+			}
+			_ = writeDataPlaneFrame(c, &dataPlaneResponse{EndpointIp: "10.0.0.1", EndpointPort: 22})
+			_, _ = io.Copy(c, c)
+		},
+	}
+	upstreams.SetAddresses([]string{"upstream:9202"})
+	upstreams.fill(ctx)
+
+	t.Run("no-connections-to-worker", func(t *testing.T) {
+		_, err := pool.DialRoute(ctx, []string{"w_other"}, "10.0.0.1:22", nil, "sc_1")
+		assert.Error(t, err)
+		_, err = pool.DialRoute(ctx, nil, "10.0.0.1:22", nil, "sc_1")
+		assert.Error(t, err)
+	})
+
+	t.Run("success", func(t *testing.T) {
+		require, assert := require.New(t), assert.New(t)
+		c, err := pool.DialRoute(ctx, []string{"w_down", "w_egress"}, "10.0.0.1:22", []string{"10.0.0.2:22"}, "sc_1")
+		require.NoError(err)
+		defer c.Close()
+		rc, ok := c.(*routedConn)
+		require.True(ok)
+		assert.Equal("10.0.0.1", rc.GetIp())
+		assert.EqualValues(22, rc.GetPort())
+
+		_, err = c.Write([]byte("ping"))
+		require.NoError(err)
+		buf := make([]byte, 4)
+		_, err = io.ReadFull(c, buf)
+		require.NoError(err)
+		assert.Equal("ping", string(buf))
+
+		mu.Lock()
+		defer mu.Unlock()
+		require.Len(gotReqs, 1)
+		assert.Equal(&dataPlaneRequest{ConnectionId: "sc_1", Endpoint: "10.0.0.1:22", FailoverEndpoints: []string{"10.0.0.2:22"}, Route: []string{"w_egress"}}, gotReqs[0])
+	})
+
+	t.Run("error-response", func(t *testing.T) {
+		_, err := pool.DialRoute(ctx, []string{"w_down"}, "unreachable:22", nil, "sc_2")
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "unable to reach endpoint")
+	})
+
+	t.Run("refill", func(t *testing.T) {
+		// Wait for the used connections to be removed from the idle set.
+		assert.Eventually(t, func() bool {
+			upstreams.mu.Lock()
+			defer upstreams.mu.Unlock()
+			return len(upstreams.conns["upstream:9202"]) == dataPlaneIdleConnsPerUpstream-2
+		}, time.Second, 10*time.Millisecond)
+		upstreams.fill(ctx)
+		upstreams.mu.Lock()
+		assert.Len(t, upstreams.conns["upstream:9202"], dataPlaneIdleConnsPerUpstream)
+		upstreams.mu.Unlock()
+	})
+
+	t.Run("removed-address-closes-connections", func(t *testing.T) {
+		upstreams.SetAddresses([]string{"other:9202"})
+		upstreams.mu.Lock()
+		assert.Empty(t, upstreams.conns["upstream:9202"])
+		upstreams.mu.Unlock()
+		// The pool's ends of the closed connections fail the exchange.
+		_, err := pool.DialRoute(ctx, []string{"w_down"}, "10.0.0.1:22", nil, "sc_3")
+		assert.Error(t, err)
+		assert.Nil(t, pool.take("w_down"))
+	})
+}
+
+func TestDataPlanePool_Evict(t *testing.T) {
+	pool := newDataPlanePool().(*dataPlanePool)
+	old, _ := net.Pipe()
+	recent, _ := net.Pipe()
+	pool.add("w_1", old)
+	pool.conns["w_1"][0].added = time.Now().Add(-time.Hour)
+	pool.add("w_1", recent)
+	pool.add("w_2", old)
+	pool.conns["w_2"][0].added = time.Now().Add(-time.Hour)
+
+	pool.evict(time.Now().Add(-time.Minute))
+	assert.Equal(t, recent, pool.take("w_1"))
+	assert.Nil(t, pool.take("w_1"))
+	assert.Nil(t, pool.take("w_2"))
+}
+
+func TestDataPlaneUpstreams_Disabled(t *testing.T) {
+	var dials int
+	upstreams := &dataPlaneUpstreams{
+		dialFn: func(context.Context, string) (net.Conn, error) {
+			dials++
+			c, _ := net.Pipe()
+			return c, nil
+		},
+		enabledFn: func() bool { return false },
+	}
+	upstreams.InitialAddresses([]string{"controller:9201"})
+	upstreams.fill(context.Background())
+	assert.Zero(t, dials)
+	assert.Equal(t, secondaryConnectionReceiverType, upstreams.Type())
+}
+
+func TestDataPlaneUpstreams_Backoff(t *testing.T) {
+	ctx := context.Background()
+	var dials int
+	fail := true
+	upstreams := &dataPlaneUpstreams{
+		dialFn: func(context.Context, string) (net.Conn, error) {
+			dials++
+			if fail {
+				return nil, errors.New("connection refused")
+			}
+			c, _ := net.Pipe()
+			return c, nil
+		},
+		enabledFn: func() bool { return true },
+		handleFn:  func(context.Context, net.Conn, *dataPlaneRequest) {},
+		idleConns: 2,
+	}
+	upstreams.SetAddresses([]string{"upstream:9202"})
+
+	// A failed dial stops the filling and the upstream isn't dialed again
+	// until its retry time.
+	upstreams.fill(ctx)
+	assert.Equal(t, 1, dials)
+	upstreams.fill(ctx)
+	assert.Equal(t, 1, dials)
+
+	// The time waited doubles with each failure, up to the maximum.
+	first, wait := upstreams.dialFailed("upstream:9202", time.Now())
+	assert.False(t, first)
+	assert.Equal(t, 2*dataPlaneMinRetryInterval, wait)
+	for i := 0; i < 20; i++ {
+		_, wait = upstreams.dialFailed("upstream:9202", time.Now())
+	}
+	assert.Equal(t, dataPlaneMaxRetryInterval, wait)
+
+	// Once the retry time has passed the upstream is dialed again, and a
+	// successful dial resets the failures.
+	upstreams.mu.Lock()
+	upstreams.retries["upstream:9202"].next = time.Now().Add(-time.Second)
+	upstreams.mu.Unlock()
+	fail = false
+	upstreams.fill(ctx)
+	assert.Equal(t, 3, dials)
+	upstreams.mu.Lock()
+	assert.Len(t, upstreams.conns["upstream:9202"], 2)
+	assert.Empty(t, upstreams.retries)
+	upstreams.mu.Unlock()
+}
+
+func TestRoutedEndpoint(t *testing.T) {
+	failover := []string{"tcp://10.0.0.2:22", "tcp://10.0.0.3:22"}
+	tests := []struct {
+		name              string
+		requested         string
+		requestedFailover []string
+		wantEndpoint      string
+		wantFailover      []string
+		wantErr           bool
+	}{
+		{
+			name:         "default",
+			wantEndpoint: "10.0.0.1:22",
+			wantFailover: []string{"10.0.0.2:22", "10.0.0.3:22"},
+		},
+		{
+			name:         "primary",
+			requested:    "10.0.0.1:22",
+			wantEndpoint: "10.0.0.1:22",
+			wantFailover: []string{"10.0.0.2:22", "10.0.0.3:22"},
+		},
+		{
+			name:         "failover",
+			requested:    "10.0.0.2:22",
+			wantEndpoint: "10.0.0.2:22",
+			wantFailover: []string{"10.0.0.1:22", "10.0.0.3:22"},
+		},
+		{
+			name:              "requested-failover-order",
+			requested:         "10.0.0.2:22",
+			requestedFailover: []string{"10.0.0.3:22", "10.0.0.1:22"},
+			wantEndpoint:      "10.0.0.2:22",
+			wantFailover:      []string{"10.0.0.3:22", "10.0.0.1:22"},
+		},
+		{
+			name:              "requested-failover-without-endpoint",
+			requestedFailover: []string{"10.0.0.3:22"},
+			wantEndpoint:      "10.0.0.1:22",
+			wantFailover:      []string{"10.0.0.3:22"},
+		},
+		{
+			name:      "other",
+			requested: "169.254.169.254:80",
+			wantErr:   true,
+		},
+		{
+			name:              "other-failover",
+			requested:         "10.0.0.1:22",
+			requestedFailover: []string{"10.0.0.2:22", "169.254.169.254:80"},
+			wantErr:           true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			endpoint, gotFailover, err := routedEndpoint("tcp://10.0.0.1:22", failover, tt.requested, tt.requestedFailover)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantEndpoint, endpoint)
+			assert.Equal(t, tt.wantFailover, gotFailover)
+		})
+	}
+}
+
+func TestWorker_HandleDataPlaneRequest_Egress(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	endpoint, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer endpoint.Close()
+	go func() {
+		for {
+			c, err := endpoint.Accept()
+			if err != nil {
+				return
+			}
+			go func() {
+				defer c.Close()
+				_, _ = io.Copy(c, c)
+			}()
+		}
+	}()
+
+	closed, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	closedAddr := closed.Addr().String()
+	require.NoError(t, closed.Close())
+
+	cert, _, _ := createTestCert(t)
+	client := pbs.NewMockSessionServiceClient()
+	client.LookupSessionFn = func(_ context.Context, req *pbs.LookupSessionRequest) (*pbs.LookupSessionResponse, error) {
+		if req.GetConnectionId() != "sc_known" || req.GetWorkerId() != "w_egress" {
+			return nil, status.Error(codes.PermissionDenied, "Unknown connection ID.")
+		}
+		return &pbs.LookupSessionResponse{
+			Authorization:     &targets.SessionAuthorizationData{SessionId: "s_1", Certificate: cert},
+			Status:            pbs.SESSIONSTATUS_SESSIONSTATUS_ACTIVE,
+			Expiration:        timestamppb.New(time.Now().Add(time.Hour)),
+			Endpoint:          "tcp://" + endpoint.Addr().String(),
+			FailoverEndpoints: []string{"tcp://" + closedAddr},
+		}, nil
+	}
+	manager, err := session.NewManager(client)
+	require.NoError(t, err)
+	w := &Worker{conf: &Config{Server: &base.Server{}}, sessionManager: manager, lastStatusSuccess: new(atomic.Value)}
+	w.lastStatusSuccess.Store(&LastStatusInformation{StatusResponse: &pbs.StatusResponse{WorkerId: "w_egress"}})
+
+	handle := func(t *testing.T, req *dataPlaneRequest) (net.Conn, *dataPlaneResponse) {
+		t.Helper()
+		upstream, downstream := net.Pipe()
+		go w.handleDataPlaneRequest(ctx, downstream, req)
+		resp := new(dataPlaneResponse)
+		require.NoError(t, readDataPlaneFrame(upstream, resp))
+		return upstream, resp
+	}
+
+	t.Run("unknown-connection", func(t *testing.T) {
+		c, resp := handle(t, &dataPlaneRequest{ConnectionId: "sc_unknown", Endpoint: endpoint.Addr().String()})
+		defer c.Close()
+		assert.Equal(t, "unknown connection", resp.Error)
+	})
+	t.Run("other-endpoint", func(t *testing.T) {
+		c, resp := handle(t, &dataPlaneRequest{ConnectionId: "sc_known", Endpoint: "169.254.169.254:80"})
+		defer c.Close()
+		assert.Equal(t, "endpoint is not an endpoint of the session", resp.Error)
+	})
+	t.Run("session-endpoint", func(t *testing.T) {
+		c, resp := handle(t, &dataPlaneRequest{ConnectionId: "sc_known", Endpoint: endpoint.Addr().String()})
+		defer c.Close()
+		require.Empty(t, resp.Error)
+		assert.Equal(t, "127.0.0.1", resp.EndpointIp)
+
+		_, err := c.Write([]byte("ping"))
+		require.NoError(t, err)
+		buf := make([]byte, 4)
+		_, err = io.ReadFull(c, buf)
+		require.NoError(t, err)
+		assert.Equal(t, "ping", string(buf))
+	})
+	t.Run("requested-failover", func(t *testing.T) {
+		c, resp := handle(t, &dataPlaneRequest{ConnectionId: "sc_known", Endpoint: closedAddr, FailoverEndpoints: []string{endpoint.Addr().String()}})
+		defer c.Close()
+		require.Empty(t, resp.Error)
+		assert.Equal(t, "127.0.0.1", resp.EndpointIp)
+		assert.EqualValues(t, endpoint.Addr().(*net.TCPAddr).Port, resp.EndpointPort)
+	})
+	t.Run("other-failover-endpoint", func(t *testing.T) {
+		c, resp := handle(t, &dataPlaneRequest{ConnectionId: "sc_known", Endpoint: closedAddr, FailoverEndpoints: []string{"169.254.169.254:80"}})
+		defer c.Close()
+		assert.Equal(t, "endpoint is not an endpoint of the session", resp.Error)
+	})
+}
@@ -26,12 +26,16 @@ type Options struct {
 	WithInjectedApplicationCredentials []*serverpb.Credential
 	WithPostConnectionHook             func(net.Conn)
 	WithDnsServerAddress               string
+	WithFailoverEndpoints              []string
+	WithEndpointDialHook               func(endpoint string, err error)
+	WithNetwork                        string
 }
 
 func getDefaultOptions() Options {
 	return Options{
 		WithInjectedApplicationCredentials: nil,
 		WithPostConnectionHook:             nil,
+		WithNetwork:                        "tcp",
 	}
 }
 
@@ -61,3 +65,31 @@ func WithDnsServerAddress(with string) Option {
 		o.WithDnsServerAddress = with
 	}
 }
+
+// WithFailoverEndpoints provides endpoints, in host:port form, to dial in order
+// if dialing the endpoint fails. It is only used when the endpoint is dialed
+// directly.
+func WithFailoverEndpoints(with []string) Option {
+	return func(o *Options) {
+		o.WithFailoverEndpoints = with
+	}
+}
+
+// WithEndpointDialHook provides a hook function called with the result of each
+// attempt to dial the endpoint or one of the failover endpoints, in host:port
+// form, so the caller knows which endpoint was actually reached. It is only
+// used when the endpoint is dialed directly.
+func WithEndpointDialHook(fn func(endpoint string, err error)) Option {
+	return func(o *Options) {
+		o.WithEndpointDialHook = fn
+	}
+}
+
+// WithNetwork provides the network, "tcp" or "udp", used when dialing the
+// endpoint. Only "tcp" can be dialed through downstream workers. Defaults to
+// "tcp".
+func WithNetwork(with string) Option {
+	return func(o *Options) {
+		o.WithNetwork = with
+	}
+}
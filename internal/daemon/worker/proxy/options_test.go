@@ -45,4 +45,32 @@ func Test_GetOpts(t *testing.T) {
 			runtime.FuncForPC(reflect.ValueOf(testOpts.WithPostConnectionHook).Pointer()).Name(),
 		)
 	})
+	t.Run("WithFailoverEndpoints", func(t *testing.T) {
+		assert := assert.New(t)
+		opts := GetOpts(WithFailoverEndpoints([]string{"10.0.0.2:22", "10.0.0.3:22"}))
+		testOpts := getDefaultOptions()
+		assert.NotEqual(opts, testOpts)
+		testOpts.WithFailoverEndpoints = []string{"10.0.0.2:22", "10.0.0.3:22"}
+		assert.Equal(opts, testOpts)
+	})
+	t.Run("WithEndpointDialHook", func(t *testing.T) {
+		assert := assert.New(t)
+		testFn := func(string, error) {}
+		opts := GetOpts(WithEndpointDialHook(testFn))
+		testOpts := getDefaultOptions()
+		assert.NotEqual(opts, testOpts)
+		testOpts.WithEndpointDialHook = testFn
+		assert.Equal(
+			runtime.FuncForPC(reflect.ValueOf(opts.WithEndpointDialHook).Pointer()).Name(),
+			runtime.FuncForPC(reflect.ValueOf(testOpts.WithEndpointDialHook).Pointer()).Name(),
+		)
+	})
+	t.Run("WithNetwork", func(t *testing.T) {
+		assert := assert.New(t)
+		opts := GetOpts(WithNetwork("udp"))
+		testOpts := getDefaultOptions()
+		assert.NotEqual(opts, testOpts)
+		testOpts.WithNetwork = "udp"
+		assert.Equal(opts, testOpts)
+	})
 }
@@ -15,6 +15,7 @@ import (
 
 var (
 	TcpHandlerName = "tcp"
+	UdpHandlerName = "udp"
 
 	// handlers is the map of registered handlers
 	handlers *sync.Map = new(sync.Map)
@@ -25,10 +26,10 @@ var (
 	// ErrProtocolAlreadyRegistered specifies the provided protocol has already been registered
 	ErrProtocolAlreadyRegistered = errors.New("proxy: protocol already registered")
 
-	// GetHandler returns the handler registered for the provided worker and
-	// protocolContext. If a protocol cannot be determined or the protocol is
-	// not registered nil, ErrUnknownProtocol is returned.
-	GetHandler = tcpOnly
+	// GetHandler returns the handler registered for the provided worker,
+	// endpoint scheme and protocolContext. If a protocol cannot be determined
+	// or the protocol is not registered nil, ErrUnknownProtocol is returned.
+	GetHandler = byScheme
 )
 
 // RecordingManager allows a handler for a protocol that supports recording.
@@ -56,9 +57,14 @@ func RegisterHandler(protocol string, handler Handler) error {
 	return nil
 }
 
-// tcpOnly returns only the TCP protocol.
-func tcpOnly(string, proto.Message) (Handler, error) {
-	handler, ok := handlers.Load(TcpHandlerName)
+// byScheme returns the UDP protocol for udp endpoints and the TCP protocol
+// for all others.
+func byScheme(_ string, scheme string, _ proto.Message) (Handler, error) {
+	protocol := TcpHandlerName
+	if scheme == UdpHandlerName {
+		protocol = UdpHandlerName
+	}
+	handler, ok := handlers.Load(protocol)
 	if !ok {
 		return nil, ErrUnknownProtocol
 	}
@@ -38,7 +38,7 @@ func TestRegisterHandler(t *testing.T) {
 	require.NoError(err)
 }
 
-func TestAlwaysTcpGetHandler(t *testing.T) {
+func TestBySchemeGetHandler(t *testing.T) {
 	assert, require := assert.New(t), require.New(t)
 	fn := func(context.Context, context.Context, DecryptFn, net.Conn, *ProxyDialer, string, *anypb.Any, RecordingManager) (ProxyConnFn, error) {
 		return nil, nil
@@ -48,12 +48,25 @@ func TestAlwaysTcpGetHandler(t *testing.T) {
 		handlers = oldHandler
 	})
 	handlers = new(sync.Map)
-	_, err := tcpOnly("wid", nil)
+	_, err := byScheme("wid", "tcp", nil)
 	assert.ErrorIs(err, ErrUnknownProtocol)
 
 	require.NoError(RegisterHandler("tcp", fn))
 
-	handler, err := tcpOnly("wid", nil)
+	handler, err := byScheme("wid", "tcp", nil)
+	require.NoError(err)
+	require.NotNil(handler)
+
+	handler, err = byScheme("wid", "ssh", nil)
+	require.NoError(err)
+	require.NotNil(handler)
+
+	_, err = byScheme("wid", "udp", nil)
+	assert.ErrorIs(err, ErrUnknownProtocol)
+
+	require.NoError(RegisterHandler("udp", fn))
+
+	handler, err = byScheme("wid", "udp", nil)
 	require.NoError(err)
 	require.NotNil(handler)
 }
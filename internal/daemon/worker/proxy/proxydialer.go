@@ -12,16 +12,75 @@ import (
 	"sync/atomic"
 
 	"github.com/hashicorp/boundary/internal/errors"
+	"github.com/hashicorp/boundary/internal/event"
 	"github.com/miekg/dns"
 	"google.golang.org/protobuf/proto"
 )
 
 // GetEndpointDialer returns a ProxyDialer which, when Dial() is called
 // returns a net.Conn which reaches the provided endpoint.
-var GetEndpointDialer = directDialer
+var GetEndpointDialer = routedDialer
 
-// directDialer returns a ProxyDialer which tcp dials directly to the provided
-// endpoint.
+// RouteDialer is implemented by receivers which can reach an endpoint through
+// the downstream workers connected to this worker. route contains the ids of
+// the remaining workers to traverse, the first being a directly connected
+// downstream worker and the last being the egress worker. The egress worker
+// dials failoverEndpoints in order if endpoint cannot be reached.
+type RouteDialer interface {
+	DialRoute(ctx context.Context, route []string, endpoint string, failoverEndpoints []string, connectionId string) (net.Conn, error)
+}
+
+// routeProvider is satisfied by the AuthorizeConnectionResponse provided to
+// GetEndpointDialer.
+type routeProvider interface {
+	GetRoute() []string
+	GetConnectionId() string
+}
+
+// routedDialer returns a ProxyDialer which reaches the endpoint through the
+// downstream workers in the route of the authorize connection response. If the
+// route only contains this worker the endpoint is dialed directly. Any
+// WithFailoverEndpoints are passed on to the egress worker, which fails over
+// to them.
+func routedDialer(ctx context.Context, endpoint string, workerId string, acResp proto.Message, receiver interface{}, opt ...Option) (*ProxyDialer, error) {
+	const op = "proxy.routedDialer"
+	rp, ok := acResp.(routeProvider)
+	if !ok || len(rp.GetRoute()) <= 1 {
+		return directDialer(ctx, endpoint, workerId, acResp, receiver, opt...)
+	}
+	if len(endpoint) == 0 {
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "endpoint is empty")
+	}
+	opts := GetOpts(opt...)
+	if opts.WithNetwork != "tcp" {
+		return nil, errors.New(ctx, errors.InvalidParameter, op, fmt.Sprintf("%s endpoints cannot be reached through downstream workers", opts.WithNetwork))
+	}
+	rd, ok := receiver.(RouteDialer)
+	if !ok || rd == nil {
+		return nil, errors.New(ctx, errors.Internal, op, "worker is unable to route connections to downstream workers")
+	}
+	route := rp.GetRoute()[1:]
+	connectionId := rp.GetConnectionId()
+
+	d, err := NewProxyDialer(ctx, func(dialerOpt ...Option) (net.Conn, error) {
+		remoteConn, err := rd.DialRoute(ctx, route, endpoint, opts.WithFailoverEndpoints, connectionId)
+		if err != nil {
+			return nil, errors.Wrap(ctx, err, op)
+		}
+		dialerOpts := GetOpts(dialerOpt...)
+		if dialerOpts.WithPostConnectionHook != nil {
+			dialerOpts.WithPostConnectionHook(remoteConn)
+		}
+		return remoteConn, nil
+	})
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	return d, nil
+}
+
+// directDialer returns a ProxyDialer which dials directly to the provided
+// endpoint over the network given by WithNetwork.
 func directDialer(ctx context.Context, endpoint string, _ string, _ proto.Message, _ interface{}, opt ...Option) (*ProxyDialer, error) {
 	const op = "proxy.directDialer"
 	if len(endpoint) == 0 {
@@ -34,7 +93,7 @@ func directDialer(ctx context.Context, endpoint string, _ string, _ proto.Messag
 		return nil, errors.Wrap(ctx, err, op)
 	}
 
-	d, err := NewProxyDialer(ctx, func(dialerOpt ...Option) (net.Conn, error) {
+	dialEndpoint := func(endpoint string) (net.Conn, error) {
 		if dnsServer != "" {
 			host, port, err := net.SplitHostPort(endpoint)
 			if err != nil {
@@ -78,7 +137,24 @@ func directDialer(ctx context.Context, endpoint string, _ string, _ proto.Messag
 				}
 			}
 		}
-		remoteConn, err := net.Dial("tcp", endpoint)
+		c, err := net.Dial(opts.WithNetwork, endpoint)
+		if opts.WithEndpointDialHook != nil {
+			opts.WithEndpointDialHook(endpoint, err)
+		}
+		return c, err
+	}
+
+	d, err := NewProxyDialer(ctx, func(dialerOpt ...Option) (net.Conn, error) {
+		remoteConn, err := dialEndpoint(endpoint)
+		for _, alt := range opts.WithFailoverEndpoints {
+			if err == nil {
+				break
+			}
+			event.WriteError(ctx, op, err, event.WithInfoMsg("error dialing endpoint, failing over", "failover_endpoint", alt))
+			if remoteConn, err = dialEndpoint(alt); err == nil {
+				event.WriteSysEvent(ctx, op, "connected to failover endpoint", "endpoint", endpoint, "failover_endpoint", alt)
+			}
+		}
 		if err != nil {
 			return nil, errors.Wrap(ctx, err, op)
 		}
@@ -106,7 +182,7 @@ func (p *proxyAddr) Ip() string {
 	return p.ip
 }
 
-// Port returns the tcp port of the endpoint connected to
+// Port returns the port of the endpoint connected to
 func (p *proxyAddr) Port() uint32 {
 	return p.port
 }
@@ -161,6 +237,9 @@ func (d *ProxyDialer) Dial(ctx context.Context, opt ...Option) (net.Conn, error)
 		ip := addr.IP.String()
 		port := uint32(addr.Port)
 		d.latestAddr.Store(&proxyAddr{ip: ip, port: port})
+	case *net.UDPConn:
+		addr := v.RemoteAddr().(*net.UDPAddr)
+		d.latestAddr.Store(&proxyAddr{ip: addr.IP.String(), port: uint32(addr.Port)})
 	case portAndIpGetter:
 		d.latestAddr.Store(&proxyAddr{ip: v.GetIp(), port: v.GetPort()})
 	default:
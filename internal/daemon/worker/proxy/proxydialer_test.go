@@ -9,6 +9,7 @@ import (
 	"net"
 	"testing"
 
+	pbs "github.com/hashicorp/boundary/internal/gen/controller/servers/services"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -63,3 +64,143 @@ func TestProxyDialer(t *testing.T) {
 		assert.EqualValues(t, tcpAddr.Port, d.LastConnectionAddr().Port())
 	})
 }
+
+type testRouteDialer struct {
+	route             []string
+	endpoint          string
+	failoverEndpoints []string
+	connectionId      string
+}
+
+type testRoutedConn struct {
+	net.Conn
+}
+
+func (testRoutedConn) GetIp() string   { return "10.0.0.1" }
+func (testRoutedConn) GetPort() uint32 { return 22 }
+
+func (d *testRouteDialer) DialRoute(_ context.Context, route []string, endpoint string, failoverEndpoints []string, connectionId string) (net.Conn, error) {
+	d.route, d.endpoint, d.failoverEndpoints, d.connectionId = route, endpoint, failoverEndpoints, connectionId
+	c, _ := net.Pipe()
+	return testRoutedConn{Conn: c}, nil
+}
+
+func TestRoutedDialer(t *testing.T) {
+	ctx := context.Background()
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	go func() {
+		_, _ = l.Accept()
+	}()
+	defer l.Close()
+
+	t.Run("single-hop-dials-directly", func(t *testing.T) {
+		rd := &testRouteDialer{}
+		d, err := routedDialer(ctx, l.Addr().String(), "w_1", &pbs.AuthorizeConnectionResponse{Route: []string{"w_1"}}, rd)
+		require.NoError(t, err)
+		c, err := d.Dial(ctx)
+		require.NoError(t, err)
+		defer c.Close()
+		assert.Nil(t, rd.route)
+		assert.Equal(t, l.Addr().(*net.TCPAddr).IP.String(), d.LastConnectionAddr().Ip())
+	})
+
+	t.Run("multi-hop", func(t *testing.T) {
+		rd := &testRouteDialer{}
+		resp := &pbs.AuthorizeConnectionResponse{ConnectionId: "sc_1", Route: []string{"w_1", "w_2", "w_3"}}
+		var hooked bool
+		d, err := routedDialer(ctx, "10.0.0.1:22", "w_1", resp, rd, WithFailoverEndpoints([]string{"10.0.0.2:22"}))
+		require.NoError(t, err)
+		c, err := d.Dial(ctx, WithPostConnectionHook(func(net.Conn) { hooked = true }))
+		require.NoError(t, err)
+		defer c.Close()
+		assert.True(t, hooked)
+		assert.Equal(t, []string{"w_2", "w_3"}, rd.route)
+		assert.Equal(t, "10.0.0.1:22", rd.endpoint)
+		assert.Equal(t, []string{"10.0.0.2:22"}, rd.failoverEndpoints)
+		assert.Equal(t, "sc_1", rd.connectionId)
+		assert.Equal(t, "10.0.0.1", d.LastConnectionAddr().Ip())
+		assert.EqualValues(t, 22, d.LastConnectionAddr().Port())
+	})
+
+	t.Run("multi-hop-without-route-dialer", func(t *testing.T) {
+		resp := &pbs.AuthorizeConnectionResponse{Route: []string{"w_1", "w_2"}}
+		_, err := routedDialer(ctx, "10.0.0.1:22", "w_1", resp, nil)
+		assert.Error(t, err)
+	})
+
+	t.Run("multi-hop-udp", func(t *testing.T) {
+		rd := &testRouteDialer{}
+		resp := &pbs.AuthorizeConnectionResponse{Route: []string{"w_1", "w_2"}}
+		_, err := routedDialer(ctx, "10.0.0.1:53", "w_1", resp, rd, WithNetwork("udp"))
+		assert.Error(t, err)
+	})
+}
+
+func TestDirectDialerUdp(t *testing.T) {
+	ctx := context.Background()
+	pc, err := net.ListenPacket("udp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer pc.Close()
+
+	d, err := directDialer(ctx, pc.LocalAddr().String(), "w_1", nil, nil, WithNetwork("udp"))
+	require.NoError(t, err)
+	c, err := d.Dial(ctx)
+	require.NoError(t, err)
+	defer c.Close()
+	assert.IsType(t, &net.UDPConn{}, c)
+	assert.EqualValues(t, pc.LocalAddr().(*net.UDPAddr).Port, d.LastConnectionAddr().Port())
+}
+
+func TestDirectDialerFailover(t *testing.T) {
+	ctx := context.Background()
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	go func() {
+		for {
+			c, err := l.Accept()
+			if err != nil {
+				return
+			}
+			_ = c.Close()
+		}
+	}()
+	defer l.Close()
+
+	// Reserve a port and close the listener so dialing it is refused.
+	closed, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	closedAddr := closed.Addr().String()
+	require.NoError(t, closed.Close())
+
+	t.Run("endpoint-reachable", func(t *testing.T) {
+		d, err := directDialer(ctx, l.Addr().String(), "w_1", nil, nil, WithFailoverEndpoints([]string{closedAddr}))
+		require.NoError(t, err)
+		c, err := d.Dial(ctx)
+		require.NoError(t, err)
+		defer c.Close()
+		assert.EqualValues(t, l.Addr().(*net.TCPAddr).Port, d.LastConnectionAddr().Port())
+	})
+
+	t.Run("fails-over", func(t *testing.T) {
+		dialed := map[string]bool{}
+		hook := func(endpoint string, err error) {
+			dialed[endpoint] = err == nil
+		}
+		d, err := directDialer(ctx, closedAddr, "w_1", nil, nil, WithFailoverEndpoints([]string{closedAddr, l.Addr().String()}), WithEndpointDialHook(hook))
+		require.NoError(t, err)
+		c, err := d.Dial(ctx)
+		require.NoError(t, err)
+		defer c.Close()
+		assert.EqualValues(t, l.Addr().(*net.TCPAddr).Port, d.LastConnectionAddr().Port())
+		assert.Equal(t, map[string]bool{closedAddr: false, l.Addr().String(): true}, dialed)
+	})
+
+	t.Run("all-unreachable", func(t *testing.T) {
+		d, err := directDialer(ctx, closedAddr, "w_1", nil, nil, WithFailoverEndpoints([]string{closedAddr}))
+		require.NoError(t, err)
+		c, err := d.Dial(ctx)
+		assert.Error(t, err)
+		assert.Nil(t, c)
+	})
+}
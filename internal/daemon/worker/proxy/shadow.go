@@ -0,0 +1,162 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package proxy
+
+import (
+	"errors"
+	"io"
+	"sync"
+
+	"github.com/hashicorp/boundary/internal/bsr"
+)
+
+// shadowObserverBufferSize is the number of chunks buffered for an observer
+// before it is considered too slow and disconnected.
+const shadowObserverBufferSize = 256
+
+var (
+	// shadowSources is the map of session ids to their registered shadow
+	// sources
+	shadowSources *sync.Map = new(sync.Map)
+
+	// ErrShadowSourceNotFound specifies the session has no registered shadow
+	// source
+	ErrShadowSourceNotFound = errors.New("proxy: shadow source not found for session")
+
+	// ErrShadowSourceAlreadyRegistered specifies the session already has a
+	// registered shadow source
+	ErrShadowSourceAlreadyRegistered = errors.New("proxy: shadow source already registered for session")
+
+	// ErrShadowSourceClosed specifies the shadow source has been closed
+	ErrShadowSourceClosed = errors.New("proxy: shadow source closed")
+
+	// ErrShadowInputUnsupported specifies the shadow source does not accept
+	// input from observers
+	ErrShadowInputUnsupported = errors.New("proxy: shadow source does not accept input")
+)
+
+// ShadowSource fans out the terminal of a session to the observers shadowing
+// it. Only protocol handlers that terminate the SSH connection of a session can
+// support shadowing, since the bytes proxied between the client and the
+// endpoint are encrypted end to end. Such a handler registers a source for the
+// session and publishes the data of the session channel to it as ssh data
+// chunks.
+type ShadowSource struct {
+	sessionId string
+	input     io.Writer
+
+	mu        sync.Mutex
+	observers map[*ShadowObserver]struct{}
+	closed    bool
+}
+
+// RegisterShadowSource registers a shadow source for the session. If input is
+// not nil, observers allowed to send input to the terminal write to it, so it
+// must write to the session channel rather than to the connection carrying it.
+// The source must be closed once the session's terminal is gone.
+func RegisterShadowSource(sessionId string, input io.Writer) (*ShadowSource, error) {
+	s := &ShadowSource{
+		sessionId: sessionId,
+		input:     input,
+		observers: make(map[*ShadowObserver]struct{}),
+	}
+	if _, loaded := shadowSources.LoadOrStore(sessionId, s); loaded {
+		return nil, ErrShadowSourceAlreadyRegistered
+	}
+	return s, nil
+}
+
+// LookupShadowSource returns the shadow source registered for the session. If
+// there is none, nil, ErrShadowSourceNotFound is returned.
+func LookupShadowSource(sessionId string) (*ShadowSource, error) {
+	s, ok := shadowSources.Load(sessionId)
+	if !ok {
+		return nil, ErrShadowSourceNotFound
+	}
+	return s.(*ShadowSource), nil
+}
+
+// Publish sends the chunk to every observer of the source. Observers that are
+// not keeping up are disconnected rather than slowing down the session.
+func (s *ShadowSource) Publish(c bsr.Chunk) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for o := range s.observers {
+		select {
+		case o.chunks <- c:
+		default:
+			delete(s.observers, o)
+			close(o.chunks)
+		}
+	}
+}
+
+// Observed returns true if the source has observers.
+func (s *ShadowSource) Observed() bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return len(s.observers) > 0
+}
+
+// Observe adds an observer to the source. The observer must be closed when
+// the caller is done with it.
+func (s *ShadowSource) Observe() (*ShadowObserver, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.closed {
+		return nil, ErrShadowSourceClosed
+	}
+	o := &ShadowObserver{
+		source: s,
+		chunks: make(chan bsr.Chunk, shadowObserverBufferSize),
+	}
+	s.observers[o] = struct{}{}
+	return o, nil
+}
+
+// InputWriter returns the writer observers send input to. If the source does
+// not accept input, nil, ErrShadowInputUnsupported is returned.
+func (s *ShadowSource) InputWriter() (io.Writer, error) {
+	if s.input == nil {
+		return nil, ErrShadowInputUnsupported
+	}
+	return s.input, nil
+}
+
+// Close unregisters the source and disconnects its observers.
+func (s *ShadowSource) Close() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.closed {
+		return
+	}
+	s.closed = true
+	shadowSources.CompareAndDelete(s.sessionId, s)
+	for o := range s.observers {
+		delete(s.observers, o)
+		close(o.chunks)
+	}
+}
+
+// ShadowObserver receives the chunks published to a shadow source.
+type ShadowObserver struct {
+	source *ShadowSource
+	chunks chan bsr.Chunk
+}
+
+// Chunks returns the channel the published chunks are sent on. The channel is
+// closed when the source is closed or the observer falls behind.
+func (o *ShadowObserver) Chunks() <-chan bsr.Chunk {
+	return o.chunks
+}
+
+// Close removes the observer from its source.
+func (o *ShadowObserver) Close() {
+	o.source.mu.Lock()
+	defer o.source.mu.Unlock()
+	if _, ok := o.source.observers[o]; ok {
+		delete(o.source.observers, o)
+		close(o.chunks)
+	}
+}
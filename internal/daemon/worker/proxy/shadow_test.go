@@ -0,0 +1,104 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package proxy
+
+import (
+	"bytes"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/boundary/internal/bsr"
+	"github.com/hashicorp/boundary/internal/bsr/ssh"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestShadowSource(t *testing.T) {
+	ctx := context.Background()
+	newChunk := func(t *testing.T, data string) bsr.Chunk {
+		c, err := ssh.NewDataChunk(ctx, bsr.Outbound, bsr.NewTimestamp(time.Now()), []byte(data))
+		require.NoError(t, err)
+		return c
+	}
+
+	t.Run("register-lookup-close", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		_, err := LookupShadowSource("s_register")
+		assert.ErrorIs(err, ErrShadowSourceNotFound)
+
+		s, err := RegisterShadowSource("s_register", nil)
+		require.NoError(err)
+		_, err = RegisterShadowSource("s_register", nil)
+		assert.ErrorIs(err, ErrShadowSourceAlreadyRegistered)
+
+		got, err := LookupShadowSource("s_register")
+		require.NoError(err)
+		assert.Same(s, got)
+
+		_, err = s.InputWriter()
+		assert.ErrorIs(err, ErrShadowInputUnsupported)
+
+		s.Close()
+		_, err = LookupShadowSource("s_register")
+		assert.ErrorIs(err, ErrShadowSourceNotFound)
+		_, err = s.Observe()
+		assert.ErrorIs(err, ErrShadowSourceClosed)
+	})
+
+	t.Run("publish", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		var input bytes.Buffer
+		s, err := RegisterShadowSource("s_publish", &input)
+		require.NoError(err)
+		t.Cleanup(s.Close)
+
+		o1, err := s.Observe()
+		require.NoError(err)
+		o2, err := s.Observe()
+		require.NoError(err)
+
+		c := newChunk(t, "ls -al")
+		s.Publish(c)
+		assert.Equal(c, <-o1.Chunks())
+		assert.Equal(c, <-o2.Chunks())
+
+		o2.Close()
+		_, ok := <-o2.Chunks()
+		assert.False(ok)
+		s.Publish(c)
+		assert.Equal(c, <-o1.Chunks())
+
+		w, err := s.InputWriter()
+		require.NoError(err)
+		_, err = w.Write([]byte("exit\n"))
+		require.NoError(err)
+		assert.Equal("exit\n", input.String())
+
+		s.Close()
+		_, ok = <-o1.Chunks()
+		assert.False(ok)
+		// Closing an observer of a closed source is a noop
+		o1.Close()
+	})
+
+	t.Run("slow-observer", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		s, err := RegisterShadowSource("s_slow", nil)
+		require.NoError(err)
+		t.Cleanup(s.Close)
+
+		o, err := s.Observe()
+		require.NoError(err)
+		c := newChunk(t, "x")
+		for i := 0; i < shadowObserverBufferSize+1; i++ {
+			s.Publish(c)
+		}
+		var received int
+		for range o.Chunks() {
+			received++
+		}
+		assert.Equal(shadowObserverBufferSize, received)
+	})
+}
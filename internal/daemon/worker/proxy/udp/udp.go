@@ -0,0 +1,137 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package udp
+
+import (
+	"context"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"net"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/boundary/internal/daemon/worker/proxy"
+	"github.com/hashicorp/boundary/internal/errors"
+	"google.golang.org/protobuf/types/known/anypb"
+)
+
+// maxDatagramSize is the largest datagram that can be relayed, which is the
+// largest length that fits in a frame header. It is larger than the payload of
+// any UDP datagram, which is at most 65507 bytes over IPv4 and 65527 bytes over
+// IPv6.
+const maxDatagramSize = 1<<16 - 1
+
+// flowIdleTimeout is how long a flow can go without relaying a datagram in
+// either direction before it is closed.
+var flowIdleTimeout = 60 * time.Second
+
+func init() {
+	err := proxy.RegisterHandler(proxy.UdpHandlerName, handleProxy)
+	if err != nil {
+		panic(err)
+	}
+}
+
+// handleProxy creates a udp relay between the incoming conn and the udp
+// socket created by the ProxyDialer. Each datagram is carried over the
+// incoming conn as a frame written by writeDatagram.
+//
+// handleProxy returns a ProxyConnFn which relays datagrams in both directions
+// and blocks until either connection is closed or the flow has been idle for
+// flowIdleTimeout.
+func handleProxy(controlCtx context.Context, _ context.Context, _ proxy.DecryptFn, conn net.Conn, out *proxy.ProxyDialer, connId string, _ *anypb.Any, _ proxy.RecordingManager) (proxy.ProxyConnFn, error) {
+	const op = "udp.HandleProxy"
+	switch {
+	case conn == nil:
+		return nil, errors.New(controlCtx, errors.InvalidParameter, op, "conn is nil")
+	case out == nil:
+		return nil, errors.New(controlCtx, errors.InvalidParameter, op, "proxy dialer is nil")
+	case len(connId) == 0:
+		return nil, errors.New(controlCtx, errors.InvalidParameter, op, "connection id is empty")
+	}
+	remoteConn, err := out.Dial(controlCtx)
+	if err != nil {
+		return nil, err
+	}
+
+	return func() {
+		closeConns := func() {
+			_ = conn.Close()
+			_ = remoteConn.Close()
+		}
+		idle := time.AfterFunc(flowIdleTimeout, closeConns)
+		defer idle.Stop()
+
+		connWg := new(sync.WaitGroup)
+		connWg.Add(2)
+		go func() {
+			defer connWg.Done()
+			defer closeConns()
+			buf := make([]byte, maxDatagramSize)
+			for {
+				n, err := readDatagram(conn, buf)
+				if err != nil {
+					return
+				}
+				idle.Reset(flowIdleTimeout)
+				if _, err := remoteConn.Write(buf[:n]); err != nil {
+					return
+				}
+			}
+		}()
+		go func() {
+			defer connWg.Done()
+			defer closeConns()
+			buf := make([]byte, maxDatagramSize)
+			for {
+				n, err := remoteConn.Read(buf)
+				if err != nil {
+					return
+				}
+				idle.Reset(flowIdleTimeout)
+				if err := writeDatagram(conn, buf[:n]); err != nil {
+					return
+				}
+			}
+		}()
+		connWg.Wait()
+	}, nil
+}
+
+// writeDatagram writes p to w in a single write as a frame holding the length
+// of p as a big-endian uint16 followed by p. An error is returned if p is
+// longer than maxDatagramSize.
+//
+// Datagrams are framed, rather than sent as websocket messages with
+// sdk/wspb, because proxy handlers are given the client connection as a
+// net.Conn. The websocket.NetConn it is built on joins the messages into a
+// byte stream, and wrappers such as the byte counting and rate limiting of the
+// worker only see that stream, so the message boundaries are not available
+// here. The client side of a udp session, in api/proxy, uses the same framing.
+func writeDatagram(w io.Writer, p []byte) error {
+	if len(p) > maxDatagramSize {
+		return fmt.Errorf("datagram of %d bytes is larger than the maximum of %d bytes", len(p), maxDatagramSize)
+	}
+	frame := make([]byte, 2+len(p))
+	binary.BigEndian.PutUint16(frame, uint16(len(p)))
+	copy(frame[2:], p)
+	_, err := w.Write(frame)
+	return err
+}
+
+// readDatagram reads a frame written by writeDatagram from r into buf, which
+// must be at least maxDatagramSize long, and returns the length of the
+// datagram.
+func readDatagram(r io.Reader, buf []byte) (int, error) {
+	var header [2]byte
+	if _, err := io.ReadFull(r, header[:]); err != nil {
+		return 0, err
+	}
+	n := int(binary.BigEndian.Uint16(header[:]))
+	if _, err := io.ReadFull(r, buf[:n]); err != nil {
+		return 0, err
+	}
+	return n, nil
+}
@@ -0,0 +1,188 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package udp
+
+import (
+	"bytes"
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/boundary/internal/daemon/worker/proxy"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"nhooyr.io/websocket"
+)
+
+func testEchoServer(t *testing.T) net.PacketConn {
+	t.Helper()
+	pc, err := net.ListenPacket("udp", "127.0.0.1:0")
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		pc.Close()
+	})
+	go func() {
+		buf := make([]byte, maxDatagramSize)
+		for {
+			n, addr, err := pc.ReadFrom(buf)
+			if err != nil {
+				return
+			}
+			_, _ = pc.WriteTo(buf[:n], addr)
+		}
+	}()
+	return pc
+}
+
+func testDialer(t *testing.T, addr string) *proxy.ProxyDialer {
+	t.Helper()
+	dialer, err := proxy.NewProxyDialer(context.Background(), func(...proxy.Option) (net.Conn, error) {
+		return net.Dial("udp", addr)
+	})
+	require.NoError(t, err)
+	return dialer
+}
+
+func TestHandleProxy_Errors(t *testing.T) {
+	c, _ := net.Pipe()
+	echo := testEchoServer(t)
+	dialer := testDialer(t, echo.LocalAddr().String())
+
+	cases := []struct {
+		name      string
+		conn      net.Conn
+		dialer    *proxy.ProxyDialer
+		connId    string
+		wantError bool
+	}{
+		{
+			name:   "valid",
+			conn:   c,
+			dialer: dialer,
+			connId: "someconnectionid",
+		},
+		{
+			name:      "nil connection",
+			dialer:    dialer,
+			connId:    "someconnectionid",
+			wantError: true,
+		},
+		{
+			name:      "nil dialer",
+			conn:      c,
+			connId:    "someconnectionid",
+			wantError: true,
+		},
+		{
+			name:      "no connection id",
+			conn:      c,
+			dialer:    dialer,
+			wantError: true,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := context.Background()
+			fn, err := handleProxy(ctx, ctx, nil, tc.conn, tc.dialer, tc.connId, nil, nil)
+			if tc.wantError {
+				assert.Error(t, err)
+				assert.Nil(t, fn)
+				return
+			}
+			assert.NoError(t, err)
+			assert.NotNil(t, fn)
+		})
+	}
+}
+
+func TestHandleProxy(t *testing.T) {
+	require, assert := require.New(t), assert.New(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	oldTimeout := flowIdleTimeout
+	flowIdleTimeout = 500 * time.Millisecond
+	t.Cleanup(func() {
+		flowIdleTimeout = oldTimeout
+	})
+
+	clientConn, proxyConn := proxy.TestWsConn(t, ctx)
+	echo := testEchoServer(t)
+
+	fn, err := handleProxy(ctx, ctx, nil, websocket.NetConn(ctx, proxyConn, websocket.MessageBinary), testDialer(t, echo.LocalAddr().String()), "someconnectionid", nil, nil)
+	require.NoError(err)
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		fn()
+	}()
+
+	netConn := websocket.NetConn(ctx, clientConn, websocket.MessageBinary)
+	buf := make([]byte, maxDatagramSize)
+	for _, d := range [][]byte{[]byte("first datagram"), []byte("second datagram"), {}} {
+		require.NoError(writeDatagram(netConn, d))
+		n, err := readDatagram(netConn, buf)
+		require.NoError(err)
+		assert.Equal(d, buf[:n])
+	}
+
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("flow was not closed after being idle")
+	}
+	_, err = readDatagram(netConn, buf)
+	assert.Error(err)
+}
+
+func TestHandleProxy_LargeDatagrams(t *testing.T) {
+	require, assert := require.New(t), assert.New(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	clientConn, proxyConn := proxy.TestWsConn(t, ctx)
+	echo := testEchoServer(t)
+
+	fn, err := handleProxy(ctx, ctx, nil, websocket.NetConn(ctx, proxyConn, websocket.MessageBinary), testDialer(t, echo.LocalAddr().String()), "someconnectionid", nil, nil)
+	require.NoError(err)
+	go fn()
+
+	// The largest UDP payload over IPv4 is larger than the default read limit
+	// of a websocket message, and the framing must still relay it whole.
+	const maxIpv4Payload = 65507
+	netConn := websocket.NetConn(ctx, clientConn, websocket.MessageBinary)
+	buf := make([]byte, maxDatagramSize)
+	for _, size := range []int{maxIpv4Payload, maxIpv4Payload - 1, 1 << 15, 1<<15 + 1} {
+		d := bytes.Repeat([]byte{byte(size)}, size)
+		require.NoError(writeDatagram(netConn, d))
+		n, err := readDatagram(netConn, buf)
+		require.NoError(err)
+		assert.Equal(size, n)
+		assert.Equal(d, buf[:n])
+	}
+}
+
+func TestDatagramFraming(t *testing.T) {
+	require, assert := require.New(t), assert.New(t)
+	var stream bytes.Buffer
+	datagrams := [][]byte{
+		[]byte("hello"),
+		{},
+		bytes.Repeat([]byte("x"), maxDatagramSize),
+	}
+	for _, d := range datagrams {
+		require.NoError(writeDatagram(&stream, d))
+	}
+	buf := make([]byte, maxDatagramSize)
+	for _, d := range datagrams {
+		n, err := readDatagram(&stream, buf)
+		require.NoError(err)
+		assert.Equal(d, buf[:n])
+	}
+	_, err := readDatagram(&stream, buf)
+	assert.Error(err)
+	assert.Error(writeDatagram(&stream, make([]byte, maxDatagramSize+1)))
+	assert.Zero(stream.Len())
+}
@@ -7,4 +7,9 @@ import "nhooyr.io/websocket"
 
 const (
 	WebsocketStatusProtocolSetupError websocket.StatusCode = 3000
+
+	// WebsocketStatusSessionCanceled is sent to clients when their
+	// connection is closed because the session was canceled. The reason of
+	// the close is the reason given when the session was canceled.
+	WebsocketStatusSessionCanceled websocket.StatusCode = 3001
 )
@@ -43,6 +43,14 @@ type Manager interface {
 	// a Session that is already in the manager's data, only the Status is updated.
 	LoadLocalSession(ctx context.Context, id string, workerId string) (Session, error)
 
+	// LookupRoutedSession looks up from the source of truth the session a
+	// connection which this worker was asked to proxy as part of a multi-hop
+	// route belongs to. It fails if the connection is unknown or no longer
+	// active, or if this worker may not be the session's egress worker. The
+	// session is not added to the local manager's data, since its connections
+	// are tracked by the ingress worker.
+	LookupRoutedSession(ctx context.Context, connectionId string, workerId string) (Session, error)
+
 	// DeleteLocalSession removes all sessions with the provided id from the
 	// local manager.  If ids are passed in which do not exist in the manager
 	// no error is returned.
@@ -131,6 +139,25 @@ func (m *manager) LoadLocalSession(ctx context.Context, id string, workerId stri
 	return actualSess, nil
 }
 
+func (m *manager) LookupRoutedSession(ctx context.Context, connectionId string, workerId string) (Session, error) {
+	const op = "session.(*manager).LookupRoutedSession"
+	switch {
+	case connectionId == "":
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "connectionId is not set")
+	case workerId == "":
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "workerId is not set")
+	}
+
+	resp, err := m.controllerSessionConn.LookupSession(ctx, &pbs.LookupSessionRequest{
+		ConnectionId: connectionId,
+		WorkerId:     workerId,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return newSess(m.controllerSessionConn, resp)
+}
+
 func (m *manager) DeleteLocalSession(sessIds []string) {
 	for _, s := range sessIds {
 		m.sessionMap.Delete(s)
@@ -71,6 +71,47 @@ func TestManager_DeleteLocalSession(t *testing.T) {
 	assert.Nil(t, manager.Get("foo"))
 }
 
+func TestManager_LookupRoutedSession(t *testing.T) {
+	ctx := context.Background()
+	mockSessionClient := pbs.NewMockSessionServiceClient()
+	var gotReq *pbs.LookupSessionRequest
+	mockSessionClient.LookupSessionFn = func(_ context.Context, req *pbs.LookupSessionRequest) (*pbs.LookupSessionResponse, error) {
+		gotReq = req
+		if req.GetConnectionId() != "sc_1" {
+			return nil, errors.New("unknown connection")
+		}
+		return &pbs.LookupSessionResponse{
+			Authorization: &targets.SessionAuthorizationData{
+				SessionId:   "s_1",
+				Certificate: createTestCert(t),
+			},
+			Version:    1,
+			Expiration: timestamppb.New(time.Now().Add(time.Hour)),
+			Status:     pbs.SESSIONSTATUS_SESSIONSTATUS_ACTIVE,
+			Endpoint:   "tcp://10.0.0.1:22",
+		}, nil
+	}
+	manager, err := NewManager(mockSessionClient)
+	require.NoError(t, err)
+
+	_, err = manager.LookupRoutedSession(ctx, "", "worker id")
+	assert.Error(t, err)
+	_, err = manager.LookupRoutedSession(ctx, "sc_1", "")
+	assert.Error(t, err)
+	_, err = manager.LookupRoutedSession(ctx, "sc_unknown", "worker id")
+	assert.Error(t, err)
+
+	s, err := manager.LookupRoutedSession(ctx, "sc_1", "worker id")
+	require.NoError(t, err)
+	assert.Equal(t, "s_1", s.GetId())
+	assert.Equal(t, "tcp://10.0.0.1:22", s.GetEndpoint())
+	assert.Equal(t, "sc_1", gotReq.GetConnectionId())
+	assert.Equal(t, "worker id", gotReq.GetWorkerId())
+	assert.Empty(t, gotReq.GetSessionId())
+	// The session's connections are tracked by the ingress worker.
+	assert.Nil(t, manager.Get("s_1"))
+}
+
 func TestManager_RequestCloseConnections(t *testing.T) {
 	ctx := context.Background()
 	mockSessionClient := pbs.NewMockSessionServiceClient()
@@ -52,7 +52,8 @@ type ConnectionCloseData struct {
 
 // Session is the local representation of a session.  After initial loading
 // the only values that will change will be the status (readable from
-// GetStatus()) and the Connections (GetLocalConnections()).
+// GetStatus()), the cancel reason (GetCancelReason()) and the Connections
+// (GetLocalConnections()).
 type Session interface {
 	// ApplyLocalConnectionStatus set's a connection's status to the one provided.
 	// If there is no connection with the provided id, an error is returned.
@@ -64,13 +65,37 @@ type Session interface {
 	// status change to the session and the connections which are present.
 	ApplyLocalStatus(st pbs.SESSIONSTATUS)
 
+	// ApplyCancelReason sets the reason given when the session was canceled,
+	// as provided by the SessionJobInfo.
+	ApplyCancelReason(reason string)
+
 	GetStatus() pbs.SESSIONSTATUS
+	// GetCancelReason returns the reason given when the session was
+	// canceled, or an empty string if none was given.
+	GetCancelReason() string
 	// GetLocalConnections returns the connections this session is handling.
 	GetLocalConnections() map[string]ConnInfo
 
 	GetTofuToken() string
 	GetConnectionLimit() int32
 	GetEndpoint() string
+	// GetHostHealthCheck returns the check used to probe the health of the
+	// session's endpoint, or an empty string if it isn't probed.
+	GetHostHealthCheck() string
+	// GetFailoverEndpoints returns the endpoints to connect to, in order, if
+	// connecting to the session's endpoint fails.
+	GetFailoverEndpoints() []string
+	// GetProbedEndpoints returns the endpoints, in host:port form, of other
+	// hosts in the session's host set whose health is probed.
+	GetProbedEndpoints() []string
+	// GetAccessWindow returns the json encoded access window of the session's
+	// target, or an empty string if it doesn't have one.
+	GetAccessWindow() string
+	// GetUploadRateLimit and GetDownloadRateLimit return the bandwidth limits,
+	// in bytes per second, of the session's target. Zero means the target
+	// doesn't limit the session.
+	GetUploadRateLimit() uint32
+	GetDownloadRateLimit() uint32
 	GetHostKeys() ([]crypto.Signer, error)
 	GetCredentials() []*pbs.Credential
 	GetExpiration() time.Time
@@ -121,17 +146,23 @@ type Session interface {
 	// authorized.  The local connection's status is updated with the result of the
 	// call.
 	RequestConnectConnection(ctx context.Context, info *pbs.ConnectConnectionRequest) error
+
+	// RequestAuthorizeShadow sends an AuthorizeShadow request to the
+	// controller to check that the observer presenting the shadow token and
+	// the certificate, in DER, can shadow the terminal of this session.
+	RequestAuthorizeShadow(ctx context.Context, workerId, shadowToken string, certificate []byte) (*pbs.AuthorizeShadowResponse, error)
 }
 
 type sess struct {
-	lock        sync.RWMutex
-	client      pbs.SessionServiceClient
-	connInfoMap map[string]*ConnInfo
-	resp        *pbs.LookupSessionResponse
-	status      pbs.SESSIONSTATUS
-	cert        *x509.Certificate
-	sessionId   string
-	tofuToken   string
+	lock         sync.RWMutex
+	client       pbs.SessionServiceClient
+	connInfoMap  map[string]*ConnInfo
+	resp         *pbs.LookupSessionResponse
+	status       pbs.SESSIONSTATUS
+	cert         *x509.Certificate
+	sessionId    string
+	tofuToken    string
+	cancelReason string
 }
 
 func newSess(client pbs.SessionServiceClient, resp *pbs.LookupSessionResponse) (*sess, error) {
@@ -191,6 +222,12 @@ func (s *sess) ApplyLocalStatus(st pbs.SESSIONSTATUS) {
 	s.status = st
 }
 
+func (s *sess) ApplyCancelReason(reason string) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.cancelReason = reason
+}
+
 func (s *sess) ApplyLocalTofuToken(tt string) {
 	s.lock.Lock()
 	defer s.lock.Unlock()
@@ -239,6 +276,48 @@ func (s *sess) GetEndpoint() string {
 	return s.resp.GetEndpoint()
 }
 
+func (s *sess) GetHostHealthCheck() string {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+	return s.resp.GetHostHealthCheck()
+}
+
+func (s *sess) GetFailoverEndpoints() []string {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+	return s.resp.GetFailoverEndpoints()
+}
+
+func (s *sess) GetProbedEndpoints() []string {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+	return s.resp.GetProbedEndpoints()
+}
+
+func (s *sess) GetCancelReason() string {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+	return s.cancelReason
+}
+
+func (s *sess) GetAccessWindow() string {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+	return s.resp.GetAccessWindow()
+}
+
+func (s *sess) GetUploadRateLimit() uint32 {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+	return s.resp.GetUploadRateLimit()
+}
+
+func (s *sess) GetDownloadRateLimit() uint32 {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+	return s.resp.GetDownloadRateLimit()
+}
+
 func (s *sess) GetHostKeys() ([]crypto.Signer, error) {
 	s.lock.RLock()
 	defer s.lock.RUnlock()
@@ -358,6 +437,27 @@ func (s *sess) RequestConnectConnection(ctx context.Context, info *pbs.ConnectCo
 	return nil
 }
 
+func (s *sess) RequestAuthorizeShadow(ctx context.Context, workerId, shadowToken string, certificate []byte) (*pbs.AuthorizeShadowResponse, error) {
+	switch {
+	case workerId == "":
+		return nil, errors.New("worker id is empty")
+	case shadowToken == "":
+		return nil, errors.New("shadow token is empty")
+	case len(certificate) == 0:
+		return nil, errors.New("certificate is empty")
+	}
+	resp, err := s.client.AuthorizeShadow(ctx, &pbs.AuthorizeShadowRequest{
+		SessionId:   s.GetId(),
+		ShadowToken: shadowToken,
+		WorkerId:    workerId,
+		Certificate: certificate,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error authorizing shadow: %w", err)
+	}
+	return resp, nil
+}
+
 // CancelOpenLocalConnections closes the local connections in this session
 // based on the connection's state by calling the connections context cancel
 // function.
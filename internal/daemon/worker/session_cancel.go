@@ -0,0 +1,50 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package worker
+
+import (
+	"context"
+	"unicode/utf8"
+
+	proxyHandlers "github.com/hashicorp/boundary/internal/daemon/worker/proxy"
+	"github.com/hashicorp/boundary/internal/daemon/worker/session"
+	pbs "github.com/hashicorp/boundary/internal/gen/controller/servers/services"
+	"nhooyr.io/websocket"
+)
+
+// maxCloseReasonLength is the maximum length in bytes of the reason of a
+// websocket close frame.
+const maxCloseReasonLength = 123
+
+// sessionCanceledCloser wraps the cancel function of a client connection so
+// that, if the connection is canceled because its session was canceled, the
+// client is told so along with the reason given for the cancellation before
+// the connection is torn down.
+//
+// The returned function is called with the session's lock held, so the
+// session is only inspected once the function has returned.
+func sessionCanceledCloser(conn *websocket.Conn, sess session.Session, cancel context.CancelFunc) context.CancelFunc {
+	return func() {
+		go func() {
+			defer cancel()
+			if sess.GetStatus() != pbs.SESSIONSTATUS_SESSIONSTATUS_CANCELING {
+				return
+			}
+			_ = conn.Close(proxyHandlers.WebsocketStatusSessionCanceled, truncateCloseReason(sess.GetCancelReason()))
+		}()
+	}
+}
+
+// truncateCloseReason truncates the reason to fit in a websocket close frame
+// without splitting a multi-byte character.
+func truncateCloseReason(reason string) string {
+	if len(reason) <= maxCloseReasonLength {
+		return reason
+	}
+	reason = reason[:maxCloseReasonLength]
+	for !utf8.ValidString(reason) {
+		reason = reason[:len(reason)-1]
+	}
+	return reason
+}
@@ -0,0 +1,53 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package worker
+
+import (
+	"strings"
+	"testing"
+	"unicode/utf8"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTruncateCloseReason(t *testing.T) {
+	tests := []struct {
+		name   string
+		reason string
+		want   string
+	}{
+		{
+			name:   "empty",
+			reason: "",
+			want:   "",
+		},
+		{
+			name:   "short",
+			reason: "maintenance window",
+			want:   "maintenance window",
+		},
+		{
+			name:   "exact",
+			reason: strings.Repeat("a", maxCloseReasonLength),
+			want:   strings.Repeat("a", maxCloseReasonLength),
+		},
+		{
+			name:   "long",
+			reason: strings.Repeat("a", maxCloseReasonLength+10),
+			want:   strings.Repeat("a", maxCloseReasonLength),
+		},
+		{
+			name:   "multi-byte boundary",
+			reason: strings.Repeat("a", maxCloseReasonLength-1) + "é",
+			want:   strings.Repeat("a", maxCloseReasonLength-1),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := truncateCloseReason(tt.reason)
+			assert.Equal(t, tt.want, got)
+			assert.True(t, utf8.ValidString(got))
+		})
+	}
+}
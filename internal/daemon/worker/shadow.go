@@ -0,0 +1,138 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package worker
+
+import (
+	"context"
+	stderrors "errors"
+	"io"
+	"net"
+
+	"github.com/hashicorp/boundary/internal/bsr"
+	proxyHandlers "github.com/hashicorp/boundary/internal/daemon/worker/proxy"
+	"github.com/hashicorp/boundary/internal/daemon/worker/session"
+	"github.com/hashicorp/boundary/internal/event"
+	"github.com/hashicorp/boundary/sdk/pbs/proxy"
+	"github.com/hashicorp/boundary/sdk/wspb"
+	"nhooyr.io/websocket"
+)
+
+// shadowWriter adapts the websocket connection of an observer to the
+// storage.Writer expected by the bsr.ChunkEncoder.
+type shadowWriter struct {
+	net.Conn
+}
+
+// WriteAndClose writes the bytes to the connection. The connection is left
+// open since it is closed by the handler once the shadow ends.
+func (w *shadowWriter) WriteAndClose(b []byte) (int, error) {
+	return w.Write(b)
+}
+
+// handleShadow streams the terminal of the session to an observer after the
+// controller has authorized the observer's shadow token and the certificate,
+// in DER, the observer connected with. The terminal is only available if the
+// protocol handler proxying the session registered a shadow source for it. If
+// the observer is allowed to send input, what it sends is forwarded to the
+// terminal, otherwise it is discarded.
+func (w *Worker) handleShadow(ctx context.Context, connCtx context.Context, conn *websocket.Conn, sess session.Session, handshake *proxy.ClientHandshake, peerCert []byte) {
+	const op = "worker.(Worker).handleShadow"
+	sessionId := sess.GetId()
+
+	if w.LastStatusSuccess() == nil || w.LastStatusSuccess().WorkerId == "" {
+		event.WriteError(ctx, op, stderrors.New("worker id is empty"))
+		if err := conn.Close(websocket.StatusInternalError, "worker id is empty"); err != nil {
+			event.WriteError(ctx, op, err, event.WithInfoMsg("error closing client connection"))
+		}
+		return
+	}
+	workerId := w.LastStatusSuccess().WorkerId
+
+	asResp, err := sess.RequestAuthorizeShadow(ctx, workerId, handshake.GetShadowToken(), peerCert)
+	if err != nil {
+		event.WriteError(ctx, op, err, event.WithInfoMsg("unable to authorize shadow", "session_id", sessionId))
+		if err = conn.Close(websocket.StatusPolicyViolation, "unable to authorize shadow"); err != nil {
+			event.WriteError(ctx, op, err, event.WithInfoMsg("error closing client connection"))
+		}
+		return
+	}
+
+	source, err := proxyHandlers.LookupShadowSource(sessionId)
+	if err != nil {
+		event.WriteError(ctx, op, err, event.WithInfoMsg("session cannot be shadowed on this worker", "session_id", sessionId))
+		if err = conn.Close(websocket.StatusUnsupportedData, "session cannot be shadowed on this worker"); err != nil {
+			event.WriteError(ctx, op, err, event.WithInfoMsg("error closing client connection"))
+		}
+		return
+	}
+	var input io.Writer = io.Discard
+	if asResp.GetWithInput() {
+		if input, err = source.InputWriter(); err != nil {
+			event.WriteError(ctx, op, err, event.WithInfoMsg("session does not accept input from observers", "session_id", sessionId))
+			if err = conn.Close(websocket.StatusUnsupportedData, "session does not accept input from observers"); err != nil {
+				event.WriteError(ctx, op, err, event.WithInfoMsg("error closing client connection"))
+			}
+			return
+		}
+	}
+	observer, err := source.Observe()
+	if err != nil {
+		event.WriteError(ctx, op, err, event.WithInfoMsg("unable to observe session", "session_id", sessionId))
+		if err = conn.Close(websocket.StatusInternalError, "unable to observe session"); err != nil {
+			event.WriteError(ctx, op, err, event.WithInfoMsg("error closing client connection"))
+		}
+		return
+	}
+	defer observer.Close()
+
+	// The shadow never outlives the grant, which the controller caps at the
+	// expiration of the session.
+	shadowCtx, shadowCancel := context.WithDeadline(connCtx, asResp.GetExpiration().AsTime())
+	defer shadowCancel()
+
+	handshakeResult := &proxy.HandshakeResult{
+		Expiration: asResp.GetExpiration(),
+	}
+	if err := wspb.Write(shadowCtx, conn, handshakeResult); err != nil {
+		event.WriteError(ctx, op, err, event.WithInfoMsg("error sending handshake result to client"))
+		if err = conn.Close(websocket.StatusProtocolError, "unable to send handshake result"); err != nil {
+			event.WriteError(ctx, op, err, event.WithInfoMsg("error closing client connection"))
+		}
+		return
+	}
+	event.WriteSysEvent(ctx, op, "session shadow started", "session_id", sessionId, "user_id", asResp.GetUserId(), "with_input", asResp.GetWithInput())
+	defer event.WriteSysEvent(ctx, op, "session shadow ended", "session_id", sessionId, "user_id", asResp.GetUserId())
+
+	netConn := websocket.NetConn(shadowCtx, conn, websocket.MessageBinary)
+	defer netConn.Close()
+	enc, err := bsr.NewChunkEncoder(shadowCtx, &shadowWriter{Conn: netConn}, bsr.NoCompression, bsr.NoEncryption)
+	if err != nil {
+		event.WriteError(ctx, op, err, event.WithInfoMsg("unable to create chunk encoder"))
+		return
+	}
+
+	go func() {
+		defer shadowCancel()
+		if _, err := io.Copy(input, netConn); err != nil && !stderrors.Is(err, io.EOF) && shadowCtx.Err() == nil {
+			event.WriteError(ctx, op, err, event.WithInfoMsg("error reading from observer", "session_id", sessionId))
+		}
+	}()
+
+	for {
+		select {
+		case <-shadowCtx.Done():
+			return
+		case c, ok := <-observer.Chunks():
+			if !ok {
+				return
+			}
+			if _, err := enc.Encode(shadowCtx, c); err != nil {
+				if shadowCtx.Err() == nil {
+					event.WriteError(ctx, op, err, event.WithInfoMsg("error sending chunk to observer", "session_id", sessionId))
+				}
+				return
+			}
+		}
+	}
+}
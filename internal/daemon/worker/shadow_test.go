@@ -0,0 +1,153 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package worker
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/boundary/internal/bsr"
+	"github.com/hashicorp/boundary/internal/bsr/ssh"
+	"github.com/hashicorp/boundary/internal/cmd/base"
+	proxyHandlers "github.com/hashicorp/boundary/internal/daemon/worker/proxy"
+	"github.com/hashicorp/boundary/internal/daemon/worker/session"
+	pbs "github.com/hashicorp/boundary/internal/gen/controller/servers/services"
+	"github.com/hashicorp/boundary/sdk/pbs/controller/api/resources/targets"
+	"github.com/hashicorp/boundary/sdk/pbs/proxy"
+	"github.com/hashicorp/boundary/sdk/wspb"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"nhooyr.io/websocket"
+)
+
+// TestShadow registers a shadow source for a session the way a protocol
+// handler terminating its ssh connection does, and checks that an observer
+// sees what is published to it and that its input reaches the terminal.
+func TestShadow(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+
+	cert, _, _ := createTestCert(t)
+	observerCert := []byte("observer certificate")
+	client := pbs.NewMockSessionServiceClient()
+	client.LookupSessionFn = func(_ context.Context, req *pbs.LookupSessionRequest) (*pbs.LookupSessionResponse, error) {
+		return &pbs.LookupSessionResponse{
+			Authorization: &targets.SessionAuthorizationData{SessionId: "s_shadow", Certificate: cert},
+			Status:        pbs.SESSIONSTATUS_SESSIONSTATUS_ACTIVE,
+			Expiration:    timestamppb.New(time.Now().Add(time.Hour)),
+			Endpoint:      "ssh://127.0.0.1:22",
+		}, nil
+	}
+	client.AuthorizeShadowFn = func(_ context.Context, req *pbs.AuthorizeShadowRequest) (*pbs.AuthorizeShadowResponse, error) {
+		if req.GetShadowToken() != "token" || string(req.GetCertificate()) != string(observerCert) {
+			return nil, status.Error(codes.PermissionDenied, "Unknown or expired shadow token.")
+		}
+		return &pbs.AuthorizeShadowResponse{
+			UserId:     "u_observer",
+			WithInput:  true,
+			Expiration: timestamppb.New(time.Now().Add(time.Hour)),
+		}, nil
+	}
+	manager, err := session.NewManager(client)
+	require.NoError(t, err)
+	sess, err := manager.LoadLocalSession(ctx, "s_shadow", "w_1")
+	require.NoError(t, err)
+	w := &Worker{conf: &Config{Server: &base.Server{}}, sessionManager: manager, lastStatusSuccess: new(atomic.Value)}
+	w.lastStatusSuccess.Store(&LastStatusInformation{StatusResponse: &pbs.StatusResponse{WorkerId: "w_1"}})
+
+	srv := httptest.NewServer(http.HandlerFunc(func(wr http.ResponseWriter, r *http.Request) {
+		conn, err := websocket.Accept(wr, r, nil)
+		if err != nil {
+			return
+		}
+		var handshake proxy.ClientHandshake
+		if err := wspb.Read(r.Context(), conn, &handshake); err != nil {
+			return
+		}
+		w.handleShadow(r.Context(), r.Context(), conn, sess, &handshake, observerCert)
+	}))
+	t.Cleanup(srv.Close)
+	observe := func(t *testing.T, token string) (*websocket.Conn, error) {
+		t.Helper()
+		conn, _, err := websocket.Dial(ctx, "ws"+strings.TrimPrefix(srv.URL, "http"), nil)
+		require.NoError(t, err)
+		require.NoError(t, wspb.Write(ctx, conn, &proxy.ClientHandshake{
+			Command:     proxy.HANDSHAKECOMMAND_HANDSHAKECOMMAND_SHADOW,
+			ShadowToken: token,
+		}))
+		var result proxy.HandshakeResult
+		return conn, wspb.Read(ctx, conn, &result)
+	}
+
+	t.Run("unknown-token", func(t *testing.T) {
+		_, err := observe(t, "not-a-token")
+		var closeErr websocket.CloseError
+		require.ErrorAs(t, err, &closeErr)
+		assert.Equal(t, websocket.StatusPolicyViolation, closeErr.Code)
+	})
+
+	// Sessions proxied by a handler that doesn't terminate ssh have no
+	// source, since their traffic is encrypted end to end.
+	t.Run("no-source", func(t *testing.T) {
+		_, err := observe(t, "token")
+		var closeErr websocket.CloseError
+		require.ErrorAs(t, err, &closeErr)
+		assert.Equal(t, websocket.StatusUnsupportedData, closeErr.Code)
+	})
+
+	t.Run("observe", func(t *testing.T) {
+		// The handler writes the input of observers to the session channel.
+		terminalIn, terminalInW := io.Pipe()
+		t.Cleanup(func() { _ = terminalIn.Close() })
+		source, err := proxyHandlers.RegisterShadowSource(sess.GetId(), terminalInW)
+		require.NoError(t, err)
+
+		conn, err := observe(t, "token")
+		require.NoError(t, err)
+		observerConn := websocket.NetConn(ctx, conn, websocket.MessageBinary)
+		dec, err := bsr.NewChunkDecoder(ctx, observerConn)
+		require.NoError(t, err)
+		require.Eventually(t, source.Observed, time.Second, 10*time.Millisecond)
+
+		// What is published to the source is streamed to the observer.
+		for _, want := range []struct {
+			d    bsr.Direction
+			data string
+		}{{bsr.Inbound, "ls"}, {bsr.Outbound, "file.txt"}} {
+			c, err := ssh.NewDataChunk(ctx, want.d, bsr.NewTimestamp(time.Now()), []byte(want.data))
+			require.NoError(t, err)
+			source.Publish(c)
+			got, err := dec.Decode(ctx)
+			require.NoError(t, err)
+			dc, ok := got.(*ssh.DataChunk)
+			require.True(t, ok)
+			assert.Equal(t, want.d, dc.GetDirection())
+			assert.Equal(t, want.data, string(dc.Data))
+		}
+
+		// What the observer sends reaches the terminal.
+		_, err = observerConn.Write([]byte("pwd"))
+		require.NoError(t, err)
+		buf := make([]byte, 3)
+		_, err = io.ReadFull(terminalIn, buf)
+		require.NoError(t, err)
+		assert.Equal(t, "pwd", string(buf))
+
+		// The shadow ends with the terminal.
+		source.Close()
+		_, err = dec.Decode(ctx)
+		assert.Error(t, err)
+		_, err = proxyHandlers.LookupShadowSource(sess.GetId())
+		assert.ErrorIs(t, err, proxyHandlers.ErrShadowSourceNotFound)
+	})
+}
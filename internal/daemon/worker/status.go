@@ -205,6 +205,7 @@ func (w *Worker) sendWorkerStatus(cancelCtx context.Context, sessionManager sess
 	}
 	versionInfo := version.Get()
 	connectionState := w.downstreamConnManager.Connected()
+	statusStart := time.Now()
 	result, err := client.Status(statusCtx, &pbs.StatusRequest{
 		Jobs: activeJobs,
 		WorkerStatus: &pb.ServerWorkerStatus{
@@ -216,11 +217,13 @@ func (w *Worker) sendWorkerStatus(cancelCtx context.Context, sessionManager sess
 			ReleaseVersion:    versionInfo.FullVersionNumber(false),
 			OperationalState:  w.operationalState.Load().(server.OperationalState).String(),
 			LocalStorageState: w.localStorageState.Load().(server.LocalStorageState).String(),
+			StatusRttMs:       w.statusRttMs.Load(),
 		},
 		ConnectedWorkerKeyIdentifiers:         connectionState.AllKeyIds(),
 		ConnectedUnmappedWorkerKeyIdentifiers: connectionState.UnmappedKeyIds(),
 		ConnectedWorkerPublicIds:              connectionState.WorkerIds(),
 		UpdateTags:                            w.updateTags.Load(),
+		HostEndpointHealth:                    w.hostHealth.report(),
 	})
 	if err != nil {
 		event.WriteError(cancelCtx, op, err, event.WithInfoMsg("error making status request to controller"))
@@ -289,6 +292,9 @@ func (w *Worker) sendWorkerStatus(cancelCtx context.Context, sessionManager sess
 	}
 
 	w.updateTags.Store(false)
+	w.statusRttMs.Store(uint32(time.Since(statusStart).Milliseconds()))
+	w.controllerDrain.Store(result.GetDrain())
+	w.updateDrainState()
 
 	if authorized := result.GetAuthorizedDownstreamWorkers(); authorized != nil {
 		connectionState.DisconnectMissingWorkers(authorized.GetWorkerPublicIds())
@@ -342,6 +348,9 @@ func (w *Worker) sendWorkerStatus(cancelCtx context.Context, sessionManager sess
 					continue
 				}
 				si.ApplyLocalStatus(sessInfo.GetStatus())
+				if reason := sessInfo.GetCancelReason(); reason != "" {
+					si.ApplyCancelReason(reason)
+				}
 
 				// Update connection state if there are any connections in
 				// the request.
@@ -459,7 +468,7 @@ func (w *Worker) cleanupConnections(cancelCtx context.Context, ignoreSessionStat
 					BytesUp:   bytesUp,
 					BytesDown: bytesDown,
 				}
-				event.WriteSysEvent(cancelCtx, op, "terminated connection due to cancellation or expiration", "session_id", s.GetId(), "connection_id", connId)
+				event.WriteSysEvent(cancelCtx, op, "terminated connection due to cancellation or expiration", "session_id", s.GetId(), "connection_id", connId, "cancel_reason", s.GetCancelReason())
 			}
 
 			// If the session is no longer valid and all connections
@@ -97,7 +97,7 @@ type recorderManager interface {
 
 // reverseConnReceiverFactory provides a simple factory which a Worker can use to
 // create its reverseConnReceiver
-var reverseConnReceiverFactory func() reverseConnReceiver
+var reverseConnReceiverFactory = newDataPlanePool
 
 var recordingStorageFactory func(
 	ctx context.Context,
@@ -151,6 +151,19 @@ type Worker struct {
 	// localStorageState is the current state of the local storage.
 	// The local storage state is updated based on the local storage events.
 	localStorageState *atomic.Value
+	// statusRttMs is the round trip time, in milliseconds, of the last
+	// successful status request. It is reported to the controller on the next
+	// status request and used when selecting workers for sessions.
+	statusRttMs atomic.Uint32
+	// localDrain is set when the worker was told to drain locally, e.g. by a
+	// signal, and controllerDrain when the worker was set to drain through the
+	// api. The worker reports the draining state while either is set.
+	localDrain      atomic.Bool
+	controllerDrain atomic.Bool
+	// localDrainTimer cancels the sessions of the worker once the deadline of
+	// a local drain has passed. It is guarded by localDrainMu.
+	localDrainMu    sync.Mutex
+	localDrainTimer *time.Timer
 
 	storageEventListener    event.EventListener
 	upstreamConnectionState *atomic.Value
@@ -184,6 +197,14 @@ type Worker struct {
 	// downstream workers and routes to those workers
 	downstreamWorkers  *atomic.Pointer[downstreamersContainer]
 	downstreamReceiver reverseConnReceiver
+	// dataPlaneUpstreams keeps idle data plane connections open to upstream
+	// workers for multi-hop sessions.
+	dataPlaneUpstreams *dataPlaneUpstreams
+	// hostHealth probes the endpoints of sessions with a host health check.
+	hostHealth *hostHealthProber
+	// bandwidth enforces the configured bandwidth limits on proxied
+	// connections.
+	bandwidth *bandwidthManager
 
 	// Timing variables. These are atomics for SIGHUP support, and are int64
 	// because they are casted to time.Duration.
@@ -245,11 +266,14 @@ func New(ctx context.Context, conf *Config) (*Worker, error) {
 	w.lastStatusSuccess.Store((*LastStatusInformation)(nil))
 	scheme := strconv.FormatInt(time.Now().UnixNano(), 36)
 	controllerResolver := manual.NewBuilderWithScheme(scheme)
-	w.addressReceivers = []addressReceiver{&grpcResolverReceiver{controllerResolver}}
+	w.dataPlaneUpstreams = w.newDataPlaneUpstreams()
+	w.hostHealth = newHostHealthProber()
+	w.addressReceivers = []addressReceiver{&grpcResolverReceiver{controllerResolver}, w.dataPlaneUpstreams}
 
 	if conf.RawConfig.Worker == nil {
 		conf.RawConfig.Worker = new(config.Worker)
 	}
+	w.bandwidth = newBandwidthManager(conf.RawConfig.Worker.BandwidthLimit)
 
 	if w.conf.RawConfig.Worker.RecordingStoragePath == "" {
 		w.localStorageState.Store(server.NotConfiguredLocalStorageState)
@@ -574,6 +598,7 @@ func (w *Worker) Start() error {
 	}
 
 	w.operationalState.Store(server.ActiveOperationalState)
+	w.updateDrainState()
 
 	// Rather than deal with some of the potential error conditions for Add on
 	// the waitgroup vs. Done (in case a function exits immediately), we will
@@ -588,6 +613,18 @@ func (w *Worker) Start() error {
 		w.startAuthRotationTicking(w.baseContext)
 	}()
 
+	w.tickerWg.Add(1)
+	go func() {
+		defer w.tickerWg.Done()
+		w.dataPlaneUpstreams.start(w.baseContext, time.Second)
+	}()
+
+	w.tickerWg.Add(1)
+	go func() {
+		defer w.tickerWg.Done()
+		w.hostHealth.start(w.baseContext, hostHealthProbeInterval)
+	}()
+
 	if w.downstreamReceiver != nil {
 		w.tickerWg.Add(2)
 		servNameFn := func() string {
@@ -615,12 +652,95 @@ func (w *Worker) Start() error {
 		}()
 	}
 
+	if createFetchRequest && w.conf.RawConfig.Worker.Attestation != nil {
+		w.tickerWg.Add(1)
+		go func() {
+			defer w.tickerWg.Done()
+			w.startAttestation(w.baseContext, w.conf.RawConfig.Worker.Attestation, w.WorkerAuthRegistrationRequest)
+		}()
+	}
+
 	w.workerStartTime = time.Now()
 	w.started.Store(true)
 
 	return nil
 }
 
+// Drain sets the worker state to "draining". A draining worker is not given
+// new sessions by the controllers but keeps proxying its existing sessions.
+// If timeout is greater than zero, the sessions the worker is still proxying
+// once it has passed are canceled, the same as when the drain deadline of a
+// worker drained through the api passes. Draining a worker which is already
+// draining restarts the timeout.
+func (w *Worker) Drain(timeout time.Duration) {
+	const op = "worker.(Worker).Drain"
+	event.WriteSysEvent(w.baseContext, op, "worker entering drain", "timeout", timeout.String())
+	w.localDrainMu.Lock()
+	if w.localDrainTimer != nil {
+		w.localDrainTimer.Stop()
+		w.localDrainTimer = nil
+	}
+	if timeout > 0 {
+		w.localDrainTimer = time.AfterFunc(timeout, w.cancelDrainedSessions)
+	}
+	w.localDrain.Store(true)
+	w.localDrainMu.Unlock()
+	w.updateDrainState()
+}
+
+// Undrain reverses a previous call to Drain, including its timeout. The worker
+// stays draining if it has also been set to drain through the api.
+func (w *Worker) Undrain() {
+	const op = "worker.(Worker).Undrain"
+	event.WriteSysEvent(w.baseContext, op, "worker leaving drain")
+	w.localDrainMu.Lock()
+	if w.localDrainTimer != nil {
+		w.localDrainTimer.Stop()
+		w.localDrainTimer = nil
+	}
+	w.localDrain.Store(false)
+	w.localDrainMu.Unlock()
+	w.updateDrainState()
+}
+
+// cancelDrainedSessions asks the controller to cancel the sessions the worker
+// is still proxying once the timeout of a local drain has passed. The
+// connections of the canceled sessions are then closed when the worker next
+// cleans up its connections.
+func (w *Worker) cancelDrainedSessions() {
+	const op = "worker.(Worker).cancelDrainedSessions"
+	if !w.localDrain.Load() || w.sessionManager == nil {
+		return
+	}
+	event.WriteSysEvent(w.baseContext, op, "worker drain timeout reached, canceling sessions")
+	w.sessionManager.ForEachLocalSession(func(s session.Session) bool {
+		switch s.GetStatus() {
+		case pbs.SESSIONSTATUS_SESSIONSTATUS_PENDING, pbs.SESSIONSTATUS_SESSIONSTATUS_ACTIVE:
+			if err := s.RequestCancel(w.baseContext); err != nil {
+				event.WriteError(w.baseContext, op, err, event.WithInfoMsg("error canceling session on drained worker", "session_id", s.GetId()))
+			}
+		}
+		return true
+	})
+}
+
+// Draining returns whether the worker has been told to drain locally.
+func (w *Worker) Draining() bool {
+	return w.localDrain.Load()
+}
+
+// updateDrainState moves the worker between the active and draining states
+// based on whether it has been told to drain. A worker that is shutting down
+// is left alone.
+func (w *Worker) updateDrainState() {
+	switch {
+	case w.localDrain.Load() || w.controllerDrain.Load():
+		w.operationalState.CompareAndSwap(server.ActiveOperationalState, server.DrainingOperationalState)
+	default:
+		w.operationalState.CompareAndSwap(server.DrainingOperationalState, server.ActiveOperationalState)
+	}
+}
+
 // GracefulShutdownm sets the worker state to "shutdown" and will wait to return until there
 // are no longer any active connections.
 func (w *Worker) GracefulShutdown() error {
@@ -843,7 +963,12 @@ func (w *Worker) getSessionTls(sessionManager session.Manager) func(hello *tls.C
 				return errors.New(ctx, errors.InvalidParameter, op, "no peer certificates provided")
 			}
 			if subtle.ConstantTimeCompare(cs.PeerCertificates[0].Raw, sess.GetCertificate().Raw) != 1 {
-				return errors.New(ctx, errors.InvalidParameter, op, "expected peer certificate to match session certificate")
+				// Observers shadowing the session connect with a short-lived
+				// certificate issued by the session's certificate, which
+				// handleProxy only accepts for shadowing the session.
+				if cs.PeerCertificates[0].IsCA || cs.PeerCertificates[0].CheckSignatureFrom(sess.GetCertificate()) != nil {
+					return errors.New(ctx, errors.InvalidParameter, op, "expected peer certificate to match or be issued by session certificate")
+				}
 			}
 			_, err := cs.PeerCertificates[0].Verify(verifyOpts)
 			return err
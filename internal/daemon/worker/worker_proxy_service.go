@@ -69,3 +69,7 @@ func (ws *workerProxyServiceServer) ConnectConnection(ctx context.Context, req *
 func (ws *workerProxyServiceServer) CloseConnection(ctx context.Context, req *pbs.CloseConnectionRequest) (*pbs.CloseConnectionResponse, error) {
 	return pbs.NewSessionServiceClient(ws.cc).CloseConnection(ctx, req)
 }
+
+func (ws *workerProxyServiceServer) AuthorizeShadow(ctx context.Context, req *pbs.AuthorizeShadowRequest) (*pbs.AuthorizeShadowResponse, error) {
+	return pbs.NewSessionServiceClient(ws.cc).AuthorizeShadow(ctx, req)
+}
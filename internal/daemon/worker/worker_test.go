@@ -10,6 +10,7 @@ import (
 	"crypto/tls"
 	"crypto/x509"
 	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 
@@ -21,6 +22,7 @@ import (
 	"github.com/hashicorp/boundary/internal/gen/controller/servers/services"
 	"github.com/hashicorp/boundary/internal/server"
 	"github.com/hashicorp/boundary/internal/util"
+	"github.com/hashicorp/boundary/sdk/pbs/controller/api/resources/targets"
 	"github.com/hashicorp/go-hclog"
 	"github.com/hashicorp/go-secure-stdlib/configutil/v2"
 	"github.com/hashicorp/go-secure-stdlib/listenerutil"
@@ -200,6 +202,102 @@ func TestWorkerNew(t *testing.T) {
 	}
 }
 
+func TestWorker_Drain(t *testing.T) {
+	newWorker := func(state server.OperationalState) *Worker {
+		w := &Worker{
+			baseContext:      context.Background(),
+			operationalState: new(atomic.Value),
+		}
+		w.operationalState.Store(state)
+		return w
+	}
+	state := func(w *Worker) server.OperationalState {
+		return w.operationalState.Load().(server.OperationalState)
+	}
+
+	t.Run("local", func(t *testing.T) {
+		w := newWorker(server.ActiveOperationalState)
+		w.Drain(0)
+		assert.True(t, w.Draining())
+		assert.Equal(t, server.DrainingOperationalState, state(w))
+		w.Undrain()
+		assert.False(t, w.Draining())
+		assert.Equal(t, server.ActiveOperationalState, state(w))
+	})
+
+	t.Run("controller", func(t *testing.T) {
+		w := newWorker(server.ActiveOperationalState)
+		w.controllerDrain.Store(true)
+		w.updateDrainState()
+		assert.Equal(t, server.DrainingOperationalState, state(w))
+
+		// Undraining locally keeps the worker draining while the controller
+		// still has it set to drain.
+		w.Drain(0)
+		w.Undrain()
+		assert.Equal(t, server.DrainingOperationalState, state(w))
+
+		w.controllerDrain.Store(false)
+		w.updateDrainState()
+		assert.Equal(t, server.ActiveOperationalState, state(w))
+	})
+
+	t.Run("shutdown", func(t *testing.T) {
+		w := newWorker(server.ShutdownOperationalState)
+		w.Drain(0)
+		assert.Equal(t, server.ShutdownOperationalState, state(w))
+		w.Undrain()
+		assert.Equal(t, server.ShutdownOperationalState, state(w))
+	})
+
+	t.Run("timeout", func(t *testing.T) {
+		ctx := context.Background()
+		cert, _, _ := createTestCert(t)
+		canceled := make(chan string, 2)
+		client := services.NewMockSessionServiceClient()
+		client.LookupSessionFn = func(_ context.Context, req *services.LookupSessionRequest) (*services.LookupSessionResponse, error) {
+			return &services.LookupSessionResponse{
+				Authorization: &targets.SessionAuthorizationData{SessionId: req.GetSessionId(), Certificate: cert},
+				Status:        services.SESSIONSTATUS_SESSIONSTATUS_ACTIVE,
+				Expiration:    timestamppb.New(time.Now().Add(time.Hour)),
+			}, nil
+		}
+		client.CancelSessionFn = func(_ context.Context, req *services.CancelSessionRequest) (*services.CancelSessionResponse, error) {
+			canceled <- req.GetSessionId()
+			return &services.CancelSessionResponse{Status: services.SESSIONSTATUS_SESSIONSTATUS_CANCELING}, nil
+		}
+		manager, err := session.NewManager(client)
+		require.NoError(t, err)
+		sess, err := manager.LoadLocalSession(ctx, "s_1234567890", "w_1234567890")
+		require.NoError(t, err)
+
+		w := newWorker(server.ActiveOperationalState)
+		w.sessionManager = manager
+
+		// Undraining stops the timeout.
+		w.Drain(10 * time.Millisecond)
+		w.Undrain()
+		select {
+		case id := <-canceled:
+			t.Fatalf("session %s canceled after undraining", id)
+		case <-time.After(100 * time.Millisecond):
+		}
+		assert.Equal(t, services.SESSIONSTATUS_SESSIONSTATUS_ACTIVE, sess.GetStatus())
+
+		w.Drain(10 * time.Millisecond)
+		select {
+		case id := <-canceled:
+			assert.Equal(t, "s_1234567890", id)
+		case <-time.After(5 * time.Second):
+			t.Fatal("session not canceled after the drain timeout")
+		}
+		assert.Eventually(t, func() bool {
+			return sess.GetStatus() == services.SESSIONSTATUS_SESSIONSTATUS_CANCELING
+		}, 5*time.Second, 10*time.Millisecond)
+		assert.Equal(t, server.DrainingOperationalState, state(w))
+	})
+}
+
 func TestSetupWorkerAuthStorage(t *testing.T) {
 	ctx := context.Background()
 
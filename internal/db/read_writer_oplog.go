@@ -11,6 +11,7 @@ import (
 	"github.com/hashicorp/boundary/internal/errors"
 	"github.com/hashicorp/boundary/internal/oplog"
 	"github.com/hashicorp/boundary/internal/oplog/store"
+	"github.com/hashicorp/boundary/internal/requests"
 	"github.com/hashicorp/go-dbw"
 	wrapping "github.com/hashicorp/go-kms-wrapping/v2"
 	"google.golang.org/protobuf/proto"
@@ -256,7 +257,7 @@ func (rw *Db) addOplogForItems(ctx context.Context, opType OpType, opts Options,
 	entry, err := oplog.NewEntry(
 		ctx,
 		replayable.TableName(),
-		oplogArgs.metadata,
+		withActorMetadata(ctx, oplogArgs.metadata),
 		oplogArgs.wrapper,
 		ticketer,
 	)
@@ -291,7 +292,7 @@ func (rw *Db) addOplog(ctx context.Context, opType OpType, opts Options, ticket
 	entry, err := oplog.NewEntry(
 		ctx,
 		replayable.TableName(),
-		oplogArgs.metadata,
+		withActorMetadata(ctx, oplogArgs.metadata),
 		oplogArgs.wrapper,
 		ticketer,
 	)
@@ -341,7 +342,7 @@ func (rw *Db) WriteOplogEntryWith(ctx context.Context, wrapper wrapping.Wrapper,
 	entry, err := oplog.NewEntry(
 		ctx,
 		ticket.Name,
-		metadata,
+		withActorMetadata(ctx, metadata),
 		wrapper,
 		ticketer,
 	)
@@ -389,3 +390,19 @@ func (rw *Db) newOplogMessage(ctx context.Context, opType OpType, i any, opt ...
 	}
 	return &msg, nil
 }
+
+// withActorMetadata returns the metadata with the id of the user making the
+// request, when the context has one, so oplog consumers can tell who made a
+// change.
+func withActorMetadata(ctx context.Context, metadata oplog.Metadata) oplog.Metadata {
+	reqCtx, ok := requests.RequestContextFromCtx(ctx)
+	if !ok || reqCtx.UserId == "" {
+		return metadata
+	}
+	md := make(oplog.Metadata, len(metadata)+1)
+	for k, v := range metadata {
+		md[k] = v
+	}
+	md[oplog.ActorMetadataKey] = []string{reqCtx.UserId}
+	return md
+}
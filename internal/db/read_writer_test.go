@@ -21,6 +21,7 @@ import (
 	"github.com/hashicorp/boundary/internal/event"
 	"github.com/hashicorp/boundary/internal/oplog"
 	"github.com/hashicorp/boundary/internal/oplog/store"
+	"github.com/hashicorp/boundary/internal/requests"
 	"github.com/hashicorp/go-hclog"
 	wrapping "github.com/hashicorp/go-kms-wrapping/v2"
 	"github.com/hashicorp/go-secure-stdlib/base62"
@@ -3006,6 +3007,31 @@ func TestDb_WriteOplogEntryWith(t *testing.T) {
 // 	}
 // }
 
+func Test_withActorMetadata(t *testing.T) {
+	t.Parallel()
+	md := oplog.Metadata{
+		"resource-public-id": []string{"u_1234567890"},
+		"op-type":            []string{oplog.OpType_OP_TYPE_UPDATE.String()},
+	}
+	t.Run("no-request-context", func(t *testing.T) {
+		assert := assert.New(t)
+		assert.Equal(md, withActorMetadata(context.Background(), md))
+	})
+	t.Run("no-user-id", func(t *testing.T) {
+		assert := assert.New(t)
+		ctx := requests.NewRequestContext(context.Background())
+		assert.Equal(md, withActorMetadata(ctx, md))
+	})
+	t.Run("user-id", func(t *testing.T) {
+		assert := assert.New(t)
+		ctx := requests.NewRequestContext(context.Background(), requests.WithUserId("u_auth"))
+		got := withActorMetadata(ctx, md)
+		assert.Equal([]string{"u_auth"}, got[oplog.ActorMetadataKey])
+		assert.Equal(md["resource-public-id"], got["resource-public-id"])
+		assert.NotContains(md, oplog.ActorMetadataKey)
+	})
+}
+
 func TestDb_oplogMsgsForItems(t *testing.T) {
 	t.Parallel()
 
@@ -0,0 +1,28 @@
+-- Copyright (c) HashiCorp, Inc.
+-- SPDX-License-Identifier: BUSL-1.1
+
+begin;
+
+  -- drop constraint so we can add cloud_access
+  alter table credential_type_enm
+    drop constraint only_predefined_credential_types_allowed;
+
+  -- Add new constraint that only allows known types
+  -- This replaces the constraint defined in 63/01_credential_vault_ssh_cert_library
+  -- Replaced in 88/02_static_tls_client_certificate_creds
+  alter table credential_type_enm
+    add constraint only_predefined_credential_types_allowed
+      check (
+        name in (
+          'unspecified',
+          'username_password',
+          'ssh_private_key',
+          'ssh_certificate',
+          'cloud_access'
+        )
+      );
+
+  insert into credential_type_enm (name)
+   values ('cloud_access');
+
+commit;
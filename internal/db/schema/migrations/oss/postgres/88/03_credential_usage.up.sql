@@ -0,0 +1,77 @@
+-- Copyright (c) HashiCorp, Inc.
+-- SPDX-License-Identifier: BUSL-1.1
+
+begin;
+
+  -- credential_usage intentionally has no foreign keys so the record of who
+  -- received a credential survives the deletion of the session, credential,
+  -- user and target it refers to.
+  create table credential_usage (
+    session_id wt_public_id not null,
+    credential_id wt_public_id not null,
+    credential_library_id text null,
+    credential_purpose text not null
+      constraint credential_purpose_enm_fkey
+        references credential_purpose_enm (name)
+        on delete restrict
+        on update cascade,
+    user_id wt_user_id,
+    target_id wt_public_id not null,
+    project_id wt_scope_id not null,
+    create_time wt_timestamp,
+    primary key(session_id, credential_id, credential_purpose)
+  );
+  comment on table credential_usage is
+    'credential_usage is an append only table that records each time a static or dynamic credential '
+    'is issued to a session along with the user and target the session was authorized for. '
+    'It never contains the secret itself.';
+
+  create trigger default_create_time_column before insert on credential_usage
+    for each row execute procedure default_create_time();
+
+  create trigger immutable_columns before update on credential_usage
+    for each row execute procedure immutable_columns('session_id', 'credential_id', 'credential_library_id',
+                                                     'credential_purpose', 'user_id', 'target_id', 'project_id', 'create_time');
+
+  create index credential_usage_credential_id_create_time_idx
+    on credential_usage (credential_id, create_time desc);
+
+  create function insert_credential_usage_static() returns trigger
+  as $$
+  begin
+    insert into credential_usage
+          (session_id,     credential_id,            credential_purpose,     user_id, target_id, project_id)
+    select new.session_id, new.credential_static_id, new.credential_purpose, user_id, target_id, project_id
+      from session
+     where public_id = new.session_id;
+    return new;
+  end;
+  $$ language plpgsql;
+  comment on function insert_credential_usage_static is
+    'insert_credential_usage_static is an after insert trigger for the session_credential_static table.';
+
+  create trigger insert_credential_usage_static after insert on session_credential_static
+    for each row execute procedure insert_credential_usage_static();
+
+  -- Dynamic credentials are bound to a session after they have been issued
+  -- by updating the credential_id of the session_credential_dynamic row.
+  create function insert_credential_usage_dynamic() returns trigger
+  as $$
+  begin
+    insert into credential_usage
+          (session_id,     credential_id,     credential_library_id, credential_purpose,     user_id, target_id, project_id)
+    select new.session_id, new.credential_id, new.library_id,        new.credential_purpose, user_id, target_id, project_id
+      from session
+     where public_id = new.session_id;
+    return new;
+  end;
+  $$ language plpgsql;
+  comment on function insert_credential_usage_dynamic is
+    'insert_credential_usage_dynamic is an after update trigger for the session_credential_dynamic table.';
+
+  create trigger insert_credential_usage_dynamic after update of credential_id on session_credential_dynamic
+    for each row
+    when (old.credential_id is null and new.credential_id is not null)
+    execute procedure insert_credential_usage_dynamic();
+
+commit;
@@ -0,0 +1,34 @@
+-- Copyright (c) HashiCorp, Inc.
+-- SPDX-License-Identifier: BUSL-1.1
+
+begin;
+
+  create table server_worker_downstream (
+    worker_id wt_public_id not null
+      constraint server_worker_fkey
+        references server_worker (public_id)
+        on delete cascade
+        on update cascade,
+    downstream_worker_id wt_public_id not null
+      constraint server_worker_downstream_fkey
+        references server_worker (public_id)
+        on delete cascade
+        on update cascade,
+    update_time wt_timestamp,
+    primary key (worker_id, downstream_worker_id),
+    constraint worker_is_not_its_own_downstream
+      check (worker_id <> downstream_worker_id)
+  );
+  comment on table server_worker_downstream is
+    'server_worker_downstream records the downstream workers which are connected to a worker, '
+    'as last reported by that worker in its status. Rows are only written when the reported '
+    'downstream workers change, so the last_status_time of the upstream worker is used to ignore '
+    'stale connections when calculating a route between workers.';
+
+  create trigger update_time_column before update on server_worker_downstream
+    for each row execute procedure update_time_column();
+
+  create index server_worker_downstream_downstream_worker_id_idx
+    on server_worker_downstream (downstream_worker_id);
+
+commit;
@@ -0,0 +1,140 @@
+-- Copyright (c) HashiCorp, Inc.
+-- SPDX-License-Identifier: BUSL-1.1
+
+begin;
+
+  create table target_worker_selection_strategy_enm (
+    name text primary key
+      constraint only_predefined_worker_selection_strategies_allowed
+        check (
+          name in (
+            'random',
+            'least_connections',
+            'weighted'
+          )
+        )
+  );
+  comment on table target_worker_selection_strategy_enm is
+    'target_worker_selection_strategy_enm is an enumeration table for the strategies used to order the workers '
+    'that can handle a session for a target.';
+
+  insert into target_worker_selection_strategy_enm (name) values
+    ('random'),
+    ('least_connections'),
+    ('weighted');
+
+  -- Value can be null in all cases, in which case the random strategy is used.
+  alter table target_tcp
+    add column worker_selection_strategy text
+      constraint target_worker_selection_strategy_enm_fkey
+        references target_worker_selection_strategy_enm (name)
+        on delete restrict
+        on update cascade;
+  alter table target_ssh
+    add column worker_selection_strategy text
+      constraint target_worker_selection_strategy_enm_fkey
+        references target_worker_selection_strategy_enm (name)
+        on delete restrict
+        on update cascade;
+
+  -- Replaces target_all_subtypes defined in 71/07_targets.up.sql
+  create or replace view target_all_subtypes as
+  select
+    public_id,
+    project_id,
+    name,
+    description,
+    default_port,
+    session_max_seconds,
+    session_connection_limit,
+    version,
+    create_time,
+    update_time,
+    worker_filter,
+    egress_worker_filter,
+    ingress_worker_filter,
+    default_client_port,
+    null as storage_bucket_id,
+    false as enable_session_recording,
+    'tcp' as type,
+    worker_selection_strategy
+  from target_tcp
+  union
+  select
+    public_id,
+    project_id,
+    name,
+    description,
+    default_port,
+    session_max_seconds,
+    session_connection_limit,
+    version,
+    create_time,
+    update_time,
+    worker_filter,
+    egress_worker_filter,
+    ingress_worker_filter,
+    default_client_port,
+    storage_bucket_id,
+    enable_session_recording,
+    'ssh' as type,
+    worker_selection_strategy
+  from
+    target_ssh;
+
+  -- The round trip time of the last status request a worker made to a
+  -- controller, used when selecting workers for a session.
+  alter table server_worker
+    add column status_rtt_ms bigint not null default 0
+      constraint status_rtt_ms_must_not_be_negative
+        check (status_rtt_ms >= 0);
+
+  drop view server_worker_aggregate;
+  -- Replaces view created in 86/01_server_worker_local_storage_state.up.sql to add the worker status round trip time
+  create view server_worker_aggregate as
+  with worker_config_tags(worker_id, source, tags) as (
+    select
+      ct.worker_id,
+      ct.source,
+      -- keys and tags can be any lowercase printable character so use uppercase characters as delimitors.
+      string_agg(distinct concat_ws('Y', ct.key, ct.value), 'Z') as tags
+    from server_worker_tag ct
+    group by ct.worker_id, ct.source
+  ),
+  connection_count (worker_id, count) as (
+   select
+     worker_id,
+     count(1) as count
+   from session_connection
+   where closed_reason is null
+   group by worker_id
+  )
+  select
+    w.public_id,
+    w.scope_id,
+    w.description,
+    w.name,
+    w.address,
+    w.create_time,
+    w.update_time,
+    w.version,
+    w.last_status_time,
+    w.type,
+    w.release_version,
+    w.operational_state,
+    w.local_storage_state,
+    w.status_rtt_ms,
+    cc.count as active_connection_count,
+    wt.tags as api_tags,
+    ct.tags as worker_config_tags
+  from server_worker w
+   left join worker_config_tags wt on
+      w.public_id = wt.worker_id and wt.source = 'api'
+   left join worker_config_tags ct on
+      w.public_id = ct.worker_id and ct.source = 'configuration'
+   left join connection_count as cc on
+      w.public_id = cc.worker_id;
+  comment on view server_worker_aggregate is
+    'server_worker_aggregate contains the worker resource with its worker provided config values and its configuration and api provided tags.';
+
+commit;
@@ -0,0 +1,160 @@
+-- Copyright (c) HashiCorp, Inc.
+-- SPDX-License-Identifier: BUSL-1.1
+
+begin;
+
+  create table target_host_health_check_enm (
+    name text primary key
+      constraint only_predefined_host_health_checks_allowed
+        check (
+          name in (
+            'tcp',
+            'tls'
+          )
+        )
+  );
+  comment on table target_host_health_check_enm is
+    'target_host_health_check_enm is an enumeration table for the probes workers use to check the health of the hosts of a target.';
+
+  insert into target_host_health_check_enm (name) values
+    ('tcp'),
+    ('tls');
+
+  -- Value can be null in all cases, in which case hosts are not health checked.
+  alter table target_tcp
+    add column host_health_check text
+      constraint target_host_health_check_enm_fkey
+        references target_host_health_check_enm (name)
+        on delete restrict
+        on update cascade;
+  alter table target_ssh
+    add column host_health_check text
+      constraint target_host_health_check_enm_fkey
+        references target_host_health_check_enm (name)
+        on delete restrict
+        on update cascade;
+  alter table session
+    add column host_health_check text
+      constraint target_host_health_check_enm_fkey
+        references target_host_health_check_enm (name)
+        on delete restrict
+        on update cascade;
+
+  -- Replaces target_all_subtypes defined in 88/05_target_worker_selection_strategy.up.sql
+  create or replace view target_all_subtypes as
+  select
+    public_id,
+    project_id,
+    name,
+    description,
+    default_port,
+    session_max_seconds,
+    session_connection_limit,
+    version,
+    create_time,
+    update_time,
+    worker_filter,
+    egress_worker_filter,
+    ingress_worker_filter,
+    default_client_port,
+    null as storage_bucket_id,
+    false as enable_session_recording,
+    'tcp' as type,
+    worker_selection_strategy,
+    host_health_check
+  from target_tcp
+  union
+  select
+    public_id,
+    project_id,
+    name,
+    description,
+    default_port,
+    session_max_seconds,
+    session_connection_limit,
+    version,
+    create_time,
+    update_time,
+    worker_filter,
+    egress_worker_filter,
+    ingress_worker_filter,
+    default_client_port,
+    storage_bucket_id,
+    enable_session_recording,
+    'ssh' as type,
+    worker_selection_strategy,
+    host_health_check
+  from
+    target_ssh;
+
+  -- session_failover_endpoint holds the endpoints of other hosts in the host
+  -- set of a session which a worker can connect to if the session's endpoint
+  -- is unreachable.
+  create table session_failover_endpoint (
+    session_id wt_public_id not null
+      constraint session_fkey
+        references session (public_id)
+        on delete cascade
+        on update cascade,
+    endpoint text not null
+      constraint endpoint_must_not_be_empty
+        check (length(trim(endpoint)) > 0),
+    priority int not null
+      constraint priority_must_not_be_negative
+        check (priority >= 0),
+    primary key (session_id, endpoint),
+    constraint session_failover_endpoint_session_id_priority_uq
+      unique (session_id, priority)
+  );
+  comment on table session_failover_endpoint is
+    'session_failover_endpoint holds the ordered endpoints a worker can fall back to when connecting to the endpoint of a session fails.';
+
+  create trigger immutable_columns before update on session_failover_endpoint
+    for each row execute function immutable_columns('session_id', 'endpoint', 'priority');
+
+  -- session_probed_endpoint holds the endpoints, in host:port form, of the
+  -- hosts in the host set of a session which the worker handling the session
+  -- probes the health of.
+  create table session_probed_endpoint (
+    session_id wt_public_id not null
+      constraint session_fkey
+        references session (public_id)
+        on delete cascade
+        on update cascade,
+    endpoint text not null
+      constraint endpoint_must_not_be_empty
+        check (length(trim(endpoint)) > 0),
+    primary key (session_id, endpoint)
+  );
+  comment on table session_probed_endpoint is
+    'session_probed_endpoint holds the endpoints of the hosts in the host set of a session which workers probe the health of.';
+
+  create trigger immutable_columns before update on session_probed_endpoint
+    for each row execute function immutable_columns('session_id', 'endpoint');
+
+  -- host_endpoint_health holds the result of the most recent health probe of
+  -- an endpoint, in host:port form, reported by each worker probing it. A host
+  -- may be unreachable from some workers only, so each worker's view is kept.
+  create table host_endpoint_health (
+    endpoint text not null
+      constraint endpoint_must_not_be_empty
+        check (length(trim(endpoint)) > 0),
+    worker_id wt_public_id not null
+      constraint server_worker_fkey
+        references server_worker (public_id)
+        on delete cascade
+        on update cascade,
+    healthy boolean not null,
+    update_time wt_timestamp,
+    primary key (endpoint, worker_id)
+  );
+  comment on table host_endpoint_health is
+    'host_endpoint_health holds the most recent health of a host endpoint as reported by each worker probing it.';
+
+  create trigger update_time_column before update on host_endpoint_health
+    for each row execute procedure update_time_column();
+
+  create index host_endpoint_health_update_time_ix
+    on host_endpoint_health (update_time);
+
+commit;
@@ -0,0 +1,74 @@
+-- Copyright (c) HashiCorp, Inc.
+-- SPDX-License-Identifier: BUSL-1.1
+
+begin;
+
+  -- The access window is a json object with the schedules, time zone and
+  -- blackout dates of the window. It is validated by the controller. Value can
+  -- be null, in which case sessions can be authorized at any time.
+  alter table target_tcp
+    add column access_window jsonb
+      constraint access_window_must_be_object
+        check (jsonb_typeof(access_window) = 'object');
+  alter table target_ssh
+    add column access_window jsonb
+      constraint access_window_must_be_object
+        check (jsonb_typeof(access_window) = 'object');
+
+  -- The access window of the target at the time the session was authorized,
+  -- used by workers when authorizing connections.
+  alter table session
+    add column access_window jsonb
+      constraint access_window_must_be_object
+        check (jsonb_typeof(access_window) = 'object');
+
+  -- Replaces target_all_subtypes defined in 88/06_host_health_check.up.sql
+  create or replace view target_all_subtypes as
+  select
+    public_id,
+    project_id,
+    name,
+    description,
+    default_port,
+    session_max_seconds,
+    session_connection_limit,
+    version,
+    create_time,
+    update_time,
+    worker_filter,
+    egress_worker_filter,
+    ingress_worker_filter,
+    default_client_port,
+    null as storage_bucket_id,
+    false as enable_session_recording,
+    'tcp' as type,
+    worker_selection_strategy,
+    host_health_check,
+    access_window
+  from target_tcp
+  union
+  select
+    public_id,
+    project_id,
+    name,
+    description,
+    default_port,
+    session_max_seconds,
+    session_connection_limit,
+    version,
+    create_time,
+    update_time,
+    worker_filter,
+    egress_worker_filter,
+    ingress_worker_filter,
+    default_client_port,
+    storage_bucket_id,
+    enable_session_recording,
+    'ssh' as type,
+    worker_selection_strategy,
+    host_health_check,
+    access_window
+  from
+    target_ssh;
+
+commit;
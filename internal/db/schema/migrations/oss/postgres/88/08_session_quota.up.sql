@@ -0,0 +1,38 @@
+-- Copyright (c) HashiCorp, Inc.
+-- SPDX-License-Identifier: BUSL-1.1
+
+begin;
+
+  -- Session quotas limit the number of sessions a user can hold and authorize
+  -- per day. A null value means the number is not limited.
+  alter table iam_scope
+    add column max_active_sessions_per_user integer
+      constraint max_active_sessions_per_user_must_be_greater_than_0
+        check(max_active_sessions_per_user > 0),
+    add column max_daily_sessions_per_user integer
+      constraint max_daily_sessions_per_user_must_be_greater_than_0
+        check(max_daily_sessions_per_user > 0);
+
+  comment on column iam_scope.max_active_sessions_per_user is
+    'The maximum number of pending or active sessions a user can hold when authorizing sessions in the scope or its descendants.';
+  comment on column iam_scope.max_daily_sessions_per_user is
+    'The maximum number of sessions a user can authorize in any 24 hour period in the scope or its descendants.';
+
+  alter table iam_role
+    add column max_active_sessions_per_user integer
+      constraint max_active_sessions_per_user_must_be_greater_than_0
+        check(max_active_sessions_per_user > 0),
+    add column max_daily_sessions_per_user integer
+      constraint max_daily_sessions_per_user_must_be_greater_than_0
+        check(max_daily_sessions_per_user > 0);
+
+  comment on column iam_role.max_active_sessions_per_user is
+    'The maximum number of pending or active sessions a principal of the role can hold when authorizing sessions in the scopes the role grants permissions in.';
+  comment on column iam_role.max_daily_sessions_per_user is
+    'The maximum number of sessions a principal of the role can authorize in any 24 hour period in the scopes the role grants permissions in.';
+
+  -- Used to count the sessions of a user when enforcing session quotas.
+  create index session_user_id_create_time_idx
+    on session (user_id, create_time);
+
+commit;
@@ -0,0 +1,82 @@
+-- Copyright (c) HashiCorp, Inc.
+-- SPDX-License-Identifier: BUSL-1.1
+
+begin;
+
+  -- A target can require a reason and a ticket id matching a regular
+  -- expression when a session is authorized. The pattern is validated by the
+  -- controller. Value can be null, in which case no ticket id is required.
+  alter table target_tcp
+    add column session_reason_required boolean not null default false,
+    add column session_ticket_pattern text
+      constraint session_ticket_pattern_must_not_be_empty
+        check(length(trim(session_ticket_pattern)) > 0);
+  alter table target_ssh
+    add column session_reason_required boolean not null default false,
+    add column session_ticket_pattern text
+      constraint session_ticket_pattern_must_not_be_empty
+        check(length(trim(session_ticket_pattern)) > 0);
+
+  -- The reason and ticket id given when the session was authorized.
+  alter table session
+    add column reason text
+      constraint reason_must_not_be_empty
+        check(length(trim(reason)) > 0),
+    add column ticket_id text
+      constraint ticket_id_must_not_be_empty
+        check(length(trim(ticket_id)) > 0);
+
+  -- Replaces target_all_subtypes defined in 88/07_target_access_window.up.sql
+  create or replace view target_all_subtypes as
+  select
+    public_id,
+    project_id,
+    name,
+    description,
+    default_port,
+    session_max_seconds,
+    session_connection_limit,
+    version,
+    create_time,
+    update_time,
+    worker_filter,
+    egress_worker_filter,
+    ingress_worker_filter,
+    default_client_port,
+    null as storage_bucket_id,
+    false as enable_session_recording,
+    'tcp' as type,
+    worker_selection_strategy,
+    host_health_check,
+    access_window,
+    session_reason_required,
+    session_ticket_pattern
+  from target_tcp
+  union
+  select
+    public_id,
+    project_id,
+    name,
+    description,
+    default_port,
+    session_max_seconds,
+    session_connection_limit,
+    version,
+    create_time,
+    update_time,
+    worker_filter,
+    egress_worker_filter,
+    ingress_worker_filter,
+    default_client_port,
+    storage_bucket_id,
+    enable_session_recording,
+    'ssh' as type,
+    worker_selection_strategy,
+    host_health_check,
+    access_window,
+    session_reason_required,
+    session_ticket_pattern
+  from
+    target_ssh;
+
+commit;
@@ -0,0 +1,44 @@
+-- Copyright (c) HashiCorp, Inc.
+-- SPDX-License-Identifier: BUSL-1.1
+
+begin;
+
+  -- session_shadow holds the grants that allow a user to observe the terminal
+  -- of an active session. A grant is identified by the hash of the token given
+  -- to the observer, which the worker proxying the session presents to the
+  -- controller before streaming the terminal to the observer, along with the
+  -- short-lived certificate issued to the observer for the grant.
+  create table session_shadow (
+    token_hash bytea primary key
+      constraint token_hash_must_not_be_empty
+        check (length(token_hash) > 0),
+    session_id wt_public_id not null
+      constraint session_fkey
+        references session (public_id)
+        on delete cascade
+        on update cascade,
+    user_id wt_user_id
+      constraint iam_user_fkey
+        references iam_user (public_id)
+        on delete cascade
+        on update cascade,
+    with_input boolean not null default false,
+    expiration_time wt_timestamp not null,
+    certificate bytea not null
+      constraint certificate_must_not_be_empty
+        check (length(certificate) > 0),
+    create_time wt_timestamp
+  );
+  comment on table session_shadow is
+    'session_shadow holds the grants that allow a user to observe, and optionally send input to, the terminal of an active session.';
+
+  create trigger immutable_columns before update on session_shadow
+    for each row execute function immutable_columns('token_hash', 'session_id', 'user_id', 'with_input', 'expiration_time', 'certificate', 'create_time');
+
+  create trigger default_create_time_column before insert on session_shadow
+    for each row execute procedure default_create_time();
+
+  create index session_shadow_session_id_ix
+    on session_shadow (session_id);
+
+commit;
@@ -0,0 +1,13 @@
+-- Copyright (c) HashiCorp, Inc.
+-- SPDX-License-Identifier: BUSL-1.1
+
+begin;
+
+  -- The reason given when the session was canceled. It is sent to the workers
+  -- proxying the session so it can be shown to the session's user.
+  alter table session
+    add column cancel_reason text
+      constraint cancel_reason_must_not_be_empty
+        check(length(trim(cancel_reason)) > 0);
+
+commit;
@@ -0,0 +1,243 @@
+-- Copyright (c) HashiCorp, Inc.
+-- SPDX-License-Identifier: BUSL-1.1
+
+begin;
+
+  create table target_template (
+    public_id wt_public_id primary key,
+    project_id wt_scope_id not null
+      constraint iam_scope_project_fkey
+        references iam_scope_project (scope_id)
+        on delete cascade
+        on update cascade,
+    name wt_name,
+    description wt_description,
+    -- The settings below are null when the template does not manage them, in
+    -- which case they are left as they are on the targets using the template.
+    session_max_seconds int
+      constraint session_max_seconds_must_be_greater_than_0
+        check(session_max_seconds > 0),
+    session_connection_limit int
+      constraint session_connection_limit_must_be_greater_than_0_or_negative_1
+        check(session_connection_limit > 0 or session_connection_limit = -1),
+    egress_worker_filter wt_bexprfilter,
+    ingress_worker_filter wt_bexprfilter,
+    create_time wt_timestamp,
+    update_time wt_timestamp,
+    version wt_version,
+    constraint target_template_project_id_name_uq
+      unique(project_id, name),
+    constraint target_template_project_id_public_id_uq
+      unique(project_id, public_id)
+  );
+  comment on table target_template is
+    'target_template is a table where each row is a resource that represents a target template. '
+    'The settings of a template are applied to the targets using it.';
+
+  create trigger update_version_column after update on target_template
+    for each row execute procedure update_version_column();
+
+  create trigger update_time_column before update on target_template
+    for each row execute procedure update_time_column();
+
+  create trigger default_create_time_column before insert on target_template
+    for each row execute procedure default_create_time();
+
+  create trigger immutable_columns before update on target_template
+    for each row execute procedure immutable_columns('public_id', 'project_id', 'create_time');
+
+  create function insert_target_template_project_id() returns trigger
+  as $$
+  begin
+    select project_id into new.project_id
+      from target_template
+     where target_template.public_id = new.template_id;
+    return new;
+  end;
+  $$ language plpgsql;
+  comment on function insert_target_template_project_id() is
+    'insert_target_template_project_id sets the missing value for project_id, which is derived from the target_template table.';
+
+  create table target_template_host_set (
+    template_id wt_public_id not null,
+    project_id wt_public_id not null,
+    host_set_id wt_public_id not null,
+    create_time wt_timestamp,
+    primary key(project_id, template_id, host_set_id),
+    constraint target_template_fkey
+      foreign key (project_id, template_id)
+        references target_template (project_id, public_id)
+        on delete cascade
+        on update cascade,
+    constraint host_set_fkey
+      foreign key (project_id, host_set_id)
+        references host_set (project_id, public_id)
+        on delete cascade
+        on update cascade
+  );
+  comment on table target_template_host_set is
+    'target_template_host_set is a join table between the target_template and host_set tables. '
+    'A row represents a host source set on the targets using the template.';
+
+  create trigger insert_target_template_host_set before insert on target_template_host_set
+    for each row execute function insert_target_template_project_id();
+
+  create trigger default_create_time_column before insert on target_template_host_set
+    for each row execute procedure default_create_time();
+
+  create trigger immutable_columns before update on target_template_host_set
+    for each row execute procedure immutable_columns('template_id', 'project_id', 'host_set_id', 'create_time');
+
+  create table target_template_credential_library (
+    template_id wt_public_id not null,
+    project_id wt_public_id not null,
+    credential_library_id wt_public_id not null,
+    credential_purpose text not null
+      constraint credential_purpose_enm_fkey
+        references credential_purpose_enm (name)
+        on delete restrict
+        on update cascade,
+    create_time wt_timestamp,
+    primary key(project_id, template_id, credential_library_id, credential_purpose),
+    constraint target_template_fkey
+      foreign key (project_id, template_id)
+        references target_template (project_id, public_id)
+        on delete cascade
+        on update cascade,
+    constraint credential_library_fkey
+      foreign key (project_id, credential_library_id)
+        references credential_library (project_id, public_id)
+        on delete cascade
+        on update cascade
+  );
+  comment on table target_template_credential_library is
+    'target_template_credential_library is a join table between the target_template, credential_library and credential_purpose_enm tables. '
+    'A row represents a credential library set on the targets using the template for the specified purpose.';
+
+  create trigger insert_target_template_credential_library before insert on target_template_credential_library
+    for each row execute function insert_target_template_project_id();
+
+  create trigger default_create_time_column before insert on target_template_credential_library
+    for each row execute procedure default_create_time();
+
+  create trigger immutable_columns before update on target_template_credential_library
+    for each row execute procedure immutable_columns('template_id', 'project_id', 'credential_library_id', 'credential_purpose', 'create_time');
+
+  create table target_template_static_credential (
+    template_id wt_public_id not null,
+    project_id wt_public_id not null,
+    credential_static_id wt_public_id not null,
+    credential_purpose text not null
+      constraint credential_purpose_enm_fkey
+        references credential_purpose_enm (name)
+        on delete restrict
+        on update cascade,
+    create_time wt_timestamp,
+    primary key(project_id, template_id, credential_static_id, credential_purpose),
+    constraint target_template_fkey
+      foreign key (project_id, template_id)
+        references target_template (project_id, public_id)
+        on delete cascade
+        on update cascade,
+    constraint credential_static_fkey
+      foreign key (project_id, credential_static_id)
+        references credential_static (project_id, public_id)
+        on delete cascade
+        on update cascade
+  );
+  comment on table target_template_static_credential is
+    'target_template_static_credential is a join table between the target_template, credential_static and credential_purpose_enm tables. '
+    'A row represents a static credential set on the targets using the template for the specified purpose.';
+
+  create trigger insert_target_template_static_credential before insert on target_template_static_credential
+    for each row execute function insert_target_template_project_id();
+
+  create trigger default_create_time_column before insert on target_template_static_credential
+    for each row execute procedure default_create_time();
+
+  create trigger immutable_columns before update on target_template_static_credential
+    for each row execute procedure immutable_columns('template_id', 'project_id', 'credential_static_id', 'credential_purpose', 'create_time');
+
+  create view target_template_credential_source as
+    select
+      template_id,
+      credential_library_id as credential_source_id,
+      credential_purpose
+    from
+      target_template_credential_library
+    union
+    select
+      template_id,
+      credential_static_id as credential_source_id,
+      credential_purpose
+    from
+      target_template_static_credential;
+  comment on view target_template_credential_source is
+    'target_template_credential_source returns the credential libraries and static credentials of target templates.';
+
+  create table target_template_target (
+    target_id wt_public_id primary key,
+    project_id wt_public_id not null,
+    template_id wt_public_id not null,
+    create_time wt_timestamp,
+    constraint target_fkey
+      foreign key (project_id, target_id)
+        references target (project_id, public_id)
+        on delete cascade
+        on update cascade,
+    constraint target_template_fkey
+      foreign key (project_id, template_id)
+        references target_template (project_id, public_id)
+        on delete cascade
+        on update cascade
+  );
+  comment on table target_template_target is
+    'target_template_target is a join table between the target and target_template tables. '
+    'A row represents a target using a template. A target uses at most one template.';
+
+  create index target_template_target_template_id_idx
+    on target_template_target (template_id);
+
+  create trigger insert_target_template_target before insert on target_template_target
+    for each row execute function insert_project_id();
+
+  create trigger default_create_time_column before insert on target_template_target
+    for each row execute procedure default_create_time();
+
+  create trigger immutable_columns before update on target_template_target
+    for each row execute procedure immutable_columns('target_id', 'project_id', 'template_id', 'create_time');
+
+  create table target_template_override (
+    target_id wt_public_id not null
+      constraint target_template_target_fkey
+        references target_template_target (target_id)
+        on delete cascade
+        on update cascade,
+    field_name text not null
+      constraint field_name_must_be_template_field
+        check(field_name in (
+          'session_max_seconds',
+          'session_connection_limit',
+          'egress_worker_filter',
+          'ingress_worker_filter',
+          'host_source_ids',
+          'brokered_credential_source_ids',
+          'injected_application_credential_source_ids'
+        )),
+    create_time wt_timestamp,
+    primary key(target_id, field_name)
+  );
+  comment on table target_template_override is
+    'target_template_override is a table where each row is a setting of a target that is not managed by its template.';
+
+  create trigger default_create_time_column before insert on target_template_override
+    for each row execute procedure default_create_time();
+
+  create trigger immutable_columns before update on target_template_override
+    for each row execute procedure immutable_columns('target_id', 'field_name', 'create_time');
+
+  insert into oplog_ticket (name, version)
+  values
+    ('target_template', 1);
+
+commit;
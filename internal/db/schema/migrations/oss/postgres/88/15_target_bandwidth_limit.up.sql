@@ -0,0 +1,125 @@
+-- Copyright (c) HashiCorp, Inc.
+-- SPDX-License-Identifier: BUSL-1.1
+
+begin;
+
+  -- A target can limit the bandwidth, in bytes per second, of its sessions.
+  -- The limits override the session limits configured on workers. Value can
+  -- be null, in which case the limits of the worker apply.
+  alter table target_tcp
+    add column session_upload_rate_limit int
+      constraint session_upload_rate_limit_must_be_greater_than_0
+        check(session_upload_rate_limit > 0),
+    add column session_download_rate_limit int
+      constraint session_download_rate_limit_must_be_greater_than_0
+        check(session_download_rate_limit > 0);
+  alter table target_ssh
+    add column session_upload_rate_limit int
+      constraint session_upload_rate_limit_must_be_greater_than_0
+        check(session_upload_rate_limit > 0),
+    add column session_download_rate_limit int
+      constraint session_download_rate_limit_must_be_greater_than_0
+        check(session_download_rate_limit > 0);
+  alter table target_udp
+    add column session_upload_rate_limit int
+      constraint session_upload_rate_limit_must_be_greater_than_0
+        check(session_upload_rate_limit > 0),
+    add column session_download_rate_limit int
+      constraint session_download_rate_limit_must_be_greater_than_0
+        check(session_download_rate_limit > 0);
+
+  -- The bandwidth limits of the target at the time the session was
+  -- authorized, enforced by workers when proxying connections.
+  alter table session
+    add column upload_rate_limit int
+      constraint upload_rate_limit_must_be_greater_than_0
+        check(upload_rate_limit > 0),
+    add column download_rate_limit int
+      constraint download_rate_limit_must_be_greater_than_0
+        check(download_rate_limit > 0);
+
+  -- Replaces target_all_subtypes defined in 88/13_udp_targets.up.sql
+  create or replace view target_all_subtypes as
+  select
+    public_id,
+    project_id,
+    name,
+    description,
+    default_port,
+    session_max_seconds,
+    session_connection_limit,
+    version,
+    create_time,
+    update_time,
+    worker_filter,
+    egress_worker_filter,
+    ingress_worker_filter,
+    default_client_port,
+    null as storage_bucket_id,
+    false as enable_session_recording,
+    'tcp' as type,
+    worker_selection_strategy,
+    host_health_check,
+    access_window,
+    session_reason_required,
+    session_ticket_pattern,
+    session_upload_rate_limit,
+    session_download_rate_limit
+  from target_tcp
+  union
+  select
+    public_id,
+    project_id,
+    name,
+    description,
+    default_port,
+    session_max_seconds,
+    session_connection_limit,
+    version,
+    create_time,
+    update_time,
+    worker_filter,
+    egress_worker_filter,
+    ingress_worker_filter,
+    default_client_port,
+    storage_bucket_id,
+    enable_session_recording,
+    'ssh' as type,
+    worker_selection_strategy,
+    host_health_check,
+    access_window,
+    session_reason_required,
+    session_ticket_pattern,
+    session_upload_rate_limit,
+    session_download_rate_limit
+  from target_ssh
+  union
+  select
+    public_id,
+    project_id,
+    name,
+    description,
+    default_port,
+    session_max_seconds,
+    session_connection_limit,
+    version,
+    create_time,
+    update_time,
+    worker_filter,
+    egress_worker_filter,
+    ingress_worker_filter,
+    default_client_port,
+    null as storage_bucket_id,
+    false as enable_session_recording,
+    'udp' as type,
+    worker_selection_strategy,
+    host_health_check,
+    access_window,
+    session_reason_required,
+    session_ticket_pattern,
+    session_upload_rate_limit,
+    session_download_rate_limit
+  from
+    target_udp;
+
+commit;
@@ -0,0 +1,24 @@
+-- Copyright (c) HashiCorp, Inc.
+-- SPDX-License-Identifier: BUSL-1.1
+
+begin;
+
+  -- A key rotation policy rotates the keys of a scope once the newest root key
+  -- version is older than the interval. A null interval means the keys are
+  -- only rotated manually. Null rewrap and destroy values are treated as
+  -- false.
+  alter table iam_scope
+    add column key_rotation_interval_seconds integer
+      constraint key_rotation_interval_seconds_must_be_greater_than_0
+        check(key_rotation_interval_seconds > 0),
+    add column key_rotation_rewrap boolean,
+    add column key_rotation_destroy_old_versions boolean;
+
+  comment on column iam_scope.key_rotation_interval_seconds is
+    'The number of seconds after which the keys of the scope are automatically rotated.';
+  comment on column iam_scope.key_rotation_rewrap is
+    'Whether existing data keys are rewrapped with the new root key version when the keys of the scope are automatically rotated.';
+  comment on column iam_scope.key_rotation_destroy_old_versions is
+    'Whether the destruction of previous key versions is scheduled when the keys of the scope are automatically rotated.';
+
+commit;
@@ -0,0 +1,11 @@
+-- Copyright (c) HashiCorp, Inc.
+-- SPDX-License-Identifier: BUSL-1.1
+
+begin;
+
+  -- Supports listing the oplog entries of a scope in id order, starting after
+  -- the last entry a consumer has seen.
+  create index oplog_entry_scope_id_id_idx
+    on oplog_entry (scope_id, id);
+
+commit;
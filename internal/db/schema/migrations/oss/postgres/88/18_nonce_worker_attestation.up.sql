@@ -0,0 +1,24 @@
+-- Copyright (c) HashiCorp, Inc.
+-- SPDX-License-Identifier: BUSL-1.1
+
+begin;
+
+  -- The ids of the identity documents workers registered themselves with are
+  -- stored as nonces so a document can only be used once.
+  -- Replaces the constraint defined in 18/01_nonce
+  alter table nonce_type_enm
+    drop constraint only_predefined_nonce_types_allowed;
+  alter table nonce_type_enm
+    add constraint only_predefined_nonce_types_allowed
+      check (
+        name in (
+          'recovery',
+          'worker-auth',
+          'worker-attestation'
+        )
+      );
+
+  insert into nonce_type_enm (name) values
+    ('worker-attestation');
+
+commit;
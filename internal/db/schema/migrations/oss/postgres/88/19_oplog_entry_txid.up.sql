@@ -0,0 +1,25 @@
+-- Copyright (c) HashiCorp, Inc.
+-- SPDX-License-Identifier: BUSL-1.1
+
+begin;
+
+  -- Entry ids are assigned when an entry is written, not when its transaction
+  -- commits, so an entry can become visible after entries with greater ids.
+  -- Recording the id of the transaction that wrote an entry lets consumers
+  -- following the oplog list entries in transaction order, and only up to the
+  -- oldest transaction still in progress, so no entry becomes visible behind
+  -- their position. Entries written before this column was added have a null
+  -- txid and are listed first.
+  -- txid_current is used rather than pg_current_xact_id to support
+  -- postgres 12.
+  alter table oplog_entry
+    add column txid bigint;
+  alter table oplog_entry
+    alter column txid set default txid_current();
+
+  -- Replaces the index created in 88/17_oplog_entry_scope_id_idx
+  drop index oplog_entry_scope_id_id_idx;
+  create index oplog_entry_scope_id_txid_id_idx
+    on oplog_entry (scope_id, (coalesce(txid, 0)), id);
+
+commit;
@@ -0,0 +1,392 @@
+-- Copyright (c) HashiCorp, Inc.
+-- SPDX-License-Identifier: BUSL-1.1
+
+begin;
+
+  create table credential_vault_database_library (
+    public_id wt_public_id primary key,
+    store_id wt_public_id not null
+      constraint credential_vault_store_fkey
+        references credential_vault_store (public_id)
+        on delete cascade
+        on update cascade,
+    name wt_name,
+    description wt_description,
+    create_time wt_timestamp,
+    update_time wt_timestamp,
+    version wt_version,
+    mount_path text not null
+      constraint mount_path_must_not_be_empty
+        check(length(trim(mount_path)) > 0)
+      constraint mount_path_must_not_have_leading_or_trailing_slash
+        check(mount_path !~ '(^/|/$)'),
+    role text not null
+      constraint role_must_not_be_empty
+        check(length(trim(role)) > 0)
+      constraint role_must_not_contain_slash
+        check(position('/' in role) = 0),
+    credential_type text,
+    project_id wt_public_id not null,
+    constraint credential_vault_database_library_store_id_name_uq
+      unique(store_id, name),
+    constraint credential_vault_database_library_store_id_public_id_uq
+      unique(store_id, public_id),
+    constraint credential_library_fkey
+      foreign key (project_id, store_id, public_id, credential_type)
+      references credential_library (project_id, store_id, public_id, credential_type)
+      on delete cascade
+      on update cascade
+  );
+  comment on table credential_vault_database_library is
+    'credential_vault_database_library is a credential library that issues username_password '
+    'credentials for a role from a vault database secrets engine.';
+
+  create function default_database_credential_type() returns trigger
+  as $$
+  begin
+    if new.credential_type is distinct from 'username_password' then
+      new.credential_type = 'username_password';
+    end if;
+    return new;
+  end;
+  $$ language plpgsql;
+  comment on function default_database_credential_type is
+    'default_database_credential_type ensures the credential_type is set to username_password';
+
+  create trigger default_database_credential_type before insert on credential_vault_database_library
+    for each row execute procedure default_database_credential_type();
+  create trigger insert_credential_library_subtype before insert on credential_vault_database_library
+    for each row execute procedure insert_credential_library_subtype();
+  create trigger default_create_time_column before insert on credential_vault_database_library
+    for each row execute procedure default_create_time();
+  create trigger delete_credential_library_subtype after delete on credential_vault_database_library
+    for each row execute procedure delete_credential_library_subtype();
+  create trigger immutable_columns before update on credential_vault_database_library
+    for each row execute procedure immutable_columns('public_id', 'store_id', 'project_id', 'credential_type', 'create_time');
+  create trigger update_time_column before update on credential_vault_database_library
+    for each row execute procedure update_time_column();
+  create trigger update_version_column after update on credential_vault_database_library
+    for each row execute procedure update_version_column();
+  create trigger before_insert_credential_vault_library before insert on credential_vault_database_library
+    for each row execute procedure before_insert_credential_vault_library();
+  create trigger update_credential_library_table_update_time before update on credential_vault_database_library
+    for each row execute procedure update_credential_library_table_update_time();
+
+  create table credential_vault_database_library_deleted (
+    public_id wt_public_id primary key,
+    delete_time wt_timestamp not null
+  );
+  comment on table credential_vault_database_library_deleted is
+    'credential_vault_database_library_deleted holds the ID and delete_time of '
+    'every deleted Vault database credential library. '
+    'It is automatically trimmed of records older than 30 days by a job.';
+
+  create index credential_vault_database_library_deleted_delete_time_idx
+    on credential_vault_database_library_deleted (delete_time);
+
+  create trigger insert_deleted_id after delete on credential_vault_database_library
+    for each row execute function insert_deleted_id('credential_vault_database_library_deleted');
+
+  insert into oplog_ticket (name, version)
+    values
+      ('credential_vault_database_library', 1);
+
+  -- Credentials issued by a database library carry a Vault lease, so they are
+  -- stored in credential_vault_credential like generic library credentials.
+  -- Replaces the credential_vault_library_fkey constraint defined in
+  -- 10/04_vault_credential.up.sql.
+  alter table credential_vault_credential
+    drop constraint credential_vault_library_fkey,
+    add constraint credential_library_fkey
+      foreign key (library_id)
+      references credential_library (public_id)
+      on delete set null
+      on update cascade;
+
+  -- Database libraries are issued like a generic library which reads
+  -- <mount_path>/creds/<role> with a GET request.
+  -- Replaces view from 78/01_ssh_signed_certs_additional_valid_principals.up.sql
+  drop view credential_vault_library_issue_credentials;
+  create view credential_vault_library_issue_credentials as
+  with
+    password_override (library_id, username_attribute, password_attribute) as (
+      select library_id,
+        nullif(username_attribute, wt_to_sentinel('no override')),
+        nullif(password_attribute, wt_to_sentinel('no override'))
+      from credential_vault_library_username_password_mapping_override
+    ),
+    ssh_private_key_override (library_id, username_attribute, private_key_attribute, private_key_passphrase_attribute) as (
+      select library_id,
+        nullif(username_attribute, wt_to_sentinel('no override')),
+        nullif(private_key_attribute, wt_to_sentinel('no override')),
+        nullif(private_key_passphrase_attribute, wt_to_sentinel('no override'))
+      from credential_vault_library_ssh_private_key_mapping_override
+    )
+  select library.public_id    as public_id,
+    library.store_id          as store_id,
+    library.name              as name,
+    library.description       as description,
+    library.create_time       as create_time,
+    library.update_time       as update_time,
+    library.version           as version,
+    library.vault_path        as vault_path,
+    library.http_method       as http_method,
+    library.http_request_body as http_request_body,
+    library.credential_type   as credential_type,
+    null                      as key_type,
+    null                      as key_bits,
+    null                      as username,
+    null                      as ttl,
+    null                      as key_id,
+    null                      as critical_options,
+    null                      as extensions,
+    store.project_id          as project_id,
+    store.vault_address       as vault_address,
+    store.namespace           as namespace,
+    store.ca_cert             as ca_cert,
+    store.tls_server_name     as tls_server_name,
+    store.tls_skip_verify     as tls_skip_verify,
+    store.worker_filter       as worker_filter,
+    store.ct_token            as ct_token, -- encrypted
+    store.token_hmac          as token_hmac,
+    store.token_status        as token_status,
+    store.token_key_id        as token_key_id,
+    store.client_cert         as client_cert,
+    store.ct_client_key       as ct_client_key, -- encrypted
+    store.client_key_id       as client_key_id,
+    coalesce(upasso.username_attribute,sshpk.username_attribute)
+      as username_attribute,
+    upasso.password_attribute              as password_attribute,
+    sshpk.private_key_attribute            as private_key_attribute,
+    sshpk.private_key_passphrase_attribute as private_key_passphrase_attribute,
+    'generic'                              as cred_lib_type, -- used to switch on
+    null                                   as additional_valid_principals
+    from credential_vault_library library
+    join credential_vault_store_client store
+      on library.store_id = store.public_id
+    left join password_override upasso
+      on library.public_id = upasso.library_id
+    left join ssh_private_key_override sshpk
+      on library.public_id = sshpk.library_id
+  union
+  select library.public_id      as public_id,
+    library.store_id            as store_id,
+    library.name                as name,
+    library.description         as description,
+    library.create_time         as create_time,
+    library.update_time         as update_time,
+    library.version             as version,
+    library.vault_path          as vault_path,
+    null                        as http_method,
+    null                        as http_request_body,
+    library.credential_type     as credential_type,
+    library.key_type            as key_type,
+    library.key_bits            as key_bits,
+    library.username            as username,
+    library.ttl                 as ttl,
+    library.key_id              as key_id,
+    library.critical_options    as critical_options,
+    library.extensions          as extensions,
+    store.project_id            as project_id,
+    store.vault_address         as vault_address,
+    store.namespace             as namespace,
+    store.ca_cert               as ca_cert,
+    store.tls_server_name       as tls_server_name,
+    store.tls_skip_verify       as tls_skip_verify,
+    store.worker_filter         as worker_filter,
+    store.ct_token              as ct_token, -- encrypted
+    store.token_hmac            as token_hmac,
+    store.token_status          as token_status,
+    store.token_key_id          as token_key_id,
+    store.client_cert           as client_cert,
+    store.ct_client_key         as ct_client_key, -- encrypted
+    store.client_key_id         as client_key_id,
+    null                        as username_attribute,
+    null                        as password_attribute,
+    null                        as private_key_attribute,
+    null                        as private_key_passphrase_attribute,
+    'ssh-signed-cert'           as cred_lib_type, -- used to switch on
+    additional_valid_principals as additional_valid_principals
+    from credential_vault_ssh_cert_library library
+    join credential_vault_store_client store
+      on library.store_id = store.public_id
+  union
+  select library.public_id      as public_id,
+    library.store_id            as store_id,
+    library.name                as name,
+    library.description         as description,
+    library.create_time         as create_time,
+    library.update_time         as update_time,
+    library.version             as version,
+    library.mount_path || '/creds/' || library.role
+                                as vault_path,
+    'GET'                       as http_method,
+    null                        as http_request_body,
+    library.credential_type     as credential_type,
+    null                        as key_type,
+    null                        as key_bits,
+    null                        as username,
+    null                        as ttl,
+    null                        as key_id,
+    null                        as critical_options,
+    null                        as extensions,
+    store.project_id            as project_id,
+    store.vault_address         as vault_address,
+    store.namespace             as namespace,
+    store.ca_cert               as ca_cert,
+    store.tls_server_name       as tls_server_name,
+    store.tls_skip_verify       as tls_skip_verify,
+    store.worker_filter         as worker_filter,
+    store.ct_token              as ct_token, -- encrypted
+    store.token_hmac            as token_hmac,
+    store.token_status          as token_status,
+    store.token_key_id          as token_key_id,
+    store.client_cert           as client_cert,
+    store.ct_client_key         as ct_client_key, -- encrypted
+    store.client_key_id         as client_key_id,
+    null                        as username_attribute,
+    null                        as password_attribute,
+    null                        as private_key_attribute,
+    null                        as private_key_passphrase_attribute,
+    'generic'                   as cred_lib_type, -- database credentials are issued like a generic GET
+    null                        as additional_valid_principals
+    from credential_vault_database_library library
+    join credential_vault_store_client store
+      on library.store_id = store.public_id;
+  comment on view credential_vault_library_issue_credentials is
+    'credential_vault_library_issue_credentials is a view where each row contains a credential library and the credential library''s data needed to connect to Vault. '
+    'This view should only be used when issuing credentials from a Vault credential library. Each row may contain encrypted data. '
+    'This view should not be used to retrieve data which will be returned external to boundary.';
+
+  drop view whx_credential_dimension_source;
+
+  -- The whx_credential_dimension_source view shows the current values in the
+  -- operational tables of the credential dimension.
+  -- Replaces whx_credential_dimension_source defined in oss/71/07_targets.up.sql
+  create view whx_credential_dimension_source as
+    with vault_generic_library as (
+      select vcl.public_id                                        as public_id,
+             'vault generic credential library'                   as type,
+             coalesce(vcl.name,        'None')                    as name,
+             coalesce(vcl.description, 'None')                    as description,
+             vcl.vault_path                                       as vault_path,
+             vcl.http_method                                      as http_method,
+             case
+               when vcl.http_method = 'GET' then 'Not Applicable'
+               else coalesce(vcl.http_request_body::text, 'None')
+             end                                                  as http_request_body,
+             'Not Applicable'                                     as username,
+             'Not Applicable'                                     as key_type_and_bits
+        from credential_vault_library as vcl
+    ),
+    vault_ssh_cert_library as (
+      select vsccl.public_id                                      as public_id,
+             'vault ssh certificate credential library'           as type,
+             coalesce(vsccl.name,        'None')                  as name,
+             coalesce(vsccl.description, 'None')                  as description,
+             vsccl.vault_path                                     as vault_path,
+             'Not Applicable'                                     as http_method,
+             'Not Applicable'                                     as http_request_body,
+             vsccl.username                                       as username,
+             case
+               when vsccl.key_type = 'ed25519' then vsccl.key_type
+               else vsccl.key_type || '-' || vsccl.key_bits::text
+             end                                                  as key_type_and_bits
+        from credential_vault_ssh_cert_library as vsccl
+    ),
+    vault_database_library as (
+      select vdl.public_id                                        as public_id,
+             'vault database credential library'                  as type,
+             coalesce(vdl.name,        'None')                    as name,
+             coalesce(vdl.description, 'None')                    as description,
+             vdl.mount_path || '/creds/' || vdl.role              as vault_path,
+             'GET'                                                as http_method,
+             'Not Applicable'                                     as http_request_body,
+             'Not Applicable'                                     as username,
+             'Not Applicable'                                     as key_type_and_bits
+        from credential_vault_database_library as vdl
+    ),
+    final as (
+          select s.public_id                                                                     as session_id,
+                 scd.credential_purpose                                                          as credential_purpose,
+                 cl.public_id                                                                    as credential_library_id,
+                 coalesce(vcl.type,              vsccl.type, vdl.type)                           as credential_library_type,
+                 coalesce(vcl.name,              vsccl.name, vdl.name)                           as credential_library_name,
+                 coalesce(vcl.description,       vsccl.description, vdl.description)             as credential_library_description,
+                 coalesce(vcl.vault_path,        vsccl.vault_path, vdl.vault_path)               as credential_library_vault_path,
+                 coalesce(vcl.http_method,       vsccl.http_method, vdl.http_method)             as credential_library_vault_http_method,
+                 coalesce(vcl.http_request_body, vsccl.http_request_body, vdl.http_request_body) as credential_library_vault_http_request_body,
+                 coalesce(vcl.username,          vsccl.username, vdl.username)                   as credential_library_username,
+                 coalesce(vcl.key_type_and_bits, vsccl.key_type_and_bits, vdl.key_type_and_bits) as credential_library_key_type_and_bits,
+                 cs.public_id                                                                    as credential_store_id,
+                 case
+                   when vcs is null then 'None'
+                   else 'vault credential store'
+                 end                                                                             as credential_store_type,
+                 coalesce(vcs.name,              'None')                                         as credential_store_name,
+                 coalesce(vcs.description,       'None')                                         as credential_store_description,
+                 coalesce(vcs.namespace,         'None')                                         as credential_store_vault_namespace,
+                 coalesce(vcs.vault_address,     'None')                                         as credential_store_vault_address,
+                 t.public_id                                                                     as target_id,
+                 case
+                   when tt.type = 'tcp' then 'tcp target'
+                   when tt.type = 'ssh' then 'ssh target'
+                   else 'Unknown'
+                 end                                                                             as target_type,
+                 coalesce(tt.name,               'None')                                         as target_name,
+                 coalesce(tt.description,        'None')                                         as target_description,
+                 coalesce(tt.default_port,       0)                                              as target_default_port_number,
+                 tt.session_max_seconds                                                          as target_session_max_seconds,
+                 tt.session_connection_limit                                                     as target_session_connection_limit,
+                 p.public_id                                                                     as project_id,
+                 coalesce(p.name,                'None')                                         as project_name,
+                 coalesce(p.description,         'None')                                         as project_description,
+                 o.public_id                                                                     as organization_id,
+                 coalesce(o.name,                'None')                                         as organization_name,
+                 coalesce(o.description,         'None')                                         as organization_description
+            from session_credential_dynamic as scd
+            join session                as s     on scd.session_id = s.public_id
+            join credential_library     as cl    on scd.library_id = cl.public_id
+            join credential_store       as cs    on cl.store_id    = cs.public_id
+            join target                 as t     on s.target_id    = t.public_id
+            join iam_scope              as p     on p.public_id    = t.project_id and p.type = 'project'
+            join iam_scope              as o     on p.parent_id    = o.public_id  and o.type = 'org'
+       left join vault_generic_library  as vcl   on cl.public_id   = vcl.public_id
+       left join vault_ssh_cert_library as vsccl on cl.public_id   = vsccl.public_id
+       left join vault_database_library as vdl   on cl.public_id   = vdl.public_id
+       left join credential_vault_store as vcs   on cs.public_id   = vcs.public_id
+       left join target_all_subtypes    as tt    on t.public_id    = tt.public_id
+    )
+    select session_id,
+           credential_purpose,
+           credential_library_id,
+           credential_library_type,
+           credential_library_name,
+           credential_library_description,
+           credential_library_vault_path,
+           credential_library_vault_http_method,
+           credential_library_vault_http_request_body,
+           credential_library_username,
+           credential_library_key_type_and_bits,
+           credential_store_id,
+           credential_store_type,
+           credential_store_name,
+           credential_store_description,
+           credential_store_vault_namespace,
+           credential_store_vault_address,
+           target_id,
+           target_type,
+           target_name,
+           target_description,
+           target_default_port_number,
+           target_session_max_seconds,
+           target_session_connection_limit,
+           project_id,
+           project_name,
+           project_description,
+           organization_id,
+           organization_name,
+           organization_description
+      from final;
+
+commit;
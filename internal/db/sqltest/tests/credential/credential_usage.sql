@@ -0,0 +1,89 @@
+-- Copyright (c) HashiCorp, Inc.
+-- SPDX-License-Identifier: BUSL-1.1
+
+-- credential_usage tests the insert_credential_usage_static and
+-- insert_credential_usage_dynamic triggers.
+begin;
+  select plan(13);
+  select wtt_load('widgets', 'iam', 'kms', 'auth', 'hosts', 'targets', 'credentials');
+
+  insert into session
+    ( project_id,     target_id,      user_id,        auth_token_id,  certificate,  endpoint, public_id)
+  values
+    ('p____bwidget', 't_________wb', 'u_____walter', 'tok___walter', 'abc'::bytea, 'ep1',    's1____walter');
+
+  prepare select_usage as
+    select session_id::text, credential_id::text, credential_library_id, credential_purpose, user_id::text, target_id::text, project_id::text
+      from credential_usage
+     where session_id = 's1____walter'
+  order by credential_id;
+
+  select is(count(*), 0::bigint) from credential_usage where session_id = 's1____walter';
+
+  -- a static credential is recorded when it is added to the session
+  prepare insert_session_credential_static as
+    insert into session_credential_static
+      (session_id,     credential_static_id, credential_purpose)
+    values
+      ('s1____walter', 'cred____wup1',       'brokered');
+  select lives_ok('insert_session_credential_static');
+  select results_eq(
+    'select_usage',
+    $$VALUES
+      ('s1____walter', 'cred____wup1', null::text, 'brokered', 'u_____walter', 't_________wb', 'p____bwidget')$$
+  );
+
+  -- a dynamic credential is not recorded until it has been issued
+  prepare insert_session_credential_dynamic as
+    insert into session_credential_dynamic
+      (session_id,     library_id,     credential_id, credential_purpose)
+    values
+      ('s1____walter', 'vl______wvl1', null,          'brokered');
+  select lives_ok('insert_session_credential_dynamic');
+  select is(count(*), 1::bigint) from credential_usage where session_id = 's1____walter';
+
+  prepare insert_vault_credential as
+    insert into credential_vault_credential
+      (public_id,      library_id,     session_id,     token_hmac,   external_id, last_renewal_time, expiration_time,          is_renewable, status)
+    values
+      ('cvc_____wvl1', 'vl______wvl1', 's1____walter', 'hmac-value', 'lease-1',   now(),             now() + interval '1 hour', true,        'active');
+  select lives_ok('insert_vault_credential');
+
+  -- binding the issued credential to the session records its use
+  prepare issue_session_credential_dynamic as
+    update session_credential_dynamic
+       set credential_id = 'cvc_____wvl1'
+     where session_id = 's1____walter'
+       and library_id = 'vl______wvl1';
+  select lives_ok('issue_session_credential_dynamic');
+  select results_eq(
+    'select_usage',
+    $$VALUES
+      ('s1____walter', 'cred____wup1', null::text,     'brokered', 'u_____walter', 't_________wb', 'p____bwidget'),
+      ('s1____walter', 'cvc_____wvl1', 'vl______wvl1', 'brokered', 'u_____walter', 't_________wb', 'p____bwidget')$$
+  );
+
+  -- later updates of an issued credential are not recorded again
+  prepare update_session_credential_dynamic as
+    update session_credential_dynamic
+       set credential_id = credential_id
+     where session_id = 's1____walter'
+       and library_id = 'vl______wvl1';
+  select lives_ok('update_session_credential_dynamic');
+  select is(count(*), 2::bigint) from credential_usage where session_id = 's1____walter';
+
+  -- usage rows are immutable
+  prepare update_usage as
+    update credential_usage
+       set user_id = 'u______warren'
+     where session_id = 's1____walter';
+  select throws_like('update_usage', '%immutable column%');
+
+  -- usage rows outlive the session they refer to
+  prepare delete_session as
+    delete from session where public_id = 's1____walter';
+  select lives_ok('delete_session');
+  select is(count(*), 2::bigint) from credential_usage where session_id = 's1____walter';
+
+  select * from finish();
+rollback;
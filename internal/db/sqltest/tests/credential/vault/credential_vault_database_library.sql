@@ -0,0 +1,98 @@
+-- Copyright (c) HashiCorp, Inc.
+-- SPDX-License-Identifier: BUSL-1.1
+
+begin;
+  select plan(12);
+  select wtt_load('widgets', 'iam', 'kms', 'auth', 'hosts', 'targets', 'credentials');
+
+  -- validate default values
+  prepare insert_valid as
+    insert into credential_vault_database_library
+      (store_id,       public_id,      mount_path,      role)
+    values
+      ('vs_______wvs', 'vl______vdb1', 'database',      'readonly'),
+      ('vs_______wvs', 'vl______vdb2', 'team/postgres', 'dba');
+
+  prepare select_vault_database_libraries as
+    select public_id::text, store_id::text, name::text, description::text, mount_path, role, credential_type, project_id::text
+    from credential_vault_database_library
+    where public_id like 'vl______vdb%'
+    order by public_id;
+
+  prepare select_libraries as
+    select public_id::text, store_id::text, credential_type, project_id::text
+    from credential_library
+    where public_id like 'vl______vdb%'
+    order by public_id;
+
+  select lives_ok('insert_valid');
+  select results_eq(
+    'select_vault_database_libraries',
+    $$VALUES
+      ('vl______vdb1', 'vs_______wvs', null, null, 'database',      'readonly', 'username_password', 'p____bwidget'),
+      ('vl______vdb2', 'vs_______wvs', null, null, 'team/postgres', 'dba',      'username_password', 'p____bwidget')$$
+  );
+  select results_eq(
+    'select_libraries',
+    $$VALUES
+      ('vl______vdb1', 'vs_______wvs', 'username_password', 'p____bwidget'),
+      ('vl______vdb2', 'vs_______wvs', 'username_password', 'p____bwidget')$$
+  );
+
+  -- the issue credentials view reads <mount_path>/creds/<role> like a generic library
+  select results_eq(
+    $$select vault_path, http_method, credential_type, cred_lib_type
+        from credential_vault_library_issue_credentials
+       where public_id = 'vl______vdb2'$$,
+    $$VALUES ('team/postgres/creds/dba', 'GET', 'username_password', 'generic')$$
+  );
+
+  prepare insert_invalid_mount_path_empty as
+    insert into credential_vault_database_library
+      (store_id,       public_id,      mount_path, role)
+    values
+      ('vs_______wvs', 'vl______vdb3', ' ',        'readonly');
+  select throws_ok('insert_invalid_mount_path_empty', 'new row for relation "credential_vault_database_library" violates check constraint "mount_path_must_not_be_empty"');
+
+  prepare insert_invalid_mount_path_slash as
+    insert into credential_vault_database_library
+      (store_id,       public_id,      mount_path,  role)
+    values
+      ('vs_______wvs', 'vl______vdb3', 'database/', 'readonly');
+  select throws_ok('insert_invalid_mount_path_slash', 'new row for relation "credential_vault_database_library" violates check constraint "mount_path_must_not_have_leading_or_trailing_slash"');
+
+  prepare insert_invalid_role_empty as
+    insert into credential_vault_database_library
+      (store_id,       public_id,      mount_path, role)
+    values
+      ('vs_______wvs', 'vl______vdb3', 'database', '');
+  select throws_ok('insert_invalid_role_empty', 'new row for relation "credential_vault_database_library" violates check constraint "role_must_not_be_empty"');
+
+  prepare insert_invalid_role_slash as
+    insert into credential_vault_database_library
+      (store_id,       public_id,      mount_path, role)
+    values
+      ('vs_______wvs', 'vl______vdb3', 'database', 'a/b');
+  select throws_ok('insert_invalid_role_slash', 'new row for relation "credential_vault_database_library" violates check constraint "role_must_not_contain_slash"');
+
+  prepare insert_invalid_credential_type as
+    insert into credential_vault_database_library
+      (store_id,       public_id,      mount_path, role,       credential_type)
+    values
+      ('vs_______wvs', 'vl______vdb3', 'database', 'readwrite', 'ssh_certificate');
+  select lives_ok('insert_invalid_credential_type');
+  select is(credential_type, 'username_password')
+    from credential_vault_database_library
+   where public_id = 'vl______vdb3';
+
+  prepare delete_database_cred_library as
+    delete from credential_vault_database_library where public_id = 'vl______vdb1';
+  select lives_ok('delete_database_cred_library');
+  select results_eq(
+    'select_libraries',
+    $$VALUES
+      ('vl______vdb2', 'vs_______wvs', 'username_password', 'p____bwidget'),
+      ('vl______vdb3', 'vs_______wvs', 'username_password', 'p____bwidget')$$
+  );
+
+rollback;
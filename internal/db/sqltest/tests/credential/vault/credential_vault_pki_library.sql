@@ -0,0 +1,109 @@
+-- Copyright (c) HashiCorp, Inc.
+-- SPDX-License-Identifier: BUSL-1.1
+
+begin;
+  select plan(13);
+  select wtt_load('widgets', 'iam', 'kms', 'auth', 'hosts', 'targets', 'credentials');
+
+  -- validate default values
+  prepare insert_valid as
+    insert into credential_vault_pki_library
+      (store_id,       public_id,      mount_path,     role,     common_name,          alt_names)
+    values
+      ('vs_______wvs', 'vl______vpk1', 'pki',          'client', 'client.example.com', null),
+      ('vs_______wvs', 'vl______vpk2', 'team/pki_int', 'dba',    'dba.example.com',    'a.example.com,b.example.com');
+
+  prepare select_vault_pki_libraries as
+    select public_id::text, store_id::text, name::text, description::text, mount_path, role, common_name, alt_names, credential_type, project_id::text
+    from credential_vault_pki_library
+    where public_id like 'vl______vpk%'
+    order by public_id;
+
+  prepare select_libraries as
+    select public_id::text, store_id::text, credential_type, project_id::text
+    from credential_library
+    where public_id like 'vl______vpk%'
+    order by public_id;
+
+  select lives_ok('insert_valid');
+  select results_eq(
+    'select_vault_pki_libraries',
+    $$VALUES
+      ('vl______vpk1', 'vs_______wvs', null, null, 'pki',          'client', 'client.example.com', null,                          'tls_client_certificate', 'p____bwidget'),
+      ('vl______vpk2', 'vs_______wvs', null, null, 'team/pki_int', 'dba',    'dba.example.com',    'a.example.com,b.example.com', 'tls_client_certificate', 'p____bwidget')$$
+  );
+  select results_eq(
+    'select_libraries',
+    $$VALUES
+      ('vl______vpk1', 'vs_______wvs', 'tls_client_certificate', 'p____bwidget'),
+      ('vl______vpk2', 'vs_______wvs', 'tls_client_certificate', 'p____bwidget')$$
+  );
+
+  -- the issue credentials view posts the common name and alt names to
+  -- <mount_path>/issue/<role> like a generic library
+  select results_eq(
+    $$select vault_path, http_method, convert_from(http_request_body, 'UTF8')::jsonb, credential_type, cred_lib_type
+        from credential_vault_library_issue_credentials
+       where public_id in ('vl______vpk1', 'vl______vpk2')
+       order by public_id$$,
+    $$VALUES
+      ('pki/issue/client',       'POST', '{"common_name": "client.example.com"}'::jsonb,                                         'tls_client_certificate', 'generic'),
+      ('team/pki_int/issue/dba', 'POST', '{"common_name": "dba.example.com", "alt_names": "a.example.com,b.example.com"}'::jsonb, 'tls_client_certificate', 'generic')$$
+  );
+
+  prepare insert_invalid_mount_path_slash as
+    insert into credential_vault_pki_library
+      (store_id,       public_id,      mount_path, role,     common_name)
+    values
+      ('vs_______wvs', 'vl______vpk3', 'pki/',     'client', 'client.example.com');
+  select throws_ok('insert_invalid_mount_path_slash', 'new row for relation "credential_vault_pki_library" violates check constraint "mount_path_must_not_have_leading_or_trailing_slash"');
+
+  prepare insert_invalid_role_slash as
+    insert into credential_vault_pki_library
+      (store_id,       public_id,      mount_path, role,  common_name)
+    values
+      ('vs_______wvs', 'vl______vpk3', 'pki',      'a/b', 'client.example.com');
+  select throws_ok('insert_invalid_role_slash', 'new row for relation "credential_vault_pki_library" violates check constraint "role_must_not_contain_slash"');
+
+  prepare insert_invalid_common_name_empty as
+    insert into credential_vault_pki_library
+      (store_id,       public_id,      mount_path, role,     common_name)
+    values
+      ('vs_______wvs', 'vl______vpk3', 'pki',      'client', ' ');
+  select throws_ok('insert_invalid_common_name_empty', 'new row for relation "credential_vault_pki_library" violates check constraint "common_name_must_not_be_empty"');
+
+  prepare insert_invalid_common_name_null as
+    insert into credential_vault_pki_library
+      (store_id,       public_id,      mount_path, role)
+    values
+      ('vs_______wvs', 'vl______vpk3', 'pki',      'client');
+  select throws_ok('insert_invalid_common_name_null', 'null value in column "common_name" of relation "credential_vault_pki_library" violates not-null constraint');
+
+  prepare insert_invalid_alt_names_empty as
+    insert into credential_vault_pki_library
+      (store_id,       public_id,      mount_path, role,     common_name,          alt_names)
+    values
+      ('vs_______wvs', 'vl______vpk3', 'pki',      'client', 'client.example.com', '');
+  select throws_ok('insert_invalid_alt_names_empty', 'new row for relation "credential_vault_pki_library" violates check constraint "alt_names_must_not_be_empty"');
+
+  prepare insert_invalid_credential_type as
+    insert into credential_vault_pki_library
+      (store_id,       public_id,      mount_path, role,     common_name,          credential_type)
+    values
+      ('vs_______wvs', 'vl______vpk3', 'pki',      'server', 'server.example.com', 'username_password');
+  select lives_ok('insert_invalid_credential_type');
+  select is(credential_type, 'tls_client_certificate')
+    from credential_vault_pki_library
+   where public_id = 'vl______vpk3';
+
+  prepare delete_pki_cred_library as
+    delete from credential_vault_pki_library where public_id = 'vl______vpk1';
+  select lives_ok('delete_pki_cred_library');
+  select results_eq(
+    'select_libraries',
+    $$VALUES
+      ('vl______vpk2', 'vs_______wvs', 'tls_client_certificate', 'p____bwidget'),
+      ('vl______vpk3', 'vs_______wvs', 'tls_client_certificate', 'p____bwidget')$$
+  );
+
+rollback;
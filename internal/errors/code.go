@@ -67,6 +67,7 @@ const (
 	Closed                   = 134 // Closed represents an error when an operation cannot be completed because the thing being operated on is closed
 	ChecksumMismatch         = 135 // ChecksumMismatch represents an error when a checksum is mismatched
 	Paused                   = 136 // Paused represents an error when an operation cannot be completed because the thing being operated on is paused
+	SessionQuotaExceeded     = 137 // SessionQuotaExceeded represents an error when creating a session would exceed the session quota of the user
 
 	InvalidListToken Code = 136 // InvalidListToken represents an error where the provided list token is invalid
 
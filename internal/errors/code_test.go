@@ -420,6 +420,11 @@ func TestCode_Both_String_Info(t *testing.T) {
 			c:    InvalidListToken,
 			want: InvalidListToken,
 		},
+		{
+			name: "SessionQuotaExceeded",
+			c:    SessionQuotaExceeded,
+			want: SessionQuotaExceeded,
+		},
 		{
 			name: "InvalidTextRepresentation",
 			c:    InvalidTextRepresentation,
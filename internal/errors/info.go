@@ -355,4 +355,8 @@ var errorCodeInfo = map[Code]Info{
 		Message: "invalid list token",
 		Kind:    Parameter,
 	},
+	SessionQuotaExceeded: {
+		Message: "session quota exceeded",
+		Kind:    State,
+	},
 }
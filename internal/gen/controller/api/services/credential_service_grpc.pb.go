@@ -22,11 +22,12 @@ import (
 const _ = grpc.SupportPackageIsVersion7
 
 const (
-	CredentialService_GetCredential_FullMethodName    = "/controller.api.services.v1.CredentialService/GetCredential"
-	CredentialService_ListCredentials_FullMethodName  = "/controller.api.services.v1.CredentialService/ListCredentials"
-	CredentialService_CreateCredential_FullMethodName = "/controller.api.services.v1.CredentialService/CreateCredential"
-	CredentialService_UpdateCredential_FullMethodName = "/controller.api.services.v1.CredentialService/UpdateCredential"
-	CredentialService_DeleteCredential_FullMethodName = "/controller.api.services.v1.CredentialService/DeleteCredential"
+	CredentialService_GetCredential_FullMethodName       = "/controller.api.services.v1.CredentialService/GetCredential"
+	CredentialService_ListCredentials_FullMethodName     = "/controller.api.services.v1.CredentialService/ListCredentials"
+	CredentialService_CreateCredential_FullMethodName    = "/controller.api.services.v1.CredentialService/CreateCredential"
+	CredentialService_UpdateCredential_FullMethodName    = "/controller.api.services.v1.CredentialService/UpdateCredential"
+	CredentialService_DeleteCredential_FullMethodName    = "/controller.api.services.v1.CredentialService/DeleteCredential"
+	CredentialService_ReadCredentialUsage_FullMethodName = "/controller.api.services.v1.CredentialService/ReadCredentialUsage"
 )
 
 // CredentialServiceClient is the client API for CredentialService service.
@@ -59,6 +60,10 @@ type CredentialServiceClient interface {
 	// DeleteCredential removes an Credential from Boundary. If the Credential id
 	// is malformed or not provided an error is returned.
 	DeleteCredential(ctx context.Context, in *DeleteCredentialRequest, opts ...grpc.CallOption) (*DeleteCredentialResponse, error)
+	// ReadCredentialUsage returns the sessions a Credential has been issued to,
+	// most recent first. The secret itself is never included. If the
+	// Credential id is malformed or not provided an error is returned.
+	ReadCredentialUsage(ctx context.Context, in *ReadCredentialUsageRequest, opts ...grpc.CallOption) (*ReadCredentialUsageResponse, error)
 }
 
 type credentialServiceClient struct {
@@ -114,6 +119,15 @@ func (c *credentialServiceClient) DeleteCredential(ctx context.Context, in *Dele
 	return out, nil
 }
 
+func (c *credentialServiceClient) ReadCredentialUsage(ctx context.Context, in *ReadCredentialUsageRequest, opts ...grpc.CallOption) (*ReadCredentialUsageResponse, error) {
+	out := new(ReadCredentialUsageResponse)
+	err := c.cc.Invoke(ctx, CredentialService_ReadCredentialUsage_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // CredentialServiceServer is the server API for CredentialService service.
 // All implementations must embed UnimplementedCredentialServiceServer
 // for forward compatibility
@@ -144,6 +158,10 @@ type CredentialServiceServer interface {
 	// DeleteCredential removes an Credential from Boundary. If the Credential id
 	// is malformed or not provided an error is returned.
 	DeleteCredential(context.Context, *DeleteCredentialRequest) (*DeleteCredentialResponse, error)
+	// ReadCredentialUsage returns the sessions a Credential has been issued to,
+	// most recent first. The secret itself is never included. If the
+	// Credential id is malformed or not provided an error is returned.
+	ReadCredentialUsage(context.Context, *ReadCredentialUsageRequest) (*ReadCredentialUsageResponse, error)
 	mustEmbedUnimplementedCredentialServiceServer()
 }
 
@@ -166,6 +184,9 @@ func (UnimplementedCredentialServiceServer) UpdateCredential(context.Context, *U
 func (UnimplementedCredentialServiceServer) DeleteCredential(context.Context, *DeleteCredentialRequest) (*DeleteCredentialResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method DeleteCredential not implemented")
 }
+func (UnimplementedCredentialServiceServer) ReadCredentialUsage(context.Context, *ReadCredentialUsageRequest) (*ReadCredentialUsageResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ReadCredentialUsage not implemented")
+}
 func (UnimplementedCredentialServiceServer) mustEmbedUnimplementedCredentialServiceServer() {}
 
 // UnsafeCredentialServiceServer may be embedded to opt out of forward compatibility for this service.
@@ -269,6 +290,24 @@ func _CredentialService_DeleteCredential_Handler(srv interface{}, ctx context.Co
 	return interceptor(ctx, in, info, handler)
 }
 
+func _CredentialService_ReadCredentialUsage_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReadCredentialUsageRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CredentialServiceServer).ReadCredentialUsage(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CredentialService_ReadCredentialUsage_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CredentialServiceServer).ReadCredentialUsage(ctx, req.(*ReadCredentialUsageRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // CredentialService_ServiceDesc is the grpc.ServiceDesc for CredentialService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -296,6 +335,10 @@ var CredentialService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "DeleteCredential",
 			Handler:    _CredentialService_DeleteCredential_Handler,
 		},
+		{
+			MethodName: "ReadCredentialUsage",
+			Handler:    _CredentialService_ReadCredentialUsage_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "controller/api/services/v1/credential_service.proto",
@@ -31,6 +31,7 @@ const (
 	ScopeService_RotateKeys_FullMethodName                    = "/controller.api.services.v1.ScopeService/RotateKeys"
 	ScopeService_ListKeyVersionDestructionJobs_FullMethodName = "/controller.api.services.v1.ScopeService/ListKeyVersionDestructionJobs"
 	ScopeService_DestroyKeyVersion_FullMethodName             = "/controller.api.services.v1.ScopeService/DestroyKeyVersion"
+	ScopeService_ListOplogEntries_FullMethodName              = "/controller.api.services.v1.ScopeService/ListOplogEntries"
 	ScopeService_AttachStoragePolicy_FullMethodName           = "/controller.api.services.v1.ScopeService/AttachStoragePolicy"
 	ScopeService_DetachStoragePolicy_FullMethodName           = "/controller.api.services.v1.ScopeService/DetachStoragePolicy"
 )
@@ -78,6 +79,10 @@ type ScopeServiceClient interface {
 	// existing data, it will start an asynchronous process to complete this operation
 	// before destroying the key. Use ListKeyVersionDestructionJobs to monitor pending destruction jobs.
 	DestroyKeyVersion(ctx context.Context, in *DestroyKeyVersionRequest, opts ...grpc.CallOption) (*DestroyKeyVersionResponse, error)
+	// ListOplogEntries lists the changes recorded in the operation log of the
+	// scope, oldest first. Pass the cursor of the response in the next request
+	// to list the entries written after the ones already returned.
+	ListOplogEntries(ctx context.Context, in *ListOplogEntriesRequest, opts ...grpc.CallOption) (*ListOplogEntriesResponse, error)
 	// AttachStoragePolicy sets the Scope's Storage Policy. Any existing Storage
 	// Policy on the Scope will be overwritten. The provided request must include
 	// the Scope ID and the Storage Policy ID on which the Storage Policy will be
@@ -184,6 +189,15 @@ func (c *scopeServiceClient) DestroyKeyVersion(ctx context.Context, in *DestroyK
 	return out, nil
 }
 
+func (c *scopeServiceClient) ListOplogEntries(ctx context.Context, in *ListOplogEntriesRequest, opts ...grpc.CallOption) (*ListOplogEntriesResponse, error) {
+	out := new(ListOplogEntriesResponse)
+	err := c.cc.Invoke(ctx, ScopeService_ListOplogEntries_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *scopeServiceClient) AttachStoragePolicy(ctx context.Context, in *AttachStoragePolicyRequest, opts ...grpc.CallOption) (*AttachStoragePolicyResponse, error) {
 	out := new(AttachStoragePolicyResponse)
 	err := c.cc.Invoke(ctx, ScopeService_AttachStoragePolicy_FullMethodName, in, out, opts...)
@@ -245,6 +259,10 @@ type ScopeServiceServer interface {
 	// existing data, it will start an asynchronous process to complete this operation
 	// before destroying the key. Use ListKeyVersionDestructionJobs to monitor pending destruction jobs.
 	DestroyKeyVersion(context.Context, *DestroyKeyVersionRequest) (*DestroyKeyVersionResponse, error)
+	// ListOplogEntries lists the changes recorded in the operation log of the
+	// scope, oldest first. Pass the cursor of the response in the next request
+	// to list the entries written after the ones already returned.
+	ListOplogEntries(context.Context, *ListOplogEntriesRequest) (*ListOplogEntriesResponse, error)
 	// AttachStoragePolicy sets the Scope's Storage Policy. Any existing Storage
 	// Policy on the Scope will be overwritten. The provided request must include
 	// the Scope ID and the Storage Policy ID on which the Storage Policy will be
@@ -294,6 +312,9 @@ func (UnimplementedScopeServiceServer) ListKeyVersionDestructionJobs(context.Con
 func (UnimplementedScopeServiceServer) DestroyKeyVersion(context.Context, *DestroyKeyVersionRequest) (*DestroyKeyVersionResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method DestroyKeyVersion not implemented")
 }
+func (UnimplementedScopeServiceServer) ListOplogEntries(context.Context, *ListOplogEntriesRequest) (*ListOplogEntriesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListOplogEntries not implemented")
+}
 func (UnimplementedScopeServiceServer) AttachStoragePolicy(context.Context, *AttachStoragePolicyRequest) (*AttachStoragePolicyResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method AttachStoragePolicy not implemented")
 }
@@ -475,6 +496,24 @@ func _ScopeService_DestroyKeyVersion_Handler(srv interface{}, ctx context.Contex
 	return interceptor(ctx, in, info, handler)
 }
 
+func _ScopeService_ListOplogEntries_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListOplogEntriesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ScopeServiceServer).ListOplogEntries(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ScopeService_ListOplogEntries_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ScopeServiceServer).ListOplogEntries(ctx, req.(*ListOplogEntriesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _ScopeService_AttachStoragePolicy_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(AttachStoragePolicyRequest)
 	if err := dec(in); err != nil {
@@ -554,6 +593,10 @@ var ScopeService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "DestroyKeyVersion",
 			Handler:    _ScopeService_DestroyKeyVersion_Handler,
 		},
+		{
+			MethodName: "ListOplogEntries",
+			Handler:    _ScopeService_ListOplogEntries_Handler,
+		},
 		{
 			MethodName: "AttachStoragePolicy",
 			Handler:    _ScopeService_AttachStoragePolicy_Handler,
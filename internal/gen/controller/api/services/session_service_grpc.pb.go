@@ -22,9 +22,11 @@ import (
 const _ = grpc.SupportPackageIsVersion7
 
 const (
-	SessionService_GetSession_FullMethodName    = "/controller.api.services.v1.SessionService/GetSession"
-	SessionService_ListSessions_FullMethodName  = "/controller.api.services.v1.SessionService/ListSessions"
-	SessionService_CancelSession_FullMethodName = "/controller.api.services.v1.SessionService/CancelSession"
+	SessionService_GetSession_FullMethodName     = "/controller.api.services.v1.SessionService/GetSession"
+	SessionService_ListSessions_FullMethodName   = "/controller.api.services.v1.SessionService/ListSessions"
+	SessionService_CancelSession_FullMethodName  = "/controller.api.services.v1.SessionService/CancelSession"
+	SessionService_CancelSessions_FullMethodName = "/controller.api.services.v1.SessionService/CancelSessions"
+	SessionService_ShadowSession_FullMethodName  = "/controller.api.services.v1.SessionService/ShadowSession"
 )
 
 // SessionServiceClient is the client API for SessionService service.
@@ -45,6 +47,15 @@ type SessionServiceClient interface {
 	// is returned if the request attempts to cancel a Session that does
 	// not exist.
 	CancelSession(ctx context.Context, in *CancelSessionRequest, opts ...grpc.CallOption) (*CancelSessionResponse, error)
+	// CancelSessions cancels the Sessions in the scope referenced inside the
+	// request that match the user, target and worker given in the request.
+	// Sessions the caller is not allowed to cancel are skipped. At least one of
+	// the user, target or worker must be given.
+	CancelSessions(ctx context.Context, in *CancelSessionsRequest, opts ...grpc.CallOption) (*CancelSessionsResponse, error)
+	// ShadowSession authorizes the caller to observe the terminal of an active
+	// SSH Session, and optionally to send input to it. An error is returned if
+	// the Session is not active or is not an SSH Session.
+	ShadowSession(ctx context.Context, in *ShadowSessionRequest, opts ...grpc.CallOption) (*ShadowSessionResponse, error)
 }
 
 type sessionServiceClient struct {
@@ -82,6 +93,24 @@ func (c *sessionServiceClient) CancelSession(ctx context.Context, in *CancelSess
 	return out, nil
 }
 
+func (c *sessionServiceClient) CancelSessions(ctx context.Context, in *CancelSessionsRequest, opts ...grpc.CallOption) (*CancelSessionsResponse, error) {
+	out := new(CancelSessionsResponse)
+	err := c.cc.Invoke(ctx, SessionService_CancelSessions_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *sessionServiceClient) ShadowSession(ctx context.Context, in *ShadowSessionRequest, opts ...grpc.CallOption) (*ShadowSessionResponse, error) {
+	out := new(ShadowSessionResponse)
+	err := c.cc.Invoke(ctx, SessionService_ShadowSession_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // SessionServiceServer is the server API for SessionService service.
 // All implementations must embed UnimplementedSessionServiceServer
 // for forward compatibility
@@ -100,6 +129,15 @@ type SessionServiceServer interface {
 	// is returned if the request attempts to cancel a Session that does
 	// not exist.
 	CancelSession(context.Context, *CancelSessionRequest) (*CancelSessionResponse, error)
+	// CancelSessions cancels the Sessions in the scope referenced inside the
+	// request that match the user, target and worker given in the request.
+	// Sessions the caller is not allowed to cancel are skipped. At least one of
+	// the user, target or worker must be given.
+	CancelSessions(context.Context, *CancelSessionsRequest) (*CancelSessionsResponse, error)
+	// ShadowSession authorizes the caller to observe the terminal of an active
+	// SSH Session, and optionally to send input to it. An error is returned if
+	// the Session is not active or is not an SSH Session.
+	ShadowSession(context.Context, *ShadowSessionRequest) (*ShadowSessionResponse, error)
 	mustEmbedUnimplementedSessionServiceServer()
 }
 
@@ -116,6 +154,12 @@ func (UnimplementedSessionServiceServer) ListSessions(context.Context, *ListSess
 func (UnimplementedSessionServiceServer) CancelSession(context.Context, *CancelSessionRequest) (*CancelSessionResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method CancelSession not implemented")
 }
+func (UnimplementedSessionServiceServer) CancelSessions(context.Context, *CancelSessionsRequest) (*CancelSessionsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CancelSessions not implemented")
+}
+func (UnimplementedSessionServiceServer) ShadowSession(context.Context, *ShadowSessionRequest) (*ShadowSessionResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ShadowSession not implemented")
+}
 func (UnimplementedSessionServiceServer) mustEmbedUnimplementedSessionServiceServer() {}
 
 // UnsafeSessionServiceServer may be embedded to opt out of forward compatibility for this service.
@@ -183,6 +227,42 @@ func _SessionService_CancelSession_Handler(srv interface{}, ctx context.Context,
 	return interceptor(ctx, in, info, handler)
 }
 
+func _SessionService_CancelSessions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CancelSessionsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SessionServiceServer).CancelSessions(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SessionService_CancelSessions_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SessionServiceServer).CancelSessions(ctx, req.(*CancelSessionsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SessionService_ShadowSession_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ShadowSessionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SessionServiceServer).ShadowSession(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SessionService_ShadowSession_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SessionServiceServer).ShadowSession(ctx, req.(*ShadowSessionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // SessionService_ServiceDesc is the grpc.ServiceDesc for SessionService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -202,6 +282,14 @@ var SessionService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "CancelSession",
 			Handler:    _SessionService_CancelSession_Handler,
 		},
+		{
+			MethodName: "CancelSessions",
+			Handler:    _SessionService_CancelSessions_Handler,
+		},
+		{
+			MethodName: "ShadowSession",
+			Handler:    _SessionService_ShadowSession_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "controller/api/services/v1/session_service.proto",
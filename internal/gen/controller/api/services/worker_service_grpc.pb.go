@@ -33,6 +33,9 @@ const (
 	WorkerService_RemoveWorkerTags_FullMethodName                 = "/controller.api.services.v1.WorkerService/RemoveWorkerTags"
 	WorkerService_ReadCertificateAuthority_FullMethodName         = "/controller.api.services.v1.WorkerService/ReadCertificateAuthority"
 	WorkerService_ReinitializeCertificateAuthority_FullMethodName = "/controller.api.services.v1.WorkerService/ReinitializeCertificateAuthority"
+	WorkerService_ReadFleetReport_FullMethodName                  = "/controller.api.services.v1.WorkerService/ReadFleetReport"
+	WorkerService_ReadTopology_FullMethodName                     = "/controller.api.services.v1.WorkerService/ReadTopology"
+	WorkerService_AttestWorker_FullMethodName                     = "/controller.api.services.v1.WorkerService/AttestWorker"
 )
 
 // WorkerServiceClient is the client API for WorkerService service.
@@ -89,6 +92,19 @@ type WorkerServiceClient interface {
 	ReadCertificateAuthority(ctx context.Context, in *ReadCertificateAuthorityRequest, opts ...grpc.CallOption) (*ReadCertificateAuthorityResponse, error)
 	// ReinitializeCas removes both current and next root certs and replaces them with a new set
 	ReinitializeCertificateAuthority(ctx context.Context, in *ReinitializeCertificateAuthorityRequest, opts ...grpc.CallOption) (*ReinitializeCertificateAuthorityResponse, error)
+	// ReadFleetReport returns a summary of the workers by version and
+	// configuration tag, along with their status, load and downstream workers,
+	// and the workers too old to support the features targets may use.
+	ReadFleetReport(ctx context.Context, in *ReadFleetReportRequest, opts ...grpc.CallOption) (*ReadFleetReportResponse, error)
+	// ReadTopology returns the graph of the workers which have recently reported
+	// their status, with the connections between upstream and downstream
+	// workers that multi-hop sessions are routed over.
+	ReadTopology(ctx context.Context, in *ReadTopologyRequest, opts ...grpc.CallOption) (*ReadTopologyResponse, error)
+	// AttestWorker creates a worker from a worker-generated authorization
+	// request and a signed identity document. The document is validated against
+	// the attestation trust policies of the controller instead of the grants of
+	// the caller, allowing workers to register themselves.
+	AttestWorker(ctx context.Context, in *AttestWorkerRequest, opts ...grpc.CallOption) (*AttestWorkerResponse, error)
 }
 
 type workerServiceClient struct {
@@ -198,6 +214,33 @@ func (c *workerServiceClient) ReinitializeCertificateAuthority(ctx context.Conte
 	return out, nil
 }
 
+func (c *workerServiceClient) ReadFleetReport(ctx context.Context, in *ReadFleetReportRequest, opts ...grpc.CallOption) (*ReadFleetReportResponse, error) {
+	out := new(ReadFleetReportResponse)
+	err := c.cc.Invoke(ctx, WorkerService_ReadFleetReport_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *workerServiceClient) ReadTopology(ctx context.Context, in *ReadTopologyRequest, opts ...grpc.CallOption) (*ReadTopologyResponse, error) {
+	out := new(ReadTopologyResponse)
+	err := c.cc.Invoke(ctx, WorkerService_ReadTopology_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *workerServiceClient) AttestWorker(ctx context.Context, in *AttestWorkerRequest, opts ...grpc.CallOption) (*AttestWorkerResponse, error) {
+	out := new(AttestWorkerResponse)
+	err := c.cc.Invoke(ctx, WorkerService_AttestWorker_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // WorkerServiceServer is the server API for WorkerService service.
 // All implementations must embed UnimplementedWorkerServiceServer
 // for forward compatibility
@@ -252,6 +295,19 @@ type WorkerServiceServer interface {
 	ReadCertificateAuthority(context.Context, *ReadCertificateAuthorityRequest) (*ReadCertificateAuthorityResponse, error)
 	// ReinitializeCas removes both current and next root certs and replaces them with a new set
 	ReinitializeCertificateAuthority(context.Context, *ReinitializeCertificateAuthorityRequest) (*ReinitializeCertificateAuthorityResponse, error)
+	// ReadFleetReport returns a summary of the workers by version and
+	// configuration tag, along with their status, load and downstream workers,
+	// and the workers too old to support the features targets may use.
+	ReadFleetReport(context.Context, *ReadFleetReportRequest) (*ReadFleetReportResponse, error)
+	// ReadTopology returns the graph of the workers which have recently reported
+	// their status, with the connections between upstream and downstream
+	// workers that multi-hop sessions are routed over.
+	ReadTopology(context.Context, *ReadTopologyRequest) (*ReadTopologyResponse, error)
+	// AttestWorker creates a worker from a worker-generated authorization
+	// request and a signed identity document. The document is validated against
+	// the attestation trust policies of the controller instead of the grants of
+	// the caller, allowing workers to register themselves.
+	AttestWorker(context.Context, *AttestWorkerRequest) (*AttestWorkerResponse, error)
 	mustEmbedUnimplementedWorkerServiceServer()
 }
 
@@ -292,6 +348,15 @@ func (UnimplementedWorkerServiceServer) ReadCertificateAuthority(context.Context
 func (UnimplementedWorkerServiceServer) ReinitializeCertificateAuthority(context.Context, *ReinitializeCertificateAuthorityRequest) (*ReinitializeCertificateAuthorityResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method ReinitializeCertificateAuthority not implemented")
 }
+func (UnimplementedWorkerServiceServer) ReadFleetReport(context.Context, *ReadFleetReportRequest) (*ReadFleetReportResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ReadFleetReport not implemented")
+}
+func (UnimplementedWorkerServiceServer) ReadTopology(context.Context, *ReadTopologyRequest) (*ReadTopologyResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ReadTopology not implemented")
+}
+func (UnimplementedWorkerServiceServer) AttestWorker(context.Context, *AttestWorkerRequest) (*AttestWorkerResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AttestWorker not implemented")
+}
 func (UnimplementedWorkerServiceServer) mustEmbedUnimplementedWorkerServiceServer() {}
 
 // UnsafeWorkerServiceServer may be embedded to opt out of forward compatibility for this service.
@@ -503,6 +568,60 @@ func _WorkerService_ReinitializeCertificateAuthority_Handler(srv interface{}, ct
 	return interceptor(ctx, in, info, handler)
 }
 
+func _WorkerService_ReadFleetReport_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReadFleetReportRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WorkerServiceServer).ReadFleetReport(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: WorkerService_ReadFleetReport_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WorkerServiceServer).ReadFleetReport(ctx, req.(*ReadFleetReportRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WorkerService_ReadTopology_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReadTopologyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WorkerServiceServer).ReadTopology(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: WorkerService_ReadTopology_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WorkerServiceServer).ReadTopology(ctx, req.(*ReadTopologyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WorkerService_AttestWorker_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AttestWorkerRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WorkerServiceServer).AttestWorker(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: WorkerService_AttestWorker_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WorkerServiceServer).AttestWorker(ctx, req.(*AttestWorkerRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // WorkerService_ServiceDesc is the grpc.ServiceDesc for WorkerService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -554,6 +673,18 @@ var WorkerService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "ReinitializeCertificateAuthority",
 			Handler:    _WorkerService_ReinitializeCertificateAuthority_Handler,
 		},
+		{
+			MethodName: "ReadFleetReport",
+			Handler:    _WorkerService_ReadFleetReport_Handler,
+		},
+		{
+			MethodName: "ReadTopology",
+			Handler:    _WorkerService_ReadTopology_Handler,
+		},
+		{
+			MethodName: "AttestWorker",
+			Handler:    _WorkerService_AttestWorker_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "controller/api/services/v1/worker_service.proto",
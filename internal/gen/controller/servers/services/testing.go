@@ -16,6 +16,7 @@ type mockSessionServiceClient struct {
 	AuthorizeConnectionFn func(context.Context, *AuthorizeConnectionRequest) (*AuthorizeConnectionResponse, error)
 	ConnectConnectionFn   func(context.Context, *ConnectConnectionRequest) (*ConnectConnectionResponse, error)
 	CloseConnectionFn     func(context.Context, *CloseConnectionRequest) (*CloseConnectionResponse, error)
+	AuthorizeShadowFn     func(context.Context, *AuthorizeShadowRequest) (*AuthorizeShadowResponse, error)
 }
 
 // NewMockSessionServiceClient returns a mock SessionServiceClient which allows
@@ -66,3 +67,10 @@ func (c *mockSessionServiceClient) CloseConnection(ctx context.Context, req *Clo
 	}
 	panic("not implemented")
 }
+
+func (c *mockSessionServiceClient) AuthorizeShadow(ctx context.Context, req *AuthorizeShadowRequest, _ ...grpc.CallOption) (*AuthorizeShadowResponse, error) {
+	if c.AuthorizeShadowFn != nil {
+		return c.AuthorizeShadowFn(ctx, req)
+	}
+	panic("not implemented")
+}
@@ -0,0 +1,31 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package host
+
+// HealthCheck is the probe workers use to check the health of the endpoints
+// of a target's hosts.
+type HealthCheck string
+
+const (
+	// TcpHealthCheck probes an endpoint by establishing a tcp connection.
+	TcpHealthCheck HealthCheck = "tcp"
+	// TlsHealthCheck probes an endpoint by completing a tls handshake. The
+	// certificate presented by the endpoint is not verified.
+	TlsHealthCheck HealthCheck = "tls"
+)
+
+// String returns the string representation of the HealthCheck.
+func (c HealthCheck) String() string {
+	return string(c)
+}
+
+// ValidHealthCheck returns true if the provided string is a supported
+// HealthCheck.
+func ValidHealthCheck(c string) bool {
+	switch HealthCheck(c) {
+	case TcpHealthCheck, TlsHealthCheck:
+		return true
+	}
+	return false
+}
@@ -39,6 +39,11 @@ type options struct {
 	withRandomReader            io.Reader
 	withAccountIds              []string
 	withPrimaryAuthMethodId     string
+	withMaxActiveSessions       uint32
+	withMaxDailySessions        uint32
+	withKeyRotationInterval     uint32
+	withKeyRotationRewrap       bool
+	withKeyRotationDestroy      bool
 	withReader                  db.Reader
 	withWriter                  db.Writer
 	withStartPageAfterItem      pagination.Item
@@ -156,6 +161,48 @@ func WithPrimaryAuthMethodId(id string) Option {
 	}
 }
 
+// WithMaxActiveSessionsPerUser provides an option to specify the maximum
+// number of pending or active sessions a user can hold under a scope or role.
+func WithMaxActiveSessionsPerUser(n uint32) Option {
+	return func(o *options) {
+		o.withMaxActiveSessions = n
+	}
+}
+
+// WithMaxDailySessionsPerUser provides an option to specify the maximum
+// number of sessions a user can authorize in any 24 hour period under a scope
+// or role.
+func WithMaxDailySessionsPerUser(n uint32) Option {
+	return func(o *options) {
+		o.withMaxDailySessions = n
+	}
+}
+
+// WithKeyRotationIntervalSeconds provides an option to specify the number of
+// seconds after which the keys of a scope are automatically rotated.
+func WithKeyRotationIntervalSeconds(n uint32) Option {
+	return func(o *options) {
+		o.withKeyRotationInterval = n
+	}
+}
+
+// WithKeyRotationRewrap provides an option to specify whether existing data
+// keys of a scope are rewrapped when its keys are automatically rotated.
+func WithKeyRotationRewrap(rewrap bool) Option {
+	return func(o *options) {
+		o.withKeyRotationRewrap = rewrap
+	}
+}
+
+// WithKeyRotationDestroyOldVersions provides an option to specify whether the
+// destruction of previous key versions of a scope is scheduled when its keys
+// are automatically rotated.
+func WithKeyRotationDestroyOldVersions(destroy bool) Option {
+	return func(o *options) {
+		o.withKeyRotationDestroy = destroy
+	}
+}
+
 // WithReaderWriter allows the caller to pass an inflight transaction to be used
 // for all database operations. If WithReaderWriter(...) is used, then the
 // caller is responsible for managing the transaction. The purpose of the
@@ -92,6 +92,41 @@ func Test_GetOpts(t *testing.T) {
 		testOpts.withPrimaryAuthMethodId = "test"
 		assert.Equal(opts, testOpts)
 	})
+	t.Run("WithMaxActiveSessionsPerUser", func(t *testing.T) {
+		assert := assert.New(t)
+		opts := getOpts(WithMaxActiveSessionsPerUser(5))
+		testOpts := getDefaultOptions()
+		testOpts.withMaxActiveSessions = 5
+		assert.Equal(opts, testOpts)
+	})
+	t.Run("WithMaxDailySessionsPerUser", func(t *testing.T) {
+		assert := assert.New(t)
+		opts := getOpts(WithMaxDailySessionsPerUser(50))
+		testOpts := getDefaultOptions()
+		testOpts.withMaxDailySessions = 50
+		assert.Equal(opts, testOpts)
+	})
+	t.Run("WithKeyRotationIntervalSeconds", func(t *testing.T) {
+		assert := assert.New(t)
+		opts := getOpts(WithKeyRotationIntervalSeconds(7776000))
+		testOpts := getDefaultOptions()
+		testOpts.withKeyRotationInterval = 7776000
+		assert.Equal(opts, testOpts)
+	})
+	t.Run("WithKeyRotationRewrap", func(t *testing.T) {
+		assert := assert.New(t)
+		opts := getOpts(WithKeyRotationRewrap(true))
+		testOpts := getDefaultOptions()
+		testOpts.withKeyRotationRewrap = true
+		assert.Equal(opts, testOpts)
+	})
+	t.Run("WithKeyRotationDestroyOldVersions", func(t *testing.T) {
+		assert := assert.New(t)
+		opts := getOpts(WithKeyRotationDestroyOldVersions(true))
+		testOpts := getDefaultOptions()
+		testOpts.withKeyRotationDestroy = true
+		assert.Equal(opts, testOpts)
+	})
 	t.Run("WithStartPageAfterItem", func(t *testing.T) {
 		assert := assert.New(t)
 		updateTime := time.Now()
@@ -112,8 +112,10 @@ const (
 	order by action, member_id;
 	`
 
-	grantsForUserQuery = `
-    with
+	// userRolesCtes are the common table expressions which resolve the roles
+	// of the user, directly and through groups and managed groups, into
+	// user_group_roles. The where clause selecting the user is substituted in.
+	userRolesCtes = `
     users (id) as (
       select public_id
         from iam_user
@@ -158,7 +160,11 @@ const (
       union
       select role_id
         from managed_group_roles
-    ),
+    )
+	`
+
+	grantsForUserQuery = `
+    with` + userRolesCtes + `,
     roles (role_id, role_scope_id) as (
       select iam_role.public_id,
              iam_role.scope_id
@@ -238,6 +244,23 @@ const (
       from final;
     `
 
+	// sessionQuotaRolesQuery returns the ids of the roles of the user whose
+	// grants apply to a project, resolving the grant scopes the same way as
+	// grantsForUserQuery. The parameters are the user id, the project id twice
+	// and the id of the project's org.
+	sessionQuotaRolesQuery = `
+    with` + userRolesCtes + `
+    select distinct iam_role.public_id as role_id
+      from iam_role
+      join iam_role_grant_scope
+        on iam_role_grant_scope.role_id = iam_role.public_id
+     where iam_role.public_id in (select role_id from user_group_roles)
+       and (iam_role_grant_scope.scope_id_or_special = ?
+        or (iam_role_grant_scope.scope_id_or_special = 'this'     and iam_role.scope_id = ?)
+        or (iam_role_grant_scope.scope_id_or_special = 'children' and iam_role.scope_id = ?)
+        or  iam_role_grant_scope.scope_id_or_special = 'descendants');
+    `
+
 	estimateCountRoles = `
 		select reltuples::bigint as estimate from pg_class where oid in ('iam_role'::regclass)
 	`
@@ -95,6 +95,8 @@ func (r *Repository) UpdateRole(ctx context.Context, role *Role, version uint32,
 		switch {
 		case strings.EqualFold("name", f):
 		case strings.EqualFold("description", f):
+		case strings.EqualFold("MaxActiveSessionsPerUser", f):
+		case strings.EqualFold("MaxDailySessionsPerUser", f):
 		default:
 			return nil, nil, nil, nil, db.NoRowsAffected, errors.New(ctx, errors.InvalidFieldMask, op, fmt.Sprintf("invalid field mask: %s", f))
 		}
@@ -103,8 +105,10 @@ func (r *Repository) UpdateRole(ctx context.Context, role *Role, version uint32,
 	var dbMask, nullFields []string
 	dbMask, nullFields = dbw.BuildUpdatePaths(
 		map[string]any{
-			"name":        role.Name,
-			"description": role.Description,
+			"name":                     role.Name,
+			"description":              role.Description,
+			"MaxActiveSessionsPerUser": role.MaxActiveSessionsPerUser,
+			"MaxDailySessionsPerUser":  role.MaxDailySessionsPerUser,
 		},
 		fieldMaskPaths,
 		nil,
@@ -424,9 +424,14 @@ func (r *Repository) UpdateScope(ctx context.Context, scope *Scope, version uint
 	var dbMask, nullFields []string
 	dbMask, nullFields = dbw.BuildUpdatePaths(
 		map[string]any{
-			"name":                scope.Name,
-			"description":         scope.Description,
-			"PrimaryAuthMethodId": scope.PrimaryAuthMethodId, // gorm: it's important that the field start with a capital letter.
+			"name":                          scope.Name,
+			"description":                   scope.Description,
+			"PrimaryAuthMethodId":           scope.PrimaryAuthMethodId, // gorm: it's important that the field start with a capital letter.
+			"MaxActiveSessionsPerUser":      scope.MaxActiveSessionsPerUser,
+			"MaxDailySessionsPerUser":       scope.MaxDailySessionsPerUser,
+			"KeyRotationIntervalSeconds":    scope.KeyRotationIntervalSeconds,
+			"KeyRotationRewrap":             scope.KeyRotationRewrap,
+			"KeyRotationDestroyOldVersions": scope.KeyRotationDestroyOldVersions,
 		},
 		fieldMaskPaths,
 		nil,
@@ -44,9 +44,11 @@ func NewRole(ctx context.Context, scopeId string, opt ...Option) (*Role, error)
 	opts := getOpts(opt...)
 	r := &Role{
 		Role: &store.Role{
-			ScopeId:     scopeId,
-			Name:        opts.withName,
-			Description: opts.withDescription,
+			ScopeId:                  scopeId,
+			Name:                     opts.withName,
+			Description:              opts.withDescription,
+			MaxActiveSessionsPerUser: opts.withMaxActiveSessions,
+			MaxDailySessionsPerUser:  opts.withMaxDailySessions,
 		},
 	}
 	return r, nil
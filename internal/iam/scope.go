@@ -83,11 +83,16 @@ func newScope(ctx context.Context, parent *Scope, opt ...Option) (*Scope, error)
 	opts := getOpts(opt...)
 	s := &Scope{
 		Scope: &store.Scope{
-			Type:                typ.String(),
-			Name:                opts.withName,
-			Description:         opts.withDescription,
-			ParentId:            parent.PublicId,
-			PrimaryAuthMethodId: opts.withPrimaryAuthMethodId,
+			Type:                          typ.String(),
+			Name:                          opts.withName,
+			Description:                   opts.withDescription,
+			ParentId:                      parent.PublicId,
+			PrimaryAuthMethodId:           opts.withPrimaryAuthMethodId,
+			MaxActiveSessionsPerUser:      opts.withMaxActiveSessions,
+			MaxDailySessionsPerUser:       opts.withMaxDailySessions,
+			KeyRotationIntervalSeconds:    opts.withKeyRotationInterval,
+			KeyRotationRewrap:             opts.withKeyRotationRewrap,
+			KeyRotationDestroyOldVersions: opts.withKeyRotationDestroy,
 		},
 	}
 
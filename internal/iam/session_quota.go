@@ -0,0 +1,151 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package iam
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"slices"
+	"sort"
+	"time"
+
+	"github.com/hashicorp/boundary/globals"
+	"github.com/hashicorp/boundary/internal/errors"
+	"github.com/hashicorp/boundary/internal/types/scope"
+)
+
+// DailySessionsPeriod is the period over which
+// SessionQuotaLimit.MaxDailySessions is enforced.
+const DailySessionsPeriod = 24 * time.Hour
+
+// SessionQuotaLimit is a limit on the sessions a user can authorize which is
+// defined in ScopeId. It is enforced against the sessions of the user in the
+// projects of that scope's subtree. A limit of zero means the number of
+// sessions is not limited.
+type SessionQuotaLimit struct {
+	// ScopeId is the id of the scope the limit is set on, or the scope of
+	// the roles it is set on.
+	ScopeId string
+	// MaxActiveSessions is the maximum number of pending or active sessions the
+	// user can hold.
+	MaxActiveSessions uint32
+	// MaxDailySessions is the maximum number of sessions the user can
+	// authorize in any 24 hour period.
+	MaxDailySessions uint32
+}
+
+// SessionQuota is the limit on the sessions a user can authorize in a project.
+// Authorizing a session must not exceed any of its limits, which are ordered
+// from the global scope down to the project.
+type SessionQuota struct {
+	Limits []*SessionQuotaLimit
+}
+
+// Unlimited returns true if the quota doesn't limit the number of sessions.
+func (q *SessionQuota) Unlimited() bool {
+	return len(q.Limits) == 0
+}
+
+// restrict lowers the limits of the quota in the scope to the given ones,
+// ignoring limits of zero.
+func (q *SessionQuota) restrict(scopeId string, maxActive, maxDaily uint32) {
+	if maxActive == 0 && maxDaily == 0 {
+		return
+	}
+	var l *SessionQuotaLimit
+	for _, ql := range q.Limits {
+		if ql.ScopeId == scopeId {
+			l = ql
+			break
+		}
+	}
+	if l == nil {
+		l = &SessionQuotaLimit{ScopeId: scopeId}
+		q.Limits = append(q.Limits, l)
+	}
+	if maxActive > 0 && (l.MaxActiveSessions == 0 || maxActive < l.MaxActiveSessions) {
+		l.MaxActiveSessions = maxActive
+	}
+	if maxDaily > 0 && (l.MaxDailySessions == 0 || maxDaily < l.MaxDailySessions) {
+		l.MaxDailySessions = maxDaily
+	}
+}
+
+// LookupSessionQuota returns the session quota of the user in the project
+// scope. The quota holds the limits set on the project, its org, the global
+// scope and the roles of the user whose grant scopes include the project,
+// directly, through "this", "children" or "descendants". A role's limits are
+// defined in the scope of the role, and the most restrictive of the limits
+// defined in each scope is used.
+func (r *Repository) LookupSessionQuota(ctx context.Context, userId, projectId string, _ ...Option) (*SessionQuota, error) {
+	const op = "iam.(Repository).LookupSessionQuota"
+	switch {
+	case userId == "":
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "missing user id")
+	case projectId == "":
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "missing project id")
+	}
+
+	project, err := r.LookupScope(ctx, projectId)
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	if project == nil {
+		return nil, errors.New(ctx, errors.RecordNotFound, op, fmt.Sprintf("scope %s not found", projectId))
+	}
+	if project.GetType() != scope.Project.String() {
+		return nil, errors.New(ctx, errors.InvalidParameter, op, fmt.Sprintf("scope %s is not a project", projectId))
+	}
+
+	quota := &SessionQuota{}
+	var scopes []*Scope
+	scopeIds := []string{project.GetPublicId(), project.GetParentId(), scope.Global.String()}
+	if err := r.reader.SearchWhere(ctx, &scopes, "public_id in @scope_ids", []any{sql.Named("scope_ids", scopeIds)}); err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	for _, s := range scopes {
+		quota.restrict(s.GetPublicId(), s.GetMaxActiveSessionsPerUser(), s.GetMaxDailySessionsPerUser())
+	}
+
+	// The roles count when their grants apply to the project, with the grant
+	// scopes resolved the same way as when evaluating the user's permissions.
+	userClause := `where public_id in ('u_anon', 'u_auth', ?)`
+	if userId == globals.AnonymousUserId {
+		userClause = `where public_id in (?)`
+	}
+	rows, err := r.reader.Query(ctx, fmt.Sprintf(sessionQuotaRolesQuery, userClause), []any{userId, projectId, projectId, project.GetParentId()})
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	defer rows.Close()
+	var roleIds []string
+	for rows.Next() {
+		var result struct {
+			RoleId string
+		}
+		if err := r.reader.ScanRows(ctx, rows, &result); err != nil {
+			return nil, errors.Wrap(ctx, err, op)
+		}
+		roleIds = append(roleIds, result.RoleId)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	if len(roleIds) > 0 {
+		var roles []*Role
+		if err := r.reader.SearchWhere(ctx, &roles, "public_id in @role_ids", []any{sql.Named("role_ids", roleIds)}); err != nil {
+			return nil, errors.Wrap(ctx, err, op)
+		}
+		for _, role := range roles {
+			quota.restrict(role.GetScopeId(), role.GetMaxActiveSessionsPerUser(), role.GetMaxDailySessionsPerUser())
+		}
+	}
+	// Roles granting in the project are in the project or one of the
+	// scopes above it, so every limit is in one of scopeIds.
+	sort.SliceStable(quota.Limits, func(i, j int) bool {
+		return slices.Index(scopeIds, quota.Limits[i].ScopeId) > slices.Index(scopeIds, quota.Limits[j].ScopeId)
+	})
+	return quota, nil
+}
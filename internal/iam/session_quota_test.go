@@ -0,0 +1,145 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package iam
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/boundary/globals"
+	"github.com/hashicorp/boundary/internal/db"
+	"github.com/hashicorp/boundary/internal/errors"
+	"github.com/hashicorp/boundary/internal/types/scope"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSessionQuota_restrict(t *testing.T) {
+	q := &SessionQuota{}
+	assert.True(t, q.Unlimited())
+
+	q.restrict("o_1", 0, 0)
+	assert.Equal(t, &SessionQuota{}, q)
+
+	q.restrict("o_1", 10, 0)
+	assert.Equal(t, &SessionQuota{Limits: []*SessionQuotaLimit{{ScopeId: "o_1", MaxActiveSessions: 10}}}, q)
+
+	q.restrict("o_1", 20, 100)
+	assert.Equal(t, &SessionQuota{Limits: []*SessionQuotaLimit{{ScopeId: "o_1", MaxActiveSessions: 10, MaxDailySessions: 100}}}, q)
+
+	q.restrict("o_1", 5, 0)
+	assert.Equal(t, &SessionQuota{Limits: []*SessionQuotaLimit{{ScopeId: "o_1", MaxActiveSessions: 5, MaxDailySessions: 100}}}, q)
+	assert.False(t, q.Unlimited())
+
+	// Limits of other scopes are kept separately.
+	q.restrict("p_1", 1, 0)
+	assert.Equal(t, &SessionQuota{Limits: []*SessionQuotaLimit{
+		{ScopeId: "o_1", MaxActiveSessions: 5, MaxDailySessions: 100},
+		{ScopeId: "p_1", MaxActiveSessions: 1},
+	}}, q)
+}
+
+func TestRepository_LookupSessionQuota(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	conn, _ := db.TestSetup(t, "postgres")
+	wrapper := db.TestWrapper(t)
+	repo := TestRepo(t, conn, wrapper)
+
+	org, proj := TestScopes(t, repo)
+	_, otherProj := TestScopes(t, repo)
+	user := TestUser(t, repo, org.GetPublicId())
+
+	t.Run("invalid", func(t *testing.T) {
+		_, err := repo.LookupSessionQuota(ctx, "", proj.GetPublicId())
+		assert.True(t, errors.Match(errors.T(errors.InvalidParameter), err))
+		_, err = repo.LookupSessionQuota(ctx, user.GetPublicId(), "")
+		assert.True(t, errors.Match(errors.T(errors.InvalidParameter), err))
+		_, err = repo.LookupSessionQuota(ctx, user.GetPublicId(), org.GetPublicId())
+		assert.True(t, errors.Match(errors.T(errors.InvalidParameter), err))
+		_, err = repo.LookupSessionQuota(ctx, user.GetPublicId(), "p_doesntexist")
+		assert.True(t, errors.Match(errors.T(errors.RecordNotFound), err))
+	})
+
+	t.Run("unlimited", func(t *testing.T) {
+		q, err := repo.LookupSessionQuota(ctx, user.GetPublicId(), proj.GetPublicId())
+		require.NoError(t, err)
+		assert.True(t, q.Unlimited())
+	})
+
+	org.MaxActiveSessionsPerUser = 10
+	org.MaxDailySessionsPerUser = 100
+	_, _, err := repo.UpdateScope(ctx, org, org.GetVersion(), []string{"MaxActiveSessionsPerUser", "MaxDailySessionsPerUser"})
+	require.NoError(t, err)
+	proj.MaxActiveSessionsPerUser = 20
+	_, _, err = repo.UpdateScope(ctx, proj, proj.GetVersion(), []string{"MaxActiveSessionsPerUser"})
+	require.NoError(t, err)
+
+	t.Run("scopes", func(t *testing.T) {
+		q, err := repo.LookupSessionQuota(ctx, user.GetPublicId(), proj.GetPublicId())
+		require.NoError(t, err)
+		assert.Equal(t, &SessionQuota{Limits: []*SessionQuotaLimit{
+			{ScopeId: org.GetPublicId(), MaxActiveSessions: 10, MaxDailySessions: 100},
+			{ScopeId: proj.GetPublicId(), MaxActiveSessions: 20},
+		}}, q)
+
+		q, err = repo.LookupSessionQuota(ctx, user.GetPublicId(), otherProj.GetPublicId())
+		require.NoError(t, err)
+		assert.True(t, q.Unlimited())
+	})
+
+	t.Run("roles", func(t *testing.T) {
+		role := TestRole(t, conn, proj.GetPublicId(), WithMaxActiveSessionsPerUser(3))
+		TestRoleGrant(t, conn, role.GetPublicId(), "ids=*;type=target;actions=authorize-session")
+		TestUserRole(t, conn, role.GetPublicId(), user.GetPublicId())
+
+		// Roles the user isn't a principal of don't apply.
+		TestRole(t, conn, proj.GetPublicId(), WithMaxActiveSessionsPerUser(1), WithMaxDailySessionsPerUser(1))
+
+		q, err := repo.LookupSessionQuota(ctx, user.GetPublicId(), proj.GetPublicId())
+		require.NoError(t, err)
+		assert.Equal(t, &SessionQuota{Limits: []*SessionQuotaLimit{
+			{ScopeId: org.GetPublicId(), MaxActiveSessions: 10, MaxDailySessions: 100},
+			{ScopeId: proj.GetPublicId(), MaxActiveSessions: 3},
+		}}, q)
+	})
+
+	t.Run("role-grant-scopes", func(t *testing.T) {
+		// An org role only granting in the org doesn't apply to its projects.
+		role := TestRole(t, conn, org.GetPublicId(), WithMaxActiveSessionsPerUser(1))
+		TestRoleGrant(t, conn, role.GetPublicId(), "ids=*;type=*;actions=*")
+		TestUserRole(t, conn, role.GetPublicId(), user.GetPublicId())
+		q, err := repo.LookupSessionQuota(ctx, user.GetPublicId(), proj.GetPublicId())
+		require.NoError(t, err)
+		assert.Equal(t, &SessionQuota{Limits: []*SessionQuotaLimit{
+			{ScopeId: org.GetPublicId(), MaxActiveSessions: 10, MaxDailySessions: 100},
+			{ScopeId: proj.GetPublicId(), MaxActiveSessions: 3},
+		}}, q)
+
+		// Roles granting in the project through children or descendants do,
+		// even without any grants.
+		role = TestRole(t, conn, org.GetPublicId(), WithMaxDailySessionsPerUser(50), WithGrantScopeIds([]string{globals.GrantScopeChildren}))
+		TestUserRole(t, conn, role.GetPublicId(), user.GetPublicId())
+		q, err = repo.LookupSessionQuota(ctx, user.GetPublicId(), proj.GetPublicId())
+		require.NoError(t, err)
+		assert.Equal(t, &SessionQuota{Limits: []*SessionQuotaLimit{
+			{ScopeId: org.GetPublicId(), MaxActiveSessions: 10, MaxDailySessions: 50},
+			{ScopeId: proj.GetPublicId(), MaxActiveSessions: 3},
+		}}, q)
+
+		role = TestRole(t, conn, scope.Global.String(), WithMaxActiveSessionsPerUser(2), WithGrantScopeIds([]string{globals.GrantScopeDescendants}))
+		TestUserRole(t, conn, role.GetPublicId(), user.GetPublicId())
+		q, err = repo.LookupSessionQuota(ctx, user.GetPublicId(), proj.GetPublicId())
+		require.NoError(t, err)
+		assert.Equal(t, &SessionQuota{Limits: []*SessionQuotaLimit{
+			{ScopeId: scope.Global.String(), MaxActiveSessions: 2},
+			{ScopeId: org.GetPublicId(), MaxActiveSessions: 10, MaxDailySessions: 50},
+			{ScopeId: proj.GetPublicId(), MaxActiveSessions: 3},
+		}}, q)
+
+		q, err = repo.LookupSessionQuota(ctx, user.GetPublicId(), otherProj.GetPublicId())
+		require.NoError(t, err)
+		assert.Equal(t, &SessionQuota{Limits: []*SessionQuotaLimit{{ScopeId: scope.Global.String(), MaxActiveSessions: 2}}}, q)
+	})
+}
@@ -0,0 +1,66 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package job
+
+import (
+	"context"
+	"time"
+
+	"github.com/hashicorp/boundary/internal/errors"
+	"github.com/hashicorp/boundary/internal/kms"
+	"github.com/hashicorp/boundary/internal/scheduler"
+)
+
+// keyRotationJobInterval is how often scopes are checked for keys due for
+// rotation. Key rotation intervals are expected to be days rather than
+// minutes, so keys may be rotated up to this long after they are due.
+const keyRotationJobInterval = 5 * time.Minute
+
+type keyRotationJob struct {
+	kmsRepo *kms.Kms
+}
+
+func newKeyRotationJob(ctx context.Context, kmsRepo *kms.Kms) (*keyRotationJob, error) {
+	const op = "kms.newKeyRotationJob"
+	if kmsRepo == nil {
+		return nil, errors.New(ctx, errors.Internal, "nil kms repo", op, errors.WithoutEvent())
+	}
+
+	return &keyRotationJob{
+		kmsRepo: kmsRepo,
+	}, nil
+}
+
+// Status reports the job’s current status. We never change these values as
+// this job never finishes.
+func (r keyRotationJob) Status() scheduler.JobStatus {
+	return scheduler.JobStatus{}
+}
+
+// Run performs the required work depending on the implementation.
+// The context is used to notify the job that it should exit early.
+func (r *keyRotationJob) Run(ctx context.Context) error {
+	const op = "kmsjob.(keyRotationJob).Run"
+
+	if err := r.kmsRepo.RotateScheduledKeys(ctx); err != nil {
+		return errors.Wrap(ctx, err, op)
+	}
+
+	return nil
+}
+
+// NextRunIn returns the duration until the next job run should be scheduled.
+func (r *keyRotationJob) NextRunIn(_ context.Context) (time.Duration, error) {
+	return keyRotationJobInterval, nil
+}
+
+// Name is the unique name of the job.
+func (r keyRotationJob) Name() string {
+	return "kms-key-rotation-job"
+}
+
+// Description is the human readable description of the job.
+func (r keyRotationJob) Description() string {
+	return "Rotate the keys of scopes with a key rotation interval once they are due"
+}
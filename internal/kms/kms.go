@@ -15,6 +15,7 @@ import (
 
 	"github.com/hashicorp/boundary/internal/db"
 	"github.com/hashicorp/boundary/internal/errors"
+	"github.com/hashicorp/boundary/internal/event"
 	"github.com/hashicorp/boundary/internal/types/scope"
 	"github.com/hashicorp/go-dbw"
 	wrappingKms "github.com/hashicorp/go-kms-wrapping/extras/kms/v2"
@@ -694,7 +695,112 @@ keyLoop:
 	return false, err
 }
 
+// scopeKeyRotationPolicy is the key rotation policy of a scope whose keys are
+// due for rotation.
+type scopeKeyRotationPolicy struct {
+	ScopeId            string
+	Rewrap             bool
+	DestroyOldVersions bool
+}
+
+// RotateScheduledKeys rotates the keys of every scope with a key rotation
+// interval whose newest root key version is older than the interval. Depending
+// on the policy of the scope, existing data keys are rewrapped with the new
+// root key version and the destruction of all previous key versions is
+// started through DestroyKeyVersion. A failure to rotate the keys of a scope
+// does not prevent the keys of the other scopes from being rotated.
+// Options supported: withRandomReader
+func (k *Kms) RotateScheduledKeys(ctx context.Context, opt ...Option) error {
+	const op = "kms.(Kms).RotateScheduledKeys"
+
+	opts := getOpts(opt...)
+	rows, err := k.reader.Query(ctx, scopesDueForKeyRotationQuery, nil)
+	if err != nil {
+		return errors.Wrap(ctx, err, op, errors.WithMsg("failed to find scopes due for key rotation"))
+	}
+	defer rows.Close()
+	var policies []scopeKeyRotationPolicy
+	for rows.Next() {
+		var p scopeKeyRotationPolicy
+		if err := k.reader.ScanRows(ctx, rows, &p); err != nil {
+			return errors.Wrap(ctx, err, op, errors.WithMsg("failed to scan scope due for key rotation"))
+		}
+		policies = append(policies, p)
+	}
+	if err := rows.Err(); err != nil {
+		return errors.Wrap(ctx, err, op, errors.WithMsg("failed to get next scope due for key rotation"))
+	}
+
+	var retErr error
+	for _, p := range policies {
+		if err := k.RotateKeys(ctx, p.ScopeId, WithRewrap(p.Rewrap), WithRandomReader(opts.withRandomReader)); err != nil {
+			event.WriteError(ctx, op, err, event.WithInfoMsg("failed to rotate scope keys", "scope_id", p.ScopeId))
+			retErr = errors.Wrap(ctx, err, op, errors.WithMsg("failed to rotate keys of scope %q", p.ScopeId))
+			continue
+		}
+		event.WriteSysEvent(ctx, op, "rotated scope keys", "scope_id", p.ScopeId, "rewrap", p.Rewrap)
+		if !p.DestroyOldVersions {
+			continue
+		}
+		destroying, err := k.destroyPreviousKeyVersions(ctx, p.ScopeId)
+		if err != nil {
+			event.WriteError(ctx, op, err, event.WithInfoMsg("failed to destroy previous scope key versions", "scope_id", p.ScopeId))
+			retErr = errors.Wrap(ctx, err, op, errors.WithMsg("failed to destroy previous key versions of scope %q", p.ScopeId))
+			continue
+		}
+		if len(destroying) > 0 {
+			event.WriteSysEvent(ctx, op, "destroying previous scope key versions", "scope_id", p.ScopeId, "key_version_ids", destroying)
+		}
+	}
+	return retErr
+}
+
+// destroyPreviousKeyVersions starts the destruction of every key version in
+// the scope which is not the newest version of its key, skipping oplog key
+// versions and key versions already being destroyed. It returns the ids of the
+// key versions whose destruction was started.
+func (k *Kms) destroyPreviousKeyVersions(ctx context.Context, scopeId string) ([]string, error) {
+	const op = "kms.(Kms).destroyPreviousKeyVersions"
+
+	keys, err := k.underlying.ListKeys(ctx, scopeId)
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	jobs, err := k.ListDataKeyVersionDestructionJobs(ctx, scopeId)
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	alreadyDestroying := make(map[string]bool, len(jobs))
+	for _, j := range jobs {
+		alreadyDestroying[j.GetKeyId()] = true
+	}
+
+	var destroying []string
+	for _, key := range keys {
+		if key.Purpose == wrappingKms.KeyPurpose(KeyPurposeOplog.String()) {
+			continue
+		}
+		versions := slices.Clone(key.Versions)
+		slices.SortFunc(versions, func(i, j wrappingKms.KeyVersion) int {
+			return int(i.Version) - int(j.Version)
+		})
+		for i := 0; i < len(versions)-1; i++ {
+			if alreadyDestroying[versions[i].Id] {
+				continue
+			}
+			if _, err := k.DestroyKeyVersion(ctx, scopeId, versions[i].Id); err != nil {
+				return destroying, errors.Wrap(ctx, err, op)
+			}
+			destroying = append(destroying, versions[i].Id)
+		}
+	}
+	return destroying, nil
+}
+
 // VerifyGlobalRoot will verify that the global root wrapper is reasonable.
+// When an external root wrapper has been added, it also verifies that the
+// wrapper can decrypt the global root key, so that a misconfigured root KMS is
+// detected at startup rather than on first use.
 func (k *Kms) VerifyGlobalRoot(ctx context.Context) error {
 	const op = "kms.(Kms).VerifyGlobalRoot"
 	var keys []*rootKey
@@ -702,9 +808,16 @@ func (k *Kms) VerifyGlobalRoot(ctx context.Context) error {
 		return errors.Wrap(ctx, err, op)
 	}
 	for _, rk := range keys {
-		if rk.ScopeId == scope.Global.String() {
+		if rk.ScopeId != scope.Global.String() {
+			continue
+		}
+		if _, err := k.underlying.GetExternalRootWrapper(); err != nil {
 			return nil
 		}
+		if _, err := k.underlying.GetWrapper(ctx, scope.Global.String(), wrappingKms.KeyPurposeRootKey); err != nil {
+			return errors.Wrap(ctx, err, op, errors.WithCode(errors.Decrypt), errors.WithMsg("unable to decrypt global root key with the root kms"))
+		}
+		return nil
 	}
 	return errors.New(ctx, errors.MigrationIntegrity, op, "can't find global scoped root key")
 }
@@ -224,6 +224,15 @@ func TestKms_VerifyGlobalRoot(t *testing.T) {
 
 	require.NoError(kmsCache.CreateKeys(testCtx, "global"))
 	assert.NoError(kmsCache.VerifyGlobalRoot(testCtx))
+
+	// A kms whose root wrapper cannot decrypt the global root key fails
+	rw := db.New(conn)
+	otherKms, err := kms.New(testCtx, rw, rw)
+	require.NoError(err)
+	require.NoError(otherKms.AddExternalWrappers(testCtx, kms.WithRootWrapper(db.TestWrapper(t))))
+	err = otherKms.VerifyGlobalRoot(testCtx)
+	require.Error(err)
+	assert.True(errors.Match(errors.T(errors.Decrypt), err))
 }
 
 func TestKms_GetWrapper(t *testing.T) {
@@ -5,11 +5,14 @@ package kms
 
 import (
 	"context"
+	"strings"
 	"testing"
 	"time"
 
 	"github.com/hashicorp/boundary/internal/db"
+	"github.com/hashicorp/boundary/internal/db/db_test"
 	"github.com/hashicorp/boundary/internal/errors"
+	"github.com/hashicorp/boundary/internal/oplog"
 	wrappingKms "github.com/hashicorp/go-kms-wrapping/extras/kms/v2"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -935,6 +938,243 @@ func TestDestroyKeyVersion(t *testing.T) {
 	})
 }
 
+func TestRotateScheduledKeys(t *testing.T) {
+	t.Parallel()
+	testCtx := context.Background()
+	conn, _ := db.TestSetup(t, "postgres")
+	extWrapper := db.TestWrapper(t)
+	kmsCache := TestKms(t, conn, extWrapper)
+	err := kmsCache.CreateKeys(testCtx, "global")
+	require.NoError(t, err)
+	sqldb, err := conn.SqlDB(testCtx)
+	require.NoError(t, err)
+
+	countVersions := func(t *testing.T) map[wrappingKms.KeyPurpose]int {
+		keys, err := kmsCache.ListKeys(testCtx, "global")
+		require.NoError(t, err)
+		counts := make(map[wrappingKms.KeyPurpose]int, len(keys))
+		for _, key := range keys {
+			counts[key.Purpose] = len(key.Versions)
+		}
+		return counts
+	}
+
+	t.Run("does-nothing-without-a-rotation-interval", func(t *testing.T) {
+		before := countVersions(t)
+		require.NoError(t, kmsCache.RotateScheduledKeys(testCtx))
+		assert.Equal(t, before, countVersions(t))
+	})
+	t.Run("does-nothing-before-the-interval-elapsed", func(t *testing.T) {
+		_, err = sqldb.ExecContext(testCtx, "update iam_scope set key_rotation_interval_seconds=3600 where public_id='global'")
+		require.NoError(t, err)
+		before := countVersions(t)
+		require.NoError(t, kmsCache.RotateScheduledKeys(testCtx))
+		assert.Equal(t, before, countVersions(t))
+	})
+	t.Run("rotates-once-the-interval-elapsed", func(t *testing.T) {
+		_, err = sqldb.ExecContext(testCtx, "update iam_scope set key_rotation_interval_seconds=1 where public_id='global'")
+		require.NoError(t, err)
+		before := countVersions(t)
+		time.Sleep(time.Second)
+		require.NoError(t, kmsCache.RotateScheduledKeys(testCtx))
+		after := countVersions(t)
+		for purpose, n := range before {
+			assert.Equal(t, n+1, after[purpose], "purpose %q was not rotated", purpose)
+		}
+		// The keys were just rotated, so they are not due again
+		require.NoError(t, kmsCache.RotateScheduledKeys(testCtx))
+		assert.Equal(t, after, countVersions(t))
+	})
+	t.Run("destroys-previous-key-versions", func(t *testing.T) {
+		_, err = sqldb.ExecContext(testCtx, "update iam_scope set key_rotation_destroy_old_versions=true where public_id='global'")
+		require.NoError(t, err)
+		t.Cleanup(func() {
+			_, err = sqldb.ExecContext(testCtx, "truncate kms_data_key_version_destruction_job, kms_data_key_version_destruction_job_run CASCADE")
+			require.NoError(t, err)
+		})
+		time.Sleep(time.Second)
+		require.NoError(t, kmsCache.RotateScheduledKeys(testCtx))
+
+		jobs, err := kmsCache.ListDataKeyVersionDestructionJobs(testCtx, "global")
+		require.NoError(t, err)
+		destroying := make(map[string]bool, len(jobs))
+		for _, j := range jobs {
+			destroying[j.GetKeyId()] = true
+		}
+		keys, err := kmsCache.ListKeys(testCtx, "global")
+		require.NoError(t, err)
+		for _, key := range keys {
+			if key.Purpose == wrappingKms.KeyPurpose(KeyPurposeOplog.String()) {
+				assert.Greater(t, len(key.Versions), 1, "oplog key versions should not be destroyed")
+				continue
+			}
+			var newest wrappingKms.KeyVersion
+			for _, v := range key.Versions {
+				if v.Version > newest.Version {
+					newest = v
+				}
+			}
+			for _, v := range key.Versions {
+				if v.Id != newest.Id {
+					assert.True(t, destroying[v.Id], "key version %q of purpose %q should be destroyed", v.Id, key.Purpose)
+				}
+			}
+		}
+	})
+}
+
+func TestListOplogEntries(t *testing.T) {
+	t.Parallel()
+	testCtx := context.Background()
+	conn, _ := db.TestSetup(t, "postgres")
+	db.TestCreateTables(t, conn)
+	rw := db.New(conn)
+	extWrapper := db.TestWrapper(t)
+	kmsCache := TestKms(t, conn, extWrapper)
+	require.NoError(t, kmsCache.CreateKeys(testCtx, "global"))
+	oplogWrapper, err := kmsCache.GetWrapper(testCtx, "global", KeyPurposeOplog)
+	require.NoError(t, err)
+
+	// listCreated pages through the entries after the cursor and returns the
+	// ids of the test resources they created and the cursor of the last entry.
+	listCreated := func(t *testing.T, cursor OplogCursor) ([]string, OplogCursor) {
+		t.Helper()
+		var got []string
+		for {
+			entries, next, err := kmsCache.ListOplogEntries(testCtx, []string{"global"}, cursor, 2)
+			require.NoError(t, err)
+			if len(entries) == 0 {
+				assert.Equal(t, cursor, next)
+				return got, cursor
+			}
+			assert.Equal(t, entries[len(entries)-1].Id, next.EntryId)
+			cursor = next
+			for _, e := range entries {
+				if !strings.HasPrefix(e.AggregateName, "db_test_") {
+					continue
+				}
+				ops, err := e.Operations(testCtx)
+				require.NoError(t, err)
+				require.Len(t, ops, 1)
+				assert.Equal(t, oplog.OpType_OP_TYPE_CREATE, ops[0].GetOperationType())
+				for _, md := range e.Metadata {
+					if md.Key == "resource-public-id" {
+						got = append(got, md.Value)
+					}
+				}
+			}
+		}
+	}
+
+	var userIds []string
+	for i := 0; i < 3; i++ {
+		user, err := db_test.NewTestUser()
+		require.NoError(t, err)
+		require.NoError(t, rw.Create(testCtx, user, db.WithOplog(oplogWrapper, oplog.Metadata{
+			"resource-public-id": []string{user.PublicId},
+			"op-type":            []string{oplog.OpType_OP_TYPE_CREATE.String()},
+		})))
+		userIds = append(userIds, user.PublicId)
+	}
+
+	t.Run("missing-scope-ids", func(t *testing.T) {
+		_, _, err := kmsCache.ListOplogEntries(testCtx, nil, OplogCursor{}, 10)
+		require.Error(t, err)
+		assert.True(t, errors.Match(errors.T(errors.InvalidParameter), err))
+	})
+	t.Run("invalid-limit", func(t *testing.T) {
+		_, _, err := kmsCache.ListOplogEntries(testCtx, []string{"global"}, OplogCursor{}, 0)
+		require.Error(t, err)
+		assert.True(t, errors.Match(errors.T(errors.InvalidParameter), err))
+	})
+	t.Run("unknown-scope", func(t *testing.T) {
+		entries, _, err := kmsCache.ListOplogEntries(testCtx, []string{"o_1234567890"}, OplogCursor{}, 10)
+		require.NoError(t, err)
+		assert.Empty(t, entries)
+	})
+	t.Run("pages-through-entries", func(t *testing.T) {
+		got, _ := listCreated(t, OplogCursor{})
+		assert.Equal(t, userIds, got)
+	})
+	t.Run("late-commit", func(t *testing.T) {
+		_, cursor := listCreated(t, OplogCursor{})
+
+		// The user's entry is written before the car's, so it has the lower
+		// id, but its transaction commits after. The car is used since oplog
+		// writes for the same table are serialized by their ticket.
+		user, err := db_test.NewTestUser()
+		require.NoError(t, err)
+		written, release := make(chan struct{}), make(chan struct{})
+		done := make(chan error, 1)
+		go func() {
+			_, err := rw.DoTx(testCtx, 0, db.ExpBackoff{}, func(_ db.Reader, w db.Writer) error {
+				if err := w.Create(testCtx, user, db.WithOplog(oplogWrapper, oplog.Metadata{
+					"resource-public-id": []string{user.PublicId},
+					"op-type":            []string{oplog.OpType_OP_TYPE_CREATE.String()},
+				})); err != nil {
+					return err
+				}
+				close(written)
+				<-release
+				return nil
+			})
+			done <- err
+		}()
+		select {
+		case <-written:
+		case err := <-done:
+			require.FailNow(t, "transaction finished before releasing it", "error: %v", err)
+		}
+		car, err := db_test.NewTestCar()
+		require.NoError(t, err)
+		require.NoError(t, rw.Create(testCtx, car, db.WithOplog(oplogWrapper, oplog.Metadata{
+			"resource-public-id": []string{car.PublicId},
+			"op-type":            []string{oplog.OpType_OP_TYPE_CREATE.String()},
+		})))
+
+		got, next := listCreated(t, cursor)
+		assert.Empty(t, got)
+		assert.Equal(t, cursor, next)
+
+		close(release)
+		require.NoError(t, <-done)
+		got, _ = listCreated(t, next)
+		assert.Equal(t, []string{user.PublicId, car.PublicId}, got)
+	})
+}
+
+func TestParseOplogCursor(t *testing.T) {
+	t.Parallel()
+	testCtx := context.Background()
+	tests := []struct {
+		name    string
+		cursor  string
+		want    OplogCursor
+		wantErr bool
+	}{
+		{name: "valid", cursor: "1234.56", want: OplogCursor{TxId: 1234, EntryId: 56}},
+		{name: "zero", cursor: "0.0", want: OplogCursor{}},
+		{name: "entry-id-only", cursor: "56", wantErr: true},
+		{name: "bad-tx-id", cursor: "a.56", wantErr: true},
+		{name: "bad-entry-id", cursor: "1234.b", wantErr: true},
+		{name: "entry-id-overflow", cursor: "1234.4294967296", wantErr: true},
+		{name: "negative", cursor: "-1.56", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseOplogCursor(testCtx, tt.cursor)
+			if tt.wantErr {
+				require.Error(t, err)
+				assert.True(t, errors.Match(errors.T(errors.InvalidParameter), err))
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+			assert.Equal(t, tt.cursor, got.String())
+		})
+	}
+}
+
 func Test_RegisterTableRewrapFn(t *testing.T) {
 	rewrapFn := func(ctx context.Context, dataKeyVersionId, scopeId string, reader db.Reader, writer db.Writer, kms GetWrapperer) error {
 		return nil
@@ -0,0 +1,159 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package kms
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/boundary/internal/db"
+	"github.com/hashicorp/boundary/internal/errors"
+	"github.com/hashicorp/boundary/internal/event"
+	"github.com/hashicorp/boundary/internal/oplog"
+	"github.com/hashicorp/boundary/internal/oplog/store"
+	wrapping "github.com/hashicorp/go-kms-wrapping/v2"
+)
+
+// OplogCursor is a position in the oplog. Entries are listed in the order of
+// the transactions which wrote them, then in id order, since entry ids are
+// assigned when an entry is written rather than when its transaction commits.
+// The zero value is the position before the first entry.
+type OplogCursor struct {
+	// TxId is the id of the transaction which wrote the entry, or 0 for
+	// entries written before transaction ids were recorded.
+	TxId uint64
+	// EntryId is the id of the entry.
+	EntryId uint32
+}
+
+// String returns the cursor in the form parsed by ParseOplogCursor.
+func (c OplogCursor) String() string {
+	return fmt.Sprintf("%d.%d", c.TxId, c.EntryId)
+}
+
+// ParseOplogCursor parses a cursor returned by OplogCursor.String.
+func ParseOplogCursor(ctx context.Context, s string) (OplogCursor, error) {
+	const op = "kms.ParseOplogCursor"
+	txId, entryId, ok := strings.Cut(s, ".")
+	if !ok {
+		return OplogCursor{}, errors.New(ctx, errors.InvalidParameter, op, "malformed cursor")
+	}
+	tx, err := strconv.ParseUint(txId, 10, 63)
+	if err != nil {
+		return OplogCursor{}, errors.Wrap(ctx, err, op, errors.WithCode(errors.InvalidParameter), errors.WithMsg("malformed cursor transaction id"))
+	}
+	id, err := strconv.ParseUint(entryId, 10, 32)
+	if err != nil {
+		return OplogCursor{}, errors.Wrap(ctx, err, op, errors.WithCode(errors.InvalidParameter), errors.WithMsg("malformed cursor entry id"))
+	}
+	return OplogCursor{TxId: tx, EntryId: uint32(id)}, nil
+}
+
+// oplogPosition is a row of listOplogEntryPositionsQuery.
+type oplogPosition struct {
+	Id   uint32
+	Txid int64
+}
+
+// ListOplogEntries returns up to limit oplog entries written with the oplog
+// keys of the scopes which follow the after cursor, with their metadata and
+// decrypted data, and the cursor of the last entry returned. The after cursor
+// is returned when there are no entries.
+//
+// Entries written by transactions which are still in progress, or which are
+// newer than a transaction still in progress, are held back until those
+// transactions finish. Without this an entry of a transaction which commits
+// late could become visible behind the returned cursor and never be listed.
+//
+// An entry whose data cannot be decrypted is returned without data, so it does
+// not prevent later entries from being listed. No options are currently
+// supported.
+func (k *Kms) ListOplogEntries(ctx context.Context, scopeIds []string, after OplogCursor, limit int, _ ...Option) ([]*oplog.Entry, OplogCursor, error) {
+	const op = "kms.(Kms).ListOplogEntries"
+	if len(scopeIds) == 0 {
+		return nil, after, errors.New(ctx, errors.InvalidParameter, op, "missing scope ids")
+	}
+	if limit < 1 {
+		return nil, after, errors.New(ctx, errors.InvalidParameter, op, "limit must be greater than 0")
+	}
+
+	rows, err := k.reader.Query(ctx, listOplogEntryPositionsQuery, []any{scopeIds, int64(after.TxId), int64(after.EntryId), limit})
+	if err != nil {
+		return nil, after, errors.Wrap(ctx, err, op, errors.WithMsg("failed to query oplog entry positions"))
+	}
+	defer rows.Close()
+	var positions []oplogPosition
+	for rows.Next() {
+		var p oplogPosition
+		if err := k.reader.ScanRows(ctx, rows, &p); err != nil {
+			return nil, after, errors.Wrap(ctx, err, op, errors.WithMsg("failed to scan oplog entry position"))
+		}
+		positions = append(positions, p)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, after, errors.Wrap(ctx, err, op, errors.WithMsg("failed to get next oplog entry position"))
+	}
+	if len(positions) == 0 {
+		return nil, after, nil
+	}
+
+	entryIds := make([]uint32, 0, len(positions))
+	for _, p := range positions {
+		entryIds = append(entryIds, p.Id)
+	}
+	var found []*store.Entry
+	if err := k.reader.SearchWhere(ctx, &found, "id in (?)", []any{entryIds}, db.WithLimit(-1)); err != nil {
+		return nil, after, errors.Wrap(ctx, err, op)
+	}
+	entriesById := make(map[uint32]*store.Entry, len(found))
+	for _, e := range found {
+		entriesById[e.Id] = e
+	}
+	storeEntries := make([]*store.Entry, 0, len(positions))
+	for _, p := range positions {
+		if e, ok := entriesById[p.Id]; ok {
+			storeEntries = append(storeEntries, e)
+		}
+	}
+	last := positions[len(positions)-1]
+	next := OplogCursor{TxId: uint64(last.Txid), EntryId: last.Id}
+
+	var metadata []*store.Metadata
+	if err := k.reader.SearchWhere(ctx, &metadata, "entry_id in (?)", []any{entryIds}, db.WithLimit(-1), db.WithOrder("id")); err != nil {
+		return nil, after, errors.Wrap(ctx, err, op)
+	}
+	metadataByEntry := make(map[uint32][]*store.Metadata, len(storeEntries))
+	for _, md := range metadata {
+		metadataByEntry[md.EntryId] = append(metadataByEntry[md.EntryId], md)
+	}
+
+	wrappers := make(map[string]wrapping.Wrapper)
+	entries := make([]*oplog.Entry, 0, len(storeEntries))
+	for _, e := range storeEntries {
+		e.Metadata = metadataByEntry[e.Id]
+		entry := &oplog.Entry{Entry: e}
+		w, ok := wrappers[e.KeyId]
+		if !ok {
+			var err error
+			w, err = k.GetWrapper(ctx, e.ScopeId, KeyPurposeOplog, WithKeyId(e.KeyId))
+			if err != nil {
+				event.WriteError(ctx, op, err, event.WithInfoMsg(fmt.Sprintf("unable to get oplog wrapper for entry %d", e.Id)))
+			}
+			wrappers[e.KeyId] = w
+		}
+		if w != nil {
+			entry.Wrapper = w
+			if err := entry.DecryptData(ctx); err != nil {
+				event.WriteError(ctx, op, err, event.WithInfoMsg(fmt.Sprintf("unable to decrypt oplog entry %d", e.Id)))
+				e.Data = nil
+			}
+		} else {
+			e.Data = nil
+		}
+		entries = append(entries, entry)
+	}
+	return entries, next, nil
+}
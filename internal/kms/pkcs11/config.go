@@ -0,0 +1,112 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+// Package pkcs11 provides a wrapper which protects Boundary's externally
+// configured keys with an AES key held in a PKCS#11 token, such as a hardware
+// security module or SoftHSM.
+//
+// The wrapper uses envelope encryption: data is encrypted with a random data
+// key, and the data key is encrypted with the token key using AES-GCM. The
+// label of the token key is recorded as the key ID of the encrypted blob, so
+// blobs encrypted before the configured key label changed can still be
+// decrypted as long as the previous key remains on the token.
+package pkcs11
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/boundary/internal/errors"
+	"github.com/hashicorp/go-secure-stdlib/parseutil"
+)
+
+// The configuration keys accepted in a "pkcs11" kms block.
+const (
+	// ConfigLib is the path of the PKCS#11 module to load.
+	ConfigLib = "lib"
+	// ConfigSlot is the id of the slot holding the token. Either the slot or
+	// the token label must be set.
+	ConfigSlot = "slot"
+	// ConfigTokenLabel is the label of the token holding the key.
+	ConfigTokenLabel = "token_label"
+	// ConfigPin is the user pin of the token. It can refer to an environment
+	// variable or a file with env:// and file:// URLs.
+	ConfigPin = "pin"
+	// ConfigKeyLabel is the label of the AES key used to encrypt new data.
+	ConfigKeyLabel = "key_label"
+	// ConfigGenerateKey creates a 256-bit AES key with the key label when
+	// none is found on the token.
+	ConfigGenerateKey = "generate_key"
+)
+
+type config struct {
+	lib         string
+	slot        *uint
+	tokenLabel  string
+	pin         string
+	keyLabel    string
+	generateKey bool
+}
+
+// parseConfig validates the configuration of a "pkcs11" kms block.
+func parseConfig(in map[string]string) (*config, error) {
+	const op = "pkcs11.parseConfig"
+	c := &config{
+		lib:        strings.TrimSpace(in[ConfigLib]),
+		tokenLabel: strings.TrimSpace(in[ConfigTokenLabel]),
+		keyLabel:   strings.TrimSpace(in[ConfigKeyLabel]),
+	}
+	if c.lib == "" {
+		return nil, fmt.Errorf("%s: missing %q", op, ConfigLib)
+	}
+	if c.keyLabel == "" {
+		return nil, fmt.Errorf("%s: missing %q", op, ConfigKeyLabel)
+	}
+	if s := strings.TrimSpace(in[ConfigSlot]); s != "" {
+		slot, err := strconv.ParseUint(s, 0, 64)
+		if err != nil {
+			return nil, fmt.Errorf("%s: invalid %q: %w", op, ConfigSlot, err)
+		}
+		u := uint(slot)
+		c.slot = &u
+	}
+	switch {
+	case c.slot == nil && c.tokenLabel == "":
+		return nil, fmt.Errorf("%s: one of %q or %q must be set", op, ConfigSlot, ConfigTokenLabel)
+	case c.slot != nil && c.tokenLabel != "":
+		return nil, fmt.Errorf("%s: only one of %q or %q can be set", op, ConfigSlot, ConfigTokenLabel)
+	}
+
+	pin, err := parseutil.ParsePath(in[ConfigPin])
+	if err != nil && !errors.Is(err, parseutil.ErrNotAUrl) {
+		return nil, fmt.Errorf("%s: error reading %q: %w", op, ConfigPin, err)
+	}
+	c.pin = strings.TrimSpace(pin)
+	if c.pin == "" {
+		return nil, fmt.Errorf("%s: missing %q", op, ConfigPin)
+	}
+
+	if s := strings.TrimSpace(in[ConfigGenerateKey]); s != "" {
+		c.generateKey, err = strconv.ParseBool(s)
+		if err != nil {
+			return nil, fmt.Errorf("%s: invalid %q: %w", op, ConfigGenerateKey, err)
+		}
+	}
+	return c, nil
+}
+
+// metadata returns the configuration reported by SetConfig, without the pin.
+func (c *config) metadata() map[string]string {
+	md := map[string]string{
+		ConfigLib:      c.lib,
+		ConfigKeyLabel: c.keyLabel,
+	}
+	if c.slot != nil {
+		md[ConfigSlot] = strconv.FormatUint(uint64(*c.slot), 10)
+	}
+	if c.tokenLabel != "" {
+		md[ConfigTokenLabel] = c.tokenLabel
+	}
+	return md
+}
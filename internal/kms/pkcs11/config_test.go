@@ -0,0 +1,110 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package pkcs11
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseConfig(t *testing.T) {
+	t.Parallel()
+	pinPath := filepath.Join(t.TempDir(), "pin")
+	require.NoError(t, os.WriteFile(pinPath, []byte("1234\n"), 0o600))
+	slot := uint(3)
+
+	tests := []struct {
+		name    string
+		in      map[string]string
+		want    *config
+		wantErr string
+	}{
+		{
+			name: "token-label",
+			in: map[string]string{
+				ConfigLib:        "/usr/lib/softhsm/libsofthsm2.so",
+				ConfigTokenLabel: "boundary",
+				ConfigPin:        "1234",
+				ConfigKeyLabel:   "root",
+			},
+			want: &config{
+				lib:        "/usr/lib/softhsm/libsofthsm2.so",
+				tokenLabel: "boundary",
+				pin:        "1234",
+				keyLabel:   "root",
+			},
+		},
+		{
+			name: "slot-pin-file-generate",
+			in: map[string]string{
+				ConfigLib:         "/usr/lib/softhsm/libsofthsm2.so",
+				ConfigSlot:        "3",
+				ConfigPin:         "file://" + pinPath,
+				ConfigKeyLabel:    "root",
+				ConfigGenerateKey: "true",
+			},
+			want: &config{
+				lib:         "/usr/lib/softhsm/libsofthsm2.so",
+				slot:        &slot,
+				pin:         "1234",
+				keyLabel:    "root",
+				generateKey: true,
+			},
+		},
+		{
+			name:    "missing-lib",
+			in:      map[string]string{ConfigTokenLabel: "boundary", ConfigPin: "1234", ConfigKeyLabel: "root"},
+			wantErr: `missing "lib"`,
+		},
+		{
+			name:    "missing-key-label",
+			in:      map[string]string{ConfigLib: "lib.so", ConfigTokenLabel: "boundary", ConfigPin: "1234"},
+			wantErr: `missing "key_label"`,
+		},
+		{
+			name:    "missing-token",
+			in:      map[string]string{ConfigLib: "lib.so", ConfigPin: "1234", ConfigKeyLabel: "root"},
+			wantErr: `one of "slot" or "token_label" must be set`,
+		},
+		{
+			name:    "slot-and-token-label",
+			in:      map[string]string{ConfigLib: "lib.so", ConfigSlot: "1", ConfigTokenLabel: "boundary", ConfigPin: "1234", ConfigKeyLabel: "root"},
+			wantErr: `only one of "slot" or "token_label" can be set`,
+		},
+		{
+			name:    "invalid-slot",
+			in:      map[string]string{ConfigLib: "lib.so", ConfigSlot: "first", ConfigPin: "1234", ConfigKeyLabel: "root"},
+			wantErr: `invalid "slot"`,
+		},
+		{
+			name:    "missing-pin",
+			in:      map[string]string{ConfigLib: "lib.so", ConfigTokenLabel: "boundary", ConfigKeyLabel: "root"},
+			wantErr: `missing "pin"`,
+		},
+		{
+			name:    "invalid-generate-key",
+			in:      map[string]string{ConfigLib: "lib.so", ConfigTokenLabel: "boundary", ConfigPin: "1234", ConfigKeyLabel: "root", ConfigGenerateKey: "maybe"},
+			wantErr: `invalid "generate_key"`,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got, err := parseConfig(tt.in)
+			if tt.wantErr != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+			assert.NotContains(t, got.metadata(), ConfigPin)
+		})
+	}
+}
@@ -0,0 +1,341 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+//go:build cgo
+// +build cgo
+
+package pkcs11
+
+import (
+	"context"
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"sync"
+
+	wrapping "github.com/hashicorp/go-kms-wrapping/v2"
+	"github.com/miekg/pkcs11"
+)
+
+const (
+	// gcmIvSize is the size of the iv used when encrypting data keys with the
+	// token key.
+	gcmIvSize = 12
+	// gcmTagBits is the size of the authentication tag of the encrypted data
+	// keys.
+	gcmTagBits = 128
+	// generatedKeySize is the size in bytes of keys created on the token.
+	generatedKeySize = 32
+)
+
+var (
+	_ wrapping.Wrapper       = (*Wrapper)(nil)
+	_ wrapping.InitFinalizer = (*Wrapper)(nil)
+)
+
+// Wrapper encrypts data with an AES key held in a PKCS#11 token. A PKCS#11
+// session can only be used by one caller at a time, so operations on the
+// token are serialized.
+type Wrapper struct {
+	l       sync.Mutex
+	conf    *config
+	ctx     *pkcs11.Ctx
+	session pkcs11.SessionHandle
+	// keys caches the handle of the keys found on the token by label.
+	keys map[string]pkcs11.ObjectHandle
+}
+
+// NewWrapper returns an unconfigured PKCS#11 wrapper. SetConfig must be
+// called before it can be used.
+func NewWrapper() *Wrapper {
+	return &Wrapper{
+		keys: make(map[string]pkcs11.ObjectHandle),
+	}
+}
+
+// Type returns the type of the wrapper.
+func (w *Wrapper) Type(_ context.Context) (wrapping.WrapperType, error) {
+	return wrapping.WrapperTypePkcs11, nil
+}
+
+// KeyId returns the label of the key used to encrypt new data.
+func (w *Wrapper) KeyId(_ context.Context) (string, error) {
+	w.l.Lock()
+	defer w.l.Unlock()
+	if w.conf == nil {
+		return "", nil
+	}
+	return w.conf.keyLabel, nil
+}
+
+// SetConfig loads the PKCS#11 module, logs into the token and looks up the
+// key with the configured label, creating it if the configuration allows.
+// The configuration is passed through wrapping.WithConfigMap using the
+// Config* keys of this package.
+func (w *Wrapper) SetConfig(_ context.Context, opt ...wrapping.Option) (*wrapping.WrapperConfig, error) {
+	const op = "pkcs11.(Wrapper).SetConfig"
+	opts, err := wrapping.GetOpts(opt...)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	conf, err := parseConfig(opts.WithConfigMap)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	w.l.Lock()
+	defer w.l.Unlock()
+	if w.ctx != nil {
+		return nil, fmt.Errorf("%s: wrapper is already configured", op)
+	}
+	if err := w.open(conf); err != nil {
+		w.close()
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	w.conf = conf
+
+	if _, err := w.findKey(conf.keyLabel); err != nil {
+		if !errors.Is(err, errKeyNotFound) || !conf.generateKey {
+			w.close()
+			return nil, fmt.Errorf("%s: error finding key %q: %w", op, conf.keyLabel, err)
+		}
+		if err := w.generateKey(conf.keyLabel); err != nil {
+			w.close()
+			return nil, fmt.Errorf("%s: error generating key %q: %w", op, conf.keyLabel, err)
+		}
+	}
+
+	return &wrapping.WrapperConfig{Metadata: conf.metadata()}, nil
+}
+
+// Init is a no-op, the token is opened by SetConfig.
+func (w *Wrapper) Init(_ context.Context, _ ...wrapping.Option) error {
+	return nil
+}
+
+// Finalize logs out of the token and unloads the PKCS#11 module.
+func (w *Wrapper) Finalize(_ context.Context, _ ...wrapping.Option) error {
+	w.l.Lock()
+	defer w.l.Unlock()
+	w.close()
+	return nil
+}
+
+// Encrypt encrypts the plaintext with a random data key and encrypts the data
+// key with the token key.
+func (w *Wrapper) Encrypt(_ context.Context, plaintext []byte, opt ...wrapping.Option) (*wrapping.BlobInfo, error) {
+	const op = "pkcs11.(Wrapper).Encrypt"
+	env, err := wrapping.EnvelopeEncrypt(plaintext, opt...)
+	if err != nil {
+		return nil, fmt.Errorf("%s: error wrapping data: %w", op, err)
+	}
+
+	w.l.Lock()
+	defer w.l.Unlock()
+	if w.ctx == nil {
+		return nil, fmt.Errorf("%s: wrapper is not configured", op)
+	}
+	key, err := w.findKey(w.conf.keyLabel)
+	if err != nil {
+		return nil, fmt.Errorf("%s: error finding key %q: %w", op, w.conf.keyLabel, err)
+	}
+	iv := make([]byte, gcmIvSize)
+	if _, err := rand.Read(iv); err != nil {
+		return nil, fmt.Errorf("%s: error generating iv: %w", op, err)
+	}
+	params := pkcs11.NewGCMParams(iv, nil, gcmTagBits)
+	defer params.Free()
+	if err := w.ctx.EncryptInit(w.session, []*pkcs11.Mechanism{pkcs11.NewMechanism(pkcs11.CKM_AES_GCM, params)}, key); err != nil {
+		return nil, fmt.Errorf("%s: error initializing encryption: %w", op, err)
+	}
+	wrappedKey, err := w.ctx.Encrypt(w.session, env.Key)
+	if err != nil {
+		return nil, fmt.Errorf("%s: error encrypting data key: %w", op, err)
+	}
+	// Some tokens generate their own iv and ignore the one passed in.
+	if actual := params.IV(); len(actual) > 0 {
+		iv = actual
+	}
+
+	return &wrapping.BlobInfo{
+		Ciphertext: env.Ciphertext,
+		Iv:         env.Iv,
+		KeyInfo: &wrapping.KeyInfo{
+			Mechanism:  pkcs11.CKM_AES_GCM,
+			KeyId:      w.conf.keyLabel,
+			WrappedKey: append(iv, wrappedKey...),
+		},
+	}, nil
+}
+
+// Decrypt decrypts the data key with the token key whose label is the key ID
+// of the blob, and decrypts the ciphertext with the data key.
+func (w *Wrapper) Decrypt(_ context.Context, in *wrapping.BlobInfo, opt ...wrapping.Option) ([]byte, error) {
+	const op = "pkcs11.(Wrapper).Decrypt"
+	switch {
+	case in == nil:
+		return nil, fmt.Errorf("%s: missing blob info", op)
+	case in.GetKeyInfo() == nil:
+		return nil, fmt.Errorf("%s: missing key info", op)
+	case len(in.GetKeyInfo().GetWrappedKey()) <= gcmIvSize:
+		return nil, fmt.Errorf("%s: invalid wrapped key", op)
+	}
+
+	w.l.Lock()
+	defer w.l.Unlock()
+	if w.ctx == nil {
+		return nil, fmt.Errorf("%s: wrapper is not configured", op)
+	}
+	label := in.GetKeyInfo().GetKeyId()
+	if label == "" {
+		label = w.conf.keyLabel
+	}
+	key, err := w.findKey(label)
+	if err != nil {
+		return nil, fmt.Errorf("%s: error finding key %q: %w", op, label, err)
+	}
+	wrappedKey := in.GetKeyInfo().GetWrappedKey()
+	params := pkcs11.NewGCMParams(wrappedKey[:gcmIvSize], nil, gcmTagBits)
+	defer params.Free()
+	if err := w.ctx.DecryptInit(w.session, []*pkcs11.Mechanism{pkcs11.NewMechanism(pkcs11.CKM_AES_GCM, params)}, key); err != nil {
+		return nil, fmt.Errorf("%s: error initializing decryption: %w", op, err)
+	}
+	dataKey, err := w.ctx.Decrypt(w.session, wrappedKey[gcmIvSize:])
+	if err != nil {
+		return nil, fmt.Errorf("%s: error decrypting data key: %w", op, err)
+	}
+
+	pt, err := wrapping.EnvelopeDecrypt(&wrapping.EnvelopeInfo{
+		Ciphertext: in.GetCiphertext(),
+		Iv:         in.GetIv(),
+		Key:        dataKey,
+	}, opt...)
+	if err != nil {
+		return nil, fmt.Errorf("%s: error decrypting data: %w", op, err)
+	}
+	return pt, nil
+}
+
+// open loads the module and opens an authenticated session on the token.
+// The caller must hold the lock.
+func (w *Wrapper) open(conf *config) error {
+	w.ctx = pkcs11.New(conf.lib)
+	if w.ctx == nil {
+		return fmt.Errorf("unable to load PKCS#11 module %q", conf.lib)
+	}
+	if err := w.ctx.Initialize(); err != nil {
+		w.ctx.Destroy()
+		w.ctx = nil
+		return fmt.Errorf("error initializing PKCS#11 module: %w", err)
+	}
+	slot, err := w.findSlot(conf)
+	if err != nil {
+		return err
+	}
+	w.session, err = w.ctx.OpenSession(slot, pkcs11.CKF_SERIAL_SESSION|pkcs11.CKF_RW_SESSION)
+	if err != nil {
+		return fmt.Errorf("error opening session: %w", err)
+	}
+	if err := w.ctx.Login(w.session, pkcs11.CKU_USER, conf.pin); err != nil && !errors.Is(err, pkcs11.Error(pkcs11.CKR_USER_ALREADY_LOGGED_IN)) {
+		return fmt.Errorf("error logging into token: %w", err)
+	}
+	return nil
+}
+
+// close releases the session and the module. The caller must hold the lock.
+func (w *Wrapper) close() {
+	if w.ctx == nil {
+		return
+	}
+	if w.session != 0 {
+		_ = w.ctx.Logout(w.session)
+		_ = w.ctx.CloseSession(w.session)
+		w.session = 0
+	}
+	_ = w.ctx.Finalize()
+	w.ctx.Destroy()
+	w.ctx = nil
+	w.keys = make(map[string]pkcs11.ObjectHandle)
+}
+
+// findSlot returns the configured slot, or the slot of the token with the
+// configured label.
+func (w *Wrapper) findSlot(conf *config) (uint, error) {
+	slots, err := w.ctx.GetSlotList(true)
+	if err != nil {
+		return 0, fmt.Errorf("error listing slots: %w", err)
+	}
+	for _, s := range slots {
+		if conf.slot != nil {
+			if s == *conf.slot {
+				return s, nil
+			}
+			continue
+		}
+		info, err := w.ctx.GetTokenInfo(s)
+		if err != nil {
+			return 0, fmt.Errorf("error reading token info of slot %d: %w", s, err)
+		}
+		if info.Label == conf.tokenLabel {
+			return s, nil
+		}
+	}
+	if conf.slot != nil {
+		return 0, fmt.Errorf("no token found in slot %d", *conf.slot)
+	}
+	return 0, fmt.Errorf("no token found with label %q", conf.tokenLabel)
+}
+
+var errKeyNotFound = errors.New("key not found")
+
+// findKey returns the handle of the secret key with the label. The caller
+// must hold the lock.
+func (w *Wrapper) findKey(label string) (pkcs11.ObjectHandle, error) {
+	if h, ok := w.keys[label]; ok {
+		return h, nil
+	}
+	template := []*pkcs11.Attribute{
+		pkcs11.NewAttribute(pkcs11.CKA_CLASS, pkcs11.CKO_SECRET_KEY),
+		pkcs11.NewAttribute(pkcs11.CKA_LABEL, label),
+	}
+	if err := w.ctx.FindObjectsInit(w.session, template); err != nil {
+		return 0, err
+	}
+	handles, _, err := w.ctx.FindObjects(w.session, 2)
+	if finalErr := w.ctx.FindObjectsFinal(w.session); err == nil {
+		err = finalErr
+	}
+	switch {
+	case err != nil:
+		return 0, err
+	case len(handles) == 0:
+		return 0, errKeyNotFound
+	case len(handles) > 1:
+		return 0, errors.New("found more than one key with the label")
+	}
+	w.keys[label] = handles[0]
+	return handles[0], nil
+}
+
+// generateKey creates a non-extractable AES key on the token. The caller must
+// hold the lock.
+func (w *Wrapper) generateKey(label string) error {
+	template := []*pkcs11.Attribute{
+		pkcs11.NewAttribute(pkcs11.CKA_CLASS, pkcs11.CKO_SECRET_KEY),
+		pkcs11.NewAttribute(pkcs11.CKA_KEY_TYPE, pkcs11.CKK_AES),
+		pkcs11.NewAttribute(pkcs11.CKA_VALUE_LEN, generatedKeySize),
+		pkcs11.NewAttribute(pkcs11.CKA_LABEL, label),
+		pkcs11.NewAttribute(pkcs11.CKA_TOKEN, true),
+		pkcs11.NewAttribute(pkcs11.CKA_PRIVATE, true),
+		pkcs11.NewAttribute(pkcs11.CKA_SENSITIVE, true),
+		pkcs11.NewAttribute(pkcs11.CKA_EXTRACTABLE, false),
+		pkcs11.NewAttribute(pkcs11.CKA_ENCRYPT, true),
+		pkcs11.NewAttribute(pkcs11.CKA_DECRYPT, true),
+	}
+	h, err := w.ctx.GenerateKey(w.session, []*pkcs11.Mechanism{pkcs11.NewMechanism(pkcs11.CKM_AES_KEY_GEN, nil)}, template)
+	if err != nil {
+		return err
+	}
+	w.keys[label] = h
+	return nil
+}
@@ -0,0 +1,54 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+//go:build !cgo
+// +build !cgo
+
+package pkcs11
+
+import (
+	"context"
+	"errors"
+
+	wrapping "github.com/hashicorp/go-kms-wrapping/v2"
+)
+
+// ErrCgoRequired is returned when configuring the wrapper in a binary built
+// without cgo, which is required to load PKCS#11 modules.
+var ErrCgoRequired = errors.New("pkcs11: support for PKCS#11 requires a build with cgo enabled")
+
+var _ wrapping.Wrapper = (*Wrapper)(nil)
+
+// Wrapper is a placeholder for the PKCS#11 wrapper in binaries built without
+// cgo. It cannot be configured.
+type Wrapper struct{}
+
+// NewWrapper returns a wrapper whose SetConfig always fails.
+func NewWrapper() *Wrapper {
+	return &Wrapper{}
+}
+
+// Type returns the type of the wrapper.
+func (w *Wrapper) Type(_ context.Context) (wrapping.WrapperType, error) {
+	return wrapping.WrapperTypePkcs11, nil
+}
+
+// KeyId always returns an empty key id.
+func (w *Wrapper) KeyId(_ context.Context) (string, error) {
+	return "", nil
+}
+
+// SetConfig always returns ErrCgoRequired.
+func (w *Wrapper) SetConfig(_ context.Context, _ ...wrapping.Option) (*wrapping.WrapperConfig, error) {
+	return nil, ErrCgoRequired
+}
+
+// Encrypt always returns ErrCgoRequired.
+func (w *Wrapper) Encrypt(_ context.Context, _ []byte, _ ...wrapping.Option) (*wrapping.BlobInfo, error) {
+	return nil, ErrCgoRequired
+}
+
+// Decrypt always returns ErrCgoRequired.
+func (w *Wrapper) Decrypt(_ context.Context, _ *wrapping.BlobInfo, _ ...wrapping.Option) ([]byte, error) {
+	return nil, ErrCgoRequired
+}
@@ -0,0 +1,135 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+//go:build cgo
+// +build cgo
+
+package pkcs11
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	wrapping "github.com/hashicorp/go-kms-wrapping/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// softHsmLibPaths are the usual install locations of the SoftHSM module.
+var softHsmLibPaths = []string{
+	"/usr/lib/softhsm/libsofthsm2.so",
+	"/usr/lib/x86_64-linux-gnu/softhsm/libsofthsm2.so",
+	"/usr/lib64/pkcs11/libsofthsm2.so",
+	"/usr/local/lib/softhsm/libsofthsm2.so",
+	"/opt/homebrew/lib/softhsm/libsofthsm2.so",
+}
+
+// testSoftHsmToken initializes a SoftHSM token in a temporary directory and
+// returns the path of the module. The test is skipped when SoftHSM is not
+// installed. The module path can be set with BOUNDARY_TESTING_PKCS11_LIB.
+func testSoftHsmToken(t *testing.T, tokenLabel, pin string) string {
+	t.Helper()
+	lib := os.Getenv("BOUNDARY_TESTING_PKCS11_LIB")
+	if lib == "" {
+		for _, p := range softHsmLibPaths {
+			if _, err := os.Stat(p); err == nil {
+				lib = p
+				break
+			}
+		}
+	}
+	util, err := exec.LookPath("softhsm2-util")
+	if lib == "" || err != nil {
+		t.Skip("SoftHSM is not installed")
+	}
+
+	dir := t.TempDir()
+	conf := filepath.Join(dir, "softhsm2.conf")
+	require.NoError(t, os.Mkdir(filepath.Join(dir, "tokens"), 0o700))
+	require.NoError(t, os.WriteFile(conf, []byte(fmt.Sprintf("directories.tokendir = %s\nobjectstore.backend = file\n", filepath.Join(dir, "tokens"))), 0o600))
+	t.Setenv("SOFTHSM2_CONF", conf)
+
+	out, err := exec.Command(util, "--init-token", "--free", "--label", tokenLabel, "--pin", pin, "--so-pin", pin).CombinedOutput()
+	require.NoError(t, err, string(out))
+	return lib
+}
+
+func TestWrapper(t *testing.T) {
+	ctx := context.Background()
+	lib := testSoftHsmToken(t, "boundary", "1234")
+
+	newWrapper := func(t *testing.T, keyLabel string, generate bool) *Wrapper {
+		t.Helper()
+		w := NewWrapper()
+		_, err := w.SetConfig(ctx, wrapping.WithConfigMap(map[string]string{
+			ConfigLib:         lib,
+			ConfigTokenLabel:  "boundary",
+			ConfigPin:         "1234",
+			ConfigKeyLabel:    keyLabel,
+			ConfigGenerateKey: fmt.Sprintf("%t", generate),
+		}))
+		require.NoError(t, err)
+		t.Cleanup(func() { require.NoError(t, w.Finalize(ctx)) })
+		return w
+	}
+
+	t.Run("missing-key", func(t *testing.T) {
+		w := NewWrapper()
+		_, err := w.SetConfig(ctx, wrapping.WithConfigMap(map[string]string{
+			ConfigLib:        lib,
+			ConfigTokenLabel: "boundary",
+			ConfigPin:        "1234",
+			ConfigKeyLabel:   "missing",
+		}))
+		require.Error(t, err)
+		assert.ErrorIs(t, err, errKeyNotFound)
+	})
+
+	t.Run("wrong-pin", func(t *testing.T) {
+		w := NewWrapper()
+		_, err := w.SetConfig(ctx, wrapping.WithConfigMap(map[string]string{
+			ConfigLib:        lib,
+			ConfigTokenLabel: "boundary",
+			ConfigPin:        "4321",
+			ConfigKeyLabel:   "root",
+		}))
+		require.Error(t, err)
+	})
+
+	t.Run("round-trip-and-rotation", func(t *testing.T) {
+		w := newWrapper(t, "root-1", true)
+		keyId, err := w.KeyId(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, "root-1", keyId)
+
+		blob, err := w.Encrypt(ctx, []byte("secret"), wrapping.WithAad([]byte("aad")))
+		require.NoError(t, err)
+		assert.Equal(t, "root-1", blob.GetKeyInfo().GetKeyId())
+		pt, err := w.Decrypt(ctx, blob, wrapping.WithAad([]byte("aad")))
+		require.NoError(t, err)
+		assert.Equal(t, []byte("secret"), pt)
+		_, err = w.Decrypt(ctx, blob, wrapping.WithAad([]byte("other")))
+		require.Error(t, err)
+
+		// A wrapper configured with a new key label still decrypts data
+		// encrypted with the previous key.
+		require.NoError(t, w.Finalize(ctx))
+		rotated := newWrapper(t, "root-2", true)
+		pt, err = rotated.Decrypt(ctx, blob, wrapping.WithAad([]byte("aad")))
+		require.NoError(t, err)
+		assert.Equal(t, []byte("secret"), pt)
+		blob, err = rotated.Encrypt(ctx, []byte("secret"))
+		require.NoError(t, err)
+		assert.Equal(t, "root-2", blob.GetKeyInfo().GetKeyId())
+	})
+
+	t.Run("not-configured", func(t *testing.T) {
+		w := NewWrapper()
+		_, err := w.Encrypt(ctx, []byte("secret"))
+		require.Error(t, err)
+	})
+}
@@ -70,4 +70,49 @@ having
 	// with a specific data key version ID in a table. The interpolated
 	// variable is the table name.
 	findAffectedRowsForKeyQueryTemplate = `select count(*) from %q where key_id=?`
+	// scopesDueForKeyRotationQuery returns the key rotation policy of all
+	// scopes whose newest root key version is older than the key rotation
+	// interval of the scope.
+	scopesDueForKeyRotationQuery = `
+select
+	s.public_id                                          as scope_id,
+	coalesce(s.key_rotation_rewrap, false)               as rewrap,
+	coalesce(s.key_rotation_destroy_old_versions, false) as destroy_old_versions
+from
+	iam_scope            s
+inner join
+	kms_root_key         rk
+	on rk.scope_id=s.public_id
+inner join
+	kms_root_key_version rkv
+	on rkv.root_key_id=rk.private_id
+where
+	s.key_rotation_interval_seconds is not null
+group by
+	s.public_id
+having
+	max(rkv.create_time) + make_interval(secs => s.key_rotation_interval_seconds) <= now()
+order by
+	s.public_id
+`
+
+	// listOplogEntryPositionsQuery lists the positions of the oplog entries
+	// of the scopes after the given position, in transaction then id order.
+	// Only entries written by transactions older than the oldest transaction
+	// still in progress are listed, since a transaction in progress can still
+	// commit entries which would be ordered before the ones it listed.
+	listOplogEntryPositionsQuery = `
+select
+	id, coalesce(txid, 0) as txid
+from
+	oplog_entry
+where
+	scope_id in (?)
+	and (coalesce(txid, 0), id) > (?, ?)
+	and coalesce(txid, 0) < txid_snapshot_xmin(txid_current_snapshot())
+order by
+	coalesce(txid, 0), id
+limit
+	?
+`
 )
@@ -48,6 +48,10 @@ type Entry struct {
 // Metadata provides meta information about the Entry
 type Metadata map[string][]string
 
+// ActorMetadataKey is the Metadata key for the id of the user whose request
+// wrote the Entry.
+const ActorMetadataKey = "actor"
+
 // NewEntry creates a new Entry
 func NewEntry(ctx context.Context, aggregateName string, metadata Metadata, wrapper wrapping.Wrapper, ticketer Ticketer) (*Entry, error) {
 	const op = "oplog.NewEntry"
@@ -152,6 +156,31 @@ func (e *Entry) UnmarshalData(ctx context.Context, types *TypeCatalog) ([]Messag
 	return msgs, nil
 }
 
+// Operations returns the operations in the data attribute without unmarshaling
+// their messages, so the entry can be inspected without a TypeCatalog for the
+// message types.  The data must be decrypted first.
+func (e *Entry) Operations(ctx context.Context) ([]*AnyOperation, error) {
+	const op = "oplog.(Entry).Operations"
+	if len(e.Data) == 0 {
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "missing data")
+	}
+	ops := []*AnyOperation{}
+	queue := Queue{
+		Buffer: *bytes.NewBuffer(e.Data),
+	}
+	for {
+		item, err := queue.removeOperation(ctx)
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, errors.Wrap(ctx, err, op, errors.WithMsg("error removing operation from queue"))
+		}
+		ops = append(ops, item)
+	}
+	return ops, nil
+}
+
 func convertToDbwOpts(ctx context.Context, opts *OperationOptions) ([]dbw.Option, error) {
 	const op = "oplog.convertToDbwOpts"
 	if opts == nil {
@@ -9,6 +9,7 @@ import (
 	"testing"
 
 	"github.com/hashicorp/boundary/internal/oplog/oplog_test"
+	"github.com/hashicorp/boundary/internal/oplog/store"
 	dbassert "github.com/hashicorp/dbassert/gorm"
 	"github.com/hashicorp/go-dbw"
 	"github.com/stretchr/testify/assert"
@@ -336,6 +337,41 @@ func Test_UnmarshalData(t *testing.T) {
 	})
 }
 
+func Test_Operations(t *testing.T) {
+	t.Parallel()
+	testCtx := context.Background()
+
+	t.Run("valid", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		queue := Queue{}
+		user := &oplog_test.TestUser{
+			Id:   1,
+			Name: "alice",
+		}
+		require.NoError(queue.add(testCtx, user, "user", OpType_OP_TYPE_CREATE))
+		require.NoError(queue.add(testCtx, user, "user", OpType_OP_TYPE_UPDATE, WithFieldMaskPaths([]string{"Name"}), WithSetToNullPaths([]string{"Email"})))
+		entry := &Entry{Entry: &store.Entry{Data: queue.Bytes()}}
+
+		ops, err := entry.Operations(testCtx)
+		require.NoError(err)
+		require.Len(ops, 2)
+		assert.Equal("user", ops[0].GetTypeName())
+		assert.Equal(OpType_OP_TYPE_CREATE, ops[0].GetOperationType())
+		assert.Equal("user", ops[1].GetTypeName())
+		assert.Equal(OpType_OP_TYPE_UPDATE, ops[1].GetOperationType())
+		assert.Equal([]string{"Name"}, ops[1].GetFieldMask().GetPaths())
+		assert.Equal([]string{"Email"}, ops[1].GetNullMask().GetPaths())
+	})
+
+	t.Run("no data", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		entry := &Entry{Entry: &store.Entry{}}
+		_, err := entry.Operations(testCtx)
+		require.Error(err)
+		assert.Equal("oplog.(Entry).Operations: missing data: parameter violation: error #100", err.Error())
+	})
+}
+
 // Test_Replay provides some basic unit tests for replaying entries
 func Test_Replay(t *testing.T) {
 	testCtx := context.Background()
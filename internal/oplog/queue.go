@@ -121,21 +121,12 @@ func (q *Queue) remove(ctx context.Context) (*queueEntry, error) {
 	if q.Catalog == nil {
 		return nil, errors.New(ctx, errors.InvalidParameter, op, "nil catalog")
 	}
-	q.mx.Lock()
-	defer q.mx.Unlock()
-	var n uint32
-	err := binary.Read(q, binary.LittleEndian, &n)
+	msg, err := q.removeOperation(ctx)
 	if err == io.EOF {
 		return nil, err // intentionally not wrapping error, return io.EOF so client can handle it correctly
 	}
 	if err != nil {
-		return nil, errors.New(ctx, errors.Io, op, "binary read error", errors.WithWrap(err))
-	}
-	data := q.Next(int(n))
-	msg := new(AnyOperation)
-	err = proto.Unmarshal(data, msg)
-	if err != nil {
-		return nil, errors.New(ctx, errors.Decode, op, "error unmarshaling message", errors.WithWrap(err))
+		return nil, errors.Wrap(ctx, err, op)
 	}
 	if msg.Value == nil {
 		return nil, nil
@@ -168,3 +159,26 @@ func (q *Queue) remove(ctx context.Context) (*queueEntry, error) {
 		operationOptions: msg.GetOptions(),
 	}, nil
 }
+
+// removeOperation removes the next operation from the queue without
+// unmarshaling its message, and returns EOF if the queue is empty. Unlike
+// remove, it does not require a Catalog.
+func (q *Queue) removeOperation(ctx context.Context) (*AnyOperation, error) {
+	const op = "oplog.(Queue).removeOperation"
+	q.mx.Lock()
+	defer q.mx.Unlock()
+	var n uint32
+	err := binary.Read(q, binary.LittleEndian, &n)
+	if err == io.EOF {
+		return nil, err // intentionally not wrapping error, return io.EOF so client can handle it correctly
+	}
+	if err != nil {
+		return nil, errors.New(ctx, errors.Io, op, "binary read error", errors.WithWrap(err))
+	}
+	data := q.Next(int(n))
+	msg := new(AnyOperation)
+	if err := proto.Unmarshal(data, msg); err != nil {
+		return nil, errors.New(ctx, errors.Decode, op, "error unmarshaling message", errors.WithWrap(err))
+	}
+	return msg, nil
+}
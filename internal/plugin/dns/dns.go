@@ -0,0 +1,510 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+// Package dns provides a built-in, in-process host plugin that discovers hosts
+// from DNS. Host sets are populated from SRV records, in which case every SRV
+// target becomes a host, from A/AAAA records for a list of hostnames, or from
+// every name with A/AAAA records under a zone, which is enumerated with a zone
+// transfer. Names that are not fully qualified are resolved relative to the
+// zone configured on the host catalog.
+//
+// The plugin keeps no state of its own: every ListHosts call performs fresh
+// lookups using the attributes of the catalog and sets it is given, so it is
+// driven entirely by the set sync job.
+package dns
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"sort"
+	"strings"
+	"time"
+
+	plgpb "github.com/hashicorp/boundary/sdk/pbs/plugin"
+	mdns "github.com/miekg/dns"
+	"github.com/mitchellh/mapstructure"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+const (
+	// PluginName is the name the DNS host plugin is registered with.
+	PluginName = "dns"
+
+	resolverAttrField   = "resolver"
+	zoneAttrField       = "zone"
+	timeoutAttrField    = "timeout"
+	srvRecordsAttrField = "srv_records"
+	hostnamesAttrField  = "hostnames"
+	zonesAttrField      = "zones"
+
+	// catalogZoneName refers to the zone configured on the catalog, as "@"
+	// does in a zone file.
+	catalogZoneName = "@"
+
+	defaultDnsPort = "53"
+	defaultTimeout = 5 * time.Second
+)
+
+var _ plgpb.HostPluginServiceServer = (*DnsPlugin)(nil)
+
+// catalogAttributes are the attributes accepted by a DNS host catalog.
+type catalogAttributes struct {
+	// Resolver is the address of the DNS server used for lookups, in host or
+	// host:port form. If empty, the system resolver is used.
+	Resolver string `mapstructure:"resolver"`
+	// Zone is appended to any set name that is not fully qualified.
+	Zone string `mapstructure:"zone"`
+	// Timeout bounds each individual lookup, as a duration string.
+	Timeout string `mapstructure:"timeout"`
+}
+
+// setAttributes are the attributes accepted by a DNS host set.
+type setAttributes struct {
+	// SrvRecords are SRV names, such as _ssh._tcp, whose targets become hosts.
+	SrvRecords []string `mapstructure:"srv_records"`
+	// Hostnames are names whose A/AAAA records become hosts.
+	Hostnames []string `mapstructure:"hostnames"`
+	// Zones are zones whose names with A/AAAA records become hosts. The zones
+	// are enumerated with a zone transfer from the catalog's resolver.
+	Zones []string `mapstructure:"zones"`
+}
+
+// DnsPlugin is a host plugin that discovers hosts using DNS.
+type DnsPlugin struct {
+	plgpb.UnimplementedHostPluginServiceServer
+}
+
+// NewDnsPlugin returns a new DNS host plugin.
+func NewDnsPlugin() *DnsPlugin {
+	return &DnsPlugin{}
+}
+
+// newResolver returns a net.Resolver that sends all queries to the catalog's
+// resolver address, or the system resolver if none is configured.
+func newResolver(attrs *catalogAttributes) *net.Resolver {
+	if attrs.Resolver == "" {
+		return net.DefaultResolver
+	}
+	addr := resolverAddress(attrs.Resolver)
+	return &net.Resolver{
+		PreferGo: true,
+		Dial: func(ctx context.Context, network, _ string) (net.Conn, error) {
+			var d net.Dialer
+			return d.DialContext(ctx, network, addr)
+		},
+	}
+}
+
+// resolverAddress adds the default DNS port to addr if it has none.
+func resolverAddress(addr string) string {
+	if _, _, err := net.SplitHostPort(addr); err == nil {
+		return addr
+	}
+	return net.JoinHostPort(strings.Trim(addr, "[]"), defaultDnsPort)
+}
+
+// NormalizeCatalogData is called before a catalog is created or updated and
+// trims surrounding whitespace from the catalog attributes.
+func (p *DnsPlugin) NormalizeCatalogData(_ context.Context, req *plgpb.NormalizeCatalogDataRequest) (*plgpb.NormalizeCatalogDataResponse, error) {
+	if req.GetAttributes() == nil {
+		return &plgpb.NormalizeCatalogDataResponse{}, nil
+	}
+	m := req.GetAttributes().AsMap()
+	for _, f := range []string{resolverAttrField, zoneAttrField, timeoutAttrField} {
+		if v, ok := m[f].(string); ok {
+			m[f] = strings.TrimSpace(v)
+		}
+	}
+	attrs, err := structpb.NewStruct(m)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "error normalizing catalog attributes: %s", err)
+	}
+	return &plgpb.NormalizeCatalogDataResponse{Attributes: attrs}, nil
+}
+
+// OnCreateCatalog validates the attributes of a new catalog.
+func (p *DnsPlugin) OnCreateCatalog(_ context.Context, req *plgpb.OnCreateCatalogRequest) (*plgpb.OnCreateCatalogResponse, error) {
+	if req.GetCatalog() == nil {
+		return nil, status.Error(codes.InvalidArgument, "catalog is nil")
+	}
+	if _, err := getCatalogAttributes(req.GetCatalog().GetAttributes()); err != nil {
+		return nil, err
+	}
+	return &plgpb.OnCreateCatalogResponse{}, nil
+}
+
+// OnUpdateCatalog validates the attributes of an updated catalog.
+func (p *DnsPlugin) OnUpdateCatalog(_ context.Context, req *plgpb.OnUpdateCatalogRequest) (*plgpb.OnUpdateCatalogResponse, error) {
+	if req.GetNewCatalog() == nil {
+		return nil, status.Error(codes.InvalidArgument, "new catalog is nil")
+	}
+	if _, err := getCatalogAttributes(req.GetNewCatalog().GetAttributes()); err != nil {
+		return nil, err
+	}
+	return &plgpb.OnUpdateCatalogResponse{}, nil
+}
+
+// OnDeleteCatalog is a no-op since the plugin keeps no state.
+func (p *DnsPlugin) OnDeleteCatalog(context.Context, *plgpb.OnDeleteCatalogRequest) (*plgpb.OnDeleteCatalogResponse, error) {
+	return &plgpb.OnDeleteCatalogResponse{}, nil
+}
+
+// OnCreateSet validates the attributes of a new set.
+func (p *DnsPlugin) OnCreateSet(_ context.Context, req *plgpb.OnCreateSetRequest) (*plgpb.OnCreateSetResponse, error) {
+	if req.GetSet() == nil {
+		return nil, status.Error(codes.InvalidArgument, "set is nil")
+	}
+	setAttrs, err := getSetAttributes(req.GetSet().GetAttributes())
+	if err != nil {
+		return nil, err
+	}
+	if req.GetCatalog() != nil {
+		catAttrs, err := getCatalogAttributes(req.GetCatalog().GetAttributes())
+		if err != nil {
+			return nil, err
+		}
+		if err := checkZones(catAttrs, setAttrs); err != nil {
+			return nil, err
+		}
+	}
+	return &plgpb.OnCreateSetResponse{}, nil
+}
+
+// OnUpdateSet validates the attributes of an updated set.
+func (p *DnsPlugin) OnUpdateSet(_ context.Context, req *plgpb.OnUpdateSetRequest) (*plgpb.OnUpdateSetResponse, error) {
+	if req.GetNewSet() == nil {
+		return nil, status.Error(codes.InvalidArgument, "new set is nil")
+	}
+	setAttrs, err := getSetAttributes(req.GetNewSet().GetAttributes())
+	if err != nil {
+		return nil, err
+	}
+	if req.GetCatalog() != nil {
+		catAttrs, err := getCatalogAttributes(req.GetCatalog().GetAttributes())
+		if err != nil {
+			return nil, err
+		}
+		if err := checkZones(catAttrs, setAttrs); err != nil {
+			return nil, err
+		}
+	}
+	return &plgpb.OnUpdateSetResponse{}, nil
+}
+
+// OnDeleteSet is a no-op since the plugin keeps no state.
+func (p *DnsPlugin) OnDeleteSet(context.Context, *plgpb.OnDeleteSetRequest) (*plgpb.OnDeleteSetResponse, error) {
+	return &plgpb.OnDeleteSetResponse{}, nil
+}
+
+// ListHosts resolves the DNS records of every requested set and returns one
+// host per distinct hostname, ordered by hostname. A hostname found through more than one set is
+// returned once with all of the matching set ids.
+func (p *DnsPlugin) ListHosts(ctx context.Context, req *plgpb.ListHostsRequest) (*plgpb.ListHostsResponse, error) {
+	if req.GetCatalog() == nil {
+		return nil, status.Error(codes.InvalidArgument, "catalog is nil")
+	}
+	catAttrs, err := getCatalogAttributes(req.GetCatalog().GetAttributes())
+	if err != nil {
+		return nil, err
+	}
+	timeout := defaultTimeout
+	if catAttrs.Timeout != "" {
+		// Already validated by getCatalogAttributes.
+		timeout, _ = time.ParseDuration(catAttrs.Timeout)
+	}
+	r := newResolver(catAttrs)
+
+	hosts := make(map[string]*plgpb.ListHostsResponseHost)
+	for _, set := range req.GetSets() {
+		if set.GetId() == "" {
+			return nil, status.Error(codes.InvalidArgument, "set is missing id")
+		}
+		setAttrs, err := getSetAttributes(set.GetAttributes())
+		if err != nil {
+			return nil, err
+		}
+		if err := checkZones(catAttrs, setAttrs); err != nil {
+			return nil, err
+		}
+
+		var names []string
+		for _, srv := range setAttrs.SrvRecords {
+			targets, err := lookupSrv(ctx, r, qualify(srv, catAttrs.Zone), timeout)
+			if err != nil {
+				return nil, err
+			}
+			names = append(names, targets...)
+		}
+		for _, hn := range setAttrs.Hostnames {
+			names = append(names, qualify(hn, catAttrs.Zone))
+		}
+		// Addresses found in a zone transfer are used as is rather than
+		// being looked up again.
+		zoneAddrs := make(map[string][]string)
+		for _, z := range setAttrs.Zones {
+			found, err := transferZone(resolverAddress(catAttrs.Resolver), zoneName(z, catAttrs.Zone), timeout)
+			if err != nil {
+				return nil, err
+			}
+			for name, addrs := range found {
+				zoneAddrs[name] = addrs
+				names = append(names, name)
+			}
+		}
+
+		for _, name := range names {
+			externalId := canonicalName(name)
+			if h, ok := hosts[externalId]; ok {
+				if h.SetIds[len(h.SetIds)-1] != set.GetId() {
+					h.SetIds = append(h.SetIds, set.GetId())
+				}
+				continue
+			}
+			addrs, ok := zoneAddrs[externalId]
+			if !ok {
+				addrs, err = lookupAddrs(ctx, r, name, timeout)
+				if err != nil {
+					return nil, err
+				}
+			}
+			if len(addrs) == 0 {
+				continue
+			}
+			hosts[externalId] = &plgpb.ListHostsResponseHost{
+				ExternalId:   externalId,
+				ExternalName: externalId,
+				IpAddresses:  addrs,
+				DnsNames:     []string{externalId},
+				SetIds:       []string{set.GetId()},
+			}
+		}
+	}
+
+	resp := &plgpb.ListHostsResponse{}
+	for _, h := range hosts {
+		resp.Hosts = append(resp.Hosts, h)
+	}
+	// Results are sorted since SRV records of equal priority are returned in
+	// a random order.
+	sort.Slice(resp.Hosts, func(i, j int) bool {
+		return resp.Hosts[i].GetExternalId() < resp.Hosts[j].GetExternalId()
+	})
+	return resp, nil
+}
+
+// lookupSrv returns the targets of the SRV records for name. A name without
+// SRV records results in no targets rather than an error.
+func lookupSrv(ctx context.Context, r *net.Resolver, name string, timeout time.Duration) ([]string, error) {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+	_, srvs, err := r.LookupSRV(ctx, "", "", name)
+	if err != nil {
+		if isNotFound(err) {
+			return nil, nil
+		}
+		return nil, status.Errorf(codes.Unavailable, "error looking up SRV records for %q: %s", name, err)
+	}
+	ret := make([]string, 0, len(srvs))
+	for _, srv := range srvs {
+		// A target of "." explicitly indicates the service is unavailable.
+		if srv.Target == "" || srv.Target == "." {
+			continue
+		}
+		ret = append(ret, srv.Target)
+	}
+	return ret, nil
+}
+
+// lookupAddrs returns the sorted A and AAAA addresses for name. A name with no
+// addresses results in an empty slice rather than an error.
+func lookupAddrs(ctx context.Context, r *net.Resolver, name string, timeout time.Duration) ([]string, error) {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+	ips, err := r.LookupIPAddr(ctx, name)
+	if err != nil {
+		if isNotFound(err) {
+			return nil, nil
+		}
+		return nil, status.Errorf(codes.Unavailable, "error looking up addresses for %q: %s", name, err)
+	}
+	ret := make([]string, 0, len(ips))
+	for _, ip := range ips {
+		ret = append(ret, ip.IP.String())
+	}
+	sort.Strings(ret)
+	return ret, nil
+}
+
+// transferZone enumerates zone with a zone transfer (AXFR) from the DNS server
+// at addr and returns the sorted A and AAAA addresses of every name in the
+// zone, keyed by canonical name. Wildcard names are skipped since they do not
+// identify a host.
+func transferZone(addr, zone string, timeout time.Duration) (map[string][]string, error) {
+	m := new(mdns.Msg)
+	m.SetAxfr(mdns.Fqdn(zone))
+	t := &mdns.Transfer{DialTimeout: timeout, ReadTimeout: timeout, WriteTimeout: timeout}
+	envs, err := t.In(m, addr)
+	if err != nil {
+		return nil, status.Errorf(codes.Unavailable, "error transferring zone %q: %s", zone, err)
+	}
+	ret := make(map[string][]string)
+	for env := range envs {
+		if env.Error != nil {
+			return nil, status.Errorf(codes.Unavailable, "error transferring zone %q: %s", zone, env.Error)
+		}
+		for _, rr := range env.RR {
+			var ip net.IP
+			switch v := rr.(type) {
+			case *mdns.A:
+				ip = v.A
+			case *mdns.AAAA:
+				ip = v.AAAA
+			default:
+				continue
+			}
+			name := canonicalName(rr.Header().Name)
+			if strings.HasPrefix(name, "*.") {
+				continue
+			}
+			ret[name] = append(ret[name], ip.String())
+		}
+	}
+	for _, addrs := range ret {
+		sort.Strings(addrs)
+	}
+	return ret, nil
+}
+
+func isNotFound(err error) bool {
+	dnsErr, ok := err.(*net.DNSError)
+	return ok && dnsErr.IsNotFound
+}
+
+// qualify appends zone to name unless name is already fully qualified, which
+// is indicated by a trailing dot, or no zone is configured.
+func qualify(name, zone string) string {
+	if strings.HasSuffix(name, ".") || zone == "" {
+		return name
+	}
+	return strings.TrimSuffix(name, ".") + "." + strings.Trim(zone, ".") + "."
+}
+
+// zoneName returns the zone to transfer for a zone set attribute value. The
+// value @ refers to the catalog zone; any other value is qualified like a
+// hostname.
+func zoneName(name, zone string) string {
+	if name == catalogZoneName {
+		return zone
+	}
+	return qualify(name, zone)
+}
+
+// checkZones returns an error if the set enumerates zones that cannot be
+// transferred with the catalog's attributes. A zone transfer needs an
+// explicit resolver since the system resolver's address is not known.
+func checkZones(catAttrs *catalogAttributes, setAttrs *setAttributes) error {
+	if len(setAttrs.Zones) == 0 {
+		return nil
+	}
+	if catAttrs.Resolver == "" {
+		return status.Errorf(codes.InvalidArgument, "attributes.%s: the catalog must have a %s to transfer zones from", zonesAttrField, resolverAttrField)
+	}
+	for _, z := range setAttrs.Zones {
+		if z == catalogZoneName && catAttrs.Zone == "" {
+			return status.Errorf(codes.InvalidArgument, "attributes.%s: %s requires the catalog to have a %s", zonesAttrField, catalogZoneName, zoneAttrField)
+		}
+	}
+	return nil
+}
+
+// canonicalName lower cases name and removes any trailing dot so the same host
+// always produces the same external id.
+func canonicalName(name string) string {
+	return strings.ToLower(strings.TrimSuffix(name, "."))
+}
+
+func getCatalogAttributes(in *structpb.Struct) (*catalogAttributes, error) {
+	attrs := new(catalogAttributes)
+	if in == nil {
+		return attrs, nil
+	}
+	m := in.AsMap()
+	if err := checkUnknownFields(m, resolverAttrField, zoneAttrField, timeoutAttrField); err != nil {
+		return nil, err
+	}
+	if err := decode(m, attrs); err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "error decoding catalog attributes: %s", err)
+	}
+	if attrs.Resolver != "" {
+		host, _, err := net.SplitHostPort(resolverAddress(attrs.Resolver))
+		if err != nil || host == "" {
+			return nil, status.Errorf(codes.InvalidArgument, "attributes.%s: invalid resolver address %q", resolverAttrField, attrs.Resolver)
+		}
+	}
+	if attrs.Timeout != "" {
+		d, err := time.ParseDuration(attrs.Timeout)
+		if err != nil || d <= 0 {
+			return nil, status.Errorf(codes.InvalidArgument, "attributes.%s: must be a positive duration", timeoutAttrField)
+		}
+	}
+	return attrs, nil
+}
+
+func getSetAttributes(in *structpb.Struct) (*setAttributes, error) {
+	attrs := new(setAttributes)
+	if in != nil {
+		m := in.AsMap()
+		if err := checkUnknownFields(m, srvRecordsAttrField, hostnamesAttrField, zonesAttrField); err != nil {
+			return nil, err
+		}
+		if err := decode(m, attrs); err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "error decoding set attributes: %s", err)
+		}
+	}
+	if len(attrs.SrvRecords) == 0 && len(attrs.Hostnames) == 0 && len(attrs.Zones) == 0 {
+		return nil, status.Errorf(codes.InvalidArgument, "attributes: at least one of %s, %s or %s must be set", srvRecordsAttrField, hostnamesAttrField, zonesAttrField)
+	}
+	var names []string
+	names = append(names, attrs.SrvRecords...)
+	names = append(names, attrs.Hostnames...)
+	names = append(names, attrs.Zones...)
+	for _, v := range names {
+		if strings.TrimSpace(v) == "" || strings.TrimSpace(v) != v {
+			return nil, status.Errorf(codes.InvalidArgument, "attributes: invalid name %q", v)
+		}
+	}
+	return attrs, nil
+}
+
+// decode decodes m into out. Weak typing is used so that a single value, as
+// produced by passing an attribute once on the command line, is accepted where
+// a list is expected.
+func decode(m map[string]any, out any) error {
+	d, err := mapstructure.NewDecoder(&mapstructure.DecoderConfig{
+		WeaklyTypedInput: true,
+		Result:           out,
+	})
+	if err != nil {
+		return err
+	}
+	return d.Decode(m)
+}
+
+func checkUnknownFields(m map[string]any, known ...string) error {
+	for k := range m {
+		found := false
+		for _, f := range known {
+			if k == f {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return status.Error(codes.InvalidArgument, fmt.Sprintf("attributes.%s: unrecognized field", k))
+		}
+	}
+	return nil
+}
@@ -0,0 +1,366 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package dns
+
+import (
+	"context"
+	"net"
+	"testing"
+
+	"github.com/hashicorp/boundary/sdk/pbs/controller/api/resources/hostcatalogs"
+	"github.com/hashicorp/boundary/sdk/pbs/controller/api/resources/hostsets"
+	plgpb "github.com/hashicorp/boundary/sdk/pbs/plugin"
+	mdns "github.com/miekg/dns"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+// testDnsServer starts a DNS server on a random local UDP port that answers
+// from records and returns its address.
+func testDnsServer(t *testing.T, records map[uint16]map[string][]string) string {
+	t.Helper()
+	// The resource records are built up front so that no assertions are made
+	// in the handler goroutine.
+	answers := make(map[uint16]map[string][]mdns.RR, len(records))
+	for qtype, names := range records {
+		answers[qtype] = make(map[string][]mdns.RR, len(names))
+		for name, values := range names {
+			for _, v := range values {
+				rr, err := mdns.NewRR(name + " 60 IN " + mdns.TypeToString[qtype] + " " + v)
+				require.NoError(t, err)
+				answers[qtype][name] = append(answers[qtype][name], rr)
+			}
+		}
+	}
+
+	pc, err := net.ListenPacket("udp", "127.0.0.1:0")
+	require.NoError(t, err)
+
+	handler := mdns.HandlerFunc(func(w mdns.ResponseWriter, req *mdns.Msg) {
+		m := new(mdns.Msg)
+		m.SetReply(req)
+		q := req.Question[0]
+		rrs, ok := answers[q.Qtype][q.Name]
+		if !ok {
+			m.Rcode = mdns.RcodeNameError
+			_ = w.WriteMsg(m)
+			return
+		}
+		m.Answer = append(m.Answer, rrs...)
+		_ = w.WriteMsg(m)
+	})
+	started := make(chan struct{})
+	srv := &mdns.Server{PacketConn: pc, Handler: handler, NotifyStartedFunc: func() { close(started) }}
+	go func() { _ = srv.ActivateAndServe() }()
+	<-started
+	t.Cleanup(func() { _ = srv.Shutdown() })
+	return pc.LocalAddr().String()
+}
+
+// testZoneServer starts a DNS server on a random local TCP port that answers
+// zone transfers of zones, which map a zone name to its records in zone file
+// form, and returns its address. Transfers of any other zone are refused.
+func testZoneServer(t *testing.T, zones map[string][]string) string {
+	t.Helper()
+	answers := make(map[string][]mdns.RR, len(zones))
+	for zone, records := range zones {
+		soa, err := mdns.NewRR(zone + " 60 IN SOA ns." + zone + " admin." + zone + " 1 60 60 60 60")
+		require.NoError(t, err)
+		answers[zone] = append(answers[zone], soa)
+		for _, r := range records {
+			rr, err := mdns.NewRR(r)
+			require.NoError(t, err)
+			answers[zone] = append(answers[zone], rr)
+		}
+		answers[zone] = append(answers[zone], soa)
+	}
+
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+
+	handler := mdns.HandlerFunc(func(w mdns.ResponseWriter, req *mdns.Msg) {
+		m := new(mdns.Msg)
+		m.SetReply(req)
+		q := req.Question[0]
+		rrs, ok := answers[q.Name]
+		if q.Qtype != mdns.TypeAXFR || !ok {
+			m.Rcode = mdns.RcodeRefused
+			_ = w.WriteMsg(m)
+			return
+		}
+		m.Answer = rrs
+		_ = w.WriteMsg(m)
+	})
+	started := make(chan struct{})
+	srv := &mdns.Server{Listener: l, Handler: handler, NotifyStartedFunc: func() { close(started) }}
+	go func() { _ = srv.ActivateAndServe() }()
+	<-started
+	t.Cleanup(func() { _ = srv.Shutdown() })
+	return l.Addr().String()
+}
+
+func mustStruct(t *testing.T, m map[string]any) *structpb.Struct {
+	t.Helper()
+	s, err := structpb.NewStruct(m)
+	require.NoError(t, err)
+	return s
+}
+
+func TestDnsPlugin_ListHosts(t *testing.T) {
+	ctx := context.Background()
+	addr := testDnsServer(t, map[uint16]map[string][]string{
+		mdns.TypeSRV: {
+			"_ssh._tcp.example.com.":   {"10 5 22 web1.example.com.", "10 5 22 web2.example.com."},
+			"_empty._tcp.example.com.": {"0 0 0 ."},
+		},
+		mdns.TypeA: {
+			"web1.example.com.": {"10.0.0.1"},
+			"web2.example.com.": {"10.0.0.3", "10.0.0.2"},
+			"db.example.com.":   {"10.0.1.1"},
+			"other.test.":       {"192.168.0.1"},
+		},
+		mdns.TypeAAAA: {
+			"web1.example.com.": {"fd00::1"},
+		},
+	})
+	catalog := &hostcatalogs.HostCatalog{
+		Attrs: &hostcatalogs.HostCatalog_Attributes{
+			Attributes: mustStruct(t, map[string]any{"resolver": addr, "zone": "example.com"}),
+		},
+	}
+	newSet := func(id string, attrs map[string]any) *hostsets.HostSet {
+		return &hostsets.HostSet{
+			Id:    id,
+			Attrs: &hostsets.HostSet_Attributes{Attributes: mustStruct(t, attrs)},
+		}
+	}
+
+	tests := []struct {
+		name    string
+		sets    []*hostsets.HostSet
+		want    []*plgpb.ListHostsResponseHost
+		wantErr codes.Code
+	}{
+		{
+			name: "srv",
+			sets: []*hostsets.HostSet{newSet("set1", map[string]any{"srv_records": []any{"_ssh._tcp"}})},
+			want: []*plgpb.ListHostsResponseHost{
+				{ExternalId: "web1.example.com", ExternalName: "web1.example.com", IpAddresses: []string{"10.0.0.1", "fd00::1"}, DnsNames: []string{"web1.example.com"}, SetIds: []string{"set1"}},
+				{ExternalId: "web2.example.com", ExternalName: "web2.example.com", IpAddresses: []string{"10.0.0.2", "10.0.0.3"}, DnsNames: []string{"web2.example.com"}, SetIds: []string{"set1"}},
+			},
+		},
+		{
+			name: "hostnames-relative-and-qualified",
+			sets: []*hostsets.HostSet{newSet("set1", map[string]any{"hostnames": []any{"db", "other.test."}})},
+			want: []*plgpb.ListHostsResponseHost{
+				{ExternalId: "db.example.com", ExternalName: "db.example.com", IpAddresses: []string{"10.0.1.1"}, DnsNames: []string{"db.example.com"}, SetIds: []string{"set1"}},
+				{ExternalId: "other.test", ExternalName: "other.test", IpAddresses: []string{"192.168.0.1"}, DnsNames: []string{"other.test"}, SetIds: []string{"set1"}},
+			},
+		},
+		{
+			name: "host-in-multiple-sets",
+			sets: []*hostsets.HostSet{
+				newSet("set1", map[string]any{"srv_records": []any{"_ssh._tcp"}}),
+				newSet("set2", map[string]any{"hostnames": []any{"web1"}}),
+			},
+			want: []*plgpb.ListHostsResponseHost{
+				{ExternalId: "web1.example.com", ExternalName: "web1.example.com", IpAddresses: []string{"10.0.0.1", "fd00::1"}, DnsNames: []string{"web1.example.com"}, SetIds: []string{"set1", "set2"}},
+				{ExternalId: "web2.example.com", ExternalName: "web2.example.com", IpAddresses: []string{"10.0.0.2", "10.0.0.3"}, DnsNames: []string{"web2.example.com"}, SetIds: []string{"set1"}},
+			},
+		},
+		{
+			name: "missing-records",
+			sets: []*hostsets.HostSet{newSet("set1", map[string]any{"srv_records": []any{"_none._tcp", "_empty._tcp"}, "hostnames": []any{"missing"}})},
+		},
+		{
+			name:    "invalid-set-attributes",
+			sets:    []*hostsets.HostSet{newSet("set1", map[string]any{"unknown": "value"})},
+			wantErr: codes.InvalidArgument,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert, require := assert.New(t), require.New(t)
+			resp, err := NewDnsPlugin().ListHosts(ctx, &plgpb.ListHostsRequest{Catalog: catalog, Sets: tt.sets})
+			if tt.wantErr != codes.OK {
+				require.Error(err)
+				assert.Equal(tt.wantErr, status.Code(err))
+				return
+			}
+			require.NoError(err)
+			require.Len(resp.GetHosts(), len(tt.want))
+			for i, h := range resp.GetHosts() {
+				assert.Equal(tt.want[i].GetExternalId(), h.GetExternalId())
+				assert.Equal(tt.want[i].GetExternalName(), h.GetExternalName())
+				assert.Equal(tt.want[i].GetIpAddresses(), h.GetIpAddresses())
+				assert.Equal(tt.want[i].GetDnsNames(), h.GetDnsNames())
+				assert.Equal(tt.want[i].GetSetIds(), h.GetSetIds())
+			}
+		})
+	}
+}
+
+func TestDnsPlugin_ListHostsZones(t *testing.T) {
+	ctx := context.Background()
+	addr := testZoneServer(t, map[string][]string{
+		"example.com.": {
+			"web1.example.com. 60 IN A 10.0.0.1",
+			"web1.example.com. 60 IN AAAA fd00::1",
+			"Web2.example.com. 60 IN A 10.0.0.3",
+			"web2.example.com. 60 IN A 10.0.0.2",
+			"*.example.com. 60 IN A 10.0.0.9",
+			"alias.example.com. 60 IN CNAME web1.example.com.",
+			"example.com. 60 IN MX 10 mail.example.com.",
+		},
+		"prod.example.com.": {
+			"db.prod.example.com. 60 IN A 10.0.1.1",
+		},
+	})
+	catalog := &hostcatalogs.HostCatalog{
+		Attrs: &hostcatalogs.HostCatalog_Attributes{
+			Attributes: mustStruct(t, map[string]any{"resolver": addr, "zone": "example.com"}),
+		},
+	}
+	newSet := func(id string, attrs map[string]any) *hostsets.HostSet {
+		return &hostsets.HostSet{
+			Id:    id,
+			Attrs: &hostsets.HostSet_Attributes{Attributes: mustStruct(t, attrs)},
+		}
+	}
+
+	tests := []struct {
+		name    string
+		catalog *hostcatalogs.HostCatalog
+		sets    []*hostsets.HostSet
+		want    []*plgpb.ListHostsResponseHost
+		wantErr codes.Code
+	}{
+		{
+			name:    "catalog-zone",
+			catalog: catalog,
+			sets:    []*hostsets.HostSet{newSet("set1", map[string]any{"zones": []any{"@"}})},
+			want: []*plgpb.ListHostsResponseHost{
+				{ExternalId: "web1.example.com", ExternalName: "web1.example.com", IpAddresses: []string{"10.0.0.1", "fd00::1"}, DnsNames: []string{"web1.example.com"}, SetIds: []string{"set1"}},
+				{ExternalId: "web2.example.com", ExternalName: "web2.example.com", IpAddresses: []string{"10.0.0.2", "10.0.0.3"}, DnsNames: []string{"web2.example.com"}, SetIds: []string{"set1"}},
+			},
+		},
+		{
+			name:    "relative-and-qualified-zones",
+			catalog: catalog,
+			sets: []*hostsets.HostSet{
+				newSet("set1", map[string]any{"zones": []any{"prod"}}),
+				newSet("set2", map[string]any{"zones": []any{"example.com."}, "hostnames": []any{"db.prod"}}),
+			},
+			want: []*plgpb.ListHostsResponseHost{
+				{ExternalId: "db.prod.example.com", ExternalName: "db.prod.example.com", IpAddresses: []string{"10.0.1.1"}, DnsNames: []string{"db.prod.example.com"}, SetIds: []string{"set1", "set2"}},
+				{ExternalId: "web1.example.com", ExternalName: "web1.example.com", IpAddresses: []string{"10.0.0.1", "fd00::1"}, DnsNames: []string{"web1.example.com"}, SetIds: []string{"set2"}},
+				{ExternalId: "web2.example.com", ExternalName: "web2.example.com", IpAddresses: []string{"10.0.0.2", "10.0.0.3"}, DnsNames: []string{"web2.example.com"}, SetIds: []string{"set2"}},
+			},
+		},
+		{
+			name:    "transfer-refused",
+			catalog: catalog,
+			sets:    []*hostsets.HostSet{newSet("set1", map[string]any{"zones": []any{"other.test."}})},
+			wantErr: codes.Unavailable,
+		},
+		{
+			name:    "no-resolver",
+			catalog: &hostcatalogs.HostCatalog{},
+			sets:    []*hostsets.HostSet{newSet("set1", map[string]any{"zones": []any{"example.com."}})},
+			wantErr: codes.InvalidArgument,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert, require := assert.New(t), require.New(t)
+			resp, err := NewDnsPlugin().ListHosts(ctx, &plgpb.ListHostsRequest{Catalog: tt.catalog, Sets: tt.sets})
+			if tt.wantErr != codes.OK {
+				require.Error(err)
+				assert.Equal(tt.wantErr, status.Code(err))
+				return
+			}
+			require.NoError(err)
+			require.Len(resp.GetHosts(), len(tt.want))
+			for i, h := range resp.GetHosts() {
+				assert.Equal(tt.want[i].GetExternalId(), h.GetExternalId())
+				assert.Equal(tt.want[i].GetExternalName(), h.GetExternalName())
+				assert.Equal(tt.want[i].GetIpAddresses(), h.GetIpAddresses())
+				assert.Equal(tt.want[i].GetDnsNames(), h.GetDnsNames())
+				assert.Equal(tt.want[i].GetSetIds(), h.GetSetIds())
+			}
+		})
+	}
+}
+
+func TestDnsPlugin_Validation(t *testing.T) {
+	ctx := context.Background()
+	plg := NewDnsPlugin()
+
+	catalogTests := []struct {
+		name    string
+		attrs   map[string]any
+		wantErr bool
+	}{
+		{name: "no-attributes"},
+		{name: "valid", attrs: map[string]any{"resolver": "127.0.0.1:5353", "zone": "example.com", "timeout": "2s"}},
+		{name: "resolver-without-port", attrs: map[string]any{"resolver": "10.0.0.53"}},
+		{name: "ipv6-resolver-without-port", attrs: map[string]any{"resolver": "[fd00::53]"}},
+		{name: "bad-timeout", attrs: map[string]any{"timeout": "soon"}, wantErr: true},
+		{name: "negative-timeout", attrs: map[string]any{"timeout": "-1s"}, wantErr: true},
+		{name: "unknown-field", attrs: map[string]any{"region": "us-east-1"}, wantErr: true},
+	}
+	for _, tt := range catalogTests {
+		t.Run("catalog-"+tt.name, func(t *testing.T) {
+			cat := &hostcatalogs.HostCatalog{}
+			if tt.attrs != nil {
+				cat.Attrs = &hostcatalogs.HostCatalog_Attributes{Attributes: mustStruct(t, tt.attrs)}
+			}
+			_, err := plg.OnCreateCatalog(ctx, &plgpb.OnCreateCatalogRequest{Catalog: cat})
+			if tt.wantErr {
+				assert.Equal(t, codes.InvalidArgument, status.Code(err))
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+
+	setTests := []struct {
+		name    string
+		attrs   map[string]any
+		catalog map[string]any
+		wantErr bool
+	}{
+		{name: "no-attributes", wantErr: true},
+		{name: "srv", attrs: map[string]any{"srv_records": []any{"_ssh._tcp"}}},
+		{name: "hostnames", attrs: map[string]any{"hostnames": []any{"web1", "web2.example.com."}}},
+		{name: "single-value", attrs: map[string]any{"hostnames": "web1"}},
+		{name: "zones", attrs: map[string]any{"zones": []any{"@", "prod"}}},
+		{name: "zones-without-resolver", attrs: map[string]any{"zones": []any{"prod"}}, catalog: map[string]any{"zone": "example.com"}, wantErr: true},
+		{name: "catalog-zone-without-zone", attrs: map[string]any{"zones": []any{"@"}}, catalog: map[string]any{"resolver": "10.0.0.53"}, wantErr: true},
+		{name: "empty-lists", attrs: map[string]any{"srv_records": []any{}, "hostnames": []any{}}, wantErr: true},
+		{name: "blank-name", attrs: map[string]any{"hostnames": []any{" "}}, wantErr: true},
+		{name: "unknown-field", attrs: map[string]any{"filters": []any{"tag=web"}}, wantErr: true},
+	}
+	for _, tt := range setTests {
+		t.Run("set-"+tt.name, func(t *testing.T) {
+			set := &hostsets.HostSet{Id: "set1"}
+			if tt.attrs != nil {
+				set.Attrs = &hostsets.HostSet_Attributes{Attributes: mustStruct(t, tt.attrs)}
+			}
+			var cat *hostcatalogs.HostCatalog
+			if tt.catalog != nil {
+				cat = &hostcatalogs.HostCatalog{Attrs: &hostcatalogs.HostCatalog_Attributes{Attributes: mustStruct(t, tt.catalog)}}
+			}
+			_, err := plg.OnCreateSet(ctx, &plgpb.OnCreateSetRequest{Catalog: cat, Set: set})
+			if tt.wantErr {
+				assert.Equal(t, codes.InvalidArgument, status.Code(err))
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
@@ -0,0 +1,144 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+// Package attestation validates the identity documents presented by workers
+// registering themselves with the controller. An Attestor verifies a document
+// against the trust policy it was configured with and returns the identity of
+// the worker, which is used to name and tag the new worker.
+package attestation
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/boundary/internal/errors"
+)
+
+// Type is the type of an attestor.
+type Type string
+
+const (
+	// JwtType is the type of attestors validating JWT identity documents, such
+	// as the ones issued by cloud instance metadata services.
+	JwtType Type = "jwt"
+)
+
+// Config is the trust policy of an attestor. It is read from the
+// worker_attestation blocks of the controller configuration.
+type Config struct {
+	// Type is the type of the attestor. Only JwtType is currently supported.
+	Type string `hcl:"type"`
+
+	// Issuer is the expected "iss" claim of the document. Skipped if empty.
+	Issuer string `hcl:"issuer"`
+
+	// Audiences are the accepted "aud" claims of the document. Skipped if
+	// empty.
+	Audiences []string `hcl:"audiences"`
+
+	// PublicKeys are PEM encoded public keys used to verify the signature of
+	// the document. Either PublicKeys or JwksUrl must be set.
+	PublicKeys []string `hcl:"public_keys"`
+
+	// JwksUrl is the URL of a JSON Web Key Set used to verify the signature of
+	// the document, and JwksCaCert an optional PEM encoded CA certificate used
+	// to connect to it.
+	JwksUrl    string `hcl:"jwks_url"`
+	JwksCaCert string `hcl:"jwks_ca_cert"`
+
+	// SigningAlgorithms are the accepted signing algorithms of the document.
+	// Defaults to RS256.
+	SigningAlgorithms []string `hcl:"signing_algorithms"`
+
+	// BoundClaims are claims which must be present with the given value. If
+	// the claim is a list, it must contain the value.
+	BoundClaims map[string]string `hcl:"bound_claims"`
+
+	// NameClaim is the claim used as the name of the worker. If empty, the
+	// worker is created without a name.
+	NameClaim string `hcl:"name_claim"`
+
+	// ClaimTags maps worker tag keys to the claims their values are read
+	// from.
+	ClaimTags map[string]string `hcl:"claim_tags"`
+
+	// IdClaim is the claim uniquely identifying the document, such as a JWT ID
+	// or the id of the instance it was issued to. A document is only accepted
+	// once. Defaults to "jti".
+	IdClaim string `hcl:"id_claim"`
+
+	// MaxAge is the maximum time elapsed since the document was issued, based
+	// on its "iat" claim. Zero means no maximum.
+	MaxAgeHCL string        `hcl:"max_age"`
+	MaxAge    time.Duration `hcl:"-"`
+}
+
+// Identity is the identity of an attested worker.
+type Identity struct {
+	// Name is the name of the worker, empty if the trust policy does not
+	// derive one.
+	Name string
+
+	// Tags are the worker tags derived from the document.
+	Tags map[string][]string
+
+	// DocumentId uniquely identifies the document within its issuer. It is
+	// used to refuse documents which were already used.
+	DocumentId string
+
+	// Expiration is the time the document expires.
+	Expiration time.Time
+}
+
+// Attestor validates identity documents against a trust policy.
+type Attestor interface {
+	// Type returns the type of documents the attestor validates.
+	Type() Type
+
+	// Attest validates the document and returns the identity it attests. An
+	// error with the Forbidden code is returned if the document is not
+	// trusted.
+	Attest(ctx context.Context, document string) (*Identity, error)
+}
+
+// New returns the Attestor for the given configuration.
+func New(ctx context.Context, conf *Config) (Attestor, error) {
+	const op = "attestation.New"
+	if conf == nil {
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "missing config")
+	}
+	switch Type(conf.Type) {
+	case JwtType:
+		return NewJwtAttestor(ctx, conf)
+	default:
+		return nil, errors.New(ctx, errors.InvalidParameter, op, fmt.Sprintf("unknown attestation type %q", conf.Type))
+	}
+}
+
+// Attest validates the document with the attestors of the given type and
+// returns the identity attested by the first one trusting it.
+func Attest(ctx context.Context, attestors []Attestor, typ Type, document string) (*Identity, error) {
+	const op = "attestation.Attest"
+	switch {
+	case typ == "":
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "missing attestation type")
+	case document == "":
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "missing attestation document")
+	}
+	var lastErr error
+	for _, a := range attestors {
+		if a.Type() != typ {
+			continue
+		}
+		id, err := a.Attest(ctx, document)
+		if err == nil {
+			return id, nil
+		}
+		lastErr = err
+	}
+	if lastErr == nil {
+		return nil, errors.New(ctx, errors.InvalidParameter, op, fmt.Sprintf("no attestor configured for type %q", typ))
+	}
+	return nil, errors.Wrap(ctx, lastErr, op)
+}
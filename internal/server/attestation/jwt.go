@@ -0,0 +1,208 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package attestation
+
+import (
+	"context"
+	"crypto"
+	"fmt"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/hashicorp/boundary/internal/errors"
+	"github.com/hashicorp/cap/jwt"
+)
+
+// JwtAttestor is an Attestor validating signed JWTs, such as the instance
+// identity tokens issued by cloud providers or a workload identity system.
+type JwtAttestor struct {
+	validator   *jwt.Validator
+	expected    jwt.Expected
+	boundClaims map[string]string
+	nameClaim   string
+	claimTags   map[string]string
+	idClaim     string
+	maxAge      time.Duration
+}
+
+var _ Attestor = (*JwtAttestor)(nil)
+
+// NewJwtAttestor returns a JwtAttestor enforcing the trust policy of the
+// given configuration.
+func NewJwtAttestor(ctx context.Context, conf *Config) (*JwtAttestor, error) {
+	const op = "attestation.NewJwtAttestor"
+	switch {
+	case conf == nil:
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "missing config")
+	case len(conf.PublicKeys) == 0 && conf.JwksUrl == "":
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "one of public keys or jwks url must be set")
+	case len(conf.PublicKeys) > 0 && conf.JwksUrl != "":
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "only one of public keys or jwks url may be set")
+	case conf.MaxAge < 0:
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "max age is negative")
+	}
+
+	var keySet jwt.KeySet
+	var err error
+	switch {
+	case conf.JwksUrl != "":
+		keySet, err = jwt.NewJSONWebKeySet(ctx, conf.JwksUrl, conf.JwksCaCert)
+		if err != nil {
+			return nil, errors.Wrap(ctx, err, op, errors.WithMsg("unable to create jwks key set"))
+		}
+	default:
+		keys := make([]crypto.PublicKey, 0, len(conf.PublicKeys))
+		for i, pem := range conf.PublicKeys {
+			key, err := jwt.ParsePublicKeyPEM([]byte(pem))
+			if err != nil {
+				return nil, errors.Wrap(ctx, err, op, errors.WithMsg(fmt.Sprintf("unable to parse public key %d", i)))
+			}
+			keys = append(keys, key)
+		}
+		keySet, err = jwt.NewStaticKeySet(keys)
+		if err != nil {
+			return nil, errors.Wrap(ctx, err, op, errors.WithMsg("unable to create static key set"))
+		}
+	}
+	validator, err := jwt.NewValidator(keySet)
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op, errors.WithMsg("unable to create validator"))
+	}
+
+	algs := make([]jwt.Alg, 0, len(conf.SigningAlgorithms))
+	for _, a := range conf.SigningAlgorithms {
+		algs = append(algs, jwt.Alg(a))
+	}
+	if len(algs) > 0 {
+		if err := jwt.SupportedSigningAlgorithm(algs...); err != nil {
+			return nil, errors.Wrap(ctx, err, op, errors.WithCode(errors.InvalidParameter))
+		}
+	}
+
+	idClaim := conf.IdClaim
+	if idClaim == "" {
+		idClaim = "jti"
+	}
+
+	return &JwtAttestor{
+		validator: validator,
+		expected: jwt.Expected{
+			Issuer:            conf.Issuer,
+			Audiences:         conf.Audiences,
+			SigningAlgorithms: algs,
+		},
+		boundClaims: conf.BoundClaims,
+		nameClaim:   conf.NameClaim,
+		claimTags:   conf.ClaimTags,
+		idClaim:     idClaim,
+		maxAge:      conf.MaxAge,
+	}, nil
+}
+
+// Type implements Attestor.
+func (a *JwtAttestor) Type() Type {
+	return JwtType
+}
+
+// Attest implements Attestor. The document is the JWT in its compact
+// serialization form.
+func (a *JwtAttestor) Attest(ctx context.Context, document string) (*Identity, error) {
+	const op = "attestation.(JwtAttestor).Attest"
+	if document == "" {
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "missing document")
+	}
+	claims, err := a.validator.Validate(ctx, document, a.expected)
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op, errors.WithCode(errors.Forbidden))
+	}
+
+	// Documents are only accepted once, so they must be identifiable and
+	// expire for the ids of used documents to be kept until then.
+	exp, ok := claims["exp"].(float64)
+	if !ok {
+		return nil, errors.New(ctx, errors.Forbidden, op, "missing expiration time (exp) claim")
+	}
+	docIds, ok := claimValues(claims, a.idClaim)
+	if !ok || len(docIds) != 1 {
+		return nil, errors.New(ctx, errors.Forbidden, op, fmt.Sprintf("id claim %q is missing or not a single value", a.idClaim))
+	}
+	iss, _ := claims["iss"].(string)
+
+	if a.maxAge > 0 {
+		iat, ok := claims["iat"].(float64)
+		if !ok {
+			return nil, errors.New(ctx, errors.Forbidden, op, "missing issued at (iat) claim")
+		}
+		if time.Since(time.Unix(int64(iat), 0)) > a.maxAge {
+			return nil, errors.New(ctx, errors.Forbidden, op, "document is older than the max age")
+		}
+	}
+
+	for claim, want := range a.boundClaims {
+		values, ok := claimValues(claims, claim)
+		if !ok {
+			return nil, errors.New(ctx, errors.Forbidden, op, fmt.Sprintf("missing bound claim %q", claim))
+		}
+		var found bool
+		for _, v := range values {
+			if v == want {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return nil, errors.New(ctx, errors.Forbidden, op, fmt.Sprintf("claim %q does not match its bound value", claim))
+		}
+	}
+
+	id := &Identity{
+		Tags:       make(map[string][]string, len(a.claimTags)),
+		DocumentId: iss + "#" + docIds[0],
+		Expiration: time.Unix(int64(exp), 0),
+	}
+	if a.nameClaim != "" {
+		values, ok := claimValues(claims, a.nameClaim)
+		if !ok || len(values) != 1 {
+			return nil, errors.New(ctx, errors.Forbidden, op, fmt.Sprintf("name claim %q is missing or not a single value", a.nameClaim))
+		}
+		id.Name = values[0]
+	}
+	for key, claim := range a.claimTags {
+		values, ok := claimValues(claims, claim)
+		if !ok || len(values) == 0 {
+			continue
+		}
+		sort.Strings(values)
+		id.Tags[key] = values
+	}
+	return id, nil
+}
+
+// claimValues returns the values of the claim as strings. Lists are
+// flattened, and objects and nulls are ignored.
+func claimValues(claims map[string]any, claim string) ([]string, bool) {
+	raw, ok := claims[claim]
+	if !ok {
+		return nil, false
+	}
+	var values []string
+	var add func(v any)
+	add = func(v any) {
+		switch t := v.(type) {
+		case string:
+			values = append(values, t)
+		case bool:
+			values = append(values, strconv.FormatBool(t))
+		case float64:
+			values = append(values, strconv.FormatFloat(t, 'f', -1, 64))
+		case []any:
+			for _, e := range t {
+				add(e)
+			}
+		}
+	}
+	add(raw)
+	return values, true
+}
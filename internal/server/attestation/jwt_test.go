@@ -0,0 +1,229 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package attestation
+
+import (
+	"context"
+	"crypto/x509"
+	"encoding/pem"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/boundary/internal/errors"
+	"github.com/hashicorp/cap/oidc"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestJwtAttestor_Attest(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	pub, priv := oidc.TestGenerateKeys(t)
+	der, err := x509.MarshalPKIXPublicKey(pub)
+	require.NoError(t, err)
+	pubPem := string(pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der}))
+	_, otherPriv := oidc.TestGenerateKeys(t)
+
+	conf := &Config{
+		Type:              string(JwtType),
+		Issuer:            "https://metadata.example.com",
+		Audiences:         []string{"boundary"},
+		PublicKeys:        []string{pubPem},
+		SigningAlgorithms: []string{string(oidc.ES256)},
+		BoundClaims:       map[string]string{"project": "prod", "groups": "workers"},
+		NameClaim:         "instance_name",
+		ClaimTags:         map[string]string{"region": "region", "groups": "groups", "missing": "missing"},
+		MaxAge:            time.Hour,
+	}
+	a, err := New(ctx, conf)
+	require.NoError(t, err)
+	assert.Equal(t, JwtType, a.Type())
+
+	now := time.Now()
+	claims := func(mod func(map[string]any)) map[string]any {
+		c := map[string]any{
+			"iss":           "https://metadata.example.com",
+			"aud":           []string{"boundary"},
+			"iat":           now.Unix(),
+			"exp":           now.Add(time.Minute).Unix(),
+			"jti":           "document-1",
+			"project":       "prod",
+			"groups":        []string{"workers", "east"},
+			"instance_name": "worker-1",
+			"region":        "us-east-1",
+		}
+		if mod != nil {
+			mod(c)
+		}
+		return c
+	}
+
+	tests := []struct {
+		name    string
+		key     any
+		claims  map[string]any
+		want    *Identity
+		wantErr errors.Code
+	}{
+		{
+			name:   "valid",
+			key:    priv,
+			claims: claims(nil),
+			want: &Identity{
+				Name: "worker-1",
+				Tags: map[string][]string{
+					"region": {"us-east-1"},
+					"groups": {"east", "workers"},
+				},
+				DocumentId: "https://metadata.example.com#document-1",
+				Expiration: time.Unix(now.Add(time.Minute).Unix(), 0),
+			},
+		},
+		{
+			name:    "wrong-signer",
+			key:     otherPriv,
+			claims:  claims(nil),
+			wantErr: errors.Forbidden,
+		},
+		{
+			name:    "wrong-issuer",
+			key:     priv,
+			claims:  claims(func(c map[string]any) { c["iss"] = "https://other.example.com" }),
+			wantErr: errors.Forbidden,
+		},
+		{
+			name:    "wrong-audience",
+			key:     priv,
+			claims:  claims(func(c map[string]any) { c["aud"] = []string{"other"} }),
+			wantErr: errors.Forbidden,
+		},
+		{
+			name:    "expired",
+			key:     priv,
+			claims:  claims(func(c map[string]any) { c["exp"] = time.Now().Add(-time.Hour).Unix() }),
+			wantErr: errors.Forbidden,
+		},
+		{
+			name: "too-old",
+			key:  priv,
+			claims: claims(func(c map[string]any) {
+				c["iat"] = time.Now().Add(-2 * time.Hour).Unix()
+				c["exp"] = time.Now().Add(time.Hour).Unix()
+			}),
+			wantErr: errors.Forbidden,
+		},
+		{
+			name:    "bound-claim-mismatch",
+			key:     priv,
+			claims:  claims(func(c map[string]any) { c["project"] = "dev" }),
+			wantErr: errors.Forbidden,
+		},
+		{
+			name:    "bound-claim-missing",
+			key:     priv,
+			claims:  claims(func(c map[string]any) { delete(c, "groups") }),
+			wantErr: errors.Forbidden,
+		},
+		{
+			name:    "id-claim-missing",
+			key:     priv,
+			claims:  claims(func(c map[string]any) { delete(c, "jti") }),
+			wantErr: errors.Forbidden,
+		},
+		{
+			name:    "expiration-missing",
+			key:     priv,
+			claims:  claims(func(c map[string]any) { delete(c, "exp") }),
+			wantErr: errors.Forbidden,
+		},
+		{
+			name:    "name-claim-missing",
+			key:     priv,
+			claims:  claims(func(c map[string]any) { delete(c, "instance_name") }),
+			wantErr: errors.Forbidden,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			token := oidc.TestSignJWT(t, tt.key, string(oidc.ES256), tt.claims, nil)
+			got, err := Attest(ctx, []Attestor{a}, JwtType, token)
+			if tt.wantErr != 0 {
+				require.Error(t, err)
+				assert.True(t, errors.Match(errors.T(tt.wantErr), err), "unexpected error: %v", err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+
+	t.Run("unknown-type", func(t *testing.T) {
+		token := oidc.TestSignJWT(t, priv, string(oidc.ES256), claims(nil), nil)
+		_, err := Attest(ctx, []Attestor{a}, Type("tpm"), token)
+		require.Error(t, err)
+		assert.True(t, errors.Match(errors.T(errors.InvalidParameter), err))
+	})
+}
+
+func TestNew(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	pub, _ := oidc.TestGenerateKeys(t)
+	der, err := x509.MarshalPKIXPublicKey(pub)
+	require.NoError(t, err)
+	pubPem := string(pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der}))
+
+	tests := []struct {
+		name    string
+		conf    *Config
+		wantErr bool
+	}{
+		{
+			name:    "nil",
+			wantErr: true,
+		},
+		{
+			name:    "unknown-type",
+			conf:    &Config{Type: "tpm", PublicKeys: []string{pubPem}},
+			wantErr: true,
+		},
+		{
+			name:    "no-keys",
+			conf:    &Config{Type: "jwt"},
+			wantErr: true,
+		},
+		{
+			name:    "keys-and-jwks",
+			conf:    &Config{Type: "jwt", PublicKeys: []string{pubPem}, JwksUrl: "https://example.com/jwks"},
+			wantErr: true,
+		},
+		{
+			name:    "bad-key",
+			conf:    &Config{Type: "jwt", PublicKeys: []string{"not a key"}},
+			wantErr: true,
+		},
+		{
+			name:    "bad-algorithm",
+			conf:    &Config{Type: "jwt", PublicKeys: []string{pubPem}, SigningAlgorithms: []string{"none"}},
+			wantErr: true,
+		},
+		{
+			name: "valid",
+			conf: &Config{Type: "jwt", PublicKeys: []string{pubPem}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := New(ctx, tt.conf)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.NotNil(t, got)
+		})
+	}
+}
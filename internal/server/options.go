@@ -43,6 +43,7 @@ type options struct {
 	withTestPkiWorkerAuthorized            bool
 	withTestPkiWorkerKeyId                 *string
 	withTestUseInputTagsAsApiTags          bool
+	withTestActiveConnectionCount          uint32
 	withWorkerType                         WorkerType
 	withRoot                               RootInfo
 	withStopAfter                          uint
@@ -50,6 +51,9 @@ type options struct {
 	withReleaseVersion                     string
 	withOperationalState                   string
 	withLocalStorageState                  string
+	withStatusRttMs                        uint32
+	withApiTags                            []*Tag
+	withAttestationNonce                   string
 	withActiveWorkers                      bool
 	withFeature                            version.Feature
 	withDirectlyConnected                  bool
@@ -199,6 +203,15 @@ func WithTestUseInputTagsAsApiTags(with bool) Option {
 	}
 }
 
+// WithTestActiveConnectionCount tells NewWorker to set the active connection
+// count of the worker. This is useful for testing worker selection without
+// having to store sessions in the database.
+func WithTestActiveConnectionCount(count uint32) Option {
+	return func(o *options) {
+		o.withTestActiveConnectionCount = count
+	}
+}
+
 // WithWorkerType allows specifying a particular type of worker (kms, pki)
 // during lookup or listing
 func WithWorkerType(with WorkerType) Option {
@@ -276,3 +289,27 @@ func WithLocalStorageState(state string) Option {
 		o.withLocalStorageState = state
 	}
 }
+
+// WithStatusRttMs provides an optional round trip time, in milliseconds, of a
+// worker's status request.
+func WithStatusRttMs(rtt uint32) Option {
+	return func(o *options) {
+		o.withStatusRttMs = rtt
+	}
+}
+
+// WithApiTags provides api tags to create a worker with.
+func WithApiTags(tags ...*Tag) Option {
+	return func(o *options) {
+		o.withApiTags = tags
+	}
+}
+
+// WithAttestationNonce provides the id of the identity document a worker is
+// created from. It is stored as a nonce in the transaction creating the
+// worker so a document can only be used once.
+func WithAttestationNonce(nonce string) Option {
+	return func(o *options) {
+		o.withAttestationNonce = nonce
+	}
+}
@@ -251,4 +251,37 @@ func Test_GetOpts(t *testing.T) {
 		testOpts.withNewIdFunc = nil
 		assert.Equal(t, opts, testOpts)
 	})
+	t.Run("WithTestActiveConnectionCount", func(t *testing.T) {
+		opts := GetOpts(WithTestActiveConnectionCount(3))
+		testOpts := getDefaultOptions()
+		testOpts.withTestActiveConnectionCount = 3
+		opts.withNewIdFunc = nil
+		testOpts.withNewIdFunc = nil
+		assert.Equal(t, opts, testOpts)
+	})
+	t.Run("WithStatusRttMs", func(t *testing.T) {
+		opts := GetOpts(WithStatusRttMs(25))
+		testOpts := getDefaultOptions()
+		testOpts.withStatusRttMs = 25
+		opts.withNewIdFunc = nil
+		testOpts.withNewIdFunc = nil
+		assert.Equal(t, opts, testOpts)
+	})
+	t.Run("WithApiTags", func(t *testing.T) {
+		tags := []*Tag{{Key: "key", Value: "value"}}
+		opts := GetOpts(WithApiTags(tags...))
+		testOpts := getDefaultOptions()
+		testOpts.withApiTags = tags
+		opts.withNewIdFunc = nil
+		testOpts.withNewIdFunc = nil
+		assert.Equal(t, opts, testOpts)
+	})
+	t.Run("WithAttestationNonce", func(t *testing.T) {
+		opts := GetOpts(WithAttestationNonce("nonce"))
+		testOpts := getDefaultOptions()
+		testOpts.withAttestationNonce = "nonce"
+		opts.withNewIdFunc = nil
+		testOpts.withNewIdFunc = nil
+		assert.Equal(t, opts, testOpts)
+	})
 }
@@ -4,7 +4,8 @@
 package server
 
 const (
-	deleteWhereCreateTimeSql = `create_time < ?`
+	deleteWhereCreateTimeSql        = `create_time < ? and purpose != ?`
+	deleteWhereCreateTimePurposeSql = `create_time < ? and purpose = ?`
 
 	deleteTagsByWorkerIdSql = `
 	delete 
@@ -99,4 +100,67 @@ const (
 		where worker.scope_id = ?
 			and auth_token.key_id = ?
 	`
+
+	deleteAllWorkerDownstreamsSql = `
+		delete from server_worker_downstream
+		 where worker_id = ?
+	`
+
+	deleteMissingWorkerDownstreamsSql = `
+		delete from server_worker_downstream
+		 where worker_id = ?
+		   and downstream_worker_id not in (?)
+	`
+
+	upsertWorkerDownstreamSql = `
+		insert into server_worker_downstream
+		  (worker_id, downstream_worker_id)
+		values
+		  (?, ?)
+		on conflict (worker_id, downstream_worker_id) do update
+		  set update_time = now()
+	`
+
+	listWorkerDownstreamsSql = `
+		select d.worker_id, d.downstream_worker_id, w.last_status_time
+		  from server_worker_downstream d
+		  join server_worker w
+		    on w.public_id = d.worker_id
+		 where w.last_status_time > now() - interval '%d seconds'
+	`
+
+	listWorkerActiveSessionCountsSql = `
+		select worker_id, count(distinct session_id) as session_count
+		  from session_connection
+		 where closed_reason is null
+		   and worker_id is not null
+		 group by worker_id
+	`
+
+	upsertHostEndpointHealthSql = `
+		insert into host_endpoint_health
+		  (endpoint, healthy, worker_id)
+		values
+		  (?, ?, ?)
+		on conflict (endpoint, worker_id) do update
+		  set healthy     = excluded.healthy,
+		      update_time = now()
+	`
+
+	listUnhealthyHostEndpointsSql = `
+		  select endpoint
+		    from host_endpoint_health
+		   where endpoint in (?)
+		     and update_time > now() - interval '%d seconds'
+		group by endpoint
+		  having bool_and(not healthy)
+	`
+
+	listHostEndpointUnhealthyWorkersSql = `
+		select worker_id
+		  from host_endpoint_health
+		 where endpoint = ?
+		   and healthy = false
+		   and update_time > now() - interval '%d seconds'
+	`
 )
@@ -0,0 +1,139 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package server
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/boundary/internal/db"
+	"github.com/hashicorp/boundary/internal/errors"
+	"github.com/hashicorp/boundary/internal/util"
+)
+
+// HostEndpointHealth is the result of a worker's health probe of a host
+// endpoint in host:port form.
+type HostEndpointHealth struct {
+	Endpoint string
+	Healthy  bool
+}
+
+// UpsertHostEndpointHealth records the health of host endpoints as reported
+// by the worker with workerId. The health reported by each worker probing an
+// endpoint is kept separately. Like worker statuses, host endpoint health is
+// intentionally not oplogged.
+func (r *Repository) UpsertHostEndpointHealth(ctx context.Context, workerId string, health []*HostEndpointHealth, _ ...Option) error {
+	const op = "server.(Repository).UpsertHostEndpointHealth"
+	if workerId == "" {
+		return errors.New(ctx, errors.InvalidParameter, op, "missing worker id")
+	}
+	for _, h := range health {
+		if h == nil || h.Endpoint == "" {
+			return errors.New(ctx, errors.InvalidParameter, op, "missing endpoint")
+		}
+	}
+	if len(health) == 0 {
+		return nil
+	}
+
+	_, err := r.writer.DoTx(
+		ctx,
+		db.StdRetryCnt,
+		db.ExpBackoff{},
+		func(_ db.Reader, w db.Writer) error {
+			for _, h := range health {
+				if _, err := w.Exec(ctx, upsertHostEndpointHealthSql, []any{h.Endpoint, h.Healthy, workerId}); err != nil {
+					return errors.Wrap(ctx, err, op, errors.WithMsg(fmt.Sprintf("unable to upsert health of endpoint %q", h.Endpoint)))
+				}
+			}
+			return nil
+		},
+	)
+	if err != nil {
+		return errors.Wrap(ctx, err, op)
+	}
+	return nil
+}
+
+// ListUnhealthyHostEndpoints returns the endpoints, out of the provided ones,
+// which every worker probing them most recently reported as unhealthy. An
+// endpoint unreachable from some workers only is not returned. Reports older
+// than the liveness duration are ignored, so endpoints which are no longer
+// probed are considered healthy. Supported options: WithLiveness. If WithLiveness is zero
+// the default liveness value is used.
+func (r *Repository) ListUnhealthyHostEndpoints(ctx context.Context, endpoints []string, opt ...Option) ([]string, error) {
+	const op = "server.(Repository).ListUnhealthyHostEndpoints"
+	if util.IsNil(r.reader) {
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "missing reader")
+	}
+	if len(endpoints) == 0 {
+		return nil, nil
+	}
+	opts := GetOpts(opt...)
+	liveness := opts.withLiveness
+	if liveness <= 0 {
+		liveness = DefaultLiveness
+	}
+
+	rows, err := r.reader.Query(ctx, fmt.Sprintf(listUnhealthyHostEndpointsSql, uint32(liveness.Seconds())), []any{endpoints})
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	defer rows.Close()
+
+	var ret []string
+	for rows.Next() {
+		var result struct {
+			Endpoint string
+		}
+		if err := r.reader.ScanRows(ctx, rows, &result); err != nil {
+			return nil, errors.Wrap(ctx, err, op)
+		}
+		ret = append(ret, result.Endpoint)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	return ret, nil
+}
+
+// ListHostEndpointUnhealthyWorkers returns the ids of the workers which most
+// recently reported the endpoint as unhealthy. Reports older than the liveness
+// duration are ignored. Supported options: WithLiveness. If WithLiveness is
+// zero the default liveness value is used.
+func (r *Repository) ListHostEndpointUnhealthyWorkers(ctx context.Context, endpoint string, opt ...Option) ([]string, error) {
+	const op = "server.(Repository).ListHostEndpointUnhealthyWorkers"
+	if util.IsNil(r.reader) {
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "missing reader")
+	}
+	if endpoint == "" {
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "missing endpoint")
+	}
+	opts := GetOpts(opt...)
+	liveness := opts.withLiveness
+	if liveness <= 0 {
+		liveness = DefaultLiveness
+	}
+
+	rows, err := r.reader.Query(ctx, fmt.Sprintf(listHostEndpointUnhealthyWorkersSql, uint32(liveness.Seconds())), []any{endpoint})
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	defer rows.Close()
+
+	var ret []string
+	for rows.Next() {
+		var result struct {
+			WorkerId string
+		}
+		if err := r.reader.ScanRows(ctx, rows, &result); err != nil {
+			return nil, errors.Wrap(ctx, err, op)
+		}
+		ret = append(ret, result.WorkerId)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	return ret, nil
+}
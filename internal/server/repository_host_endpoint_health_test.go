@@ -0,0 +1,103 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package server_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/boundary/internal/db"
+	"github.com/hashicorp/boundary/internal/errors"
+	"github.com/hashicorp/boundary/internal/kms"
+	"github.com/hashicorp/boundary/internal/server"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRepository_HostEndpointHealth(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	conn, _ := db.TestSetup(t, "postgres")
+	rw := db.New(conn)
+	wrapper := db.TestWrapper(t)
+	kms := kms.TestKms(t, conn, wrapper)
+	repo, err := server.NewRepository(ctx, rw, rw, kms)
+	require.NoError(t, err)
+
+	w1 := server.TestKmsWorker(t, conn, wrapper)
+	w2 := server.TestPkiWorker(t, conn, wrapper)
+
+	t.Run("invalid", func(t *testing.T) {
+		err := repo.UpsertHostEndpointHealth(ctx, "", []*server.HostEndpointHealth{{Endpoint: "10.0.0.1:22"}})
+		assert.True(t, errors.Match(errors.T(errors.InvalidParameter), err))
+		err = repo.UpsertHostEndpointHealth(ctx, w1.GetPublicId(), []*server.HostEndpointHealth{{Endpoint: ""}})
+		assert.True(t, errors.Match(errors.T(errors.InvalidParameter), err))
+		err = repo.UpsertHostEndpointHealth(ctx, w1.GetPublicId(), []*server.HostEndpointHealth{nil})
+		assert.True(t, errors.Match(errors.T(errors.InvalidParameter), err))
+	})
+
+	t.Run("upsert-and-list", func(t *testing.T) {
+		require := require.New(t)
+		endpoints := []string{"10.0.0.1:22", "10.0.0.2:22", "10.0.0.3:22"}
+		got, err := repo.ListUnhealthyHostEndpoints(ctx, endpoints)
+		require.NoError(err)
+		require.Empty(got)
+
+		require.NoError(repo.UpsertHostEndpointHealth(ctx, w1.GetPublicId(), []*server.HostEndpointHealth{
+			{Endpoint: "10.0.0.1:22", Healthy: true},
+			{Endpoint: "10.0.0.2:22", Healthy: false},
+			{Endpoint: "10.0.0.4:22", Healthy: false},
+		}))
+		got, err = repo.ListUnhealthyHostEndpoints(ctx, endpoints)
+		require.NoError(err)
+		require.ElementsMatch([]string{"10.0.0.2:22"}, got)
+
+		// An endpoint is only unhealthy if every worker probing it says so.
+		require.NoError(repo.UpsertHostEndpointHealth(ctx, w2.GetPublicId(), []*server.HostEndpointHealth{
+			{Endpoint: "10.0.0.1:22", Healthy: false},
+			{Endpoint: "10.0.0.2:22", Healthy: true},
+		}))
+		got, err = repo.ListUnhealthyHostEndpoints(ctx, endpoints)
+		require.NoError(err)
+		require.Empty(got)
+
+		// Each worker's most recent report wins.
+		require.NoError(repo.UpsertHostEndpointHealth(ctx, w1.GetPublicId(), []*server.HostEndpointHealth{
+			{Endpoint: "10.0.0.1:22", Healthy: false},
+		}))
+		got, err = repo.ListUnhealthyHostEndpoints(ctx, endpoints)
+		require.NoError(err)
+		require.ElementsMatch([]string{"10.0.0.1:22"}, got)
+
+		workers, err := repo.ListHostEndpointUnhealthyWorkers(ctx, "10.0.0.2:22")
+		require.NoError(err)
+		require.ElementsMatch([]string{w1.GetPublicId()}, workers)
+		workers, err = repo.ListHostEndpointUnhealthyWorkers(ctx, "10.0.0.1:22")
+		require.NoError(err)
+		require.ElementsMatch([]string{w1.GetPublicId(), w2.GetPublicId()}, workers)
+		_, err = repo.ListHostEndpointUnhealthyWorkers(ctx, "")
+		assert.True(t, errors.Match(errors.T(errors.InvalidParameter), err))
+
+		got, err = repo.ListUnhealthyHostEndpoints(ctx, nil)
+		require.NoError(err)
+		require.Empty(got)
+	})
+
+	t.Run("deleted-worker", func(t *testing.T) {
+		require := require.New(t)
+		require.NoError(repo.UpsertHostEndpointHealth(ctx, w2.GetPublicId(), []*server.HostEndpointHealth{
+			{Endpoint: "10.0.0.5:22", Healthy: false},
+		}))
+		got, err := repo.ListUnhealthyHostEndpoints(ctx, []string{"10.0.0.5:22"})
+		require.NoError(err)
+		require.Equal([]string{"10.0.0.5:22"}, got)
+
+		// The reports of a deleted worker are removed with it.
+		_, err = repo.DeleteWorker(ctx, w2.GetPublicId())
+		require.NoError(err)
+		got, err = repo.ListUnhealthyHostEndpoints(ctx, []string{"10.0.0.5:22"})
+		require.NoError(err)
+		require.Empty(got)
+	})
+}
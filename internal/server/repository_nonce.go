@@ -24,8 +24,9 @@ func (n *Nonce) TableName() string {
 }
 
 const (
-	NoncePurposeRecovery   = "recovery"
-	NoncePurposeWorkerAuth = "worker-auth"
+	NoncePurposeRecovery          = "recovery"
+	NoncePurposeWorkerAuth        = "worker-auth"
+	NoncePurposeWorkerAttestation = "worker-attestation"
 )
 
 // AddNonce adds a nonce
@@ -35,7 +36,7 @@ func (r *Repository) AddNonce(ctx context.Context, nonce, purpose string, opt ..
 		return errors.New(ctx, errors.InvalidParameter, op, "empty nonce")
 	}
 	switch purpose {
-	case NoncePurposeRecovery, NoncePurposeWorkerAuth:
+	case NoncePurposeRecovery, NoncePurposeWorkerAuth, NoncePurposeWorkerAttestation:
 	case "":
 		return errors.New(ctx, errors.InvalidParameter, op, "empty nonce purpose")
 	default:
@@ -60,11 +61,18 @@ func (r *Repository) CleanupNonces(ctx context.Context, opt ...Option) (int, err
 	// If something was inserted before 3x the actual validity period, clean it out
 	endTime := time.Now().Add(-3 * maxDuration)
 
-	rows, err := r.writer.Delete(ctx, &Nonce{}, db.WithWhere(deleteWhereCreateTimeSql, endTime))
+	rows, err := r.writer.Delete(ctx, &Nonce{}, db.WithWhere(deleteWhereCreateTimeSql, endTime, NoncePurposeWorkerAttestation))
 	if err != nil {
 		return db.NoRowsAffected, errors.Wrap(ctx, err, "server.CleanupNonces")
 	}
-	return rows, nil
+	// Attestation nonces guard against the reuse of identity documents and
+	// are kept for as long as an accepted document can be valid.
+	attestationEndTime := time.Now().Add(-globals.WorkerAttestationNonceValidityPeriod)
+	attestationRows, err := r.writer.Delete(ctx, &Nonce{}, db.WithWhere(deleteWhereCreateTimePurposeSql, attestationEndTime, NoncePurposeWorkerAttestation))
+	if err != nil {
+		return db.NoRowsAffected, errors.Wrap(ctx, err, "server.CleanupNonces")
+	}
+	return rows + attestationRows, nil
 }
 
 // ListNonces lists nonces. Used only for tests at the moment.
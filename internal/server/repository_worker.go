@@ -15,6 +15,7 @@ import (
 	"github.com/hashicorp/boundary/internal/server/store"
 	"github.com/hashicorp/boundary/internal/types/scope"
 	"github.com/hashicorp/go-dbw"
+	"github.com/hashicorp/go-secure-stdlib/strutil"
 	"github.com/hashicorp/nodeenrollment"
 	"github.com/hashicorp/nodeenrollment/registration"
 	"github.com/hashicorp/nodeenrollment/types"
@@ -244,7 +245,9 @@ func ListWorkers(ctx context.Context, reader db.Reader, scopeIds []string, opt .
 	}
 
 	if opts.withActiveWorkers {
-		where = append(where, "operational_state = ?")
+		// A worker set to drain through the api may not have reported the
+		// draining state yet, so exclude it explicitly.
+		where = append(where, "operational_state = ?", "drain = false")
 		whereArgs = append(whereArgs, ActiveOperationalState.String())
 	}
 
@@ -281,6 +284,35 @@ func ListWorkers(ctx context.Context, reader db.Reader, scopeIds []string, opt .
 	return workers, nil
 }
 
+// ListWorkerActiveSessionCounts returns a map of worker ids to the number of
+// sessions with at least one open connection through the worker. Workers
+// without open connections are not included.
+func (r *Repository) ListWorkerActiveSessionCounts(ctx context.Context, _ ...Option) (map[string]uint32, error) {
+	const op = "server.(Repository).ListWorkerActiveSessionCounts"
+	rows, err := r.reader.Query(ctx, listWorkerActiveSessionCountsSql, nil)
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	defer rows.Close()
+
+	type rowsResult struct {
+		WorkerId     string
+		SessionCount uint32
+	}
+	ret := make(map[string]uint32)
+	for rows.Next() {
+		var result rowsResult
+		if err := r.reader.ScanRows(ctx, rows, &result); err != nil {
+			return nil, errors.Wrap(ctx, err, op)
+		}
+		ret[result.WorkerId] = result.SessionCount
+	}
+	if err := rows.Err(); err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	return ret, nil
+}
+
 // UpsertWorkerStatus will update the address and last status time for a worker.
 // If the worker is a kms worker that hasn't been seen yet, it'll attempt to
 // create a new one, but will return an error if another worker (kms or other)
@@ -351,7 +383,7 @@ func (r *Repository) UpsertWorkerStatus(ctx context.Context, worker *Worker, opt
 				// KMS-PKI) PKI-based workers to come via API only. We can't
 				// really guard on this in the DB so we need to be sure to not
 				// include it here.
-				n, err := w.Update(ctx, workerClone, []string{"address", "ReleaseVersion", "OperationalState", "LocalStorageState"}, nil)
+				n, err := w.Update(ctx, workerClone, []string{"address", "ReleaseVersion", "OperationalState", "LocalStorageState", "StatusRttMs"}, nil)
 				if err != nil {
 					return errors.Wrap(ctx, err, op, errors.WithMsg("unable to update status of pki worker"))
 				}
@@ -368,7 +400,7 @@ func (r *Repository) UpsertWorkerStatus(ctx context.Context, worker *Worker, opt
 				workerClone.Type = KmsWorkerType.String()
 				workerCreateConflict := &db.OnConflict{
 					Target: db.Columns{"public_id"},
-					Action: append(db.SetColumns([]string{"address", "release_version", "operational_state", "local_storage_state"}),
+					Action: append(db.SetColumns([]string{"address", "release_version", "operational_state", "local_storage_state", "status_rtt_ms"}),
 						db.SetColumnValues(map[string]any{"last_status_time": "now()"})...),
 				}
 				var withRowsAffected int64
@@ -464,15 +496,19 @@ func setWorkerTags(ctx context.Context, w db.Writer, id string, ts TagSource, ta
 // UpdateWorker will update a worker in the repository and return the resulting
 // worker. fieldMaskPaths provides field_mask.proto paths for fields that should
 // be updated. Fields will be set to NULL if the field is a zero value and
-// included in fieldMask. Name, Description, and Address are the only updatable
-// fields, if no updatable fields are included in the fieldMaskPaths, then an
-// error is returned. If any paths besides those listed above are included in
-// the path then an error is returned. If the worker is a KMS worker (whether
-// via the old registration method or pki-kms) name updates will be disallowed.
+// included in fieldMask. Name, Description, Drain, and DrainDeadline are the
+// only updatable fields, if no updatable fields are included in the
+// fieldMaskPaths, then an error is returned. If any paths besides those listed
+// above are included in the path then an error is returned. If the worker is a
+// KMS worker (whether via the old registration method or pki-kms) name and
+// description updates will be disallowed, but it can still be set to drain.
+// Setting Drain to false always clears the DrainDeadline.
 func (r *Repository) UpdateWorker(ctx context.Context, worker *Worker, version uint32, fieldMaskPaths []string, opt ...Option) (*Worker, int, error) {
 	const (
-		nameField = "name"
-		descField = "description"
+		nameField          = "name"
+		descField          = "description"
+		drainField         = "drain"
+		drainDeadlineField = "DrainDeadline"
 	)
 	const op = "server.(Repository).UpdateWorker"
 	switch {
@@ -484,10 +520,16 @@ func (r *Repository) UpdateWorker(ctx context.Context, worker *Worker, version u
 		return nil, db.NoRowsAffected, errors.New(ctx, errors.InvalidParameter, op, "version is zero")
 	}
 
+	var updatingNameOrDesc, updatingDrain bool
 	for _, f := range fieldMaskPaths {
 		switch {
 		case strings.EqualFold(nameField, f):
+			updatingNameOrDesc = true
 		case strings.EqualFold(descField, f):
+			updatingNameOrDesc = true
+		case strings.EqualFold(drainField, f):
+			updatingDrain = true
+		case strings.EqualFold(drainDeadlineField, f):
 		default:
 			return nil, db.NoRowsAffected, errors.New(ctx, errors.InvalidFieldMask, op, fmt.Sprintf("invalid field mask: %s", f))
 		}
@@ -496,12 +538,21 @@ func (r *Repository) UpdateWorker(ctx context.Context, worker *Worker, version u
 	var dbMask, nullFields []string
 	dbMask, nullFields = dbw.BuildUpdatePaths(
 		map[string]any{
-			nameField: worker.Name,
-			descField: worker.Description,
+			nameField:          worker.Name,
+			descField:          worker.Description,
+			drainDeadlineField: worker.DrainDeadline,
 		},
 		fieldMaskPaths,
 		nil,
 	)
+	if updatingDrain {
+		// drain is not nullable so false must be written rather than nulled.
+		dbMask = append(dbMask, drainField)
+		if !worker.Drain && !strutil.StrListContainsCaseInsensitive(nullFields, drainDeadlineField) {
+			dbMask = strutil.StrListDelete(dbMask, drainDeadlineField)
+			nullFields = append(nullFields, drainDeadlineField)
+		}
+	}
 	if len(dbMask) == 0 && len(nullFields) == 0 {
 		return nil, db.NoRowsAffected, errors.New(ctx, errors.EmptyFieldMask, op, "no fields to update")
 	}
@@ -530,17 +581,21 @@ func (r *Repository) UpdateWorker(ctx context.Context, worker *Worker, version u
 			if err != nil {
 				return errors.Wrap(ctx, err, op, errors.WithMsg("error generating worker id in kms-pki name check case"))
 			}
-			if workerId == worker.PublicId {
+			if updatingNameOrDesc && workerId == worker.PublicId {
 				return errors.Wrap(ctx, ErrCannotUpdateKmsWorkerViaApi, op, errors.WithCode(errors.InvalidParameter))
 			}
 
+			var updateOpts []db.Option
+			updateOpts = append(updateOpts, db.WithVersion(&version))
+			if updatingNameOrDesc {
+				updateOpts = append(updateOpts, db.WithWhere("server_worker.type = 'pki'"))
+			}
 			worker := worker.clone()
-			rowsUpdated, err = w.Update(ctx, worker, dbMask, nullFields, db.WithVersion(&version),
-				db.WithWhere("server_worker.type = 'pki'"))
+			rowsUpdated, err = w.Update(ctx, worker, dbMask, nullFields, updateOpts...)
 			if err != nil {
 				return errors.Wrap(ctx, err, op)
 			}
-			if worker.Type == KmsWorkerType.String() {
+			if updatingNameOrDesc && worker.Type == KmsWorkerType.String() {
 				return errors.New(ctx, errors.InvalidParameter, op, "cannot update a KMS worker")
 			}
 			if rowsUpdated > 1 {
@@ -578,6 +633,9 @@ func (r *Repository) UpdateWorker(ctx context.Context, worker *Worker, version u
 // WithFetchNodeCredentialsRequest,
 // WithCreateControllerLedActivationToken. The latter two are mutually
 // exclusive.
+// WithApiTags, to create the worker with api tags.
+// WithAttestationNonce, to store the nonce in the same transaction. An error
+// with the NotUnique code is returned if the nonce was already used.
 func (r *Repository) CreateWorker(ctx context.Context, worker *Worker, opt ...Option) (*Worker, error) {
 	const op = "server.CreateWorker"
 
@@ -687,6 +745,18 @@ func (r *Repository) CreateWorker(ctx context.Context, worker *Worker, opt ...Op
 				returnedWorker.ControllerGeneratedActivationToken = activationToken
 			}
 
+			if len(opts.withApiTags) > 0 {
+				if err := setWorkerTags(ctx, w, returnedWorker.PublicId, ApiTagSource, opts.withApiTags); err != nil {
+					return errors.Wrap(ctx, err, op)
+				}
+				returnedWorker.apiTags = opts.withApiTags
+			}
+			if opts.withAttestationNonce != "" {
+				if err := w.Create(ctx, &Nonce{Nonce: opts.withAttestationNonce, Purpose: NoncePurposeWorkerAttestation}); err != nil {
+					return errors.Wrap(ctx, err, op, errors.WithMsg("unable to store attestation nonce"))
+				}
+			}
+
 			return nil
 		},
 	); err != nil {
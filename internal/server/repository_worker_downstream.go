@@ -0,0 +1,133 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package server
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/boundary/internal/db"
+	"github.com/hashicorp/boundary/internal/errors"
+	"github.com/hashicorp/boundary/internal/util"
+)
+
+// UpsertWorkerDownstreams records that the workers in downstreamIds are
+// currently connected to the worker with workerId. Any downstream previously
+// recorded for the worker which is not in downstreamIds is removed, so an
+// empty downstreamIds clears all downstreams of the worker.
+// Like worker statuses, worker downstreams are intentionally not oplogged.
+func (r *Repository) UpsertWorkerDownstreams(ctx context.Context, workerId string, downstreamIds []string, _ ...Option) error {
+	const op = "server.(Repository).UpsertWorkerDownstreams"
+	if workerId == "" {
+		return errors.New(ctx, errors.InvalidParameter, op, "missing worker id")
+	}
+	for _, id := range downstreamIds {
+		switch id {
+		case "":
+			return errors.New(ctx, errors.InvalidParameter, op, "empty downstream worker id")
+		case workerId:
+			return errors.New(ctx, errors.InvalidParameter, op, "worker cannot be its own downstream")
+		}
+	}
+
+	_, err := r.writer.DoTx(
+		ctx,
+		db.StdRetryCnt,
+		db.ExpBackoff{},
+		func(_ db.Reader, w db.Writer) error {
+			var err error
+			switch len(downstreamIds) {
+			case 0:
+				_, err = w.Exec(ctx, deleteAllWorkerDownstreamsSql, []any{workerId})
+			default:
+				_, err = w.Exec(ctx, deleteMissingWorkerDownstreamsSql, []any{workerId, downstreamIds})
+			}
+			if err != nil {
+				return errors.Wrap(ctx, err, op, errors.WithMsg("unable to delete stale downstream workers"))
+			}
+			for _, id := range downstreamIds {
+				if _, err := w.Exec(ctx, upsertWorkerDownstreamSql, []any{workerId, id}); err != nil {
+					return errors.Wrap(ctx, err, op, errors.WithMsg(fmt.Sprintf("unable to upsert downstream worker %q", id)))
+				}
+			}
+			return nil
+		},
+	)
+	if err != nil {
+		return errors.Wrap(ctx, err, op)
+	}
+	return nil
+}
+
+// WorkerDownstreamEdge is a connection from a downstream worker to the
+// upstream worker it is connected to.
+type WorkerDownstreamEdge struct {
+	WorkerId           string
+	DownstreamWorkerId string
+	// LastSeen is the last time the upstream worker reported its status.
+	LastSeen time.Time
+}
+
+// ListWorkerDownstreams returns a map of worker ids to the ids of the
+// downstream workers connected to them. Only connections of upstream workers
+// which have reported their status within the liveness duration are returned. Supported options:
+// WithLiveness. If WithLiveness is zero the default liveness value is used.
+func (r *Repository) ListWorkerDownstreams(ctx context.Context, opt ...Option) (map[string][]string, error) {
+	const op = "server.(Repository).ListWorkerDownstreams"
+	edges, err := r.ListWorkerDownstreamEdges(ctx, opt...)
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	ret := make(map[string][]string)
+	for _, e := range edges {
+		ret[e.WorkerId] = append(ret[e.WorkerId], e.DownstreamWorkerId)
+	}
+	return ret, nil
+}
+
+// ListWorkerDownstreamEdges returns the connections between upstream and
+// downstream workers along with when they were last reported. Only
+// connections of upstream workers which have reported their status within the
+// liveness duration are returned. Supported options: WithLiveness. If WithLiveness is zero the
+// default liveness value is used.
+func (r *Repository) ListWorkerDownstreamEdges(ctx context.Context, opt ...Option) ([]*WorkerDownstreamEdge, error) {
+	const op = "server.(Repository).ListWorkerDownstreamEdges"
+	opts := GetOpts(opt...)
+	liveness := opts.withLiveness
+	if liveness <= 0 {
+		liveness = DefaultLiveness
+	}
+	if util.IsNil(r.reader) {
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "missing reader")
+	}
+
+	rows, err := r.reader.Query(ctx, fmt.Sprintf(listWorkerDownstreamsSql, uint32(liveness.Seconds())), nil)
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	defer rows.Close()
+
+	type rowsResult struct {
+		WorkerId           string
+		DownstreamWorkerId string
+		LastStatusTime     time.Time
+	}
+	var ret []*WorkerDownstreamEdge
+	for rows.Next() {
+		var result rowsResult
+		if err := r.reader.ScanRows(ctx, rows, &result); err != nil {
+			return nil, errors.Wrap(ctx, err, op)
+		}
+		ret = append(ret, &WorkerDownstreamEdge{
+			WorkerId:           result.WorkerId,
+			DownstreamWorkerId: result.DownstreamWorkerId,
+			LastSeen:           result.LastStatusTime,
+		})
+	}
+	if err := rows.Err(); err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	return ret, nil
+}
@@ -0,0 +1,114 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package server_test
+
+import (
+	"context"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/boundary/internal/db"
+	"github.com/hashicorp/boundary/internal/errors"
+	"github.com/hashicorp/boundary/internal/kms"
+	"github.com/hashicorp/boundary/internal/server"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRepository_WorkerDownstreams(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	conn, _ := db.TestSetup(t, "postgres")
+	rw := db.New(conn)
+	wrapper := db.TestWrapper(t)
+	kms := kms.TestKms(t, conn, wrapper)
+	repo, err := server.NewRepository(ctx, rw, rw, kms)
+	require.NoError(t, err)
+
+	w1 := server.TestKmsWorker(t, conn, wrapper)
+	w2 := server.TestPkiWorker(t, conn, wrapper)
+	w3 := server.TestPkiWorker(t, conn, wrapper)
+	// Only the downstreams of workers which reported their status are listed.
+	for _, w := range []*server.Worker{w2, w3} {
+		_, err := rw.Exec(ctx, "update server_worker set last_status_time = now() where public_id = ?", []any{w.GetPublicId()})
+		require.NoError(t, err)
+	}
+
+	listSorted := func(t *testing.T) map[string][]string {
+		t.Helper()
+		got, err := repo.ListWorkerDownstreams(ctx)
+		require.NoError(t, err)
+		for _, v := range got {
+			sort.Strings(v)
+		}
+		return got
+	}
+
+	t.Run("invalid", func(t *testing.T) {
+		err := repo.UpsertWorkerDownstreams(ctx, "", []string{w2.GetPublicId()})
+		assert.True(t, errors.Match(errors.T(errors.InvalidParameter), err))
+		err = repo.UpsertWorkerDownstreams(ctx, w1.GetPublicId(), []string{""})
+		assert.True(t, errors.Match(errors.T(errors.InvalidParameter), err))
+		err = repo.UpsertWorkerDownstreams(ctx, w1.GetPublicId(), []string{w1.GetPublicId()})
+		assert.True(t, errors.Match(errors.T(errors.InvalidParameter), err))
+	})
+
+	t.Run("upsert-replace-and-clear", func(t *testing.T) {
+		require := require.New(t)
+		require.NoError(repo.UpsertWorkerDownstreams(ctx, w1.GetPublicId(), []string{w2.GetPublicId(), w3.GetPublicId()}))
+		require.NoError(repo.UpsertWorkerDownstreams(ctx, w2.GetPublicId(), []string{w3.GetPublicId()}))
+		want := map[string][]string{
+			w1.GetPublicId(): {w2.GetPublicId(), w3.GetPublicId()},
+			w2.GetPublicId(): {w3.GetPublicId()},
+		}
+		sort.Strings(want[w1.GetPublicId()])
+		require.Equal(want, listSorted(t))
+
+		// Upserting the same set again is a no-op
+		require.NoError(repo.UpsertWorkerDownstreams(ctx, w1.GetPublicId(), []string{w2.GetPublicId(), w3.GetPublicId()}))
+		require.Equal(want, listSorted(t))
+
+		// Downstreams not reported anymore are removed
+		require.NoError(repo.UpsertWorkerDownstreams(ctx, w1.GetPublicId(), []string{w2.GetPublicId()}))
+		require.Equal(map[string][]string{
+			w1.GetPublicId(): {w2.GetPublicId()},
+			w2.GetPublicId(): {w3.GetPublicId()},
+		}, listSorted(t))
+
+		require.NoError(repo.UpsertWorkerDownstreams(ctx, w2.GetPublicId(), nil))
+		require.Equal(map[string][]string{
+			w1.GetPublicId(): {w2.GetPublicId()},
+		}, listSorted(t))
+	})
+
+	t.Run("edges", func(t *testing.T) {
+		require := require.New(t)
+		require.NoError(repo.UpsertWorkerDownstreams(ctx, w1.GetPublicId(), []string{w2.GetPublicId()}))
+		edges, err := repo.ListWorkerDownstreamEdges(ctx)
+		require.NoError(err)
+		require.Len(edges, 1)
+		assert.Equal(t, w1.GetPublicId(), edges[0].WorkerId)
+		assert.Equal(t, w2.GetPublicId(), edges[0].DownstreamWorkerId)
+		assert.WithinDuration(t, time.Now(), edges[0].LastSeen, time.Minute)
+	})
+
+	t.Run("stale-upstream", func(t *testing.T) {
+		require := require.New(t)
+		require.NoError(repo.UpsertWorkerDownstreams(ctx, w3.GetPublicId(), []string{w1.GetPublicId()}))
+		_, err := rw.Exec(ctx, "update server_worker set last_status_time = now() - interval '1 hour' where public_id = ?", []any{w3.GetPublicId()})
+		require.NoError(err)
+		require.Equal(map[string][]string{
+			w1.GetPublicId(): {w2.GetPublicId()},
+		}, listSorted(t))
+		require.NoError(repo.UpsertWorkerDownstreams(ctx, w3.GetPublicId(), nil))
+	})
+
+	t.Run("deleted-worker", func(t *testing.T) {
+		require := require.New(t)
+		_, err := repo.DeleteWorker(ctx, w2.GetPublicId())
+		require.NoError(err)
+		require.Empty(listSorted(t))
+	})
+}
@@ -253,6 +253,45 @@ func TestLookupWorker(t *testing.T) {
 	})
 }
 
+func TestListWorkerActiveSessionCounts(t *testing.T) {
+	ctx := context.Background()
+	conn, _ := db.TestSetup(t, "postgres")
+	rw := db.New(conn)
+	wrapper := db.TestWrapper(t)
+	kms := kms.TestKms(t, conn, wrapper)
+	repo, err := server.NewRepository(ctx, rw, rw, kms)
+	require.NoError(t, err)
+
+	w := server.TestKmsWorker(t, conn, wrapper)
+	idle := server.TestKmsWorker(t, conn, wrapper)
+
+	got, err := repo.ListWorkerActiveSessionCounts(ctx)
+	require.NoError(t, err)
+	assert.Empty(t, got)
+
+	sessRepo, err := session.NewRepository(ctx, rw, rw, kms)
+	require.NoError(t, err)
+	connRepo, err := session.NewConnectionRepository(ctx, rw, rw, kms, session.WithWorkerStateDelay(0))
+	require.NoError(t, err)
+	// 2 sessions with 3 connections
+	for _, conns := range []int{2, 1} {
+		sess := session.TestDefaultSession(t, conn, wrapper, iam.TestRepo(t, conn, wrapper),
+			session.WithDbOpts(db.WithSkipVetForWrite(true)))
+		sess, _, err = sessRepo.ActivateSession(ctx, sess.GetPublicId(), sess.Version, []byte("foo"))
+		require.NoError(t, err)
+		for i := 0; i < conns; i++ {
+			c, _, err := connRepo.AuthorizeConnection(ctx, sess.GetPublicId(), w.GetPublicId())
+			require.NoError(t, err)
+			require.NotNil(t, c)
+		}
+	}
+
+	got, err = repo.ListWorkerActiveSessionCounts(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]uint32{w.GetPublicId(): 2}, got)
+	assert.NotContains(t, got, idle.GetPublicId())
+}
+
 func TestUpsertWorkerStatus(t *testing.T) {
 	ctx := context.Background()
 	conn, _ := db.TestSetup(t, "postgres")
@@ -814,6 +853,20 @@ func TestListWorkers_WithActiveWorkers(t *testing.T) {
 			wantCnt:   1,
 			wantState: server.ActiveOperationalState.String(),
 		},
+		{ // Upsert with draining status and do not expect to get a hit
+			name: "upsert-draining-status",
+			upsertFn: func() (*server.Worker, error) {
+				return serversRepo.UpsertWorkerStatus(ctx,
+					server.NewWorker(scope.Global.String(),
+						server.WithName(worker3.GetName()),
+						server.WithAddress(worker3.GetAddress()),
+						server.WithOperationalState(server.DrainingOperationalState.String()),
+						server.WithReleaseVersion("Boundary v.0.11")),
+					server.WithPublicId(worker3.GetPublicId()))
+			},
+			wantCnt:   0,
+			wantState: server.DrainingOperationalState.String(),
+		},
 		{ // Upsert with unknown status and do not expect to get a hit- test worker create before status
 			name: "upsert-unknown-status",
 			upsertFn: func() (*server.Worker, error) {
@@ -1247,6 +1300,30 @@ func TestRepository_CreateWorker(t *testing.T) {
 			}
 		})
 	}
+
+	t.Run("attested", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		tags := []*server.Tag{{Key: "region", Value: "us-east-1"}}
+		got, err := testRepo.CreateWorker(testCtx, server.NewWorker(scope.Global.String()), server.WithApiTags(tags...), server.WithAttestationNonce("issuer#document"))
+		require.NoError(err)
+		found, err := testRepo.LookupWorker(testCtx, got.GetPublicId())
+		require.NoError(err)
+		assert.Equal(map[string][]string{"region": {"us-east-1"}}, found.GetApiTags())
+		nonces, err := testRepo.ListNonces(testCtx, server.NoncePurposeWorkerAttestation)
+		require.NoError(err)
+		require.Len(nonces, 1)
+		assert.Equal("issuer#document", nonces[0].Nonce)
+
+		// Reusing the nonce fails the creation of the worker and its tags.
+		before, err := testRepo.ListWorkers(testCtx, []string{scope.Global.String()}, server.WithLiveness(-1))
+		require.NoError(err)
+		_, err = testRepo.CreateWorker(testCtx, server.NewWorker(scope.Global.String()), server.WithApiTags(tags...), server.WithAttestationNonce("issuer#document"))
+		require.Error(err)
+		assert.True(errors.IsUniqueError(err))
+		after, err := testRepo.ListWorkers(testCtx, []string{scope.Global.String()}, server.WithLiveness(-1))
+		require.NoError(err)
+		assert.Len(after, len(before))
+	})
 }
 
 func TestRepository_UpdateWorker(t *testing.T) {
@@ -1418,6 +1495,37 @@ func TestRepository_UpdateWorker(t *testing.T) {
 		assert.Equal(t, wkr.GetUpdateTime().AsTime(), result.GetUpdateTime().AsTime())
 	})
 
+	t.Run("drain kms worker", func(t *testing.T) {
+		wkr := server.TestKmsWorker(t, conn, wrapper)
+		deadline := time.Now().Add(time.Hour).Truncate(time.Second)
+		wkr.Drain = true
+		wkr.DrainDeadline = timestamp.New(deadline)
+		got, numUpdated, err := repo.UpdateWorker(ctx, wkr, wkr.GetVersion(), []string{"drain", "DrainDeadline"})
+		require.NoError(t, err)
+		assert.Equal(t, 1, numUpdated)
+		assert.True(t, got.GetDrain())
+		assert.True(t, deadline.Equal(got.GetDrainDeadline().AsTime()))
+
+		got.Drain = false
+		got, numUpdated, err = repo.UpdateWorker(ctx, got, got.GetVersion(), []string{"drain"})
+		require.NoError(t, err)
+		assert.Equal(t, 1, numUpdated)
+		assert.False(t, got.GetDrain())
+		assert.Nil(t, got.GetDrainDeadline())
+	})
+
+	t.Run("drain excludes worker from active workers", func(t *testing.T) {
+		wkr := server.TestPkiWorker(t, conn, wrapper)
+		wkr.Drain = true
+		_, _, err := repo.UpdateWorker(ctx, wkr, wkr.GetVersion(), []string{"drain"})
+		require.NoError(t, err)
+		got, err := repo.ListWorkers(ctx, []string{scope.Global.String()}, server.WithActiveWorkers(true), server.WithLiveness(-1))
+		require.NoError(t, err)
+		for _, w := range got {
+			assert.NotEqual(t, wkr.GetPublicId(), w.GetPublicId())
+		}
+	})
+
 	errorCases := []struct {
 		name    string
 		input   *server.Worker
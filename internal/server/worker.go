@@ -26,6 +26,7 @@ const (
 	KmsWorkerType                         WorkerType        = "kms"
 	PkiWorkerType                         WorkerType        = "pki"
 	ActiveOperationalState                OperationalState  = "active"
+	DrainingOperationalState              OperationalState  = "draining"
 	ShutdownOperationalState              OperationalState  = "shutdown"
 	UnknownOperationalState               OperationalState  = "unknown"
 	AvailableLocalStorageState            LocalStorageState = "available"
@@ -58,7 +59,7 @@ type workerAuthWorkerId struct {
 
 func ValidOperationalState(s string) bool {
 	switch s {
-	case ActiveOperationalState.String(), ShutdownOperationalState.String():
+	case ActiveOperationalState.String(), DrainingOperationalState.String(), ShutdownOperationalState.String():
 		return true
 	}
 	return false
@@ -66,7 +67,7 @@ func ValidOperationalState(s string) bool {
 
 func (t OperationalState) String() string {
 	switch t {
-	case ActiveOperationalState, ShutdownOperationalState:
+	case ActiveOperationalState, DrainingOperationalState, ShutdownOperationalState:
 		return string(t)
 	}
 	return string(UnknownOperationalState)
@@ -142,8 +143,10 @@ func NewWorker(scopeId string, opt ...Option) *Worker {
 			ReleaseVersion:    opts.withReleaseVersion,
 			OperationalState:  opts.withOperationalState,
 			LocalStorageState: opts.withLocalStorageState,
+			StatusRttMs:       opts.withStatusRttMs,
 		},
-		inputTags: opts.withWorkerTags,
+		inputTags:             opts.withWorkerTags,
+		activeConnectionCount: opts.withTestActiveConnectionCount,
 	}
 	if opts.withTestUseInputTagsAsApiTags {
 		worker.apiTags = worker.inputTags
@@ -260,6 +263,9 @@ type workerAggregate struct {
 	ActiveConnectionCount uint32
 	OperationalState      string
 	LocalStorageState     string
+	StatusRttMs           uint32
+	Drain                 bool
+	DrainDeadline         *timestamp.Timestamp
 	// Config Fields
 	LastStatusTime   *timestamp.Timestamp
 	WorkerConfigTags string
@@ -282,6 +288,9 @@ func (a *workerAggregate) toWorker(ctx context.Context) (*Worker, error) {
 			ReleaseVersion:    a.ReleaseVersion,
 			OperationalState:  a.OperationalState,
 			LocalStorageState: a.LocalStorageState,
+			StatusRttMs:       a.StatusRttMs,
+			Drain:             a.Drain,
+			DrainDeadline:     a.DrainDeadline,
 		},
 		activeConnectionCount: a.ActiveConnectionCount,
 	}
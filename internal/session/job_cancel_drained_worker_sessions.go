@@ -0,0 +1,74 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package session
+
+import (
+	"context"
+	"time"
+
+	"github.com/hashicorp/boundary/internal/errors"
+	"github.com/hashicorp/boundary/internal/scheduler"
+)
+
+// drainedWorkerCancelReason is the cancel reason recorded on sessions canceled
+// because the worker proxying them reached its drain deadline.
+const drainedWorkerCancelReason = "The worker handling this session was taken down for maintenance."
+
+// cancelDrainedWorkerSessionsJob defines a periodic job that cancels the
+// sessions still proxied through draining workers once the drain deadline set
+// on the worker has passed.
+type cancelDrainedWorkerSessionsJob struct {
+	repo *Repository
+
+	// the number of sessions canceled in the most recent run
+	canceledInRun int
+}
+
+func newCancelDrainedWorkerSessionsJob(ctx context.Context, repo *Repository) (*cancelDrainedWorkerSessionsJob, error) {
+	const op = "session.newCancelDrainedWorkerSessionsJob"
+	switch {
+	case repo == nil:
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "missing repository")
+	}
+
+	return &cancelDrainedWorkerSessionsJob{
+		repo: repo,
+	}, nil
+}
+
+// Status reports the job’s current status.
+func (c *cancelDrainedWorkerSessionsJob) Status() scheduler.JobStatus {
+	return scheduler.JobStatus{
+		Completed: c.canceledInRun,
+		Total:     c.canceledInRun,
+	}
+}
+
+// Run cancels the sessions on workers past their drain deadline.
+func (c *cancelDrainedWorkerSessionsJob) Run(ctx context.Context) error {
+	const op = "session.(cancelDrainedWorkerSessionsJob).Run"
+	c.canceledInRun = 0
+	var err error
+
+	c.canceledInRun, err = c.repo.cancelSessionsOnDrainedWorkers(ctx)
+	if err != nil {
+		return errors.Wrap(ctx, err, op)
+	}
+	return nil
+}
+
+// NextRunIn returns the duration until the next job run should be scheduled.
+func (c *cancelDrainedWorkerSessionsJob) NextRunIn(_ context.Context) (time.Duration, error) {
+	return 10 * time.Second, nil
+}
+
+// Name is the unique name of the job.
+func (c *cancelDrainedWorkerSessionsJob) Name() string {
+	return "cancel_drained_worker_sessions"
+}
+
+// Description is the human readable description of the job.
+func (c *cancelDrainedWorkerSessionsJob) Description() string {
+	return "Cancel sessions on draining workers that are past their drain deadline"
+}
@@ -44,6 +44,13 @@ func RegisterJobs(ctx context.Context, scheduler *scheduler.Scheduler, w db.Writ
 	if err = scheduler.RegisterJob(ctx, deleteTerminatedJob); err != nil {
 		return fmt.Errorf("error registering delete terminated session job: %w", err)
 	}
+	cancelDrainedJob, err := newCancelDrainedWorkerSessionsJob(ctx, repo)
+	if err != nil {
+		return fmt.Errorf("error creating cancel drained worker sessions job: %w", err)
+	}
+	if err = scheduler.RegisterJob(ctx, cancelDrainedJob); err != nil {
+		return fmt.Errorf("error registering cancel drained worker sessions job: %w", err)
+	}
 
 	return nil
 }
@@ -10,6 +10,7 @@ import (
 
 	"github.com/hashicorp/boundary/internal/db"
 	"github.com/hashicorp/boundary/internal/db/timestamp"
+	"github.com/hashicorp/boundary/internal/iam"
 	"github.com/hashicorp/boundary/internal/pagination"
 	"github.com/hashicorp/boundary/internal/perms"
 )
@@ -42,6 +43,10 @@ type options struct {
 	withIgnoreDecryptionFailures bool
 	withRandomReader             io.Reader
 	withStartPageAfterItem       pagination.Item
+	withCancelReason             string
+	withTargetId                 string
+	withWorkerId                 string
+	withSessionQuota             *iam.SessionQuota
 }
 
 func getDefaultOptions() options {
@@ -159,3 +164,34 @@ func WithStartPageAfterItem(item pagination.Item) Option {
 		o.withStartPageAfterItem = item
 	}
 }
+
+// WithCancelReason is used to record the reason a session is canceled.
+func WithCancelReason(reason string) Option {
+	return func(o *options) {
+		o.withCancelReason = reason
+	}
+}
+
+// WithTargetId allows specifying a target ID criteria for the function.
+func WithTargetId(targetId string) Option {
+	return func(o *options) {
+		o.withTargetId = targetId
+	}
+}
+
+// WithWorkerId allows specifying a worker ID criteria for the function. When
+// listing cancelable sessions, only sessions with connections that are not
+// closed on the worker match.
+func WithWorkerId(workerId string) Option {
+	return func(o *options) {
+		o.withWorkerId = workerId
+	}
+}
+
+// WithSessionQuota allows specifying the session quota of the user which must
+// not be exceeded by creating a session.
+func WithSessionQuota(quota *iam.SessionQuota) Option {
+	return func(o *options) {
+		o.withSessionQuota = quota
+	}
+}
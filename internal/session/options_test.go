@@ -9,6 +9,7 @@ import (
 
 	"github.com/hashicorp/boundary/internal/db"
 	"github.com/hashicorp/boundary/internal/db/timestamp"
+	"github.com/hashicorp/boundary/internal/iam"
 	"github.com/stretchr/testify/assert"
 	"google.golang.org/protobuf/types/known/timestamppb"
 )
@@ -94,4 +95,33 @@ func Test_GetOpts(t *testing.T) {
 		testOpts.withRandomReader = reader
 		assert.Equal(opts, testOpts)
 	})
+	t.Run("WithCancelReason", func(t *testing.T) {
+		assert := assert.New(t)
+		opts := getOpts(WithCancelReason("incident"))
+		testOpts := getDefaultOptions()
+		testOpts.withCancelReason = "incident"
+		assert.Equal(opts, testOpts)
+	})
+	t.Run("WithTargetId", func(t *testing.T) {
+		assert := assert.New(t)
+		opts := getOpts(WithTargetId("ttcp_1234567890"))
+		testOpts := getDefaultOptions()
+		testOpts.withTargetId = "ttcp_1234567890"
+		assert.Equal(opts, testOpts)
+	})
+	t.Run("WithWorkerId", func(t *testing.T) {
+		assert := assert.New(t)
+		opts := getOpts(WithWorkerId("w_1234567890"))
+		testOpts := getDefaultOptions()
+		testOpts.withWorkerId = "w_1234567890"
+		assert.Equal(opts, testOpts)
+	})
+	t.Run("WithSessionQuota", func(t *testing.T) {
+		assert := assert.New(t)
+		quota := &iam.SessionQuota{Limits: []*iam.SessionQuotaLimit{{ScopeId: "p_1234567890", MaxActiveSessions: 1, MaxDailySessions: 2}}}
+		opts := getOpts(WithSessionQuota(quota))
+		testOpts := getDefaultOptions()
+		testOpts.withSessionQuota = quota
+		assert.Equal(opts, testOpts)
+	})
 }
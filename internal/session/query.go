@@ -461,6 +461,98 @@ order by update_time desc, public_id desc;
 	estimateCountSessions = `
     select reltuples::bigint as estimate from pg_class where oid in ('session'::regclass)
 `
+
+	// countUserSessions counts the sessions of a user in the projects of a
+	// scope's subtree.
+	countUserSessions = `
+select
+	count(*) filter (where ss.state in ('pending', 'active')) as active_count,
+	count(*) filter (where s.create_time >= @since)           as created_count
+from
+	session s
+	join session_state ss on
+		ss.session_id = s.public_id and
+		ss.end_time is null
+where
+	s.user_id = @user_id and
+	(
+		@scope_id = 'global' or
+		s.project_id = @scope_id or
+		s.project_id in (select public_id from iam_scope where parent_id = @scope_id)
+	);
+`
+
+	// lockUserSessions serializes the creation of sessions for a user until
+	// the end of the transaction, so that the session quota of the user can't
+	// be exceeded by concurrent requests. The limits of a quota may span
+	// projects, so the lock is per user.
+	lockUserSessions = `
+select pg_advisory_xact_lock(hashtextextended('session_quota/' || @user_id, 0));
+`
+
+	listConnectedWorkerIds = `
+select distinct
+	sc.worker_id
+from
+	session_connection sc
+	join session_connection_state scs on
+		scs.connection_id = sc.public_id and
+		scs.end_time is null
+where
+	sc.session_id = @session_id and
+	sc.worker_id is not null and
+	scs.state = 'connected';
+`
+
+	listCancelReasons = `
+select
+	public_id,
+	cancel_reason
+from
+	session
+where
+	public_id = any(@session_ids) and
+	cancel_reason is not null;
+`
+
+	listSessionsOnDrainedWorkers = `
+select distinct
+	s.public_id,
+	s.version
+from
+	session s
+	join session_state ss on
+		ss.session_id = s.public_id and
+		ss.end_time is null
+	join session_connection sc on
+		sc.session_id = s.public_id and
+		sc.closed_reason is null
+	join server_worker w on
+		w.public_id = sc.worker_id
+where
+	ss.state in ('pending', 'active') and
+	w.drain and
+	w.drain_deadline < now();
+`
+
+	listCancelableSessionsTemplate = `
+select
+	s.public_id,
+	s.version,
+	s.project_id,
+	s.user_id
+from
+	session s
+	join session_state ss on
+		ss.session_id = s.public_id and
+		ss.end_time is null
+where
+	s.project_id = any(@project_ids) and
+	ss.state in ('pending', 'active')
+	%s
+order by
+	s.create_time, s.public_id;
+`
 )
 
 const (
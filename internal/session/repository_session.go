@@ -15,6 +15,7 @@ import (
 	"github.com/hashicorp/boundary/internal/db/timestamp"
 	"github.com/hashicorp/boundary/internal/errors"
 	"github.com/hashicorp/boundary/internal/event"
+	"github.com/hashicorp/boundary/internal/iam"
 	"github.com/hashicorp/boundary/internal/kms"
 	"github.com/hashicorp/boundary/internal/util"
 	wrapping "github.com/hashicorp/go-kms-wrapping/v2"
@@ -22,9 +23,9 @@ import (
 
 // CreateSession inserts into the repository and returns the new Session with
 // its State of "Pending".  The following fields must be empty when creating a
-// session: WorkerId, and PublicId.  No options are
-// currently supported.
-func (r *Repository) CreateSession(ctx context.Context, sessionWrapper wrapping.Wrapper, newSession *Session, workerAddresses []string, _ ...Option) (*Session, error) {
+// session: WorkerId, and PublicId.  WithSessionQuota is the only supported
+// option.
+func (r *Repository) CreateSession(ctx context.Context, sessionWrapper wrapping.Wrapper, newSession *Session, workerAddresses []string, opt ...Option) (*Session, error) {
 	const op = "session.(Repository).CreateSession"
 	if newSession == nil {
 		return nil, errors.New(ctx, errors.InvalidParameter, op, "missing session")
@@ -79,15 +80,23 @@ func (r *Repository) CreateSession(ctx context.Context, sessionWrapper wrapping.
 		return nil, errors.Wrap(ctx, err, op, errors.WithMsg("failed to encrypt session"))
 	}
 
+	opts := getOpts(opt...)
 	var returnedSession *Session
 	_, err = r.writer.DoTx(
 		ctx,
 		db.StdRetryCnt,
 		db.ExpBackoff{},
 		func(read db.Reader, w db.Writer) error {
+			if opts.withSessionQuota != nil && !opts.withSessionQuota.Unlimited() {
+				if err := checkSessionQuota(ctx, read, w, newSession.UserId, opts.withSessionQuota); err != nil {
+					return err
+				}
+			}
 			returnedSession = newSession.Clone().(*Session)
 			returnedSession.DynamicCredentials = nil
 			returnedSession.StaticCredentials = nil
+			returnedSession.FailoverEndpoints = nil
+			returnedSession.ProbedEndpoints = nil
 			if err = w.Create(ctx, returnedSession); err != nil {
 				return errors.Wrap(ctx, err, op)
 			}
@@ -123,6 +132,36 @@ func (r *Repository) CreateSession(ctx context.Context, sessionWrapper wrapping.
 				returnedSession.ProtocolWorkerId = swp.WorkerId
 			}
 
+			if len(newSession.FailoverEndpoints) > 0 {
+				failoverEndpoints := make([]any, 0, len(newSession.FailoverEndpoints))
+				for i, ep := range newSession.FailoverEndpoints {
+					fe, err := NewSessionFailoverEndpoint(ctx, newSession.PublicId, ep, uint32(i))
+					if err != nil {
+						return errors.Wrap(ctx, err, op)
+					}
+					failoverEndpoints = append(failoverEndpoints, fe)
+				}
+				if err = w.CreateItems(ctx, failoverEndpoints); err != nil {
+					return errors.Wrap(ctx, err, op, errors.WithMsg("failed to create failover endpoints"))
+				}
+				returnedSession.FailoverEndpoints = newSession.FailoverEndpoints
+			}
+
+			if len(newSession.ProbedEndpoints) > 0 {
+				probedEndpoints := make([]any, 0, len(newSession.ProbedEndpoints))
+				for _, ep := range newSession.ProbedEndpoints {
+					pe, err := NewSessionProbedEndpoint(ctx, newSession.PublicId, ep)
+					if err != nil {
+						return errors.Wrap(ctx, err, op)
+					}
+					probedEndpoints = append(probedEndpoints, pe)
+				}
+				if err = w.CreateItems(ctx, probedEndpoints); err != nil {
+					return errors.Wrap(ctx, err, op, errors.WithMsg("failed to create probed endpoints"))
+				}
+				returnedSession.ProbedEndpoints = newSession.ProbedEndpoints
+			}
+
 			for _, cred := range newSession.DynamicCredentials {
 				cred.SessionId = newSession.PublicId
 			}
@@ -247,6 +286,22 @@ func (r *Repository) LookupSession(ctx context.Context, sessionId string, opt ..
 			}
 			session.ProtocolWorkerId = sessionWorkerProtocol.WorkerId
 
+			var failoverEndpoints []*SessionFailoverEndpoint
+			if err := read.SearchWhere(ctx, &failoverEndpoints, "session_id = ?", []any{sessionId}, db.WithOrder("priority asc")); err != nil {
+				return errors.Wrap(ctx, err, op)
+			}
+			for _, fe := range failoverEndpoints {
+				session.FailoverEndpoints = append(session.FailoverEndpoints, fe.Endpoint)
+			}
+
+			var probedEndpoints []*SessionProbedEndpoint
+			if err := read.SearchWhere(ctx, &probedEndpoints, "session_id = ?", []any{sessionId}, db.WithOrder("endpoint asc")); err != nil {
+				return errors.Wrap(ctx, err, op)
+			}
+			for _, pe := range probedEndpoints {
+				session.ProbedEndpoints = append(session.ProbedEndpoints, pe.Endpoint)
+			}
+
 			connections, err := fetchConnections(ctx, read, sessionId, db.WithOrder("create_time desc"))
 			if err != nil {
 				return errors.Wrap(ctx, err, op)
@@ -440,6 +495,77 @@ func (r *Repository) estimatedCount(ctx context.Context) (int, error) {
 	return count, nil
 }
 
+// CountUserSessions returns the number of pending or active sessions of the
+// user in the projects of the scope's subtree, and the number of sessions
+// created for the user in those projects since the given time.
+func (r *Repository) CountUserSessions(ctx context.Context, userId, scopeId string, since time.Time) (active int, created int, _ error) {
+	const op = "session.(Repository).CountUserSessions"
+	if userId == "" {
+		return 0, 0, errors.New(ctx, errors.InvalidParameter, op, "missing user id")
+	}
+	if scopeId == "" {
+		return 0, 0, errors.New(ctx, errors.InvalidParameter, op, "missing scope id")
+	}
+	active, created, err := countUserSessionsIn(ctx, r.reader, userId, scopeId, since)
+	if err != nil {
+		return 0, 0, errors.Wrap(ctx, err, op)
+	}
+	return active, created, nil
+}
+
+func countUserSessionsIn(ctx context.Context, r db.Reader, userId, scopeId string, since time.Time) (active int, created int, _ error) {
+	const op = "session.countUserSessionsIn"
+	rows, err := r.Query(ctx, countUserSessions, []any{
+		sql.Named("user_id", userId),
+		sql.Named("scope_id", scopeId),
+		sql.Named("since", since),
+	})
+	if err != nil {
+		return 0, 0, errors.Wrap(ctx, err, op)
+	}
+	defer rows.Close()
+	for rows.Next() {
+		if err := rows.Scan(&active, &created); err != nil {
+			return 0, 0, errors.Wrap(ctx, err, op, errors.WithMsg("scan row failed"))
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return 0, 0, errors.Wrap(ctx, err, op)
+	}
+	return active, created, nil
+}
+
+// checkSessionQuota locks the sessions of the user for the rest of the
+// transaction and returns an error with the SessionQuotaExceeded code if
+// creating another session would exceed any of the limits of the quota. Each
+// limit counts the sessions of the user in the subtree of its scope.
+func checkSessionQuota(ctx context.Context, r db.Reader, w db.Writer, userId string, quota *iam.SessionQuota) error {
+	const op = "session.checkSessionQuota"
+	rows, err := w.Query(ctx, lockUserSessions, []any{
+		sql.Named("user_id", userId),
+	})
+	if err != nil {
+		return errors.Wrap(ctx, err, op, errors.WithMsg("failed to lock user sessions"))
+	}
+	rows.Close()
+	since := time.Now().Add(-iam.DailySessionsPeriod)
+	for _, l := range quota.Limits {
+		active, daily, err := countUserSessionsIn(ctx, r, userId, l.ScopeId, since)
+		if err != nil {
+			return errors.Wrap(ctx, err, op)
+		}
+		switch {
+		case l.MaxActiveSessions > 0 && active >= int(l.MaxActiveSessions):
+			return errors.New(ctx, errors.SessionQuotaExceeded, op,
+				fmt.Sprintf("Session quota exceeded: the user already has %d of a maximum of %d pending or active sessions in scope %s.", active, l.MaxActiveSessions, l.ScopeId))
+		case l.MaxDailySessions > 0 && daily >= int(l.MaxDailySessions):
+			return errors.New(ctx, errors.SessionQuotaExceeded, op,
+				fmt.Sprintf("Session quota exceeded: the user has authorized %d of a maximum of %d sessions in the last 24 hours in scope %s.", daily, l.MaxDailySessions, l.ScopeId))
+		}
+	}
+	return nil
+}
+
 // DeleteSession will delete a session from the repository.
 func (r *Repository) DeleteSession(ctx context.Context, publicId string, _ ...Option) (int, error) {
 	const op = "session.(Repository).DeleteSession"
@@ -487,6 +613,7 @@ func (r *Repository) DeleteSession(ctx context.Context, publicId string, _ ...Op
 // idempotent.
 // Supported Options:
 //   - WithIgnoreDecryptionFailures
+//   - WithCancelReason
 func (r *Repository) CancelSession(ctx context.Context, sessionId string, sessionVersion uint32, opt ...Option) (*Session, error) {
 	const op = "session.(Repository).CancelSession"
 	if sessionId == "" {
@@ -503,6 +630,58 @@ func (r *Repository) CancelSession(ctx context.Context, sessionId string, sessio
 	return s, nil
 }
 
+// ListCancelableSessions returns the pending and active sessions in the
+// projects. Only the public id, version, project id and user id of the
+// returned sessions are set.
+// Supported Options:
+//   - WithUserId
+//   - WithTargetId
+//   - WithWorkerId: only sessions with a connection proxied by the worker
+func (r *Repository) ListCancelableSessions(ctx context.Context, projectIds []string, opt ...Option) ([]*Session, error) {
+	const op = "session.(Repository).ListCancelableSessions"
+	if len(projectIds) == 0 {
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "missing project ids")
+	}
+	opts := getOpts(opt...)
+
+	var conditions []string
+	args := []any{sql.Named("project_ids", "{"+strings.Join(projectIds, ",")+"}")}
+	if opts.withUserId != "" {
+		conditions = append(conditions, "and s.user_id = @user_id")
+		args = append(args, sql.Named("user_id", opts.withUserId))
+	}
+	if opts.withTargetId != "" {
+		conditions = append(conditions, "and s.target_id = @target_id")
+		args = append(args, sql.Named("target_id", opts.withTargetId))
+	}
+	if opts.withWorkerId != "" {
+		// Only sessions with connections still open on the worker are
+		// affected by it.
+		conditions = append(conditions, "and exists (select 1 from session_connection sc join session_connection_state scs on scs.connection_id = sc.public_id and scs.end_time is null where sc.session_id = s.public_id and sc.worker_id = @worker_id and scs.state != 'closed')")
+		args = append(args, sql.Named("worker_id", opts.withWorkerId))
+	}
+
+	rows, err := r.reader.Query(ctx, fmt.Sprintf(listCancelableSessionsTemplate, strings.Join(conditions, "\n\t")), args)
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	defer rows.Close()
+	var sessions []*Session
+	for rows.Next() {
+		s := AllocSession()
+		var userId sql.NullString
+		if err := rows.Scan(&s.PublicId, &s.Version, &s.ProjectId, &userId); err != nil {
+			return nil, errors.Wrap(ctx, err, op, errors.WithMsg("scan row failed"))
+		}
+		s.UserId = userId.String
+		sessions = append(sessions, &s)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	return sessions, nil
+}
+
 // TerminateCompletedSessions will terminate sessions in the repo based on:
 //   - sessions that have exhausted their connection limit and all their connections are closed.
 //   - sessions that are expired and all their connections are closed.
@@ -806,7 +985,12 @@ func (r *Repository) updateState(ctx context.Context, sessionId string, sessionV
 			// We need to update the session version as that's the aggregate
 			updatedSession.PublicId = sessionId
 			updatedSession.Version = uint32(sessionVersion) + 1
-			rowsUpdated, err := w.Update(ctx, &updatedSession, []string{"Version"}, nil, db.WithVersion(&sessionVersion))
+			fieldMask := []string{"Version"}
+			if s == StatusCanceling && opts.withCancelReason != "" {
+				updatedSession.CancelReason = opts.withCancelReason
+				fieldMask = append(fieldMask, "CancelReason")
+			}
+			rowsUpdated, err := w.Update(ctx, &updatedSession, fieldMask, nil, db.WithVersion(&sessionVersion))
 			if err != nil {
 				return errors.Wrap(ctx, err, op)
 			}
@@ -877,12 +1061,15 @@ func (r *Repository) CheckIfNotActive(ctx context.Context, reportedSessions []st
 				return errors.Wrap(ctx, err, op)
 			}
 
+			var canceling []string
 			for _, s := range states {
 				delete(unrecognizedSessions, s.SessionId)
 				switch s.Status {
 				case StatusPending, StatusActive:
 					continue
-				case StatusCanceling, StatusTerminated:
+				case StatusCanceling:
+					canceling = append(canceling, s.SessionId)
+				case StatusTerminated:
 				default:
 					return errors.New(ctx, errors.Internal, op, fmt.Sprintf("unknown session state %q", s.Status))
 				}
@@ -892,6 +1079,30 @@ func (r *Repository) CheckIfNotActive(ctx context.Context, reportedSessions []st
 					Status:    s.Status,
 				})
 			}
+			if len(canceling) == 0 {
+				return nil
+			}
+
+			// Workers show the reason a session was canceled to its user.
+			rows, err := reader.Query(ctx, listCancelReasons, []any{sql.Named("session_ids", "{"+strings.Join(canceling, ",")+"}")})
+			if err != nil {
+				return errors.Wrap(ctx, err, op)
+			}
+			defer rows.Close()
+			reasons := make(map[string]string, len(canceling))
+			for rows.Next() {
+				var id, reason string
+				if err := rows.Scan(&id, &reason); err != nil {
+					return errors.Wrap(ctx, err, op, errors.WithMsg("scan row failed"))
+				}
+				reasons[id] = reason
+			}
+			if err := rows.Err(); err != nil {
+				return errors.Wrap(ctx, err, op)
+			}
+			for _, na := range notActive {
+				na.CancelReason = reasons[na.SessionId]
+			}
 			return nil
 		},
 	)
@@ -923,6 +1134,48 @@ func (r *Repository) deleteSessionsTerminatedBefore(ctx context.Context, thresho
 	return c, nil
 }
 
+// cancelSessionsOnDrainedWorkers cancels the sessions which still have open
+// connections on draining workers whose drain deadline has passed. It returns
+// the number of sessions canceled.
+func (r *Repository) cancelSessionsOnDrainedWorkers(ctx context.Context) (int, error) {
+	const op = "session.(Repository).cancelSessionsOnDrainedWorkers"
+
+	rows, err := r.reader.Query(ctx, listSessionsOnDrainedWorkers, nil)
+	if err != nil {
+		return 0, errors.Wrap(ctx, err, op)
+	}
+	type drainedSession struct {
+		id      string
+		version uint32
+	}
+	var sessions []drainedSession
+	for rows.Next() {
+		var ds drainedSession
+		if err := rows.Scan(&ds.id, &ds.version); err != nil {
+			rows.Close()
+			return 0, errors.Wrap(ctx, err, op, errors.WithMsg("scan row failed"))
+		}
+		sessions = append(sessions, ds)
+	}
+	if err := rows.Err(); err != nil {
+		rows.Close()
+		return 0, errors.Wrap(ctx, err, op)
+	}
+	rows.Close()
+
+	var canceled int
+	for _, ds := range sessions {
+		if _, err := r.CancelSession(ctx, ds.id, ds.version, WithCancelReason(drainedWorkerCancelReason)); err != nil {
+			// The session may have changed since it was listed, it will be
+			// picked up again on the next run if it is still eligible.
+			event.WriteError(ctx, op, err, event.WithInfoMsg("error canceling session on drained worker", "session_id", ds.id))
+			continue
+		}
+		canceled++
+	}
+	return canceled, nil
+}
+
 func fetchStates(ctx context.Context, r db.Reader, sessionId string, opt ...db.Option) ([]*State, error) {
 	const op = "session.fetchStates"
 	var states []*State
@@ -31,6 +31,7 @@ import (
 	tcpStore "github.com/hashicorp/boundary/internal/target/tcp/store"
 	"github.com/hashicorp/boundary/internal/types/action"
 	"github.com/hashicorp/boundary/internal/types/resource"
+	"github.com/hashicorp/boundary/internal/types/scope"
 	wrapping "github.com/hashicorp/go-kms-wrapping/v2"
 	"github.com/hashicorp/go-uuid"
 	"github.com/jackc/pgconn"
@@ -1175,6 +1176,185 @@ func TestRepository_TerminateCompletedSessions(t *testing.T) {
 	}
 }
 
+func TestRepository_CountUserSessions(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	conn, _ := db.TestSetup(t, "postgres")
+	rw := db.New(conn)
+	wrapper := db.TestWrapper(t)
+	iamRepo := iam.TestRepo(t, conn, wrapper)
+	testKms := kms.TestKms(t, conn, wrapper)
+	repo, err := NewRepository(ctx, rw, rw, testKms)
+	require.NoError(t, err)
+
+	_, _, err = repo.CountUserSessions(ctx, "", "p_1234567890", time.Now())
+	assert.True(t, errors.Match(errors.T(errors.InvalidParameter), err))
+	_, _, err = repo.CountUserSessions(ctx, "u_1234567890", "", time.Now())
+	assert.True(t, errors.Match(errors.T(errors.InvalidParameter), err))
+
+	composedOf := TestSessionParams(t, conn, wrapper, iamRepo)
+	future := &timestamp.Timestamp{Timestamp: timestamppb.New(time.Now().Add(time.Hour))}
+	first := TestSession(t, conn, wrapper, composedOf, WithExpirationTime(future))
+	_ = TestSession(t, conn, wrapper, composedOf, WithExpirationTime(future))
+	// A session of another user isn't counted.
+	_ = TestDefaultSession(t, conn, wrapper, iamRepo)
+	// Nor is a session of the user in another project.
+	otherProject := TestSessionParams(t, conn, wrapper, iamRepo)
+	otherProject.UserId = composedOf.UserId
+	otherProject.AuthTokenId = composedOf.AuthTokenId
+	_ = TestSession(t, conn, wrapper, otherProject, WithExpirationTime(future))
+
+	active, created, err := repo.CountUserSessions(ctx, composedOf.UserId, composedOf.ProjectId, time.Now().Add(-time.Hour))
+	require.NoError(t, err)
+	assert.Equal(t, 2, active)
+	assert.Equal(t, 2, created)
+
+	// The sessions in the projects of an org are counted in the org, and
+	// those in all projects in the global scope.
+	proj, err := iamRepo.LookupScope(ctx, composedOf.ProjectId)
+	require.NoError(t, err)
+	active, created, err = repo.CountUserSessions(ctx, composedOf.UserId, proj.GetParentId(), time.Now().Add(-time.Hour))
+	require.NoError(t, err)
+	assert.Equal(t, 2, active)
+	assert.Equal(t, 2, created)
+	active, created, err = repo.CountUserSessions(ctx, composedOf.UserId, scope.Global.String(), time.Now().Add(-time.Hour))
+	require.NoError(t, err)
+	assert.Equal(t, 3, active)
+	assert.Equal(t, 3, created)
+
+	_, err = repo.CancelSession(ctx, first.PublicId, first.Version)
+	require.NoError(t, err)
+	active, created, err = repo.CountUserSessions(ctx, composedOf.UserId, composedOf.ProjectId, time.Now().Add(time.Hour))
+	require.NoError(t, err)
+	assert.Equal(t, 1, active)
+	assert.Equal(t, 0, created)
+}
+
+func TestRepository_CreateSession_WithSessionQuota(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	conn, _ := db.TestSetup(t, "postgres")
+	rw := db.New(conn)
+	wrapper := db.TestWrapper(t)
+	iamRepo := iam.TestRepo(t, conn, wrapper)
+	testKms := kms.TestKms(t, conn, wrapper)
+	repo, err := NewRepository(ctx, rw, rw, testKms)
+	require.NoError(t, err)
+
+	composedOf := TestSessionParams(t, conn, wrapper, iamRepo)
+	sessionWrapper, err := testKms.GetWrapper(ctx, composedOf.ProjectId, kms.KeyPurposeSessions)
+	require.NoError(t, err)
+	create := func(limits ...*iam.SessionQuotaLimit) (*Session, error) {
+		s, err := New(ctx, composedOf)
+		require.NoError(t, err)
+		return repo.CreateSession(ctx, sessionWrapper, s, []string{"1.2.3.4"}, WithSessionQuota(&iam.SessionQuota{Limits: limits}))
+	}
+	projectId := composedOf.ProjectId
+	globalId := scope.Global.String()
+
+	first, err := create(&iam.SessionQuotaLimit{ScopeId: projectId, MaxActiveSessions: 1})
+	require.NoError(t, err)
+	_, err = create(&iam.SessionQuotaLimit{ScopeId: projectId, MaxActiveSessions: 1})
+	require.Error(t, err)
+	assert.True(t, errors.Match(errors.T(errors.SessionQuotaExceeded), err))
+	assert.Contains(t, err.Error(), "already has 1 of a maximum of 1 pending or active sessions in scope "+projectId)
+
+	// Canceled sessions don't count against the active sessions, but they
+	// still count against the daily sessions.
+	_, err = repo.CancelSession(ctx, first.PublicId, first.Version)
+	require.NoError(t, err)
+	_, err = create(&iam.SessionQuotaLimit{ScopeId: projectId, MaxActiveSessions: 1, MaxDailySessions: 2})
+	require.NoError(t, err)
+	_, err = create(&iam.SessionQuotaLimit{ScopeId: projectId, MaxDailySessions: 2})
+	require.Error(t, err)
+	assert.True(t, errors.Match(errors.T(errors.SessionQuotaExceeded), err))
+	assert.Contains(t, err.Error(), "authorized 2 of a maximum of 2 sessions in the last 24 hours in scope "+projectId)
+
+	// An unlimited quota isn't checked.
+	_, err = create()
+	require.NoError(t, err)
+
+	active, created, err := repo.CountUserSessions(ctx, composedOf.UserId, composedOf.ProjectId, time.Now().Add(-time.Hour))
+	require.NoError(t, err)
+	assert.Equal(t, 2, active)
+	assert.Equal(t, 3, created)
+
+	// A global limit also counts the sessions of the user in other projects,
+	// and every limit of the quota must hold.
+	otherProject := TestSessionParams(t, conn, wrapper, iamRepo)
+	otherProject.UserId = composedOf.UserId
+	otherProject.AuthTokenId = composedOf.AuthTokenId
+	_ = TestSession(t, conn, wrapper, otherProject, WithExpirationTime(&timestamp.Timestamp{Timestamp: timestamppb.New(time.Now().Add(time.Hour))}))
+	_, err = create(&iam.SessionQuotaLimit{ScopeId: globalId, MaxActiveSessions: 3}, &iam.SessionQuotaLimit{ScopeId: projectId, MaxActiveSessions: 5})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "already has 3 of a maximum of 3 pending or active sessions in scope global")
+	_, err = create(&iam.SessionQuotaLimit{ScopeId: globalId, MaxActiveSessions: 5}, &iam.SessionQuotaLimit{ScopeId: projectId, MaxActiveSessions: 3})
+	require.NoError(t, err)
+}
+
+func TestRepository_ListCancelableSessions(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	conn, _ := db.TestSetup(t, "postgres")
+	rw := db.New(conn)
+	wrapper := db.TestWrapper(t)
+	iamRepo := iam.TestRepo(t, conn, wrapper)
+	testKms := kms.TestKms(t, conn, wrapper)
+	repo, err := NewRepository(ctx, rw, rw, testKms)
+	require.NoError(t, err)
+
+	_, err = repo.ListCancelableSessions(ctx, nil)
+	assert.True(t, errors.Match(errors.T(errors.InvalidParameter), err))
+
+	composedOf := TestSessionParams(t, conn, wrapper, iamRepo)
+	first := TestSession(t, conn, wrapper, composedOf)
+	second := TestSession(t, conn, wrapper, composedOf)
+	// A session of another user in another project.
+	other := TestDefaultSession(t, conn, wrapper, iamRepo)
+
+	got, err := repo.ListCancelableSessions(ctx, []string{composedOf.ProjectId, other.ProjectId}, WithUserId(composedOf.UserId))
+	require.NoError(t, err)
+	require.Len(t, got, 2)
+	assert.Equal(t, first.PublicId, got[0].PublicId)
+	assert.Equal(t, second.PublicId, got[1].PublicId)
+
+	got, err = repo.ListCancelableSessions(ctx, []string{composedOf.ProjectId}, WithTargetId(other.TargetId))
+	require.NoError(t, err)
+	assert.Empty(t, got)
+
+	got, err = repo.ListCancelableSessions(ctx, []string{composedOf.ProjectId}, WithWorkerId("w_1234567890"))
+	require.NoError(t, err)
+	assert.Empty(t, got)
+
+	// Canceled sessions are no longer listed, and keep the cancel reason.
+	canceled, err := repo.CancelSession(ctx, first.PublicId, first.Version, WithCancelReason("maintenance"))
+	require.NoError(t, err)
+	assert.Equal(t, "maintenance", canceled.CancelReason)
+	got, err = repo.ListCancelableSessions(ctx, []string{composedOf.ProjectId}, WithUserId(composedOf.UserId))
+	require.NoError(t, err)
+	require.Len(t, got, 1)
+	assert.Equal(t, second.PublicId, got[0].PublicId)
+
+	// Only sessions with connections that are not closed on the worker are
+	// listed for it.
+	connRepo, err := NewConnectionRepository(ctx, rw, rw, testKms)
+	require.NoError(t, err)
+	worker := server.TestKmsWorker(t, conn, wrapper)
+	second, _, err = repo.ActivateSession(ctx, second.PublicId, second.Version, []byte("foo"))
+	require.NoError(t, err)
+	c, _, err := connRepo.AuthorizeConnection(ctx, second.PublicId, worker.PublicId)
+	require.NoError(t, err)
+	got, err = repo.ListCancelableSessions(ctx, []string{composedOf.ProjectId}, WithWorkerId(worker.PublicId))
+	require.NoError(t, err)
+	require.Len(t, got, 1)
+	assert.Equal(t, second.PublicId, got[0].PublicId)
+	_, err = connRepo.closeConnections(ctx, []CloseWith{{ConnectionId: c.PublicId, ClosedReason: ConnectionClosedByUser}})
+	require.NoError(t, err)
+	got, err = repo.ListCancelableSessions(ctx, []string{composedOf.ProjectId}, WithWorkerId(worker.PublicId))
+	require.NoError(t, err)
+	assert.Empty(t, got)
+}
+
 func TestRepository_CancelSession(t *testing.T) {
 	t.Parallel()
 	ctx := context.Background()
@@ -16,6 +16,8 @@ type StateReport struct {
 	SessionId   string
 	Status      Status
 	Connections []*Connection
+	// CancelReason is the reason given when the session was canceled, if any.
+	CancelReason string
 	// Unrecognized indicates that the SessionId was not found in the database.
 	Unrecognized bool
 }
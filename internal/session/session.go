@@ -68,6 +68,32 @@ type ComposedOf struct {
 	ProtocolWorkerId string
 	// CorrelationId is the CorrelationId of the authorize-session request
 	CorrelationId string `json:"-" gorm:"default:null"`
+	// HostHealthCheck is the probe workers use to check the health of the
+	// endpoint and failover endpoints. Empty if hosts are not health checked.
+	HostHealthCheck string
+	// FailoverEndpoints are the endpoints of other hosts in the host set of the
+	// session, in the order a worker tries them if it cannot connect to the
+	// endpoint. FailoverEndpoints optional.
+	FailoverEndpoints []string
+	// ProbedEndpoints are the endpoints, in host:port form, of hosts in the
+	// host set of the session which the worker probes the health of.
+	// ProbedEndpoints optional.
+	ProbedEndpoints []string
+	// AccessWindow is the json encoded access window of the target. Workers
+	// don't authorize connections outside of the window. AccessWindow is
+	// optional.
+	AccessWindow string
+	// Reason is the reason the user gave for needing the session. Reason is
+	// optional.
+	Reason string
+	// TicketId is the id of the ticket the user gave for needing the session.
+	// TicketId is optional.
+	TicketId string
+	// UploadRateLimit and DownloadRateLimit are the bandwidth limits, in bytes
+	// per second, of the target. Workers enforce them when proxying the
+	// session's connections. They are optional.
+	UploadRateLimit   uint32
+	DownloadRateLimit uint32
 }
 
 // Session contains information about a user's session with a target
@@ -115,6 +141,27 @@ type Session struct {
 	EgressWorkerFilter  string `json:"-" gorm:"default:null"`
 	IngressWorkerFilter string `json:"-" gorm:"default:null"`
 
+	// HostHealthCheck is the probe workers use to check the health of the
+	// endpoint and failover endpoints
+	HostHealthCheck string `json:"-" gorm:"default:null"`
+
+	// AccessWindow is the json encoded access window of the target
+	AccessWindow string `json:"-" gorm:"default:null"`
+
+	// UploadRateLimit is the bandwidth limit, in bytes per second, of the
+	// traffic sent by the session's clients
+	UploadRateLimit uint32 `json:"-" gorm:"default:null"`
+	// DownloadRateLimit is the bandwidth limit, in bytes per second, of the
+	// traffic sent to the session's clients
+	DownloadRateLimit uint32 `json:"-" gorm:"default:null"`
+
+	// Reason is the reason given when the session was authorized
+	Reason string `json:"reason,omitempty" gorm:"default:null"`
+	// TicketId is the ticket id given when the session was authorized
+	TicketId string `json:"ticket_id,omitempty" gorm:"default:null"`
+	// CancelReason is the reason given when the session was canceled
+	CancelReason string `json:"cancel_reason,omitempty" gorm:"default:null"`
+
 	// key_id is the ID of the key version used to encrypt any fields in this struct
 	KeyId string `json:"key_id,omitempty" gorm:"default:null"`
 
@@ -137,6 +184,12 @@ type Session struct {
 	// ProtocolWorkerId of the session
 	ProtocolWorkerId string `gorm:"-"`
 
+	// FailoverEndpoints of the session in the order they are tried
+	FailoverEndpoints []string `gorm:"-"`
+
+	// ProbedEndpoints of the session, in host:port form
+	ProbedEndpoints []string `gorm:"-"`
+
 	// Connections for the session are for read only and are ignored during write operations
 	Connections []*Connection `gorm:"-"`
 
@@ -211,6 +264,14 @@ func New(ctx context.Context, c ComposedOf, _ ...Option) (*Session, error) {
 		StaticCredentials:   c.StaticCredentials,
 		ProtocolWorkerId:    c.ProtocolWorkerId,
 		CorrelationId:       c.CorrelationId,
+		HostHealthCheck:     c.HostHealthCheck,
+		FailoverEndpoints:   c.FailoverEndpoints,
+		ProbedEndpoints:     c.ProbedEndpoints,
+		AccessWindow:        c.AccessWindow,
+		Reason:              c.Reason,
+		TicketId:            c.TicketId,
+		UploadRateLimit:     c.UploadRateLimit,
+		DownloadRateLimit:   c.DownloadRateLimit,
 	}
 	if err := s.validateNewSession(ctx); err != nil {
 		return nil, errors.Wrap(ctx, err, op)
@@ -243,6 +304,21 @@ func (s *Session) Clone() any {
 		KeyId:               s.KeyId,
 		ProtocolWorkerId:    s.ProtocolWorkerId,
 		CorrelationId:       s.CorrelationId,
+		HostHealthCheck:     s.HostHealthCheck,
+		AccessWindow:        s.AccessWindow,
+		UploadRateLimit:     s.UploadRateLimit,
+		DownloadRateLimit:   s.DownloadRateLimit,
+		Reason:              s.Reason,
+		TicketId:            s.TicketId,
+		CancelReason:        s.CancelReason,
+	}
+	if len(s.FailoverEndpoints) > 0 {
+		clone.FailoverEndpoints = make([]string, len(s.FailoverEndpoints))
+		copy(clone.FailoverEndpoints, s.FailoverEndpoints)
+	}
+	if len(s.ProbedEndpoints) > 0 {
+		clone.ProbedEndpoints = make([]string, len(s.ProbedEndpoints))
+		copy(clone.ProbedEndpoints, s.ProbedEndpoints)
 	}
 	if len(s.States) > 0 {
 		clone.States = make([]*State, 0, len(s.States))
@@ -0,0 +1,60 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package session
+
+import (
+	"context"
+
+	"github.com/hashicorp/boundary/internal/errors"
+)
+
+const (
+	defaultSessionFailoverEndpointTableName = "session_failover_endpoint"
+)
+
+// SessionFailoverEndpoint is an endpoint a worker can connect to if it cannot
+// connect to the endpoint of the session. Failover endpoints are tried in
+// ascending priority order.
+type SessionFailoverEndpoint struct {
+	// SessionId of the session
+	SessionId string `json:"session_id,omitempty" gorm:"primary_key"`
+	// Endpoint to fail over to
+	Endpoint string `json:"endpoint,omitempty" gorm:"primary_key"`
+	// Priority of the endpoint, lower is tried first
+	Priority uint32 `json:"priority,omitempty"`
+
+	tableName string `gorm:"-"`
+}
+
+// NewSessionFailoverEndpoint creates a new in-memory session failover
+// endpoint
+func NewSessionFailoverEndpoint(ctx context.Context, sessionId, endpoint string, priority uint32) (*SessionFailoverEndpoint, error) {
+	const op = "session.NewSessionFailoverEndpoint"
+	if sessionId == "" {
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "missing session id")
+	}
+	if endpoint == "" {
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "missing endpoint")
+	}
+	return &SessionFailoverEndpoint{
+		SessionId: sessionId,
+		Endpoint:  endpoint,
+		Priority:  priority,
+	}, nil
+}
+
+// TableName returns the tablename to override the default gorm table name
+func (s *SessionFailoverEndpoint) TableName() string {
+	if s.tableName != "" {
+		return s.tableName
+	}
+	return defaultSessionFailoverEndpointTableName
+}
+
+// SetTableName sets the tablename and satisfies the ReplayableMessage
+// interface. If the caller attempts to set the name to "" the name will be
+// reset to the default name.
+func (s *SessionFailoverEndpoint) SetTableName(n string) {
+	s.tableName = n
+}
@@ -0,0 +1,55 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package session
+
+import (
+	"context"
+
+	"github.com/hashicorp/boundary/internal/errors"
+)
+
+const (
+	defaultSessionProbedEndpointTableName = "session_probed_endpoint"
+)
+
+// SessionProbedEndpoint is an endpoint, in host:port form, of a host in the
+// host set of the session whose health the worker handling the session probes.
+type SessionProbedEndpoint struct {
+	// SessionId of the session
+	SessionId string `json:"session_id,omitempty" gorm:"primary_key"`
+	// Endpoint to probe
+	Endpoint string `json:"endpoint,omitempty" gorm:"primary_key"`
+
+	tableName string `gorm:"-"`
+}
+
+// NewSessionProbedEndpoint creates a new in-memory session probed endpoint
+func NewSessionProbedEndpoint(ctx context.Context, sessionId, endpoint string) (*SessionProbedEndpoint, error) {
+	const op = "session.NewSessionProbedEndpoint"
+	if sessionId == "" {
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "missing session id")
+	}
+	if endpoint == "" {
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "missing endpoint")
+	}
+	return &SessionProbedEndpoint{
+		SessionId: sessionId,
+		Endpoint:  endpoint,
+	}, nil
+}
+
+// TableName returns the tablename to override the default gorm table name
+func (s *SessionProbedEndpoint) TableName() string {
+	if s.tableName != "" {
+		return s.tableName
+	}
+	return defaultSessionProbedEndpointTableName
+}
+
+// SetTableName sets the tablename and satisfies the ReplayableMessage
+// interface. If the caller attempts to set the name to "" the name will be
+// reset to the default name.
+func (s *SessionProbedEndpoint) SetTableName(n string) {
+	s.tableName = n
+}
@@ -0,0 +1,224 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package session
+
+import (
+	"context"
+	"crypto/ed25519"
+	"crypto/sha256"
+	"crypto/x509"
+	"database/sql"
+	"io"
+	"math/big"
+	mathrand "math/rand"
+	"time"
+
+	"github.com/hashicorp/boundary/internal/db"
+	"github.com/hashicorp/boundary/internal/db/timestamp"
+	"github.com/hashicorp/boundary/internal/errors"
+	"github.com/hashicorp/go-secure-stdlib/base62"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+const (
+	defaultSessionShadowTableName = "session_shadow"
+
+	// shadowTokenLength is the length of the tokens identifying shadow grants.
+	shadowTokenLength = 32
+
+	// shadowCertificateLifetime is how long the observer has to connect to a
+	// worker with the certificate issued for a shadow grant.
+	shadowCertificateLifetime = 5 * time.Minute
+)
+
+// Shadow is a grant allowing a user to observe the terminal of an active
+// session, and optionally to send input to it. A grant is identified by the
+// hash of a token, which is only returned when the grant is created along
+// with the private key of the observer's certificate.
+type Shadow struct {
+	// TokenHash is the sha256 hash of the token identifying the grant
+	TokenHash []byte `json:"token_hash,omitempty" gorm:"primary_key"`
+	// SessionId of the session that can be observed
+	SessionId string `json:"session_id,omitempty" gorm:"default:null"`
+	// UserId of the observer
+	UserId string `json:"user_id,omitempty" gorm:"default:null"`
+	// WithInput is true if the observer can send input to the terminal
+	WithInput bool `json:"with_input,omitempty" gorm:"default:false"`
+	// ExpirationTime of the grant
+	ExpirationTime *timestamp.Timestamp `json:"expiration_time,omitempty" gorm:"default:null"`
+	// Certificate is the DER of the short-lived certificate the observer
+	// connects to the worker with. It is issued by the session's certificate.
+	Certificate []byte `json:"certificate,omitempty" gorm:"default:null"`
+	// CreateTime from the RDBMS
+	CreateTime *timestamp.Timestamp `json:"create_time,omitempty" gorm:"default:current_timestamp"`
+
+	// PrivateKey of the certificate. It is not stored.
+	PrivateKey ed25519.PrivateKey `json:"-" gorm:"-"`
+
+	tableName string `gorm:"-"`
+}
+
+var _ db.VetForWriter = (*Shadow)(nil)
+
+// TableName returns the tablename to override the default gorm table name
+func (s *Shadow) TableName() string {
+	if s.tableName != "" {
+		return s.tableName
+	}
+	return defaultSessionShadowTableName
+}
+
+// SetTableName sets the tablename and satisfies the ReplayableMessage
+// interface. If the caller attempts to set the name to "" the name will be
+// reset to the default name.
+func (s *Shadow) SetTableName(n string) {
+	s.tableName = n
+}
+
+func hashShadowToken(token string) []byte {
+	h := sha256.Sum256([]byte(token))
+	return h[:]
+}
+
+// CreateShadow creates a grant allowing the user to observe the terminal of
+// the session until the expiration time. The returned token identifies the
+// grant and must be presented to the worker proxying the session. The
+// observer connects to the worker with the certificate of the returned grant,
+// which is issued by the session's certificate and expires shortly, so that
+// the session's own certificate and private key are never handed out.
+func (r *Repository) CreateShadow(ctx context.Context, sess *Session, userId string, withInput bool, expiration time.Time) (*Shadow, string, error) {
+	const op = "session.(Repository).CreateShadow"
+	switch {
+	case sess == nil:
+		return nil, "", errors.New(ctx, errors.InvalidParameter, op, "missing session")
+	case sess.PublicId == "":
+		return nil, "", errors.New(ctx, errors.InvalidParameter, op, "missing session id")
+	case len(sess.Certificate) == 0:
+		return nil, "", errors.New(ctx, errors.InvalidParameter, op, "missing session certificate")
+	case len(sess.CertificatePrivateKey) == 0:
+		return nil, "", errors.New(ctx, errors.InvalidParameter, op, "missing session private key")
+	case userId == "":
+		return nil, "", errors.New(ctx, errors.InvalidParameter, op, "missing user id")
+	case expiration.IsZero():
+		return nil, "", errors.New(ctx, errors.InvalidParameter, op, "missing expiration time")
+	}
+	token, err := base62.RandomWithReader(shadowTokenLength, r.randomReader)
+	if err != nil {
+		return nil, "", errors.Wrap(ctx, err, op)
+	}
+	certExpiration := time.Now().Add(shadowCertificateLifetime)
+	if expiration.Before(certExpiration) {
+		certExpiration = expiration
+	}
+	privKey, certBytes, err := newShadowCert(ctx, sess, certExpiration, r.randomReader)
+	if err != nil {
+		return nil, "", errors.Wrap(ctx, err, op)
+	}
+	s := &Shadow{
+		TokenHash:      hashShadowToken(token),
+		SessionId:      sess.PublicId,
+		UserId:         userId,
+		WithInput:      withInput,
+		ExpirationTime: &timestamp.Timestamp{Timestamp: timestamppb.New(expiration)},
+		Certificate:    certBytes,
+	}
+	if err := r.writer.Create(ctx, s); err != nil {
+		return nil, "", errors.Wrap(ctx, err, op)
+	}
+	s.PrivateKey = privKey
+	return s, token, nil
+}
+
+// newShadowCert returns a client certificate for the session which expires at
+// exp, signed with the session's private key. Workers accept it in place of
+// the session's certificate, but only to shadow the session.
+func newShadowCert(ctx context.Context, sess *Session, exp time.Time, rand io.Reader) (ed25519.PrivateKey, []byte, error) {
+	const op = "session.newShadowCert"
+	parent, err := x509.ParseCertificate(sess.Certificate)
+	if err != nil {
+		return nil, nil, errors.Wrap(ctx, err, op, errors.WithCode(errors.GenCert), errors.WithMsg("unable to parse session certificate"))
+	}
+	pubKey, privKey, err := ed25519.GenerateKey(rand)
+	if err != nil {
+		return nil, nil, errors.Wrap(ctx, err, op)
+	}
+	template := &x509.Certificate{
+		ExtKeyUsage: []x509.ExtKeyUsage{
+			x509.ExtKeyUsageClientAuth,
+		},
+		DNSNames:              []string{sess.PublicId},
+		KeyUsage:              x509.KeyUsageDigitalSignature,
+		SerialNumber:          big.NewInt(mathrand.Int63()),
+		NotBefore:             time.Now().Add(-1 * time.Minute),
+		NotAfter:              exp,
+		BasicConstraintsValid: true,
+	}
+	certBytes, err := x509.CreateCertificate(rand, template, parent, pubKey, ed25519.PrivateKey(sess.CertificatePrivateKey))
+	if err != nil {
+		return nil, nil, errors.Wrap(ctx, err, op, errors.WithCode(errors.GenCert))
+	}
+	return privKey, certBytes, nil
+}
+
+// LookupShadow returns the unexpired grant identified by the token for the
+// session. If there is no such grant, nil is returned.
+func (r *Repository) LookupShadow(ctx context.Context, sessionId, token string) (*Shadow, error) {
+	const op = "session.(Repository).LookupShadow"
+	switch {
+	case sessionId == "":
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "missing session id")
+	case token == "":
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "missing token")
+	}
+	s := &Shadow{}
+	err := r.reader.LookupWhere(ctx, s, "token_hash = ? and session_id = ? and expiration_time > now()", []any{hashShadowToken(token), sessionId})
+	switch {
+	case errors.IsNotFoundError(err):
+		return nil, nil
+	case err != nil:
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	return s, nil
+}
+
+// ListConnectedWorkerIds returns the ids of the workers proxying the
+// connected connections of the session.
+func (r *Repository) ListConnectedWorkerIds(ctx context.Context, sessionId string) ([]string, error) {
+	const op = "session.(Repository).ListConnectedWorkerIds"
+	if sessionId == "" {
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "missing session id")
+	}
+	rows, err := r.reader.Query(ctx, listConnectedWorkerIds, []any{sql.Named("session_id", sessionId)})
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	defer rows.Close()
+	var ids []string
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			return nil, errors.Wrap(ctx, err, op, errors.WithMsg("scan row failed"))
+		}
+		ids = append(ids, id)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	return ids, nil
+}
+
+// VetForWrite implements db.VetForWrite() interface for shadow grants.
+func (s *Shadow) VetForWrite(ctx context.Context, _ db.Reader, opType db.OpType, _ ...db.Option) error {
+	const op = "session.(Shadow).VetForWrite"
+	if opType != db.CreateOp {
+		return errors.New(ctx, errors.InvalidParameter, op, "shadow grants are immutable")
+	}
+	if len(s.TokenHash) == 0 {
+		return errors.New(ctx, errors.InvalidParameter, op, "missing token hash")
+	}
+	if len(s.Certificate) == 0 {
+		return errors.New(ctx, errors.InvalidParameter, op, "missing certificate")
+	}
+	return nil
+}
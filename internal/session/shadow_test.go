@@ -0,0 +1,92 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package session
+
+import (
+	"context"
+	"crypto/x509"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/boundary/internal/db"
+	"github.com/hashicorp/boundary/internal/errors"
+	"github.com/hashicorp/boundary/internal/iam"
+	"github.com/hashicorp/boundary/internal/kms"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRepository_Shadow(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	conn, _ := db.TestSetup(t, "postgres")
+	rw := db.New(conn)
+	wrapper := db.TestWrapper(t)
+	iamRepo := iam.TestRepo(t, conn, wrapper)
+	testKms := kms.TestKms(t, conn, wrapper)
+	repo, err := NewRepository(ctx, rw, rw, testKms)
+	require.NoError(t, err)
+
+	s := TestDefaultSession(t, conn, wrapper, iamRepo)
+	expiration := time.Now().Add(time.Hour)
+
+	_, _, err = repo.CreateShadow(ctx, nil, s.UserId, false, expiration)
+	assert.True(t, errors.Match(errors.T(errors.InvalidParameter), err))
+	_, _, err = repo.CreateShadow(ctx, &Session{Certificate: s.Certificate, CertificatePrivateKey: s.CertificatePrivateKey}, s.UserId, false, expiration)
+	assert.True(t, errors.Match(errors.T(errors.InvalidParameter), err))
+	_, _, err = repo.CreateShadow(ctx, &Session{PublicId: s.PublicId, Certificate: s.Certificate}, s.UserId, false, expiration)
+	assert.True(t, errors.Match(errors.T(errors.InvalidParameter), err))
+	_, _, err = repo.CreateShadow(ctx, s, "", false, expiration)
+	assert.True(t, errors.Match(errors.T(errors.InvalidParameter), err))
+	_, _, err = repo.CreateShadow(ctx, s, s.UserId, false, time.Time{})
+	assert.True(t, errors.Match(errors.T(errors.InvalidParameter), err))
+
+	created, token, err := repo.CreateShadow(ctx, s, s.UserId, true, expiration)
+	require.NoError(t, err)
+	require.NotEmpty(t, token)
+	assert.Equal(t, hashShadowToken(token), created.TokenHash)
+
+	// The observer gets its own short-lived certificate, issued by the
+	// session's certificate.
+	require.NotEmpty(t, created.PrivateKey)
+	assert.NotEqual(t, s.Certificate, created.Certificate)
+	sessionCert, err := x509.ParseCertificate(s.Certificate)
+	require.NoError(t, err)
+	observerCert, err := x509.ParseCertificate(created.Certificate)
+	require.NoError(t, err)
+	require.NoError(t, observerCert.CheckSignatureFrom(sessionCert))
+	assert.Equal(t, []string{s.PublicId}, observerCert.DNSNames)
+	assert.False(t, observerCert.IsCA)
+	assert.WithinDuration(t, time.Now().Add(shadowCertificateLifetime), observerCert.NotAfter, time.Minute)
+	assert.Equal(t, created.PrivateKey.Public(), observerCert.PublicKey)
+
+	got, err := repo.LookupShadow(ctx, s.PublicId, token)
+	require.NoError(t, err)
+	require.NotNil(t, got)
+	assert.Equal(t, s.UserId, got.UserId)
+	assert.True(t, got.WithInput)
+	assert.Equal(t, created.Certificate, got.Certificate)
+	assert.Empty(t, got.PrivateKey)
+
+	// The token only identifies a grant for its session.
+	other := TestDefaultSession(t, conn, wrapper, iamRepo)
+	got, err = repo.LookupShadow(ctx, other.PublicId, token)
+	require.NoError(t, err)
+	assert.Nil(t, got)
+
+	got, err = repo.LookupShadow(ctx, s.PublicId, "not-a-token")
+	require.NoError(t, err)
+	assert.Nil(t, got)
+
+	// Expired grants are not returned.
+	_, expiredToken, err := repo.CreateShadow(ctx, s, s.UserId, false, time.Now().Add(-time.Minute))
+	require.NoError(t, err)
+	got, err = repo.LookupShadow(ctx, s.PublicId, expiredToken)
+	require.NoError(t, err)
+	assert.Nil(t, got)
+
+	ids, err := repo.ListConnectedWorkerIds(ctx, s.PublicId)
+	require.NoError(t, err)
+	assert.Empty(t, ids)
+}
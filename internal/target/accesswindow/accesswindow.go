@@ -0,0 +1,363 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+// Package accesswindow provides the access windows of targets. An access
+// window restricts the times at which sessions to a target can be authorized
+// and connections to those sessions established.
+//
+// A window consists of cron-like schedules, evaluated in the window's time
+// zone, and blackout dates. A time is inside the window if it is not on a
+// blackout date and it matches at least one of the schedules, or there are no
+// schedules.
+//
+// Each schedule has five space separated fields: minute (0-59), hour (0-23),
+// day of month (1-31), month (1-12 or jan-dec) and day of week (0-7 or
+// sun-sat, where both 0 and 7 are Sunday). A field is a comma separated list
+// of "*", a value, or a range of values "a-b", each optionally followed by a
+// step "/n". As with cron, if both the day of month and day of week are
+// restricted a time matches if either of them matches. For example
+// "* 9-16 * * mon-fri" allows access from 09:00 to 16:59 on weekdays.
+//
+// This package only depends on the standard library so that it can be used by
+// workers as well as controllers. It embeds the time zone database so that time
+// zones can be loaded on hosts without one.
+package accesswindow
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/bits"
+	"strings"
+	"time"
+	_ "time/tzdata"
+)
+
+// DateLayout is the layout of blackout dates.
+const DateLayout = "2006-01-02"
+
+// maxSearch bounds how far ahead Window.NextClose looks for the window to
+// close.
+const maxSearch = 366 * 24 * time.Hour
+
+// Window is the access window of a target.
+type Window struct {
+	// Schedules are the cron-like schedules of the times at which the window is
+	// open.
+	Schedules []string `json:"schedules,omitempty"`
+	// TimeZone is the IANA time zone the schedules and blackout dates are
+	// evaluated in. Defaults to UTC.
+	TimeZone string `json:"time_zone,omitempty"`
+	// BlackoutDates are the dates, in DateLayout, on which the window is
+	// closed.
+	BlackoutDates []string `json:"blackout_dates,omitempty"`
+	// TerminateSessions determines whether sessions are terminated when the
+	// window closes. If false only new connections are prevented.
+	TerminateSessions bool `json:"terminate_sessions,omitempty"`
+
+	schedules []*schedule
+	location  *time.Location
+	blackouts map[string]struct{}
+}
+
+// New creates a Window and validates it.
+func New(schedules []string, timeZone string, blackoutDates []string, terminateSessions bool) (*Window, error) {
+	w := &Window{
+		Schedules:         schedules,
+		TimeZone:          timeZone,
+		BlackoutDates:     blackoutDates,
+		TerminateSessions: terminateSessions,
+	}
+	if err := w.init(); err != nil {
+		return nil, err
+	}
+	return w, nil
+}
+
+// Parse parses a Window from its json encoding, as returned by Window.String,
+// and validates it.
+func Parse(s string) (*Window, error) {
+	w := &Window{}
+	if err := json.Unmarshal([]byte(s), w); err != nil {
+		return nil, fmt.Errorf("invalid access window: %w", err)
+	}
+	if err := w.init(); err != nil {
+		return nil, err
+	}
+	return w, nil
+}
+
+// init validates the window and prepares it for evaluation.
+func (w *Window) init() error {
+	if len(w.Schedules) == 0 && len(w.BlackoutDates) == 0 {
+		return errors.New("access window must have at least one schedule or blackout date")
+	}
+	var err error
+	w.location = time.UTC
+	if w.TimeZone != "" {
+		if w.location, err = time.LoadLocation(w.TimeZone); err != nil {
+			return fmt.Errorf("invalid time zone %q: %w", w.TimeZone, err)
+		}
+	}
+	w.schedules = make([]*schedule, 0, len(w.Schedules))
+	for _, s := range w.Schedules {
+		sched, err := parseSchedule(s)
+		if err != nil {
+			return err
+		}
+		w.schedules = append(w.schedules, sched)
+	}
+	w.blackouts = make(map[string]struct{}, len(w.BlackoutDates))
+	for _, d := range w.BlackoutDates {
+		if _, err := time.Parse(DateLayout, d); err != nil {
+			return fmt.Errorf("invalid blackout date %q, must be in YYYY-MM-DD form", d)
+		}
+		w.blackouts[d] = struct{}{}
+	}
+	return nil
+}
+
+// String returns the json encoding of the window.
+func (w *Window) String() string {
+	b, _ := json.Marshal(w)
+	return string(b)
+}
+
+// Allows returns whether t is inside the window.
+func (w *Window) Allows(t time.Time) bool {
+	t = t.In(w.location)
+	if _, ok := w.blackouts[t.Format(DateLayout)]; ok {
+		return false
+	}
+	if len(w.schedules) == 0 {
+		return true
+	}
+	for _, s := range w.schedules {
+		if s.matches(t) {
+			return true
+		}
+	}
+	return false
+}
+
+// NextClose returns the first time at or after from that is outside the
+// window, searching up to until. The returned bool is false if the window
+// doesn't close before until.
+func (w *Window) NextClose(from, until time.Time) (time.Time, bool) {
+	if !w.Allows(from) {
+		return from, true
+	}
+	if until.After(from.Add(maxSearch)) {
+		until = from.Add(maxSearch)
+	}
+	// Schedules have a resolution of a minute and blackout dates of a day, so
+	// the window can only close at the start of a minute. Rather than checking
+	// every minute, the first closed minute of each day is computed. Days are
+	// split where the time zone's offset changes so that the minutes of the
+	// day map directly to times.
+	t := from.Truncate(time.Minute).Add(time.Minute)
+	for t.Before(until) {
+		local := t.In(w.location)
+		end := until
+		if _, zoneEnd := local.ZoneBounds(); !zoneEnd.IsZero() && zoneEnd.Before(end) {
+			end = zoneEnd
+		}
+		start := local.Hour()*60 + local.Minute()
+		if m, ok := w.firstClosedMinute(local, start); ok {
+			if closes := t.Add(time.Duration(m-start) * time.Minute); closes.Before(end) {
+				return closes, true
+			}
+		}
+		next := t.Add(time.Duration(24*60-start) * time.Minute)
+		if end.Before(next) {
+			next = end
+		}
+		t = next
+	}
+	return time.Time{}, false
+}
+
+// allMinutes is the bit set of all the minutes of an hour.
+const allMinutes = 1<<60 - 1
+
+// firstClosedMinute returns the first minute of the day of t, counted from
+// midnight and at or after start, at which the window is closed. The returned
+// bool is false if the window is open for the rest of the day.
+func (w *Window) firstClosedMinute(t time.Time, start int) (int, bool) {
+	if start >= 24*60 {
+		return 0, false
+	}
+	if _, ok := w.blackouts[t.Format(DateLayout)]; ok {
+		return start, true
+	}
+	if len(w.schedules) == 0 {
+		return 0, false
+	}
+	var matching []*schedule
+	for _, s := range w.schedules {
+		if s.matchesDay(t) {
+			matching = append(matching, s)
+		}
+	}
+	for h := start / 60; h < 24; h++ {
+		var open uint64
+		for _, s := range matching {
+			if s.hour&(1<<uint(h)) != 0 {
+				open |= s.minute
+			}
+		}
+		closed := ^open & allMinutes
+		if h == start/60 {
+			closed &^= 1<<uint(start%60) - 1
+		}
+		if closed != 0 {
+			return h*60 + bits.TrailingZeros64(closed), true
+		}
+	}
+	return 0, false
+}
+
+// schedule is a parsed cron-like schedule. Each field is a bit set of the
+// allowed values.
+type schedule struct {
+	minute, hour, dom, month, dow uint64
+	domStar, dowStar              bool
+}
+
+func (s *schedule) matches(t time.Time) bool {
+	return s.minute&(1<<uint(t.Minute())) != 0 &&
+		s.hour&(1<<uint(t.Hour())) != 0 &&
+		s.matchesDay(t)
+}
+
+// matchesDay returns whether the schedule matches the day of t, regardless of
+// the time of day.
+func (s *schedule) matchesDay(t time.Time) bool {
+	if s.month&(1<<uint(t.Month())) == 0 {
+		return false
+	}
+	domMatch := s.dom&(1<<uint(t.Day())) != 0
+	dowMatch := s.dow&(1<<uint(t.Weekday())) != 0
+	if s.domStar || s.dowStar {
+		return domMatch && dowMatch
+	}
+	return domMatch || dowMatch
+}
+
+type field struct {
+	name     string
+	min, max int
+	names    []string
+}
+
+var (
+	minuteField = field{name: "minute", min: 0, max: 59}
+	hourField   = field{name: "hour", min: 0, max: 23}
+	domField    = field{name: "day of month", min: 1, max: 31}
+	monthField  = field{name: "month", min: 1, max: 12, names: []string{"", "jan", "feb", "mar", "apr", "may", "jun", "jul", "aug", "sep", "oct", "nov", "dec"}}
+	dowField    = field{name: "day of week", min: 0, max: 7, names: []string{"sun", "mon", "tue", "wed", "thu", "fri", "sat"}}
+)
+
+func parseSchedule(s string) (*schedule, error) {
+	parts := strings.Fields(s)
+	if len(parts) != 5 {
+		return nil, fmt.Errorf("invalid schedule %q, must have 5 fields", s)
+	}
+	var sched schedule
+	var err error
+	if sched.minute, err = minuteField.parse(parts[0]); err != nil {
+		return nil, fmt.Errorf("invalid schedule %q: %w", s, err)
+	}
+	if sched.hour, err = hourField.parse(parts[1]); err != nil {
+		return nil, fmt.Errorf("invalid schedule %q: %w", s, err)
+	}
+	if sched.dom, err = domField.parse(parts[2]); err != nil {
+		return nil, fmt.Errorf("invalid schedule %q: %w", s, err)
+	}
+	if sched.month, err = monthField.parse(parts[3]); err != nil {
+		return nil, fmt.Errorf("invalid schedule %q: %w", s, err)
+	}
+	if sched.dow, err = dowField.parse(parts[4]); err != nil {
+		return nil, fmt.Errorf("invalid schedule %q: %w", s, err)
+	}
+	// Sunday can be written as 0 or 7.
+	if sched.dow&(1<<7) != 0 {
+		sched.dow |= 1
+	}
+	sched.domStar = strings.HasPrefix(parts[2], "*")
+	sched.dowStar = strings.HasPrefix(parts[4], "*")
+	return &sched, nil
+}
+
+// parse returns the bit set of the values in the field expression.
+func (f field) parse(expr string) (uint64, error) {
+	var bits uint64
+	for _, part := range strings.Split(expr, ",") {
+		rng, stepStr, hasStep := strings.Cut(part, "/")
+		step := 1
+		if hasStep {
+			var err error
+			if step, err = parseInt(stepStr); err != nil || step < 1 {
+				return 0, fmt.Errorf("invalid step %q in %s field", stepStr, f.name)
+			}
+		}
+		var lo, hi int
+		switch {
+		case rng == "*":
+			lo, hi = f.min, f.max
+		default:
+			loStr, hiStr, isRange := strings.Cut(rng, "-")
+			var err error
+			if lo, err = f.value(loStr); err != nil {
+				return 0, err
+			}
+			hi = lo
+			if isRange {
+				if hi, err = f.value(hiStr); err != nil {
+					return 0, err
+				}
+			} else if hasStep {
+				// "a/n" means every n starting at a.
+				hi = f.max
+			}
+			if hi < lo {
+				return 0, fmt.Errorf("invalid range %q in %s field", rng, f.name)
+			}
+		}
+		for v := lo; v <= hi; v += step {
+			bits |= 1 << uint(v)
+		}
+	}
+	return bits, nil
+}
+
+// value parses a single number or name in the field.
+func (f field) value(s string) (int, error) {
+	for i, n := range f.names {
+		if n != "" && strings.EqualFold(s, n) {
+			return i, nil
+		}
+	}
+	v, err := parseInt(s)
+	if err != nil || v < f.min || v > f.max {
+		return 0, fmt.Errorf("invalid value %q in %s field, must be between %d and %d", s, f.name, f.min, f.max)
+	}
+	return v, nil
+}
+
+func parseInt(s string) (int, error) {
+	if s == "" {
+		return 0, errors.New("empty value")
+	}
+	v := 0
+	for _, c := range s {
+		if c < '0' || c > '9' {
+			return 0, fmt.Errorf("invalid number %q", s)
+		}
+		v = v*10 + int(c-'0')
+		if v > 1000 {
+			return 0, fmt.Errorf("number %q out of range", s)
+		}
+	}
+	return v, nil
+}
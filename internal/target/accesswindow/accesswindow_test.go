@@ -0,0 +1,195 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package accesswindow
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNew(t *testing.T) {
+	tests := []struct {
+		name      string
+		schedules []string
+		tz        string
+		blackouts []string
+		wantErr   string
+	}{
+		{name: "empty", wantErr: "at least one schedule or blackout date"},
+		{name: "schedule", schedules: []string{"* 9-16 * * mon-fri"}},
+		{name: "blackouts-only", blackouts: []string{"2026-12-25"}},
+		{name: "time-zone", schedules: []string{"*/15 0 1 jan-mar,dec 0,7"}, tz: "Europe/Berlin"},
+		{name: "bad-time-zone", schedules: []string{"* * * * *"}, tz: "Mars/Olympus", wantErr: "invalid time zone"},
+		{name: "too-few-fields", schedules: []string{"* * * *"}, wantErr: "must have 5 fields"},
+		{name: "out-of-range", schedules: []string{"* 24 * * *"}, wantErr: "hour field"},
+		{name: "bad-name", schedules: []string{"* * * * funday"}, wantErr: "day of week field"},
+		{name: "reversed-range", schedules: []string{"* 17-9 * * *"}, wantErr: "invalid range"},
+		{name: "zero-step", schedules: []string{"*/0 * * * *"}, wantErr: "invalid step"},
+		{name: "bad-date", blackouts: []string{"25/12/2026"}, wantErr: "invalid blackout date"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w, err := New(tt.schedules, tt.tz, tt.blackouts, false)
+			if tt.wantErr != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			parsed, err := Parse(w.String())
+			require.NoError(t, err)
+			assert.Equal(t, w.String(), parsed.String())
+		})
+	}
+}
+
+func TestWindow_Allows(t *testing.T) {
+	berlin, err := time.LoadLocation("Europe/Berlin")
+	require.NoError(t, err)
+	// 2026-10-19 is a Monday.
+	at := func(day, hour, min int) time.Time {
+		return time.Date(2026, time.October, day, hour, min, 0, 0, time.UTC)
+	}
+
+	t.Run("weekday-hours", func(t *testing.T) {
+		w, err := New([]string{"* 9-16 * * mon-fri"}, "", nil, false)
+		require.NoError(t, err)
+		assert.True(t, w.Allows(at(19, 9, 0)))
+		assert.True(t, w.Allows(at(19, 16, 59)))
+		assert.False(t, w.Allows(at(19, 17, 0)))
+		assert.False(t, w.Allows(at(19, 8, 59)))
+		// Saturday
+		assert.False(t, w.Allows(at(24, 12, 0)))
+	})
+
+	t.Run("time-zone", func(t *testing.T) {
+		w, err := New([]string{"* 9-16 * * *"}, "Europe/Berlin", nil, false)
+		require.NoError(t, err)
+		assert.True(t, w.Allows(time.Date(2026, time.October, 19, 9, 0, 0, 0, berlin)))
+		// 16:00 UTC is 18:00 in Berlin in October.
+		assert.False(t, w.Allows(at(19, 16, 0)))
+		assert.True(t, w.Allows(at(19, 7, 0)))
+	})
+
+	t.Run("blackout-dates", func(t *testing.T) {
+		w, err := New([]string{"* * * * *"}, "Europe/Berlin", []string{"2026-10-20"}, false)
+		require.NoError(t, err)
+		assert.True(t, w.Allows(at(19, 12, 0)))
+		// 22:00 UTC on the 19th is the 20th in Berlin.
+		assert.False(t, w.Allows(at(19, 22, 0)))
+		assert.False(t, w.Allows(at(20, 12, 0)))
+
+		w, err = New(nil, "", []string{"2026-10-20"}, false)
+		require.NoError(t, err)
+		assert.True(t, w.Allows(at(19, 12, 0)))
+		assert.False(t, w.Allows(at(20, 12, 0)))
+	})
+
+	t.Run("day-of-month-or-week", func(t *testing.T) {
+		// The 1st of the month or any Sunday.
+		w, err := New([]string{"* * 1 * 7"}, "", nil, false)
+		require.NoError(t, err)
+		assert.True(t, w.Allows(time.Date(2026, time.October, 1, 0, 0, 0, 0, time.UTC)))
+		assert.True(t, w.Allows(at(25, 0, 0)))
+		assert.False(t, w.Allows(at(19, 0, 0)))
+	})
+
+	t.Run("steps-and-multiple-schedules", func(t *testing.T) {
+		w, err := New([]string{"0/30 2 * * *", "* 22-23 * * sat"}, "", nil, false)
+		require.NoError(t, err)
+		assert.True(t, w.Allows(at(19, 2, 30)))
+		assert.False(t, w.Allows(at(19, 2, 31)))
+		assert.True(t, w.Allows(at(24, 23, 15)))
+	})
+}
+
+func TestWindow_NextClose(t *testing.T) {
+	w, err := New([]string{"* 9-16 * * *"}, "", []string{"2026-10-20"}, true)
+	require.NoError(t, err)
+	from := time.Date(2026, time.October, 19, 15, 30, 10, 0, time.UTC)
+
+	got, ok := w.NextClose(from, from.Add(8*time.Hour))
+	require.True(t, ok)
+	assert.Equal(t, time.Date(2026, time.October, 19, 17, 0, 0, 0, time.UTC), got)
+
+	_, ok = w.NextClose(from, from.Add(time.Hour))
+	assert.False(t, ok)
+
+	closed := time.Date(2026, time.October, 19, 20, 0, 0, 0, time.UTC)
+	got, ok = w.NextClose(closed, closed.Add(time.Hour))
+	require.True(t, ok)
+	assert.Equal(t, closed, got)
+
+	// Always open except for blackout dates.
+	w, err = New([]string{"* * * * *"}, "", []string{"2026-10-20"}, true)
+	require.NoError(t, err)
+	got, ok = w.NextClose(from, from.Add(48*time.Hour))
+	require.True(t, ok)
+	assert.Equal(t, time.Date(2026, time.October, 20, 0, 0, 0, 0, time.UTC), got)
+}
+
+// nextCloseByMinute finds the next close of the window by checking every
+// minute, for comparison with NextClose.
+func nextCloseByMinute(w *Window, from, until time.Time) (time.Time, bool) {
+	if !w.Allows(from) {
+		return from, true
+	}
+	for t := from.Truncate(time.Minute).Add(time.Minute); t.Before(until); t = t.Add(time.Minute) {
+		if !w.Allows(t) {
+			return t, true
+		}
+	}
+	return time.Time{}, false
+}
+
+func TestWindow_NextClose_MatchesMinuteSearch(t *testing.T) {
+	windows := []struct {
+		schedules []string
+		tz        string
+		blackouts []string
+	}{
+		{schedules: []string{"* 9-16 * * mon-fri"}},
+		{schedules: []string{"*/15 * * * *", "0-44 3 * * *"}, tz: "Europe/Berlin"},
+		{schedules: []string{"* 0-2,22-23 * * *", "* * 1 * sat"}, tz: "America/New_York", blackouts: []string{"2026-11-03"}},
+		{schedules: []string{"30-59 1 * * *", "0-29 2 * * *", "* 3-23 * * *"}, tz: "America/New_York"},
+		{blackouts: []string{"2026-10-25", "2026-11-01"}, tz: "Australia/Lord_Howe"},
+	}
+	// The daylight saving time changes of 2026 fall on October 25th in Europe
+	// and November 1st in the US.
+	start := time.Date(2026, time.October, 24, 0, 0, 0, 0, time.UTC)
+	for i, tc := range windows {
+		w, err := New(tc.schedules, tc.tz, tc.blackouts, true)
+		require.NoError(t, err)
+		for from := start; from.Before(start.Add(14 * 24 * time.Hour)); from = from.Add(37*time.Minute + 13*time.Second) {
+			until := from.Add(3 * 24 * time.Hour)
+			want, wantOk := nextCloseByMinute(w, from, until)
+			got, ok := w.NextClose(from, until)
+			require.Equal(t, wantOk, ok, "window %d from %s", i, from)
+			require.True(t, want.Equal(got), "window %d from %s: want %s, got %s", i, from, want, got)
+		}
+	}
+}
+
+func TestWindow_NextClose_FarAhead(t *testing.T) {
+	// Closed only on one day a year.
+	w, err := New(nil, "", []string{"2027-10-01"}, true)
+	require.NoError(t, err)
+	from := time.Date(2026, time.October, 19, 15, 30, 0, 0, time.UTC)
+	got, ok := w.NextClose(from, from.Add(2*maxSearch))
+	require.True(t, ok)
+	assert.Equal(t, time.Date(2027, time.October, 1, 0, 0, 0, 0, time.UTC), got)
+
+	// Open every minute but one a year.
+	w, err = New([]string{"1-59 * * * *", "0 1-23 * * *", "0 0 2-31 * *", "0 0 1 1-9,11-12 *"}, "", nil, true)
+	require.NoError(t, err)
+	got, ok = w.NextClose(from, from.Add(2*maxSearch))
+	require.True(t, ok)
+	assert.Equal(t, time.Date(2027, time.October, 1, 0, 0, 0, 0, time.UTC), got)
+
+	_, ok = w.NextClose(from, from.Add(24*time.Hour))
+	assert.False(t, ok)
+}
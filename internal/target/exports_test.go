@@ -7,4 +7,5 @@ package target
 var (
 	AllocTargetView         = allocTargetView
 	TargetsViewDefaultTable = targetsViewDefaultTable
+	TemplateOutOfSyncFields = (*Template).outOfSyncFields
 )
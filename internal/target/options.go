@@ -28,61 +28,77 @@ type Option func(*options)
 
 // options = how options are represented
 type options struct {
-	WithName                   string
-	WithDescription            string
-	WithDefaultPort            uint32
-	WithDefaultClientPort      uint32
-	WithLimit                  int
-	WithProjectId              string
-	WithProjectIds             []string
-	WithProjectName            string
-	WithUserId                 string
-	WithType                   globals.Subtype
-	WithHostSources            []string
-	WithCredentialLibraries    []*CredentialLibrary
-	WithStaticCredentials      []*StaticCredential
-	WithSessionMaxSeconds      uint32
-	WithSessionConnectionLimit int32
-	WithPermissions            []perms.Permission
-	WithPublicId               string
-	WithWorkerFilter           string
-	WithTestWorkerFilter       string
-	WithEgressWorkerFilter     string
-	WithIngressWorkerFilter    string
-	WithTargetIds              []string
-	WithAddress                string
-	WithStorageBucketId        string
-	WithEnableSessionRecording bool
-	WithNetResolver            intglobals.NetIpResolver
-	WithStartPageAfterItem     pagination.Item
-	withAliases                []*talias.Alias
+	WithName                     string
+	WithDescription              string
+	WithDefaultPort              uint32
+	WithDefaultClientPort        uint32
+	WithLimit                    int
+	WithProjectId                string
+	WithProjectIds               []string
+	WithProjectName              string
+	WithUserId                   string
+	WithType                     globals.Subtype
+	WithHostSources              []string
+	WithCredentialLibraries      []*CredentialLibrary
+	WithStaticCredentials        []*StaticCredential
+	WithSessionMaxSeconds        uint32
+	WithSessionConnectionLimit   int32
+	WithPermissions              []perms.Permission
+	WithPublicId                 string
+	WithWorkerFilter             string
+	WithTestWorkerFilter         string
+	WithEgressWorkerFilter       string
+	WithIngressWorkerFilter      string
+	WithWorkerSelectionStrategy  string
+	WithHostHealthCheck          string
+	WithAccessWindow             string
+	WithSessionReasonRequired    bool
+	WithSessionTicketPattern     string
+	WithSessionUploadRateLimit   uint32
+	WithSessionDownloadRateLimit uint32
+	WithTargetIds                []string
+	WithAddress                  string
+	WithStorageBucketId          string
+	WithEnableSessionRecording   bool
+	WithNetResolver              intglobals.NetIpResolver
+	WithStartPageAfterItem       pagination.Item
+	withAliases                  []*talias.Alias
+	withTemplateId               *string
+	withTemplateOverrides        *[]string
 }
 
 func getDefaultOptions() options {
 	return options{
-		WithName:                   "",
-		WithDescription:            "",
-		WithLimit:                  0,
-		WithDefaultPort:            0,
-		WithDefaultClientPort:      0,
-		WithProjectId:              "",
-		WithProjectIds:             nil,
-		WithProjectName:            "",
-		WithUserId:                 "",
-		WithType:                   "",
-		WithHostSources:            nil,
-		WithCredentialLibraries:    nil,
-		WithStaticCredentials:      nil,
-		WithSessionMaxSeconds:      uint32((8 * time.Hour).Seconds()),
-		WithSessionConnectionLimit: -1,
-		WithPermissions:            nil,
-		WithPublicId:               "",
-		WithWorkerFilter:           "",
-		WithTestWorkerFilter:       "",
-		WithEgressWorkerFilter:     "",
-		WithIngressWorkerFilter:    "",
-		WithAddress:                "",
-		WithNetResolver:            net.DefaultResolver,
+		WithName:                     "",
+		WithDescription:              "",
+		WithLimit:                    0,
+		WithDefaultPort:              0,
+		WithDefaultClientPort:        0,
+		WithProjectId:                "",
+		WithProjectIds:               nil,
+		WithProjectName:              "",
+		WithUserId:                   "",
+		WithType:                     "",
+		WithHostSources:              nil,
+		WithCredentialLibraries:      nil,
+		WithStaticCredentials:        nil,
+		WithSessionMaxSeconds:        uint32((8 * time.Hour).Seconds()),
+		WithSessionConnectionLimit:   -1,
+		WithPermissions:              nil,
+		WithPublicId:                 "",
+		WithWorkerFilter:             "",
+		WithTestWorkerFilter:         "",
+		WithEgressWorkerFilter:       "",
+		WithIngressWorkerFilter:      "",
+		WithWorkerSelectionStrategy:  "",
+		WithHostHealthCheck:          "",
+		WithAccessWindow:             "",
+		WithSessionReasonRequired:    false,
+		WithSessionTicketPattern:     "",
+		WithSessionUploadRateLimit:   0,
+		WithSessionDownloadRateLimit: 0,
+		WithAddress:                  "",
+		WithNetResolver:              net.DefaultResolver,
 	}
 }
 
@@ -226,6 +242,59 @@ func WithIngressWorkerFilter(filter string) Option {
 	}
 }
 
+// WithWorkerSelectionStrategy provides an optional worker selection strategy
+func WithWorkerSelectionStrategy(strategy string) Option {
+	return func(o *options) {
+		o.WithWorkerSelectionStrategy = strategy
+	}
+}
+
+// WithHostHealthCheck provides an optional host health check
+func WithHostHealthCheck(check string) Option {
+	return func(o *options) {
+		o.WithHostHealthCheck = check
+	}
+}
+
+// WithAccessWindow provides an optional json encoded access window
+func WithAccessWindow(window string) Option {
+	return func(o *options) {
+		o.WithAccessWindow = window
+	}
+}
+
+// WithSessionReasonRequired provides an option to require a reason when
+// authorizing a session
+func WithSessionReasonRequired(required bool) Option {
+	return func(o *options) {
+		o.WithSessionReasonRequired = required
+	}
+}
+
+// WithSessionTicketPattern provides an optional regular expression ticket ids
+// must match when authorizing a session
+func WithSessionTicketPattern(pattern string) Option {
+	return func(o *options) {
+		o.WithSessionTicketPattern = pattern
+	}
+}
+
+// WithSessionUploadRateLimit provides an optional limit, in bytes per second,
+// of the traffic sent by the clients of a session
+func WithSessionUploadRateLimit(limit uint32) Option {
+	return func(o *options) {
+		o.WithSessionUploadRateLimit = limit
+	}
+}
+
+// WithSessionDownloadRateLimit provides an optional limit, in bytes per
+// second, of the traffic sent to the clients of a session
+func WithSessionDownloadRateLimit(limit uint32) Option {
+	return func(o *options) {
+		o.WithSessionDownloadRateLimit = limit
+	}
+}
+
 // WithTargetIds provides an option to search by specific target IDs
 func WithTargetIds(with []string) Option {
 	return func(o *options) {
@@ -284,3 +353,21 @@ func WithAliases(in []*talias.Alias) Option {
 		o.withAliases = in
 	}
 }
+
+// WithTemplateId provides an option to set the Template used by the target
+// being created or updated in the same transaction as the target. An empty id
+// removes the template, and the overrides, from the target.
+func WithTemplateId(id string) Option {
+	return func(o *options) {
+		o.withTemplateId = &id
+	}
+}
+
+// WithTemplateOverrides provides an option to set the settings of the target
+// being created or updated which are not managed by its Template, in the same
+// transaction as the target.
+func WithTemplateOverrides(fields []string) Option {
+	return func(o *options) {
+		o.withTemplateOverrides = &fields
+	}
+}
@@ -166,6 +166,55 @@ func Test_GetOpts(t *testing.T) {
 		testOpts.WithIngressWorkerFilter = `"/foo" == "bar"`
 		assert.Equal(opts, testOpts)
 	})
+	t.Run("WithWorkerSelectionStrategy", func(t *testing.T) {
+		assert := assert.New(t)
+		opts := GetOpts(WithWorkerSelectionStrategy("least_connections"))
+		testOpts := getDefaultOptions()
+		testOpts.WithWorkerSelectionStrategy = "least_connections"
+		assert.Equal(opts, testOpts)
+	})
+	t.Run("WithHostHealthCheck", func(t *testing.T) {
+		assert := assert.New(t)
+		opts := GetOpts(WithHostHealthCheck("tls"))
+		testOpts := getDefaultOptions()
+		testOpts.WithHostHealthCheck = "tls"
+		assert.Equal(opts, testOpts)
+	})
+	t.Run("WithAccessWindow", func(t *testing.T) {
+		assert := assert.New(t)
+		opts := GetOpts(WithAccessWindow(`{"schedules":["* 9-16 * * mon-fri"]}`))
+		testOpts := getDefaultOptions()
+		testOpts.WithAccessWindow = `{"schedules":["* 9-16 * * mon-fri"]}`
+		assert.Equal(opts, testOpts)
+	})
+	t.Run("WithSessionReasonRequired", func(t *testing.T) {
+		assert := assert.New(t)
+		opts := GetOpts(WithSessionReasonRequired(true))
+		testOpts := getDefaultOptions()
+		testOpts.WithSessionReasonRequired = true
+		assert.Equal(opts, testOpts)
+	})
+	t.Run("WithSessionTicketPattern", func(t *testing.T) {
+		assert := assert.New(t)
+		opts := GetOpts(WithSessionTicketPattern(`^JIRA-\d+$`))
+		testOpts := getDefaultOptions()
+		testOpts.WithSessionTicketPattern = `^JIRA-\d+$`
+		assert.Equal(opts, testOpts)
+	})
+	t.Run("WithSessionUploadRateLimit", func(t *testing.T) {
+		assert := assert.New(t)
+		opts := GetOpts(WithSessionUploadRateLimit(1024))
+		testOpts := getDefaultOptions()
+		testOpts.WithSessionUploadRateLimit = 1024
+		assert.Equal(opts, testOpts)
+	})
+	t.Run("WithSessionDownloadRateLimit", func(t *testing.T) {
+		assert := assert.New(t)
+		opts := GetOpts(WithSessionDownloadRateLimit(2048))
+		testOpts := getDefaultOptions()
+		testOpts.WithSessionDownloadRateLimit = 2048
+		assert.Equal(opts, testOpts)
+	})
 	t.Run("WithPermissions", func(t *testing.T) {
 		assert := assert.New(t)
 		opts := GetOpts(WithPermissions([]perms.Permission{{ScopeId: "test1"}, {ScopeId: "test2"}}))
@@ -268,4 +317,30 @@ func Test_GetOpts(t *testing.T) {
 		opts := GetOpts(WithAliases(input))
 		assert.Equal(input, opts.withAliases)
 	})
+	t.Run("WithTemplateId", func(t *testing.T) {
+		assert := assert.New(t)
+		opts := GetOpts()
+		assert.Nil(opts.withTemplateId)
+		opts = GetOpts(WithTemplateId("ttpl_1234567890"))
+		if assert.NotNil(opts.withTemplateId) {
+			assert.Equal("ttpl_1234567890", *opts.withTemplateId)
+		}
+		opts = GetOpts(WithTemplateId(""))
+		if assert.NotNil(opts.withTemplateId) {
+			assert.Empty(*opts.withTemplateId)
+		}
+	})
+	t.Run("WithTemplateOverrides", func(t *testing.T) {
+		assert := assert.New(t)
+		opts := GetOpts()
+		assert.Nil(opts.withTemplateOverrides)
+		opts = GetOpts(WithTemplateOverrides([]string{TemplateSessionMaxSecondsField}))
+		if assert.NotNil(opts.withTemplateOverrides) {
+			assert.Equal([]string{TemplateSessionMaxSecondsField}, *opts.withTemplateOverrides)
+		}
+		opts = GetOpts(WithTemplateOverrides(nil))
+		if assert.NotNil(opts.withTemplateOverrides) {
+			assert.Empty(*opts.withTemplateOverrides)
+		}
+	})
 }
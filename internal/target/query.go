@@ -60,7 +60,7 @@ select public_id, project_id from target
 `
 
 	estimateCountTargets = `
-select sum(reltuples::bigint) as estimate from pg_class where oid in ('target_tcp'::regclass, 'target_ssh'::regclass)
+select sum(reltuples::bigint) as estimate from pg_class where oid in ('target_tcp'::regclass, 'target_ssh'::regclass, 'target_udp'::regclass)
 `
 
 	listTargetsTemplate = `
@@ -81,6 +81,11 @@ ssh_targets as (
     from target_ssh
    where public_id in (select public_id from targets)
 ),
+udp_targets as (
+  select *
+    from target_udp
+   where public_id in (select public_id from targets)
+),
 final as (
   select public_id,
          project_id,
@@ -95,6 +100,13 @@ final as (
          worker_filter,
          egress_worker_filter,
          ingress_worker_filter,
+         worker_selection_strategy,
+         host_health_check,
+         access_window,
+         session_reason_required,
+         session_ticket_pattern,
+         session_upload_rate_limit,
+         session_download_rate_limit,
          default_client_port,
          null as storage_bucket_id,
          false as enable_session_recording,
@@ -114,11 +126,44 @@ final as (
          worker_filter,
          egress_worker_filter,
          ingress_worker_filter,
+         worker_selection_strategy,
+         host_health_check,
+         access_window,
+         session_reason_required,
+         session_ticket_pattern,
+         session_upload_rate_limit,
+         session_download_rate_limit,
          default_client_port,
          storage_bucket_id,
          enable_session_recording,
          'ssh' as type
     from ssh_targets
+   union
+  select public_id,
+         project_id,
+         name,
+         description,
+         default_port,
+         session_max_seconds,
+         session_connection_limit,
+         version,
+         create_time,
+         update_time,
+         worker_filter,
+         egress_worker_filter,
+         ingress_worker_filter,
+         worker_selection_strategy,
+         host_health_check,
+         access_window,
+         session_reason_required,
+         session_ticket_pattern,
+         session_upload_rate_limit,
+         session_download_rate_limit,
+         default_client_port,
+         null as storage_bucket_id,
+         false as enable_session_recording,
+         'udp' as type
+    from udp_targets
 )
   select *
     from final
@@ -144,6 +189,11 @@ ssh_targets as (
     from target_ssh
    where public_id in (select public_id from targets)
 ),
+udp_targets as (
+  select *
+    from target_udp
+   where public_id in (select public_id from targets)
+),
 final as (
   select public_id,
          project_id,
@@ -158,6 +208,13 @@ final as (
          worker_filter,
          egress_worker_filter,
          ingress_worker_filter,
+         worker_selection_strategy,
+         host_health_check,
+         access_window,
+         session_reason_required,
+         session_ticket_pattern,
+         session_upload_rate_limit,
+         session_download_rate_limit,
          default_client_port,
          null as storage_bucket_id,
          false as enable_session_recording,
@@ -177,11 +234,44 @@ final as (
          worker_filter,
          egress_worker_filter,
          ingress_worker_filter,
+         worker_selection_strategy,
+         host_health_check,
+         access_window,
+         session_reason_required,
+         session_ticket_pattern,
+         session_upload_rate_limit,
+         session_download_rate_limit,
          default_client_port,
          storage_bucket_id,
          enable_session_recording,
          'ssh' as type
     from ssh_targets
+   union
+  select public_id,
+         project_id,
+         name,
+         description,
+         default_port,
+         session_max_seconds,
+         session_connection_limit,
+         version,
+         create_time,
+         update_time,
+         worker_filter,
+         egress_worker_filter,
+         ingress_worker_filter,
+         worker_selection_strategy,
+         host_health_check,
+         access_window,
+         session_reason_required,
+         session_ticket_pattern,
+         session_upload_rate_limit,
+         session_download_rate_limit,
+         default_client_port,
+         null as storage_bucket_id,
+         false as enable_session_recording,
+         'udp' as type
+    from udp_targets
 )
   select *
     from final
@@ -207,6 +297,11 @@ ssh_targets as (
     from target_ssh
    where public_id in (select public_id from targets)
 ),
+udp_targets as (
+  select *
+    from target_udp
+   where public_id in (select public_id from targets)
+),
 final as (
   select public_id,
          project_id,
@@ -221,6 +316,13 @@ final as (
          worker_filter,
          egress_worker_filter,
          ingress_worker_filter,
+         worker_selection_strategy,
+         host_health_check,
+         access_window,
+         session_reason_required,
+         session_ticket_pattern,
+         session_upload_rate_limit,
+         session_download_rate_limit,
          default_client_port,
          null as storage_bucket_id,
          false as enable_session_recording,
@@ -240,11 +342,44 @@ final as (
          worker_filter,
          egress_worker_filter,
          ingress_worker_filter,
+         worker_selection_strategy,
+         host_health_check,
+         access_window,
+         session_reason_required,
+         session_ticket_pattern,
+         session_upload_rate_limit,
+         session_download_rate_limit,
          default_client_port,
          storage_bucket_id,
          enable_session_recording,
          'ssh' as type
     from ssh_targets
+   union
+  select public_id,
+         project_id,
+         name,
+         description,
+         default_port,
+         session_max_seconds,
+         session_connection_limit,
+         version,
+         create_time,
+         update_time,
+         worker_filter,
+         egress_worker_filter,
+         ingress_worker_filter,
+         worker_selection_strategy,
+         host_health_check,
+         access_window,
+         session_reason_required,
+         session_ticket_pattern,
+         session_upload_rate_limit,
+         session_download_rate_limit,
+         default_client_port,
+         null as storage_bucket_id,
+         false as enable_session_recording,
+         'udp' as type
+    from udp_targets
 )
   select *
     from final
@@ -271,6 +406,11 @@ ssh_targets as (
     from target_ssh
    where public_id in (select public_id from targets)
 ),
+udp_targets as (
+  select *
+    from target_udp
+   where public_id in (select public_id from targets)
+),
 final as (
   select public_id,
          project_id,
@@ -285,6 +425,13 @@ final as (
          worker_filter,
          egress_worker_filter,
          ingress_worker_filter,
+         worker_selection_strategy,
+         host_health_check,
+         access_window,
+         session_reason_required,
+         session_ticket_pattern,
+         session_upload_rate_limit,
+         session_download_rate_limit,
          default_client_port,
          null as storage_bucket_id,
          false as enable_session_recording,
@@ -304,11 +451,44 @@ final as (
          worker_filter,
          egress_worker_filter,
          ingress_worker_filter,
+         worker_selection_strategy,
+         host_health_check,
+         access_window,
+         session_reason_required,
+         session_ticket_pattern,
+         session_upload_rate_limit,
+         session_download_rate_limit,
          default_client_port,
          storage_bucket_id,
          enable_session_recording,
          'ssh' as type
     from ssh_targets
+   union
+  select public_id,
+         project_id,
+         name,
+         description,
+         default_port,
+         session_max_seconds,
+         session_connection_limit,
+         version,
+         create_time,
+         update_time,
+         worker_filter,
+         egress_worker_filter,
+         ingress_worker_filter,
+         worker_selection_strategy,
+         host_health_check,
+         access_window,
+         session_reason_required,
+         session_ticket_pattern,
+         session_upload_rate_limit,
+         session_download_rate_limit,
+         default_client_port,
+         null as storage_bucket_id,
+         false as enable_session_recording,
+         'udp' as type
+    from udp_targets
 )
   select *
     from final
@@ -136,6 +136,8 @@ func (r *Repository) LookupTarget(ctx context.Context, publicIdOrName string, op
 	var hostSources []HostSource
 	var credSources []CredentialSource
 	var aliases []*talias.Alias
+	var templateId string
+	var templateOverrides []string
 	_, err := r.writer.DoTx(
 		ctx,
 		db.StdRetryCnt,
@@ -162,6 +164,9 @@ func (r *Repository) LookupTarget(ctx context.Context, publicIdOrName string, op
 			if aliases, err = fetchTargetAliases(ctx, read, target.PublicId); err != nil {
 				return errors.Wrap(ctx, err, op)
 			}
+			if templateId, templateOverrides, err = fetchTargetTemplate(ctx, read, target.PublicId); err != nil {
+				return errors.Wrap(ctx, err, op)
+			}
 			targetAddress, err := fetchAddress(ctx, read, target.PublicId)
 			if err != nil && !errors.IsNotFoundError(err) {
 				return errors.Wrap(ctx, err, op)
@@ -185,6 +190,8 @@ func (r *Repository) LookupTarget(ctx context.Context, publicIdOrName string, op
 	subtype.SetHostSources(hostSources)
 	subtype.SetCredentialSources(credSources)
 	subtype.SetAliases(aliases)
+	subtype.SetTemplateId(templateId)
+	subtype.SetTemplateOverrides(templateOverrides)
 
 	return subtype, nil
 }
@@ -504,7 +511,9 @@ func (r *Repository) DeleteTarget(ctx context.Context, publicId string, _ ...Opt
 
 // CreateTarget inserts into the repository and returns the new Target with
 // its list of host sets and credential libraries.
-// WithPublicId and WithAliases are supported options.
+// WithPublicId, WithAliases, WithTemplateId and WithTemplateOverrides are
+// supported options. The template is set, and applied, in the same
+// transaction as the target is created.
 func (r *Repository) CreateTarget(ctx context.Context, target Target, opt ...Option) (Target, error) {
 	const op = "target.(Repository).CreateTarget"
 	opts := GetOpts(opt...)
@@ -528,6 +537,17 @@ func (r *Repository) CreateTarget(ctx context.Context, target Target, opt ...Opt
 	if target.GetPublicId() != "" {
 		return nil, errors.New(ctx, errors.InvalidParameter, op, "public id not empty")
 	}
+	var templateId string
+	var templateOverrides []string
+	if opts.withTemplateId != nil {
+		templateId = *opts.withTemplateId
+	}
+	if opts.withTemplateOverrides != nil {
+		templateOverrides = *opts.withTemplateOverrides
+	}
+	if templateId == "" && len(templateOverrides) > 0 {
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "template overrides set without a template")
+	}
 
 	t := target.Clone()
 
@@ -612,10 +632,27 @@ func (r *Repository) CreateTarget(ctx context.Context, target Target, opt ...Opt
 				return errors.Wrap(ctx, err, op, errors.WithMsg("unable to write oplog"))
 			}
 
+			if templateId == "" {
+				return nil
+			}
+			if err := setTargetTemplate(ctx, read, w, oplogWrapper, t.GetProjectId(), t.GetPublicId(), templateId, templateOverrides); err != nil {
+				return errors.Wrap(ctx, err, op)
+			}
+			// Read the target back so it includes its template and the
+			// settings applied from it.
+			if returnedTarget, err = fetchTemplateTarget(ctx, read, t.GetPublicId()); err != nil {
+				return errors.Wrap(ctx, err, op)
+			}
+			if returnedTarget == nil {
+				return errors.New(ctx, errors.RecordNotFound, op, fmt.Sprintf("target %s not found", t.GetPublicId()))
+			}
 			return nil
 		},
 	)
 	if err != nil {
+		if isTargetTemplateNotFound(err) {
+			return nil, errors.New(ctx, errors.NotFound, op, fmt.Sprintf("target template %s not found in project %s", templateId, t.GetProjectId()))
+		}
 		return nil, errors.Wrap(ctx, err, op, errors.WithMsg(fmt.Sprintf("failed for %s target id", t.GetPublicId())))
 	}
 	returnedTarget.SetAliases(createdAliases)
@@ -627,9 +664,17 @@ func (r *Repository) CreateTarget(ctx context.Context, target Target, opt ...Opt
 // be updated.  Fields will be set to NULL if the field is a zero value and
 // included in fieldMask. Name, Description, and WorkerFilter are the only
 // updatable fields. If no updatable fields are included in the fieldMaskPaths,
-// then an error is returned.
-func (r *Repository) UpdateTarget(ctx context.Context, target Target, version uint32, fieldMaskPaths []string, _ ...Option) (Target, int, error) {
+// and the template of the target is not being set, then an error is returned.
+//
+// WithTemplateId and WithTemplateOverrides are supported options. They set the
+// template of the target, and apply it, in the same transaction as the
+// target is updated; a template which is not given keeps its current value.
+// Setting only the template still checks and increments the version of the
+// target.
+func (r *Repository) UpdateTarget(ctx context.Context, target Target, version uint32, fieldMaskPaths []string, opt ...Option) (Target, int, error) {
 	const op = "target.(Repository).UpdateTarget"
+	opts := GetOpts(opt...)
+	setTemplate := opts.withTemplateId != nil || opts.withTemplateOverrides != nil
 	if target == nil {
 		return nil, db.NoRowsAffected, errors.New(ctx, errors.InvalidParameter, op, "missing target")
 	}
@@ -662,6 +707,13 @@ func (r *Repository) UpdateTarget(ctx context.Context, target Target, version ui
 		case strings.EqualFold("workerfilter", f):
 		case strings.EqualFold("egressworkerfilter", f):
 		case strings.EqualFold("ingressworkerfilter", f):
+		case strings.EqualFold("workerselectionstrategy", f):
+		case strings.EqualFold("hosthealthcheck", f):
+		case strings.EqualFold("accesswindow", f):
+		case strings.EqualFold("sessionreasonrequired", f):
+		case strings.EqualFold("sessionticketpattern", f):
+		case strings.EqualFold("sessionuploadratelimit", f):
+		case strings.EqualFold("sessiondownloadratelimit", f):
 		case strings.EqualFold("address", f):
 			target.SetAddress(strings.TrimSpace(target.GetAddress()))
 			addressEndpoint = target.GetAddress()
@@ -675,23 +727,30 @@ func (r *Repository) UpdateTarget(ctx context.Context, target Target, version ui
 	var dbMask, nullFields []string
 	dbMask, nullFields = dbw.BuildUpdatePaths(
 		map[string]any{
-			"Name":                   target.GetName(),
-			"Description":            target.GetDescription(),
-			"DefaultPort":            target.GetDefaultPort(),
-			"DefaultClientPort":      target.GetDefaultClientPort(),
-			"SessionMaxSeconds":      target.GetSessionMaxSeconds(),
-			"SessionConnectionLimit": target.GetSessionConnectionLimit(),
-			"WorkerFilter":           target.GetWorkerFilter(),
-			"EgressWorkerFilter":     target.GetEgressWorkerFilter(),
-			"IngressWorkerFilter":    target.GetIngressWorkerFilter(),
-			"Address":                target.GetAddress(),
-			"StorageBucketId":        target.GetStorageBucketId(),
-			"EnableSessionRecording": target.GetEnableSessionRecording(),
+			"Name":                     target.GetName(),
+			"Description":              target.GetDescription(),
+			"DefaultPort":              target.GetDefaultPort(),
+			"DefaultClientPort":        target.GetDefaultClientPort(),
+			"SessionMaxSeconds":        target.GetSessionMaxSeconds(),
+			"SessionConnectionLimit":   target.GetSessionConnectionLimit(),
+			"WorkerFilter":             target.GetWorkerFilter(),
+			"EgressWorkerFilter":       target.GetEgressWorkerFilter(),
+			"IngressWorkerFilter":      target.GetIngressWorkerFilter(),
+			"WorkerSelectionStrategy":  target.GetWorkerSelectionStrategy(),
+			"HostHealthCheck":          target.GetHostHealthCheck(),
+			"AccessWindow":             target.GetAccessWindow(),
+			"SessionReasonRequired":    target.GetSessionReasonRequired(),
+			"SessionTicketPattern":     target.GetSessionTicketPattern(),
+			"SessionUploadRateLimit":   target.GetSessionUploadRateLimit(),
+			"SessionDownloadRateLimit": target.GetSessionDownloadRateLimit(),
+			"Address":                  target.GetAddress(),
+			"StorageBucketId":          target.GetStorageBucketId(),
+			"EnableSessionRecording":   target.GetEnableSessionRecording(),
 		},
 		fieldMaskPaths,
 		[]string{"SessionMaxSeconds", "SessionConnectionLimit", "EnableSessionRecording"},
 	)
-	if len(dbMask) == 0 && len(nullFields) == 0 {
+	if len(dbMask) == 0 && len(nullFields) == 0 && !setTemplate {
 		return nil, db.NoRowsAffected, errors.New(ctx, errors.EmptyFieldMask, op, "empty field mask")
 	}
 
@@ -717,10 +776,10 @@ func (r *Repository) UpdateTarget(ctx context.Context, target Target, version ui
 		}
 	}
 
-	// If the Address field is the only present change, then we must still
-	// update the target's version because a target address is a child object
-	// of the target.
-	if (len(filteredDbMask) == 0 && len(filteredNullFields) == 0) && (updateAddress || deleteAddress) {
+	// If the Address field or the template is the only present change, then
+	// we must still update the target's version because a target address and
+	// the template of a target are child objects of the target.
+	if (len(filteredDbMask) == 0 && len(filteredNullFields) == 0) && (updateAddress || deleteAddress || setTemplate) {
 		target.SetVersion(version + 1)
 		filteredDbMask = append(filteredDbMask, "Version")
 	}
@@ -802,10 +861,41 @@ func (r *Repository) UpdateTarget(ctx context.Context, target Target, version ui
 			}
 			returnedTarget = t.Clone()
 
+			if !setTemplate || rowsUpdated == 0 {
+				return nil
+			}
+			templateId, templateOverrides, err := fetchTargetTemplate(ctx, read, t.GetPublicId())
+			if err != nil {
+				return errors.Wrap(ctx, err, op)
+			}
+			if opts.withTemplateId != nil {
+				templateId = *opts.withTemplateId
+				if templateId == "" {
+					templateOverrides = nil
+				}
+			}
+			if opts.withTemplateOverrides != nil {
+				templateOverrides = *opts.withTemplateOverrides
+			}
+			if err := setTargetTemplate(ctx, read, w, oplogWrapper, target.GetProjectId(), t.GetPublicId(), templateId, templateOverrides); err != nil {
+				return errors.Wrap(ctx, err, op)
+			}
+			// Read the target back so it includes its template and the
+			// settings applied from it.
+			if returnedTarget, err = fetchTemplateTarget(ctx, read, t.GetPublicId()); err != nil {
+				return errors.Wrap(ctx, err, op)
+			}
+			if returnedTarget == nil {
+				return errors.New(ctx, errors.RecordNotFound, op, fmt.Sprintf("target %s not found", t.GetPublicId()))
+			}
+			hostSources, credSources = returnedTarget.GetHostSources(), returnedTarget.GetCredentialSources()
 			return nil
 		},
 	)
 	if err != nil {
+		if isTargetTemplateNotFound(err) {
+			return nil, db.NoRowsAffected, errors.New(ctx, errors.NotFound, op, fmt.Sprintf("target template not found in project %s", target.GetProjectId()))
+		}
 		if errors.IsUniqueError(err) {
 			return nil, db.NoRowsAffected, errors.New(ctx, errors.NotUnique, op, fmt.Sprintf("target %s already exists in project %s", target.GetName(), target.GetProjectId()))
 		}
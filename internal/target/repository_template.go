@@ -0,0 +1,976 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package target
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/hashicorp/boundary/globals"
+	"github.com/hashicorp/boundary/internal/credential"
+	"github.com/hashicorp/boundary/internal/db"
+	"github.com/hashicorp/boundary/internal/errors"
+	"github.com/hashicorp/boundary/internal/kms"
+	"github.com/hashicorp/boundary/internal/oplog"
+	"github.com/hashicorp/boundary/internal/target/store"
+	"github.com/hashicorp/go-dbw"
+	wrapping "github.com/hashicorp/go-kms-wrapping/v2"
+	"github.com/hashicorp/go-secure-stdlib/strutil"
+)
+
+// CreateTemplate inserts tpl into the repository and returns a new Template
+// containing the template's PublicId. tpl is not changed. tpl must contain a
+// valid ProjectId and must not contain a PublicId. No options are currently
+// supported.
+func (r *Repository) CreateTemplate(ctx context.Context, tpl *Template, _ ...Option) (*Template, error) {
+	const op = "target.(Repository).CreateTemplate"
+	switch {
+	case tpl == nil:
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "missing template")
+	case tpl.Template == nil:
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "missing embedded template")
+	case tpl.ProjectId == "":
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "missing project id")
+	case tpl.PublicId != "":
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "public id not empty")
+	}
+	tpl = tpl.Clone()
+
+	id, err := db.NewPublicId(ctx, globals.TargetTemplatePrefix)
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	tpl.PublicId = id
+
+	hostSets := newTemplateHostSets(tpl.PublicId, tpl.HostSourceIds)
+	libs, statics, err := r.newTemplateCredentialSources(ctx, tpl.PublicId, tpl.CredentialSources)
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+
+	oplogWrapper, err := r.kms.GetWrapper(ctx, tpl.ProjectId, kms.KeyPurposeOplog)
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op, errors.WithMsg("unable to get oplog wrapper"))
+	}
+
+	_, err = r.writer.DoTx(
+		ctx,
+		db.StdRetryCnt,
+		db.ExpBackoff{},
+		func(_ db.Reader, w db.Writer) error {
+			ticket, err := w.GetTicket(ctx, tpl)
+			if err != nil {
+				return errors.Wrap(ctx, err, op, errors.WithMsg("unable to get ticket"))
+			}
+			var msgs []*oplog.Message
+			var tplOplogMsg oplog.Message
+			if err := w.Create(ctx, tpl.Clone(), db.NewOplogMsg(&tplOplogMsg)); err != nil {
+				return errors.Wrap(ctx, err, op, errors.WithMsg("unable to create target template"))
+			}
+			msgs = append(msgs, &tplOplogMsg)
+			for _, items := range [][]any{hostSets, libs, statics} {
+				if len(items) == 0 {
+					continue
+				}
+				itemMsgs := make([]*oplog.Message, 0, len(items))
+				if err := w.CreateItems(ctx, items, db.NewOplogMsgs(&itemMsgs)); err != nil {
+					return errors.Wrap(ctx, err, op, errors.WithMsg("unable to add target template sources"))
+				}
+				msgs = append(msgs, itemMsgs...)
+			}
+			if err := w.WriteOplogEntryWith(ctx, oplogWrapper, ticket, tpl.oplog(oplog.OpType_OP_TYPE_CREATE), msgs); err != nil {
+				return errors.Wrap(ctx, err, op, errors.WithMsg("unable to write oplog"))
+			}
+			return nil
+		},
+	)
+	if err != nil {
+		if errors.IsUniqueError(err) {
+			return nil, errors.New(ctx, errors.NotUnique, op, fmt.Sprintf("target template %s already exists in project %s", tpl.Name, tpl.ProjectId))
+		}
+		return nil, errors.Wrap(ctx, err, op, errors.WithMsg(fmt.Sprintf("failed for %s", tpl.PublicId)))
+	}
+	return r.LookupTemplate(ctx, tpl.PublicId)
+}
+
+// UpdateTemplate updates the repository entry for tpl.PublicId with the values
+// in tpl for the fields listed in fieldMaskPaths, and applies the changes to
+// the targets using the template in the same transaction, so that either the
+// template and all of its targets are updated or none of them are. It returns
+// a new Template containing the updated values and a count of the number of
+// records updated. tpl is not changed. No options are currently supported.
+func (r *Repository) UpdateTemplate(ctx context.Context, tpl *Template, version uint32, fieldMaskPaths []string, _ ...Option) (*Template, int, error) {
+	const op = "target.(Repository).UpdateTemplate"
+	switch {
+	case tpl == nil:
+		return nil, db.NoRowsAffected, errors.New(ctx, errors.InvalidParameter, op, "missing template")
+	case tpl.Template == nil:
+		return nil, db.NoRowsAffected, errors.New(ctx, errors.InvalidParameter, op, "missing embedded template")
+	case tpl.PublicId == "":
+		return nil, db.NoRowsAffected, errors.New(ctx, errors.InvalidParameter, op, "missing public id")
+	case tpl.ProjectId == "":
+		return nil, db.NoRowsAffected, errors.New(ctx, errors.InvalidParameter, op, "missing project id")
+	case version == 0:
+		return nil, db.NoRowsAffected, errors.New(ctx, errors.InvalidParameter, op, "missing version")
+	}
+
+	var setHostSources bool
+	var setPurposes []credential.Purpose
+	var scalarMask []string
+	for _, f := range fieldMaskPaths {
+		switch {
+		case strings.EqualFold("name", f):
+		case strings.EqualFold("description", f):
+		case strings.EqualFold("sessionmaxseconds", f):
+		case strings.EqualFold("sessionconnectionlimit", f):
+		case strings.EqualFold("egressworkerfilter", f):
+		case strings.EqualFold("ingressworkerfilter", f):
+		case strings.EqualFold("hostsourceids", f):
+			setHostSources = true
+			continue
+		case strings.EqualFold("brokeredcredentialsourceids", f):
+			setPurposes = append(setPurposes, credential.BrokeredPurpose)
+			continue
+		case strings.EqualFold("injectedapplicationcredentialsourceids", f):
+			setPurposes = append(setPurposes, credential.InjectedApplicationPurpose)
+			continue
+		default:
+			return nil, db.NoRowsAffected, errors.New(ctx, errors.InvalidFieldMask, op, fmt.Sprintf("invalid field mask: %s", f))
+		}
+		scalarMask = append(scalarMask, f)
+	}
+
+	dbMask, nullFields := dbw.BuildUpdatePaths(
+		map[string]any{
+			"Name":                   tpl.Name,
+			"Description":            tpl.Description,
+			"SessionMaxSeconds":      tpl.SessionMaxSeconds,
+			"SessionConnectionLimit": tpl.SessionConnectionLimit,
+			"EgressWorkerFilter":     tpl.EgressWorkerFilter,
+			"IngressWorkerFilter":    tpl.IngressWorkerFilter,
+		},
+		scalarMask,
+		nil,
+	)
+	if len(dbMask) == 0 && len(nullFields) == 0 {
+		if !setHostSources && len(setPurposes) == 0 {
+			return nil, db.NoRowsAffected, errors.New(ctx, errors.EmptyFieldMask, op, "empty field mask")
+		}
+		// The sources of a template are child objects of the template, so the
+		// template's version must still be updated.
+		tpl = tpl.Clone()
+		tpl.Version = version + 1
+		dbMask = append(dbMask, "Version")
+	}
+
+	var addItems, deleteItems [][]any
+	if setHostSources {
+		addItems = append(addItems, newTemplateHostSets(tpl.PublicId, tpl.HostSourceIds))
+		var found []*templateHostSet
+		if err := r.reader.SearchWhere(ctx, &found, "template_id = ?", []any{tpl.PublicId}); err != nil {
+			return nil, db.NoRowsAffected, errors.Wrap(ctx, err, op, errors.WithMsg("unable to search for existing template host sources"))
+		}
+		deleteItems = append(deleteItems, anySlice(found))
+	}
+	for _, purpose := range setPurposes {
+		var ids CredentialSources
+		switch purpose {
+		case credential.BrokeredPurpose:
+			ids.BrokeredCredentialIds = tpl.CredentialSources.BrokeredCredentialIds
+		case credential.InjectedApplicationPurpose:
+			ids.InjectedApplicationCredentialIds = tpl.CredentialSources.InjectedApplicationCredentialIds
+		}
+		libs, statics, err := r.newTemplateCredentialSources(ctx, tpl.PublicId, ids)
+		if err != nil {
+			return nil, db.NoRowsAffected, errors.Wrap(ctx, err, op)
+		}
+		addItems = append(addItems, libs, statics)
+		var foundLibs []*templateCredentialLibrary
+		if err := r.reader.SearchWhere(ctx, &foundLibs, "template_id = ? and credential_purpose = ?", []any{tpl.PublicId, string(purpose)}); err != nil {
+			return nil, db.NoRowsAffected, errors.Wrap(ctx, err, op, errors.WithMsg("unable to search for existing template credential libraries"))
+		}
+		var foundStatics []*templateStaticCredential
+		if err := r.reader.SearchWhere(ctx, &foundStatics, "template_id = ? and credential_purpose = ?", []any{tpl.PublicId, string(purpose)}); err != nil {
+			return nil, db.NoRowsAffected, errors.Wrap(ctx, err, op, errors.WithMsg("unable to search for existing template static credentials"))
+		}
+		deleteItems = append(deleteItems, anySlice(foundLibs), anySlice(foundStatics))
+	}
+
+	oplogWrapper, err := r.kms.GetWrapper(ctx, tpl.ProjectId, kms.KeyPurposeOplog)
+	if err != nil {
+		return nil, db.NoRowsAffected, errors.Wrap(ctx, err, op, errors.WithMsg("unable to get oplog wrapper"))
+	}
+
+	var rowsUpdated int
+	_, err = r.writer.DoTx(
+		ctx,
+		db.StdRetryCnt,
+		db.ExpBackoff{},
+		func(reader db.Reader, w db.Writer) error {
+			ticket, err := w.GetTicket(ctx, tpl)
+			if err != nil {
+				return errors.Wrap(ctx, err, op, errors.WithMsg("unable to get ticket"))
+			}
+			var msgs []*oplog.Message
+			var tplOplogMsg oplog.Message
+			rowsUpdated, err = w.Update(ctx, tpl.Clone(), dbMask, nullFields, db.NewOplogMsg(&tplOplogMsg), db.WithVersion(&version))
+			if err != nil {
+				return errors.Wrap(ctx, err, op)
+			}
+			if rowsUpdated > 1 {
+				return errors.New(ctx, errors.MultipleRecords, op, "more than 1 resource would have been updated")
+			}
+			if rowsUpdated == 0 {
+				// The version did not match, so neither the sources of the
+				// template nor its targets are changed.
+				return nil
+			}
+			msgs = append(msgs, &tplOplogMsg)
+			for _, items := range deleteItems {
+				if len(items) == 0 {
+					continue
+				}
+				itemMsgs := make([]*oplog.Message, 0, len(items))
+				if _, err := w.DeleteItems(ctx, items, db.NewOplogMsgs(&itemMsgs)); err != nil {
+					return errors.Wrap(ctx, err, op, errors.WithMsg("unable to delete target template sources"))
+				}
+				msgs = append(msgs, itemMsgs...)
+			}
+			for _, items := range addItems {
+				if len(items) == 0 {
+					continue
+				}
+				itemMsgs := make([]*oplog.Message, 0, len(items))
+				if err := w.CreateItems(ctx, items, db.NewOplogMsgs(&itemMsgs)); err != nil {
+					return errors.Wrap(ctx, err, op, errors.WithMsg("unable to add target template sources"))
+				}
+				msgs = append(msgs, itemMsgs...)
+			}
+			if err := w.WriteOplogEntryWith(ctx, oplogWrapper, ticket, tpl.oplog(oplog.OpType_OP_TYPE_UPDATE), msgs); err != nil {
+				return errors.Wrap(ctx, err, op, errors.WithMsg("unable to write oplog"))
+			}
+
+			updated, targets, err := templateTargets(ctx, reader, tpl.PublicId)
+			if err != nil {
+				return errors.Wrap(ctx, err, op)
+			}
+			if _, err := applyTemplate(ctx, reader, w, oplogWrapper, updated, targets); err != nil {
+				return errors.Wrap(ctx, err, op, errors.WithMsg("unable to apply target template to its targets"))
+			}
+			return nil
+		},
+	)
+	if err != nil {
+		if errors.IsUniqueError(err) {
+			return nil, db.NoRowsAffected, errors.New(ctx, errors.NotUnique, op, fmt.Sprintf("target template %s already exists in project %s", tpl.Name, tpl.ProjectId))
+		}
+		return nil, db.NoRowsAffected, errors.Wrap(ctx, err, op, errors.WithMsg(fmt.Sprintf("failed for %s", tpl.PublicId)))
+	}
+
+	returnedTemplate, err := r.LookupTemplate(ctx, tpl.PublicId)
+	if err != nil {
+		return nil, db.NoRowsAffected, errors.Wrap(ctx, err, op)
+	}
+	return returnedTemplate, rowsUpdated, nil
+}
+
+// LookupTemplate returns the Template for id. Returns nil, nil if no Template
+// is found for id.
+func (r *Repository) LookupTemplate(ctx context.Context, id string, _ ...Option) (*Template, error) {
+	const op = "target.(Repository).LookupTemplate"
+	if id == "" {
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "missing public id")
+	}
+	tpl := allocTemplate()
+	tpl.PublicId = id
+	if err := r.reader.LookupByPublicId(ctx, tpl); err != nil {
+		if errors.IsNotFoundError(err) {
+			return nil, nil
+		}
+		return nil, errors.Wrap(ctx, err, op, errors.WithMsg(fmt.Sprintf("failed for %s", id)))
+	}
+	if err := fetchTemplateSources(ctx, r.reader, tpl); err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	return tpl, nil
+}
+
+// ListTemplates returns the Templates in the projects. WithLimit is the only
+// supported option.
+func (r *Repository) ListTemplates(ctx context.Context, projectIds []string, opt ...Option) ([]*Template, error) {
+	const op = "target.(Repository).ListTemplates"
+	if len(projectIds) == 0 {
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "missing project ids")
+	}
+	opts := GetOpts(opt...)
+	limit := r.defaultLimit
+	if opts.WithLimit != 0 {
+		limit = opts.WithLimit
+	}
+	var templates []*Template
+	if err := r.reader.SearchWhere(ctx, &templates, "project_id in (?)", []any{projectIds}, db.WithLimit(limit), db.WithOrder("public_id")); err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	for _, tpl := range templates {
+		if err := fetchTemplateSources(ctx, r.reader, tpl); err != nil {
+			return nil, errors.Wrap(ctx, err, op)
+		}
+	}
+	return templates, nil
+}
+
+// DeleteTemplate deletes the Template for id from the repository returning a
+// count of the number of records deleted. The targets using the template keep
+// their settings. No options are currently supported.
+func (r *Repository) DeleteTemplate(ctx context.Context, id string, _ ...Option) (int, error) {
+	const op = "target.(Repository).DeleteTemplate"
+	if id == "" {
+		return db.NoRowsAffected, errors.New(ctx, errors.InvalidParameter, op, "missing public id")
+	}
+	tpl := allocTemplate()
+	tpl.PublicId = id
+	if err := r.reader.LookupByPublicId(ctx, tpl); err != nil {
+		if errors.IsNotFoundError(err) {
+			return db.NoRowsAffected, nil
+		}
+		return db.NoRowsAffected, errors.Wrap(ctx, err, op, errors.WithMsg(fmt.Sprintf("failed for %s", id)))
+	}
+
+	oplogWrapper, err := r.kms.GetWrapper(ctx, tpl.ProjectId, kms.KeyPurposeOplog)
+	if err != nil {
+		return db.NoRowsAffected, errors.Wrap(ctx, err, op, errors.WithMsg("unable to get oplog wrapper"))
+	}
+
+	var rowsDeleted int
+	_, err = r.writer.DoTx(
+		ctx,
+		db.StdRetryCnt,
+		db.ExpBackoff{},
+		func(_ db.Reader, w db.Writer) error {
+			var err error
+			rowsDeleted, err = w.Delete(ctx, tpl.Clone(), db.WithOplog(oplogWrapper, tpl.oplog(oplog.OpType_OP_TYPE_DELETE)))
+			if err != nil {
+				return errors.Wrap(ctx, err, op)
+			}
+			if rowsDeleted > 1 {
+				return errors.New(ctx, errors.MultipleRecords, op, "more than 1 resource would have been deleted")
+			}
+			return nil
+		},
+	)
+	if err != nil {
+		return db.NoRowsAffected, errors.Wrap(ctx, err, op, errors.WithMsg(fmt.Sprintf("delete failed for %s", id)))
+	}
+	return rowsDeleted, nil
+}
+
+// SetTargetTemplate sets the Template used by the target and the settings of
+// the target which are not managed by the template, replacing the current
+// ones, and applies the template to the target in the same transaction. An
+// empty templateId
+// removes the template from the target, which keeps its settings. No options
+// are currently supported.
+func (r *Repository) SetTargetTemplate(ctx context.Context, targetId, templateId string, overrides []string, _ ...Option) error {
+	const op = "target.(Repository).SetTargetTemplate"
+	switch {
+	case targetId == "":
+		return errors.New(ctx, errors.InvalidParameter, op, "missing target id")
+	case templateId == "" && len(overrides) > 0:
+		return errors.New(ctx, errors.InvalidParameter, op, "overrides set without a template")
+	}
+
+	t := allocTargetView()
+	t.PublicId = targetId
+	if err := r.reader.LookupByPublicId(ctx, &t); err != nil {
+		return errors.Wrap(ctx, err, op, errors.WithMsg(fmt.Sprintf("failed for %s", targetId)))
+	}
+
+	oplogWrapper, err := r.kms.GetWrapper(ctx, t.GetProjectId(), kms.KeyPurposeOplog)
+	if err != nil {
+		return errors.Wrap(ctx, err, op, errors.WithMsg("unable to get oplog wrapper"))
+	}
+
+	_, err = r.writer.DoTx(
+		ctx,
+		db.StdRetryCnt,
+		db.ExpBackoff{},
+		func(reader db.Reader, w db.Writer) error {
+			return setTargetTemplate(ctx, reader, w, oplogWrapper, t.GetProjectId(), targetId, templateId, overrides)
+		},
+	)
+	if err != nil {
+		if isTargetTemplateNotFound(err) {
+			return errors.New(ctx, errors.NotFound, op, fmt.Sprintf("target template %s not found in the project of target %s", templateId, targetId))
+		}
+		return errors.Wrap(ctx, err, op, errors.WithMsg(fmt.Sprintf("failed for %s", targetId)))
+	}
+	return nil
+}
+
+// setTargetTemplate sets the Template used by the target and the settings of
+// the target which are not managed by the template using w, and applies the
+// template to the target. It must be called within a transaction.
+func setTargetTemplate(ctx context.Context, reader db.Reader, w db.Writer, oplogWrapper wrapping.Wrapper, projectId, targetId, templateId string, overrides []string) error {
+	const op = "target.setTargetTemplate"
+	if templateId == "" && len(overrides) > 0 {
+		return errors.New(ctx, errors.InvalidParameter, op, "overrides set without a template")
+	}
+	var tt *templateTarget
+	var overrideItems []any
+	if templateId != "" {
+		var err error
+		if tt, err = newTemplateTarget(ctx, targetId, templateId); err != nil {
+			return errors.Wrap(ctx, err, op)
+		}
+		for _, f := range strutil.RemoveDuplicates(overrides, false) {
+			o, err := newTemplateOverride(ctx, targetId, f)
+			if err != nil {
+				return errors.Wrap(ctx, err, op)
+			}
+			overrideItems = append(overrideItems, o)
+		}
+	}
+
+	ticket, err := w.GetTicket(ctx, allocTemplate())
+	if err != nil {
+		return errors.Wrap(ctx, err, op, errors.WithMsg("unable to get ticket"))
+	}
+	var msgs []*oplog.Message
+	// Removing the current template also removes the overrides of the
+	// target.
+	current := &templateTarget{TemplateTarget: &store.TemplateTarget{TargetId: targetId}}
+	var deleteMsg oplog.Message
+	rowsDeleted, err := w.Delete(ctx, current, db.NewOplogMsg(&deleteMsg))
+	if err != nil {
+		return errors.Wrap(ctx, err, op, errors.WithMsg("unable to remove current target template"))
+	}
+	if rowsDeleted > 0 {
+		msgs = append(msgs, &deleteMsg)
+	}
+	if tt != nil {
+		var createMsg oplog.Message
+		if err := w.Create(ctx, tt, db.NewOplogMsg(&createMsg)); err != nil {
+			return errors.Wrap(ctx, err, op, errors.WithMsg("unable to set target template"))
+		}
+		msgs = append(msgs, &createMsg)
+	}
+	if len(overrideItems) > 0 {
+		overrideMsgs := make([]*oplog.Message, 0, len(overrideItems))
+		if err := w.CreateItems(ctx, overrideItems, db.NewOplogMsgs(&overrideMsgs)); err != nil {
+			return errors.Wrap(ctx, err, op, errors.WithMsg("unable to set target template overrides"))
+		}
+		msgs = append(msgs, overrideMsgs...)
+	}
+	if len(msgs) == 0 {
+		return nil
+	}
+	metadata := oplog.Metadata{
+		"resource-public-id": []string{targetId},
+		"resource-type":      []string{"target-template-target"},
+		"op-type":            []string{oplog.OpType_OP_TYPE_UPDATE.String()},
+		"project-id":         []string{projectId},
+	}
+	if err := w.WriteOplogEntryWith(ctx, oplogWrapper, ticket, metadata, msgs); err != nil {
+		return errors.Wrap(ctx, err, op, errors.WithMsg("unable to write oplog"))
+	}
+	if tt == nil {
+		return nil
+	}
+
+	tpl, targets, err := templateTargets(ctx, reader, templateId, WithTargetIds([]string{targetId}))
+	if err != nil {
+		return errors.Wrap(ctx, err, op)
+	}
+	if _, err := applyTemplate(ctx, reader, w, oplogWrapper, tpl, targets); err != nil {
+		return errors.Wrap(ctx, err, op)
+	}
+	return nil
+}
+
+// isTargetTemplateNotFound reports whether err is the result of setting a
+// Template on a target which does not exist in the project of the target.
+func isTargetTemplateNotFound(err error) bool {
+	return err != nil && strings.Contains(err.Error(), `violates foreign key constraint "target_template_fkey"`)
+}
+
+// ApplyTemplate sets the settings managed by the Template on the targets using
+// it whose settings differ from the template, except for the settings
+// overridden by a target. The targets are updated in a single transaction, so
+// if any of them cannot be updated none of them are. It returns the number of
+// targets updated. Supports WithTargetIds to only apply the template to some
+// of its targets.
+func (r *Repository) ApplyTemplate(ctx context.Context, templateId string, opt ...Option) (int, error) {
+	const op = "target.(Repository).ApplyTemplate"
+	tpl, err := r.LookupTemplate(ctx, templateId)
+	if err != nil {
+		return db.NoRowsAffected, errors.Wrap(ctx, err, op)
+	}
+	if tpl == nil {
+		return db.NoRowsAffected, errors.New(ctx, errors.RecordNotFound, op, fmt.Sprintf("target template %s not found", templateId))
+	}
+
+	oplogWrapper, err := r.kms.GetWrapper(ctx, tpl.ProjectId, kms.KeyPurposeOplog)
+	if err != nil {
+		return db.NoRowsAffected, errors.Wrap(ctx, err, op, errors.WithMsg("unable to get oplog wrapper"))
+	}
+
+	var updated int
+	_, err = r.writer.DoTx(
+		ctx,
+		db.StdRetryCnt,
+		db.ExpBackoff{},
+		func(reader db.Reader, w db.Writer) error {
+			tpl, targets, err := templateTargets(ctx, reader, templateId, opt...)
+			if err != nil {
+				return errors.Wrap(ctx, err, op)
+			}
+			updated, err = applyTemplate(ctx, reader, w, oplogWrapper, tpl, targets)
+			if err != nil {
+				return errors.Wrap(ctx, err, op)
+			}
+			return nil
+		},
+	)
+	if err != nil {
+		return db.NoRowsAffected, errors.Wrap(ctx, err, op)
+	}
+	return updated, nil
+}
+
+// ListOutOfSyncTargets returns the targets using the Template whose settings
+// differ from the settings managed by the template, along with the names of
+// the settings that differ. The settings overridden by a target are not
+// reported. No options are currently supported.
+func (r *Repository) ListOutOfSyncTargets(ctx context.Context, templateId string, _ ...Option) ([]*OutOfSyncTarget, error) {
+	const op = "target.(Repository).ListOutOfSyncTargets"
+	tpl, targets, err := templateTargets(ctx, r.reader, templateId)
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	var outOfSync []*OutOfSyncTarget
+	for _, t := range targets {
+		if fields := tpl.outOfSyncFields(t); len(fields) > 0 {
+			outOfSync = append(outOfSync, &OutOfSyncTarget{
+				TargetId: t.GetPublicId(),
+				Fields:   fields,
+			})
+		}
+	}
+	return outOfSync, nil
+}
+
+// applyTemplate sets the settings managed by tpl on the targets whose settings
+// differ from the template, using w. It returns the number of targets
+// updated.
+func applyTemplate(ctx context.Context, reader db.Reader, w db.Writer, oplogWrapper wrapping.Wrapper, tpl *Template, targets []Target) (int, error) {
+	const op = "target.applyTemplate"
+	var updated int
+	for _, t := range targets {
+		fields := tpl.outOfSyncFields(t)
+		if len(fields) == 0 {
+			continue
+		}
+		if err := applyTemplateToTarget(ctx, reader, w, oplogWrapper, tpl, t, fields); err != nil {
+			return db.NoRowsAffected, errors.Wrap(ctx, err, op, errors.WithMsg(fmt.Sprintf("unable to apply target template to target %s", t.GetPublicId())))
+		}
+		updated++
+	}
+	return updated, nil
+}
+
+// applyTemplateToTarget sets the settings of tgt named by fields to the values
+// of tpl and writes them, along with an oplog entry, using w.
+func applyTemplateToTarget(ctx context.Context, reader db.Reader, w db.Writer, oplogWrapper wrapping.Wrapper, tpl *Template, tgt Target, fields []string) error {
+	const op = "target.applyTemplateToTarget"
+	t := tgt.Clone()
+	targetId := t.GetPublicId()
+	version := t.GetVersion()
+
+	var mask []string
+	var addHostSets, deleteHostSets []any
+	var addLibs, deleteLibs []*CredentialLibrary
+	var addStatics, deleteStatics []*StaticCredential
+	for _, f := range fields {
+		switch f {
+		case TemplateSessionMaxSecondsField:
+			t.SetSessionMaxSeconds(tpl.GetSessionMaxSeconds())
+			mask = append(mask, "SessionMaxSeconds")
+		case TemplateSessionConnectionLimitField:
+			t.SetSessionConnectionLimit(tpl.GetSessionConnectionLimit())
+			mask = append(mask, "SessionConnectionLimit")
+		case TemplateEgressWorkerFilterField:
+			t.SetEgressWorkerFilter(tpl.GetEgressWorkerFilter())
+			mask = append(mask, "EgressWorkerFilter")
+		case TemplateIngressWorkerFilterField:
+			t.SetIngressWorkerFilter(tpl.GetIngressWorkerFilter())
+			mask = append(mask, "IngressWorkerFilter")
+		case TemplateHostSourceIdsField:
+			if t.GetAddress() != "" {
+				return errors.New(ctx, errors.Conflict, op, "unable to set host sources because a network address is directly assigned to the target")
+			}
+			current := make([]string, 0, len(t.GetHostSources()))
+			for _, hs := range t.GetHostSources() {
+				current = append(current, hs.Id())
+			}
+			add, del := idChanges(current, tpl.HostSourceIds)
+			for _, id := range add {
+				hs, err := NewTargetHostSet(ctx, targetId, id)
+				if err != nil {
+					return errors.Wrap(ctx, err, op)
+				}
+				addHostSets = append(addHostSets, hs)
+			}
+			for _, id := range del {
+				hs, err := NewTargetHostSet(ctx, targetId, id)
+				if err != nil {
+					return errors.Wrap(ctx, err, op)
+				}
+				deleteHostSets = append(deleteHostSets, hs)
+			}
+		case TemplateBrokeredCredentialSourceIdsField, TemplateInjectedApplicationCredentialSourceIdsField:
+			purpose, ids := credential.BrokeredPurpose, tpl.CredentialSources.BrokeredCredentialIds
+			if f == TemplateInjectedApplicationCredentialSourceIdsField {
+				purpose, ids = credential.InjectedApplicationPurpose, tpl.CredentialSources.InjectedApplicationCredentialIds
+			}
+			var current []string
+			types := make(map[string]CredentialSourceType)
+			for _, cs := range t.GetCredentialSources() {
+				if cs.CredentialPurpose() == purpose {
+					current = append(current, cs.Id())
+					types[cs.Id()] = cs.Type()
+				}
+			}
+			add, del := idChanges(current, ids)
+			if len(add) > 0 {
+				var views []*credentialSourceView
+				if err := reader.SearchWhere(ctx, &views, "public_id in (?)", []any{add}); err != nil {
+					return errors.Wrap(ctx, err, op, errors.WithMsg("can't retrieve credentials"))
+				}
+				for _, v := range views {
+					types[v.GetPublicId()] = CredentialSourceType(v.GetType())
+				}
+			}
+			for _, id := range slices.Concat(add, del) {
+				isAdd := slices.Contains(add, id)
+				switch types[id] {
+				case LibraryCredentialSourceType:
+					lib, err := NewCredentialLibrary(ctx, targetId, id, purpose)
+					if err != nil {
+						return errors.Wrap(ctx, err, op)
+					}
+					if isAdd {
+						addLibs = append(addLibs, lib)
+					} else {
+						deleteLibs = append(deleteLibs, lib)
+					}
+				case StaticCredentialSourceType:
+					cred, err := NewStaticCredential(ctx, targetId, id, purpose)
+					if err != nil {
+						return errors.Wrap(ctx, err, op)
+					}
+					if isAdd {
+						addStatics = append(addStatics, cred)
+					} else {
+						deleteStatics = append(deleteStatics, cred)
+					}
+				default:
+					return errors.New(ctx, errors.NotSpecificIntegrity, op, fmt.Sprintf("credential source %s not found", id))
+				}
+			}
+		}
+	}
+	if len(addLibs) > 0 || len(addStatics) > 0 {
+		vetCredentialSources, ok := subtypeRegistry.vetCredentialSourcesFunc(t.GetType())
+		if !ok {
+			return errors.New(ctx, errors.InvalidParameter, op, fmt.Sprintf("%s is an unsupported target type %s", targetId, t.GetType()))
+		}
+		if err := vetCredentialSources(ctx, addLibs, addStatics); err != nil {
+			return err
+		}
+	}
+
+	// The sources of a target are child objects of the target, so the
+	// target's version must still be updated when only they change.
+	if len(mask) == 0 {
+		t.SetVersion(version + 1)
+		mask = append(mask, "Version")
+	}
+
+	ticket, err := w.GetTicket(ctx, t)
+	if err != nil {
+		return errors.Wrap(ctx, err, op, errors.WithMsg("unable to get ticket"))
+	}
+	metadata := t.Oplog(oplog.OpType_OP_TYPE_UPDATE)
+	var msgs []*oplog.Message
+	var targetOplogMsg oplog.Message
+	rowsUpdated, err := w.Update(ctx, t, mask, nil, db.NewOplogMsg(&targetOplogMsg), db.WithVersion(&version))
+	if err != nil {
+		return errors.Wrap(ctx, err, op, errors.WithMsg("unable to update target"))
+	}
+	if rowsUpdated == 0 {
+		return errors.New(ctx, errors.VersionMismatch, op, "invalid target version")
+	}
+	if rowsUpdated > 1 {
+		return errors.New(ctx, errors.MultipleRecords, op, fmt.Sprintf("updated target and %d rows updated", rowsUpdated))
+	}
+	msgs = append(msgs, &targetOplogMsg)
+
+	for _, items := range [][]any{deleteHostSets, anySlice(deleteLibs), anySlice(deleteStatics)} {
+		if len(items) == 0 {
+			continue
+		}
+		itemMsgs := make([]*oplog.Message, 0, len(items))
+		rowsDeleted, err := w.DeleteItems(ctx, items, db.NewOplogMsgs(&itemMsgs))
+		if err != nil {
+			return errors.Wrap(ctx, err, op, errors.WithMsg("unable to delete target sources"))
+		}
+		if rowsDeleted != len(items) {
+			return errors.New(ctx, errors.MultipleRecords, op, fmt.Sprintf("target sources deleted %d did not match request for %d", rowsDeleted, len(items)))
+		}
+		msgs = append(msgs, itemMsgs...)
+		metadata["op-type"] = append(metadata["op-type"], oplog.OpType_OP_TYPE_DELETE.String())
+	}
+	for _, items := range [][]any{addHostSets, anySlice(addLibs), anySlice(addStatics)} {
+		if len(items) == 0 {
+			continue
+		}
+		itemMsgs := make([]*oplog.Message, 0, len(items))
+		if err := w.CreateItems(ctx, items, db.NewOplogMsgs(&itemMsgs)); err != nil {
+			return errors.Wrap(ctx, err, op, errors.WithMsg("unable to add target sources"))
+		}
+		msgs = append(msgs, itemMsgs...)
+		metadata["op-type"] = append(metadata["op-type"], oplog.OpType_OP_TYPE_CREATE.String())
+	}
+
+	if err := w.WriteOplogEntryWith(ctx, oplogWrapper, ticket, metadata, msgs); err != nil {
+		return errors.Wrap(ctx, err, op, errors.WithMsg("unable to write oplog"))
+	}
+	return nil
+}
+
+// idChanges returns the ids in want which are not in current, and the ids in
+// current which are not in want.
+func idChanges(current, want []string) (add, del []string) {
+	for _, id := range strutil.RemoveDuplicates(want, false) {
+		if !slices.Contains(current, id) {
+			add = append(add, id)
+		}
+	}
+	for _, id := range current {
+		if !slices.Contains(want, id) {
+			del = append(del, id)
+		}
+	}
+	return add, del
+}
+
+// templateTargets returns the Template and the targets using it, read with
+// reader. Supports WithTargetIds to only return some of the targets.
+func templateTargets(ctx context.Context, reader db.Reader, templateId string, opt ...Option) (*Template, []Target, error) {
+	const op = "target.templateTargets"
+	if templateId == "" {
+		return nil, nil, errors.New(ctx, errors.InvalidParameter, op, "missing template id")
+	}
+	opts := GetOpts(opt...)
+	tpl := allocTemplate()
+	tpl.PublicId = templateId
+	if err := reader.LookupByPublicId(ctx, tpl); err != nil {
+		if errors.IsNotFoundError(err) {
+			return nil, nil, errors.New(ctx, errors.RecordNotFound, op, fmt.Sprintf("target template %s not found", templateId))
+		}
+		return nil, nil, errors.Wrap(ctx, err, op)
+	}
+	if err := fetchTemplateSources(ctx, reader, tpl); err != nil {
+		return nil, nil, errors.Wrap(ctx, err, op)
+	}
+
+	where, args := "template_id = ?", []any{templateId}
+	if len(opts.WithTargetIds) > 0 {
+		where, args = where+" and target_id in (?)", append(args, opts.WithTargetIds)
+	}
+	var tts []*templateTarget
+	if err := reader.SearchWhere(ctx, &tts, where, args, db.WithOrder("target_id")); err != nil {
+		return nil, nil, errors.Wrap(ctx, err, op)
+	}
+	targets := make([]Target, 0, len(tts))
+	for _, tt := range tts {
+		t, err := fetchTemplateTarget(ctx, reader, tt.TargetId)
+		if err != nil {
+			return nil, nil, errors.Wrap(ctx, err, op)
+		}
+		if t == nil {
+			continue
+		}
+		targets = append(targets, t)
+	}
+	return tpl, targets, nil
+}
+
+// fetchTemplateTarget returns the target for targetId with the settings
+// which can be managed by a Template, or nil if it is not found.
+func fetchTemplateTarget(ctx context.Context, r db.Reader, targetId string) (Target, error) {
+	const op = "target.fetchTemplateTarget"
+	tv := allocTargetView()
+	tv.PublicId = targetId
+	if err := r.LookupById(ctx, &tv); err != nil {
+		if errors.IsNotFoundError(err) {
+			return nil, nil
+		}
+		return nil, errors.Wrap(ctx, err, op, errors.WithMsg(fmt.Sprintf("failed for %s", targetId)))
+	}
+	hostSources, err := fetchHostSources(ctx, r, targetId)
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	credSources, err := fetchCredentialSources(ctx, r, targetId)
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	templateId, overrides, err := fetchTargetTemplate(ctx, r, targetId)
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	var address string
+	targetAddress, err := fetchAddress(ctx, r, targetId)
+	if err != nil && !errors.IsNotFoundError(err) {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	if targetAddress != nil {
+		address = targetAddress.GetAddress()
+	}
+	t, err := tv.targetSubtype(ctx, address)
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	t.SetHostSources(hostSources)
+	t.SetCredentialSources(credSources)
+	t.SetTemplateId(templateId)
+	t.SetTemplateOverrides(overrides)
+	return t, nil
+}
+
+// fetchTargetTemplate returns the id of the Template used by the target and
+// the settings of the target which are not managed by the template.
+func fetchTargetTemplate(ctx context.Context, r db.Reader, targetId string) (string, []string, error) {
+	const op = "target.fetchTargetTemplate"
+	tt := &templateTarget{TemplateTarget: &store.TemplateTarget{}}
+	if err := r.LookupWhere(ctx, tt, "target_id = ?", []any{targetId}); err != nil {
+		if errors.IsNotFoundError(err) {
+			return "", nil, nil
+		}
+		return "", nil, errors.Wrap(ctx, err, op)
+	}
+	var found []*templateOverride
+	if err := r.SearchWhere(ctx, &found, "target_id = ?", []any{targetId}, db.WithOrder("field_name")); err != nil {
+		return "", nil, errors.Wrap(ctx, err, op)
+	}
+	var overrides []string
+	for _, o := range found {
+		overrides = append(overrides, o.FieldName)
+	}
+	return tt.TemplateId, overrides, nil
+}
+
+// fetchTemplateSources sets the host sources and credential sources of tpl.
+func fetchTemplateSources(ctx context.Context, r db.Reader, tpl *Template) error {
+	const op = "target.fetchTemplateSources"
+	var hostSets []*templateHostSet
+	if err := r.SearchWhere(ctx, &hostSets, "template_id = ?", []any{tpl.PublicId}, db.WithOrder("host_set_id")); err != nil {
+		return errors.Wrap(ctx, err, op)
+	}
+	tpl.HostSourceIds = nil
+	for _, hs := range hostSets {
+		tpl.HostSourceIds = append(tpl.HostSourceIds, hs.HostSetId)
+	}
+	var credSources []*templateCredentialSource
+	if err := r.SearchWhere(ctx, &credSources, "template_id = ?", []any{tpl.PublicId}, db.WithOrder("credential_source_id")); err != nil {
+		return errors.Wrap(ctx, err, op)
+	}
+	tpl.CredentialSources = CredentialSources{}
+	for _, cs := range credSources {
+		switch credential.Purpose(cs.CredentialPurpose) {
+		case credential.BrokeredPurpose:
+			tpl.CredentialSources.BrokeredCredentialIds = append(tpl.CredentialSources.BrokeredCredentialIds, cs.CredentialSourceId)
+		case credential.InjectedApplicationPurpose:
+			tpl.CredentialSources.InjectedApplicationCredentialIds = append(tpl.CredentialSources.InjectedApplicationCredentialIds, cs.CredentialSourceId)
+		}
+	}
+	return nil
+}
+
+func newTemplateHostSets(templateId string, hostSetIds []string) []any {
+	items := make([]any, 0, len(hostSetIds))
+	for _, id := range strutil.RemoveDuplicates(hostSetIds, false) {
+		items = append(items, &templateHostSet{
+			TemplateHostSet: &store.TemplateHostSet{
+				TemplateId: templateId,
+				HostSetId:  id,
+			},
+		})
+	}
+	return items
+}
+
+// newTemplateCredentialSources returns the credential libraries and static
+// credentials to set on the template for the credential sources.
+func (r *Repository) newTemplateCredentialSources(ctx context.Context, templateId string, credSources CredentialSources) ([]any, []any, error) {
+	const op = "target.(Repository).newTemplateCredentialSources"
+	ids := strutil.RemoveDuplicates(slices.Concat(credSources.BrokeredCredentialIds, credSources.InjectedApplicationCredentialIds), false)
+	if len(ids) == 0 {
+		return nil, nil, nil
+	}
+
+	// Fetch credentials from database to determine the type of credential
+	var credView []*credentialSourceView
+	if err := r.reader.SearchWhere(ctx, &credView, "public_id in (?)", []any{ids}); err != nil {
+		return nil, nil, errors.Wrap(ctx, err, op, errors.WithMsg("can't retrieve credentials"))
+	}
+	if len(ids) != len(credView) {
+		return nil, nil, errors.New(ctx, errors.NotSpecificIntegrity, op,
+			fmt.Sprintf("mismatch between request and returned source ids, expected %d got %d", len(ids), len(credView)))
+	}
+	credTypeById := make(map[string]CredentialSourceType, len(ids))
+	for _, cv := range credView {
+		credTypeById[cv.GetPublicId()] = CredentialSourceType(cv.GetType())
+	}
+
+	var libs, statics []any
+	byPurpose := map[credential.Purpose][]string{
+		credential.BrokeredPurpose:            credSources.BrokeredCredentialIds,
+		credential.InjectedApplicationPurpose: credSources.InjectedApplicationCredentialIds,
+	}
+	for purpose, ids := range byPurpose {
+		for _, id := range strutil.RemoveDuplicates(ids, false) {
+			switch credTypeById[id] {
+			case LibraryCredentialSourceType:
+				libs = append(libs, &templateCredentialLibrary{
+					TemplateCredentialLibrary: &store.TemplateCredentialLibrary{
+						TemplateId:          templateId,
+						CredentialLibraryId: id,
+						CredentialPurpose:   string(purpose),
+					},
+				})
+			case StaticCredentialSourceType:
+				statics = append(statics, &templateStaticCredential{
+					TemplateStaticCredential: &store.TemplateStaticCredential{
+						TemplateId:        templateId,
+						CredentialId:      id,
+						CredentialPurpose: string(purpose),
+					},
+				})
+			}
+		}
+	}
+	return libs, statics, nil
+}
+
+func anySlice[T any](in []T) []any {
+	out := make([]any, 0, len(in))
+	for _, v := range in {
+		out = append(out, v)
+	}
+	return out
+}
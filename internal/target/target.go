@@ -36,12 +36,21 @@ type Target interface {
 	GetWorkerFilter() string
 	GetEgressWorkerFilter() string
 	GetIngressWorkerFilter() string
+	GetWorkerSelectionStrategy() string
+	GetHostHealthCheck() string
+	GetAccessWindow() string
+	GetSessionReasonRequired() bool
+	GetSessionTicketPattern() string
+	GetSessionUploadRateLimit() uint32
+	GetSessionDownloadRateLimit() uint32
 	GetAddress() string
 	GetAliases() []*target.Alias
 	GetHostSources() []HostSource
 	GetCredentialSources() []CredentialSource
 	GetStorageBucketId() string
 	GetEnableSessionRecording() bool
+	GetTemplateId() string
+	GetTemplateOverrides() []string
 	Clone() Target
 	SetPublicId(context.Context, string) error
 	SetProjectId(string)
@@ -57,12 +66,21 @@ type Target interface {
 	SetWorkerFilter(string)
 	SetEgressWorkerFilter(string)
 	SetIngressWorkerFilter(string)
+	SetWorkerSelectionStrategy(string)
+	SetHostHealthCheck(string)
+	SetAccessWindow(string)
+	SetSessionReasonRequired(bool)
+	SetSessionTicketPattern(string)
+	SetSessionUploadRateLimit(uint32)
+	SetSessionDownloadRateLimit(uint32)
 	SetAddress(string)
 	SetAliases([]*target.Alias)
 	SetHostSources([]HostSource)
 	SetCredentialSources([]CredentialSource)
 	SetStorageBucketId(string)
 	SetEnableSessionRecording(bool)
+	SetTemplateId(string)
+	SetTemplateOverrides([]string)
 	Oplog(op oplog.OpType) oplog.Metadata
 }
 
@@ -186,6 +204,13 @@ func (t *targetView) targetSubtype(ctx context.Context, address string) (Target,
 	tt.SetWorkerFilter(t.WorkerFilter)
 	tt.SetEgressWorkerFilter(t.EgressWorkerFilter)
 	tt.SetIngressWorkerFilter(t.IngressWorkerFilter)
+	tt.SetWorkerSelectionStrategy(t.WorkerSelectionStrategy)
+	tt.SetHostHealthCheck(t.HostHealthCheck)
+	tt.SetAccessWindow(t.AccessWindow)
+	tt.SetSessionReasonRequired(t.SessionReasonRequired)
+	tt.SetSessionTicketPattern(t.SessionTicketPattern)
+	tt.SetSessionUploadRateLimit(t.SessionUploadRateLimit)
+	tt.SetSessionDownloadRateLimit(t.SessionDownloadRateLimit)
 	tt.SetAddress(address)
 	tt.SetHostSources(t.HostSource)
 	tt.SetCredentialSources(t.CredentialSources)
@@ -36,6 +36,8 @@ type Target struct {
 	HostSource        []target.HostSource       `gorm:"-"`
 	CredentialSources []target.CredentialSource `gorm:"-"`
 	Aliases           []*talias.Alias           `gorm:"-"`
+	TemplateId        string                    `gorm:"-"`
+	TemplateOverrides []string                  `gorm:"-"`
 }
 
 var (
@@ -137,6 +139,34 @@ func (t *Target) GetIngressWorkerFilter() string {
 	return t.IngressWorkerFilter
 }
 
+func (t *Target) GetWorkerSelectionStrategy() string {
+	return t.WorkerSelectionStrategy
+}
+
+func (t *Target) GetHostHealthCheck() string {
+	return t.HostHealthCheck
+}
+
+func (t *Target) GetAccessWindow() string {
+	return t.AccessWindow
+}
+
+func (t *Target) GetSessionReasonRequired() bool {
+	return t.SessionReasonRequired
+}
+
+func (t *Target) GetSessionTicketPattern() string {
+	return t.SessionTicketPattern
+}
+
+func (t *Target) GetSessionUploadRateLimit() uint32 {
+	return t.SessionUploadRateLimit
+}
+
+func (t *Target) GetSessionDownloadRateLimit() uint32 {
+	return t.SessionDownloadRateLimit
+}
+
 func (t *Target) GetAddress() string {
 	return t.Address
 }
@@ -145,6 +175,14 @@ func (t *Target) GetAliases() []*talias.Alias {
 	return t.Aliases
 }
 
+func (t *Target) GetTemplateId() string {
+	return t.TemplateId
+}
+
+func (t *Target) GetTemplateOverrides() []string {
+	return t.TemplateOverrides
+}
+
 func (t *Target) GetHostSources() []target.HostSource {
 	return t.HostSource
 }
@@ -168,6 +206,8 @@ func (t *Target) Clone() target.Target {
 		Target:            cp.(*store.Target),
 		HostSource:        t.HostSource,
 		CredentialSources: t.CredentialSources,
+		TemplateId:        t.TemplateId,
+		TemplateOverrides: t.TemplateOverrides,
 	}
 }
 
@@ -233,6 +273,34 @@ func (t *Target) SetIngressWorkerFilter(filter string) {
 	t.IngressWorkerFilter = filter
 }
 
+func (t *Target) SetWorkerSelectionStrategy(strategy string) {
+	t.WorkerSelectionStrategy = strategy
+}
+
+func (t *Target) SetHostHealthCheck(check string) {
+	t.HostHealthCheck = check
+}
+
+func (t *Target) SetAccessWindow(window string) {
+	t.AccessWindow = window
+}
+
+func (t *Target) SetSessionReasonRequired(required bool) {
+	t.SessionReasonRequired = required
+}
+
+func (t *Target) SetSessionTicketPattern(pattern string) {
+	t.SessionTicketPattern = pattern
+}
+
+func (t *Target) SetSessionUploadRateLimit(limit uint32) {
+	t.SessionUploadRateLimit = limit
+}
+
+func (t *Target) SetSessionDownloadRateLimit(limit uint32) {
+	t.SessionDownloadRateLimit = limit
+}
+
 func (t *Target) SetAddress(a string) {
 	t.Address = a
 }
@@ -241,6 +309,14 @@ func (t *Target) SetAliases(aliases []*talias.Alias) {
 	t.Aliases = aliases
 }
 
+func (t *Target) SetTemplateId(id string) {
+	t.TemplateId = id
+}
+
+func (t *Target) SetTemplateOverrides(overrides []string) {
+	t.TemplateOverrides = overrides
+}
+
 func (t *Target) SetHostSources(sources []target.HostSource) {
 	t.HostSource = sources
 }
@@ -328,16 +404,23 @@ func New(ctx context.Context, projectId string, opt ...target.Option) (target.Ta
 	}
 	t := &Target{
 		Target: &store.Target{
-			ProjectId:              projectId,
-			Name:                   opts.WithName,
-			Description:            opts.WithDescription,
-			DefaultPort:            opts.WithDefaultPort,
-			DefaultClientPort:      opts.WithDefaultClientPort,
-			SessionConnectionLimit: opts.WithSessionConnectionLimit,
-			SessionMaxSeconds:      opts.WithSessionMaxSeconds,
-			WorkerFilter:           opts.WithWorkerFilter,
-			EgressWorkerFilter:     opts.WithEgressWorkerFilter,
-			IngressWorkerFilter:    opts.WithIngressWorkerFilter,
+			ProjectId:                projectId,
+			Name:                     opts.WithName,
+			Description:              opts.WithDescription,
+			DefaultPort:              opts.WithDefaultPort,
+			DefaultClientPort:        opts.WithDefaultClientPort,
+			SessionConnectionLimit:   opts.WithSessionConnectionLimit,
+			SessionMaxSeconds:        opts.WithSessionMaxSeconds,
+			WorkerFilter:             opts.WithWorkerFilter,
+			EgressWorkerFilter:       opts.WithEgressWorkerFilter,
+			IngressWorkerFilter:      opts.WithIngressWorkerFilter,
+			WorkerSelectionStrategy:  opts.WithWorkerSelectionStrategy,
+			HostHealthCheck:          opts.WithHostHealthCheck,
+			AccessWindow:             opts.WithAccessWindow,
+			SessionReasonRequired:    opts.WithSessionReasonRequired,
+			SessionTicketPattern:     opts.WithSessionTicketPattern,
+			SessionUploadRateLimit:   opts.WithSessionUploadRateLimit,
+			SessionDownloadRateLimit: opts.WithSessionDownloadRateLimit,
 		},
 		Address: opts.WithAddress,
 	}
@@ -0,0 +1,358 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package tcp_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/boundary/internal/credential/vault"
+	"github.com/hashicorp/boundary/internal/db"
+	"github.com/hashicorp/boundary/internal/errors"
+	"github.com/hashicorp/boundary/internal/host/static"
+	"github.com/hashicorp/boundary/internal/iam"
+	"github.com/hashicorp/boundary/internal/kms"
+	"github.com/hashicorp/boundary/internal/target"
+	"github.com/hashicorp/boundary/internal/target/store"
+	"github.com/hashicorp/boundary/internal/target/tcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func hostSourceIds(t target.Target) []string {
+	var ids []string
+	for _, hs := range t.GetHostSources() {
+		ids = append(ids, hs.Id())
+	}
+	return ids
+}
+
+func TestRepository_CreateTemplate(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	conn, _ := db.TestSetup(t, "postgres")
+	rw := db.New(conn)
+	wrapper := db.TestWrapper(t)
+	testKms := kms.TestKms(t, conn, wrapper)
+	repo, err := target.NewRepository(ctx, rw, rw, testKms)
+	require.NoError(t, err)
+
+	iamRepo := iam.TestRepo(t, conn, wrapper)
+	_, proj := iam.TestScopes(t, iamRepo)
+	cats := static.TestCatalogs(t, conn, proj.GetPublicId(), 1)
+	hsets := static.TestSets(t, conn, cats[0].GetPublicId(), 2)
+	cs := vault.TestCredentialStores(t, conn, wrapper, proj.GetPublicId(), 1)[0]
+	libs := vault.TestCredentialLibraries(t, conn, wrapper, cs.GetPublicId(), 1)
+
+	t.Run("valid", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		tpl, err := target.NewTemplate(ctx, proj.GetPublicId(), target.WithName("valid"))
+		require.NoError(err)
+		tpl.SessionMaxSeconds = 3600
+		tpl.EgressWorkerFilter = `"prod" in "/tags/env"`
+		tpl.HostSourceIds = []string{hsets[0].GetPublicId(), hsets[1].GetPublicId()}
+		tpl.CredentialSources.BrokeredCredentialIds = []string{libs[0].GetPublicId()}
+
+		got, err := repo.CreateTemplate(ctx, tpl)
+		require.NoError(err)
+		assert.NotEmpty(got.GetPublicId())
+		assert.Equal(uint32(1), got.GetVersion())
+		assert.Equal(uint32(3600), got.GetSessionMaxSeconds())
+		assert.ElementsMatch(tpl.HostSourceIds, got.HostSourceIds)
+		assert.Equal(tpl.CredentialSources.BrokeredCredentialIds, got.CredentialSources.BrokeredCredentialIds)
+		assert.Empty(tpl.GetPublicId(), "the template passed in must not be changed")
+
+		found, err := repo.LookupTemplate(ctx, got.GetPublicId())
+		require.NoError(err)
+		assert.Equal(got, found)
+	})
+	t.Run("duplicate-name", func(t *testing.T) {
+		tpl, err := target.NewTemplate(ctx, proj.GetPublicId(), target.WithName("duplicate"))
+		require.NoError(t, err)
+		_, err = repo.CreateTemplate(ctx, tpl)
+		require.NoError(t, err)
+		_, err = repo.CreateTemplate(ctx, tpl)
+		assert.True(t, errors.Match(errors.T(errors.NotUnique), err))
+	})
+	t.Run("missing-project", func(t *testing.T) {
+		_, err := repo.CreateTemplate(ctx, &target.Template{Template: &store.Template{}})
+		assert.True(t, errors.Match(errors.T(errors.InvalidParameter), err))
+	})
+	t.Run("public-id-set", func(t *testing.T) {
+		tpl, err := target.NewTemplate(ctx, proj.GetPublicId())
+		require.NoError(t, err)
+		tpl.PublicId = "ttpl_1234567890"
+		_, err = repo.CreateTemplate(ctx, tpl)
+		assert.True(t, errors.Match(errors.T(errors.InvalidParameter), err))
+	})
+}
+
+func TestRepository_UpdateTemplate(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	conn, _ := db.TestSetup(t, "postgres")
+	rw := db.New(conn)
+	wrapper := db.TestWrapper(t)
+	testKms := kms.TestKms(t, conn, wrapper)
+	repo, err := target.NewRepository(ctx, rw, rw, testKms)
+	require.NoError(t, err)
+
+	iamRepo := iam.TestRepo(t, conn, wrapper)
+	_, proj := iam.TestScopes(t, iamRepo)
+	cats := static.TestCatalogs(t, conn, proj.GetPublicId(), 1)
+	hsets := static.TestSets(t, conn, cats[0].GetPublicId(), 2)
+
+	newTemplate := func(t *testing.T, name string) *target.Template {
+		t.Helper()
+		tpl, err := target.NewTemplate(ctx, proj.GetPublicId(), target.WithName(name))
+		require.NoError(t, err)
+		tpl.SessionMaxSeconds = 3600
+		tpl, err = repo.CreateTemplate(ctx, tpl)
+		require.NoError(t, err)
+		return tpl
+	}
+	lookup := func(t *testing.T, id string) target.Target {
+		t.Helper()
+		tgt, err := repo.LookupTarget(ctx, id)
+		require.NoError(t, err)
+		require.NotNil(t, tgt)
+		return tgt
+	}
+
+	t.Run("propagates", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		tpl := newTemplate(t, "propagates")
+		using := tcp.TestTarget(ctx, t, conn, proj.GetPublicId(), "propagates-using")
+		overriding := tcp.TestTarget(ctx, t, conn, proj.GetPublicId(), "propagates-overriding")
+		other := tcp.TestTarget(ctx, t, conn, proj.GetPublicId(), "propagates-other")
+		overridden := lookup(t, overriding.GetPublicId()).GetSessionMaxSeconds()
+		otherVersion := lookup(t, other.GetPublicId()).GetVersion()
+		require.NoError(repo.SetTargetTemplate(ctx, using.GetPublicId(), tpl.GetPublicId(), nil))
+		require.NoError(repo.SetTargetTemplate(ctx, overriding.GetPublicId(), tpl.GetPublicId(), []string{target.TemplateSessionMaxSecondsField}))
+		assert.Equal(uint32(3600), lookup(t, using.GetPublicId()).GetSessionMaxSeconds())
+		assert.Equal(overridden, lookup(t, overriding.GetPublicId()).GetSessionMaxSeconds())
+
+		tpl.SessionMaxSeconds = 60
+		tpl.HostSourceIds = []string{hsets[0].GetPublicId()}
+		got, rowsUpdated, err := repo.UpdateTemplate(ctx, tpl, tpl.GetVersion(), []string{"SessionMaxSeconds", "HostSourceIds"})
+		require.NoError(err)
+		assert.Equal(1, rowsUpdated)
+		assert.Equal(uint32(60), got.GetSessionMaxSeconds())
+		assert.Equal(tpl.HostSourceIds, got.HostSourceIds)
+
+		u := lookup(t, using.GetPublicId())
+		assert.Equal(uint32(60), u.GetSessionMaxSeconds())
+		assert.Equal(tpl.HostSourceIds, hostSourceIds(u))
+		o := lookup(t, overriding.GetPublicId())
+		assert.Equal(overridden, o.GetSessionMaxSeconds())
+		assert.Equal(tpl.HostSourceIds, hostSourceIds(o))
+		assert.Equal(otherVersion, lookup(t, other.GetPublicId()).GetVersion())
+
+		outOfSync, err := repo.ListOutOfSyncTargets(ctx, tpl.GetPublicId())
+		require.NoError(err)
+		assert.Empty(outOfSync)
+	})
+
+	t.Run("rolls-back", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		tpl := newTemplate(t, "rolls-back")
+		// A target with a network address cannot be given host sources, so
+		// the update must not change the template or any of its targets.
+		using := tcp.TestTarget(ctx, t, conn, proj.GetPublicId(), "rolls-back-using")
+		withAddress := tcp.TestTarget(ctx, t, conn, proj.GetPublicId(), "rolls-back-address", target.WithAddress("8.8.8.8"))
+		require.NoError(repo.SetTargetTemplate(ctx, using.GetPublicId(), tpl.GetPublicId(), nil))
+		require.NoError(repo.SetTargetTemplate(ctx, withAddress.GetPublicId(), tpl.GetPublicId(), nil))
+		before := lookup(t, using.GetPublicId())
+
+		updated := tpl.Clone()
+		updated.SessionMaxSeconds = 60
+		updated.HostSourceIds = []string{hsets[1].GetPublicId()}
+		_, _, err := repo.UpdateTemplate(ctx, updated, tpl.GetVersion(), []string{"SessionMaxSeconds", "HostSourceIds"})
+		require.Error(err)
+		assert.Contains(err.Error(), withAddress.GetPublicId())
+
+		found, err := repo.LookupTemplate(ctx, tpl.GetPublicId())
+		require.NoError(err)
+		assert.Equal(tpl, found)
+		assert.Equal(before, lookup(t, using.GetPublicId()))
+	})
+
+	t.Run("version-mismatch", func(t *testing.T) {
+		tpl := newTemplate(t, "version-mismatch")
+		tpl.SessionMaxSeconds = 60
+		_, _, err := repo.UpdateTemplate(ctx, tpl, tpl.GetVersion()+1, []string{"SessionMaxSeconds"})
+		require.Error(t, err)
+	})
+
+	t.Run("invalid-field-mask", func(t *testing.T) {
+		tpl := newTemplate(t, "invalid-field-mask")
+		_, _, err := repo.UpdateTemplate(ctx, tpl, tpl.GetVersion(), []string{"ProjectId"})
+		assert.True(t, errors.Match(errors.T(errors.InvalidFieldMask), err))
+	})
+}
+
+func TestRepository_ApplyTemplate(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	conn, _ := db.TestSetup(t, "postgres")
+	rw := db.New(conn)
+	wrapper := db.TestWrapper(t)
+	testKms := kms.TestKms(t, conn, wrapper)
+	repo, err := target.NewRepository(ctx, rw, rw, testKms)
+	require.NoError(t, err)
+
+	iamRepo := iam.TestRepo(t, conn, wrapper)
+	_, proj := iam.TestScopes(t, iamRepo)
+	cats := static.TestCatalogs(t, conn, proj.GetPublicId(), 1)
+	hsets := static.TestSets(t, conn, cats[0].GetPublicId(), 2)
+	cs := vault.TestCredentialStores(t, conn, wrapper, proj.GetPublicId(), 1)[0]
+	libs := vault.TestCredentialLibraries(t, conn, wrapper, cs.GetPublicId(), 1)
+
+	tpl, err := target.NewTemplate(ctx, proj.GetPublicId())
+	require.NoError(t, err)
+	tpl.SessionConnectionLimit = 5
+	tpl.HostSourceIds = []string{hsets[0].GetPublicId()}
+	tpl.CredentialSources.BrokeredCredentialIds = []string{libs[0].GetPublicId()}
+	tpl, err = repo.CreateTemplate(ctx, tpl)
+	require.NoError(t, err)
+
+	drifting := tcp.TestTarget(ctx, t, conn, proj.GetPublicId(), "drifting")
+	inSync := tcp.TestTarget(ctx, t, conn, proj.GetPublicId(), "in-sync")
+	for _, tgt := range []target.Target{drifting, inSync} {
+		require.NoError(t, repo.SetTargetTemplate(ctx, tgt.GetPublicId(), tpl.GetPublicId(), nil))
+	}
+
+	// Drift one of the targets away from the template.
+	drifted, err := repo.LookupTarget(ctx, drifting.GetPublicId())
+	require.NoError(t, err)
+	drifted.SetSessionConnectionLimit(10)
+	drifted, _, err = repo.UpdateTarget(ctx, drifted, drifted.GetVersion(), []string{"SessionConnectionLimit"})
+	require.NoError(t, err)
+	_, _, _, err = repo.SetTargetHostSources(ctx, drifted.GetPublicId(), drifted.GetVersion(), []string{hsets[1].GetPublicId()})
+	require.NoError(t, err)
+
+	outOfSync, err := repo.ListOutOfSyncTargets(ctx, tpl.GetPublicId())
+	require.NoError(t, err)
+	assert.Equal(t, []*target.OutOfSyncTarget{{
+		TargetId: drifting.GetPublicId(),
+		Fields:   []string{target.TemplateSessionConnectionLimitField, target.TemplateHostSourceIdsField},
+	}}, outOfSync)
+
+	updated, err := repo.ApplyTemplate(ctx, tpl.GetPublicId())
+	require.NoError(t, err)
+	assert.Equal(t, 1, updated)
+
+	got, err := repo.LookupTarget(ctx, drifting.GetPublicId())
+	require.NoError(t, err)
+	assert.Equal(t, int32(5), got.GetSessionConnectionLimit())
+	assert.Equal(t, tpl.HostSourceIds, hostSourceIds(got))
+	require.Len(t, got.GetCredentialSources(), 1)
+	assert.Equal(t, libs[0].GetPublicId(), got.GetCredentialSources()[0].Id())
+
+	outOfSync, err = repo.ListOutOfSyncTargets(ctx, tpl.GetPublicId())
+	require.NoError(t, err)
+	assert.Empty(t, outOfSync)
+
+	updated, err = repo.ApplyTemplate(ctx, tpl.GetPublicId())
+	require.NoError(t, err)
+	assert.Zero(t, updated)
+
+	_, err = repo.ApplyTemplate(ctx, "ttpl_1234567890")
+	assert.True(t, errors.Match(errors.T(errors.RecordNotFound), err))
+}
+
+func TestRepository_WriteTargetWithTemplate(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	conn, _ := db.TestSetup(t, "postgres")
+	rw := db.New(conn)
+	wrapper := db.TestWrapper(t)
+	testKms := kms.TestKms(t, conn, wrapper)
+	repo, err := target.NewRepository(ctx, rw, rw, testKms)
+	require.NoError(t, err)
+
+	iamRepo := iam.TestRepo(t, conn, wrapper)
+	_, proj := iam.TestScopes(t, iamRepo)
+
+	tpl, err := target.NewTemplate(ctx, proj.GetPublicId())
+	require.NoError(t, err)
+	tpl.SessionMaxSeconds = 3600
+	tpl.SessionConnectionLimit = 5
+	tpl, err = repo.CreateTemplate(ctx, tpl)
+	require.NoError(t, err)
+
+	newTarget := func(t *testing.T, name string) target.Target {
+		t.Helper()
+		tgt, err := target.New(ctx, tcp.Subtype, proj.GetPublicId(), target.WithName(name), target.WithDefaultPort(22))
+		require.NoError(t, err)
+		return tgt
+	}
+
+	t.Run("create", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		got, err := repo.CreateTarget(ctx, newTarget(t, "create"),
+			target.WithTemplateId(tpl.GetPublicId()),
+			target.WithTemplateOverrides([]string{target.TemplateSessionConnectionLimitField}))
+		require.NoError(err)
+		assert.Equal(tpl.GetPublicId(), got.GetTemplateId())
+		assert.Equal([]string{target.TemplateSessionConnectionLimitField}, got.GetTemplateOverrides())
+		assert.Equal(uint32(3600), got.GetSessionMaxSeconds())
+		assert.Equal(int32(-1), got.GetSessionConnectionLimit())
+	})
+
+	t.Run("create-template-not-found", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		_, err := repo.CreateTarget(ctx, newTarget(t, "create-template-not-found"), target.WithTemplateId("ttpl_1234567890"))
+		require.Error(err)
+		assert.True(errors.Match(errors.T(errors.NotFound), err))
+		// The target must not be created without its template.
+		found, err := repo.LookupTarget(ctx, "create-template-not-found", target.WithName("create-template-not-found"), target.WithProjectId(proj.GetPublicId()))
+		require.NoError(err)
+		assert.Nil(found)
+	})
+
+	t.Run("create-overrides-without-template", func(t *testing.T) {
+		_, err := repo.CreateTarget(ctx, newTarget(t, "create-overrides"), target.WithTemplateOverrides([]string{target.TemplateSessionMaxSecondsField}))
+		assert.True(t, errors.Match(errors.T(errors.InvalidParameter), err))
+	})
+
+	t.Run("update", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		tgt, err := repo.CreateTarget(ctx, newTarget(t, "update"))
+		require.NoError(err)
+
+		tgt.SetDescription("updated")
+		got, rowsUpdated, err := repo.UpdateTarget(ctx, tgt, tgt.GetVersion(), []string{"Description"}, target.WithTemplateId(tpl.GetPublicId()))
+		require.NoError(err)
+		assert.Equal(1, rowsUpdated)
+		assert.Equal("updated", got.GetDescription())
+		assert.Equal(tpl.GetPublicId(), got.GetTemplateId())
+		assert.Equal(uint32(3600), got.GetSessionMaxSeconds())
+		assert.Equal(int32(5), got.GetSessionConnectionLimit())
+
+		// Setting only the overrides keeps the template of the target and
+		// still increments its version.
+		version := got.GetVersion()
+		got, rowsUpdated, err = repo.UpdateTarget(ctx, got, version, nil, target.WithTemplateOverrides([]string{target.TemplateSessionMaxSecondsField}))
+		require.NoError(err)
+		assert.Equal(1, rowsUpdated)
+		assert.Equal(tpl.GetPublicId(), got.GetTemplateId())
+		assert.Equal([]string{target.TemplateSessionMaxSecondsField}, got.GetTemplateOverrides())
+		assert.Greater(got.GetVersion(), version)
+
+		// An outdated version must not change the template.
+		_, rowsUpdated, err = repo.UpdateTarget(ctx, got, version, nil, target.WithTemplateId(""))
+		require.NoError(err)
+		assert.Zero(rowsUpdated)
+		found, err := repo.LookupTarget(ctx, got.GetPublicId())
+		require.NoError(err)
+		assert.Equal(tpl.GetPublicId(), found.GetTemplateId())
+
+		got, rowsUpdated, err = repo.UpdateTarget(ctx, found, found.GetVersion(), nil, target.WithTemplateId(""))
+		require.NoError(err)
+		assert.Equal(1, rowsUpdated)
+		assert.Empty(got.GetTemplateId())
+		assert.Empty(got.GetTemplateOverrides())
+	})
+}
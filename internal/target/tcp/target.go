@@ -38,6 +38,8 @@ type Target struct {
 	HostSource        []target.HostSource       `gorm:"-"`
 	CredentialSources []target.CredentialSource `gorm:"-"`
 	Aliases           []*talias.Alias           `gorm:"-"`
+	TemplateId        string                    `gorm:"-"`
+	TemplateOverrides []string                  `gorm:"-"`
 }
 
 // Ensure Target implements interfaces
@@ -57,16 +59,23 @@ func (h targetHooks) NewTarget(ctx context.Context, projectId string, opt ...tar
 	}
 	t := &Target{
 		Target: &store.Target{
-			ProjectId:              projectId,
-			Name:                   opts.WithName,
-			Description:            opts.WithDescription,
-			DefaultPort:            opts.WithDefaultPort,
-			DefaultClientPort:      opts.WithDefaultClientPort,
-			SessionConnectionLimit: opts.WithSessionConnectionLimit,
-			SessionMaxSeconds:      opts.WithSessionMaxSeconds,
-			WorkerFilter:           opts.WithWorkerFilter,
-			EgressWorkerFilter:     opts.WithEgressWorkerFilter,
-			IngressWorkerFilter:    opts.WithIngressWorkerFilter,
+			ProjectId:                projectId,
+			Name:                     opts.WithName,
+			Description:              opts.WithDescription,
+			DefaultPort:              opts.WithDefaultPort,
+			DefaultClientPort:        opts.WithDefaultClientPort,
+			SessionConnectionLimit:   opts.WithSessionConnectionLimit,
+			SessionMaxSeconds:        opts.WithSessionMaxSeconds,
+			WorkerFilter:             opts.WithWorkerFilter,
+			EgressWorkerFilter:       opts.WithEgressWorkerFilter,
+			IngressWorkerFilter:      opts.WithIngressWorkerFilter,
+			WorkerSelectionStrategy:  opts.WithWorkerSelectionStrategy,
+			HostHealthCheck:          opts.WithHostHealthCheck,
+			AccessWindow:             opts.WithAccessWindow,
+			SessionReasonRequired:    opts.WithSessionReasonRequired,
+			SessionTicketPattern:     opts.WithSessionTicketPattern,
+			SessionUploadRateLimit:   opts.WithSessionUploadRateLimit,
+			SessionDownloadRateLimit: opts.WithSessionDownloadRateLimit,
 		},
 		Address: opts.WithAddress,
 	}
@@ -89,6 +98,8 @@ func (t *Target) Clone() target.Target {
 		HostSource:        t.HostSource,
 		CredentialSources: t.CredentialSources,
 		Aliases:           t.Aliases,
+		TemplateId:        t.TemplateId,
+		TemplateOverrides: t.TemplateOverrides,
 	}
 }
 
@@ -148,6 +159,14 @@ func (t *Target) GetAliases() []*talias.Alias {
 	return t.Aliases
 }
 
+func (t *Target) GetTemplateId() string {
+	return t.TemplateId
+}
+
+func (t *Target) GetTemplateOverrides() []string {
+	return t.TemplateOverrides
+}
+
 func (t *Target) GetHostSources() []target.HostSource {
 	return t.HostSource
 }
@@ -231,6 +250,34 @@ func (t *Target) SetIngressWorkerFilter(filter string) {
 	t.IngressWorkerFilter = filter
 }
 
+func (t *Target) SetWorkerSelectionStrategy(strategy string) {
+	t.WorkerSelectionStrategy = strategy
+}
+
+func (t *Target) SetHostHealthCheck(check string) {
+	t.HostHealthCheck = check
+}
+
+func (t *Target) SetAccessWindow(window string) {
+	t.AccessWindow = window
+}
+
+func (t *Target) SetSessionReasonRequired(required bool) {
+	t.SessionReasonRequired = required
+}
+
+func (t *Target) SetSessionTicketPattern(pattern string) {
+	t.SessionTicketPattern = pattern
+}
+
+func (t *Target) SetSessionUploadRateLimit(limit uint32) {
+	t.SessionUploadRateLimit = limit
+}
+
+func (t *Target) SetSessionDownloadRateLimit(limit uint32) {
+	t.SessionDownloadRateLimit = limit
+}
+
 func (t *Target) SetAddress(address string) {
 	t.Address = address
 }
@@ -239,6 +286,14 @@ func (t *Target) SetAliases(aliases []*talias.Alias) {
 	t.Aliases = aliases
 }
 
+func (t *Target) SetTemplateId(id string) {
+	t.TemplateId = id
+}
+
+func (t *Target) SetTemplateOverrides(overrides []string) {
+	t.TemplateOverrides = overrides
+}
+
 func (t *Target) SetHostSources(sources []target.HostSource) {
 	t.HostSource = sources
 }
@@ -0,0 +1,383 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package target
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"github.com/hashicorp/boundary/internal/credential"
+	"github.com/hashicorp/boundary/internal/db"
+	"github.com/hashicorp/boundary/internal/errors"
+	"github.com/hashicorp/boundary/internal/oplog"
+	"github.com/hashicorp/boundary/internal/target/store"
+	"github.com/hashicorp/boundary/internal/types/resource"
+	"google.golang.org/protobuf/proto"
+)
+
+// The settings of a target which can be managed by a Template. These are also
+// the names of the settings a target can override.
+const (
+	TemplateSessionMaxSecondsField                      = "session_max_seconds"
+	TemplateSessionConnectionLimitField                 = "session_connection_limit"
+	TemplateEgressWorkerFilterField                     = "egress_worker_filter"
+	TemplateIngressWorkerFilterField                    = "ingress_worker_filter"
+	TemplateHostSourceIdsField                          = "host_source_ids"
+	TemplateBrokeredCredentialSourceIdsField            = "brokered_credential_source_ids"
+	TemplateInjectedApplicationCredentialSourceIdsField = "injected_application_credential_source_ids"
+)
+
+// TemplateFields returns the names of the settings of a target which can be
+// managed by a Template.
+func TemplateFields() []string {
+	return []string{
+		TemplateSessionMaxSecondsField,
+		TemplateSessionConnectionLimitField,
+		TemplateEgressWorkerFilterField,
+		TemplateIngressWorkerFilterField,
+		TemplateHostSourceIdsField,
+		TemplateBrokeredCredentialSourceIdsField,
+		TemplateInjectedApplicationCredentialSourceIdsField,
+	}
+}
+
+// A Template holds settings shared by the targets using it. A setting with a
+// zero value is not managed by the Template and is left as it is on the
+// targets using the Template. It is owned by a project.
+type Template struct {
+	*store.Template
+	HostSourceIds     []string          `gorm:"-"`
+	CredentialSources CredentialSources `gorm:"-"`
+	tableName         string            `gorm:"-"`
+}
+
+var _ oplog.ReplayableMessage = (*Template)(nil)
+
+// NewTemplate creates a new in memory Template in the project. WithName and
+// WithDescription are the only supported options.
+func NewTemplate(ctx context.Context, projectId string, opt ...Option) (*Template, error) {
+	const op = "target.NewTemplate"
+	if projectId == "" {
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "missing project id")
+	}
+	opts := GetOpts(opt...)
+	return &Template{
+		Template: &store.Template{
+			ProjectId:   projectId,
+			Name:        opts.WithName,
+			Description: opts.WithDescription,
+		},
+	}, nil
+}
+
+func allocTemplate() *Template {
+	return &Template{
+		Template: &store.Template{},
+	}
+}
+
+// Clone creates a clone of the Template
+func (t *Template) Clone() *Template {
+	cp := proto.Clone(t.Template)
+	return &Template{
+		Template:      cp.(*store.Template),
+		HostSourceIds: slices.Clone(t.HostSourceIds),
+		CredentialSources: CredentialSources{
+			BrokeredCredentialIds:            slices.Clone(t.CredentialSources.BrokeredCredentialIds),
+			InjectedApplicationCredentialIds: slices.Clone(t.CredentialSources.InjectedApplicationCredentialIds),
+		},
+	}
+}
+
+// GetResourceType returns the resource type of the Template
+func (t *Template) GetResourceType() resource.Type {
+	return resource.TargetTemplate
+}
+
+// TableName returns the table name.
+func (t *Template) TableName() string {
+	if t.tableName != "" {
+		return t.tableName
+	}
+	return "target_template"
+}
+
+// SetTableName sets the table name.
+func (t *Template) SetTableName(n string) {
+	t.tableName = n
+}
+
+func (t *Template) oplog(op oplog.OpType) oplog.Metadata {
+	return oplog.Metadata{
+		"resource-public-id": []string{t.PublicId},
+		"resource-type":      []string{"target-template"},
+		"op-type":            []string{op.String()},
+		"project-id":         []string{t.ProjectId},
+	}
+}
+
+// managedFields returns the names of the settings managed by the Template.
+func (t *Template) managedFields() []string {
+	var fields []string
+	if t.GetSessionMaxSeconds() != 0 {
+		fields = append(fields, TemplateSessionMaxSecondsField)
+	}
+	if t.GetSessionConnectionLimit() != 0 {
+		fields = append(fields, TemplateSessionConnectionLimitField)
+	}
+	if t.GetEgressWorkerFilter() != "" {
+		fields = append(fields, TemplateEgressWorkerFilterField)
+	}
+	if t.GetIngressWorkerFilter() != "" {
+		fields = append(fields, TemplateIngressWorkerFilterField)
+	}
+	if len(t.HostSourceIds) > 0 {
+		fields = append(fields, TemplateHostSourceIdsField)
+	}
+	if len(t.CredentialSources.BrokeredCredentialIds) > 0 {
+		fields = append(fields, TemplateBrokeredCredentialSourceIdsField)
+	}
+	if len(t.CredentialSources.InjectedApplicationCredentialIds) > 0 {
+		fields = append(fields, TemplateInjectedApplicationCredentialSourceIdsField)
+	}
+	return fields
+}
+
+// outOfSyncFields returns the names of the settings managed by the Template
+// whose values on tgt differ from the Template, ignoring the settings
+// overridden by tgt.
+func (t *Template) outOfSyncFields(tgt Target) []string {
+	var brokered, injected []string
+	for _, cs := range tgt.GetCredentialSources() {
+		switch cs.CredentialPurpose() {
+		case credential.BrokeredPurpose:
+			brokered = append(brokered, cs.Id())
+		case credential.InjectedApplicationPurpose:
+			injected = append(injected, cs.Id())
+		}
+	}
+	hostSources := make([]string, 0, len(tgt.GetHostSources()))
+	for _, hs := range tgt.GetHostSources() {
+		hostSources = append(hostSources, hs.Id())
+	}
+
+	var fields []string
+	for _, f := range t.managedFields() {
+		if slices.Contains(tgt.GetTemplateOverrides(), f) {
+			continue
+		}
+		var inSync bool
+		switch f {
+		case TemplateSessionMaxSecondsField:
+			inSync = t.GetSessionMaxSeconds() == tgt.GetSessionMaxSeconds()
+		case TemplateSessionConnectionLimitField:
+			inSync = t.GetSessionConnectionLimit() == tgt.GetSessionConnectionLimit()
+		case TemplateEgressWorkerFilterField:
+			inSync = t.GetEgressWorkerFilter() == tgt.GetEgressWorkerFilter()
+		case TemplateIngressWorkerFilterField:
+			inSync = t.GetIngressWorkerFilter() == tgt.GetIngressWorkerFilter()
+		case TemplateHostSourceIdsField:
+			inSync = sameIds(t.HostSourceIds, hostSources)
+		case TemplateBrokeredCredentialSourceIdsField:
+			inSync = sameIds(t.CredentialSources.BrokeredCredentialIds, brokered)
+		case TemplateInjectedApplicationCredentialSourceIdsField:
+			inSync = sameIds(t.CredentialSources.InjectedApplicationCredentialIds, injected)
+		}
+		if !inSync {
+			fields = append(fields, f)
+		}
+	}
+	return fields
+}
+
+// sameIds reports whether a and b contain the same ids, regardless of order.
+func sameIds(a, b []string) bool {
+	a, b = slices.Clone(a), slices.Clone(b)
+	slices.Sort(a)
+	slices.Sort(b)
+	return slices.Equal(slices.Compact(a), slices.Compact(b))
+}
+
+// OutOfSyncTarget is a target using a Template whose settings differ from the
+// settings managed by the Template.
+type OutOfSyncTarget struct {
+	TargetId string
+	// Fields are the names of the settings that differ from the Template.
+	Fields []string
+}
+
+// templateHostSet is a host source set on the targets using a Template.
+type templateHostSet struct {
+	*store.TemplateHostSet
+	tableName string `gorm:"-"`
+}
+
+// TableName returns the table name.
+func (t *templateHostSet) TableName() string {
+	if t.tableName != "" {
+		return t.tableName
+	}
+	return "target_template_host_set"
+}
+
+// SetTableName sets the table name.
+func (t *templateHostSet) SetTableName(n string) {
+	t.tableName = n
+}
+
+// templateCredentialLibrary is a credential library set on the targets using
+// a Template.
+type templateCredentialLibrary struct {
+	*store.TemplateCredentialLibrary
+	tableName string `gorm:"-"`
+}
+
+// TableName returns the table name.
+func (t *templateCredentialLibrary) TableName() string {
+	if t.tableName != "" {
+		return t.tableName
+	}
+	return "target_template_credential_library"
+}
+
+// SetTableName sets the table name.
+func (t *templateCredentialLibrary) SetTableName(n string) {
+	t.tableName = n
+}
+
+// templateStaticCredential is a static credential set on the targets using a
+// Template.
+type templateStaticCredential struct {
+	*store.TemplateStaticCredential
+	tableName string `gorm:"-"`
+}
+
+// TableName returns the table name.
+func (t *templateStaticCredential) TableName() string {
+	if t.tableName != "" {
+		return t.tableName
+	}
+	return "target_template_static_credential"
+}
+
+// SetTableName sets the table name.
+func (t *templateStaticCredential) SetTableName(n string) {
+	t.tableName = n
+}
+
+// templateCredentialSource is a credential library or static credential set
+// on the targets using a Template.
+type templateCredentialSource struct {
+	*store.TemplateCredentialSource
+}
+
+// TableName returns the view name.
+func (t *templateCredentialSource) TableName() string {
+	return "target_template_credential_source"
+}
+
+// templateTarget is a target using a Template.
+type templateTarget struct {
+	*store.TemplateTarget
+	tableName string `gorm:"-"`
+}
+
+func newTemplateTarget(ctx context.Context, targetId, templateId string) (*templateTarget, error) {
+	const op = "target.newTemplateTarget"
+	switch {
+	case targetId == "":
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "missing target id")
+	case templateId == "":
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "missing template id")
+	}
+	return &templateTarget{
+		TemplateTarget: &store.TemplateTarget{
+			TargetId:   targetId,
+			TemplateId: templateId,
+		},
+	}, nil
+}
+
+// TableName returns the table name.
+func (t *templateTarget) TableName() string {
+	if t.tableName != "" {
+		return t.tableName
+	}
+	return "target_template_target"
+}
+
+// SetTableName sets the table name.
+func (t *templateTarget) SetTableName(n string) {
+	t.tableName = n
+}
+
+// templateOverride is a setting of a target which is not managed by the
+// Template used by the target.
+type templateOverride struct {
+	*store.TemplateOverride
+	tableName string `gorm:"-"`
+}
+
+func newTemplateOverride(ctx context.Context, targetId, field string) (*templateOverride, error) {
+	const op = "target.newTemplateOverride"
+	switch {
+	case targetId == "":
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "missing target id")
+	case !slices.Contains(TemplateFields(), field):
+		return nil, errors.New(ctx, errors.InvalidParameter, op, fmt.Sprintf("%q is not a setting managed by target templates", field))
+	}
+	return &templateOverride{
+		TemplateOverride: &store.TemplateOverride{
+			TargetId:  targetId,
+			FieldName: field,
+		},
+	}, nil
+}
+
+// TableName returns the table name.
+func (t *templateOverride) TableName() string {
+	if t.tableName != "" {
+		return t.tableName
+	}
+	return "target_template_override"
+}
+
+// SetTableName sets the table name.
+func (t *templateOverride) SetTableName(n string) {
+	t.tableName = n
+}
+
+var (
+	_ db.VetForWriter = (*templateHostSet)(nil)
+	_ db.VetForWriter = (*templateCredentialLibrary)(nil)
+	_ db.VetForWriter = (*templateStaticCredential)(nil)
+)
+
+// VetForWrite implements db.VetForWrite() interface.
+func (t *templateHostSet) VetForWrite(ctx context.Context, _ db.Reader, _ db.OpType, _ ...db.Option) error {
+	const op = "target.(templateHostSet).VetForWrite"
+	if t.TemplateId == "" || t.HostSetId == "" {
+		return errors.New(ctx, errors.InvalidParameter, op, "missing template or host set id")
+	}
+	return nil
+}
+
+// VetForWrite implements db.VetForWrite() interface.
+func (t *templateCredentialLibrary) VetForWrite(ctx context.Context, _ db.Reader, _ db.OpType, _ ...db.Option) error {
+	const op = "target.(templateCredentialLibrary).VetForWrite"
+	if t.TemplateId == "" || t.CredentialLibraryId == "" || t.CredentialPurpose == "" {
+		return errors.New(ctx, errors.InvalidParameter, op, "missing template id, credential library id or purpose")
+	}
+	return nil
+}
+
+// VetForWrite implements db.VetForWrite() interface.
+func (t *templateStaticCredential) VetForWrite(ctx context.Context, _ db.Reader, _ db.OpType, _ ...db.Option) error {
+	const op = "target.(templateStaticCredential).VetForWrite"
+	if t.TemplateId == "" || t.CredentialId == "" || t.CredentialPurpose == "" {
+		return errors.New(ctx, errors.InvalidParameter, op, "missing template id, static credential id or purpose")
+	}
+	return nil
+}
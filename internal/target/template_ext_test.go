@@ -0,0 +1,97 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package target_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/boundary/internal/credential"
+	hostStore "github.com/hashicorp/boundary/internal/host/store"
+	"github.com/hashicorp/boundary/internal/target"
+	"github.com/hashicorp/boundary/internal/target/store"
+	"github.com/hashicorp/boundary/internal/target/targettest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTemplate_OutOfSyncFields(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	newTemplate := func(t *testing.T) *target.Template {
+		tpl, err := target.NewTemplate(ctx, "p_1234567890")
+		require.NoError(t, err)
+		tpl.SessionMaxSeconds = 3600
+		tpl.EgressWorkerFilter = `"prod" in "/tags/env"`
+		tpl.HostSourceIds = []string{"hsst_1", "hsst_2"}
+		tpl.CredentialSources.BrokeredCredentialIds = []string{"clvlt_1"}
+		return tpl
+	}
+	newTarget := func(t *testing.T) *targettest.Target {
+		tgt, err := targettest.New(ctx, "p_1234567890")
+		require.NoError(t, err)
+		tt := tgt.(*targettest.Target)
+		tt.SetSessionMaxSeconds(3600)
+		tt.SetSessionConnectionLimit(5)
+		tt.SetEgressWorkerFilter(`"prod" in "/tags/env"`)
+		tt.SetHostSources([]target.HostSource{
+			&target.TargetSet{Set: &hostStore.Set{PublicId: "hsst_2"}},
+			&target.TargetSet{Set: &hostStore.Set{PublicId: "hsst_1"}},
+		})
+		tt.SetCredentialSources([]target.CredentialSource{
+			&target.TargetCredentialSource{CredentialSource: &store.CredentialSource{
+				CredentialSourceId: "clvlt_1",
+				CredentialPurpose:  string(credential.BrokeredPurpose),
+			}},
+			&target.TargetCredentialSource{CredentialSource: &store.CredentialSource{
+				CredentialSourceId: "clvlt_2",
+				CredentialPurpose:  string(credential.InjectedApplicationPurpose),
+			}},
+		})
+		return tt
+	}
+
+	tests := []struct {
+		name   string
+		modify func(*targettest.Target)
+		want   []string
+	}{
+		{
+			name:   "in-sync",
+			modify: func(*targettest.Target) {},
+		},
+		{
+			name: "drifted-settings",
+			modify: func(tgt *targettest.Target) {
+				tgt.SetSessionMaxSeconds(60)
+				tgt.SetEgressWorkerFilter("")
+			},
+			want: []string{target.TemplateSessionMaxSecondsField, target.TemplateEgressWorkerFilterField},
+		},
+		{
+			name: "drifted-sources",
+			modify: func(tgt *targettest.Target) {
+				tgt.SetHostSources(nil)
+				tgt.SetCredentialSources(nil)
+			},
+			want: []string{target.TemplateHostSourceIdsField, target.TemplateBrokeredCredentialSourceIdsField},
+		},
+		{
+			name: "overridden",
+			modify: func(tgt *targettest.Target) {
+				tgt.SetSessionMaxSeconds(60)
+				tgt.SetHostSources(nil)
+				tgt.SetTemplateOverrides([]string{target.TemplateSessionMaxSecondsField, target.TemplateHostSourceIdsField})
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tgt := newTarget(t)
+			tt.modify(tgt)
+			assert.Equal(t, tt.want, target.TemplateOutOfSyncFields(newTemplate(t), tgt))
+		})
+	}
+}
@@ -76,6 +76,15 @@ const (
 	RemoveGrantScopes                  Type = 62
 	MonthlyActiveUsers                 Type = 63
 	ListResolvableAliases              Type = 64
+	ReadUsage                          Type = 65
+	ReadSessionQuota                   Type = 66
+	Shadow                             Type = 67
+	ListOutOfSyncTargets               Type = 68
+	AttestWorker                       Type = 69
+	ReadFleetReport                    Type = 70
+	ReadTopology                       Type = 71
+	ListScopeOplogEntries              Type = 72
+	ShadowInput                        Type = 73
 
 	// When adding new actions, be sure to update:
 	//
@@ -148,6 +157,15 @@ var Map = map[string]Type{
 	RemoveGrantScopes.String():                  RemoveGrantScopes,
 	MonthlyActiveUsers.String():                 MonthlyActiveUsers,
 	ListResolvableAliases.String():              ListResolvableAliases,
+	ReadUsage.String():                          ReadUsage,
+	ReadSessionQuota.String():                   ReadSessionQuota,
+	Shadow.String():                             Shadow,
+	ListOutOfSyncTargets.String():               ListOutOfSyncTargets,
+	AttestWorker.String():                       AttestWorker,
+	ReadFleetReport.String():                    ReadFleetReport,
+	ReadTopology.String():                       ReadTopology,
+	ListScopeOplogEntries.String():              ListScopeOplogEntries,
+	ShadowInput.String():                        ShadowInput,
 }
 
 var DeprecatedMap = map[string]Type{
@@ -226,6 +244,15 @@ func (a Type) String() string {
 		"remove-grant-scopes",
 		"monthly-active-users",
 		"list-resolvable-aliases",
+		"read-usage",
+		"read-session-quota",
+		"shadow",
+		"list-out-of-sync-targets",
+		"attest",
+		"read-fleet-report",
+		"read-topology",
+		"list-oplog-entries",
+		"shadow-input",
 	}[a]
 }
 
@@ -170,6 +170,42 @@ func TestAction(t *testing.T) {
 			action: ListResolvableAliases,
 			want:   "list-resolvable-aliases",
 		},
+		{
+			action: ReadUsage,
+			want:   "read-usage",
+		},
+		{
+			action: ReadSessionQuota,
+			want:   "read-session-quota",
+		},
+		{
+			action: Shadow,
+			want:   "shadow",
+		},
+		{
+			action: ListOutOfSyncTargets,
+			want:   "list-out-of-sync-targets",
+		},
+		{
+			action: AttestWorker,
+			want:   "attest",
+		},
+		{
+			action: ReadFleetReport,
+			want:   "read-fleet-report",
+		},
+		{
+			action: ReadTopology,
+			want:   "read-topology",
+		},
+		{
+			action: ListScopeOplogEntries,
+			want:   "list-oplog-entries",
+		},
+		{
+			action: ShadowInput,
+			want:   "shadow-input",
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.want, func(t *testing.T) {
@@ -37,6 +37,7 @@ const (
 	Policy
 	Billing
 	Alias
+	TargetTemplate
 	// NOTE: When adding a new type, be sure to update:
 	//
 	// * The Grant.validateType function and test
@@ -78,6 +79,7 @@ func (r Type) String() string {
 		"policy",
 		"billing",
 		"alias",
+		"target-template",
 	}[r]
 }
 
@@ -138,6 +140,7 @@ var Map = map[string]Type{
 	Policy.String():            Policy,
 	Billing.String():           Billing,
 	Alias.String():             Alias,
+	TargetTemplate.String():    TargetTemplate,
 }
 
 // Parent returns the parent type for a given type; if there is no parent, it
@@ -182,6 +185,7 @@ func TopLevelType(typ Type) bool {
 		StorageBucket,
 		Policy,
 		Alias,
+		TargetTemplate,
 		Worker:
 		return true
 	}
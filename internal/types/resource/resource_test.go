@@ -96,6 +96,11 @@ func Test_Resource(t *testing.T) {
 			want:         Alias,
 			topLevelType: true,
 		},
+		{
+			typeString:   "target-template",
+			want:         TargetTemplate,
+			topLevelType: true,
+		},
 		{
 			typeString:   "session",
 			want:         Session,
@@ -406,6 +406,9 @@ var resources = map[resource.Type]info{
 	},
 	resource.Credential: {
 		scopes: infraScope,
+		actionDescOverrides: map[action.Type]string{
+			action.ReadUsage: "Read the sessions a credential has been issued to",
+		},
 	},
 	resource.CredentialLibrary: {
 		scopes: infraScope,
@@ -433,13 +436,18 @@ var resources = map[resource.Type]info{
 	},
 	resource.Scope: {
 		scopes: iamScopes,
+		actionDescOverrides: map[action.Type]string{
+			action.ListScopeOplogEntries: "List the changes recorded in the operation log of a scope",
+		},
 	},
 	resource.Session: {
 		scopes: infraScope,
 		actionDescOverrides: map[action.Type]string{
-			action.Cancel:     "Cancel a session",
-			action.CancelSelf: "Cancel a session, which must be associated with the calling user",
-			action.ReadSelf:   "Read a session, which must be associated with the calling user",
+			action.Cancel:      "Cancel a session",
+			action.CancelSelf:  "Cancel a session, which must be associated with the calling user",
+			action.ReadSelf:    "Read a session, which must be associated with the calling user",
+			action.Shadow:      "Observe the terminal of an active SSH session",
+			action.ShadowInput: "Observe the terminal of an active SSH session and send input to it",
 		},
 	},
 	resource.SessionRecording: {
@@ -458,14 +466,26 @@ var resources = map[resource.Type]info{
 			action.AuthorizeSession: "Authorize a session via the target",
 		},
 	},
+	resource.TargetTemplate: {
+		scopes: infraScope,
+		actionDescOverrides: map[action.Type]string{
+			action.ListOutOfSyncTargets: "List targets whose settings have drifted from the template",
+		},
+	},
 	resource.User: {
 		scopes: iamScopes,
+		actionDescOverrides: map[action.Type]string{
+			action.ReadSessionQuota: "Read the session quota of a user in a project",
+		},
 	},
 	resource.Worker: {
 		scopes: []string{"Global"},
 		actionDescOverrides: map[action.Type]string{
 			action.CreateControllerLed: "Create a worker using the controller-led workflow",
 			action.CreateWorkerLed:     "Create a worker using the worker-led workflow",
+			action.AttestWorker:        "Create a worker by presenting an identity document trusted by the controller",
+			action.ReadFleetReport:     "Read a report of the versions, status and compatibility of the workers",
+			action.ReadTopology:        "Read the graph of connections between upstream and downstream workers",
 		},
 	},
 }
@@ -13,6 +13,7 @@ import (
 	wrapping "github.com/hashicorp/go-kms-wrapping/v2"
 
 	_ "github.com/hashicorp/boundary/internal/daemon/controller/handlers/targets/tcp"
+	_ "github.com/hashicorp/boundary/internal/daemon/controller/handlers/targets/udp"
 )
 
 type option struct {
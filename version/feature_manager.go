@@ -24,8 +24,38 @@ const (
 	SupportIdInGrants
 	PluginDelete
 	LocalStorageState
+	UdpTarget
+	TargetBandwidthLimit
+	TargetAccessWindow
+	HostHealthFailover
+	SessionShadow
 )
 
+var featureNames = map[Feature]string{
+	MultiHopSessionFeature:        "multi-hop-session",
+	IncludeStatusInCli:            "include-status-in-cli",
+	CredentialLibraryVaultSubtype: "credential-library-vault-subtype",
+	UseTargetIdForHostId:          "use-target-id-for-host-id",
+	RequireVersionInWorkerInfo:    "require-version-in-worker-info",
+	SshSessionRecording:           "ssh-session-recording",
+	SupportIdInGrants:             "support-id-in-grants",
+	PluginDelete:                  "plugin-delete",
+	LocalStorageState:             "local-storage-state",
+	UdpTarget:                     "udp-target",
+	TargetBandwidthLimit:          "target-bandwidth-limit",
+	TargetAccessWindow:            "target-access-window",
+	HostHealthFailover:            "host-health-failover",
+	SessionShadow:                 "session-shadow",
+}
+
+// String returns the name of the feature.
+func (f Feature) String() string {
+	if n, ok := featureNames[f]; ok {
+		return n
+	}
+	return "unknown"
+}
+
 var featureMap map[Feature]MetadataConstraint
 
 // Binary is the version of the running binary.
@@ -91,6 +121,32 @@ func init() {
 	featureMap[LocalStorageState] = MetadataConstraint{
 		Constraints: mustNewConstraints(">= 0.16.0"),
 	}
+
+	// Worker supports proxying sessions of udp targets
+	featureMap[UdpTarget] = MetadataConstraint{
+		Constraints: mustNewConstraints(">= 0.17.0"),
+	}
+
+	// Worker enforces the session bandwidth limits of targets
+	featureMap[TargetBandwidthLimit] = MetadataConstraint{
+		Constraints: mustNewConstraints(">= 0.17.0"),
+	}
+
+	// Worker only authorizes connections inside the access window of targets
+	featureMap[TargetAccessWindow] = MetadataConstraint{
+		Constraints: mustNewConstraints(">= 0.17.0"),
+	}
+
+	// Worker probes the health of host endpoints and fails over to healthy
+	// ones
+	featureMap[HostHealthFailover] = MetadataConstraint{
+		Constraints: mustNewConstraints(">= 0.17.0"),
+	}
+
+	// Worker streams the terminal of ssh sessions to observers
+	featureMap[SessionShadow] = MetadataConstraint{
+		Constraints: mustNewConstraints(">= 0.17.0"),
+	}
 }
 
 func mustNewConstraints(v string) gvers.Constraints {
@@ -125,6 +181,16 @@ func SupportsFeature(version *gvers.Version, feature Feature) bool {
 	return Check(version, featureVersion)
 }
 
+// FeatureConstraint returns the version constraints of the feature, or an
+// empty string if the feature is unknown.
+func FeatureConstraint(feature Feature) string {
+	featureVersion, found := featureMap[feature]
+	if !found {
+		return ""
+	}
+	return featureVersion.Constraints.String()
+}
+
 // GetReleaseVersion returns a go-version of this binary's Boundary version
 func GetReleaseVersion() (*gvers.Version, error) {
 	ver := Get()
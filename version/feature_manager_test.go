@@ -81,6 +81,25 @@ func TestHasFeature_AllMetaData(t *testing.T) {
 	}
 }
 
+func TestFeature_String(t *testing.T) {
+	assert.Equal(t, "udp-target", UdpTarget.String())
+	assert.Equal(t, "target-bandwidth-limit", TargetBandwidthLimit.String())
+	assert.Equal(t, "target-access-window", TargetAccessWindow.String())
+	assert.Equal(t, "host-health-failover", HostHealthFailover.String())
+	assert.Equal(t, "session-shadow", SessionShadow.String())
+	assert.Equal(t, "unknown", Feature(-1).String())
+}
+
+func TestFeatureConstraint(t *testing.T) {
+	assert.Equal(t, ">= 0.17.0", FeatureConstraint(UdpTarget))
+	assert.Equal(t, ">= 0.17.0", FeatureConstraint(TargetBandwidthLimit))
+	assert.Equal(t, ">= 0.17.0", FeatureConstraint(TargetAccessWindow))
+	assert.Equal(t, ">= 0.17.0", FeatureConstraint(HostHealthFailover))
+	assert.Equal(t, ">= 0.17.0", FeatureConstraint(SessionShadow))
+	assert.Equal(t, "< 0.14.0", FeatureConstraint(IncludeStatusInCli))
+	assert.Empty(t, FeatureConstraint(Feature(-1)))
+}
+
 func TestEnableFeatureOnVersionForTest_AllMetaData(t *testing.T) {
 	FutureFeature := Feature(997)
 